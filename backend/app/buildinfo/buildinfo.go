@@ -0,0 +1,48 @@
+// Package buildinfo reports which build of the server is running.
+package buildinfo
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// Version and Commit are set at build time with
+//
+//	-ldflags "-X github.com/nishiki/backend/app/buildinfo.Version=v1.2.0 -X github.com/nishiki/backend/app/buildinfo.Commit=abc1234"
+//
+// When Commit is not set, the VCS revision Go stamps into builds made from a
+// git checkout is used instead.
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+var vcsOnce = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	var revision string
+	modified := false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if revision != "" && modified {
+		revision += "-dirty"
+	}
+	return revision
+})
+
+// BuildCommit returns the commit the server was built from, or "" when it
+// is unknown.
+func BuildCommit() string {
+	if Commit != "" {
+		return Commit
+	}
+	return vcsOnce()
+}
@@ -3,18 +3,35 @@ package config
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig   `toml:"server" mapstructure:"server"`
-	Database DatabaseConfig `toml:"database" mapstructure:"database"`
-	Auth     AuthConfig     `toml:"auth" mapstructure:"auth"`
-	Logging  LoggingConfig  `toml:"logging" mapstructure:"logging"`
-	Import   ImportConfig   `toml:"import" mapstructure:"import"`
-	Images   ImagesConfig   `toml:"images" mapstructure:"images"`
+	Server      ServerConfig      `toml:"server" mapstructure:"server"`
+	Database    DatabaseConfig    `toml:"database" mapstructure:"database"`
+	Auth        AuthConfig        `toml:"auth" mapstructure:"auth"`
+	Logging     LoggingConfig     `toml:"logging" mapstructure:"logging"`
+	Import      ImportConfig      `toml:"import" mapstructure:"import"`
+	Images      ImagesConfig      `toml:"images" mapstructure:"images"`
+	Email       EmailConfig       `toml:"email" mapstructure:"email"`
+	Digest      DigestConfig      `toml:"digest" mapstructure:"digest"`
+	Recurrence  RecurrenceConfig  `toml:"recurrence" mapstructure:"recurrence"`
+	Automations AutomationsConfig `toml:"automations" mapstructure:"automations"`
+	CORS        CORSConfig        `toml:"cors" mapstructure:"cors"`
+	RateLimit   RateLimitConfig   `toml:"rate_limit" mapstructure:"rate_limit"`
+	Snapshots   SnapshotsConfig   `toml:"snapshots" mapstructure:"snapshots"`
+	Media       MediaConfig       `toml:"media" mapstructure:"media"`
+	Nutrition   NutritionConfig   `toml:"nutrition" mapstructure:"nutrition"`
+	Inbox       InboxConfig       `toml:"inbox" mapstructure:"inbox"`
+	// Features holds feature flags by name, e.g. [features.meal_planner]
+	Features map[string]FeatureConfig `toml:"features" mapstructure:"features"`
 }
 
 type ServerConfig struct {
@@ -23,6 +40,24 @@ type ServerConfig struct {
 	MCPSSEPort int       `toml:"mcp_sse_port" mapstructure:"mcp_sse_port"`
 	Debug      bool      `toml:"debug" mapstructure:"debug"`
 	TLS        TLSConfig `toml:"tls" mapstructure:"tls"`
+	// LegacyAPISunset is the date, as 2006-01-02, after which the
+	// unversioned API paths may be removed; announced in their Sunset
+	// header. Empty leaves the date unannounced.
+	LegacyAPISunset string `toml:"legacy_api_sunset" mapstructure:"legacy_api_sunset"`
+	// Frontend serves the web app bundled into this binary under / on the
+	// REST port, moving the API under /api. The binary must have been built
+	// after the WASM app (`just build` in frontend/).
+	Frontend bool `toml:"frontend" mapstructure:"frontend"`
+}
+
+// LegacyAPISunsetTime parses LegacyAPISunset, returning the zero time when it
+// is unset
+func (c ServerConfig) LegacyAPISunsetTime() time.Time {
+	t, err := time.Parse(time.DateOnly, c.LegacyAPISunset)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
 }
 
 type TLSConfig struct {
@@ -45,6 +80,18 @@ type DatabaseConfig struct {
 
 	// Legacy URI field (optional, overrides individual fields if provided)
 	URI string `toml:"uri" mapstructure:"uri"`
+
+	// Connection pool: at most MaxPoolSize connections to each server (0
+	// for no limit), at least MinPoolSize kept open, and idle ones closed
+	// after MaxConnIdleTime seconds (0 keeps them)
+	MaxPoolSize     int `toml:"max_pool_size" mapstructure:"max_pool_size"`
+	MinPoolSize     int `toml:"min_pool_size" mapstructure:"min_pool_size"`
+	MaxConnIdleTime int `toml:"max_conn_idle_time" mapstructure:"max_conn_idle_time"`
+
+	// ReadURI optionally connects the heavy read endpoints (search, stats,
+	// reports and exports) elsewhere, e.g. to a replica set member with
+	// readPreference=secondary. Empty serves them from the primary.
+	ReadURI string `toml:"read_uri" mapstructure:"read_uri"`
 }
 
 // GetURI constructs or returns the MongoDB connection URI
@@ -84,7 +131,42 @@ type OAuthClient struct {
 	RedirectURL  string `toml:"redirect_url" mapstructure:"redirect_url"`
 }
 
+// Auth modes, identity providers and group directories selectable in
+// AuthConfig.
+const (
+	AuthModeExternal = "external"
+	AuthModeLocal    = "local"
+
+	AuthProviderAuthentik = "authentik"
+	AuthProviderOIDC      = "oidc"
+
+	GroupsAuthentik = "authentik"
+	GroupsClaims    = "claims"
+)
+
 type AuthConfig struct {
+	// Mode is "external" (the default) to sign in through Provider, or
+	// "local" to keep username/password accounts in Nishiki itself, as
+	// configured under Local.
+	Mode  string          `toml:"mode" mapstructure:"mode"`
+	Local LocalAuthConfig `toml:"local" mapstructure:"local"`
+	// Provider selects the identity provider: "authentik" (the default) or
+	// "oidc" for any other OpenID Connect provider, such as Keycloak, Auth0
+	// or Dex.
+	Provider string `toml:"provider" mapstructure:"provider"`
+	// IssuerURLs lists the issuer URLs of an "oidc" provider in preferred
+	// order, probed at startup like AuthentikURLs. All clients share the
+	// issuer that answers first.
+	IssuerURLs []string `toml:"issuer_urls" mapstructure:"issuer_urls"`
+	// Groups selects where groups live: "authentik" manages them through the
+	// Authentik API with APIToken, "claims" reads them read-only from the
+	// GroupClaim of the user's token. Defaults to "authentik" for the
+	// authentik provider and "claims" otherwise.
+	Groups string `toml:"groups" mapstructure:"groups"`
+	// GroupClaim is the token claim holding the user's group names when
+	// Groups is "claims". Only values starting with GroupPrefix count.
+	GroupClaim  string `toml:"group_claim" mapstructure:"group_claim"`
+	GroupPrefix string `toml:"group_prefix" mapstructure:"group_prefix"`
 	// AuthentikURL is a single-URL convenience form. If set, it is prepended
 	// to AuthentikURLs during Load(). Prefer AuthentikURLs for new configs.
 	AuthentikURL string `toml:"authentik_url" mapstructure:"authentik_url"`
@@ -96,12 +178,158 @@ type AuthConfig struct {
 	JWKSCacheDuration int           `toml:"jwks_cache_duration" mapstructure:"jwks_cache_duration"`
 	AllowSelfSigned   bool          `toml:"allow_self_signed" mapstructure:"allow_self_signed"`
 	APIToken          string        `toml:"api_token" mapstructure:"api_token"`
+	// ReauthMaxAge is how many seconds may pass since the user last entered
+	// their credentials before sensitive endpoints (account deletion, data
+	// export) ask them to sign in again.
+	ReauthMaxAge int `toml:"reauth_max_age" mapstructure:"reauth_max_age"`
+	// AdminGroup names the group, as listed in the token's groups claim, whose
+	// members may use the admin endpoints. Empty disables them.
+	AdminGroup string `toml:"admin_group" mapstructure:"admin_group"`
+}
+
+// LocalAuthConfig configures the built-in accounts of the "local" auth mode.
+type LocalAuthConfig struct {
+	// Secret signs the issued JWTs with HMAC-SHA256. At least 32 bytes.
+	Secret string `toml:"secret" mapstructure:"secret"`
+	// TokenLifetime and RefreshLifetime are in seconds.
+	TokenLifetime   int `toml:"token_lifetime" mapstructure:"token_lifetime"`
+	RefreshLifetime int `toml:"refresh_lifetime" mapstructure:"refresh_lifetime"`
+	// AllowRegistration keeps registration open once the first account
+	// exists. The first account can always register.
+	AllowRegistration bool `toml:"allow_registration" mapstructure:"allow_registration"`
+	// RedirectURLs are the callbacks the sign-in page may send an
+	// authorization code to, e.g. "http://localhost:8080/auth/callback".
+	RedirectURLs []string `toml:"redirect_urls" mapstructure:"redirect_urls"`
+}
+
+// IsLocal reports whether accounts are kept by Nishiki instead of an
+// identity provider.
+func (c AuthConfig) IsLocal() bool {
+	return c.Mode == AuthModeLocal
+}
+
+// GroupSource returns the configured group directory, or the provider's
+// default when none is set.
+func (c AuthConfig) GroupSource() string {
+	if c.Groups != "" {
+		return c.Groups
+	}
+	if c.Provider == AuthProviderOIDC || c.IsLocal() {
+		return GroupsClaims
+	}
+	return GroupsAuthentik
 }
 
 type LoggingConfig struct {
 	Level       string `toml:"level" mapstructure:"level"`
 	SeqEndpoint string `toml:"seq_endpoint" mapstructure:"seq_endpoint"`
 	SeqAPIKey   string `toml:"seq_api_key" mapstructure:"seq_api_key"`
+	// Stderr sends console logs to stderr instead of stdout. The --mcp stdio
+	// mode turns it on because stdout carries the JSON-RPC stream.
+	Stderr bool `toml:"stderr" mapstructure:"stderr"`
+	// Sinks are where logs go besides the console and Seq
+	Sinks []LogSinkConfig `toml:"sinks" mapstructure:"sinks"`
+}
+
+// Log sink types
+const (
+	LogSinkFile     = "file"
+	LogSinkSyslog   = "syslog"
+	LogSinkJournald = "journald"
+)
+
+// LogSinkConfig is one [[logging.sinks]] entry: a rotating file, syslog or
+// the systemd journal.
+type LogSinkConfig struct {
+	// Name identifies the sink to the admin logging endpoint. Empty means
+	// the type, so only one sink of each type may leave it out.
+	Name string `toml:"name" mapstructure:"name"`
+	Type string `toml:"type" mapstructure:"type"`
+	// Level is the sink's own minimum level; empty follows logging.level
+	Level string `toml:"level" mapstructure:"level"`
+
+	// Path is the file a file sink writes, rotated once it reaches
+	// MaxSizeMB. Rotated files older than MaxAgeDays or beyond the newest
+	// MaxBackups are deleted; zero keeps them.
+	Path       string `toml:"path" mapstructure:"path"`
+	MaxSizeMB  int    `toml:"max_size_mb" mapstructure:"max_size_mb"`
+	MaxAgeDays int    `toml:"max_age_days" mapstructure:"max_age_days"`
+	MaxBackups int    `toml:"max_backups" mapstructure:"max_backups"`
+
+	// Network and Address reach a remote syslog server, e.g. "udp" and
+	// "logs.lan:514"; empty uses the local one.
+	Network string `toml:"network" mapstructure:"network"`
+	Address string `toml:"address" mapstructure:"address"`
+	// Tag is the syslog tag or journal identifier; empty means "nishiki"
+	Tag string `toml:"tag" mapstructure:"tag"`
+}
+
+// SinkName is the name the sink goes by: Name, or the type when unnamed
+func (c LogSinkConfig) SinkName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Type
+}
+
+// SlogLevel parses Level, which is one of debug, info, warn or error. Empty
+// means info.
+func (c LoggingConfig) SlogLevel() (slog.Level, error) {
+	if c.Level == "" {
+		return slog.LevelInfo, nil
+	}
+	level, err := ParseLogLevel(c.Level)
+	if err != nil {
+		return slog.LevelInfo, fmt.Errorf("logging level %w", err)
+	}
+	return level, nil
+}
+
+// ParseLogLevel parses debug, info, warn or error
+func ParseLogLevel(s string) (slog.Level, error) {
+	switch s {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("%q must be debug, info, warn or error", s)
+	}
+}
+
+// validateSinks checks each sink's type, level and limits, and that no two
+// share a name
+func (c LoggingConfig) validateSinks() error {
+	names := map[string]bool{"console": true, "seq": true}
+	for i, sink := range c.Sinks {
+		switch sink.Type {
+		case LogSinkFile:
+			if sink.Path == "" {
+				return fmt.Errorf("logging sinks[%d] path is required for a file sink", i)
+			}
+			if sink.MaxSizeMB < 0 || sink.MaxAgeDays < 0 || sink.MaxBackups < 0 {
+				return fmt.Errorf("logging sinks[%d] max_size_mb, max_age_days and max_backups must not be negative", i)
+			}
+		case LogSinkSyslog, LogSinkJournald:
+		default:
+			return fmt.Errorf("logging sinks[%d] type %q must be file, syslog or journald", i, sink.Type)
+		}
+		if sink.Level != "" {
+			if _, err := ParseLogLevel(sink.Level); err != nil {
+				return fmt.Errorf("logging sinks[%d] level %w", i, err)
+			}
+		}
+		name := sink.SinkName()
+		if names[name] {
+			return fmt.Errorf("logging sinks[%d] name %q is already taken; give the sink a name of its own", i, name)
+		}
+		names[name] = true
+	}
+	return nil
 }
 
 // ImagesConfig controls image search and caching during import.
@@ -112,15 +340,227 @@ type ImagesConfig struct {
 	CacheDir             string `toml:"cache_dir" mapstructure:"cache_dir"`
 }
 
+// EmailConfig holds the SMTP settings used for outgoing mail.
+type EmailConfig struct {
+	Enabled  bool   `toml:"enabled" mapstructure:"enabled"`
+	SMTPHost string `toml:"smtp_host" mapstructure:"smtp_host"`
+	SMTPPort int    `toml:"smtp_port" mapstructure:"smtp_port"`
+	Username string `toml:"username" mapstructure:"username"`
+	Password string `toml:"password" mapstructure:"password"`
+	From     string `toml:"from" mapstructure:"from"`
+}
+
+// DigestConfig controls the periodic expiring/low-stock email digest.
+type DigestConfig struct {
+	Enabled bool `toml:"enabled" mapstructure:"enabled"`
+	// CheckInterval is how often, in minutes, subscriptions are checked for
+	// a due digest.
+	CheckInterval int `toml:"check_interval" mapstructure:"check_interval"`
+	// ExpiringWithinDays is how far ahead an expiry date counts as "soon".
+	ExpiringWithinDays int `toml:"expiring_within_days" mapstructure:"expiring_within_days"`
+	// LowStockThreshold flags objects whose quantity is at or below it. Objects
+	// with their own min_quantity use that instead.
+	LowStockThreshold float64 `toml:"low_stock_threshold" mapstructure:"low_stock_threshold"`
+	// PublicURL is the externally reachable backend URL, used to build
+	// unsubscribe links.
+	PublicURL string `toml:"public_url" mapstructure:"public_url"`
+}
+
+// RecurrenceConfig controls the scheduler that puts recurring staples back on
+// shopping lists.
+type RecurrenceConfig struct {
+	// CheckInterval is how often, in minutes, recurrence rules are checked
+	// for staples that have come due.
+	CheckInterval int `toml:"check_interval" mapstructure:"check_interval"`
+}
+
+// AutomationsConfig controls how users' automation rules are run.
+type AutomationsConfig struct {
+	// CheckInterval is how often, in minutes, objects are checked for
+	// coming within an expiry rule's window.
+	CheckInterval int `toml:"check_interval" mapstructure:"check_interval"`
+	// QueueSize is how many object changes can wait to be checked against
+	// rules; changes beyond it are dropped rather than slowing requests.
+	QueueSize int `toml:"queue_size" mapstructure:"queue_size"`
+	// WebhookTimeout is how long, in seconds, a rule's webhook may take to
+	// answer.
+	WebhookTimeout int `toml:"webhook_timeout" mapstructure:"webhook_timeout"`
+	// WebhookRetryInterval is how often, in seconds, failed webhooks are
+	// checked for a retry that has come due.
+	WebhookRetryInterval int `toml:"webhook_retry_interval" mapstructure:"webhook_retry_interval"`
+	// AllowPrivateWebhooks lets webhooks reach loopback, private and
+	// link-local addresses, such as a home automation server on the LAN. Off,
+	// any user who can create a rule cannot make the server call internal
+	// services or the cloud metadata endpoint.
+	AllowPrivateWebhooks bool `toml:"allow_private_webhooks" mapstructure:"allow_private_webhooks"`
+}
+
+// SnapshotsConfig controls point-in-time copies of collections.
+type SnapshotsConfig struct {
+	// MaxPerCollection is how many snapshots a collection keeps; the oldest
+	// are dropped when a new one is taken. 0 keeps every snapshot.
+	MaxPerCollection int `toml:"max_per_collection" mapstructure:"max_per_collection"`
+	// BeforeImport takes a snapshot before each bulk import into a
+	// collection so a bad import can be rolled back.
+	BeforeImport bool `toml:"before_import" mapstructure:"before_import"`
+}
+
+// MediaConfig controls photos uploaded to objects and containers.
+type MediaConfig struct {
+	// Dir is where uploaded photos and their thumbnails are stored.
+	Dir string `toml:"dir" mapstructure:"dir"`
+	// MaxUploadSize is the largest accepted photo, in bytes.
+	MaxUploadSize int64 `toml:"max_upload_size" mapstructure:"max_upload_size"`
+	// MaxPerOwner is how many photos one object or container may have.
+	MaxPerOwner int `toml:"max_per_owner" mapstructure:"max_per_owner"`
+}
+
+// NutritionConfig controls looking up nutrition facts for food objects by
+// UPC in the OpenFoodFacts database.
+type NutritionConfig struct {
+	Enabled bool `toml:"enabled" mapstructure:"enabled"`
+	// BaseURL is the OpenFoodFacts instance to query.
+	BaseURL string `toml:"base_url" mapstructure:"base_url"`
+	// UserAgent identifies this server to OpenFoodFacts, which asks every
+	// client to name itself with a contact address.
+	UserAgent string `toml:"user_agent" mapstructure:"user_agent"`
+	// Timeout is how long a lookup may take, in seconds.
+	Timeout int `toml:"timeout" mapstructure:"timeout"`
+}
+
+// InboxConfig controls the address users forward order confirmations and
+// receipts to. The mail provider (e.g. Mailgun) receives mail for Address
+// and posts each message to /inbox/email.
+type InboxConfig struct {
+	Enabled bool `toml:"enabled" mapstructure:"enabled"`
+	// Address is the base inbox address; each user forwards to it with
+	// their own token after a +, e.g. inbox+3f9a2c@example.com.
+	Address string `toml:"address" mapstructure:"address"`
+	// SigningKey is the provider's webhook signing key, used to check that
+	// a posted message really came from the provider.
+	SigningKey string `toml:"signing_key" mapstructure:"signing_key"`
+}
+
+// FeatureConfig turns a feature on for everyone, or only for the listed
+// users and groups. A flag an admin sets through the API replaces it.
+type FeatureConfig struct {
+	Enabled bool `toml:"enabled" mapstructure:"enabled"`
+	// Users lists user IDs or usernames the feature is on for.
+	Users []string `toml:"users" mapstructure:"users"`
+	// Groups lists group names, as in the token's groups claim.
+	Groups      []string `toml:"groups" mapstructure:"groups"`
+	Description string   `toml:"description" mapstructure:"description"`
+}
+
+// CORSConfig controls the cross-origin policy applied to every HTTP route.
+// Set AllowedOrigins to the frontend's origin(s) when it is served from a
+// different domain than the backend.
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins (scheme://host[:port]) or "*" for any.
+	AllowedOrigins   []string `toml:"allowed_origins" mapstructure:"allowed_origins"`
+	AllowedMethods   []string `toml:"allowed_methods" mapstructure:"allowed_methods"`
+	AllowedHeaders   []string `toml:"allowed_headers" mapstructure:"allowed_headers"`
+	ExposedHeaders   []string `toml:"exposed_headers" mapstructure:"exposed_headers"`
+	AllowCredentials bool     `toml:"allow_credentials" mapstructure:"allow_credentials"`
+	// MaxAge is how long, in seconds, browsers may cache a preflight result.
+	MaxAge int `toml:"max_age" mapstructure:"max_age"`
+}
+
+// RateLimitConfig controls how often one client may call the public,
+// unauthenticated endpoints.
+type RateLimitConfig struct {
+	// StatusPerMinute is how many times a minute one client may fetch /status.
+	StatusPerMinute int `toml:"status_per_minute" mapstructure:"status_per_minute"`
+	// TrustedProxies lists the addresses or CIDR ranges of reverse proxies in
+	// front of the server. Only requests from them may name the client with
+	// X-Forwarded-For or X-Real-IP; when empty, those headers are ignored.
+	TrustedProxies []string `toml:"trusted_proxies" mapstructure:"trusted_proxies"`
+}
+
+// TrustedProxyPrefixes parses TrustedProxies, taking a bare address as a
+// range of one.
+func (c RateLimitConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for i, proxy := range c.TrustedProxies {
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("rate_limit trusted_proxies[%d] %q is not an address or CIDR range", i, proxy)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit trusted_proxies[%d] %q is not an address or CIDR range", i, proxy)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
 // ImportConfig controls bulk-import behaviour.
 type ImportConfig struct {
 	// ReservedColumns lists snake_case column names that map to Object fields
 	// (name, description, quantity, etc.) and must NOT be stored as properties.
 	// Extend this list to protect additional columns in your CSV exports.
 	ReservedColumns []string `toml:"reserved_columns" mapstructure:"reserved_columns"`
+	// Workers is how many collection imports run at once in the background.
+	Workers int `toml:"workers" mapstructure:"workers"`
+	// QueueSize is how many imports can wait for a worker before new ones
+	// are refused.
+	QueueSize int `toml:"queue_size" mapstructure:"queue_size"`
 }
 
+// featureNamePattern matches the feature names entities.ValidFeatureName
+// accepts
+var featureNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
 func Load() (*Config, error) {
+	return load(false)
+}
+
+// LoadDemo loads the configuration for demo mode, which runs without MongoDB
+// or Authentik: app.toml is optional and the database and auth sections are
+// not checked.
+func LoadDemo() (*Config, error) {
+	return load(true)
+}
+
+func load(demo bool) (*Config, error) {
+	config, err := read(demo)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(config, demo); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+	return config, nil
+}
+
+// Read loads the configuration the way Load does without checking it, for
+// --check-config to report on. It also returns the path of the app.toml
+// that was read.
+func Read() (*Config, string, error) {
+	return readFile(false)
+}
+
+// Validate checks a configuration returned by Read.
+func Validate(config *Config) error {
+	return validate(config, false)
+}
+
+// read loads app.toml, the defaults and the environment without checking
+// the result.
+func read(demo bool) (*Config, error) {
+	config, _, err := readFile(demo)
+	return config, err
+}
+
+// readFile is read, also returning the path of the app.toml it read, or ""
+// when there was none.
+func readFile(demo bool) (*Config, string, error) {
 	v := viper.New()
 
 	// Set config name and paths
@@ -141,15 +581,19 @@ func Load() (*Config, error) {
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if errors.As(err, &configFileNotFoundError) {
-			return nil, fmt.Errorf("error reading config file: %w", err)
+		if !errors.As(err, &configFileNotFoundError) {
+			// The file is there but is not valid TOML
+			return nil, v.ConfigFileUsed(), fmt.Errorf("error reading config file %s: %w", v.ConfigFileUsed(), err)
+		}
+		if !demo {
+			return nil, "", fmt.Errorf("error reading config file: %w", err)
 		}
 		// Config file not found, continue with defaults and env vars
 	}
 
 	var config Config
 	if err := v.Unmarshal(&config); err != nil {
-		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+		return nil, v.ConfigFileUsed(), fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
 	// Fold the singular authentik_url into the plural list so the rest of the
@@ -159,11 +603,7 @@ func Load() (*Config, error) {
 		config.Auth.AuthentikURL = ""
 	}
 
-	if err := validate(&config); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
-	}
-
-	return &config, nil
+	return &config, v.ConfigFileUsed(), nil
 }
 
 func setDefaults(v *viper.Viper) {
@@ -172,6 +612,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.mcp_port", 3002)
 	v.SetDefault("server.mcp_sse_port", 3003)
 	v.SetDefault("server.debug", false)
+	v.SetDefault("server.legacy_api_sunset", "2027-04-15")
+	v.SetDefault("server.frontend", false)
 	v.SetDefault("server.tls.enabled", true)
 	v.SetDefault("server.tls.cert_file", "./certs/server.crt")
 	v.SetDefault("server.tls.key_file", "./certs/server.key")
@@ -185,12 +627,29 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.database", "nishiki")
 	v.SetDefault("database.timeout", 10)
 	v.SetDefault("database.uri", "") // Legacy field
+	v.SetDefault("database.max_pool_size", 100)
+	v.SetDefault("database.min_pool_size", 0)
+	v.SetDefault("database.max_conn_idle_time", 0)
+	v.SetDefault("database.read_uri", "")
 
 	// Auth defaults
+	v.SetDefault("auth.mode", AuthModeExternal)
+	v.SetDefault("auth.local.secret", "")
+	v.SetDefault("auth.local.token_lifetime", 3600)
+	v.SetDefault("auth.local.refresh_lifetime", 30*24*3600)
+	v.SetDefault("auth.local.allow_registration", false)
+	v.SetDefault("auth.local.redirect_urls", []string{})
+	v.SetDefault("auth.provider", AuthProviderAuthentik)
+	v.SetDefault("auth.issuer_urls", []string{})
+	v.SetDefault("auth.groups", "")
+	v.SetDefault("auth.group_claim", "groups")
+	v.SetDefault("auth.group_prefix", "")
 	v.SetDefault("auth.authentik_urls", []string{})
 	v.SetDefault("auth.jwks_cache_duration", 300)
 	v.SetDefault("auth.allow_self_signed", false)
 	v.SetDefault("auth.api_token", "")
+	v.SetDefault("auth.reauth_max_age", 300)
+	v.SetDefault("auth.admin_group", "nishiki-admins")
 	v.SetDefault("auth.clients", []OAuthClient{})
 
 	// Images defaults
@@ -199,23 +658,205 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("images.google_search_engine_id", "")
 	v.SetDefault("images.cache_dir", "./image_cache")
 
+	// Email defaults
+	v.SetDefault("email.enabled", false)
+	v.SetDefault("email.smtp_host", "")
+	v.SetDefault("email.smtp_port", 587)
+	v.SetDefault("email.username", "")
+	v.SetDefault("email.password", "")
+	v.SetDefault("email.from", "")
+
+	// Digest defaults
+	v.SetDefault("digest.enabled", false)
+	v.SetDefault("digest.check_interval", 60)
+	v.SetDefault("digest.expiring_within_days", 7)
+	v.SetDefault("digest.low_stock_threshold", 1)
+	v.SetDefault("digest.public_url", "")
+
+	// Recurrence defaults
+	v.SetDefault("recurrence.check_interval", 15)
+
+	// Automation defaults
+	v.SetDefault("automations.check_interval", 60)
+	v.SetDefault("automations.queue_size", 256)
+	v.SetDefault("automations.webhook_timeout", 10)
+	v.SetDefault("automations.webhook_retry_interval", 30)
+	v.SetDefault("automations.allow_private_webhooks", false)
+
+	// Snapshot defaults
+	v.SetDefault("snapshots.max_per_collection", 20)
+	v.SetDefault("snapshots.before_import", true)
+
+	// Media defaults
+	v.SetDefault("media.dir", "./media")
+	v.SetDefault("media.max_upload_size", 10<<20)
+	v.SetDefault("media.max_per_owner", 50)
+
+	// Nutrition defaults
+	v.SetDefault("nutrition.enabled", false)
+	v.SetDefault("nutrition.base_url", "https://world.openfoodfacts.org")
+	v.SetDefault("nutrition.user_agent", "Nishiki/1.0")
+	v.SetDefault("nutrition.timeout", 10)
+
+	// Inbox defaults
+	v.SetDefault("inbox.enabled", false)
+	v.SetDefault("inbox.address", "")
+	v.SetDefault("inbox.signing_key", "")
+
+	// CORS defaults
+	v.SetDefault("cors.allowed_origins", []string{"*"})
+	v.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	v.SetDefault("cors.allowed_headers", []string{"Origin", "Content-Type", "Accept", "Authorization", "If-None-Match", "If-Modified-Since"})
+	v.SetDefault("cors.exposed_headers", []string{"Content-Length", "Content-Disposition", "ETag", "Last-Modified", "X-Request-ID"})
+	v.SetDefault("cors.allow_credentials", true)
+	v.SetDefault("cors.max_age", 86400)
+
+	// Rate limit defaults
+	v.SetDefault("rate_limit.status_per_minute", 30)
+	v.SetDefault("rate_limit.trusted_proxies", []string{})
+
 	// Import defaults
 	v.SetDefault("import.reserved_columns", []string{
 		"name", "title", "item",
-		"description", "quantity", "tags", "location",
+		"description", "quantity", "unit", "expires_at", "tags", "location",
 	})
+	v.SetDefault("import.workers", 2)
+	v.SetDefault("import.queue_size", 32)
 
 	// Logging defaults
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.seq_endpoint", "")
 	v.SetDefault("logging.seq_api_key", "")
+	v.SetDefault("logging.stderr", false)
 }
 
-func validate(config *Config) error {
+func validate(config *Config, demo bool) error {
 	if config.Server.Port <= 0 || config.Server.Port > 65535 {
 		return errors.New("server port must be between 1 and 65535")
 	}
+	if config.Server.LegacyAPISunset != "" {
+		if _, err := time.Parse(time.DateOnly, config.Server.LegacyAPISunset); err != nil {
+			return fmt.Errorf("server legacy_api_sunset %q must be a date like 2027-04-15", config.Server.LegacyAPISunset)
+		}
+	}
+
+	if _, err := config.Logging.SlogLevel(); err != nil {
+		return err
+	}
+	if err := config.Logging.validateSinks(); err != nil {
+		return err
+	}
+
+	if !demo {
+		if err := validateBackends(config); err != nil {
+			return err
+		}
+	}
+
+	if config.Email.Enabled {
+		if config.Email.SMTPHost == "" {
+			return errors.New("email smtp_host is required when email is enabled")
+		}
+		if config.Email.From == "" {
+			return errors.New("email from address is required when email is enabled")
+		}
+	}
+
+	if config.Digest.Enabled {
+		if !config.Email.Enabled {
+			return errors.New("digest requires email to be enabled")
+		}
+		if config.Digest.CheckInterval <= 0 {
+			return errors.New("digest check_interval must be positive")
+		}
+		if config.Digest.PublicURL == "" {
+			return errors.New("digest public_url is required for unsubscribe links")
+		}
+	}
+
+	if config.Recurrence.CheckInterval <= 0 {
+		return errors.New("recurrence check_interval must be positive")
+	}
+
+	if config.Automations.CheckInterval <= 0 {
+		return errors.New("automations check_interval must be positive")
+	}
+	if config.Automations.QueueSize <= 0 {
+		return errors.New("automations queue_size must be positive")
+	}
+	if config.Automations.WebhookTimeout <= 0 {
+		return errors.New("automations webhook_timeout must be positive")
+	}
+	if config.Automations.WebhookRetryInterval <= 0 {
+		return errors.New("automations webhook_retry_interval must be positive")
+	}
+
+	if config.Snapshots.MaxPerCollection < 0 {
+		return errors.New("snapshots max_per_collection must not be negative")
+	}
+
+	if config.Media.MaxUploadSize <= 0 {
+		return errors.New("media max_upload_size must be positive")
+	}
+	if config.Media.MaxPerOwner <= 0 {
+		return errors.New("media max_per_owner must be positive")
+	}
+
+	if config.Nutrition.Enabled {
+		u, err := url.Parse(config.Nutrition.BaseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("nutrition base_url %q must be an absolute URL", config.Nutrition.BaseURL)
+		}
+		if config.Nutrition.Timeout <= 0 {
+			return errors.New("nutrition timeout must be positive")
+		}
+	}
+
+	if config.Inbox.Enabled {
+		local, domain, ok := strings.Cut(config.Inbox.Address, "@")
+		if !ok || local == "" || domain == "" || strings.Contains(local, "+") {
+			return fmt.Errorf("inbox address %q must be an email address without a +tag", config.Inbox.Address)
+		}
+		if config.Inbox.SigningKey == "" {
+			return errors.New("inbox signing_key is required when the inbox is enabled")
+		}
+	}
+
+	for name := range config.Features {
+		if !featureNamePattern.MatchString(name) {
+			return fmt.Errorf("feature %q must be lowercase letters, digits and underscores, starting with a letter", name)
+		}
+	}
+
+	if len(config.CORS.AllowedOrigins) == 0 {
+		return errors.New("cors allowed_origins must not be empty; use \"*\" to allow any origin")
+	}
+	for i, origin := range config.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			return fmt.Errorf("cors allowed_origins[%d] %q must be \"*\" or scheme://host[:port]", i, origin)
+		}
+	}
+	if config.CORS.MaxAge < 0 {
+		return errors.New("cors max_age must not be negative")
+	}
+
+	if config.RateLimit.StatusPerMinute <= 0 {
+		return errors.New("rate_limit status_per_minute must be positive")
+	}
+	if _, err := config.RateLimit.TrustedProxyPrefixes(); err != nil {
+		return err
+	}
+
+	return nil
+}
 
+// validateBackends checks the MongoDB and Authentik settings, which demo
+// mode does without.
+func validateBackends(config *Config) error {
 	// Validate database configuration
 	if config.Database.URI == "" && config.Database.Host == "" {
 		return errors.New("either database URI or host must be provided")
@@ -225,22 +866,96 @@ func validate(config *Config) error {
 		return errors.New("database name is required")
 	}
 
-	if len(config.Auth.AuthentikURLs) == 0 {
-		return errors.New("at least one authentik_urls entry is required")
+	if config.Database.MaxPoolSize < 0 || config.Database.MinPoolSize < 0 {
+		return errors.New("database max_pool_size and min_pool_size cannot be negative")
+	}
+	if config.Database.MaxPoolSize > 0 && config.Database.MinPoolSize > config.Database.MaxPoolSize {
+		return errors.New("database max_pool_size must be at least min_pool_size")
+	}
+
+	if config.Database.MaxConnIdleTime < 0 {
+		return errors.New("database max_conn_idle_time cannot be negative")
 	}
-	for i, u := range config.Auth.AuthentikURLs {
-		if u == "" {
-			return fmt.Errorf("authentik_urls[%d] is empty", i)
+
+	switch config.Auth.Mode {
+	case AuthModeExternal, "":
+		return validateProvider(config.Auth)
+	case AuthModeLocal:
+		return validateLocalAuth(config.Auth)
+	default:
+		return fmt.Errorf("unknown auth mode %q; use %q or %q", config.Auth.Mode, AuthModeExternal, AuthModeLocal)
+	}
+}
+
+// validateLocalAuth checks the built-in accounts of the local auth mode,
+// which need no identity provider or OAuth clients.
+func validateLocalAuth(auth AuthConfig) error {
+	if len(auth.Local.Secret) < 32 {
+		return errors.New("auth.local.secret must be at least 32 bytes in local auth mode")
+	}
+	if auth.Local.TokenLifetime <= 0 || auth.Local.RefreshLifetime <= 0 {
+		return errors.New("auth.local token_lifetime and refresh_lifetime must be positive")
+	}
+	switch auth.GroupSource() {
+	case GroupsClaims:
+	case GroupsAuthentik:
+		if len(auth.AuthentikURLs) == 0 || auth.AuthentikURLs[0] == "" {
+			return errors.New("at least one authentik_urls entry is required for authentik groups")
+		}
+	default:
+		return fmt.Errorf("unknown auth groups %q; use %q or %q", auth.Groups, GroupsAuthentik, GroupsClaims)
+	}
+	return nil
+}
+
+// validateProvider checks the identity provider and OAuth clients of the
+// external auth mode.
+func validateProvider(auth AuthConfig) error {
+	switch auth.Provider {
+	case AuthProviderAuthentik, "":
+	case AuthProviderOIDC:
+		if len(auth.IssuerURLs) == 0 {
+			return errors.New("at least one issuer_urls entry is required for the oidc provider")
+		}
+		for i, u := range auth.IssuerURLs {
+			if u == "" {
+				return fmt.Errorf("issuer_urls[%d] is empty", i)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown auth provider %q; use %q or %q", auth.Provider, AuthProviderAuthentik, AuthProviderOIDC)
+	}
+
+	switch auth.GroupSource() {
+	case GroupsAuthentik:
+	case GroupsClaims:
+		if auth.GroupClaim == "" {
+			return errors.New("group_claim is required when groups come from token claims")
 		}
+	default:
+		return fmt.Errorf("unknown auth groups %q; use %q or %q", auth.Groups, GroupsAuthentik, GroupsClaims)
 	}
 
-	if len(config.Auth.Clients) == 0 {
+	// Authentik serves sign-in for its own provider and the group API for
+	// the authentik group directory
+	if auth.Provider != AuthProviderOIDC || auth.GroupSource() == GroupsAuthentik {
+		if len(auth.AuthentikURLs) == 0 {
+			return errors.New("at least one authentik_urls entry is required")
+		}
+		for i, u := range auth.AuthentikURLs {
+			if u == "" {
+				return fmt.Errorf("authentik_urls[%d] is empty", i)
+			}
+		}
+	}
+
+	if len(auth.Clients) == 0 {
 		return errors.New("at least one OAuth client must be configured")
 	}
 
 	// Validate each OAuth client
 	providerNames := make(map[string]bool)
-	for i, client := range config.Auth.Clients {
+	for i, client := range auth.Clients {
 		if client.ProviderName == "" {
 			return fmt.Errorf("client %d: provider name is required", i)
 		}
@@ -262,6 +977,5 @@ func validate(config *Config) error {
 			return fmt.Errorf("client %s: redirect URL is required", client.ProviderName)
 		}
 	}
-
 	return nil
 }
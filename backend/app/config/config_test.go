@@ -0,0 +1,61 @@
+package config
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBackends_PoolSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		max     int
+		min     int
+		wantErr string
+	}{
+		{name: "bounded", max: 100, min: 10},
+		{name: "zero max is unlimited", max: 0, min: 10},
+		{name: "min above max", max: 5, min: 10, wantErr: "at least min_pool_size"},
+		{name: "negative max", max: -1, wantErr: "cannot be negative"},
+		{name: "negative min", max: 10, min: -1, wantErr: "cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{
+				Database: DatabaseConfig{URI: "mongodb://localhost:27017", Database: "nishiki", MaxPoolSize: tt.max, MinPoolSize: tt.min},
+				Auth: AuthConfig{Mode: AuthModeLocal, Local: LocalAuthConfig{
+					Secret:          "0123456789abcdef0123456789abcdef",
+					TokenLifetime:   900,
+					RefreshLifetime: 86400,
+				}},
+			}
+			err := validateBackends(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRateLimitConfig_TrustedProxyPrefixes(t *testing.T) {
+	t.Parallel()
+
+	prefixes, err := RateLimitConfig{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.7", "::ffff:172.16.0.1", "fd00::/8"}}.TrustedProxyPrefixes()
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.7/32"),
+		netip.MustParsePrefix("172.16.0.1/32"),
+		netip.MustParsePrefix("fd00::/8"),
+	}, prefixes)
+
+	_, err = RateLimitConfig{TrustedProxies: []string{"proxy.internal"}}.TrustedProxyPrefixes()
+	assert.ErrorContains(t, err, "trusted_proxies[0]")
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// reloadableKeys are the settings a running server applies on reload. Keys
+// ending in a dot cover the whole section; every other change waits for a
+// restart.
+var reloadableKeys = []string{
+	"logging.level",
+	"rate_limit.",
+	"cors.",
+	"digest.check_interval",
+	"digest.expiring_within_days",
+	"digest.low_stock_threshold",
+	"digest.public_url",
+	"recurrence.check_interval",
+	"automations.check_interval",
+	"automations.webhook_retry_interval",
+	"features",
+}
+
+// secretKeyParts mark settings whose values are never shown in a diff
+var secretKeyParts = []string{"password", "secret", "api_key", "api_token"}
+
+// Change is one setting that differs between two configurations, keyed the
+// way it is written in app.toml (e.g. "cors.max_age").
+type Change struct {
+	Key string
+	Old string
+	New string
+	// Restart is set when the running server does not pick the change up
+	Restart bool
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s: %s -> %s", c.Key, c.Old, c.New)
+	if c.Restart {
+		s += " (needs restart)"
+	}
+	return s
+}
+
+// ReloadError rejects a configuration that failed validation, listing what
+// changed from the running one so the offending edit is easy to find.
+type ReloadError struct {
+	Err     error
+	Changes []Change
+}
+
+func (e *ReloadError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "config reload rejected: %v", e.Err)
+	if len(e.Changes) > 0 {
+		b.WriteString("; changed: ")
+		for i, c := range e.Changes {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(c.String())
+		}
+	}
+	return b.String()
+}
+
+func (e *ReloadError) Unwrap() error {
+	return e.Err
+}
+
+// Reload reads the configuration again and returns the running one with the
+// reloadable settings replaced, along with everything that changed. An
+// invalid configuration is rejected with a *ReloadError and current is kept.
+func Reload(current *Config, demo bool) (*Config, []Change, error) {
+	next, err := read(demo)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes := Diff(current, next)
+	if err := validate(next, demo); err != nil {
+		return nil, changes, &ReloadError{Err: err, Changes: changes}
+	}
+
+	merged := current.withReloadable(next)
+	if err := validate(merged, demo); err != nil {
+		return nil, changes, &ReloadError{Err: err, Changes: changes}
+	}
+	return merged, changes, nil
+}
+
+// withReloadable copies c with the settings listed in reloadableKeys taken
+// from next
+func (c *Config) withReloadable(next *Config) *Config {
+	merged := *c
+	merged.Logging.Level = next.Logging.Level
+	merged.RateLimit = next.RateLimit
+	merged.CORS = next.CORS
+	merged.Digest.CheckInterval = next.Digest.CheckInterval
+	merged.Digest.ExpiringWithinDays = next.Digest.ExpiringWithinDays
+	merged.Digest.LowStockThreshold = next.Digest.LowStockThreshold
+	merged.Digest.PublicURL = next.Digest.PublicURL
+	merged.Recurrence.CheckInterval = next.Recurrence.CheckInterval
+	merged.Automations.CheckInterval = next.Automations.CheckInterval
+	merged.Automations.WebhookRetryInterval = next.Automations.WebhookRetryInterval
+	merged.Features = next.Features
+	return &merged
+}
+
+// Diff lists the settings that differ between old and next, in the order
+// they are declared. Secrets are masked.
+func Diff(old, next *Config) []Change {
+	var changes []Change
+	diffValues("", reflect.ValueOf(*old), reflect.ValueOf(*next), &changes)
+	return changes
+}
+
+func diffValues(prefix string, old, next reflect.Value, changes *[]Change) {
+	if old.Kind() == reflect.Struct {
+		for i := range old.NumField() {
+			key, _, _ := strings.Cut(old.Type().Field(i).Tag.Get("mapstructure"), ",")
+			diffValues(prefix+key+".", old.Field(i), next.Field(i), changes)
+		}
+		return
+	}
+	if reflect.DeepEqual(old.Interface(), next.Interface()) {
+		return
+	}
+
+	key := strings.TrimSuffix(prefix, ".")
+	change := Change{Key: key, Old: formatValue(key, old), New: formatValue(key, next), Restart: true}
+	for _, reloadable := range reloadableKeys {
+		if key == reloadable || (strings.HasSuffix(reloadable, ".") && strings.HasPrefix(key, reloadable)) {
+			change.Restart = false
+			break
+		}
+	}
+	*changes = append(*changes, change)
+}
+
+func formatValue(key string, v reflect.Value) string {
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return "(hidden)"
+		}
+	}
+	// Lists of structs, such as auth.clients, carry secrets of their own
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Struct {
+		return fmt.Sprintf("(%d entries)", v.Len())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAppTOML(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.toml"), []byte(content), 0o600))
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeAppTOML(t, dir, `
+[server]
+port = 3001
+
+[logging]
+level = "info"
+`)
+	current, err := LoadDemo()
+	require.NoError(t, err)
+
+	t.Run("applies reloadable settings", func(t *testing.T) {
+		writeAppTOML(t, dir, `
+[server]
+port = 4000
+
+[logging]
+level = "debug"
+
+[cors]
+allowed_origins = ["https://inventory.example.com"]
+
+[rate_limit]
+status_per_minute = 5
+
+[features.meal_planner]
+users = ["alice"]
+`)
+		next, changes, err := Reload(current, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, "debug", next.Logging.Level)
+		assert.Equal(t, []string{"https://inventory.example.com"}, next.CORS.AllowedOrigins)
+		assert.Equal(t, 5, next.RateLimit.StatusPerMinute)
+		assert.Equal(t, []string{"alice"}, next.Features["meal_planner"].Users)
+		// The port is only read at startup
+		assert.Equal(t, 3001, next.Server.Port)
+
+		byKey := make(map[string]Change)
+		for _, change := range changes {
+			byKey[change.Key] = change
+		}
+		assert.True(t, byKey["server.port"].Restart)
+		assert.False(t, byKey["logging.level"].Restart)
+		assert.Equal(t, "info", byKey["logging.level"].Old)
+		assert.Equal(t, "debug", byKey["logging.level"].New)
+		assert.False(t, byKey["cors.allowed_origins"].Restart)
+		assert.False(t, byKey["features"].Restart)
+	})
+
+	t.Run("rejects invalid config with what changed", func(t *testing.T) {
+		writeAppTOML(t, dir, `
+[logging]
+level = "loud"
+
+[cors]
+allowed_origins = ["inventory.example.com"]
+`)
+		next, _, err := Reload(current, true)
+		assert.Nil(t, next)
+
+		var reloadErr *ReloadError
+		require.ErrorAs(t, err, &reloadErr)
+		assert.Contains(t, err.Error(), `logging level "loud"`)
+		assert.Contains(t, err.Error(), "logging.level: info -> loud")
+		assert.Contains(t, err.Error(), "cors.allowed_origins: [*] -> [inventory.example.com]")
+	})
+}
+
+func TestDiff_HidesSecrets(t *testing.T) {
+	t.Parallel()
+
+	old := &Config{Email: EmailConfig{Password: "hunter2"}, Auth: AuthConfig{Clients: []OAuthClient{{ClientSecret: "a"}}}}
+	next := &Config{Email: EmailConfig{Password: "correct horse"}, Auth: AuthConfig{Clients: []OAuthClient{{ClientSecret: "b"}}}}
+
+	changes := Diff(old, next)
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, Change{Key: "auth.clients", Old: "(1 entries)", New: "(1 entries)", Restart: true}, changes[0])
+	assert.Equal(t, Change{Key: "email.password", Old: "(hidden)", New: "(hidden)", Restart: true}, changes[1])
+}
@@ -0,0 +1,12 @@
+package config
+
+import _ "embed"
+
+//go:embed sample.toml
+var sample []byte
+
+// Sample returns an app.toml listing every setting with its default value
+// and a comment on what it does, for --print-default-config.
+func Sample() []byte {
+	return sample
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSample_MatchesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	defaults, err := read(true)
+	require.NoError(t, err)
+
+	writeAppTOML(t, dir, string(Sample()))
+	fromSample, path, err := Read()
+	require.NoError(t, err)
+	assert.Contains(t, path, "app.toml")
+
+	assert.Empty(t, Diff(defaults, fromSample), "sample.toml must list every setting at its default")
+}
+
+func TestSample_ListsEverySetting(t *testing.T) {
+	t.Parallel()
+
+	sample := string(Sample())
+	for _, key := range settingKeys("", reflect.TypeFor[Config]()) {
+		section, name := "", key
+		if i := strings.LastIndex(key, "."); i >= 0 {
+			section, name = key[:i], key[i+1:]
+		}
+		// Lists and maps of tables are shown as commented-out examples
+		pattern := `(?m)^(# )?` + regexp.QuoteMeta(name) + ` =`
+		if section == "auth.clients" || section == "logging.sinks" || section == "features" {
+			pattern = `(?m)^# ` + regexp.QuoteMeta(name) + ` =`
+		}
+		assert.Regexp(t, pattern, sample, "sample.toml does not describe %s", key)
+	}
+}
+
+func TestRead_RejectsMalformedFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeAppTOML(t, dir, "[server\nport = 3001\n")
+
+	_, path, err := Read()
+	require.Error(t, err)
+	assert.Contains(t, path, "app.toml")
+
+	// Demo mode does without app.toml, but not with a broken one
+	_, err = LoadDemo()
+	assert.Error(t, err)
+}
+
+// settingKeys lists the dotted app.toml key of every setting in t
+func settingKeys(prefix string, t reflect.Type) []string {
+	var keys []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		ft := field.Type
+		if (ft.Kind() == reflect.Slice || ft.Kind() == reflect.Map) && ft.Elem().Kind() == reflect.Struct {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			keys = append(keys, settingKeys(prefix+key+".", ft)...)
+			continue
+		}
+		keys = append(keys, prefix+key)
+	}
+	return keys
+}
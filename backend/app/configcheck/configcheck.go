@@ -0,0 +1,186 @@
+// Package configcheck validates the server's configuration and checks that
+// the services it names answer, for `nishiki --check-config`. It lets a
+// self-hoster find a bad setting before the server is started with it.
+package configcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/external/adapters"
+	"github.com/nishiki/backend/external/services"
+)
+
+// Check names reported by Run.
+const (
+	CheckFile     = "config file"
+	CheckSettings = "settings"
+	CheckDatabase = "database"
+	CheckAuth     = "identity provider"
+	CheckTLS      = "tls"
+)
+
+// CheckResult is the outcome of one check. Detail is what was checked, such
+// as the file read or the URL probed; Hint says what to change when Err is
+// set.
+type CheckResult struct {
+	Name   string
+	Detail string
+	Err    error
+	Hint   string
+}
+
+// Report lists every check Run performed, in the order it ran them.
+type Report struct {
+	Results []CheckResult
+}
+
+// Failed returns the checks that did not pass.
+func (r *Report) Failed() []CheckResult {
+	var failed []CheckResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (r *Report) add(result CheckResult) bool {
+	r.Results = append(r.Results, result)
+	return result.Err == nil
+}
+
+// Run reads and validates the configuration the way the server does at
+// startup, then connects to MongoDB and the identity provider and loads the
+// TLS certificate. Checks stop at the first problem with the configuration
+// itself; the services are each checked even when another one fails.
+func Run(ctx context.Context) *Report {
+	report := &Report{}
+
+	cfg, path, err := config.Read()
+	if !report.add(checkFile(path, err)) {
+		return report
+	}
+	if !report.add(checkSettings(cfg, path)) {
+		return report
+	}
+
+	report.add(checkDatabase(ctx, cfg.Database))
+	report.add(checkAuth(cfg.Auth))
+	if cfg.Server.TLS.Enabled {
+		report.add(checkTLS(cfg.Server.TLS))
+	}
+	return report
+}
+
+func checkFile(path string, err error) CheckResult {
+	result := CheckResult{Name: CheckFile, Detail: path, Err: err}
+	var (
+		notFound viper.ConfigFileNotFoundError
+		parseErr viper.ConfigParseError
+	)
+	switch {
+	case err == nil:
+	case errors.As(err, &notFound):
+		result.Detail = "no app.toml"
+		result.Hint = "create app.toml in the working directory, ./config or /etc/nishiki/; " +
+			"`nishiki --print-default-config > app.toml` writes one with every setting"
+	case errors.As(err, &parseErr):
+		result.Hint = "fix the TOML syntax where the error points"
+	default:
+		result.Hint = "a setting has the wrong type, e.g. text where a number belongs"
+	}
+	return result
+}
+
+func checkSettings(cfg *config.Config, path string) CheckResult {
+	err := config.Validate(cfg)
+	if err == nil {
+		return CheckResult{Name: CheckSettings}
+	}
+	return CheckResult{
+		Name: CheckSettings,
+		Err:  err,
+		Hint: "change the setting named above in " + path +
+			", or the NISHIKI_<SECTION>_<KEY> environment variable that overrides it",
+	}
+}
+
+func checkDatabase(ctx context.Context, cfg config.DatabaseConfig) CheckResult {
+	result := CheckResult{Name: CheckDatabase, Detail: redactedURI(cfg)}
+	db := adapters.NewMongoDatabase(cfg)
+	if err := db.Connect(ctx); err != nil {
+		result.Err = err
+		result.Hint = "check that MongoDB is running and reachable from this host, " +
+			"and the [database] host, port, username and password, or uri, and read_uri when set"
+		return result
+	}
+	_ = db.Disconnect(ctx)
+	return result
+}
+
+// redactedURI is the MongoDB connection URI with its password masked
+func redactedURI(cfg config.DatabaseConfig) string {
+	uri := cfg.GetURI()
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	}
+	return u.Redacted()
+}
+
+// checkAuth fetches the identity provider's discovery document for every
+// OAuth client, the way the server does at startup. Local accounts have no
+// provider to reach.
+func checkAuth(cfg config.AuthConfig) CheckResult {
+	if cfg.IsLocal() {
+		return CheckResult{Name: CheckAuth, Detail: "local accounts"}
+	}
+
+	candidates, setting := cfg.AuthentikURLs, "authentik_urls"
+	if cfg.Provider == config.AuthProviderOIDC {
+		candidates, setting = cfg.IssuerURLs, "issuer_urls"
+	}
+	result := CheckResult{Name: CheckAuth, Detail: strings.Join(candidates, ", ")}
+
+	svc, err := services.NewAuthService(cfg, slog.New(slog.DiscardHandler))
+	switch {
+	case err == nil:
+		result.Detail = svc.IssuerURL()
+	case errors.Is(err, services.ErrOIDCProviderInit):
+		result.Err = err
+		result.Hint = "an [[auth.clients]] entry names a provider the identity provider does not serve; " +
+			"for Authentik, provider_name must be the application's slug"
+	default:
+		result.Err = err
+		result.Hint = "none of " + setting + " answered from this host; check the URLs, " +
+			"and set allow_self_signed = true if the provider uses a self-signed certificate"
+	}
+	return result
+}
+
+// checkTLS loads the certificate the REST API is served with. When either
+// file is missing the server falls back to plain HTTP; when they do not
+// load it refuses to start.
+func checkTLS(cfg config.TLSConfig) CheckResult {
+	result := CheckResult{Name: CheckTLS, Detail: cfg.CertFile}
+	if _, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err != nil {
+		result.Err = err
+		result.Hint = "the server will not start; check that key_file is the private key of cert_file"
+		if errors.Is(err, fs.ErrNotExist) {
+			result.Hint = "the REST API would be served over plain HTTP; point [server.tls] cert_file and key_file " +
+				"at the certificate and its key, or set enabled = false behind a proxy that terminates TLS"
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package configcheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+)
+
+func TestRun_MissingFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	report := Run(context.Background())
+
+	require.Len(t, report.Results, 1)
+	result := report.Results[0]
+	assert.Equal(t, CheckFile, result.Name)
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Hint, "--print-default-config")
+}
+
+func TestRun_StopsAtInvalidSettings(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.toml"), []byte("[server]\nport = 70000\n"), 0o600))
+
+	report := Run(context.Background())
+
+	// Neither MongoDB nor the identity provider is tried
+	require.Len(t, report.Results, 2)
+	assert.NoError(t, report.Results[0].Err)
+	assert.Equal(t, CheckSettings, report.Results[1].Name)
+	assert.ErrorContains(t, report.Results[1].Err, "server port")
+	assert.Len(t, report.Failed(), 1)
+}
+
+func TestCheckTLS(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	missing := checkTLS(config.TLSConfig{CertFile: filepath.Join(dir, "server.crt"), KeyFile: filepath.Join(dir, "server.key")})
+	require.Error(t, missing.Err)
+	assert.Contains(t, missing.Hint, "plain HTTP")
+
+	cert := filepath.Join(dir, "bad.crt")
+	require.NoError(t, os.WriteFile(cert, []byte("not a certificate"), 0o600))
+	bad := checkTLS(config.TLSConfig{CertFile: cert, KeyFile: cert})
+	require.Error(t, bad.Err)
+	assert.Contains(t, bad.Hint, "will not start")
+}
+
+func TestRedactedURI(t *testing.T) {
+	t.Parallel()
+
+	uri := redactedURI(config.DatabaseConfig{Host: "db.lan", Port: 27017, Username: "nishiki", Password: "hunter2", AuthSource: "admin"})
+
+	assert.NotContains(t, uri, "hunter2")
+	assert.Contains(t, uri, "db.lan:27017")
+}
@@ -2,34 +2,103 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
-
-	slogmulti "github.com/samber/slog-multi"
-	"github.com/swczk/go-seqlogger"
+	"sync"
+	"time"
 
 	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/events"
 	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/importjobs"
+	"github.com/nishiki/backend/app/logging"
+	"github.com/nishiki/backend/app/metrics"
+	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/repositories"
 	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/domain/usecases"
 	"github.com/nishiki/backend/external/adapters"
 	extRepos "github.com/nishiki/backend/external/repositories"
 	extServices "github.com/nishiki/backend/external/services"
 )
 
 type Container struct {
-	config *config.Config
-	logger *slog.Logger
+	configMu sync.RWMutex
+	config   *config.Config
+	logger   *slog.Logger
+	logSinks *logging.Sinks
 
 	database *adapters.MongoDatabase
+	inMemory bool
 
 	ContainerRepo  repositories.ContainerRepository
 	CategoryRepo   repositories.CategoryRepository
 	CollectionRepo repositories.CollectionRepository
 
+	DigestSubscriptionRepo repositories.DigestSubscriptionRepository
+	ContainerTemplateRepo  repositories.ContainerTemplateRepository
+	ObjectMoveRepo         repositories.ObjectMoveRepository
+	AdjustmentRepo         repositories.QuantityAdjustmentRepository
+	MealPlanRepo           repositories.MealPlanRepository
+	RecurrenceRuleRepo     repositories.RecurrenceRuleRepository
+	AutomationRuleRepo     repositories.AutomationRuleRepository
+	WebhookKeyRepo         repositories.WebhookSigningKeyRepository
+	WebhookDeliveryRepo    repositories.WebhookDeliveryRepository
+	FolderRepo             repositories.CollectionFolderRepository
+	ObjectTypeRepo         repositories.CustomObjectTypeRepository
+	ObjectCodeRepo         repositories.ObjectCodeRepository
+	SnapshotRepo           repositories.CollectionSnapshotRepository
+	PreferencesRepo        repositories.UserPreferencesRepository
+	MediaRepo              repositories.MediaRepository
+	LocalAccountRepo       repositories.LocalAccountRepository
+	CommentRepo            repositories.CommentRepository
+	AccountStatusRepo      repositories.AccountStatusRepository
+	SessionRepo            repositories.SessionRepository
+	GroupQuotaRepo         repositories.GroupQuotaRepository
+	OAuthClientRepo        repositories.OAuthClientRepository
+	UsageRepo              repositories.UsageRepository
+	InboxRepo              repositories.InboxRepository
+	ExpiryHistoryRepo      repositories.ExpiryHistoryRepository
+	FeatureFlagRepo        repositories.FeatureFlagRepository
+
 	AuthService        services.AuthService
 	ImageSearchService services.ImageSearchService
+	EmailService       services.EmailService
+	ReportRenderer     services.ReportRenderer
+	LabelRenderer      services.LabelRenderer
+	MediaStorage       services.MediaStorage
+	BarcodeDecoder     services.BarcodeDecoder
+	NutritionProvider  services.NutritionProvider
+	WebhookSender      services.WebhookSender
+
+	checkAccountOnce sync.Once
+	checkAccount     *usecases.CheckAccountUseCase
+
+	checkSessionOnce sync.Once
+	checkSession     *usecases.CheckSessionUseCase
+
+	oauthClientsOnce sync.Once
+	oauthClients     *usecases.OAuthClientUseCase
+
+	featuresOnce sync.Once
+	features     *usecases.FeatureFlagUseCase
+
+	metricsOnce sync.Once
+	metrics     *metrics.Metrics
+
+	importJobsOnce sync.Once
+	importJobs     *importjobs.Queue
+
+	eventsOnce sync.Once
+	events     *events.Bus
+
+	corsOnce sync.Once
+	cors     *middleware.CORS
+
+	statusLimitOnce sync.Once
+	statusLimit     *middleware.RateLimiter
 }
 
 func NewContainer(cfg *config.Config) (*Container, error) {
@@ -57,48 +126,22 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 }
 
 func (c *Container) setupLogger() error {
-	var level slog.Level
-	switch c.config.Logging.Level {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
-
-	// Always create console JSON handler
-	consoleHandler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
-	})
-
-	// If Seq endpoint is configured, create multi-handler with both console and Seq
-	if c.config.Logging.SeqEndpoint != "" {
-		seqConfig := seqlogger.DefaultConfig(c.config.Logging.SeqEndpoint).
-			WithLogLevel(level)
-
-		if c.config.Logging.SeqAPIKey != "" {
-			seqConfig = seqConfig.WithAPIKey(c.config.Logging.SeqAPIKey)
-		}
-
-		seqLogger := seqlogger.New(seqConfig)
-
-		// Use slog-multi to combine console and Seq logging
-		multiHandler := slogmulti.Fanout(
-			consoleHandler,
-			seqLogger.Handler(),
-		)
+	return c.SetupLogging(c.config.Logging)
+}
 
-		c.logger = slog.New(multiHandler)
-	} else {
-		// Only console logging
-		c.logger = slog.New(consoleHandler)
+// SetupLogging opens the log sinks configured in cfg, console included, and
+// logs through them from then on
+func (c *Container) SetupLogging(cfg config.LoggingConfig) error {
+	console := os.Stdout
+	if cfg.Stderr {
+		console = os.Stderr
 	}
-
+	sinks, err := logging.Open(cfg, console)
+	if err != nil {
+		return err
+	}
+	c.logSinks = sinks
+	c.logger = slog.New(sinks.Handler())
 	return nil
 }
 
@@ -120,6 +163,31 @@ func (c *Container) setupRepositories() error {
 	c.ContainerRepo = extRepos.NewMongoContainerRepository(c.database)
 	c.CategoryRepo = extRepos.NewMongoCategoryRepository(c.database)
 	c.CollectionRepo = extRepos.NewMongoCollectionRepository(c.database)
+	c.DigestSubscriptionRepo = extRepos.NewMongoDigestSubscriptionRepository(c.database)
+	c.ContainerTemplateRepo = extRepos.NewMongoContainerTemplateRepository(c.database)
+	c.ObjectMoveRepo = extRepos.NewMongoObjectMoveRepository(c.database)
+	c.AdjustmentRepo = extRepos.NewMongoQuantityAdjustmentRepository(c.database)
+	c.MealPlanRepo = extRepos.NewMongoMealPlanRepository(c.database)
+	c.RecurrenceRuleRepo = extRepos.NewMongoRecurrenceRuleRepository(c.database)
+	c.AutomationRuleRepo = extRepos.NewMongoAutomationRuleRepository(c.database)
+	c.WebhookKeyRepo = extRepos.NewMongoWebhookSigningKeyRepository(c.database)
+	c.WebhookDeliveryRepo = extRepos.NewMongoWebhookDeliveryRepository(c.database)
+	c.FolderRepo = extRepos.NewMongoCollectionFolderRepository(c.database)
+	c.ObjectTypeRepo = extRepos.NewMongoCustomObjectTypeRepository(c.database)
+	c.ObjectCodeRepo = extRepos.NewMongoObjectCodeRepository(c.database)
+	c.SnapshotRepo = extRepos.NewMongoCollectionSnapshotRepository(c.database)
+	c.PreferencesRepo = extRepos.NewMongoUserPreferencesRepository(c.database)
+	c.MediaRepo = extRepos.NewMongoMediaRepository(c.database)
+	c.LocalAccountRepo = extRepos.NewMongoLocalAccountRepository(c.database)
+	c.CommentRepo = extRepos.NewMongoCommentRepository(c.database)
+	c.AccountStatusRepo = extRepos.NewMongoAccountStatusRepository(c.database)
+	c.SessionRepo = extRepos.NewMongoSessionRepository(c.database)
+	c.GroupQuotaRepo = extRepos.NewMongoGroupQuotaRepository(c.database)
+	c.OAuthClientRepo = extRepos.NewMongoOAuthClientRepository(c.database)
+	c.UsageRepo = extRepos.NewMongoUsageRepository(c.database)
+	c.InboxRepo = extRepos.NewMongoInboxRepository(c.database)
+	c.ExpiryHistoryRepo = extRepos.NewMongoExpiryHistoryRepository(c.database)
+	c.FeatureFlagRepo = extRepos.NewMongoFeatureFlagRepository(c.database)
 
 	c.logger.Info("Repositories initialized successfully")
 	return nil
@@ -127,9 +195,14 @@ func (c *Container) setupRepositories() error {
 
 func (c *Container) setupServices() error {
 	var err error
-	c.AuthService, err = extServices.NewAuthentikAuthService(c.config.Auth, c.logger)
-	if err != nil {
-		return fmt.Errorf("failed to create auth service: %w", err)
+	if c.config.Auth.IsLocal() {
+		c.AuthService = extServices.NewLocalAuthService(c.config.Auth, c.LocalAccountRepo, c.logger)
+		c.logger.Info("Local auth mode: accounts are kept by Nishiki")
+	} else {
+		c.AuthService, err = extServices.NewAuthService(c.config.Auth, c.logger)
+		if err != nil {
+			return fmt.Errorf("failed to create auth service: %w", err)
+		}
 	}
 
 	if c.config.Images.Enabled {
@@ -141,6 +214,36 @@ func (c *Container) setupServices() error {
 			slog.String("cache_dir", c.config.Images.CacheDir))
 	}
 
+	if c.config.Nutrition.Enabled {
+		c.NutritionProvider = extServices.NewOpenFoodFactsNutritionProvider(c.config.Nutrition, c.logger)
+		c.logger.Info("Nutrition provider initialized",
+			slog.String("base_url", c.config.Nutrition.BaseURL))
+	}
+
+	if c.config.Email.Enabled {
+		c.EmailService, err = extServices.NewSMTPEmailService(c.config.Email, c.logger)
+		if err != nil {
+			return fmt.Errorf("failed to create email service: %w", err)
+		}
+		c.logger.Info("Email service initialized",
+			slog.String("smtp_host", c.config.Email.SMTPHost))
+	}
+
+	if err := c.OAuthClients().Load(context.Background()); err != nil {
+		c.logger.Warn("Registered OAuth clients not loaded; only clients from config are accepted",
+			slog.Any("error", err))
+	}
+
+	c.ReportRenderer = extServices.NewPDFReportRenderer(c.config.Images, c.logger)
+	c.LabelRenderer = extServices.NewLabelRenderer()
+	c.BarcodeDecoder = extServices.NewBarcodeDecoder()
+	c.WebhookSender = extServices.NewHTTPWebhookSender(c.config.Automations, c.logger)
+
+	c.MediaStorage, err = extServices.NewLocalMediaStorage(c.config.Media, c.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create media storage: %w", err)
+	}
+
 	c.logger.Info("Services initialized successfully")
 	return nil
 }
@@ -153,21 +256,201 @@ func (c *Container) Close() error {
 			return err
 		}
 	}
+	if c.logSinks != nil {
+		if err := c.logSinks.Close(); err != nil {
+			return fmt.Errorf("failed to close log sinks: %w", err)
+		}
+	}
 
 	return nil
 }
 
+// CheckDatabase pings the primary MongoDB node. In-memory storage is always
+// healthy.
+func (c *Container) CheckDatabase(ctx context.Context) error {
+	if c.inMemory {
+		return nil
+	}
+	if c.database == nil {
+		return errors.New("database not initialized")
+	}
+	return c.database.Health(ctx)
+}
+
 // Getters
 func (c *Container) GetConfig() *config.Config {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
 	return c.config
 }
 
+// LogSinks are where the container's logger writes, or nil when the logger
+// was set with SetLogger
+func (c *Container) LogSinks() *logging.Sinks {
+	return c.logSinks
+}
+
+// ApplyConfig switches the running server to cfg, as returned by
+// config.Reload: the log level, CORS policy and rate limits change at once
+// and everything reading GetConfig sees cfg from then on.
+func (c *Container) ApplyConfig(cfg *config.Config) error {
+	level, err := cfg.Logging.SlogLevel()
+	if err != nil {
+		return err
+	}
+	proxies, err := cfg.RateLimit.TrustedProxyPrefixes()
+	if err != nil {
+		return err
+	}
+
+	c.configMu.Lock()
+	c.config = cfg
+	c.configMu.Unlock()
+
+	if c.logSinks != nil {
+		c.logSinks.SetLevel(level)
+	}
+	c.CORS().Update(corsConfig(cfg.CORS))
+	c.StatusRateLimit().SetLimit(cfg.RateLimit.StatusPerMinute)
+	c.StatusRateLimit().SetTrustedProxies(proxies)
+	return nil
+}
+
+// CORS returns the cross-origin policy applied to every HTTP route
+func (c *Container) CORS() *middleware.CORS {
+	c.corsOnce.Do(func() {
+		c.cors = middleware.NewCORS(corsConfig(c.GetConfig().CORS))
+	})
+	return c.cors
+}
+
+// StatusRateLimit returns the per-client limit on the public /status page
+func (c *Container) StatusRateLimit() *middleware.RateLimiter {
+	c.statusLimitOnce.Do(func() {
+		cfg := c.GetConfig().RateLimit
+		c.statusLimit = middleware.NewRateLimiter(cfg.StatusPerMinute, time.Minute)
+		// The config was validated on load, so this only fails for one
+		// built by hand; forwarded headers are then ignored
+		if proxies, err := cfg.TrustedProxyPrefixes(); err == nil {
+			c.statusLimit.SetTrustedProxies(proxies)
+		} else {
+			c.GetLogger().Warn("Ignoring rate_limit trusted_proxies", slog.Any("error", err))
+		}
+	})
+	return c.statusLimit
+}
+
+func corsConfig(cfg config.CORSConfig) middleware.CORSConfig {
+	return middleware.CORSConfig{
+		AllowOrigins:     cfg.AllowedOrigins,
+		AllowMethods:     cfg.AllowedMethods,
+		AllowHeaders:     cfg.AllowedHeaders,
+		ExposeHeaders:    cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	}
+}
+
 func (c *Container) GetLogger() *slog.Logger {
 	return c.logger
 }
 
 func (c *Container) GetAuthMiddleware() *middleware.AuthMiddleware {
-	return middleware.NewAuthMiddleware(c.AuthService, c.logger)
+	checkAccount := c.CheckAccount()
+	checkSession := c.CheckSession()
+	return middleware.NewAuthMiddleware(c.AuthService, func(ctx context.Context, user *entities.User) error {
+		return checkAccount.Execute(ctx, usecases.CheckAccountRequest{User: user})
+	}, func(ctx context.Context, user *entities.User, session middleware.SessionInfo) error {
+		return checkSession.Execute(ctx, usecases.CheckSessionRequest{
+			UserID:    user.ID(),
+			SessionID: session.ID,
+			UserAgent: session.UserAgent,
+			IPAddress: session.IPAddress,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}, c.logger)
+}
+
+// CheckAccount returns the process-wide account check, shared so that an
+// admin disabling an account clears the cached status the middleware reads.
+func (c *Container) CheckAccount() *usecases.CheckAccountUseCase {
+	c.checkAccountOnce.Do(func() {
+		c.checkAccount = usecases.NewCheckAccountUseCase(c.AccountStatusRepo)
+	})
+	return c.checkAccount
+}
+
+// CheckSession returns the process-wide session check, shared so that
+// signing out another device clears the cached state the middleware reads.
+func (c *Container) CheckSession() *usecases.CheckSessionUseCase {
+	c.checkSessionOnce.Do(func() {
+		c.checkSession = usecases.NewCheckSessionUseCase(c.SessionRepo)
+	})
+	return c.checkSession
+}
+
+// OAuthClients returns the process-wide OAuth client registrations, shared
+// so that startup loading and admin changes do not interleave.
+func (c *Container) OAuthClients() *usecases.OAuthClientUseCase {
+	c.oauthClientsOnce.Do(func() {
+		registry, _ := c.AuthService.(services.OAuthClientRegistry)
+		c.oauthClients = usecases.NewOAuthClientUseCase(c.OAuthClientRepo, registry)
+	})
+	return c.oauthClients
+}
+
+// Features returns the process-wide feature flags, shared so that an admin
+// changing a flag clears the cached flags every check reads. Flags in
+// app.toml are read from the current config, so a reload applies them.
+func (c *Container) Features() *usecases.FeatureFlagUseCase {
+	c.featuresOnce.Do(func() {
+		c.features = usecases.NewFeatureFlagUseCase(c.FeatureFlagRepo, func() []*entities.FeatureFlag {
+			configured := c.GetConfig().Features
+			flags := make([]*entities.FeatureFlag, 0, len(configured))
+			for name, feature := range configured {
+				flags = append(flags, entities.ReconstructFeatureFlag(entities.FeatureFlagProps{
+					Name:        name,
+					Description: feature.Description,
+					Enabled:     feature.Enabled,
+					Users:       feature.Users,
+					Groups:      feature.Groups,
+				}, time.Time{}))
+			}
+			return flags
+		})
+	})
+	return c.features
+}
+
+// GetMetrics returns the process-wide request counters, started on first use
+func (c *Container) GetMetrics() *metrics.Metrics {
+	c.metricsOnce.Do(func() {
+		c.metrics = metrics.New()
+	})
+	return c.metrics
+}
+
+// GetImportJobs returns the background import queue, starting its workers
+// on first use
+func (c *Container) GetImportJobs() *importjobs.Queue {
+	c.importJobsOnce.Do(func() {
+		cfg := c.GetConfig().Import
+		c.importJobs = importjobs.NewQueue(cfg.Workers, cfg.QueueSize, c.GetLogger())
+	})
+	return c.importJobs
+}
+
+// Events returns the object event bus, starting it and subscribing the
+// quantity adjustment history and the automation rules on first use
+func (c *Container) Events() *events.Bus {
+	c.eventsOnce.Do(func() {
+		c.events = events.NewBus(c.GetConfig().Automations.QueueSize, c.GetLogger())
+		adjustments := usecases.NewRecordAdjustmentsUseCase(c.AdjustmentRepo, c.GetLogger())
+		c.events.Subscribe(adjustments.Handle)
+		automations := usecases.NewRunAutomationsUseCase(c.AutomationRuleRepo, c.ContainerRepo, c.ObjectMoveRepo, c.RecurrenceRuleRepo, c.WebhookDeliveryRepo, c.WebhookKeyRepo, c.WebhookSender, c.GetLogger())
+		c.events.Subscribe(automations.Handle)
+	})
+	return c.events
 }
 
 // SetLogger sets the logger (primarily for testing purposes)
@@ -177,5 +460,13 @@ func (c *Container) SetLogger(logger *slog.Logger) {
 
 // SetConfig sets the config (primarily for testing purposes)
 func (c *Container) SetConfig(cfg *config.Config) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
 	c.config = cfg
 }
+
+// SetInMemory marks the repositories as in-memory, so there is no database
+// to check or disconnect (used by demo mode)
+func (c *Container) SetInMemory() {
+	c.inMemory = true
+}
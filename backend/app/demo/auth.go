@@ -0,0 +1,112 @@
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nishiki/backend/app/mcp/testkit"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// Token is the access token handed out by the demo login. Any bearer token
+// is accepted, so clients may also send their own.
+const Token = "demo"
+
+// tokenLifetime is how long the frontend treats a demo token as valid
+// before refreshing it.
+const tokenLifetime = 24 * time.Hour
+
+// AuthService signs every request in as the demo user, whatever bearer
+// token it carries. Groups are kept in memory by the embedded fake, the
+// same one the MCP test kit uses.
+type AuthService struct {
+	*testkit.FakeAuthService
+	user *entities.User
+}
+
+func NewAuthService(user *entities.User) *AuthService {
+	fake := testkit.NewFakeAuthService()
+	fake.AddUser(user, Token)
+	return &AuthService{FakeAuthService: fake, user: user}
+}
+
+// IssuerURL is empty so MCP clients are not sent to an OAuth issuer.
+func (s *AuthService) IssuerURL() string {
+	return ""
+}
+
+// ValidateToken accepts any token. The claims are issued now, so actions
+// behind RequireRecentAuth work as well.
+func (s *AuthService) ValidateToken(_ context.Context, _ string) (*services.AuthClaims, error) {
+	now := time.Now()
+	return &services.AuthClaims{
+		Subject:   s.user.ID().String(),
+		Email:     s.user.EmailAddress().String(),
+		Username:  s.user.Username().String(),
+		Name:      s.user.Username().String(),
+		ExpiresAt: now.Add(tokenLifetime).Unix(),
+		IssuedAt:  now.Unix(),
+		AuthTime:  now.Unix(),
+		Issuer:    "nishiki-demo",
+		// Every request is a fresh sign-in, but all of them one session
+		SID: "demo",
+	}, nil
+}
+
+// ProxyTokenExchange answers every authorization code and refresh token
+// grant with the demo token.
+func (s *AuthService) ProxyTokenExchange(context.Context, map[string]any) ([]byte, int, error) {
+	body, err := json.Marshal(map[string]any{
+		"access_token":  Token,
+		"refresh_token": Token,
+		"token_type":    "Bearer",
+		"expires_in":    int(tokenLifetime.Seconds()),
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return body, http.StatusOK, nil
+}
+
+// WithLogin serves the two Authentik pages the frontend sends the browser
+// to, so a frontend with auth_url pointing at this backend can sign in and
+// out without Authentik. Authorization redirects straight back with a code
+// that /auth/token exchanges for the demo token. Everything else goes to
+// next.
+func WithLogin(next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /application/o/authorize/{$}", authorize)
+	mux.HandleFunc("GET /application/o/{provider}/end-session/", endSession)
+	mux.Handle("/", next)
+	return mux
+}
+
+func authorize(w http.ResponseWriter, r *http.Request) {
+	redirect, err := url.Parse(r.URL.Query().Get("redirect_uri"))
+	if err != nil || (redirect.Scheme != "http" && redirect.Scheme != "https") || redirect.Host == "" {
+		http.Error(w, "redirect_uri must be an absolute http(s) URL", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", Token)
+	if state := r.URL.Query().Get("state"); state != "" {
+		query.Set("state", state)
+	}
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func endSession(w http.ResponseWriter, r *http.Request) {
+	// Send the browser back to the app it came from when we know it
+	if referer, err := url.Parse(r.Referer()); err == nil && referer.Host != "" && referer.Host != r.Host {
+		http.Redirect(w, r, referer.Scheme+"://"+referer.Host+"/", http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = io.WriteString(w, "<!doctype html><title>Signed out</title><p>Signed out of the Nishiki demo. You can close this tab.</p>")
+}
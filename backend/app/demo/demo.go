@@ -0,0 +1,41 @@
+// Package demo boots the backend without MongoDB or Authentik: repositories
+// are in memory and seeded with sample collections, and every request is
+// signed in as a single demo user. It backs the --demo flag, for trying
+// Nishiki out and for running the frontend locally. Nothing is persisted.
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/mcp/testkit"
+	extServices "github.com/nishiki/backend/external/services"
+)
+
+// Username is the demo user every request is signed in as.
+const Username = "demo"
+
+// NewContainer builds a container on in-memory repositories seeded with the
+// sample data. Image search and nutrition lookups stay offline and uploaded
+// photos are discarded; PDF reports render as usual.
+func NewContainer(ctx context.Context, cfg *config.Config) (*container.Container, error) {
+	user, err := newUser(Username, "demo@nishiki.local")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create demo user: %w", err)
+	}
+	auth := NewAuthService(user)
+
+	c := testkit.NewMemoryContainer(cfg, auth)
+	if err := c.SetupLogging(cfg.Logging); err != nil {
+		return nil, fmt.Errorf("failed to setup logger: %w", err)
+	}
+	c.ReportRenderer = extServices.NewPDFReportRenderer(cfg.Images, c.GetLogger())
+	c.LabelRenderer = extServices.NewLabelRenderer()
+
+	if err := Seed(ctx, c, auth, user); err != nil {
+		return nil, fmt.Errorf("failed to seed demo data: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package demo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestNewContainer_SeedsSampleCollections(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewContainer(ctx, &config.Config{})
+	require.NoError(t, err)
+	require.NoError(t, c.CheckDatabase(ctx))
+
+	claims, err := c.AuthService.ValidateToken(ctx, "any token at all")
+	require.NoError(t, err)
+	user, err := c.AuthService.GetUserFromClaims(ctx, claims)
+	require.NoError(t, err)
+	assert.Equal(t, Username, user.Username().String())
+	assert.NotZero(t, claims.AuthTime)
+
+	collections, err := c.CollectionRepo.List(ctx, 0, 0)
+	require.NoError(t, err)
+	types := make(map[string]entities.ObjectType)
+	for _, collection := range collections {
+		full, err := c.CollectionRepo.GetByID(ctx, collection.ID())
+		require.NoError(t, err)
+		assert.NotEmpty(t, full.Containers(), "collection %s has no containers", full.Name())
+		types[full.Name().String()] = full.ObjectType()
+		if full.Name().String() == "Pantry" {
+			assert.True(t, full.IsGroupOwned())
+		}
+	}
+	assert.Equal(t, map[string]entities.ObjectType{
+		"Pantry":       entities.ObjectTypeFood,
+		"Bookshelf":    entities.ObjectTypeBook,
+		"Game library": entities.ObjectTypeVideoGame,
+	}, types)
+}
+
+func TestWithLogin(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+	h := WithLogin(next)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/application/o/authorize/?response_type=code&redirect_uri="+url.QueryEscape("http://localhost:8080/auth/callback")+"&state=xyz", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "http://localhost:8080/auth/callback?code=demo&state=xyz", rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/application/o/authorize/?redirect_uri=javascript:alert(1)", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/application/o/nishiki/end-session/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+}
@@ -0,0 +1,304 @@
+package demo
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// sampleObject is one seeded item. expiresIn counts days from today; zero
+// means the item does not expire.
+type sampleObject struct {
+	name       string
+	objectType entities.ObjectType
+	quantity   float64
+	unit       string
+	minimum    float64
+	condition  entities.Condition
+	status     entities.ObjectStatus
+	expiresIn  int
+	tags       []string
+	properties map[string]entities.TypedValue
+}
+
+type sampleContainer struct {
+	name          string
+	containerType entities.ContainerType
+	location      string
+	objects       []sampleObject
+}
+
+type sampleCollection struct {
+	name       string
+	objectType entities.ObjectType
+	location   string
+	tags       []string
+	groupOwned bool
+	schema     []entities.PropertyDefinition
+	containers []sampleContainer
+}
+
+func text(s string) entities.TypedValue {
+	return entities.NewTypedValue(entities.PropertyTypeText, s)
+}
+
+func grouped(s string) entities.TypedValue {
+	return entities.NewTypedValue(entities.PropertyTypeGroupedText, s)
+}
+
+func number(f float64) entities.TypedValue {
+	return entities.NewTypedValue(entities.PropertyTypeNumeric, f)
+}
+
+// nutrition is the per-serving block food objects carry.
+func nutrition(calories, protein, carbs, fat float64, servingSize string, servings float64) map[string]entities.TypedValue {
+	return map[string]entities.TypedValue{
+		entities.PropertyCalories:    number(calories),
+		entities.PropertyProtein:     number(protein),
+		entities.PropertyCarbs:       number(carbs),
+		entities.PropertyFat:         number(fat),
+		entities.PropertyServingSize: text(servingSize),
+		entities.PropertyServings:    number(servings),
+	}
+}
+
+func book(author, isbn string, year float64, genre string) map[string]entities.TypedValue {
+	return map[string]entities.TypedValue{
+		"author": grouped(author),
+		"isbn":   text(isbn),
+		"year":   number(year),
+		"genre":  grouped(genre),
+	}
+}
+
+func game(platform, players, genre string) map[string]entities.TypedValue {
+	return map[string]entities.TypedValue{
+		"platform": grouped(platform),
+		"players":  text(players),
+		"genre":    grouped(genre),
+	}
+}
+
+var sampleCollections = []sampleCollection{
+	{
+		name:       "Pantry",
+		objectType: entities.ObjectTypeFood,
+		location:   "Kitchen",
+		tags:       []string{"groceries"},
+		groupOwned: true,
+		containers: []sampleContainer{
+			{name: "Fridge", containerType: entities.ContainerTypeCabinet, location: "Kitchen", objects: []sampleObject{
+				{name: "Whole milk", quantity: 1, unit: "l", minimum: 1, expiresIn: 4, tags: []string{"dairy"},
+					properties: nutrition(150, 8, 12, 8, "250 ml", 4)},
+				{name: "Greek yogurt", quantity: 3, unit: "cups", expiresIn: 9, tags: []string{"dairy"},
+					properties: nutrition(100, 17, 6, 0.7, "170 g", 1)},
+				{name: "Eggs", quantity: 8, unit: "pcs", minimum: 6, expiresIn: 18,
+					properties: nutrition(72, 6.3, 0.4, 4.8, "1 egg", 1)},
+				{name: "Baby spinach", quantity: 1, unit: "bag", expiresIn: 2, tags: []string{"produce"},
+					properties: nutrition(7, 0.9, 1.1, 0.1, "30 g", 5)},
+				{name: "Cheddar", quantity: 200, unit: "g", expiresIn: 30, tags: []string{"dairy"},
+					properties: nutrition(113, 7, 0.4, 9.3, "28 g", 0.036)},
+			}},
+			{name: "Pantry shelf", containerType: entities.ContainerTypeShelf, location: "Kitchen", objects: []sampleObject{
+				{name: "Basmati rice", quantity: 2, unit: "kg", minimum: 1, expiresIn: 540, tags: []string{"grains"},
+					properties: nutrition(160, 3.5, 36, 0.4, "45 g", 22)},
+				{name: "Spaghetti", quantity: 3, unit: "boxes", minimum: 2, expiresIn: 400, tags: []string{"grains"},
+					properties: nutrition(200, 7, 42, 1, "56 g", 8)},
+				{name: "Black beans", quantity: 4, unit: "cans", minimum: 2, expiresIn: 720, tags: []string{"canned"},
+					properties: nutrition(110, 7, 20, 0.5, "130 g", 3.5)},
+				{name: "Crushed tomatoes", quantity: 1, unit: "cans", minimum: 2, expiresIn: 600, tags: []string{"canned"},
+					properties: nutrition(35, 1.5, 7, 0, "121 g", 3.5)},
+				{name: "Rolled oats", quantity: 1, unit: "kg", expiresIn: 300, tags: []string{"breakfast", "grains"},
+					properties: nutrition(150, 5, 27, 3, "40 g", 25)},
+				{name: "Olive oil", quantity: 0.5, unit: "l", minimum: 0.25, tags: []string{"oils"},
+					properties: nutrition(120, 0, 0, 14, "15 ml", 67)},
+			}},
+			{name: "Freezer", containerType: entities.ContainerTypeCabinet, location: "Garage", objects: []sampleObject{
+				{name: "Frozen peas", quantity: 2, unit: "bags", expiresIn: 240, tags: []string{"produce"},
+					properties: nutrition(62, 4, 11, 0.2, "89 g", 5)},
+				{name: "Chicken thighs", quantity: 1.2, unit: "kg", expiresIn: 120, tags: []string{"meat"},
+					properties: nutrition(210, 26, 0, 11, "113 g", 8.8)},
+			}},
+		},
+	},
+	{
+		name:       "Bookshelf",
+		objectType: entities.ObjectTypeBook,
+		location:   "Living room",
+		schema: []entities.PropertyDefinition{
+			{Key: "author", DisplayName: "Author", Type: entities.PropertyTypeGroupedText},
+			{Key: "isbn", DisplayName: "ISBN", Type: entities.PropertyTypeText},
+			{Key: "year", DisplayName: "Year", Type: entities.PropertyTypeNumeric},
+			{Key: "genre", DisplayName: "Genre", Type: entities.PropertyTypeGroupedText},
+		},
+		containers: []sampleContainer{
+			{name: "Living room bookcase", containerType: entities.ContainerTypeBookshelf, location: "Living room", objects: []sampleObject{
+				{name: "The Left Hand of Darkness", quantity: 1, condition: entities.ConditionFair, properties: book("Ursula K. Le Guin", "9780441478125", 1969, "Science fiction")},
+				{name: "A Wizard of Earthsea", quantity: 1, properties: book("Ursula K. Le Guin", "9780547773742", 1968, "Fantasy")},
+				{name: "Dune", quantity: 1, properties: book("Frank Herbert", "9780441172719", 1965, "Science fiction")},
+				{name: "Piranesi", quantity: 1, condition: entities.ConditionMint, properties: book("Susanna Clarke", "9781635575637", 2020, "Fantasy")},
+				{name: "The Remains of the Day", quantity: 1, properties: book("Kazuo Ishiguro", "9780679731726", 1989, "Literary fiction")},
+				{name: "The Dispossessed", status: entities.ObjectStatusWanted, properties: book("Ursula K. Le Guin", "9780061054884", 1974, "Science fiction")},
+			}},
+			{name: "Bedside stack", containerType: entities.ContainerTypeShelf, location: "Bedroom", objects: []sampleObject{
+				{name: "Project Hail Mary", quantity: 1, tags: []string{"reading"}, properties: book("Andy Weir", "9780593135204", 2021, "Science fiction")},
+				{name: "Salt, Fat, Acid, Heat", quantity: 1, properties: book("Samin Nosrat", "9781476753836", 2017, "Cooking")},
+			}},
+		},
+	},
+	{
+		name:       "Game library",
+		objectType: entities.ObjectTypeVideoGame,
+		location:   "Den",
+		schema: []entities.PropertyDefinition{
+			{Key: "platform", DisplayName: "Platform", Type: entities.PropertyTypeGroupedText},
+			{Key: "players", DisplayName: "Players", Type: entities.PropertyTypeText},
+			{Key: "genre", DisplayName: "Genre", Type: entities.PropertyTypeGroupedText},
+		},
+		containers: []sampleContainer{
+			{name: "TV console", containerType: entities.ContainerTypeCabinet, location: "Den", objects: []sampleObject{
+				{name: "The Legend of Zelda: Tears of the Kingdom", quantity: 1, properties: game("Switch", "1", "Adventure")},
+				{name: "Mario Kart 8 Deluxe", quantity: 1, tags: []string{"party"}, properties: game("Switch", "1-4", "Racing")},
+				{name: "Stardew Valley", quantity: 1, properties: game("Switch", "1-4", "Simulation")},
+				{name: "Hades", quantity: 1, condition: entities.ConditionNearMint, properties: game("PC", "1", "Roguelike")},
+				{name: "Overcooked! 2", quantity: 1, tags: []string{"party"}, properties: game("Switch", "1-4", "Party")},
+			}},
+			{name: "Board game shelf", containerType: entities.ContainerTypeShelf, location: "Den", objects: []sampleObject{
+				{name: "Wingspan", objectType: entities.ObjectTypeBoardGame, quantity: 1, condition: entities.ConditionGood, properties: game("Tabletop", "1-5", "Engine building")},
+				{name: "Codenames", objectType: entities.ObjectTypeBoardGame, quantity: 1, tags: []string{"party"}, properties: game("Tabletop", "2-8", "Party")},
+				{name: "Ticket to Ride", objectType: entities.ObjectTypeBoardGame, quantity: 1, properties: game("Tabletop", "2-5", "Route building")},
+				{name: "Cascadia", objectType: entities.ObjectTypeBoardGame, status: entities.ObjectStatusOrdered, properties: game("Tabletop", "1-4", "Tile laying")},
+			}},
+		},
+	},
+}
+
+// Seed fills the container's repositories with the sample collections, a
+// household group the demo user belongs to, and a meal plan for tonight.
+// The pantry is owned by the group so the shared views have something to
+// show.
+func Seed(ctx context.Context, c *container.Container, auth *AuthService, user *entities.User) error {
+	group, err := auth.CreateGroup(ctx, Token, "Household", user.ID().String())
+	if err != nil {
+		return err
+	}
+	groupID := group.ID()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	objectsByName := make(map[string]entities.Object)
+	for _, sample := range sampleCollections {
+		name, err := entities.NewCollectionName(sample.name)
+		if err != nil {
+			return err
+		}
+		props := entities.CollectionProps{
+			UserID:     user.ID(),
+			Name:       name,
+			ObjectType: sample.objectType,
+			Location:   sample.location,
+			Tags:       sample.tags,
+		}
+		if sample.groupOwned {
+			props.GroupID = &groupID
+			props.GroupOwned = true
+		}
+		if sample.schema != nil {
+			props.PropertySchema = &entities.PropertySchema{Definitions: sample.schema}
+		}
+		collection, err := entities.NewCollection(props)
+		if err != nil {
+			return err
+		}
+
+		for _, sc := range sample.containers {
+			containerName, err := entities.NewContainerName(sc.name)
+			if err != nil {
+				return err
+			}
+			cont, err := entities.NewContainer(entities.ContainerProps{
+				CollectionID:  collection.ID(),
+				Name:          containerName,
+				ContainerType: sc.containerType,
+				Location:      sc.location,
+			})
+			if err != nil {
+				return err
+			}
+			for _, so := range sc.objects {
+				obj, err := newObject(so, sample.objectType, today)
+				if err != nil {
+					return err
+				}
+				if err := cont.AddObject(*obj); err != nil {
+					return err
+				}
+				objectsByName[so.name] = *obj
+			}
+			if err := c.ContainerRepo.Create(ctx, cont); err != nil {
+				return err
+			}
+			if err := collection.AddContainer(*cont); err != nil {
+				return err
+			}
+		}
+		if err := c.CollectionRepo.Create(ctx, collection); err != nil {
+			return err
+		}
+	}
+
+	rice, beans := objectsByName["Basmati rice"], objectsByName["Black beans"]
+	plan, err := entities.NewMealPlan(user.ID(), today, entities.MealTypeDinner, "Rice and beans", []entities.MealIngredient{
+		{ObjectID: rice.ID(), Name: "Basmati rice", Quantity: 0.3, Unit: "kg"},
+		{ObjectID: beans.ID(), Name: "Black beans", Quantity: 2, Unit: "cans"},
+	})
+	if err != nil {
+		return err
+	}
+	return c.MealPlanRepo.Create(ctx, plan)
+}
+
+func newObject(sample sampleObject, defaultType entities.ObjectType, today time.Time) (*entities.Object, error) {
+	name, err := entities.NewObjectName(sample.name)
+	if err != nil {
+		return nil, err
+	}
+	props := entities.ObjectProps{
+		Name:       name,
+		ObjectType: sample.objectType,
+		Unit:       sample.unit,
+		Condition:  sample.condition,
+		Status:     sample.status,
+		Tags:       sample.tags,
+		Properties: sample.properties,
+	}
+	if props.ObjectType == "" {
+		props.ObjectType = defaultType
+	}
+	if sample.quantity > 0 {
+		quantity := sample.quantity
+		props.Quantity = &quantity
+	}
+	if sample.minimum > 0 {
+		minimum := sample.minimum
+		props.MinQuantity = &minimum
+	}
+	if sample.expiresIn > 0 {
+		expires := today.AddDate(0, 0, sample.expiresIn)
+		props.ExpiresAt = &expires
+	}
+	return entities.NewObject(props)
+}
+
+func newUser(username, email string) (*entities.User, error) {
+	name, err := entities.NewUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	address, err := entities.NewEmailAddress(email)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewUser(entities.UserProps{Username: name, EmailAddress: address})
+}
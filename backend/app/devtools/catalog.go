@@ -0,0 +1,161 @@
+package devtools
+
+import (
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// item is one kind of thing a fixture object can be. shelfLife is in days
+// and only set for food; detail fills in the type's usual properties.
+type item struct {
+	name      string
+	unit      string
+	category  string
+	shelfLife int
+	detail    map[string]string
+}
+
+func food(name, unit, category string, shelfLife int) item {
+	return item{name: name, unit: unit, category: category, shelfLife: shelfLife}
+}
+
+func titled(name string, detail map[string]string) item {
+	return item{name: name, detail: detail}
+}
+
+// catalog lists what each object type's fixtures are drawn from, with the
+// collection and container names that suit them. Anything not listed uses
+// the general catalog.
+type catalog struct {
+	collection string
+	containers []string
+	// containerType is what the fixture containers are created as
+	containerType entities.ContainerType
+	schema        []entities.PropertyDefinition
+	items         []item
+}
+
+var catalogs = map[entities.ObjectType]catalog{
+	entities.ObjectTypeFood: {
+		collection:    "Pantry",
+		containers:    []string{"Fridge", "Freezer", "Pantry shelf", "Spice rack", "Cellar"},
+		containerType: entities.ContainerTypeCabinet,
+		schema: []entities.PropertyDefinition{
+			{Key: entities.ShelfLifeCategoryProperty, DisplayName: "Category", Type: entities.PropertyTypeGroupedText},
+		},
+		items: []item{
+			food("Whole milk", "l", "dairy", 7),
+			food("Greek yogurt", "cups", "dairy", 14),
+			food("Cheddar", "g", "dairy", 30),
+			food("Butter", "g", "dairy", 60),
+			food("Eggs", "pcs", "dairy", 21),
+			food("Baby spinach", "bags", "produce", 5),
+			food("Carrots", "kg", "produce", 21),
+			food("Apples", "pcs", "produce", 30),
+			food("Chicken thighs", "kg", "meat", 3),
+			food("Ground beef", "kg", "meat", 2),
+			food("Frozen peas", "bags", "frozen", 240),
+			food("Vanilla ice cream", "tubs", "frozen", 180),
+			food("Sourdough loaf", "pcs", "bread", 4),
+			food("Basmati rice", "kg", "grains", 540),
+			food("Spaghetti", "boxes", "grains", 400),
+			food("Rolled oats", "kg", "grains", 300),
+			food("Black beans", "cans", "canned", 720),
+			food("Crushed tomatoes", "cans", "canned", 600),
+			food("Olive oil", "l", "oils", 540),
+			food("Ground cumin", "jars", "spices", 900),
+		},
+	},
+	entities.ObjectTypeBook: {
+		collection:    "Bookshelf",
+		containers:    []string{"Living room bookcase", "Bedside stack", "Study shelf", "Attic box"},
+		containerType: entities.ContainerTypeBookshelf,
+		schema: []entities.PropertyDefinition{
+			{Key: "author", DisplayName: "Author", Type: entities.PropertyTypeGroupedText},
+		},
+		items: []item{
+			titled("The Left Hand of Darkness", map[string]string{"author": "Ursula K. Le Guin"}),
+			titled("A Wizard of Earthsea", map[string]string{"author": "Ursula K. Le Guin"}),
+			titled("Dune", map[string]string{"author": "Frank Herbert"}),
+			titled("Piranesi", map[string]string{"author": "Susanna Clarke"}),
+			titled("The Remains of the Day", map[string]string{"author": "Kazuo Ishiguro"}),
+			titled("Project Hail Mary", map[string]string{"author": "Andy Weir"}),
+			titled("Salt, Fat, Acid, Heat", map[string]string{"author": "Samin Nosrat"}),
+			titled("Middlemarch", map[string]string{"author": "George Eliot"}),
+			titled("Beloved", map[string]string{"author": "Toni Morrison"}),
+			titled("The Name of the Rose", map[string]string{"author": "Umberto Eco"}),
+		},
+	},
+	entities.ObjectTypeVideoGame: {
+		collection:    "Game library",
+		containers:    []string{"TV console", "Handheld case", "Storage drawer"},
+		containerType: entities.ContainerTypeCabinet,
+		schema: []entities.PropertyDefinition{
+			{Key: "platform", DisplayName: "Platform", Type: entities.PropertyTypeGroupedText},
+		},
+		items: []item{
+			titled("Mario Kart 8 Deluxe", map[string]string{"platform": "Switch"}),
+			titled("Stardew Valley", map[string]string{"platform": "Switch"}),
+			titled("Hades", map[string]string{"platform": "PC"}),
+			titled("Celeste", map[string]string{"platform": "PC"}),
+			titled("Elden Ring", map[string]string{"platform": "PS5"}),
+			titled("Overcooked! 2", map[string]string{"platform": "Switch"}),
+			titled("Outer Wilds", map[string]string{"platform": "PC"}),
+			titled("Tetris Effect", map[string]string{"platform": "PS5"}),
+		},
+	},
+	entities.ObjectTypeMusic: {
+		collection:    "Record collection",
+		containers:    []string{"Vinyl crate", "CD tower", "Listening shelf"},
+		containerType: entities.ContainerTypeShelf,
+		schema: []entities.PropertyDefinition{
+			{Key: "artist", DisplayName: "Artist", Type: entities.PropertyTypeGroupedText},
+		},
+		items: []item{
+			titled("Kind of Blue", map[string]string{"artist": "Miles Davis"}),
+			titled("Blue", map[string]string{"artist": "Joni Mitchell"}),
+			titled("Remain in Light", map[string]string{"artist": "Talking Heads"}),
+			titled("Homogenic", map[string]string{"artist": "Björk"}),
+			titled("Rumours", map[string]string{"artist": "Fleetwood Mac"}),
+			titled("In Rainbows", map[string]string{"artist": "Radiohead"}),
+		},
+	},
+	entities.ObjectTypeBoardGame: {
+		collection:    "Board games",
+		containers:    []string{"Game shelf", "Closet", "Travel bag"},
+		containerType: entities.ContainerTypeShelf,
+		schema: []entities.PropertyDefinition{
+			{Key: "players", DisplayName: "Players", Type: entities.PropertyTypeText},
+		},
+		items: []item{
+			titled("Wingspan", map[string]string{"players": "1-5"}),
+			titled("Codenames", map[string]string{"players": "2-8"}),
+			titled("Ticket to Ride", map[string]string{"players": "2-5"}),
+			titled("Cascadia", map[string]string{"players": "1-4"}),
+			titled("Azul", map[string]string{"players": "2-4"}),
+			titled("Carcassonne", map[string]string{"players": "2-5"}),
+		},
+	},
+	entities.ObjectTypeGeneral: {
+		collection:    "Storage",
+		containers:    []string{"Garage shelf", "Hall closet", "Toolbox", "Under the stairs"},
+		containerType: entities.ContainerTypeGeneral,
+		items: []item{
+			{name: "Cordless drill", unit: "pcs"},
+			{name: "Extension cord", unit: "pcs"},
+			{name: "AA batteries", unit: "pcs"},
+			{name: "Light bulbs", unit: "pcs"},
+			{name: "Duct tape", unit: "rolls"},
+			{name: "Picture hooks", unit: "boxes"},
+			{name: "Camping lantern", unit: "pcs"},
+			{name: "Bike pump", unit: "pcs"},
+		},
+	},
+}
+
+// catalogFor returns the catalog of an object type, or the general one
+func catalogFor(objectType entities.ObjectType) catalog {
+	if c, ok := catalogs[objectType]; ok {
+		return c
+	}
+	return catalogs[entities.ObjectTypeGeneral]
+}
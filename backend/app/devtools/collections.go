@@ -0,0 +1,142 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// CollectionSpec describes a fixture collection. Zero values pick the
+// defaults: a food collection named after its type ("Pantry", then
+// "Pantry 2"), with one container holding no objects.
+type CollectionSpec struct {
+	Name       string
+	ObjectType entities.ObjectType
+	Containers int
+	// Objects is how many objects each container holds
+	Objects int
+	// GroupID shares the collection with a group, which then owns it
+	GroupID *entities.GroupID
+}
+
+// Collection creates a collection owned by owner, filled per spec with
+// objects drawn from the object type's catalog. Food gets a category and
+// an expiry date as if bought some time within its shelf life, so a few
+// items are close to or past their date.
+func (s *Seeder) Collection(ctx context.Context, owner entities.UserID, spec CollectionSpec) (*entities.Collection, error) {
+	objectType := spec.ObjectType
+	if objectType == "" {
+		objectType = entities.ObjectTypeFood
+	}
+	if !objectType.IsBuiltIn() {
+		return nil, fmt.Errorf("%w: %s", entities.ErrUnknownObjectType, objectType)
+	}
+	cat := catalogFor(objectType)
+
+	nameText := spec.Name
+	if nameText == "" {
+		s.named[cat.collection]++
+		nameText = cat.collection
+		if n := s.named[cat.collection]; n > 1 {
+			nameText = fmt.Sprintf("%s %d", nameText, n)
+		}
+	}
+	name, err := entities.NewCollectionName(nameText)
+	if err != nil {
+		return nil, err
+	}
+	props := entities.CollectionProps{
+		UserID:     owner,
+		Name:       name,
+		ObjectType: objectType,
+		GroupID:    spec.GroupID,
+		GroupOwned: spec.GroupID != nil,
+	}
+	if cat.schema != nil {
+		props.PropertySchema = &entities.PropertySchema{Definitions: cat.schema}
+	}
+	collection, err := entities.NewCollection(props)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range max(spec.Containers, 1) {
+		cont, err := s.container(collection.ID(), cat, i, objectType, spec.Objects)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.c.ContainerRepo.Create(ctx, cont); err != nil {
+			return nil, fmt.Errorf("failed to create container: %w", err)
+		}
+		if err := collection.AddContainer(*cont); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.c.CollectionRepo.Create(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	return collection, nil
+}
+
+// container builds the i-th container of a collection with its objects.
+// Names run through the catalog's, then repeat with a number.
+func (s *Seeder) container(collectionID entities.CollectionID, cat catalog, i int, objectType entities.ObjectType, objects int) (*entities.Container, error) {
+	nameText := cat.containers[i%len(cat.containers)]
+	if round := i / len(cat.containers); round > 0 {
+		nameText = fmt.Sprintf("%s %d", nameText, round+1)
+	}
+	name, err := entities.NewContainerName(nameText)
+	if err != nil {
+		return nil, err
+	}
+	cont, err := entities.NewContainer(entities.ContainerProps{
+		CollectionID:  collectionID,
+		Name:          name,
+		ContainerType: cat.containerType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for range objects {
+		obj, err := s.object(cat.items[s.rand.IntN(len(cat.items))], objectType)
+		if err != nil {
+			return nil, err
+		}
+		if err := cont.AddObject(*obj); err != nil {
+			return nil, err
+		}
+	}
+	return cont, nil
+}
+
+func (s *Seeder) object(it item, objectType entities.ObjectType) (*entities.Object, error) {
+	name, err := entities.NewObjectName(it.name)
+	if err != nil {
+		return nil, err
+	}
+	quantity := float64(1 + s.rand.IntN(5))
+	props := entities.ObjectProps{
+		Name:       name,
+		ObjectType: objectType,
+		Quantity:   &quantity,
+		Unit:       it.unit,
+		Properties: make(map[string]entities.TypedValue),
+	}
+	for key, value := range it.detail {
+		props.Properties[key] = entities.NewTypedValue(entities.PropertyTypeGroupedText, value)
+	}
+	if it.category != "" {
+		props.Properties[entities.ShelfLifeCategoryProperty] = entities.NewTypedValue(entities.PropertyTypeGroupedText, it.category)
+		props.Tags = []string{it.category}
+	}
+	if it.shelfLife > 0 {
+		expires := s.now.AddDate(0, 0, it.shelfLife-s.rand.IntN(it.shelfLife+3))
+		props.ExpiresAt = &expires
+		minimum := 1.0
+		props.MinQuantity = &minimum
+	}
+	return entities.NewObject(props)
+}
@@ -0,0 +1,116 @@
+package devtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/mcp/testkit"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestSeeder_Collection(t *testing.T) {
+	ctx := context.Background()
+	c := testkit.NewMemoryContainer(&config.Config{}, testkit.NewFakeAuthService())
+	owner, err := NewUser("dev-owner")
+	require.NoError(t, err)
+	s := NewSeeder(c, 1)
+
+	first, err := s.Collection(ctx, owner.ID(), CollectionSpec{Containers: 6, Objects: 4})
+	require.NoError(t, err)
+	second, err := s.Collection(ctx, owner.ID(), CollectionSpec{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Pantry", first.Name().String())
+	assert.Equal(t, "Pantry 2", second.Name().String())
+	assert.Equal(t, entities.ObjectTypeFood, first.ObjectType())
+	require.Len(t, first.Containers(), 6)
+	assert.Len(t, second.Containers(), 1, "at least one container")
+
+	// Container names repeat with a number once the catalog runs out
+	names := make(map[string]bool)
+	for _, cont := range first.Containers() {
+		assert.False(t, names[cont.Name().String()], "duplicate container %s", cont.Name())
+		names[cont.Name().String()] = true
+		assert.Len(t, cont.Objects(), 4)
+		for _, obj := range cont.Objects() {
+			assert.NotNil(t, obj.ExpiresAt(), "food expires")
+			assert.NotEmpty(t, obj.Tags(), "food has a category tag")
+		}
+	}
+
+	stored, err := c.CollectionRepo.GetByID(ctx, first.ID())
+	require.NoError(t, err)
+	assert.Len(t, stored.Containers(), 6)
+	stored2, err := c.ContainerRepo.GetByID(ctx, first.Containers()[0].ID())
+	require.NoError(t, err)
+	assert.Len(t, stored2.Objects(), 4)
+}
+
+func TestSeeder_CollectionTypes(t *testing.T) {
+	ctx := context.Background()
+	c := testkit.NewMemoryContainer(&config.Config{}, testkit.NewFakeAuthService())
+	owner := entities.NewUserID()
+	s := NewSeeder(c, 1)
+
+	books, err := s.Collection(ctx, owner, CollectionSpec{Name: "Study", ObjectType: entities.ObjectTypeBook, Objects: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "Study", books.Name().String())
+	for _, obj := range books.Containers()[0].Objects() {
+		assert.Nil(t, obj.ExpiresAt(), "books do not expire")
+	}
+
+	_, err = s.Collection(ctx, owner, CollectionSpec{ObjectType: "spaceship"})
+	assert.ErrorIs(t, err, entities.ErrUnknownObjectType)
+}
+
+func TestSeeder_SameSeedSameData(t *testing.T) {
+	ctx := context.Background()
+	objectNames := func() []string {
+		c := testkit.NewMemoryContainer(&config.Config{}, testkit.NewFakeAuthService())
+		collection, err := NewSeeder(c, 42).Collection(ctx, entities.NewUserID(), CollectionSpec{Containers: 2, Objects: 5})
+		require.NoError(t, err)
+		var names []string
+		for _, cont := range collection.Containers() {
+			for _, obj := range cont.Objects() {
+				names = append(names, obj.Name().String())
+			}
+		}
+		return names
+	}
+
+	assert.Equal(t, objectNames(), objectNames())
+}
+
+func TestSeeder_Group(t *testing.T) {
+	ctx := context.Background()
+	auth := testkit.NewFakeAuthService()
+	c := testkit.NewMemoryContainer(&config.Config{}, auth)
+	owner, err := NewUser("dev-owner")
+	require.NoError(t, err)
+	member, err := NewUser("dev-member")
+	require.NoError(t, err)
+	auth.AddUser(owner, "owner-token")
+	auth.AddUser(member, "member-token")
+	s := NewSeeder(c, 1)
+
+	group, err := s.Group(ctx, "owner-token", "Household", owner, member)
+	require.NoError(t, err)
+	groups, err := auth.GetUserGroups(ctx, "member-token", member.ID().String())
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, group.ID(), groups[0].ID())
+
+	groupID := group.ID()
+	collection, err := s.Collection(ctx, owner.ID(), CollectionSpec{GroupID: &groupID})
+	require.NoError(t, err)
+	assert.True(t, collection.IsGroupOwned())
+
+	stranger, err := NewUser("dev-stranger")
+	require.NoError(t, err)
+	_, err = s.Group(ctx, "owner-token", "Other", owner, stranger)
+	assert.Error(t, err, "members must exist in the auth service")
+}
@@ -0,0 +1,87 @@
+// Package events hands object events to the parts of the app that react to
+// them, such as automation rules, off the request that raised them.
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// defaultQueueSize is used by NewBus when the configuration leaves the size
+// unset.
+const defaultQueueSize = 256
+
+// Handler reacts to one event. It runs on the bus's goroutine, so a slow
+// handler holds up the events behind it.
+type Handler func(ctx context.Context, event entities.ObjectEvent)
+
+// Bus delivers events to its handlers one at a time, in the order they were
+// published, so a handler sees an object's changes in sequence.
+type Bus struct {
+	pending chan entities.ObjectEvent
+	logger  *slog.Logger
+
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+// NewBus starts the goroutine delivering events, with a backlog of up to
+// size events; values below one use the default. The goroutine runs for the
+// life of the process.
+func NewBus(size int, logger *slog.Logger) *Bus {
+	if size < 1 {
+		size = defaultQueueSize
+	}
+	b := &Bus{
+		pending: make(chan entities.ObjectEvent, size),
+		logger:  logger,
+	}
+	go b.work()
+	return b
+}
+
+// Subscribe adds a handler for every event published from then on.
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish queues event without waiting. When the backlog is full the event
+// is dropped, so a burst of changes never slows the requests making them.
+func (b *Bus) Publish(event entities.ObjectEvent) {
+	select {
+	case b.pending <- event:
+	default:
+		b.logger.Warn("Event queue full, event dropped",
+			slog.String("kind", string(event.Kind)),
+			slog.String("object_id", event.Object.ID().String()))
+	}
+}
+
+func (b *Bus) work() {
+	for event := range b.pending {
+		b.mu.RLock()
+		handlers := b.handlers
+		b.mu.RUnlock()
+		for _, handler := range handlers {
+			b.deliver(handler, event)
+		}
+	}
+}
+
+// deliver runs one handler, keeping a panic in it from stopping the bus
+func (b *Bus) deliver(handler Handler, event entities.ObjectEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked",
+				slog.String("kind", string(event.Kind)),
+				slog.Any("error", fmt.Errorf("%v", r)))
+		}
+	}()
+	handler(context.Background(), event)
+}
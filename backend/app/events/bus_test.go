@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func newTestEvent(kind entities.ObjectEventKind) entities.ObjectEvent {
+	return entities.ObjectEvent{Kind: kind, UserID: entities.NewUserID(), At: time.Now()}
+}
+
+func TestBus_DeliversInOrder(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus(4, slog.New(slog.DiscardHandler))
+	got := make(chan entities.ObjectEventKind, 3)
+	bus.Subscribe(func(_ context.Context, event entities.ObjectEvent) {
+		got <- event.Kind
+	})
+
+	bus.Publish(newTestEvent(entities.ObjectCreated))
+	bus.Publish(newTestEvent(entities.ObjectUpdated))
+	bus.Publish(newTestEvent(entities.ObjectExpiring))
+
+	for _, want := range []entities.ObjectEventKind{entities.ObjectCreated, entities.ObjectUpdated, entities.ObjectExpiring} {
+		select {
+		case kind := <-got:
+			assert.Equal(t, want, kind)
+		case <-time.After(time.Second):
+			require.Fail(t, "event not delivered")
+		}
+	}
+}
+
+func TestBus_SurvivesPanickingHandler(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus(4, slog.New(slog.DiscardHandler))
+	got := make(chan struct{}, 2)
+	bus.Subscribe(func(context.Context, entities.ObjectEvent) { panic("boom") })
+	bus.Subscribe(func(context.Context, entities.ObjectEvent) { got <- struct{}{} })
+
+	bus.Publish(newTestEvent(entities.ObjectCreated))
+	bus.Publish(newTestEvent(entities.ObjectCreated))
+
+	for range 2 {
+		select {
+		case <-got:
+		case <-time.After(time.Second):
+			require.Fail(t, "bus stopped after a handler panicked")
+		}
+	}
+}
+
+func TestBus_DropsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus(1, slog.New(slog.DiscardHandler))
+	release := make(chan struct{})
+	delivered := make(chan struct{}, 3)
+	bus.Subscribe(func(context.Context, entities.ObjectEvent) {
+		<-release
+		delivered <- struct{}{}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		// One held by the handler, one waiting, the rest dropped
+		for range 5 {
+			bus.Publish(newTestEvent(entities.ObjectUpdated))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "Publish blocked on a full queue")
+	}
+	close(release)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, len(delivered), 2)
+}
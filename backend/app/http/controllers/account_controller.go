@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type AccountController struct {
+	deleteAccountUC     *usecases.DeleteAccountUseCase
+	exportAccountDataUC *usecases.ExportAccountDataUseCase
+	// localAccounts is set in local auth mode, where the sign-in is part of
+	// the account and is deleted with it
+	localAccounts repositories.LocalAccountRepository
+	logger        *slog.Logger
+}
+
+func NewAccountController(
+	c *container.Container,
+	logger *slog.Logger,
+) *AccountController {
+	ctrl := &AccountController{
+		deleteAccountUC:     usecases.NewDeleteAccountUseCase(c.CollectionRepo, c.ContainerRepo, c.ObjectMoveRepo, c.AdjustmentRepo, c.ContainerTemplateRepo, c.MealPlanRepo, c.RecurrenceRuleRepo, c.FolderRepo, c.ObjectCodeRepo, c.SnapshotRepo, c.DigestSubscriptionRepo, c.PreferencesRepo, c.MediaRepo, c.MediaStorage, c.CommentRepo, c.ObjectTypeRepo, c.InboxRepo, c.ExpiryHistoryRepo, c.AutomationRuleRepo, c.WebhookDeliveryRepo, c.WebhookKeyRepo, c.SessionRepo, c.AccountStatusRepo),
+		exportAccountDataUC: usecases.NewExportAccountDataUseCase(c.CollectionRepo, c.DigestSubscriptionRepo, c.ContainerTemplateRepo, c.ObjectMoveRepo),
+		logger:              logger,
+	}
+	if c.GetConfig().Auth.IsLocal() {
+		ctrl.localAccounts = c.LocalAccountRepo
+	}
+	return ctrl
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Permanently delete every collection, container, object, object history entry, saved template and digest preference the user owns, and end all of its sign-in sessions. Requires a recent sign-in and the username as confirmation.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body request.DeleteAccountRequest true "Confirmation"
+// @Success 200 {object} response.AccountDeletionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id} [delete]
+// @Security BearerAuth
+func (ctrl *AccountController) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.DeleteAccountRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	if req.ConfirmUsername != user.Username().String() {
+		httputil.Error(w, http.StatusBadRequest, "confirm_username does not match the account username")
+		return
+	}
+
+	resp, err := ctrl.deleteAccountUC.Execute(r.Context(), usecases.DeleteAccountRequest{UserID: user.ID()})
+	if err != nil {
+		ctrl.logger.Error("Failed to delete account",
+			slog.String("user_id", user.ID().String()),
+			slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to delete account")
+		return
+	}
+
+	if ctrl.localAccounts != nil {
+		if err := ctrl.localAccounts.DeleteByUserID(r.Context(), user.ID()); err != nil {
+			ctrl.logger.Error("Failed to delete local account",
+				slog.String("user_id", user.ID().String()),
+				slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to delete account")
+			return
+		}
+	}
+
+	ctrl.logger.Info("Account deleted",
+		slog.String("user_id", user.ID().String()),
+		slog.Int64("collections", resp.CollectionsDeleted),
+		slog.Int64("objects", resp.ObjectsDeleted))
+
+	httputil.JSON(w, http.StatusOK, response.AccountDeletionResponse{
+		CollectionsDeleted: resp.CollectionsDeleted,
+		ContainersDeleted:  resp.ContainersDeleted,
+		ObjectsDeleted:     resp.ObjectsDeleted,
+		MovesDeleted:       resp.MovesDeleted,
+		TemplatesDeleted:   resp.TemplatesDeleted,
+		MealPlansDeleted:   resp.MealPlansDeleted,
+		SnapshotsDeleted:   resp.SnapshotsDeleted,
+		MediaDeleted:       resp.MediaDeleted,
+		CommentsDeleted:    resp.CommentsDeleted,
+	})
+}
+
+// ExportAccountData godoc
+// @Summary Export personal data
+// @Description Download the user's profile, settings, collections, templates and object history as JSON. Other account data (meal plans, media, comments, sessions, automations, webhooks, ...) is not included. Requires a recent sign-in.
+// @Tags accounts
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.AccountDataExport
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/data-export [get]
+// @Security BearerAuth
+func (ctrl *AccountController) ExportAccountData(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.exportAccountDataUC.Execute(r.Context(), usecases.ExportAccountDataRequest{UserID: user.ID()})
+	if err != nil {
+		ctrl.logger.Error("Failed to export account data", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to export account data")
+		return
+	}
+
+	export := response.NewAccountDataExport(user, resp.Collections, resp.Digest, resp.Templates, resp.Moves, resp.ExportedAt)
+	filename := "nishiki-data-export-" + resp.ExportedAt.Format("2006-01-02") + ".json"
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if err := json.MarshalWrite(w, export); err != nil {
+		ctrl.logger.Error("Failed to write account data export", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,561 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/app/logging"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// AdminController serves the admin dashboard. Its routes are wrapped in
+// middleware.RequireAdmin, so handlers do not check the role again.
+type AdminController struct {
+	listAccountsUC       *usecases.ListAccountsUseCase
+	getSystemStatsUC     *usecases.GetSystemStatsUseCase
+	setAccountDisabledUC *usecases.SetAccountDisabledUseCase
+	oauthClientsUC       *usecases.OAuthClientUseCase
+	featuresUC           *usecases.FeatureFlagUseCase
+	logSinks             *logging.Sinks
+	logger               *slog.Logger
+}
+
+func NewAdminController(c *container.Container, logger *slog.Logger) *AdminController {
+	return &AdminController{
+		listAccountsUC:       usecases.NewListAccountsUseCase(c.AccountStatusRepo, c.UsageRepo),
+		getSystemStatsUC:     usecases.NewGetSystemStatsUseCase(c.AccountStatusRepo, c.UsageRepo),
+		setAccountDisabledUC: usecases.NewSetAccountDisabledUseCase(c.AccountStatusRepo, c.CheckAccount()),
+		oauthClientsUC:       c.OAuthClients(),
+		featuresUC:           c.Features(),
+		logSinks:             c.LogSinks(),
+		logger:               logger,
+	}
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description List every user with their collection, container, object and photo counts, most recently seen first. Admin only.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.AdminUserListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users [get]
+// @Security BearerAuth
+func (ctrl *AdminController) ListUsers(w http.ResponseWriter, r *http.Request) {
+	resp, err := ctrl.listAccountsUC.Execute(r.Context())
+	if err != nil {
+		ctrl.logger.Error("Failed to list users", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list users")
+		return
+	}
+
+	users := make([]response.AdminUserResponse, len(resp.Accounts))
+	for i, account := range resp.Accounts {
+		users[i] = response.NewAdminUserResponse(account.Status, account.Usage)
+	}
+
+	httputil.JSON(w, http.StatusOK, response.AdminUserListResponse{Users: users})
+}
+
+// GetStats godoc
+// @Summary Get system stats
+// @Description Total users, disabled users, collections, containers, objects and photo storage across the deployment. Admin only.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.SystemStatsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/stats [get]
+// @Security BearerAuth
+func (ctrl *AdminController) GetStats(w http.ResponseWriter, r *http.Request) {
+	resp, err := ctrl.getSystemStatsUC.Execute(r.Context())
+	if err != nil {
+		ctrl.logger.Error("Failed to get system stats", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get system stats")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewSystemStatsResponse(resp.Stats))
+}
+
+// DisableUser godoc
+// @Summary Disable a user
+// @Description Refuse the user's requests from their next request on. Their data is kept. Admins cannot disable themselves. Admin only.
+// @Tags admin
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{user_id}/disable [post]
+// @Security BearerAuth
+func (ctrl *AdminController) DisableUser(w http.ResponseWriter, r *http.Request) {
+	ctrl.setDisabled(w, r, true)
+}
+
+// EnableUser godoc
+// @Summary Re-enable a user
+// @Description Let a disabled user sign in again. Admin only.
+// @Tags admin
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{user_id}/enable [post]
+// @Security BearerAuth
+func (ctrl *AdminController) EnableUser(w http.ResponseWriter, r *http.Request) {
+	ctrl.setDisabled(w, r, false)
+}
+
+func (ctrl *AdminController) setDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	admin, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userID, err := request.GetAdminUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = ctrl.setAccountDisabledUC.Execute(r.Context(), usecases.SetAccountDisabledRequest{
+		UserID:   userID,
+		Disabled: disabled,
+		AdminID:  admin.ID(),
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrCannotDisableSelf) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to update account status", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to update account status")
+		return
+	}
+
+	ctrl.logger.Info("Account status changed",
+		slog.String("admin_id", admin.ID().String()),
+		slog.String("user_id", userID.String()),
+		slog.Bool("disabled", disabled))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOAuthClients godoc
+// @Summary List registered OAuth clients
+// @Description List the OAuth clients registered through the API, oldest first. Clients from config.toml are not listed. Secrets are never returned. Admin only.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.OAuthClientListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/oauth-clients [get]
+// @Security BearerAuth
+func (ctrl *AdminController) ListOAuthClients(w http.ResponseWriter, r *http.Request) {
+	clients, err := ctrl.oauthClientsUC.List(r.Context())
+	if err != nil {
+		ctrl.logger.Error("Failed to list oauth clients", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list oauth clients")
+		return
+	}
+
+	resp := response.OAuthClientListResponse{Clients: make([]response.OAuthClientResponse, len(clients))}
+	for i, client := range clients {
+		resp.Clients[i] = response.NewOAuthClientResponse(client)
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// RegisterOAuthClient godoc
+// @Summary Register an OAuth client
+// @Description Register an OAuth client alongside those in config.toml. The provider's discovery document is fetched first, and the client is accepted for sign-in straight away. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param client body request.RegisterOAuthClientRequest true "Client"
+// @Success 201 {object} response.OAuthClientResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /admin/oauth-clients [post]
+// @Security BearerAuth
+func (ctrl *AdminController) RegisterOAuthClient(w http.ResponseWriter, r *http.Request) {
+	var req request.RegisterOAuthClientRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	client, err := ctrl.oauthClientsUC.Register(r.Context(), usecases.RegisterOAuthClientRequest{
+		ProviderName: req.ProviderName,
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		RedirectURL:  req.RedirectURL,
+	})
+	if err != nil {
+		ctrl.writeOAuthClientError(w, err, "failed to register oauth client")
+		return
+	}
+
+	ctrl.logger.Info("OAuth client registered",
+		slog.String("client_id", client.ClientID()),
+		slog.String("provider_name", client.ProviderName()))
+	httputil.JSON(w, http.StatusCreated, response.NewOAuthClientResponse(client))
+}
+
+// UpdateOAuthClient godoc
+// @Summary Update a registered OAuth client
+// @Description Change a registered client's provider, redirect URL or secret; an empty secret keeps the current one. Takes effect without a restart. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param client_id path string true "Client ID"
+// @Param client body request.UpdateOAuthClientRequest true "Client"
+// @Success 200 {object} response.OAuthClientResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /admin/oauth-clients/{client_id} [put]
+// @Security BearerAuth
+func (ctrl *AdminController) UpdateOAuthClient(w http.ResponseWriter, r *http.Request) {
+	clientID, err := request.GetOAuthClientIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateOAuthClientRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	client, err := ctrl.oauthClientsUC.Update(r.Context(), usecases.UpdateOAuthClientRequest{
+		ClientID:     clientID,
+		ProviderName: req.ProviderName,
+		ClientSecret: req.ClientSecret,
+		RedirectURL:  req.RedirectURL,
+	})
+	if err != nil {
+		ctrl.writeOAuthClientError(w, err, "failed to update oauth client")
+		return
+	}
+
+	ctrl.logger.Info("OAuth client updated", slog.String("client_id", clientID))
+	httputil.JSON(w, http.StatusOK, response.NewOAuthClientResponse(client))
+}
+
+// RemoveOAuthClient godoc
+// @Summary Remove a registered OAuth client
+// @Description Stop accepting a registered client's tokens and sign-ins. Clients from config.toml cannot be removed. Admin only.
+// @Tags admin
+// @Param client_id path string true "Client ID"
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Router /admin/oauth-clients/{client_id} [delete]
+// @Security BearerAuth
+func (ctrl *AdminController) RemoveOAuthClient(w http.ResponseWriter, r *http.Request) {
+	clientID, err := request.GetOAuthClientIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctrl.oauthClientsUC.Remove(r.Context(), clientID); err != nil {
+		ctrl.writeOAuthClientError(w, err, "failed to remove oauth client")
+		return
+	}
+
+	ctrl.logger.Info("OAuth client removed", slog.String("client_id", clientID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ctrl *AdminController) writeOAuthClientError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, entities.ErrInvalidOAuthClient):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entities.ErrOAuthClientNotFound):
+		httputil.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, entities.ErrOAuthClientExists), errors.Is(err, entities.ErrOAuthClientConfigured):
+		httputil.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, entities.ErrOAuthClientsStatic):
+		httputil.Error(w, http.StatusNotImplemented, err.Error())
+	case errors.Is(err, entities.ErrOAuthProviderFailed):
+		ctrl.logger.Warn("OAuth client provider could not be loaded", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadGateway, err.Error())
+	default:
+		ctrl.logger.Error(fallback, slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// ListFeatureFlags godoc
+// @Summary List feature flags
+// @Description The flag in effect for every known feature, by name, and whether it comes from the built-in default, app.toml or an admin. Admin only.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.FeatureFlagListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/features [get]
+// @Security BearerAuth
+func (ctrl *AdminController) ListFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	flags, err := ctrl.featuresUC.List(r.Context())
+	if err != nil {
+		ctrl.logger.Error("Failed to list feature flags", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list feature flags")
+		return
+	}
+
+	resp := response.FeatureFlagListResponse{Flags: make([]response.FeatureFlagResponse, len(flags))}
+	for i, flag := range flags {
+		resp.Flags[i] = response.NewFeatureFlagResponse(flag.Flag, flag.Source)
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// SetFeatureFlag godoc
+// @Summary Set a feature flag
+// @Description Turn a feature on for everyone, or only for the listed users and groups. Replaces the flag from app.toml until removed, and applies to every instance within 30 seconds. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param name path string true "Feature name"
+// @Param flag body request.SetFeatureFlagRequest true "Flag"
+// @Success 200 {object} response.FeatureFlagResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/features/{name} [put]
+// @Security BearerAuth
+func (ctrl *AdminController) SetFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	name, err := request.GetFeatureNameFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.SetFeatureFlagRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	flag, err := ctrl.featuresUC.Set(r.Context(), entities.FeatureFlagProps{
+		Name:        name,
+		Description: req.Description,
+		Enabled:     req.Enabled,
+		Users:       req.Users,
+		Groups:      req.Groups,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrInvalidFeatureName) || errors.Is(err, entities.ErrTooManyFeatureTargets) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to set feature flag", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to set feature flag")
+		return
+	}
+
+	ctrl.logger.Info("Feature flag set",
+		slog.String("feature", name),
+		slog.Bool("enabled", flag.Enabled()),
+		slog.Int("users", len(flag.Users())),
+		slog.Int("groups", len(flag.Groups())))
+	httputil.JSON(w, http.StatusOK, response.NewFeatureFlagResponse(flag, usecases.FeatureSourceAdmin))
+}
+
+// RemoveFeatureFlag godoc
+// @Summary Remove a feature flag
+// @Description Remove an admin's flag, so the one in app.toml or the built-in default applies again. Admin only.
+// @Tags admin
+// @Param name path string true "Feature name"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/features/{name} [delete]
+// @Security BearerAuth
+func (ctrl *AdminController) RemoveFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	name, err := request.GetFeatureNameFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctrl.featuresUC.Remove(r.Context(), name); err != nil {
+		if errors.Is(err, entities.ErrFeatureFlagNotFound) {
+			httputil.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to remove feature flag", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to remove feature flag")
+		return
+	}
+
+	ctrl.logger.Info("Feature flag removed", slog.String("feature", name))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLogLevels godoc
+// @Summary Get log levels
+// @Description The default log level and the level of each log sink: the console, Seq and those under [[logging.sinks]]. Admin only.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.LogLevelsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Router /admin/logging [get]
+// @Security BearerAuth
+func (ctrl *AdminController) GetLogLevels(w http.ResponseWriter, r *http.Request) {
+	if ctrl.logSinks == nil {
+		httputil.Error(w, http.StatusNotImplemented, "log levels are not adjustable on this server")
+		return
+	}
+	httputil.JSON(w, http.StatusOK, ctrl.logLevelsResponse())
+}
+
+// UpdateLogLevels godoc
+// @Summary Change log levels
+// @Description Change the default log level, which sinks without a level of their own follow, or individual sinks' levels; an empty sink level follows the default again. Takes effect at once and lasts until the next restart. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param levels body request.UpdateLogLevelsRequest true "Levels"
+// @Success 200 {object} response.LogLevelsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Router /admin/logging [put]
+// @Security BearerAuth
+func (ctrl *AdminController) UpdateLogLevels(w http.ResponseWriter, r *http.Request) {
+	if ctrl.logSinks == nil {
+		httputil.Error(w, http.StatusNotImplemented, "log levels are not adjustable on this server")
+		return
+	}
+
+	var req request.UpdateLogLevelsRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	// Parse everything first so a bad entry changes nothing
+	var level *slog.Level
+	if req.Level != "" {
+		parsed, err := config.ParseLogLevel(req.Level)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "level "+err.Error())
+			return
+		}
+		level = &parsed
+	}
+	known := make(map[string]bool)
+	for _, sink := range ctrl.logSinks.Levels() {
+		known[sink.Name] = true
+	}
+	sinkLevels := make([]*slog.Level, len(req.Sinks))
+	for i, sink := range req.Sinks {
+		if !known[sink.Name] {
+			httputil.Error(w, http.StatusNotFound, logging.ErrUnknownSink.Error()+": "+sink.Name)
+			return
+		}
+		if sink.Level == "" {
+			continue
+		}
+		parsed, err := config.ParseLogLevel(sink.Level)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "sink "+sink.Name+" level "+err.Error())
+			return
+		}
+		sinkLevels[i] = &parsed
+	}
+
+	if level != nil {
+		ctrl.logSinks.SetLevel(*level)
+	}
+	for i, sink := range req.Sinks {
+		if err := ctrl.logSinks.SetSinkLevel(sink.Name, sinkLevels[i]); err != nil {
+			httputil.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := ctrl.logLevelsResponse()
+	ctrl.logger.Info("Log levels changed",
+		slog.String("level", resp.Level),
+		slog.Int("sinks_changed", len(req.Sinks)))
+
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+func (ctrl *AdminController) logLevelsResponse() response.LogLevelsResponse {
+	levels := ctrl.logSinks.Levels()
+	resp := response.LogLevelsResponse{
+		Level: logging.LevelName(ctrl.logSinks.Level()),
+		Sinks: make([]response.LogSinkResponse, len(levels)),
+	}
+	for i, sink := range levels {
+		resp.Sinks[i] = response.LogSinkResponse{
+			Name:      sink.Name,
+			Type:      sink.Type,
+			Level:     logging.LevelName(sink.Level),
+			Inherited: sink.Inherited,
+		}
+	}
+	return resp
+}
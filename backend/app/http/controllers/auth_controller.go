@@ -53,7 +53,8 @@ func (ctrl *AuthController) GetCurrentUser(w http.ResponseWriter, r *http.Reques
 		slog.String("user_id", user.ID().String()),
 		slog.String("username", user.Username().String()))
 
-	httputil.JSON(w, http.StatusOK, response.NewAuthInfoResponse(user, claims))
+	admin := claims.InGroup(ctrl.container.GetConfig().Auth.AdminGroup)
+	httputil.JSON(w, http.StatusOK, response.NewAuthInfoResponse(user, claims, admin))
 }
 
 // HealthCheck godoc
@@ -96,8 +97,6 @@ func (ctrl *AuthController) GetOIDCConfig(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Set CORS headers and return config
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	httputil.JSON(w, http.StatusOK, oidcConfig)
 }
 
@@ -156,11 +155,6 @@ func (ctrl *AuthController) ProxyTokenExchange(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Set CORS headers
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-
 	// Forward status code and response body from auth service
 	httputil.Data(w, statusCode, "application/json", responseBody)
 }
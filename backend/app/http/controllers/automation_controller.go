@@ -0,0 +1,314 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type AutomationController struct {
+	rulesUC *usecases.AutomationRuleUseCase
+	logger  *slog.Logger
+}
+
+func NewAutomationController(
+	c *container.Container,
+	logger *slog.Logger,
+) *AutomationController {
+	return &AutomationController{
+		rulesUC: usecases.NewAutomationRuleUseCase(c.AutomationRuleRepo, c.CollectionRepo, c.ContainerRepo, c.AuthService),
+		logger:  logger,
+	}
+}
+
+// ListAutomationRules godoc
+// @Summary List automation rules
+// @Description Returns the user's automation rules, oldest first. Webhook secrets are left out; has_secret says whether one is set.
+// @Tags automations
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.AutomationRuleListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/automations [get]
+// @Security BearerAuth
+func (ctrl *AutomationController) ListAutomationRules(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	rules, err := ctrl.rulesUC.List(r.Context(), user.ID())
+	if err != nil {
+		ctrl.logger.Error("Failed to list automation rules", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list automation rules")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewAutomationRuleListResponse(rules))
+}
+
+// CreateAutomationRule godoc
+// @Summary Create an automation rule
+// @Description Runs the rule's actions on each of the user's objects its trigger fires for: when one is created, gets a tag, drops below a quantity or comes within some days of expiring. Actions add a tag, move the object to a container in the same collection, put it on the shopping list or post it to a webhook. A user has at most 50 rules.
+// @Tags automations
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param rule body request.AutomationRuleRequest true "Automation rule"
+// @Success 201 {object} response.AutomationRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/automations [post]
+// @Security BearerAuth
+func (ctrl *AutomationController) CreateAutomationRule(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.AutomationRuleRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	props, err := req.ToProps()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := ctrl.rulesUC.Create(r.Context(), usecases.CreateAutomationRuleRequest{
+		Props:     props,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.writeRuleError(w, err, "failed to create automation rule")
+		return
+	}
+
+	ctrl.logger.Info("Automation rule created",
+		slog.String("rule_id", rule.ID().String()),
+		slog.String("trigger", string(rule.Trigger().Kind)),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusCreated, response.NewAutomationRuleResponse(rule))
+}
+
+// UpdateAutomationRule godoc
+// @Summary Update an automation rule
+// @Description Replaces the rule's name, trigger and actions, and turns it on or off. A webhook sent without a secret keeps the one it had for the same URL.
+// @Tags automations
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param rule_id path string true "Automation rule ID"
+// @Param rule body request.AutomationRuleRequest true "Automation rule"
+// @Success 200 {object} response.AutomationRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/automations/{rule_id} [put]
+// @Security BearerAuth
+func (ctrl *AutomationController) UpdateAutomationRule(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	ruleID, err := request.GetAutomationRuleIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.AutomationRuleRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	props, err := req.ToProps()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := ctrl.rulesUC.Update(r.Context(), usecases.UpdateAutomationRuleRequest{
+		RuleID:    ruleID,
+		Props:     props,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.writeRuleError(w, err, "failed to update automation rule")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewAutomationRuleResponse(rule))
+}
+
+// DeleteAutomationRule godoc
+// @Summary Delete an automation rule
+// @Description Deletes the rule. What it already did is kept.
+// @Tags automations
+// @Param id path string true "User ID"
+// @Param rule_id path string true "Automation rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/automations/{rule_id} [delete]
+// @Security BearerAuth
+func (ctrl *AutomationController) DeleteAutomationRule(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	ruleID, err := request.GetAutomationRuleIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.rulesUC.Delete(r.Context(), ruleID, user.ID())
+	switch {
+	case errors.Is(err, entities.ErrAutomationRuleNotFound):
+		httputil.Error(w, http.StatusNotFound, "automation rule not found")
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to delete automation rule", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to delete automation rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeRuleError maps a create or update failure to its status
+func (ctrl *AutomationController) writeRuleError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, entities.ErrAutomationRuleNotFound):
+		httputil.Error(w, http.StatusNotFound, "automation rule not found")
+	case errors.Is(err, entities.ErrTooManyAutomationRules):
+		httputil.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, entities.ErrInvalidAutomationRuleName),
+		errors.Is(err, entities.ErrInvalidAutomationTrigger),
+		errors.Is(err, entities.ErrInvalidAutomationAction),
+		errors.Is(err, entities.ErrInvalidWebhookRetry):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	case strings.Contains(err.Error(), "access denied"):
+		httputil.Error(w, http.StatusForbidden, "access denied")
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, err.Error())
+	default:
+		ctrl.logger.Error("Automation rule request failed", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
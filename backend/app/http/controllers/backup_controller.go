@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type BackupController struct {
+	backupAccountUC  *usecases.BackupAccountUseCase
+	restoreAccountUC *usecases.RestoreAccountUseCase
+	logger           *slog.Logger
+}
+
+func NewBackupController(
+	c *container.Container,
+	logger *slog.Logger,
+) *BackupController {
+	return &BackupController{
+		backupAccountUC:  usecases.NewBackupAccountUseCase(c.CollectionRepo, c.DigestSubscriptionRepo),
+		restoreAccountUC: usecases.NewRestoreAccountUseCase(c.CollectionRepo, c.ContainerRepo, c.ObjectCodeRepo, c.DigestSubscriptionRepo, c.AuthService),
+		logger:           logger,
+	}
+}
+
+// Backup godoc
+// @Summary Download a full account backup
+// @Description Streams every collection, container and object the user owns, plus account settings, as a JSON archive or a tar.gz containing backup.json
+// @Tags backup
+// @Produce json
+// @Produce application/gzip
+// @Param id path string true "User ID"
+// @Param format query string false "Archive format: json (default) or tar.gz"
+// @Success 200 {object} response.BackupArchive
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/backup [get]
+// @Security BearerAuth
+func (ctrl *BackupController) Backup(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "tar.gz" {
+		httputil.Error(w, http.StatusBadRequest, "format must be json or tar.gz")
+		return
+	}
+
+	resp, err := ctrl.backupAccountUC.Execute(r.Context(), usecases.BackupAccountRequest{UserID: user.ID()})
+	if err != nil {
+		ctrl.logger.Error("Failed to build backup", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to build backup")
+		return
+	}
+
+	archive := response.NewBackupArchive(user.ID(), resp.Collections, resp.Digest, resp.ExportedAt)
+	basename := "nishiki-backup-" + resp.ExportedAt.Format("2006-01-02")
+
+	if format == "tar.gz" {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar.gz"`, basename))
+		if err := writeBackupTarGz(w, archive); err != nil {
+			ctrl.logger.Error("Failed to write backup archive", slog.Any("error", err))
+		}
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, basename))
+		if err := json.MarshalWrite(w, archive); err != nil {
+			ctrl.logger.Error("Failed to write backup", slog.Any("error", err))
+		}
+	}
+
+	ctrl.logger.Info("Account backup downloaded",
+		slog.String("user_id", user.ID().String()),
+		slog.Int("collections", len(archive.Collections)),
+		slog.String("format", format))
+}
+
+// writeBackupTarGz writes the archive as backup.json inside a gzipped tarball.
+// The JSON has to be marshalled up front because tar headers need its size.
+func writeBackupTarGz(w http.ResponseWriter, archive response.BackupArchive) error {
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	hdr := &tar.Header{
+		Name:    request.BackupArchiveFilename,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Restore godoc
+// @Summary Restore an account backup
+// @Description Imports a backup archive (JSON or tar.gz). strategy decides what happens to collections that already exist: skip (default) leaves them, overwrite replaces them, merge adds missing containers and objects
+// @Tags backup
+// @Accept json
+// @Accept application/gzip
+// @Produce json
+// @Param id path string true "User ID"
+// @Param strategy query string false "Collision strategy: skip, overwrite or merge"
+// @Param archive body response.BackupArchive true "Backup archive"
+// @Success 200 {object} response.RestoreResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/restore [post]
+// @Security BearerAuth
+func (ctrl *BackupController) Restore(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	strategy, err := request.GetRestoreStrategyFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	archive, err := request.ReadBackupArchive(w, r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid backup archive", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collections, err := request.BackupArchiveToCollections(archive, user.ID())
+	if err != nil {
+		ctrl.logger.Warn("Invalid backup contents", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ucReq := usecases.RestoreAccountRequest{
+		UserID:      user.ID(),
+		UserToken:   userToken,
+		Strategy:    strategy,
+		Collections: collections,
+	}
+
+	digestEmail, digestFrequency, err := request.BackupArchiveToDigestSettings(archive)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if digestEmail != nil {
+		ucReq.Digest = &usecases.RestoreDigestSettings{Email: *digestEmail, Frequency: digestFrequency}
+	}
+
+	resp, err := ctrl.restoreAccountUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to restore backup", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to restore backup")
+		return
+	}
+
+	ctrl.logger.Info("Account backup restored",
+		slog.String("user_id", user.ID().String()),
+		slog.String("strategy", strategy.String()),
+		slog.Int("created", resp.CollectionsCreated),
+		slog.Int("updated", resp.CollectionsUpdated),
+		slog.Int("skipped", resp.CollectionsSkipped),
+		slog.Int("errors", len(resp.Errors)))
+
+	httputil.JSON(w, http.StatusOK, response.RestoreResponse{
+		CollectionsCreated: resp.CollectionsCreated,
+		CollectionsUpdated: resp.CollectionsUpdated,
+		CollectionsSkipped: resp.CollectionsSkipped,
+		ContainersCreated:  resp.ContainersCreated,
+		ObjectsRestored:    resp.ObjectsRestored,
+		Errors:             resp.Errors,
+	})
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+)
+
+const (
+	// clientErrorWindow is how long repeats of one error are counted
+	// instead of logged.
+	clientErrorWindow = time.Minute
+	// maxClientErrorFingerprints bounds the aggregation table; when it is
+	// full, reports of new errors are dropped until the window rolls over.
+	maxClientErrorFingerprints = 1000
+)
+
+// clientErrorCount tracks one error fingerprint within the current window.
+type clientErrorCount struct {
+	windowStart time.Time
+	repeats     int
+}
+
+// ClientErrorController receives crash and API failure reports from
+// frontends that opted in to error reporting and logs them, grouped by
+// fingerprint so a crash loop in many browsers does not flood the logs.
+type ClientErrorController struct {
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	counts map[string]*clientErrorCount
+}
+
+func NewClientErrorController(_ *container.Container, logger *slog.Logger) *ClientErrorController {
+	return &ClientErrorController{
+		logger: logger,
+		now:    time.Now,
+		counts: make(map[string]*clientErrorCount),
+	}
+}
+
+// ReportClientError godoc
+// @Summary Report a frontend error
+// @Description Record a recovered panic or failed API call from the frontend. Authentication is optional; when a valid token is sent the report is attributed to its user. Repeats of the same error within a minute are counted rather than logged individually.
+// @Tags client-errors
+// @Accept json
+// @Param request body request.ClientErrorRequest true "Error report"
+// @Success 202
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Router /client-errors [post]
+func (ctrl *ClientErrorController) ReportClientError(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, request.MaxClientErrorBodySize)
+
+	var req request.ClientErrorRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httputil.Error(w, http.StatusRequestEntityTooLarge, "error report is too large")
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	fingerprint := clientErrorFingerprint(&req)
+	if log, repeats := ctrl.track(fingerprint); log {
+		attrs := []any{
+			slog.String("kind", req.Kind),
+			slog.String("fingerprint", fingerprint),
+			slog.String("message", req.Message),
+			slog.String("view", req.View),
+			slog.String("app_version", req.AppVersion),
+			slog.String("user_agent", req.UserAgent),
+			slog.Int("repeats", repeats),
+		}
+		if req.Kind == request.ClientErrorKindAPI {
+			attrs = append(attrs,
+				slog.String("method", req.Method),
+				slog.String("endpoint", req.Endpoint),
+				slog.Int("status", req.Status),
+			)
+		}
+		if req.Stack != "" {
+			attrs = append(attrs, slog.String("stack", req.Stack))
+		}
+		if user, ok := middleware.GetCurrentUser(r); ok {
+			attrs = append(attrs, slog.String("user_id", user.ID().String()))
+		}
+		ctrl.logger.Warn("Client error reported", attrs...)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// track reports whether this occurrence of fingerprint should be logged and,
+// if so, how many repeats were swallowed since it was last logged.
+func (ctrl *ClientErrorController) track(fingerprint string) (bool, int) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	now := ctrl.now()
+	if count, ok := ctrl.counts[fingerprint]; ok {
+		if now.Sub(count.windowStart) < clientErrorWindow {
+			count.repeats++
+			return false, 0
+		}
+		repeats := count.repeats
+		count.windowStart, count.repeats = now, 0
+		return true, repeats
+	}
+
+	if len(ctrl.counts) >= maxClientErrorFingerprints {
+		for key, count := range ctrl.counts {
+			if now.Sub(count.windowStart) >= clientErrorWindow {
+				delete(ctrl.counts, key)
+			}
+		}
+		if len(ctrl.counts) >= maxClientErrorFingerprints {
+			return false, 0
+		}
+	}
+	ctrl.counts[fingerprint] = &clientErrorCount{windowStart: now}
+	return true, 0
+}
+
+// clientErrorFingerprint groups reports of the same failure: the kind, the
+// message and, for panics, the first frame of the stack or, for API calls,
+// the endpoint. The view and user agent are left out so the same bug seen
+// from different screens or browsers lands in one group.
+func clientErrorFingerprint(req *request.ClientErrorRequest) string {
+	h := sha256.New()
+	h.Write([]byte(req.Kind + "\x00" + req.Message + "\x00"))
+	switch req.Kind {
+	case request.ClientErrorKindPanic:
+		frame, _, _ := strings.Cut(strings.TrimSpace(req.Stack), "\n")
+		h.Write([]byte(frame))
+	case request.ClientErrorKindAPI:
+		h.Write([]byte(req.Method + " " + req.Endpoint))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
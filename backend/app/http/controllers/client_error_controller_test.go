@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler keeps the records logged through it.
+type recordingHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func (h *recordingHandler) attr(i int, key string) slog.Value {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var value slog.Value
+	h.records[i].Attrs(func(a slog.Attr) bool {
+		if a.Key == key {
+			value = a.Value
+			return false
+		}
+		return true
+	})
+	return value
+}
+
+func TestClientErrorController_ReportClientError(t *testing.T) {
+	t.Parallel()
+
+	panicReport := `{"kind":"panic","message":"index out of range","stack":"main.render()\n\tapp.go:10","view":"Dashboard","app_version":"abc123"}`
+
+	t.Run("rejects invalid reports", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newTestContainer(t)
+		controller := NewClientErrorController(c, c.GetLogger())
+
+		for name, body := range map[string]string{
+			"malformed":    `{`,
+			"unknown kind": `{"kind":"oops","message":"x"}`,
+			"no message":   `{"kind":"panic"}`,
+			"long message": `{"kind":"panic","message":"` + strings.Repeat("x", 4096) + `"}`,
+		} {
+			rr := httptest.NewRecorder()
+			controller.ReportClientError(rr, httptest.NewRequest(http.MethodPost, "/client-errors", strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+		}
+	})
+
+	t.Run("rejects oversized bodies", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newTestContainer(t)
+		controller := NewClientErrorController(c, c.GetLogger())
+
+		body := `{"kind":"panic","message":"x","stack":"` + strings.Repeat("x", 128<<10) + `"}`
+		rr := httptest.NewRecorder()
+		controller.ReportClientError(rr, httptest.NewRequest(http.MethodPost, "/client-errors", strings.NewReader(body)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("logs repeats once per window", func(t *testing.T) {
+		t.Parallel()
+
+		records := &recordingHandler{}
+		controller := NewClientErrorController(nil, slog.New(records))
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		controller.now = func() time.Time { return now }
+
+		report := func(body string) {
+			rr := httptest.NewRecorder()
+			controller.ReportClientError(rr, httptest.NewRequest(http.MethodPost, "/client-errors", strings.NewReader(body)))
+			require.Equal(t, http.StatusAccepted, rr.Code)
+		}
+
+		report(panicReport)
+		// Same failure from another view is the same fingerprint
+		report(strings.Replace(panicReport, "Dashboard", "Search", 1))
+		report(panicReport)
+		report(`{"kind":"api","message":"500 Internal Server Error","method":"GET","endpoint":"/groups","status":500}`)
+		require.Len(t, records.records, 2)
+		assert.Equal(t, "panic", records.attr(0, "kind").String())
+		assert.Equal(t, "Dashboard", records.attr(0, "view").String())
+		assert.Equal(t, "/groups", records.attr(1, "endpoint").String())
+		assert.Equal(t, int64(500), records.attr(1, "status").Int64())
+
+		now = now.Add(clientErrorWindow)
+		report(panicReport)
+		require.Len(t, records.records, 3)
+		assert.Equal(t, int64(2), records.attr(2, "repeats").Int64())
+		assert.Equal(t, records.attr(0, "fingerprint").String(), records.attr(2, "fingerprint").String())
+	})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -11,6 +12,8 @@ import (
 	"github.com/nishiki/backend/app/http/middleware"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
 	"github.com/nishiki/backend/domain/usecases"
 )
 
@@ -20,7 +23,10 @@ type CollectionController struct {
 	updateCollectionUC     *usecases.UpdateCollectionUseCase
 	deleteCollectionUC     *usecases.DeleteCollectionUseCase
 	updatePropertySchemaUC *usecases.UpdatePropertySchemaUseCase
+	updateShelfLifeUC      *usecases.UpdateShelfLifeUseCase
 	exportCollectionUC     *usecases.ExportCollectionUseCase
+	generateReportUC       *usecases.GenerateCollectionReportUseCase
+	transferCollectionUC   *usecases.TransferCollectionUseCase
 	logger                 *slog.Logger
 }
 
@@ -29,12 +35,15 @@ func NewCollectionController(
 	logger *slog.Logger,
 ) *CollectionController {
 	return &CollectionController{
-		createCollectionUC:     usecases.NewCreateCollectionUseCase(c.CollectionRepo, c.AuthService),
-		getCollectionsUC:       usecases.NewGetCollectionsUseCase(c.CollectionRepo, c.AuthService),
-		updateCollectionUC:     usecases.NewUpdateCollectionUseCase(c.CollectionRepo, c.AuthService),
-		deleteCollectionUC:     usecases.NewDeleteCollectionUseCase(c.CollectionRepo, c.ContainerRepo),
-		updatePropertySchemaUC: usecases.NewUpdatePropertySchemaUseCase(c.CollectionRepo),
+		createCollectionUC:     usecases.NewCreateCollectionUseCase(c.CollectionRepo, c.ObjectTypeRepo, c.AuthService),
+		getCollectionsUC:       usecases.NewGetCollectionsUseCase(c.CollectionRepo, c.PreferencesRepo, c.AuthService),
+		updateCollectionUC:     usecases.NewUpdateCollectionUseCase(c.CollectionRepo, c.ObjectTypeRepo, c.AuthService),
+		deleteCollectionUC:     usecases.NewDeleteCollectionUseCase(c.CollectionRepo, c.ContainerRepo, c.SnapshotRepo, c.MediaRepo, c.MediaStorage, c.CommentRepo, c.AuthService),
+		updatePropertySchemaUC: usecases.NewUpdatePropertySchemaUseCase(c.CollectionRepo, c.AuthService),
+		updateShelfLifeUC:      usecases.NewUpdateShelfLifeUseCase(c.CollectionRepo, c.AuthService),
 		exportCollectionUC:     usecases.NewExportCollectionUseCase(c.CollectionRepo, c.AuthService),
+		generateReportUC:       usecases.NewGenerateCollectionReportUseCase(c.CollectionRepo, c.AuthService, c.ReportRenderer),
+		transferCollectionUC:   usecases.NewTransferCollectionUseCase(c.CollectionRepo, c.AuthService),
 		logger:                 logger,
 	}
 }
@@ -76,7 +85,7 @@ func (ctrl *CollectionController) CreateCollection(w http.ResponseWriter, r *htt
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -98,6 +107,7 @@ func (ctrl *CollectionController) CreateCollection(w http.ResponseWriter, r *htt
 	ucReq := usecases.CreateCollectionRequest{
 		UserID:         user.ID(),
 		GroupID:        groupID,
+		GroupOwned:     req.GroupOwned,
 		Name:           req.Name,
 		ObjectType:     req.GetObjectType(),
 		Tags:           req.Tags,
@@ -113,6 +123,10 @@ func (ctrl *CollectionController) CreateCollection(w http.ResponseWriter, r *htt
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
 		}
+		if errors.Is(err, entities.ErrUnknownObjectType) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		httputil.Error(w, http.StatusInternalServerError, "failed to create collection")
 		return
 	}
@@ -131,7 +145,10 @@ func (ctrl *CollectionController) CreateCollection(w http.ResponseWriter, r *htt
 // @Tags collections
 // @Produce json
 // @Param id path string true "User ID"
+// @Param sort query string false "Sort field (name, created_at, updated_at, custom); pinned collections always come first"
+// @Param order query string false "Sort order (asc, desc)"
 // @Success 200 {object} response.CollectionListResponse
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -165,9 +182,17 @@ func (ctrl *CollectionController) GetCollections(w http.ResponseWriter, r *http.
 		return
 	}
 
+	sortOpts, err := request.GetSortOptionsFromQuery(r, usecases.CollectionSortFields)
+	if err != nil {
+		ctrl.logger.Warn("Invalid sort parameters", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	ucReq := usecases.GetCollectionsRequest{
 		UserID:    pathUserID,
 		UserToken: userToken,
+		Sort:      sortOpts,
 	}
 
 	resp, err := ctrl.getCollectionsUC.Execute(r.Context(), ucReq)
@@ -328,7 +353,7 @@ func (ctrl *CollectionController) UpdateCollection(w http.ResponseWriter, r *htt
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -343,7 +368,106 @@ func (ctrl *CollectionController) UpdateCollection(w http.ResponseWriter, r *htt
 	if req.ObjectType != "" {
 		ucReq.ObjectType = &req.ObjectType
 	}
+	ctrl.writeCollectionUpdate(w, r, ucReq)
+}
+
+// PatchCollection godoc
+// @Summary Patch collection
+// @Description Apply an RFC 7386 JSON Merge Patch to a collection: only the fields sent change and null removes a value. Tags, when given, replace the collection's tags.
+// @Tags collections
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param collection body request.PatchCollectionRequest true "Fields to change"
+// @Success 200 {object} response.CollectionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id} [patch]
+// @Security BearerAuth
+func (ctrl *CollectionController) PatchCollection(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.PatchCollectionRequest
+	patch, err := httputil.DecodeMergePatch(r, &req)
+	if errors.Is(err, httputil.ErrUnsupportedPatchType) {
+		httputil.Error(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if patch.Empty() {
+		httputil.Error(w, http.StatusBadRequest, "no fields to update")
+		return
+	}
+	if patch.Removes("name") || patch.Removes("object_type") {
+		httputil.Error(w, http.StatusBadRequest, "name and object_type cannot be removed")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	ucReq := usecases.UpdateCollectionRequest{
+		CollectionID: collectionID,
+		UserID:       pathUserID,
+		Name:         req.Name,
+		ObjectType:   req.ObjectType,
+		Tags:         req.Tags,
+		ClearTags:    patch.Removes("tags") || (req.Tags != nil && len(req.Tags) == 0),
+		Location:     req.Location,
+		UserToken:    userToken,
+	}
+	if patch.Removes("location") {
+		ucReq.Location = new("")
+	}
+	ctrl.writeCollectionUpdate(w, r, ucReq)
+}
 
+// writeCollectionUpdate runs an update or patch and answers with the collection.
+func (ctrl *CollectionController) writeCollectionUpdate(w http.ResponseWriter, r *http.Request, ucReq usecases.UpdateCollectionRequest) {
 	resp, err := ctrl.updateCollectionUC.Execute(r.Context(), ucReq)
 	if err != nil {
 		ctrl.logger.Error("Failed to update collection", slog.Any("error", err))
@@ -351,6 +475,10 @@ func (ctrl *CollectionController) UpdateCollection(w http.ResponseWriter, r *htt
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
 		}
+		if errors.Is(err, entities.ErrUnknownObjectType) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			httputil.Error(w, http.StatusNotFound, "collection not found")
 			return
@@ -360,8 +488,8 @@ func (ctrl *CollectionController) UpdateCollection(w http.ResponseWriter, r *htt
 	}
 
 	ctrl.logger.Info("Collection updated successfully",
-		slog.String("collection_id", collectionID.String()),
-		slog.String("user_id", user.ID().String()))
+		slog.String("collection_id", ucReq.CollectionID.String()),
+		slog.String("user_id", ucReq.UserID.String()))
 
 	httputil.JSON(w, http.StatusOK, response.NewCollectionResponse(resp.Collection))
 }
@@ -432,6 +560,83 @@ func (ctrl *CollectionController) ExportCollection(w http.ResponseWriter, r *htt
 	_, _ = w.Write(resp.CSV)
 }
 
+// GenerateReport godoc
+// @Summary Generate collection report as PDF
+// @Description Render a printable PDF report of a collection grouped by container, with object counts, values and expiry dates
+// @Tags collections
+// @Produce application/pdf
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param layout query string false "summary or detailed (default detailed)"
+// @Param images query bool false "Include cached object images in the detailed layout"
+// @Success 200 {string} string "PDF document"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/report.pdf [get]
+// @Security BearerAuth
+func (ctrl *CollectionController) GenerateReport(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	layout, err := services.ParseReportLayout(q.Get("layout"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.generateReportUC.Execute(r.Context(), usecases.GenerateCollectionReportRequest{
+		CollectionID:  collectionID,
+		Layout:        layout,
+		IncludeImages: q.Get("images") == "true",
+		UserID:        pathUserID,
+		UserToken:     userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to generate collection report", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to generate report")
+		return
+	}
+
+	filename := sanitizeFilename(resp.CollectionName) + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp.PDF)
+}
+
 // sanitizeFilename replaces characters that are unsafe in Content-Disposition filenames.
 func sanitizeFilename(name string) string {
 	r := strings.NewReplacer(`"`, "", `\`, "", "/", "-", "\n", "", "\r", "")
@@ -483,10 +688,13 @@ func (ctrl *CollectionController) DeleteCollection(w http.ResponseWriter, r *htt
 
 	force := r.URL.Query().Get("force") == "true"
 
+	userToken, _ := middleware.GetCurrentToken(r)
+
 	ucReq := usecases.DeleteCollectionRequest{
 		CollectionID: collectionID,
 		UserID:       pathUserID,
 		Force:        force,
+		UserToken:    userToken,
 	}
 
 	resp, err := ctrl.deleteCollectionUC.Execute(r.Context(), ucReq)
@@ -557,10 +765,13 @@ func (ctrl *CollectionController) UpdatePropertySchema(w http.ResponseWriter, r
 		return
 	}
 
+	userToken, _ := middleware.GetCurrentToken(r)
+
 	resp, err := ctrl.updatePropertySchemaUC.Execute(r.Context(), usecases.UpdatePropertySchemaRequest{
 		CollectionID:   collectionID,
 		UserID:         user.ID(),
 		PropertySchema: req.PropertySchema.ToEntity(),
+		UserToken:      userToken,
 	})
 	if err != nil {
 		ctrl.logger.Error("Failed to update property schema", slog.Any("error", err))
@@ -578,3 +789,176 @@ func (ctrl *CollectionController) UpdatePropertySchema(w http.ResponseWriter, r
 
 	httputil.JSON(w, http.StatusOK, response.NewCollectionResponse(resp.Collection))
 }
+
+// UpdateShelfLife godoc
+// @Summary Update collection shelf life defaults
+// @Description Replace the default shelf life by category. New food objects without an expires_at get one from the rule matching their category property or a tag, or from the rule with an empty category.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param shelf_life body request.UpdateShelfLifeRequest true "Shelf life rules"
+// @Success 200 {object} response.CollectionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/shelf-life [put]
+// @Security BearerAuth
+func (ctrl *CollectionController) UpdateShelfLife(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateShelfLifeRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rules, err := req.ToEntities()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userToken, _ := middleware.GetCurrentToken(r)
+
+	resp, err := ctrl.updateShelfLifeUC.Execute(r.Context(), usecases.UpdateShelfLifeRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		ShelfLife:    rules,
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to update shelf life defaults", slog.Any("error", err))
+		if errors.Is(err, entities.ErrDuplicateShelfLife) || errors.Is(err, entities.ErrInvalidShelfLife) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to update shelf life defaults")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionResponse(resp.Collection))
+}
+
+// TransferCollection godoc
+// @Summary Transfer collection ownership
+// @Description Move ownership to a group the caller belongs to, or to a user who is a member of the collection's group. Group-owned collections stay with the group when members leave; any member can manage them, but only group admins can transfer or delete them.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param transfer body request.TransferCollectionRequest true "Transfer target"
+// @Success 200 {object} response.CollectionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/transfer [post]
+// @Security BearerAuth
+func (ctrl *CollectionController) TransferCollection(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.TransferCollectionRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	toGroupID, err := req.GetToGroupID()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid group ID")
+		return
+	}
+	toUserID, err := req.GetToUserID()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	resp, err := ctrl.transferCollectionUC.Execute(r.Context(), usecases.TransferCollectionRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+		ToGroupID:    toGroupID,
+		ToUserID:     toUserID,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to transfer collection", slog.Any("error", err))
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "recipient must be") || strings.Contains(err.Error(), "already belongs") {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to transfer collection")
+		return
+	}
+
+	ctrl.logger.Info("Collection transferred",
+		slog.String("collection_id", collectionID.String()),
+		slog.String("user_id", user.ID().String()),
+		slog.Bool("group_owned", resp.Collection.IsGroupOwned()))
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionResponse(resp.Collection))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
 	"github.com/nishiki/backend/domain/entities"
@@ -138,6 +139,7 @@ func TestCollectionController_GetCollections(t *testing.T) {
 				entities.NewCollectionID(),
 				testUser.ID(),
 				nil,
+				false,
 				collectionName,
 				nil,
 				entities.ObjectTypeGeneral,
@@ -145,17 +147,31 @@ func TestCollectionController_GetCollections(t *testing.T) {
 				[]string{},
 				"",
 				nil,
+				nil,
+				nil,
 				time.Now(),
 				time.Now(),
 			)
 			collections = append(collections, collection)
 		}
 
-		// GetCollectionsUseCase calls GetByUserIDSummary (no GetUserGroups in simple path)
+		// GetCollectionsUseCase lists owned collections, then those owned by the user's groups
 		m.CollectionRepo.EXPECT().
 			GetByUserIDSummary(gomock.Any(), testUser.ID()).
 			Return(collections, nil).
 			Times(1)
+		m.AuthService.EXPECT().
+			GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).
+			Return([]*entities.Group{}, nil).
+			Times(1)
+		m.CollectionRepo.EXPECT().
+			GetGroupOwnedSummary(gomock.Any(), gomock.Len(0)).
+			Return(nil, nil).
+			Times(1)
+		m.PreferencesRepo.EXPECT().
+			GetByUserID(gomock.Any(), testUser.ID()).
+			Return(nil, entities.ErrUserPreferencesNotFound).
+			Times(1)
 
 		req := newTestRequest(http.MethodGet, "/accounts/"+testUser.ID().String()+"/collections", nil)
 		req.SetPathValue("id", testUser.ID().String())
@@ -220,6 +236,7 @@ func TestCollectionController_DeleteCollection(t *testing.T) {
 			collectionID,
 			testUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -227,6 +244,8 @@ func TestCollectionController_DeleteCollection(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -241,6 +260,26 @@ func TestCollectionController_DeleteCollection(t *testing.T) {
 			Return(nil).
 			Times(1)
 
+		m.SnapshotRepo.EXPECT().
+			DeleteByCollectionID(gomock.Any(), collectionID).
+			Return(int64(0), nil).
+			Times(1)
+
+		m.MediaRepo.EXPECT().
+			DeleteByCollectionID(gomock.Any(), collectionID).
+			Return(int64(0), nil).
+			Times(1)
+
+		m.MediaStorage.EXPECT().
+			DeleteCollection(gomock.Any(), collectionID).
+			Return(nil).
+			Times(1)
+
+		m.CommentRepo.EXPECT().
+			DeleteByCollectionID(gomock.Any(), collectionID).
+			Return(int64(0), nil).
+			Times(1)
+
 		req := newTestRequest(http.MethodDelete, "/accounts/"+testUser.ID().String()+"/collections/"+collectionID.String(), nil)
 		req.SetPathValue("id", testUser.ID().String())
 		req.SetPathValue("collection_id", collectionID.String())
@@ -290,3 +329,77 @@ func TestCollectionController_DeleteCollection(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 }
+
+func TestCollectionController_PatchCollection(t *testing.T) {
+	t.Parallel()
+
+	c, m := newTestContainer(t)
+	controller := NewCollectionController(c, c.GetLogger())
+
+	patchRequest := func(user *entities.User, collectionID string, body any) *http.Request {
+		req := newTestRequest(http.MethodPatch, "/accounts/"+user.ID().String()+"/collections/"+collectionID, body)
+		req.Header.Set("Content-Type", httputil.MergePatchContentType)
+		req.SetPathValue("id", user.ID().String())
+		req.SetPathValue("collection_id", collectionID)
+		return setAuthContext(req, user, "test-token")
+	}
+
+	t.Run("success - changes sent fields and removes nulls", func(t *testing.T) {
+		testUser := randomUser()
+		collectionID := entities.NewCollectionID()
+
+		collectionName, _ := entities.NewCollectionName("Pantry")
+		testCollection := entities.ReconstructCollection(
+			collectionID,
+			testUser.ID(),
+			nil,
+			false,
+			collectionName,
+			nil,
+			entities.ObjectTypeFood,
+			[]entities.Container{},
+			[]string{"kitchen"},
+			"Basement",
+			nil,
+			nil,
+			nil,
+			time.Now(),
+			time.Now(),
+		)
+
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rr := httptest.NewRecorder()
+		controller.PatchCollection(rr, patchRequest(testUser, collectionID.String(), map[string]any{
+			"location": nil,
+			"tags":     nil,
+		}))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp response.CollectionResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "Pantry", resp.Name)
+		assert.Equal(t, string(entities.ObjectTypeFood), resp.ObjectType)
+		assert.Empty(t, resp.Location)
+		assert.Empty(t, resp.Tags)
+	})
+
+	t.Run("error - object type cannot be removed", func(t *testing.T) {
+		testUser := randomUser()
+
+		rr := httptest.NewRecorder()
+		controller.PatchCollection(rr, patchRequest(testUser, entities.NewCollectionID().String(), map[string]any{"object_type": nil}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("error - body is not an object", func(t *testing.T) {
+		testUser := randomUser()
+
+		rr := httptest.NewRecorder()
+		controller.PatchCollection(rr, patchRequest(testUser, entities.NewCollectionID().String(), []string{"name"}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
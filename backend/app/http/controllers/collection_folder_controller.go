@@ -0,0 +1,489 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type CollectionFolderController struct {
+	listFoldersUC  *usecases.ListCollectionFoldersUseCase
+	createFolderUC *usecases.CreateCollectionFolderUseCase
+	updateFolderUC *usecases.UpdateCollectionFolderUseCase
+	deleteFolderUC *usecases.DeleteCollectionFolderUseCase
+	fileUC         *usecases.FileCollectionUseCase
+	unfileUC       *usecases.UnfileCollectionUseCase
+	folderStatsUC  *usecases.GetCollectionFolderStatsUseCase
+	logger         *slog.Logger
+}
+
+func NewCollectionFolderController(
+	c *container.Container,
+	logger *slog.Logger,
+) *CollectionFolderController {
+	return &CollectionFolderController{
+		listFoldersUC:  usecases.NewListCollectionFoldersUseCase(c.FolderRepo),
+		createFolderUC: usecases.NewCreateCollectionFolderUseCase(c.FolderRepo),
+		updateFolderUC: usecases.NewUpdateCollectionFolderUseCase(c.FolderRepo),
+		deleteFolderUC: usecases.NewDeleteCollectionFolderUseCase(c.FolderRepo),
+		fileUC:         usecases.NewFileCollectionUseCase(c.FolderRepo, c.CollectionRepo, c.AuthService),
+		unfileUC:       usecases.NewUnfileCollectionUseCase(c.FolderRepo),
+		folderStatsUC:  usecases.NewGetCollectionFolderStatsUseCase(c.FolderRepo, c.CollectionRepo, c.AuthService),
+		logger:         logger,
+	}
+}
+
+// writeFolderError maps folder errors shared by every handler here to a
+// status, falling back to 500 with fallback as the message.
+func (ctrl *CollectionFolderController) writeFolderError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, entities.ErrCollectionFolderNotFound):
+		httputil.Error(w, http.StatusNotFound, "folder not found")
+	case errors.Is(err, entities.ErrInvalidCollectionFolderName),
+		errors.Is(err, entities.ErrCollectionFolderCycle),
+		errors.Is(err, entities.ErrCollectionFolderTooDeep):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	case strings.Contains(err.Error(), "access denied"):
+		httputil.Error(w, http.StatusForbidden, "access denied")
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, err.Error())
+	default:
+		ctrl.logger.Error(fallback, slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// ListCollectionFolders godoc
+// @Summary List collection folders
+// @Description Returns the user's collection folders ordered by name. Nesting is given by parent_id.
+// @Tags collection-folders
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.CollectionFolderListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders [get]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) ListCollectionFolders(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.listFoldersUC.Execute(r.Context(), usecases.ListCollectionFoldersRequest{
+		UserID: user.ID(),
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to list folders")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionFolderListResponse(resp.Folders))
+}
+
+// CreateCollectionFolder godoc
+// @Summary Create a collection folder
+// @Description Creates a folder for grouping collections, optionally nested in another folder
+// @Tags collection-folders
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param folder body request.CreateCollectionFolderRequest true "Folder"
+// @Success 201 {object} response.CollectionFolderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders [post]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) CreateCollectionFolder(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.CreateCollectionFolderRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.createFolderUC.Execute(r.Context(), usecases.CreateCollectionFolderRequest{
+		Name:     req.Name,
+		ParentID: req.GetParentID(),
+		UserID:   user.ID(),
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to create folder")
+		return
+	}
+
+	ctrl.logger.Info("Collection folder created",
+		slog.String("folder_id", resp.Folder.ID().String()),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusCreated, response.NewCollectionFolderResponse(resp.Folder))
+}
+
+// UpdateCollectionFolder godoc
+// @Summary Update a collection folder
+// @Description Renames a folder or moves it. An empty parent_id moves it to the top level.
+// @Tags collection-folders
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param folder_id path string true "Folder ID"
+// @Param folder body request.UpdateCollectionFolderRequest true "Fields to change"
+// @Success 200 {object} response.CollectionFolderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders/{folder_id} [put]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) UpdateCollectionFolder(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	folderID, err := request.GetCollectionFolderIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateCollectionFolderRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.updateFolderUC.Execute(r.Context(), usecases.UpdateCollectionFolderRequest{
+		FolderID: folderID,
+		Name:     req.Name,
+		ParentID: req.GetParentID(),
+		ToRoot:   req.MovesToRoot(),
+		UserID:   user.ID(),
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to update folder")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionFolderResponse(resp.Folder))
+}
+
+// DeleteCollectionFolder godoc
+// @Summary Delete a collection folder
+// @Description Deletes a folder. Its subfolders and collections move up into its parent folder, or to the top level.
+// @Tags collection-folders
+// @Param id path string true "User ID"
+// @Param folder_id path string true "Folder ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders/{folder_id} [delete]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) DeleteCollectionFolder(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	folderID, err := request.GetCollectionFolderIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteFolderUC.Execute(r.Context(), usecases.DeleteCollectionFolderRequest{
+		FolderID: folderID,
+		UserID:   user.ID(),
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to delete folder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FileCollection godoc
+// @Summary Move a collection into a folder
+// @Description Files a collection the user can see into one of their folders, taking it out of any other folder
+// @Tags collection-folders
+// @Produce json
+// @Param id path string true "User ID"
+// @Param folder_id path string true "Folder ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} response.CollectionFolderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders/{folder_id}/collections/{collection_id} [put]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) FileCollection(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	folderID, err := request.GetCollectionFolderIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.fileUC.Execute(r.Context(), usecases.FileCollectionRequest{
+		FolderID:     folderID,
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to move collection into folder")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionFolderResponse(resp.Folder))
+}
+
+// UnfileCollection godoc
+// @Summary Take a collection out of a folder
+// @Description Moves a collection out of a folder, back to the top level of the user's list
+// @Tags collection-folders
+// @Produce json
+// @Param id path string true "User ID"
+// @Param folder_id path string true "Folder ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} response.CollectionFolderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders/{folder_id}/collections/{collection_id} [delete]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) UnfileCollection(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	folderID, err := request.GetCollectionFolderIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.unfileUC.Execute(r.Context(), usecases.UnfileCollectionRequest{
+		FolderID:     folderID,
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to take collection out of folder")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionFolderResponse(resp.Folder))
+}
+
+// GetCollectionFolderStats godoc
+// @Summary Get folder stats
+// @Description Totals collections, containers and objects in a folder and its subfolders, with low stock and expiry counts. Archived objects are not counted.
+// @Tags collection-folders
+// @Produce json
+// @Param id path string true "User ID"
+// @Param folder_id path string true "Folder ID"
+// @Success 200 {object} response.CollectionFolderStatsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collection-folders/{folder_id}/stats [get]
+// @Security BearerAuth
+func (ctrl *CollectionFolderController) GetCollectionFolderStats(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	folderID, err := request.GetCollectionFolderIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.folderStatsUC.Execute(r.Context(), usecases.GetCollectionFolderStatsRequest{
+		FolderID:  folderID,
+		UserID:    user.ID(),
+		UserToken: userToken,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		ctrl.writeFolderError(w, err, "failed to get folder stats")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionFolderStatsResponse(folderID, resp.Stats))
+}
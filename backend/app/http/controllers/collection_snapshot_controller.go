@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type CollectionSnapshotController struct {
+	listSnapshotsUC   *usecases.ListCollectionSnapshotsUseCase
+	createSnapshotUC  *usecases.CreateCollectionSnapshotUseCase
+	diffSnapshotUC    *usecases.DiffCollectionSnapshotUseCase
+	restoreSnapshotUC *usecases.RestoreCollectionSnapshotUseCase
+	deleteSnapshotUC  *usecases.DeleteCollectionSnapshotUseCase
+	maxPerCollection  int
+	logger            *slog.Logger
+}
+
+func NewCollectionSnapshotController(
+	c *container.Container,
+	logger *slog.Logger,
+) *CollectionSnapshotController {
+	maxPerCollection := c.GetConfig().Snapshots.MaxPerCollection
+	return &CollectionSnapshotController{
+		listSnapshotsUC:   usecases.NewListCollectionSnapshotsUseCase(c.CollectionRepo, c.SnapshotRepo, c.AuthService),
+		createSnapshotUC:  usecases.NewCreateCollectionSnapshotUseCase(c.CollectionRepo, c.SnapshotRepo, c.AuthService, maxPerCollection),
+		diffSnapshotUC:    usecases.NewDiffCollectionSnapshotUseCase(c.CollectionRepo, c.SnapshotRepo, c.AuthService),
+		restoreSnapshotUC: usecases.NewRestoreCollectionSnapshotUseCase(c.CollectionRepo, c.ContainerRepo, c.ObjectCodeRepo, c.SnapshotRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService, maxPerCollection),
+		deleteSnapshotUC:  usecases.NewDeleteCollectionSnapshotUseCase(c.CollectionRepo, c.SnapshotRepo, c.AuthService),
+		maxPerCollection:  maxPerCollection,
+		logger:            logger,
+	}
+}
+
+// ListSnapshots godoc
+// @Summary List collection snapshots
+// @Description Returns the collection's snapshots, newest first, with the number of containers and objects each captured
+// @Tags snapshots
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} response.CollectionSnapshotListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/snapshots [get]
+// @Security BearerAuth
+func (ctrl *CollectionSnapshotController) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := ctrl.listSnapshotsUC.Execute(r.Context(), usecases.ListCollectionSnapshotsRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to list snapshots", slog.Any("error", err))
+		writeSnapshotError(w, err, "failed to list snapshots")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionSnapshotListResponse(resp.Snapshots, ctrl.maxPerCollection))
+}
+
+// CreateSnapshot godoc
+// @Summary Snapshot a collection
+// @Description Captures the collection's containers and objects as they are now. The oldest snapshots are dropped once the configured limit is reached.
+// @Tags snapshots
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param request body request.CreateCollectionSnapshotRequest false "Optional label"
+// @Success 201 {object} response.CollectionSnapshotResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/snapshots [post]
+// @Security BearerAuth
+func (ctrl *CollectionSnapshotController) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req request.CreateCollectionSnapshotRequest
+	if r.ContentLength != 0 {
+		if err := httputil.DecodeJSON(r, &req); err != nil {
+			ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.createSnapshotUC.Execute(r.Context(), usecases.CreateCollectionSnapshotRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+		Label:        req.Label,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to create snapshot", slog.Any("error", err))
+		writeSnapshotError(w, err, "failed to create snapshot")
+		return
+	}
+
+	ctrl.logger.Info("Collection snapshot created",
+		slog.String("snapshot_id", resp.Snapshot.ID().String()),
+		slog.String("collection_id", collectionID.String()),
+		slog.Int("objects", resp.Snapshot.ObjectCount()),
+		slog.Int("pruned", resp.Pruned))
+
+	httputil.JSON(w, http.StatusCreated, response.NewCollectionSnapshotResponse(resp.Snapshot))
+}
+
+// DiffSnapshot godoc
+// @Summary Compare a snapshot
+// @Description Lists the containers and objects added, removed or changed since the snapshot, either up to now or up to a later snapshot given by against
+// @Tags snapshots
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param snapshot_id path string true "Snapshot ID"
+// @Param against query string false "Later snapshot ID, or current (default)"
+// @Success 200 {object} response.CollectionSnapshotDiffResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}/diff [get]
+// @Security BearerAuth
+func (ctrl *CollectionSnapshotController) DiffSnapshot(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	snapshotID, err := request.GetSnapshotIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid snapshot ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	against, err := request.GetSnapshotDiffTargetFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.diffSnapshotUC.Execute(r.Context(), usecases.DiffCollectionSnapshotRequest{
+		CollectionID: collectionID,
+		SnapshotID:   snapshotID,
+		AgainstID:    against,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to diff snapshot", slog.Any("error", err))
+		writeSnapshotError(w, err, "failed to compare snapshot")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCollectionSnapshotDiffResponse(resp.From, resp.To, resp.Diff))
+}
+
+// RestoreSnapshot godoc
+// @Summary Restore a snapshot
+// @Description Replaces the collection's containers and objects with the snapshot's copy. The current state is snapshotted first so the restore can be undone. Objects that have since moved to another collection are left where they are.
+// @Tags snapshots
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param snapshot_id path string true "Snapshot ID"
+// @Success 200 {object} response.RestoreCollectionSnapshotResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}/restore [post]
+// @Security BearerAuth
+func (ctrl *CollectionSnapshotController) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	snapshotID, err := request.GetSnapshotIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid snapshot ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.restoreSnapshotUC.Execute(r.Context(), usecases.RestoreCollectionSnapshotRequest{
+		CollectionID: collectionID,
+		SnapshotID:   snapshotID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to restore snapshot", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		writeSnapshotError(w, err, "failed to restore snapshot")
+		return
+	}
+
+	ctrl.logger.Info("Collection snapshot restored",
+		slog.String("snapshot_id", snapshotID.String()),
+		slog.String("collection_id", collectionID.String()),
+		slog.Int("containers", resp.ContainersRestored),
+		slog.Int("objects", resp.ObjectsRestored),
+		slog.Int("skipped", len(resp.Skipped)))
+
+	httputil.JSON(w, http.StatusOK, response.RestoreCollectionSnapshotResponse{
+		Backup:             response.NewCollectionSnapshotResponse(resp.Backup),
+		ContainersRestored: resp.ContainersRestored,
+		ObjectsRestored:    resp.ObjectsRestored,
+		Skipped:            resp.Skipped,
+	})
+}
+
+// DeleteSnapshot godoc
+// @Summary Delete a snapshot
+// @Tags snapshots
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param snapshot_id path string true "Snapshot ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id} [delete]
+// @Security BearerAuth
+func (ctrl *CollectionSnapshotController) DeleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	snapshotID, err := request.GetSnapshotIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid snapshot ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteSnapshotUC.Execute(r.Context(), usecases.DeleteCollectionSnapshotRequest{
+		CollectionID: collectionID,
+		SnapshotID:   snapshotID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to delete snapshot", slog.Any("error", err))
+		writeSnapshotError(w, err, "failed to delete snapshot")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// collectionFromRequest reads the caller and the collection from the path,
+// writing the error response itself when ok is false.
+func (ctrl *CollectionSnapshotController) collectionFromRequest(w http.ResponseWriter, r *http.Request) (*entities.User, string, entities.CollectionID, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	return user, userToken, collectionID, true
+}
+
+func writeSnapshotError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "access denied"):
+		httputil.Error(w, http.StatusForbidden, "access denied")
+	case errors.Is(err, entities.ErrCollectionSnapshotNotFound):
+		httputil.Error(w, http.StatusNotFound, "snapshot not found")
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, "collection not found")
+	case errors.Is(err, entities.ErrInvalidSnapshotLabel):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
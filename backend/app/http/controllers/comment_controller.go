@@ -0,0 +1,389 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type CommentController struct {
+	createCommentUC *usecases.CreateCommentUseCase
+	listCommentsUC  *usecases.ListCommentsUseCase
+	deleteCommentUC *usecases.DeleteCommentUseCase
+	markReadUC      *usecases.MarkCommentsReadUseCase
+	getUnreadUC     *usecases.GetUnreadCommentsUseCase
+	logger          *slog.Logger
+}
+
+func NewCommentController(
+	c *container.Container,
+	logger *slog.Logger,
+) *CommentController {
+	return &CommentController{
+		createCommentUC: usecases.NewCreateCommentUseCase(c.CollectionRepo, c.ContainerRepo, c.CommentRepo, c.AuthService),
+		listCommentsUC:  usecases.NewListCommentsUseCase(c.CollectionRepo, c.ContainerRepo, c.CommentRepo, c.AuthService),
+		deleteCommentUC: usecases.NewDeleteCommentUseCase(c.CollectionRepo, c.CommentRepo, c.AuthService),
+		markReadUC:      usecases.NewMarkCommentsReadUseCase(c.CollectionRepo, c.CommentRepo, c.AuthService),
+		getUnreadUC:     usecases.NewGetUnreadCommentsUseCase(c.CollectionRepo, c.CommentRepo, c.AuthService),
+		logger:          logger,
+	}
+}
+
+// ListCollectionComments godoc
+// @Summary List collection comments
+// @Description Returns a page of the comments on the collection and its objects, newest first
+// @Tags comments
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param limit query int false "Page size, 1-100 (default 50)"
+// @Param before query string false "Only comments posted before this RFC 3339 time"
+// @Success 200 {object} response.CommentListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/comments [get]
+// @Security BearerAuth
+func (ctrl *CommentController) ListCollectionComments(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.list(w, r, usecases.ListCommentsRequest{CollectionID: collectionID})
+}
+
+// ListObjectComments godoc
+// @Summary List object comments
+// @Description Returns a page of the comments on the object, newest first
+// @Tags comments
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param limit query int false "Page size, 1-100 (default 50)"
+// @Param before query string false "Only comments posted before this RFC 3339 time"
+// @Success 200 {object} response.CommentListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/comments [get]
+// @Security BearerAuth
+func (ctrl *CommentController) ListObjectComments(w http.ResponseWriter, r *http.Request) {
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.list(w, r, usecases.ListCommentsRequest{ObjectID: &objectID})
+}
+
+// CreateCollectionComment godoc
+// @Summary Comment on a collection
+// @Description Posts a note for everyone who can see the collection. @username mentions of the owner or group members are recorded so they show up for them.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param request body request.CreateCommentRequest true "Comment"
+// @Success 201 {object} response.CommentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/comments [post]
+// @Security BearerAuth
+func (ctrl *CommentController) CreateCollectionComment(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.create(w, r, usecases.CreateCommentRequest{CollectionID: collectionID})
+}
+
+// CreateObjectComment godoc
+// @Summary Comment on an object
+// @Description Posts a note about one object, such as "buy more of this brand". It also shows in the collection's comments.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param request body request.CreateCommentRequest true "Comment"
+// @Success 201 {object} response.CommentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/comments [post]
+// @Security BearerAuth
+func (ctrl *CommentController) CreateObjectComment(w http.ResponseWriter, r *http.Request) {
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.create(w, r, usecases.CreateCommentRequest{ObjectID: &objectID})
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description The author can delete their comment, and the collection owner any comment on the collection
+// @Tags comments
+// @Param id path string true "User ID"
+// @Param comment_id path string true "Comment ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/comments/{comment_id} [delete]
+// @Security BearerAuth
+func (ctrl *CommentController) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	commentID, err := request.GetCommentIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid comment ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteCommentUC.Execute(r.Context(), usecases.DeleteCommentRequest{
+		CommentID: commentID,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to delete comment", slog.Any("error", err))
+		writeCommentError(w, err, "failed to delete comment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkCommentsRead godoc
+// @Summary Mark collection comments read
+// @Description Clears the collection's unread badge up to read_at, or up to now when it is omitted
+// @Tags comments
+// @Accept json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param request body request.MarkCommentsReadRequest false "Newest comment time seen"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/comments/read [post]
+// @Security BearerAuth
+func (ctrl *CommentController) MarkCommentsRead(w http.ResponseWriter, r *http.Request) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.MarkCommentsReadRequest
+	if r.ContentLength != 0 {
+		if err := httputil.DecodeJSON(r, &req); err != nil {
+			ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	err = ctrl.markReadUC.Execute(r.Context(), usecases.MarkCommentsReadRequest{
+		CollectionID: collectionID,
+		ReadAt:       req.ReadAt,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to mark comments read", slog.Any("error", err))
+		writeCommentError(w, err, "failed to mark comments read")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUnreadComments godoc
+// @Summary Unread comment counts
+// @Description Returns, for each of the user's collections with unread comments, how many there are and how many mention the user
+// @Tags comments
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.UnreadCommentsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/comments/unread [get]
+// @Security BearerAuth
+func (ctrl *CommentController) GetUnreadComments(w http.ResponseWriter, r *http.Request) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := ctrl.getUnreadUC.Execute(r.Context(), usecases.GetUnreadCommentsRequest{
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to count unread comments", slog.Any("error", err))
+		writeCommentError(w, err, "failed to count unread comments")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewUnreadCommentsResponse(resp.Unread))
+}
+
+func (ctrl *CommentController) list(w http.ResponseWriter, r *http.Request, req usecases.ListCommentsRequest) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	before, limit, err := request.GetCommentPageFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.Before = before
+	req.Limit = limit
+	req.UserID = user.ID()
+	req.UserToken = userToken
+	resp, err := ctrl.listCommentsUC.Execute(r.Context(), req)
+	if err != nil {
+		ctrl.logger.Error("Failed to list comments", slog.Any("error", err))
+		writeCommentError(w, err, "failed to list comments")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewCommentListResponse(resp.Comments))
+}
+
+func (ctrl *CommentController) create(w http.ResponseWriter, r *http.Request, req usecases.CreateCommentRequest) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var body request.CreateCommentRequest
+	if err := httputil.DecodeJSON(r, &body); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	req.Body = body.Body
+	req.UserID = user.ID()
+	req.Username = user.Username().String()
+	req.UserToken = userToken
+	resp, err := ctrl.createCommentUC.Execute(r.Context(), req)
+	if err != nil {
+		ctrl.logger.Error("Failed to create comment", slog.Any("error", err))
+		writeCommentError(w, err, "failed to create comment")
+		return
+	}
+
+	ctrl.logger.Info("Comment created",
+		slog.String("comment_id", resp.Comment.ID().String()),
+		slog.String("collection_id", resp.Comment.CollectionID().String()),
+		slog.Int("mentions", len(resp.Comment.Mentions())))
+
+	httputil.JSON(w, http.StatusCreated, response.NewCommentResponse(resp.Comment))
+}
+
+func (ctrl *CommentController) userFromRequest(w http.ResponseWriter, r *http.Request) (*entities.User, string, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, "", false
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, "", false
+	}
+
+	return user, userToken, true
+}
+
+func writeCommentError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "access denied"):
+		httputil.Error(w, http.StatusForbidden, "access denied")
+	case errors.Is(err, entities.ErrInvalidCommentBody),
+		errors.Is(err, entities.ErrInvalidCommentPage):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entities.ErrCommentNotFound):
+		httputil.Error(w, http.StatusNotFound, "comment not found")
+	case strings.Contains(err.Error(), "object not found"):
+		httputil.Error(w, http.StatusNotFound, "object not found")
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, "collection not found")
+	default:
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
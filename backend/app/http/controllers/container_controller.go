@@ -1,6 +1,9 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -22,6 +25,9 @@ type ContainerController struct {
 	getContainerByIDUC          *usecases.GetContainerByIDUseCase
 	getContainersUC             *usecases.GetContainersUseCase
 	getContainersByCollectionUC *usecases.GetContainersByCollectionUseCase
+	exportContainerTreeUC       *usecases.ExportContainerTreeUseCase
+	importContainerTreeUC       *usecases.ImportContainerTreeUseCase
+	bulkCreateContainersUC      *usecases.BulkCreateContainersUseCase
 	logger                      *slog.Logger
 }
 
@@ -30,13 +36,16 @@ func NewContainerController(
 	logger *slog.Logger,
 ) *ContainerController {
 	return &ContainerController{
-		createContainerUC:           usecases.NewCreateContainerUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		createContainerUC:           usecases.NewCreateContainerUseCase(c.ContainerRepo, c.CollectionRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService),
 		updateContainerUC:           usecases.NewUpdateContainerUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
-		deleteContainerUC:           usecases.NewDeleteContainerUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		deleteContainerUC:           usecases.NewDeleteContainerUseCase(c.ContainerRepo, c.CollectionRepo, c.MediaRepo, c.MediaStorage, c.AuthService),
 		getAllContainersUC:          usecases.NewGetAllContainersUseCase(c.ContainerRepo, c.AuthService),
 		getContainerByIDUC:          usecases.NewGetContainerByIDUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
 		getContainersUC:             usecases.NewGetContainersUseCase(c.ContainerRepo, c.AuthService),
-		getContainersByCollectionUC: usecases.NewGetContainersByCollectionUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		getContainersByCollectionUC: usecases.NewGetContainersByCollectionUseCase(c.ContainerRepo, c.CollectionRepo, c.PreferencesRepo, c.AuthService),
+		exportContainerTreeUC:       usecases.NewExportContainerTreeUseCase(c.CollectionRepo, c.AuthService),
+		importContainerTreeUC:       usecases.NewImportContainerTreeUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService),
+		bulkCreateContainersUC:      usecases.NewBulkCreateContainersUseCase(c.ContainerRepo, c.CollectionRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService),
 		logger:                      logger,
 	}
 }
@@ -79,7 +88,7 @@ func (ctrl *ContainerController) CreateContainer(w http.ResponseWriter, r *http.
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -131,6 +140,8 @@ func (ctrl *ContainerController) CreateContainer(w http.ResponseWriter, r *http.
 		Depth:             req.Depth,
 		Rows:              req.Rows,
 		Capacity:          req.Capacity,
+		TemperatureZone:   entities.TemperatureZone(req.TemperatureZone),
+		Humidity:          req.Humidity,
 		UserID:            user.ID(),
 		UserToken:         userToken,
 	}
@@ -138,6 +149,9 @@ func (ctrl *ContainerController) CreateContainer(w http.ResponseWriter, r *http.
 	resp, err := ctrl.createContainerUC.Execute(r.Context(), ucReq)
 	if err != nil {
 		ctrl.logger.Error("Failed to create container", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
 		if strings.Contains(err.Error(), "access denied") {
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
@@ -164,7 +178,10 @@ func (ctrl *ContainerController) CreateContainer(w http.ResponseWriter, r *http.
 // @Description Get all containers from groups the user is a member of
 // @Tags containers
 // @Produce json
+// @Param sort query string false "Sort field (name, created_at, updated_at, expires_at, quantity, custom); collection listings only, with pinned containers first"
+// @Param order query string false "Sort order (asc, desc)"
 // @Success 200 {object} response.ContainerListResponse
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /containers [get]
@@ -195,10 +212,18 @@ func (ctrl *ContainerController) GetContainers(w http.ResponseWriter, r *http.Re
 			return
 		}
 
+		sortOpts, err := request.GetSortOptionsFromQuery(r, usecases.ContainerSortFields)
+		if err != nil {
+			ctrl.logger.Warn("Invalid sort parameters", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		ucReq := usecases.GetContainersByCollectionRequest{
 			CollectionID: collectionID,
 			UserID:       user.ID(),
 			UserToken:    userToken,
+			Sort:         sortOpts,
 		}
 
 		resp, err := ctrl.getContainersByCollectionUC.Execute(r.Context(), ucReq)
@@ -363,7 +388,7 @@ func (ctrl *ContainerController) UpdateContainer(w http.ResponseWriter, r *http.
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -384,38 +409,10 @@ func (ctrl *ContainerController) UpdateContainer(w http.ResponseWriter, r *http.
 		ucReq.ContainerType = &containerType
 	}
 
-	if req.ParentContainerID != nil {
-		if *req.ParentContainerID == "" {
-			// Empty string means remove parent
-			var nilParent *entities.ContainerID
-			ucReq.ParentContainerID = &nilParent
-		} else {
-			parentID, err := entities.ContainerIDFromString(*req.ParentContainerID)
-			if err != nil {
-				ctrl.logger.Warn("Invalid parent container ID", slog.Any("error", err))
-				httputil.Error(w, http.StatusBadRequest, "invalid parent container ID")
-				return
-			}
-			parentIDPtr := &parentID
-			ucReq.ParentContainerID = &parentIDPtr
-		}
-	}
-
-	if req.GroupID != nil {
-		if *req.GroupID == "" {
-			// Empty string means remove group
-			var nilGroup *entities.GroupID
-			ucReq.GroupID = &nilGroup
-		} else {
-			groupID, err := entities.GroupIDFromString(*req.GroupID)
-			if err != nil {
-				ctrl.logger.Warn("Invalid group ID", slog.Any("error", err))
-				httputil.Error(w, http.StatusBadRequest, "invalid group ID")
-				return
-			}
-			groupIDPtr := &groupID
-			ucReq.GroupID = &groupIDPtr
-		}
+	if err := setContainerLinks(&ucReq, req.ParentContainerID, req.GroupID); err != nil {
+		ctrl.logger.Warn("Invalid container link", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	if req.Location != "" {
@@ -438,6 +435,167 @@ func (ctrl *ContainerController) UpdateContainer(w http.ResponseWriter, r *http.
 		ucReq.Capacity = &req.Capacity
 	}
 
+	if req.TemperatureZone != nil {
+		zone := entities.TemperatureZone(*req.TemperatureZone)
+		ucReq.TemperatureZone = &zone
+	}
+
+	if req.Humidity != nil {
+		ucReq.Humidity = &req.Humidity
+	}
+	ctrl.writeContainerUpdate(w, r, ucReq)
+}
+
+// PatchContainer godoc
+// @Summary Patch a container
+// @Description Apply an RFC 7386 JSON Merge Patch to a container: only the fields sent change and null removes a value, such as the parent, group, a dimension or the temperature zone
+// @Tags containers
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param container body request.PatchContainerRequest true "Fields to change"
+// @Success 200 {object} response.ContainerResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /containers/{id} [patch]
+// @Security BearerAuth
+func (ctrl *ContainerController) PatchContainer(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	containerID, err := request.GetContainerIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid container ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.PatchContainerRequest
+	patch, err := httputil.DecodeMergePatch(r, &req)
+	if errors.Is(err, httputil.ErrUnsupportedPatchType) {
+		httputil.Error(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if patch.Empty() {
+		httputil.Error(w, http.StatusBadRequest, "no fields to update")
+		return
+	}
+	if patch.Removes("name") || patch.Removes("type") {
+		httputil.Error(w, http.StatusBadRequest, "name and type cannot be removed")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	ucReq := usecases.UpdateContainerRequest{
+		ContainerID: containerID,
+		Name:        req.Name,
+		Location:    req.Location,
+		UserID:      user.ID(),
+		UserToken:   userToken,
+	}
+	if req.Type != nil {
+		ucReq.ContainerType = new(entities.ContainerType(*req.Type))
+	}
+
+	// null takes a link, text or measurement away, the way an empty string
+	// does on PUT
+	if patch.Removes("parent_container_id") {
+		req.ParentContainerID = new("")
+	}
+	if patch.Removes("group_id") {
+		req.GroupID = new("")
+	}
+	if err := setContainerLinks(&ucReq, req.ParentContainerID, req.GroupID); err != nil {
+		ctrl.logger.Warn("Invalid container link", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if patch.Removes("location") {
+		ucReq.Location = new("")
+	}
+	if req.TemperatureZone != nil {
+		ucReq.TemperatureZone = new(entities.TemperatureZone(*req.TemperatureZone))
+	} else if patch.Removes("temperature_zone") {
+		ucReq.TemperatureZone = new(entities.TemperatureZone(""))
+	}
+	ucReq.Width = patchedMeasure(patch, "width", req.Width)
+	ucReq.Depth = patchedMeasure(patch, "depth", req.Depth)
+	ucReq.Rows = patchedMeasure(patch, "rows", req.Rows)
+	ucReq.Capacity = patchedMeasure(patch, "capacity", req.Capacity)
+	ucReq.Humidity = patchedMeasure(patch, "humidity", req.Humidity)
+
+	ctrl.writeContainerUpdate(w, r, ucReq)
+}
+
+// setContainerLinks sets the parent and group of a container update from
+// their IDs in a request; an empty ID removes the link
+func setContainerLinks(ucReq *usecases.UpdateContainerRequest, parentID, groupID *string) error {
+	if parentID != nil {
+		if *parentID == "" {
+			ucReq.ParentContainerID = new((*entities.ContainerID)(nil))
+		} else {
+			id, err := entities.ContainerIDFromString(*parentID)
+			if err != nil {
+				return errors.New("invalid parent container ID")
+			}
+			ucReq.ParentContainerID = new(&id)
+		}
+	}
+
+	if groupID != nil {
+		if *groupID == "" {
+			ucReq.GroupID = new((*entities.GroupID)(nil))
+		} else {
+			id, err := entities.GroupIDFromString(*groupID)
+			if err != nil {
+				return errors.New("invalid group ID")
+			}
+			ucReq.GroupID = new(&id)
+		}
+	}
+	return nil
+}
+
+// patchedMeasure turns a patched measurement into the use case's double
+// pointer: nil keeps it, a pointer to nil removes it
+func patchedMeasure[T any](patch *httputil.MergePatch, member string, value *T) **T {
+	if patch.Removes(member) {
+		return new((*T)(nil))
+	}
+	if value == nil {
+		return nil
+	}
+	return &value
+}
+
+// writeContainerUpdate runs an update or patch and answers with the container.
+func (ctrl *ContainerController) writeContainerUpdate(w http.ResponseWriter, r *http.Request, ucReq usecases.UpdateContainerRequest) {
 	resp, err := ctrl.updateContainerUC.Execute(r.Context(), ucReq)
 	if err != nil {
 		ctrl.logger.Error("Failed to update container", slog.Any("error", err))
@@ -454,8 +612,8 @@ func (ctrl *ContainerController) UpdateContainer(w http.ResponseWriter, r *http.
 	}
 
 	ctrl.logger.Info("Container updated successfully",
-		slog.String("container_id", containerID.String()),
-		slog.String("user_id", user.ID().String()))
+		slog.String("container_id", ucReq.ContainerID.String()),
+		slog.String("user_id", ucReq.UserID.String()))
 
 	httputil.JSON(w, http.StatusOK, response.NewContainerResponse(resp.Container))
 }
@@ -528,3 +686,280 @@ func (ctrl *ContainerController) DeleteContainer(w http.ResponseWriter, r *http.
 
 	httputil.JSON(w, http.StatusOK, map[string]bool{"success": resp.Success})
 }
+
+// ExportContainerTree godoc
+// @Summary Export a collection's container tree as YAML
+// @Description Returns every container of the collection, nested under its parent, as an editable YAML document. Objects are not included.
+// @Tags containers
+// @Produce application/yaml
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {string} string "YAML document"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/tree [get]
+// @Security BearerAuth
+func (ctrl *ContainerController) ExportContainerTree(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.exportContainerTreeUC.Execute(r.Context(), usecases.ExportContainerTreeRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to export container tree", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to export container tree")
+		return
+	}
+
+	filename := sanitizeFilename(resp.CollectionName) + "-containers.yaml"
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp.YAML)
+}
+
+// ImportContainerTree godoc
+// @Summary Import a container tree from YAML
+// @Description Applies an edited container tree to the collection. Entries with an id rename or move that container, entries without one are created. Containers left out of the document are not changed or deleted.
+// @Tags containers
+// @Accept application/yaml
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param dry_run query bool false "Validate and report the changes without saving them"
+// @Param tree body string true "YAML document as returned by the export"
+// @Success 200 {object} response.ImportContainerTreeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/tree [put]
+// @Security BearerAuth
+func (ctrl *ContainerController) ImportContainerTree(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, request.MaxContainerTreeBodySize))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			httputil.Error(w, http.StatusRequestEntityTooLarge, "container tree document is too large")
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	resp, err := ctrl.importContainerTreeUC.Execute(r.Context(), usecases.ImportContainerTreeRequest{
+		CollectionID: collectionID,
+		YAML:         body,
+		DryRun:       dryRun,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecases.ErrInvalidContainerTree):
+			ctrl.logger.Warn("Invalid container tree", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+		default:
+			ctrl.logger.Error("Failed to import container tree", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to import container tree")
+		}
+		return
+	}
+
+	if !dryRun {
+		ctrl.logger.Info("Container tree imported",
+			slog.String("collection_id", collectionID.String()),
+			slog.Int("created", len(resp.Created)),
+			slog.Int("updated", len(resp.Updated)),
+			slog.String("user_id", user.ID().String()))
+	}
+
+	httputil.JSON(w, http.StatusOK, response.ImportContainerTreeResponse{
+		DryRun:    dryRun,
+		Created:   response.NewContainerSummaryListResponse(resp.Created),
+		Updated:   response.NewContainerSummaryListResponse(resp.Updated),
+		Unchanged: resp.Unchanged,
+		Unlisted:  resp.Unlisted,
+	})
+}
+
+// BulkCreateContainers godoc
+// @Summary Create containers in bulk from CSV or JSON rows
+// @Description Creates one container per row (name, type, parent_path, location) to set up a whole hierarchy in one request. parent_path names the parent by container names from the collection root, joined with "/", and may refer to an existing container or one created by another row. Rows are validated together: if any fails, such as a parent path that does not resolve, nothing is created and every problem is reported.
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param dry_run query bool false "Validate the rows and report what would be created without saving"
+// @Param request body request.BulkCreateContainersRequest true "Container rows"
+// @Success 200 {object} response.BulkCreateContainersResponse
+// @Failure 400 {object} response.BulkCreateContainersResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/import [post]
+// @Security BearerAuth
+func (ctrl *ContainerController) BulkCreateContainers(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.BulkCreateContainersRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	rows := make([]usecases.ContainerRow, len(req.Data))
+	for i, row := range req.Data {
+		rows[i] = usecases.ContainerRow{
+			Name:       req.Column(row, "name"),
+			Type:       req.Column(row, "type"),
+			ParentPath: req.Column(row, "parent_path", "parent"),
+			Location:   req.Column(row, "location"),
+		}
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	resp, err := ctrl.bulkCreateContainersUC.Execute(r.Context(), usecases.BulkCreateContainersRequest{
+		CollectionID: collectionID,
+		Rows:         rows,
+		DryRun:       dryRun,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecases.ErrInvalidContainerTree):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+		default:
+			ctrl.logger.Error("Failed to create containers", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to create containers")
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if len(resp.Errors) > 0 {
+		ctrl.logger.Warn("Container rows rejected",
+			slog.String("collection_id", collectionID.String()),
+			slog.Int("errors", len(resp.Errors)))
+		if !dryRun {
+			status = http.StatusBadRequest
+		}
+	} else if !dryRun {
+		ctrl.logger.Info("Containers created in bulk",
+			slog.String("collection_id", collectionID.String()),
+			slog.Int("created", len(resp.Created)),
+			slog.String("user_id", user.ID().String()))
+	}
+
+	httputil.JSON(w, status, response.BulkCreateContainersResponse{
+		DryRun:  dryRun,
+		Created: response.NewContainerSummaryListResponse(resp.Created),
+		Errors:  resp.Errors,
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
 	"github.com/nishiki/backend/domain/entities"
@@ -37,6 +38,7 @@ func TestContainerController_CreateContainer(t *testing.T) {
 			collectionID,
 			testUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -44,6 +46,8 @@ func TestContainerController_CreateContainer(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -83,6 +87,11 @@ func TestContainerController_CreateContainer(t *testing.T) {
 		controller.CreateContainer(rr, req)
 
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var body httputil.ErrorBody
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, httputil.CodeValidationFailed, body.Code)
+		assert.Equal(t, []httputil.FieldError{{Field: "name", Message: "name must be between 1 and 255 characters"}}, body.Fields)
 	})
 
 	t.Run("error - invalid collection ID", func(t *testing.T) {
@@ -118,6 +127,7 @@ func TestContainerController_CreateContainer(t *testing.T) {
 			collectionID,
 			otherUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -125,6 +135,8 @@ func TestContainerController_CreateContainer(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -185,6 +197,7 @@ func TestContainerController_GetContainer(t *testing.T) {
 			collectionID,
 			testUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -192,6 +205,8 @@ func TestContainerController_GetContainer(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -262,6 +277,7 @@ func TestContainerController_GetContainer(t *testing.T) {
 			collectionID,
 			otherUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -269,6 +285,8 @@ func TestContainerController_GetContainer(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -287,3 +305,75 @@ func TestContainerController_GetContainer(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, rr.Code)
 	})
 }
+
+func TestContainerController_PatchContainer(t *testing.T) {
+	t.Parallel()
+
+	c, m := newTestContainer(t)
+	controller := NewContainerController(c, c.GetLogger())
+
+	patchRequest := func(user *entities.User, containerID string, body any) *http.Request {
+		req := newTestRequest(http.MethodPatch, "/containers/"+containerID, body)
+		req.Header.Set("Content-Type", httputil.MergePatchContentType)
+		req.SetPathValue("container_id", containerID)
+		return setAuthContext(req, user, "test-token")
+	}
+
+	t.Run("success - changes sent fields and removes nulls", func(t *testing.T) {
+		testUser := randomUser()
+		collectionID := entities.NewCollectionID()
+
+		containerName, _ := entities.NewContainerName("Freezer")
+		testContainer, _ := entities.NewContainer(entities.ContainerProps{
+			CollectionID:    collectionID,
+			Name:            containerName,
+			Location:        "Garage",
+			Width:           new(60.0),
+			Depth:           new(50.0),
+			TemperatureZone: entities.TemperatureZoneFrozen,
+		})
+
+		m.ContainerRepo.EXPECT().GetByID(gomock.Any(), testContainer.ID()).Return(testContainer, nil)
+		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral), nil)
+		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rr := httptest.NewRecorder()
+		controller.PatchContainer(rr, patchRequest(testUser, testContainer.ID().String(), map[string]any{
+			"depth":            45,
+			"width":            nil,
+			"temperature_zone": nil,
+		}))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp response.ContainerResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "Freezer", resp.Name)
+		assert.Equal(t, "Garage", resp.Location)
+		assert.Nil(t, resp.Width)
+		require.NotNil(t, resp.Depth)
+		assert.Equal(t, 45.0, *resp.Depth)
+		assert.Empty(t, resp.TemperatureZone)
+	})
+
+	t.Run("error - nothing to change", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.PatchContainer(rr, patchRequest(randomUser(), entities.NewContainerID().String(), map[string]any{}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("error - name cannot be removed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.PatchContainer(rr, patchRequest(randomUser(), entities.NewContainerID().String(), map[string]any{"name": nil}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("error - invalid parent container ID", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.PatchContainer(rr, patchRequest(randomUser(), entities.NewContainerID().String(), map[string]any{"parent_container_id": "not-an-id"}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,355 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type ContainerTemplateController struct {
+	listContainerTemplatesUC       *usecases.ListContainerTemplatesUseCase
+	saveContainerTemplateUC        *usecases.SaveContainerTemplateUseCase
+	deleteContainerTemplateUC      *usecases.DeleteContainerTemplateUseCase
+	createContainersFromTemplateUC *usecases.CreateContainersFromTemplateUseCase
+	logger                         *slog.Logger
+}
+
+func NewContainerTemplateController(
+	c *container.Container,
+	logger *slog.Logger,
+) *ContainerTemplateController {
+	return &ContainerTemplateController{
+		listContainerTemplatesUC:       usecases.NewListContainerTemplatesUseCase(c.ContainerTemplateRepo),
+		saveContainerTemplateUC:        usecases.NewSaveContainerTemplateUseCase(c.ContainerTemplateRepo, c.CollectionRepo, c.AuthService),
+		deleteContainerTemplateUC:      usecases.NewDeleteContainerTemplateUseCase(c.ContainerTemplateRepo),
+		createContainersFromTemplateUC: usecases.NewCreateContainersFromTemplateUseCase(c.ContainerRepo, c.CollectionRepo, c.ContainerTemplateRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService),
+		logger:                         logger,
+	}
+}
+
+// ListContainerTemplates godoc
+// @Summary List container templates
+// @Description Returns the built-in container layouts followed by the templates the user has saved
+// @Tags containers
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {array} response.ContainerTemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/container-templates [get]
+// @Security BearerAuth
+func (ctrl *ContainerTemplateController) ListContainerTemplates(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.listContainerTemplatesUC.Execute(r.Context(), usecases.ListContainerTemplatesRequest{UserID: user.ID()})
+	if err != nil {
+		ctrl.logger.Error("Failed to list container templates", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list container templates")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewContainerTemplateListResponse(resp.Templates))
+}
+
+// SaveContainerTemplate godoc
+// @Summary Save a container tree as a template
+// @Description Saves the containers of a collection, or one container and everything nested in it, as a reusable template. Objects are not included.
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param template body request.SaveContainerTemplateRequest true "Template source"
+// @Success 201 {object} response.ContainerTemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/container-templates [post]
+// @Security BearerAuth
+func (ctrl *ContainerTemplateController) SaveContainerTemplate(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.SaveContainerTemplateRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	collectionID, _ := entities.CollectionIDFromString(req.CollectionID)
+	var rootID *entities.ContainerID
+	if req.ContainerID != nil {
+		id, _ := entities.ContainerIDFromString(*req.ContainerID)
+		rootID = &id
+	}
+
+	resp, err := ctrl.saveContainerTemplateUC.Execute(r.Context(), usecases.SaveContainerTemplateRequest{
+		CollectionID:    collectionID,
+		RootContainerID: rootID,
+		Name:            req.Name,
+		Description:     req.Description,
+		UserID:          user.ID(),
+		UserToken:       userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to save container template", slog.Any("error", err))
+		switch {
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "not found")
+		case errors.Is(err, entities.ErrEmptyContainerTemplate),
+			errors.Is(err, entities.ErrContainerTemplateTooLarge),
+			errors.Is(err, entities.ErrInvalidContainerTemplateName):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to save container template")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Container template saved",
+		slog.String("template_id", resp.Template.ID().String()),
+		slog.String("user_id", user.ID().String()),
+		slog.Int("containers", resp.Template.ContainerCount()))
+
+	httputil.JSON(w, http.StatusCreated, response.NewContainerTemplateResponse(resp.Template))
+}
+
+// DeleteContainerTemplate godoc
+// @Summary Delete a saved container template
+// @Description Deletes one of the user's own templates. Built-in templates cannot be deleted.
+// @Tags containers
+// @Param id path string true "User ID"
+// @Param template_id path string true "Template ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/container-templates/{template_id} [delete]
+// @Security BearerAuth
+func (ctrl *ContainerTemplateController) DeleteContainerTemplate(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	templateID, err := request.GetContainerTemplateIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteContainerTemplateUC.Execute(r.Context(), usecases.DeleteContainerTemplateRequest{
+		TemplateID: templateID,
+		UserID:     user.ID(),
+	})
+	switch {
+	case errors.Is(err, entities.ErrBuiltInContainerTemplate):
+		httputil.Error(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, entities.ErrContainerTemplateNotFound):
+		httputil.Error(w, http.StatusNotFound, "container template not found")
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to delete container template", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to delete container template")
+		return
+	}
+
+	ctrl.logger.Info("Container template deleted",
+		slog.String("template_id", templateID.String()),
+		slog.String("user_id", user.ID().String()))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateContainersFromTemplate godoc
+// @Summary Create containers from a template
+// @Description Creates every container in a built-in or saved template inside the collection, optionally nested under an existing container
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param template body request.CreateContainersFromTemplateRequest true "Template to apply"
+// @Success 201 {array} response.ContainerResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/from-template [post]
+// @Security BearerAuth
+func (ctrl *ContainerTemplateController) CreateContainersFromTemplate(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.CreateContainersFromTemplateRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	templateID, _ := entities.ContainerTemplateIDFromString(req.TemplateID)
+	ucReq := usecases.CreateContainersFromTemplateRequest{
+		CollectionID: collectionID,
+		TemplateID:   templateID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	}
+	if req.ParentContainerID != nil {
+		pid, _ := entities.ContainerIDFromString(*req.ParentContainerID)
+		ucReq.ParentContainerID = &pid
+	}
+	if req.GroupID != nil {
+		gid, _ := entities.GroupIDFromString(*req.GroupID)
+		ucReq.GroupID = &gid
+	}
+
+	resp, err := ctrl.createContainersFromTemplateUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to create containers from template", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		switch {
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case errors.Is(err, entities.ErrContainerTemplateNotFound):
+			httputil.Error(w, http.StatusNotFound, "container template not found")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "not found")
+		case strings.Contains(err.Error(), "cannot have children"):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to create containers from template")
+		}
+		return
+	}
+
+	containers := make(response.ContainerListResponse, len(resp.Containers))
+	for i, c := range resp.Containers {
+		containers[i] = response.NewContainerResponse(c)
+	}
+
+	ctrl.logger.Info("Containers created from template",
+		slog.String("template_id", templateID.String()),
+		slog.String("collection_id", collectionID.String()),
+		slog.Int("containers", len(containers)),
+		slog.String("creator_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusCreated, containers)
+}
@@ -1,8 +1,12 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json/v2"
+	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -17,6 +21,7 @@ import (
 
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/app/importjobs"
 	"github.com/nishiki/backend/domain/entities"
 )
 
@@ -60,6 +65,7 @@ func newTestCollection(userID entities.UserID, collectionID entities.CollectionI
 		collectionID,
 		userID,
 		nil,
+		false,
 		collectionName,
 		nil,
 		objectType,
@@ -67,11 +73,46 @@ func newTestCollection(userID entities.UserID, collectionID entities.CollectionI
 		[]string{},
 		"",
 		nil,
+		nil,
+		nil,
 		time.Now(),
 		time.Now(),
 	)
 }
 
+// runCollectionImport calls BulkImportToCollection, waits for the queued
+// job to finish and returns its result as GET /imports/{job_id} reports it.
+func runCollectionImport(t *testing.T, controller *ObjectController, req *http.Request) response.BulkImportResponse {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	controller.BulkImportToCollection(rr, req)
+	require.Equal(t, http.StatusAccepted, rr.Code)
+
+	var queued response.ImportJobResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &queued))
+	state := waitForImportJob(t, controller.importJobs, queued.JobID)
+	require.Equal(t, importjobs.StatusCompleted, state.Status, "import failed: %v", state.Err)
+
+	result := newImportJobResponse(state).Result
+	require.NotNil(t, result)
+	return *result
+}
+
+// waitForImportJob blocks until the job with id has finished
+func waitForImportJob(t *testing.T, jobs *importjobs.Queue, id string) importjobs.State {
+	t.Helper()
+
+	job, ok := jobs.Get(id)
+	require.True(t, ok, "queued job should be known")
+	updates, cancel := job.Subscribe()
+	defer cancel()
+	var state importjobs.State
+	for state = range updates {
+	}
+	return state
+}
+
 // TestBulkImportToCollection_ElectronicSuppliesCSV tests importing the Electronic_Supplies.csv
 // file using location-based distribution. The CSV has a "Location" column with values like
 // OD1, OD4, OD5, Desk, Kitchen, Car, KD3 — each should become a container.
@@ -105,7 +146,7 @@ func TestBulkImportToCollection_ElectronicSuppliesCSV(t *testing.T) {
 
 		// Mock auth and collection lookup
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 
 		// Location distribution fetches existing containers (none initially)
 		m.ContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{}, nil)
@@ -136,13 +177,7 @@ func TestBulkImportToCollection_ElectronicSuppliesCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total, "total should match input rows")
 		assert.Equal(t, len(data), resp.Imported, "all valid rows should import")
@@ -156,7 +191,7 @@ func TestBulkImportToCollection_ElectronicSuppliesCSV(t *testing.T) {
 		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral)
 
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 
 		// Default distribution creates a "Default Container" since collection has no containers
 		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -176,13 +211,7 @@ func TestBulkImportToCollection_ElectronicSuppliesCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total)
 		assert.Equal(t, len(data), resp.Imported)
@@ -195,7 +224,7 @@ func TestBulkImportToCollection_ElectronicSuppliesCSV(t *testing.T) {
 		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral)
 
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 
 		// Schema inference triggers collection update for schema save, then again for container add
 		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -216,13 +245,7 @@ func TestBulkImportToCollection_ElectronicSuppliesCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total)
 		assert.Equal(t, len(data), resp.Imported)
@@ -252,7 +275,7 @@ func TestBulkImportToCollection_LibibBooksCSV(t *testing.T) {
 		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeBook)
 
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 
 		// Default distribution path
 		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -272,13 +295,7 @@ func TestBulkImportToCollection_LibibBooksCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total)
 		assert.Equal(t, len(data), resp.Imported, "all books should import successfully")
@@ -291,7 +308,7 @@ func TestBulkImportToCollection_LibibBooksCSV(t *testing.T) {
 		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeBook)
 
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
@@ -309,13 +326,7 @@ func TestBulkImportToCollection_LibibBooksCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total)
 		assert.Equal(t, len(data), resp.Imported)
@@ -344,7 +355,7 @@ func TestBulkImportToCollection_LibibVideoGamesCSV(t *testing.T) {
 		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeVideoGame)
 
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
@@ -361,13 +372,7 @@ func TestBulkImportToCollection_LibibVideoGamesCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total)
 		assert.Equal(t, len(data), resp.Imported)
@@ -396,7 +401,7 @@ func TestBulkImportToCollection_LibibMusicCSV(t *testing.T) {
 		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeMusic)
 
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
@@ -413,13 +418,7 @@ func TestBulkImportToCollection_LibibMusicCSV(t *testing.T) {
 		req.SetPathValue("collection_id", collectionID.String())
 		req = setAuthContext(req, testUser, "test-token")
 
-		rr := httptest.NewRecorder()
-		controller.BulkImportToCollection(rr, req)
-
-		assert.Equal(t, http.StatusOK, rr.Code)
-
-		var resp response.BulkImportResponse
-		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		resp := runCollectionImport(t, controller, req)
 
 		assert.Equal(t, len(data), resp.Total)
 		assert.Equal(t, len(data), resp.Imported)
@@ -481,7 +480,7 @@ func TestBulkImport_ElectronicSuppliesCSV(t *testing.T) {
 
 		m.ContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(testContainer, nil)
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
-		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
 		m.ContainerRepo.EXPECT().Update(gomock.Any(), testContainer).Return(nil)
 
 		requestBody := request.BulkImportRequest{
@@ -591,3 +590,123 @@ func TestBulkImportToCollection_ValidationErrors(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 }
+
+// TestBulkImportToCollection_AccessErrors tests that missing and foreign
+// collections are refused up front instead of being queued.
+func TestBulkImportToCollection_AccessErrors(t *testing.T) {
+	t.Parallel()
+
+	c, m := newTestContainer(t)
+	controller := NewObjectController(c, c.GetLogger())
+
+	importRequest := func(user *entities.User, collectionID entities.CollectionID) *http.Request {
+		req := newTestRequest(http.MethodPost,
+			"/accounts/"+user.ID().String()+"/collections/"+collectionID.String()+"/import",
+			request.BulkImportCollectionRequest{
+				Format: "json",
+				Data:   []map[string]any{{"name": "Hammer"}},
+			},
+		)
+		req.SetPathValue("id", user.ID().String())
+		req.SetPathValue("collection_id", collectionID.String())
+		return setAuthContext(req, user, "test-token")
+	}
+
+	t.Run("collection not found", func(t *testing.T) {
+		testUser := randomUser()
+		collectionID := entities.NewCollectionID()
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(nil, errors.New("not found"))
+
+		rr := httptest.NewRecorder()
+		controller.BulkImportToCollection(rr, importRequest(testUser, collectionID))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("another user's collection", func(t *testing.T) {
+		testUser := randomUser()
+		collectionID := entities.NewCollectionID()
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).
+			Return(newTestCollection(entities.NewUserID(), collectionID, entities.ObjectTypeGeneral), nil)
+
+		rr := httptest.NewRecorder()
+		controller.BulkImportToCollection(rr, importRequest(testUser, collectionID))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+// TestBulkImportToCollection_FileUpload tests importing a CSV file uploaded
+// as multipart/form-data, as the frontend's import dialog sends it.
+func TestBulkImportToCollection_FileUpload(t *testing.T) {
+	t.Parallel()
+
+	c, m := newTestContainer(t)
+	controller := NewObjectController(c, c.GetLogger())
+
+	newUpload := func(t *testing.T, userID, collectionID string, filename, content string, fields map[string]string) *http.Request {
+		t.Helper()
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, err := mw.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		for k, v := range fields {
+			require.NoError(t, mw.WriteField(k, v))
+		}
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"/accounts/"+userID+"/collections/"+collectionID+"/import", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.SetPathValue("id", userID)
+		req.SetPathValue("collection_id", collectionID)
+		return req
+	}
+
+	t.Run("csv file with omitted columns", func(t *testing.T) {
+		testUser := randomUser()
+		collectionID := entities.NewCollectionID()
+		testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral)
+
+		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
+		m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		var saved []entities.Object
+		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ctr *entities.Container) error {
+			saved = append(saved, ctr.Objects()...)
+			return nil
+		}).AnyTimes()
+
+		csvFile := "\ufeffItem,Quantity,Internal ID,Color\nCable,3,X-1,red\nAdapter,1,X-2,\n,,,\n"
+		req := newUpload(t, testUser.ID().String(), collectionID.String(), "supplies.csv", csvFile, map[string]string{
+			"name_column":  "Item",
+			"omit_columns": "Internal ID",
+		})
+		req = setAuthContext(req, testUser, "test-token")
+
+		resp := runCollectionImport(t, controller, req)
+
+		assert.Equal(t, 2, resp.Total, "the empty trailing row is skipped")
+		assert.Equal(t, 2, resp.Imported)
+		require.Len(t, saved, 2)
+		for _, obj := range saved {
+			assert.NotContains(t, obj.Properties(), "Internal ID")
+		}
+	})
+
+	t.Run("unsupported file", func(t *testing.T) {
+		testUser := randomUser()
+		collectionID := entities.NewCollectionID()
+
+		req := newUpload(t, testUser.ID().String(), collectionID.String(), "supplies.xlsx", "PK", nil)
+		req = setAuthContext(req, testUser, "test-token")
+
+		rr := httptest.NewRecorder()
+		controller.BulkImportToCollection(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "format must be")
+	})
+}
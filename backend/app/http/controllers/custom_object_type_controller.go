@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type CustomObjectTypeController struct {
+	listTypesUC  *usecases.ListCustomObjectTypesUseCase
+	createTypeUC *usecases.CreateCustomObjectTypeUseCase
+	updateTypeUC *usecases.UpdateCustomObjectTypeUseCase
+	deleteTypeUC *usecases.DeleteCustomObjectTypeUseCase
+	logger       *slog.Logger
+}
+
+func NewCustomObjectTypeController(
+	c *container.Container,
+	logger *slog.Logger,
+) *CustomObjectTypeController {
+	return &CustomObjectTypeController{
+		listTypesUC:  usecases.NewListCustomObjectTypesUseCase(c.ObjectTypeRepo),
+		createTypeUC: usecases.NewCreateCustomObjectTypeUseCase(c.ObjectTypeRepo),
+		updateTypeUC: usecases.NewUpdateCustomObjectTypeUseCase(c.ObjectTypeRepo),
+		deleteTypeUC: usecases.NewDeleteCustomObjectTypeUseCase(c.ObjectTypeRepo, c.CollectionRepo),
+		logger:       logger,
+	}
+}
+
+// writeObjectTypeError maps object type errors shared by every handler here
+// to a status, falling back to 500 with fallback as the message.
+func (ctrl *CustomObjectTypeController) writeObjectTypeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, entities.ErrCustomObjectTypeNotFound):
+		httputil.Error(w, http.StatusNotFound, "object type not found")
+	case errors.Is(err, entities.ErrCustomObjectTypeExists),
+		errors.Is(err, entities.ErrCustomObjectTypeInUse):
+		httputil.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, entities.ErrInvalidObjectTypeKey),
+		errors.Is(err, entities.ErrInvalidObjectTypeName),
+		errors.Is(err, entities.ErrInvalidObjectTypeIcon),
+		errors.Is(err, entities.ErrInvalidObjectTypeColor),
+		errors.Is(err, entities.ErrBuiltInObjectType):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		ctrl.logger.Error(fallback, slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// ListObjectTypes godoc
+// @Summary List object types
+// @Description Returns the built-in object types followed by the user's custom ones, ordered by name
+// @Tags object-types
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.ObjectTypeListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/object-types [get]
+// @Security BearerAuth
+func (ctrl *CustomObjectTypeController) ListObjectTypes(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.listTypesUC.Execute(r.Context(), usecases.ListCustomObjectTypesRequest{
+		UserID: user.ID(),
+	})
+	if err != nil {
+		ctrl.writeObjectTypeError(w, err, "failed to list object types")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewObjectTypeListResponse(resp.ObjectTypes))
+}
+
+// CreateObjectType godoc
+// @Summary Create a custom object type
+// @Description Defines an object type beyond the built-in ones, with an icon, color and the fields new collections of the type start with
+// @Tags object-types
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_type body request.CreateCustomObjectTypeRequest true "Object type"
+// @Success 201 {object} response.ObjectTypeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/object-types [post]
+// @Security BearerAuth
+func (ctrl *CustomObjectTypeController) CreateObjectType(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.CreateCustomObjectTypeRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.createTypeUC.Execute(r.Context(), usecases.CreateCustomObjectTypeRequest{
+		Key:    entities.ObjectType(req.Key),
+		Name:   req.Name,
+		Icon:   req.Icon,
+		Color:  req.Color,
+		Schema: req.Schema.ToEntity(),
+		UserID: user.ID(),
+	})
+	if err != nil {
+		ctrl.writeObjectTypeError(w, err, "failed to create object type")
+		return
+	}
+
+	ctrl.logger.Info("Custom object type created",
+		slog.String("object_type", resp.ObjectType.Key().String()),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusCreated, response.NewObjectTypeResponse(resp.ObjectType))
+}
+
+// UpdateObjectType godoc
+// @Summary Update a custom object type
+// @Description Changes a custom type's name, icon, color or field schema. The key stays the same; collections already created keep their own schema.
+// @Tags object-types
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param type_id path string true "Object type ID"
+// @Param object_type body request.UpdateCustomObjectTypeRequest true "Fields to change"
+// @Success 200 {object} response.ObjectTypeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/object-types/{type_id} [put]
+// @Security BearerAuth
+func (ctrl *CustomObjectTypeController) UpdateObjectType(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	typeID, err := request.GetCustomObjectTypeIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateCustomObjectTypeRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.updateTypeUC.Execute(r.Context(), usecases.UpdateCustomObjectTypeRequest{
+		TypeID: typeID,
+		Name:   req.Name,
+		Icon:   req.Icon,
+		Color:  req.Color,
+		Schema: req.Schema.ToEntity(),
+		UserID: user.ID(),
+	})
+	if err != nil {
+		ctrl.writeObjectTypeError(w, err, "failed to update object type")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewObjectTypeResponse(resp.ObjectType))
+}
+
+// DeleteObjectType godoc
+// @Summary Delete a custom object type
+// @Description Deletes a custom object type no collection of the user's uses any more
+// @Tags object-types
+// @Param id path string true "User ID"
+// @Param type_id path string true "Object type ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/object-types/{type_id} [delete]
+// @Security BearerAuth
+func (ctrl *CustomObjectTypeController) DeleteObjectType(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	typeID, err := request.GetCustomObjectTypeIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteTypeUC.Execute(r.Context(), usecases.DeleteCustomObjectTypeRequest{
+		TypeID: typeID,
+		UserID: user.ID(),
+	})
+	if err != nil {
+		ctrl.writeObjectTypeError(w, err, "failed to delete object type")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// dashboardDefaultExpiringDays is how far ahead the dashboard counts expiring
+// objects when the request does not say.
+const dashboardDefaultExpiringDays = 7
+
+type DashboardController struct {
+	getDashboardUC *usecases.GetDashboardUseCase
+	adminGroup     string
+	logger         *slog.Logger
+}
+
+func NewDashboardController(
+	c *container.Container,
+	logger *slog.Logger,
+) *DashboardController {
+	return &DashboardController{
+		getDashboardUC: usecases.NewGetDashboardUseCase(c.CollectionRepo, c.ContainerRepo, c.PreferencesRepo, c.AuthService),
+		adminGroup:     c.GetConfig().Auth.AdminGroup,
+		logger:         logger,
+	}
+}
+
+// GetDashboard godoc
+// @Summary Get the dashboard
+// @Description The current user, their groups and their collections with low stock and expiring counts, in one response for the client's initial load. Expiring counts look days ahead (default 7, at most 366).
+// @Tags dashboard
+// @Produce json
+// @Param id path string true "User ID"
+// @Param days query int false "How many days ahead objects count as expiring"
+// @Param sort query string false "Collection sort field (name, created_at, updated_at, custom); pinned collections always come first"
+// @Param order query string false "Sort order (asc, desc)"
+// @Success 200 {object} response.DashboardResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/dashboard [get]
+// @Security BearerAuth
+func (ctrl *DashboardController) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	claims, claimsExist := middleware.GetCurrentClaims(r)
+	if !claimsExist {
+		ctrl.logger.Error("No auth claims found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	days, err := request.GetExpiringDaysFromQuery(r, dashboardDefaultExpiringDays)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sortOpts, err := request.GetSortOptionsFromQuery(r, usecases.CollectionSortFields)
+	if err != nil {
+		ctrl.logger.Warn("Invalid sort parameters", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.getDashboardUC.Execute(r.Context(), usecases.GetDashboardRequest{
+		UserID:       pathUserID,
+		UserToken:    userToken,
+		Now:          time.Now(),
+		ExpiringDays: days,
+		Sort:         sortOpts,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrInvalidExpiringDays) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to get dashboard", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get dashboard")
+		return
+	}
+
+	dashboard := response.DashboardResponse{
+		User:            response.NewAuthInfoResponse(user, claims, claims.InGroup(ctrl.adminGroup)),
+		Groups:          response.NewGroupListResponse(resp.Groups),
+		Collections:     response.NewCollectionListResponse(resp.Collections),
+		CollectionStats: make([]response.DashboardCollectionStatsResponse, len(resp.Stats)),
+		ExpiringDays:    days,
+	}
+	for i, stats := range resp.Stats {
+		// The collections are summaries, so their low stock count is only known here
+		dashboard.Collections[i].LowStockCount = stats.LowStockCount
+		dashboard.CollectionStats[i] = response.DashboardCollectionStatsResponse{
+			CollectionID:  resp.Collections[i].ID().String(),
+			ObjectCount:   stats.ObjectCount,
+			LowStockCount: stats.LowStockCount,
+			ExpiringCount: stats.ExpiringCount,
+			ExpiredCount:  stats.ExpiredCount,
+		}
+		dashboard.ExpiringCount += stats.ExpiringCount
+		dashboard.ExpiredCount += stats.ExpiredCount
+	}
+
+	ctrl.logger.Debug("Dashboard retrieved successfully",
+		slog.String("user_id", user.ID().String()),
+		slog.Int("group_count", len(resp.Groups)),
+		slog.Int("collection_count", len(resp.Collections)))
+
+	httputil.JSON(w, http.StatusOK, dashboard)
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/devtools"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// DevController serves development helpers. Its routes are only registered
+// in debug mode.
+type DevController struct {
+	container *container.Container
+	logger    *slog.Logger
+}
+
+func NewDevController(
+	c *container.Container,
+	logger *slog.Logger,
+) *DevController {
+	return &DevController{
+		container: c,
+		logger:    logger,
+	}
+}
+
+// Seed godoc
+// @Summary Seed fixture data
+// @Description Debug mode only. Creates collections of generated containers and objects owned by the caller, optionally shared through a new group with extra local accounts in it
+// @Tags dev
+// @Accept json
+// @Produce json
+// @Param request body request.SeedRequest true "What to create"
+// @Success 201 {object} response.SeedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /dev/seed [post]
+// @Security BearerAuth
+func (ctrl *DevController) Seed(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var req request.SeedRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+	req = req.WithDefaults()
+
+	// Seeded accounts can only sign in where Nishiki keeps the passwords
+	if _, ok := ctrl.container.AuthService.(services.PasswordAuthenticator); req.Users > 0 && !ok {
+		httputil.Error(w, http.StatusBadRequest, "users can only be seeded in local auth mode")
+		return
+	}
+
+	seed := rand.Uint64()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	seeder := devtools.NewSeeder(ctrl.container, seed)
+	resp := response.SeedResponse{
+		Users:       []response.SeededUserResponse{},
+		Collections: []response.SeededCollectionResponse{},
+	}
+
+	// Usernames carry a fresh batch prefix so seeding twice never collides
+	batch := entities.NewUserID().String()[:8]
+	members := make([]*entities.User, req.Users)
+	for i := range members {
+		member, err := devtools.NewUser(fmt.Sprintf("dev-%s-%d", batch, i+1))
+		if err == nil {
+			err = seeder.LocalAccount(r.Context(), member, req.Password)
+		}
+		if err != nil {
+			ctrl.logger.Error("Failed to seed user", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to seed users")
+			return
+		}
+		members[i] = member
+		resp.Users = append(resp.Users, response.SeededUserResponse{ID: member.ID().String(), Username: member.Username().String()})
+	}
+
+	var groupID *entities.GroupID
+	if req.Group != "" {
+		group, err := seeder.Group(r.Context(), userToken, req.Group, user, members...)
+		if err != nil {
+			if errors.Is(err, services.ErrGroupsReadOnly) {
+				httputil.Error(w, http.StatusConflict, err.Error())
+				return
+			}
+			ctrl.logger.Error("Failed to seed group", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to seed group")
+			return
+		}
+		id := group.ID()
+		groupID = &id
+		resp.GroupID = id.String()
+	}
+
+	for range req.Collections {
+		collection, err := seeder.Collection(r.Context(), user.ID(), devtools.CollectionSpec{
+			ObjectType: entities.ObjectType(req.ObjectType),
+			Containers: req.Containers,
+			Objects:    req.Objects,
+			GroupID:    groupID,
+		})
+		if err != nil {
+			ctrl.logger.Error("Failed to seed collection", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to seed collections")
+			return
+		}
+		objects := 0
+		for _, c := range collection.Containers() {
+			objects += len(c.Objects())
+		}
+		resp.Collections = append(resp.Collections, response.SeededCollectionResponse{
+			ID:         collection.ID().String(),
+			Name:       collection.Name().String(),
+			Containers: len(collection.Containers()),
+			Objects:    objects,
+		})
+	}
+
+	ctrl.logger.Info("Seeded fixture data",
+		slog.String("user_id", user.ID().String()),
+		slog.Int("collections", len(resp.Collections)),
+		slog.Int("users", len(resp.Users)),
+		slog.Uint64("seed", seed))
+	httputil.JSON(w, http.StatusCreated, resp)
+}
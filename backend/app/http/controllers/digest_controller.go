@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// unsubscribeConfirmPage is what an unsubscribe link opens. Unsubscribing
+// takes a POST, so link scanners and prefetchers that follow the link do not
+// turn the digest off.
+var unsubscribeConfirmPage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body style="font-family: sans-serif; text-align: center; margin-top: 64px;">
+<h1>Unsubscribe from Nishiki digests?</h1>
+<p>You will no longer receive Nishiki digest emails. You can turn them back on from your profile.</p>
+<form method="post">
+<input type="hidden" name="token" value="{{.}}">
+<button type="submit">Unsubscribe</button>
+</form>
+</body></html>`))
+
+const unsubscribedPage = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Unsubscribed</title></head>
+<body style="font-family: sans-serif; text-align: center; margin-top: 64px;">
+<h1>You have been unsubscribed</h1>
+<p>You will no longer receive Nishiki digest emails. You can turn them back on from your profile.</p>
+</body></html>`
+
+type DigestController struct {
+	getDigestPreferencesUC    *usecases.GetDigestPreferencesUseCase
+	updateDigestPreferencesUC *usecases.UpdateDigestPreferencesUseCase
+	unsubscribeDigestUC       *usecases.UnsubscribeDigestUseCase
+	logger                    *slog.Logger
+}
+
+func NewDigestController(
+	c *container.Container,
+	logger *slog.Logger,
+) *DigestController {
+	return &DigestController{
+		getDigestPreferencesUC:    usecases.NewGetDigestPreferencesUseCase(c.DigestSubscriptionRepo),
+		updateDigestPreferencesUC: usecases.NewUpdateDigestPreferencesUseCase(c.DigestSubscriptionRepo, c.AuthService),
+		unsubscribeDigestUC:       usecases.NewUnsubscribeDigestUseCase(c.DigestSubscriptionRepo),
+		logger:                    logger,
+	}
+}
+
+// GetDigestPreferences godoc
+// @Summary Get email digest preferences
+// @Description Get the current user's expiring/low-stock digest settings
+// @Tags digest
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.DigestPreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/digest-preferences [get]
+// @Security BearerAuth
+func (ctrl *DigestController) GetDigestPreferences(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.getDigestPreferencesUC.Execute(r.Context(), usecases.GetDigestPreferencesRequest{
+		UserID: user.ID(),
+		Email:  user.EmailAddress(),
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to get digest preferences", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get digest preferences")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.DigestPreferencesResponse{
+		Email:      resp.Email.String(),
+		Frequency:  resp.Frequency.String(),
+		LastSentAt: resp.LastSentAt,
+	})
+}
+
+// UpdateDigestPreferences godoc
+// @Summary Update email digest preferences
+// @Description Set how often the current user receives the digest email, or turn it off. Digests are sent to the account's email address.
+// @Tags digest
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param preferences body request.UpdateDigestPreferencesRequest true "Digest preferences"
+// @Success 200 {object} response.DigestPreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/digest-preferences [put]
+// @Security BearerAuth
+func (ctrl *DigestController) UpdateDigestPreferences(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.UpdateDigestPreferencesRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	frequency, err := req.GetFrequency()
+	if err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	// Digests only go to the account's own, verified address
+	resp, err := ctrl.updateDigestPreferencesUC.Execute(r.Context(), usecases.UpdateDigestPreferencesRequest{
+		UserID:    user.ID(),
+		UserToken: userToken,
+		Email:     user.EmailAddress(),
+		Frequency: frequency,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to update digest preferences", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to update digest preferences")
+		return
+	}
+
+	ctrl.logger.Info("Digest preferences updated",
+		slog.String("user_id", user.ID().String()),
+		slog.String("frequency", frequency.String()))
+
+	httputil.JSON(w, http.StatusOK, response.DigestPreferencesResponse{
+		Email:      resp.Subscription.Email().String(),
+		Frequency:  resp.Subscription.Frequency().String(),
+		LastSentAt: resp.Subscription.LastSentAt(),
+	})
+}
+
+// UnsubscribePage godoc
+// @Summary Confirm unsubscribing from the email digest
+// @Description Shows a confirmation page for the unsubscribe link in a digest email. Nothing changes until the page's form is submitted.
+// @Tags digest
+// @Produce html
+// @Param token query string true "Unsubscribe token from the digest email"
+// @Success 200 {string} string "Confirmation page"
+// @Failure 400 {object} map[string]string
+// @Router /digest/unsubscribe [get]
+func (ctrl *DigestController) UnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	token, err := request.GetUnsubscribeToken(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := unsubscribeConfirmPage.Execute(w, token); err != nil {
+		ctrl.logger.Error("Failed to render unsubscribe page", slog.Any("error", err))
+	}
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from the email digest
+// @Description Turns off the digest for the subscription identified by the token, from the confirmation page's form or a mail client's one-click unsubscribe (RFC 8058).
+// @Tags digest
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param token query string false "Unsubscribe token from the digest email"
+// @Param token formData string false "Unsubscribe token, when not in the query"
+// @Success 200 {string} string "Unsubscribed page"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /digest/unsubscribe [post]
+func (ctrl *DigestController) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token, err := request.GetUnsubscribeToken(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctrl.unsubscribeDigestUC.Execute(r.Context(), usecases.UnsubscribeDigestRequest{Token: token}); err != nil {
+		if errors.Is(err, entities.ErrDigestSubscriptionNotFound) {
+			httputil.Error(w, http.StatusNotFound, "unknown unsubscribe link")
+			return
+		}
+		ctrl.logger.Error("Failed to unsubscribe from digest", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to unsubscribe")
+		return
+	}
+
+	ctrl.logger.Info("Digest unsubscribed via email link")
+	httputil.Data(w, http.StatusOK, "text/html; charset=utf-8", []byte(unsubscribedPage))
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestDigestController_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	newSubscription := func(t *testing.T) *entities.DigestSubscription {
+		email, err := entities.NewEmailAddress("alice@example.com")
+		require.NoError(t, err)
+		return entities.ReconstructDigestSubscription(entities.NewUserID(), email, entities.DigestFrequencyWeekly, nil, "tok", nil, time.Now(), time.Now())
+	}
+
+	t.Run("GET only asks for confirmation", func(t *testing.T) {
+		t.Parallel()
+		c, _ := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+
+		w := httptest.NewRecorder()
+		controller.UnsubscribePage(w, httptest.NewRequest(http.MethodGet, "/digest/unsubscribe?token=%22tok%22", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<form method="post">`)
+		assert.Contains(t, w.Body.String(), `value="&#34;tok&#34;"`)
+	})
+
+	t.Run("GET without a token", func(t *testing.T) {
+		t.Parallel()
+		c, _ := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+
+		w := httptest.NewRecorder()
+		controller.UnsubscribePage(w, httptest.NewRequest(http.MethodGet, "/digest/unsubscribe", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("one-click POST", func(t *testing.T) {
+		t.Parallel()
+		c, m := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+		subscription := newSubscription(t)
+		m.DigestRepo.EXPECT().GetByUnsubscribeToken(gomock.Any(), "tok").Return(subscription, nil)
+		m.DigestRepo.EXPECT().Save(gomock.Any(), subscription).Return(nil)
+
+		r := httptest.NewRequest(http.MethodPost, "/digest/unsubscribe?token=tok", strings.NewReader("List-Unsubscribe=One-Click"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		controller.Unsubscribe(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, entities.DigestFrequencyOff, subscription.Frequency())
+	})
+
+	t.Run("confirmation form POST", func(t *testing.T) {
+		t.Parallel()
+		c, m := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+		subscription := newSubscription(t)
+		m.DigestRepo.EXPECT().GetByUnsubscribeToken(gomock.Any(), "tok").Return(subscription, nil)
+		m.DigestRepo.EXPECT().Save(gomock.Any(), subscription).Return(nil)
+
+		r := httptest.NewRequest(http.MethodPost, "/digest/unsubscribe", strings.NewReader("token=tok"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		controller.Unsubscribe(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		t.Parallel()
+		c, m := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+		m.DigestRepo.EXPECT().GetByUnsubscribeToken(gomock.Any(), "nope").Return(nil, entities.ErrDigestSubscriptionNotFound)
+
+		w := httptest.NewRecorder()
+		controller.Unsubscribe(w, httptest.NewRequest(http.MethodPost, "/digest/unsubscribe?token=nope", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDigestController_UpdateDigestPreferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("an address in the body is not used", func(t *testing.T) {
+		t.Parallel()
+		c, m := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+		user := randomUser()
+
+		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", user.ID().String()).Return(nil, nil)
+		m.DigestRepo.EXPECT().GetByUserID(gomock.Any(), user.ID()).Return(nil, entities.ErrDigestSubscriptionNotFound)
+		m.DigestRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s *entities.DigestSubscription) error {
+				assert.Equal(t, user.EmailAddress(), s.Email())
+				return nil
+			})
+
+		req := newTestRequest(http.MethodPut, "/accounts/"+user.ID().String()+"/digest-preferences", map[string]string{
+			"frequency": "weekly",
+			"email":     "someone-else@example.com",
+		})
+		req.SetPathValue("id", user.ID().String())
+		req = setAuthContext(req, user, "test-token")
+		w := httptest.NewRecorder()
+		controller.UpdateDigestPreferences(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), user.EmailAddress().String())
+		assert.NotContains(t, w.Body.String(), "someone-else@example.com")
+	})
+
+	t.Run("invalid frequency", func(t *testing.T) {
+		t.Parallel()
+		c, _ := newTestContainer(t)
+		controller := NewDigestController(c, c.GetLogger())
+		user := randomUser()
+
+		req := newTestRequest(http.MethodPut, "/accounts/"+user.ID().String()+"/digest-preferences", map[string]string{"frequency": "hourly"})
+		req.SetPathValue("id", user.ID().String())
+		req = setAuthContext(req, user, "test-token")
+		w := httptest.NewRecorder()
+		controller.UpdateDigestPreferences(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type FeatureController struct {
+	featuresUC *usecases.FeatureFlagUseCase
+	logger     *slog.Logger
+}
+
+func NewFeatureController(c *container.Container, logger *slog.Logger) *FeatureController {
+	return &FeatureController{
+		featuresUC: c.Features(),
+		logger:     logger,
+	}
+}
+
+// GetFeatures godoc
+// @Summary Get features
+// @Description Whether each known feature is on for the current user, from the flags in app.toml and those set by admins. Groups are read from the token's groups claim.
+// @Tags features
+// @Produce json
+// @Success 200 {object} response.FeaturesResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /features [get]
+// @Security BearerAuth
+func (ctrl *FeatureController) GetFeatures(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	claims, claimsExist := middleware.GetCurrentClaims(r)
+	if !exists || !claimsExist {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	features, err := ctrl.featuresUC.Evaluate(r.Context(), user, claims.Groups)
+	if err != nil {
+		ctrl.logger.Error("Failed to evaluate features", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get features")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.FeaturesResponse{Features: features})
+}
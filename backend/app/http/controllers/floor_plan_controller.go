@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// FloorPlanController manages a collection's floor plan image and where its
+// containers are on it, for a map view of the collection.
+type FloorPlanController struct {
+	floorPlanUC   *usecases.FloorPlanUseCase
+	maxUploadSize int64
+	logger        *slog.Logger
+}
+
+func NewFloorPlanController(c *container.Container, logger *slog.Logger) *FloorPlanController {
+	mediaCfg := c.GetConfig().Media
+	return &FloorPlanController{
+		floorPlanUC:   usecases.NewFloorPlanUseCase(c.CollectionRepo, c.ContainerRepo, c.MediaStorage, c.GroupQuotaRepo, c.UsageRepo, c.AuthService, mediaCfg.MaxUploadSize),
+		maxUploadSize: mediaCfg.MaxUploadSize,
+		logger:        logger,
+	}
+}
+
+// UploadFloorPlan godoc
+// @Summary Upload a collection's floor plan
+// @Description Sets the image the collection's containers are placed on, as multipart/form-data with one image in the file field. A previous floor plan is replaced; container positions are kept.
+// @Tags containers
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param file formData file true "JPEG, PNG or GIF image"
+// @Success 200 {object} response.FloorPlanResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/floor-plan [put]
+// @Security BearerAuth
+func (ctrl *FloorPlanController) UploadFloorPlan(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	file, err := request.ReadFloorPlanFile(w, r, ctrl.maxUploadSize)
+	if err != nil {
+		ctrl.logger.Warn("Invalid floor plan upload", slog.Any("error", err))
+		status := http.StatusBadRequest
+		if errors.Is(err, entities.ErrMediaTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		httputil.Error(w, status, err.Error())
+		return
+	}
+
+	plan, err := ctrl.floorPlanUC.Upload(r.Context(), usecases.UploadFloorPlanRequest{
+		CollectionID: collectionID,
+		File:         usecases.MediaUpload{Filename: file.Filename, ContentType: file.ContentType, Data: file.Data},
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to upload floor plan", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		writeMediaError(w, err, "failed to upload floor plan")
+		return
+	}
+
+	ctrl.logger.Info("Floor plan uploaded",
+		slog.String("collection_id", collectionID.String()),
+		slog.Int64("size", plan.Size()))
+	httputil.JSON(w, http.StatusOK, response.NewFloorPlanResponse(plan))
+}
+
+// DeleteFloorPlan godoc
+// @Summary Remove a collection's floor plan
+// @Description Deletes the floor plan image. Container positions are kept for a later plan.
+// @Tags containers
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/floor-plan [delete]
+// @Security BearerAuth
+func (ctrl *FloorPlanController) DeleteFloorPlan(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := ctrl.floorPlanUC.Remove(r.Context(), usecases.RemoveFloorPlanRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if errors.Is(err, entities.ErrFloorPlanNotFound) {
+		httputil.Error(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctrl.logger.Error("Failed to remove floor plan", slog.Any("error", err))
+		writeMediaError(w, err, "failed to remove floor plan")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateContainerPlacements godoc
+// @Summary Place containers on the floor plan
+// @Description Sets the x/y position (fractions of the floor plan's width and height from its top left corner) and floor and room of each listed container. Containers not listed keep their placement; one listed with no position, floor or room is taken off the plan.
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param request body request.UpdateContainerPlacementsRequest true "Placements"
+// @Success 200 {object} response.ContainerListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/placements [put]
+// @Security BearerAuth
+func (ctrl *FloorPlanController) UpdateContainerPlacements(w http.ResponseWriter, r *http.Request) {
+	user, userToken, collectionID, ok := ctrl.collectionFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateContainerPlacementsRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+	ids, placements, err := req.ToEntities()
+	if err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	updates := make([]usecases.ContainerPlacementUpdate, len(ids))
+	for i := range ids {
+		updates[i] = usecases.ContainerPlacementUpdate{ContainerID: ids[i], Placement: placements[i]}
+	}
+	containers, err := ctrl.floorPlanUC.PlaceContainers(r.Context(), usecases.PlaceContainersRequest{
+		CollectionID: collectionID,
+		Placements:   updates,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if errors.Is(err, entities.ErrContainerNotFound) {
+		httputil.Error(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctrl.logger.Error("Failed to place containers", slog.Any("error", err))
+		writeMediaError(w, err, "failed to place containers")
+		return
+	}
+
+	out := make(response.ContainerListResponse, len(containers))
+	for i, c := range containers {
+		out[i] = response.NewContainerSummaryResponse(c)
+	}
+	httputil.JSON(w, http.StatusOK, out)
+}
+
+func (ctrl *FloorPlanController) collectionFromRequest(w http.ResponseWriter, r *http.Request) (*entities.User, string, entities.CollectionID, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", entities.CollectionID{}, false
+	}
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, "", entities.CollectionID{}, false
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, "", entities.CollectionID{}, false
+	}
+	return user, userToken, collectionID, true
+}
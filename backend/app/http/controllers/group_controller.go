@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/nishiki/backend/app/container"
@@ -10,6 +12,7 @@ import (
 	"github.com/nishiki/backend/app/http/middleware"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/services"
 	"github.com/nishiki/backend/domain/usecases"
 )
@@ -18,6 +21,7 @@ type GroupController struct {
 	createGroupUC   *usecases.CreateGroupUseCase
 	getGroupsUC     *usecases.GetGroupsUseCase
 	groupUC         *usecases.GroupUseCase
+	groupQuotaUC    *usecases.GroupQuotaUseCase
 	getContainersUC *usecases.GetContainersUseCase
 	authService     services.AuthService
 	logger          *slog.Logger
@@ -31,6 +35,7 @@ func NewGroupController(
 		createGroupUC:   usecases.NewCreateGroupUseCase(c.AuthService),
 		getGroupsUC:     usecases.NewGetGroupsUseCase(c.AuthService),
 		groupUC:         usecases.NewGroupUseCase(c.AuthService),
+		groupQuotaUC:    usecases.NewGroupQuotaUseCase(c.GroupQuotaRepo, c.UsageRepo, c.AuthService),
 		getContainersUC: usecases.NewGetContainersUseCase(c.ContainerRepo, c.AuthService),
 		authService:     c.AuthService,
 		logger:          logger,
@@ -74,7 +79,7 @@ func (ctrl *GroupController) CreateGroup(w http.ResponseWriter, r *http.Request)
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -92,6 +97,10 @@ func (ctrl *GroupController) CreateGroup(w http.ResponseWriter, r *http.Request)
 			httputil.Error(w, http.StatusUnauthorized, "authentication failed")
 			return
 		}
+		if errors.Is(err, services.ErrGroupsReadOnly) {
+			httputil.Error(w, http.StatusForbidden, err.Error())
+			return
+		}
 		httputil.Error(w, http.StatusInternalServerError, "failed to create group")
 		return
 	}
@@ -249,6 +258,18 @@ func (ctrl *GroupController) GetGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userGroups, err := ctrl.authService.GetUserGroups(r.Context(), userToken, user.ID().String())
+	if err != nil {
+		ctrl.logger.Error("Failed to get user groups", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get group")
+		return
+	}
+	// Outsiders are told the group does not exist
+	if !slices.ContainsFunc(userGroups, func(g *entities.Group) bool { return g.ID().Equals(groupID) }) {
+		httputil.Error(w, http.StatusNotFound, "group not found")
+		return
+	}
+
 	group, err := ctrl.authService.GetGroupByID(r.Context(), userToken, groupID.String())
 	if err != nil {
 		ctrl.logger.Error("Failed to get group", slog.Any("error", err))
@@ -264,6 +285,7 @@ func (ctrl *GroupController) GetGroup(w http.ResponseWriter, r *http.Request) {
 		slog.String("group_id", groupID.String()),
 		slog.String("user_id", user.ID().String()))
 
+	httputil.SetLastModified(w, group.UpdatedAt())
 	httputil.JSON(w, http.StatusOK, response.NewGroupResponse(group))
 }
 
@@ -303,9 +325,6 @@ func (ctrl *GroupController) GetGroupUsers(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// TODO: Check if user is a member of the group for authorization
-	// For now, we'll allow any authenticated user to see group members
-
 	users, err := ctrl.authService.GetGroupUsers(r.Context(), userToken, groupID.String())
 	if err != nil {
 		ctrl.logger.Error("Failed to get group users", slog.Any("error", err))
@@ -313,6 +332,12 @@ func (ctrl *GroupController) GetGroupUsers(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Outsiders are told the group does not exist
+	if !slices.ContainsFunc(users, func(u *entities.User) bool { return u.ID().Equals(user.ID()) }) {
+		httputil.Error(w, http.StatusNotFound, "group not found")
+		return
+	}
+
 	ctrl.logger.Debug("Group users retrieved successfully",
 		slog.String("group_id", groupID.String()),
 		slog.String("user_id", user.ID().String()),
@@ -352,7 +377,7 @@ func (ctrl *GroupController) JoinGroup(w http.ResponseWriter, r *http.Request) {
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -412,7 +437,7 @@ func (ctrl *GroupController) UpdateGroup(w http.ResponseWriter, r *http.Request)
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -431,6 +456,10 @@ func (ctrl *GroupController) UpdateGroup(w http.ResponseWriter, r *http.Request)
 			httputil.Error(w, http.StatusNotFound, "group not found")
 			return
 		}
+		if errors.Is(err, services.ErrGroupsReadOnly) {
+			httputil.Error(w, http.StatusForbidden, err.Error())
+			return
+		}
 		httputil.Error(w, http.StatusInternalServerError, "failed to update group")
 		return
 	}
@@ -490,6 +519,10 @@ func (ctrl *GroupController) DeleteGroup(w http.ResponseWriter, r *http.Request)
 			httputil.Error(w, http.StatusNotFound, "group not found")
 			return
 		}
+		if errors.Is(err, services.ErrGroupsReadOnly) {
+			httputil.Error(w, http.StatusForbidden, err.Error())
+			return
+		}
 		httputil.Error(w, http.StatusInternalServerError, "failed to delete group")
 		return
 	}
@@ -503,7 +536,7 @@ func (ctrl *GroupController) DeleteGroup(w http.ResponseWriter, r *http.Request)
 
 // AddGroupMember godoc
 // @Summary Add a member to a group
-// @Description Add a user to a group by user ID
+// @Description Add a user to a group by user ID. Only members may add users, and only admins once the group has any.
 // @Tags groups
 // @Produce json
 // @Param id path string true "Group ID"
@@ -511,12 +544,14 @@ func (ctrl *GroupController) DeleteGroup(w http.ResponseWriter, r *http.Request)
 // @Success 204
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
 // @Router /groups/{id}/users/{user_id} [post]
 // @Security BearerAuth
 func (ctrl *GroupController) AddGroupMember(w http.ResponseWriter, r *http.Request) {
-	_, exists := middleware.GetCurrentUser(r)
+	user, exists := middleware.GetCurrentUser(r)
 	if !exists {
 		httputil.Error(w, http.StatusUnauthorized, "authentication required")
 		return
@@ -543,18 +578,11 @@ func (ctrl *GroupController) AddGroupMember(w http.ResponseWriter, r *http.Reque
 	if err := ctrl.groupUC.AddMember(r.Context(), usecases.GroupMemberRequest{
 		GroupID:   groupID,
 		UserID:    targetUserID,
+		ActorID:   user.ID(),
 		UserToken: userToken,
 	}); err != nil {
 		ctrl.logger.Error("Failed to add group member", slog.Any("error", err))
-		if strings.Contains(err.Error(), "authentication failed") {
-			httputil.Error(w, http.StatusUnauthorized, "authentication failed")
-			return
-		}
-		if strings.Contains(err.Error(), "not found") {
-			httputil.Error(w, http.StatusNotFound, err.Error())
-			return
-		}
-		httputil.Error(w, http.StatusInternalServerError, "failed to add member")
+		writeGroupMemberError(w, err, "failed to add member")
 		return
 	}
 
@@ -563,7 +591,7 @@ func (ctrl *GroupController) AddGroupMember(w http.ResponseWriter, r *http.Reque
 
 // RemoveGroupMember godoc
 // @Summary Remove a member from a group
-// @Description Remove a user from a group by user ID
+// @Description Remove a user from a group by user ID. Only members may remove users, and only admins once the group has any.
 // @Tags groups
 // @Produce json
 // @Param id path string true "Group ID"
@@ -571,12 +599,14 @@ func (ctrl *GroupController) AddGroupMember(w http.ResponseWriter, r *http.Reque
 // @Success 204
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
 // @Router /groups/{id}/users/{user_id} [delete]
 // @Security BearerAuth
 func (ctrl *GroupController) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
-	_, exists := middleware.GetCurrentUser(r)
+	user, exists := middleware.GetCurrentUser(r)
 	if !exists {
 		httputil.Error(w, http.StatusUnauthorized, "authentication required")
 		return
@@ -603,20 +633,359 @@ func (ctrl *GroupController) RemoveGroupMember(w http.ResponseWriter, r *http.Re
 	if err := ctrl.groupUC.RemoveMember(r.Context(), usecases.GroupMemberRequest{
 		GroupID:   groupID,
 		UserID:    targetUserID,
+		ActorID:   user.ID(),
 		UserToken: userToken,
 	}); err != nil {
 		ctrl.logger.Error("Failed to remove group member", slog.Any("error", err))
-		if strings.Contains(err.Error(), "authentication failed") {
-			httputil.Error(w, http.StatusUnauthorized, "authentication failed")
-			return
+		writeGroupMemberError(w, err, "failed to remove member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetGroupMembers godoc
+// @Summary List group members with roles
+// @Description List the members of a group and whether each is a member or an admin
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID"
+// @Success 200 {object} response.GroupMemberListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /groups/{id}/members [get]
+// @Security BearerAuth
+func (ctrl *GroupController) GetGroupMembers(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	groupID, err := request.GetGroupIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	members, err := ctrl.groupUC.ListMembers(r.Context(), usecases.ListGroupMembersRequest{
+		GroupID:   groupID,
+		ActorID:   user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to list group members", slog.Any("error", err))
+		writeGroupMemberError(w, err, "failed to get group members")
+		return
+	}
+
+	resp := make(response.GroupMemberListResponse, len(members))
+	for i, member := range members {
+		resp[i] = response.NewGroupMemberResponse(member.User, member.Role)
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// InviteGroupMember godoc
+// @Summary Invite a member by email
+// @Description Add the user registered under an email address to a group. Only members may invite, and only admins once the group has any. The answer is the same whether or not the address has an account.
+// @Tags groups
+// @Accept json
+// @Param id path string true "Group ID"
+// @Param invitation body request.InviteGroupMemberRequest true "Email to invite"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /groups/{id}/invitations [post]
+// @Security BearerAuth
+func (ctrl *GroupController) InviteGroupMember(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	groupID, err := request.GetGroupIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.InviteGroupMemberRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	if err := ctrl.groupUC.InviteMember(r.Context(), usecases.InviteGroupMemberRequest{
+		GroupID:   groupID,
+		Email:     req.Email,
+		ActorID:   user.ID(),
+		UserToken: userToken,
+	}); err != nil {
+		ctrl.logger.Error("Failed to invite group member", slog.Any("error", err))
+		switch {
+		case errors.Is(err, entities.ErrInvalidEmailAddress):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entities.ErrNotGroupAdmin):
+			httputil.Error(w, http.StatusForbidden, err.Error())
+		default:
+			writeGroupMemberError(w, err, "failed to invite member")
 		}
-		if strings.Contains(err.Error(), "not found") {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetGroupMemberRole godoc
+// @Summary Change a member's role
+// @Description Make a group member an admin or a plain member. Only admins may change roles.
+// @Tags groups
+// @Accept json
+// @Param id path string true "Group ID"
+// @Param user_id path string true "User ID"
+// @Param role body request.SetGroupMemberRoleRequest true "New role"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /groups/{id}/users/{user_id}/role [put]
+// @Security BearerAuth
+func (ctrl *GroupController) SetGroupMemberRole(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	groupID, err := request.GetGroupIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	targetUserID, err := request.GetGroupMemberIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.SetGroupMemberRoleRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	if err := ctrl.groupUC.SetMemberRole(r.Context(), usecases.SetGroupMemberRoleRequest{
+		GroupID:   groupID,
+		UserID:    targetUserID,
+		Role:      req.Role,
+		ActorID:   user.ID(),
+		UserToken: userToken,
+	}); err != nil {
+		ctrl.logger.Error("Failed to set group member role", slog.Any("error", err))
+		switch {
+		case errors.Is(err, entities.ErrInvalidGroupRole):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entities.ErrNotGroupAdmin):
+			httputil.Error(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, entities.ErrNotGroupMember):
 			httputil.Error(w, http.StatusNotFound, err.Error())
-			return
+		default:
+			writeGroupMemberError(w, err, "failed to change role")
 		}
-		httputil.Error(w, http.StatusInternalServerError, "failed to remove member")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetGroupQuota godoc
+// @Summary Get a group's quota
+// @Description Get the limits on containers, objects and photo storage in the collections shared with a group, with what they use now. A limit of 0 is unlimited.
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID"
+// @Success 200 {object} response.GroupQuotaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /groups/{id}/quota [get]
+// @Security BearerAuth
+func (ctrl *GroupController) GetGroupQuota(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	groupID, err := request.GetGroupIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.groupQuotaUC.GetQuota(r.Context(), usecases.GetGroupQuotaRequest{
+		GroupID:   groupID,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to get group quota", slog.Any("error", err))
+		// Outsiders are told the group does not exist
+		if errors.Is(err, entities.ErrNotGroupQuotaMember) {
+			httputil.Error(w, http.StatusNotFound, "group not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to get group quota")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewGroupQuotaResponse(resp.GroupID, resp.Quota, resp.Usage, resp.CanEdit))
+}
+
+// SetGroupQuota godoc
+// @Summary Set a group's quota
+// @Description Set the limits on containers, objects and photo storage in the collections shared with a group; 0 leaves a limit off. Only group admins may. A limit below current usage removes nothing but refuses further creates.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param quota body request.SetGroupQuotaRequest true "Limits"
+// @Success 200 {object} response.GroupQuotaResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /groups/{id}/quota [put]
+// @Security BearerAuth
+func (ctrl *GroupController) SetGroupQuota(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	groupID, err := request.GetGroupIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.SetGroupQuotaRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.groupQuotaUC.SetQuota(r.Context(), usecases.SetGroupQuotaRequest{
+		GroupID:         groupID,
+		MaxContainers:   req.MaxContainers,
+		MaxObjects:      req.MaxObjects,
+		MaxStorageBytes: req.MaxStorageBytes,
+		UserID:          user.ID(),
+		UserToken:       userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to set group quota", slog.Any("error", err))
+		switch {
+		case errors.Is(err, entities.ErrInvalidGroupQuota):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entities.ErrNotGroupQuotaAdmin):
+			httputil.Error(w, http.StatusForbidden, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to set group quota")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Group quota set",
+		slog.String("group_id", groupID.String()),
+		slog.Int("max_containers", req.MaxContainers),
+		slog.Int("max_objects", req.MaxObjects),
+		slog.Int64("max_storage_bytes", req.MaxStorageBytes),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.NewGroupQuotaResponse(resp.GroupID, resp.Quota, resp.Usage, resp.CanEdit))
+}
+
+// writeQuotaExceeded answers a create refused for the group's quota with 403
+// and what the limit is, so the client can say which one was reached. It
+// reports whether err was such a refusal.
+func writeQuotaExceeded(w http.ResponseWriter, err error) bool {
+	var quotaErr *entities.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+	httputil.JSON(w, http.StatusForbidden, response.NewQuotaExceededResponse(quotaErr, middleware.RequestID(w)))
+	return true
+}
+
+// writeGroupMemberError reports a failed membership change. When the
+// identity provider rejected it, its reason is passed on with 502 so the
+// user learns the group did not change instead of seeing a bare 500.
+func writeGroupMemberError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "authentication failed"):
+		httputil.Error(w, http.StatusUnauthorized, "authentication failed")
+	case errors.Is(err, services.ErrGroupsReadOnly), errors.Is(err, entities.ErrNotGroupAdmin):
+		httputil.Error(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrGroupSyncFailed):
+		httputil.Error(w, http.StatusBadGateway, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, err.Error())
+	default:
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
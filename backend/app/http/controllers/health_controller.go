@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/buildinfo"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/app/metrics"
+)
+
+// readinessTimeout bounds the whole readiness probe so a hung dependency
+// cannot stall the kubelet.
+const readinessTimeout = 5 * time.Second
+
+type healthCheck func(ctx context.Context) error
+
+type HealthController struct {
+	checks map[string]healthCheck
+	// optional checks are reported by the readiness probe but never fail it:
+	// the server keeps serving when an enrichment provider is unreachable.
+	optional map[string]healthCheck
+	metrics  *metrics.Metrics
+	logger   *slog.Logger
+}
+
+func NewHealthController(c *container.Container, logger *slog.Logger) *HealthController {
+	checks := map[string]healthCheck{
+		"database":  c.CheckDatabase,
+		"authentik": c.AuthService.CheckHealth,
+	}
+	if c.ImageSearchService != nil {
+		checks["image_cache"] = c.ImageSearchService.CheckHealth
+	}
+	if c.MediaStorage != nil {
+		checks["media"] = c.MediaStorage.CheckHealth
+	}
+
+	optional := map[string]healthCheck{}
+	if c.NutritionProvider != nil {
+		optional["nutrition"] = c.NutritionProvider.CheckHealth
+	}
+
+	return &HealthController{
+		checks:   checks,
+		optional: optional,
+		metrics:  c.GetMetrics(),
+		logger:   logger,
+	}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Reports that the process is running. Does not touch any dependency.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.LivenessResponse
+// @Router /health/live [get]
+func (ctrl *HealthController) Live(w http.ResponseWriter, _ *http.Request) {
+	httputil.JSON(w, http.StatusOK, response.LivenessResponse{Status: response.HealthStatusUp})
+}
+
+// Status godoc
+// @Summary Public status
+// @Description Reports the server version, build commit, uptime and request totals since start for uptime monitors and the app's About dialog. No authentication required; rate limited per client IP.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.StatusResponse
+// @Failure 429 {object} map[string]string
+// @Router /status [get]
+func (ctrl *HealthController) Status(w http.ResponseWriter, _ *http.Request) {
+	s := ctrl.metrics.Snapshot()
+	httputil.JSON(w, http.StatusOK, response.StatusResponse{
+		Status:        response.HealthStatusUp,
+		Version:       buildinfo.Version,
+		Commit:        buildinfo.BuildCommit(),
+		StartedAt:     s.StartedAt.UTC(),
+		UptimeSeconds: int64(s.Uptime / time.Second),
+		Requests:      s.Requests,
+		ServerErrors:  s.ServerErrors,
+		ErrorRate:     s.ErrorRate,
+	})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Checks the database, Authentik OIDC discovery, the image cache and media storage (when enabled) and reports whether each is up. Optional providers such as nutrition lookups are reported but do not fail readiness. Failure details are logged, not returned.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.ReadinessResponse
+// @Failure 503 {object} response.ReadinessResponse
+// @Router /health/ready [get]
+func (ctrl *HealthController) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		res = response.ReadinessResponse{
+			Status:       response.HealthStatusUp,
+			Dependencies: make(map[string]response.DependencyHealth, len(ctrl.checks)+len(ctrl.optional)),
+		}
+	)
+
+	run := func(name string, check healthCheck, optional bool) {
+		start := time.Now()
+		err := check(ctx)
+		dep := response.DependencyHealth{Status: response.HealthStatusUp, Optional: optional}
+		if err != nil {
+			dep.Status = response.HealthStatusDown
+			ctrl.logger.Warn("Readiness check failed",
+				slog.String("dependency", name),
+				slog.Bool("optional", optional),
+				slog.Duration("latency", time.Since(start)),
+				slog.Any("error", err))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		res.Dependencies[name] = dep
+		if err != nil && !optional {
+			res.Status = response.HealthStatusDown
+		}
+	}
+
+	for name, check := range ctrl.checks {
+		wg.Go(func() { run(name, check, false) })
+	}
+	for name, check := range ctrl.optional {
+		wg.Go(func() { run(name, check, true) })
+	}
+	wg.Wait()
+
+	if res.Status != response.HealthStatusUp {
+		httputil.JSON(w, http.StatusServiceUnavailable, res)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, res)
+}
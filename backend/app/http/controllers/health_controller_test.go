@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestHealthController_Live(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newTestContainer(t)
+	controller := NewHealthController(c, c.GetLogger())
+
+	rr := httptest.NewRecorder()
+	controller.Live(rr, newTestRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestHealthController_Ready(t *testing.T) {
+	t.Parallel()
+
+	// The test container has no database, so that check always reports down.
+	t.Run("reports each dependency", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestContainer(t)
+		controller := NewHealthController(c, c.GetLogger())
+		m.AuthService.EXPECT().CheckHealth(gomock.Any()).Return(nil)
+		m.MediaStorage.EXPECT().CheckHealth(gomock.Any()).Return(nil)
+
+		rr := httptest.NewRecorder()
+		controller.Ready(rr, newTestRequest(http.MethodGet, "/health/ready", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+		var res response.ReadinessResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, response.HealthStatusDown, res.Status)
+		assert.Equal(t, response.HealthStatusUp, res.Dependencies["authentik"].Status)
+		assert.Equal(t, response.HealthStatusDown, res.Dependencies["database"].Status)
+		assert.Equal(t, response.HealthStatusUp, res.Dependencies["media"].Status)
+		assert.NotContains(t, res.Dependencies, "image_cache")
+	})
+
+	t.Run("authentik failure is reported", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestContainer(t)
+		controller := NewHealthController(c, c.GetLogger())
+		m.AuthService.EXPECT().CheckHealth(gomock.Any()).Return(errors.New("dial tcp auth.internal:9000: connection refused"))
+		m.MediaStorage.EXPECT().CheckHealth(gomock.Any()).Return(nil)
+
+		rr := httptest.NewRecorder()
+		controller.Ready(rr, newTestRequest(http.MethodGet, "/health/ready", nil))
+
+		var res response.ReadinessResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, response.HealthStatusDown, res.Dependencies["authentik"].Status)
+		assert.NotContains(t, rr.Body.String(), "auth.internal", "failure details stay in the log")
+	})
+
+	t.Run("optional provider failure does not fail readiness", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newTestContainer(t)
+		nutrition := mocks.NewMockNutritionProvider(gomock.NewController(t))
+		nutrition.EXPECT().CheckHealth(gomock.Any()).Return(errors.New("openfoodfacts unreachable"))
+		c.NutritionProvider = nutrition
+		controller := NewHealthController(c, c.GetLogger())
+		controller.checks = map[string]healthCheck{
+			"database": func(context.Context) error { return nil },
+		}
+
+		rr := httptest.NewRecorder()
+		controller.Ready(rr, newTestRequest(http.MethodGet, "/health/ready", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var res response.ReadinessResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, response.HealthStatusUp, res.Status)
+		assert.Equal(t, response.DependencyHealth{Status: response.HealthStatusDown, Optional: true}, res.Dependencies["nutrition"])
+		assert.False(t, res.Dependencies["database"].Optional)
+	})
+}
+
+func TestHealthController_Status(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newTestContainer(t)
+	controller := NewHealthController(c, c.GetLogger())
+	c.GetMetrics().Observe(http.StatusOK)
+	c.GetMetrics().Observe(http.StatusInternalServerError)
+
+	rr := httptest.NewRecorder()
+	controller.Status(rr, newTestRequest(http.MethodGet, "/status", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var res response.StatusResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, response.HealthStatusUp, res.Status)
+	assert.NotEmpty(t, res.Version)
+	assert.Equal(t, int64(2), res.Requests)
+	assert.Equal(t, int64(1), res.ServerErrors)
+	assert.InDelta(t, 0.5, res.ErrorRate, 1e-9)
+	assert.False(t, res.StartedAt.IsZero())
+}
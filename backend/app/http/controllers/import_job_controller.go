@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/app/importjobs"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// importEventKeepAlive is how often an idle event stream gets a comment so
+// proxies do not close it while a slow import is running.
+const importEventKeepAlive = 15 * time.Second
+
+// ImportJobController reports on collection imports running in the
+// background (see ObjectController.BulkImportToCollection).
+type ImportJobController struct {
+	importJobs *importjobs.Queue
+	logger     *slog.Logger
+}
+
+func NewImportJobController(
+	c *container.Container,
+	logger *slog.Logger,
+) *ImportJobController {
+	return &ImportJobController{
+		importJobs: c.GetImportJobs(),
+		logger:     logger,
+	}
+}
+
+// GetImportJob godoc
+// @Summary Get import job
+// @Description Get the progress of a background import, and its result once finished
+// @Tags objects
+// @Produce json
+// @Param job_id path string true "Import job ID"
+// @Success 200 {object} response.ImportJobResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /imports/{job_id} [get]
+// @Security BearerAuth
+func (ctrl *ImportJobController) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := ctrl.userJob(w, r)
+	if !ok {
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, newImportJobResponse(job.State()))
+}
+
+// StreamImportJobEvents godoc
+// @Summary Stream import job progress
+// @Description Server-sent events for a background import: "progress" as rows are handled, then "completed" or "failed" with the result, after which the stream ends
+// @Tags objects
+// @Produce text/event-stream
+// @Param job_id path string true "Import job ID"
+// @Success 200 {object} response.ImportJobResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /imports/{job_id}/events [get]
+// @Security BearerAuth
+func (ctrl *ImportJobController) StreamImportJobEvents(w http.ResponseWriter, r *http.Request) {
+	job, ok := ctrl.userJob(w, r)
+	if !ok {
+		return
+	}
+
+	updates, cancel := job.Subscribe()
+	defer cancel()
+
+	httputil.StartEventStream(w)
+	keepAlive := time.NewTicker(importEventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if err := httputil.WriteEventComment(w, "keep-alive"); err != nil {
+				return
+			}
+		case state, open := <-updates:
+			if !open {
+				return
+			}
+			event := "progress"
+			if state.Status.Finished() {
+				event = string(state.Status)
+			}
+			if err := httputil.WriteEvent(w, event, newImportJobResponse(state)); err != nil {
+				ctrl.logger.Debug("Import event stream closed", slog.String("job_id", job.ID()), slog.Any("error", err))
+				return
+			}
+		}
+	}
+}
+
+// userJob looks up the job in the path, answering 404 for jobs that do not
+// exist, have expired or belong to someone else.
+func (ctrl *ImportJobController) userJob(w http.ResponseWriter, r *http.Request) (*importjobs.Job, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, false
+	}
+
+	jobID, err := request.GetImportJobIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	job, ok := ctrl.importJobs.Get(jobID)
+	if !ok || !job.UserID().Equals(user.ID()) {
+		httputil.Error(w, http.StatusNotFound, "import job not found")
+		return nil, false
+	}
+	return job, true
+}
+
+// newImportJobResponse turns a job's state into its API form. Errors are
+// reduced to what the import endpoint used to answer with directly.
+func newImportJobResponse(state importjobs.State) response.ImportJobResponse {
+	resp := response.ImportJobResponse{
+		JobID:  state.ID,
+		Status: string(state.Status),
+		Done:   state.Done,
+		Total:  state.Total,
+	}
+	if state.Result != nil {
+		resp.Result = &response.BulkImportResponse{
+			Imported:     state.Result.Imported,
+			Failed:       state.Result.Failed,
+			Total:        state.Result.Total,
+			Errors:       state.Result.Errors,
+			SourceFormat: string(state.Result.SourceFormat),
+		}
+	}
+	if state.Err != nil {
+		switch {
+		case errors.Is(state.Err, entities.ErrGroupQuotaExceeded):
+			resp.Error = state.Err.Error()
+		case strings.Contains(state.Err.Error(), "access denied"):
+			resp.Error = "access denied"
+		case strings.Contains(state.Err.Error(), "not found"):
+			resp.Error = "collection not found"
+		default:
+			resp.Error = "failed to import objects"
+		}
+	}
+	return resp
+}
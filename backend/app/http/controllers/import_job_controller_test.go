@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestImportJobController(t *testing.T) {
+	t.Parallel()
+
+	c, m := newTestContainer(t)
+	objectController := NewObjectController(c, c.GetLogger())
+	controller := NewImportJobController(c, c.GetLogger())
+
+	testUser := randomUser()
+	collectionID := entities.NewCollectionID()
+	testCollection := newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral)
+
+	m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
+	m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil).Times(2)
+	m.CollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	req := newTestRequest(http.MethodPost,
+		"/accounts/"+testUser.ID().String()+"/collections/"+collectionID.String()+"/import",
+		request.BulkImportCollectionRequest{
+			Format: "json",
+			Data:   []map[string]any{{"name": "Hammer"}, {"name": "Wrench"}},
+		},
+	)
+	req.SetPathValue("id", testUser.ID().String())
+	req.SetPathValue("collection_id", collectionID.String())
+	req = setAuthContext(req, testUser, "test-token")
+
+	rr := httptest.NewRecorder()
+	objectController.BulkImportToCollection(rr, req)
+	require.Equal(t, http.StatusAccepted, rr.Code)
+
+	var queued response.ImportJobResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &queued))
+	assert.Equal(t, 2, queued.Total)
+	id := queued.JobID
+	waitForImportJob(t, c.GetImportJobs(), id)
+
+	jobRequest := func(path string, user *entities.User, id string) *http.Request {
+		r := newTestRequest(http.MethodGet, path, nil)
+		r.SetPathValue("job_id", id)
+		return setAuthContext(r, user, "test-token")
+	}
+
+	t.Run("get finished job", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.GetImportJob(rr, jobRequest("/imports/"+id, testUser, id))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp response.ImportJobResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "completed", resp.Status)
+		assert.Equal(t, 2, resp.Done)
+		require.NotNil(t, resp.Result)
+		assert.Equal(t, 2, resp.Result.Imported)
+	})
+
+	t.Run("event stream of finished job", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.StreamImportJobEvents(rr, jobRequest("/imports/"+id+"/events", testUser, id))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+		body := rr.Body.String()
+		assert.True(t, strings.HasPrefix(body, "event: completed\ndata: {"), body)
+		assert.Contains(t, body, `"imported":2`)
+	})
+
+	t.Run("another user's job", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.GetImportJob(rr, jobRequest("/imports/"+id, randomUser(), id))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		controller.GetImportJob(rr, jobRequest("/imports/missing", testUser, "missing"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
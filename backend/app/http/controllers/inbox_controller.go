@@ -0,0 +1,451 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+const (
+	// maxInboxEmailBytes caps a forwarded message, attachments included;
+	// only the plain-text part is read.
+	maxInboxEmailBytes = 10 << 20
+	// mailgunSignatureMaxAge is how old a webhook's timestamp may be, so a
+	// captured request cannot be replayed later.
+	mailgunSignatureMaxAge = 5 * time.Minute
+)
+
+type InboxController struct {
+	inboxUC   *usecases.InboxUseCase
+	container *container.Container
+	logger    *slog.Logger
+}
+
+func NewInboxController(
+	c *container.Container,
+	logger *slog.Logger,
+) *InboxController {
+	return &InboxController{
+		inboxUC:   usecases.NewInboxUseCase(c.InboxRepo, c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.GroupQuotaRepo, c.UsageRepo, c.ExpiryHistoryRepo, c.AuthService, c.Events()),
+		container: c,
+		logger:    logger,
+	}
+}
+
+// ReceiveEmail godoc
+// @Summary Receive a forwarded email
+// @Description Mailgun inbound route webhook. The recipient's +token picks the user; the plain-text body is read for items and filed in their inbox. Requests must carry a valid Mailgun signature. 406 tells Mailgun not to retry mail that can never be accepted.
+// @Tags inbox
+// @Accept multipart/form-data
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 406 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /inbox/email [post]
+func (ctrl *InboxController) ReceiveEmail(w http.ResponseWriter, r *http.Request) {
+	cfg := ctrl.container.GetConfig().Inbox
+	if !cfg.Enabled {
+		httputil.Error(w, http.StatusNotFound, "inbox is not enabled")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxInboxEmailBytes)
+	if err := r.ParseMultipartForm(1 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		httputil.Error(w, http.StatusBadRequest, "invalid form body")
+		return
+	}
+	if r.MultipartForm != nil {
+		defer func() { _ = r.MultipartForm.RemoveAll() }()
+	}
+
+	if !verifyMailgunSignature(cfg.SigningKey, r.PostFormValue("timestamp"), r.PostFormValue("token"), r.PostFormValue("signature"), time.Now()) {
+		ctrl.logger.Warn("Rejected inbox email with an invalid signature")
+		httputil.Error(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	sender := r.PostFormValue("sender")
+	if sender == "" {
+		sender = r.PostFormValue("from")
+	}
+	item, err := ctrl.inboxUC.Receive(r.Context(), usecases.ReceiveInboxEmailRequest{
+		Recipients:  r.PostFormValue("recipient"),
+		BaseAddress: cfg.Address,
+		Sender:      sender,
+		Subject:     r.PostFormValue("subject"),
+		Body:        r.PostFormValue("body-plain"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entities.ErrInboxAddressNotFound),
+			errors.Is(err, entities.ErrInboxItemEmpty),
+			errors.Is(err, entities.ErrInboxFull):
+			ctrl.logger.Info("Inbox email not accepted", slog.Any("reason", err))
+			httputil.Error(w, http.StatusNotAcceptable, err.Error())
+		default:
+			ctrl.logger.Error("Failed to receive inbox email", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to receive email")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Inbox email received",
+		slog.String("inbox_item_id", item.ID().String()),
+		slog.String("user_id", item.UserID().String()),
+		slog.Int("lines", len(item.Lines())))
+
+	httputil.JSON(w, http.StatusOK, map[string]string{"id": item.ID().String()})
+}
+
+// GetInboxAddress godoc
+// @Summary Get the inbox address
+// @Description Returns the private address the user forwards receipts and order confirmations to, creating it on first use. enabled is false when the server has no inbox set up.
+// @Tags inbox
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.InboxAddressResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/inbox/address [get]
+// @Security BearerAuth
+func (ctrl *InboxController) GetInboxAddress(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	cfg := ctrl.container.GetConfig().Inbox
+	if !cfg.Enabled {
+		httputil.JSON(w, http.StatusOK, response.InboxAddressResponse{Enabled: false})
+		return
+	}
+
+	address, err := ctrl.inboxUC.GetAddress(r.Context(), user.ID())
+	if err != nil {
+		ctrl.logger.Error("Failed to get inbox address", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get inbox address")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.InboxAddressResponse{Enabled: true, Address: address.Address(cfg.Address)})
+}
+
+// ResetInboxAddress godoc
+// @Summary Reset the inbox address
+// @Description Replaces the user's inbox address, for when it has leaked. Mail to the old address is refused; items already received stay.
+// @Tags inbox
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.InboxAddressResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/inbox/address/reset [post]
+// @Security BearerAuth
+func (ctrl *InboxController) ResetInboxAddress(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	cfg := ctrl.container.GetConfig().Inbox
+	if !cfg.Enabled {
+		httputil.Error(w, http.StatusNotFound, "inbox is not enabled")
+		return
+	}
+
+	address, err := ctrl.inboxUC.ResetAddress(r.Context(), user.ID())
+	if err != nil {
+		ctrl.logger.Error("Failed to reset inbox address", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to reset inbox address")
+		return
+	}
+
+	ctrl.logger.Info("Inbox address reset", slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.InboxAddressResponse{Enabled: true, Address: address.Address(cfg.Address)})
+}
+
+// ListInboxItems godoc
+// @Summary List inbox items
+// @Description Returns the forwarded emails waiting to be confirmed or dismissed, newest first, with the items read from each
+// @Tags inbox
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.InboxItemListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/inbox [get]
+// @Security BearerAuth
+func (ctrl *InboxController) ListInboxItems(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	items, err := ctrl.inboxUC.List(r.Context(), user.ID())
+	if err != nil {
+		ctrl.logger.Error("Failed to list inbox items", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list inbox items")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewInboxItemListResponse(items))
+}
+
+// ConfirmInboxItem godoc
+// @Summary Confirm an inbox item
+// @Description Creates an object for each line, in the given container or the collection's default one. Lines may be corrected or left out first; a line's price goes to the collection's first currency property. Lines that fail stay in the inbox and are listed under failed.
+// @Tags inbox
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param item_id path string true "Inbox item ID"
+// @Param confirm body request.ConfirmInboxItemRequest true "Where to file the lines"
+// @Success 200 {object} response.ConfirmInboxItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/inbox/{item_id}/confirm [post]
+// @Security BearerAuth
+func (ctrl *InboxController) ConfirmInboxItem(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	itemID, err := request.GetInboxItemIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.ConfirmInboxItemRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	collectionID, _ := entities.CollectionIDFromString(req.CollectionID)
+	ucReq := usecases.ConfirmInboxItemRequest{
+		ItemID:       itemID,
+		CollectionID: collectionID,
+		Lines:        req.ToInboxLines(),
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	}
+	if req.ContainerID != nil {
+		containerID, _ := entities.ContainerIDFromString(*req.ContainerID)
+		ucReq.ContainerID = &containerID
+	}
+
+	resp, err := ctrl.inboxUC.Confirm(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to confirm inbox item", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrInboxItemNotFound):
+			httputil.Error(w, http.StatusNotFound, "inbox item not found")
+		case errors.Is(err, entities.ErrInboxItemEmpty),
+			strings.Contains(err.Error(), "invalid properties"):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "container or collection not found")
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to confirm inbox item")
+		}
+		return
+	}
+
+	out := response.NewConfirmInboxItemResponse(resp.Created, resp.ContainerID)
+	for _, failed := range resp.Failed {
+		out.Failed = append(out.Failed, response.InboxLineErrorResponse{
+			Line:  response.NewInboxLineResponse(failed.Line),
+			Error: failed.Error,
+		})
+	}
+
+	ctrl.logger.Info("Inbox item confirmed",
+		slog.String("inbox_item_id", itemID.String()),
+		slog.String("user_id", user.ID().String()),
+		slog.Int("created", len(out.Created)),
+		slog.Int("failed", len(out.Failed)))
+
+	httputil.JSON(w, http.StatusOK, out)
+}
+
+// DismissInboxItem godoc
+// @Summary Dismiss an inbox item
+// @Description Removes a forwarded email from the inbox without creating anything
+// @Tags inbox
+// @Param id path string true "User ID"
+// @Param item_id path string true "Inbox item ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/inbox/{item_id} [delete]
+// @Security BearerAuth
+func (ctrl *InboxController) DismissInboxItem(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	itemID, err := request.GetInboxItemIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.inboxUC.Dismiss(r.Context(), itemID, user.ID())
+	switch {
+	case errors.Is(err, entities.ErrInboxItemNotFound):
+		httputil.Error(w, http.StatusNotFound, "inbox item not found")
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to dismiss inbox item", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to dismiss inbox item")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyMailgunSignature checks a webhook's signature: the hex HMAC-SHA256
+// of timestamp+token under the signing key, with a recent timestamp.
+func verifyMailgunSignature(key, timestamp, token, signature string, now time.Time) bool {
+	if key == "" || timestamp == "" || token == "" || signature == "" {
+		return false
+	}
+	secs, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := now.Sub(time.Unix(secs, 0))
+	if age > mailgunSignatureMaxAge || age < -mailgunSignatureMaxAge {
+		return false
+	}
+
+	want, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + token))
+	return hmac.Equal(mac.Sum(nil), want)
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+const testSigningKey = "key-test"
+
+func signMailgun(key, timestamp, token string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyMailgunSignature(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	sig := signMailgun(testSigningKey, ts, "abc")
+
+	assert.True(t, verifyMailgunSignature(testSigningKey, ts, "abc", sig, now))
+	assert.True(t, verifyMailgunSignature(testSigningKey, ts, "abc", sig, now.Add(4*time.Minute)))
+	assert.False(t, verifyMailgunSignature(testSigningKey, ts, "abc", sig, now.Add(6*time.Minute)), "stale")
+	assert.False(t, verifyMailgunSignature("other-key", ts, "abc", sig, now), "wrong key")
+	assert.False(t, verifyMailgunSignature(testSigningKey, ts, "abd", sig, now), "wrong token")
+	assert.False(t, verifyMailgunSignature(testSigningKey, ts, "abc", "zz", now), "not hex")
+	assert.False(t, verifyMailgunSignature("", ts, "abc", signMailgun("", ts, "abc"), now), "no key configured")
+}
+
+func TestInboxController_ReceiveEmail(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*InboxController, *testMocks) {
+		c, m := newTestContainer(t)
+		c.SetConfig(&config.Config{Inbox: config.InboxConfig{
+			Enabled:    true,
+			Address:    "inbox@example.com",
+			SigningKey: testSigningKey,
+		}})
+		return NewInboxController(c, c.GetLogger()), m
+	}
+	post := func(controller *InboxController, form url.Values) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/inbox/email", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		controller.ReceiveEmail(rr, r)
+		return rr
+	}
+	signed := func(recipient, body string) url.Values {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		return url.Values{
+			"timestamp":  {ts},
+			"token":      {"abc"},
+			"signature":  {signMailgun(testSigningKey, ts, "abc")},
+			"recipient":  {recipient},
+			"sender":     {"shop@example.com"},
+			"subject":    {"Your order"},
+			"body-plain": {body},
+		}
+	}
+
+	t.Run("files a signed email", func(t *testing.T) {
+		t.Parallel()
+
+		controller, m := setup(t)
+		userID := entities.NewUserID()
+		m.InboxRepo.EXPECT().GetAddressByToken(gomock.Any(), "3f9a2c").
+			Return(entities.ReconstructInboxAddress(userID, "3f9a2c", time.Now()), nil)
+		m.InboxRepo.EXPECT().CountItemsByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		m.InboxRepo.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Return(nil)
+
+		rr := post(controller, signed("inbox+3f9a2c@example.com", "Whole Milk  $3.49\n"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("rejects a bad signature", func(t *testing.T) {
+		t.Parallel()
+
+		controller, _ := setup(t)
+		form := signed("inbox+3f9a2c@example.com", "Whole Milk  $3.49\n")
+		form.Set("signature", signMailgun("guessed", form.Get("timestamp"), "abc"))
+
+		rr := post(controller, form)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("refuses mail to an unknown address without retries", func(t *testing.T) {
+		t.Parallel()
+
+		controller, _ := setup(t)
+
+		rr := post(controller, signed("someone@example.com", "Whole Milk  $3.49\n"))
+
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+
+	t.Run("not found when the inbox is off", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newTestContainer(t)
+		controller := NewInboxController(c, c.GetLogger())
+
+		rr := post(controller, signed("inbox+3f9a2c@example.com", "Whole Milk  $3.49\n"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// signInPage is the sign-in form the frontend's authorization request lands
+// on in local auth mode. The OAuth parameters ride along as hidden fields.
+var signInPage = template.Must(template.New("sign-in").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in to Nishiki</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 22rem; margin: 4rem auto; padding: 0 1rem; }
+label, input, button { display: block; width: 100%; box-sizing: border-box; }
+input { margin: .25rem 0 .75rem; padding: .5rem; }
+button { padding: .5rem; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>{{if .Register}}Create your account{{else}}Sign in{{end}}</h1>
+{{with .Error}}<p class="error">{{.}}</p>{{end}}
+<form method="post">
+<input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+<input type="hidden" name="state" value="{{.State}}">
+<input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">
+{{if .Register}}<input type="hidden" name="action" value="register">{{end}}
+<label>Username <input name="username" value="{{.Username}}" autocomplete="username" required autofocus></label>
+{{if .Register}}<label>Email <input name="email" type="email" autocomplete="email" required></label>{{end}}
+<label>Password <input name="password" type="password" autocomplete="{{if .Register}}new-password{{else}}current-password{{end}}" required></label>
+<button type="submit">{{if .Register}}Create account{{else}}Sign in{{end}}</button>
+</form>
+{{with .RegisterURL}}<p>New here? <a href="{{.}}">Create an account</a></p>{{end}}
+</body>
+</html>
+`))
+
+type signInPageData struct {
+	RedirectURI   string
+	State         string
+	CodeChallenge string
+	Username      string
+	Register      bool
+	RegisterURL   string // link to the registration form, while it is open
+	Error         string
+}
+
+// LocalAuthController serves registration and sign-in for the accounts of
+// the local auth mode.
+type LocalAuthController struct {
+	auth   services.PasswordAuthenticator
+	logger *slog.Logger
+}
+
+// NewLocalAuthController returns nil unless the container's auth service
+// keeps local accounts.
+func NewLocalAuthController(appContainer *container.Container, logger *slog.Logger) *LocalAuthController {
+	auth, ok := appContainer.AuthService.(services.PasswordAuthenticator)
+	if !ok {
+		return nil
+	}
+	return &LocalAuthController{auth: auth, logger: logger}
+}
+
+// Register godoc
+// @Summary Register a local account
+// @Description Create a username/password account in local auth mode. The first account can always register; later ones only when auth.local.allow_registration is set.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.RegisterRequest true "New account"
+// @Success 201 {object} response.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/register [post]
+func (ctrl *LocalAuthController) Register(w http.ResponseWriter, r *http.Request) {
+	var req request.RegisterRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	token, err := ctrl.auth.Register(r.Context(), req.Username, req.Email, req.Password)
+	if err != nil {
+		status, message := ctrl.registerError(err)
+		httputil.Error(w, status, message)
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, response.NewTokenResponse(token))
+}
+
+func (ctrl *LocalAuthController) registerError(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrRegistrationClosed):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, entities.ErrLocalAccountExists):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, services.ErrInvalidPassword),
+		errors.Is(err, entities.ErrInvalidUsername),
+		errors.Is(err, entities.ErrInvalidEmailAddress):
+		return http.StatusBadRequest, err.Error()
+	default:
+		ctrl.logger.Error("Failed to register local account", slog.Any("error", err))
+		return http.StatusInternalServerError, "failed to register account"
+	}
+}
+
+// Login godoc
+// @Summary Sign in to a local account
+// @Description Exchange a username and password for an access token and a refresh token in local auth mode.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.LoginRequest true "Credentials"
+// @Success 200 {object} response.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /auth/login [post]
+func (ctrl *LocalAuthController) Login(w http.ResponseWriter, r *http.Request) {
+	var req request.LoginRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	token, err := ctrl.auth.Login(r.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			httputil.Error(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to sign in to local account", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to sign in")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewTokenResponse(token))
+}
+
+// SignInPage godoc
+// @Summary Show the local sign-in page
+// @Description Authorization endpoint of the local auth mode: an HTML sign-in form, or the registration form when register is set and registration is open.
+// @Tags auth
+// @Produce html
+// @Param redirect_uri query string true "Allowed frontend URL to return to"
+// @Param state query string false "Returned unchanged with the code"
+// @Param code_challenge query string true "PKCE code challenge"
+// @Param code_challenge_method query string false "PKCE method, S256 only"
+// @Param register query string false "Show the registration form"
+// @Success 200 {string} string "HTML form"
+// @Failure 400 {object} map[string]string
+// @Router /auth/local/authorize [get]
+func (ctrl *LocalAuthController) SignInPage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	data := signInPageData{
+		RedirectURI:   query.Get("redirect_uri"),
+		State:         query.Get("state"),
+		CodeChallenge: query.Get("code_challenge"),
+	}
+	if !ctrl.checkAuthorizeRequest(w, data.RedirectURI, query.Get("code_challenge_method")) {
+		return
+	}
+
+	open, err := ctrl.auth.RegistrationOpen(r.Context())
+	if err != nil {
+		ctrl.logger.Error("Failed to check local registration", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to show sign-in page")
+		return
+	}
+	data.Register = open && query.Get("register") != ""
+	if open && !data.Register {
+		query.Set("register", "1")
+		data.RegisterURL = r.URL.Path + "?" + query.Encode()
+	}
+
+	ctrl.renderSignIn(w, http.StatusOK, data)
+}
+
+// SignIn godoc
+// @Summary Submit the local sign-in form
+// @Description Sign in, or register first when action is register, and redirect to redirect_uri with an authorization code, which /auth/token redeems. Rejected credentials or registrations re-render the HTML form.
+// @Tags auth
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param redirect_uri formData string true "Allowed frontend URL to return to"
+// @Param state formData string false "Returned unchanged with the code"
+// @Param code_challenge formData string true "PKCE code challenge"
+// @Param username formData string true "Username"
+// @Param password formData string true "Password"
+// @Param email formData string false "Email address when registering"
+// @Param action formData string false "register to create the account first"
+// @Success 302 {string} string "Redirect with an authorization code"
+// @Failure 400 {string} string "Invalid form or redirect URL"
+// @Failure 401 {string} string "HTML form with the error"
+// @Failure 403 {string} string "HTML form with the error"
+// @Failure 409 {string} string "HTML form with the error"
+// @Router /auth/local/authorize [post]
+func (ctrl *LocalAuthController) SignIn(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid form data")
+		return
+	}
+	data := signInPageData{
+		RedirectURI:   r.PostForm.Get("redirect_uri"),
+		State:         r.PostForm.Get("state"),
+		CodeChallenge: r.PostForm.Get("code_challenge"),
+		Username:      r.PostForm.Get("username"),
+		Register:      r.PostForm.Get("action") == "register",
+	}
+	if !ctrl.checkAuthorizeRequest(w, data.RedirectURI, "") {
+		return
+	}
+	password := r.PostForm.Get("password")
+
+	if data.Register {
+		if _, err := ctrl.auth.Register(r.Context(), data.Username, r.PostForm.Get("email"), password); err != nil {
+			status, message := ctrl.registerError(err)
+			data.Error = message
+			ctrl.renderSignIn(w, status, data)
+			return
+		}
+	}
+
+	code, err := ctrl.auth.Authorize(r.Context(), data.Username, password, data.RedirectURI, data.CodeChallenge)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			data.Error = "Wrong username or password."
+			ctrl.renderSignIn(w, http.StatusUnauthorized, data)
+			return
+		}
+		ctrl.logger.Error("Failed to authorize local sign-in", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to sign in")
+		return
+	}
+
+	redirect, err := url.Parse(data.RedirectURI)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid redirect_uri")
+		return
+	}
+	params := redirect.Query()
+	params.Set("code", code)
+	if data.State != "" {
+		params.Set("state", data.State)
+	}
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// EndSession godoc
+// @Summary Show the local sign-out page
+// @Description Tokens are stateless, so there is no session to end beyond what the frontend has already forgotten.
+// @Tags auth
+// @Produce html
+// @Success 200 {string} string "HTML page"
+// @Router /auth/local/end-session [get]
+func (ctrl *LocalAuthController) EndSession(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte("<!doctype html><title>Signed out</title><p>Signed out of Nishiki. You can close this tab.</p>"))
+}
+
+func (ctrl *LocalAuthController) checkAuthorizeRequest(w http.ResponseWriter, redirectURI, challengeMethod string) bool {
+	if err := ctrl.auth.CheckRedirectURL(redirectURI); err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if challengeMethod != "" && challengeMethod != "S256" {
+		httputil.Error(w, http.StatusBadRequest, "code_challenge_method must be S256")
+		return false
+	}
+	return true
+}
+
+func (ctrl *LocalAuthController) renderSignIn(w http.ResponseWriter, status int, data signInPageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := signInPage.Execute(w, data); err != nil {
+		ctrl.logger.Error("Failed to render sign-in page", slog.Any("error", err))
+	}
+}
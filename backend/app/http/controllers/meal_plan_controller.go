@@ -0,0 +1,443 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type MealPlanController struct {
+	listMealPlansUC    *usecases.ListMealPlansUseCase
+	createMealPlanUC   *usecases.CreateMealPlanUseCase
+	updateMealPlanUC   *usecases.UpdateMealPlanUseCase
+	deleteMealPlanUC   *usecases.DeleteMealPlanUseCase
+	completeMealPlanUC *usecases.CompleteMealPlanUseCase
+	logger             *slog.Logger
+}
+
+func NewMealPlanController(
+	c *container.Container,
+	logger *slog.Logger,
+) *MealPlanController {
+	return &MealPlanController{
+		listMealPlansUC:    usecases.NewListMealPlansUseCase(c.MealPlanRepo),
+		createMealPlanUC:   usecases.NewCreateMealPlanUseCase(c.MealPlanRepo, c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		updateMealPlanUC:   usecases.NewUpdateMealPlanUseCase(c.MealPlanRepo, c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		deleteMealPlanUC:   usecases.NewDeleteMealPlanUseCase(c.MealPlanRepo),
+		completeMealPlanUC: usecases.NewCompleteMealPlanUseCase(c.MealPlanRepo, c.CollectionRepo, c.ContainerRepo, c.AuthService, c.Events()),
+		logger:             logger,
+	}
+}
+
+// ListMealPlans godoc
+// @Summary List meal plans
+// @Description Returns the user's planned meals between from (inclusive) and to (exclusive), ordered by day and meal. Defaults to the current Monday-to-Sunday week.
+// @Tags meals
+// @Produce json
+// @Param id path string true "User ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Day after the last day (YYYY-MM-DD)"
+// @Success 200 {object} response.MealPlanListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/meal-plans [get]
+// @Security BearerAuth
+func (ctrl *MealPlanController) ListMealPlans(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	from, to, err := request.GetMealPlanRangeFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.listMealPlansUC.Execute(r.Context(), usecases.ListMealPlansRequest{
+		From:   from,
+		To:     to,
+		UserID: user.ID(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "failed to list") {
+			ctrl.logger.Error("Failed to list meal plans", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to list meal plans")
+			return
+		}
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewMealPlanListResponse(from, to, resp.MealPlans))
+}
+
+// CreateMealPlan godoc
+// @Summary Plan a meal
+// @Description Plans a meal on a day, optionally linking the food objects it uses and how much of each
+// @Tags meals
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param meal_plan body request.CreateMealPlanRequest true "Meal plan"
+// @Success 201 {object} response.MealPlanResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/meal-plans [post]
+// @Security BearerAuth
+func (ctrl *MealPlanController) CreateMealPlan(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.CreateMealPlanRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	date, _ := request.ParseMealPlanDate(req.Date)
+	resp, err := ctrl.createMealPlanUC.Execute(r.Context(), usecases.CreateMealPlanRequest{
+		Date:        date,
+		Meal:        entities.MealType(req.Meal),
+		RecipeName:  req.RecipeName,
+		Ingredients: request.ToMealIngredients(req.Ingredients),
+		UserID:      user.ID(),
+		UserToken:   userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to create meal plan", slog.Any("error", err))
+		switch {
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, entities.ErrInvalidMealType),
+			errors.Is(err, entities.ErrInvalidRecipeName),
+			errors.Is(err, entities.ErrInvalidMealIngredient):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to create meal plan")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Meal plan created",
+		slog.String("meal_plan_id", resp.MealPlan.ID().String()),
+		slog.String("user_id", user.ID().String()),
+		slog.Int("ingredients", len(resp.MealPlan.Ingredients())))
+
+	httputil.JSON(w, http.StatusCreated, response.NewMealPlanResponse(resp.MealPlan))
+}
+
+// UpdateMealPlan godoc
+// @Summary Update a meal plan
+// @Description Changes the day, meal, recipe or linked ingredients of a plan that has not been completed. A present ingredients list replaces the existing one.
+// @Tags meals
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param meal_plan_id path string true "Meal plan ID"
+// @Param meal_plan body request.UpdateMealPlanRequest true "Fields to change"
+// @Success 200 {object} response.MealPlanResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/meal-plans/{meal_plan_id} [put]
+// @Security BearerAuth
+func (ctrl *MealPlanController) UpdateMealPlan(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	mealPlanID, err := request.GetMealPlanIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateMealPlanRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	ucReq := usecases.UpdateMealPlanRequest{
+		MealPlanID: mealPlanID,
+		RecipeName: req.RecipeName,
+		UserID:     user.ID(),
+		UserToken:  userToken,
+	}
+	if req.Date != nil {
+		date, _ := request.ParseMealPlanDate(*req.Date)
+		ucReq.Date = &date
+	}
+	if req.Meal != nil {
+		meal := entities.MealType(*req.Meal)
+		ucReq.Meal = &meal
+	}
+	if req.Ingredients != nil {
+		ucReq.Ingredients = request.ToMealIngredients(*req.Ingredients)
+	}
+
+	resp, err := ctrl.updateMealPlanUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to update meal plan", slog.Any("error", err))
+		switch {
+		case errors.Is(err, entities.ErrMealPlanNotFound):
+			httputil.Error(w, http.StatusNotFound, "meal plan not found")
+		case errors.Is(err, entities.ErrMealPlanCompleted):
+			httputil.Error(w, http.StatusConflict, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, entities.ErrInvalidMealType),
+			errors.Is(err, entities.ErrInvalidRecipeName),
+			errors.Is(err, entities.ErrInvalidMealIngredient):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to update meal plan")
+		}
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewMealPlanResponse(resp.MealPlan))
+}
+
+// DeleteMealPlan godoc
+// @Summary Delete a meal plan
+// @Description Deletes a planned or completed meal. Quantities used up by completing it are not restored.
+// @Tags meals
+// @Param id path string true "User ID"
+// @Param meal_plan_id path string true "Meal plan ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/meal-plans/{meal_plan_id} [delete]
+// @Security BearerAuth
+func (ctrl *MealPlanController) DeleteMealPlan(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	mealPlanID, err := request.GetMealPlanIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteMealPlanUC.Execute(r.Context(), usecases.DeleteMealPlanRequest{
+		MealPlanID: mealPlanID,
+		UserID:     user.ID(),
+	})
+	switch {
+	case errors.Is(err, entities.ErrMealPlanNotFound):
+		httputil.Error(w, http.StatusNotFound, "meal plan not found")
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to delete meal plan", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to delete meal plan")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CompleteMealPlan godoc
+// @Summary Complete a meal
+// @Description Marks the meal as cooked and reduces the quantity of each linked object, converting units where needed. Ingredients whose objects are gone or whose units do not convert are reported as skipped.
+// @Tags meals
+// @Produce json
+// @Param id path string true "User ID"
+// @Param meal_plan_id path string true "Meal plan ID"
+// @Success 200 {object} response.CompleteMealPlanResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/meal-plans/{meal_plan_id}/complete [post]
+// @Security BearerAuth
+func (ctrl *MealPlanController) CompleteMealPlan(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	mealPlanID, err := request.GetMealPlanIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.completeMealPlanUC.Execute(r.Context(), usecases.CompleteMealPlanRequest{
+		MealPlanID: mealPlanID,
+		UserID:     user.ID(),
+		UserToken:  userToken,
+	})
+	switch {
+	case errors.Is(err, entities.ErrMealPlanNotFound):
+		httputil.Error(w, http.StatusNotFound, "meal plan not found")
+		return
+	case errors.Is(err, entities.ErrMealPlanCompleted):
+		httputil.Error(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to complete meal plan", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to complete meal plan")
+		return
+	}
+
+	out := response.CompleteMealPlanResponse{
+		MealPlan: response.NewMealPlanResponse(resp.MealPlan),
+		Adjusted: make([]response.ObjectResponse, len(resp.Adjusted)),
+		Skipped:  make([]response.SkippedMealIngredientResponse, len(resp.Skipped)),
+	}
+	for i, adj := range resp.Adjusted {
+		out.Adjusted[i] = response.NewObjectResponse(*adj.Object, adj.ContainerID.String())
+	}
+	for i, s := range resp.Skipped {
+		out.Skipped[i] = response.SkippedMealIngredientResponse{
+			Ingredient: response.NewMealIngredientResponse(s.Ingredient),
+			Reason:     s.Reason,
+		}
+	}
+
+	ctrl.logger.Info("Meal plan completed",
+		slog.String("meal_plan_id", mealPlanID.String()),
+		slog.String("user_id", user.ID().String()),
+		slog.Int("adjusted", len(out.Adjusted)),
+		slog.Int("skipped", len(out.Skipped)))
+
+	httputil.JSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,388 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type MediaController struct {
+	uploadMediaUC *usecases.UploadMediaUseCase
+	listMediaUC   *usecases.ListMediaUseCase
+	deleteMediaUC *usecases.DeleteMediaUseCase
+	maxUploadSize int64
+	logger        *slog.Logger
+}
+
+func NewMediaController(
+	c *container.Container,
+	logger *slog.Logger,
+) *MediaController {
+	mediaCfg := c.GetConfig().Media
+	return &MediaController{
+		uploadMediaUC: usecases.NewUploadMediaUseCase(c.ContainerRepo, c.CollectionRepo, c.MediaRepo, c.MediaStorage, c.GroupQuotaRepo, c.UsageRepo, c.AuthService, mediaCfg.MaxUploadSize, mediaCfg.MaxPerOwner),
+		listMediaUC:   usecases.NewListMediaUseCase(c.ContainerRepo, c.CollectionRepo, c.MediaRepo, c.AuthService),
+		deleteMediaUC: usecases.NewDeleteMediaUseCase(c.CollectionRepo, c.MediaRepo, c.MediaStorage, c.AuthService),
+		maxUploadSize: mediaCfg.MaxUploadSize,
+		logger:        logger,
+	}
+}
+
+// UploadObjectMedia godoc
+// @Summary Upload object photos
+// @Description Attaches one or more photos to an object as multipart/form-data, one part per photo in the files field. Each photo is saved on its own; rejected ones are listed in failed.
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param files formData file true "JPEG, PNG or GIF photos"
+// @Success 201 {object} response.MediaUploadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/media [post]
+// @Security BearerAuth
+func (ctrl *MediaController) UploadObjectMedia(w http.ResponseWriter, r *http.Request) {
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.upload(w, r, nil, entities.MediaOwnerObject, objectID.String())
+}
+
+// UploadContainerMedia godoc
+// @Summary Upload container photos
+// @Description Attaches one or more photos to a container as multipart/form-data, one part per photo in the files field. Each photo is saved on its own; rejected ones are listed in failed.
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param container_id path string true "Container ID"
+// @Param files formData file true "JPEG, PNG or GIF photos"
+// @Success 201 {object} response.MediaUploadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/{container_id}/media [post]
+// @Security BearerAuth
+func (ctrl *MediaController) UploadContainerMedia(w http.ResponseWriter, r *http.Request) {
+	collectionID, containerID, ok := ctrl.containerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	ctrl.upload(w, r, &collectionID, entities.MediaOwnerContainer, containerID.String())
+}
+
+// ListObjectMedia godoc
+// @Summary List object photos
+// @Description Returns a page of the object's photos, newest first
+// @Tags media
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param limit query int false "Page size, 1-100 (default 24)"
+// @Param offset query int false "Photos to skip"
+// @Success 200 {object} response.MediaListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/media [get]
+// @Security BearerAuth
+func (ctrl *MediaController) ListObjectMedia(w http.ResponseWriter, r *http.Request) {
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.list(w, r, usecases.ListMediaRequest{
+		OwnerKind: entities.MediaOwnerObject,
+		OwnerID:   objectID.String(),
+	})
+}
+
+// ListContainerMedia godoc
+// @Summary List container photos
+// @Description Returns a page of the container's own photos, newest first
+// @Tags media
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param container_id path string true "Container ID"
+// @Param limit query int false "Page size, 1-100 (default 24)"
+// @Param offset query int false "Photos to skip"
+// @Success 200 {object} response.MediaListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/containers/{container_id}/media [get]
+// @Security BearerAuth
+func (ctrl *MediaController) ListContainerMedia(w http.ResponseWriter, r *http.Request) {
+	collectionID, containerID, ok := ctrl.containerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	ctrl.list(w, r, usecases.ListMediaRequest{
+		CollectionID: &collectionID,
+		OwnerKind:    entities.MediaOwnerContainer,
+		OwnerID:      containerID.String(),
+	})
+}
+
+// ListCollectionMedia godoc
+// @Summary List collection photos
+// @Description Returns a page of the photos attached to any of the collection's objects or containers, newest first, for a gallery view
+// @Tags media
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param limit query int false "Page size, 1-100 (default 24)"
+// @Param offset query int false "Photos to skip"
+// @Success 200 {object} response.MediaListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/media [get]
+// @Security BearerAuth
+func (ctrl *MediaController) ListCollectionMedia(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctrl.list(w, r, usecases.ListMediaRequest{CollectionID: &collectionID})
+}
+
+// DeleteMedia godoc
+// @Summary Delete a photo
+// @Description Removes a photo and its thumbnail
+// @Tags media
+// @Param id path string true "User ID"
+// @Param media_id path string true "Media ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/media/{media_id} [delete]
+// @Security BearerAuth
+func (ctrl *MediaController) DeleteMedia(w http.ResponseWriter, r *http.Request) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	mediaID, err := request.GetMediaIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid media ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteMediaUC.Execute(r.Context(), usecases.DeleteMediaRequest{
+		MediaID:   mediaID,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to delete photo", slog.Any("error", err))
+		writeMediaError(w, err, "failed to delete photo")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ctrl *MediaController) upload(w http.ResponseWriter, r *http.Request, collectionID *entities.CollectionID, kind entities.MediaOwnerKind, ownerID string) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	files, err := request.ReadMediaFiles(w, r, ctrl.maxUploadSize)
+	if err != nil {
+		ctrl.logger.Warn("Invalid photo upload", slog.Any("error", err))
+		status := http.StatusBadRequest
+		if errors.Is(err, entities.ErrMediaTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		httputil.Error(w, status, err.Error())
+		return
+	}
+
+	uploads := make([]usecases.MediaUpload, len(files))
+	for i, f := range files {
+		uploads[i] = usecases.MediaUpload{Filename: f.Filename, ContentType: f.ContentType, Data: f.Data}
+	}
+
+	resp, err := ctrl.uploadMediaUC.Execute(r.Context(), usecases.UploadMediaRequest{
+		CollectionID: collectionID,
+		OwnerKind:    kind,
+		OwnerID:      ownerID,
+		Files:        uploads,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to upload photos", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		writeMediaError(w, err, "failed to upload photos")
+		return
+	}
+
+	ctrl.logger.Info("Photos uploaded",
+		slog.String("owner_kind", kind.String()),
+		slog.String("owner_id", ownerID),
+		slog.Int("saved", len(resp.Media)),
+		slog.Int("failed", len(resp.Failed)))
+
+	out := response.MediaUploadResponse{
+		Media:  make([]response.MediaResponse, len(resp.Media)),
+		Failed: make([]response.MediaUploadFailure, len(resp.Failed)),
+	}
+	for i, m := range resp.Media {
+		out.Media[i] = response.NewMediaResponse(m)
+	}
+	for i, f := range resp.Failed {
+		out.Failed[i] = response.MediaUploadFailure{Filename: f.Filename, Error: f.Err.Error()}
+	}
+
+	status := http.StatusCreated
+	if len(resp.Media) == 0 {
+		status = http.StatusBadRequest
+	}
+	httputil.JSON(w, status, out)
+}
+
+func (ctrl *MediaController) list(w http.ResponseWriter, r *http.Request, req usecases.ListMediaRequest) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := request.GetMediaPageFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.Page = page
+	req.UserID = user.ID()
+	req.UserToken = userToken
+	resp, err := ctrl.listMediaUC.Execute(r.Context(), req)
+	if err != nil {
+		ctrl.logger.Error("Failed to list photos", slog.Any("error", err))
+		writeMediaError(w, err, "failed to list photos")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewMediaListResponse(resp.Media, resp.Total, resp.Page))
+}
+
+func (ctrl *MediaController) userFromRequest(w http.ResponseWriter, r *http.Request) (*entities.User, string, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, "", false
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, "", false
+	}
+
+	return user, userToken, true
+}
+
+func (ctrl *MediaController) containerFromPath(w http.ResponseWriter, r *http.Request) (entities.CollectionID, entities.ContainerID, bool) {
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return entities.CollectionID{}, entities.ContainerID{}, false
+	}
+
+	containerID, err := request.GetContainerIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid container ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return entities.CollectionID{}, entities.ContainerID{}, false
+	}
+
+	return collectionID, containerID, true
+}
+
+func writeMediaError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "access denied"):
+		httputil.Error(w, http.StatusForbidden, "access denied")
+	case errors.Is(err, entities.ErrMediaTooLarge):
+		httputil.Error(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, entities.ErrTooManyMedia),
+		errors.Is(err, entities.ErrUnsupportedMediaType),
+		errors.Is(err, entities.ErrInvalidMediaPage),
+		errors.Is(err, entities.ErrInvalidMediaOwnerKind):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entities.ErrMediaNotFound):
+		httputil.Error(w, http.StatusNotFound, "photo not found")
+	case errors.Is(err, entities.ErrMediaOwnerNotInCollection):
+		httputil.Error(w, http.StatusNotFound, "container not found in collection")
+	case strings.Contains(err.Error(), "object not found"):
+		httputil.Error(w, http.StatusNotFound, "object not found")
+	case strings.Contains(err.Error(), "container not found"):
+		httputil.Error(w, http.StatusNotFound, "container not found")
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, "collection not found")
+	default:
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
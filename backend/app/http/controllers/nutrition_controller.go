@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type NutritionController struct {
+	enrichNutritionUC *usecases.EnrichObjectNutritionUseCase
+	nutritionStatsUC  *usecases.GetNutritionStatsUseCase
+	logger            *slog.Logger
+}
+
+func NewNutritionController(
+	c *container.Container,
+	logger *slog.Logger,
+) *NutritionController {
+	return &NutritionController{
+		enrichNutritionUC: usecases.NewEnrichObjectNutritionUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.AuthService, c.NutritionProvider),
+		nutritionStatsUC:  usecases.NewGetNutritionStatsUseCase(c.ContainerRepo, c.AuthService),
+		logger:            logger,
+	}
+}
+
+// EnrichObjectNutrition godoc
+// @Summary Look up an object's nutrition facts
+// @Description Looks up a food object's UPC on OpenFoodFacts and fills in its calories, protein_g, carbs_g, fat_g and serving_size properties. The UPC in the body is stored on the object; leave it empty to use the object's upc property.
+// @Tags nutrition
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param request body request.EnrichNutritionRequest true "UPC to look up"
+// @Success 200 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/nutrition [post]
+// @Security BearerAuth
+func (ctrl *NutritionController) EnrichObjectNutrition(w http.ResponseWriter, r *http.Request) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.EnrichNutritionRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.enrichNutritionUC.Execute(r.Context(), usecases.EnrichObjectNutritionRequest{
+		ObjectID:  objectID,
+		UPC:       req.UPC,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to look up nutrition facts", slog.Any("error", err))
+		writeNutritionError(w, err, "failed to look up nutrition facts")
+		return
+	}
+
+	ctrl.logger.Info("Object nutrition facts updated",
+		slog.String("object_id", objectID.String()),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+}
+
+// GetNutritionStats godoc
+// @Summary Get pantry nutrition totals
+// @Description Sums the calories, protein, carbs and fat held by the collection's active food objects, per serving × servings × quantity. Expired objects are skipped. Also lists calories by container, most first.
+// @Tags nutrition
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} response.NutritionStatsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/nutrition [get]
+// @Security BearerAuth
+func (ctrl *NutritionController) GetNutritionStats(w http.ResponseWriter, r *http.Request) {
+	user, userToken, ok := ctrl.userFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.nutritionStatsUC.Execute(r.Context(), usecases.GetNutritionStatsRequest{
+		CollectionID: collectionID,
+		UserID:       user.ID(),
+		UserToken:    userToken,
+		Now:          time.Now(),
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to get nutrition stats", slog.Any("error", err))
+		writeNutritionError(w, err, "failed to get nutrition stats")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewNutritionStatsResponse(resp.Stats))
+}
+
+func (ctrl *NutritionController) userFromRequest(w http.ResponseWriter, r *http.Request) (*entities.User, string, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, "", false
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, "", false
+	}
+
+	return user, userToken, true
+}
+
+func writeNutritionError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "access denied"):
+		httputil.Error(w, http.StatusForbidden, "access denied")
+	case errors.Is(err, entities.ErrNutritionDisabled):
+		httputil.Error(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, entities.ErrNotFoodObject),
+		errors.Is(err, entities.ErrMissingUPC),
+		errors.Is(err, entities.ErrInvalidUPC):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entities.ErrNutritionNotFound):
+		httputil.Error(w, http.StatusNotFound, err.Error())
+	case strings.Contains(err.Error(), "nutrition lookup failed"):
+		httputil.Error(w, http.StatusBadGateway, "nutrition lookup failed")
+	case strings.Contains(err.Error(), "object not found"):
+		httputil.Error(w, http.StatusNotFound, "object not found")
+	case strings.Contains(err.Error(), "not found"):
+		httputil.Error(w, http.StatusNotFound, "collection not found")
+	default:
+		httputil.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -1,27 +1,49 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/nishiki/backend/app/container"
 	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/middleware"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/app/importjobs"
 	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
 	"github.com/nishiki/backend/domain/usecases"
 )
 
 type ObjectController struct {
 	createObjectUC         *usecases.CreateObjectUseCase
 	updateObjectUC         *usecases.UpdateObjectUseCase
+	copyObjectUC           *usecases.CopyObjectUseCase
 	deleteObjectUC         *usecases.DeleteObjectUseCase
+	archiveObjectUC        *usecases.ArchiveObjectUseCase
+	claimObjectUC          *usecases.ClaimObjectUseCase
+	getObjectHistoryUC     *usecases.GetObjectHistoryUseCase
 	getCollectionObjectsUC *usecases.GetCollectionObjectsUseCase
 	bulkImportUC           *usecases.BulkImportObjectsUseCase
 	bulkImportCollectionUC *usecases.BulkImportCollectionUseCase
+	mergeObjectsUC         *usecases.MergeObjectsUseCase
+	retagObjectsUC         *usecases.RetagObjectsUseCase
+	batchObjectsUC         *usecases.BatchObjectsUseCase
+	parseObjectPhraseUC    *usecases.ParseObjectPhraseUseCase
+	findDuplicatesUC       *usecases.FindDuplicateObjectsUseCase
+	lookupCodeUC           *usecases.LookupObjectCodeUseCase
+	getExpiringObjectsUC   *usecases.GetExpiringObjectsUseCase
+	expirySuggestionsUC    *usecases.GetExpirySuggestionsUseCase
+	searchObjectsUC        *usecases.SearchObjectsUseCase
+	generateLabelUC        *usecases.GenerateObjectLabelUseCase
+	createSnapshotUC       *usecases.CreateCollectionSnapshotUseCase
+	snapshotBeforeImport   bool
+	importJobs             *importjobs.Queue
 	logger                 *slog.Logger
 }
 
@@ -30,12 +52,29 @@ func NewObjectController(
 	logger *slog.Logger,
 ) *ObjectController {
 	return &ObjectController{
-		createObjectUC:         usecases.NewCreateObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
-		updateObjectUC:         usecases.NewUpdateObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
-		deleteObjectUC:         usecases.NewDeleteObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		createObjectUC:         usecases.NewCreateObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.GroupQuotaRepo, c.UsageRepo, c.ExpiryHistoryRepo, c.AuthService, c.Events()),
+		updateObjectUC:         usecases.NewUpdateObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectMoveRepo, c.ObjectCodeRepo, c.AuthService, c.Events()),
+		copyObjectUC:           usecases.NewCopyObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService),
+		deleteObjectUC:         usecases.NewDeleteObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.MediaRepo, c.MediaStorage, c.AuthService),
+		archiveObjectUC:        usecases.NewArchiveObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		claimObjectUC:          usecases.NewClaimObjectUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		getObjectHistoryUC:     usecases.NewGetObjectHistoryUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectMoveRepo, c.AuthService),
 		getCollectionObjectsUC: usecases.NewGetCollectionObjectsUseCase(c.CollectionRepo, c.ContainerRepo, c.AuthService),
-		bulkImportUC:           usecases.NewBulkImportObjectsUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService, c.ImageSearchService, logger),
-		bulkImportCollectionUC: usecases.NewBulkImportCollectionUseCase(c.CollectionRepo, c.ContainerRepo, c.AuthService, c.GetConfig().Import.ReservedColumns, c.ImageSearchService, logger),
+		bulkImportUC:           usecases.NewBulkImportObjectsUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService, c.ImageSearchService, logger),
+		bulkImportCollectionUC: usecases.NewBulkImportCollectionUseCase(c.CollectionRepo, c.ContainerRepo, c.ObjectCodeRepo, c.GroupQuotaRepo, c.UsageRepo, c.AuthService, c.GetConfig().Import.ReservedColumns, c.ImageSearchService, logger),
+		mergeObjectsUC:         usecases.NewMergeObjectsUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.AuthService),
+		retagObjectsUC:         usecases.NewRetagObjectsUseCase(c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		batchObjectsUC:         usecases.NewBatchObjectsUseCase(c.ContainerRepo, c.CollectionRepo, c.ObjectCodeRepo, c.ObjectMoveRepo, c.MediaRepo, c.MediaStorage, c.AuthService),
+		parseObjectPhraseUC:    usecases.NewParseObjectPhraseUseCase(c.CollectionRepo, c.ContainerRepo, c.AuthService),
+		findDuplicatesUC:       usecases.NewFindDuplicateObjectsUseCase(c.ContainerRepo, c.AuthService),
+		lookupCodeUC:           usecases.NewLookupObjectCodeUseCase(c.ObjectCodeRepo, c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		getExpiringObjectsUC:   usecases.NewGetExpiringObjectsUseCase(c.CollectionRepo, c.ContainerRepo, c.AuthService),
+		expirySuggestionsUC:    usecases.NewGetExpirySuggestionsUseCase(c.ExpiryHistoryRepo),
+		searchObjectsUC:        usecases.NewSearchObjectsUseCase(c.CollectionRepo, c.ContainerRepo, c.AuthService),
+		generateLabelUC:        usecases.NewGenerateObjectLabelUseCase(c.ContainerRepo, c.CollectionRepo, c.PreferencesRepo, c.AuthService, c.LabelRenderer),
+		createSnapshotUC:       usecases.NewCreateCollectionSnapshotUseCase(c.CollectionRepo, c.SnapshotRepo, c.AuthService, c.GetConfig().Snapshots.MaxPerCollection),
+		snapshotBeforeImport:   c.GetConfig().Snapshots.BeforeImport,
+		importJobs:             c.GetImportJobs(),
 		logger:                 logger,
 	}
 }
@@ -91,7 +130,7 @@ func (ctrl *ObjectController) CreateObject(w http.ResponseWriter, r *http.Reques
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -102,74 +141,1598 @@ func (ctrl *ObjectController) CreateObject(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// CollectionID from path (needed when no container specified)
-	var collectionID *entities.CollectionID
-	if cidStr := r.URL.Query().Get("collection_id"); cidStr != "" {
-		cid, err := entities.CollectionIDFromString(cidStr)
-		if err != nil {
-			ctrl.logger.Warn("Invalid collection_id query param", slog.Any("error", err))
-			httputil.Error(w, http.StatusBadRequest, "invalid collection_id")
-			return
-		}
-		collectionID = &cid
+	// CollectionID from path (needed when no container specified)
+	var collectionID *entities.CollectionID
+	if cidStr := r.URL.Query().Get("collection_id"); cidStr != "" {
+		cid, err := entities.CollectionIDFromString(cidStr)
+		if err != nil {
+			ctrl.logger.Warn("Invalid collection_id query param", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, "invalid collection_id")
+			return
+		}
+		collectionID = &cid
+	}
+
+	ucReq := usecases.CreateObjectRequest{
+		ContainerID:   containerID,
+		CollectionID:  collectionID,
+		Name:          req.Name,
+		Description:   req.Description,
+		ObjectType:    req.GetObjectType(),
+		Location:      req.Location,
+		Quantity:      req.Quantity,
+		Unit:          req.Unit,
+		MinQuantity:   req.MinQuantity,
+		Condition:     req.GetCondition(),
+		Status:        req.GetStatus(),
+		RawProperties: req.Properties,
+		Tags:          req.Tags,
+		ExpiresAt:     req.ExpiresAt,
+		UserID:        pathUserID,
+		UserToken:     userToken,
+	}
+
+	resp, err := ctrl.createObjectUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to create object", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		if strings.Contains(err.Error(), "invalid properties") || errors.Is(err, entities.ErrUnknownObjectType) || errors.Is(err, entities.ErrConditionNotSupported) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "container or collection not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to create object")
+		return
+	}
+
+	ctrl.logger.Info("Object created successfully",
+		slog.String("object_id", resp.Object.ID().String()),
+		slog.String("object_name", resp.Object.Name().String()),
+		slog.String("container_id", resp.ContainerID.String()),
+		slog.String("user_id", user.ID().String()))
+
+	objectResp := response.NewObjectResponse(*resp.Object, resp.ContainerID.String())
+	objectResp.StorageWarning = response.NewStorageWarningResponse(resp.StorageWarning)
+	httputil.JSON(w, http.StatusCreated, objectResp)
+}
+
+// GetCollectionObjects godoc
+// @Summary Get objects in collection
+// @Description Get all objects in a specific collection
+// @Tags objects
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param sort query string false "Sort field (name, created_at, updated_at, expires_at, quantity)"
+// @Param order query string false "Sort order (asc, desc)"
+// @Param archived query bool false "List archived objects instead of active ones"
+// @Param condition query []string false "Only objects graded with one of these conditions (mint, near_mint, good, fair, poor); repeatable"
+// @Param status query []string false "Only objects with one of these statuses (owned, wanted, ordered); repeatable"
+// @Success 200 {object} response.ObjectListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/objects [get]
+// @Security BearerAuth
+func (ctrl *ObjectController) GetCollectionObjects(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Users can only access their own objects
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	ucReq := usecases.GetCollectionObjectsRequest{
+		CollectionID: collectionID,
+		UserID:       pathUserID,
+		UserToken:    userToken,
+	}
+
+	// Parse optional filter query parameters.
+	q := r.URL.Query()
+	ucReq.Query = q.Get("q")
+	ucReq.Tags = q["tag"]
+	ucReq.Archived = q.Get("archived") == "true"
+
+	for _, raw := range q["condition"] {
+		condition, err := entities.ParseCondition(raw)
+		if err != nil || condition == entities.ConditionNone {
+			httputil.Error(w, http.StatusBadRequest, entities.ErrInvalidCondition.Error())
+			return
+		}
+		ucReq.Conditions = append(ucReq.Conditions, condition)
+	}
+
+	for _, raw := range q["status"] {
+		status, err := entities.ParseObjectStatus(raw)
+		if err != nil || raw == "" {
+			httputil.Error(w, http.StatusBadRequest, entities.ErrInvalidObjectStatus.Error())
+			return
+		}
+		ucReq.Statuses = append(ucReq.Statuses, status)
+	}
+
+	if cidStr := q.Get("container_id"); cidStr != "" {
+		cid, err := entities.ContainerIDFromString(cidStr)
+		if err != nil {
+			ctrl.logger.Warn("Invalid container_id query param", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, "invalid container_id")
+			return
+		}
+		ucReq.ContainerID = &cid
+	}
+
+	ucReq.Sort, err = request.GetSortOptionsFromQuery(r, usecases.ObjectSortFields)
+	if err != nil {
+		ctrl.logger.Warn("Invalid sort parameters", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse property[key]=value filters.
+	for paramKey, values := range q {
+		if strings.HasPrefix(paramKey, "property[") && strings.HasSuffix(paramKey, "]") {
+			propKey := paramKey[len("property[") : len(paramKey)-1]
+			if ucReq.PropertyFilters == nil {
+				ucReq.PropertyFilters = make(map[string]string)
+			}
+			ucReq.PropertyFilters[propKey] = values[0]
+		}
+	}
+
+	resp, err := ctrl.getCollectionObjectsUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to get objects", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to get objects")
+		return
+	}
+	ctrl.logger.Debug("Objects retrieved successfully",
+		slog.String("collection_id", collectionID.String()),
+		slog.String("user_id", user.ID().String()),
+		slog.Int("object_count", len(resp.Objects)))
+
+	objectResponses := make([]response.ObjectResponse, len(resp.Objects))
+	for i, item := range resp.Objects {
+		objectResponses[i] = response.NewObjectResponse(item.Object, item.ContainerID.String())
+		objectResponses[i].StorageWarning = response.NewStorageWarningResponse(item.StorageWarning)
+	}
+	httputil.JSON(w, http.StatusOK, response.ObjectListResponse{Objects: objectResponses, Total: len(objectResponses)})
+}
+
+// UpdateObject godoc
+// @Summary Update object
+// @Description Update object properties
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param object body request.UpdateObjectRequest true "Object update data"
+// @Success 200 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id} [put]
+// @Security BearerAuth
+func (ctrl *ObjectController) UpdateObject(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Users can only update their own objects
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.UpdateObjectRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	containerID, err := req.GetContainerID()
+	if err != nil {
+		ctrl.logger.Warn("Invalid container ID in body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, "invalid container_id")
+		return
+	}
+
+	ucReq := usecases.UpdateObjectRequest{
+		ContainerID:   containerID,
+		ObjectID:      objectID,
+		Name:          req.Name,
+		Description:   req.Description,
+		Location:      req.Location,
+		Quantity:      req.Quantity,
+		Unit:          req.Unit,
+		MinQuantity:   req.MinQuantity,
+		Condition:     req.GetCondition(),
+		Status:        req.GetStatus(),
+		RawProperties: req.Properties,
+		Tags:          req.Tags,
+		ExpiresAt:     req.ExpiresAt,
+		UserID:        pathUserID,
+		UserToken:     userToken,
+	}
+	ctrl.writeObjectUpdate(w, r, ucReq)
+}
+
+// PatchObject godoc
+// @Summary Patch object
+// @Description Apply an RFC 7386 JSON Merge Patch to an object: only the fields sent change and null removes a value. Properties are merged into the current ones; tags, when given, replace the object's tags, and a container_id moves the object.
+// @Tags objects
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param object body request.PatchObjectRequest true "Fields to change"
+// @Success 200 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id} [patch]
+// @Security BearerAuth
+func (ctrl *ObjectController) PatchObject(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.PatchObjectRequest
+	patch, err := httputil.DecodeMergePatch(r, &req)
+	if errors.Is(err, httputil.ErrUnsupportedPatchType) {
+		httputil.Error(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if patch.Empty() {
+		httputil.Error(w, http.StatusBadRequest, "no fields to update")
+		return
+	}
+	if patch.Removes("name") || patch.Removes("container_id") {
+		httputil.Error(w, http.StatusBadRequest, "name and container_id cannot be removed")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	containerID, err := req.GetContainerID()
+	if err != nil {
+		ctrl.logger.Warn("Invalid container ID in body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, "invalid container_id")
+		return
+	}
+
+	ucReq := usecases.UpdateObjectRequest{
+		ContainerID:     containerID,
+		ObjectID:        objectID,
+		Name:            req.Name,
+		Description:     req.Description,
+		Location:        req.Location,
+		Quantity:        req.Quantity,
+		Unit:            req.Unit,
+		MinQuantity:     req.MinQuantity,
+		Condition:       req.GetCondition(),
+		Status:          req.GetStatus(),
+		RawProperties:   req.Properties,
+		MergeProperties: true,
+		Tags:            req.Tags,
+		ExpiresAt:       req.ExpiresAt,
+		UserID:          pathUserID,
+		UserToken:       userToken,
+	}
+	// null removes a value: text fields are emptied, the quantity, the
+	// low-stock threshold, the condition and the expiry dropped, properties
+	// and tags cleared, and the status reset to owned
+	if patch.Removes("description") {
+		ucReq.Description = new("")
+	}
+	if patch.Removes("location") {
+		ucReq.Location = new("")
+	}
+	if patch.Removes("unit") {
+		ucReq.Unit = new("")
+	}
+	ucReq.ClearQuantity = patch.Removes("quantity")
+	if patch.Removes("min_quantity") {
+		ucReq.MinQuantity = new(-1.0)
+	}
+	if patch.Removes("condition") {
+		ucReq.Condition = new(entities.ConditionNone)
+	}
+	if patch.Removes("status") {
+		ucReq.Status = new(entities.ObjectStatusOwned)
+	}
+	if patch.Removes("properties") {
+		ucReq.RawProperties = map[string]any{}
+		ucReq.MergeProperties = false
+	}
+	if patch.Removes("tags") {
+		ucReq.Tags = []string{}
+	}
+	ucReq.ClearExpiresAt = patch.Removes("expires_at")
+	ctrl.writeObjectUpdate(w, r, ucReq)
+}
+
+// writeObjectUpdate runs an update or patch and answers with the object.
+func (ctrl *ObjectController) writeObjectUpdate(w http.ResponseWriter, r *http.Request, ucReq usecases.UpdateObjectRequest) {
+	resp, err := ctrl.updateObjectUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to update object", slog.Any("error", err))
+		if strings.Contains(err.Error(), "invalid properties") || errors.Is(err, entities.ErrConditionNotSupported) || errors.Is(err, entities.ErrObjectTypeMismatch) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "object not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to update object")
+		return
+	}
+
+	ctrl.logger.Info("Object updated successfully",
+		slog.String("object_id", ucReq.ObjectID.String()),
+		slog.String("user_id", ucReq.UserID.String()))
+
+	objectResp := response.NewObjectResponse(*resp.Object, resp.ContainerID.String())
+	objectResp.StorageWarning = response.NewStorageWarningResponse(resp.StorageWarning)
+	objectResp.DroppedProperties = resp.DroppedProperties
+	httputil.JSON(w, http.StatusOK, objectResp)
+}
+
+// CopyObject godoc
+// @Summary Copy object
+// @Description Duplicate an object under a new ID, next to the original or into container_id. The container may be in another collection of the same object type: properties are carried over to its schema and those it has no place for are listed in dropped_properties.
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param body body request.CopyObjectRequest false "Where to put the copy"
+// @Success 201 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/copy [post]
+// @Security BearerAuth
+func (ctrl *ObjectController) CopyObject(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.CopyObjectRequest
+	if r.ContentLength != 0 {
+		if err := httputil.DecodeJSON(r, &req); err != nil {
+			ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	containerID, err := req.GetContainerID()
+	if err != nil {
+		ctrl.logger.Warn("Invalid container ID in body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, "invalid container_id")
+		return
+	}
+
+	resp, err := ctrl.copyObjectUC.Execute(r.Context(), usecases.CopyObjectRequest{
+		ObjectID:    objectID,
+		ContainerID: containerID,
+		UserID:      pathUserID,
+		UserToken:   userToken,
+	})
+	if err != nil {
+		ctrl.logger.Warn("Failed to copy object", slog.Any("error", err))
+		if writeQuotaExceeded(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrObjectTypeMismatch), errors.Is(err, entities.ErrConditionNotSupported):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "target container not found"):
+			httputil.Error(w, http.StatusNotFound, "container not found")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "object not found")
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to copy object")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Object copied",
+		slog.String("object_id", objectID.String()),
+		slog.String("copy_id", resp.Object.ID().String()),
+		slog.String("user_id", user.ID().String()))
+
+	objectResp := response.NewObjectResponse(*resp.Object, resp.ContainerID.String())
+	objectResp.StorageWarning = response.NewStorageWarningResponse(resp.StorageWarning)
+	objectResp.DroppedProperties = resp.DroppedProperties
+	httputil.JSON(w, http.StatusCreated, objectResp)
+}
+
+// ArchiveObject godoc
+// @Summary Archive or restore object
+// @Description Archive an object to keep it for history while hiding it from default lists and counts, or restore it
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param body body request.ArchiveObjectRequest true "Archived state"
+// @Success 200 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/archive [patch]
+// @Security BearerAuth
+func (ctrl *ObjectController) ArchiveObject(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.ArchiveObjectRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.archiveObjectUC.Execute(r.Context(), usecases.ArchiveObjectRequest{
+		ObjectID:  objectID,
+		Archived:  *req.Archived,
+		UserID:    pathUserID,
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to archive object", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "object not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to archive object")
+		return
+	}
+
+	ctrl.logger.Info("Object archived state updated",
+		slog.String("object_id", objectID.String()),
+		slog.Bool("archived", *req.Archived),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+}
+
+// ClaimObject godoc
+// @Summary Claim object
+// @Description Reserve an object, e.g. an ingredient you plan to cook with, so other group members see it is taken. The claim expires at until, or after a day; claiming your own object again renews it.
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Param body body request.ClaimObjectRequest false "When the claim ends"
+// @Success 200 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/claim [post]
+// @Security BearerAuth
+func (ctrl *ObjectController) ClaimObject(w http.ResponseWriter, r *http.Request) {
+	ctrl.setObjectClaim(w, r, true)
+}
+
+// ReleaseObjectClaim godoc
+// @Summary Release object claim
+// @Description Drop the claim on an object. Only the claimer and the collection owner may release an active claim.
+// @Tags objects
+// @Produce json
+// @Param id path string true "User ID"
+// @Param object_id path string true "Object ID"
+// @Success 200 {object} response.ObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/{object_id}/claim [delete]
+// @Security BearerAuth
+func (ctrl *ObjectController) ReleaseObjectClaim(w http.ResponseWriter, r *http.Request) {
+	ctrl.setObjectClaim(w, r, false)
+}
+
+func (ctrl *ObjectController) setObjectClaim(w http.ResponseWriter, r *http.Request, claimed bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objectID, err := request.GetObjectIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.ClaimObjectRequest
+	if claimed && r.ContentLength != 0 {
+		if err := httputil.DecodeJSON(r, &req); err != nil {
+			ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	resp, err := ctrl.claimObjectUC.Execute(r.Context(), usecases.ClaimObjectRequest{
+		ObjectID:  objectID,
+		Claimed:   claimed,
+		Until:     req.Until,
+		UserID:    pathUserID,
+		Username:  user.Username().String(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Warn("Failed to update object claim", slog.Any("error", err))
+		switch {
+		case errors.Is(err, entities.ErrObjectClaimed):
+			httputil.Error(w, http.StatusConflict, err.Error())
+		case errors.Is(err, entities.ErrInvalidClaimExpiry), strings.Contains(err.Error(), "cannot be claimed"):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "object not found")
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to update object claim")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Object claim updated",
+		slog.String("object_id", objectID.String()),
+		slog.Bool("claimed", claimed),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+}
+
+// RetagObjects godoc
+// @Summary Add and remove tags in bulk
+// @Description Add and remove tags on every object of a collection matching a filter
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param retag body request.RetagObjectsRequest true "Filter and tags"
+// @Success 200 {object} response.RetagObjectsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/objects/retag [post]
+// @Security BearerAuth
+func (ctrl *ObjectController) RetagObjects(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.RetagObjectsRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	containerID, err := req.Filter.GetContainerID()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	objectIDs, err := req.Filter.GetObjectIDs()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.retagObjectsUC.Execute(r.Context(), usecases.RetagObjectsRequest{
+		CollectionID: collectionID,
+		Filter: usecases.RetagFilter{
+			Tag:         strings.TrimSpace(req.Filter.Tag),
+			ContainerID: containerID,
+			ObjectType:  entities.ObjectType(req.Filter.ObjectType),
+			ObjectIDs:   objectIDs,
+		},
+		AddTags:    req.AddTags,
+		RemoveTags: req.RemoveTags,
+		UserID:     pathUserID,
+		UserToken:  userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to retag objects", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "container not found") {
+			httputil.Error(w, http.StatusNotFound, "container not found")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		if strings.Contains(err.Error(), "both added and removed") || strings.Contains(err.Error(), "at least one tag") {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to retag objects")
+		return
+	}
+
+	ctrl.logger.Info("Objects retagged",
+		slog.String("collection_id", collectionID.String()),
+		slog.Int("matched", resp.Matched),
+		slog.Int("updated", resp.Updated),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.RetagObjectsResponse{Matched: resp.Matched, Updated: resp.Updated})
+}
+
+// BatchObjects godoc
+// @Summary Move or delete objects in bulk
+// @Description Move the named objects of a collection to one of its containers, or delete them
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Param batch body request.BatchObjectsRequest true "Operation and objects"
+// @Success 200 {object} response.BatchObjectsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/objects/batch [post]
+// @Security BearerAuth
+func (ctrl *ObjectController) BatchObjects(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.BatchObjectsRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	objectIDs, err := req.GetObjectIDs()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	containerID, err := req.GetContainerID()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.batchObjectsUC.Execute(r.Context(), usecases.BatchObjectsRequest{
+		CollectionID: collectionID,
+		ObjectIDs:    objectIDs,
+		Operation:    entities.BatchOperation(req.Operation),
+		ContainerID:  containerID,
+		UserID:       pathUserID,
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to apply batch operation", slog.String("operation", req.Operation), slog.Any("error", err))
+		switch {
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case errors.Is(err, usecases.ErrBatchObjectNotFound):
+			httputil.Error(w, http.StatusNotFound, err.Error())
+		case strings.Contains(err.Error(), "container not found"):
+			httputil.Error(w, http.StatusNotFound, "container not found")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+		case errors.Is(err, entities.ErrInvalidBatchOperation):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to apply batch operation")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Batch operation applied",
+		slog.String("collection_id", collectionID.String()),
+		slog.String("operation", req.Operation),
+		slog.Int("requested", len(objectIDs)),
+		slog.Int("changed", resp.Changed),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.BatchObjectsResponse{Changed: resp.Changed})
+}
+
+// ParseObject godoc
+// @Summary Parse a quick add phrase
+// @Description Read the name, quantity, unit and place out of a phrase like "three cans of tomatoes in the pantry". Nothing is saved.
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param phrase body request.ParseObjectRequest true "Phrase to parse"
+// @Success 200 {object} response.ParsedObjectResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/parse [post]
+// @Security BearerAuth
+func (ctrl *ObjectController) ParseObject(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.ParseObjectRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	collectionID, err := req.GetCollectionID()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, "invalid collection_id")
+		return
+	}
+
+	resp, err := ctrl.parseObjectPhraseUC.Execute(r.Context(), usecases.ParseObjectPhraseRequest{
+		Text:         req.Text,
+		CollectionID: collectionID,
+		UserID:       pathUserID,
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Warn("Failed to parse object phrase", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		if strings.Contains(err.Error(), "must name an object") {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to parse phrase")
+		return
+	}
+
+	parsed := response.ParsedObjectResponse{
+		Name:     resp.Name,
+		Quantity: resp.Quantity,
+		Unit:     resp.Unit,
+		Location: resp.Location,
+	}
+	if resp.Container != nil {
+		parsed.ContainerID = resp.Container.ID().String()
+		parsed.ContainerName = resp.Container.Name().String()
+	}
+	httputil.JSON(w, http.StatusOK, parsed)
+}
+
+// MergeObjects godoc
+// @Summary Merge objects
+// @Description Merge two or more objects of one collection into the oldest of them
+// @Tags objects
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param merge body request.MergeObjectsRequest true "Objects to merge"
+// @Success 200 {object} response.MergeObjectsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/objects/merge [post]
+// @Security BearerAuth
+func (ctrl *ObjectController) MergeObjects(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.MergeObjectsRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	objectIDs, err := req.GetObjectIDs()
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.mergeObjectsUC.Execute(r.Context(), usecases.MergeObjectsRequest{
+		ObjectIDs: objectIDs,
+		Preview:   req.Preview,
+		UserID:    pathUserID,
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to merge objects", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "object not found")
+			return
+		}
+		if strings.Contains(err.Error(), "at least two") || strings.Contains(err.Error(), "same collection") ||
+			strings.Contains(err.Error(), "archived objects") || strings.Contains(err.Error(), "cannot merge quantities") {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to merge objects")
+		return
+	}
+
+	mergedIDs := make([]string, len(resp.MergedIDs))
+	for i, id := range resp.MergedIDs {
+		mergedIDs[i] = id.String()
+	}
+
+	if !req.Preview {
+		ctrl.logger.Info("Objects merged",
+			slog.String("object_id", resp.Object.ID().String()),
+			slog.Any("merged_ids", mergedIDs),
+			slog.String("user_id", user.ID().String()))
+	}
+
+	httputil.JSON(w, http.StatusOK, response.MergeObjectsResponse{
+		Object:    response.NewObjectResponse(*resp.Object, resp.ContainerID.String()),
+		MergedIDs: mergedIDs,
+		Preview:   req.Preview,
+	})
+}
+
+// FindDuplicateObjects godoc
+// @Summary Find duplicate objects
+// @Description List groups of active objects in a collection that share a name, ignoring case and spacing
+// @Tags objects
+// @Produce json
+// @Param id path string true "User ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} response.DuplicateGroupsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/{collection_id}/duplicates [get]
+// @Security BearerAuth
+func (ctrl *ObjectController) FindDuplicateObjects(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collectionID, err := request.GetCollectionIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.findDuplicatesUC.Execute(r.Context(), usecases.FindDuplicateObjectsRequest{
+		CollectionID: collectionID,
+		UserID:       pathUserID,
+		UserToken:    userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to find duplicate objects", slog.Any("error", err))
+		if strings.Contains(err.Error(), "access denied") {
+			httputil.Error(w, http.StatusForbidden, "access denied")
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			httputil.Error(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to find duplicate objects")
+		return
+	}
+
+	groups := make([]response.DuplicateGroupResponse, len(resp.Groups))
+	for i, group := range resp.Groups {
+		objects := make([]response.ObjectResponse, len(group.Objects))
+		for j, item := range group.Objects {
+			objects[j] = response.NewObjectResponse(item.Object, item.ContainerID.String())
+		}
+		groups[i] = response.DuplicateGroupResponse{Key: group.Key, Objects: objects}
+	}
+	httputil.JSON(w, http.StatusOK, response.DuplicateGroupsResponse{Groups: groups})
+}
+
+// LookupObjectCode godoc
+// @Summary Find objects by barcode, ISBN or SKU
+// @Description List the account's active objects carrying a code, so scanning a known item can increase its quantity instead of creating a duplicate
+// @Tags objects
+// @Produce json
+// @Param id path string true "User ID"
+// @Param code query string true "Barcode, ISBN or SKU; spaces and dashes are ignored"
+// @Success 200 {object} response.ObjectCodeLookupResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/lookup [get]
+// @Security BearerAuth
+func (ctrl *ObjectController) LookupObjectCode(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		httputil.Error(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	resp, err := ctrl.lookupCodeUC.Execute(r.Context(), usecases.LookupObjectCodeRequest{
+		Code:      code,
+		UserID:    pathUserID,
+		UserToken: userToken,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrInvalidObjectCode) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to look up object code", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to look up code")
+		return
+	}
+
+	matches := make([]response.ObjectCodeMatchResponse, len(resp.Objects))
+	for i, match := range resp.Objects {
+		matches[i] = response.ObjectCodeMatchResponse{
+			CollectionID: match.CollectionID.String(),
+			Object:       response.NewObjectResponse(match.Object, match.ContainerID.String()),
+		}
+	}
+	httputil.JSON(w, http.StatusOK, response.ObjectCodeLookupResponse{Code: resp.Code, Matches: matches})
+}
+
+// GetExpirySuggestions godoc
+// @Summary Suggest expiry dates by category
+// @Description How many days the account's objects of each category usually keep, going by the expiry dates set when creating them, for pre-filling a new object's expiry
+// @Tags objects
+// @Produce json
+// @Param id path string true "User ID"
+// @Param category query string false "Only this category, ignoring case"
+// @Success 200 {object} response.ExpirySuggestionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/expiry-suggestions [get]
+// @Security BearerAuth
+func (ctrl *ObjectController) GetExpirySuggestions(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.expirySuggestionsUC.Execute(r.Context(), usecases.GetExpirySuggestionsRequest{
+		UserID:   pathUserID,
+		Category: r.URL.Query().Get("category"),
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to get expiry suggestions", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get expiry suggestions")
+		return
+	}
+
+	suggestions := make([]response.ExpirySuggestionResponse, len(resp.Suggestions))
+	for i, s := range resp.Suggestions {
+		suggestions[i] = response.ExpirySuggestionResponse{Category: s.Category, Days: s.Days, Samples: s.Samples}
+	}
+	httputil.JSON(w, http.StatusOK, response.ExpirySuggestionsResponse{Suggestions: suggestions})
+}
+
+// SearchObjects godoc
+// @Summary Search objects
+// @Description Find active objects in every collection the user can see whose name, tags, description, location or property values contain each term of q, ignoring case. Name matches come first; each match carries a snippet with the matched terms as byte ranges.
+// @Tags objects
+// @Produce json
+// @Param id path string true "User ID"
+// @Param q query string true "Search terms"
+// @Param limit query int false "Most matches to return, 1-100 (default 20)"
+// @Success 200 {object} response.SearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/search [get]
+// @Security BearerAuth
+func (ctrl *ObjectController) SearchObjects(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	query, limit, err := request.GetSearchFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.searchObjectsUC.Execute(r.Context(), usecases.SearchObjectsRequest{
+		UserID:    pathUserID,
+		UserToken: userToken,
+		Query:     query,
+		Limit:     limit,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrEmptySearchQuery) || errors.Is(err, entities.ErrInvalidSearchLimit) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		// The client moved on to a newer query
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		ctrl.logger.Error("Failed to search objects", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to search objects")
+		return
+	}
+
+	matches := make([]response.SearchMatchResponse, len(resp.Matches))
+	for i, match := range resp.Matches {
+		highlights := make([]response.TextRangeResponse, len(match.Highlights))
+		for j, h := range match.Highlights {
+			highlights[j] = response.TextRangeResponse{Start: h.Start, End: h.End}
+		}
+		matches[i] = response.SearchMatchResponse{
+			Object:         response.NewObjectResponse(match.Object, match.ContainerID.String()),
+			CollectionID:   match.CollectionID.String(),
+			CollectionName: match.CollectionName,
+			ContainerName:  match.ContainerName,
+			Field:          match.Field,
+			Snippet:        match.Snippet,
+			Highlights:     highlights,
+		}
+	}
+	httputil.JSON(w, http.StatusOK, response.SearchResponse{Query: query, Matches: matches, Truncated: resp.Truncated})
+}
+
+// expiryCalendarDefaultDays is how far ahead the expiry calendar looks when
+// the subscription URL does not say.
+const expiryCalendarDefaultDays = 90
+
+// GetExpiryCalendar godoc
+// @Summary Subscribe to expiry dates
+// @Description iCalendar feed with an all-day event on the expiry date of every active object the user can see that expires within days (default 90, at most 366). Already expired objects are included.
+// @Tags objects
+// @Produce text/calendar
+// @Param id path string true "User ID"
+// @Param days query int false "How many days ahead to include"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/expiring.ics [get]
+// @Security BearerAuth
+func (ctrl *ObjectController) GetExpiryCalendar(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
 	}
 
-	ucReq := usecases.CreateObjectRequest{
-		ContainerID:   containerID,
-		CollectionID:  collectionID,
-		Name:          req.Name,
-		Description:   req.Description,
-		ObjectType:    req.GetObjectType(),
-		Location:      req.Location,
-		Quantity:      req.Quantity,
-		Unit:          req.Unit,
-		RawProperties: req.Properties,
-		Tags:          req.Tags,
-		ExpiresAt:     req.ExpiresAt,
-		UserID:        pathUserID,
-		UserToken:     userToken,
+	days, err := request.GetExpiringDaysFromQuery(r, expiryCalendarDefaultDays)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	resp, err := ctrl.createObjectUC.Execute(r.Context(), ucReq)
+	now := time.Now()
+	resp, err := ctrl.getExpiringObjectsUC.Execute(r.Context(), usecases.GetExpiringObjectsRequest{
+		UserID:    pathUserID,
+		UserToken: userToken,
+		Now:       now,
+		Days:      days,
+	})
 	if err != nil {
-		ctrl.logger.Error("Failed to create object", slog.Any("error", err))
-		if strings.Contains(err.Error(), "access denied") {
-			httputil.Error(w, http.StatusForbidden, "access denied")
-			return
-		}
-		if strings.Contains(err.Error(), "not found") {
-			httputil.Error(w, http.StatusNotFound, "container or collection not found")
+		if errors.Is(err, entities.ErrInvalidExpiringDays) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		httputil.Error(w, http.StatusInternalServerError, "failed to create object")
+		ctrl.logger.Error("Failed to list expiring objects", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to build expiry calendar")
 		return
 	}
 
-	ctrl.logger.Info("Object created successfully",
-		slog.String("object_id", resp.Object.ID().String()),
-		slog.String("object_name", resp.Object.Name().String()),
-		slog.String("container_id", resp.ContainerID.String()),
-		slog.String("user_id", user.ID().String()))
-
-	httputil.JSON(w, http.StatusCreated, response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="expiring.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(usecases.EncodeExpiryCalendar(resp.Objects, now))
 }
 
-// GetCollectionObjects godoc
-// @Summary Get objects in collection
-// @Description Get all objects in a specific collection
+// DeleteObject godoc
+// @Summary Delete object
+// @Description Delete an object from a collection
 // @Tags objects
 // @Produce json
 // @Param id path string true "User ID"
-// @Param collection_id path string true "Collection ID"
-// @Success 200 {object} response.ObjectListResponse
+// @Param object_id path string true "Object ID"
+// @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /accounts/{id}/collections/{collection_id}/objects [get]
+// @Router /accounts/{id}/objects/{object_id} [delete]
 // @Security BearerAuth
-func (ctrl *ObjectController) GetCollectionObjects(w http.ResponseWriter, r *http.Request) {
+func (ctrl *ObjectController) DeleteObject(w http.ResponseWriter, r *http.Request) {
 	user, exists := middleware.GetCurrentUser(r)
 	if !exists {
 		ctrl.logger.Error("No authenticated user found in context")
@@ -191,95 +1754,78 @@ func (ctrl *ObjectController) GetCollectionObjects(w http.ResponseWriter, r *htt
 		return
 	}
 
-	collectionID, err := request.GetCollectionIDFromPath(r)
+	objectID, err := request.GetObjectIDFromPath(r)
 	if err != nil {
-		ctrl.logger.Warn("Invalid collection ID in path", slog.Any("error", err))
+		ctrl.logger.Warn("Invalid object ID in path", slog.Any("error", err))
 		httputil.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Users can only access their own objects
+	// Users can only delete their own objects
 	if !pathUserID.Equals(user.ID()) {
 		httputil.Error(w, http.StatusForbidden, "access denied")
 		return
 	}
 
-	ucReq := usecases.GetCollectionObjectsRequest{
-		CollectionID: collectionID,
-		UserID:       pathUserID,
-		UserToken:    userToken,
-	}
-
-	// Parse optional filter query parameters.
-	q := r.URL.Query()
-	ucReq.Query = q.Get("q")
-	ucReq.Tags = q["tag"]
-
-	if cidStr := q.Get("container_id"); cidStr != "" {
+	// container_id is optional — use case looks it up automatically if omitted
+	var containerID *entities.ContainerID
+	if cidStr := r.URL.Query().Get("container_id"); cidStr != "" {
 		cid, err := entities.ContainerIDFromString(cidStr)
 		if err != nil {
-			ctrl.logger.Warn("Invalid container_id query param", slog.Any("error", err))
+			ctrl.logger.Warn("Invalid container ID", slog.Any("error", err))
 			httputil.Error(w, http.StatusBadRequest, "invalid container_id")
 			return
 		}
-		ucReq.ContainerID = &cid
+		containerID = &cid
 	}
 
-	// Parse property[key]=value filters.
-	for paramKey, values := range q {
-		if strings.HasPrefix(paramKey, "property[") && strings.HasSuffix(paramKey, "]") {
-			propKey := paramKey[len("property[") : len(paramKey)-1]
-			if ucReq.PropertyFilters == nil {
-				ucReq.PropertyFilters = make(map[string]string)
-			}
-			ucReq.PropertyFilters[propKey] = values[0]
-		}
+	ucReq := usecases.DeleteObjectRequest{
+		ContainerID: containerID,
+		ObjectID:    objectID,
+		UserID:      pathUserID,
+		UserToken:   userToken,
 	}
 
-	resp, err := ctrl.getCollectionObjectsUC.Execute(r.Context(), ucReq)
+	resp, err := ctrl.deleteObjectUC.Execute(r.Context(), ucReq)
 	if err != nil {
-		ctrl.logger.Error("Failed to get objects", slog.Any("error", err))
+		ctrl.logger.Error("Failed to delete object", slog.Any("error", err))
 		if strings.Contains(err.Error(), "access denied") {
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
 		}
 		if strings.Contains(err.Error(), "not found") {
-			httputil.Error(w, http.StatusNotFound, "collection not found")
+			httputil.Error(w, http.StatusNotFound, "object not found")
 			return
 		}
-		httputil.Error(w, http.StatusInternalServerError, "failed to get objects")
+		httputil.Error(w, http.StatusInternalServerError, "failed to delete object")
 		return
 	}
-	ctrl.logger.Debug("Objects retrieved successfully",
-		slog.String("collection_id", collectionID.String()),
-		slog.String("user_id", user.ID().String()),
-		slog.Int("object_count", len(resp.Objects)))
 
-	objectResponses := make([]response.ObjectResponse, len(resp.Objects))
-	for i, item := range resp.Objects {
-		objectResponses[i] = response.NewObjectResponse(item.Object, item.ContainerID.String())
-	}
-	httputil.JSON(w, http.StatusOK, response.ObjectListResponse{Objects: objectResponses, Total: len(objectResponses)})
+	ctrl.logger.Info("Object deleted successfully",
+		slog.String("object_id", objectID.String()),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusOK, response.DeleteObjectResponse{
+		Success: resp.Success,
+	})
 }
 
-// UpdateObject godoc
-// @Summary Update object
-// @Description Update object properties
+// GetObjectHistory godoc
+// @Summary Get object location history
+// @Description List every container the object has been in, oldest first, with when it arrived and who moved it
 // @Tags objects
-// @Accept json
 // @Produce json
 // @Param id path string true "User ID"
 // @Param object_id path string true "Object ID"
-// @Param object body request.UpdateObjectRequest true "Object update data"
-// @Success 200 {object} response.ObjectResponse
+// @Success 200 {object} response.ObjectHistoryResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /accounts/{id}/objects/{object_id} [put]
+// @Router /accounts/{id}/objects/{object_id}/history [get]
 // @Security BearerAuth
-func (ctrl *ObjectController) UpdateObject(w http.ResponseWriter, r *http.Request) {
+func (ctrl *ObjectController) GetObjectHistory(w http.ResponseWriter, r *http.Request) {
 	user, exists := middleware.GetCurrentUser(r)
 	if !exists {
 		ctrl.logger.Error("No authenticated user found in context")
@@ -308,49 +1854,18 @@ func (ctrl *ObjectController) UpdateObject(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Users can only update their own objects
 	if !pathUserID.Equals(user.ID()) {
 		httputil.Error(w, http.StatusForbidden, "access denied")
 		return
 	}
 
-	var req request.UpdateObjectRequest
-	if err := httputil.DecodeJSON(r, &req); err != nil {
-		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if err := req.Validate(); err != nil {
-		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	containerID, err := req.GetContainerID()
-	if err != nil {
-		ctrl.logger.Warn("Invalid container ID in body", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, "invalid container_id")
-		return
-	}
-
-	ucReq := usecases.UpdateObjectRequest{
-		ContainerID:   containerID,
-		ObjectID:      objectID,
-		Name:          req.Name,
-		Description:   req.Description,
-		Location:      req.Location,
-		Quantity:      req.Quantity,
-		Unit:          req.Unit,
-		RawProperties: req.Properties,
-		Tags:          req.Tags,
-		UserID:        pathUserID,
-		UserToken:     userToken,
-	}
-
-	resp, err := ctrl.updateObjectUC.Execute(r.Context(), ucReq)
+	resp, err := ctrl.getObjectHistoryUC.Execute(r.Context(), usecases.GetObjectHistoryRequest{
+		ObjectID:  objectID,
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
 	if err != nil {
-		ctrl.logger.Error("Failed to update object", slog.Any("error", err))
+		ctrl.logger.Error("Failed to get object history", slog.Any("error", err))
 		if strings.Contains(err.Error(), "access denied") {
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
@@ -359,33 +1874,32 @@ func (ctrl *ObjectController) UpdateObject(w http.ResponseWriter, r *http.Reques
 			httputil.Error(w, http.StatusNotFound, "object not found")
 			return
 		}
-		httputil.Error(w, http.StatusInternalServerError, "failed to update object")
+		httputil.Error(w, http.StatusInternalServerError, "failed to get object history")
 		return
 	}
 
-	ctrl.logger.Info("Object updated successfully",
-		slog.String("object_id", objectID.String()),
-		slog.String("user_id", user.ID().String()))
-
-	httputil.JSON(w, http.StatusOK, response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+	httputil.JSON(w, http.StatusOK, response.NewObjectHistoryResponse(resp.Object, resp.Timeline))
 }
 
-// DeleteObject godoc
-// @Summary Delete object
-// @Description Delete an object from a collection
+// GetObjectLabel godoc
+// @Summary Get a printable object label
+// @Description Render a label with the object's name, a QR code of its ID, its expiry and container path, sized to a Dymo or Brother label template. The template defaults to the one in the user's preferences.
 // @Tags objects
-// @Produce json
+// @Produce application/pdf
+// @Produce image/png
 // @Param id path string true "User ID"
 // @Param object_id path string true "Object ID"
-// @Success 200 {object} map[string]bool
+// @Param format query string false "pdf or png (default pdf)"
+// @Param template query string false "Label template key, overriding the preferred one"
+// @Success 200 {string} string "Label file"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /accounts/{id}/objects/{object_id} [delete]
+// @Router /accounts/{id}/objects/{object_id}/label [get]
 // @Security BearerAuth
-func (ctrl *ObjectController) DeleteObject(w http.ResponseWriter, r *http.Request) {
+func (ctrl *ObjectController) GetObjectLabel(w http.ResponseWriter, r *http.Request) {
 	user, exists := middleware.GetCurrentUser(r)
 	if !exists {
 		ctrl.logger.Error("No authenticated user found in context")
@@ -414,34 +1928,31 @@ func (ctrl *ObjectController) DeleteObject(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Users can only delete their own objects
 	if !pathUserID.Equals(user.ID()) {
 		httputil.Error(w, http.StatusForbidden, "access denied")
 		return
 	}
 
-	// container_id is optional — use case looks it up automatically if omitted
-	var containerID *entities.ContainerID
-	if cidStr := r.URL.Query().Get("container_id"); cidStr != "" {
-		cid, err := entities.ContainerIDFromString(cidStr)
-		if err != nil {
-			ctrl.logger.Warn("Invalid container ID", slog.Any("error", err))
-			httputil.Error(w, http.StatusBadRequest, "invalid container_id")
-			return
-		}
-		containerID = &cid
-	}
-
-	ucReq := usecases.DeleteObjectRequest{
-		ContainerID: containerID,
-		ObjectID:    objectID,
-		UserID:      pathUserID,
-		UserToken:   userToken,
+	q := r.URL.Query()
+	format, err := services.ParseLabelFormat(q.Get("format"))
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	resp, err := ctrl.deleteObjectUC.Execute(r.Context(), ucReq)
+	resp, err := ctrl.generateLabelUC.Execute(r.Context(), usecases.GenerateObjectLabelRequest{
+		ObjectID:  objectID,
+		Format:    format,
+		Template:  q.Get("template"),
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
 	if err != nil {
-		ctrl.logger.Error("Failed to delete object", slog.Any("error", err))
+		ctrl.logger.Error("Failed to generate object label", slog.Any("error", err))
+		if errors.Is(err, entities.ErrUnknownLabelTemplate) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "access denied") {
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
@@ -450,17 +1961,15 @@ func (ctrl *ObjectController) DeleteObject(w http.ResponseWriter, r *http.Reques
 			httputil.Error(w, http.StatusNotFound, "object not found")
 			return
 		}
-		httputil.Error(w, http.StatusInternalServerError, "failed to delete object")
+		httputil.Error(w, http.StatusInternalServerError, "failed to generate label")
 		return
 	}
 
-	ctrl.logger.Info("Object deleted successfully",
-		slog.String("object_id", objectID.String()),
-		slog.String("user_id", user.ID().String()))
-
-	httputil.JSON(w, http.StatusOK, response.DeleteObjectResponse{
-		Success: resp.Success,
-	})
+	filename := sanitizeFilename(resp.ObjectName) + "-label." + string(format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp.Label)
 }
 
 // RemoveObjectFromContainer godoc
@@ -605,7 +2114,7 @@ func (ctrl *ObjectController) BulkImport(w http.ResponseWriter, r *http.Request)
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -656,6 +2165,9 @@ func (ctrl *ObjectController) BulkImport(w http.ResponseWriter, r *http.Request)
 
 	resp, err := ctrl.bulkImportUC.Execute(r.Context(), ucReq)
 	if err != nil {
+		if writeQuotaExceeded(w, err) {
+			return
+		}
 		ctrl.logger.Error("Failed to bulk import", slog.Any("error", err))
 		httputil.Error(w, http.StatusInternalServerError, "failed to import objects")
 		return
@@ -683,18 +2195,20 @@ func (ctrl *ObjectController) BulkImport(w http.ResponseWriter, r *http.Request)
 
 // BulkImportToCollection godoc
 // @Summary Bulk import objects to collection
-// @Description Import multiple objects to a specific collection from JSON/CSV data
+// @Description Queue an import of JSON/CSV data into a collection. The rows may be sent as JSON, or the file uploaded as multipart/form-data in the file field with the other options as form fields. The import runs in the background; follow it with GET /imports/{job_id} or its event stream.
 // @Tags objects
 // @Accept json
+// @Accept multipart/form-data
 // @Produce json
 // @Param id path string true "User ID"
 // @Param collection_id path string true "Collection ID"
 // @Param import body request.BulkImportCollectionRequest true "Bulk import data"
-// @Success 200 {object} request.BulkImportResponse
+// @Success 202 {object} response.ImportJobResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 403 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /accounts/{id}/collections/{collection_id}/import [post]
 // @Security BearerAuth
 func (ctrl *ObjectController) BulkImportToCollection(w http.ResponseWriter, r *http.Request) {
@@ -732,8 +2246,17 @@ func (ctrl *ObjectController) BulkImportToCollection(w http.ResponseWriter, r *h
 		return
 	}
 
+	// The file may be uploaded as is, or parsed by the client and sent as JSON
 	var req request.BulkImportCollectionRequest
-	if err := httputil.DecodeJSON(r, &req); err != nil {
+	if request.IsMultipart(r) {
+		form, err := request.ReadBulkImportCollectionForm(w, r)
+		if err != nil {
+			ctrl.logger.Warn("Invalid import upload", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		req = *form
+	} else if err := httputil.DecodeJSON(r, &req); err != nil {
 		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
 		httputil.Error(w, http.StatusBadRequest, err.Error())
 		return
@@ -741,7 +2264,7 @@ func (ctrl *ObjectController) BulkImportToCollection(w http.ResponseWriter, r *h
 
 	if err := req.Validate(); err != nil {
 		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
-		httputil.Error(w, http.StatusBadRequest, err.Error())
+		httputil.InvalidRequest(w, err)
 		return
 	}
 
@@ -769,11 +2292,13 @@ func (ctrl *ObjectController) BulkImportToCollection(w http.ResponseWriter, r *h
 		LocationColumn:    req.LocationColumn,
 		NameColumn:        req.NameColumn,
 		InferSchema:       req.InferSchema,
+		SourceFormat:      usecases.ImportSourceFormat(req.SourceFormat),
 	}
 
-	resp, err := ctrl.bulkImportCollectionUC.Execute(r.Context(), ucReq)
-	if err != nil {
-		ctrl.logger.Error("Failed to bulk import to collection", slog.Any("error", err))
+	// Reject missing or foreign collections now rather than in a job the
+	// client has to poll to find out
+	if err := ctrl.bulkImportCollectionUC.CheckAccess(r.Context(), ucReq); err != nil {
+		ctrl.logger.Warn("Import rejected", slog.String("collection_id", collectionID.String()), slog.Any("error", err))
 		if strings.Contains(err.Error(), "access denied") {
 			httputil.Error(w, http.StatusForbidden, "access denied")
 			return
@@ -786,22 +2311,62 @@ func (ctrl *ObjectController) BulkImportToCollection(w http.ResponseWriter, r *h
 		return
 	}
 
-	ctrl.logger.Info("Bulk import to collection completed",
-		slog.String("user_id", user.ID().String()),
-		slog.String("collection_id", collectionID.String()),
-		slog.Int("imported", resp.Imported),
-		slog.Int("failed", resp.Failed))
-
-	if resp.Failed > 0 {
-		for _, errMsg := range resp.Errors {
-			ctrl.logger.Warn("Import item failed", slog.String("collection_id", collectionID.String()), slog.String("error", errMsg))
+	// Snapshot first so a bad import can be rolled back. Only the owner can
+	// snapshot, and a failed snapshot does not block the import.
+	if ctrl.snapshotBeforeImport {
+		_, err := ctrl.createSnapshotUC.Execute(r.Context(), usecases.CreateCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			UserID:       user.ID(),
+			UserToken:    userToken,
+			Label:        "Before import",
+		})
+		if err != nil && !strings.Contains(err.Error(), "access denied") {
+			ctrl.logger.Warn("Failed to snapshot collection before import",
+				slog.String("collection_id", collectionID.String()),
+				slog.Any("error", err))
 		}
 	}
 
-	httputil.JSON(w, http.StatusOK, response.BulkImportResponse{
-		Imported: resp.Imported,
-		Failed:   resp.Failed,
-		Total:    resp.Total,
-		Errors:   resp.Errors,
+	// Large files take longer than a request should stay open, so the
+	// import runs on a worker and the client follows its progress
+	job, err := ctrl.importJobs.Submit(r.Context(), user.ID(), len(req.Data), func(ctx context.Context, progress func(done, total int)) (*usecases.BulkImportCollectionResponse, error) {
+		ucReq.Progress = progress
+		resp, err := ctrl.bulkImportCollectionUC.Execute(ctx, ucReq)
+		if err != nil {
+			ctrl.logger.Error("Failed to bulk import to collection", slog.Any("error", err))
+			return nil, err
+		}
+
+		ctrl.logger.Info("Bulk import to collection completed",
+			slog.String("user_id", user.ID().String()),
+			slog.String("collection_id", collectionID.String()),
+			slog.String("source_format", string(resp.SourceFormat)),
+			slog.Int("imported", resp.Imported),
+			slog.Int("failed", resp.Failed))
+
+		if resp.Failed > 0 {
+			for _, errMsg := range resp.Errors {
+				ctrl.logger.Warn("Import item failed", slog.String("collection_id", collectionID.String()), slog.String("error", errMsg))
+			}
+		}
+		return resp, nil
 	})
+	if err != nil {
+		ctrl.logger.Warn("Failed to queue import", slog.Any("error", err))
+		if errors.Is(err, importjobs.ErrQueueFull) {
+			w.Header().Set("Retry-After", "30")
+			httputil.Error(w, http.StatusServiceUnavailable, "too many imports in progress, try again shortly")
+			return
+		}
+		httputil.Error(w, http.StatusInternalServerError, "failed to import objects")
+		return
+	}
+
+	ctrl.logger.Info("Bulk import to collection queued",
+		slog.String("user_id", user.ID().String()),
+		slog.String("collection_id", collectionID.String()),
+		slog.String("job_id", job.ID()),
+		slog.Int("rows", len(req.Data)))
+
+	httputil.JSON(w, http.StatusAccepted, newImportJobResponse(job.State()))
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
 	"github.com/nishiki/backend/domain/entities"
@@ -47,6 +48,7 @@ func TestObjectController_CreateObject(t *testing.T) {
 			collectionID,
 			testUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -54,6 +56,8 @@ func TestObjectController_CreateObject(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -119,6 +123,7 @@ func TestObjectController_CreateObject(t *testing.T) {
 			collectionID,
 			otherUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -126,6 +131,8 @@ func TestObjectController_CreateObject(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -182,7 +189,7 @@ func TestObjectController_DeleteObject(t *testing.T) {
 		// Create an object with the specific ID so RemoveObject succeeds
 		objectName, _ := entities.NewObjectName("Test Object")
 		objectDesc := entities.NewObjectDescription("")
-		testObject := entities.ReconstructObject(objectID, objectName, objectDesc, entities.ObjectTypeGeneral, "", nil, "", nil, nil, "", nil, time.Now(), time.Now())
+		testObject := entities.ReconstructObject(objectID, objectName, objectDesc, entities.ObjectTypeGeneral, "", nil, "", nil, "", "", nil, nil, "", nil, nil, nil, time.Now(), time.Now())
 
 		// Create a container that already holds the object
 		containerName, _ := entities.NewContainerName("Test Container")
@@ -193,7 +200,8 @@ func TestObjectController_DeleteObject(t *testing.T) {
 			entities.ContainerTypeGeneral,
 			nil, nil, nil,
 			[]entities.Object{*testObject},
-			"", nil, nil, nil, nil,
+			"", nil, nil, nil, nil, "", nil,
+			entities.ContainerPlacement{},
 			time.Now(), time.Now(),
 		)
 
@@ -203,6 +211,7 @@ func TestObjectController_DeleteObject(t *testing.T) {
 			collectionID,
 			testUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -210,6 +219,8 @@ func TestObjectController_DeleteObject(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -218,6 +229,7 @@ func TestObjectController_DeleteObject(t *testing.T) {
 		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
 		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
 		m.ContainerRepo.EXPECT().RemoveObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		m.MediaRepo.EXPECT().ListByOwner(gomock.Any(), entities.MediaOwnerObject, objectID.String(), gomock.Any()).Return(nil, int64(0), nil)
 
 		req := newTestRequest(http.MethodDelete, "/accounts/"+testUser.ID().String()+"/objects/"+objectID.String()+"?container_id="+containerID.String(), nil)
 		req.SetPathValue("id", testUser.ID().String())
@@ -269,6 +281,162 @@ func TestObjectController_DeleteObject(t *testing.T) {
 	})
 }
 
+func TestObjectController_PatchObject(t *testing.T) {
+	t.Parallel()
+
+	c, m := newTestContainer(t)
+	controller := NewObjectController(c, c.GetLogger())
+
+	patchRequest := func(user *entities.User, objectID string, body any) *http.Request {
+		req := newTestRequest(http.MethodPatch, "/accounts/"+user.ID().String()+"/objects/"+objectID, body)
+		req.SetPathValue("id", user.ID().String())
+		req.SetPathValue("object_id", objectID)
+		return setAuthContext(req, user, "test-token")
+	}
+
+	t.Run("success - changes only sent fields", func(t *testing.T) {
+		testUser := randomUser()
+		objectID := entities.NewObjectID()
+		collectionID := entities.NewCollectionID()
+
+		objectName, _ := entities.NewObjectName("Hammer")
+		properties := map[string]entities.TypedValue{
+			"brand": {Type: entities.PropertyTypeText, Val: "Acme"},
+			"color": {Type: entities.PropertyTypeText, Val: "red"},
+		}
+		testObject := entities.ReconstructObject(objectID, objectName, entities.NewObjectDescription("Claw hammer"), entities.ObjectTypeGeneral, "", new(1.0), "", nil, "", "", properties, []string{"tools"}, "", nil, nil, nil, time.Now(), time.Now())
+		containerName, _ := entities.NewContainerName("Toolbox")
+		testContainer := entities.ReconstructContainer(
+			entities.NewContainerID(),
+			collectionID,
+			containerName,
+			entities.ContainerTypeGeneral,
+			nil, nil, nil,
+			[]entities.Object{*testObject},
+			"", nil, nil, nil, nil, "", nil,
+			entities.ContainerPlacement{},
+			time.Now(), time.Now(),
+		)
+
+		m.ContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(testContainer, nil)
+		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral), nil)
+		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rr := httptest.NewRecorder()
+		controller.PatchObject(rr, patchRequest(testUser, objectID.String(), map[string]any{
+			"quantity":   3,
+			"properties": map[string]any{"color": "blue"},
+			"expires_at": "2026-12-01T00:00:00Z",
+		}))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp response.ObjectResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "Hammer", resp.Name)
+		assert.Equal(t, "Claw hammer", resp.Description)
+		require.NotNil(t, resp.Quantity)
+		assert.Equal(t, 3.0, *resp.Quantity)
+		assert.Equal(t, "Acme", resp.Properties["brand"].Val)
+		assert.Equal(t, "blue", resp.Properties["color"].Val)
+		assert.Equal(t, []string{"tools"}, resp.Tags)
+		require.NotNil(t, resp.ExpiresAt)
+		assert.True(t, resp.ExpiresAt.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("success - null removes values", func(t *testing.T) {
+		testUser := randomUser()
+		objectID := entities.NewObjectID()
+		collectionID := entities.NewCollectionID()
+
+		objectName, _ := entities.NewObjectName("Hammer")
+		properties := map[string]entities.TypedValue{
+			"brand": {Type: entities.PropertyTypeText, Val: "Acme"},
+			"color": {Type: entities.PropertyTypeText, Val: "red"},
+		}
+		testObject := entities.ReconstructObject(objectID, objectName, entities.NewObjectDescription("Claw hammer"), entities.ObjectTypeGeneral, "", new(1.0), "", nil, "", "", properties, []string{"tools"}, "", new(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)), nil, nil, time.Now(), time.Now())
+		containerName, _ := entities.NewContainerName("Toolbox")
+		testContainer := entities.ReconstructContainer(
+			entities.NewContainerID(),
+			collectionID,
+			containerName,
+			entities.ContainerTypeGeneral,
+			nil, nil, nil,
+			[]entities.Object{*testObject},
+			"", nil, nil, nil, nil, "", nil,
+			entities.ContainerPlacement{},
+			time.Now(), time.Now(),
+		)
+
+		m.ContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(testContainer, nil)
+		m.AuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", testUser.ID().String()).Return([]*entities.Group{}, nil)
+		m.CollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(newTestCollection(testUser.ID(), collectionID, entities.ObjectTypeGeneral), nil)
+		m.ContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		req := patchRequest(testUser, objectID.String(), map[string]any{
+			"description": nil,
+			"quantity":    nil,
+			"properties":  map[string]any{"color": nil},
+			"tags":        nil,
+			"expires_at":  nil,
+		})
+		req.Header.Set("Content-Type", httputil.MergePatchContentType)
+		rr := httptest.NewRecorder()
+		controller.PatchObject(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp response.ObjectResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "Hammer", resp.Name)
+		assert.Empty(t, resp.Description)
+		assert.Nil(t, resp.Quantity)
+		assert.Equal(t, "Acme", resp.Properties["brand"].Val)
+		assert.NotContains(t, resp.Properties, "color")
+		assert.Empty(t, resp.Tags)
+		assert.Nil(t, resp.ExpiresAt)
+	})
+
+	t.Run("error - nothing to change", func(t *testing.T) {
+		testUser := randomUser()
+
+		rr := httptest.NewRecorder()
+		controller.PatchObject(rr, patchRequest(testUser, entities.NewObjectID().String(), map[string]any{}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("error - name cannot be removed", func(t *testing.T) {
+		testUser := randomUser()
+
+		rr := httptest.NewRecorder()
+		controller.PatchObject(rr, patchRequest(testUser, entities.NewObjectID().String(), map[string]any{"name": nil}))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("error - unsupported content type", func(t *testing.T) {
+		testUser := randomUser()
+		req := patchRequest(testUser, entities.NewObjectID().String(), map[string]any{"name": "Mallet"})
+		req.Header.Set("Content-Type", "application/json-patch+json")
+
+		rr := httptest.NewRecorder()
+		controller.PatchObject(rr, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+	})
+
+	t.Run("error - another user's account", func(t *testing.T) {
+		testUser := randomUser()
+		req := patchRequest(testUser, entities.NewObjectID().String(), map[string]any{"name": "Mallet"})
+		req.SetPathValue("id", entities.NewUserID().String())
+
+		rr := httptest.NewRecorder()
+		controller.PatchObject(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
 func TestObjectController_BulkImport(t *testing.T) {
 	t.Parallel()
 
@@ -302,6 +470,7 @@ func TestObjectController_BulkImport(t *testing.T) {
 			collectionID,
 			testUser.ID(),
 			nil,
+			false,
 			collectionName,
 			nil,
 			entities.ObjectTypeGeneral,
@@ -309,6 +478,8 @@ func TestObjectController_BulkImport(t *testing.T) {
 			[]string{},
 			"",
 			nil,
+			nil,
+			nil,
 			time.Now(),
 			time.Now(),
 		)
@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type PreferencesController struct {
+	getUserPreferencesUC    *usecases.GetUserPreferencesUseCase
+	updateUserPreferencesUC *usecases.UpdateUserPreferencesUseCase
+	updateItemOrderUC       *usecases.UpdateItemOrderUseCase
+	logger                  *slog.Logger
+}
+
+func NewPreferencesController(
+	c *container.Container,
+	logger *slog.Logger,
+) *PreferencesController {
+	return &PreferencesController{
+		getUserPreferencesUC:    usecases.NewGetUserPreferencesUseCase(c.PreferencesRepo),
+		updateUserPreferencesUC: usecases.NewUpdateUserPreferencesUseCase(c.PreferencesRepo),
+		updateItemOrderUC:       usecases.NewUpdateItemOrderUseCase(c.PreferencesRepo),
+		logger:                  logger,
+	}
+}
+
+// GetPreferences godoc
+// @Summary Get view preferences
+// @Description Get the current user's saved sort, grouping and filter choices, keyed by view
+// @Tags preferences
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.UserPreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/preferences [get]
+// @Security BearerAuth
+func (ctrl *PreferencesController) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	resp, err := ctrl.getUserPreferencesUC.Execute(r.Context(), usecases.GetUserPreferencesRequest{
+		UserID: user.ID(),
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to get view preferences", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get view preferences")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewUserPreferencesResponse(resp.Preferences))
+}
+
+// UpdatePreferences godoc
+// @Summary Update view preferences
+// @Description Save sort, grouping and filter choices for one or more views. Views not in the body keep their saved preferences; a null or empty preference forgets the view. label_template sets the label stock used by object labels and unit_system (metric or imperial) the units quantities are shown in.
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param preferences body request.UpdateUserPreferencesRequest true "View preferences"
+// @Success 200 {object} response.UserPreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/preferences [put]
+// @Security BearerAuth
+func (ctrl *PreferencesController) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.UpdateUserPreferencesRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.updateUserPreferencesUC.Execute(r.Context(), usecases.UpdateUserPreferencesRequest{
+		UserID:        user.ID(),
+		Views:         req.ToViewPreferences(),
+		LabelTemplate: req.LabelTemplate,
+		UnitSystem:    req.UnitSystem,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrInvalidViewKey) ||
+			errors.Is(err, entities.ErrInvalidViewPreference) ||
+			errors.Is(err, entities.ErrTooManyViews) ||
+			errors.Is(err, entities.ErrUnknownLabelTemplate) ||
+			errors.Is(err, entities.ErrUnknownUnitSystem) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to update view preferences", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to update view preferences")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewUserPreferencesResponse(resp.Preferences))
+}
+
+// UpdateCollectionOrder godoc
+// @Summary Pin and reorder collections
+// @Description Save where the user placed their collections. Collections not in the body keep their placement; sort_index 0 with pinned false forgets one. Pinned collections come first in every listing and sort=custom orders by sort_index.
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param order body request.UpdateItemOrderRequest true "Collection placements"
+// @Success 200 {object} response.UserPreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/collections/order [patch]
+// @Security BearerAuth
+func (ctrl *PreferencesController) UpdateCollectionOrder(w http.ResponseWriter, r *http.Request) {
+	ctrl.updateItemOrder(w, r, entities.OrderScopeCollections)
+}
+
+// UpdateContainerOrder godoc
+// @Summary Pin and reorder containers
+// @Description Save where the user placed containers within their collections. Containers not in the body keep their placement; sort_index 0 with pinned false forgets one. Pinned containers come first in collection listings and sort=custom orders by sort_index.
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param order body request.UpdateItemOrderRequest true "Container placements"
+// @Success 200 {object} response.UserPreferencesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/containers/order [patch]
+// @Security BearerAuth
+func (ctrl *PreferencesController) UpdateContainerOrder(w http.ResponseWriter, r *http.Request) {
+	ctrl.updateItemOrder(w, r, entities.OrderScopeContainers)
+}
+
+func (ctrl *PreferencesController) updateItemOrder(w http.ResponseWriter, r *http.Request, scope entities.OrderScope) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.UpdateItemOrderRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.updateItemOrderUC.Execute(r.Context(), usecases.UpdateItemOrderRequest{
+		UserID: user.ID(),
+		Scope:  scope,
+		Items:  req.ToItemOrder(),
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrInvalidItemOrder) || errors.Is(err, entities.ErrTooManyOrderedItems) {
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to update item order", slog.String("scope", string(scope)), slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to update order")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewUserPreferencesResponse(resp.Preferences))
+}
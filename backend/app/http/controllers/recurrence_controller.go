@@ -0,0 +1,323 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type RecurrenceController struct {
+	listRulesUC  *usecases.ListRecurrenceRulesUseCase
+	createRuleUC *usecases.CreateRecurrenceRuleUseCase
+	updateRuleUC *usecases.UpdateRecurrenceRuleUseCase
+	deleteRuleUC *usecases.DeleteRecurrenceRuleUseCase
+	logger       *slog.Logger
+}
+
+func NewRecurrenceController(
+	c *container.Container,
+	logger *slog.Logger,
+) *RecurrenceController {
+	return &RecurrenceController{
+		listRulesUC:  usecases.NewListRecurrenceRulesUseCase(c.RecurrenceRuleRepo),
+		createRuleUC: usecases.NewCreateRecurrenceRuleUseCase(c.RecurrenceRuleRepo, c.ContainerRepo, c.CollectionRepo, c.AuthService),
+		updateRuleUC: usecases.NewUpdateRecurrenceRuleUseCase(c.RecurrenceRuleRepo),
+		deleteRuleUC: usecases.NewDeleteRecurrenceRuleUseCase(c.RecurrenceRuleRepo),
+		logger:       logger,
+	}
+}
+
+// ListRecurrenceRules godoc
+// @Summary List recurring staples
+// @Description Returns the user's recurrence rules, soonest due first. due=true keeps only staples waiting to be bought, which is the shopping list; object_id keeps only that object's rule.
+// @Tags recurrences
+// @Produce json
+// @Param id path string true "User ID"
+// @Param due query bool false "Only staples that are due"
+// @Param object_id query string false "Only this object's rule"
+// @Success 200 {object} response.RecurrenceRuleListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/recurrences [get]
+// @Security BearerAuth
+func (ctrl *RecurrenceController) ListRecurrenceRules(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	ucReq := usecases.ListRecurrenceRulesRequest{
+		UserID:  user.ID(),
+		DueOnly: r.URL.Query().Get("due") == "true",
+	}
+	if s := r.URL.Query().Get("object_id"); s != "" {
+		objectID, err := entities.ObjectIDFromHex(s)
+		if err != nil {
+			httputil.Error(w, http.StatusBadRequest, "invalid object ID")
+			return
+		}
+		ucReq.ObjectID = &objectID
+	}
+
+	resp, err := ctrl.listRulesUC.Execute(r.Context(), ucReq)
+	if err != nil {
+		ctrl.logger.Error("Failed to list recurrence rules", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list recurrence rules")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewRecurrenceRuleListResponse(resp.Rules))
+}
+
+// CreateRecurrenceRule godoc
+// @Summary Make an object a recurring staple
+// @Description Puts the object back on the shopping list every so many days, weeks or months, whether or not its stock was counted down. The first round comes due one period from now. An object has at most one rule per user.
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param rule body request.CreateRecurrenceRuleRequest true "Recurrence rule"
+// @Success 201 {object} response.RecurrenceRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/recurrences [post]
+// @Security BearerAuth
+func (ctrl *RecurrenceController) CreateRecurrenceRule(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	var req request.CreateRecurrenceRuleRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	objectID, _ := entities.ObjectIDFromHex(req.ObjectID)
+	resp, err := ctrl.createRuleUC.Execute(r.Context(), usecases.CreateRecurrenceRuleRequest{
+		ObjectID:   objectID,
+		Recurrence: entities.Recurrence{Every: req.Every, Period: entities.RecurrencePeriod(req.Period)},
+		Quantity:   req.Quantity,
+		UserID:     user.ID(),
+		UserToken:  userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to create recurrence rule", slog.Any("error", err))
+		switch {
+		case errors.Is(err, entities.ErrRecurrenceRuleExists):
+			httputil.Error(w, http.StatusConflict, err.Error())
+		case strings.Contains(err.Error(), "access denied"):
+			httputil.Error(w, http.StatusForbidden, "access denied")
+		case strings.Contains(err.Error(), "not found"):
+			httputil.Error(w, http.StatusNotFound, "object not found")
+		case errors.Is(err, entities.ErrInvalidRecurrence),
+			errors.Is(err, entities.ErrInvalidRecurrenceAmount):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.Error(w, http.StatusInternalServerError, "failed to create recurrence rule")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Recurrence rule created",
+		slog.String("rule_id", resp.Rule.ID().String()),
+		slog.String("object_id", objectID.String()),
+		slog.String("user_id", user.ID().String()))
+
+	httputil.JSON(w, http.StatusCreated, response.NewRecurrenceRuleResponse(resp.Rule))
+}
+
+// UpdateRecurrenceRule godoc
+// @Summary Update a recurring staple
+// @Description Changes the schedule or the amount to buy, or with bought=true takes a due staple off the shopping list until it next comes due. A changed schedule is counted from now.
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param recurrence_id path string true "Recurrence rule ID"
+// @Param rule body request.UpdateRecurrenceRuleRequest true "Fields to change"
+// @Success 200 {object} response.RecurrenceRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/recurrences/{recurrence_id} [put]
+// @Security BearerAuth
+func (ctrl *RecurrenceController) UpdateRecurrenceRule(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	ruleID, err := request.GetRecurrenceRuleIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateRecurrenceRuleRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	resp, err := ctrl.updateRuleUC.Execute(r.Context(), usecases.UpdateRecurrenceRuleRequest{
+		RuleID:     ruleID,
+		Recurrence: req.ToRecurrence(),
+		Quantity:   req.Quantity,
+		Bought:     req.Bought,
+		UserID:     user.ID(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entities.ErrRecurrenceRuleNotFound):
+			httputil.Error(w, http.StatusNotFound, "recurrence rule not found")
+		case errors.Is(err, entities.ErrInvalidRecurrence),
+			errors.Is(err, entities.ErrInvalidRecurrenceAmount):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			ctrl.logger.Error("Failed to update recurrence rule", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to update recurrence rule")
+		}
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewRecurrenceRuleResponse(resp.Rule))
+}
+
+// DeleteRecurrenceRule godoc
+// @Summary Stop a staple from recurring
+// @Description Deletes the recurrence rule. The object is kept.
+// @Tags recurrences
+// @Param id path string true "User ID"
+// @Param recurrence_id path string true "Recurrence rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/recurrences/{recurrence_id} [delete]
+// @Security BearerAuth
+func (ctrl *RecurrenceController) DeleteRecurrenceRule(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	ruleID, err := request.GetRecurrenceRuleIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = ctrl.deleteRuleUC.Execute(r.Context(), usecases.DeleteRecurrenceRuleRequest{
+		RuleID: ruleID,
+		UserID: user.ID(),
+	})
+	switch {
+	case errors.Is(err, entities.ErrRecurrenceRuleNotFound):
+		httputil.Error(w, http.StatusNotFound, "recurrence rule not found")
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to delete recurrence rule", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to delete recurrence rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
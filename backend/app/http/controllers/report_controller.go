@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type ReportController struct {
+	valuationReportUC *usecases.GetValuationReportUseCase
+	purchaseHistoryUC *usecases.GetPurchaseHistoryUseCase
+	logger            *slog.Logger
+}
+
+func NewReportController(
+	c *container.Container,
+	logger *slog.Logger,
+) *ReportController {
+	return &ReportController{
+		valuationReportUC: usecases.NewGetValuationReportUseCase(c.CollectionRepo, c.ContainerRepo, c.AuthService),
+		purchaseHistoryUC: usecases.NewGetPurchaseHistoryUseCase(c.CollectionRepo, c.ContainerRepo, c.AdjustmentRepo, c.AuthService),
+		logger:            logger,
+	}
+}
+
+// GetValuationReport godoc
+// @Summary Get inventory valuation report
+// @Description Totals the value of the user's owned, active objects across every collection they can see, by currency, with breakdowns by collection, object type, tag and condition. An object is worth the value of its collection's price field, the first currency property in its schema. format=csv returns the same figures as one row per total and group.
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param id path string true "User ID"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} response.ValuationReportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/reports/valuation [get]
+// @Security BearerAuth
+func (ctrl *ReportController) GetValuationReport(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format != "" && format != "json" && format != "csv" {
+		httputil.Error(w, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	resp, err := ctrl.valuationReportUC.Execute(r.Context(), usecases.GetValuationReportRequest{
+		UserID:    user.ID(),
+		UserToken: userToken,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to build valuation report", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to build valuation report")
+		return
+	}
+
+	if format == "csv" {
+		data, err := usecases.EncodeValuationReportCSV(resp.Report)
+		if err != nil {
+			ctrl.logger.Error("Failed to encode valuation report", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to build valuation report")
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="valuation.csv"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewValuationReportResponse(resp.Report))
+}
+
+// GetPurchaseHistory godoc
+// @Summary Get food purchase history
+// @Description Sums the owned objects added to the food collections the user can see over the last days days (default 30, at most 365), archived ones included, priced like the valuation report, by currency and by category, with the purchases themselves newest first (at most 200) and the quantity changes made to the collections' objects per category and unit. An object's category is its category property, else its first tag.
+// @Tags reports
+// @Produce json
+// @Param id path string true "User ID"
+// @Param days query int false "Days to look back (default 30, at most 365)"
+// @Success 200 {object} response.PurchaseHistoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/reports/purchases [get]
+// @Security BearerAuth
+func (ctrl *ReportController) GetPurchaseHistory(w http.ResponseWriter, r *http.Request) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	userToken, tokenExists := middleware.GetCurrentToken(r)
+	if !tokenExists {
+		ctrl.logger.Error("No auth token found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	days, err := request.GetPurchaseHistoryDaysFromQuery(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.purchaseHistoryUC.Execute(r.Context(), usecases.GetPurchaseHistoryRequest{
+		UserID:    user.ID(),
+		UserToken: userToken,
+		Days:      days,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to build purchase history", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to build purchase history")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewPurchaseHistoryResponse(resp.History))
+}
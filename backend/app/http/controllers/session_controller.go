@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// SessionController lists the devices a user is signed in on and signs
+// them out remotely
+type SessionController struct {
+	listSessionsUC      *usecases.ListSessionsUseCase
+	revokeSessionsUC    *usecases.RevokeSessionsUseCase
+	setSessionTrustedUC *usecases.SetSessionTrustedUseCase
+	logger              *slog.Logger
+}
+
+func NewSessionController(c *container.Container, logger *slog.Logger) *SessionController {
+	return &SessionController{
+		listSessionsUC:      usecases.NewListSessionsUseCase(c.SessionRepo),
+		revokeSessionsUC:    usecases.NewRevokeSessionsUseCase(c.SessionRepo, c.CheckSession()),
+		setSessionTrustedUC: usecases.NewSetSessionTrustedUseCase(c.SessionRepo),
+		logger:              logger,
+	}
+}
+
+// ListSessions godoc
+// @Summary List sessions
+// @Description List the devices the user is signed in on, most recently used first. Each sign-in is one session, kept across token refreshes; current marks the one the request was made from.
+// @Tags accounts
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.SessionListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/sessions [get]
+// @Security BearerAuth
+func (ctrl *SessionController) ListSessions(w http.ResponseWriter, r *http.Request) {
+	user, currentID, ok := ctrl.sessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := ctrl.listSessionsUC.Execute(r.Context(), usecases.ListSessionsRequest{UserID: user.ID()})
+	if err != nil {
+		ctrl.logger.Error("Failed to list sessions", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list sessions")
+		return
+	}
+
+	sessions := make([]response.SessionResponse, len(resp.Sessions))
+	for i, s := range resp.Sessions {
+		sessions[i] = response.NewSessionResponse(s, currentID)
+	}
+	httputil.JSON(w, http.StatusOK, response.SessionListResponse{Sessions: sessions})
+}
+
+// UpdateSession godoc
+// @Summary Trust or untrust a session
+// @Description Remember a device so that signing out other devices leaves it signed in, or forget it again.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param session_id path string true "Session ID"
+// @Param body body request.UpdateSessionRequest true "Trusted"
+// @Success 200 {object} response.SessionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/sessions/{session_id} [put]
+// @Security BearerAuth
+func (ctrl *SessionController) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	user, currentID, ok := ctrl.sessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	sessionID, err := request.GetSessionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req request.UpdateSessionRequest
+	if err := httputil.DecodeJSON(r, &req); err != nil {
+		ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := ctrl.setSessionTrustedUC.Execute(r.Context(), usecases.SetSessionTrustedRequest{
+		UserID:    user.ID(),
+		SessionID: sessionID,
+		Trusted:   req.Trusted,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrSessionNotFound) {
+			httputil.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		ctrl.logger.Error("Failed to update session", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to update session")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewSessionResponse(resp.Session, currentID))
+}
+
+// RevokeSession godoc
+// @Summary Sign out a session
+// @Description Sign out one other device. Its tokens, and those refreshed from them, are refused from its next request on, so it has to sign in again. The current session cannot be revoked; sign out instead.
+// @Tags accounts
+// @Produce json
+// @Param id path string true "User ID"
+// @Param session_id path string true "Session ID"
+// @Success 200 {object} response.RevokeSessionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/sessions/{session_id} [delete]
+// @Security BearerAuth
+func (ctrl *SessionController) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := request.GetSessionIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctrl.revoke(w, r, sessionID)
+}
+
+// RevokeOtherSessions godoc
+// @Summary Sign out other devices
+// @Description Sign out every session but the current one, except those marked trusted.
+// @Tags accounts
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.RevokeSessionsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/sessions/revoke-others [post]
+// @Security BearerAuth
+func (ctrl *SessionController) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	ctrl.revoke(w, r, "")
+}
+
+func (ctrl *SessionController) revoke(w http.ResponseWriter, r *http.Request, sessionID string) {
+	user, currentID, ok := ctrl.sessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := ctrl.revokeSessionsUC.Execute(r.Context(), usecases.RevokeSessionsRequest{
+		UserID:           user.ID(),
+		CurrentSessionID: currentID,
+		SessionID:        sessionID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entities.ErrSessionNotFound):
+			httputil.Error(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, entities.ErrCannotRevokeCurrentSession):
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+		default:
+			ctrl.logger.Error("Failed to revoke sessions", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to revoke sessions")
+		}
+		return
+	}
+
+	ctrl.logger.Info("Sessions revoked",
+		slog.String("user_id", user.ID().String()),
+		slog.Int("revoked", resp.Revoked))
+	httputil.JSON(w, http.StatusOK, response.RevokeSessionsResponse{Revoked: resp.Revoked})
+}
+
+// sessionOwner returns the signed-in user and their current session,
+// writing an error unless the path names that user
+func (ctrl *SessionController) sessionOwner(w http.ResponseWriter, r *http.Request) (*entities.User, string, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, "", false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil || !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, "", false
+	}
+
+	currentID, _ := middleware.GetCurrentSessionID(r)
+	return user, currentID, true
+}
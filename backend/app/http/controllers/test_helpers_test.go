@@ -13,9 +13,16 @@ import (
 
 // testMocks holds all mock dependencies used across controller tests.
 type testMocks struct {
-	ContainerRepo  *mocks.MockContainerRepository
-	CollectionRepo *mocks.MockCollectionRepository
-	AuthService    *mocks.MockAuthService
+	ContainerRepo   *mocks.MockContainerRepository
+	CollectionRepo  *mocks.MockCollectionRepository
+	SnapshotRepo    *mocks.MockCollectionSnapshotRepository
+	MediaRepo       *mocks.MockMediaRepository
+	CommentRepo     *mocks.MockCommentRepository
+	PreferencesRepo *mocks.MockUserPreferencesRepository
+	AuthService     *mocks.MockAuthService
+	MediaStorage    *mocks.MockMediaStorage
+	InboxRepo       *mocks.MockInboxRepository
+	DigestRepo      *mocks.MockDigestSubscriptionRepository
 }
 
 // newTestContainer creates a Container populated with mocks and a discard logger,
@@ -25,15 +32,29 @@ func newTestContainer(t *testing.T) (*container.Container, *testMocks) {
 	ctrl := gomock.NewController(t)
 
 	m := &testMocks{
-		ContainerRepo:  mocks.NewMockContainerRepository(ctrl),
-		CollectionRepo: mocks.NewMockCollectionRepository(ctrl),
-		AuthService:    mocks.NewMockAuthService(ctrl),
+		ContainerRepo:   mocks.NewMockContainerRepository(ctrl),
+		CollectionRepo:  mocks.NewMockCollectionRepository(ctrl),
+		SnapshotRepo:    mocks.NewMockCollectionSnapshotRepository(ctrl),
+		MediaRepo:       mocks.NewMockMediaRepository(ctrl),
+		CommentRepo:     mocks.NewMockCommentRepository(ctrl),
+		PreferencesRepo: mocks.NewMockUserPreferencesRepository(ctrl),
+		AuthService:     mocks.NewMockAuthService(ctrl),
+		MediaStorage:    mocks.NewMockMediaStorage(ctrl),
+		InboxRepo:       mocks.NewMockInboxRepository(ctrl),
+		DigestRepo:      mocks.NewMockDigestSubscriptionRepository(ctrl),
 	}
 
 	c := &container.Container{
-		ContainerRepo:  m.ContainerRepo,
-		CollectionRepo: m.CollectionRepo,
-		AuthService:    m.AuthService,
+		ContainerRepo:          m.ContainerRepo,
+		CollectionRepo:         m.CollectionRepo,
+		SnapshotRepo:           m.SnapshotRepo,
+		MediaRepo:              m.MediaRepo,
+		CommentRepo:            m.CommentRepo,
+		PreferencesRepo:        m.PreferencesRepo,
+		AuthService:            m.AuthService,
+		MediaStorage:           m.MediaStorage,
+		InboxRepo:              m.InboxRepo,
+		DigestSubscriptionRepo: m.DigestRepo,
 	}
 	c.SetConfig(&config.Config{})
 	c.SetLogger(slog.New(slog.DiscardHandler))
@@ -1,14 +1,17 @@
 package controllers
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"slices"
 
 	"github.com/nishiki/backend/app/container"
 	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/middleware"
 	"github.com/nishiki/backend/app/http/request"
 	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/services"
 )
 
@@ -62,6 +65,20 @@ func (ctrl *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Other users are only visible to those who share a group with them
+	if !userID.Equals(currentUser.ID()) {
+		shared, err := ctrl.sharesGroup(r.Context(), userToken, currentUser.ID(), userID)
+		if err != nil {
+			ctrl.logger.Error("Failed to check shared groups", slog.Any("error", err))
+			httputil.Error(w, http.StatusInternalServerError, "failed to get user")
+			return
+		}
+		if !shared {
+			httputil.Error(w, http.StatusNotFound, "user not found")
+			return
+		}
+	}
+
 	user, err := ctrl.authService.GetUserByID(r.Context(), userToken, userID.String())
 	if err != nil {
 		ctrl.logger.Error("Failed to get user", slog.Any("error", err))
@@ -79,3 +96,21 @@ func (ctrl *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	httputil.JSON(w, http.StatusOK, response.NewUserResponse(user))
 }
+
+// sharesGroup reports whether the two users belong to a common group
+func (ctrl *UserController) sharesGroup(ctx context.Context, userToken string, a, b entities.UserID) (bool, error) {
+	groupsA, err := ctrl.authService.GetUserGroups(ctx, userToken, a.String())
+	if err != nil {
+		return false, err
+	}
+	groupsB, err := ctrl.authService.GetUserGroups(ctx, userToken, b.String())
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groupsA {
+		if slices.ContainsFunc(groupsB, func(other *entities.Group) bool { return other.ID().Equals(group.ID()) }) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -91,7 +91,17 @@ func TestUserController_GetUser(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 
-		// Setup mock expectations
+		// Setup mock expectations: the users share a group, but the other
+		// one no longer exists
+		groupID, _ := entities.GroupIDFromString("family")
+		groupName, _ := entities.NewGroupName("Family")
+		family := entities.ReconstructGroup(groupID, groupName, entities.NewGroupDescription(""), time.Now(), time.Now())
+		m.AuthService.EXPECT().
+			GetUserGroups(gomock.Any(), "test-token", authUser.ID().String()).
+			Return([]*entities.Group{family}, nil)
+		m.AuthService.EXPECT().
+			GetUserGroups(gomock.Any(), "test-token", userID.String()).
+			Return([]*entities.Group{family}, nil)
 		m.AuthService.EXPECT().
 			GetUserByID(gomock.Any(), "test-token", userID.String()).
 			Return(nil, errors.New("user not found"))
@@ -107,6 +117,33 @@ func TestUserController_GetUser(t *testing.T) {
 		assert.Equal(t, "user not found", response["error"])
 	})
 
+	t.Run("error - no shared group", func(t *testing.T) {
+		t.Parallel()
+
+		userID := entities.NewUserID()
+		username, _ := entities.NewUsername("outsider")
+		email, _ := entities.NewEmailAddress("outsider@example.com")
+		authUser := entities.ReconstructUser(entities.NewUserID(), username, email, "", time.Now(), time.Now())
+
+		req := newTestRequest(http.MethodGet, "/users/"+userID.String(), nil)
+		req.SetPathValue("id", userID.String())
+		req = setAuthContext(req, authUser, "test-token")
+
+		rr := httptest.NewRecorder()
+
+		// The user exists, but the requester is not told so
+		m.AuthService.EXPECT().
+			GetUserGroups(gomock.Any(), "test-token", authUser.ID().String()).
+			Return([]*entities.Group{}, nil)
+		m.AuthService.EXPECT().
+			GetUserGroups(gomock.Any(), "test-token", userID.String()).
+			Return([]*entities.Group{}, nil)
+
+		controller.GetUser(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
 	t.Run("error - missing user ID", func(t *testing.T) {
 		t.Parallel()
 
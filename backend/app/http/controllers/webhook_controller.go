@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/request"
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+type WebhookController struct {
+	webhooksUC *usecases.WebhookUseCase
+	logger     *slog.Logger
+}
+
+func NewWebhookController(
+	c *container.Container,
+	logger *slog.Logger,
+) *WebhookController {
+	return &WebhookController{
+		webhooksUC: usecases.NewWebhookUseCase(c.AutomationRuleRepo, c.WebhookDeliveryRepo, c.WebhookKeyRepo, c.WebhookSender, logger),
+		logger:     logger,
+	}
+}
+
+// GetWebhookSigningKey godoc
+// @Summary Get the webhook signing key
+// @Description Says whether the account has a key for signing the webhooks of rules without a secret of their own, when it was made and until when the key it replaced still signs. The key itself is only returned by the rotation that makes it.
+// @Tags automations
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.WebhookSigningKeyResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/webhooks/signing-key [get]
+// @Security BearerAuth
+func (ctrl *WebhookController) GetWebhookSigningKey(w http.ResponseWriter, r *http.Request) {
+	user, ok := ctrl.accountOwner(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := ctrl.webhooksUC.SigningKeys(r.Context(), user.ID())
+	switch {
+	case errors.Is(err, entities.ErrWebhookSigningKeyNotFound):
+		keys = nil
+	case err != nil:
+		ctrl.logger.Error("Failed to get webhook signing key", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to get webhook signing key")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewWebhookSigningKeyResponse(keys, false, time.Now()))
+}
+
+// RotateWebhookSigningKey godoc
+// @Summary Rotate the webhook signing key
+// @Description Makes a new signing key and returns it; it is not shown again. The old key keeps signing alongside it for grace_hours (default 24, at most 168), so receivers can switch without rejecting calls. The first rotation creates the account's key.
+// @Tags automations
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param rotation body request.RotateWebhookKeyRequest false "Grace period"
+// @Success 200 {object} response.WebhookSigningKeyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/webhooks/signing-key/rotate [post]
+// @Security BearerAuth
+func (ctrl *WebhookController) RotateWebhookSigningKey(w http.ResponseWriter, r *http.Request) {
+	user, ok := ctrl.accountOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var req request.RotateWebhookKeyRequest
+	if r.ContentLength != 0 {
+		if err := httputil.DecodeJSON(r, &req); err != nil {
+			ctrl.logger.Warn("Invalid request body", slog.Any("error", err))
+			httputil.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		ctrl.logger.Warn("Request validation failed", slog.Any("error", err))
+		httputil.InvalidRequest(w, err)
+		return
+	}
+
+	keys, err := ctrl.webhooksUC.RotateSigningKey(r.Context(), user.ID(), req.Grace())
+	switch {
+	case errors.Is(err, entities.ErrInvalidWebhookKeyGrace):
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to rotate webhook signing key", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to rotate webhook signing key")
+		return
+	}
+
+	ctrl.logger.Info("Webhook signing key rotated",
+		slog.String("user_id", user.ID().String()),
+		slog.Duration("grace", req.Grace()))
+
+	httputil.JSON(w, http.StatusOK, response.NewWebhookSigningKeyResponse(keys, true, time.Now()))
+}
+
+// ListWebhookDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Returns the last 50 webhooks the user's automation rules sent, newest first, with their status, attempts and body. Finished deliveries are kept for 30 days.
+// @Tags automations
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.WebhookDeliveryListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /accounts/{id}/webhooks/deliveries [get]
+// @Security BearerAuth
+func (ctrl *WebhookController) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	user, ok := ctrl.accountOwner(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := ctrl.webhooksUC.ListDeliveries(r.Context(), user.ID())
+	if err != nil {
+		ctrl.logger.Error("Failed to list webhook deliveries", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to list webhook deliveries")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewWebhookDeliveryListResponse(deliveries))
+}
+
+// RetryWebhookDelivery godoc
+// @Summary Send a webhook delivery again
+// @Description Posts the delivery's body to its URL once more, now, and returns the delivery with the attempt recorded. A failed send shows in the delivery's status rather than as an error.
+// @Tags automations
+// @Produce json
+// @Param id path string true "User ID"
+// @Param delivery_id path string true "Webhook delivery ID"
+// @Success 200 {object} response.WebhookDeliveryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /accounts/{id}/webhooks/deliveries/{delivery_id}/retry [post]
+// @Security BearerAuth
+func (ctrl *WebhookController) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	user, ok := ctrl.accountOwner(w, r)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := request.GetWebhookDeliveryIDFromPath(r)
+	if err != nil {
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	delivery, err := ctrl.webhooksUC.Redeliver(r.Context(), deliveryID, user.ID())
+	switch {
+	case errors.Is(err, entities.ErrWebhookDeliveryNotFound):
+		httputil.Error(w, http.StatusNotFound, "webhook delivery not found")
+		return
+	case errors.Is(err, usecases.ErrWebhooksUnavailable):
+		httputil.Error(w, http.StatusServiceUnavailable, err.Error())
+		return
+	case err != nil:
+		ctrl.logger.Error("Failed to resend webhook delivery", slog.Any("error", err))
+		httputil.Error(w, http.StatusInternalServerError, "failed to resend webhook delivery")
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, response.NewWebhookDeliveryResponse(delivery))
+}
+
+// accountOwner returns the signed-in user, writing an error unless the path
+// names that user
+func (ctrl *WebhookController) accountOwner(w http.ResponseWriter, r *http.Request) (*entities.User, bool) {
+	user, exists := middleware.GetCurrentUser(r)
+	if !exists {
+		ctrl.logger.Error("No authenticated user found in context")
+		httputil.Error(w, http.StatusUnauthorized, "authentication required")
+		return nil, false
+	}
+
+	pathUserID, err := request.GetUserIDFromPath(r)
+	if err != nil {
+		ctrl.logger.Warn("Invalid user ID in path", slog.Any("error", err))
+		httputil.Error(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	if !pathUserID.Equals(user.ID()) {
+		httputil.Error(w, http.StatusForbidden, "access denied")
+		return nil, false
+	}
+
+	return user, true
+}
@@ -26,3 +26,6 @@ func WrapHandler(h http.Handler, middlewares ...Middleware) http.HandlerFunc {
 	chain := Chain(middlewares...)
 	return chain(h).ServeHTTP
 }
+
+// APIBasePath prefixes every versioned API route
+const APIBasePath = "/v1"
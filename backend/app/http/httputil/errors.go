@@ -0,0 +1,138 @@
+package httputil
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error codes name what went wrong independently of the wording of the
+// message, so clients can branch on them and show their own text. A code
+// never changes meaning once published; new failures get new codes.
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeConflict             = "conflict"
+	CodeGone                 = "gone"
+	CodePreconditionFailed   = "precondition_failed"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeUnprocessable        = "unprocessable"
+	CodeRateLimited          = "rate_limited"
+	CodeInternal             = "internal_error"
+	CodeNotImplemented       = "not_implemented"
+	CodeBadGateway           = "bad_gateway"
+	CodeUnavailable          = "service_unavailable"
+	CodeTimeout              = "gateway_timeout"
+
+	CodeReauthRequired  = "reauth_required"
+	CodeSessionRevoked  = "session_revoked"
+	CodeAccountDisabled = "account_disabled"
+	CodeQuotaExceeded   = "quota_exceeded"
+)
+
+// RequestIDHeader carries the ID of a request, set on every response by the
+// request ID middleware and echoed in error bodies
+const RequestIDHeader = "X-Request-ID"
+
+// FieldError names one part of a request that was rejected and why
+type FieldError struct {
+	Field   string `json:"field"` // e.g. "name" or "body.items[0].id"
+	Message string `json:"message"`
+}
+
+// ErrorBody is the body of every error response. Message is for people and
+// may change; Code is for programs. Error repeats Message for clients written
+// before the envelope had codes.
+type ErrorBody struct {
+	Error     string       `json:"error"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// NewErrorBody builds the error envelope for a response being written to w,
+// picking up the request ID the middleware set on it
+func NewErrorBody(w http.ResponseWriter, code, message string, fields ...FieldError) ErrorBody {
+	return ErrorBody{
+		Error:     message,
+		Code:      code,
+		Message:   message,
+		Fields:    fields,
+		RequestID: w.Header().Get(RequestIDHeader),
+	}
+}
+
+// Error writes a JSON error response whose code follows from the status
+func Error(w http.ResponseWriter, statusCode int, message string) {
+	ErrorCode(w, statusCode, StatusCode(statusCode), message)
+}
+
+// ErrorCode writes a JSON error response with a specific code, for failures
+// a client needs to tell apart from others with the same status
+func ErrorCode(w http.ResponseWriter, statusCode int, code, message string) {
+	JSON(w, statusCode, NewErrorBody(w, code, message))
+}
+
+// ValidationError writes a 400 naming the fields that were rejected
+func ValidationError(w http.ResponseWriter, message string, fields ...FieldError) {
+	JSON(w, http.StatusBadRequest, NewErrorBody(w, CodeValidationFailed, message, fields...))
+}
+
+// InvalidRequest writes a 400 for a request that failed validation. When err
+// can name the offending fields (it has a FieldErrors() []FieldError method)
+// they are listed.
+func InvalidRequest(w http.ResponseWriter, err error) {
+	var fe interface{ FieldErrors() []FieldError }
+	if errors.As(err, &fe) {
+		ValidationError(w, err.Error(), fe.FieldErrors()...)
+		return
+	}
+	ValidationError(w, err.Error())
+}
+
+// StatusCode is the generic error code for an HTTP status
+func StatusCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessable
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusNotImplemented:
+		return CodeNotImplemented
+	case http.StatusBadGateway:
+		return CodeBadGateway
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if statusCode >= 500 {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
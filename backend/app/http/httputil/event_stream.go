@@ -0,0 +1,49 @@
+package httputil
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+)
+
+// StartEventStream sends the headers for a server-sent event stream and
+// flushes them, so the client sees the stream open before the first event
+func StartEventStream(w http.ResponseWriter) {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	// Stop nginx and similar proxies from buffering the stream
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flush(w)
+}
+
+// WriteEvent writes one server-sent event with data as its JSON payload and
+// flushes it to the client
+func WriteEvent(w http.ResponseWriter, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	flush(w)
+	return nil
+}
+
+// WriteEventComment writes a comment line, which clients ignore; it keeps
+// idle connections from being closed by proxies
+func WriteEventComment(w http.ResponseWriter, comment string) error {
+	if _, err := fmt.Fprintf(w, ": %s\n\n", comment); err != nil {
+		return err
+	}
+	flush(w)
+	return nil
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -3,6 +3,7 @@ package httputil
 import (
 	"encoding/json/v2"
 	"net/http"
+	"time"
 )
 
 // JSON writes a JSON response with the given status code
@@ -17,11 +18,6 @@ func JSON(w http.ResponseWriter, statusCode int, data any) {
 	}
 }
 
-// Error writes a JSON error response
-func Error(w http.ResponseWriter, statusCode int, message string) {
-	JSON(w, statusCode, map[string]string{"error": message})
-}
-
 // DecodeJSON decodes JSON from the request body into the target
 func DecodeJSON(r *http.Request, target any) error {
 	return json.UnmarshalRead(r.Body, target)
@@ -33,3 +29,12 @@ func Data(w http.ResponseWriter, statusCode int, contentType string, data []byte
 	w.WriteHeader(statusCode)
 	_, _ = w.Write(data)
 }
+
+// SetLastModified sets the Last-Modified header so conditional requests can
+// be answered without re-sending the body
+func SetLastModified(w http.ResponseWriter, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
@@ -0,0 +1,60 @@
+package httputil
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// MergePatchContentType is the media type of an RFC 7386 JSON Merge Patch
+const MergePatchContentType = "application/merge-patch+json"
+
+// ErrUnsupportedPatchType is returned by DecodeMergePatch for a body that is
+// neither a merge patch nor plain JSON
+var ErrUnsupportedPatchType = errors.New("PATCH body must be " + MergePatchContentType)
+
+// MergePatch is the set of members sent in a JSON Merge Patch. Members left
+// out are unchanged; members sent as null are to be removed.
+type MergePatch struct {
+	members map[string]jsontext.Value
+}
+
+// DecodeMergePatch reads a JSON Merge Patch body into target, whose pointer
+// and slice fields stay nil for members that were left out or sent as
+// null; the returned patch tells the two apart. application/json bodies
+// are accepted too.
+func DecodeMergePatch(r *http.Request, target any) (*MergePatch, error) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != MergePatchContentType && mediaType != "application/json") {
+			return nil, ErrUnsupportedPatchType
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var members map[string]jsontext.Value
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return nil, errors.New("merge patch must be a JSON object")
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return nil, err
+	}
+	return &MergePatch{members: members}, nil
+}
+
+// Empty reports whether the patch changes nothing
+func (p *MergePatch) Empty() bool {
+	return len(p.members) == 0
+}
+
+// Removes reports whether the patch sets member to null, removing its value
+func (p *MergePatch) Removes(member string) bool {
+	v, ok := p.members[member]
+	return ok && v.Kind() == 'n'
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+// legacyAPIDeprecated is when the unversioned API paths were deprecated in
+// favour of their /v1 equivalents
+var legacyAPIDeprecated = time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+// unversionedPrefixes are served outside the API version: probes, media,
+// browser sign-in pages, links sent by email and the spec itself
+var unversionedPrefixes = []string{
+	"/health",
+	"/status",
+	"/images/",
+	"/media/",
+	"/auth/local/",
+	"/digest/unsubscribe",
+	"/api/openapi.json",
+}
+
+// APIVersionConfig holds the configuration for the API version middleware
+type APIVersionConfig struct {
+	// Sunset is announced on legacy paths when set
+	Sunset time.Time
+	// KeepPrefix passes versioned requests on unchanged, for handlers that
+	// ignore the path or build links from it
+	KeepPrefix bool
+}
+
+// APIVersionMiddleware serves the API under httputil.APIBasePath. Versioned
+// requests have the prefix stripped so routes stay registered once; legacy
+// unversioned requests are still served but marked deprecated with
+// Deprecation (RFC 9745), Sunset (RFC 8594) and a successor-version link.
+func APIVersionMiddleware(config APIVersionConfig) func(http.Handler) http.Handler {
+	deprecation := "@" + formatUnix(legacyAPIDeprecated)
+	var sunset string
+	if !config.Sunset.IsZero() {
+		sunset = config.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rest, ok := trimAPIBasePath(r.URL.Path); ok {
+				if config.KeepPrefix {
+					next.ServeHTTP(w, r)
+					return
+				}
+				r2 := r.Clone(r.Context())
+				r2.URL.Path = rest
+				if r.URL.RawPath != "" {
+					if rawRest, ok := trimAPIBasePath(r.URL.RawPath); ok {
+						r2.URL.RawPath = rawRest
+					}
+				}
+				next.ServeHTTP(w, r2)
+				return
+			}
+
+			if !isUnversioned(r.URL.Path) {
+				h := w.Header()
+				h.Set("Deprecation", deprecation)
+				if sunset != "" {
+					h.Set("Sunset", sunset)
+				}
+				h.Add("Link", "<"+httputil.APIBasePath+r.URL.EscapedPath()+`>; rel="successor-version"`)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// trimAPIBasePath strips the version prefix, reporting whether it was there
+func trimAPIBasePath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, httputil.APIBasePath)
+	if !ok || (rest != "" && rest[0] != '/') {
+		return path, false
+	}
+	if rest == "" {
+		rest = "/"
+	}
+	return rest, true
+}
+
+func isUnversioned(path string) bool {
+	if path == "/" {
+		return true
+	}
+	for _, prefix := range unversionedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatUnix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
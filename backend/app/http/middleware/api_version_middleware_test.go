@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	handler := APIVersionMiddleware(APIVersionConfig{
+		Sunset: time.Date(2027, time.April, 15, 0, 0, 0, 0, time.UTC),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}))
+
+	serve := func(path string) http.Header {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Header()
+	}
+
+	t.Run("versioned path is stripped and not deprecated", func(t *testing.T) {
+		h := serve("/v1/accounts/a1/objects?limit=5")
+		assert.Equal(t, "/accounts/a1/objects", gotPath)
+		assert.Empty(t, h.Get("Deprecation"))
+		assert.Empty(t, h.Get("Sunset"))
+	})
+
+	t.Run("bare version prefix maps to the root", func(t *testing.T) {
+		serve("/v1")
+		assert.Equal(t, "/", gotPath)
+	})
+
+	t.Run("legacy path is served with deprecation headers", func(t *testing.T) {
+		h := serve("/groups/g1")
+		assert.Equal(t, "/groups/g1", gotPath)
+		assert.Equal(t, "@1792022400", h.Get("Deprecation"))
+		assert.Equal(t, "Thu, 15 Apr 2027 00:00:00 GMT", h.Get("Sunset"))
+		assert.Equal(t, `</v1/groups/g1>; rel="successor-version"`, h.Get("Link"))
+	})
+
+	t.Run("unversioned endpoints are not deprecated", func(t *testing.T) {
+		for _, path := range []string{"/health/live", "/images/x.png", "/auth/local/login", "/api/openapi.json", "/v10/groups"} {
+			h := serve(path)
+			assert.Equal(t, path, gotPath)
+			if path == "/v10/groups" {
+				assert.NotEmpty(t, h.Get("Deprecation"), path)
+				continue
+			}
+			assert.Empty(t, h.Get("Deprecation"), path)
+		}
+	})
+}
+
+func TestAPIVersionMiddleware_KeepPrefix(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	handler := APIVersionMiddleware(APIVersionConfig{KeepPrefix: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/sse", nil))
+	assert.Equal(t, "/v1/sse", gotPath)
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	assert.Equal(t, "@1792022400", rec.Header().Get("Deprecation"))
+	assert.Empty(t, rec.Header().Get("Sunset"))
+}
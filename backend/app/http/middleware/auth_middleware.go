@@ -1,24 +1,51 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
 	"github.com/nishiki/backend/domain/services"
 )
 
+// AccountCheck records the user behind a request and returns
+// entities.ErrAccountDisabled when an admin has disabled their account.
+type AccountCheck func(ctx context.Context, user *entities.User) error
+
+// SessionInfo describes the sign-in a request's token belongs to
+type SessionInfo struct {
+	ID        string
+	UserAgent string
+	IPAddress string
+	ExpiresAt time.Time // zero when the token does not expire
+}
+
+// SessionCheck records the session behind a request and returns
+// entities.ErrSessionRevoked when the user has signed it out from another
+// device.
+type SessionCheck func(ctx context.Context, user *entities.User, session SessionInfo) error
+
 type AuthMiddleware struct {
-	authService services.AuthService
-	logger      *slog.Logger
+	authService  services.AuthService
+	checkAccount AccountCheck
+	checkSession SessionCheck
+	logger       *slog.Logger
 }
 
-func NewAuthMiddleware(authService services.AuthService, logger *slog.Logger) *AuthMiddleware {
+// NewAuthMiddleware builds the auth middleware. checkAccount and
+// checkSession may be nil to skip account and session tracking.
+func NewAuthMiddleware(authService services.AuthService, checkAccount AccountCheck, checkSession SessionCheck, logger *slog.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService: authService,
-		logger:      logger,
+		authService:  authService,
+		checkAccount: checkAccount,
+		checkSession: checkSession,
+		logger:       logger,
 	}
 }
 
@@ -65,10 +92,46 @@ func (m *AuthMiddleware) RequireAuth() func(http.Handler) http.Handler {
 					slog.String("username", user.Username().String()))
 			}
 
+			if m.checkAccount != nil {
+				err := m.checkAccount(r.Context(), user)
+				if errors.Is(err, entities.ErrAccountDisabled) {
+					m.logger.Info("Request from disabled account",
+						slog.String("user_id", user.ID().String()))
+					httputil.ErrorCode(w, http.StatusForbidden, httputil.CodeAccountDisabled, AccountDisabledMessage)
+					return
+				}
+				if err != nil {
+					// Tracking is best effort; an unreachable status store
+					// should not sign everyone out.
+					m.logger.Warn("Failed to check account status", slog.Any("error", err))
+				}
+			}
+
+			if session := NewSessionInfo(r, claims); m.checkSession != nil && session.ID == "" {
+				m.logger.Debug("Token names no session, not tracked",
+					slog.String("user_id", user.ID().String()))
+			} else if m.checkSession != nil {
+				err := m.checkSession(r.Context(), user, session)
+				if errors.Is(err, entities.ErrSessionRevoked) {
+					m.logger.Info("Request from revoked session",
+						slog.String("user_id", user.ID().String()))
+					// 401 so clients sign out and start a new sign-in
+					httputil.ErrorCode(w, http.StatusUnauthorized, httputil.CodeSessionRevoked, SessionRevokedMessage)
+					return
+				}
+				if err != nil {
+					// Like the account check, an unreachable session store
+					// lets the request through; sessions known to be revoked
+					// are still refused.
+					m.logger.Warn("Failed to check session", slog.Any("error", err))
+				}
+			}
+
 			// Store user, claims, and token in context
 			r = httputil.SetContextValue(r, httputil.AuthUserKey, user)
 			r = httputil.SetContextValue(r, httputil.AuthClaimsKey, claims)
 			r = httputil.SetContextValue(r, httputil.AuthTokenKey, token)
+			r = m.withTenant(r, user, token)
 
 			next.ServeHTTP(w, r)
 		})
@@ -107,6 +170,92 @@ func (m *AuthMiddleware) OptionalAuth() func(http.Handler) http.Handler {
 			r = httputil.SetContextValue(r, httputil.AuthUserKey, user)
 			r = httputil.SetContextValue(r, httputil.AuthClaimsKey, claims)
 			r = httputil.SetContextValue(r, httputil.AuthTokenKey, token)
+			r = m.withTenant(r, user, token)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// NewSessionInfo describes the session of a request authenticated with
+// claims. ID is empty for tokens that name no session.
+func NewSessionInfo(r *http.Request, claims *services.AuthClaims) SessionInfo {
+	id, _ := claims.SessionID()
+	info := SessionInfo{
+		ID:        id,
+		UserAgent: r.UserAgent(),
+		IPAddress: clientIP(r),
+	}
+	if claims.ExpiresAt != 0 {
+		info.ExpiresAt = time.Unix(claims.ExpiresAt, 0)
+	}
+	return info
+}
+
+// withTenant limits the repositories to the user's own and group data for
+// the rest of the request
+func (m *AuthMiddleware) withTenant(r *http.Request, user *entities.User, token string) *http.Request {
+	tenant := services.NewTenant(m.authService, user, token)
+	return r.WithContext(repositories.WithTenant(r.Context(), tenant))
+}
+
+// RequireRecentAuth rejects requests whose token was issued for a sign-in
+// older than maxAge, so destructive account operations cannot be performed
+// with a long-lived session alone. It must run after RequireAuth. Tokens
+// without an auth_time claim fall back to their issue time.
+func RequireRecentAuth(maxAge time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetCurrentClaims(r)
+			if !ok {
+				httputil.Error(w, http.StatusUnauthorized, "authentication required")
+				return
+			}
+
+			authTime := claims.SignedInAt()
+			if age := time.Since(time.Unix(authTime, 0)); authTime == 0 || age > maxAge {
+				logger.Info("Recent authentication required",
+					slog.String("subject", claims.Subject),
+					slog.Duration("age", age))
+				// 403 rather than 401: the session is still valid and clients
+				// should not treat this as a sign-out.
+				httputil.ErrorCode(w, http.StatusForbidden, httputil.CodeReauthRequired, ReauthRequiredMessage)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ReauthRequiredMessage is the error message returned by RequireRecentAuth.
+const ReauthRequiredMessage = "reauthentication required"
+
+// SessionRevokedMessage is the error message returned for a token whose
+// session was signed out from another device.
+const SessionRevokedMessage = "session revoked"
+
+// AccountDisabledMessage is the error message returned for a disabled account.
+const AccountDisabledMessage = "account disabled"
+
+// RequireAdmin rejects requests from users outside the admin group. It must
+// run after RequireAuth. An empty group turns every request away.
+func RequireAdmin(adminGroup string, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetCurrentClaims(r)
+			if !ok {
+				httputil.Error(w, http.StatusUnauthorized, "authentication required")
+				return
+			}
+
+			if !claims.InGroup(adminGroup) {
+				logger.Warn("Admin endpoint refused",
+					slog.String("subject", claims.Subject),
+					slog.String("path", r.URL.Path))
+				httputil.Error(w, http.StatusForbidden, "admin access required")
+				return
+			}
+
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -142,6 +291,16 @@ func GetCurrentClaims(r *http.Request) (*services.AuthClaims, bool) {
 	return authClaims, true
 }
 
+// GetCurrentSessionID returns the ID of the session the request's token
+// belongs to, false when it names none
+func GetCurrentSessionID(r *http.Request) (string, bool) {
+	claims, ok := GetCurrentClaims(r)
+	if !ok {
+		return "", false
+	}
+	return claims.SessionID()
+}
+
 // GetCurrentToken extracts the auth token from the request context
 func GetCurrentToken(r *http.Request) (string, bool) {
 	token := httputil.GetContextValue(r, httputil.AuthTokenKey)
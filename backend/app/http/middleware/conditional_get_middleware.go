@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ConditionalGetMiddleware tags successful GET responses with an ETag derived
+// from the body and answers 304 Not Modified when the client already holds
+// that body. Handlers that know when their resource last changed can also set
+// a Last-Modified header, which is then checked against If-Modified-Since.
+//
+// The response is buffered to hash it, so only wrap JSON list and detail
+// endpoints with it, not downloads or streams.
+func ConditionalGetMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(bw, r)
+
+			if bw.status != http.StatusOK {
+				w.WriteHeader(bw.status)
+				_, _ = w.Write(bw.body.Bytes())
+				return
+			}
+
+			sum := sha256.Sum256(bw.body.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+			w.Header().Set("ETag", etag)
+			// Responses are per user: browsers may keep them but must revalidate
+			if w.Header().Get("Cache-Control") == "" {
+				w.Header().Set("Cache-Control", "private, no-cache")
+			}
+
+			if notModified(r, w.Header(), etag) {
+				w.Header().Del("Content-Type")
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(bw.body.Bytes())
+		})
+	}
+}
+
+// notModified reports whether the client's cached copy is still current.
+// If-None-Match takes precedence over If-Modified-Since (RFC 9110 §13.2.2).
+func notModified(r *http.Request, header http.Header, etag string) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagListMatches(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	lm := header.Get("Last-Modified")
+	if ims == "" || lm == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lm)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagListMatches compares an If-None-Match list against etag using the weak
+// comparison GET requires
+func etagListMatches(list, etag string) bool {
+	for candidate := range strings.SplitSeq(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter holds the status and body back from the client so
+// the ETag can be computed before anything is sent. Headers go straight to the
+// underlying writer.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (bw *bufferedResponseWriter) WriteHeader(statusCode int) {
+	if bw.wroteHeader {
+		return
+	}
+	bw.status = statusCode
+	bw.wroteHeader = true
+}
+
+func (bw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	bw.wroteHeader = true
+	return bw.body.Write(b)
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+func serveConditional(t *testing.T, h http.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	ConditionalGetMiddleware()(h).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConditionalGetMiddleware_ETag(t *testing.T) {
+	t.Parallel()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		httputil.JSON(w, http.StatusOK, map[string]string{"name": "Pantry"})
+	}
+
+	first := serveConditional(t, handler, httptest.NewRequest(http.MethodGet, "/collections", nil))
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "private, no-cache", first.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"name":"Pantry"}`, first.Body.String())
+
+	t.Run("matching tag is not modified", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/collections", nil)
+		req.Header.Set("If-None-Match", `"other", W/`+etag)
+		rec := serveConditional(t, handler, req)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+	})
+
+	t.Run("stale tag gets the body", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodGet, "/collections", nil)
+		req.Header.Set("If-None-Match", `"stale"`)
+		rec := serveConditional(t, handler, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"name":"Pantry"}`, rec.Body.String())
+	})
+}
+
+func TestConditionalGetMiddleware_LastModified(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		httputil.SetLastModified(w, updated)
+		httputil.JSON(w, http.StatusOK, map[string]string{"name": "Family"})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/groups/1", nil)
+	req.Header.Set("If-Modified-Since", updated.Format(http.TimeFormat))
+	assert.Equal(t, http.StatusNotModified, serveConditional(t, handler, req).Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/groups/1", nil)
+	req.Header.Set("If-Modified-Since", updated.Add(-time.Hour).Format(http.TimeFormat))
+	assert.Equal(t, http.StatusOK, serveConditional(t, handler, req).Code)
+}
+
+func TestConditionalGetMiddleware_PassesThroughErrorsAndWrites(t *testing.T) {
+	t.Parallel()
+
+	notFound := func(w http.ResponseWriter, r *http.Request) {
+		httputil.Error(w, http.StatusNotFound, "collection not found")
+	}
+	req := httptest.NewRequest(http.MethodGet, "/collections/1", nil)
+	req.Header.Set("If-None-Match", "*")
+	rec := serveConditional(t, notFound, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+
+	created := func(w http.ResponseWriter, r *http.Request) {
+		httputil.JSON(w, http.StatusCreated, map[string]string{"id": "1"})
+	}
+	rec = serveConditional(t, created, httptest.NewRequest(http.MethodPost, "/collections", nil))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // CORSConfig holds the configuration for CORS middleware
@@ -20,63 +21,102 @@ type CORSConfig struct {
 func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-None-Match", "If-Modified-Since"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Last-Modified", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
 	}
 }
 
+// CORS applies a cross-origin policy that can be replaced while the server
+// runs, so a config reload takes effect on the next request.
+type CORS struct {
+	policy atomic.Pointer[corsPolicy]
+}
+
+// corsPolicy is a CORSConfig with its header values joined once up front
+type corsPolicy struct {
+	config        CORSConfig
+	allowMethods  string
+	allowHeaders  string
+	exposeHeaders string
+	maxAge        string
+}
+
+// NewCORS creates a CORS policy with the given configuration
+func NewCORS(config CORSConfig) *CORS {
+	c := &CORS{}
+	c.Update(config)
+	return c
+}
+
+// Update replaces the policy for requests that start after it returns
+func (c *CORS) Update(config CORSConfig) {
+	c.policy.Store(&corsPolicy{
+		config:        config,
+		allowMethods:  strings.Join(config.AllowMethods, ", "),
+		allowHeaders:  strings.Join(config.AllowHeaders, ", "),
+		exposeHeaders: strings.Join(config.ExposeHeaders, ", "),
+		maxAge:        strconv.Itoa(config.MaxAge),
+	})
+}
+
 // CORSMiddleware creates a CORS middleware with the given configuration
 func CORSMiddleware(config CORSConfig) func(http.Handler) http.Handler {
-	allowMethods := strings.Join(config.AllowMethods, ", ")
-	allowHeaders := strings.Join(config.AllowHeaders, ", ")
-	exposeHeaders := strings.Join(config.ExposeHeaders, ", ")
-	maxAge := strconv.Itoa(config.MaxAge)
-
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get("Origin")
-
-			// Check if origin is allowed
-			allowedOrigin := ""
-			for _, o := range config.AllowOrigins {
-				if o == "*" {
-					allowedOrigin = "*"
-					break
-				}
-				if o == origin {
-					allowedOrigin = origin
-					break
-				}
+	return NewCORS(config).Middleware
+}
+
+// Middleware applies the current policy to each request
+func (c *CORS) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy := c.policy.Load()
+		config := policy.config
+		origin := r.Header.Get("Origin")
+
+		// Check if origin is allowed
+		allowedOrigin := ""
+		for _, o := range config.AllowOrigins {
+			if o == "*" {
+				allowedOrigin = "*"
+				break
+			}
+			if o == origin {
+				allowedOrigin = origin
+				break
 			}
+		}
 
-			if allowedOrigin != "" {
-				w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
-				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+		// The response differs per origin unless every origin is allowed,
+		// so caches must key on it.
+		if allowedOrigin != "*" {
+			w.Header().Add("Vary", "Origin")
+		}
 
-				if exposeHeaders != "" {
-					w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
-				}
+		if allowedOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", policy.allowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", policy.allowHeaders)
 
-				if config.AllowCredentials && allowedOrigin != "*" {
-					w.Header().Set("Access-Control-Allow-Credentials", "true")
-				}
+			if policy.exposeHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", policy.exposeHeaders)
+			}
 
-				if config.MaxAge > 0 {
-					w.Header().Set("Access-Control-Max-Age", maxAge)
-				}
+			if config.AllowCredentials && allowedOrigin != "*" {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
 			}
 
-			// Handle preflight requests
-			if r.Method == http.MethodOptions {
-				w.WriteHeader(http.StatusNoContent)
-				return
+			if config.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", policy.maxAge)
 			}
+		}
 
-			next.ServeHTTP(w, r)
-		})
-	}
+		// Handle preflight requests
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
 }
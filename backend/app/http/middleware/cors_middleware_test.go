@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_Update(t *testing.T) {
+	t.Parallel()
+
+	cors := NewCORS(CORSConfig{AllowOrigins: []string{"https://a.example"}, AllowMethods: []string{"GET"}})
+	handler := cors.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	allowed := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/groups", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	assert.Equal(t, "https://a.example", allowed("https://a.example"))
+	assert.Empty(t, allowed("https://b.example"))
+
+	cors.Update(CORSConfig{AllowOrigins: []string{"https://b.example"}, AllowMethods: []string{"GET", "PUT"}})
+	assert.Empty(t, allowed("https://a.example"))
+	assert.Equal(t, "https://b.example", allowed("https://b.example"))
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// FeatureCheck reports whether the named feature is on for user, a member
+// of the named groups
+type FeatureCheck func(ctx context.Context, name string, user *entities.User, groups []string) (bool, error)
+
+// RequireFeature answers 404 when the feature is off for the signed-in user,
+// as if the route did not exist. Groups are read from the token's groups
+// claim. It must run after RequireAuth.
+func RequireFeature(name string, check FeatureCheck, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := GetCurrentUser(r)
+			claims, claimsOK := GetCurrentClaims(r)
+			if !ok || !claimsOK {
+				httputil.Error(w, http.StatusUnauthorized, "authentication required")
+				return
+			}
+
+			enabled, err := check(r.Context(), name, user, claims.Groups)
+			if err != nil {
+				logger.Error("Failed to check feature flag",
+					slog.String("feature", name),
+					slog.Any("error", err))
+				httputil.Error(w, http.StatusInternalServerError, "failed to check feature")
+				return
+			}
+			if !enabled {
+				httputil.Error(w, http.StatusNotFound, "feature not enabled")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
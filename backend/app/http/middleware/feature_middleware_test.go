@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+func TestRequireFeature(t *testing.T) {
+	t.Parallel()
+
+	username, err := entities.NewUsername("alice")
+	require.NoError(t, err)
+	email, err := entities.NewEmailAddress("alice@example.com")
+	require.NoError(t, err)
+	user, err := entities.NewUser(entities.UserProps{Username: username, EmailAddress: email})
+	require.NoError(t, err)
+
+	check := func(_ context.Context, name string, _ *entities.User, groups []string) (bool, error) {
+		if name == "broken" {
+			return false, errors.New("database down")
+		}
+		return slices.Contains(groups, "beta"), nil
+	}
+	serve := func(name string, groups []string, signedIn bool) int {
+		handler := RequireFeature(name, check, slog.New(slog.DiscardHandler))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/accounts/me/meal-plans", nil)
+		if signedIn {
+			r = httputil.SetContextValue(r, httputil.AuthUserKey, user)
+			r = httputil.SetContextValue(r, httputil.AuthClaimsKey, &services.AuthClaims{Subject: user.ID().String(), Groups: groups})
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(entities.FeatureMealPlanner, []string{"beta"}, true))
+	assert.Equal(t, http.StatusNotFound, serve(entities.FeatureMealPlanner, []string{"staff"}, true), "off reads as a missing route")
+	assert.Equal(t, http.StatusInternalServerError, serve("broken", nil, true))
+	assert.Equal(t, http.StatusUnauthorized, serve(entities.FeatureMealPlanner, []string{"beta"}, false))
+}
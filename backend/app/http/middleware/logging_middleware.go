@@ -29,23 +29,16 @@ func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 				path = path + "?" + raw
 			}
 
-			// Get client IP
-			clientIP := r.RemoteAddr
-			if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-				clientIP = forwarded
-			} else if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-				clientIP = realIP
-			}
-
 			// Log request details
 			logger.Info("HTTP Request",
 				slog.String("method", r.Method),
 				slog.String("path", path),
 				slog.Int("status", rw.Status()),
 				slog.Duration("latency", latency),
-				slog.String("ip", clientIP),
+				slog.String("ip", clientIP(r)),
 				slog.String("user-agent", r.UserAgent()),
 				slog.Int("size", rw.Size()),
+				slog.String("request_id", RequestID(rw)),
 			)
 		})
 	}
@@ -67,10 +60,7 @@ func ErrorHandlingMiddleware(logger *slog.Logger) func(http.Handler) http.Handle
 					slog.Int("status", rw.Status()),
 				)
 
-				httputil.JSON(rw, http.StatusInternalServerError, map[string]string{
-					"error": "internal server error",
-					"code":  "INTERNAL_ERROR",
-				})
+				httputil.Error(rw, http.StatusInternalServerError, "internal server error")
 			}
 		})
 	}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/metrics"
+)
+
+// MetricsMiddleware counts every request by its response status. It must
+// wrap RecoveryMiddleware so requests that panicked are counted as errors.
+func MetricsMiddleware(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := httputil.NewResponseWriter(w)
+			next.ServeHTTP(rw, r)
+			m.Observe(rw.Status())
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+// maxRateLimitClients bounds the table of counted clients. When it is full,
+// clients not yet seen in the current window share one overflow count.
+const maxRateLimitClients = 10000
+
+// rateLimitOverflow is the table key clients share once it is full
+const rateLimitOverflow = ""
+
+// RateLimiter counts requests per client IP over a fixed window. Its limit
+// and trusted proxies can be changed while the server runs.
+type RateLimiter struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu          sync.Mutex
+	limit       int
+	proxies     []netip.Prefix
+	windowStart time.Time
+	counts      map[string]int
+}
+
+// NewRateLimiter lets each client make limit requests per window
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		counts: make(map[string]int),
+	}
+}
+
+// allow counts a request from client, returning how long until the window
+// rolls over when the client is over its limit.
+func (l *RateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.windowStart) >= l.window {
+		l.windowStart = now
+		clear(l.counts)
+	}
+
+	if _, ok := l.counts[client]; !ok && len(l.counts) >= maxRateLimitClients {
+		client = rateLimitOverflow
+	}
+	if l.counts[client] >= l.limit {
+		return false, l.windowStart.Add(l.window).Sub(now)
+	}
+	l.counts[client]++
+	return true, 0
+}
+
+// RateLimitMiddleware lets each client IP make limit requests per window and
+// answers the rest with 429 Too Many Requests and a Retry-After header. It is
+// meant for unauthenticated endpoints; counts live in this process only.
+func RateLimitMiddleware(limit int, window time.Duration) func(http.Handler) http.Handler {
+	return NewRateLimiter(limit, window).Middleware
+}
+
+// SetLimit changes how many requests a client may make per window. Counts
+// already taken in the current window are kept.
+func (l *RateLimiter) SetLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+}
+
+// SetTrustedProxies sets the reverse proxies whose X-Forwarded-For and
+// X-Real-IP headers name the client. Requests from anywhere else are counted
+// by their own address, so a client cannot pick a new key per request.
+func (l *RateLimiter) SetTrustedProxies(proxies []netip.Prefix) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.proxies = proxies
+}
+
+// isTrustedProxy reports whether addr is one of the trusted proxies
+func (l *RateLimiter) isTrustedProxy(addr netip.Addr) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, proxy := range l.proxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware answers clients over the limit with 429 Too Many Requests and a
+// Retry-After header
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, retryAfter := l.allow(l.clientAddr(r))
+		if !ok {
+			seconds := int((retryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+			httputil.Error(w, http.StatusTooManyRequests, "too many requests")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientAddr is the address a request is counted under. Only a trusted proxy
+// may name another: the rightmost X-Forwarded-For entry that is not itself a
+// trusted proxy, or else X-Real-IP. Entries left of that came from the client.
+func (l *RateLimiter) clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	peer, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	peer = peer.Unmap()
+	if !l.isTrustedProxy(peer) {
+		return peer.String()
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		client := peer
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			client = hop.Unmap()
+			if !l.isTrustedProxy(client) {
+				break
+			}
+		}
+		return client.String()
+	}
+	if realIP, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return realIP.Unmap().String()
+	}
+	return peer.String()
+}
+
+// clientIP is the address a request came from as shown in logs and session
+// lists, preferring the headers a reverse proxy sets. Clients can set those
+// headers too, so it must not be used to tell clients apart.
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return forwarded
+	}
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	get := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		req.RemoteAddr = ip + ":52100"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, get("10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, get("10.0.0.1").Code)
+
+	now = now.Add(20 * time.Second)
+	limited := get("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "40", limited.Header().Get("Retry-After"))
+
+	// Other clients have their own count
+	assert.Equal(t, http.StatusOK, get("10.0.0.2").Code)
+
+	// A new window starts everyone over
+	now = now.Add(time.Minute)
+	assert.Equal(t, http.StatusOK, get("10.0.0.1").Code)
+}
+
+func TestRateLimiter_SetLimit(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(1, time.Minute)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	get := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusTooManyRequests, get())
+
+	// A raised limit applies within the current window
+	limiter.SetLimit(3)
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusTooManyRequests, get())
+}
+
+func TestRateLimiter_ClientAddr(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(1, time.Minute)
+	limiter.SetTrustedProxies([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.5:40000", want: "203.0.113.5"},
+		{name: "direct client spoofing X-Forwarded-For", remoteAddr: "203.0.113.5:40000", forwarded: []string{"198.51.100.1"}, want: "203.0.113.5"},
+		{name: "direct client spoofing X-Real-IP", remoteAddr: "203.0.113.5:40000", realIP: "198.51.100.1", want: "203.0.113.5"},
+		{name: "IPv6 client", remoteAddr: "[2001:db8::1]:40000", want: "2001:db8::1"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.2:40000", forwarded: []string{"203.0.113.5"}, want: "203.0.113.5"},
+		{name: "trusted proxy chain", remoteAddr: "10.0.0.2:40000", forwarded: []string{"203.0.113.5, 10.0.0.3"}, want: "203.0.113.5"},
+		{name: "entries the client prepended", remoteAddr: "10.0.0.2:40000", forwarded: []string{"198.51.100.1, 203.0.113.5"}, want: "203.0.113.5"},
+		{name: "repeated header", remoteAddr: "10.0.0.2:40000", forwarded: []string{"198.51.100.1", "203.0.113.5"}, want: "203.0.113.5"},
+		{name: "trusted proxy with X-Real-IP", remoteAddr: "10.0.0.2:40000", realIP: "203.0.113.5", want: "203.0.113.5"},
+		{name: "trusted proxy with no header", remoteAddr: "10.0.0.2:40000", want: "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/status", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			assert.Equal(t, tt.want, limiter.clientAddr(req))
+		})
+	}
+}
+
+func TestRateLimitMiddleware_SpoofedHeaders(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(1, time.Minute)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	get := func(forwarded string) int {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		req.RemoteAddr = "203.0.113.5:40000"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		req.Header.Set("X-Real-IP", forwarded)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A new forwarded address on every request does not reset the count
+	assert.Equal(t, http.StatusOK, get("198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, get("198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, get("198.51.100.3"))
+}
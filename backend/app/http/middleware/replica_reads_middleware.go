@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// ReplicaReadsMiddleware lets the repositories serve the request's reads
+// from the database read replica, when one is configured. Only wrap
+// read-only endpoints that can tolerate results a moment behind the
+// primary, such as search, stats and exports.
+func ReplicaReadsMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(repositories.WithReplicaReads(r.Context())))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+func TestReplicaReadsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var replica bool
+	handler := ReplicaReadsMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		replica = repositories.ReplicaReads(r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/u1/search", nil))
+	assert.True(t, replica)
+	assert.False(t, repositories.ReplicaReads(t.Context()), "unmarked contexts read from the primary")
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+// maxRequestIDLength bounds a request ID taken from the client, so a proxy's
+// ID is kept but the header cannot be used to stuff the logs
+const maxRequestIDLength = 64
+
+// RequestIDMiddleware gives every request an ID, set as X-Request-ID on the
+// response before any handler runs so error bodies and the request log can
+// quote it. An ID sent by the client or a proxy in front of us is reused when
+// it is short and plain; otherwise a random one is made.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(httputil.RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(httputil.RequestIDHeader, id)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequestID returns the ID RequestIDMiddleware gave the request being
+// answered on w
+func RequestID(w http.ResponseWriter) string {
+	return w.Header().Get(httputil.RequestIDHeader)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
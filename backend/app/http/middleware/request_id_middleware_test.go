@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	t.Parallel()
+
+	handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httputil.Error(w, http.StatusNotFound, "collection not found")
+	}))
+	serve := func(sent string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/collections/c1", nil)
+		if sent != "" {
+			req.Header.Set(httputil.RequestIDHeader, sent)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("error body quotes the generated ID", func(t *testing.T) {
+		rec := serve("")
+		id := rec.Header().Get(httputil.RequestIDHeader)
+		require.Len(t, id, 24)
+
+		var body httputil.ErrorBody
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, httputil.ErrorBody{
+			Error:     "collection not found",
+			Code:      httputil.CodeNotFound,
+			Message:   "collection not found",
+			RequestID: id,
+		}, body)
+	})
+
+	t.Run("an ID from a proxy is kept", func(t *testing.T) {
+		assert.Equal(t, "edge-7f3a.2", serve("edge-7f3a.2").Header().Get(httputil.RequestIDHeader))
+	})
+
+	t.Run("unsafe or oversized IDs are replaced", func(t *testing.T) {
+		for _, sent := range []string{"a b", "id\nforged: log", strings.Repeat("x", maxRequestIDLength+1)} {
+			got := serve(sent).Header().Get(httputil.RequestIDHeader)
+			assert.NotEqual(t, sent, got)
+			assert.Len(t, got, 24)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"context"
 	"encoding/json/jsontext"
 	"encoding/json/v2"
 	"net/http"
@@ -9,12 +10,15 @@ import (
 	swagno "github.com/go-swagno/swagno/v3"
 	"github.com/go-swagno/swagno/v3/components/endpoint"
 	"github.com/go-swagno/swagno/v3/components/http/response"
+	"github.com/go-swagno/swagno/v3/components/mime"
 	"github.com/go-swagno/swagno/v3/components/parameter"
 	"github.com/go-swagno/swagno/v3/components/security"
 	"github.com/go-swagno/swagno/v3/components/tag"
 
+	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/request"
 	httpresp "github.com/nishiki/backend/app/http/response"
+	mcpserver "github.com/nishiki/backend/app/mcp"
 )
 
 var (
@@ -28,10 +32,10 @@ func GenerateOpenAPISpec() []byte {
 		sw := swagno.New(swagno.Config{
 			Title:       "Nishiki Inventory API",
 			Version:     "1.0.0",
-			Description: "Inventory management REST API with integrated MCP (Model Context Protocol) server. See x-mcp-tools, x-mcp-resources, and x-mcp-prompts for AI assistant integration.",
+			Description: "Inventory management REST API with integrated MCP (Model Context Protocol) server. Paths are served under " + httputil.APIBasePath + "; the unversioned paths remain as deprecated aliases answering with Deprecation and Sunset headers. See x-mcp-tools, x-mcp-resources, and x-mcp-prompts for AI assistant integration.",
 		})
 
-		sw.SetBearerAuth("JWT", "Bearer token obtained from Authentik OIDC. Required for all endpoints except /health, /auth/oidc-config, and /auth/token.")
+		sw.SetBearerAuth("JWT", "Bearer token obtained from the OIDC provider, or from /auth/login in local auth mode. Required for all endpoints except /health*, /status, /auth/oidc-config, /auth/token, /auth/register, /auth/login and /client-errors.")
 
 		sw.AddTags(
 			tag.New("auth", "Authentication and session management"),
@@ -41,15 +45,50 @@ func GenerateOpenAPISpec() []byte {
 			tag.New("containers", "Container and storage management"),
 			tag.New("objects", "Inventory object CRUD operations"),
 			tag.New("import", "Bulk import of inventory items"),
+			tag.New("digest", "Expiring and low-stock email digest"),
+			tag.New("preferences", "Per-view sort, grouping and filter preferences, and pinned or hand-ordered collections and containers"),
+			tag.New("backup", "Full account backup and restore"),
+			tag.New("meals", "Meal planning linked to food inventory, behind the meal_planner feature flag; 404 for users it is off for"),
+			tag.New("inbox", "Receipts and order confirmations forwarded by email"),
+			tag.New("recurrences", "Staples put back on the shopping list on a schedule"),
+			tag.New("automations", "Rules that tag, move, restock or post objects when they change"),
+			tag.New("snapshots", "Collection snapshots, comparison and restore"),
+			tag.New("media", "Photos of objects and containers"),
+			tag.New("comments", "Notes on collections and objects for group members"),
+			tag.New("nutrition", "Nutrition facts of food objects and pantry totals"),
+			tag.New("reports", "Account-wide reports over every collection the user can see"),
+			tag.New("client-errors", "Crash and API failure reports from the frontend"),
+			tag.New("features", "Feature flags evaluated for the current user"),
+			tag.New("admin", "User management and usage for members of the admin group"),
+			tag.New("dev", "Fixture data for development, in debug mode only"),
 		)
 
 		registerAuthEndpoints(sw)
 		registerGroupEndpoints(sw)
 		registerUserEndpoints(sw)
+		registerFeatureEndpoints(sw)
+		registerDashboardEndpoints(sw)
 		registerCollectionEndpoints(sw)
+		registerCollectionFolderEndpoints(sw)
+		registerObjectTypeEndpoints(sw)
 		registerContainerEndpoints(sw)
 		registerObjectEndpoints(sw)
 		registerImportEndpoints(sw)
+		registerDigestEndpoints(sw)
+		registerPreferencesEndpoints(sw)
+		registerBackupEndpoints(sw)
+		registerMealPlanEndpoints(sw)
+		registerInboxEndpoints(sw)
+		registerRecurrenceEndpoints(sw)
+		registerAutomationEndpoints(sw)
+		registerSnapshotEndpoints(sw)
+		registerMediaEndpoints(sw)
+		registerCommentEndpoints(sw)
+		registerNutritionEndpoints(sw)
+		registerReportEndpoints(sw)
+		registerClientErrorEndpoints(sw)
+		registerAdminEndpoints(sw)
+		registerDevEndpoints(sw)
 
 		baseSpec, err := sw.ToJson()
 		if err != nil {
@@ -64,7 +103,11 @@ func GenerateOpenAPISpec() []byte {
 			openAPISpec = baseSpec
 			return
 		}
-		specMap["x-mcp-tools"] = mcpToolsDocs()
+		normalizeSchemas(specMap)
+		specMap["servers"] = []any{map[string]any{"url": httputil.APIBasePath}}
+		if tools, err := mcpserver.ToolDocs(context.Background()); err == nil {
+			specMap["x-mcp-tools"] = tools
+		}
 		specMap["x-mcp-resources"] = mcpResourcesDocs()
 		specMap["x-mcp-prompts"] = mcpPromptsDocs()
 		specMap["x-mcp-config"] = mcpConfigExample()
@@ -79,14 +122,87 @@ func GenerateOpenAPISpec() []byte {
 	return openAPISpec
 }
 
+// freeFormMaps are the OpenAPI-safe property maps declared as
+// map[string]string only because swagno panics on interface{}; the API
+// accepts values of any JSON type in them.
+var freeFormMaps = []string{
+	"openapi.OpenAPICreateObjectRequest.properties",
+	"openapi.OpenAPIUpdateObjectRequest.properties",
+	"openapi.OpenAPIPatchObjectRequest.properties",
+	"openapi.OpenAPIObjectResponse.properties",
+}
+
+// mergePatchSchemas are the JSON Merge Patch bodies, in which any member
+// may be null to remove its value
+var mergePatchSchemas = []string{
+	"openapi.OpenAPIPatchObjectRequest",
+	"request.PatchCollectionRequest",
+	"request.PatchContainerRequest",
+}
+
+// normalizeSchemas rewrites swagno's rendering of Go maps into
+// additionalProperties, so the spec and the validator treat map keys as data
+// rather than a property literally named "string".
+func normalizeSchemas(spec map[string]any) {
+	components, _ := spec["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	var walk func(node any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			if n["type"] == "map" {
+				n["type"] = "object"
+			}
+			if props, ok := n["properties"].(map[string]any); ok && n["type"] == "object" && len(props) == 1 {
+				if value, ok := props["string"]; ok {
+					delete(n, "properties")
+					n["additionalProperties"] = value
+				}
+			}
+			for _, v := range n {
+				walk(v)
+			}
+		case []any:
+			for _, v := range n {
+				walk(v)
+			}
+		}
+	}
+	walk(schemas)
+	walk(spec["paths"])
+
+	for _, name := range freeFormMaps {
+		if schema, ok := schemas[name].(map[string]any); ok {
+			schema["additionalProperties"] = map[string]any{}
+		}
+	}
+	for _, name := range mergePatchSchemas {
+		schema, _ := schemas[name].(map[string]any)
+		props, _ := schema["properties"].(map[string]any)
+		for _, prop := range props {
+			if prop, ok := prop.(map[string]any); ok {
+				prop["nullable"] = true
+			}
+		}
+	}
+}
+
 // HandleOpenAPISpec serves the OpenAPI JSON specification at /api/openapi.json.
 func HandleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
 	spec := GenerateOpenAPISpec()
 	w.Header().Set("Content-Type", "application/json")
+	// The spec is the same for everyone and only changes with a deploy
+	w.Header().Set("Cache-Control", "public, no-cache")
 	w.WriteHeader(http.StatusOK)
 	w.Write(spec) //nolint:errcheck
 }
 
+// mergePatchConsume declares the media types a PATCH endpoint accepts: an RFC
+// 7386 JSON Merge Patch, or the same document sent as plain JSON.
+func mergePatchConsume() endpoint.EndPointOption {
+	return endpoint.WithConsume([]mime.MIME{httputil.MergePatchContentType, mime.JSON})
+}
+
 // authSecurity returns the security requirement for JWT-protected endpoints.
 func authSecurity() []map[security.SecuritySchemeName][]string {
 	return []map[security.SecuritySchemeName][]string{
@@ -110,6 +226,42 @@ func registerAuthEndpoints(sw *swagno.OpenAPI) {
 				response.New(map[string]string{}, "200", "Server is healthy"),
 			}),
 		),
+		endpoint.New(
+			endpoint.GET,
+			"/health/live",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Liveness probe"),
+			endpoint.WithDescription("Reports that the process is running without checking dependencies. No authentication required."),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.LivenessResponse{}, "200", "Process is alive"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/status",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Public status"),
+			endpoint.WithDescription("Returns the server version, build commit, uptime and anonymous request totals since start (requests, 5xx errors and the error rate). No authentication required. Limited to 30 requests a minute per client IP; beyond that it answers 429 with Retry-After."),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.StatusResponse{}, "200", "Server status"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "429", "Too many requests"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/health/ready",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Readiness probe"),
+			endpoint.WithDescription("Checks the database, Authentik OIDC discovery, the image cache and media storage (when enabled). Returns 503 if any of these fails. Optional providers such as nutrition lookups are reported with optional set but never cause a 503. Each dependency is reported only as up or down; the reason a check failed is logged, not returned. No authentication required."),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ReadinessResponse{}, "200", "All dependencies are up"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(httpresp.ReadinessResponse{}, "503", "One or more dependencies are down"),
+			}),
+		),
 		endpoint.New(
 			endpoint.GET,
 			"/auth/oidc-config",
@@ -133,6 +285,87 @@ func registerAuthEndpoints(sw *swagno.OpenAPI) {
 				response.New(ErrorResponse{}, "400", "Invalid authorization code"),
 			}),
 		),
+		endpoint.New(
+			endpoint.POST,
+			"/auth/register",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Register a local account"),
+			endpoint.WithDescription("Local auth mode only. Creates a username/password account and signs it in. The first account can always register; later ones only when auth.local.allow_registration is set. No authentication required."),
+			endpoint.WithBody(request.RegisterRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.TokenResponse{}, "201", "Account created"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid username, email or password"),
+				response.New(ErrorResponse{}, "403", "Registration is closed"),
+				response.New(ErrorResponse{}, "409", "Username is already taken"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/auth/login",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Sign in to a local account"),
+			endpoint.WithDescription("Local auth mode only. Exchanges a username and password for an access token and a refresh token; refresh through /auth/token with grant_type refresh_token. No authentication required."),
+			endpoint.WithBody(request.LoginRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.TokenResponse{}, "200", "Signed in"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "401", "Invalid username or password"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/auth/local/authorize",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Show the local sign-in page"),
+			endpoint.WithDescription("Local auth mode only. The authorization endpoint the frontend sends the browser to: an HTML sign-in form, or the registration form when register is set and registration is open. The OAuth parameters are carried into the form as hidden fields. No authentication required."),
+			endpoint.WithProduce([]mime.MIME{mime.HTML}),
+			endpoint.WithParams(
+				parameter.StrParam("redirect_uri", parameter.Query, parameter.WithRequired(), parameter.WithDescription("Frontend URL to return to; must be an allowed redirect URL")),
+				parameter.StrParam("state", parameter.Query, parameter.WithDescription("Opaque value returned unchanged with the code")),
+				parameter.StrParam("code_challenge", parameter.Query, parameter.WithRequired(), parameter.WithDescription("PKCE code challenge")),
+				parameter.StrParam("code_challenge_method", parameter.Query, parameter.WithDescription("PKCE method; only S256 is accepted")),
+				parameter.StrParam("register", parameter.Query, parameter.WithDescription("Any value shows the registration form while registration is open")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "HTML sign-in or registration form"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Redirect URL not allowed or unsupported code_challenge_method"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/auth/local/authorize",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Submit the local sign-in form"),
+			endpoint.WithDescription("Local auth mode only. Signs in, or registers first when action is register, and redirects with 302 to redirect_uri with code and state query parameters; redeem the code through /auth/token with grant_type authorization_code. Rejected credentials or registrations re-render the HTML form with the error status. No authentication required."),
+			endpoint.WithConsume([]mime.MIME{mime.MIME("application/x-www-form-urlencoded")}),
+			endpoint.WithProduce([]mime.MIME{mime.HTML}),
+			endpoint.WithBody(OpenAPILocalSignInForm{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "302", "Redirect to redirect_uri with an authorization code"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid form, redirect URL not allowed, or invalid registration details"),
+				response.New(EmptyResponse{}, "401", "Wrong username or password; the form is shown again"),
+				response.New(EmptyResponse{}, "403", "Registration is closed; the form is shown again"),
+				response.New(EmptyResponse{}, "409", "Username is already taken; the form is shown again"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/auth/local/end-session",
+			endpoint.WithTags("auth"),
+			endpoint.WithSummary("Show the local sign-out page"),
+			endpoint.WithDescription("Local auth mode only. The end-session endpoint the frontend opens on sign-out. Tokens are stateless, so this only shows an HTML confirmation. No authentication required."),
+			endpoint.WithProduce([]mime.MIME{mime.HTML}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "HTML signed-out page"),
+			}),
+		),
 		endpoint.New(
 			endpoint.GET,
 			"/auth/me",
@@ -150,6 +383,29 @@ func registerAuthEndpoints(sw *swagno.OpenAPI) {
 	})
 }
 
+// ============================================
+// FEATURE ENDPOINTS
+// ============================================
+
+func registerFeatureEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/features",
+			endpoint.WithTags("features"),
+			endpoint.WithSummary("Get features"),
+			endpoint.WithDescription("Returns whether each known feature is on for the current user. A feature's flag is the one an admin set, else the one in app.toml under [features], else its built-in default; a flag turns the feature on for everyone or only for the user IDs, usernames and groups (from the token's groups claim) it lists. Routes of a feature that is off answer 404."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.FeaturesResponse{}, "200", "Feature names and whether each is on"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "401", "Not authenticated"),
+			}),
+		),
+	})
+}
+
 // ============================================
 // GROUP ENDPOINTS
 // ============================================
@@ -216,6 +472,103 @@ func registerGroupEndpoints(sw *swagno.OpenAPI) {
 				response.New(ErrorResponse{}, "404", "Group not found"),
 			}),
 		),
+		endpoint.New(
+			endpoint.GET,
+			"/groups/{id}/members",
+			endpoint.WithTags("groups"),
+			endpoint.WithSummary("List group members with roles"),
+			endpoint.WithDescription("Returns the users in a group with their role there: member or admin."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Group ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New([]httpresp.GroupMemberResponse{}, "200", "List of group members"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Group not found"),
+				response.New(ErrorResponse{}, "502", "Authentik did not answer or rejected the request"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/groups/{id}/invitations",
+			endpoint.WithTags("groups"),
+			endpoint.WithSummary("Invite a member by email"),
+			endpoint.WithDescription("Adds the user registered under the email address to the group. Only members may invite, and only admins once the group has any. An address without an account is answered the same as one with, so invitations do not reveal who has an account."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Group ID")),
+			),
+			endpoint.WithBody(request.InviteGroupMemberRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Invitation handled"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid email address"),
+				response.New(ErrorResponse{}, "403", "Not a group admin, or groups are managed by the identity provider"),
+				response.New(ErrorResponse{}, "404", "Group not found"),
+				response.New(ErrorResponse{}, "502", "Authentik did not answer or rejected the request"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/groups/{id}/users/{user_id}/role",
+			endpoint.WithTags("groups"),
+			endpoint.WithSummary("Change a member's role"),
+			endpoint.WithDescription("Makes a member an admin or a plain member. Only admins may change roles; in a group without admins any member can appoint the first one. The user has to be a member of the group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Group ID")),
+				parameter.StrParam("user_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User ID")),
+			),
+			endpoint.WithBody(request.SetGroupMemberRoleRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Role changed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid role"),
+				response.New(ErrorResponse{}, "403", "Not a group admin, or groups are managed by the identity provider"),
+				response.New(ErrorResponse{}, "404", "Group not found, or the user is not a member"),
+				response.New(ErrorResponse{}, "502", "Authentik did not answer or rejected the request"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/groups/{id}/quota",
+			endpoint.WithTags("groups"),
+			endpoint.WithSummary("Get group quota"),
+			endpoint.WithDescription("Returns the limits on containers, objects and photo storage in the collections shared with the group, next to what they use now. A limit of 0 is unlimited. can_edit tells whether the caller is an admin who may change them."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Group ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.GroupQuotaResponse{}, "200", "Quota and usage"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Group not found or not a member"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/groups/{id}/quota",
+			endpoint.WithTags("groups"),
+			endpoint.WithSummary("Set group quota"),
+			endpoint.WithDescription("Replaces the group's limits; 0 leaves one off. Only group admins may. Once a limit is reached, creating a container or object, or uploading a photo, in one of the group's collections is refused with 403 and code quota_exceeded. A limit below current usage removes nothing."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Group ID")),
+			),
+			endpoint.WithBody(request.SetGroupQuotaRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.GroupQuotaResponse{}, "200", "Quota and usage"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Negative limit"),
+				response.New(ErrorResponse{}, "403", "Not a group admin"),
+			}),
+		),
 		endpoint.New(
 			endpoint.GET,
 			"/groups/{id}/containers",
@@ -291,6 +644,146 @@ func registerUserEndpoints(sw *swagno.OpenAPI) {
 				response.New(ErrorResponse{}, "404", "User not found"),
 			}),
 		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}",
+			endpoint.WithTags("users"),
+			endpoint.WithSummary("Delete account"),
+			endpoint.WithDescription("Permanently deletes every collection, container, object, object history entry, saved container template and digest preference owned by the user, and ends all of its sign-in sessions. Collections shared through groups are not affected, and the identity in the auth provider is kept. Requires a sign-in younger than auth.reauth_max_age and the username repeated in confirm_username."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User/Account ID (UUID)")),
+			),
+			endpoint.WithBody(request.DeleteAccountRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.AccountDeletionResponse{}, "200", "Counts of deleted records"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Confirmation missing or does not match"),
+				response.New(ErrorResponse{}, "403", "Access denied or reauthentication required"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/data-export",
+			endpoint.WithTags("users"),
+			endpoint.WithSummary("Export personal data"),
+			endpoint.WithDescription("Downloads the user's profile, settings, collections with containers and objects, saved container templates and object move history as JSON. Other account data such as meal plans, preferences, media, comments, folders, custom types, recurrences, sessions, inbox items, automations and webhooks is not included. Requires a sign-in younger than auth.reauth_max_age."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User/Account ID (UUID)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.AccountDataExport{}, "200", "Personal data export"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Access denied or reauthentication required"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/sessions",
+			endpoint.WithTags("users"),
+			endpoint.WithSummary("List sessions"),
+			endpoint.WithDescription("Lists the devices the user is signed in on, most recently used first. A session is one sign-in, recorded the first time its token is used and kept across token refreshes; current marks the one the request was made from. Revoked sessions and those whose tokens have expired are left out."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User/Account ID (UUID)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.SessionListResponse{}, "200", "Sessions"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Access denied"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/sessions/{session_id}",
+			endpoint.WithTags("users"),
+			endpoint.WithSummary("Trust or untrust a session"),
+			endpoint.WithDescription("Remembers a device, so that signing out other devices leaves it signed in, or forgets it again."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User/Account ID (UUID)")),
+				parameter.StrParam("session_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Session ID")),
+			),
+			endpoint.WithBody(request.UpdateSessionRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.SessionResponse{}, "200", "Updated session"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request body"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Session not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/sessions/{session_id}",
+			endpoint.WithTags("users"),
+			endpoint.WithSummary("Sign out a session"),
+			endpoint.WithDescription("Signs out one other device, trusted or not. Its tokens, and any refreshed from them, are refused with 401 \"session revoked\" within a minute, so it has to sign in again. The current session cannot be revoked; sign out instead."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User/Account ID (UUID)")),
+				parameter.StrParam("session_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Session ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RevokeSessionsResponse{}, "200", "Session revoked"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "The current session"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Session not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/sessions/revoke-others",
+			endpoint.WithTags("users"),
+			endpoint.WithSummary("Sign out other devices"),
+			endpoint.WithDescription("Revokes every session but the current one, except those marked trusted."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User/Account ID (UUID)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RevokeSessionsResponse{}, "200", "Number of sessions revoked"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Access denied"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// DASHBOARD ENDPOINTS
+// ============================================
+
+func registerDashboardEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/dashboard",
+			endpoint.WithTags("dashboard"),
+			endpoint.WithSummary("Get dashboard"),
+			endpoint.WithDescription("Returns what the client shows on sign-in in one request: the user as /auth/me returns them, their groups, their collections ordered as the collection list orders them, and per-collection object, low stock and expiry counts. Expiring counts cover objects due within days that have not expired yet; expired counts those already past their date."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.IntParam("days", parameter.Query, parameter.WithDescription("How many days ahead objects count as expiring, 1-366 (default 7)")),
+				parameter.StrParam("sort", parameter.Query, parameter.WithDescription("Collection sort field (name, created_at, updated_at, custom)")),
+				parameter.StrParam("order", parameter.Query, parameter.WithDescription("Sort order (asc, desc)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.DashboardResponse{}, "200", "Dashboard"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid days or sort"),
+			}),
+		),
 	})
 }
 
@@ -319,7 +812,7 @@ func registerCollectionEndpoints(sw *swagno.OpenAPI) {
 			"/accounts/{id}/collections",
 			endpoint.WithTags("collections"),
 			endpoint.WithSummary("Create collection"),
-			endpoint.WithDescription("Creates a new inventory collection. object_type must be one of: food, book, videogame, music, boardgame, general."),
+			endpoint.WithDescription("Creates a new inventory collection. object_type is one of the built-in types (food, book, videogame, music, boardgame, general) or the key of one of the user's custom object types, whose schema the collection starts with unless property_schema is given."),
 			endpoint.WithSecurity(authSecurity()),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
@@ -371,26 +864,154 @@ func registerCollectionEndpoints(sw *swagno.OpenAPI) {
 			}),
 		),
 		endpoint.New(
-			endpoint.DELETE,
+			endpoint.PATCH,
 			"/accounts/{id}/collections/{collection_id}",
 			endpoint.WithTags("collections"),
-			endpoint.WithSummary("Delete collection"),
-			endpoint.WithDescription("Deletes a collection and all its containers and objects."),
+			endpoint.WithSummary("Patch collection"),
+			endpoint.WithDescription("Applies a JSON Merge Patch (RFC 7386): only the fields sent change and null removes a value. Tags, when sent, replace the collection's tags. name and object_type cannot be removed."),
 			endpoint.WithSecurity(authSecurity()),
+			mergePatchConsume(),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
 				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
 			),
+			endpoint.WithBody(request.PatchCollectionRequest{}),
 			endpoint.WithSuccessfulReturns([]response.Response{
-				response.New(EmptyResponse{}, "200", "Collection deleted"),
+				response.New(httpresp.CollectionResponse{}, "200", "Updated collection"),
 			}),
 			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
 				response.New(ErrorResponse{}, "404", "Collection not found"),
+				response.New(ErrorResponse{}, "415", "Body is not a JSON Merge Patch"),
 			}),
 		),
-	})
-}
-
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/collections/{collection_id}",
+			endpoint.WithTags("collections"),
+			endpoint.WithSummary("Delete collection"),
+			endpoint.WithDescription("Deletes a collection and all its containers and objects."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "Collection deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/report.pdf",
+			endpoint.WithTags("collections"),
+			endpoint.WithSummary("Generate collection report"),
+			endpoint.WithDescription("Renders a printable PDF report grouped by container, with object counts, values and expiry dates. Archived objects are left out."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithProduce([]mime.MIME{mime.PDF}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("layout", parameter.Query, parameter.WithDescription("summary (one line per container) or detailed (default, every object)")),
+				parameter.BoolParam("images", parameter.Query, parameter.WithDescription("Include cached object images in the detailed layout")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "PDF document"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid layout"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/transfer",
+			endpoint.WithTags("collections"),
+			endpoint.WithSummary("Transfer collection ownership"),
+			endpoint.WithDescription("Moves ownership to a group (to_group_id, caller must be a member) or to a user (to_user_id, must be a member of the collection's group). Group-owned collections have no personal owner: any group member can manage them, only group admins can transfer or delete them, and they stay with the group when members leave."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.TransferCollectionRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionResponse{}, "200", "Collection after transfer"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid target or recipient not in the collection's group"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/collections/{collection_id}/shelf-life",
+			endpoint.WithTags("collections"),
+			endpoint.WithSummary("Update shelf life defaults"),
+			endpoint.WithDescription("Replaces the collection's default shelf life by category (e.g. dairy 7 days, frozen 90 days). Food objects created or imported without expires_at get one from the rule matching their category property, then their tags, then the rule with an empty category. Only the collection owner can change them."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.UpdateShelfLifeRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionResponse{}, "200", "Collection with its new defaults"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Days out of range or category listed twice"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/collections/{collection_id}/floor-plan",
+			endpoint.WithTags("collections"),
+			endpoint.WithSummary("Upload floor plan"),
+			endpoint.WithDescription("Sets the image the collection's containers are placed on, e.g. a sketch of the house. Send multipart/form-data with one JPEG, PNG or GIF in the file field, within the photo size limit. A previous plan is replaced and its image deleted; container placements are kept. The plan is returned in the collection's floor_plan."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithConsume([]mime.MIME{mime.MIME("multipart/form-data")}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.FloorPlanResponse{}, "200", "The new floor plan"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "No file, or not a JPEG, PNG or GIF image"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its storage quota"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+				response.New(ErrorResponse{}, "413", "Image too large"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/collections/{collection_id}/floor-plan",
+			endpoint.WithTags("collections"),
+			endpoint.WithSummary("Remove floor plan"),
+			endpoint.WithDescription("Deletes the collection's floor plan image. Container placements are kept for a later plan."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Floor plan removed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection not found or has no floor plan"),
+			}),
+		),
+	})
+}
+
 // ============================================
 // CONTAINER ENDPOINTS
 // ============================================
@@ -422,6 +1043,7 @@ func registerContainerEndpoints(sw *swagno.OpenAPI) {
 			}),
 			endpoint.WithErrors([]response.Response{
 				response.New(ErrorResponse{}, "400", "Invalid request or container type"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its container quota"),
 			}),
 		),
 		endpoint.New(
@@ -460,6 +1082,28 @@ func registerContainerEndpoints(sw *swagno.OpenAPI) {
 				response.New(ErrorResponse{}, "404", "Container not found"),
 			}),
 		),
+		endpoint.New(
+			endpoint.PATCH,
+			"/containers/{container_id}",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Patch container"),
+			endpoint.WithDescription("Applies a JSON Merge Patch (RFC 7386): only the fields sent change and null removes a value, such as the parent, group, a dimension or the temperature zone. name and type cannot be removed."),
+			endpoint.WithSecurity(authSecurity()),
+			mergePatchConsume(),
+			endpoint.WithParams(
+				parameter.StrParam("container_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Container ID")),
+			),
+			endpoint.WithBody(request.PatchContainerRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ContainerResponse{}, "200", "Updated container"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Container not found"),
+				response.New(ErrorResponse{}, "415", "Body is not a JSON Merge Patch"),
+			}),
+		),
 
 		// Collection-scoped container routes
 		endpoint.New(
@@ -494,6 +1138,165 @@ func registerContainerEndpoints(sw *swagno.OpenAPI) {
 			}),
 			endpoint.WithErrors([]response.Response{
 				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its container quota"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/containers/from-template",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Create containers from template"),
+			endpoint.WithDescription("Creates every container in a template inside the collection. template_id is a built-in ID (e.g. builtin:kitchen, builtin:library) or the ID of a saved template. parent_container_id nests the template under an existing container."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.CreateContainersFromTemplateRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New([]httpresp.ContainerResponse{}, "201", "Created containers, parents before children"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request or parent cannot have children"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its container quota"),
+				response.New(ErrorResponse{}, "404", "Template, collection or parent container not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/containers/tree",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Export container tree"),
+			endpoint.WithDescription("Returns the collection's containers, nested under their parents, as an editable YAML document. Objects are not included."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithProduce([]mime.MIME{mime.YAML}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "YAML document"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/collections/{collection_id}/containers/tree",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Import container tree"),
+			endpoint.WithDescription("Applies an edited YAML container tree. Entries with an id rename or move that container, entries without one are created. Containers left out of the document are not changed or deleted. The whole document is validated before anything is saved."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithConsume([]mime.MIME{mime.YAML}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.BoolParam("dry_run", parameter.Query, parameter.WithDescription("Validate and report the changes without saving them")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ImportContainerTreeResponse{}, "200", "Containers created and updated"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid YAML or container tree"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its container quota"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+				response.New(ErrorResponse{}, "413", "Document too large"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/containers/import",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Create containers in bulk"),
+			endpoint.WithDescription("Creates one container per CSV or JSON row with the columns name, type, parent_path and location. parent_path names the parent by container names from the collection root joined with \"/\", either an existing container or one from another row. Rows are validated together: if any fails nothing is created, and every problem is listed in errors. A dry run reports the same without saving."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.BoolParam("dry_run", parameter.Query, parameter.WithDescription("Validate the rows and report what would be created without saving")),
+			),
+			endpoint.WithBody(OpenAPIBulkCreateContainersRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.BulkCreateContainersResponse{}, "200", "Containers created, or the dry-run report"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(httpresp.BulkCreateContainersResponse{}, "400", "Rows rejected; nothing was created"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its container quota"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/collections/{collection_id}/containers/placements",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Place containers on the floor plan"),
+			endpoint.WithDescription("Sets where each listed container is: x and y as fractions (0-1) of the floor plan's width and height from its top left corner, sent together, and an optional floor and room name (at most 100 characters). Containers not listed keep their placement; one listed with no position, floor or room is taken off the plan. Every container is checked before any is saved. Placements are returned in each container's placement."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.UpdateContainerPlacementsRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ContainerListResponse{}, "200", "The placed containers, without their objects"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Position out of range, x without y, or a container listed twice"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection not found, or a container not in it"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/container-templates",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("List container templates"),
+			endpoint.WithDescription("Returns the built-in container layouts followed by the user's saved templates."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New([]httpresp.ContainerTemplateResponse{}, "200", "Container templates"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/container-templates",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Save container template"),
+			endpoint.WithDescription("Saves a collection's container tree, or one container and its descendants when container_id is set, as a reusable template. Objects are not copied."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.SaveContainerTemplateRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ContainerTemplateResponse{}, "201", "Saved template"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request or empty/oversized tree"),
+				response.New(ErrorResponse{}, "404", "Collection or container not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/container-templates/{template_id}",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Delete container template"),
+			endpoint.WithDescription("Deletes one of the user's saved templates. Built-in templates cannot be deleted."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("template_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Template ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Template deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Built-in template"),
+				response.New(ErrorResponse{}, "404", "Template not found"),
 			}),
 		),
 		endpoint.New(
@@ -536,6 +1339,30 @@ func registerContainerEndpoints(sw *swagno.OpenAPI) {
 				response.New(ErrorResponse{}, "404", "Container not found"),
 			}),
 		),
+		endpoint.New(
+			endpoint.PATCH,
+			"/accounts/{id}/collections/{collection_id}/containers/{container_id}",
+			endpoint.WithTags("containers"),
+			endpoint.WithSummary("Patch container in collection"),
+			endpoint.WithDescription("Applies a JSON Merge Patch (RFC 7386) to a container within a collection; see PATCH /containers/{container_id}."),
+			endpoint.WithSecurity(authSecurity()),
+			mergePatchConsume(),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("container_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Container ID")),
+			),
+			endpoint.WithBody(request.PatchContainerRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ContainerResponse{}, "200", "Updated container"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Container not found"),
+				response.New(ErrorResponse{}, "415", "Body is not a JSON Merge Patch"),
+			}),
+		),
 		endpoint.New(
 			endpoint.DELETE,
 			"/accounts/{id}/collections/{collection_id}/containers/{container_id}",
@@ -569,11 +1396,14 @@ func registerObjectEndpoints(sw *swagno.OpenAPI) {
 			"/accounts/{id}/collections/{collection_id}/objects",
 			endpoint.WithTags("objects"),
 			endpoint.WithSummary("List collection objects"),
-			endpoint.WithDescription("Returns all objects within a collection."),
+			endpoint.WithDescription("Returns all objects within a collection. Archived objects are left out unless archived=true, which lists only archived objects. Repeating condition keeps the objects graded with any of the given conditions, and repeating status the objects with any of the given statuses."),
 			endpoint.WithSecurity(authSecurity()),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
 				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.BoolParam("archived", parameter.Query, parameter.WithDescription("List archived objects instead of active ones")),
+				parameter.StrParam("condition", parameter.Query, parameter.WithDescription("Only objects in this condition: mint, near_mint, good, fair or poor; repeatable")),
+				parameter.StrParam("status", parameter.Query, parameter.WithDescription("Only objects with this status: owned, wanted or ordered; repeatable")),
 			),
 			endpoint.WithSuccessfulReturns([]response.Response{
 				response.New(OpenAPIObjectListResponse{}, "200", "List of objects"),
@@ -600,7 +1430,7 @@ func registerObjectEndpoints(sw *swagno.OpenAPI) {
 			"/accounts/{id}/objects",
 			endpoint.WithTags("objects"),
 			endpoint.WithSummary("Create object"),
-			endpoint.WithDescription("Creates a new inventory object. object_type must be one of: food, book, videogame, music, boardgame, general. Properties is a free-form map of type-specific fields."),
+			endpoint.WithDescription("Creates a new inventory object. object_type must be a built-in type (food, book, videogame, music, boardgame, general) or the collection's own custom type. Properties is a free-form map of type-specific fields. min_quantity sets a restock threshold; objects at or below it are returned with low_stock and stock_status (low, or out when none are left). Books, video games, music and board games can be given a condition: mint, near_mint, good, fair or poor. A status of wanted or ordered puts the object on the collection's wishlist, leaving it out of counts and stats until it is owned."),
 			endpoint.WithSecurity(authSecurity()),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
@@ -611,6 +1441,7 @@ func registerObjectEndpoints(sw *swagno.OpenAPI) {
 			}),
 			endpoint.WithErrors([]response.Response{
 				response.New(ErrorResponse{}, "400", "Invalid request or object_type"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its object quota"),
 			}),
 		),
 		endpoint.New(
@@ -618,7 +1449,7 @@ func registerObjectEndpoints(sw *swagno.OpenAPI) {
 			"/accounts/{id}/objects/{object_id}",
 			endpoint.WithTags("objects"),
 			endpoint.WithSummary("Update object"),
-			endpoint.WithDescription("Updates an inventory object. container_id is required to locate the object."),
+			endpoint.WithDescription("Updates an inventory object. container_id is required to locate the object. A negative min_quantity removes the restock threshold and an empty condition removes the grade. Setting status to owned moves a wishlist item into the inventory."),
 			endpoint.WithSecurity(authSecurity()),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
@@ -634,62 +1465,2054 @@ func registerObjectEndpoints(sw *swagno.OpenAPI) {
 			}),
 		),
 		endpoint.New(
-			endpoint.DELETE,
+			endpoint.PATCH,
 			"/accounts/{id}/objects/{object_id}",
 			endpoint.WithTags("objects"),
-			endpoint.WithSummary("Delete object"),
-			endpoint.WithDescription("Deletes an inventory object."),
+			endpoint.WithSummary("Patch object"),
+			endpoint.WithDescription("Applies a JSON Merge Patch (RFC 7386): only the fields sent change and null removes a value. Properties are merged into the current ones, a null property removing it. Tags, when sent, replace the object's tags; container_id moves the object, also into another collection of the same object type, whose schema the properties are carried over to (those it has no place for are listed in dropped_properties). A null status resets it to owned. name and container_id cannot be removed."),
 			endpoint.WithSecurity(authSecurity()),
+			mergePatchConsume(),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
 				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
 			),
+			endpoint.WithBody(OpenAPIPatchObjectRequest{}),
 			endpoint.WithSuccessfulReturns([]response.Response{
-				response.New(httpresp.DeleteObjectResponse{}, "200", "Object deleted"),
+				response.New(OpenAPIUpdateObjectResponse{}, "200", "Updated object"),
 			}),
 			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
 				response.New(ErrorResponse{}, "404", "Object not found"),
+				response.New(ErrorResponse{}, "415", "Body is not a JSON Merge Patch"),
 			}),
 		),
-	})
-}
-
-// ============================================
-// IMPORT ENDPOINTS
-// ============================================
-
-func registerImportEndpoints(sw *swagno.OpenAPI) {
-	sw.AddEndpoints([]*endpoint.EndPoint{
 		endpoint.New(
-			endpoint.POST,
-			"/accounts/{id}/collections/{collection_id}/import",
-			endpoint.WithTags("import"),
-			endpoint.WithSummary("Bulk import objects to collection"),
-			endpoint.WithDescription("Imports multiple objects into an existing collection. distribution_mode controls container assignment: 'automatic' (auto-distribute), 'manual' (each item specifies container), 'target' (all to target_container_id). data is an array of objects where keys match the collection's object type fields."),
+			endpoint.PATCH,
+			"/accounts/{id}/objects/{object_id}/archive",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Archive or restore object"),
+			endpoint.WithDescription("Sets whether an object is archived. Archived objects are kept but left out of default lists and counts."),
 			endpoint.WithSecurity(authSecurity()),
 			endpoint.WithParams(
 				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
-				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
 			),
-			endpoint.WithBody(OpenAPIBulkImportCollectionRequest{}),
+			endpoint.WithBody(request.ArchiveObjectRequest{}),
 			endpoint.WithSuccessfulReturns([]response.Response{
-				response.New(httpresp.BulkImportResponse{}, "200", "Import results with counts and any errors"),
+				response.New(OpenAPIObjectResponse{}, "200", "Updated object"),
 			}),
 			endpoint.WithErrors([]response.Response{
-				response.New(ErrorResponse{}, "400", "Invalid format or data"),
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
 			}),
 		),
-	})
-}
-
-// ============================================
-// MCP X-EXTENSIONS
-// ============================================
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/{object_id}/copy",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Copy object"),
+			endpoint.WithDescription("Duplicates an object under a new ID, next to the original or into container_id. The container may be in another collection of the same object type: properties are carried over to its schema, matched by key or display name and converted to its types, and those it has no place for are listed in dropped_properties. The body is optional."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithBody(request.CopyObjectRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIObjectResponse{}, "201", "The copy"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid container_id or a collection of another object type"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its object quota"),
+				response.New(ErrorResponse{}, "404", "Object or container not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/{object_id}/claim",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Claim object"),
+			endpoint.WithDescription("Reserves an object for the signed-in member until the given time (default 24 hours, at most 30 days). The claim expires on its own; claiming again renews it. The body is optional."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithBody(request.ClaimObjectRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIObjectResponse{}, "200", "Claimed object"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid expiry or archived object"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+				response.New(ErrorResponse{}, "409", "Object is claimed by another member"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/objects/{object_id}/claim",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Release object claim"),
+			endpoint.WithDescription("Releases the claim on an object. Only the claimer or the collection owner can release an active claim."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIObjectResponse{}, "200", "Updated object"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Object not found"),
+				response.New(ErrorResponse{}, "409", "Object is claimed by another member"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/merge",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Merge objects"),
+			endpoint.WithDescription("Folds two or more objects of the same collection into the oldest one, which keeps its ID and created_at. Quantities are summed (converted to the survivor's unit), tags are unioned, and properties, description, image and expiry missing on the survivor are filled from the others. The other objects are deleted. With preview=true nothing is saved."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.MergeObjectsRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIMergeObjectsResponse{}, "200", "Merged object"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Fewer than two objects, different collections, archived objects or incompatible units"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/parse",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Parse a quick add phrase"),
+			endpoint.WithDescription("Reads the name, quantity, unit and place out of a spoken or typed phrase such as \"three cans of tomatoes in the pantry\". Numbers may be digits or words. With collection_id, the place is matched against the collection's containers and the best match returned as container_id. Nothing is saved; clients prefill their create form."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.ParseObjectRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ParsedObjectResponse{}, "200", "Parsed object fields"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Empty text or no object named"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/duplicates",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Find duplicate objects"),
+			endpoint.WithDescription("Groups the collection's active objects whose names match ignoring case and spacing. Only groups with two or more objects are returned."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIDuplicateGroupsResponse{}, "200", "Duplicate groups"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/objects/retag",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Add and remove tags in bulk"),
+			endpoint.WithDescription("Adds add_tags to and removes remove_tags from every active object of the collection matching the filter. Filter fields combine: tag, container_id, object_type (untyped objects count as the collection's type) and object_ids; an empty filter matches the whole collection. Every object is retagged before any is saved, so a rejected request changes nothing. Returns how many objects matched and how many changed."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.RetagObjectsRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RetagObjectsResponse{}, "200", "Matched and updated counts"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "No tags given, a tag both added and removed, or an invalid filter"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection or container not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/objects/batch",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Move or delete objects in bulk"),
+			endpoint.WithDescription("Applies operation to up to 500 objects of the collection named by object_ids. move puts them in container_id, which must be a container of the same collection, and records each move in the objects' history; delete removes them with their photos. Every object must be found in the collection before anything is saved, so a stale selection changes nothing. Returns how many objects changed; objects already in the target container do not count."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.BatchObjectsRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.BatchObjectsResponse{}, "200", "How many objects changed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Unknown operation, no objects, too many objects, or a move without container_id"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection, container or object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/lookup",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Find objects by barcode, ISBN or SKU"),
+			endpoint.WithDescription("Lists the account's active objects whose upc, ean, barcode, isbn or sku property matches the code, ignoring spaces, dashes and case. Clients call this after a scan to offer increasing the quantity of a known item instead of creating a duplicate. Objects are indexed per account as they are created, updated or imported."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("code", parameter.Query, parameter.WithRequired(), parameter.WithDescription("Barcode, ISBN or SKU, 4 to 32 letters or digits")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIObjectCodeLookupResponse{}, "200", "Objects carrying the code; empty when none do"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Missing or malformed code"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/expiry-suggestions",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Suggest expiry dates by category"),
+			endpoint.WithDescription("Lists, per category, how many days the account's objects usually keep: the median of the last 20 expiry dates set when creating objects of that category, taking the shorter of the middle two. An object's category is its category property, else its first tag, compared ignoring case. Dates filled in from a collection's shelf life are not counted. Clients use this to pre-fill a new object's expiry."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("category", parameter.Query, parameter.WithDescription("Only this category")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ExpirySuggestionsResponse{}, "200", "Suggestions by category; empty until expiry dates have been set"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/search",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Search objects"),
+			endpoint.WithDescription("Finds active objects in every collection the account can see whose name, tags, description, location or property values contain each whitespace-separated term of q, ignoring case. Name matches come first, then tags, description, location and properties, each by object name. Every match has a snippet of the best matching field with the matched terms given as byte ranges [start, end) for highlighting."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("q", parameter.Query, parameter.WithRequired(), parameter.WithDescription("Search terms")),
+				parameter.IntParam("limit", parameter.Query, parameter.WithDescription("Most matches to return, 1-100 (default 20)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.SearchResponse{}, "200", "Matching objects; empty when none match"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Empty query or invalid limit"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/expiring.ics",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Subscribe to expiry dates"),
+			endpoint.WithDescription("iCalendar (RFC 5545) feed with an all-day event on the expiry date of every active object the account can see that expires within days. Already expired objects are included. Event UIDs are object IDs, so calendar apps move an event when its expiry date changes."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithProduce([]mime.MIME{mime.MIME("text/calendar")}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.IntParam("days", parameter.Query, parameter.WithDescription("How many days ahead to include, 1-366 (default 90)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "iCalendar feed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid days"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/objects/{object_id}",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Delete object"),
+			endpoint.WithDescription("Deletes an inventory object."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.DeleteObjectResponse{}, "200", "Object deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/objects/{object_id}/history",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Get object location history"),
+			endpoint.WithDescription("Returns every container the object has been in, oldest first. The last entry has no until and is where the object is now."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ObjectHistoryResponse{}, "200", "Location timeline"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/objects/{object_id}/label",
+			endpoint.WithTags("objects"),
+			endpoint.WithSummary("Get printable object label"),
+			endpoint.WithDescription("Renders a label with the object's name, a QR code of its ID, its expiry and container path, sized to a Dymo or Brother label template. Without template the label_template from the user's preferences is used."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithProduce([]mime.MIME{mime.PDF, mime.MIME("image/png")}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+				parameter.StrParam("format", parameter.Query, parameter.WithDescription("pdf (default) or png")),
+				parameter.StrParam("template", parameter.Query, parameter.WithDescription("Label template: dymo-30252, dymo-30336, dymo-11354, brother-dk-11201, brother-dk-11204 or brother-dk-11209")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "Label file"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid format or template"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// IMPORT ENDPOINTS
+// ============================================
+
+func registerImportEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/import",
+			endpoint.WithTags("import"),
+			endpoint.WithSummary("Bulk import objects to collection"),
+			endpoint.WithDescription("Queues an import of multiple objects into an existing collection and answers 202 with a job to follow through GET /imports/{job_id} or its event stream; the counts and errors are in the job's result once it completes. Answers 503 with Retry-After when too many imports are already waiting. distribution_mode controls container assignment: 'automatic' (auto-distribute), 'manual' (each item specifies container), 'target' (all to target_container_id). data is an array of objects where keys match the collection's object type fields. source_format maps exports from other apps onto those fields: 'grocy' (Grocy stock/product JSON), 'pantry_csv' (pantry app CSV headers such as Qty and Expiration Date), 'auto' (detect), or 'generic' (default). Instead of parsed rows the file itself may be uploaded as multipart/form-data in the file field (at most 32 MiB), with the other options as form fields and an omit_columns field listing columns to leave out; the format defaults to the file's extension."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithConsume([]mime.MIME{mime.JSON, mime.MIME("multipart/form-data")}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(OpenAPIBulkImportCollectionRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ImportJobResponse{}, "202", "Import queued"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid format or data"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+				response.New(ErrorResponse{}, "503", "Too many imports in progress"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/imports/{job_id}",
+			endpoint.WithTags("import"),
+			endpoint.WithSummary("Get import job"),
+			endpoint.WithDescription("Returns a background import's status (queued, running, completed or failed), the rows handled so far out of the total, and once finished its result or error. Jobs are kept for 15 minutes after they finish and are only visible to the user who started them."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("job_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Import job ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ImportJobResponse{}, "200", "Import job"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Import job not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/imports/{job_id}/events",
+			endpoint.WithTags("import"),
+			endpoint.WithSummary("Stream import job progress"),
+			endpoint.WithDescription("Server-sent events for a background import. Each event's data is the job as returned by GET /imports/{job_id}: the current state first, a \"progress\" event as rows are handled, then one \"completed\" or \"failed\" event, after which the stream ends. Idle streams get a comment every 15 seconds."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithProduce([]mime.MIME{"text/event-stream"}),
+			endpoint.WithParams(
+				parameter.StrParam("job_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Import job ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "Event stream"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Import job not found"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// PREFERENCES ENDPOINTS
+// ============================================
+
+func registerPreferencesEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/preferences",
+			endpoint.WithTags("preferences"),
+			endpoint.WithSummary("Get view preferences"),
+			endpoint.WithDescription("Returns the user's saved sort, grouping and filter choices keyed by view, e.g. \"collection:<id>\". Users who never saved any get an empty views map."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.UserPreferencesResponse{}, "200", "View preferences"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/preferences",
+			endpoint.WithTags("preferences"),
+			endpoint.WithSummary("Update view preferences"),
+			endpoint.WithDescription("Merges the given views into the saved preferences. Views not in the body are kept; a null or empty preference forgets the view. Sort directions are asc or desc, with at most 5 sort fields and 20 filters per view. label_template sets the label stock object labels are printed on; an empty string resets it. unit_system is metric or imperial and picks the units the app shows quantities in; an empty string resets it to metric."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.UpdateUserPreferencesRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.UserPreferencesResponse{}, "200", "Updated view preferences"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid view key or preference"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PATCH,
+			"/accounts/{id}/collections/order",
+			endpoint.WithTags("preferences"),
+			endpoint.WithSummary("Pin and reorder collections"),
+			endpoint.WithDescription("Merges collection placements into the user's saved order. Pinned collections come first in every collection listing; sort=custom orders the rest by sort_index (starting at 1), with unplaced collections last. sort_index 0 with pinned false forgets a collection."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.UpdateItemOrderRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.UserPreferencesResponse{}, "200", "Updated preferences"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid item ID or sort index"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PATCH,
+			"/accounts/{id}/containers/order",
+			endpoint.WithTags("preferences"),
+			endpoint.WithSummary("Pin and reorder containers"),
+			endpoint.WithDescription("Merges container placements into the user's saved order. Pinned containers come first when listing a collection's containers; sort=custom orders the rest by sort_index (starting at 1), with unplaced containers last. sort_index 0 with pinned false forgets a container."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.UpdateItemOrderRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.UserPreferencesResponse{}, "200", "Updated preferences"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid item ID or sort index"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// DIGEST ENDPOINTS
+// ============================================
+
+func registerDigestEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/digest-preferences",
+			endpoint.WithTags("digest"),
+			endpoint.WithSummary("Get digest preferences"),
+			endpoint.WithDescription("Returns the user's digest frequency and delivery address. Users who never saved preferences get frequency 'off' and their account email."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.DigestPreferencesResponse{}, "200", "Digest preferences"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/digest-preferences",
+			endpoint.WithTags("digest"),
+			endpoint.WithSummary("Update digest preferences"),
+			endpoint.WithDescription("Sets the digest frequency (off, daily, weekly). Digests are sent to the account's email address, which is also updated here if it changed. Also refreshes the group memberships used for the recent-activity section."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.UpdateDigestPreferencesRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.DigestPreferencesResponse{}, "200", "Updated digest preferences"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid frequency"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/digest/unsubscribe",
+			endpoint.WithTags("digest"),
+			endpoint.WithSummary("Confirm unsubscribing from digest"),
+			endpoint.WithDescription("The unsubscribe link in digest emails. Returns an HTML page asking for confirmation and changes nothing, so link scanners that follow it do not unsubscribe anyone; the page's form POSTs to the same path. No authentication required."),
+			endpoint.WithProduce([]mime.MIME{mime.HTML}),
+			endpoint.WithParams(
+				parameter.StrParam("token", parameter.Query, parameter.WithRequired(), parameter.WithDescription("Unsubscribe token from the digest email")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "HTML confirmation page"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Missing token"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/digest/unsubscribe",
+			endpoint.WithTags("digest"),
+			endpoint.WithSummary("Unsubscribe from digest"),
+			endpoint.WithDescription("Turns off the digest identified by the token and returns an HTML page saying so. Posted by the confirmation page's form, or by mail clients as RFC 8058 one-click unsubscribe with the token in the query and a List-Unsubscribe=One-Click body. No authentication required."),
+			endpoint.WithConsume([]mime.MIME{mime.MIME("application/x-www-form-urlencoded")}),
+			endpoint.WithProduce([]mime.MIME{mime.HTML}),
+			endpoint.WithParams(
+				parameter.StrParam("token", parameter.Query, parameter.WithDescription("Unsubscribe token from the digest email; may be sent as a form field instead")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "200", "HTML unsubscribed page"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Missing token"),
+				response.New(ErrorResponse{}, "404", "Unknown unsubscribe token"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// BACKUP ENDPOINTS
+// ============================================
+
+func registerBackupEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/backup",
+			endpoint.WithTags("backup"),
+			endpoint.WithSummary("Download account backup"),
+			endpoint.WithDescription("Returns every collection, container and object the user owns, plus account settings, as a downloadable archive. Group collections owned by other users are not included."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("format", parameter.Query, parameter.WithDescription("json (default) or tar.gz (a gzipped tarball containing backup.json)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.BackupArchive{}, "200", "Backup archive"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/restore",
+			endpoint.WithTags("backup"),
+			endpoint.WithSummary("Restore account backup"),
+			endpoint.WithDescription("Imports a backup archive (JSON or tar.gz). strategy controls collections that already exist: skip (default) leaves them untouched, overwrite replaces them with the archived copy, merge adds archived containers and objects that are missing."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("strategy", parameter.Query, parameter.WithDescription("skip, overwrite or merge")),
+			),
+			endpoint.WithBody(httpresp.BackupArchive{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RestoreResponse{}, "200", "Restore summary"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid archive or strategy"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// COLLECTION FOLDER ENDPOINTS
+// ============================================
+
+func registerCollectionFolderEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collection-folders",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("List collection folders"),
+			endpoint.WithDescription("Returns the user's folders ordered by name, each with the IDs of the collections filed in it. Nesting is given by parent_id. Folders are personal: members of a shared collection each file it their own way."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionFolderListResponse{}, "200", "Folders"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collection-folders",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("Create collection folder"),
+			endpoint.WithDescription("Creates a folder, at the top level or inside parent_id. Folders nest at most five deep."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.CreateCollectionFolderRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionFolderResponse{}, "201", "Created folder"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid name or nesting too deep"),
+				response.New(ErrorResponse{}, "404", "Parent folder not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/collection-folders/{folder_id}",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("Update collection folder"),
+			endpoint.WithDescription("Renames a folder or moves it into another folder. An empty parent_id moves it to the top level. A folder cannot be moved into one of its own subfolders."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("folder_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Folder ID")),
+			),
+			endpoint.WithBody(request.UpdateCollectionFolderRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionFolderResponse{}, "200", "Updated folder"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid name, cycle or nesting too deep"),
+				response.New(ErrorResponse{}, "404", "Folder or parent folder not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/collection-folders/{folder_id}",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("Delete collection folder"),
+			endpoint.WithDescription("Deletes a folder. Its subfolders and collections move up into its parent, or to the top level; no collection is deleted."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("folder_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Folder ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Folder deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Folder not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/collection-folders/{folder_id}/collections/{collection_id}",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("Move collection into folder"),
+			endpoint.WithDescription("Files a collection the user owns or shares into the folder. A collection is in at most one folder per user, so it leaves any other folder."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("folder_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Folder ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionFolderResponse{}, "200", "Folder with the collection"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Folder or collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/collection-folders/{folder_id}/collections/{collection_id}",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("Take collection out of folder"),
+			endpoint.WithDescription("Moves a collection out of the folder, back to the top level of the user's list."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("folder_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Folder ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionFolderResponse{}, "200", "Folder without the collection"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Folder not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collection-folders/{folder_id}/stats",
+			endpoint.WithTags("collection-folders"),
+			endpoint.WithSummary("Get folder stats"),
+			endpoint.WithDescription("Totals the collections in the folder and all its subfolders: containers, objects, low stock, objects expiring within seven days and expired ones. Archived objects and collections no longer shared with the user are left out."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("folder_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Folder ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionFolderStatsResponse{}, "200", "Folder stats"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Folder not found"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// OBJECT TYPE ENDPOINTS
+// ============================================
+
+func registerObjectTypeEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/object-types",
+			endpoint.WithTags("object-types"),
+			endpoint.WithSummary("List object types"),
+			endpoint.WithDescription("Returns the six built-in object types followed by the user's custom types ordered by name. Built-in types are marked built_in and have no ID, icon or color."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ObjectTypeListResponse{}, "200", "Object types"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/object-types",
+			endpoint.WithTags("object-types"),
+			endpoint.WithSummary("Create custom object type"),
+			endpoint.WithDescription("Defines an object type collections can be created with. key is derived from name when left out and cannot change later. Collections created with the type start with its schema unless they send their own."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.CreateCustomObjectTypeRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ObjectTypeResponse{}, "201", "Created object type"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid key, name, icon or color, or a built-in key"),
+				response.New(ErrorResponse{}, "409", "A type with the key already exists"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/object-types/{type_id}",
+			endpoint.WithTags("object-types"),
+			endpoint.WithSummary("Update custom object type"),
+			endpoint.WithDescription("Changes the name, icon, color or schema of a custom type. Collections already created with the type keep their schema."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("type_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object type ID")),
+			),
+			endpoint.WithBody(request.UpdateCustomObjectTypeRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ObjectTypeResponse{}, "200", "Updated object type"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid name, icon or color"),
+				response.New(ErrorResponse{}, "404", "Object type not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/object-types/{type_id}",
+			endpoint.WithTags("object-types"),
+			endpoint.WithSummary("Delete custom object type"),
+			endpoint.WithDescription("Deletes a custom type. Types still used by one of the user's collections are kept."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("type_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object type ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Object type deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Object type not found"),
+				response.New(ErrorResponse{}, "409", "A collection still uses the type"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// MEAL PLAN ENDPOINTS
+// ============================================
+
+func registerMealPlanEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/meal-plans",
+			endpoint.WithTags("meals"),
+			endpoint.WithSummary("List meal plans"),
+			endpoint.WithDescription("Returns planned meals dated on or after from and before to, ordered by day and meal. Without parameters the current Monday-to-Sunday week is returned; from alone covers seven days. At most 92 days can be listed at once."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("from", parameter.Query, parameter.WithDescription("First day (YYYY-MM-DD)")),
+				parameter.StrParam("to", parameter.Query, parameter.WithDescription("Day after the last day (YYYY-MM-DD)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MealPlanListResponse{}, "200", "Meal plans in the range"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid or too long date range"),
+				response.New(ErrorResponse{}, "404", "Meal planner not enabled"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/meal-plans",
+			endpoint.WithTags("meals"),
+			endpoint.WithSummary("Create meal plan"),
+			endpoint.WithDescription("Plans a breakfast, lunch, dinner or snack on a day. Each ingredient links a food object and the quantity the recipe uses; unit defaults to the object's unit."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.CreateMealPlanRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MealPlanResponse{}, "201", "Created meal plan"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Ingredient object not found or meal planner not enabled"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/meal-plans/{meal_plan_id}",
+			endpoint.WithTags("meals"),
+			endpoint.WithSummary("Update meal plan"),
+			endpoint.WithDescription("Changes the day, meal, recipe name or ingredients of a plan that has not been completed. A present ingredients list replaces the existing one."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("meal_plan_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Meal plan ID")),
+			),
+			endpoint.WithBody(request.UpdateMealPlanRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MealPlanResponse{}, "200", "Updated meal plan"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Meal plan or ingredient object not found, or meal planner not enabled"),
+				response.New(ErrorResponse{}, "409", "Meal plan already completed"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/meal-plans/{meal_plan_id}",
+			endpoint.WithTags("meals"),
+			endpoint.WithSummary("Delete meal plan"),
+			endpoint.WithDescription("Deletes a meal plan. Quantities used up by completing it are not restored."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("meal_plan_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Meal plan ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Meal plan deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Meal plan not found or meal planner not enabled"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/meal-plans/{meal_plan_id}/complete",
+			endpoint.WithTags("meals"),
+			endpoint.WithSummary("Complete meal plan"),
+			endpoint.WithDescription("Marks the meal as cooked and subtracts each ingredient's quantity from its object, converting units like adjust_quantity. Ingredients that cannot be adjusted are listed under skipped."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("meal_plan_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Meal plan ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPICompleteMealPlanResponse{}, "200", "Completed meal plan and adjusted objects"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Meal plan not found or meal planner not enabled"),
+				response.New(ErrorResponse{}, "409", "Meal plan already completed"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// INBOX ENDPOINTS
+// ============================================
+
+func registerInboxEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/inbox",
+			endpoint.WithTags("inbox"),
+			endpoint.WithSummary("List inbox items"),
+			endpoint.WithDescription("Returns the forwarded emails waiting to be confirmed or dismissed, newest first. Each has the lines read from its plain-text body: a name and, where the email gave them, a quantity, unit and price."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.InboxItemListResponse{}, "200", "Pending inbox items"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/inbox/address",
+			endpoint.WithTags("inbox"),
+			endpoint.WithSummary("Get inbox address"),
+			endpoint.WithDescription("Returns the user's private forwarding address, creating it on first use. enabled is false, with no address, when the server has no inbox configured."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.InboxAddressResponse{}, "200", "Forwarding address"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/inbox/address/reset",
+			endpoint.WithTags("inbox"),
+			endpoint.WithSummary("Reset inbox address"),
+			endpoint.WithDescription("Gives the user a new forwarding address. Mail to the old one is refused from then on; items already received stay."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.InboxAddressResponse{}, "200", "New forwarding address"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Inbox not enabled on this server"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/inbox/{item_id}/confirm",
+			endpoint.WithTags("inbox"),
+			endpoint.WithSummary("Confirm inbox item"),
+			endpoint.WithDescription("Creates an object for each line in container_id, or the collection's default container when it is left out. lines replaces the received lines, so they can be corrected and unwanted ones left out. A line's price is stored in the collection's first currency property. Once every line is created the item leaves the inbox; lines that fail stay in it and are listed under failed. When no line can be created the first failure is returned as the error."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("item_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Inbox item ID")),
+			),
+			endpoint.WithBody(request.ConfirmInboxItemRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPIConfirmInboxItemResponse{}, "200", "Created objects and failed lines"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its object quota"),
+				response.New(ErrorResponse{}, "404", "Inbox item, collection or container not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/inbox/{item_id}",
+			endpoint.WithTags("inbox"),
+			endpoint.WithSummary("Dismiss inbox item"),
+			endpoint.WithDescription("Removes a forwarded email from the inbox without creating anything."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("item_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Inbox item ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Inbox item dismissed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Inbox item not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/inbox/email",
+			endpoint.WithTags("inbox"),
+			endpoint.WithSummary("Receive forwarded email"),
+			endpoint.WithDescription("Webhook for a Mailgun inbound route, posted as multipart/form-data or a urlencoded form. No authentication required; the timestamp, token and signature fields must carry a valid Mailgun signature no more than five minutes old. The +token of the recipient picks the user and the body-plain field is read for items. 406 marks mail that will never be accepted, so Mailgun stops retrying it: an unknown address, no items recognised, or a full inbox."),
+			endpoint.WithConsume([]mime.MIME{mime.MIME("multipart/form-data"), mime.MIME("application/x-www-form-urlencoded")}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(map[string]string{}, "200", "Email filed in the inbox"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "401", "Missing, stale or invalid signature"),
+				response.New(ErrorResponse{}, "404", "Inbox not enabled on this server"),
+				response.New(ErrorResponse{}, "406", "Unknown address, no items recognised, or inbox full"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// RECURRENCE ENDPOINTS
+// ============================================
+
+func registerRecurrenceEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/recurrences",
+			endpoint.WithTags("recurrences"),
+			endpoint.WithSummary("List recurring staples"),
+			endpoint.WithDescription("Returns the user's recurrence rules, soonest due first. due=true keeps only staples waiting to be bought, which is the shopping list; object_id keeps only that object's rule."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.BoolParam("due", parameter.Query, parameter.WithDescription("Only staples that are due")),
+				parameter.StrParam("object_id", parameter.Query, parameter.WithDescription("Only this object's rule")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RecurrenceRuleListResponse{}, "200", "Recurrence rules"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid object ID"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/recurrences",
+			endpoint.WithTags("recurrences"),
+			endpoint.WithSummary("Create recurrence rule"),
+			endpoint.WithDescription("Puts the object back on the shopping list every so many days, weeks or months (period day, week or month), whether or not its stock was counted down. The first round comes due one period from now. quantity is how much to buy, in the object's unit."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.CreateRecurrenceRuleRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RecurrenceRuleResponse{}, "201", "Created recurrence rule"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+				response.New(ErrorResponse{}, "409", "Object already has a recurrence rule"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/recurrences/{recurrence_id}",
+			endpoint.WithTags("recurrences"),
+			endpoint.WithSummary("Update recurrence rule"),
+			endpoint.WithDescription("Changes the schedule (every and period together) or the amount to buy (0 clears it), or with bought=true takes a due staple off the shopping list until it next comes due. A changed schedule is counted from now."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("recurrence_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Recurrence rule ID")),
+			),
+			endpoint.WithBody(request.UpdateRecurrenceRuleRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RecurrenceRuleResponse{}, "200", "Updated recurrence rule"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Recurrence rule not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/recurrences/{recurrence_id}",
+			endpoint.WithTags("recurrences"),
+			endpoint.WithSummary("Delete recurrence rule"),
+			endpoint.WithDescription("Stops the staple from recurring. The object is kept."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("recurrence_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Recurrence rule ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Recurrence rule deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Recurrence rule not found"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// AUTOMATION ENDPOINTS
+// ============================================
+
+func registerAutomationEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/automations",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("List automation rules"),
+			endpoint.WithDescription("Returns the user's automation rules, oldest first. Webhook secrets are left out; has_secret says whether one is set."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.AutomationRuleListResponse{}, "200", "Automation rules"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/automations",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("Create automation rule"),
+			endpoint.WithDescription("Runs the rule's actions on each of the user's objects its trigger fires for. Triggers are object_created, tag_added (tag), quantity_below (threshold; fires when the quantity drops under it) and expires_within (days; fires once as the object comes that close to expiring). Actions are add_tag (tag), move_to_container (container_id, in the same collection), add_to_shopping_list and fire_webhook (url; optional secret to sign the body with, otherwise the account's signing key signs it; max_retries up to 10 and retry_backoff in seconds, doubling after each retry). trigger.collection_id limits the rule to one collection. Up to 5 actions per rule and 50 rules per user."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.AutomationRuleRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.AutomationRuleResponse{}, "201", "Created automation rule"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Collection or container not found"),
+				response.New(ErrorResponse{}, "409", "Too many automation rules"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/accounts/{id}/automations/{rule_id}",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("Update automation rule"),
+			endpoint.WithDescription("Replaces the rule's name, trigger and actions, and turns it on or off. A webhook sent without a secret keeps the one it had for the same URL."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("rule_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Automation rule ID")),
+			),
+			endpoint.WithBody(request.AutomationRuleRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.AutomationRuleResponse{}, "200", "Updated automation rule"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid request"),
+				response.New(ErrorResponse{}, "404", "Automation rule not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/automations/{rule_id}",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("Delete automation rule"),
+			endpoint.WithDescription("Deletes the rule. What it already did is kept."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("rule_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Automation rule ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Automation rule deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Automation rule not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/webhooks/signing-key",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("Get webhook signing key"),
+			endpoint.WithDescription("Says whether the account has a key for signing the webhooks of rules without a secret of their own, when it was made and until when the key it replaced still signs. The key itself is only returned by the rotation that makes it; hint is its last characters."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.WebhookSigningKeyResponse{}, "200", "Webhook signing key"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/webhooks/signing-key/rotate",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("Rotate webhook signing key"),
+			endpoint.WithDescription("Makes a new signing key and returns it; it is not shown again. The old key keeps signing alongside it for grace_hours (default 24, at most 168): X-Nishiki-Signature then carries one sha256= signature per key, comma separated. The first rotation creates the account's key."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithBody(request.RotateWebhookKeyRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.WebhookSigningKeyResponse{}, "200", "New webhook signing key"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid grace period"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/webhooks/deliveries",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("List webhook deliveries"),
+			endpoint.WithDescription("Returns the last 50 webhooks the user's automation rules sent, newest first, with their status (pending, succeeded or failed), attempts, last error and body. Finished deliveries are kept for 30 days."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.WebhookDeliveryListResponse{}, "200", "Webhook deliveries"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/webhooks/deliveries/{delivery_id}/retry",
+			endpoint.WithTags("automations"),
+			endpoint.WithSummary("Resend webhook delivery"),
+			endpoint.WithDescription("Posts the delivery's body to its URL once more, now, and returns the delivery with the attempt recorded. A failed send shows in the delivery's status rather than as an error."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("delivery_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Webhook delivery ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.WebhookDeliveryResponse{}, "200", "Webhook delivery"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "404", "Webhook delivery not found"),
+				response.New(ErrorResponse{}, "503", "Webhooks not available on this server"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// SNAPSHOT ENDPOINTS
+// ============================================
+
+func registerSnapshotEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/snapshots",
+			endpoint.WithTags("snapshots"),
+			endpoint.WithSummary("List collection snapshots"),
+			endpoint.WithDescription("Returns the collection's snapshots, newest first. limit is how many are kept before the oldest are dropped; 0 means unlimited."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionSnapshotListResponse{}, "200", "Snapshots of the collection"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not the collection owner"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/snapshots",
+			endpoint.WithTags("snapshots"),
+			endpoint.WithSummary("Snapshot collection"),
+			endpoint.WithDescription("Captures the collection's containers and objects as they are now. Once the configured limit is reached the oldest snapshots are dropped. The body is optional."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.CreateCollectionSnapshotRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CollectionSnapshotResponse{}, "201", "Created snapshot"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Label too long"),
+				response.New(ErrorResponse{}, "403", "Not the collection owner"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}/diff",
+			endpoint.WithTags("snapshots"),
+			endpoint.WithSummary("Compare snapshot"),
+			endpoint.WithDescription("Lists containers and objects added, removed or changed since the snapshot. Compares against the current state unless against names a later snapshot. An object moved between containers is reported as changed with the container field."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("snapshot_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Snapshot ID")),
+				parameter.StrParam("against", parameter.Query, parameter.WithDescription("Snapshot ID to compare with, or current (default)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(OpenAPICollectionSnapshotDiffResponse{}, "200", "Differences"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid snapshot ID"),
+				response.New(ErrorResponse{}, "403", "Not the collection owner"),
+				response.New(ErrorResponse{}, "404", "Collection or snapshot not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}/restore",
+			endpoint.WithTags("snapshots"),
+			endpoint.WithSummary("Restore snapshot"),
+			endpoint.WithDescription("Replaces the collection's containers and objects with the snapshot's copy. The current state is snapshotted first and returned as backup. Containers and objects that have since moved to another collection are left there and listed under skipped."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("snapshot_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Snapshot ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.RestoreCollectionSnapshotResponse{}, "200", "Restore summary"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(httpresp.QuotaExceededResponse{}, "403", "Not the collection owner, or the restore would take its group past a quota"),
+				response.New(ErrorResponse{}, "404", "Collection or snapshot not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}",
+			endpoint.WithTags("snapshots"),
+			endpoint.WithSummary("Delete snapshot"),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("snapshot_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Snapshot ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Snapshot deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not the collection owner"),
+				response.New(ErrorResponse{}, "404", "Collection or snapshot not found"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// MEDIA ENDPOINTS
+// ============================================
+
+func registerMediaEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/media",
+			endpoint.WithTags("media"),
+			endpoint.WithSummary("List collection photos"),
+			endpoint.WithDescription("Returns the photos of every object and container in the collection, newest first. Use thumbnail_url for galleries and url for the full image."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.IntParam("limit", parameter.Query, parameter.WithDescription("Photos per page, 1-100 (default 24)")),
+				parameter.IntParam("offset", parameter.Query, parameter.WithDescription("Photos to skip")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MediaListResponse{}, "200", "One page of photos"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid limit or offset"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/containers/{container_id}/media",
+			endpoint.WithTags("media"),
+			endpoint.WithSummary("List container photos"),
+			endpoint.WithDescription("Returns the photos attached to the container, newest first."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("container_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Container ID")),
+				parameter.IntParam("limit", parameter.Query, parameter.WithDescription("Photos per page, 1-100 (default 24)")),
+				parameter.IntParam("offset", parameter.Query, parameter.WithDescription("Photos to skip")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MediaListResponse{}, "200", "One page of photos"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid limit or offset"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Container not found or not in the collection"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/containers/{container_id}/media",
+			endpoint.WithTags("media"),
+			endpoint.WithSummary("Upload container photos"),
+			endpoint.WithDescription("Attaches photos to the container. Send multipart/form-data with one or more files fields (at most 20); JPEG, PNG and GIF are accepted. Each file is saved on its own: one that is too large or not an image is listed under failed without stopping the rest. The batch is refused when it would take the collection's group past its storage quota, or the container past the configured photo limit."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+				parameter.StrParam("container_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Container ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MediaUploadResponse{}, "201", "Saved and rejected photos"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "No photo could be saved, or the photo limit would be exceeded"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its storage quota"),
+				response.New(ErrorResponse{}, "404", "Container not found or not in the collection"),
+				response.New(ErrorResponse{}, "413", "Upload too large"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/objects/{object_id}/media",
+			endpoint.WithTags("media"),
+			endpoint.WithSummary("List object photos"),
+			endpoint.WithDescription("Returns the photos attached to the object, newest first."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+				parameter.IntParam("limit", parameter.Query, parameter.WithDescription("Photos per page, 1-100 (default 24)")),
+				parameter.IntParam("offset", parameter.Query, parameter.WithDescription("Photos to skip")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MediaListResponse{}, "200", "One page of photos"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid limit or offset"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/{object_id}/media",
+			endpoint.WithTags("media"),
+			endpoint.WithSummary("Upload object photos"),
+			endpoint.WithDescription("Attaches photos to the object. Send multipart/form-data with one or more files fields (at most 20); JPEG, PNG and GIF are accepted. Each file is saved on its own: one that is too large or not an image is listed under failed without stopping the rest. The batch is refused when it would take the collection's group past its storage quota, or the object past the configured photo limit."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.MediaUploadResponse{}, "201", "Saved and rejected photos"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "No photo could be saved, or the photo limit would be exceeded"),
+				response.New(httpresp.QuotaExceededResponse{}, "403", "No access to the collection, or its group is at its storage quota"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+				response.New(ErrorResponse{}, "413", "Upload too large"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/media/{media_id}",
+			endpoint.WithTags("media"),
+			endpoint.WithSummary("Delete photo"),
+			endpoint.WithDescription("Removes the photo and its thumbnail. Any member of the collection may delete it."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("media_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Photo ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Photo deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Photo not found"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// COMMENT ENDPOINTS
+// ============================================
+
+func registerCommentEndpoints(sw *swagno.OpenAPI) {
+	pageParams := []*parameter.Parameter{
+		parameter.IntParam("limit", parameter.Query, parameter.WithDescription("Comments per page, 1-100 (default 50)")),
+		parameter.StrParam("before", parameter.Query, parameter.WithDescription("Only comments posted before this RFC 3339 time; pass the oldest created_at shown to load the next page")),
+	}
+
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/comments",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("List collection comments"),
+			endpoint.WithDescription("Returns the comments on the collection and on its objects, newest first. Comments on an object carry its object_id."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(append([]*parameter.Parameter{
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			}, pageParams...)...),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CommentListResponse{}, "200", "One page of comments"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid limit or before"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/comments",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("Comment on collection"),
+			endpoint.WithDescription("Posts a note for everyone who can see the collection. @username mentions of the owner or group members are listed in mentions; other @words stay plain text."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.CreateCommentRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CommentResponse{}, "201", "Created comment"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Empty comment or longer than 2000 characters"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/collections/{collection_id}/comments/read",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("Mark comments read"),
+			endpoint.WithDescription("Clears the collection's unread count for the user up to read_at, or up to now when the body is omitted. A marker never moves back."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithBody(request.MarkCommentsReadRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Marked read"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Collection not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/objects/{object_id}/comments",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("List object comments"),
+			endpoint.WithDescription("Returns the comments on the object, newest first."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(append([]*parameter.Parameter{
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			}, pageParams...)...),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CommentListResponse{}, "200", "One page of comments"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid limit or before"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/{object_id}/comments",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("Comment on object"),
+			endpoint.WithDescription("Posts a note about one object, such as \"buy more of this brand\". It also appears in the collection's comments."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithBody(request.CreateCommentRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.CommentResponse{}, "201", "Created comment"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Empty comment or longer than 2000 characters"),
+				response.New(ErrorResponse{}, "403", "No access to the collection"),
+				response.New(ErrorResponse{}, "404", "Object not found"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/comments/unread",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("Unread comment counts"),
+			endpoint.WithDescription("Lists the user's collections with comments by others posted since they last marked them read, with how many of those mention the user."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.UnreadCommentsResponse{}, "200", "Unread counts"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Path user is not the caller"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/accounts/{id}/comments/{comment_id}",
+			endpoint.WithTags("comments"),
+			endpoint.WithSummary("Delete comment"),
+			endpoint.WithDescription("The author may delete their comment; the collection owner, or any member of an owning group, may delete any comment on it."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("comment_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Comment ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Comment deleted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Neither the author nor the collection owner"),
+				response.New(ErrorResponse{}, "404", "Comment not found"),
+			}),
+		),
+	})
+}
 
-type mcpTool struct {
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	InputFields map[string]string `json:"input_fields,omitempty"`
+// ============================================
+// NUTRITION ENDPOINTS
+// ============================================
+
+func registerNutritionEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/collections/{collection_id}/nutrition",
+			endpoint.WithTags("nutrition"),
+			endpoint.WithSummary("Get pantry nutrition totals"),
+			endpoint.WithDescription("Sums calories, protein, carbs and fat over the collection's active food objects: per-serving values × servings × quantity. Expired objects are skipped and counted in expired_skipped. by_container lists calories per container, most first."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("collection_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Collection ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.NutritionStatsResponse{}, "200", "Nutrition totals"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Access denied"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/accounts/{id}/objects/{object_id}/nutrition",
+			endpoint.WithTags("nutrition"),
+			endpoint.WithSummary("Look up object nutrition facts"),
+			endpoint.WithDescription("Looks up the food object's UPC on OpenFoodFacts and sets its calories, protein_g, carbs_g, fat_g and serving_size properties. Send an empty upc to use the object's upc property. Returns 503 when nutrition lookup is not enabled."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("object_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Object ID")),
+			),
+			endpoint.WithBody(request.EnrichNutritionRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ObjectResponse{}, "200", "Object with nutrition facts"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Not a food object, or missing or invalid UPC"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+				response.New(ErrorResponse{}, "404", "Object not found, or no nutrition facts for the UPC"),
+				response.New(ErrorResponse{}, "502", "OpenFoodFacts lookup failed"),
+				response.New(ErrorResponse{}, "503", "Nutrition lookup not enabled"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// REPORT ENDPOINTS
+// ============================================
+
+func registerReportEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/reports/valuation",
+			endpoint.WithTags("reports"),
+			endpoint.WithSummary("Get inventory valuation report"),
+			endpoint.WithDescription("Totals the value of the user's owned, active objects across every collection they can see. An object is worth the value of its collection's price field, the first currency property in the schema (or found on its objects when there is no schema), as the collection report counts it. Amounts are kept apart by currency: totals has one entry per currency, and by_collection, by_object_type, by_tag and by_condition one per group and currency, highest first. An object counts toward each of its tags; untagged objects have an empty key. by_condition covers the graded types only. unvalued counts the objects without a price. format=csv returns the same figures as breakdown,key,name,currency,objects,total rows."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithProduce([]mime.MIME{mime.JSON, mime.MIME("text/csv")}),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.StrParam("format", parameter.Query, parameter.WithDescription("json (default) or csv")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.ValuationReportResponse{}, "200", "Valuation report"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid format"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/accounts/{id}/reports/purchases",
+			endpoint.WithTags("reports"),
+			endpoint.WithSummary("Get food purchase history"),
+			endpoint.WithDescription("Sums what the food collections the user can see took in over the last days days: the owned objects added, archived ones included, and the quantity changes made to their objects. Objects are priced like the valuation report, by their collection's price field. An object's category is its category property, else its first tag, lowercased; empty when it has neither. totals has the spend per currency and by_category per category and currency, highest first; purchases lists the objects added, newest first, at most 200; priced and unpriced count them with and without a price. adjustments totals the quantity changes per category and unit: restocked sums the increases and used the decreases. Changes are recorded as they are made, so the window cannot reach back before the history started."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Account/User ID")),
+				parameter.IntParam("days", parameter.Query, parameter.WithDescription("Days to look back (default 30, at most 365)")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.PurchaseHistoryResponse{}, "200", "Purchase history"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid days"),
+				response.New(ErrorResponse{}, "403", "Access denied"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// CLIENT ERROR ENDPOINTS
+// ============================================
+
+func registerClientErrorEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.POST,
+			"/client-errors",
+			endpoint.WithTags("client-errors"),
+			endpoint.WithSummary("Report a frontend error"),
+			endpoint.WithDescription("Records a recovered panic (kind panic) or failed API call (kind api) from a frontend with error reporting turned on. Reports are logged grouped by fingerprint; repeats within a minute are counted, not logged. Authentication is optional: with a valid token the report is attributed to its user."),
+			endpoint.WithBody(request.ClientErrorRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(map[string]string{}, "202", "Report accepted"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid report"),
+				response.New(ErrorResponse{}, "413", "Report too large"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// ADMIN ENDPOINTS
+// ============================================
+
+func registerAdminEndpoints(sw *swagno.OpenAPI) {
+	userIDParam := parameter.StrParam("user_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("User ID"))
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/admin/users",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("List users"),
+			endpoint.WithDescription("Lists every user most recently seen first, with the collections they own and the containers, objects and photos in them. Users who own data but have not signed in since account tracking began come last with only their ID. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.AdminUserListResponse{}, "200", "Users and their usage"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/admin/stats",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Get system stats"),
+			endpoint.WithDescription("Totals users, disabled users, collections, containers, objects and photo storage across the deployment. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.SystemStatsResponse{}, "200", "System stats"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/admin/users/{user_id}/disable",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Disable a user"),
+			endpoint.WithDescription("Refuses the user's requests, over HTTP and MCP, with 403 \"account disabled\" within a minute. Their data is kept. Admins cannot disable themselves. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(userIDParam),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "User disabled"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid user ID or disabling yourself"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/admin/users/{user_id}/enable",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Re-enable a user"),
+			endpoint.WithDescription("Lets a disabled user back in. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(userIDParam),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "User enabled"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid user ID"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/admin/oauth-clients",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("List registered OAuth clients"),
+			endpoint.WithDescription("Lists the OAuth clients registered through the API, oldest first. Clients from config.toml are not listed and secrets are never returned. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.OAuthClientListResponse{}, "200", "Registered clients"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/admin/oauth-clients",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Register an OAuth client"),
+			endpoint.WithDescription("Registers an OAuth client alongside those in config.toml. The instance serving the request accepts its tokens and sign-ins straight away; other instances reload registered clients every minute. The provider's discovery document is fetched before the client is stored. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithBody(request.RegisterOAuthClientRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.OAuthClientResponse{}, "201", "Client registered"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Missing fields or relative redirect URL"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+				response.New(ErrorResponse{}, "409", "Client ID already registered or in config.toml"),
+				response.New(ErrorResponse{}, "501", "Local auth mode has no OAuth clients"),
+				response.New(ErrorResponse{}, "502", "Provider discovery failed"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/admin/oauth-clients/{client_id}",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Update a registered OAuth client"),
+			endpoint.WithDescription("Replaces a registered client's provider name, redirect URL and secret; an empty client_secret keeps the current one. Takes effect without a restart. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("client_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Client ID")),
+			),
+			endpoint.WithBody(request.UpdateOAuthClientRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.OAuthClientResponse{}, "200", "Client updated"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Missing fields or relative redirect URL"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+				response.New(ErrorResponse{}, "404", "Client not registered"),
+				response.New(ErrorResponse{}, "502", "Provider discovery failed"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/admin/oauth-clients/{client_id}",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Remove a registered OAuth client"),
+			endpoint.WithDescription("Stops accepting the client's tokens and sign-ins. Clients from config.toml cannot be removed. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("client_id", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Client ID")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Client removed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+				response.New(ErrorResponse{}, "404", "Client not registered"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/admin/logging",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Get log levels"),
+			endpoint.WithDescription("Returns the default log level and each log sink's level: console, seq when seq_endpoint is set, and every [[logging.sinks]] entry by name. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.LogLevelsResponse{}, "200", "Log levels"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+				response.New(ErrorResponse{}, "501", "Logging was set up without adjustable sinks"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/admin/logging",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Change log levels"),
+			endpoint.WithDescription("Sets the default level (debug, info, warn or error), which sinks without a level of their own follow, and/or individual sinks' levels; a sink given an empty level follows the default again. Nothing changes if any entry is invalid. Lasts until the next restart; a config reload only replaces the default. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithBody(request.UpdateLogLevelsRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.LogLevelsResponse{}, "200", "Log levels after the change"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid level or nothing to change"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+				response.New(ErrorResponse{}, "404", "No sink by that name"),
+				response.New(ErrorResponse{}, "501", "Logging was set up without adjustable sinks"),
+			}),
+		),
+		endpoint.New(
+			endpoint.GET,
+			"/admin/features",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("List feature flags"),
+			endpoint.WithDescription("Lists the flag in effect for every known feature by name, with its source: default (built in), config (app.toml) or admin. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.FeatureFlagListResponse{}, "200", "Feature flags"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.PUT,
+			"/admin/features/{name}",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Set a feature flag"),
+			endpoint.WithDescription("Turns a feature on for everyone (enabled) or only for the listed user IDs, usernames and groups. Replaces the flag from app.toml until removed; other instances pick it up within 30 seconds. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("name", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Feature name, e.g. meal_planner")),
+			),
+			endpoint.WithBody(request.SetFeatureFlagRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.FeatureFlagResponse{}, "200", "Flag as stored"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid feature name or too many users or groups"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+			}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/admin/features/{name}",
+			endpoint.WithTags("admin"),
+			endpoint.WithSummary("Remove a feature flag"),
+			endpoint.WithDescription("Removes an admin's flag, so the one in app.toml or the built-in default applies again. Requires membership of auth.admin_group."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithParams(
+				parameter.StrParam("name", parameter.Path, parameter.WithRequired(), parameter.WithDescription("Feature name")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(EmptyResponse{}, "204", "Flag removed"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Invalid feature name"),
+				response.New(ErrorResponse{}, "403", "Not an admin"),
+				response.New(ErrorResponse{}, "404", "No admin flag for this feature"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// DEV ENDPOINTS
+// ============================================
+
+func registerDevEndpoints(sw *swagno.OpenAPI) {
+	sw.AddEndpoints([]*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.POST,
+			"/dev/seed",
+			endpoint.WithTags("dev"),
+			endpoint.WithSummary("Seed fixture data"),
+			endpoint.WithDescription("Debug mode only (server.debug); otherwise the route does not exist. Creates collections of generated containers and objects owned by the caller. Zero counts take the defaults: one food collection of three containers holding ten objects each. group shares the collections through a new group; users adds that many local accounts, all signing in with password, to it (local auth mode only). The same seed gives the same names, quantities and dates."),
+			endpoint.WithSecurity(authSecurity()),
+			endpoint.WithBody(request.SeedRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New(httpresp.SeedResponse{}, "201", "What was created"),
+			}),
+			endpoint.WithErrors([]response.Response{
+				response.New(ErrorResponse{}, "400", "Counts out of range, unknown object type, or users asked for without local auth"),
+				response.New(ErrorResponse{}, "409", "The auth provider manages groups itself"),
+			}),
+		),
+	})
+}
+
+// ============================================
+// MCP X-EXTENSIONS
+// ============================================
+
+// MCPToolDocs returns the tools the spec documents under x-mcp-tools, for
+// mcpserver.VerifyTools.
+func MCPToolDocs() ([]mcpserver.ToolDoc, error) {
+	var spec struct {
+		Tools []mcpserver.ToolDoc `json:"x-mcp-tools"`
+	}
+	if err := json.Unmarshal(GenerateOpenAPISpec(), &spec); err != nil {
+		return nil, err
+	}
+	return spec.Tools, nil
 }
 
 type mcpResource struct {
@@ -705,25 +3528,6 @@ type mcpPrompt struct {
 	Arguments   map[string]string `json:"arguments,omitempty"`
 }
 
-func mcpToolsDocs() []mcpTool {
-	return []mcpTool{
-		{Name: "create_collection", Description: "Create a new inventory collection for a specific object type (food, books, games, etc.)", InputFields: map[string]string{"name": "required", "object_type": "required: food|book|videogame|music|boardgame|general", "location": "optional", "group_id": "optional", "tags": "optional"}},
-		{Name: "update_collection", Description: "Update a collection's name, location, or tags", InputFields: map[string]string{"collection_id": "required", "name": "optional", "location": "optional", "tags": "optional"}},
-		{Name: "delete_collection", Description: "Delete a collection and all its containers and objects", InputFields: map[string]string{"collection_id": "required"}},
-		{Name: "create_container", Description: "Create a new container within a collection", InputFields: map[string]string{"collection_id": "required", "name": "required", "type": "optional: room|bookshelf|shelf|binder|cabinet|general", "parent_container_id": "optional", "location": "optional", "capacity": "optional"}},
-		{Name: "update_container", Description: "Update a container's name, type, location, or capacity", InputFields: map[string]string{"container_id": "required", "name": "optional", "type": "optional", "location": "optional", "capacity": "optional"}},
-		{Name: "delete_container", Description: "Delete a container and all its objects", InputFields: map[string]string{"container_id": "required"}},
-		{Name: "create_object", Description: "Add a new object to a container", InputFields: map[string]string{"container_id": "required", "name": "required", "object_type": "required", "description": "optional", "quantity": "optional", "unit": "optional", "tags": "optional", "expires_at": "optional (RFC3339)"}},
-		{Name: "update_object", Description: "Update an existing inventory object", InputFields: map[string]string{"object_id": "required", "container_id": "required", "name": "optional", "quantity": "optional", "tags": "optional", "expires_at": "optional"}},
-		{Name: "delete_object", Description: "Delete an inventory object", InputFields: map[string]string{"object_id": "required", "container_id": "required"}},
-		{Name: "create_group", Description: "Create a new sharing group", InputFields: map[string]string{"name": "required", "description": "optional"}},
-		{Name: "join_group", Description: "Join a group using an invitation hash", InputFields: map[string]string{"invitation_hash": "required"}},
-		{Name: "update_group", Description: "Update a group's name or description", InputFields: map[string]string{"group_id": "required", "name": "optional", "description": "optional"}},
-		{Name: "delete_group", Description: "Delete a group", InputFields: map[string]string{"group_id": "required"}},
-		{Name: "bulk_import", Description: "Import multiple objects into a collection at once from structured data", InputFields: map[string]string{"collection_id": "required", "data": "required: array of object maps", "format": "required: json|csv", "distribution_mode": "optional: automatic|manual|target", "target_container_id": "optional"}},
-	}
-}
-
 func mcpResourcesDocs() []mcpResource {
 	return []mcpResource{
 		{URI: "nishiki://health", Name: "health", Description: "Server health status"},
@@ -731,6 +3535,7 @@ func mcpResourcesDocs() []mcpResource {
 		{URI: "nishiki://groups", Name: "groups", Description: "Groups the current user belongs to"},
 		{URI: "nishiki://collections", Name: "collections", Description: "All collections owned by or shared with the current user"},
 		{URI: "nishiki://containers", Name: "containers", Description: "All containers accessible to the current user"},
+		{URI: "nishiki://expiring", Name: "expiring", Description: "Objects expiring within the digest window, soonest first, including ones already expired"},
 		{URI: "nishiki://collections/{id}", Name: "collection", Description: "A specific collection with its containers", Template: true},
 		{URI: "nishiki://collections/{id}/containers", Name: "collection-containers", Description: "Containers within a specific collection", Template: true},
 		{URI: "nishiki://collections/{id}/objects", Name: "collection-objects", Description: "Objects within a specific collection", Template: true},
@@ -738,6 +3543,7 @@ func mcpResourcesDocs() []mcpResource {
 		{URI: "nishiki://containers/{id}/objects", Name: "container-objects", Description: "Objects within a specific container", Template: true},
 		{URI: "nishiki://groups/{id}", Name: "group", Description: "A specific group with its members", Template: true},
 		{URI: "nishiki://groups/{id}/containers", Name: "group-containers", Description: "Containers shared with a specific group", Template: true},
+		{URI: "nishiki://expiring/{days}", Name: "expiring-within", Description: "Objects expiring within the given number of days (1-366)", Template: true},
 	}
 }
 
@@ -747,6 +3553,7 @@ func mcpPromptsDocs() []mcpPrompt {
 		{Name: "add_receipt", Description: "Parse receipt items and bulk import them into the appropriate collection", Arguments: map[string]string{"receipt_text": "required: text content of the receipt to parse and import"}},
 		{Name: "find_item", Description: "Search for an item across all collections and containers", Arguments: map[string]string{"query": "required: item name or description to search for"}},
 		{Name: "expiration_check", Description: "Scan all food collections for items expiring soon", Arguments: map[string]string{"days": "optional: number of days ahead to check (default: 30)"}},
+		{Name: "plan_meals", Description: "Suggest meals that use expiring and available food, then save them as meal plans", Arguments: map[string]string{"days": "optional: number of days to plan (default: 7)", "preferences": "optional: dietary preferences or dishes to favor"}},
 		{Name: "reorganize", Description: "Analyze inventory layout and suggest reorganization for better utilization", Arguments: map[string]string{"collection_id": "optional: ID of the collection to analyze (leave empty for all collections)"}},
 	}
 }
@@ -0,0 +1,19 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mcpserver "github.com/nishiki/backend/app/mcp"
+)
+
+func TestMCPToolDocsMatchServer(t *testing.T) {
+	docs, err := MCPToolDocs()
+	require.NoError(t, err)
+	require.NotEmpty(t, docs)
+
+	server := mcpserver.NewMCPServer(&mcpserver.MCPContext{})
+	require.NoError(t, mcpserver.VerifyTools(context.Background(), server, docs))
+}
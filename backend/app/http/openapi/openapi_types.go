@@ -1,10 +1,29 @@
 package openapi
 
-import "time"
+import (
+	"time"
 
-// ErrorResponse is returned by all endpoints on error.
+	httpresp "github.com/nishiki/backend/app/http/response"
+)
+
+// ErrorResponse is returned by all endpoints on error (httputil.ErrorBody).
+// Code is stable and meant for programs: a status's generic code such as
+// not_found or conflict, or a specific one such as validation_failed,
+// reauth_required, account_disabled, session_revoked or quota_exceeded.
+// Fields lists the rejected parts of a request that failed validation.
+// Error repeats Message for older clients.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error     string               `json:"error"`
+	Code      string               `json:"code"`
+	Message   string               `json:"message"`
+	Fields    []ErrorFieldResponse `json:"fields,omitempty"`
+	RequestID string               `json:"request_id,omitempty"`
+}
+
+// ErrorFieldResponse names one rejected field of a request.
+type ErrorFieldResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // EmptyResponse is returned by DELETE endpoints on success.
@@ -20,11 +39,19 @@ type OpenAPIObjectResponse struct {
 	ObjectType  string            `json:"object_type"`
 	Quantity    *float64          `json:"quantity,omitempty"`
 	Unit        string            `json:"unit,omitempty"`
+	MinQuantity *float64          `json:"min_quantity,omitempty"`
+	LowStock    bool              `json:"low_stock,omitempty"`
+	StockStatus string            `json:"stock_status,omitempty"`
+	Condition   string            `json:"condition,omitempty"`
+	Status      string            `json:"status,omitempty"`
 	Properties  map[string]string `json:"properties"`
 	Tags        []string          `json:"tags"`
 	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ArchivedAt  *time.Time        `json:"archived_at,omitempty"`
+	// Set after a move or copy to a collection whose schema lacks them
+	DroppedProperties []string  `json:"dropped_properties,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // OpenAPIObjectListResponse wraps a list of objects.
@@ -43,14 +70,84 @@ type OpenAPIUpdateObjectResponse struct {
 	Object OpenAPIObjectResponse `json:"object"`
 }
 
+// OpenAPIMergeObjectsResponse is an OpenAPI-safe version of response.MergeObjectsResponse.
+type OpenAPIMergeObjectsResponse struct {
+	Object    OpenAPIObjectResponse `json:"object"`
+	MergedIDs []string              `json:"merged_ids"`
+	Preview   bool                  `json:"preview"`
+}
+
+// OpenAPIDuplicateGroup is an OpenAPI-safe version of response.DuplicateGroupResponse.
+type OpenAPIDuplicateGroup struct {
+	Key     string                  `json:"key"`
+	Objects []OpenAPIObjectResponse `json:"objects"`
+}
+
+// OpenAPIDuplicateGroupsResponse wraps the duplicate groups of a collection.
+type OpenAPIDuplicateGroupsResponse struct {
+	Groups []OpenAPIDuplicateGroup `json:"groups"`
+}
+
+// OpenAPIObjectCodeMatch is an OpenAPI-safe version of response.ObjectCodeMatchResponse.
+type OpenAPIObjectCodeMatch struct {
+	CollectionID string                `json:"collection_id"`
+	Object       OpenAPIObjectResponse `json:"object"`
+}
+
+// OpenAPIObjectCodeLookupResponse is an OpenAPI-safe version of response.ObjectCodeLookupResponse.
+type OpenAPIObjectCodeLookupResponse struct {
+	Code    string                   `json:"code"`
+	Matches []OpenAPIObjectCodeMatch `json:"matches"`
+}
+
+// OpenAPICompleteMealPlanResponse is an OpenAPI-safe version of response.CompleteMealPlanResponse.
+type OpenAPICompleteMealPlanResponse struct {
+	MealPlan httpresp.MealPlanResponse                `json:"meal_plan"`
+	Adjusted []OpenAPIObjectResponse                  `json:"adjusted"`
+	Skipped  []httpresp.SkippedMealIngredientResponse `json:"skipped"`
+}
+
+// OpenAPIConfirmInboxItemResponse is an OpenAPI-safe version of response.ConfirmInboxItemResponse.
+type OpenAPIConfirmInboxItemResponse struct {
+	Created     []OpenAPIObjectResponse           `json:"created"`
+	ContainerID string                            `json:"container_id"`
+	Failed      []httpresp.InboxLineErrorResponse `json:"failed"`
+}
+
+// OpenAPISnapshotObjectChange is an OpenAPI-safe version of response.SnapshotObjectChange.
+type OpenAPISnapshotObjectChange struct {
+	ID              string                         `json:"id"`
+	Name            string                         `json:"name"`
+	Before          *OpenAPIObjectResponse         `json:"before,omitempty"`
+	After           *OpenAPIObjectResponse         `json:"after,omitempty"`
+	BeforeContainer *httpresp.SnapshotContainerRef `json:"before_container,omitempty"`
+	AfterContainer  *httpresp.SnapshotContainerRef `json:"after_container,omitempty"`
+	Fields          []string                       `json:"fields,omitempty"`
+}
+
+// OpenAPICollectionSnapshotDiffResponse is an OpenAPI-safe version of response.CollectionSnapshotDiffResponse.
+type OpenAPICollectionSnapshotDiffResponse struct {
+	From              httpresp.CollectionSnapshotResponse  `json:"from"`
+	To                *httpresp.CollectionSnapshotResponse `json:"to,omitempty"`
+	ContainersAdded   []httpresp.SnapshotContainerRef      `json:"containers_added"`
+	ContainersRemoved []httpresp.SnapshotContainerRef      `json:"containers_removed"`
+	ContainersChanged []httpresp.SnapshotContainerChange   `json:"containers_changed"`
+	ObjectsAdded      []OpenAPISnapshotObjectChange        `json:"objects_added"`
+	ObjectsRemoved    []OpenAPISnapshotObjectChange        `json:"objects_removed"`
+	ObjectsChanged    []OpenAPISnapshotObjectChange        `json:"objects_changed"`
+}
+
 // OpenAPICreateObjectRequest is an OpenAPI-safe version of request.CreateObjectRequest.
 type OpenAPICreateObjectRequest struct {
-	ContainerID string            `json:"container_id"`
+	ContainerID string            `json:"container_id,omitempty"`
 	Name        string            `json:"name"`
 	Description string            `json:"description,omitempty"`
 	ObjectType  string            `json:"object_type"`
 	Quantity    *float64          `json:"quantity,omitempty"`
 	Unit        string            `json:"unit,omitempty"`
+	MinQuantity *float64          `json:"min_quantity,omitempty"`
+	Condition   string            `json:"condition,omitempty"`
+	Status      string            `json:"status,omitempty"`
 	Properties  map[string]string `json:"properties,omitempty"`
 	Tags        []string          `json:"tags,omitempty"`
 	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
@@ -58,16 +155,41 @@ type OpenAPICreateObjectRequest struct {
 
 // OpenAPIUpdateObjectRequest is an OpenAPI-safe version of request.UpdateObjectRequest.
 type OpenAPIUpdateObjectRequest struct {
-	ContainerID string            `json:"container_id"`
+	ContainerID string            `json:"container_id,omitempty"`
 	Name        *string           `json:"name,omitempty"`
 	Description *string           `json:"description,omitempty"`
 	Quantity    *float64          `json:"quantity,omitempty"`
 	Unit        *string           `json:"unit,omitempty"`
+	MinQuantity *float64          `json:"min_quantity,omitempty"`
+	Condition   *string           `json:"condition,omitempty"`
+	Status      *string           `json:"status,omitempty"`
 	Properties  map[string]string `json:"properties,omitempty"`
 	Tags        []string          `json:"tags,omitempty"`
 	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
 }
 
+// OpenAPIPatchObjectRequest is an OpenAPI-safe version of request.PatchObjectRequest.
+type OpenAPIPatchObjectRequest struct {
+	ContainerID *string           `json:"container_id,omitempty"`
+	Name        *string           `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Location    *string           `json:"location,omitempty"`
+	Quantity    *float64          `json:"quantity,omitempty"`
+	Unit        *string           `json:"unit,omitempty"`
+	MinQuantity *float64          `json:"min_quantity,omitempty"`
+	Condition   *string           `json:"condition,omitempty"`
+	Status      *string           `json:"status,omitempty"`
+	Properties  map[string]string `json:"properties,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
+}
+
+// OpenAPIBulkCreateContainersRequest is an OpenAPI-safe version of request.BulkCreateContainersRequest.
+type OpenAPIBulkCreateContainersRequest struct {
+	Format string              `json:"format"`
+	Data   []map[string]string `json:"data"`
+}
+
 // OpenAPIBulkImportCollectionRequest is an OpenAPI-safe version of request.BulkImportCollectionRequest.
 // Data uses []map[string]string instead of []map[string]interface{}.
 type OpenAPIBulkImportCollectionRequest struct {
@@ -76,4 +198,17 @@ type OpenAPIBulkImportCollectionRequest struct {
 	Format            string              `json:"format"`
 	Data              []map[string]string `json:"data"`
 	DefaultTags       []string            `json:"default_tags,omitempty"`
+	SourceFormat      string              `json:"source_format,omitempty"`
+}
+
+// OpenAPILocalSignInForm describes the urlencoded form posted by the local
+// auth mode's sign-in page.
+type OpenAPILocalSignInForm struct {
+	RedirectURI   string `json:"redirect_uri"`
+	State         string `json:"state,omitempty"`
+	CodeChallenge string `json:"code_challenge"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	Email         string `json:"email,omitempty"`
+	Action        string `json:"action,omitempty"`
 }
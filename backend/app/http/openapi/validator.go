@@ -0,0 +1,445 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/app/http/request"
+)
+
+// MaxRequestBodySize caps the JSON bodies the validator buffers. The
+// validator runs ahead of authentication, so without a cap anyone could make
+// the server hold an arbitrarily large body in memory.
+const MaxRequestBodySize = 10 << 20
+
+// ErrRequestBodyTooLarge is returned by ValidateRequest for a body over its
+// operation's limit
+var ErrRequestBodyTooLarge = errors.New("request body is too large")
+
+// bodyLimits raises MaxRequestBodySize for the operations documented to take
+// larger JSON bodies, keyed by method and spec path
+var bodyLimits = map[string]int64{
+	"POST /accounts/{id}/restore": request.MaxRestoreBodySize,
+}
+
+// Validator checks requests, and optionally responses, against the
+// generated OpenAPI spec so the documented contract is the enforced one.
+// Routes the spec does not describe pass through untouched.
+type Validator struct {
+	routes      []specRoute
+	schemas     map[string]any
+	maxBodySize int64
+}
+
+type specRoute struct {
+	method    string
+	path      string
+	segments  []string // "{name}" segments match any value
+	literals  int      // non-parameter segments, to prefer /objects/parse over /objects/{object_id}
+	operation map[string]any
+}
+
+// NewValidator indexes the operations of a spec produced by GenerateOpenAPISpec
+func NewValidator(spec []byte) (*Validator, error) {
+	var doc struct {
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	v := &Validator{schemas: doc.Components.Schemas, maxBodySize: MaxRequestBodySize}
+	for path, ops := range doc.Paths {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		literals := 0
+		for _, s := range segments {
+			if !isPathParam(s) {
+				literals++
+			}
+		}
+		for method, op := range ops {
+			v.routes = append(v.routes, specRoute{
+				method:    strings.ToUpper(method),
+				path:      path,
+				segments:  segments,
+				literals:  literals,
+				operation: op,
+			})
+		}
+	}
+	slices.SortFunc(v.routes, func(a, b specRoute) int { return b.literals - a.literals })
+	return v, nil
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// match returns the documented route for a request and its path
+// parameters, or nil when the spec does not describe it
+func (v *Validator) match(method, path string) (*specRoute, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range v.routes {
+		route := &v.routes[i]
+		if route.method != method || len(route.segments) != len(segments) {
+			continue
+		}
+		params := make(map[string]string)
+		matched := true
+		for i, s := range route.segments {
+			if isPathParam(s) {
+				params[strings.Trim(s, "{}")] = segments[i]
+			} else if s != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route, params
+		}
+	}
+	return nil, nil
+}
+
+// FieldError names one part of a request or response that does not match the spec
+type FieldError struct {
+	Path    string `json:"path"` // e.g. "body.items[0].id" or "query.limit"
+	Message string `json:"message"`
+}
+
+// ValidateRequest checks a request's parameters and JSON body against its
+// documented operation. The body is read and put back for the handler; one
+// over the operation's limit is not read past it and fails with
+// ErrRequestBodyTooLarge.
+func (v *Validator) ValidateRequest(r *http.Request) ([]FieldError, error) {
+	route, pathParams := v.match(r.Method, r.URL.Path)
+	if route == nil {
+		return nil, nil
+	}
+	op := route.operation
+
+	var errs []FieldError
+	params, _ := op["parameters"].([]any)
+	query := r.URL.Query()
+	for _, p := range params {
+		param, _ := p.(map[string]any)
+		name, _ := param["name"].(string)
+		schema, _ := param["schema"].(map[string]any)
+		required, _ := param["required"].(bool)
+
+		var value string
+		var present bool
+		switch param["in"] {
+		case "path":
+			value, present = pathParams[name]
+		case "query":
+			present = query.Has(name)
+			value = query.Get(name)
+		default:
+			continue
+		}
+		where := fmt.Sprintf("%s.%s", param["in"], name)
+		if !present || value == "" {
+			if required {
+				errs = append(errs, FieldError{Path: where, Message: "is required"})
+			}
+			continue
+		}
+		if msg := checkParam(schema, value); msg != "" {
+			errs = append(errs, FieldError{Path: where, Message: msg})
+		}
+	}
+
+	schema := jsonBodySchema(op["requestBody"])
+	if schema == nil || r.Body == nil || !isJSONContent(r.Header.Get("Content-Type"), true) {
+		return errs, nil
+	}
+	limit := v.maxBodySize
+	if l, ok := bodyLimits[route.method+" "+route.path]; ok {
+		limit = l
+	}
+	if r.ContentLength > limit {
+		return nil, ErrRequestBodyTooLarge
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if int64(len(body)) > limit {
+		return nil, ErrRequestBodyTooLarge
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		// Leave reporting a broken body to the handler
+		return errs, nil
+	}
+	return append(errs, v.checkBody(schema, body)...), nil
+}
+
+// ValidateResponse checks a JSON response body against the schema documented
+// for its status code
+func (v *Validator) ValidateResponse(method, path string, status int, body []byte) []FieldError {
+	route, _ := v.match(method, path)
+	if route == nil {
+		return nil
+	}
+	responses, _ := route.operation["responses"].(map[string]any)
+	schema := jsonBodySchema(responses[strconv.Itoa(status)])
+	if schema == nil {
+		return nil
+	}
+	return v.checkBody(schema, body)
+}
+
+func (v *Validator) checkBody(schema map[string]any, body []byte) []FieldError {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if required, _ := v.resolve(schema)["required"].([]any); len(required) > 0 {
+			return []FieldError{{Path: "body", Message: "is required"}}
+		}
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []FieldError{{Path: "body", Message: "is not valid JSON"}}
+	}
+	var errs []FieldError
+	v.checkValue(schema, value, "body", &errs)
+	return errs
+}
+
+// jsonBodySchema returns the application/json schema of a request body or
+// response object
+func jsonBodySchema(node any) map[string]any {
+	obj, _ := node.(map[string]any)
+	content, _ := obj["content"].(map[string]any)
+	media, _ := content["application/json"].(map[string]any)
+	schema, _ := media["schema"].(map[string]any)
+	return schema
+}
+
+// isJSONContent reports whether a Content-Type carries JSON. Requests
+// without one are decoded as JSON by the handlers, so they count when
+// allowMissing is set.
+func isJSONContent(contentType string, allowMissing bool) bool {
+	if contentType == "" {
+		return allowMissing
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// resolve follows a $ref to its component schema. Siblings of the $ref,
+// such as nullable, are kept.
+func (v *Validator) resolve(schema map[string]any) map[string]any {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	target, _ := v.schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+	merged := make(map[string]any, len(target)+1)
+	for k, val := range target {
+		merged[k] = val
+	}
+	if nullable, ok := schema["nullable"]; ok {
+		merged["nullable"] = nullable
+	}
+	return merged
+}
+
+// checkValue appends a FieldError for every way value breaks schema. It
+// covers the keywords the generated spec uses: type, nullable, format,
+// enum, required, properties, additionalProperties and items. Fields the
+// spec does not list are allowed, as the handlers ignore them.
+func (v *Validator) checkValue(schema map[string]any, value any, path string, errs *[]FieldError) {
+	schema = v.resolve(schema)
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); !nullable && schema["type"] != nil {
+			*errs = append(*errs, FieldError{Path: path, Message: "must not be null"})
+		}
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf("must be one of %v", enum)})
+		return
+	}
+
+	switch schema["type"] {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			*errs = append(*errs, FieldError{Path: path, Message: "must be a string"})
+			return
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				*errs = append(*errs, FieldError{Path: path, Message: "must be an RFC 3339 date-time"})
+			}
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			*errs = append(*errs, FieldError{Path: path, Message: "must be a number"})
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			*errs = append(*errs, FieldError{Path: path, Message: "must be an integer"})
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, FieldError{Path: path, Message: "must be a boolean"})
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			*errs = append(*errs, FieldError{Path: path, Message: "must be an array"})
+			return
+		}
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for i, item := range items {
+				v.checkValue(itemSchema, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			*errs = append(*errs, FieldError{Path: path, Message: "must be an object"})
+			return
+		}
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			key, _ := name.(string)
+			if _, ok := obj[key]; !ok {
+				*errs = append(*errs, FieldError{Path: path + "." + key, Message: "is required"})
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		extra, _ := schema["additionalProperties"].(map[string]any)
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if propSchema, ok := props[key].(map[string]any); ok {
+				v.checkValue(propSchema, obj[key], path+"."+key, errs)
+			} else if extra != nil {
+				v.checkValue(extra, obj[key], path+"."+key, errs)
+			}
+		}
+	}
+}
+
+// checkParam checks a path or query parameter against its schema type
+func checkParam(schema map[string]any, value string) string {
+	switch schema["type"] {
+	case "integer":
+		if _, err := strconv.Atoi(value); err != nil {
+			return "must be an integer"
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be true or false"
+		}
+	}
+	return ""
+}
+
+// Middleware rejects requests that do not match the spec with 400 and the
+// offending fields, and JSON bodies over their limit with 413. With validateResponses set, JSON responses are checked
+// too and mismatches are logged; they are never altered, so this is meant
+// for development, where the buffered copy of each body is affordable.
+func (v *Validator) Middleware(logger *slog.Logger, validateResponses bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			errs, err := v.ValidateRequest(r)
+			if errors.Is(err, ErrRequestBodyTooLarge) {
+				httputil.Error(w, http.StatusRequestEntityTooLarge, err.Error())
+				return
+			}
+			if len(errs) > 0 {
+				logger.Warn("Request does not match the API spec",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Any("fields", errs))
+				fields := make([]httputil.FieldError, len(errs))
+				for i, e := range errs {
+					fields[i] = httputil.FieldError{Field: e.Path, Message: e.Message}
+				}
+				httputil.ValidationError(w, "request does not match the API spec", fields...)
+				return
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tw := &teeResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(tw, r)
+			if !tw.capture {
+				return
+			}
+			if errs := v.ValidateResponse(r.Method, r.URL.Path, tw.status, tw.body.Bytes()); len(errs) > 0 {
+				logger.Warn("Response does not match the API spec",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("status", tw.status),
+					slog.Any("fields", errs))
+			}
+		})
+	}
+}
+
+// teeResponseWriter passes a response through while keeping a copy of JSON
+// bodies to validate afterwards
+type teeResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	capture     bool
+	body        bytes.Buffer
+}
+
+func (tw *teeResponseWriter) WriteHeader(status int) {
+	if tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	tw.status = status
+	tw.capture = isJSONContent(tw.Header().Get("Content-Type"), false)
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+func (tw *teeResponseWriter) Write(b []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.capture {
+		tw.body.Write(b)
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Flush keeps streamed responses streaming
+func (tw *teeResponseWriter) Flush() {
+	if f, ok := tw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,210 @@
+package openapi
+
+import (
+	"encoding/json/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+const testAccount = "/accounts/507f1f77bcf86cd799439011"
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	v, err := NewValidator(GenerateOpenAPISpec())
+	require.NoError(t, err)
+	return v
+}
+
+func jsonRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestValidator_ValidateRequest(t *testing.T) {
+	t.Parallel()
+	v := newTestValidator(t)
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want []FieldError
+	}{
+		{
+			name: "valid body with free-form properties",
+			req: jsonRequest(http.MethodPost, testAccount+"/objects",
+				`{"name":"Oats","object_type":"food","quantity":2,"properties":{"calories":150,"upc":"0123456789012"}}`),
+		},
+		{
+			name: "missing required field and wrong types",
+			req:  jsonRequest(http.MethodPost, testAccount+"/objects", `{"object_type":"food","quantity":"two","tags":["a",3]}`),
+			want: []FieldError{
+				{Path: "body.name", Message: "is required"},
+				{Path: "body.quantity", Message: "must be a number"},
+				{Path: "body.tags[1]", Message: "must be a string"},
+			},
+		},
+		{
+			name: "nullable field accepts null",
+			req:  jsonRequest(http.MethodPut, testAccount+"/objects/507f1f77bcf86cd799439012", `{"quantity":null}`),
+		},
+		{
+			name: "merge patch removes values with null",
+			req: func() *http.Request {
+				req := jsonRequest(http.MethodPatch, testAccount+"/collections/507f1f77bcf86cd799439012/containers/507f1f77bcf86cd799439013",
+					`{"location":null,"width":null}`)
+				req.Header.Set("Content-Type", httputil.MergePatchContentType)
+				return req
+			}(),
+		},
+		{
+			name: "merge patch removes object values with null",
+			req: jsonRequest(http.MethodPatch, testAccount+"/objects/507f1f77bcf86cd799439012",
+				`{"description":null,"quantity":null,"properties":{"color":null},"tags":null}`),
+		},
+		{
+			name: "merge patch clears collection tags",
+			req:  jsonRequest(http.MethodPatch, testAccount+"/collections/507f1f77bcf86cd799439012", `{"tags":null,"location":null}`),
+		},
+		{
+			name: "merge patch is still typed",
+			req: func() *http.Request {
+				req := jsonRequest(http.MethodPatch, "/containers/507f1f77bcf86cd799439012", `{"width":null,"rows":"two"}`)
+				req.Header.Set("Content-Type", httputil.MergePatchContentType)
+				return req
+			}(),
+			want: []FieldError{{Path: "body.rows", Message: "must be an integer"}},
+		},
+		{
+			name: "date-time format",
+			req:  jsonRequest(http.MethodPut, testAccount+"/objects/507f1f77bcf86cd799439012", `{"expires_at":"next week"}`),
+			want: []FieldError{{Path: "body.expires_at", Message: "must be an RFC 3339 date-time"}},
+		},
+		{
+			name: "literal segment wins over parameter",
+			req:  jsonRequest(http.MethodPost, testAccount+"/objects/parse", `{}`),
+			want: []FieldError{{Path: "body.text", Message: "is required"}},
+		},
+		{
+			name: "empty body with required fields",
+			req:  jsonRequest(http.MethodPost, "/groups", ""),
+			want: []FieldError{{Path: "body", Message: "is required"}},
+		},
+		{
+			name: "empty body when nothing is required",
+			req:  jsonRequest(http.MethodPost, testAccount+"/objects/507f1f77bcf86cd799439012/claim", ""),
+		},
+		{
+			name: "malformed JSON",
+			req:  jsonRequest(http.MethodPost, "/groups", `{"name":`),
+			want: []FieldError{{Path: "body", Message: "is not valid JSON"}},
+		},
+		{
+			name: "typed and required query parameters",
+			req:  httptest.NewRequest(http.MethodGet, testAccount+"/lookup?limit=1", nil),
+			want: []FieldError{{Path: "query.code", Message: "is required"}},
+		},
+		{
+			name: "integer query parameter",
+			req:  httptest.NewRequest(http.MethodGet, testAccount+"/objects/507f1f77bcf86cd799439012/comments?limit=ten", nil),
+			want: []FieldError{{Path: "query.limit", Message: "must be an integer"}},
+		},
+		{
+			name: "non-JSON body is left to the handler",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/groups", strings.NewReader("name=Family"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				return req
+			}(),
+		},
+		{
+			name: "undocumented route",
+			req:  jsonRequest(http.MethodPost, "/mcp", `not json`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := v.ValidateRequest(tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, errs)
+		})
+	}
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	t.Parallel()
+	v := newTestValidator(t)
+
+	ok := v.ValidateResponse(http.MethodGet, testAccount+"/lookup", http.StatusOK, []byte(`{"code":"ABCD","matches":[]}`))
+	assert.Empty(t, ok)
+
+	bad := v.ValidateResponse(http.MethodGet, testAccount+"/lookup", http.StatusOK, []byte(`{"code":4,"matches":[]}`))
+	assert.Equal(t, []FieldError{{Path: "body.code", Message: "must be a string"}}, bad)
+
+	undocumented := v.ValidateResponse(http.MethodGet, testAccount+"/lookup", http.StatusTeapot, []byte(`[]`))
+	assert.Empty(t, undocumented)
+}
+
+func TestValidator_Middleware(t *testing.T) {
+	t.Parallel()
+	v := newTestValidator(t)
+	logger := slog.New(slog.DiscardHandler)
+
+	var received string
+	handler := v.Middleware(logger, true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		httputil.JSON(w, http.StatusCreated, map[string]string{"id": "g1"})
+	}))
+
+	t.Run("invalid request is rejected with field paths", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, jsonRequest(http.MethodPost, "/groups", `{"name":7}`))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp httputil.ErrorBody
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, httputil.CodeValidationFailed, resp.Code)
+		assert.Equal(t, "request does not match the API spec", resp.Message)
+		assert.Equal(t, []httputil.FieldError{{Field: "body.name", Message: "must be a string"}}, resp.Fields)
+	})
+
+	t.Run("valid request reaches the handler with its body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, jsonRequest(http.MethodPost, "/groups", `{"name":"Family"}`))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"g1"}`, rec.Body.String())
+		assert.Equal(t, `{"name":"Family"}`, received)
+	})
+
+	t.Run("oversized body is rejected before it is buffered", func(t *testing.T) {
+		small := newTestValidator(t)
+		small.maxBodySize = 64
+		handler := small.Middleware(logger, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler reached with an oversized body")
+		}))
+		body := `{"name":"` + strings.Repeat("a", 100) + `"}`
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, jsonRequest(http.MethodPost, "/groups", body))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+		// Without a Content-Length the body is still cut off at the limit
+		req := jsonRequest(http.MethodPost, "/groups", body)
+		req.ContentLength = -1
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+}
@@ -0,0 +1,30 @@
+package request
+
+import "errors"
+
+// RegisterRequest creates a local account in local auth mode.
+type RegisterRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginRequest signs in to a local account in local auth mode.
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (r *RegisterRequest) Validate() error {
+	if r.Username == "" || r.Email == "" || r.Password == "" {
+		return errors.New("username, email and password are required")
+	}
+	return nil
+}
+
+func (r *LoginRequest) Validate() error {
+	if r.Username == "" || r.Password == "" {
+		return errors.New("username and password are required")
+	}
+	return nil
+}
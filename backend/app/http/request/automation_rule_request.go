@@ -0,0 +1,166 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// AutomationTriggerRequest says what sets a rule off. Kind is
+// object_created, tag_added (with tag), quantity_below (with threshold) or
+// expires_within (with days).
+type AutomationTriggerRequest struct {
+	Kind      string  `json:"kind"`
+	Tag       string  `json:"tag,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Days      int     `json:"days,omitempty"`
+	// CollectionID limits the rule to one collection
+	CollectionID string `json:"collection_id,omitempty"`
+}
+
+// AutomationActionRequest is one thing a rule does. Kind is add_tag (with
+// tag), move_to_container (with container_id), add_to_shopping_list or
+// fire_webhook (with url, and optionally a secret to sign the body with and
+// a retry policy).
+type AutomationActionRequest struct {
+	Kind        string `json:"kind"`
+	Tag         string `json:"tag,omitempty"`
+	ContainerID string `json:"container_id,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// Secret is left out to keep the secret the webhook already has
+	Secret string `json:"secret,omitempty"`
+	// MaxRetries is how often a failed webhook is tried again, 0 to 10
+	MaxRetries int `json:"max_retries,omitempty"`
+	// RetryBackoff is the wait in seconds before the first retry, doubling
+	// for each one after it
+	RetryBackoff int `json:"retry_backoff,omitempty"`
+}
+
+// AutomationRuleRequest creates a rule, or replaces one whole.
+type AutomationRuleRequest struct {
+	Name string `json:"name"`
+	// Enabled defaults to true
+	Enabled *bool                     `json:"enabled,omitempty"`
+	Trigger AutomationTriggerRequest  `json:"trigger"`
+	Actions []AutomationActionRequest `json:"actions"`
+}
+
+func (r *AutomationRuleRequest) Validate() error {
+	if len(r.Name) == 0 || len(r.Name) > 100 {
+		return fieldError("name", entities.ErrInvalidAutomationRuleName.Error())
+	}
+
+	if !entities.IsValidAutomationTriggerKind(r.Trigger.Kind) {
+		return fieldError("trigger.kind", "trigger kind must be object_created, tag_added, quantity_below or expires_within")
+	}
+	if r.Trigger.CollectionID != "" {
+		if _, err := entities.CollectionIDFromString(r.Trigger.CollectionID); err != nil {
+			return fieldError("trigger.collection_id", "invalid collection ID")
+		}
+	}
+	if err := r.toTrigger().Validate(); err != nil {
+		switch entities.AutomationTriggerKind(r.Trigger.Kind) {
+		case entities.TriggerTagAdded:
+			return fieldError("trigger.tag", "a tag is required")
+		case entities.TriggerQuantityBelow:
+			return fieldError("trigger.threshold", "threshold must be greater than zero")
+		default:
+			return fieldError("trigger.days", "days must be between 0 and 365")
+		}
+	}
+
+	if len(r.Actions) == 0 || len(r.Actions) > entities.MaxAutomationActions {
+		return fieldErrorf("actions", "a rule needs between 1 and %d actions", entities.MaxAutomationActions)
+	}
+	for i, a := range r.Actions {
+		field := fmt.Sprintf("actions[%d]", i)
+		if !entities.IsValidAutomationActionKind(a.Kind) {
+			return fieldError(field+".kind", "action kind must be add_tag, move_to_container, add_to_shopping_list or fire_webhook")
+		}
+		action, err := a.toEntity()
+		if err != nil {
+			return fieldError(field+".container_id", "invalid container ID")
+		}
+		if err := action.Validate(); err != nil {
+			switch {
+			case errors.Is(err, entities.ErrInvalidWebhookRetry) && (a.MaxRetries < 0 || a.MaxRetries > entities.MaxWebhookRetries):
+				return fieldErrorf(field+".max_retries", "max_retries must be between 0 and %d", entities.MaxWebhookRetries)
+			case errors.Is(err, entities.ErrInvalidWebhookRetry):
+				return fieldErrorf(field+".retry_backoff", "retry_backoff must be between 1 and %d seconds", entities.MaxWebhookRetryBackoff)
+			}
+			switch action.Kind {
+			case entities.ActionAddTag:
+				return fieldError(field+".tag", "a tag is required")
+			case entities.ActionMoveToContainer:
+				return fieldError(field+".container_id", "a container is required")
+			default:
+				return fieldError(field+".url", "url must be an absolute http or https URL")
+			}
+		}
+	}
+	return nil
+}
+
+// ToProps returns the rule's settings. Call Validate first.
+func (r *AutomationRuleRequest) ToProps() (entities.AutomationRuleProps, error) {
+	props := entities.AutomationRuleProps{
+		Name:    r.Name,
+		Enabled: r.Enabled == nil || *r.Enabled,
+		Trigger: r.toTrigger(),
+	}
+	for _, a := range r.Actions {
+		action, err := a.toEntity()
+		if err != nil {
+			return entities.AutomationRuleProps{}, err
+		}
+		props.Actions = append(props.Actions, action)
+	}
+	return props, nil
+}
+
+func (r *AutomationRuleRequest) toTrigger() entities.AutomationTrigger {
+	trigger := entities.AutomationTrigger{
+		Kind:      entities.AutomationTriggerKind(r.Trigger.Kind),
+		Tag:       r.Trigger.Tag,
+		Threshold: r.Trigger.Threshold,
+		Days:      r.Trigger.Days,
+	}
+	if collectionID, err := entities.CollectionIDFromString(r.Trigger.CollectionID); err == nil {
+		trigger.CollectionID = &collectionID
+	}
+	return trigger
+}
+
+func (a AutomationActionRequest) toEntity() (entities.AutomationAction, error) {
+	action := entities.AutomationAction{
+		Kind:   entities.AutomationActionKind(a.Kind),
+		Tag:    a.Tag,
+		URL:    a.URL,
+		Secret: a.Secret,
+		Retry:  entities.WebhookRetryPolicy{MaxRetries: a.MaxRetries, Backoff: a.RetryBackoff},
+	}
+	if a.ContainerID != "" {
+		containerID, err := entities.ContainerIDFromString(a.ContainerID)
+		if err != nil {
+			return entities.AutomationAction{}, err
+		}
+		action.ContainerID = &containerID
+	}
+	return action, nil
+}
+
+func GetAutomationRuleIDFromPath(r *http.Request) (entities.AutomationRuleID, error) {
+	idStr := r.PathValue("rule_id")
+	if idStr == "" {
+		return entities.AutomationRuleID{}, errors.New("missing automation rule ID in path")
+	}
+
+	ruleID, err := entities.AutomationRuleIDFromString(idStr)
+	if err != nil {
+		return entities.AutomationRuleID{}, fmt.Errorf("invalid automation rule ID: %w", err)
+	}
+
+	return ruleID, nil
+}
@@ -2,7 +2,7 @@ package request
 
 import (
 	"errors"
-	"fmt"
+	"net/http"
 
 	"github.com/nishiki/backend/domain/entities"
 )
@@ -25,29 +25,25 @@ type BulkImportCollectionRequest struct {
 	LocationColumn    string           `json:"location_column,omitempty"` // column name for container mapping (default: "location")
 	NameColumn        string           `json:"name_column,omitempty"`     // column name override for object name
 	InferSchema       bool             `json:"infer_schema,omitempty"`    // run type inference and save schema
+	SourceFormat      string           `json:"source_format,omitempty"`   // "generic" (default), "auto", "grocy", "pantry_csv"
 }
 
 func (r *BulkImportRequest) Validate() error {
 	if r.ContainerID == "" {
-		return errors.New("container_id is required")
+		return fieldError("container_id", "container_id is required")
 	}
 
 	if r.Format != "csv" && r.Format != "json" {
-		return errors.New("format must be 'csv' or 'json'")
+		return fieldError("format", "format must be 'csv' or 'json'")
 	}
 
 	if len(r.Data) == 0 {
-		return errors.New("data is required and cannot be empty")
+		return fieldError("data", "data is required and cannot be empty")
 	}
 
-	// Validate object type
-	objectType := entities.ObjectType(r.ObjectType)
-	switch objectType {
-	case entities.ObjectTypeFood, entities.ObjectTypeBook, entities.ObjectTypeVideoGame,
-		entities.ObjectTypeMusic, entities.ObjectTypeBoardGame, entities.ObjectTypeGeneral:
-		// Valid object type
-	default:
-		return fmt.Errorf("invalid object_type: %s", r.ObjectType)
+	// Built-in or custom; a custom type must be the collection's own
+	if err := entities.ValidateObjectTypeKey(entities.ObjectType(r.ObjectType)); err != nil {
+		return fieldErrorf("object_type", "invalid object_type: %s", r.ObjectType)
 	}
 
 	return nil
@@ -55,28 +51,38 @@ func (r *BulkImportRequest) Validate() error {
 
 func (r *BulkImportCollectionRequest) Validate() error {
 	if r.Format != "csv" && r.Format != "json" {
-		return errors.New("format must be 'csv' or 'json'")
+		return fieldError("format", "format must be 'csv' or 'json'")
 	}
 
 	if len(r.Data) == 0 {
-		return errors.New("data is required and cannot be empty")
+		return fieldError("data", "data is required and cannot be empty")
 	}
 
 	// Note: CollectionID comes from URL path, not validated here
 
+	// Validate source format if provided
+	if r.SourceFormat != "" {
+		switch r.SourceFormat {
+		case "generic", "auto", "grocy", "pantry_csv":
+			// Valid formats
+		default:
+			return fieldError("source_format", "source_format must be 'generic', 'auto', 'grocy', or 'pantry_csv'")
+		}
+	}
+
 	// Validate distribution mode if provided
 	if r.DistributionMode != "" {
 		switch r.DistributionMode {
 		case "automatic", "manual", "target", "location":
 			// Valid modes
 		default:
-			return errors.New("distribution_mode must be 'automatic', 'manual', 'target', or 'location'")
+			return fieldError("distribution_mode", "distribution_mode must be 'automatic', 'manual', 'target', or 'location'")
 		}
 	}
 
 	// If distribution mode is "target", require target_container_id
 	if r.DistributionMode == "target" && r.TargetContainerID == nil {
-		return errors.New("target_container_id is required when distribution_mode is 'target'")
+		return fieldError("target_container_id", "target_container_id is required when distribution_mode is 'target'")
 	}
 
 	return nil
@@ -104,3 +110,13 @@ func (r *BulkImportCollectionRequest) GetTargetContainerID() (*entities.Containe
 	}
 	return &containerID, nil
 }
+
+// GetImportJobIDFromPath reads the job_id path value of the import job
+// endpoints
+func GetImportJobIDFromPath(r *http.Request) (string, error) {
+	jobID := r.PathValue("job_id")
+	if jobID == "" {
+		return "", errors.New("missing import job ID in path")
+	}
+	return jobID, nil
+}
@@ -24,14 +24,14 @@ type UpdateCategoryRequest struct {
 
 func (r *CreateCategoryRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 	return nil
 }
 
 func (r *UpdateCategoryRequest) Validate() error {
 	if r.Name != nil && (len(*r.Name) < 1 || len(*r.Name) > 255) {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 	return nil
 }
@@ -0,0 +1,56 @@
+package request
+
+// MaxClientErrorBodySize caps the size of a client error report.
+const MaxClientErrorBodySize = 64 << 10
+
+const (
+	ClientErrorKindPanic = "panic"
+	ClientErrorKindAPI   = "api"
+)
+
+const (
+	maxClientErrorMessage = 2 << 10
+	maxClientErrorStack   = 32 << 10
+	maxClientErrorField   = 512
+)
+
+// ClientErrorRequest is a crash or failed API call reported by a frontend
+// that opted in to error reporting.
+type ClientErrorRequest struct {
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	Stack      string `json:"stack,omitempty"`
+	View       string `json:"view,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	AppVersion string `json:"app_version,omitempty"`
+	Method     string `json:"method,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	Status     int    `json:"status,omitempty"`
+}
+
+func (r *ClientErrorRequest) Validate() error {
+	if r.Kind != ClientErrorKindPanic && r.Kind != ClientErrorKindAPI {
+		return fieldErrorf("kind", "kind must be %q or %q", ClientErrorKindPanic, ClientErrorKindAPI)
+	}
+	if r.Message == "" {
+		return fieldError("message", "message is required")
+	}
+	if len(r.Message) > maxClientErrorMessage {
+		return fieldErrorf("message", "message must be at most %d bytes", maxClientErrorMessage)
+	}
+	if len(r.Stack) > maxClientErrorStack {
+		return fieldErrorf("stack", "stack must be at most %d bytes", maxClientErrorStack)
+	}
+	for name, value := range map[string]string{
+		"view": r.View, "user_agent": r.UserAgent, "app_version": r.AppVersion,
+		"method": r.Method, "endpoint": r.Endpoint,
+	} {
+		if len(value) > maxClientErrorField {
+			return fieldErrorf(name, "%s must be at most %d bytes", name, maxClientErrorField)
+		}
+	}
+	if r.Status < 0 || r.Status > 999 {
+		return fieldError("status", "status must be an HTTP status code")
+	}
+	return nil
+}
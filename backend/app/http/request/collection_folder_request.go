@@ -0,0 +1,102 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CreateCollectionFolderRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+	// ParentID nests the folder in another of the user's folders.
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+type UpdateCollectionFolderRequest struct {
+	Name *string `json:"name,omitempty"`
+	// ParentID moves the folder into another folder; an empty string moves
+	// it to the top level.
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+func (r *CreateCollectionFolderRequest) Validate() error {
+	if err := validateCollectionFolderName(r.Name); err != nil {
+		return err
+	}
+	if r.ParentID != nil {
+		if _, err := entities.CollectionFolderIDFromString(*r.ParentID); err != nil {
+			return fmt.Errorf("invalid parent folder ID: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *UpdateCollectionFolderRequest) Validate() error {
+	if r.Name != nil {
+		if err := validateCollectionFolderName(*r.Name); err != nil {
+			return err
+		}
+	}
+	if r.ParentID != nil && *r.ParentID != "" {
+		if _, err := entities.CollectionFolderIDFromString(*r.ParentID); err != nil {
+			return fmt.Errorf("invalid parent folder ID: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetParentID returns the parent to nest the new folder in, or nil for the
+// top level.
+func (r *CreateCollectionFolderRequest) GetParentID() *entities.CollectionFolderID {
+	if r.ParentID == nil {
+		return nil
+	}
+	id, err := entities.CollectionFolderIDFromString(*r.ParentID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// GetParentID returns the folder to move into, or nil when the folder stays
+// where it is or moves to the top level (see MovesToRoot).
+func (r *UpdateCollectionFolderRequest) GetParentID() *entities.CollectionFolderID {
+	if r.ParentID == nil || *r.ParentID == "" {
+		return nil
+	}
+	id, err := entities.CollectionFolderIDFromString(*r.ParentID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// MovesToRoot reports whether parent_id was sent empty.
+func (r *UpdateCollectionFolderRequest) MovesToRoot() bool {
+	return r.ParentID != nil && *r.ParentID == ""
+}
+
+func validateCollectionFolderName(name string) error {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > 100 {
+		return entities.ErrInvalidCollectionFolderName
+	}
+	return nil
+}
+
+func GetCollectionFolderIDFromPath(r *http.Request) (entities.CollectionFolderID, error) {
+	idStr := r.PathValue("folder_id")
+	if idStr == "" {
+		return entities.CollectionFolderID{}, errors.New("missing folder ID in path")
+	}
+
+	folderID, err := entities.CollectionFolderIDFromString(idStr)
+	if err != nil {
+		return entities.CollectionFolderID{}, fmt.Errorf("invalid folder ID: %w", err)
+	}
+
+	return folderID, nil
+}
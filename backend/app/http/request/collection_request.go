@@ -38,7 +38,10 @@ func (r *PropertySchemaRequest) ToEntity() *entities.PropertySchema {
 }
 
 type CreateCollectionRequest struct {
-	GroupID        *string                `json:"group_id,omitempty"`
+	GroupID *string `json:"group_id,omitempty"`
+	// GroupOwned makes the group rather than the caller the owner, so the
+	// collection stays with the group when the caller leaves it.
+	GroupOwned     bool                   `json:"group_owned,omitempty"`
 	Name           string                 `json:"name" binding:"required,min=1,max=255"`
 	ObjectType     string                 `json:"object_type,omitempty"`
 	Tags           []string               `json:"tags,omitempty"`
@@ -54,23 +57,96 @@ type UpdateCollectionRequest struct {
 	PropertySchema *PropertySchemaRequest `json:"property_schema,omitempty"`
 }
 
+// PatchCollectionRequest is an RFC 7386 JSON Merge Patch of a collection:
+// only the members sent change and null removes a value. Tags, being a
+// list, are replaced.
+type PatchCollectionRequest struct {
+	Name       *string  `json:"name,omitempty"`
+	ObjectType *string  `json:"object_type,omitempty"`
+	Tags       []string `json:"tags,omitzero"`
+	Location   *string  `json:"location,omitempty"`
+}
+
 // UpdatePropertySchemaRequest is used by the dedicated schema endpoint.
 type UpdatePropertySchemaRequest struct {
 	PropertySchema PropertySchemaRequest `json:"property_schema"`
 }
 
+// ShelfLifeRequest is one default shelf life rule. An empty category applies
+// to food that matches no other rule.
+type ShelfLifeRequest struct {
+	Category string `json:"category"`
+	Days     int    `json:"days"`
+}
+
+// UpdateShelfLifeRequest replaces a collection's default shelf life rules. An
+// empty list removes them.
+type UpdateShelfLifeRequest struct {
+	ShelfLife []ShelfLifeRequest `json:"shelf_life"`
+}
+
+// ToEntities validates each rule and converts it to the domain type
+func (r UpdateShelfLifeRequest) ToEntities() ([]entities.ShelfLife, error) {
+	return ShelfLifeToEntities(r.ShelfLife)
+}
+
+// ShelfLifeToEntities converts shelf life rules from a request or backup
+func ShelfLifeToEntities(rules []ShelfLifeRequest) ([]entities.ShelfLife, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	out := make([]entities.ShelfLife, len(rules))
+	for i, rule := range rules {
+		s, err := entities.NewShelfLife(rule.Category, rule.Days)
+		if err != nil {
+			return nil, fmt.Errorf("shelf life %q: %w", rule.Category, err)
+		}
+		out[i] = s
+	}
+	if err := entities.ValidateShelfLives(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (r *CreateCollectionRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
+	}
+	if r.GroupOwned && (r.GroupID == nil || *r.GroupID == "") {
+		return errors.New("group_owned requires group_id")
+	}
+	// ObjectType is optional; defaults to "general" if empty. Custom types
+	// (e.g. "electronic_supplies") must be defined for the account, which the
+	// use case checks.
+	if r.ObjectType != "" {
+		if err := entities.ValidateObjectTypeKey(entities.ObjectType(r.ObjectType)); err != nil {
+			return err
+		}
 	}
-	// ObjectType is optional; defaults to "general" if empty.
-	// Custom types (e.g. "electronic_supplies") are allowed.
 	return nil
 }
 
 func (r *UpdateCollectionRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
+	}
+	if r.ObjectType != "" {
+		if err := entities.ValidateObjectTypeKey(entities.ObjectType(r.ObjectType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PatchCollectionRequest) Validate() error {
+	if r.Name != nil && (len(*r.Name) < 1 || len(*r.Name) > 255) {
+		return fieldError("name", "name must be between 1 and 255 characters")
+	}
+	if r.ObjectType != nil {
+		if err := entities.ValidateObjectTypeKey(entities.ObjectType(*r.ObjectType)); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -87,6 +163,43 @@ func (r *CreateCollectionRequest) GetGroupID() (*entities.GroupID, error) {
 	return &groupID, nil
 }
 
+// TransferCollectionRequest moves ownership to exactly one of a group or a user.
+type TransferCollectionRequest struct {
+	ToGroupID *string `json:"to_group_id,omitempty"`
+	ToUserID  *string `json:"to_user_id,omitempty"`
+}
+
+func (r *TransferCollectionRequest) Validate() error {
+	hasGroup := r.ToGroupID != nil && *r.ToGroupID != ""
+	hasUser := r.ToUserID != nil && *r.ToUserID != ""
+	if hasGroup == hasUser {
+		return errors.New("exactly one of to_group_id or to_user_id is required")
+	}
+	return nil
+}
+
+func (r *TransferCollectionRequest) GetToGroupID() (*entities.GroupID, error) {
+	if r.ToGroupID == nil || *r.ToGroupID == "" {
+		return nil, nil
+	}
+	groupID, err := entities.GroupIDFromString(*r.ToGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupID, nil
+}
+
+func (r *TransferCollectionRequest) GetToUserID() (*entities.UserID, error) {
+	if r.ToUserID == nil || *r.ToUserID == "" {
+		return nil, nil
+	}
+	userID, err := entities.UserIDFromString(*r.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &userID, nil
+}
+
 func (r *CreateCollectionRequest) GetObjectType() entities.ObjectType {
 	if r.ObjectType == "" {
 		return entities.ObjectTypeGeneral
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CreateCollectionSnapshotRequest struct {
+	// Label is an optional note such as "before spring clean-up".
+	Label string `json:"label,omitempty" binding:"max=100"`
+}
+
+func (r *CreateCollectionSnapshotRequest) Validate() error {
+	if len(strings.TrimSpace(r.Label)) > 100 {
+		return entities.ErrInvalidSnapshotLabel
+	}
+	return nil
+}
+
+func GetSnapshotIDFromPath(r *http.Request) (entities.CollectionSnapshotID, error) {
+	idStr := r.PathValue("snapshot_id")
+	if idStr == "" {
+		return entities.CollectionSnapshotID{}, errors.New("missing snapshot ID in path")
+	}
+
+	snapshotID, err := entities.CollectionSnapshotIDFromString(idStr)
+	if err != nil {
+		return entities.CollectionSnapshotID{}, fmt.Errorf("invalid snapshot ID: %w", err)
+	}
+
+	return snapshotID, nil
+}
+
+// GetSnapshotDiffTargetFromQuery reads the optional against query parameter:
+// a later snapshot to compare with. Nil means the collection's current state.
+func GetSnapshotDiffTargetFromQuery(r *http.Request) (*entities.CollectionSnapshotID, error) {
+	against := r.URL.Query().Get("against")
+	if against == "" || against == "current" {
+		return nil, nil
+	}
+
+	snapshotID, err := entities.CollectionSnapshotIDFromString(against)
+	if err != nil {
+		return nil, fmt.Errorf("invalid against: %w", err)
+	}
+	return &snapshotID, nil
+}
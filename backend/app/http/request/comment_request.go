@@ -0,0 +1,70 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CreateCommentRequest struct {
+	// Body is the note; @username mentions notify the people named.
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+func (r *CreateCommentRequest) Validate() error {
+	body := strings.TrimSpace(r.Body)
+	if body == "" || utf8.RuneCountInString(body) > entities.MaxCommentLength {
+		return entities.ErrInvalidCommentBody
+	}
+	return nil
+}
+
+type MarkCommentsReadRequest struct {
+	// ReadAt is the newest comment time the client has shown; omit it to
+	// mark everything up to now as read.
+	ReadAt time.Time `json:"read_at,omitempty,omitzero"`
+}
+
+func GetCommentIDFromPath(r *http.Request) (entities.CommentID, error) {
+	idStr := r.PathValue("comment_id")
+	if idStr == "" {
+		return entities.CommentID{}, errors.New("missing comment ID in path")
+	}
+
+	commentID, err := entities.CommentIDFromString(idStr)
+	if err != nil {
+		return entities.CommentID{}, fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	return commentID, nil
+}
+
+// GetCommentPageFromQuery parses the optional limit and before query
+// parameters (e.g. ?limit=50&before=2024-05-01T10:00:00Z). Passing the
+// created_at of the oldest comment shown as before loads the next page.
+func GetCommentPageFromQuery(r *http.Request) (time.Time, int, error) {
+	q := r.URL.Query()
+	var before time.Time
+	limit := 0
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return time.Time{}, 0, entities.ErrInvalidCommentPage
+		}
+		limit = n
+	}
+	if v := q.Get("before"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("invalid before: %w", err)
+		}
+		before = t
+	}
+	return before, limit, nil
+}
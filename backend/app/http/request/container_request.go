@@ -4,14 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/nishiki/backend/domain/entities"
 )
 
+// MaxContainerTreeBodySize caps the size of an imported container tree YAML
+// document.
+const MaxContainerTreeBodySize = 1 << 20
+
 type CreateContainerRequest struct {
 	CollectionID      string   `json:"collection_id" binding:"required"`
 	Name              string   `json:"name" binding:"required,min=1,max=255"`
-	Type              string   `json:"type"` // room, bookshelf, shelf, binder, cabinet, general
+	Type              string   `json:"type,omitempty"` // room, bookshelf, shelf, binder, cabinet, general
 	ParentContainerID *string  `json:"parent_container_id,omitempty"`
 	GroupID           *string  `json:"group_id,omitempty"`
 	Location          string   `json:"location,omitempty"`
@@ -19,6 +24,16 @@ type CreateContainerRequest struct {
 	Depth             *float64 `json:"depth,omitempty"`
 	Rows              *int     `json:"rows,omitempty"`
 	Capacity          *float64 `json:"capacity,omitempty"`
+	TemperatureZone   string   `json:"temperature_zone,omitempty"` // frozen, chilled, ambient
+	Humidity          *float64 `json:"humidity,omitempty"`         // relative humidity in percent
+}
+
+// BulkCreateContainersRequest lists containers to create, one row per
+// container as read from a CSV or JSON file. Rows use the columns name, type,
+// parent_path (or parent) and location.
+type BulkCreateContainersRequest struct {
+	Format string           `json:"format" binding:"required"` // "csv" or "json"
+	Data   []map[string]any `json:"data" binding:"required"`
 }
 
 type UpdateContainerRequest struct {
@@ -31,57 +46,135 @@ type UpdateContainerRequest struct {
 	Depth             *float64 `json:"depth,omitempty"`
 	Rows              *int     `json:"rows,omitempty"`
 	Capacity          *float64 `json:"capacity,omitempty"`
+	TemperatureZone   *string  `json:"temperature_zone,omitempty"` // empty string clears the zone
+	Humidity          *float64 `json:"humidity,omitempty"`
+}
+
+// PatchContainerRequest is an RFC 7386 JSON Merge Patch of a container: only
+// the members sent change and null removes a value, such as the parent,
+// group, a dimension or the temperature zone.
+type PatchContainerRequest struct {
+	Name              *string  `json:"name,omitempty"`
+	Type              *string  `json:"type,omitempty"`
+	ParentContainerID *string  `json:"parent_container_id,omitempty"`
+	GroupID           *string  `json:"group_id,omitempty"`
+	Location          *string  `json:"location,omitempty"`
+	Width             *float64 `json:"width,omitempty"`
+	Depth             *float64 `json:"depth,omitempty"`
+	Rows              *int     `json:"rows,omitempty"`
+	Capacity          *float64 `json:"capacity,omitempty"`
+	TemperatureZone   *string  `json:"temperature_zone,omitempty"`
+	Humidity          *float64 `json:"humidity,omitempty"`
 }
 
 func (r *CreateContainerRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 	if r.CollectionID == "" {
-		return errors.New("collection_id is required")
+		return fieldError("collection_id", "collection_id is required")
 	}
 	// Validate container type if provided
 	if r.Type != "" && !entities.IsValidContainerType(r.Type) {
-		return fmt.Errorf("invalid container type: %s", r.Type)
+		return fieldErrorf("type", "invalid container type: %s", r.Type)
 	}
 	// Validate dimensions if provided
 	if r.Width != nil && *r.Width < 0 {
-		return errors.New("width must be non-negative")
+		return fieldError("width", "width must be non-negative")
 	}
 	if r.Depth != nil && *r.Depth < 0 {
-		return errors.New("depth must be non-negative")
+		return fieldError("depth", "depth must be non-negative")
 	}
 	if r.Rows != nil && *r.Rows < 0 {
-		return errors.New("rows must be non-negative")
+		return fieldError("rows", "rows must be non-negative")
 	}
 	if r.Capacity != nil && *r.Capacity < 0 {
-		return errors.New("capacity must be non-negative")
+		return fieldError("capacity", "capacity must be non-negative")
+	}
+	if _, err := entities.ParseTemperatureZone(r.TemperatureZone); err != nil {
+		return err
+	}
+	return entities.ValidateHumidity(r.Humidity)
+}
+
+func (r *BulkCreateContainersRequest) Validate() error {
+	if r.Format != "csv" && r.Format != "json" {
+		return fieldError("format", "format must be 'csv' or 'json'")
+	}
+	if len(r.Data) == 0 {
+		return fieldError("data", "data is required and cannot be empty")
 	}
 	return nil
 }
 
+// Column returns the row's value for the first of the columns present.
+// Headers are matched case-insensitively with spaces read as underscores, so
+// a spreadsheet's "Parent Path" is parent_path.
+func (r *BulkCreateContainersRequest) Column(row map[string]any, columns ...string) string {
+	for _, column := range columns {
+		for key, value := range row {
+			if value != nil && strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), " ", "_") == column {
+				return strings.TrimSpace(fmt.Sprint(value))
+			}
+		}
+	}
+	return ""
+}
+
 func (r *UpdateContainerRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 	// Validate container type if provided
 	if r.Type != "" && !entities.IsValidContainerType(r.Type) {
-		return fmt.Errorf("invalid container type: %s", r.Type)
+		return fieldErrorf("type", "invalid container type: %s", r.Type)
 	}
 	// Validate dimensions if provided
 	if r.Width != nil && *r.Width < 0 {
-		return errors.New("width must be non-negative")
+		return fieldError("width", "width must be non-negative")
 	}
 	if r.Depth != nil && *r.Depth < 0 {
-		return errors.New("depth must be non-negative")
+		return fieldError("depth", "depth must be non-negative")
 	}
 	if r.Rows != nil && *r.Rows < 0 {
-		return errors.New("rows must be non-negative")
+		return fieldError("rows", "rows must be non-negative")
 	}
 	if r.Capacity != nil && *r.Capacity < 0 {
-		return errors.New("capacity must be non-negative")
+		return fieldError("capacity", "capacity must be non-negative")
 	}
-	return nil
+	if r.TemperatureZone != nil {
+		if _, err := entities.ParseTemperatureZone(*r.TemperatureZone); err != nil {
+			return err
+		}
+	}
+	return entities.ValidateHumidity(r.Humidity)
+}
+
+func (r *PatchContainerRequest) Validate() error {
+	if r.Name != nil && (len(*r.Name) < 1 || len(*r.Name) > 255) {
+		return fieldError("name", "name must be between 1 and 255 characters")
+	}
+	if r.Type != nil && !entities.IsValidContainerType(*r.Type) {
+		return fieldErrorf("type", "invalid container type: %s", *r.Type)
+	}
+	if r.Width != nil && *r.Width < 0 {
+		return fieldError("width", "width must be non-negative")
+	}
+	if r.Depth != nil && *r.Depth < 0 {
+		return fieldError("depth", "depth must be non-negative")
+	}
+	if r.Rows != nil && *r.Rows < 0 {
+		return fieldError("rows", "rows must be non-negative")
+	}
+	if r.Capacity != nil && *r.Capacity < 0 {
+		return fieldError("capacity", "capacity must be non-negative")
+	}
+	if r.TemperatureZone != nil {
+		if _, err := entities.ParseTemperatureZone(*r.TemperatureZone); err != nil {
+			return err
+		}
+	}
+	return entities.ValidateHumidity(r.Humidity)
 }
 
 func (r *CreateContainerRequest) GetCollectionID() (entities.CollectionID, error) {
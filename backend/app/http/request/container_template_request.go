@@ -0,0 +1,73 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CreateContainersFromTemplateRequest struct {
+	TemplateID        string  `json:"template_id" binding:"required"`
+	ParentContainerID *string `json:"parent_container_id,omitempty"`
+	GroupID           *string `json:"group_id,omitempty"`
+}
+
+type SaveContainerTemplateRequest struct {
+	Name         string  `json:"name" binding:"required,min=1,max=100"`
+	Description  string  `json:"description,omitempty"`
+	CollectionID string  `json:"collection_id" binding:"required"`
+	ContainerID  *string `json:"container_id,omitempty"`
+}
+
+func (r *CreateContainersFromTemplateRequest) Validate() error {
+	if _, err := entities.ContainerTemplateIDFromString(r.TemplateID); err != nil {
+		return fieldError("template_id", "template_id is required and must be a valid template ID")
+	}
+	if r.ParentContainerID != nil {
+		if _, err := entities.ContainerIDFromString(*r.ParentContainerID); err != nil {
+			return errors.New("invalid parent container ID")
+		}
+	}
+	if r.GroupID != nil {
+		if _, err := entities.GroupIDFromString(*r.GroupID); err != nil {
+			return errors.New("invalid group ID")
+		}
+	}
+	return nil
+}
+
+func (r *SaveContainerTemplateRequest) Validate() error {
+	name := strings.TrimSpace(r.Name)
+	if len(name) < 1 || len(name) > 100 {
+		return fieldError("name", "name must be between 1 and 100 characters")
+	}
+	if len(r.Description) > 500 {
+		return fieldError("description", "description must be at most 500 characters")
+	}
+	if _, err := entities.CollectionIDFromString(r.CollectionID); err != nil {
+		return fieldError("collection_id", "collection_id is required and must be a valid collection ID")
+	}
+	if r.ContainerID != nil {
+		if _, err := entities.ContainerIDFromString(*r.ContainerID); err != nil {
+			return errors.New("invalid container ID")
+		}
+	}
+	return nil
+}
+
+func GetContainerTemplateIDFromPath(r *http.Request) (entities.ContainerTemplateID, error) {
+	idStr := r.PathValue("template_id")
+	if idStr == "" {
+		return entities.ContainerTemplateID{}, errors.New("missing template ID in path")
+	}
+
+	templateID, err := entities.ContainerTemplateIDFromString(idStr)
+	if err != nil {
+		return entities.ContainerTemplateID{}, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	return templateID, nil
+}
@@ -0,0 +1,62 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CreateCustomObjectTypeRequest struct {
+	// Key is the object_type collections of this type carry. Left empty it
+	// is derived from the name, e.g. "Wine Bottles" becomes "wine_bottles".
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name" binding:"required,min=1,max=50"`
+	// Icon is a short symbol, usually an emoji.
+	Icon string `json:"icon,omitempty"`
+	// Color is a hex color like "#3b82f6".
+	Color  string                 `json:"color,omitempty"`
+	Schema *PropertySchemaRequest `json:"schema,omitempty"`
+}
+
+type UpdateCustomObjectTypeRequest struct {
+	Name  *string `json:"name,omitempty"`
+	Icon  *string `json:"icon,omitempty"`
+	Color *string `json:"color,omitempty"`
+	// Schema replaces the fields new collections of the type start with; an
+	// empty definitions list removes them.
+	Schema *PropertySchemaRequest `json:"schema,omitempty"`
+}
+
+func (r *CreateCustomObjectTypeRequest) Validate() error {
+	if r.Key == "" {
+		return nil
+	}
+	ot := entities.ObjectType(r.Key)
+	if ot.IsBuiltIn() {
+		return entities.ErrBuiltInObjectType
+	}
+	return entities.ValidateObjectTypeKey(ot)
+}
+
+func (r *UpdateCustomObjectTypeRequest) Validate() error {
+	if r.Name == nil && r.Icon == nil && r.Color == nil && r.Schema == nil {
+		return errors.New("nothing to update")
+	}
+	return nil
+}
+
+func GetCustomObjectTypeIDFromPath(r *http.Request) (entities.CustomObjectTypeID, error) {
+	idStr := r.PathValue("type_id")
+	if idStr == "" {
+		return entities.CustomObjectTypeID{}, errors.New("missing object type ID in path")
+	}
+
+	typeID, err := entities.CustomObjectTypeIDFromString(idStr)
+	if err != nil {
+		return entities.CustomObjectTypeID{}, fmt.Errorf("invalid object type ID: %w", err)
+	}
+
+	return typeID, nil
+}
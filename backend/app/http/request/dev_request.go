@@ -0,0 +1,73 @@
+package request
+
+import (
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// Limits on one seed request, so a slip of the keyboard cannot fill the
+// database
+const (
+	MaxSeedCollections = 20
+	MaxSeedContainers  = 50
+	MaxSeedObjects     = 200
+	MaxSeedUsers       = 20
+)
+
+// SeedRequest asks for fixture data owned by the caller. Zero counts take
+// the defaults: one collection of three containers holding ten objects each.
+type SeedRequest struct {
+	Collections int    `json:"collections,omitempty"`
+	Containers  int    `json:"containers,omitempty"` // per collection
+	Objects     int    `json:"objects,omitempty"`    // per container
+	ObjectType  string `json:"object_type,omitempty"`
+	// Group shares the collections through a new group of this name
+	Group string `json:"group,omitempty"`
+	// Users adds this many local accounts, signing in with Password, to the
+	// group. Local auth mode only.
+	Users    int    `json:"users,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Seed makes the generated names, quantities and dates repeatable
+	Seed *uint64 `json:"seed,omitempty"`
+}
+
+func (r *SeedRequest) Validate() error {
+	if r.Collections < 0 || r.Collections > MaxSeedCollections {
+		return fieldErrorf("collections", "collections must be between 0 and %d", MaxSeedCollections)
+	}
+	if r.Containers < 0 || r.Containers > MaxSeedContainers {
+		return fieldErrorf("containers", "containers must be between 0 and %d", MaxSeedContainers)
+	}
+	if r.Objects < 0 || r.Objects > MaxSeedObjects {
+		return fieldErrorf("objects", "objects must be between 0 and %d", MaxSeedObjects)
+	}
+	if r.ObjectType != "" && !entities.ObjectType(r.ObjectType).IsBuiltIn() {
+		return fieldError("object_type", "object_type must be a built-in object type")
+	}
+	if r.Users < 0 || r.Users > MaxSeedUsers {
+		return fieldErrorf("users", "users must be between 0 and %d", MaxSeedUsers)
+	}
+	if r.Users > 0 && r.Group == "" {
+		return fieldError("group", "group is required to add users to")
+	}
+	if r.Users > 0 && (len(r.Password) < 8 || len(r.Password) > 72) {
+		return fieldError("password", "password must be between 8 and 72 bytes")
+	}
+	return nil
+}
+
+// WithDefaults returns the request with zero counts replaced by the defaults
+func (r SeedRequest) WithDefaults() SeedRequest {
+	if r.Collections == 0 {
+		r.Collections = 1
+	}
+	if r.Containers == 0 {
+		r.Containers = 3
+	}
+	if r.Objects == 0 {
+		r.Objects = 10
+	}
+	if r.ObjectType == "" {
+		r.ObjectType = string(entities.ObjectTypeFood)
+	}
+	return r
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type UpdateDigestPreferencesRequest struct {
+	// Frequency is one of off, daily or weekly.
+	Frequency string `json:"frequency" binding:"required"`
+}
+
+// GetFrequency parses Frequency.
+func (r *UpdateDigestPreferencesRequest) GetFrequency() (entities.DigestFrequency, error) {
+	return entities.NewDigestFrequency(r.Frequency)
+}
+
+// GetUnsubscribeToken reads the token of an unsubscribe link: the ?token=
+// parameter of the link itself, or the token field of the confirmation form.
+func GetUnsubscribeToken(r *http.Request) (string, error) {
+	token := r.FormValue("token")
+	if token == "" {
+		return "", errors.New("missing unsubscribe token")
+	}
+	return token, nil
+}
@@ -0,0 +1,41 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// SetFeatureFlagRequest replaces the flag of the feature in the path. With
+// enabled false, the feature is on only for the listed users and groups.
+type SetFeatureFlagRequest struct {
+	Enabled bool `json:"enabled"`
+	// Users lists user IDs or usernames
+	Users []string `json:"users,omitempty"`
+	// Groups lists group names, as in the token's groups claim
+	Groups      []string `json:"groups,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+func (r *SetFeatureFlagRequest) Validate() error {
+	if len(r.Users) > entities.MaxFeatureFlagTargets {
+		return fieldErrorf("users", "users must list at most %d entries", entities.MaxFeatureFlagTargets)
+	}
+	if len(r.Groups) > entities.MaxFeatureFlagTargets {
+		return fieldErrorf("groups", "groups must list at most %d entries", entities.MaxFeatureFlagTargets)
+	}
+	if len(r.Description) > 500 {
+		return fieldError("description", "description must be at most 500 characters")
+	}
+	return nil
+}
+
+// GetFeatureNameFromPath reads the {name} segment from
+// /admin/features/{name}.
+func GetFeatureNameFromPath(r *http.Request) (string, error) {
+	name := r.PathValue("name")
+	if !entities.ValidFeatureName(name) {
+		return "", entities.ErrInvalidFeatureName
+	}
+	return name, nil
+}
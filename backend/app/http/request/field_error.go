@@ -0,0 +1,32 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/nishiki/backend/app/http/httputil"
+)
+
+// FieldError is a Validate failure caused by one field of the request. Its
+// message reads on its own, e.g. "name must be between 1 and 255
+// characters"; Field lets the error response point a form at the input.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// FieldErrors lists the field for httputil.InvalidRequest
+func (e *FieldError) FieldErrors() []httputil.FieldError {
+	return []httputil.FieldError{{Field: e.Field, Message: e.Message}}
+}
+
+func fieldError(field, message string) error {
+	return &FieldError{Field: field, Message: message}
+}
+
+func fieldErrorf(field, format string, args ...any) error {
+	return &FieldError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
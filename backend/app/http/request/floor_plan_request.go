@@ -0,0 +1,105 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// MaxContainerPlacementsPerRequest caps how many containers one request may
+// move on the floor plan.
+const MaxContainerPlacementsPerRequest = 500
+
+// ContainerPlacementRequest is where one container goes on the floor plan.
+// X and Y are fractions of the plan's width and height from its top left
+// corner; leaving out both, floor and room takes the container off the plan.
+type ContainerPlacementRequest struct {
+	ContainerID string   `json:"container_id"`
+	X           *float64 `json:"x,omitempty"`
+	Y           *float64 `json:"y,omitempty"`
+	Floor       string   `json:"floor,omitempty"`
+	Room        string   `json:"room,omitempty"`
+}
+
+type UpdateContainerPlacementsRequest struct {
+	Placements []ContainerPlacementRequest `json:"placements"`
+}
+
+func (r *UpdateContainerPlacementsRequest) Validate() error {
+	if len(r.Placements) == 0 {
+		return fieldError("placements", "at least one placement is required")
+	}
+	if len(r.Placements) > MaxContainerPlacementsPerRequest {
+		return fieldErrorf("placements", "at most %d containers can be placed at once", MaxContainerPlacementsPerRequest)
+	}
+	seen := make(map[string]bool, len(r.Placements))
+	for i, p := range r.Placements {
+		if _, err := p.toEntity(); err != nil {
+			return fieldErrorf(fmt.Sprintf("placements[%d]", i), "%s", err.Error())
+		}
+		if seen[p.ContainerID] {
+			return fieldErrorf(fmt.Sprintf("placements[%d].container_id", i), "container %s is listed twice", p.ContainerID)
+		}
+		seen[p.ContainerID] = true
+	}
+	return nil
+}
+
+// ToEntities returns the container IDs and placements, in request order.
+// Call Validate first.
+func (r *UpdateContainerPlacementsRequest) ToEntities() ([]entities.ContainerID, []entities.ContainerPlacement, error) {
+	ids := make([]entities.ContainerID, len(r.Placements))
+	placements := make([]entities.ContainerPlacement, len(r.Placements))
+	for i, p := range r.Placements {
+		id, err := entities.ContainerIDFromString(p.ContainerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		placement, err := p.toEntity()
+		if err != nil {
+			return nil, nil, err
+		}
+		ids[i], placements[i] = id, placement
+	}
+	return ids, placements, nil
+}
+
+func (p ContainerPlacementRequest) toEntity() (entities.ContainerPlacement, error) {
+	if _, err := entities.ContainerIDFromString(p.ContainerID); err != nil {
+		return entities.ContainerPlacement{}, err
+	}
+	return entities.NewContainerPlacement(p.X, p.Y, p.Floor, p.Room)
+}
+
+// ReadFloorPlanFile reads the single "file" part of a multipart/form-data
+// floor plan upload. Like ReadMediaFiles, a file larger than maxFileSize is
+// returned truncated to maxFileSize+1 bytes for the caller to report.
+func ReadFloorPlanFile(w http.ResponseWriter, r *http.Request, maxFileSize int64) (MediaFile, error) {
+	maxBody := maxFileSize + 1<<20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+	if err := r.ParseMultipartForm(mediaFormMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return MediaFile{}, fmt.Errorf("%w: the upload exceeds %d bytes", entities.ErrMediaTooLarge, maxBody)
+		}
+		return MediaFile{}, fmt.Errorf("invalid multipart upload: %w", err)
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	headers := r.MultipartForm.File["file"]
+	if len(headers) != 1 {
+		return MediaFile{}, errors.New("exactly one image is required in the file field")
+	}
+	fh := headers[0]
+	data, err := readMediaPart(fh, maxFileSize)
+	if err != nil {
+		return MediaFile{}, err
+	}
+	contentType := fh.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(data)
+	}
+	return MediaFile{Filename: fh.Filename, ContentType: contentType, Data: data}, nil
+}
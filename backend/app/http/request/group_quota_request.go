@@ -0,0 +1,17 @@
+package request
+
+import "errors"
+
+// SetGroupQuotaRequest replaces a group's limits; 0 leaves one unlimited
+type SetGroupQuotaRequest struct {
+	MaxContainers   int   `json:"max_containers"`
+	MaxObjects      int   `json:"max_objects"`
+	MaxStorageBytes int64 `json:"max_storage_bytes"`
+}
+
+func (r *SetGroupQuotaRequest) Validate() error {
+	if r.MaxContainers < 0 || r.MaxObjects < 0 || r.MaxStorageBytes < 0 {
+		return errors.New("limits cannot be negative; use 0 for unlimited")
+	}
+	return nil
+}
@@ -22,16 +22,38 @@ type JoinGroupRequest struct {
 	InvitationHash string `json:"invitationHash" binding:"required"`
 }
 
+type InviteGroupMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type SetGroupMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=member admin"`
+}
+
+func (r *InviteGroupMemberRequest) Validate() error {
+	if r.Email == "" {
+		return fieldError("email", "email is required")
+	}
+	return nil
+}
+
+func (r *SetGroupMemberRoleRequest) Validate() error {
+	if r.Role == "" {
+		return fieldError("role", "role is required")
+	}
+	return nil
+}
+
 func (r *CreateGroupRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 	return nil
 }
 
 func (r *UpdateGroupRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 	return nil
 }
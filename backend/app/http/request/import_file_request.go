@@ -0,0 +1,155 @@
+package request
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MaxImportFileSize caps an import file uploaded as multipart/form-data.
+const MaxImportFileSize = 32 << 20
+
+// IsMultipart reports whether the request body is multipart/form-data.
+func IsMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// ReadBulkImportCollectionForm reads a collection import uploaded as
+// multipart/form-data: the CSV or JSON file in the file field, and the other
+// BulkImportCollectionRequest options as form fields of the same names.
+// default_tags and omit_columns may be repeated or comma-separated; columns
+// in omit_columns are dropped from every row. The format is taken from the
+// format field, or else the file's extension.
+func ReadBulkImportCollectionForm(w http.ResponseWriter, r *http.Request) (*BulkImportCollectionRequest, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportFileSize+1<<20)
+	if err := r.ParseMultipartForm(mediaFormMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("import file exceeds %d bytes", MaxImportFileSize)
+		}
+		return nil, fmt.Errorf("invalid multipart upload: %w", err)
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.New("an import file is required in the file field")
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, MaxImportFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", header.Filename, err)
+	}
+	if len(content) > MaxImportFileSize {
+		return nil, fmt.Errorf("import file exceeds %d bytes", MaxImportFileSize)
+	}
+
+	req := &BulkImportCollectionRequest{
+		Format:           strings.ToLower(r.FormValue("format")),
+		DistributionMode: r.FormValue("distribution_mode"),
+		DefaultTags:      formList(r, "default_tags"),
+		LocationColumn:   r.FormValue("location_column"),
+		NameColumn:       r.FormValue("name_column"),
+		SourceFormat:     r.FormValue("source_format"),
+	}
+	if req.Format == "" {
+		req.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+	if v := r.FormValue("target_container_id"); v != "" {
+		req.TargetContainerID = &v
+	}
+	if v := r.FormValue("infer_schema"); v != "" {
+		if req.InferSchema, err = strconv.ParseBool(v); err != nil {
+			return nil, errors.New("infer_schema must be true or false")
+		}
+	}
+
+	switch req.Format {
+	case "csv":
+		req.Data, err = parseImportCSV(content)
+	case "json":
+		req.Data, err = parseImportJSON(content)
+	default:
+		return nil, errors.New("format must be 'csv' or 'json'")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if omit := formList(r, "omit_columns"); len(omit) > 0 {
+		for _, row := range req.Data {
+			for _, column := range omit {
+				delete(row, column)
+			}
+		}
+	}
+	return req, nil
+}
+
+// formList reads a form field that may be repeated or hold a comma-separated
+// list.
+func formList(r *http.Request, key string) []string {
+	var list []string
+	for _, value := range r.MultipartForm.Value[key] {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// parseImportCSV reads a CSV file with a header row into one map per data
+// row. Values are trimmed and empty ones left out, as are rows with no values;
+// rows shorter than the header leave the missing columns out.
+func parseImportCSV(content []byte) ([]map[string]any, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("CSV file must have at least a header row and one data row")
+	}
+
+	headers := records[0]
+	for i, h := range headers {
+		headers[i] = strings.TrimSpace(h)
+	}
+	data := make([]map[string]any, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]any, len(headers))
+		for i, h := range headers {
+			if i < len(record) && h != "" {
+				if v := strings.TrimSpace(record[i]); v != "" {
+					row[h] = v
+				}
+			}
+		}
+		// Spreadsheets often end in rows of empty cells
+		if len(row) > 0 {
+			data = append(data, row)
+		}
+	}
+	return data, nil
+}
+
+// parseImportJSON reads a JSON file holding an array of objects.
+func parseImportJSON(content []byte) ([]map[string]any, error) {
+	var data []map[string]any
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: the file must hold an array of objects: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,88 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type InboxLineRequest struct {
+	Name     string   `json:"name" binding:"required,min=1,max=255"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+	Price    *float64 `json:"price,omitempty"`
+}
+
+type ConfirmInboxItemRequest struct {
+	CollectionID string `json:"collection_id" binding:"required"`
+	// ContainerID defaults to the collection's General container.
+	ContainerID *string `json:"container_id,omitempty"`
+	// Lines are the lines to create, corrected by the user; when absent
+	// every line is created as received.
+	Lines *[]InboxLineRequest `json:"lines,omitempty"`
+}
+
+func (r *ConfirmInboxItemRequest) Validate() error {
+	if _, err := entities.CollectionIDFromString(r.CollectionID); err != nil {
+		return fieldError("collection_id", "collection_id is required and must be a valid ID")
+	}
+	if r.ContainerID != nil {
+		if _, err := entities.ContainerIDFromString(*r.ContainerID); err != nil {
+			return fieldError("container_id", "container_id must be a valid ID")
+		}
+	}
+	if r.Lines == nil {
+		return nil
+	}
+	if len(*r.Lines) == 0 {
+		return fieldError("lines", "lines must not be empty; dismiss the item instead")
+	}
+	for i, line := range *r.Lines {
+		name := strings.TrimSpace(line.Name)
+		if len(name) < 1 || len(name) > 255 {
+			return fieldErrorf("lines", "lines[%d]: name must be between 1 and 255 characters", i)
+		}
+		if line.Quantity != nil && *line.Quantity < 0 {
+			return fieldErrorf("lines", "lines[%d]: quantity must not be negative", i)
+		}
+		if line.Price != nil && *line.Price < 0 {
+			return fieldErrorf("lines", "lines[%d]: price must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// ToInboxLines converts validated lines to entities, or nil when the
+// request has none
+func (r *ConfirmInboxItemRequest) ToInboxLines() []entities.InboxLine {
+	if r.Lines == nil {
+		return nil
+	}
+	out := make([]entities.InboxLine, len(*r.Lines))
+	for i, line := range *r.Lines {
+		out[i] = entities.InboxLine{
+			Name:     strings.TrimSpace(line.Name),
+			Quantity: line.Quantity,
+			Unit:     strings.TrimSpace(line.Unit),
+			Price:    line.Price,
+		}
+	}
+	return out
+}
+
+func GetInboxItemIDFromPath(r *http.Request) (entities.InboxItemID, error) {
+	idStr := r.PathValue("item_id")
+	if idStr == "" {
+		return entities.InboxItemID{}, errors.New("missing inbox item ID in path")
+	}
+
+	itemID, err := entities.InboxItemIDFromString(idStr)
+	if err != nil {
+		return entities.InboxItemID{}, fmt.Errorf("invalid inbox item ID: %w", err)
+	}
+
+	return itemID, nil
+}
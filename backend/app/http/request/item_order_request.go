@@ -0,0 +1,34 @@
+package request
+
+import "github.com/nishiki/backend/domain/entities"
+
+type ItemOrderRequest struct {
+	ID string `json:"id"`
+	// SortIndex is the item's position under the custom sort, starting at 1;
+	// 0 leaves the item unplaced.
+	SortIndex int  `json:"sort_index"`
+	Pinned    bool `json:"pinned"`
+}
+
+type UpdateItemOrderRequest struct {
+	// Items are the collections or containers to place. Items not listed keep
+	// their placement.
+	Items []ItemOrderRequest `json:"items" binding:"required"`
+}
+
+func (r *UpdateItemOrderRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return fieldError("items", "items is required")
+	}
+	return nil
+}
+
+// ToItemOrder converts the request items to entities, keyed by item ID.
+// Validation is left to entities.UserPreferences.SetItemOrder.
+func (r *UpdateItemOrderRequest) ToItemOrder() map[string]entities.ItemOrder {
+	items := make(map[string]entities.ItemOrder, len(r.Items))
+	for _, item := range r.Items {
+		items[item.ID] = entities.ItemOrder{SortIndex: item.SortIndex, Pinned: item.Pinned}
+	}
+	return items
+}
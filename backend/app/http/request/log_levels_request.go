@@ -0,0 +1,29 @@
+package request
+
+import "errors"
+
+// UpdateLogLevelsRequest changes log levels on the running server. Level
+// sets the default that sinks without a level of their own follow; each
+// entry in Sinks sets one sink's level, or with an empty level makes it
+// follow the default again. The changes last until the next restart.
+type UpdateLogLevelsRequest struct {
+	Level string             `json:"level,omitempty"`
+	Sinks []SinkLevelRequest `json:"sinks,omitempty"`
+}
+
+type SinkLevelRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Level string `json:"level"`
+}
+
+func (r *UpdateLogLevelsRequest) Validate() error {
+	if r.Level == "" && len(r.Sinks) == 0 {
+		return errors.New("level or sinks is required")
+	}
+	for _, sink := range r.Sinks {
+		if sink.Name == "" {
+			return errors.New("every sink needs a name")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type MealIngredientRequest struct {
+	ObjectID string  `json:"object_id" binding:"required"`
+	Quantity float64 `json:"quantity" binding:"required"`
+	// Unit defaults to the object's own unit when empty.
+	Unit string `json:"unit,omitempty"`
+}
+
+type CreateMealPlanRequest struct {
+	// Date is a calendar day in YYYY-MM-DD form.
+	Date        string                  `json:"date" binding:"required"`
+	Meal        string                  `json:"meal" binding:"required"`
+	RecipeName  string                  `json:"recipe_name" binding:"required,min=1,max=200"`
+	Ingredients []MealIngredientRequest `json:"ingredients,omitempty"`
+}
+
+type UpdateMealPlanRequest struct {
+	Date       *string `json:"date,omitempty"`
+	Meal       *string `json:"meal,omitempty"`
+	RecipeName *string `json:"recipe_name,omitempty"`
+	// Ingredients replaces the linked objects when present; an empty list
+	// removes them all.
+	Ingredients *[]MealIngredientRequest `json:"ingredients,omitempty"`
+}
+
+func (r *CreateMealPlanRequest) Validate() error {
+	if _, err := ParseMealPlanDate(r.Date); err != nil {
+		return err
+	}
+	if !entities.IsValidMealType(r.Meal) {
+		return entities.ErrInvalidMealType
+	}
+	name := strings.TrimSpace(r.RecipeName)
+	if len(name) < 1 || len(name) > 200 {
+		return entities.ErrInvalidRecipeName
+	}
+	return validateMealIngredients(r.Ingredients)
+}
+
+func (r *UpdateMealPlanRequest) Validate() error {
+	if r.Date != nil {
+		if _, err := ParseMealPlanDate(*r.Date); err != nil {
+			return err
+		}
+	}
+	if r.Meal != nil && !entities.IsValidMealType(*r.Meal) {
+		return entities.ErrInvalidMealType
+	}
+	if r.RecipeName != nil {
+		name := strings.TrimSpace(*r.RecipeName)
+		if len(name) < 1 || len(name) > 200 {
+			return entities.ErrInvalidRecipeName
+		}
+	}
+	if r.Ingredients != nil {
+		return validateMealIngredients(*r.Ingredients)
+	}
+	return nil
+}
+
+func validateMealIngredients(ingredients []MealIngredientRequest) error {
+	for i, ing := range ingredients {
+		if _, err := entities.ObjectIDFromHex(ing.ObjectID); err != nil {
+			return fmt.Errorf("ingredients[%d]: invalid object ID", i)
+		}
+		if ing.Quantity <= 0 {
+			return fmt.Errorf("ingredients[%d]: %w", i, entities.ErrInvalidMealIngredient)
+		}
+	}
+	return nil
+}
+
+// ToMealIngredients converts validated ingredient requests to entities.
+// Names are filled in from the linked objects by the use case.
+func ToMealIngredients(ingredients []MealIngredientRequest) []entities.MealIngredient {
+	out := make([]entities.MealIngredient, len(ingredients))
+	for i, ing := range ingredients {
+		objectID, _ := entities.ObjectIDFromHex(ing.ObjectID)
+		out[i] = entities.MealIngredient{
+			ObjectID: objectID,
+			Quantity: ing.Quantity,
+			Unit:     strings.TrimSpace(ing.Unit),
+		}
+	}
+	return out
+}
+
+// ParseMealPlanDate parses a YYYY-MM-DD day as midnight UTC.
+func ParseMealPlanDate(s string) (time.Time, error) {
+	date, err := time.Parse(entities.MealPlanDateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date must be formatted as %s", entities.MealPlanDateLayout)
+	}
+	return date, nil
+}
+
+// GetMealPlanRangeFromQuery reads the from and to query parameters. Both
+// default to the Monday-to-Monday week containing today.
+func GetMealPlanRangeFromQuery(r *http.Request) (time.Time, time.Time, error) {
+	today := entities.MealPlanDay(time.Now())
+	weekday := (int(today.Weekday()) + 6) % 7
+	from := today.AddDate(0, 0, -weekday)
+	to := from.AddDate(0, 0, 7)
+
+	q := r.URL.Query()
+	if s := q.Get("from"); s != "" {
+		d, err := ParseMealPlanDate(s)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+		}
+		from = d
+		if q.Get("to") == "" {
+			to = from.AddDate(0, 0, 7)
+		}
+	}
+	if s := q.Get("to"); s != "" {
+		d, err := ParseMealPlanDate(s)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+		}
+		to = d
+	}
+	return from, to, nil
+}
+
+func GetMealPlanIDFromPath(r *http.Request) (entities.MealPlanID, error) {
+	idStr := r.PathValue("meal_plan_id")
+	if idStr == "" {
+		return entities.MealPlanID{}, errors.New("missing meal plan ID in path")
+	}
+
+	mealPlanID, err := entities.MealPlanIDFromString(idStr)
+	if err != nil {
+		return entities.MealPlanID{}, fmt.Errorf("invalid meal plan ID: %w", err)
+	}
+
+	return mealPlanID, nil
+}
@@ -0,0 +1,106 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// MaxMediaFilesPerUpload caps how many photos one upload request may carry.
+const MaxMediaFilesPerUpload = 20
+
+// mediaFormMemory is how much of a multipart upload is held in memory before
+// the rest spills to temporary files.
+const mediaFormMemory = 32 << 20
+
+// MediaFile is one photo read from a multipart upload.
+type MediaFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// GetMediaIDFromPath reads the {media_id} path parameter.
+func GetMediaIDFromPath(r *http.Request) (entities.MediaID, error) {
+	id := r.PathValue("media_id")
+	if id == "" {
+		return entities.MediaID{}, errors.New("media ID is required")
+	}
+	return entities.MediaIDFromString(id)
+}
+
+// GetMediaPageFromQuery parses the optional limit and offset query
+// parameters (e.g. ?limit=24&offset=48).
+func GetMediaPageFromQuery(r *http.Request) (entities.MediaPage, error) {
+	q := r.URL.Query()
+	limit, offset := 0, 0
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return entities.MediaPage{}, entities.ErrInvalidMediaPage
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil {
+			return entities.MediaPage{}, entities.ErrInvalidMediaPage
+		}
+	}
+	return entities.NewMediaPage(limit, offset)
+}
+
+// ReadMediaFiles reads the "files" parts of a multipart/form-data upload.
+// A file larger than maxFileSize is returned truncated to maxFileSize+1 bytes
+// so the caller can report it without the whole body being buffered.
+func ReadMediaFiles(w http.ResponseWriter, r *http.Request, maxFileSize int64) ([]MediaFile, error) {
+	maxBody := MaxMediaFilesPerUpload*(maxFileSize+1) + 1<<20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+	if err := r.ParseMultipartForm(mediaFormMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("%w: the upload exceeds %d bytes", entities.ErrMediaTooLarge, maxBody)
+		}
+		return nil, fmt.Errorf("invalid multipart upload: %w", err)
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	headers := r.MultipartForm.File["files"]
+	if len(headers) == 0 {
+		return nil, errors.New("at least one file is required in the files field")
+	}
+	if len(headers) > MaxMediaFilesPerUpload {
+		return nil, fmt.Errorf("at most %d files can be uploaded at once", MaxMediaFilesPerUpload)
+	}
+
+	files := make([]MediaFile, 0, len(headers))
+	for _, fh := range headers {
+		data, err := readMediaPart(fh, maxFileSize)
+		if err != nil {
+			return nil, err
+		}
+		contentType := fh.Header.Get("Content-Type")
+		if contentType == "" || contentType == "application/octet-stream" {
+			contentType = http.DetectContentType(data)
+		}
+		files = append(files, MediaFile{Filename: fh.Filename, ContentType: contentType, Data: data})
+	}
+	return files, nil
+}
+
+func readMediaPart(fh *multipart.FileHeader, maxFileSize int64) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fh.Filename, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fh.Filename, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,7 @@
+package request
+
+// EnrichNutritionRequest asks for an object's nutrition facts to be looked
+// up. UPC may be left empty to use the object's stored upc property.
+type EnrichNutritionRequest struct {
+	UPC string `json:"upc,omitempty"`
+}
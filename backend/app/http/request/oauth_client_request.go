@@ -0,0 +1,45 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+)
+
+type RegisterOAuthClientRequest struct {
+	ProviderName string `json:"provider_name" binding:"required"`
+	ClientID     string `json:"client_id" binding:"required"`
+	ClientSecret string `json:"client_secret" binding:"required"`
+	RedirectURL  string `json:"redirect_url" binding:"required"`
+}
+
+// UpdateOAuthClientRequest replaces a registered client's settings. An
+// empty client_secret keeps the current secret.
+type UpdateOAuthClientRequest struct {
+	ProviderName string `json:"provider_name" binding:"required"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	RedirectURL  string `json:"redirect_url" binding:"required"`
+}
+
+func (r *RegisterOAuthClientRequest) Validate() error {
+	if r.ProviderName == "" || r.ClientID == "" || r.ClientSecret == "" || r.RedirectURL == "" {
+		return errors.New("provider_name, client_id, client_secret and redirect_url are required")
+	}
+	return nil
+}
+
+func (r *UpdateOAuthClientRequest) Validate() error {
+	if r.ProviderName == "" || r.RedirectURL == "" {
+		return errors.New("provider_name and redirect_url are required")
+	}
+	return nil
+}
+
+// GetOAuthClientIDFromPath reads the {client_id} segment from
+// /admin/oauth-clients/{client_id}.
+func GetOAuthClientIDFromPath(r *http.Request) (string, error) {
+	id := r.PathValue("client_id")
+	if id == "" {
+		return "", errors.New("missing client_id in path")
+	}
+	return id, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nishiki/backend/domain/entities"
@@ -17,6 +19,9 @@ type CreateObjectRequest struct {
 	Location    string         `json:"location,omitempty"`
 	Quantity    *float64       `json:"quantity,omitempty"`
 	Unit        string         `json:"unit,omitempty"`
+	MinQuantity *float64       `json:"min_quantity,omitempty"`
+	Condition   string         `json:"condition,omitempty"` // mint, near_mint, good, fair or poor
+	Status      string         `json:"status,omitempty"`    // owned (default), wanted or ordered
 	Properties  map[string]any `json:"properties,omitempty"`
 	Tags        []string       `json:"tags,omitempty"`
 	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
@@ -29,24 +34,110 @@ type UpdateObjectRequest struct {
 	Location    *string        `json:"location,omitempty"`
 	Quantity    *float64       `json:"quantity,omitempty"`
 	Unit        *string        `json:"unit,omitempty"`
+	MinQuantity *float64       `json:"min_quantity,omitempty"` // negative clears the threshold
+	Condition   *string        `json:"condition,omitempty"`    // empty clears the grade
+	Status      *string        `json:"status,omitempty"`
 	Properties  map[string]any `json:"properties,omitempty"`
 	Tags        []string       `json:"tags,omitempty"`
 	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
 }
 
+// PatchObjectRequest is an RFC 7386 JSON Merge Patch of an object: only the
+// members sent change and null removes a value. Properties are merged into
+// the object's current ones; tags, being a list, are replaced. A container_id
+// moves the object.
+type PatchObjectRequest struct {
+	ContainerID *string        `json:"container_id,omitempty"`
+	Name        *string        `json:"name,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Location    *string        `json:"location,omitempty"`
+	Quantity    *float64       `json:"quantity,omitempty"`
+	Unit        *string        `json:"unit,omitempty"`
+	MinQuantity *float64       `json:"min_quantity,omitempty"` // negative clears the threshold
+	Condition   *string        `json:"condition,omitempty"`
+	Status      *string        `json:"status,omitempty"`
+	Properties  map[string]any `json:"properties,omitempty"`
+	Tags        []string       `json:"tags,omitzero"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+}
+
+// ArchiveObjectRequest toggles whether an object is archived.
+type ArchiveObjectRequest struct {
+	Archived *bool `json:"archived"`
+}
+
+// ClaimObjectRequest reserves an object for the caller. The body is
+// optional; without until the claim lasts a day.
+type ClaimObjectRequest struct {
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// CopyObjectRequest duplicates an object. The body is optional; without
+// container_id the copy goes next to the original.
+type CopyObjectRequest struct {
+	ContainerID string `json:"container_id,omitempty"`
+}
+
+// MergeObjectsRequest folds two or more objects of one collection into the
+// oldest of them. With preview set the merged object is returned unsaved.
+type MergeObjectsRequest struct {
+	ObjectIDs []string `json:"object_ids"`
+	Preview   bool     `json:"preview,omitempty"`
+}
+
+// RetagObjectsRequest adds and removes tags on every object of a collection
+// matching the filter.
+type RetagObjectsRequest struct {
+	Filter     RetagFilter `json:"filter"`
+	AddTags    []string    `json:"add_tags,omitempty"`
+	RemoveTags []string    `json:"remove_tags,omitempty"`
+}
+
+// RetagFilter picks the objects to retag; every field set must match and an
+// empty filter matches the whole collection.
+type RetagFilter struct {
+	Tag         string   `json:"tag,omitempty"`
+	ContainerID string   `json:"container_id,omitempty"`
+	ObjectType  string   `json:"object_type,omitempty"`
+	ObjectIDs   []string `json:"object_ids,omitempty"`
+}
+
+// BatchObjectsRequest moves or deletes the named objects of a collection.
+// A move needs container_id, a container of the same collection.
+type BatchObjectsRequest struct {
+	Operation   string   `json:"operation"`
+	ObjectIDs   []string `json:"object_ids"`
+	ContainerID string   `json:"container_id,omitempty"`
+}
+
+// ParseObjectRequest is a phrase to turn into object fields, such as "three
+// cans of tomatoes in the pantry". With collection_id set, the place in the
+// phrase is matched against that collection's containers.
+type ParseObjectRequest struct {
+	Text         string `json:"text"`
+	CollectionID string `json:"collection_id,omitempty"`
+}
+
 func (r *CreateObjectRequest) Validate() error {
 	if len(r.Name) < 1 || len(r.Name) > 255 {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
 	}
 
-	// Validate object type
-	objectType := entities.ObjectType(r.ObjectType)
-	switch objectType {
-	case entities.ObjectTypeFood, entities.ObjectTypeBook, entities.ObjectTypeVideoGame,
-		entities.ObjectTypeMusic, entities.ObjectTypeBoardGame, entities.ObjectTypeGeneral:
-		// Valid object type
-	default:
-		return fmt.Errorf("invalid object_type: %s", r.ObjectType)
+	// Built-in or custom; a custom type must be the collection's own
+	if err := entities.ValidateObjectTypeKey(entities.ObjectType(r.ObjectType)); err != nil {
+		return fieldErrorf("object_type", "invalid object_type: %s", r.ObjectType)
+	}
+
+	if r.MinQuantity != nil && *r.MinQuantity < 0 {
+		return entities.ErrInvalidMinQuantity
+	}
+
+	if _, err := entities.ParseCondition(r.Condition); err != nil {
+		return err
+	}
+
+	if _, err := entities.ParseObjectStatus(r.Status); err != nil {
+		return err
 	}
 
 	return nil
@@ -54,11 +145,184 @@ func (r *CreateObjectRequest) Validate() error {
 
 func (r *UpdateObjectRequest) Validate() error {
 	if r.Name != nil && (len(*r.Name) < 1 || len(*r.Name) > 255) {
-		return errors.New("name must be between 1 and 255 characters")
+		return fieldError("name", "name must be between 1 and 255 characters")
+	}
+	if _, err := parseConditionUpdate(r.Condition); err != nil {
+		return err
+	}
+	if _, err := parseStatusUpdate(r.Status); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *PatchObjectRequest) Validate() error {
+	if r.Name != nil && (len(*r.Name) < 1 || len(*r.Name) > 255) {
+		return fieldError("name", "name must be between 1 and 255 characters")
+	}
+	if _, err := parseConditionUpdate(r.Condition); err != nil {
+		return err
+	}
+	if _, err := parseStatusUpdate(r.Status); err != nil {
+		return err
+	}
+	if r.Tags != nil {
+		r.Tags = trimTags(r.Tags)
+	}
+	return nil
+}
+
+func (r *ArchiveObjectRequest) Validate() error {
+	if r.Archived == nil {
+		return fieldError("archived", "archived is required")
+	}
+	return nil
+}
+
+func (r *MergeObjectsRequest) Validate() error {
+	if len(r.ObjectIDs) < 2 {
+		return fieldError("object_ids", "object_ids must contain at least two objects")
+	}
+	return nil
+}
+
+// Validate trims the tag lists, dropping blanks, and checks the filter.
+func (r *RetagObjectsRequest) Validate() error {
+	r.AddTags = trimTags(r.AddTags)
+	r.RemoveTags = trimTags(r.RemoveTags)
+	if len(r.AddTags) == 0 && len(r.RemoveTags) == 0 {
+		return errors.New("add_tags or remove_tags must name at least one tag")
+	}
+	if r.Filter.ObjectType != "" && entities.ValidateObjectTypeKey(entities.ObjectType(r.Filter.ObjectType)) != nil {
+		return fieldErrorf("filter.object_type", "invalid object_type: %s", r.Filter.ObjectType)
+	}
+	return nil
+}
+
+func trimTags(tags []string) []string {
+	trimmed := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			trimmed = append(trimmed, tag)
+		}
+	}
+	return trimmed
+}
+
+// GetContainerID parses the filter's container_id, nil when unset.
+func (f *RetagFilter) GetContainerID() (*entities.ContainerID, error) {
+	if f.ContainerID == "" {
+		return nil, nil
+	}
+	id, err := entities.ContainerIDFromString(f.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container id: %s", f.ContainerID)
+	}
+	return &id, nil
+}
+
+// GetObjectIDs parses the filter's object_ids.
+func (f *RetagFilter) GetObjectIDs() ([]entities.ObjectID, error) {
+	ids := make([]entities.ObjectID, len(f.ObjectIDs))
+	for i, raw := range f.ObjectIDs {
+		id, err := entities.ObjectIDFromHex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object id: %s", raw)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (r *BatchObjectsRequest) Validate() error {
+	switch entities.BatchOperation(r.Operation) {
+	case entities.BatchMove:
+		if r.ContainerID == "" {
+			return fieldError("container_id", "container_id is required to move objects")
+		}
+	case entities.BatchDelete:
+	default:
+		return fieldErrorf("operation", "operation must be move or delete, not %q", r.Operation)
+	}
+	if len(r.ObjectIDs) == 0 {
+		return fieldError("object_ids", "object_ids must name at least one object")
+	}
+	if len(r.ObjectIDs) > entities.MaxBatchObjects {
+		return fieldErrorf("object_ids", "object_ids can name at most %d objects", entities.MaxBatchObjects)
+	}
+	return nil
+}
+
+// GetObjectIDs parses the request's object_ids.
+func (r *BatchObjectsRequest) GetObjectIDs() ([]entities.ObjectID, error) {
+	ids := make([]entities.ObjectID, len(r.ObjectIDs))
+	for i, raw := range r.ObjectIDs {
+		id, err := entities.ObjectIDFromHex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object id: %s", raw)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// GetContainerID parses the request's container_id, nil when unset.
+func (r *BatchObjectsRequest) GetContainerID() (*entities.ContainerID, error) {
+	if r.ContainerID == "" {
+		return nil, nil
+	}
+	id, err := entities.ContainerIDFromString(r.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container id: %s", r.ContainerID)
+	}
+	return &id, nil
+}
+
+func (r *ParseObjectRequest) Validate() error {
+	if strings.TrimSpace(r.Text) == "" {
+		return fieldError("text", "text is required")
+	}
+	if len(r.Text) > 500 {
+		return fieldError("text", "text must be at most 500 characters")
 	}
 	return nil
 }
 
+func (r *ParseObjectRequest) GetCollectionID() (*entities.CollectionID, error) {
+	if r.CollectionID == "" {
+		return nil, nil
+	}
+	cid, err := entities.CollectionIDFromString(r.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	return &cid, nil
+}
+
+// GetObjectIDs parses object_ids.
+func (r *MergeObjectsRequest) GetObjectIDs() ([]entities.ObjectID, error) {
+	ids := make([]entities.ObjectID, len(r.ObjectIDs))
+	for i, raw := range r.ObjectIDs {
+		id, err := entities.ObjectIDFromHex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object id: %s", raw)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (r *PatchObjectRequest) GetContainerID() (*entities.ContainerID, error) {
+	if r.ContainerID == nil || *r.ContainerID == "" {
+		return nil, nil
+	}
+	cid, err := entities.ContainerIDFromString(*r.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	return &cid, nil
+}
+
 func (r *UpdateObjectRequest) GetContainerID() (*entities.ContainerID, error) {
 	if r.ContainerID == "" {
 		return nil, nil
@@ -70,6 +334,17 @@ func (r *UpdateObjectRequest) GetContainerID() (*entities.ContainerID, error) {
 	return &cid, nil
 }
 
+func (r *CopyObjectRequest) GetContainerID() (*entities.ContainerID, error) {
+	if r.ContainerID == "" {
+		return nil, nil
+	}
+	cid, err := entities.ContainerIDFromString(r.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	return &cid, nil
+}
+
 func (r *CreateObjectRequest) GetContainerID() (*entities.ContainerID, error) {
 	if r.ContainerID == "" {
 		return nil, nil
@@ -85,6 +360,65 @@ func (r *CreateObjectRequest) GetObjectType() entities.ObjectType {
 	return entities.ObjectType(r.ObjectType)
 }
 
+// GetCondition returns the validated grade, ConditionNone when unset
+func (r *CreateObjectRequest) GetCondition() entities.Condition {
+	return entities.Condition(r.Condition)
+}
+
+// GetCondition returns the validated grade to set, nil to keep the current
+// one; an empty string clears it
+func (r *UpdateObjectRequest) GetCondition() *entities.Condition {
+	c, _ := parseConditionUpdate(r.Condition)
+	return c
+}
+
+// GetCondition returns the validated grade to set, nil to keep the current
+// one; an empty string clears it
+func (r *PatchObjectRequest) GetCondition() *entities.Condition {
+	c, _ := parseConditionUpdate(r.Condition)
+	return c
+}
+
+// GetStatus returns the validated status, ObjectStatusOwned when unset
+func (r *CreateObjectRequest) GetStatus() entities.ObjectStatus {
+	s, _ := entities.ParseObjectStatus(r.Status)
+	return s
+}
+
+// GetStatus returns the validated status to set, nil to keep the current one
+func (r *UpdateObjectRequest) GetStatus() *entities.ObjectStatus {
+	s, _ := parseStatusUpdate(r.Status)
+	return s
+}
+
+// GetStatus returns the validated status to set, nil to keep the current one
+func (r *PatchObjectRequest) GetStatus() *entities.ObjectStatus {
+	s, _ := parseStatusUpdate(r.Status)
+	return s
+}
+
+func parseStatusUpdate(status *string) (*entities.ObjectStatus, error) {
+	if status == nil {
+		return nil, nil
+	}
+	s, err := entities.ParseObjectStatus(*status)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func parseConditionUpdate(condition *string) (*entities.Condition, error) {
+	if condition == nil {
+		return nil, nil
+	}
+	c, err := entities.ParseCondition(*condition)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
 func GetObjectIDFromPath(r *http.Request) (entities.ObjectID, error) {
 	idStr := r.PathValue("object_id")
 	if idStr == "" {
@@ -101,3 +435,38 @@ func GetObjectIDFromPath(r *http.Request) (entities.ObjectID, error) {
 
 	return objectID, nil
 }
+
+// GetExpiringDaysFromQuery parses the optional days query parameter
+// (e.g. ?days=14), returning defaultDays when it is absent.
+func GetExpiringDaysFromQuery(r *http.Request, defaultDays int) (int, error) {
+	v := r.URL.Query().Get("days")
+	if v == "" {
+		return defaultDays, nil
+	}
+	days, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, entities.ErrInvalidExpiringDays
+	}
+	return days, nil
+}
+
+// defaultSearchLimit is how many matches a search returns without ?limit=
+const defaultSearchLimit = 20
+
+// GetSearchFromQuery parses the q and optional limit query parameters of a
+// search (e.g. ?q=tomato+soup&limit=10)
+func GetSearchFromQuery(r *http.Request) (string, int, error) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		return "", 0, entities.ErrEmptySearchQuery
+	}
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return q, defaultSearchLimit, nil
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil {
+		return "", 0, entities.ErrInvalidSearchLimit
+	}
+	return q, limit, nil
+}
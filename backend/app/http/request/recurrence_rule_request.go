@@ -0,0 +1,80 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CreateRecurrenceRuleRequest struct {
+	ObjectID string `json:"object_id" binding:"required"`
+	// Every and Period set the schedule, e.g. every 2 weeks.
+	Every  int    `json:"every" binding:"required,min=1,max=365"`
+	Period string `json:"period" binding:"required"`
+	// Quantity is how much to buy each time, in the object's unit.
+	Quantity *float64 `json:"quantity,omitempty"`
+}
+
+type UpdateRecurrenceRuleRequest struct {
+	Every  *int    `json:"every,omitempty"`
+	Period *string `json:"period,omitempty"`
+	// Quantity replaces how much to buy; 0 clears it.
+	Quantity *float64 `json:"quantity,omitempty"`
+	// Bought takes the staple off the shopping list until it next comes due.
+	Bought bool `json:"bought,omitempty"`
+}
+
+func (r *CreateRecurrenceRuleRequest) Validate() error {
+	if _, err := entities.ObjectIDFromHex(r.ObjectID); err != nil {
+		return errors.New("invalid object ID")
+	}
+	if err := (entities.Recurrence{Every: r.Every, Period: entities.RecurrencePeriod(r.Period)}).Validate(); err != nil {
+		return err
+	}
+	if r.Quantity != nil && *r.Quantity <= 0 {
+		return entities.ErrInvalidRecurrenceAmount
+	}
+	return nil
+}
+
+func (r *UpdateRecurrenceRuleRequest) Validate() error {
+	if (r.Every == nil) != (r.Period == nil) {
+		return errors.New("every and period must be changed together")
+	}
+	if r.Every != nil {
+		if err := (entities.Recurrence{Every: *r.Every, Period: entities.RecurrencePeriod(*r.Period)}).Validate(); err != nil {
+			return err
+		}
+	}
+	if r.Quantity != nil && *r.Quantity < 0 {
+		return entities.ErrInvalidRecurrenceAmount
+	}
+	if r.Every == nil && r.Quantity == nil && !r.Bought {
+		return errors.New("every and period, quantity or bought is required")
+	}
+	return nil
+}
+
+// ToRecurrence returns the new schedule, nil when it is not being changed.
+func (r *UpdateRecurrenceRuleRequest) ToRecurrence() *entities.Recurrence {
+	if r.Every == nil {
+		return nil
+	}
+	return &entities.Recurrence{Every: *r.Every, Period: entities.RecurrencePeriod(*r.Period)}
+}
+
+func GetRecurrenceRuleIDFromPath(r *http.Request) (entities.RecurrenceRuleID, error) {
+	idStr := r.PathValue("recurrence_id")
+	if idStr == "" {
+		return entities.RecurrenceRuleID{}, errors.New("missing recurrence rule ID in path")
+	}
+
+	ruleID, err := entities.RecurrenceRuleIDFromString(idStr)
+	if err != nil {
+		return entities.RecurrenceRuleID{}, fmt.Errorf("invalid recurrence rule ID: %w", err)
+	}
+
+	return ruleID, nil
+}
@@ -0,0 +1,23 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// GetPurchaseHistoryDaysFromQuery parses the optional days query parameter
+// of the purchase history (e.g. ?days=90), returning 0 for the default when
+// it is absent.
+func GetPurchaseHistoryDaysFromQuery(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("days")
+	if v == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(v)
+	if err != nil || days < 1 || days > entities.MaxPurchaseHistoryDays {
+		return 0, entities.ErrInvalidPurchaseHistoryDays
+	}
+	return days, nil
+}
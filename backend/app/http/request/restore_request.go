@@ -0,0 +1,270 @@
+package request
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// BackupArchiveFilename is the name of the JSON document inside a tar.gz backup.
+const BackupArchiveFilename = "backup.json"
+
+// MaxRestoreBodySize caps the size of an uploaded restore archive.
+const MaxRestoreBodySize = 100 << 20
+
+// GetRestoreStrategyFromQuery reads the ?strategy= parameter (skip, overwrite or merge).
+func GetRestoreStrategyFromQuery(r *http.Request) (entities.RestoreStrategy, error) {
+	return entities.NewRestoreStrategy(r.URL.Query().Get("strategy"))
+}
+
+// ReadBackupArchive decodes a restore upload. Both the plain JSON archive and
+// the tar.gz form produced by ?format=tar.gz are accepted; gzip is detected
+// from the body's magic bytes.
+func ReadBackupArchive(w http.ResponseWriter, r *http.Request) (*response.BackupArchive, error) {
+	body := bufio.NewReader(http.MaxBytesReader(w, r.Body, MaxRestoreBodySize))
+
+	var src io.Reader = body
+	if magic, err := body.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		jsonDoc, err := extractBackupFromTarGz(body)
+		if err != nil {
+			return nil, err
+		}
+		src = jsonDoc
+	}
+
+	var archive response.BackupArchive
+	if err := json.UnmarshalRead(src, &archive); err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if archive.Version < 1 || archive.Version > response.BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", archive.Version)
+	}
+
+	return &archive, nil
+}
+
+func extractBackupFromTarGz(r io.Reader) (io.Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip archive: %w", err)
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s not found in archive", BackupArchiveFilename)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tar archive: %w", err)
+		}
+		if path.Base(hdr.Name) == BackupArchiveFilename {
+			return tr, nil
+		}
+	}
+}
+
+// BackupArchiveToCollections converts the archived collections into entities
+// owned by userID. IDs are preserved so restoring onto the same account can
+// detect existing data.
+func BackupArchiveToCollections(archive *response.BackupArchive, userID entities.UserID) ([]*entities.Collection, error) {
+	collections := make([]*entities.Collection, 0, len(archive.Collections))
+	for _, bc := range archive.Collections {
+		col, err := backupToCollection(bc, userID)
+		if err != nil {
+			return nil, fmt.Errorf("collection %q: %w", bc.Name, err)
+		}
+		collections = append(collections, col)
+	}
+	return collections, nil
+}
+
+// BackupArchiveToDigestSettings returns the archived digest preferences, or nil
+// if the archive has none.
+func BackupArchiveToDigestSettings(archive *response.BackupArchive) (*entities.EmailAddress, entities.DigestFrequency, error) {
+	if archive.Settings.DigestFrequency == "" {
+		return nil, "", nil
+	}
+	frequency, err := entities.NewDigestFrequency(archive.Settings.DigestFrequency)
+	if err != nil {
+		return nil, "", err
+	}
+	email, err := entities.NewEmailAddress(archive.Settings.DigestEmail)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid digest email: %w", err)
+	}
+	return &email, frequency, nil
+}
+
+func backupToCollection(bc response.BackupCollection, userID entities.UserID) (*entities.Collection, error) {
+	id, err := entities.CollectionIDFromString(bc.ID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := entities.NewCollectionName(bc.Name)
+	if err != nil {
+		return nil, err
+	}
+	objectType := entities.ObjectType(bc.ObjectType)
+	// Custom types come back with the account's type definitions, if the
+	// backup was taken after they were defined
+	if entities.ValidateObjectTypeKey(objectType) != nil {
+		return nil, fmt.Errorf("invalid object type %q", bc.ObjectType)
+	}
+	groupID, err := optionalGroupID(bc.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var categoryID *entities.CategoryID
+	if bc.CategoryID != nil {
+		if cid, err := entities.CategoryIDFromHex(*bc.CategoryID); err == nil {
+			categoryID = &cid
+		}
+	}
+
+	containers := make([]entities.Container, 0, len(bc.Containers))
+	for _, bctr := range bc.Containers {
+		container, err := backupToContainer(bctr, id)
+		if err != nil {
+			return nil, fmt.Errorf("container %q: %w", bctr.Name, err)
+		}
+		containers = append(containers, *container)
+	}
+
+	shelfLife := make([]ShelfLifeRequest, len(bc.ShelfLife))
+	for i, s := range bc.ShelfLife {
+		shelfLife[i] = ShelfLifeRequest(s)
+	}
+	rules, err := ShelfLifeToEntities(shelfLife)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ReconstructCollection(
+		id, userID, groupID, false, name, categoryID, objectType,
+		containers, bc.Tags, bc.Location, bc.PropertySchema, rules, nil,
+		bc.CreatedAt, bc.UpdatedAt,
+	), nil
+}
+
+func backupToContainer(bc response.BackupContainer, collectionID entities.CollectionID) (*entities.Container, error) {
+	id, err := entities.ContainerIDFromString(bc.ID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := entities.NewContainerName(bc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if bc.Type != "" && !entities.IsValidContainerType(bc.Type) {
+		return nil, fmt.Errorf("invalid container type %q", bc.Type)
+	}
+	groupID, err := optionalGroupID(bc.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *entities.ContainerID
+	if bc.ParentContainerID != nil {
+		pid, err := entities.ContainerIDFromString(*bc.ParentContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent container ID: %w", err)
+		}
+		parentID = &pid
+	}
+
+	zone, err := entities.ParseTemperatureZone(bc.TemperatureZone)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateHumidity(bc.Humidity); err != nil {
+		return nil, err
+	}
+	var placement entities.ContainerPlacement
+	if bc.Placement != nil {
+		p := bc.Placement
+		if placement, err = entities.NewContainerPlacement(p.X, p.Y, p.Floor, p.Room); err != nil {
+			return nil, err
+		}
+	}
+
+	objects := make([]entities.Object, 0, len(bc.Objects))
+	for _, bo := range bc.Objects {
+		obj, err := backupToObject(bo)
+		if err != nil {
+			return nil, fmt.Errorf("object %q: %w", bo.Name, err)
+		}
+		objects = append(objects, *obj)
+	}
+
+	return entities.ReconstructContainer(
+		id, collectionID, name, entities.ContainerType(bc.Type),
+		parentID, nil, groupID, objects, bc.Location,
+		bc.Width, bc.Depth, bc.Rows, bc.Capacity, zone, bc.Humidity, placement,
+		bc.CreatedAt, bc.UpdatedAt,
+	), nil
+}
+
+func backupToObject(bo response.ObjectResponse) (*entities.Object, error) {
+	id, err := entities.ObjectIDFromHex(bo.ID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := entities.NewObjectName(bo.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]entities.TypedValue, len(bo.Properties))
+	for k, tv := range bo.Properties {
+		props[k] = entities.TypedValue{
+			Type:     entities.PropertyType(tv.Type),
+			Val:      tv.Val,
+			Approx:   tv.Approx,
+			Currency: tv.Currency,
+		}
+	}
+
+	tags := bo.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	condition, err := entities.ParseCondition(bo.Condition)
+	if err != nil {
+		return nil, err
+	}
+	status, err := entities.ParseObjectStatus(bo.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ReconstructObject(
+		id, name, entities.NewObjectDescription(bo.Description),
+		entities.ObjectType(bo.ObjectType), bo.Location, bo.Quantity, bo.Unit, bo.MinQuantity, condition, status,
+		props, tags, bo.ImageURL, bo.ExpiresAt, bo.ArchivedAt, nil,
+		bo.CreatedAt, bo.UpdatedAt,
+	), nil
+}
+
+func optionalGroupID(id *string) (*entities.GroupID, error) {
+	if id == nil {
+		return nil, nil
+	}
+	gid, err := entities.GroupIDFromString(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &gid, nil
+}
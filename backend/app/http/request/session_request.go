@@ -0,0 +1,20 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+)
+
+// UpdateSessionRequest remembers a device, or forgets it again
+type UpdateSessionRequest struct {
+	// Trusted sessions stay signed in when the user signs out other devices.
+	Trusted bool `json:"trusted"`
+}
+
+func GetSessionIDFromPath(r *http.Request) (string, error) {
+	id := r.PathValue("session_id")
+	if id == "" {
+		return "", errors.New("missing session ID in path")
+	}
+	return id, nil
+}
@@ -0,0 +1,14 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// GetSortOptionsFromQuery parses the optional sort and order query parameters
+// (e.g. ?sort=name&order=desc). allowed restricts the accepted sort fields.
+func GetSortOptionsFromQuery(r *http.Request, allowed []entities.SortField) (entities.SortOptions, error) {
+	q := r.URL.Query()
+	return entities.NewSortOptions(q.Get("sort"), q.Get("order"), allowed)
+}
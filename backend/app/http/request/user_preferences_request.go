@@ -0,0 +1,61 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type ViewSortRequest struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+type ViewPreferenceRequest struct {
+	// Sort is the chained sort, primary field first.
+	Sort    []ViewSortRequest `json:"sort,omitempty"`
+	GroupBy string            `json:"group_by,omitempty"`
+	// Filters maps a property key to its selected value.
+	Filters map[string]string `json:"filters,omitempty"`
+}
+
+type UpdateUserPreferencesRequest struct {
+	// Views maps view keys such as "collection:<id>" to their preferences.
+	// Views not listed are left as saved; a null or empty preference forgets the view.
+	Views map[string]*ViewPreferenceRequest `json:"views,omitempty"`
+	// LabelTemplate picks the label stock object labels are printed on, such
+	// as "dymo-30252"; an empty string goes back to the default.
+	LabelTemplate *string `json:"label_template,omitempty"`
+	// UnitSystem is "metric" or "imperial", the system quantities are shown
+	// and entered in; an empty string goes back to metric.
+	UnitSystem *string `json:"unit_system,omitempty"`
+}
+
+func (r *UpdateUserPreferencesRequest) Validate() error {
+	if len(r.Views) == 0 && r.LabelTemplate == nil && r.UnitSystem == nil {
+		return errors.New("views, label_template or unit_system is required")
+	}
+	return nil
+}
+
+// ToViewPreferences converts the request views to entities. Validation is left
+// to entities.UserPreferences.SetView.
+func (r *UpdateUserPreferencesRequest) ToViewPreferences() map[string]entities.ViewPreference {
+	views := make(map[string]entities.ViewPreference, len(r.Views))
+	for key, view := range r.Views {
+		if view == nil {
+			views[key] = entities.ViewPreference{}
+			continue
+		}
+		sorts := make([]entities.ViewSort, len(view.Sort))
+		for i, s := range view.Sort {
+			sorts[i] = entities.ViewSort{Field: s.Field, Direction: s.Direction}
+		}
+		views[key] = entities.ViewPreference{
+			Sort:    sorts,
+			GroupBy: view.GroupBy,
+			Filters: view.Filters,
+		}
+	}
+	return views
+}
@@ -21,3 +21,31 @@ func GetUserIDFromPath(r *http.Request) (entities.UserID, error) {
 
 	return userID, nil
 }
+
+// DeleteAccountRequest confirms an account deletion by repeating the username.
+type DeleteAccountRequest struct {
+	ConfirmUsername string `json:"confirm_username"`
+}
+
+func (r *DeleteAccountRequest) Validate() error {
+	if r.ConfirmUsername == "" {
+		return fieldError("confirm_username", "confirm_username is required")
+	}
+	return nil
+}
+
+// GetAdminUserIDFromPath reads the {user_id} segment from admin routes
+// (e.g. /admin/users/{user_id}/disable).
+func GetAdminUserIDFromPath(r *http.Request) (entities.UserID, error) {
+	idStr := r.PathValue("user_id")
+	if idStr == "" {
+		return entities.UserID{}, errors.New("missing user_id in path")
+	}
+
+	userID, err := entities.UserIDFromString(idStr)
+	if err != nil {
+		return entities.UserID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return userID, nil
+}
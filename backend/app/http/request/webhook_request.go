@@ -0,0 +1,46 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// RotateWebhookKeyRequest replaces the account's webhook signing key.
+type RotateWebhookKeyRequest struct {
+	// GraceHours is how long the old key keeps signing alongside the new
+	// one, 0 to 168. Defaults to 24.
+	GraceHours *int `json:"grace_hours,omitempty"`
+}
+
+func (r *RotateWebhookKeyRequest) Validate() error {
+	if r.GraceHours != nil && (*r.GraceHours < 0 || time.Duration(*r.GraceHours)*time.Hour > entities.MaxWebhookKeyGrace) {
+		return fieldErrorf("grace_hours", "grace_hours must be between 0 and %d", int(entities.MaxWebhookKeyGrace/time.Hour))
+	}
+	return nil
+}
+
+// Grace returns the requested grace period. Call Validate first.
+func (r *RotateWebhookKeyRequest) Grace() time.Duration {
+	if r.GraceHours == nil {
+		return entities.DefaultWebhookKeyGrace
+	}
+	return time.Duration(*r.GraceHours) * time.Hour
+}
+
+func GetWebhookDeliveryIDFromPath(r *http.Request) (entities.WebhookDeliveryID, error) {
+	idStr := r.PathValue("delivery_id")
+	if idStr == "" {
+		return entities.WebhookDeliveryID{}, errors.New("missing webhook delivery ID in path")
+	}
+
+	deliveryID, err := entities.WebhookDeliveryIDFromString(idStr)
+	if err != nil {
+		return entities.WebhookDeliveryID{}, fmt.Errorf("invalid webhook delivery ID: %w", err)
+	}
+
+	return deliveryID, nil
+}
@@ -0,0 +1,92 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// AccountDataExportVersion is bumped whenever the export layout changes.
+const AccountDataExportVersion = 1
+
+// AccountDataExport is the machine-readable copy of a user's inventory data,
+// served by GET /accounts/{id}/data-export. Collections use the same
+// layout as the backup archive.
+type AccountDataExport struct {
+	Version            int                         `json:"version"`
+	ExportedAt         time.Time                   `json:"exported_at"`
+	Profile            AccountProfile              `json:"profile"`
+	Settings           BackupSettings              `json:"settings"`
+	Collections        []BackupCollection          `json:"collections"`
+	ContainerTemplates []ContainerTemplateResponse `json:"container_templates"`
+	ObjectMoves        []ObjectMoveExport          `json:"object_moves"`
+}
+
+// AccountProfile is the identity the auth provider reported for the user.
+type AccountProfile struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ObjectMoveExport is one recorded move of an object between containers.
+type ObjectMoveExport struct {
+	ObjectID      string    `json:"object_id"`
+	FromContainer string    `json:"from_container"`
+	FromLocation  string    `json:"from_location,omitempty"`
+	ToContainer   string    `json:"to_container"`
+	ToLocation    string    `json:"to_location,omitempty"`
+	MovedBy       string    `json:"moved_by"`
+	MovedAt       time.Time `json:"moved_at"`
+}
+
+// AccountDeletionResponse reports what DELETE /accounts/{id} removed.
+type AccountDeletionResponse struct {
+	CollectionsDeleted int64 `json:"collections_deleted"`
+	ContainersDeleted  int64 `json:"containers_deleted"`
+	ObjectsDeleted     int64 `json:"objects_deleted"`
+	MovesDeleted       int64 `json:"moves_deleted"`
+	TemplatesDeleted   int64 `json:"templates_deleted"`
+	MealPlansDeleted   int64 `json:"meal_plans_deleted"`
+	SnapshotsDeleted   int64 `json:"snapshots_deleted"`
+	MediaDeleted       int64 `json:"media_deleted"`
+	CommentsDeleted    int64 `json:"comments_deleted"`
+}
+
+func NewAccountDataExport(user *entities.User, collections []*entities.Collection, digest *entities.DigestSubscription, templates []*entities.ContainerTemplate, moves []*entities.ObjectMove, exportedAt time.Time) AccountDataExport {
+	backup := NewBackupArchive(user.ID(), collections, digest, exportedAt)
+
+	export := AccountDataExport{
+		Version:    AccountDataExportVersion,
+		ExportedAt: exportedAt,
+		Profile: AccountProfile{
+			ID:        user.ID().String(),
+			Username:  user.Username().String(),
+			Email:     user.EmailAddress().String(),
+			CreatedAt: user.CreatedAt(),
+		},
+		Settings:           backup.Settings,
+		Collections:        backup.Collections,
+		ContainerTemplates: make([]ContainerTemplateResponse, len(templates)),
+		ObjectMoves:        make([]ObjectMoveExport, len(moves)),
+	}
+
+	for i, t := range templates {
+		export.ContainerTemplates[i] = NewContainerTemplateResponse(t)
+	}
+
+	for i, m := range moves {
+		export.ObjectMoves[i] = ObjectMoveExport{
+			ObjectID:      m.ObjectID().String(),
+			FromContainer: m.From().ContainerName,
+			FromLocation:  m.From().Location,
+			ToContainer:   m.To().ContainerName,
+			ToLocation:    m.To().Location,
+			MovedBy:       m.MovedByUserID().String(),
+			MovedAt:       m.MovedAt(),
+		}
+	}
+
+	return export
+}
@@ -0,0 +1,81 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type UserUsageResponse struct {
+	Collections int   `json:"collections"`
+	Containers  int   `json:"containers"`
+	Objects     int   `json:"objects"`
+	MediaCount  int   `json:"media_count"`
+	MediaBytes  int64 `json:"media_bytes"`
+}
+
+// AdminUserResponse is one user on the admin dashboard. Username, email and
+// the seen times are empty for users who own data but have not signed in
+// since accounts were tracked.
+type AdminUserResponse struct {
+	ID          string            `json:"id"`
+	Username    string            `json:"username"`
+	Email       string            `json:"email"`
+	Disabled    bool              `json:"disabled"`
+	DisabledAt  *time.Time        `json:"disabled_at,omitempty"`
+	FirstSeenAt *time.Time        `json:"first_seen_at,omitempty"`
+	LastSeenAt  *time.Time        `json:"last_seen_at,omitempty"`
+	Usage       UserUsageResponse `json:"usage"`
+}
+
+type AdminUserListResponse struct {
+	Users []AdminUserResponse `json:"users"`
+}
+
+type SystemStatsResponse struct {
+	Users         int   `json:"users"`
+	DisabledUsers int   `json:"disabled_users"`
+	Collections   int   `json:"collections"`
+	Containers    int   `json:"containers"`
+	Objects       int   `json:"objects"`
+	MediaCount    int   `json:"media_count"`
+	MediaBytes    int64 `json:"media_bytes"`
+}
+
+func NewAdminUserResponse(status *entities.AccountStatus, usage entities.UserUsage) AdminUserResponse {
+	return AdminUserResponse{
+		ID:          status.UserID().String(),
+		Username:    status.Username(),
+		Email:       status.Email(),
+		Disabled:    status.IsDisabled(),
+		DisabledAt:  status.DisabledAt(),
+		FirstSeenAt: timeOrNil(status.FirstSeenAt()),
+		LastSeenAt:  timeOrNil(status.LastSeenAt()),
+		Usage: UserUsageResponse{
+			Collections: usage.Collections,
+			Containers:  usage.Containers,
+			Objects:     usage.Objects,
+			MediaCount:  usage.MediaCount,
+			MediaBytes:  usage.MediaBytes,
+		},
+	}
+}
+
+func NewSystemStatsResponse(stats entities.SystemStats) SystemStatsResponse {
+	return SystemStatsResponse{
+		Users:         stats.Users,
+		DisabledUsers: stats.DisabledUsers,
+		Collections:   stats.Collections,
+		Containers:    stats.Containers,
+		Objects:       stats.Objects,
+		MediaCount:    stats.MediaCount,
+		MediaBytes:    stats.MediaBytes,
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
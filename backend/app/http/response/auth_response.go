@@ -29,6 +29,26 @@ type ClaimsInfo struct {
 	Groups    []string `json:"groups"`
 	ExpiresAt int64    `json:"expires_at"`
 	IssuedAt  int64    `json:"issued_at"`
+	// Admin is set when the user is in the configured admin group.
+	Admin bool `json:"admin"`
+}
+
+// TokenResponse is an OAuth-style token response for tokens issued in local
+// auth mode.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func NewTokenResponse(token *services.IssuedToken) TokenResponse {
+	return TokenResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    token.ExpiresIn,
+	}
 }
 
 func NewUserResponse(user *entities.User) UserResponse {
@@ -50,7 +70,7 @@ func NewUserListResponse(users []*entities.User) UserListResponse {
 	return UserListResponse(userResponses)
 }
 
-func NewAuthInfoResponse(user *entities.User, claims *services.AuthClaims) AuthInfoResponse {
+func NewAuthInfoResponse(user *entities.User, claims *services.AuthClaims, admin bool) AuthInfoResponse {
 	return AuthInfoResponse{
 		User: NewUserResponse(user),
 		Claims: ClaimsInfo{
@@ -60,6 +80,7 @@ func NewAuthInfoResponse(user *entities.User, claims *services.AuthClaims) AuthI
 			Groups:    claims.Groups,
 			ExpiresAt: claims.ExpiresAt,
 			IssuedAt:  claims.IssuedAt,
+			Admin:     admin,
 		},
 	}
 }
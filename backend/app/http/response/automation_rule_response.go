@@ -0,0 +1,90 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type AutomationTriggerResponse struct {
+	Kind         string  `json:"kind"`
+	Tag          string  `json:"tag,omitempty"`
+	Threshold    float64 `json:"threshold,omitempty"`
+	Days         int     `json:"days,omitempty"`
+	CollectionID string  `json:"collection_id,omitempty"`
+}
+
+// AutomationActionResponse is one of a rule's actions. Webhook secrets are
+// never sent back; HasSecret says whether there is one.
+type AutomationActionResponse struct {
+	Kind        string `json:"kind"`
+	Tag         string `json:"tag,omitempty"`
+	ContainerID string `json:"container_id,omitempty"`
+	URL         string `json:"url,omitempty"`
+	HasSecret   bool   `json:"has_secret,omitempty"`
+	MaxRetries  int    `json:"max_retries,omitempty"`
+	// RetryBackoff is in seconds
+	RetryBackoff int `json:"retry_backoff,omitempty"`
+}
+
+type AutomationRuleResponse struct {
+	ID        string                     `json:"id"`
+	Name      string                     `json:"name"`
+	Enabled   bool                       `json:"enabled"`
+	Trigger   AutomationTriggerResponse  `json:"trigger"`
+	Actions   []AutomationActionResponse `json:"actions"`
+	LastRunAt *time.Time                 `json:"last_run_at,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+type AutomationRuleListResponse struct {
+	Rules []AutomationRuleResponse `json:"rules"`
+}
+
+func NewAutomationRuleResponse(rule *entities.AutomationRule) AutomationRuleResponse {
+	trigger := rule.Trigger()
+	resp := AutomationRuleResponse{
+		ID:      rule.ID().String(),
+		Name:    rule.Name(),
+		Enabled: rule.Enabled(),
+		Trigger: AutomationTriggerResponse{
+			Kind:      string(trigger.Kind),
+			Tag:       trigger.Tag,
+			Threshold: trigger.Threshold,
+			Days:      trigger.Days,
+		},
+		Actions:   make([]AutomationActionResponse, 0, len(rule.Actions())),
+		LastRunAt: rule.LastRunAt(),
+		CreatedAt: rule.CreatedAt(),
+		UpdatedAt: rule.UpdatedAt(),
+	}
+	if trigger.CollectionID != nil {
+		resp.Trigger.CollectionID = trigger.CollectionID.String()
+	}
+	for _, action := range rule.Actions() {
+		a := AutomationActionResponse{
+			Kind:      string(action.Kind),
+			Tag:       action.Tag,
+			URL:       action.URL,
+			HasSecret: action.Secret != "",
+		}
+		if action.Kind == entities.ActionFireWebhook {
+			a.MaxRetries = action.Retry.MaxRetries
+			a.RetryBackoff = action.Retry.Backoff
+		}
+		if action.ContainerID != nil {
+			a.ContainerID = action.ContainerID.String()
+		}
+		resp.Actions = append(resp.Actions, a)
+	}
+	return resp
+}
+
+func NewAutomationRuleListResponse(rules []*entities.AutomationRule) AutomationRuleListResponse {
+	list := make([]AutomationRuleResponse, len(rules))
+	for i, rule := range rules {
+		list[i] = NewAutomationRuleResponse(rule)
+	}
+	return AutomationRuleListResponse{Rules: list}
+}
@@ -0,0 +1,155 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// BackupFormatVersion is bumped whenever the archive layout changes
+// incompatibly. Restore rejects archives with a newer version.
+const BackupFormatVersion = 1
+
+// BackupArchive is the full-account archive served by GET /accounts/{id}/backup
+// and accepted by POST /accounts/{id}/restore.
+type BackupArchive struct {
+	Version     int                `json:"version"`
+	ExportedAt  time.Time          `json:"exported_at"`
+	UserID      string             `json:"user_id"`
+	Settings    BackupSettings     `json:"settings"`
+	Collections []BackupCollection `json:"collections"`
+}
+
+type BackupSettings struct {
+	DigestEmail     string `json:"digest_email,omitempty"`
+	DigestFrequency string `json:"digest_frequency,omitempty"`
+}
+
+type BackupCollection struct {
+	ID             string                   `json:"id"`
+	GroupID        *string                  `json:"group_id,omitempty"`
+	Name           string                   `json:"name"`
+	CategoryID     *string                  `json:"category_id,omitempty"`
+	ObjectType     string                   `json:"object_type"`
+	Tags           []string                 `json:"tags"`
+	Location       string                   `json:"location,omitempty"`
+	PropertySchema *entities.PropertySchema `json:"property_schema,omitempty"`
+	ShelfLife      []ShelfLifeResponse      `json:"shelf_life,omitempty"`
+	Containers     []BackupContainer        `json:"containers"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+type BackupContainer struct {
+	ID                string                      `json:"id"`
+	Name              string                      `json:"name"`
+	Type              string                      `json:"type"`
+	ParentContainerID *string                     `json:"parent_container_id,omitempty"`
+	GroupID           *string                     `json:"group_id,omitempty"`
+	Location          string                      `json:"location,omitempty"`
+	Width             *float64                    `json:"width,omitempty"`
+	Depth             *float64                    `json:"depth,omitempty"`
+	Rows              *int                        `json:"rows,omitempty"`
+	Capacity          *float64                    `json:"capacity,omitempty"`
+	TemperatureZone   string                      `json:"temperature_zone,omitempty"`
+	Humidity          *float64                    `json:"humidity,omitempty"`
+	Placement         *ContainerPlacementResponse `json:"placement,omitempty"`
+	Objects           []ObjectResponse            `json:"objects"`
+	CreatedAt         time.Time                   `json:"created_at"`
+	UpdatedAt         time.Time                   `json:"updated_at"`
+}
+
+// RestoreResponse summarises what a restore changed.
+type RestoreResponse struct {
+	CollectionsCreated int      `json:"collections_created"`
+	CollectionsUpdated int      `json:"collections_updated"`
+	CollectionsSkipped int      `json:"collections_skipped"`
+	ContainersCreated  int      `json:"containers_created"`
+	ObjectsRestored    int      `json:"objects_restored"`
+	Errors             []string `json:"errors,omitempty"`
+}
+
+func NewBackupArchive(userID entities.UserID, collections []*entities.Collection, digest *entities.DigestSubscription, exportedAt time.Time) BackupArchive {
+	archive := BackupArchive{
+		Version:     BackupFormatVersion,
+		ExportedAt:  exportedAt,
+		UserID:      userID.String(),
+		Collections: make([]BackupCollection, len(collections)),
+	}
+
+	if digest != nil {
+		archive.Settings = BackupSettings{
+			DigestEmail:     digest.Email().String(),
+			DigestFrequency: digest.Frequency().String(),
+		}
+	}
+
+	for i, col := range collections {
+		archive.Collections[i] = newBackupCollection(col)
+	}
+
+	return archive
+}
+
+func newBackupCollection(col *entities.Collection) BackupCollection {
+	bc := BackupCollection{
+		ID:             col.ID().String(),
+		Name:           col.Name().String(),
+		ObjectType:     col.ObjectType().String(),
+		Tags:           col.Tags(),
+		Location:       col.Location(),
+		PropertySchema: col.PropertySchema(),
+		ShelfLife:      newShelfLifeResponses(col.ShelfLife()),
+		Containers:     make([]BackupContainer, len(col.Containers())),
+		CreatedAt:      col.CreatedAt(),
+		UpdatedAt:      col.UpdatedAt(),
+	}
+	if col.GroupID() != nil {
+		id := col.GroupID().String()
+		bc.GroupID = &id
+	}
+	if col.CategoryID() != nil {
+		id := col.CategoryID().String()
+		bc.CategoryID = &id
+	}
+
+	for i, container := range col.Containers() {
+		bc.Containers[i] = newBackupContainer(container)
+	}
+
+	return bc
+}
+
+func newBackupContainer(container entities.Container) BackupContainer {
+	bc := BackupContainer{
+		ID:              container.ID().String(),
+		Name:            container.Name().String(),
+		Type:            string(container.ContainerType()),
+		Location:        container.Location(),
+		Width:           container.Width(),
+		Depth:           container.Depth(),
+		Rows:            container.Rows(),
+		Capacity:        container.Capacity(),
+		TemperatureZone: string(container.TemperatureZone()),
+		Humidity:        container.Humidity(),
+		Placement:       newContainerPlacementResponse(container.Placement()),
+		Objects:         make([]ObjectResponse, len(container.Objects())),
+		CreatedAt:       container.CreatedAt(),
+		UpdatedAt:       container.UpdatedAt(),
+	}
+	if container.ParentContainerID() != nil {
+		id := container.ParentContainerID().String()
+		bc.ParentContainerID = &id
+	}
+	if container.GroupID() != nil {
+		id := container.GroupID().String()
+		bc.GroupID = &id
+	}
+
+	for i, obj := range container.Objects() {
+		// Container ID is implied by nesting
+		bc.Objects[i] = NewObjectResponse(obj, "")
+	}
+
+	return bc
+}
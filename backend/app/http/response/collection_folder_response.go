@@ -0,0 +1,74 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CollectionFolderResponse struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	ParentID      *string   `json:"parent_id,omitempty"`
+	CollectionIDs []string  `json:"collection_ids"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CollectionFolderListResponse struct {
+	Folders []CollectionFolderResponse `json:"folders"`
+}
+
+// CollectionFolderStatsResponse totals a folder and everything nested in it.
+type CollectionFolderStatsResponse struct {
+	FolderID     string `json:"folder_id"`
+	Folders      int    `json:"folders"`
+	Collections  int    `json:"collections"`
+	Containers   int    `json:"containers"`
+	Objects      int    `json:"objects"`
+	LowStock     int    `json:"low_stock"`
+	ExpiringSoon int    `json:"expiring_soon"`
+	Expired      int    `json:"expired"`
+}
+
+func NewCollectionFolderResponse(f *entities.CollectionFolder) CollectionFolderResponse {
+	var parentID *string
+	if p := f.ParentID(); p != nil {
+		parentID = new(p.String())
+	}
+
+	collectionIDs := make([]string, len(f.CollectionIDs()))
+	for i, id := range f.CollectionIDs() {
+		collectionIDs[i] = id.String()
+	}
+
+	return CollectionFolderResponse{
+		ID:            f.ID().String(),
+		Name:          f.Name(),
+		ParentID:      parentID,
+		CollectionIDs: collectionIDs,
+		CreatedAt:     f.CreatedAt(),
+		UpdatedAt:     f.UpdatedAt(),
+	}
+}
+
+func NewCollectionFolderListResponse(folders []*entities.CollectionFolder) CollectionFolderListResponse {
+	out := make([]CollectionFolderResponse, len(folders))
+	for i, f := range folders {
+		out[i] = NewCollectionFolderResponse(f)
+	}
+	return CollectionFolderListResponse{Folders: out}
+}
+
+func NewCollectionFolderStatsResponse(folderID entities.CollectionFolderID, s entities.CollectionFolderStats) CollectionFolderStatsResponse {
+	return CollectionFolderStatsResponse{
+		FolderID:     folderID.String(),
+		Folders:      s.Folders,
+		Collections:  s.Collections,
+		Containers:   s.Containers,
+		Objects:      s.Objects,
+		LowStock:     s.LowStock,
+		ExpiringSoon: s.ExpiringSoon,
+		Expired:      s.Expired,
+	}
+}
@@ -18,10 +18,18 @@ type PropertySchemaResponse struct {
 	Definitions []PropertyDefinitionResponse `json:"definitions"`
 }
 
+// ShelfLifeResponse is one default shelf life rule; an empty category is the
+// fallback for food no other rule matches
+type ShelfLifeResponse struct {
+	Category string `json:"category"`
+	Days     int    `json:"days"`
+}
+
 type CollectionResponse struct {
 	ID             string                  `json:"id"`
 	UserID         string                  `json:"user_id"`
 	GroupID        *string                 `json:"group_id,omitempty"`
+	GroupOwned     bool                    `json:"group_owned"`
 	Name           string                  `json:"name"`
 	CategoryID     *string                 `json:"category_id,omitempty"`
 	ObjectType     string                  `json:"object_type"`
@@ -29,21 +37,35 @@ type CollectionResponse struct {
 	Tags           []string                `json:"tags"`
 	Location       string                  `json:"location"`
 	PropertySchema *PropertySchemaResponse `json:"property_schema,omitempty"`
-	CreatedAt      time.Time               `json:"created_at"`
-	UpdatedAt      time.Time               `json:"updated_at"`
+	ShelfLife      []ShelfLifeResponse     `json:"shelf_life,omitempty"`
+	FloorPlan      *FloorPlanResponse      `json:"floor_plan,omitempty"`
+	LowStockCount  int                     `json:"low_stock_count"`
+	// ConditionCounts counts the graded objects by condition
+	ConditionCounts map[string]int `json:"condition_counts,omitempty"`
+	// WishlistCount counts the wanted and ordered objects, which are not in
+	// the other counts
+	WishlistCount int       `json:"wishlist_count,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type CollectionListResponse []CollectionResponse
 
 type CollectionSummaryResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	ObjectType  string    `json:"object_type"`
-	ObjectCount int       `json:"object_count"`
-	Tags        []string  `json:"tags"`
-	Location    string    `json:"location"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	ObjectType    string `json:"object_type"`
+	ObjectCount   int    `json:"object_count"`
+	LowStockCount int    `json:"low_stock_count"`
+	// ConditionCounts counts the graded objects by condition
+	ConditionCounts map[string]int `json:"condition_counts,omitempty"`
+	// WishlistCount counts the wanted and ordered objects, which are not in
+	// the other counts
+	WishlistCount int       `json:"wishlist_count,omitempty"`
+	Tags          []string  `json:"tags"`
+	Location      string    `json:"location"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type CollectionSummaryListResponse struct {
@@ -68,6 +90,28 @@ func NewPropertySchemaResponse(schema *entities.PropertySchema) *PropertySchemaR
 	return &PropertySchemaResponse{Definitions: defs}
 }
 
+func newShelfLifeResponses(rules []entities.ShelfLife) []ShelfLifeResponse {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make([]ShelfLifeResponse, len(rules))
+	for i, r := range rules {
+		out[i] = ShelfLifeResponse{Category: r.Category(), Days: r.Days()}
+	}
+	return out
+}
+
+func newConditionCounts(counts map[entities.Condition]int) map[string]int {
+	if len(counts) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(counts))
+	for condition, n := range counts {
+		out[condition.String()] = n
+	}
+	return out
+}
+
 func NewCollectionResponse(collection *entities.Collection) CollectionResponse {
 	containers := make([]ContainerResponse, len(collection.Containers()))
 	for i, container := range collection.Containers() {
@@ -75,16 +119,22 @@ func NewCollectionResponse(collection *entities.Collection) CollectionResponse {
 	}
 
 	response := CollectionResponse{
-		ID:             collection.ID().String(),
-		UserID:         collection.UserID().String(),
-		Name:           collection.Name().String(),
-		ObjectType:     collection.ObjectType().String(),
-		Containers:     containers,
-		Tags:           collection.Tags(),
-		Location:       collection.Location(),
-		PropertySchema: NewPropertySchemaResponse(collection.PropertySchema()),
-		CreatedAt:      collection.CreatedAt(),
-		UpdatedAt:      collection.UpdatedAt(),
+		ID:              collection.ID().String(),
+		UserID:          collection.UserID().String(),
+		GroupOwned:      collection.IsGroupOwned(),
+		Name:            collection.Name().String(),
+		ObjectType:      collection.ObjectType().String(),
+		Containers:      containers,
+		Tags:            collection.Tags(),
+		Location:        collection.Location(),
+		PropertySchema:  NewPropertySchemaResponse(collection.PropertySchema()),
+		ShelfLife:       newShelfLifeResponses(collection.ShelfLife()),
+		FloorPlan:       NewFloorPlanResponse(collection.FloorPlan()),
+		LowStockCount:   collection.LowStockCount(),
+		ConditionCounts: newConditionCounts(collection.ConditionCounts()),
+		WishlistCount:   collection.WishlistCount(),
+		CreatedAt:       collection.CreatedAt(),
+		UpdatedAt:       collection.UpdatedAt(),
 	}
 
 	if collection.GroupID() != nil {
@@ -111,14 +161,17 @@ func NewCollectionListResponse(collections []*entities.Collection) CollectionLis
 
 func NewCollectionSummaryResponse(collection *entities.Collection) CollectionSummaryResponse {
 	return CollectionSummaryResponse{
-		ID:          collection.ID().String(),
-		Name:        collection.Name().String(),
-		ObjectType:  collection.ObjectType().String(),
-		ObjectCount: collection.TotalObjectCount(),
-		Tags:        collection.Tags(),
-		Location:    collection.Location(),
-		CreatedAt:   collection.CreatedAt(),
-		UpdatedAt:   collection.UpdatedAt(),
+		ID:              collection.ID().String(),
+		Name:            collection.Name().String(),
+		ObjectType:      collection.ObjectType().String(),
+		ObjectCount:     collection.TotalObjectCount(),
+		LowStockCount:   collection.LowStockCount(),
+		ConditionCounts: newConditionCounts(collection.ConditionCounts()),
+		WishlistCount:   collection.WishlistCount(),
+		Tags:            collection.Tags(),
+		Location:        collection.Location(),
+		CreatedAt:       collection.CreatedAt(),
+		UpdatedAt:       collection.UpdatedAt(),
 	}
 }
 
@@ -0,0 +1,148 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CollectionSnapshotResponse struct {
+	ID             string    `json:"id"`
+	CollectionID   string    `json:"collection_id"`
+	CollectionName string    `json:"collection_name"`
+	Label          string    `json:"label,omitempty"`
+	CreatedBy      string    `json:"created_by"`
+	ContainerCount int       `json:"container_count"`
+	ObjectCount    int       `json:"object_count"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CollectionSnapshotListResponse struct {
+	// Limit is how many snapshots the collection keeps; 0 means unlimited.
+	Limit     int                          `json:"limit"`
+	Snapshots []CollectionSnapshotResponse `json:"snapshots"`
+}
+
+type SnapshotContainerRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type SnapshotContainerChange struct {
+	Container SnapshotContainerRef `json:"container"`
+	Fields    []string             `json:"fields"`
+}
+
+// SnapshotObjectChange is an object that differs between two states. Before
+// is omitted for added objects and After for removed ones.
+type SnapshotObjectChange struct {
+	ID              string                `json:"id"`
+	Name            string                `json:"name"`
+	Before          *ObjectResponse       `json:"before,omitempty"`
+	After           *ObjectResponse       `json:"after,omitempty"`
+	BeforeContainer *SnapshotContainerRef `json:"before_container,omitempty"`
+	AfterContainer  *SnapshotContainerRef `json:"after_container,omitempty"`
+	Fields          []string              `json:"fields,omitempty"`
+}
+
+// CollectionSnapshotDiffResponse lists what changed from a snapshot to a
+// later snapshot, or to the current state when To is omitted.
+type CollectionSnapshotDiffResponse struct {
+	From              CollectionSnapshotResponse  `json:"from"`
+	To                *CollectionSnapshotResponse `json:"to,omitempty"`
+	ContainersAdded   []SnapshotContainerRef      `json:"containers_added"`
+	ContainersRemoved []SnapshotContainerRef      `json:"containers_removed"`
+	ContainersChanged []SnapshotContainerChange   `json:"containers_changed"`
+	ObjectsAdded      []SnapshotObjectChange      `json:"objects_added"`
+	ObjectsRemoved    []SnapshotObjectChange      `json:"objects_removed"`
+	ObjectsChanged    []SnapshotObjectChange      `json:"objects_changed"`
+}
+
+type RestoreCollectionSnapshotResponse struct {
+	// Backup is the snapshot of the state the restore replaced.
+	Backup             CollectionSnapshotResponse `json:"backup"`
+	ContainersRestored int                        `json:"containers_restored"`
+	ObjectsRestored    int                        `json:"objects_restored"`
+	Skipped            []string                   `json:"skipped,omitempty"`
+}
+
+func NewCollectionSnapshotResponse(s *entities.CollectionSnapshot) CollectionSnapshotResponse {
+	return CollectionSnapshotResponse{
+		ID:             s.ID().String(),
+		CollectionID:   s.CollectionID().String(),
+		CollectionName: s.CollectionName(),
+		Label:          s.Label(),
+		CreatedBy:      s.CreatedBy().String(),
+		ContainerCount: s.ContainerCount(),
+		ObjectCount:    s.ObjectCount(),
+		CreatedAt:      s.CreatedAt(),
+	}
+}
+
+func NewCollectionSnapshotListResponse(snapshots []*entities.CollectionSnapshot, limit int) CollectionSnapshotListResponse {
+	list := make([]CollectionSnapshotResponse, len(snapshots))
+	for i, s := range snapshots {
+		list[i] = NewCollectionSnapshotResponse(s)
+	}
+	return CollectionSnapshotListResponse{Limit: limit, Snapshots: list}
+}
+
+func NewCollectionSnapshotDiffResponse(from, to *entities.CollectionSnapshot, diff entities.CollectionDiff) CollectionSnapshotDiffResponse {
+	resp := CollectionSnapshotDiffResponse{
+		From:              NewCollectionSnapshotResponse(from),
+		ContainersAdded:   newSnapshotContainerRefs(diff.ContainersAdded),
+		ContainersRemoved: newSnapshotContainerRefs(diff.ContainersRemoved),
+		ContainersChanged: make([]SnapshotContainerChange, len(diff.ContainersChanged)),
+		ObjectsAdded:      newSnapshotObjectChanges(diff.ObjectsAdded),
+		ObjectsRemoved:    newSnapshotObjectChanges(diff.ObjectsRemoved),
+		ObjectsChanged:    newSnapshotObjectChanges(diff.ObjectsChanged),
+	}
+	if to != nil {
+		toResp := NewCollectionSnapshotResponse(to)
+		resp.To = &toResp
+	}
+	for i, c := range diff.ContainersChanged {
+		resp.ContainersChanged[i] = SnapshotContainerChange{
+			Container: newSnapshotContainerRef(c.Container),
+			Fields:    c.Fields,
+		}
+	}
+	return resp
+}
+
+func newSnapshotContainerRef(ref entities.ContainerRef) SnapshotContainerRef {
+	return SnapshotContainerRef{ID: ref.ID.String(), Name: ref.Name}
+}
+
+func newSnapshotContainerRefs(refs []entities.ContainerRef) []SnapshotContainerRef {
+	out := make([]SnapshotContainerRef, len(refs))
+	for i, ref := range refs {
+		out[i] = newSnapshotContainerRef(ref)
+	}
+	return out
+}
+
+func newSnapshotObjectChanges(changes []entities.ObjectChange) []SnapshotObjectChange {
+	out := make([]SnapshotObjectChange, len(changes))
+	for i, c := range changes {
+		change := SnapshotObjectChange{
+			ID:     c.ID.String(),
+			Name:   c.Name,
+			Fields: c.Fields,
+		}
+		if c.Before != nil {
+			ref := newSnapshotContainerRef(*c.BeforeContainer)
+			before := NewObjectResponse(*c.Before, ref.ID)
+			change.Before = &before
+			change.BeforeContainer = &ref
+		}
+		if c.After != nil {
+			ref := newSnapshotContainerRef(*c.AfterContainer)
+			after := NewObjectResponse(*c.After, ref.ID)
+			change.After = &after
+			change.AfterContainer = &ref
+		}
+		out[i] = change
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type CommentResponse struct {
+	ID           string    `json:"id"`
+	CollectionID string    `json:"collection_id"`
+	ObjectID     string    `json:"object_id,omitempty"`
+	AuthorID     string    `json:"author_id"`
+	AuthorName   string    `json:"author_name"`
+	Body         string    `json:"body"`
+	Mentions     []string  `json:"mentions"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CommentListResponse struct {
+	// Comments are ordered newest first.
+	Comments []CommentResponse `json:"comments"`
+}
+
+type CollectionUnreadComments struct {
+	CollectionID string `json:"collection_id"`
+	Count        int    `json:"count"`
+	Mentions     int    `json:"mentions"`
+}
+
+// UnreadCommentsResponse lists only the collections with unread comments.
+type UnreadCommentsResponse struct {
+	Collections []CollectionUnreadComments `json:"collections"`
+}
+
+func NewCommentResponse(c *entities.Comment) CommentResponse {
+	resp := CommentResponse{
+		ID:           c.ID().String(),
+		CollectionID: c.CollectionID().String(),
+		AuthorID:     c.AuthorID().String(),
+		AuthorName:   c.AuthorName(),
+		Body:         c.Body(),
+		Mentions:     make([]string, len(c.Mentions())),
+		CreatedAt:    c.CreatedAt(),
+	}
+	if c.ObjectID() != nil {
+		resp.ObjectID = c.ObjectID().String()
+	}
+	for i, id := range c.Mentions() {
+		resp.Mentions[i] = id.String()
+	}
+	return resp
+}
+
+func NewCommentListResponse(comments []*entities.Comment) CommentListResponse {
+	resp := CommentListResponse{Comments: make([]CommentResponse, len(comments))}
+	for i, c := range comments {
+		resp.Comments[i] = NewCommentResponse(c)
+	}
+	return resp
+}
+
+func NewUnreadCommentsResponse(unread []entities.CommentUnread) UnreadCommentsResponse {
+	resp := UnreadCommentsResponse{Collections: make([]CollectionUnreadComments, len(unread))}
+	for i, u := range unread {
+		resp.Collections[i] = CollectionUnreadComments{
+			CollectionID: u.CollectionID.String(),
+			Count:        u.Count,
+			Mentions:     u.Mentions,
+		}
+	}
+	return resp
+}
@@ -16,6 +16,7 @@ type ContainerResponse struct {
 	GroupID             *string          `json:"group_id,omitempty"`
 	Objects             []ObjectResponse `json:"objects"`
 	ObjectCount         int              `json:"object_count"`
+	LowStockCount       int              `json:"low_stock_count"`
 	Location            string           `json:"location"`
 	Width               *float64         `json:"width,omitempty"`
 	Depth               *float64         `json:"depth,omitempty"`
@@ -23,15 +24,20 @@ type ContainerResponse struct {
 	Capacity            *float64         `json:"capacity,omitempty"`
 	UsedCapacity        *float64         `json:"used_capacity,omitempty"`
 	CapacityUtilization *float64         `json:"capacity_utilization,omitempty"`
-	CreatedAt           time.Time        `json:"created_at"`
-	UpdatedAt           time.Time        `json:"updated_at"`
+	TemperatureZone     string           `json:"temperature_zone,omitempty"`
+	Humidity            *float64         `json:"humidity,omitempty"`
+	// Placement is where the container is on the collection's floor plan
+	Placement *ContainerPlacementResponse `json:"placement,omitempty"`
+	CreatedAt time.Time                   `json:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
 }
 
 type ContainerListResponse []ContainerResponse
 
 func NewContainerResponse(container *entities.Container) ContainerResponse {
-	objects := make([]ObjectResponse, len(container.Objects()))
-	for i, object := range container.Objects() {
+	active := container.ActiveObjects()
+	objects := make([]ObjectResponse, len(active))
+	for i, object := range active {
 		objects[i] = NewObjectResponse(object, container.ID().String())
 	}
 
@@ -66,6 +72,7 @@ func NewContainerResponse(container *entities.Container) ContainerResponse {
 		GroupID:             groupID,
 		Objects:             objects,
 		ObjectCount:         len(objects),
+		LowStockCount:       container.LowStockCount(),
 		Location:            container.Location(),
 		Width:               container.Width(),
 		Depth:               container.Depth(),
@@ -73,6 +80,9 @@ func NewContainerResponse(container *entities.Container) ContainerResponse {
 		Capacity:            container.Capacity(),
 		UsedCapacity:        &usedCapacity,
 		CapacityUtilization: container.GetCapacityUtilization(),
+		TemperatureZone:     string(container.TemperatureZone()),
+		Humidity:            container.Humidity(),
+		Placement:           newContainerPlacementResponse(container.Placement()),
 		CreatedAt:           container.CreatedAt(),
 		UpdatedAt:           container.UpdatedAt(),
 	}
@@ -110,7 +120,8 @@ func NewContainerSummaryResponse(container *entities.Container) ContainerRespons
 		CategoryID:          categoryID,
 		GroupID:             groupID,
 		Objects:             nil,
-		ObjectCount:         len(container.Objects()),
+		ObjectCount:         container.ObjectCount(),
+		LowStockCount:       container.LowStockCount(),
 		Location:            container.Location(),
 		Width:               container.Width(),
 		Depth:               container.Depth(),
@@ -118,6 +129,9 @@ func NewContainerSummaryResponse(container *entities.Container) ContainerRespons
 		Capacity:            container.Capacity(),
 		UsedCapacity:        &usedCapacity,
 		CapacityUtilization: container.GetCapacityUtilization(),
+		TemperatureZone:     string(container.TemperatureZone()),
+		Humidity:            container.Humidity(),
+		Placement:           newContainerPlacementResponse(container.Placement()),
 		CreatedAt:           container.CreatedAt(),
 		UpdatedAt:           container.UpdatedAt(),
 	}
@@ -141,3 +155,21 @@ func NewContainerSummaryListResponse(containers []*entities.Container) Container
 
 	return ContainerListResponse(containerResponses)
 }
+
+// ImportContainerTreeResponse reports what a container tree import changed,
+// or would change on a dry run
+type ImportContainerTreeResponse struct {
+	DryRun    bool                  `json:"dry_run"`
+	Created   ContainerListResponse `json:"created"`
+	Updated   ContainerListResponse `json:"updated"`
+	Unchanged int                   `json:"unchanged"`
+	Unlisted  int                   `json:"unlisted"`
+}
+
+// BulkCreateContainersResponse reports the containers created from CSV or
+// JSON rows, or the problems that kept any from being created
+type BulkCreateContainersResponse struct {
+	DryRun  bool                  `json:"dry_run"`
+	Created ContainerListResponse `json:"created"`
+	Errors  []string              `json:"errors,omitempty"`
+}
@@ -0,0 +1,75 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type ContainerTemplateNodeResponse struct {
+	Name     string                          `json:"name"`
+	Type     string                          `json:"type"`
+	Location string                          `json:"location,omitempty"`
+	Width    *float64                        `json:"width,omitempty"`
+	Depth    *float64                        `json:"depth,omitempty"`
+	Rows     *int                            `json:"rows,omitempty"`
+	Capacity *float64                        `json:"capacity,omitempty"`
+	Children []ContainerTemplateNodeResponse `json:"children,omitempty"`
+}
+
+type ContainerTemplateResponse struct {
+	ID             string                          `json:"id"`
+	Name           string                          `json:"name"`
+	Description    string                          `json:"description,omitempty"`
+	BuiltIn        bool                            `json:"built_in"`
+	ContainerCount int                             `json:"container_count"`
+	Containers     []ContainerTemplateNodeResponse `json:"containers"`
+	CreatedAt      *time.Time                      `json:"created_at,omitempty"`
+}
+
+type ContainerTemplateListResponse []ContainerTemplateResponse
+
+func NewContainerTemplateResponse(t *entities.ContainerTemplate) ContainerTemplateResponse {
+	resp := ContainerTemplateResponse{
+		ID:             t.ID().String(),
+		Name:           t.Name(),
+		Description:    t.Description(),
+		BuiltIn:        t.IsBuiltIn(),
+		ContainerCount: t.ContainerCount(),
+		Containers:     newContainerTemplateNodeResponses(t.Nodes()),
+	}
+	if !t.IsBuiltIn() {
+		createdAt := t.CreatedAt()
+		resp.CreatedAt = &createdAt
+	}
+	return resp
+}
+
+func NewContainerTemplateListResponse(templates []*entities.ContainerTemplate) ContainerTemplateListResponse {
+	list := make(ContainerTemplateListResponse, len(templates))
+	for i, t := range templates {
+		list[i] = NewContainerTemplateResponse(t)
+	}
+	return list
+}
+
+func newContainerTemplateNodeResponses(nodes []entities.ContainerTemplateNode) []ContainerTemplateNodeResponse {
+	resp := make([]ContainerTemplateNodeResponse, len(nodes))
+	for i, n := range nodes {
+		nodeType := n.Type
+		if nodeType == "" {
+			nodeType = entities.ContainerTypeGeneral
+		}
+		resp[i] = ContainerTemplateNodeResponse{
+			Name:     n.Name,
+			Type:     string(nodeType),
+			Location: n.Location,
+			Width:    n.Width,
+			Depth:    n.Depth,
+			Rows:     n.Rows,
+			Capacity: n.Capacity,
+			Children: newContainerTemplateNodeResponses(n.Children),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,128 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// builtInObjectTypeNames are the display names of entities.AllObjectTypes
+var builtInObjectTypeNames = map[entities.ObjectType]string{
+	entities.ObjectTypeFood:      "Food",
+	entities.ObjectTypeBook:      "Books",
+	entities.ObjectTypeVideoGame: "Video Games",
+	entities.ObjectTypeMusic:     "Music",
+	entities.ObjectTypeBoardGame: "Board Games",
+	entities.ObjectTypeGeneral:   "General",
+}
+
+// ObjectTypeResponse describes a type collections can hold. Built-in types
+// have no ID, icon or color; clients supply their own for those.
+type ObjectTypeResponse struct {
+	ID        string                  `json:"id,omitempty"`
+	Key       string                  `json:"key"`
+	Name      string                  `json:"name"`
+	Icon      string                  `json:"icon,omitempty"`
+	Color     string                  `json:"color,omitempty"`
+	Schema    *PropertySchemaResponse `json:"schema,omitempty"`
+	BuiltIn   bool                    `json:"built_in"`
+	CreatedAt *time.Time              `json:"created_at,omitempty"`
+	UpdatedAt *time.Time              `json:"updated_at,omitempty"`
+}
+
+type ObjectTypeListResponse struct {
+	ObjectTypes []ObjectTypeResponse `json:"object_types"`
+}
+
+func NewObjectTypeResponse(t *entities.CustomObjectType) ObjectTypeResponse {
+	return ObjectTypeResponse{
+		ID:        t.ID().String(),
+		Key:       t.Key().String(),
+		Name:      t.Name(),
+		Icon:      t.Icon(),
+		Color:     t.Color(),
+		Schema:    NewPropertySchemaResponse(t.Schema()),
+		CreatedAt: new(t.CreatedAt()),
+		UpdatedAt: new(t.UpdatedAt()),
+	}
+}
+
+// NewObjectTypeListResponse lists the built-in types followed by the
+// account's custom ones.
+func NewObjectTypeListResponse(custom []*entities.CustomObjectType) ObjectTypeListResponse {
+	out := make([]ObjectTypeResponse, 0, len(entities.AllObjectTypes)+len(custom))
+	for _, ot := range entities.AllObjectTypes {
+		out = append(out, ObjectTypeResponse{
+			Key:     ot.String(),
+			Name:    builtInObjectTypeNames[ot],
+			BuiltIn: true,
+		})
+	}
+	for _, t := range custom {
+		out = append(out, NewObjectTypeResponse(t))
+	}
+	return ObjectTypeListResponse{ObjectTypes: out}
+}
+
+// propertyValueFormats says how to write a property value of each type in a
+// create or import payload
+var propertyValueFormats = map[string]string{
+	string(entities.PropertyTypeText):        "string",
+	string(entities.PropertyTypeGroupedText): "string; objects sharing a value are grouped for filtering",
+	string(entities.PropertyTypeURL):         "string holding an http(s) URL",
+	string(entities.PropertyTypeNumeric):     "number, or a numeric string",
+	string(entities.PropertyTypeCurrency):    "number in the definition's currency_code, or a string such as \"$12.50\"",
+	string(entities.PropertyTypeDate):        "date string such as \"2024-05-31\", or \"~1998\" for an approximate year",
+	string(entities.PropertyTypeBool):        "true or false",
+}
+
+// ObjectTypeSchemaResponse lists the properties objects of a type are
+// checked against, and the conditions they can be graded with
+type ObjectTypeSchemaResponse struct {
+	Key        string                       `json:"key"`
+	Name       string                       `json:"name"`
+	BuiltIn    bool                         `json:"built_in"`
+	Conditions []string                     `json:"conditions,omitempty"`
+	Properties []PropertyDefinitionResponse `json:"properties"`
+}
+
+// ObjectTypeSchemaListResponse describes every object type an account can
+// use, so clients can build create and import payloads without guessing
+// property names. A collection copies its type's schema when created and may
+// have changed it since; its own schema is the one objects are checked
+// against.
+type ObjectTypeSchemaListResponse struct {
+	ObjectTypes     []ObjectTypeSchemaResponse `json:"object_types"`
+	PropertyFormats map[string]string          `json:"property_formats"`
+}
+
+func newObjectTypeSchemaResponse(key entities.ObjectType, name string, schema *entities.PropertySchema) ObjectTypeSchemaResponse {
+	resp := ObjectTypeSchemaResponse{
+		Key:        key.String(),
+		Name:       name,
+		BuiltIn:    key.IsBuiltIn(),
+		Properties: []PropertyDefinitionResponse{},
+	}
+	if key.Graded() {
+		for _, c := range entities.AllConditions {
+			resp.Conditions = append(resp.Conditions, c.String())
+		}
+	}
+	if s := NewPropertySchemaResponse(schema.ForObjectType(key)); s != nil {
+		resp.Properties = s.Definitions
+	}
+	return resp
+}
+
+// NewObjectTypeSchemaListResponse describes the built-in types followed by
+// the account's custom ones
+func NewObjectTypeSchemaListResponse(custom []*entities.CustomObjectType) ObjectTypeSchemaListResponse {
+	out := make([]ObjectTypeSchemaResponse, 0, len(entities.AllObjectTypes)+len(custom))
+	for _, ot := range entities.AllObjectTypes {
+		out = append(out, newObjectTypeSchemaResponse(ot, builtInObjectTypeNames[ot], nil))
+	}
+	for _, t := range custom {
+		out = append(out, newObjectTypeSchemaResponse(t.Key(), t.Name(), t.Schema()))
+	}
+	return ObjectTypeSchemaListResponse{ObjectTypes: out, PropertyFormats: propertyValueFormats}
+}
@@ -0,0 +1,24 @@
+package response
+
+// DashboardCollectionStatsResponse counts a collection's owned, active
+// objects. Expiring leaves out the ones already expired.
+type DashboardCollectionStatsResponse struct {
+	CollectionID  string `json:"collection_id"`
+	ObjectCount   int    `json:"object_count"`
+	LowStockCount int    `json:"low_stock_count"`
+	ExpiringCount int    `json:"expiring_count"`
+	ExpiredCount  int    `json:"expired_count"`
+}
+
+// DashboardResponse is everything the dashboard shows on sign-in. The
+// collections carry their low stock counts, unlike the collection list.
+type DashboardResponse struct {
+	User            AuthInfoResponse                   `json:"user"`
+	Groups          GroupListResponse                  `json:"groups"`
+	Collections     CollectionListResponse             `json:"collections"`
+	CollectionStats []DashboardCollectionStatsResponse `json:"collection_stats"`
+	// ExpiringDays is the window the expiring counts look ahead
+	ExpiringDays  int `json:"expiring_days"`
+	ExpiringCount int `json:"expiring_count"`
+	ExpiredCount  int `json:"expired_count"`
+}
@@ -0,0 +1,24 @@
+package response
+
+// SeededUserResponse is a local account created by a seed request
+type SeededUserResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// SeededCollectionResponse is a collection created by a seed request, with
+// how much it holds
+type SeededCollectionResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Containers int    `json:"containers"`
+	Objects    int    `json:"objects"`
+}
+
+// SeedResponse lists what a seed request created. GroupID is empty when no
+// group was asked for.
+type SeedResponse struct {
+	GroupID     string                     `json:"group_id,omitempty"`
+	Users       []SeededUserResponse       `json:"users"`
+	Collections []SeededCollectionResponse `json:"collections"`
+}
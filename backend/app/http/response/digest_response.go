@@ -0,0 +1,11 @@
+package response
+
+import (
+	"time"
+)
+
+type DigestPreferencesResponse struct {
+	Email      string     `json:"email"`
+	Frequency  string     `json:"frequency"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+}
@@ -0,0 +1,36 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// ExpiringObjectResponse is an object due to expire, with the names of the
+// collection and container it is kept in.
+type ExpiringObjectResponse struct {
+	Object         ObjectResponse `json:"object"`
+	CollectionID   string         `json:"collection_id"`
+	CollectionName string         `json:"collection_name"`
+	ContainerName  string         `json:"container_name"`
+	Expired        bool           `json:"expired"`
+}
+
+// ExpiringObjectListResponse lists objects expiring within Days, soonest
+// first. Objects that have already expired are included.
+type ExpiringObjectListResponse struct {
+	Days    int                      `json:"days"`
+	Objects []ExpiringObjectResponse `json:"objects"`
+	Total   int                      `json:"total"`
+}
+
+func NewExpiringObjectResponse(obj entities.Object, containerID entities.ContainerID, containerName string, collectionID entities.CollectionID, collectionName string, now time.Time) ExpiringObjectResponse {
+	exp := obj.ExpiresAt()
+	return ExpiringObjectResponse{
+		Object:         NewObjectResponse(obj, containerID.String()),
+		CollectionID:   collectionID.String(),
+		CollectionName: collectionName,
+		ContainerName:  containerName,
+		Expired:        exp != nil && exp.Before(now),
+	}
+}
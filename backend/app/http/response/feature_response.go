@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// FeaturesResponse lists whether each known feature is on for the caller
+type FeaturesResponse struct {
+	Features map[string]bool `json:"features"`
+}
+
+// FeatureFlagResponse is the flag in effect for a feature. Source is
+// "default", "config" or "admin"; only admin flags can be removed.
+type FeatureFlagResponse struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Enabled     bool       `json:"enabled"`
+	Users       []string   `json:"users"`
+	Groups      []string   `json:"groups"`
+	Source      string     `json:"source"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+type FeatureFlagListResponse struct {
+	Flags []FeatureFlagResponse `json:"flags"`
+}
+
+func NewFeatureFlagResponse(flag *entities.FeatureFlag, source string) FeatureFlagResponse {
+	resp := FeatureFlagResponse{
+		Name:        flag.Name(),
+		Description: flag.Description(),
+		Enabled:     flag.Enabled(),
+		Users:       flag.Users(),
+		Groups:      flag.Groups(),
+		Source:      source,
+	}
+	if resp.Users == nil {
+		resp.Users = []string{}
+	}
+	if resp.Groups == nil {
+		resp.Groups = []string{}
+	}
+	if updatedAt := flag.UpdatedAt(); !updatedAt.IsZero() {
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
@@ -0,0 +1,55 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// FloorPlanResponse is the image a collection's containers are placed on.
+type FloorPlanResponse struct {
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// ContainerPlacementResponse is where a container is on the floor plan. X
+// and Y are fractions of the plan's width and height from its top left
+// corner.
+type ContainerPlacementResponse struct {
+	X     *float64 `json:"x,omitempty"`
+	Y     *float64 `json:"y,omitempty"`
+	Floor string   `json:"floor,omitempty"`
+	Room  string   `json:"room,omitempty"`
+}
+
+// NewFloorPlanResponse returns nil when the collection has no floor plan
+func NewFloorPlanResponse(plan *entities.FloorPlan) *FloorPlanResponse {
+	if plan == nil {
+		return nil
+	}
+	return &FloorPlanResponse{
+		URL:         plan.URL(),
+		ContentType: plan.ContentType(),
+		Size:        plan.Size(),
+		Width:       plan.Width(),
+		Height:      plan.Height(),
+		UploadedAt:  plan.UploadedAt(),
+	}
+}
+
+// newContainerPlacementResponse returns nil for an unplaced container
+func newContainerPlacementResponse(placement entities.ContainerPlacement) *ContainerPlacementResponse {
+	if placement.IsZero() {
+		return nil
+	}
+	return &ContainerPlacementResponse{
+		X:     placement.X,
+		Y:     placement.Y,
+		Floor: placement.Floor,
+		Room:  placement.Room,
+	}
+}
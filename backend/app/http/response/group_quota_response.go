@@ -0,0 +1,77 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/app/http/httputil"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// GroupQuotaResponse is a group's limits next to what its shared
+// collections use. A limit of 0 is unlimited. CanEdit tells whether the
+// caller is an admin who may change the limits.
+type GroupQuotaResponse struct {
+	GroupID         string             `json:"group_id"`
+	MaxContainers   int                `json:"max_containers"`
+	MaxObjects      int                `json:"max_objects"`
+	MaxStorageBytes int64              `json:"max_storage_bytes"`
+	Usage           GroupUsageResponse `json:"usage"`
+	CanEdit         bool               `json:"can_edit"`
+	UpdatedAt       *time.Time         `json:"updated_at,omitempty"`
+}
+
+type GroupUsageResponse struct {
+	Containers   int   `json:"containers"`
+	Objects      int   `json:"objects"`
+	StorageBytes int64 `json:"storage_bytes"`
+}
+
+// QuotaExceededResponse is the 403 body of a create refused because the
+// collection's group is at its quota: the standard error envelope with code
+// quota_exceeded, plus the limit reached. Resource is containers, objects or
+// storage_bytes.
+type QuotaExceededResponse struct {
+	Error     string `json:"error"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+	GroupID   string `json:"group_id"`
+	Resource  string `json:"resource"`
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	Requested int64  `json:"requested"`
+}
+
+func NewGroupQuotaResponse(groupID entities.GroupID, quota *entities.GroupQuota, usage entities.GroupUsage, canEdit bool) GroupQuotaResponse {
+	resp := GroupQuotaResponse{
+		GroupID: groupID.String(),
+		Usage: GroupUsageResponse{
+			Containers:   usage.Containers,
+			Objects:      usage.Objects,
+			StorageBytes: usage.StorageBytes,
+		},
+		CanEdit: canEdit,
+	}
+	if quota != nil {
+		resp.MaxContainers = quota.MaxContainers()
+		resp.MaxObjects = quota.MaxObjects()
+		resp.MaxStorageBytes = quota.MaxStorageBytes()
+		updatedAt := quota.UpdatedAt()
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+func NewQuotaExceededResponse(err *entities.QuotaExceededError, requestID string) QuotaExceededResponse {
+	return QuotaExceededResponse{
+		Error:     err.Error(),
+		Code:      httputil.CodeQuotaExceeded,
+		Message:   err.Error(),
+		RequestID: requestID,
+		GroupID:   err.GroupID.String(),
+		Resource:  string(err.Resource),
+		Limit:     err.Limit,
+		Used:      err.Used,
+		Requested: err.Requested,
+	}
+}
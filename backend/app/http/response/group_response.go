@@ -34,3 +34,18 @@ func NewGroupListResponse(groups []*entities.Group) GroupListResponse {
 
 	return GroupListResponse(groupResponses)
 }
+
+// GroupMemberResponse is a group member with their role in the group.
+type GroupMemberResponse struct {
+	UserResponse
+	Role string `json:"role"`
+}
+
+type GroupMemberListResponse []GroupMemberResponse
+
+func NewGroupMemberResponse(user *entities.User, role entities.GroupRole) GroupMemberResponse {
+	return GroupMemberResponse{
+		UserResponse: NewUserResponse(user),
+		Role:         role.String(),
+	}
+}
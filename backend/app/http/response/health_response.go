@@ -0,0 +1,42 @@
+package response
+
+import "time"
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// DependencyHealth is the result of probing a single backing service. The
+// probe is unauthenticated, so why a check failed only goes to the log.
+type DependencyHealth struct {
+	Status string `json:"status"`
+	// Optional dependencies are reported but do not affect the overall status.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// LivenessResponse is returned by /health/live.
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// ReadinessResponse is returned by /health/ready. Status is "up" only when
+// every required dependency is up.
+type ReadinessResponse struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
+// StatusResponse is returned by the public /status page. The counters are
+// totals since the server started and say nothing about individual users.
+type StatusResponse struct {
+	Status        string    `json:"status"`
+	Version       string    `json:"version"`
+	Commit        string    `json:"commit,omitempty"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	Requests      int64     `json:"requests"`
+	ServerErrors  int64     `json:"server_errors"`
+	// ErrorRate is the share of requests answered with a 5xx status.
+	ErrorRate float64 `json:"error_rate"`
+}
@@ -0,0 +1,95 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type InboxLineResponse struct {
+	Name     string   `json:"name"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+	Price    *float64 `json:"price,omitempty"`
+}
+
+// InboxItemResponse is a forwarded email waiting to be confirmed, with the
+// lines read from it.
+type InboxItemResponse struct {
+	ID         string              `json:"id"`
+	Sender     string              `json:"sender"`
+	Subject    string              `json:"subject"`
+	Lines      []InboxLineResponse `json:"lines"`
+	ReceivedAt time.Time           `json:"received_at"`
+}
+
+type InboxItemListResponse struct {
+	Items []InboxItemResponse `json:"items"`
+}
+
+// InboxAddressResponse is where the user forwards receipts. Enabled is false,
+// and Address empty, when the server has no inbox set up.
+type InboxAddressResponse struct {
+	Enabled bool   `json:"enabled"`
+	Address string `json:"address,omitempty"`
+}
+
+// InboxLineErrorResponse is a line that could not be created; it stays in
+// the inbox item.
+type InboxLineErrorResponse struct {
+	Line  InboxLineResponse `json:"line"`
+	Error string            `json:"error"`
+}
+
+// ConfirmInboxItemResponse lists the objects created from an inbox item and
+// the lines that failed.
+type ConfirmInboxItemResponse struct {
+	Created     []ObjectResponse         `json:"created"`
+	ContainerID string                   `json:"container_id"`
+	Failed      []InboxLineErrorResponse `json:"failed"`
+}
+
+func NewInboxLineResponse(line entities.InboxLine) InboxLineResponse {
+	return InboxLineResponse{
+		Name:     line.Name,
+		Quantity: line.Quantity,
+		Unit:     line.Unit,
+		Price:    line.Price,
+	}
+}
+
+func NewInboxItemResponse(item *entities.InboxItem) InboxItemResponse {
+	lines := make([]InboxLineResponse, len(item.Lines()))
+	for i, line := range item.Lines() {
+		lines[i] = NewInboxLineResponse(line)
+	}
+	return InboxItemResponse{
+		ID:         item.ID().String(),
+		Sender:     item.Sender(),
+		Subject:    item.Subject(),
+		Lines:      lines,
+		ReceivedAt: item.ReceivedAt(),
+	}
+}
+
+func NewInboxItemListResponse(items []*entities.InboxItem) InboxItemListResponse {
+	resp := InboxItemListResponse{Items: make([]InboxItemResponse, len(items))}
+	for i, item := range items {
+		resp.Items[i] = NewInboxItemResponse(item)
+	}
+	return resp
+}
+
+// NewConfirmInboxItemResponse lists the created objects; callers append
+// the failed lines
+func NewConfirmInboxItemResponse(created []*entities.Object, containerID entities.ContainerID) ConfirmInboxItemResponse {
+	resp := ConfirmInboxItemResponse{
+		Created:     make([]ObjectResponse, len(created)),
+		ContainerID: containerID.String(),
+		Failed:      []InboxLineErrorResponse{},
+	}
+	for i, obj := range created {
+		resp.Created[i] = NewObjectResponse(*obj, resp.ContainerID)
+	}
+	return resp
+}
@@ -0,0 +1,17 @@
+package response
+
+// LogLevelsResponse is the running server's default log level and the level
+// of each sink, console first.
+type LogLevelsResponse struct {
+	Level string            `json:"level"`
+	Sinks []LogSinkResponse `json:"sinks"`
+}
+
+// LogSinkResponse is one log sink. Inherited is set when the sink follows
+// the default level rather than a level of its own.
+type LogSinkResponse struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Level     string `json:"level"`
+	Inherited bool   `json:"inherited"`
+}
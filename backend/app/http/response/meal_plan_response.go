@@ -0,0 +1,86 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type MealIngredientResponse struct {
+	ObjectID string  `json:"object_id"`
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit,omitempty"`
+}
+
+type MealPlanResponse struct {
+	ID          string                   `json:"id"`
+	Date        string                   `json:"date"`
+	Meal        string                   `json:"meal"`
+	RecipeName  string                   `json:"recipe_name"`
+	Ingredients []MealIngredientResponse `json:"ingredients"`
+	Completed   bool                     `json:"completed"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+type MealPlanListResponse struct {
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	MealPlans []MealPlanResponse `json:"meal_plans"`
+}
+
+// SkippedMealIngredientResponse is an ingredient that was not used up when
+// its meal was completed.
+type SkippedMealIngredientResponse struct {
+	Ingredient MealIngredientResponse `json:"ingredient"`
+	Reason     string                 `json:"reason"`
+}
+
+// CompleteMealPlanResponse is the completed plan with the objects whose
+// quantities were reduced and the ingredients that could not be.
+type CompleteMealPlanResponse struct {
+	MealPlan MealPlanResponse                `json:"meal_plan"`
+	Adjusted []ObjectResponse                `json:"adjusted"`
+	Skipped  []SkippedMealIngredientResponse `json:"skipped"`
+}
+
+func NewMealIngredientResponse(ing entities.MealIngredient) MealIngredientResponse {
+	return MealIngredientResponse{
+		ObjectID: ing.ObjectID.String(),
+		Name:     ing.Name,
+		Quantity: ing.Quantity,
+		Unit:     ing.Unit,
+	}
+}
+
+func NewMealPlanResponse(p *entities.MealPlan) MealPlanResponse {
+	ingredients := make([]MealIngredientResponse, len(p.Ingredients()))
+	for i, ing := range p.Ingredients() {
+		ingredients[i] = NewMealIngredientResponse(ing)
+	}
+	return MealPlanResponse{
+		ID:          p.ID().String(),
+		Date:        p.Date().Format(entities.MealPlanDateLayout),
+		Meal:        string(p.Meal()),
+		RecipeName:  p.RecipeName(),
+		Ingredients: ingredients,
+		Completed:   p.IsCompleted(),
+		CompletedAt: p.CompletedAt(),
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
+	}
+}
+
+func NewMealPlanListResponse(from, to time.Time, plans []*entities.MealPlan) MealPlanListResponse {
+	list := make([]MealPlanResponse, len(plans))
+	for i, p := range plans {
+		list[i] = NewMealPlanResponse(p)
+	}
+	return MealPlanListResponse{
+		From:      from.Format(entities.MealPlanDateLayout),
+		To:        to.Format(entities.MealPlanDateLayout),
+		MealPlans: list,
+	}
+}
@@ -0,0 +1,70 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type MediaResponse struct {
+	ID           string    `json:"id"`
+	CollectionID string    `json:"collection_id"`
+	OwnerKind    string    `json:"owner_kind"` // "object" or "container"
+	OwnerID      string    `json:"owner_id"`
+	Filename     string    `json:"filename,omitempty"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	UploadedBy   string    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MediaListResponse is one page of photos, newest first. Total counts every
+// photo matching the listing, not just this page.
+type MediaListResponse struct {
+	Media  []MediaResponse `json:"media"`
+	Total  int64           `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+type MediaUploadFailure struct {
+	Filename string `json:"filename"`
+	Error    string `json:"error"`
+}
+
+// MediaUploadResponse lists the photos saved by a batch upload and any that
+// were rejected.
+type MediaUploadResponse struct {
+	Media  []MediaResponse      `json:"media"`
+	Failed []MediaUploadFailure `json:"failed"`
+}
+
+func NewMediaResponse(m *entities.Media) MediaResponse {
+	return MediaResponse{
+		ID:           m.ID().String(),
+		CollectionID: m.CollectionID().String(),
+		OwnerKind:    m.OwnerKind().String(),
+		OwnerID:      m.OwnerID(),
+		Filename:     m.Filename(),
+		ContentType:  m.ContentType(),
+		Size:         m.Size(),
+		Width:        m.Width(),
+		Height:       m.Height(),
+		URL:          m.URL(),
+		ThumbnailURL: m.ThumbnailURL(),
+		UploadedBy:   m.UploadedBy().String(),
+		CreatedAt:    m.CreatedAt(),
+	}
+}
+
+func NewMediaListResponse(media []*entities.Media, total int64, page entities.MediaPage) MediaListResponse {
+	list := make([]MediaResponse, len(media))
+	for i, m := range media {
+		list[i] = NewMediaResponse(m)
+	}
+	return MediaListResponse{Media: list, Total: total, Limit: page.Limit, Offset: page.Offset}
+}
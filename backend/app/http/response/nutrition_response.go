@@ -0,0 +1,43 @@
+package response
+
+import "github.com/nishiki/backend/domain/entities"
+
+type ContainerNutritionResponse struct {
+	ContainerID   string  `json:"container_id"`
+	ContainerName string  `json:"container_name"`
+	Calories      float64 `json:"calories"`
+}
+
+// NutritionStatsResponse totals the nutrition facts of a collection's
+// active, unexpired food. Grams are totals, not per serving.
+type NutritionStatsResponse struct {
+	Calories         float64                      `json:"calories"`
+	ProteinG         float64                      `json:"protein_g"`
+	CarbsG           float64                      `json:"carbs_g"`
+	FatG             float64                      `json:"fat_g"`
+	WithNutrition    int                          `json:"with_nutrition"`
+	WithoutNutrition int                          `json:"without_nutrition"`
+	ExpiredSkipped   int                          `json:"expired_skipped"`
+	ByContainer      []ContainerNutritionResponse `json:"by_container"`
+}
+
+func NewNutritionStatsResponse(stats entities.NutritionStats) NutritionStatsResponse {
+	byContainer := make([]ContainerNutritionResponse, len(stats.ByContainer))
+	for i, c := range stats.ByContainer {
+		byContainer[i] = ContainerNutritionResponse{
+			ContainerID:   c.ContainerID.String(),
+			ContainerName: c.ContainerName,
+			Calories:      c.Calories,
+		}
+	}
+	return NutritionStatsResponse{
+		Calories:         stats.Calories,
+		ProteinG:         stats.Protein,
+		CarbsG:           stats.Carbs,
+		FatG:             stats.Fat,
+		WithNutrition:    stats.WithNutrition,
+		WithoutNutrition: stats.WithoutNutrition,
+		ExpiredSkipped:   stats.ExpiredSkipped,
+		ByContainer:      byContainer,
+	}
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// OAuthClientResponse is a runtime-registered OAuth client. The secret is
+// never sent back.
+type OAuthClientResponse struct {
+	ProviderName string    `json:"provider_name"`
+	ClientID     string    `json:"client_id"`
+	RedirectURL  string    `json:"redirect_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type OAuthClientListResponse struct {
+	Clients []OAuthClientResponse `json:"clients"`
+}
+
+func NewOAuthClientResponse(client *entities.OAuthClient) OAuthClientResponse {
+	return OAuthClientResponse{
+		ProviderName: client.ProviderName(),
+		ClientID:     client.ClientID(),
+		RedirectURL:  client.RedirectURL(),
+		CreatedAt:    client.CreatedAt(),
+		UpdatedAt:    client.UpdatedAt(),
+	}
+}
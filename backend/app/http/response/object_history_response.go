@@ -0,0 +1,46 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// ObjectLocationResponse is one stay of an object in a container.
+type ObjectLocationResponse struct {
+	ContainerID   string     `json:"container_id"`
+	ContainerName string     `json:"container_name"`
+	Location      string     `json:"location,omitempty"`
+	Since         time.Time  `json:"since"`
+	Until         *time.Time `json:"until,omitempty"`
+	MovedBy       *string    `json:"moved_by,omitempty"`
+}
+
+type ObjectHistoryResponse struct {
+	ObjectID   string                   `json:"object_id"`
+	ObjectName string                   `json:"object_name"`
+	Timeline   []ObjectLocationResponse `json:"timeline"`
+}
+
+func NewObjectHistoryResponse(object *entities.Object, timeline []entities.ObjectTimelineEntry) ObjectHistoryResponse {
+	entries := make([]ObjectLocationResponse, len(timeline))
+	for i, e := range timeline {
+		entries[i] = ObjectLocationResponse{
+			ContainerID:   e.Placement.ContainerID.String(),
+			ContainerName: e.Placement.ContainerName,
+			Location:      e.Placement.Location,
+			Since:         e.Since,
+			Until:         e.Until,
+		}
+		if e.MovedByUserID != nil {
+			movedBy := e.MovedByUserID.String()
+			entries[i].MovedBy = &movedBy
+		}
+	}
+
+	return ObjectHistoryResponse{
+		ObjectID:   object.ID().String(),
+		ObjectName: object.Name().String(),
+		Timeline:   entries,
+	}
+}
@@ -23,12 +23,53 @@ type ObjectResponse struct {
 	Location    string                        `json:"location,omitempty"`
 	Quantity    *float64                      `json:"quantity,omitempty"`
 	Unit        string                        `json:"unit,omitempty"`
+	MinQuantity *float64                      `json:"min_quantity,omitempty"`
+	LowStock    bool                          `json:"low_stock,omitempty"`
+	StockStatus string                        `json:"stock_status,omitempty"` // "low" or "out" when LowStock
+	Condition   string                        `json:"condition,omitempty"`    // mint, near_mint, good, fair or poor; collectibles only
+	Status      string                        `json:"status"`                 // owned, wanted or ordered
 	Properties  map[string]TypedValueResponse `json:"properties"`
 	Tags        []string                      `json:"tags"`
 	ImageURL    string                        `json:"image_url,omitempty"`
 	ExpiresAt   *time.Time                    `json:"expires_at,omitempty"`
-	CreatedAt   time.Time                     `json:"created_at"`
-	UpdatedAt   time.Time                     `json:"updated_at"`
+	ArchivedAt  *time.Time                    `json:"archived_at,omitempty"`
+	Claim       *ObjectClaimResponse          `json:"claim,omitempty"` // set while a member has the object reserved
+	// StorageWarning is set when the object's container is too warm for it
+	StorageWarning *StorageWarningResponse `json:"storage_warning,omitempty"`
+	// DroppedProperties lists the properties left behind by a move or copy
+	// to a collection whose schema has no place for them
+	DroppedProperties []string  `json:"dropped_properties,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// StorageWarningResponse says which zone a food object's category needs and
+// which zone its container is in.
+type StorageWarningResponse struct {
+	Category string `json:"category"`
+	Required string `json:"required"`
+	Zone     string `json:"zone"`
+	Message  string `json:"message"`
+}
+
+// RetagObjectsResponse counts the objects a retag matched and changed.
+type RetagObjectsResponse struct {
+	Matched int `json:"matched"`
+	Updated int `json:"updated"`
+}
+
+// BatchObjectsResponse counts the objects a batch moved or deleted.
+type BatchObjectsResponse struct {
+	Changed int `json:"changed"`
+}
+
+// ObjectClaimResponse names the member who reserved an object and when the
+// reservation ends.
+type ObjectClaimResponse struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	Until     time.Time `json:"until"`
 }
 
 type ObjectListResponse struct {
@@ -36,6 +77,62 @@ type ObjectListResponse struct {
 	Total   int              `json:"total"`
 }
 
+// MergeObjectsResponse is the surviving object of a merge and the IDs of the
+// objects folded into it.
+type MergeObjectsResponse struct {
+	Object    ObjectResponse `json:"object"`
+	MergedIDs []string       `json:"merged_ids"`
+	Preview   bool           `json:"preview"`
+}
+
+// ParsedObjectResponse holds the fields read from a quick add phrase. Empty
+// fields were not found; container_id is set when the place named matched a
+// container of the collection.
+type ParsedObjectResponse struct {
+	Name          string   `json:"name"`
+	Quantity      *float64 `json:"quantity,omitempty"`
+	Unit          string   `json:"unit,omitempty"`
+	Location      string   `json:"location,omitempty"`
+	ContainerID   string   `json:"container_id,omitempty"`
+	ContainerName string   `json:"container_name,omitempty"`
+}
+
+// DuplicateGroupResponse lists objects that share a normalized name.
+type DuplicateGroupResponse struct {
+	Key     string           `json:"key"`
+	Objects []ObjectResponse `json:"objects"`
+}
+
+type DuplicateGroupsResponse struct {
+	Groups []DuplicateGroupResponse `json:"groups"`
+}
+
+// ObjectCodeMatchResponse is an object already carrying a looked-up code.
+type ObjectCodeMatchResponse struct {
+	CollectionID string         `json:"collection_id"`
+	Object       ObjectResponse `json:"object"`
+}
+
+// ObjectCodeLookupResponse lists the account's objects carrying a barcode,
+// ISBN or SKU, so scanning a known item can offer to increase its quantity
+// instead of creating a duplicate.
+type ObjectCodeLookupResponse struct {
+	Code    string                    `json:"code"`
+	Matches []ObjectCodeMatchResponse `json:"matches"`
+}
+
+// ExpirySuggestionResponse is how many days objects of a category usually
+// keep, the median of the last expiry dates the user set for them.
+type ExpirySuggestionResponse struct {
+	Category string `json:"category"`
+	Days     int    `json:"days"`
+	Samples  int    `json:"samples"`
+}
+
+type ExpirySuggestionsResponse struct {
+	Suggestions []ExpirySuggestionResponse `json:"suggestions"`
+}
+
 func NewObjectResponse(object entities.Object, containerID string) ObjectResponse {
 	rawProps := object.Properties()
 	props := make(map[string]TypedValueResponse, len(rawProps))
@@ -56,15 +153,47 @@ func NewObjectResponse(object entities.Object, containerID string) ObjectRespons
 		Location:    object.Location(),
 		Quantity:    object.Quantity(),
 		Unit:        object.Unit(),
+		MinQuantity: object.MinQuantity(),
+		LowStock:    object.IsLowStock(),
+		StockStatus: string(object.StockStatus()),
+		Condition:   object.Condition().String(),
+		Status:      object.Status().String(),
 		Properties:  props,
 		Tags:        object.Tags(),
 		ImageURL:    object.ImageURL(),
 		ExpiresAt:   object.ExpiresAt(),
+		ArchivedAt:  object.ArchivedAt(),
+		Claim:       newObjectClaimResponse(object.ActiveClaim(time.Now())),
 		CreatedAt:   object.CreatedAt(),
 		UpdatedAt:   object.UpdatedAt(),
 	}
 }
 
+func newObjectClaimResponse(claim *entities.ObjectClaim) *ObjectClaimResponse {
+	if claim == nil {
+		return nil
+	}
+	return &ObjectClaimResponse{
+		UserID:    claim.UserID().String(),
+		Username:  claim.Username(),
+		ClaimedAt: claim.ClaimedAt(),
+		Until:     claim.Until(),
+	}
+}
+
+// NewStorageWarningResponse converts a warning, returning nil for no warning
+func NewStorageWarningResponse(warning *entities.StorageWarning) *StorageWarningResponse {
+	if warning == nil {
+		return nil
+	}
+	return &StorageWarningResponse{
+		Category: warning.Category(),
+		Required: string(warning.Required()),
+		Zone:     string(warning.Zone()),
+		Message:  warning.Message(),
+	}
+}
+
 type CreateObjectResponse struct {
 	Object ObjectResponse `json:"object"`
 }
@@ -78,8 +207,21 @@ type DeleteObjectResponse struct {
 }
 
 type BulkImportResponse struct {
-	Imported int      `json:"imported"`
-	Failed   int      `json:"failed"`
-	Total    int      `json:"total"`
-	Errors   []string `json:"errors,omitempty"`
+	Imported     int      `json:"imported"`
+	Failed       int      `json:"failed"`
+	Total        int      `json:"total"`
+	Errors       []string `json:"errors,omitempty"`
+	SourceFormat string   `json:"source_format,omitempty"` // format applied after auto-detection
+}
+
+// ImportJobResponse describes a collection import running in the
+// background. Result is set once status is "completed" and Error once it is
+// "failed".
+type ImportJobResponse struct {
+	JobID  string              `json:"job_id"`
+	Status string              `json:"status"` // "queued", "running", "completed" or "failed"
+	Done   int                 `json:"done"`
+	Total  int                 `json:"total"`
+	Result *BulkImportResponse `json:"result,omitempty"`
+	Error  string              `json:"error,omitempty"`
 }
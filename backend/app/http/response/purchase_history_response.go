@@ -0,0 +1,88 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// PurchaseResponse is a food object added during the window. Price is left
+// out when the object has none.
+type PurchaseResponse struct {
+	ObjectID       string    `json:"object_id"`
+	Name           string    `json:"name"`
+	CollectionID   string    `json:"collection_id"`
+	CollectionName string    `json:"collection_name"`
+	Category       string    `json:"category"`
+	Quantity       *float64  `json:"quantity,omitempty"`
+	Unit           string    `json:"unit,omitempty"`
+	Price          *float64  `json:"price,omitempty"`
+	Currency       string    `json:"currency,omitempty"`
+	AddedAt        time.Time `json:"added_at"`
+}
+
+// AdjustmentSummaryResponse totals the quantity changes of one category in
+// one unit: restocked adds up the increases, used the decreases
+type AdjustmentSummaryResponse struct {
+	Category    string  `json:"category"`
+	Unit        string  `json:"unit"`
+	Adjustments int     `json:"adjustments"`
+	Restocked   float64 `json:"restocked"`
+	Used        float64 `json:"used"`
+}
+
+// PurchaseHistoryResponse sums the food added between from and to, by
+// currency and by category (empty for uncategorized food), highest first,
+// with the purchases newest first and the quantity changes made meanwhile
+type PurchaseHistoryResponse struct {
+	From        time.Time                   `json:"from"`
+	To          time.Time                   `json:"to"`
+	Totals      []ValuationTotalResponse    `json:"totals"`
+	ByCategory  []ValuationGroupResponse    `json:"by_category"`
+	Purchases   []PurchaseResponse          `json:"purchases"`
+	Priced      int                         `json:"priced"`
+	Unpriced    int                         `json:"unpriced"`
+	Adjustments []AdjustmentSummaryResponse `json:"adjustments"`
+}
+
+func NewPurchaseHistoryResponse(history entities.PurchaseHistory) PurchaseHistoryResponse {
+	totals := make([]ValuationTotalResponse, len(history.Totals))
+	for i, t := range history.Totals {
+		totals[i] = ValuationTotalResponse{Currency: t.Currency, Objects: t.Objects, Total: t.Total}
+	}
+	purchases := make([]PurchaseResponse, len(history.Purchases))
+	for i, p := range history.Purchases {
+		purchases[i] = PurchaseResponse{
+			ObjectID:       p.ObjectID.String(),
+			Name:           p.Name,
+			CollectionID:   p.CollectionID.String(),
+			CollectionName: p.CollectionName,
+			Category:       p.Category,
+			Quantity:       p.Quantity,
+			Unit:           p.Unit,
+			Price:          p.Price,
+			Currency:       p.Currency,
+			AddedAt:        p.AddedAt,
+		}
+	}
+	adjustments := make([]AdjustmentSummaryResponse, len(history.Adjustments))
+	for i, a := range history.Adjustments {
+		adjustments[i] = AdjustmentSummaryResponse{
+			Category:    a.Category,
+			Unit:        a.Unit,
+			Adjustments: a.Adjustments,
+			Restocked:   a.Restocked,
+			Used:        a.Used,
+		}
+	}
+	return PurchaseHistoryResponse{
+		From:        history.From,
+		To:          history.To,
+		Totals:      totals,
+		ByCategory:  newValuationGroupResponses(history.ByCategory),
+		Purchases:   purchases,
+		Priced:      history.Priced,
+		Unpriced:    history.Unpriced,
+		Adjustments: adjustments,
+	}
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type RecurrenceRuleResponse struct {
+	ID           string    `json:"id"`
+	ObjectID     string    `json:"object_id"`
+	CollectionID string    `json:"collection_id"`
+	Name         string    `json:"name"`
+	Every        int       `json:"every"`
+	Period       string    `json:"period"`
+	Quantity     *float64  `json:"quantity,omitempty"`
+	NextDueAt    time.Time `json:"next_due_at"`
+	// Due is set while the staple is on the shopping list.
+	Due       bool       `json:"due"`
+	DueSince  *time.Time `json:"due_since,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type RecurrenceRuleListResponse struct {
+	Rules []RecurrenceRuleResponse `json:"rules"`
+}
+
+func NewRecurrenceRuleResponse(rule *entities.RecurrenceRule) RecurrenceRuleResponse {
+	return RecurrenceRuleResponse{
+		ID:           rule.ID().String(),
+		ObjectID:     rule.ObjectID().String(),
+		CollectionID: rule.CollectionID().String(),
+		Name:         rule.Name(),
+		Every:        rule.Recurrence().Every,
+		Period:       string(rule.Recurrence().Period),
+		Quantity:     rule.Quantity(),
+		NextDueAt:    rule.NextDueAt(),
+		Due:          rule.IsDue(),
+		DueSince:     rule.DueSince(),
+		CreatedAt:    rule.CreatedAt(),
+		UpdatedAt:    rule.UpdatedAt(),
+	}
+}
+
+func NewRecurrenceRuleListResponse(rules []*entities.RecurrenceRule) RecurrenceRuleListResponse {
+	list := make([]RecurrenceRuleResponse, len(rules))
+	for i, rule := range rules {
+		list[i] = NewRecurrenceRuleResponse(rule)
+	}
+	return RecurrenceRuleListResponse{Rules: list}
+}
@@ -0,0 +1,27 @@
+package response
+
+// TextRangeResponse is a half-open byte range [start, end) of a snippet
+type TextRangeResponse struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SearchMatchResponse is an object matching a search, where it is kept, and
+// the text it matched with the matched terms marked by highlights.
+type SearchMatchResponse struct {
+	Object         ObjectResponse      `json:"object"`
+	CollectionID   string              `json:"collection_id"`
+	CollectionName string              `json:"collection_name"`
+	ContainerName  string              `json:"container_name"`
+	Field          string              `json:"field"` // name, tags, description, location or properties
+	Snippet        string              `json:"snippet"`
+	Highlights     []TextRangeResponse `json:"highlights"`
+}
+
+// SearchResponse lists the matches of Query, name matches first. Truncated
+// is set when more objects matched than were returned.
+type SearchResponse struct {
+	Query     string                `json:"query"`
+	Matches   []SearchMatchResponse `json:"matches"`
+	Truncated bool                  `json:"truncated"`
+}
@@ -0,0 +1,44 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// SessionResponse is one device the user is signed in on. Current marks
+// the session the request was made from.
+type SessionResponse struct {
+	ID         string     `json:"id"`
+	Device     string     `json:"device"`
+	IPAddress  string     `json:"ip_address"`
+	Trusted    bool       `json:"trusted"`
+	Current    bool       `json:"current"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+func NewSessionResponse(session *entities.Session, currentID string) SessionResponse {
+	resp := SessionResponse{
+		ID:         session.ID(),
+		Device:     session.Device(),
+		IPAddress:  session.IPAddress(),
+		Trusted:    session.IsTrusted(),
+		Current:    session.ID() == currentID,
+		CreatedAt:  session.CreatedAt(),
+		LastSeenAt: session.LastSeenAt(),
+	}
+	if expiresAt := session.ExpiresAt(); !expiresAt.IsZero() {
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type ViewSortResponse struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+type ViewPreferenceResponse struct {
+	Sort    []ViewSortResponse `json:"sort,omitempty"`
+	GroupBy string             `json:"group_by,omitempty"`
+	Filters map[string]string  `json:"filters,omitempty"`
+}
+
+type ItemOrderResponse struct {
+	SortIndex int  `json:"sort_index,omitempty"`
+	Pinned    bool `json:"pinned,omitempty"`
+}
+
+type UserPreferencesResponse struct {
+	Views map[string]ViewPreferenceResponse `json:"views"`
+	// CollectionOrder and ContainerOrder map item IDs to where the user
+	// pinned or placed them.
+	CollectionOrder map[string]ItemOrderResponse `json:"collection_order"`
+	ContainerOrder  map[string]ItemOrderResponse `json:"container_order"`
+	// LabelTemplate is the label stock object labels are printed on
+	LabelTemplate string `json:"label_template"`
+	// UnitSystem is metric or imperial
+	UnitSystem string    `json:"unit_system"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewUserPreferencesResponse(preferences *entities.UserPreferences) UserPreferencesResponse {
+	views := make(map[string]ViewPreferenceResponse, len(preferences.Views()))
+	for key, view := range preferences.Views() {
+		sorts := make([]ViewSortResponse, len(view.Sort))
+		for i, s := range view.Sort {
+			sorts[i] = ViewSortResponse{Field: s.Field, Direction: s.Direction}
+		}
+		views[key] = ViewPreferenceResponse{
+			Sort:    sorts,
+			GroupBy: view.GroupBy,
+			Filters: view.Filters,
+		}
+	}
+
+	return UserPreferencesResponse{
+		Views:           views,
+		CollectionOrder: newItemOrderResponse(preferences.Order(entities.OrderScopeCollections)),
+		ContainerOrder:  newItemOrderResponse(preferences.Order(entities.OrderScopeContainers)),
+		LabelTemplate:   preferences.LabelTemplate(),
+		UnitSystem:      string(preferences.UnitSystem()),
+		UpdatedAt:       preferences.UpdatedAt(),
+	}
+}
+
+func newItemOrderResponse(orders map[string]entities.ItemOrder) map[string]ItemOrderResponse {
+	resp := make(map[string]ItemOrderResponse, len(orders))
+	for id, o := range orders {
+		resp[id] = ItemOrderResponse{SortIndex: o.SortIndex, Pinned: o.Pinned}
+	}
+	return resp
+}
@@ -0,0 +1,62 @@
+package response
+
+import "github.com/nishiki/backend/domain/entities"
+
+type ValuationTotalResponse struct {
+	Currency string  `json:"currency"`
+	Objects  int     `json:"objects"`
+	Total    float64 `json:"total"`
+}
+
+// ValuationGroupResponse is the value of the objects in one currency that
+// share a collection, object type, tag or condition. Key is empty for
+// untagged or ungraded objects.
+type ValuationGroupResponse struct {
+	Key      string  `json:"key"`
+	Name     string  `json:"name"`
+	Currency string  `json:"currency"`
+	Objects  int     `json:"objects"`
+	Total    float64 `json:"total"`
+}
+
+// ValuationReportResponse totals the value of the user's owned, active
+// objects by currency, with breakdowns ordered highest total first
+type ValuationReportResponse struct {
+	Totals       []ValuationTotalResponse `json:"totals"`
+	ByCollection []ValuationGroupResponse `json:"by_collection"`
+	ByObjectType []ValuationGroupResponse `json:"by_object_type"`
+	ByTag        []ValuationGroupResponse `json:"by_tag"`
+	ByCondition  []ValuationGroupResponse `json:"by_condition"`
+	Valued       int                      `json:"valued"`
+	Unvalued     int                      `json:"unvalued"`
+}
+
+func NewValuationReportResponse(report entities.ValuationReport) ValuationReportResponse {
+	totals := make([]ValuationTotalResponse, len(report.Totals))
+	for i, t := range report.Totals {
+		totals[i] = ValuationTotalResponse{Currency: t.Currency, Objects: t.Objects, Total: t.Total}
+	}
+	return ValuationReportResponse{
+		Totals:       totals,
+		ByCollection: newValuationGroupResponses(report.ByCollection),
+		ByObjectType: newValuationGroupResponses(report.ByObjectType),
+		ByTag:        newValuationGroupResponses(report.ByTag),
+		ByCondition:  newValuationGroupResponses(report.ByCondition),
+		Valued:       report.Valued,
+		Unvalued:     report.Unvalued,
+	}
+}
+
+func newValuationGroupResponses(groups []entities.ValuationGroup) []ValuationGroupResponse {
+	resp := make([]ValuationGroupResponse, len(groups))
+	for i, g := range groups {
+		resp[i] = ValuationGroupResponse{
+			Key:      g.Key,
+			Name:     g.Name,
+			Currency: g.Currency,
+			Objects:  g.Objects,
+			Total:    g.Total,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,91 @@
+package response
+
+import (
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// WebhookSigningKeyResponse describes the account's webhook signing key.
+// The key itself is only sent back by the rotation that made it; Hint is
+// its last characters so a receiver's config can be checked against it.
+type WebhookSigningKeyResponse struct {
+	Configured bool       `json:"configured"`
+	Key        string     `json:"key,omitempty"`
+	Hint       string     `json:"hint,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	// PreviousExpiresAt is when the key replaced by the last rotation stops
+	// signing, if it still does
+	PreviousExpiresAt *time.Time `json:"previous_expires_at,omitempty"`
+}
+
+// WebhookDeliveryResponse is one webhook an automation rule sent. Payload
+// is the JSON body, as posted on every attempt.
+type WebhookDeliveryResponse struct {
+	ID            string     `json:"id"`
+	RuleID        string     `json:"rule_id"`
+	RuleName      string     `json:"rule_name"`
+	URL           string     `json:"url"`
+	Event         string     `json:"event"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	MaxRetries    int        `json:"max_retries"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	Payload       string     `json:"payload"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// NewWebhookSigningKeyResponse describes keys; withKey includes the current
+// key. keys may be nil for an account without one.
+func NewWebhookSigningKeyResponse(keys *entities.WebhookSigningKeys, withKey bool, now time.Time) WebhookSigningKeyResponse {
+	if keys == nil {
+		return WebhookSigningKeyResponse{}
+	}
+	createdAt := keys.CurrentCreatedAt()
+	resp := WebhookSigningKeyResponse{
+		Configured: true,
+		CreatedAt:  &createdAt,
+	}
+	if current := keys.Current(); len(current) > 4 {
+		resp.Hint = current[len(current)-4:]
+	}
+	if withKey {
+		resp.Key = keys.Current()
+	}
+	if expires := keys.PreviousExpiresAt(); expires != nil && now.Before(*expires) {
+		resp.PreviousExpiresAt = expires
+	}
+	return resp
+}
+
+func NewWebhookDeliveryResponse(d *entities.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:            d.ID().String(),
+		RuleID:        d.RuleID().String(),
+		RuleName:      d.RuleName(),
+		URL:           d.URL(),
+		Event:         d.Event(),
+		Status:        string(d.Status()),
+		Attempts:      d.Attempts(),
+		MaxRetries:    d.Retry().MaxRetries,
+		LastError:     d.LastError(),
+		LastAttemptAt: d.LastAttemptAt(),
+		NextAttemptAt: d.NextAttemptAt(),
+		Payload:       string(d.Payload()),
+		CreatedAt:     d.CreatedAt(),
+	}
+}
+
+func NewWebhookDeliveryListResponse(deliveries []*entities.WebhookDelivery) WebhookDeliveryListResponse {
+	list := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		list[i] = NewWebhookDeliveryResponse(d)
+	}
+	return WebhookDeliveryListResponse{Deliveries: list}
+}
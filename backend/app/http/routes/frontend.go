@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"net/http"
+)
+
+// FrontendAPIPrefix is where the API moves when the backend also serves the
+// web app
+const FrontendAPIPrefix = "/api"
+
+// WithFrontend serves the web app under / and the API under
+// FrontendAPIPrefix, stripped so the API routes stay registered once. The
+// health checks also answer at the root so probes keep working unchanged.
+func WithFrontend(api, frontend http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(FrontendAPIPrefix+"/", http.StripPrefix(FrontendAPIPrefix, api))
+	mux.Handle("/health", api)
+	mux.Handle("/health/", api)
+	mux.Handle("/", frontend)
+	return mux
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFrontend(t *testing.T) {
+	t.Parallel()
+
+	name := func(label string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(label + " " + r.URL.Path))
+		})
+	}
+	h := WithFrontend(name("api"), name("app"))
+
+	for path, want := range map[string]string{
+		"/":                     "app /",
+		"/collections/42":       "app /collections/42",
+		"/app.wasm":             "app /app.wasm",
+		"/api/v1/groups":        "api /v1/groups",
+		"/api/api/openapi.json": "api /api/openapi.json",
+		"/health/live":          "api /health/live",
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, want, rec.Body.String(), path)
+	}
+}
@@ -1,13 +1,16 @@
 package routes
 
 import (
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/nishiki/backend/app/container"
 	"github.com/nishiki/backend/app/http/controllers"
 	"github.com/nishiki/backend/app/http/httputil"
 	"github.com/nishiki/backend/app/http/middleware"
 	"github.com/nishiki/backend/app/http/openapi"
+	"github.com/nishiki/backend/domain/entities"
 )
 
 // Setup configures all routes and returns an http.Handler
@@ -26,20 +29,52 @@ func Setup(appContainer *container.Container) http.Handler {
 	containerController := controllers.NewContainerController(appContainer, logger)
 	collectionController := controllers.NewCollectionController(appContainer, logger)
 	objectController := controllers.NewObjectController(appContainer, logger)
+	digestController := controllers.NewDigestController(appContainer, logger)
+	preferencesController := controllers.NewPreferencesController(appContainer, logger)
+	mediaController := controllers.NewMediaController(appContainer, logger)
+	floorPlanController := controllers.NewFloorPlanController(appContainer, logger)
+	nutritionController := controllers.NewNutritionController(appContainer, logger)
+	reportController := controllers.NewReportController(appContainer, logger)
+	backupController := controllers.NewBackupController(appContainer, logger)
+	healthController := controllers.NewHealthController(appContainer, logger)
+	containerTemplateController := controllers.NewContainerTemplateController(appContainer, logger)
+	accountController := controllers.NewAccountController(appContainer, logger)
+	sessionController := controllers.NewSessionController(appContainer, logger)
+	mealPlanController := controllers.NewMealPlanController(appContainer, logger)
+	recurrenceController := controllers.NewRecurrenceController(appContainer, logger)
+	automationController := controllers.NewAutomationController(appContainer, logger)
+	webhookController := controllers.NewWebhookController(appContainer, logger)
+	folderController := controllers.NewCollectionFolderController(appContainer, logger)
+	objectTypeController := controllers.NewCustomObjectTypeController(appContainer, logger)
+	snapshotController := controllers.NewCollectionSnapshotController(appContainer, logger)
+	commentController := controllers.NewCommentController(appContainer, logger)
+	clientErrorController := controllers.NewClientErrorController(appContainer, logger)
+	adminController := controllers.NewAdminController(appContainer, logger)
+	importJobController := controllers.NewImportJobController(appContainer, logger)
+	dashboardController := controllers.NewDashboardController(appContainer, logger)
+	inboxController := controllers.NewInboxController(appContainer, logger)
+	featureController := controllers.NewFeatureController(appContainer, logger)
 
 	// Define global middleware chain
 	globalMiddleware := httputil.Chain(
-		middleware.CORSMiddleware(middleware.CORSConfig{
-			AllowOrigins:     []string{"*"},
-			AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-			ExposeHeaders:    []string{"Content-Length"},
-			AllowCredentials: true,
-		}),
+		appContainer.CORS().Middleware,
+		middleware.RequestIDMiddleware(),
+		middleware.MetricsMiddleware(appContainer.GetMetrics()),
 		middleware.RecoveryMiddleware(logger),
 		middleware.LoggingMiddleware(logger),
+		middleware.APIVersionMiddleware(middleware.APIVersionConfig{
+			Sunset: appContainer.GetConfig().Server.LegacyAPISunsetTime(),
+		}),
 	)
 
+	// Requests are checked against the generated spec before any handler
+	// sees them; in debug mode responses are checked too and mismatches logged
+	if validator, err := openapi.NewValidator(openapi.GenerateOpenAPISpec()); err != nil {
+		logger.Error("OpenAPI validation disabled", slog.Any("error", err))
+	} else {
+		globalMiddleware = httputil.Chain(globalMiddleware, validator.Middleware(logger, appContainer.GetConfig().Server.Debug))
+	}
+
 	// Auth middleware for protected routes
 	authRequired := authMiddleware.RequireAuth()
 
@@ -48,72 +83,296 @@ func Setup(appContainer *container.Container) http.Handler {
 		return httputil.WrapHandler(h, authRequired)
 	}
 
-	// API spec (no auth required — docs UI served by frontend)
-	mux.HandleFunc("GET /api/openapi.json", openapi.HandleOpenAPISpec)
+	// Read endpoints the frontend revisits often answer If-None-Match with 304
+	withCache := func(h http.HandlerFunc) http.HandlerFunc {
+		return httputil.WrapHandler(h, authRequired, middleware.ConditionalGetMiddleware())
+	}
+
+	// Destructive or data-revealing account routes also need a fresh sign-in
+	reauthMaxAge := time.Duration(appContainer.GetConfig().Auth.ReauthMaxAge) * time.Second
+	withRecentAuth := func(h http.HandlerFunc) http.HandlerFunc {
+		return httputil.WrapHandler(h, authRequired, middleware.RequireRecentAuth(reauthMaxAge, logger))
+	}
+
+	// Admin routes need membership of the configured admin group
+	adminGroup := appContainer.GetConfig().Auth.AdminGroup
+	withAdmin := func(h http.HandlerFunc) http.HandlerFunc {
+		return httputil.WrapHandler(h, authRequired, middleware.RequireAdmin(adminGroup, logger))
+	}
+
+	// Experimental features answer 404 to users they are not rolled out to;
+	// wrap the result in withAuth or withCache
+	withFeature := func(name string, h http.HandlerFunc) http.HandlerFunc {
+		return httputil.WrapHandler(h, middleware.RequireFeature(name, appContainer.Features().IsEnabled, logger))
+	}
+
+	// Heavy read-only endpoints may be served from the database read
+	// replica; wrap the result in withAuth or withCache
+	withReplica := func(h http.HandlerFunc) http.HandlerFunc {
+		return httputil.WrapHandler(h, middleware.ReplicaReadsMiddleware())
+	}
+
+	// API spec (no auth required — docs UI served by frontend). The spec is
+	// built once per process; the ETag lets the docs page revalidate it cheaply.
+	mux.HandleFunc("GET /api/openapi.json", httputil.WrapHandler(http.HandlerFunc(openapi.HandleOpenAPISpec), middleware.ConditionalGetMiddleware()))
 
 	// Health check endpoint (no auth required)
 	mux.HandleFunc("GET /health", authController.HealthCheck)
+	mux.HandleFunc("GET /health/live", healthController.Live)
+	mux.HandleFunc("GET /health/ready", healthController.Ready)
+
+	// Public status page, for uptime monitors that poll every few seconds at
+	// most; anything faster is turned away
+	mux.HandleFunc("GET /status", httputil.WrapHandler(http.HandlerFunc(healthController.Status), appContainer.StatusRateLimit().Middleware))
 
 	// Auth routes (without auth middleware for OIDC endpoints)
 	mux.HandleFunc("GET /auth/oidc-config", authController.GetOIDCConfig)
 	mux.HandleFunc("POST /auth/token", authController.ProxyTokenExchange)
 	mux.HandleFunc("GET /auth/me", withAuth(authController.GetCurrentUser))
 
+	// Feature flags evaluated for the current user
+	mux.HandleFunc("GET /features", withAuth(featureController.GetFeatures))
+
+	// Local accounts and their sign-in page (local auth mode only)
+	if localAuthController := controllers.NewLocalAuthController(appContainer, logger); localAuthController != nil {
+		mux.HandleFunc("POST /auth/register", localAuthController.Register)
+		mux.HandleFunc("POST /auth/login", localAuthController.Login)
+		mux.HandleFunc("GET /auth/local/authorize", localAuthController.SignInPage)
+		mux.HandleFunc("POST /auth/local/authorize", localAuthController.SignIn)
+		mux.HandleFunc("GET /auth/local/end-session", localAuthController.EndSession)
+	}
+
+	// Frontend error reports (auth optional, attributed to the user when present)
+	mux.HandleFunc("POST /client-errors", httputil.WrapHandler(http.HandlerFunc(clientErrorController.ReportClientError), authMiddleware.OptionalAuth()))
+
 	// Group routes (all require auth)
-	mux.HandleFunc("GET /groups", withAuth(groupController.GetGroups))
+	mux.HandleFunc("GET /groups", withCache(groupController.GetGroups))
 	mux.HandleFunc("POST /groups", withAuth(groupController.CreateGroup))
-	mux.HandleFunc("GET /groups/{id}", withAuth(groupController.GetGroup))
+	mux.HandleFunc("GET /groups/{id}", withCache(groupController.GetGroup))
 	mux.HandleFunc("PUT /groups/{id}", withAuth(groupController.UpdateGroup))
 	mux.HandleFunc("DELETE /groups/{id}", withAuth(groupController.DeleteGroup))
-	mux.HandleFunc("GET /groups/{id}/containers", withAuth(groupController.GetGroupContainers))
-	mux.HandleFunc("GET /groups/{id}/users", withAuth(groupController.GetGroupUsers))
+	mux.HandleFunc("GET /groups/{id}/containers", withCache(groupController.GetGroupContainers))
+	mux.HandleFunc("GET /groups/{id}/users", withCache(groupController.GetGroupUsers))
 	mux.HandleFunc("POST /groups/{id}/users/{user_id}", withAuth(groupController.AddGroupMember))
 	mux.HandleFunc("DELETE /groups/{id}/users/{user_id}", withAuth(groupController.RemoveGroupMember))
+	mux.HandleFunc("PUT /groups/{id}/users/{user_id}/role", withAuth(groupController.SetGroupMemberRole))
+	mux.HandleFunc("GET /groups/{id}/quota", withCache(groupController.GetGroupQuota))
+	mux.HandleFunc("PUT /groups/{id}/quota", withAuth(groupController.SetGroupQuota))
+	mux.HandleFunc("GET /groups/{id}/members", withAuth(groupController.GetGroupMembers))
+	mux.HandleFunc("POST /groups/{id}/invitations", withAuth(groupController.InviteGroupMember))
 	mux.HandleFunc("POST /groups/join", withAuth(groupController.JoinGroup))
 
 	// User routes (all require auth)
-	mux.HandleFunc("GET /users/{id}", withAuth(userController.GetUser))
+	mux.HandleFunc("GET /users/{id}", withCache(userController.GetUser))
 
 	// Container routes (all require auth)
-	mux.HandleFunc("GET /containers", withAuth(containerController.GetContainers))
+	mux.HandleFunc("GET /containers", withCache(containerController.GetContainers))
 	mux.HandleFunc("POST /containers", withAuth(containerController.CreateContainer))
-	mux.HandleFunc("GET /containers/{container_id}", withAuth(containerController.GetContainer))
+	mux.HandleFunc("GET /containers/{container_id}", withCache(containerController.GetContainer))
 	mux.HandleFunc("PUT /containers/{container_id}", withAuth(containerController.UpdateContainer))
+	mux.HandleFunc("PATCH /containers/{container_id}", withAuth(containerController.PatchContainer))
 
 	// Account routes (mapped to user functionality, all require auth)
-	mux.HandleFunc("GET /accounts/{id}", withAuth(userController.GetUser))
+	mux.HandleFunc("GET /accounts/{id}", withCache(userController.GetUser))
+	mux.HandleFunc("DELETE /accounts/{id}", withRecentAuth(accountController.DeleteAccount))
+	mux.HandleFunc("GET /accounts/{id}/data-export", withRecentAuth(withReplica(accountController.ExportAccountData)))
+
+	// Signed-in devices
+	mux.HandleFunc("GET /accounts/{id}/sessions", withCache(sessionController.ListSessions))
+	mux.HandleFunc("POST /accounts/{id}/sessions/revoke-others", withAuth(sessionController.RevokeOtherSessions))
+	mux.HandleFunc("PUT /accounts/{id}/sessions/{session_id}", withAuth(sessionController.UpdateSession))
+	mux.HandleFunc("DELETE /accounts/{id}/sessions/{session_id}", withAuth(sessionController.RevokeSession))
 
 	// Collections under accounts
-	mux.HandleFunc("GET /accounts/{id}/collections", withAuth(collectionController.GetCollections))
+	mux.HandleFunc("GET /accounts/{id}/dashboard", withCache(dashboardController.GetDashboard))
+	mux.HandleFunc("GET /accounts/{id}/collections", withCache(collectionController.GetCollections))
 	mux.HandleFunc("POST /accounts/{id}/collections", withAuth(collectionController.CreateCollection))
-	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}", withAuth(collectionController.GetCollection))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}", withCache(collectionController.GetCollection))
 	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}", withAuth(collectionController.UpdateCollection))
+	mux.HandleFunc("PATCH /accounts/{id}/collections/{collection_id}", withAuth(collectionController.PatchCollection))
 	mux.HandleFunc("DELETE /accounts/{id}/collections/{collection_id}", withAuth(collectionController.DeleteCollection))
 	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}/schema", withAuth(collectionController.UpdatePropertySchema))
-	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/export", withAuth(collectionController.ExportCollection))
+	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}/shelf-life", withAuth(collectionController.UpdateShelfLife))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/export", withAuth(withReplica(collectionController.ExportCollection)))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/report.pdf", withAuth(withReplica(collectionController.GenerateReport)))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/transfer", withAuth(collectionController.TransferCollection))
+	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}/floor-plan", withAuth(floorPlanController.UploadFloorPlan))
+	mux.HandleFunc("DELETE /accounts/{id}/collections/{collection_id}/floor-plan", withAuth(floorPlanController.DeleteFloorPlan))
 
 	// Containers under collections
-	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers", withAuth(containerController.GetContainers))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers", withCache(containerController.GetContainers))
 	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/containers", withAuth(containerController.CreateContainer))
-	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers/{container_id}", withAuth(containerController.GetContainer))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/containers/from-template", withAuth(containerTemplateController.CreateContainersFromTemplate))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers/tree", withAuth(withReplica(containerController.ExportContainerTree)))
+	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}/containers/tree", withAuth(containerController.ImportContainerTree))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/containers/import", withAuth(containerController.BulkCreateContainers))
+	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}/containers/placements", withAuth(floorPlanController.UpdateContainerPlacements))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers/{container_id}", withCache(containerController.GetContainer))
 	mux.HandleFunc("PUT /accounts/{id}/collections/{collection_id}/containers/{container_id}", withAuth(containerController.UpdateContainer))
+	mux.HandleFunc("PATCH /accounts/{id}/collections/{collection_id}/containers/{container_id}", withAuth(containerController.PatchContainer))
 	mux.HandleFunc("DELETE /accounts/{id}/collections/{collection_id}/containers/{container_id}", withAuth(containerController.DeleteContainer))
 
 	// Container objects
-	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers/{container_id}/objects", withAuth(objectController.GetCollectionObjects))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers/{container_id}/objects", withCache(objectController.GetCollectionObjects))
 	mux.HandleFunc("DELETE /accounts/{id}/collections/{collection_id}/containers/{container_id}/objects/{object_id}", withAuth(objectController.RemoveObjectFromContainer))
 
 	// Collection objects
-	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/objects", withAuth(objectController.GetCollectionObjects))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/objects", withCache(objectController.GetCollectionObjects))
 	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/import", withAuth(objectController.BulkImportToCollection))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/duplicates", withAuth(objectController.FindDuplicateObjects))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/objects/retag", withAuth(objectController.RetagObjects))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/objects/batch", withAuth(objectController.BatchObjects))
+
+	// Point-in-time copies of a collection's containers and objects
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/snapshots", withCache(snapshotController.ListSnapshots))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/snapshots", withAuth(snapshotController.CreateSnapshot))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}/diff", withCache(snapshotController.DiffSnapshot))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}/restore", withAuth(snapshotController.RestoreSnapshot))
+	mux.HandleFunc("DELETE /accounts/{id}/collections/{collection_id}/snapshots/{snapshot_id}", withAuth(snapshotController.DeleteSnapshot))
+
+	// Photos of objects and containers, and the collection-wide gallery
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/media", withCache(mediaController.ListCollectionMedia))
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/containers/{container_id}/media", withCache(mediaController.ListContainerMedia))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/containers/{container_id}/media", withAuth(mediaController.UploadContainerMedia))
+	mux.HandleFunc("GET /accounts/{id}/objects/{object_id}/media", withCache(mediaController.ListObjectMedia))
+	mux.HandleFunc("POST /accounts/{id}/objects/{object_id}/media", withAuth(mediaController.UploadObjectMedia))
+	mux.HandleFunc("DELETE /accounts/{id}/media/{media_id}", withAuth(mediaController.DeleteMedia))
+
+	// Notes left for the people a collection is shared with, and unread badges
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/comments", withCache(commentController.ListCollectionComments))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/comments", withAuth(commentController.CreateCollectionComment))
+	mux.HandleFunc("POST /accounts/{id}/collections/{collection_id}/comments/read", withAuth(commentController.MarkCommentsRead))
+	mux.HandleFunc("GET /accounts/{id}/objects/{object_id}/comments", withCache(commentController.ListObjectComments))
+	mux.HandleFunc("POST /accounts/{id}/objects/{object_id}/comments", withAuth(commentController.CreateObjectComment))
+	mux.HandleFunc("GET /accounts/{id}/comments/unread", withCache(commentController.GetUnreadComments))
+	mux.HandleFunc("DELETE /accounts/{id}/comments/{comment_id}", withAuth(commentController.DeleteComment))
+
+	// Folders grouping a user's collections, with totals across subfolders
+	mux.HandleFunc("GET /accounts/{id}/collection-folders", withCache(folderController.ListCollectionFolders))
+	mux.HandleFunc("POST /accounts/{id}/collection-folders", withAuth(folderController.CreateCollectionFolder))
+	mux.HandleFunc("PUT /accounts/{id}/collection-folders/{folder_id}", withAuth(folderController.UpdateCollectionFolder))
+	mux.HandleFunc("DELETE /accounts/{id}/collection-folders/{folder_id}", withAuth(folderController.DeleteCollectionFolder))
+	mux.HandleFunc("PUT /accounts/{id}/collection-folders/{folder_id}/collections/{collection_id}", withAuth(folderController.FileCollection))
+	mux.HandleFunc("DELETE /accounts/{id}/collection-folders/{folder_id}/collections/{collection_id}", withAuth(folderController.UnfileCollection))
+	mux.HandleFunc("GET /accounts/{id}/collection-folders/{folder_id}/stats", withCache(withReplica(folderController.GetCollectionFolderStats)))
+
+	// Custom object types
+	mux.HandleFunc("GET /accounts/{id}/object-types", withCache(objectTypeController.ListObjectTypes))
+	mux.HandleFunc("POST /accounts/{id}/object-types", withAuth(objectTypeController.CreateObjectType))
+	mux.HandleFunc("PUT /accounts/{id}/object-types/{type_id}", withAuth(objectTypeController.UpdateObjectType))
+	mux.HandleFunc("DELETE /accounts/{id}/object-types/{type_id}", withAuth(objectTypeController.DeleteObjectType))
+
+	// Nutrition facts lookup for food objects and pantry totals
+	mux.HandleFunc("GET /accounts/{id}/collections/{collection_id}/nutrition", withCache(withReplica(nutritionController.GetNutritionStats)))
+	mux.HandleFunc("POST /accounts/{id}/objects/{object_id}/nutrition", withAuth(nutritionController.EnrichObjectNutrition))
+
+	// Account-wide reports
+	mux.HandleFunc("GET /accounts/{id}/reports/valuation", withCache(withReplica(reportController.GetValuationReport)))
+	mux.HandleFunc("GET /accounts/{id}/reports/purchases", withCache(withReplica(reportController.GetPurchaseHistory)))
 
 	// Bulk import to a container (container_id in request body)
 	mux.HandleFunc("POST /accounts/{id}/import", withAuth(objectController.BulkImport))
 
+	// Progress of collection imports running in the background
+	mux.HandleFunc("GET /imports/{job_id}", withAuth(importJobController.GetImportJob))
+	mux.HandleFunc("GET /imports/{job_id}/events", withAuth(importJobController.StreamImportJobEvents))
+
 	// Objects under accounts
 	mux.HandleFunc("POST /accounts/{id}/objects", withAuth(objectController.CreateObject))
+	mux.HandleFunc("POST /accounts/{id}/objects/merge", withAuth(objectController.MergeObjects))
+	mux.HandleFunc("POST /accounts/{id}/objects/parse", withAuth(objectController.ParseObject))
+	mux.HandleFunc("GET /accounts/{id}/lookup", withAuth(objectController.LookupObjectCode))
+	mux.HandleFunc("GET /accounts/{id}/search", withAuth(withReplica(objectController.SearchObjects)))
+	mux.HandleFunc("GET /accounts/{id}/expiring.ics", withAuth(objectController.GetExpiryCalendar))
+	mux.HandleFunc("GET /accounts/{id}/expiry-suggestions", withCache(objectController.GetExpirySuggestions))
 	mux.HandleFunc("PUT /accounts/{id}/objects/{object_id}", withAuth(objectController.UpdateObject))
+	mux.HandleFunc("PATCH /accounts/{id}/objects/{object_id}", withAuth(objectController.PatchObject))
+	mux.HandleFunc("PATCH /accounts/{id}/objects/{object_id}/archive", withAuth(objectController.ArchiveObject))
+	mux.HandleFunc("POST /accounts/{id}/objects/{object_id}/copy", withAuth(objectController.CopyObject))
+	mux.HandleFunc("POST /accounts/{id}/objects/{object_id}/claim", withAuth(objectController.ClaimObject))
+	mux.HandleFunc("DELETE /accounts/{id}/objects/{object_id}/claim", withAuth(objectController.ReleaseObjectClaim))
 	mux.HandleFunc("DELETE /accounts/{id}/objects/{object_id}", withAuth(objectController.DeleteObject))
+	mux.HandleFunc("GET /accounts/{id}/objects/{object_id}/history", withCache(objectController.GetObjectHistory))
+	mux.HandleFunc("GET /accounts/{id}/objects/{object_id}/label", withAuth(objectController.GetObjectLabel))
+
+	// Reusable container layouts
+	mux.HandleFunc("GET /accounts/{id}/container-templates", withCache(containerTemplateController.ListContainerTemplates))
+	mux.HandleFunc("POST /accounts/{id}/container-templates", withAuth(containerTemplateController.SaveContainerTemplate))
+	mux.HandleFunc("DELETE /accounts/{id}/container-templates/{template_id}", withAuth(containerTemplateController.DeleteContainerTemplate))
+
+	// Meal plans linked to food objects
+	mux.HandleFunc("GET /accounts/{id}/meal-plans", withCache(withFeature(entities.FeatureMealPlanner, mealPlanController.ListMealPlans)))
+	mux.HandleFunc("POST /accounts/{id}/meal-plans", withAuth(withFeature(entities.FeatureMealPlanner, mealPlanController.CreateMealPlan)))
+	mux.HandleFunc("PUT /accounts/{id}/meal-plans/{meal_plan_id}", withAuth(withFeature(entities.FeatureMealPlanner, mealPlanController.UpdateMealPlan)))
+	mux.HandleFunc("DELETE /accounts/{id}/meal-plans/{meal_plan_id}", withAuth(withFeature(entities.FeatureMealPlanner, mealPlanController.DeleteMealPlan)))
+	mux.HandleFunc("POST /accounts/{id}/meal-plans/{meal_plan_id}/complete", withAuth(withFeature(entities.FeatureMealPlanner, mealPlanController.CompleteMealPlan)))
+
+	// Receipts and order confirmations forwarded by email
+	mux.HandleFunc("GET /accounts/{id}/inbox", withCache(inboxController.ListInboxItems))
+	mux.HandleFunc("GET /accounts/{id}/inbox/address", withAuth(inboxController.GetInboxAddress))
+	mux.HandleFunc("POST /accounts/{id}/inbox/address/reset", withAuth(inboxController.ResetInboxAddress))
+	mux.HandleFunc("POST /accounts/{id}/inbox/{item_id}/confirm", withAuth(inboxController.ConfirmInboxItem))
+	mux.HandleFunc("DELETE /accounts/{id}/inbox/{item_id}", withAuth(inboxController.DismissInboxItem))
+
+	// Staples put back on the shopping list on a schedule
+	mux.HandleFunc("GET /accounts/{id}/recurrences", withCache(recurrenceController.ListRecurrenceRules))
+	mux.HandleFunc("POST /accounts/{id}/recurrences", withAuth(recurrenceController.CreateRecurrenceRule))
+	mux.HandleFunc("PUT /accounts/{id}/recurrences/{recurrence_id}", withAuth(recurrenceController.UpdateRecurrenceRule))
+	mux.HandleFunc("DELETE /accounts/{id}/recurrences/{recurrence_id}", withAuth(recurrenceController.DeleteRecurrenceRule))
+
+	// Rules run on object changes
+	mux.HandleFunc("GET /accounts/{id}/automations", withCache(automationController.ListAutomationRules))
+	mux.HandleFunc("POST /accounts/{id}/automations", withAuth(automationController.CreateAutomationRule))
+	mux.HandleFunc("PUT /accounts/{id}/automations/{rule_id}", withAuth(automationController.UpdateAutomationRule))
+	mux.HandleFunc("DELETE /accounts/{id}/automations/{rule_id}", withAuth(automationController.DeleteAutomationRule))
+	mux.HandleFunc("GET /accounts/{id}/webhooks/signing-key", withAuth(webhookController.GetWebhookSigningKey))
+	mux.HandleFunc("POST /accounts/{id}/webhooks/signing-key/rotate", withAuth(webhookController.RotateWebhookSigningKey))
+	mux.HandleFunc("GET /accounts/{id}/webhooks/deliveries", withAuth(webhookController.ListWebhookDeliveries))
+	mux.HandleFunc("POST /accounts/{id}/webhooks/deliveries/{delivery_id}/retry", withAuth(webhookController.RetryWebhookDelivery))
+
+	// Full account backup and restore
+	mux.HandleFunc("GET /accounts/{id}/backup", withAuth(backupController.Backup))
+	mux.HandleFunc("POST /accounts/{id}/restore", withAuth(backupController.Restore))
+
+	// Email digest preferences
+	mux.HandleFunc("GET /accounts/{id}/digest-preferences", withCache(digestController.GetDigestPreferences))
+	mux.HandleFunc("PUT /accounts/{id}/digest-preferences", withAuth(digestController.UpdateDigestPreferences))
+
+	// Per-view sort, grouping and filter preferences, and pinned/custom order
+	mux.HandleFunc("GET /accounts/{id}/preferences", withCache(preferencesController.GetPreferences))
+	mux.HandleFunc("PUT /accounts/{id}/preferences", withAuth(preferencesController.UpdatePreferences))
+	mux.HandleFunc("PATCH /accounts/{id}/collections/order", withAuth(preferencesController.UpdateCollectionOrder))
+	mux.HandleFunc("PATCH /accounts/{id}/containers/order", withAuth(preferencesController.UpdateContainerOrder))
+
+	// Admin dashboard: users, their usage, and disabling accounts
+	mux.HandleFunc("GET /admin/users", withAdmin(adminController.ListUsers))
+	mux.HandleFunc("GET /admin/stats", withAdmin(withReplica(adminController.GetStats)))
+	mux.HandleFunc("POST /admin/users/{user_id}/disable", withAdmin(adminController.DisableUser))
+	mux.HandleFunc("POST /admin/users/{user_id}/enable", withAdmin(adminController.EnableUser))
+	mux.HandleFunc("GET /admin/oauth-clients", withAdmin(adminController.ListOAuthClients))
+	mux.HandleFunc("POST /admin/oauth-clients", withAdmin(adminController.RegisterOAuthClient))
+	mux.HandleFunc("PUT /admin/oauth-clients/{client_id}", withAdmin(adminController.UpdateOAuthClient))
+	mux.HandleFunc("DELETE /admin/oauth-clients/{client_id}", withAdmin(adminController.RemoveOAuthClient))
+	mux.HandleFunc("GET /admin/logging", withAdmin(adminController.GetLogLevels))
+	mux.HandleFunc("PUT /admin/logging", withAdmin(adminController.UpdateLogLevels))
+	mux.HandleFunc("GET /admin/features", withAdmin(adminController.ListFeatureFlags))
+	mux.HandleFunc("PUT /admin/features/{name}", withAdmin(adminController.SetFeatureFlag))
+	mux.HandleFunc("DELETE /admin/features/{name}", withAdmin(adminController.RemoveFeatureFlag))
+
+	// Fixture data for frontend development and integration tests
+	if appContainer.GetConfig().Server.Debug {
+		devController := controllers.NewDevController(appContainer, logger)
+		mux.HandleFunc("POST /dev/seed", withAuth(devController.Seed))
+	}
+
+	// Unsubscribe links in digest emails (no auth — the token is the credential).
+	// GET only asks for confirmation; POST unsubscribes, from that page or
+	// from RFC 8058 one-click unsubscribe in mail clients.
+	mux.HandleFunc("GET /digest/unsubscribe", digestController.UnsubscribePage)
+	mux.HandleFunc("POST /digest/unsubscribe", digestController.Unsubscribe)
+
+	// Inbound email webhook (no auth — requests carry the mail provider's signature).
+	mux.HandleFunc("POST /inbox/email", inboxController.ReceiveEmail)
 
 	// Serve cached images (no auth required — URLs are unguessable hashes)
 	imagesCacheDir := appContainer.GetConfig().Images.CacheDir
@@ -122,6 +381,13 @@ func Setup(appContainer *container.Container) http.Handler {
 	}
 	mux.Handle("GET /images/", http.StripPrefix("/images/", http.FileServer(http.Dir(imagesCacheDir))))
 
+	// Serve uploaded photos (no auth required — paths are random IDs)
+	mediaDir := appContainer.GetConfig().Media.Dir
+	if mediaDir == "" {
+		mediaDir = "./media"
+	}
+	mux.Handle("GET /media/", http.StripPrefix("/media/", http.FileServer(http.Dir(mediaDir))))
+
 	// Apply global middleware
 	return globalMiddleware(mux)
 }
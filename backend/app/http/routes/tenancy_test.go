@@ -0,0 +1,356 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/http/openapi"
+	"github.com/nishiki/backend/app/importjobs"
+	"github.com/nishiki/backend/app/mcp/testkit"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+const attackerToken = "attacker-token"
+
+// TestTenancy_CrossAccountAccess calls every endpoint in the API spec as an
+// outsider, once under the victim's account ID and once under the
+// attacker's own, with the victim's collection, container, object and other
+// IDs in the path and body. No write may succeed against the victim's
+// data, no response may reveal it and none of it may change.
+func TestTenancy_CrossAccountAccess(t *testing.T) {
+	ctx := context.Background()
+	kit, err := testkit.New(ctx, nil)
+	require.NoError(t, err)
+	defer kit.Close()
+	kit.Container.SetLogger(slog.New(slog.DiscardHandler))
+	seed := kit.Seed
+
+	username, err := entities.NewUsername("mallory")
+	require.NoError(t, err)
+	email, err := entities.NewEmailAddress("mallory@example.com")
+	require.NoError(t, err)
+	attacker, err := entities.NewUser(entities.UserProps{Username: username, EmailAddress: email})
+	require.NoError(t, err)
+	kit.Auth.AddUser(attacker, attackerToken)
+
+	// The attacker has a collection of their own to aim the victim's IDs at
+	name, err := entities.NewCollectionName("Garage")
+	require.NoError(t, err)
+	own, err := entities.NewCollection(entities.CollectionProps{UserID: attacker.ID(), Name: name, ObjectType: entities.ObjectTypeGeneral})
+	require.NoError(t, err)
+	require.NoError(t, kit.Container.CollectionRepo.Create(ctx, own))
+
+	// Rules are not part of the shared seed, so the victim gets one here
+	rule, err := entities.NewAutomationRule(seed.User.ID(), entities.AutomationRuleProps{
+		Name:    "Tag new food",
+		Trigger: entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+		Actions: []entities.AutomationAction{{Kind: entities.ActionAddTag, Tag: "new"}},
+	}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, kit.Container.AutomationRuleRepo.Create(ctx, rule))
+
+	before := victimState(t, kit)
+	handler := Setup(kit.Container)
+
+	victimIDs := map[string]string{
+		"collection_id": seed.Collection.ID().String(),
+		"container_id":  seed.Pantry.ID().String(),
+		"object_id":     seed.Rice.ID().String(),
+		"snapshot_id":   seed.Snapshot.ID().String(),
+		"meal_plan_id":  seed.MealPlan.ID().String(),
+		"recurrence_id": seed.Recurrence.ID().String(),
+		"rule_id":       rule.ID().String(),
+		"user_id":       seed.User.ID().String(),
+	}
+	// Anything of the victim's that no successful response may contain.
+	// Failures may echo the IDs they were given, so only names are checked
+	// there. The collection's name is left out as a built-in container
+	// template shares it.
+	names := []string{
+		seed.Beans.Name().String(),
+		seed.User.EmailAddress().String(),
+	}
+	secrets := append(slices.Clone(names),
+		seed.Collection.ID().String(),
+		seed.Pantry.ID().String(),
+		seed.Rice.ID().String(),
+	)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Schema map[string]any `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(openapi.GenerateOpenAPISpec(), &spec))
+	require.NotEmpty(t, spec.Paths)
+	bodies := &exampleBodies{schemas: spec.Components.Schemas, ids: victimIDs}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		for method, op := range spec.Paths[path] {
+			method = strings.ToUpper(method)
+			body := []byte{}
+			if content, ok := op.RequestBody.Content["application/json"]; ok {
+				body, err = json.Marshal(bodies.example(content.Schema, 0))
+				require.NoError(t, err)
+			}
+
+			for _, account := range []*entities.User{seed.User, attacker} {
+				target, touchesVictim := fillPath(path, account, seed, victimIDs)
+				if account == seed.User {
+					touchesVictim = touchesVictim || strings.Contains(path, "{id}")
+				}
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(method, target, bytes.NewReader(body))
+				req.Header.Set("Authorization", "Bearer "+attackerToken)
+				req.Header.Set("Content-Type", "application/json")
+				handler.ServeHTTP(rec, req)
+
+				// Reads may answer with an empty result instead of an error
+				if touchesVictim && method != http.MethodGet {
+					assert.GreaterOrEqual(t, rec.Code, 400, "%s %s succeeded: %s", method, target, rec.Body.String())
+				}
+				leakable := names
+				if rec.Code < 400 {
+					leakable = secrets
+				}
+				for _, secret := range leakable {
+					assert.NotContains(t, rec.Body.String(), secret, "%s %s leaked victim data", method, target)
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, before, victimState(t, kit), "victim data changed")
+}
+
+// TestTenancy_RepositoriesEnforceOwnership checks the repositories refuse
+// another account's data even when asked for it by ID, as a handler that
+// forgot its access check would.
+func TestTenancy_RepositoriesEnforceOwnership(t *testing.T) {
+	ctx := context.Background()
+	kit, err := testkit.New(ctx, nil)
+	require.NoError(t, err)
+	defer kit.Close()
+	seed := kit.Seed
+
+	outsider := repositories.WithTenant(ctx, repositories.NewTenant(seed.OtherUser.ID(), nil))
+	member := repositories.WithTenant(ctx, repositories.NewTenant(seed.User.ID(), nil))
+
+	_, err = kit.Container.CollectionRepo.GetByID(outsider, seed.Collection.ID())
+	assert.Error(t, err)
+	_, err = kit.Container.ContainerRepo.GetByID(outsider, seed.Pantry.ID())
+	assert.Error(t, err)
+	_, err = kit.Container.ContainerRepo.FindByObjectID(outsider, seed.Rice.ID())
+	assert.Error(t, err)
+	collections, err := kit.Container.CollectionRepo.GetByUserID(outsider, seed.User.ID())
+	require.NoError(t, err)
+	assert.Empty(t, collections)
+	containers, err := kit.Container.ContainerRepo.GetByCollectionID(outsider, seed.Collection.ID())
+	require.NoError(t, err)
+	assert.Empty(t, containers)
+
+	assert.Error(t, kit.Container.ContainerRepo.Delete(outsider, seed.Pantry.ID()))
+	assert.Error(t, kit.Container.CollectionRepo.Delete(outsider, seed.Collection.ID()))
+	assert.ErrorIs(t, kit.Container.CollectionRepo.Update(outsider, seed.Collection), repositories.ErrOutsideTenant)
+	assert.ErrorIs(t, kit.Container.ContainerRepo.Update(outsider, seed.Pantry), repositories.ErrOutsideTenant)
+
+	stolen, err := entities.NewContainer(entities.ContainerProps{
+		CollectionID:  seed.Collection.ID(),
+		Name:          seed.Pantry.Name(),
+		ContainerType: entities.ContainerTypeShelf,
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, kit.Container.ContainerRepo.Create(outsider, stolen), repositories.ErrOutsideTenant)
+
+	// The owner still sees everything
+	_, err = kit.Container.CollectionRepo.GetByID(member, seed.Collection.ID())
+	assert.NoError(t, err)
+	containers, err = kit.Container.ContainerRepo.GetByCollectionID(member, seed.Collection.ID())
+	require.NoError(t, err)
+	assert.Len(t, containers, 1)
+}
+
+// TestTenancy_QueuedImportKeepsTenant checks an import queued by one
+// account still runs scoped to it, so it cannot write into another
+// account's collection once it leaves the request.
+func TestTenancy_QueuedImportKeepsTenant(t *testing.T) {
+	ctx := context.Background()
+	kit, err := testkit.New(ctx, nil)
+	require.NoError(t, err)
+	defer kit.Close()
+	seed := kit.Seed
+
+	outsider := repositories.WithTenant(ctx, repositories.NewTenant(seed.OtherUser.ID(), nil))
+	stolen, err := entities.NewContainer(entities.ContainerProps{
+		CollectionID:  seed.Collection.ID(),
+		Name:          seed.Pantry.Name(),
+		ContainerType: entities.ContainerTypeShelf,
+	})
+	require.NoError(t, err)
+
+	job, err := kit.Container.GetImportJobs().Submit(outsider, seed.OtherUser.ID(), 1, func(ctx context.Context, progress func(done, total int)) (*usecases.BulkImportCollectionResponse, error) {
+		return nil, kit.Container.ContainerRepo.Create(ctx, stolen)
+	})
+	require.NoError(t, err)
+
+	updates, cancel := job.Subscribe()
+	defer cancel()
+	var state importjobs.State
+	for state = range updates {
+	}
+	assert.Equal(t, importjobs.StatusFailed, state.Status)
+	assert.ErrorIs(t, state.Err, repositories.ErrOutsideTenant)
+
+	containers, err := kit.Container.ContainerRepo.GetByCollectionID(ctx, seed.Collection.ID())
+	require.NoError(t, err)
+	assert.Len(t, containers, 1)
+}
+
+var pathParam = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// fillPath substitutes path parameters: {id} is the account, resource IDs
+// are the victim's and anything else is an unknown ID. It reports whether
+// the path names one of the victim's resources.
+func fillPath(path string, account *entities.User, seed testkit.Seed, victimIDs map[string]string) (string, bool) {
+	touchesVictim := false
+	filled := pathParam.ReplaceAllStringFunc(path, func(param string) string {
+		name := strings.Trim(param, "{}")
+		switch {
+		case name == "id" && strings.HasPrefix(path, "/groups/"):
+			touchesVictim = true
+			return seed.Group.ID().String()
+		case name == "id":
+			return account.ID().String()
+		}
+		if id, ok := victimIDs[name]; ok {
+			touchesVictim = true
+			return id
+		}
+		return uuid.NewString()
+	})
+	return filled, touchesVictim
+}
+
+// exampleBodies builds request bodies that pass the spec's validation, so
+// each request reaches its handler. Fields named like one of the victim's
+// IDs get that ID.
+type exampleBodies struct {
+	schemas map[string]any
+	ids     map[string]string
+}
+
+func (b *exampleBodies) example(schema map[string]any, depth int) any {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, _ := b.schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+		return b.example(resolved, depth)
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	switch schema["type"] {
+	case "string":
+		if schema["format"] == "date-time" {
+			return time.Now().UTC().Format(time.RFC3339)
+		}
+		return "probe"
+	case "number", "integer":
+		return 1
+	case "boolean":
+		return true
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		if depth > 3 || items == nil {
+			return []any{}
+		}
+		return []any{b.example(items, depth+1)}
+	case "object":
+		obj := map[string]any{}
+		if depth > 3 {
+			return obj
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for name, prop := range props {
+			propSchema, _ := prop.(map[string]any)
+			if id, ok := b.ids[name]; ok && propSchema["type"] == "string" {
+				obj[name] = id
+				continue
+			}
+			obj[name] = b.example(propSchema, depth+1)
+		}
+		return obj
+	}
+	return nil
+}
+
+// victimState captures the victim's data, read without a tenant
+func victimState(t *testing.T, kit *testkit.Kit) string {
+	t.Helper()
+	ctx := context.Background()
+	collections, err := kit.Container.CollectionRepo.GetByUserID(ctx, kit.Seed.User.ID())
+	require.NoError(t, err)
+	var b strings.Builder
+	for _, collection := range collections {
+		fmt.Fprintf(&b, "%s %s %s %v\n", collection.ID(), collection.Name(), collection.UserID(), collection.GroupID())
+		containers, err := kit.Container.ContainerRepo.GetByCollectionID(ctx, collection.ID())
+		require.NoError(t, err)
+		for _, container := range containers {
+			fmt.Fprintf(&b, "  %s %s\n", container.ID(), container.Name())
+			for _, object := range container.Objects() {
+				fmt.Fprintf(&b, "    %s %s %v\n", object.ID(), object.Name(), object.Quantity())
+			}
+		}
+	}
+	plans, err := kit.Container.MealPlanRepo.ListByUserID(ctx, kit.Seed.User.ID(), time.Time{}, time.Now().AddDate(1, 0, 0))
+	require.NoError(t, err)
+	for _, plan := range plans {
+		fmt.Fprintf(&b, "plan %s %s %v\n", plan.ID(), plan.RecipeName(), plan.IsCompleted())
+	}
+	rules, err := kit.Container.RecurrenceRuleRepo.ListByUserID(ctx, kit.Seed.User.ID())
+	require.NoError(t, err)
+	for _, rule := range rules {
+		fmt.Fprintf(&b, "recurrence %s %v %v\n", rule.ID(), rule.Recurrence(), rule.IsDue())
+	}
+	automations, err := kit.Container.AutomationRuleRepo.ListByUserID(ctx, kit.Seed.User.ID())
+	require.NoError(t, err)
+	for _, rule := range automations {
+		fmt.Fprintf(&b, "automation %s %s %v %v\n", rule.ID(), rule.Name(), rule.Enabled(), rule.Actions())
+	}
+	snapshots, err := kit.Container.SnapshotRepo.ListByCollectionID(ctx, kit.Seed.Collection.ID())
+	require.NoError(t, err)
+	fmt.Fprintf(&b, "snapshots %d\n", len(snapshots))
+	members, err := kit.Auth.GetGroupUsers(ctx, "", kit.Seed.Group.ID().String())
+	require.NoError(t, err)
+	for _, member := range members {
+		fmt.Fprintf(&b, "member %s\n", member.ID())
+	}
+	return b.String()
+}
@@ -0,0 +1,270 @@
+// Package importjobs runs bulk imports in the background so large files do
+// not hold a request open until every row is saved.
+package importjobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// ErrQueueFull is returned by Submit when every worker is busy and the
+// backlog is at its limit.
+var ErrQueueFull = errors.New("import queue is full")
+
+// Defaults for NewQueue when the configuration leaves them unset.
+const (
+	defaultWorkers   = 2
+	defaultQueueSize = 32
+)
+
+// retention is how long a finished job can still be looked up, so a client
+// that reconnects after the import ended still gets the result.
+const retention = 15 * time.Minute
+
+// Status is where a job is in its life.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Finished reports whether the job will not change any more.
+func (s Status) Finished() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// RunFunc does the import, calling progress as rows are handled.
+type RunFunc func(ctx context.Context, progress func(done, total int)) (*usecases.BulkImportCollectionResponse, error)
+
+// State is a copy of a job's progress at one moment.
+type State struct {
+	ID     string
+	Status Status
+	Done   int
+	Total  int
+	// Result is set once the job completed, Err once it failed.
+	Result *usecases.BulkImportCollectionResponse
+	Err    error
+}
+
+// Job is one queued import.
+type Job struct {
+	id     string
+	userID entities.UserID
+	run    RunFunc
+	// ctx carries the values of the request that submitted the job, the
+	// tenant the repositories scope it to among them
+	ctx context.Context
+
+	mu          sync.Mutex
+	state       State
+	finishedAt  time.Time
+	subscribers map[chan State]struct{}
+}
+
+// ID is the job's opaque identifier.
+func (j *Job) ID() string {
+	return j.id
+}
+
+// UserID is the user who started the import; only they may follow it.
+func (j *Job) UserID() entities.UserID {
+	return j.userID
+}
+
+// State returns the job's current progress.
+func (j *Job) State() State {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state
+}
+
+// Subscribe returns a channel that receives the job's state now and after
+// each change, and is closed after the final state. Updates a slow reader
+// has not taken yet are replaced by newer ones. Call cancel to stop early.
+func (j *Job) Subscribe() (updates <-chan State, cancel func()) {
+	ch := make(chan State, 1)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ch <- j.state
+	if j.state.Status.Finished() {
+		close(ch)
+		return ch, func() {}
+	}
+	j.subscribers[ch] = struct{}{}
+	return ch, func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if _, ok := j.subscribers[ch]; ok {
+			delete(j.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// update applies fn to the state and tells subscribers. Must not be called
+// with j.mu held.
+func (j *Job) update(now time.Time, fn func(*State)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fn(&j.state)
+	finished := j.state.Status.Finished()
+	if finished {
+		j.finishedAt = now
+	}
+	for ch := range j.subscribers {
+		// Only the holder of j.mu sends, so after dropping a stale update
+		// there is room for this one
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- j.state
+		if finished {
+			close(ch)
+		}
+	}
+	if finished {
+		j.subscribers = nil
+	}
+}
+
+// Queue hands imports to a fixed pool of workers and keeps their progress
+// for lookup by ID.
+type Queue struct {
+	pending chan *Job
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewQueue starts workers goroutines taking jobs from a backlog of up to
+// size imports; values below one use the defaults. The workers run for the
+// life of the process.
+func NewQueue(workers, size int, logger *slog.Logger) *Queue {
+	if workers < 1 {
+		workers = defaultWorkers
+	}
+	if size < 1 {
+		size = defaultQueueSize
+	}
+	q := &Queue{
+		pending: make(chan *Job, size),
+		logger:  logger,
+		now:     time.Now,
+		jobs:    make(map[string]*Job),
+	}
+	for range workers {
+		go q.work()
+	}
+	return q
+}
+
+// Submit queues run for userID. total is the number of rows, reported
+// until the import itself says otherwise. run gets the values of ctx, such
+// as the request's tenant, but outlives its cancellation, so the import goes
+// on after the request that started it has been answered.
+func (q *Queue) Submit(ctx context.Context, userID entities.UserID, total int, run RunFunc) (*Job, error) {
+	job := &Job{
+		id:          uuid.NewString(),
+		userID:      userID,
+		run:         run,
+		ctx:         context.WithoutCancel(ctx),
+		subscribers: make(map[chan State]struct{}),
+	}
+	job.state = State{ID: job.id, Status: StatusQueued, Total: total}
+
+	q.mu.Lock()
+	q.pruneLocked()
+	q.jobs[job.id] = job
+	q.mu.Unlock()
+
+	select {
+	case q.pending <- job:
+		return job, nil
+	default:
+		q.mu.Lock()
+		delete(q.jobs, job.id)
+		q.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+}
+
+// Get returns the job with id, if it is still known.
+func (q *Queue) Get(id string) (*Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pruneLocked()
+	job, ok := q.jobs[id]
+	return job, ok
+}
+
+// pruneLocked forgets jobs that finished longer ago than the retention.
+func (q *Queue) pruneLocked() {
+	cutoff := q.now().Add(-retention)
+	for id, job := range q.jobs {
+		job.mu.Lock()
+		expired := job.state.Status.Finished() && job.finishedAt.Before(cutoff)
+		job.mu.Unlock()
+		if expired {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+func (q *Queue) work() {
+	for job := range q.pending {
+		q.runJob(job)
+	}
+}
+
+func (q *Queue) runJob(job *Job) {
+	job.update(q.now(), func(s *State) { s.Status = StatusRunning })
+
+	progress := func(done, total int) {
+		job.update(q.now(), func(s *State) {
+			s.Done = done
+			s.Total = total
+		})
+	}
+
+	result, err := func() (result *usecases.BulkImportCollectionResponse, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				q.logger.Error("Import job panicked", slog.String("job_id", job.id), slog.Any("panic", r))
+				err = errors.New("import failed unexpectedly")
+			}
+		}()
+		return job.run(job.ctx, progress)
+	}()
+	// Let the rows and the request go while the job is kept for lookup
+	job.run = nil
+	job.ctx = nil
+
+	job.update(q.now(), func(s *State) {
+		if err != nil {
+			s.Status = StatusFailed
+			s.Err = err
+			return
+		}
+		s.Status = StatusCompleted
+		s.Result = result
+		s.Done = result.Total
+		s.Total = result.Total
+	})
+}
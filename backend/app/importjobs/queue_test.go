@@ -0,0 +1,156 @@
+package importjobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+func newTestQueue(workers, size int) *Queue {
+	return NewQueue(workers, size, slog.New(slog.DiscardHandler))
+}
+
+// finalState follows the job until it finishes
+func finalState(t *testing.T, job *Job) State {
+	t.Helper()
+	updates, cancel := job.Subscribe()
+	defer cancel()
+	var state State
+	for state = range updates {
+	}
+	return state
+}
+
+func TestQueue_RunsJobAndReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	q := newTestQueue(1, 1)
+	userID := entities.NewUserID()
+	release := make(chan struct{})
+
+	job, err := q.Submit(context.Background(), userID, 3, func(ctx context.Context, progress func(done, total int)) (*usecases.BulkImportCollectionResponse, error) {
+		<-release
+		progress(2, 3)
+		return &usecases.BulkImportCollectionResponse{Imported: 3, Total: 3}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, userID, job.UserID())
+
+	updates, cancel := job.Subscribe()
+	defer cancel()
+	first := <-updates
+	assert.Contains(t, []Status{StatusQueued, StatusRunning}, first.Status)
+	assert.Equal(t, 3, first.Total)
+	close(release)
+
+	var last State
+	for last = range updates {
+	}
+	assert.Equal(t, StatusCompleted, last.Status)
+	assert.Equal(t, 3, last.Done)
+	require.NotNil(t, last.Result)
+	assert.Equal(t, 3, last.Result.Imported)
+
+	got, ok := q.Get(job.ID())
+	require.True(t, ok)
+	assert.Equal(t, StatusCompleted, got.State().Status)
+}
+
+func TestQueue_FailedAndPanickingJobs(t *testing.T) {
+	t.Parallel()
+
+	q := newTestQueue(1, 2)
+	failed, err := q.Submit(context.Background(), entities.NewUserID(), 1, func(context.Context, func(int, int)) (*usecases.BulkImportCollectionResponse, error) {
+		return nil, errors.New("collection not found")
+	})
+	require.NoError(t, err)
+	panicked, err := q.Submit(context.Background(), entities.NewUserID(), 1, func(context.Context, func(int, int)) (*usecases.BulkImportCollectionResponse, error) {
+		panic("boom")
+	})
+	require.NoError(t, err)
+
+	state := finalState(t, failed)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.EqualError(t, state.Err, "collection not found")
+
+	state = finalState(t, panicked)
+	assert.Equal(t, StatusFailed, state.Status)
+	assert.Error(t, state.Err)
+}
+
+func TestQueue_FullBacklog(t *testing.T) {
+	t.Parallel()
+
+	q := newTestQueue(1, 1)
+	release := make(chan struct{})
+	defer close(release)
+	block := func(context.Context, func(int, int)) (*usecases.BulkImportCollectionResponse, error) {
+		<-release
+		return &usecases.BulkImportCollectionResponse{}, nil
+	}
+
+	// One job occupies the worker, the next fills the backlog
+	running, err := q.Submit(context.Background(), entities.NewUserID(), 1, block)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return running.State().Status == StatusRunning
+	}, time.Second, time.Millisecond)
+	_, err = q.Submit(context.Background(), entities.NewUserID(), 1, block)
+	require.NoError(t, err)
+
+	_, err = q.Submit(context.Background(), entities.NewUserID(), 1, block)
+	assert.ErrorIs(t, err, ErrQueueFull)
+}
+
+func TestQueue_ForgetsOldFinishedJobs(t *testing.T) {
+	t.Parallel()
+
+	q := newTestQueue(1, 1)
+	job, err := q.Submit(context.Background(), entities.NewUserID(), 0, func(context.Context, func(int, int)) (*usecases.BulkImportCollectionResponse, error) {
+		return &usecases.BulkImportCollectionResponse{}, nil
+	})
+	require.NoError(t, err)
+	finalState(t, job)
+
+	q.mu.Lock()
+	q.now = func() time.Time { return time.Now().Add(retention + time.Minute) }
+	q.mu.Unlock()
+
+	_, ok := q.Get(job.ID())
+	assert.False(t, ok)
+}
+
+func TestQueue_RunKeepsSubmitContextValues(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+	q := newTestQueue(1, 1)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "tenant"))
+
+	var value any
+	var runErr error
+	release := make(chan struct{})
+	job, err := q.Submit(ctx, entities.NewUserID(), 1, func(ctx context.Context, progress func(done, total int)) (*usecases.BulkImportCollectionResponse, error) {
+		<-release
+		value = ctx.Value(key{})
+		runErr = ctx.Err()
+		return &usecases.BulkImportCollectionResponse{}, nil
+	})
+	require.NoError(t, err)
+
+	// The request is answered before the import runs
+	cancel()
+	close(release)
+
+	assert.Equal(t, StatusCompleted, finalState(t, job).Status)
+	assert.Equal(t, "tenant", value)
+	assert.NoError(t, runErr)
+}
@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// AutomationScheduler periodically looks for objects that came within an
+// expiry rule's window and publishes them for the automation rules to act
+// on. The interval is read on every run, so a config reload changes it
+// without a restart.
+type AutomationScheduler struct {
+	expiringUC *usecases.ExpiringObjectEventsUseCase
+	events     services.EventPublisher
+	config     func() config.AutomationsConfig
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	// since is when the last run checked up to
+	since time.Time
+}
+
+func NewAutomationScheduler(c *container.Container, logger *slog.Logger) *AutomationScheduler {
+	return &AutomationScheduler{
+		expiringUC: usecases.NewExpiringObjectEventsUseCase(c.AutomationRuleRepo, c.CollectionRepo, c.ContainerRepo),
+		events:     c.Events(),
+		config:     func() config.AutomationsConfig { return c.GetConfig().Automations },
+		logger:     logger,
+	}
+}
+
+// Start runs a check immediately and then every interval until Stop is called.
+// Should be called once at startup.
+func (s *AutomationScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	go func() {
+		interval := s.run(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if next := s.run(ctx); next != interval {
+					interval = next
+					ticker.Reset(interval)
+					s.logger.Info("Automation check interval changed", slog.Duration("interval", interval))
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels the scheduler goroutine.
+func (s *AutomationScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// run publishes the objects whose expiry window opened since the last run
+// and returns how long to wait before the next one. The first run looks
+// back one interval, so a restart does not skip objects; windows that
+// opened while the server was down longer than that are missed.
+func (s *AutomationScheduler) run(ctx context.Context) time.Duration {
+	interval := time.Duration(s.config().CheckInterval) * time.Minute
+	now := time.Now()
+	since := s.since
+	if since.IsZero() {
+		since = now.Add(-interval)
+	}
+
+	resp, err := s.expiringUC.Execute(ctx, usecases.ExpiringObjectEventsRequest{Since: since, Now: now})
+	if err != nil {
+		// since is kept, so the next run covers this one's span too
+		s.logger.Error("Automation expiry check failed", slog.Any("error", err))
+		return interval
+	}
+	s.since = now
+
+	for _, event := range resp.Events {
+		s.events.Publish(event)
+	}
+	if len(resp.Events) > 0 {
+		s.logger.Info("Automation expiry check complete", slog.Int("expiring", len(resp.Events)))
+	}
+	return interval
+}
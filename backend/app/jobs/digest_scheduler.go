@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// DigestScheduler periodically sends the expiring/low-stock digest emails.
+// The digest settings are read on every run, so a config reload changes the
+// interval and thresholds without a restart.
+type DigestScheduler struct {
+	sendDigestsUC *usecases.SendDigestsUseCase
+	config        func() config.DigestConfig
+	logger        *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// NewDigestScheduler wires the digest use case from the container. The
+// container must have an EmailService, i.e. email must be enabled.
+func NewDigestScheduler(c *container.Container, logger *slog.Logger) *DigestScheduler {
+	return &DigestScheduler{
+		sendDigestsUC: usecases.NewSendDigestsUseCase(c.DigestSubscriptionRepo, c.CollectionRepo, c.ContainerRepo, c.EmailService, logger),
+		config:        func() config.DigestConfig { return c.GetConfig().Digest },
+		logger:        logger,
+	}
+}
+
+// Start runs a check immediately and then every interval until Stop is called.
+// Should be called once at startup.
+func (s *DigestScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	go func() {
+		interval := s.run(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if next := s.run(ctx); next != interval {
+					interval = next
+					ticker.Reset(interval)
+					s.logger.Info("Digest check interval changed", slog.Duration("interval", interval))
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels the scheduler goroutine.
+func (s *DigestScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// run sends the digests that are due and returns how long to wait before
+// the next check
+func (s *DigestScheduler) run(ctx context.Context) time.Duration {
+	cfg := s.config()
+	interval := time.Duration(cfg.CheckInterval) * time.Minute
+
+	resp, err := s.sendDigestsUC.Execute(ctx, usecases.SendDigestsRequest{
+		Now:                time.Now(),
+		ExpiringWithin:     time.Duration(cfg.ExpiringWithinDays) * 24 * time.Hour,
+		LowStockThreshold:  cfg.LowStockThreshold,
+		UnsubscribeBaseURL: strings.TrimRight(cfg.PublicURL, "/"),
+	})
+	if err != nil {
+		s.logger.Error("Digest run failed", slog.Any("error", err))
+		return interval
+	}
+
+	if resp.Sent > 0 || resp.Failed > 0 {
+		s.logger.Info("Digest run complete",
+			slog.Int("sent", resp.Sent),
+			slog.Int("skipped", resp.Skipped),
+			slog.Int("failed", resp.Failed))
+	}
+	return interval
+}
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// oauthClientRefreshInterval is how often the registered OAuth clients are
+// read again. An admin's change reloads only the instance that served it;
+// the others pick it up within this interval.
+const oauthClientRefreshInterval = time.Minute
+
+// OAuthClientScheduler periodically reloads the registered OAuth clients from
+// the database, so every server instance ends up with the same clients.
+// Unchanged clients keep their provider, so a run without changes costs one
+// read.
+type OAuthClientScheduler struct {
+	oauthClientsUC *usecases.OAuthClientUseCase
+	logger         *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func NewOAuthClientScheduler(c *container.Container, logger *slog.Logger) *OAuthClientScheduler {
+	return &OAuthClientScheduler{
+		oauthClientsUC: c.OAuthClients(),
+		logger:         logger,
+	}
+}
+
+// Start reloads the clients every interval until Stop is called. The
+// container loads them at startup, so the first reload waits an interval.
+// Should be called once at startup.
+func (s *OAuthClientScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(oauthClientRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.run(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels the scheduler goroutine.
+func (s *OAuthClientScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *OAuthClientScheduler) run(ctx context.Context) {
+	if err := s.oauthClientsUC.Load(ctx); err != nil {
+		s.logger.Error("OAuth client reload failed; the current clients stay in place", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// RecurrenceScheduler periodically puts recurring staples that have come due
+// back on their owners' shopping lists. The interval is read on every run, so
+// a config reload changes it without a restart.
+type RecurrenceScheduler struct {
+	triggerUC *usecases.TriggerRecurrenceRulesUseCase
+	config    func() config.RecurrenceConfig
+	logger    *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func NewRecurrenceScheduler(c *container.Container, logger *slog.Logger) *RecurrenceScheduler {
+	return &RecurrenceScheduler{
+		triggerUC: usecases.NewTriggerRecurrenceRulesUseCase(c.RecurrenceRuleRepo, c.ContainerRepo, logger),
+		config:    func() config.RecurrenceConfig { return c.GetConfig().Recurrence },
+		logger:    logger,
+	}
+}
+
+// Start runs a check immediately and then every interval until Stop is called.
+// Should be called once at startup.
+func (s *RecurrenceScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	go func() {
+		interval := s.run(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if next := s.run(ctx); next != interval {
+					interval = next
+					ticker.Reset(interval)
+					s.logger.Info("Recurrence check interval changed", slog.Duration("interval", interval))
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels the scheduler goroutine.
+func (s *RecurrenceScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// run triggers the rules that are due and returns how long to wait before
+// the next check
+func (s *RecurrenceScheduler) run(ctx context.Context) time.Duration {
+	interval := time.Duration(s.config().CheckInterval) * time.Minute
+
+	resp, err := s.triggerUC.Execute(ctx, usecases.TriggerRecurrenceRulesRequest{Now: time.Now()})
+	if err != nil {
+		s.logger.Error("Recurrence run failed", slog.Any("error", err))
+		return interval
+	}
+
+	if resp.Triggered > 0 || resp.Removed > 0 || resp.Failed > 0 {
+		s.logger.Info("Recurrence run complete",
+			slog.Int("triggered", resp.Triggered),
+			slog.Int("removed", resp.Removed),
+			slog.Int("failed", resp.Failed))
+	}
+	return interval
+}
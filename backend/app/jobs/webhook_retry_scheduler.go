@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/domain/usecases"
+)
+
+// webhookPruneInterval is how often old webhook deliveries are cleared out
+const webhookPruneInterval = time.Hour
+
+// WebhookRetryScheduler periodically resends the automation webhooks whose
+// retry has come due, and clears out deliveries past their retention. The
+// interval is read on every run, so a config reload changes it without a
+// restart.
+type WebhookRetryScheduler struct {
+	webhooksUC *usecases.WebhookUseCase
+	config     func() config.AutomationsConfig
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	// prunedAt is when old deliveries were last cleared out
+	prunedAt time.Time
+}
+
+func NewWebhookRetryScheduler(c *container.Container, logger *slog.Logger) *WebhookRetryScheduler {
+	return &WebhookRetryScheduler{
+		webhooksUC: usecases.NewWebhookUseCase(c.AutomationRuleRepo, c.WebhookDeliveryRepo, c.WebhookKeyRepo, c.WebhookSender, logger),
+		config:     func() config.AutomationsConfig { return c.GetConfig().Automations },
+		logger:     logger,
+	}
+}
+
+// Start runs a check immediately and then every interval until Stop is called.
+// Should be called once at startup.
+func (s *WebhookRetryScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	go func() {
+		interval := s.run(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if next := s.run(ctx); next != interval {
+					interval = next
+					ticker.Reset(interval)
+					s.logger.Info("Webhook retry interval changed", slog.Duration("interval", interval))
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels the scheduler goroutine.
+func (s *WebhookRetryScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// run resends the deliveries that are due, prunes old ones about once an
+// hour, and returns how long to wait before the next run
+func (s *WebhookRetryScheduler) run(ctx context.Context) time.Duration {
+	interval := time.Duration(s.config().WebhookRetryInterval) * time.Second
+	now := time.Now()
+
+	resp, err := s.webhooksUC.RetryDue(ctx, now)
+	if err != nil {
+		s.logger.Error("Webhook retry run failed", slog.Any("error", err))
+	} else if resp.Sent > 0 || resp.Failed > 0 {
+		s.logger.Info("Webhook retry run complete",
+			slog.Int("sent", resp.Sent),
+			slog.Int("failed", resp.Failed))
+	}
+
+	if now.Sub(s.prunedAt) >= webhookPruneInterval {
+		deleted, err := s.webhooksUC.PruneDeliveries(ctx, now)
+		if err != nil {
+			s.logger.Error("Webhook delivery pruning failed", slog.Any("error", err))
+			return interval
+		}
+		s.prunedAt = now
+		if deleted > 0 {
+			s.logger.Info("Old webhook deliveries removed", slog.Int64("deleted", deleted))
+		}
+	}
+	return interval
+}
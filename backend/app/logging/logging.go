@@ -0,0 +1,305 @@
+// Package logging sends the backend's logs to the console and to the sinks
+// configured under [logging]: Seq, rotating files, syslog and the systemd
+// journal. Each sink has its own level, which can be changed while the
+// server runs.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	slogmulti "github.com/samber/slog-multi"
+	"github.com/swczk/go-seqlogger"
+
+	"github.com/nishiki/backend/app/config"
+)
+
+// ErrUnknownSink is returned when a level is set on a sink that does not exist
+var ErrUnknownSink = errors.New("log sink not found")
+
+// Sink types besides the config.LogSink* ones
+const (
+	SinkConsole = "console"
+	SinkSeq     = "seq"
+)
+
+// defaultTag names the backend in syslog and the journal
+const defaultTag = "nishiki"
+
+// Sink is one place log records go
+type Sink struct {
+	Name string
+	Type string
+
+	level   slog.LevelVar
+	inherit bool // follows the default level
+	handler slog.Handler
+	closer  io.Closer
+}
+
+// Sinks are the backend's log sinks. Records go to every sink whose level
+// they reach.
+type Sinks struct {
+	mu           sync.Mutex
+	defaultLevel slog.Level
+	sinks        []*Sink
+}
+
+// SinkLevel is a sink's current level
+type SinkLevel struct {
+	Name  string
+	Type  string
+	Level slog.Level
+	// Inherited is set when the sink follows the default level
+	Inherited bool
+}
+
+// Open opens the console sink on console, Seq when an endpoint is set, and
+// every sink in cfg.Sinks. Sinks without a level of their own start at
+// cfg.Level and follow SetLevel.
+func Open(cfg config.LoggingConfig, console io.Writer) (*Sinks, error) {
+	level, err := cfg.SlogLevel()
+	if err != nil {
+		return nil, err
+	}
+	s := &Sinks{defaultLevel: level}
+
+	s.add(&Sink{Name: SinkConsole, Type: SinkConsole, inherit: true}, newJSONHandler(console), nil)
+
+	if cfg.SeqEndpoint != "" {
+		// Seq takes a fixed level, so it accepts everything and the sink
+		// filters instead
+		seqConfig := seqlogger.DefaultConfig(cfg.SeqEndpoint).
+			WithLogLevel(slog.LevelDebug)
+		if cfg.SeqAPIKey != "" {
+			seqConfig = seqConfig.WithAPIKey(cfg.SeqAPIKey)
+		}
+		s.add(&Sink{Name: SinkSeq, Type: SinkSeq, inherit: true}, seqlogger.New(seqConfig).Handler(), nil)
+	}
+
+	for _, sinkCfg := range cfg.Sinks {
+		if err := s.open(sinkCfg); err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("failed to open log sink %s: %w", sinkCfg.SinkName(), err)
+		}
+	}
+	return s, nil
+}
+
+func (s *Sinks) open(cfg config.LogSinkConfig) error {
+	sink := &Sink{Name: cfg.SinkName(), Type: cfg.Type, inherit: cfg.Level == ""}
+	if !sink.inherit {
+		level, err := config.ParseLogLevel(cfg.Level)
+		if err != nil {
+			return err
+		}
+		sink.level.Set(level)
+	}
+
+	tag := cfg.Tag
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	switch cfg.Type {
+	case config.LogSinkFile:
+		file, err := OpenRotatingFile(cfg.Path, cfg.MaxSizeMB, cfg.MaxAgeDays, cfg.MaxBackups)
+		if err != nil {
+			return err
+		}
+		s.add(sink, newJSONHandler(file), file)
+	case config.LogSinkSyslog:
+		w, err := dialSyslog(cfg.Network, cfg.Address, tag)
+		if err != nil {
+			return err
+		}
+		s.add(sink, newPriorityHandler(w), w)
+	case config.LogSinkJournald:
+		w, err := dialJournal(tag)
+		if err != nil {
+			return err
+		}
+		s.add(sink, newPriorityHandler(w), w)
+	default:
+		return fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+	return nil
+}
+
+func (s *Sinks) add(sink *Sink, handler slog.Handler, closer io.Closer) {
+	if sink.inherit {
+		sink.level.Set(s.defaultLevel)
+	}
+	sink.handler = &levelHandler{Handler: handler, level: &sink.level}
+	sink.closer = closer
+	s.sinks = append(s.sinks, sink)
+}
+
+// newJSONHandler writes every record it is given; the sink's level filters
+func newJSONHandler(w io.Writer) slog.Handler {
+	return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+}
+
+// Handler sends each record to the sinks whose level it reaches
+func (s *Sinks) Handler() slog.Handler {
+	handlers := make([]slog.Handler, len(s.sinks))
+	for i, sink := range s.sinks {
+		handlers[i] = sink.handler
+	}
+	return slogmulti.Fanout(handlers...)
+}
+
+// Level is the default level, which sinks without one of their own follow
+func (s *Sinks) Level() slog.Level {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.defaultLevel
+}
+
+// SetLevel changes the default level and every sink following it
+func (s *Sinks) SetLevel(level slog.Level) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultLevel = level
+	for _, sink := range s.sinks {
+		if sink.inherit {
+			sink.level.Set(level)
+		}
+	}
+}
+
+// SetSinkLevel gives the named sink a level of its own, or with a nil level
+// makes it follow the default again
+func (s *Sinks) SetSinkLevel(name string, level *slog.Level) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sink := range s.sinks {
+		if sink.Name != name {
+			continue
+		}
+		sink.inherit = level == nil
+		if level == nil {
+			sink.level.Set(s.defaultLevel)
+		} else {
+			sink.level.Set(*level)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownSink, name)
+}
+
+// Levels lists the sinks in the order they were opened, console first
+func (s *Sinks) Levels() []SinkLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	levels := make([]SinkLevel, len(s.sinks))
+	for i, sink := range s.sinks {
+		levels[i] = SinkLevel{
+			Name:      sink.Name,
+			Type:      sink.Type,
+			Level:     sink.level.Level(),
+			Inherited: sink.inherit,
+		}
+	}
+	return levels
+}
+
+// Close closes the files and connections the sinks hold
+func (s *Sinks) Close() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		if sink.closer != nil {
+			errs = append(errs, sink.closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LevelName writes level the way logging.level is written in app.toml
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// levelHandler drops records below level before they reach Handler
+type levelHandler struct {
+	slog.Handler
+	level slog.Leveler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.Handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// priorityWriter takes one formatted record at a time along with its level,
+// as syslog and the journal file records under a priority
+type priorityWriter interface {
+	WriteLevel(level slog.Level, line []byte) error
+	io.Closer
+}
+
+// priorityHandler formats records as JSON for a priorityWriter. The time is
+// left out because syslog and the journal stamp each entry themselves.
+type priorityHandler struct {
+	slog.Handler
+	out *priorityOutput
+}
+
+// priorityOutput is shared by a priorityHandler and its WithAttrs and
+// WithGroup copies. mu is held while a record is formatted, so Write knows
+// the record's level.
+type priorityOutput struct {
+	mu    sync.Mutex
+	level slog.Level
+	w     priorityWriter
+}
+
+func (o *priorityOutput) Write(p []byte) (int, error) {
+	if err := o.w.WriteLevel(o.level, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func newPriorityHandler(w priorityWriter) *priorityHandler {
+	out := &priorityOutput{w: w}
+	return &priorityHandler{
+		Handler: slog.NewJSONHandler(out, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}),
+		out: out,
+	}
+}
+
+func (h *priorityHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.out.mu.Lock()
+	defer h.out.mu.Unlock()
+	h.out.level = r.Level
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *priorityHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &priorityHandler{Handler: h.Handler.WithAttrs(attrs), out: h.out}
+}
+
+func (h *priorityHandler) WithGroup(name string) slog.Handler {
+	return &priorityHandler{Handler: h.Handler.WithGroup(name), out: h.out}
+}
@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backend.log")
+	f, err := OpenRotatingFile(path, 1, 0, 2)
+	require.NoError(t, err)
+	defer f.Close()
+
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+	f.maxSize = 10
+
+	for i := range 4 {
+		now = now.Add(time.Minute)
+		_, err := f.Write([]byte("record " + string(rune('a'+i)) + "\n"))
+		require.NoError(t, err)
+	}
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "record d\n", string(current))
+
+	backups, err := filepath.Glob(filepath.Join(dir, "backend-*.log"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "backend-2026-10-15T08-03-00.000.log"),
+		filepath.Join(dir, "backend-2026-10-15T08-04-00.000.log"),
+	}, backups, "only the newest two backups are kept")
+}
+
+func TestRotatingFile_PrunesByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backend.log")
+	stale := filepath.Join(dir, "backend-2026-09-01T00-00-00.000.log")
+	unrelated := filepath.Join(dir, "backend-notes.log")
+	require.NoError(t, os.WriteFile(stale, []byte("old\n"), 0o644))
+	require.NoError(t, os.WriteFile(unrelated, []byte("keep\n"), 0o644))
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0o644))
+
+	f, err := OpenRotatingFile(path, 1, 7, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	f.now = func() time.Time { return time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC) }
+	f.maxSize = 10
+
+	_, err = f.Write([]byte("new record\n"))
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, unrelated)
+	assert.FileExists(t, filepath.Join(dir, "backend-2026-10-15T08-00-00.000.log"))
+}
+
+func TestSinks_Levels(t *testing.T) {
+	dir := t.TempDir()
+	var console bytes.Buffer
+	sinks, err := Open(config.LoggingConfig{
+		Level: "info",
+		Sinks: []config.LogSinkConfig{
+			{Type: config.LogSinkFile, Path: filepath.Join(dir, "all.log"), Level: "debug"},
+			{Name: "errors", Type: config.LogSinkFile, Path: filepath.Join(dir, "errors.log"), Level: "error"},
+		},
+	}, &console)
+	require.NoError(t, err)
+	defer sinks.Close()
+	logger := slog.New(sinks.Handler())
+
+	logger.Debug("debug record")
+	logger.Warn("warn record")
+	logger.Error("error record")
+
+	assert.NotContains(t, console.String(), "debug record")
+	assert.Contains(t, console.String(), "warn record")
+	assertLogged(t, filepath.Join(dir, "all.log"), "debug record", "warn record", "error record")
+	assertLogged(t, filepath.Join(dir, "errors.log"), "error record")
+
+	sinks.SetLevel(slog.LevelError)
+	debug := slog.LevelDebug
+	require.NoError(t, sinks.SetSinkLevel("errors", &debug))
+	require.NoError(t, sinks.SetSinkLevel(config.LogSinkFile, nil))
+	assert.ErrorIs(t, sinks.SetSinkLevel("missing", nil), ErrUnknownSink)
+
+	assert.Equal(t, []SinkLevel{
+		{Name: SinkConsole, Type: SinkConsole, Level: slog.LevelError, Inherited: true},
+		{Name: config.LogSinkFile, Type: config.LogSinkFile, Level: slog.LevelError, Inherited: true},
+		{Name: "errors", Type: config.LogSinkFile, Level: slog.LevelDebug},
+	}, sinks.Levels())
+
+	logger.Info("after the change")
+	assert.NotContains(t, console.String(), "after the change")
+	assertLogged(t, filepath.Join(dir, "errors.log"), "error record", "after the change")
+}
+
+func TestPriorityHandler(t *testing.T) {
+	w := &recordingWriter{}
+	logger := slog.New(newPriorityHandler(w)).With(slog.String("component", "test"))
+
+	logger.Debug("checking")
+	logger.Error("failed", slog.Int("attempt", 2))
+
+	require.Len(t, w.levels, 2)
+	assert.Equal(t, []slog.Level{slog.LevelDebug, slog.LevelError}, w.levels)
+	assert.Equal(t, `{"level":"ERROR","msg":"failed","component":"test","attempt":2}`+"\n", w.lines[1])
+	assert.Equal(t, 3, journalPriority(slog.LevelError))
+	assert.Equal(t, 7, journalPriority(slog.LevelDebug))
+}
+
+type recordingWriter struct {
+	levels []slog.Level
+	lines  []string
+}
+
+func (w *recordingWriter) WriteLevel(level slog.Level, line []byte) error {
+	w.levels = append(w.levels, level)
+	w.lines = append(w.lines, string(line))
+	return nil
+}
+
+func (w *recordingWriter) Close() error {
+	return nil
+}
+
+func assertLogged(t *testing.T, path string, messages ...string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, len(messages), "records in %s", filepath.Base(path))
+	for i, msg := range messages {
+		assert.Contains(t, lines[i], `"msg":"`+msg+`"`)
+	}
+}
@@ -0,0 +1,167 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxSizeMB is the size a log file rotates at when none is configured
+const defaultMaxSizeMB = 100
+
+// backupTimeFormat stamps rotated files, which are named like
+// backend-2026-10-15T08-30-00.000.log for backend.log
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is a log file that is renamed aside once it would grow past
+// its size limit, with a fresh file taking its place. Rotated files past
+// the age or count limit are deleted after each rotation.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	now        func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens path for appending, creating it and its directory
+// if needed. A maxSizeMB of zero means 100; zero maxAgeDays or maxBackups
+// keeps rotated files regardless of age or count.
+func OpenRotatingFile(path string, maxSizeMB, maxAgeDays, maxBackups int) (*RotatingFile, error) {
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := f.openFile(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) openFile() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if p would take the file past its limit.
+// A record larger than the limit still goes into a file of its own.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+
+	prefix, ext := f.backupAffixes()
+	backup := prefix + f.now().UTC().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.openFile(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+// backupAffixes splits the name of a rotated file around its timestamp
+func (f *RotatingFile) backupAffixes() (prefix, ext string) {
+	ext = filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
+
+// prune deletes the rotated files beyond maxBackups or older than maxAge
+func (f *RotatingFile) prune() error {
+	if f.maxBackups == 0 && f.maxAge == 0 {
+		return nil
+	}
+
+	prefix, ext := f.backupAffixes()
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path    string
+		rotated time.Time
+	}
+	var backups []backup
+	for _, path := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(path, prefix), ext)
+		rotated, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue // Not one of ours
+		}
+		backups = append(backups, backup{path: path, rotated: rotated})
+	}
+	// Newest first
+	slices.SortFunc(backups, func(a, b backup) int { return b.rotated.Compare(a.rotated) })
+
+	cutoff := f.now().Add(-f.maxAge)
+	for i, b := range backups {
+		tooMany := f.maxBackups > 0 && i >= f.maxBackups
+		tooOld := f.maxAge > 0 && b.rotated.Before(cutoff)
+		if tooMany || tooOld {
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove old log file: %w", err)
+			}
+		}
+	}
+	return nil
+}
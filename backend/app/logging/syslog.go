@@ -0,0 +1,41 @@
+//go:build !windows && !plan9
+
+package logging
+
+import (
+	"log/slog"
+	"log/syslog"
+)
+
+// syslogWriter files each record under the syslog severity matching its level
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+// dialSyslog connects to the syslog server at address over network, or to
+// the local one when both are empty
+func dialSyslog(network, address, tag string) (*syslogWriter, error) {
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogWriter{w: w}, nil
+}
+
+func (s *syslogWriter) WriteLevel(level slog.Level, line []byte) error {
+	msg := string(line)
+	switch {
+	case level >= slog.LevelError:
+		return s.w.Err(msg)
+	case level >= slog.LevelWarn:
+		return s.w.Warning(msg)
+	case level >= slog.LevelInfo:
+		return s.w.Info(msg)
+	default:
+		return s.w.Debug(msg)
+	}
+}
+
+func (s *syslogWriter) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,22 @@
+//go:build windows || plan9
+
+package logging
+
+import (
+	"errors"
+	"log/slog"
+)
+
+type syslogWriter struct{}
+
+func dialSyslog(network, address, tag string) (*syslogWriter, error) {
+	return nil, errors.New("syslog is not available on this platform")
+}
+
+func (s *syslogWriter) WriteLevel(level slog.Level, line []byte) error {
+	return nil
+}
+
+func (s *syslogWriter) Close() error {
+	return nil
+}
@@ -3,6 +3,7 @@ package mcpserver
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"log/slog"
 
@@ -42,32 +43,62 @@ func MCPUserFromContext(ctx context.Context) (*entities.User, string, error) {
 	return auth.User, auth.Token, nil
 }
 
+// requireFeature returns entities.ErrFeatureNotEnabled when the feature is
+// off for the signed-in user. MCP requests carry no claims, so the user's
+// groups are looked up with the auth service.
+func (c *MCPContext) requireFeature(ctx context.Context, name string) error {
+	user, token, err := MCPUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	groups, err := c.Container.AuthService.GetUserGroups(ctx, token, user.ID().String())
+	if err != nil {
+		return fmt.Errorf("failed to get groups: %w", err)
+	}
+	names := make([]string, len(groups))
+	for i, group := range groups {
+		names[i] = group.Name().String()
+	}
+	enabled, err := c.Container.Features().IsEnabled(ctx, name, user, names)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: %s", entities.ErrFeatureNotEnabled, name)
+	}
+	return nil
+}
+
 // Use case factories — constructed on demand so no state is shared across calls.
 
 func (c *MCPContext) getCollectionsUC() *usecases.GetCollectionsUseCase {
-	return usecases.NewGetCollectionsUseCase(c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewGetCollectionsUseCase(c.Container.CollectionRepo, c.Container.PreferencesRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) createCollectionUC() *usecases.CreateCollectionUseCase {
-	return usecases.NewCreateCollectionUseCase(c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewCreateCollectionUseCase(c.Container.CollectionRepo, c.Container.ObjectTypeRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) updateCollectionUC() *usecases.UpdateCollectionUseCase {
-	return usecases.NewUpdateCollectionUseCase(c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewUpdateCollectionUseCase(c.Container.CollectionRepo, c.Container.ObjectTypeRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) deleteCollectionUC() *usecases.DeleteCollectionUseCase {
-	return usecases.NewDeleteCollectionUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo)
+	return usecases.NewDeleteCollectionUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.SnapshotRepo, c.Container.MediaRepo, c.Container.MediaStorage, c.Container.CommentRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) getContainersByCollectionUC() *usecases.GetContainersByCollectionUseCase {
-	return usecases.NewGetContainersByCollectionUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewGetContainersByCollectionUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.PreferencesRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) getCollectionObjectsUC() *usecases.GetCollectionObjectsUseCase {
 	return usecases.NewGetCollectionObjectsUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AuthService)
 }
 
+func (c *MCPContext) lookupObjectCodeUC() *usecases.LookupObjectCodeUseCase {
+	return usecases.NewLookupObjectCodeUseCase(c.Container.ObjectCodeRepo, c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+}
+
 func (c *MCPContext) getAllContainersUC() *usecases.GetAllContainersUseCase {
 	return usecases.NewGetAllContainersUseCase(c.Container.ContainerRepo, c.Container.AuthService)
 }
@@ -81,7 +112,7 @@ func (c *MCPContext) getContainersUC() *usecases.GetContainersUseCase {
 }
 
 func (c *MCPContext) createContainerUC() *usecases.CreateContainerUseCase {
-	return usecases.NewCreateContainerUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewCreateContainerUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.GroupQuotaRepo, c.Container.UsageRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) updateContainerUC() *usecases.UpdateContainerUseCase {
@@ -89,19 +120,32 @@ func (c *MCPContext) updateContainerUC() *usecases.UpdateContainerUseCase {
 }
 
 func (c *MCPContext) deleteContainerUC() *usecases.DeleteContainerUseCase {
-	return usecases.NewDeleteContainerUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewDeleteContainerUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.MediaRepo, c.Container.MediaStorage, c.Container.AuthService)
 }
 
 func (c *MCPContext) createObjectUC() *usecases.CreateObjectUseCase {
-	return usecases.NewCreateObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewCreateObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.ObjectCodeRepo, c.Container.GroupQuotaRepo, c.Container.UsageRepo, c.Container.ExpiryHistoryRepo, c.Container.AuthService, c.Container.Events())
 }
 
 func (c *MCPContext) updateObjectUC() *usecases.UpdateObjectUseCase {
-	return usecases.NewUpdateObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewUpdateObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.ObjectMoveRepo, c.Container.ObjectCodeRepo, c.Container.AuthService, c.Container.Events())
+}
+
+func (c *MCPContext) copyObjectUC() *usecases.CopyObjectUseCase {
+	return usecases.NewCopyObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.ObjectCodeRepo, c.Container.GroupQuotaRepo, c.Container.UsageRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) identifyItemUC() *usecases.IdentifyItemUseCase {
+	mediaCfg := c.Container.GetConfig().Media
+	return usecases.NewIdentifyItemUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.ObjectMoveRepo, c.Container.ObjectCodeRepo, c.Container.MediaRepo, c.Container.MediaStorage, c.Container.BarcodeDecoder, c.Container.GroupQuotaRepo, c.Container.UsageRepo, c.Container.ExpiryHistoryRepo, c.Container.AuthService, mediaCfg.MaxUploadSize, mediaCfg.MaxPerOwner)
+}
+
+func (c *MCPContext) adjustObjectQuantityUC() *usecases.AdjustObjectQuantityUseCase {
+	return usecases.NewAdjustObjectQuantityUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AuthService, c.Container.Events())
 }
 
 func (c *MCPContext) deleteObjectUC() *usecases.DeleteObjectUseCase {
-	return usecases.NewDeleteObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+	return usecases.NewDeleteObjectUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.MediaRepo, c.Container.MediaStorage, c.Container.AuthService)
 }
 
 func (c *MCPContext) getGroupsUC() *usecases.GetGroupsUseCase {
@@ -117,17 +161,69 @@ func (c *MCPContext) groupUC() *usecases.GroupUseCase {
 }
 
 func (c *MCPContext) bulkImportCollectionUC() *usecases.BulkImportCollectionUseCase {
-	return usecases.NewBulkImportCollectionUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AuthService, c.Container.GetConfig().Import.ReservedColumns, c.Container.ImageSearchService, c.Container.GetLogger())
+	return usecases.NewBulkImportCollectionUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.ObjectCodeRepo, c.Container.GroupQuotaRepo, c.Container.UsageRepo, c.Container.AuthService, c.Container.GetConfig().Import.ReservedColumns, c.Container.ImageSearchService, c.Container.GetLogger())
 }
 
 func (c *MCPContext) updatePropertySchemaUC() *usecases.UpdatePropertySchemaUseCase {
-	return usecases.NewUpdatePropertySchemaUseCase(c.Container.CollectionRepo)
+	return usecases.NewUpdatePropertySchemaUseCase(c.Container.CollectionRepo, c.Container.AuthService)
 }
 
 func (c *MCPContext) exportCollectionUC() *usecases.ExportCollectionUseCase {
 	return usecases.NewExportCollectionUseCase(c.Container.CollectionRepo, c.Container.AuthService)
 }
 
+func (c *MCPContext) listMealPlansUC() *usecases.ListMealPlansUseCase {
+	return usecases.NewListMealPlansUseCase(c.Container.MealPlanRepo)
+}
+
+func (c *MCPContext) createMealPlanUC() *usecases.CreateMealPlanUseCase {
+	return usecases.NewCreateMealPlanUseCase(c.Container.MealPlanRepo, c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) completeMealPlanUC() *usecases.CompleteMealPlanUseCase {
+	return usecases.NewCompleteMealPlanUseCase(c.Container.MealPlanRepo, c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AuthService, c.Container.Events())
+}
+
+func (c *MCPContext) listRecurrenceRulesUC() *usecases.ListRecurrenceRulesUseCase {
+	return usecases.NewListRecurrenceRulesUseCase(c.Container.RecurrenceRuleRepo)
+}
+
+func (c *MCPContext) listSnapshotsUC() *usecases.ListCollectionSnapshotsUseCase {
+	return usecases.NewListCollectionSnapshotsUseCase(c.Container.CollectionRepo, c.Container.SnapshotRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) createSnapshotUC() *usecases.CreateCollectionSnapshotUseCase {
+	return usecases.NewCreateCollectionSnapshotUseCase(c.Container.CollectionRepo, c.Container.SnapshotRepo, c.Container.AuthService, c.Container.GetConfig().Snapshots.MaxPerCollection)
+}
+
+func (c *MCPContext) diffSnapshotUC() *usecases.DiffCollectionSnapshotUseCase {
+	return usecases.NewDiffCollectionSnapshotUseCase(c.Container.CollectionRepo, c.Container.SnapshotRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) valuationReportUC() *usecases.GetValuationReportUseCase {
+	return usecases.NewGetValuationReportUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) purchaseHistoryUC() *usecases.GetPurchaseHistoryUseCase {
+	return usecases.NewGetPurchaseHistoryUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AdjustmentRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) nutritionStatsUC() *usecases.GetNutritionStatsUseCase {
+	return usecases.NewGetNutritionStatsUseCase(c.Container.ContainerRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) enrichNutritionUC() *usecases.EnrichObjectNutritionUseCase {
+	return usecases.NewEnrichObjectNutritionUseCase(c.Container.ContainerRepo, c.Container.CollectionRepo, c.Container.ObjectCodeRepo, c.Container.AuthService, c.Container.NutritionProvider)
+}
+
+func (c *MCPContext) getExpiringObjectsUC() *usecases.GetExpiringObjectsUseCase {
+	return usecases.NewGetExpiringObjectsUseCase(c.Container.CollectionRepo, c.Container.ContainerRepo, c.Container.AuthService)
+}
+
+func (c *MCPContext) listCustomObjectTypesUC() *usecases.ListCustomObjectTypesUseCase {
+	return usecases.NewListCustomObjectTypesUseCase(c.Container.ObjectTypeRepo)
+}
+
 // notifyResourceUpdated sends a resource-changed notification to subscribed clients.
 // It is a no-op if the server is not yet set.
 func (c *MCPContext) notifyResourceUpdated(ctx context.Context, uris ...string) {
@@ -2,9 +2,12 @@ package mcpserver
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // MCPNotifier monitors DB health and logs state transitions.
@@ -83,3 +86,28 @@ func (n *MCPNotifier) pollDB(ctx context.Context, mctx *MCPContext) {
 		}
 	}
 }
+
+// importProgress returns a bulk import progress callback that sends
+// notifications/progress for the tool call, or nil when the client sent no
+// progress token. Sending is best effort; a failed notification is logged and
+// the import carries on.
+func importProgress(ctx context.Context, req *mcp.CallToolRequest) func(done, total int) {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+	return func(done, total int) {
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Message:       fmt.Sprintf("imported %d of %d rows", done, total),
+			Progress:      float64(done),
+			Total:         float64(total),
+		})
+		if err != nil {
+			slog.Debug("MCP: failed to send progress notification", "err", err)
+		}
+	}
+}
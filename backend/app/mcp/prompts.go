@@ -120,6 +120,118 @@ Steps:
 		}, nil
 	})
 
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "shopping_list",
+		Description: "Build a shopping list from objects at or below their restock threshold and staples that have come due",
+		Arguments: []*mcp.PromptArgument{{
+			Name:        "collection_id",
+			Description: "ID of the collection to check (leave empty for all collections)",
+			Required:    false,
+		}},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		collectionID := req.Params.Arguments["collection_id"]
+
+		target := "Read all collections (nishiki://collections) and skip those with low_stock_count 0"
+		if collectionID != "" {
+			target = fmt.Sprintf("Read collection nishiki://collections/%s", collectionID)
+		}
+
+		return &mcp.GetPromptResult{
+			Description: "Shopping list from low-stock items",
+			Messages: []*mcp.PromptMessage{{
+				Role: "user",
+				Content: &mcp.TextContent{Text: fmt.Sprintf(`Build a shopping list from low-stock items:
+
+1. %s
+2. For each collection, read its objects (nishiki://collections/{id}/objects)
+3. Keep objects with low_stock set (stock_status "out" means none left, "low" means at or below min_quantity)
+4. For each, suggest a purchase amount that brings quantity back above min_quantity, in the object's unit
+5. Call list_recurrences with due_only set and add each staple that is due, using its quantity when set, even if its stock looks fine
+6. Report the list grouped by collection, out-of-stock items first, then the due staples
+7. If a Grocy MCP server is connected, offer to add the items to its shopping list`, target)},
+			}},
+		}, nil
+	})
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "plan_meals",
+		Description: "Suggest meals that use expiring and available food, then save them as meal plans",
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        "days",
+				Description: "Number of days to plan, starting today (default: 7)",
+				Required:    false,
+			},
+			{
+				Name:        "preferences",
+				Description: "Dietary preferences or dishes to favor, e.g. \"vegetarian, quick weeknight dinners\"",
+				Required:    false,
+			},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		days := req.Params.Arguments["days"]
+		if days == "" {
+			days = "7"
+		}
+		preferences := req.Params.Arguments["preferences"]
+		if preferences == "" {
+			preferences = "none given"
+		}
+
+		return &mcp.GetPromptResult{
+			Description: "Meal plan from available food",
+			Messages: []*mcp.PromptMessage{{
+				Role: "user",
+				Content: &mcp.TextContent{Text: fmt.Sprintf(`Plan meals for the next %s days using the food I already have.
+
+Preferences: %s
+
+1. Call list_meal_plans with from set to today to see which meals are already planned
+2. Read all collections (nishiki://collections) and, for each food collection, its objects (nishiki://collections/{id}/objects)
+3. Rank the food: items with the soonest expires_at first, then items with the largest quantity; skip objects with stock_status "out"
+4. Suggest a recipe for each unplanned lunch and dinner that uses the ranked items first, noting how much of each object it needs in the object's unit
+5. Show the plan as a table (date, meal, recipe, ingredients) plus anything that would need to be bought, and ask me to confirm or change it
+6. For each confirmed meal, call create_meal_plan with the date, meal, recipe_name and ingredients (object_id and quantity)
+7. Remind me that completing a meal with complete_meal_plan will use up its ingredients`, days, preferences)},
+			}},
+		}, nil
+	})
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "budget_analysis",
+		Description: "Summarize food spending by category over a time window and flag anything unusual",
+		Arguments: []*mcp.PromptArgument{{
+			Name:        "days",
+			Description: "Number of days to look back (default: 30)",
+			Required:    false,
+		}},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		days := req.Params.Arguments["days"]
+		if days == "" {
+			days = "30"
+		}
+		return &mcp.GetPromptResult{
+			Description: "Food budget analysis",
+			Messages: []*mcp.PromptMessage{{
+				Role: "user",
+				Content: &mcp.TextContent{Text: fmt.Sprintf(`Analyze my food spending over the last %s days:
+
+1. Call purchase_history with days set to %s
+2. Summarize the spend:
+   - The total per currency (never add up different currencies)
+   - The spend per category, highest first, with its share of the total
+   - How many purchases had no price, so the totals are understood as a lower bound
+3. Compare buying with using: for each category and unit in adjustments, set restocked against used, and name categories that were bought but barely used
+4. Flag anomalies:
+   - Purchases priced well above the other purchases of the same category
+   - Days or weeks with far more purchases than the rest of the window
+   - The same item bought again while earlier ones were still unused
+5. Suggest two or three concrete ways to spend less, such as buying less of what goes unused
+6. If the window starts before the quantity history does (no adjustments at all), say so rather than concluding nothing was used`, days, days)},
+			}},
+		}, nil
+	})
+
 	s.AddPrompt(&mcp.Prompt{
 		Name:        "migrate_schema",
 		Description: "Review and update a collection's property schema after an import — shows inferred types, lets you correct them, then applies the updated schema",
@@ -2,8 +2,11 @@ package mcpserver
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -110,8 +113,58 @@ func registerResources(s *mcp.Server, mctx *MCPContext) {
 		return jsonResourceResult(req.Params.URI, response.NewContainerListResponse(resp.Containers))
 	})
 
+	// nishiki://expiring
+	s.AddResource(&mcp.Resource{
+		URI:         "nishiki://expiring",
+		Name:        "expiring",
+		Description: "Objects expiring within the digest window (digest.expiring_within_days), soonest first, including ones already expired",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		days := mctx.Container.GetConfig().Digest.ExpiringWithinDays
+		if days <= 0 {
+			days = defaultExpiringDays
+		}
+		return readExpiringResource(ctx, mctx, req.Params.URI, days)
+	})
+
+	// nishiki://object-types
+	s.AddResource(&mcp.Resource{
+		URI:         "nishiki://object-types",
+		Name:        "object-types",
+		Description: "Built-in and custom object types with the property fields, value formats and conditions create_object and bulk_import accept for each. A collection's own property_schema, if it differs, is the one its objects are checked against.",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		user, _, err := MCPUserFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := mctx.listCustomObjectTypesUC().Execute(ctx, usecases.ListCustomObjectTypesRequest{
+			UserID: user.ID(),
+		})
+		if err != nil {
+			slog.Error("failed to list custom object types", "err", err)
+			return nil, err
+		}
+		return jsonResourceResult(req.Params.URI, response.NewObjectTypeSchemaListResponse(resp.ObjectTypes))
+	})
+
 	// --- Parameterized resource templates ---
 
+	// nishiki://expiring/{days}
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "nishiki://expiring/{days}",
+		Name:        "expiring-within",
+		Description: "Objects expiring within the given number of days (1-366), soonest first, including ones already expired",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		value := extractID(req.Params.URI, "nishiki://expiring/")
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, ErrInvalidFormat.With(map[string]any{"field": "days", "value": value}).Wrap(err)
+		}
+		return readExpiringResource(ctx, mctx, req.Params.URI, days)
+	})
+
 	// nishiki://groups/{id}
 	s.AddResourceTemplate(&mcp.ResourceTemplate{
 		URITemplate: "nishiki://groups/{id}",
@@ -318,6 +371,36 @@ func registerResources(s *mcp.Server, mctx *MCPContext) {
 	})
 }
 
+// defaultExpiringDays is used for nishiki://expiring when the digest window
+// is not configured.
+const defaultExpiringDays = 7
+
+func readExpiringResource(ctx context.Context, mctx *MCPContext, uri string, days int) (*mcp.ReadResourceResult, error) {
+	user, token, err := MCPUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	resp, err := mctx.getExpiringObjectsUC().Execute(ctx, usecases.GetExpiringObjectsRequest{
+		UserID:    user.ID(),
+		UserToken: token,
+		Now:       now,
+		Days:      days,
+	})
+	if errors.Is(err, entities.ErrInvalidExpiringDays) {
+		return nil, ErrInvalidFormat.With(map[string]any{"field": "days", "value": days}).Wrap(err)
+	}
+	if err != nil {
+		slog.Error("failed to get expiring objects", "days", days, "err", err)
+		return nil, err
+	}
+	objects := make([]response.ExpiringObjectResponse, len(resp.Objects))
+	for i, item := range resp.Objects {
+		objects[i] = response.NewExpiringObjectResponse(item.Object, item.ContainerID, item.ContainerName, item.CollectionID, item.CollectionName, now)
+	}
+	return jsonResourceResult(uri, response.ExpiringObjectListResponse{Days: days, Objects: objects, Total: len(objects)})
+}
+
 // extractID parses the first path segment after the given URI prefix.
 // e.g., extractID("nishiki://groups/abc/users", "nishiki://groups/") → "abc"
 func extractID(uri, prefix string) string {
@@ -0,0 +1,75 @@
+package mcpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/app/mcp/testkit"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestObjectTypesResource(t *testing.T) {
+	ctx := context.Background()
+	kit, err := testkit.New(ctx, nil)
+	require.NoError(t, err)
+	defer kit.Close()
+
+	wine, err := entities.NewCustomObjectType(kit.Seed.User.ID(), "", "Wine", "🍷", "", &entities.PropertySchema{
+		Definitions: []entities.PropertyDefinition{
+			{Key: "vintage", DisplayName: "Vintage", Type: entities.PropertyTypeNumeric, Required: true},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, kit.Container.ObjectTypeRepo.Create(ctx, wine))
+	// Another account's type is not listed
+	other, err := entities.NewCustomObjectType(kit.Seed.OtherUser.ID(), "", "Stamps", "", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, kit.Container.ObjectTypeRepo.Create(ctx, other))
+
+	result, err := kit.Session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "nishiki://object-types"})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	var got response.ObjectTypeSchemaListResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &got))
+
+	types := make(map[string]response.ObjectTypeSchemaResponse)
+	for _, ot := range got.ObjectTypes {
+		types[ot.Key] = ot
+	}
+	assert.Len(t, got.ObjectTypes, len(entities.AllObjectTypes)+1)
+	assert.NotContains(t, types, "stamps")
+
+	food := types["food"]
+	assert.True(t, food.BuiltIn)
+	assert.Empty(t, food.Conditions, "food cannot be graded")
+	assert.Contains(t, propertyKeys(food), entities.PropertyCalories)
+
+	book := types["book"]
+	assert.Equal(t, []string{"mint", "near_mint", "good", "fair", "poor"}, book.Conditions)
+	assert.NotNil(t, book.Properties, "a type without fields lists none rather than null")
+
+	custom := types["wine"]
+	assert.False(t, custom.BuiltIn)
+	assert.Equal(t, "Wine", custom.Name)
+	require.Len(t, custom.Properties, 1)
+	assert.Equal(t, "vintage", custom.Properties[0].Key)
+	assert.True(t, custom.Properties[0].Required)
+
+	for _, def := range custom.Properties {
+		assert.Contains(t, got.PropertyFormats, def.Type)
+	}
+}
+
+func propertyKeys(ot response.ObjectTypeSchemaResponse) []string {
+	keys := make([]string, len(ot.Properties))
+	for i, def := range ot.Properties {
+		keys[i] = def.Key
+	}
+	return keys
+}
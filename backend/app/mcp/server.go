@@ -9,6 +9,8 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
 	"github.com/nishiki/backend/domain/usecases"
 )
 
@@ -21,6 +23,7 @@ func NewMCPServer(mctx *MCPContext) *mcp.Server {
 		Instructions: "Nishiki inventory management system. " +
 			"Use resources to browse collections, containers, and objects. " +
 			"Use tools to create, update, delete, and search inventory. " +
+			"Read nishiki://object-types for the property fields each object type takes. " +
 			"Collections belong to groups for shared access.",
 		CompletionHandler:  completionHandler(mctx),
 		SubscribeHandler:   subscribeHandler(),
@@ -30,11 +33,26 @@ func NewMCPServer(mctx *MCPContext) *mcp.Server {
 	registerResources(server, mctx)
 	registerTools(server, mctx)
 	registerPrompts(server)
+	server.AddReceivingMiddleware(tenantMiddleware(mctx))
 
 	mctx.Server = server
 	return server
 }
 
+// tenantMiddleware limits the repositories to the MCP user's own and group
+// data. It is added first so it runs after the transport's middleware has
+// put the user in the context.
+func tenantMiddleware(mctx *MCPContext) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if user, token, err := MCPUserFromContext(ctx); err == nil && mctx.Container != nil {
+				ctx = repositories.WithTenant(ctx, services.NewTenant(mctx.Container.AuthService, user, token))
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
 // completionHandler returns a handler that provides autocomplete for resource template parameters.
 func completionHandler(mctx *MCPContext) func(context.Context, *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
 	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
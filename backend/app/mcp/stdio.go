@@ -0,0 +1,248 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// BatchingStdioTransport serves MCP as newline-delimited JSON over a reader
+// and writer, like mcp.StdioTransport, but accepts JSON-RPC batches whatever
+// protocol version the client negotiated (the SDK only takes them before
+// 2025-06-18). The calls in a batch are handed to the server one by one and
+// their responses are written back as a single array once the last is ready,
+// so a client can issue several reads in one round-trip.
+type BatchingStdioTransport struct {
+	Reader io.ReadCloser
+	Writer io.WriteCloser
+}
+
+// Connect implements mcp.Transport.
+func (t *BatchingStdioTransport) Connect(context.Context) (mcp.Connection, error) {
+	return newBatchingConn(t.Reader, t.Writer), nil
+}
+
+type readResult struct {
+	raw json.RawMessage
+	err error
+}
+
+// pendingBatch collects the responses to a batch's calls in request order.
+type pendingBatch struct {
+	responses []json.RawMessage
+	waiting   int
+}
+
+type batchingConn struct {
+	r        io.ReadCloser
+	incoming chan readResult
+	closed   chan struct{}
+	once     sync.Once
+
+	// queue holds the rest of a batch being handed to the server. Only Read
+	// touches it, and the SDK calls Read from a single goroutine.
+	queue []jsonrpc.Message
+
+	mu      sync.Mutex // guards w, batches and index
+	w       io.WriteCloser
+	batches map[jsonrpc.ID]*pendingBatch
+	index   map[jsonrpc.ID]int
+}
+
+func newBatchingConn(r io.ReadCloser, w io.WriteCloser) *batchingConn {
+	c := &batchingConn{
+		r:        r,
+		w:        w,
+		incoming: make(chan readResult),
+		closed:   make(chan struct{}),
+		batches:  make(map[jsonrpc.ID]*pendingBatch),
+		index:    make(map[jsonrpc.ID]int),
+	}
+	// Decode on a goroutine so Close can unblock a Read waiting on stdin.
+	go func() {
+		dec := json.NewDecoder(r)
+		for {
+			var raw json.RawMessage
+			err := dec.Decode(&raw)
+			select {
+			case c.incoming <- readResult{raw: raw, err: err}:
+			case <-c.closed:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func (c *batchingConn) SessionID() string { return "" }
+
+// Read implements mcp.Connection.
+func (c *batchingConn) Read(ctx context.Context) (jsonrpc.Message, error) {
+	for {
+		if len(c.queue) > 0 {
+			msg := c.queue[0]
+			c.queue = c.queue[1:]
+			return msg, nil
+		}
+
+		var res readResult
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.closed:
+			return nil, io.EOF
+		case res = <-c.incoming:
+		}
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		if !isBatch(res.raw) {
+			return jsonrpc.DecodeMessage(res.raw)
+		}
+		msgs, err := c.startBatch(res.raw)
+		if err != nil {
+			return nil, err
+		}
+		c.queue = msgs
+	}
+}
+
+// startBatch decodes a batch and registers its calls so Write can gather
+// their responses. Entries that are not valid messages get an Invalid Request
+// error in the reply, as JSON-RPC asks; if nothing in the batch expects an
+// answer besides those, the reply is written straight away.
+func (c *batchingConn) startBatch(raw json.RawMessage) ([]jsonrpc.Message, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("invalid JSON-RPC batch: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, c.writeRaw(invalidRequest("empty batch"))
+	}
+
+	batch := &pendingBatch{}
+	var msgs []jsonrpc.Message
+	var ids []jsonrpc.ID
+	for _, entry := range entries {
+		msg, err := jsonrpc.DecodeMessage(entry)
+		if err != nil {
+			batch.responses = append(batch.responses, invalidRequest(err.Error()))
+			continue
+		}
+		if req, ok := msg.(*jsonrpc.Request); ok && req.IsCall() {
+			if slices.Contains(ids, req.ID) {
+				batch.responses = append(batch.responses, invalidRequest(fmt.Sprintf("duplicate request id %v", req.ID.Raw())))
+				continue
+			}
+			ids = append(ids, req.ID)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		if _, taken := c.batches[id]; taken {
+			return nil, fmt.Errorf("request id %v is already in flight", id.Raw())
+		}
+	}
+	if len(ids) == 0 {
+		if len(batch.responses) == 0 {
+			return msgs, nil
+		}
+		return msgs, c.writeBatchLocked(batch)
+	}
+	for _, id := range ids {
+		c.index[id] = len(batch.responses)
+		batch.responses = append(batch.responses, nil)
+		c.batches[id] = batch
+	}
+	batch.waiting = len(ids)
+	return msgs, nil
+}
+
+// Write implements mcp.Connection. A response to a call that arrived in a
+// batch is held until the batch is complete; everything else, including
+// progress notifications sent while a batch is running, goes out at once.
+func (c *batchingConn) Write(_ context.Context, msg jsonrpc.Message) error {
+	data, err := jsonrpc.EncodeMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON-RPC message: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if resp, ok := msg.(*jsonrpc.Response); ok {
+		if batch, inBatch := c.batches[resp.ID]; inBatch {
+			batch.responses[c.index[resp.ID]] = data
+			delete(c.batches, resp.ID)
+			delete(c.index, resp.ID)
+			if batch.waiting--; batch.waiting > 0 {
+				return nil
+			}
+			return c.writeBatchLocked(batch)
+		}
+	}
+	return c.writeLineLocked(data)
+}
+
+// Close implements mcp.Connection.
+func (c *batchingConn) Close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.closed)
+		err = errors.Join(c.r.Close(), c.w.Close())
+	})
+	return err
+}
+
+func (c *batchingConn) writeRaw(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writeLineLocked(data)
+}
+
+func (c *batchingConn) writeBatchLocked(batch *pendingBatch) error {
+	data, err := json.Marshal(batch.responses)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON-RPC batch: %w", err)
+	}
+	return c.writeLineLocked(data)
+}
+
+func (c *batchingConn) writeLineLocked(data []byte) error {
+	_, err := c.w.Write(append(data, '\n'))
+	return err
+}
+
+// isBatch reports whether raw is a JSON array rather than a single message.
+func isBatch(raw json.RawMessage) bool {
+	trimmed := bytes.TrimLeft(raw, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// invalidRequest is the error response for a batch entry that could not be
+// read, which has no usable id.
+func invalidRequest(reason string) json.RawMessage {
+	data, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      nil,
+		"error": map[string]any{
+			"code":    jsonrpc.CodeInvalidRequest,
+			"message": "invalid request: " + reason,
+		},
+	})
+	return data
+}
@@ -0,0 +1,96 @@
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stdioClient struct {
+	t   *testing.T
+	in  io.WriteCloser
+	out *bufio.Scanner
+}
+
+func (c *stdioClient) send(line string) {
+	c.t.Helper()
+	_, err := io.WriteString(c.in, line+"\n")
+	require.NoError(c.t, err)
+}
+
+func (c *stdioClient) receive() string {
+	c.t.Helper()
+	require.True(c.t, c.out.Scan(), "server closed the connection: %v", c.out.Err())
+	return c.out.Text()
+}
+
+func startBatchingServer(t *testing.T) *stdioClient {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	type EchoInput struct {
+		Text string `json:"text"`
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "echo"}, func(ctx context.Context, req *mcp.CallToolRequest, input EchoInput) (*mcp.CallToolResult, any, error) {
+		return textResult(input.Text), nil, nil
+	})
+
+	clientToServer, serverIn := io.Pipe()
+	serverOut, serverToClient := io.Pipe()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	session, err := server.Connect(ctx, &BatchingStdioTransport{Reader: clientToServer, Writer: serverToClient}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	c := &stdioClient{t: t, in: serverIn, out: bufio.NewScanner(serverOut)}
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	c.receive()
+	c.send(`{"jsonrpc":"2.0","method":"notifications/initialized","params":{}}`)
+	return c
+}
+
+func TestBatchingStdioTransport_Batch(t *testing.T) {
+	c := startBatchingServer(t)
+
+	c.send(`[{"jsonrpc":"2.0","id":2,"method":"tools/list"},` +
+		`{"jsonrpc":"2.0","method":"notifications/roots/list_changed"},` +
+		`{"jsonrpc":"2.0","id":"b","method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}]`)
+
+	var replies []struct {
+		ID     any             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(c.receive()), &replies))
+	require.Len(t, replies, 2)
+	assert.Equal(t, float64(2), replies[0].ID)
+	assert.Contains(t, string(replies[0].Result), `"echo"`)
+	assert.Equal(t, "b", replies[1].ID)
+	assert.Contains(t, string(replies[1].Result), `"hi"`)
+
+	// Single messages are still answered one per line.
+	c.send(`{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":3,"result":{}}`, c.receive())
+}
+
+func TestBatchingStdioTransport_InvalidEntries(t *testing.T) {
+	c := startBatchingServer(t)
+
+	c.send(`[]`)
+	assert.Contains(t, c.receive(), `"code":-32600`)
+
+	c.send(`[{"jsonrpc":"2.0","id":4,"method":"ping"},{"foo":1}]`)
+	var replies []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(c.receive()), &replies))
+	require.Len(t, replies, 2)
+	assert.Nil(t, replies[0]["id"])
+	assert.NotNil(t, replies[0]["error"])
+	assert.Equal(t, float64(4), replies[1]["id"])
+}
@@ -0,0 +1,322 @@
+package testkit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// FakeAuthService is an in-memory services.AuthService standing in for
+// Authentik. Tokens map directly to users and group membership is a plain
+// table, so the user token passed by the use cases is ignored.
+type FakeAuthService struct {
+	mu      sync.RWMutex
+	users   map[string]*entities.User
+	tokens  map[string]string
+	groups  map[string]*entities.Group
+	members map[string][]string
+	admins  map[string][]string
+}
+
+func NewFakeAuthService() *FakeAuthService {
+	return &FakeAuthService{
+		users:   make(map[string]*entities.User),
+		tokens:  make(map[string]string),
+		groups:  make(map[string]*entities.Group),
+		members: make(map[string][]string),
+		admins:  make(map[string][]string),
+	}
+}
+
+// AddUser registers a user that can authenticate with token.
+func (s *FakeAuthService) AddUser(user *entities.User, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID().String()] = user
+	if token != "" {
+		s.tokens[token] = user.ID().String()
+	}
+}
+
+func (s *FakeAuthService) IssuerURL() string {
+	return "http://authentik.invalid/application/o/nishiki/"
+}
+
+func (s *FakeAuthService) CheckHealth(context.Context) error {
+	return nil
+}
+
+func (s *FakeAuthService) ValidateToken(_ context.Context, token string) (*services.AuthClaims, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	userID, ok := s.tokens[token]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	user := s.users[userID]
+	// Every token counts as a fresh sign-in, so routes behind
+	// RequireRecentAuth can be exercised too
+	now := time.Now().Unix()
+	return &services.AuthClaims{
+		Subject:  userID,
+		Email:    user.EmailAddress().String(),
+		Username: user.Username().String(),
+		IssuedAt: now,
+		AuthTime: now,
+	}, nil
+}
+
+func (s *FakeAuthService) GetUserFromClaims(_ context.Context, claims *services.AuthClaims) (*entities.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	user, ok := s.users[claims.Subject]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (s *FakeAuthService) CreateUserFromClaims(ctx context.Context, claims *services.AuthClaims) (*entities.User, error) {
+	return s.GetUserFromClaims(ctx, claims)
+}
+
+func (s *FakeAuthService) GetOIDCConfig(context.Context, string) (map[string]any, error) {
+	return map[string]any{"issuer": s.IssuerURL()}, nil
+}
+
+func (s *FakeAuthService) ProxyTokenExchange(context.Context, map[string]any) ([]byte, int, error) {
+	return nil, 0, errors.New("token exchange is not supported by the fake auth service")
+}
+
+func (s *FakeAuthService) CreateGroup(_ context.Context, _, name string, creatorID string) (*entities.Group, error) {
+	groupName, err := entities.NewGroupName(name)
+	if err != nil {
+		return nil, err
+	}
+	groupID, err := entities.GroupIDFromString(uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+	group, err := entities.NewGroup(entities.GroupProps{ID: groupID, Name: groupName})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[groupID.String()] = group
+	s.members[groupID.String()] = []string{creatorID}
+	s.admins[groupID.String()] = []string{creatorID}
+	return group, nil
+}
+
+func (s *FakeAuthService) GetUserGroups(_ context.Context, _, userID string) ([]*entities.Group, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var groups []*entities.Group
+	for id, members := range s.members {
+		if slices.Contains(members, userID) {
+			groups = append(groups, s.groups[id])
+		}
+	}
+	slices.SortFunc(groups, func(a, b *entities.Group) int {
+		return a.CreatedAt().Compare(b.CreatedAt())
+	})
+	return groups, nil
+}
+
+func (s *FakeAuthService) GetGroupUsers(_ context.Context, _, groupID string) ([]*entities.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	members, ok := s.members[groupID]
+	if !ok {
+		return nil, errors.New("group not found")
+	}
+	users := make([]*entities.User, 0, len(members))
+	for _, id := range members {
+		if user, ok := s.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (s *FakeAuthService) GetUserByID(_ context.Context, _, userID string) (*entities.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (s *FakeAuthService) GetGroupByID(_ context.Context, _, groupID string) (*entities.Group, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	group, ok := s.groups[groupID]
+	if !ok {
+		return nil, errors.New("group not found")
+	}
+	return group, nil
+}
+
+func (s *FakeAuthService) UpdateGroup(_ context.Context, _, groupID, name string) (*entities.Group, error) {
+	groupName, err := entities.NewGroupName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, ok := s.groups[groupID]
+	if !ok {
+		return nil, errors.New("group not found")
+	}
+	updated := entities.ReconstructGroup(group.ID(), groupName, group.Description(), group.CreatedAt(), time.Now())
+	s.groups[groupID] = updated
+	return updated, nil
+}
+
+func (s *FakeAuthService) DeleteGroup(_ context.Context, _, groupID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.groups[groupID]; !ok {
+		return errors.New("group not found")
+	}
+	delete(s.groups, groupID)
+	delete(s.members, groupID)
+	delete(s.admins, groupID)
+	return nil
+}
+
+func (s *FakeAuthService) AddUserToGroup(_ context.Context, _, groupID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[groupID]
+	if !ok {
+		return errors.New("group not found")
+	}
+	if _, ok := s.users[userID]; !ok {
+		return errors.New("user not found")
+	}
+	if !slices.Contains(members, userID) {
+		s.members[groupID] = append(members, userID)
+	}
+	return nil
+}
+
+func (s *FakeAuthService) RemoveUserFromGroup(_ context.Context, _, groupID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[groupID]
+	if !ok {
+		return errors.New("group not found")
+	}
+	s.members[groupID] = slices.DeleteFunc(members, func(id string) bool { return id == userID })
+	s.admins[groupID] = slices.DeleteFunc(s.admins[groupID], func(id string) bool { return id == userID })
+	return nil
+}
+
+func (s *FakeAuthService) InviteUserByEmail(ctx context.Context, token, groupID, email string) (*entities.User, error) {
+	s.mu.RLock()
+	var invited *entities.User
+	for _, user := range s.users {
+		if user.EmailAddress().String() == email {
+			invited = user
+			break
+		}
+	}
+	s.mu.RUnlock()
+	if invited == nil {
+		return nil, services.ErrInviteeNotFound
+	}
+	if err := s.AddUserToGroup(ctx, token, groupID, invited.ID().String()); err != nil {
+		return nil, err
+	}
+	return invited, nil
+}
+
+func (s *FakeAuthService) GetGroupMemberRoles(_ context.Context, _, groupID string) (map[string]entities.GroupRole, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.members[groupID]; !ok {
+		return nil, errors.New("group not found")
+	}
+	roles := make(map[string]entities.GroupRole)
+	for _, id := range s.admins[groupID] {
+		roles[id] = entities.GroupRoleAdmin
+	}
+	return roles, nil
+}
+
+func (s *FakeAuthService) SetGroupMemberRole(_ context.Context, _, groupID, userID string, role entities.GroupRole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[groupID]
+	if !ok {
+		return errors.New("group not found")
+	}
+	if !slices.Contains(members, userID) {
+		return errors.New("user not found")
+	}
+	admins := slices.DeleteFunc(s.admins[groupID], func(id string) bool { return id == userID })
+	if role == entities.GroupRoleAdmin {
+		admins = append(admins, userID)
+	}
+	s.admins[groupID] = admins
+	return nil
+}
+
+// noImageSearch is a services.ImageSearchService that never finds an image,
+// keeping imports offline.
+type noImageSearch struct{}
+
+func (noImageSearch) SearchAndCache(context.Context, string, entities.ObjectType, map[string]entities.TypedValue) (string, error) {
+	return "", nil
+}
+
+func (noImageSearch) CheckHealth(context.Context) error {
+	return nil
+}
+
+// fixedNutrition is a services.NutritionProvider that reports the same
+// facts for every UPC, keeping lookups offline.
+type fixedNutrition struct{}
+
+func (fixedNutrition) LookupUPC(context.Context, string) (*entities.NutritionFacts, error) {
+	calories, protein, carbs, fat := 160.0, 3.0, 36.0, 0.5
+	return &entities.NutritionFacts{Calories: &calories, Protein: &protein, Carbs: &carbs, Fat: &fat, ServingSize: "45 g"}, nil
+}
+
+func (fixedNutrition) CheckHealth(context.Context) error {
+	return nil
+}
+
+// discardMediaStorage is a services.MediaStorage that keeps nothing, so the
+// kit never touches the filesystem.
+type discardMediaStorage struct{}
+
+func (discardMediaStorage) Save(_ context.Context, collectionID entities.CollectionID, id entities.MediaID, _ string, _ []byte) (*services.StoredMedia, error) {
+	url := "/media/" + collectionID.String() + "/" + id.String()
+	return &services.StoredMedia{URL: url, ThumbnailURL: url + "_thumb.jpg"}, nil
+}
+
+func (discardMediaStorage) Delete(context.Context, entities.CollectionID, entities.MediaID) error {
+	return nil
+}
+
+func (discardMediaStorage) DeleteCollection(context.Context, entities.CollectionID) error {
+	return nil
+}
+
+func (discardMediaStorage) CheckHealth(context.Context) error {
+	return nil
+}
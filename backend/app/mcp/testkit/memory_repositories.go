@@ -0,0 +1,2231 @@
+package testkit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// The in-memory repositories mirror the error strings and query semantics of
+// the Mongo implementations in external/repositories closely enough for the
+// MCP handlers to behave as they do in production. Collections and containers
+// are cloned on the way in and out so a use case that mutates an entity
+// without saving it cannot leak the change into later reads.
+
+func cloneContainer(c *entities.Container) *entities.Container {
+	return entities.ReconstructContainer(c.ID(), c.CollectionID(), c.Name(), c.ContainerType(), c.ParentContainerID(),
+		c.CategoryID(), c.GroupID(), c.Objects(), c.Location(), c.Width(), c.Depth(), c.Rows(), c.Capacity(),
+		c.TemperatureZone(), c.Humidity(), c.Placement(), c.CreatedAt(), c.UpdatedAt())
+}
+
+// MemoryContainerRepository is an in-memory repositories.ContainerRepository.
+// Like the Mongo one it only sees the containers in the collections of the
+// context's tenant, if there is one.
+type MemoryContainerRepository struct {
+	mu          sync.RWMutex
+	containers  map[entities.ContainerID]*entities.Container
+	order       []entities.ContainerID
+	collections *MemoryCollectionRepository
+}
+
+func NewMemoryContainerRepository() *MemoryContainerRepository {
+	return &MemoryContainerRepository{containers: make(map[entities.ContainerID]*entities.Container)}
+}
+
+// visibleCollections returns the IDs of the collections the context's
+// tenant may see, or nil when there is no tenant.
+func (r *MemoryContainerRepository) visibleCollections(ctx context.Context) (map[entities.CollectionID]bool, error) {
+	if repositories.TenantFromContext(ctx) == nil {
+		return nil, nil
+	}
+	r.mu.RLock()
+	collections := r.collections
+	r.mu.RUnlock()
+	visible := make(map[entities.CollectionID]bool)
+	if collections == nil {
+		return visible, nil
+	}
+	found, err := collections.tenantFilter(ctx, false, func(*entities.Collection) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range found {
+		visible[c.ID()] = true
+	}
+	return visible, nil
+}
+
+// inVisible reports whether c belongs to one of the visible collections; a
+// nil set allows everything.
+func inVisible(visible map[entities.CollectionID]bool, c *entities.Container) bool {
+	return visible == nil || visible[c.CollectionID()]
+}
+
+func (r *MemoryContainerRepository) Create(ctx context.Context, container *entities.Container) error {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return err
+	}
+	if !inVisible(visible, container) {
+		return repositories.ErrOutsideTenant
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.containers[container.ID()]; ok {
+		return errors.New("container already exists")
+	}
+	r.containers[container.ID()] = cloneContainer(container)
+	r.order = append(r.order, container.ID())
+	return nil
+}
+
+func (r *MemoryContainerRepository) GetByID(ctx context.Context, id entities.ContainerID) (*entities.Container, error) {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := r.get(id)
+	if !ok || !inVisible(visible, c) {
+		return nil, errors.New("container not found")
+	}
+	return c, nil
+}
+
+// get returns a clone of the stored container whatever the tenant
+func (r *MemoryContainerRepository) get(id entities.ContainerID) (*entities.Container, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.containers[id]
+	if !ok {
+		return nil, false
+	}
+	return cloneContainer(c), true
+}
+
+func (r *MemoryContainerRepository) Update(ctx context.Context, container *entities.Container) error {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return err
+	}
+	if !inVisible(visible, container) {
+		return repositories.ErrOutsideTenant
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.containers[container.ID()]; !ok || !inVisible(visible, stored) {
+		return errors.New("container not found")
+	}
+	r.containers[container.ID()] = cloneContainer(container)
+	return nil
+}
+
+func (r *MemoryContainerRepository) Delete(ctx context.Context, id entities.ContainerID) error {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.containers[id]; !ok || !inVisible(visible, stored) {
+		return errors.New("container not found")
+	}
+	r.remove(id)
+	return nil
+}
+
+func (r *MemoryContainerRepository) DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error) {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for _, id := range slices.Clone(r.order) {
+		if c := r.containers[id]; c.CollectionID() == collectionID && inVisible(visible, c) {
+			r.remove(id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// remove drops id from the store; the caller holds the write lock.
+func (r *MemoryContainerRepository) remove(id entities.ContainerID) {
+	delete(r.containers, id)
+	r.order = slices.DeleteFunc(r.order, func(other entities.ContainerID) bool { return other == id })
+}
+
+// filter returns clones of the stored containers the tenant may see that
+// match keep, in insertion order.
+func (r *MemoryContainerRepository) filter(ctx context.Context, keep func(*entities.Container) bool) ([]*entities.Container, error) {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*entities.Container
+	for _, id := range r.order {
+		if c := r.containers[id]; inVisible(visible, c) && keep(c) {
+			result = append(result, cloneContainer(c))
+		}
+	}
+	return result, nil
+}
+
+func (r *MemoryContainerRepository) GetByGroupID(ctx context.Context, groupID entities.GroupID) ([]*entities.Container, error) {
+	return r.filter(ctx, func(c *entities.Container) bool {
+		return c.GroupID() != nil && c.GroupID().Equals(groupID)
+	})
+}
+
+func (r *MemoryContainerRepository) GetByCollectionID(ctx context.Context, collectionID entities.CollectionID) ([]*entities.Container, error) {
+	return r.filter(ctx, func(c *entities.Container) bool { return c.CollectionID() == collectionID })
+}
+
+func (r *MemoryContainerRepository) GetChildContainers(ctx context.Context, parentID entities.ContainerID) ([]*entities.Container, error) {
+	return r.filter(ctx, func(c *entities.Container) bool {
+		return c.ParentContainerID() != nil && *c.ParentContainerID() == parentID
+	})
+}
+
+func (r *MemoryContainerRepository) List(ctx context.Context, limit, offset int) ([]*entities.Container, error) {
+	containers, err := r.filter(ctx, func(*entities.Container) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	return paginate(containers, limit, offset), nil
+}
+
+func (r *MemoryContainerRepository) Exists(ctx context.Context, id entities.ContainerID) (bool, error) {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return false, err
+	}
+	c, ok := r.get(id)
+	return ok && inVisible(visible, c), nil
+}
+
+func (r *MemoryContainerRepository) GetContainersWithExpiredFood(ctx context.Context, groupID entities.GroupID) ([]*entities.Container, error) {
+	now := time.Now()
+	return r.filter(ctx, func(c *entities.Container) bool {
+		if c.GroupID() == nil || !c.GroupID().Equals(groupID) {
+			return false
+		}
+		for _, obj := range c.Objects() {
+			if obj.ExpiresAt() != nil && obj.ExpiresAt().Before(now) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (r *MemoryContainerRepository) FindByObjectID(ctx context.Context, objectID entities.ObjectID) (*entities.Container, error) {
+	found, err := r.filter(ctx, func(c *entities.Container) bool {
+		return slices.ContainsFunc(c.Objects(), func(o entities.Object) bool { return o.ID() == objectID })
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, errors.New("container not found")
+	}
+	return found[0], nil
+}
+
+func (r *MemoryContainerRepository) AddObject(ctx context.Context, containerID entities.ContainerID, object entities.Object) error {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.containers[containerID]
+	if !ok || !inVisible(visible, c) {
+		return errors.New("container not found")
+	}
+	r.containers[containerID] = entities.ReconstructContainer(c.ID(), c.CollectionID(), c.Name(), c.ContainerType(),
+		c.ParentContainerID(), c.CategoryID(), c.GroupID(), append(c.Objects(), object), c.Location(),
+		c.Width(), c.Depth(), c.Rows(), c.Capacity(), c.TemperatureZone(), c.Humidity(), c.Placement(),
+		c.CreatedAt(), c.UpdatedAt())
+	return nil
+}
+
+func (r *MemoryContainerRepository) RemoveObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID) error {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.containers[containerID]
+	if !ok || !inVisible(visible, c) {
+		return errors.New("container not found")
+	}
+	objects := slices.DeleteFunc(c.Objects(), func(o entities.Object) bool { return o.ID() == objectID })
+	r.containers[containerID] = entities.ReconstructContainer(c.ID(), c.CollectionID(), c.Name(), c.ContainerType(),
+		c.ParentContainerID(), c.CategoryID(), c.GroupID(), objects, c.Location(),
+		c.Width(), c.Depth(), c.Rows(), c.Capacity(), c.TemperatureZone(), c.Humidity(), c.Placement(),
+		c.CreatedAt(), c.UpdatedAt())
+	return nil
+}
+
+func (r *MemoryContainerRepository) ClaimObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, claim entities.ObjectClaim) error {
+	return r.updateObject(ctx, containerID, objectID, func(o *entities.Object) error {
+		return o.ClaimFor(claim)
+	})
+}
+
+func (r *MemoryContainerRepository) ReleaseObjectClaim(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, holder *entities.UserID, now time.Time) error {
+	return r.updateObject(ctx, containerID, objectID, func(o *entities.Object) error {
+		if active := o.ActiveClaim(now); holder != nil && active != nil && !active.UserID().Equals(*holder) {
+			return entities.ErrObjectClaimed
+		}
+		o.Unclaim()
+		return nil
+	})
+}
+
+// updateObject applies fn to one object under the write lock, like the
+// positional updates of the Mongo repository.
+func (r *MemoryContainerRepository) updateObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, fn func(*entities.Object) error) error {
+	visible, err := r.visibleCollections(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.containers[containerID]
+	if !ok || !inVisible(visible, c) {
+		return errors.New("container not found")
+	}
+	objects := c.Objects()
+	i := slices.IndexFunc(objects, func(o entities.Object) bool { return o.ID() == objectID })
+	if i < 0 {
+		return entities.ErrObjectNotFoundInContainer
+	}
+	if err := fn(&objects[i]); err != nil {
+		return err
+	}
+	r.containers[containerID] = entities.ReconstructContainer(c.ID(), c.CollectionID(), c.Name(), c.ContainerType(),
+		c.ParentContainerID(), c.CategoryID(), c.GroupID(), objects, c.Location(),
+		c.Width(), c.Depth(), c.Rows(), c.Capacity(), c.TemperatureZone(), c.Humidity(), c.Placement(),
+		c.CreatedAt(), c.UpdatedAt())
+	return nil
+}
+
+// GetByCollectionIDWithAccess returns the collection's containers when the
+// user owns the collection or belongs to its group, like the Mongo $lookup.
+func (r *MemoryContainerRepository) GetByCollectionIDWithAccess(ctx context.Context, collectionID entities.CollectionID, userID entities.UserID, groupIDs []entities.GroupID) ([]*entities.Container, error) {
+	r.mu.RLock()
+	collections := r.collections
+	r.mu.RUnlock()
+	if collections == nil {
+		return nil, nil
+	}
+	collection, err := collections.GetByIDSummary(ctx, collectionID)
+	if err != nil {
+		return nil, nil
+	}
+	groupID := collection.GroupID()
+	if !collection.UserID().Equals(userID) && (groupID == nil || !slices.ContainsFunc(groupIDs, groupID.Equals)) {
+		return nil, nil
+	}
+	return r.GetByCollectionID(ctx, collectionID)
+}
+
+// MemoryCollectionRepository is an in-memory repositories.CollectionRepository.
+// Like the Mongo document it stores only the IDs of a collection's containers
+// and loads the containers themselves from the container repository on read.
+// It sees only the collections of the context's tenant, if there is one.
+type MemoryCollectionRepository struct {
+	mu           sync.RWMutex
+	collections  map[entities.CollectionID]*entities.Collection
+	containerIDs map[entities.CollectionID][]entities.ContainerID
+	order        []entities.CollectionID
+	containers   *MemoryContainerRepository
+}
+
+func NewMemoryCollectionRepository(containers *MemoryContainerRepository) *MemoryCollectionRepository {
+	r := &MemoryCollectionRepository{
+		collections:  make(map[entities.CollectionID]*entities.Collection),
+		containerIDs: make(map[entities.CollectionID][]entities.ContainerID),
+		containers:   containers,
+	}
+	containers.mu.Lock()
+	containers.collections = r
+	containers.mu.Unlock()
+	return r
+}
+
+// tenantCanAccess applies the ownership predicate the Mongo repositories add
+// to their queries when the context carries a tenant.
+func tenantCanAccess(ctx context.Context, c *entities.Collection) (bool, error) {
+	tenant := repositories.TenantFromContext(ctx)
+	if tenant == nil {
+		return true, nil
+	}
+	return tenant.CanAccess(ctx, c)
+}
+
+// store records the collection without its containers; the caller holds the write lock.
+func (r *MemoryCollectionRepository) store(collection *entities.Collection) {
+	ids := make([]entities.ContainerID, 0, collection.ContainerCount())
+	for _, c := range collection.Containers() {
+		ids = append(ids, c.ID())
+	}
+	r.collections[collection.ID()] = withContainers(collection, []entities.Container{})
+	r.containerIDs[collection.ID()] = ids
+}
+
+func withContainers(c *entities.Collection, containers []entities.Container) *entities.Collection {
+	return entities.ReconstructCollection(c.ID(), c.UserID(), c.GroupID(), c.IsGroupOwned(), c.Name(), c.CategoryID(),
+		c.ObjectType(), containers, c.Tags(), c.Location(), c.PropertySchema(), c.ShelfLife(), c.FloorPlan(),
+		c.CreatedAt(), c.UpdatedAt())
+}
+
+// load returns a full copy of the collection with its containers; the caller holds the read lock.
+func (r *MemoryCollectionRepository) load(id entities.CollectionID) *entities.Collection {
+	containers := make([]entities.Container, 0, len(r.containerIDs[id]))
+	for _, containerID := range r.containerIDs[id] {
+		if c, ok := r.containers.get(containerID); ok {
+			containers = append(containers, *c)
+		}
+	}
+	return withContainers(r.collections[id], containers)
+}
+
+// visible returns the stored collection when it exists and the tenant may
+// see it; the caller holds a lock.
+func (r *MemoryCollectionRepository) visible(ctx context.Context, id entities.CollectionID) (*entities.Collection, error) {
+	c, ok := r.collections[id]
+	if !ok {
+		return nil, errors.New("collection not found")
+	}
+	allowed, err := tenantCanAccess(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("collection not found")
+	}
+	return c, nil
+}
+
+func (r *MemoryCollectionRepository) Create(ctx context.Context, collection *entities.Collection) error {
+	if allowed, err := tenantCanAccess(ctx, collection); err != nil {
+		return err
+	} else if !allowed {
+		return repositories.ErrOutsideTenant
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.collections[collection.ID()]; ok {
+		return errors.New("collection already exists")
+	}
+	r.store(collection)
+	r.order = append(r.order, collection.ID())
+	return nil
+}
+
+func (r *MemoryCollectionRepository) GetByID(ctx context.Context, id entities.CollectionID) (*entities.Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, err := r.visible(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.load(id), nil
+}
+
+func (r *MemoryCollectionRepository) GetByIDSummary(ctx context.Context, id entities.CollectionID) (*entities.Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, err := r.visible(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return withContainers(c, []entities.Container{}), nil
+}
+
+func (r *MemoryCollectionRepository) Update(ctx context.Context, collection *entities.Collection) error {
+	if allowed, err := tenantCanAccess(ctx, collection); err != nil {
+		return err
+	} else if !allowed {
+		return repositories.ErrOutsideTenant
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.visible(ctx, collection.ID()); err != nil {
+		return err
+	}
+	r.store(collection)
+	return nil
+}
+
+func (r *MemoryCollectionRepository) Delete(ctx context.Context, id entities.CollectionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.visible(ctx, id); err != nil {
+		return err
+	}
+	delete(r.collections, id)
+	delete(r.containerIDs, id)
+	r.order = slices.DeleteFunc(r.order, func(other entities.CollectionID) bool { return other == id })
+	return nil
+}
+
+// filter returns the stored collections matching keep, in insertion order,
+// with containers loaded when full is set. It ignores the tenant, as the
+// Mongo usage queries do.
+func (r *MemoryCollectionRepository) filter(full bool, keep func(*entities.Collection) bool) []*entities.Collection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*entities.Collection
+	for _, id := range r.order {
+		c := r.collections[id]
+		if !keep(c) {
+			continue
+		}
+		if full {
+			result = append(result, r.load(id))
+		} else {
+			result = append(result, withContainers(c, []entities.Container{}))
+		}
+	}
+	return result
+}
+
+// tenantFilter is filter limited to the collections the tenant may see
+func (r *MemoryCollectionRepository) tenantFilter(ctx context.Context, full bool, keep func(*entities.Collection) bool) ([]*entities.Collection, error) {
+	var err error
+	found := r.filter(full, func(c *entities.Collection) bool {
+		if err != nil || !keep(c) {
+			return false
+		}
+		allowed, accessErr := tenantCanAccess(ctx, c)
+		err = accessErr
+		return allowed
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// ownedByUser matches collections the user personally owns, excluding
+// group-owned ones that still carry their creator's user ID.
+func ownedByUser(userID entities.UserID) func(*entities.Collection) bool {
+	return func(c *entities.Collection) bool {
+		return c.UserID().Equals(userID) && !c.IsGroupOwned()
+	}
+}
+
+func (r *MemoryCollectionRepository) GetByUserID(ctx context.Context, userID entities.UserID) ([]*entities.Collection, error) {
+	return r.tenantFilter(ctx, true, ownedByUser(userID))
+}
+
+func (r *MemoryCollectionRepository) GetByUserIDSummary(ctx context.Context, userID entities.UserID) ([]*entities.Collection, error) {
+	return r.tenantFilter(ctx, false, ownedByUser(userID))
+}
+
+func (r *MemoryCollectionRepository) GetGroupOwnedSummary(ctx context.Context, groupIDs []entities.GroupID) ([]*entities.Collection, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	return r.tenantFilter(ctx, false, func(c *entities.Collection) bool {
+		return c.IsGroupOwned() && c.GroupID() != nil && slices.ContainsFunc(groupIDs, c.GroupID().Equals)
+	})
+}
+
+func (r *MemoryCollectionRepository) GetByGroupID(ctx context.Context, groupID entities.GroupID) ([]*entities.Collection, error) {
+	return r.tenantFilter(ctx, true, func(c *entities.Collection) bool {
+		return c.GroupID() != nil && c.GroupID().Equals(groupID)
+	})
+}
+
+func (r *MemoryCollectionRepository) List(ctx context.Context, limit, offset int) ([]*entities.Collection, error) {
+	collections, err := r.tenantFilter(ctx, true, func(*entities.Collection) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	return paginate(collections, limit, offset), nil
+}
+
+func (r *MemoryCollectionRepository) Exists(ctx context.Context, id entities.CollectionID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, err := r.visible(ctx, id)
+	return err == nil, nil
+}
+
+// MemoryMealPlanRepository is an in-memory repositories.MealPlanRepository.
+type MemoryMealPlanRepository struct {
+	mu    sync.RWMutex
+	plans map[entities.MealPlanID]*entities.MealPlan
+}
+
+func NewMemoryMealPlanRepository() *MemoryMealPlanRepository {
+	return &MemoryMealPlanRepository{plans: make(map[entities.MealPlanID]*entities.MealPlan)}
+}
+
+func (r *MemoryMealPlanRepository) Create(_ context.Context, plan *entities.MealPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.plans[plan.ID()] = plan
+	return nil
+}
+
+func (r *MemoryMealPlanRepository) GetByID(_ context.Context, id entities.MealPlanID) (*entities.MealPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	plan, ok := r.plans[id]
+	if !ok {
+		return nil, entities.ErrMealPlanNotFound
+	}
+	return plan, nil
+}
+
+func (r *MemoryMealPlanRepository) ListByUserID(_ context.Context, userID entities.UserID, from, to time.Time) ([]*entities.MealPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var plans []*entities.MealPlan
+	for _, plan := range r.plans {
+		if plan.UserID().Equals(userID) && !plan.Date().Before(from) && plan.Date().Before(to) {
+			plans = append(plans, plan)
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		if !plans[i].Date().Equal(plans[j].Date()) {
+			return plans[i].Date().Before(plans[j].Date())
+		}
+		return plans[i].CreatedAt().Before(plans[j].CreatedAt())
+	})
+	return plans, nil
+}
+
+func (r *MemoryMealPlanRepository) Update(_ context.Context, plan *entities.MealPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.plans[plan.ID()]; !ok {
+		return entities.ErrMealPlanNotFound
+	}
+	r.plans[plan.ID()] = plan
+	return nil
+}
+
+func (r *MemoryMealPlanRepository) Delete(_ context.Context, id entities.MealPlanID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.plans[id]; !ok {
+		return entities.ErrMealPlanNotFound
+	}
+	delete(r.plans, id)
+	return nil
+}
+
+func (r *MemoryMealPlanRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, plan := range r.plans {
+		if plan.UserID().Equals(userID) {
+			delete(r.plans, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryRecurrenceRuleRepository is an in-memory repositories.RecurrenceRuleRepository.
+type MemoryRecurrenceRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[entities.RecurrenceRuleID]*entities.RecurrenceRule
+}
+
+func NewMemoryRecurrenceRuleRepository() *MemoryRecurrenceRuleRepository {
+	return &MemoryRecurrenceRuleRepository{rules: make(map[entities.RecurrenceRuleID]*entities.RecurrenceRule)}
+}
+
+func (r *MemoryRecurrenceRuleRepository) Create(_ context.Context, rule *entities.RecurrenceRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[rule.ID()] = rule
+	return nil
+}
+
+func (r *MemoryRecurrenceRuleRepository) GetByID(_ context.Context, id entities.RecurrenceRuleID) (*entities.RecurrenceRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, entities.ErrRecurrenceRuleNotFound
+	}
+	return rule, nil
+}
+
+func (r *MemoryRecurrenceRuleRepository) GetByObjectID(_ context.Context, userID entities.UserID, objectID entities.ObjectID) (*entities.RecurrenceRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, rule := range r.rules {
+		if rule.UserID().Equals(userID) && rule.ObjectID().Equals(objectID) {
+			return rule, nil
+		}
+	}
+	return nil, entities.ErrRecurrenceRuleNotFound
+}
+
+func (r *MemoryRecurrenceRuleRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.RecurrenceRule, error) {
+	return r.list(func(rule *entities.RecurrenceRule) bool { return rule.UserID().Equals(userID) }), nil
+}
+
+func (r *MemoryRecurrenceRuleRepository) ListDue(_ context.Context, t time.Time) ([]*entities.RecurrenceRule, error) {
+	return r.list(func(rule *entities.RecurrenceRule) bool { return !rule.NextDueAt().After(t) }), nil
+}
+
+func (r *MemoryRecurrenceRuleRepository) list(keep func(*entities.RecurrenceRule) bool) []*entities.RecurrenceRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var rules []*entities.RecurrenceRule
+	for _, rule := range r.rules {
+		if keep(rule) {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if !rules[i].NextDueAt().Equal(rules[j].NextDueAt()) {
+			return rules[i].NextDueAt().Before(rules[j].NextDueAt())
+		}
+		return rules[i].CreatedAt().Before(rules[j].CreatedAt())
+	})
+	return rules
+}
+
+func (r *MemoryRecurrenceRuleRepository) Update(_ context.Context, rule *entities.RecurrenceRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[rule.ID()]; !ok {
+		return entities.ErrRecurrenceRuleNotFound
+	}
+	r.rules[rule.ID()] = rule
+	return nil
+}
+
+func (r *MemoryRecurrenceRuleRepository) Delete(_ context.Context, id entities.RecurrenceRuleID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[id]; !ok {
+		return entities.ErrRecurrenceRuleNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func (r *MemoryRecurrenceRuleRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, rule := range r.rules {
+		if rule.UserID().Equals(userID) {
+			delete(r.rules, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryAutomationRuleRepository is an in-memory
+// repositories.AutomationRuleRepository.
+type MemoryAutomationRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[entities.AutomationRuleID]*entities.AutomationRule
+}
+
+func NewMemoryAutomationRuleRepository() *MemoryAutomationRuleRepository {
+	return &MemoryAutomationRuleRepository{rules: make(map[entities.AutomationRuleID]*entities.AutomationRule)}
+}
+
+func (r *MemoryAutomationRuleRepository) Create(_ context.Context, rule *entities.AutomationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[rule.ID()] = rule
+	return nil
+}
+
+func (r *MemoryAutomationRuleRepository) GetByID(_ context.Context, id entities.AutomationRuleID) (*entities.AutomationRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, entities.ErrAutomationRuleNotFound
+	}
+	return rule, nil
+}
+
+func (r *MemoryAutomationRuleRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.AutomationRule, error) {
+	return r.list(func(rule *entities.AutomationRule) bool { return rule.UserID().Equals(userID) }), nil
+}
+
+func (r *MemoryAutomationRuleRepository) ListEnabledByTrigger(_ context.Context, kind entities.AutomationTriggerKind) ([]*entities.AutomationRule, error) {
+	return r.list(func(rule *entities.AutomationRule) bool { return rule.Enabled() && rule.Trigger().Kind == kind }), nil
+}
+
+func (r *MemoryAutomationRuleRepository) list(keep func(*entities.AutomationRule) bool) []*entities.AutomationRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var rules []*entities.AutomationRule
+	for _, rule := range r.rules {
+		if keep(rule) {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].CreatedAt().Before(rules[j].CreatedAt()) })
+	return rules
+}
+
+func (r *MemoryAutomationRuleRepository) Update(_ context.Context, rule *entities.AutomationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rules[rule.ID()]
+	if !ok {
+		return entities.ErrAutomationRuleNotFound
+	}
+	// Keep the stored last run, as the Mongo repository does
+	r.rules[rule.ID()] = entities.ReconstructAutomationRule(rule.ID(), rule.UserID(), entities.AutomationRuleProps{
+		Name:    rule.Name(),
+		Enabled: rule.Enabled(),
+		Trigger: rule.Trigger(),
+		Actions: rule.Actions(),
+	}, stored.LastRunAt(), rule.CreatedAt(), rule.UpdatedAt())
+	return nil
+}
+
+func (r *MemoryAutomationRuleRepository) RecordRun(_ context.Context, id entities.AutomationRuleID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, ok := r.rules[id]
+	if !ok {
+		return entities.ErrAutomationRuleNotFound
+	}
+	r.rules[id] = entities.ReconstructAutomationRule(id, rule.UserID(), entities.AutomationRuleProps{
+		Name:    rule.Name(),
+		Enabled: rule.Enabled(),
+		Trigger: rule.Trigger(),
+		Actions: rule.Actions(),
+	}, &at, rule.CreatedAt(), rule.UpdatedAt())
+	return nil
+}
+
+func (r *MemoryAutomationRuleRepository) Delete(_ context.Context, id entities.AutomationRuleID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[id]; !ok {
+		return entities.ErrAutomationRuleNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func (r *MemoryAutomationRuleRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, rule := range r.rules {
+		if rule.UserID().Equals(userID) {
+			delete(r.rules, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryWebhookSigningKeyRepository is an in-memory
+// repositories.WebhookSigningKeyRepository.
+type MemoryWebhookSigningKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[entities.UserID]*entities.WebhookSigningKeys
+}
+
+func NewMemoryWebhookSigningKeyRepository() *MemoryWebhookSigningKeyRepository {
+	return &MemoryWebhookSigningKeyRepository{keys: make(map[entities.UserID]*entities.WebhookSigningKeys)}
+}
+
+func (r *MemoryWebhookSigningKeyRepository) GetByUserID(_ context.Context, userID entities.UserID) (*entities.WebhookSigningKeys, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys, ok := r.keys[userID]
+	if !ok {
+		return nil, entities.ErrWebhookSigningKeyNotFound
+	}
+	return keys, nil
+}
+
+func (r *MemoryWebhookSigningKeyRepository) Save(_ context.Context, keys *entities.WebhookSigningKeys) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[keys.UserID()] = keys
+	return nil
+}
+
+func (r *MemoryWebhookSigningKeyRepository) DeleteByUserID(_ context.Context, userID entities.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, userID)
+	return nil
+}
+
+// MemoryWebhookDeliveryRepository is an in-memory
+// repositories.WebhookDeliveryRepository.
+type MemoryWebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[entities.WebhookDeliveryID]*entities.WebhookDelivery
+}
+
+func NewMemoryWebhookDeliveryRepository() *MemoryWebhookDeliveryRepository {
+	return &MemoryWebhookDeliveryRepository{deliveries: make(map[entities.WebhookDeliveryID]*entities.WebhookDelivery)}
+}
+
+func (r *MemoryWebhookDeliveryRepository) Create(_ context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[delivery.ID()] = delivery
+	return nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) GetByID(_ context.Context, id entities.WebhookDeliveryID) (*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, entities.ErrWebhookDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) ListByUserID(_ context.Context, userID entities.UserID, limit int) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var deliveries []*entities.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.IsOwnedBy(userID) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt().After(deliveries[j].CreatedAt()) })
+	return deliveries[:min(limit, len(deliveries))], nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) ListDue(_ context.Context, t time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var deliveries []*entities.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status() == entities.WebhookPending && d.NextAttemptAt() != nil && !d.NextAttemptAt().After(t) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].NextAttemptAt().Before(*deliveries[j].NextAttemptAt()) })
+	return deliveries[:min(limit, len(deliveries))], nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) Update(_ context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deliveries[delivery.ID()]; !ok {
+		return entities.ErrWebhookDeliveryNotFound
+	}
+	r.deliveries[delivery.ID()] = delivery
+	return nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) DeleteCreatedBefore(_ context.Context, t time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, d := range r.deliveries {
+		if d.CreatedAt().Before(t) && d.Status() != entities.WebhookPending {
+			delete(r.deliveries, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, d := range r.deliveries {
+		if d.IsOwnedBy(userID) {
+			delete(r.deliveries, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryCollectionFolderRepository is an in-memory
+// repositories.CollectionFolderRepository.
+type MemoryCollectionFolderRepository struct {
+	mu      sync.RWMutex
+	folders map[entities.CollectionFolderID]*entities.CollectionFolder
+}
+
+func NewMemoryCollectionFolderRepository() *MemoryCollectionFolderRepository {
+	return &MemoryCollectionFolderRepository{folders: make(map[entities.CollectionFolderID]*entities.CollectionFolder)}
+}
+
+func (r *MemoryCollectionFolderRepository) Create(_ context.Context, folder *entities.CollectionFolder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.folders[folder.ID()] = folder
+	return nil
+}
+
+func (r *MemoryCollectionFolderRepository) GetByID(_ context.Context, id entities.CollectionFolderID) (*entities.CollectionFolder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	folder, ok := r.folders[id]
+	if !ok {
+		return nil, entities.ErrCollectionFolderNotFound
+	}
+	return folder, nil
+}
+
+func (r *MemoryCollectionFolderRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.CollectionFolder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var folders []*entities.CollectionFolder
+	for _, folder := range r.folders {
+		if folder.UserID().Equals(userID) {
+			folders = append(folders, folder)
+		}
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		if folders[i].Name() != folders[j].Name() {
+			return folders[i].Name() < folders[j].Name()
+		}
+		return folders[i].CreatedAt().Before(folders[j].CreatedAt())
+	})
+	return folders, nil
+}
+
+func (r *MemoryCollectionFolderRepository) Update(_ context.Context, folder *entities.CollectionFolder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.folders[folder.ID()]; !ok {
+		return entities.ErrCollectionFolderNotFound
+	}
+	r.folders[folder.ID()] = folder
+	return nil
+}
+
+func (r *MemoryCollectionFolderRepository) Delete(_ context.Context, id entities.CollectionFolderID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.folders[id]; !ok {
+		return entities.ErrCollectionFolderNotFound
+	}
+	delete(r.folders, id)
+	return nil
+}
+
+func (r *MemoryCollectionFolderRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, folder := range r.folders {
+		if folder.UserID().Equals(userID) {
+			delete(r.folders, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryCustomObjectTypeRepository is an in-memory
+// repositories.CustomObjectTypeRepository.
+type MemoryCustomObjectTypeRepository struct {
+	mu    sync.RWMutex
+	types map[entities.CustomObjectTypeID]*entities.CustomObjectType
+}
+
+func NewMemoryCustomObjectTypeRepository() *MemoryCustomObjectTypeRepository {
+	return &MemoryCustomObjectTypeRepository{types: make(map[entities.CustomObjectTypeID]*entities.CustomObjectType)}
+}
+
+func (r *MemoryCustomObjectTypeRepository) Create(_ context.Context, objectType *entities.CustomObjectType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[objectType.ID()] = objectType
+	return nil
+}
+
+func (r *MemoryCustomObjectTypeRepository) GetByID(_ context.Context, id entities.CustomObjectTypeID) (*entities.CustomObjectType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	objectType, ok := r.types[id]
+	if !ok {
+		return nil, entities.ErrCustomObjectTypeNotFound
+	}
+	return objectType, nil
+}
+
+func (r *MemoryCustomObjectTypeRepository) GetByKey(_ context.Context, userID entities.UserID, key entities.ObjectType) (*entities.CustomObjectType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, objectType := range r.types {
+		if objectType.UserID().Equals(userID) && objectType.Key() == key {
+			return objectType, nil
+		}
+	}
+	return nil, entities.ErrCustomObjectTypeNotFound
+}
+
+func (r *MemoryCustomObjectTypeRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.CustomObjectType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var types []*entities.CustomObjectType
+	for _, objectType := range r.types {
+		if objectType.UserID().Equals(userID) {
+			types = append(types, objectType)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Name() != types[j].Name() {
+			return types[i].Name() < types[j].Name()
+		}
+		return types[i].CreatedAt().Before(types[j].CreatedAt())
+	})
+	return types, nil
+}
+
+func (r *MemoryCustomObjectTypeRepository) Update(_ context.Context, objectType *entities.CustomObjectType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[objectType.ID()]; !ok {
+		return entities.ErrCustomObjectTypeNotFound
+	}
+	r.types[objectType.ID()] = objectType
+	return nil
+}
+
+func (r *MemoryCustomObjectTypeRepository) Delete(_ context.Context, id entities.CustomObjectTypeID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[id]; !ok {
+		return entities.ErrCustomObjectTypeNotFound
+	}
+	delete(r.types, id)
+	return nil
+}
+
+func (r *MemoryCustomObjectTypeRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, objectType := range r.types {
+		if objectType.UserID().Equals(userID) {
+			delete(r.types, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryObjectCodeRepository is an in-memory repositories.ObjectCodeRepository.
+type MemoryObjectCodeRepository struct {
+	mu    sync.RWMutex
+	codes map[entities.UserID]map[string][]entities.ObjectID
+}
+
+func NewMemoryObjectCodeRepository() *MemoryObjectCodeRepository {
+	return &MemoryObjectCodeRepository{codes: make(map[entities.UserID]map[string][]entities.ObjectID)}
+}
+
+func (r *MemoryObjectCodeRepository) Add(_ context.Context, userID entities.UserID, code string, objectID entities.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.codes[userID] == nil {
+		r.codes[userID] = make(map[string][]entities.ObjectID)
+	}
+	if !slices.Contains(r.codes[userID][code], objectID) {
+		r.codes[userID][code] = append(r.codes[userID][code], objectID)
+	}
+	return nil
+}
+
+func (r *MemoryObjectCodeRepository) Remove(_ context.Context, userID entities.UserID, code string, objectID entities.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := slices.DeleteFunc(r.codes[userID][code], func(id entities.ObjectID) bool { return id == objectID })
+	if len(ids) == 0 {
+		delete(r.codes[userID], code)
+	} else {
+		r.codes[userID][code] = ids
+	}
+	return nil
+}
+
+func (r *MemoryObjectCodeRepository) Lookup(_ context.Context, userID entities.UserID, code string) ([]entities.ObjectID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.codes[userID][code]), nil
+}
+
+func (r *MemoryObjectCodeRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deleted := int64(len(r.codes[userID]))
+	delete(r.codes, userID)
+	return deleted, nil
+}
+
+// MemoryExpiryHistoryRepository is an in-memory repositories.ExpiryHistoryRepository.
+type MemoryExpiryHistoryRepository struct {
+	mu   sync.RWMutex
+	days map[entities.UserID]map[string][]int
+}
+
+func NewMemoryExpiryHistoryRepository() *MemoryExpiryHistoryRepository {
+	return &MemoryExpiryHistoryRepository{days: make(map[entities.UserID]map[string][]int)}
+}
+
+func (r *MemoryExpiryHistoryRepository) AddSample(_ context.Context, userID entities.UserID, category string, days int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.days[userID] == nil {
+		r.days[userID] = make(map[string][]int)
+	}
+	samples := append(r.days[userID][category], days)
+	if len(samples) > entities.MaxExpirySamples {
+		samples = samples[len(samples)-entities.MaxExpirySamples:]
+	}
+	r.days[userID][category] = samples
+	return nil
+}
+
+func (r *MemoryExpiryHistoryRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.ExpiryHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var histories []*entities.ExpiryHistory
+	for category, days := range r.days[userID] {
+		histories = append(histories, entities.ReconstructExpiryHistory(userID, category, slices.Clone(days), time.Now()))
+	}
+	slices.SortFunc(histories, func(a, b *entities.ExpiryHistory) int {
+		return strings.Compare(a.Category(), b.Category())
+	})
+	return histories, nil
+}
+
+func (r *MemoryExpiryHistoryRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deleted := int64(len(r.days[userID]))
+	delete(r.days, userID)
+	return deleted, nil
+}
+
+// MemoryObjectMoveRepository is an in-memory repositories.ObjectMoveRepository.
+type MemoryObjectMoveRepository struct {
+	mu    sync.RWMutex
+	moves []*entities.ObjectMove
+}
+
+func NewMemoryObjectMoveRepository() *MemoryObjectMoveRepository {
+	return &MemoryObjectMoveRepository{}
+}
+
+func (r *MemoryObjectMoveRepository) Create(_ context.Context, move *entities.ObjectMove) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.moves = append(r.moves, move)
+	return nil
+}
+
+func (r *MemoryObjectMoveRepository) ListByObjectID(ctx context.Context, objectID entities.ObjectID) ([]*entities.ObjectMove, error) {
+	return r.ListByObjectIDs(ctx, []entities.ObjectID{objectID})
+}
+
+func (r *MemoryObjectMoveRepository) ListByObjectIDs(_ context.Context, objectIDs []entities.ObjectID) ([]*entities.ObjectMove, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var moves []*entities.ObjectMove
+	for _, move := range r.moves {
+		if slices.Contains(objectIDs, move.ObjectID()) {
+			moves = append(moves, move)
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].MovedAt().Before(moves[j].MovedAt()) })
+	return moves, nil
+}
+
+func (r *MemoryObjectMoveRepository) DeleteByObjectIDs(_ context.Context, objectIDs []entities.ObjectID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before := len(r.moves)
+	r.moves = slices.DeleteFunc(r.moves, func(move *entities.ObjectMove) bool {
+		return slices.Contains(objectIDs, move.ObjectID())
+	})
+	return int64(before - len(r.moves)), nil
+}
+
+// MemoryQuantityAdjustmentRepository is an in-memory repositories.QuantityAdjustmentRepository.
+type MemoryQuantityAdjustmentRepository struct {
+	mu          sync.RWMutex
+	adjustments []*entities.QuantityAdjustment
+}
+
+func NewMemoryQuantityAdjustmentRepository() *MemoryQuantityAdjustmentRepository {
+	return &MemoryQuantityAdjustmentRepository{}
+}
+
+func (r *MemoryQuantityAdjustmentRepository) Create(_ context.Context, adjustment *entities.QuantityAdjustment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.adjustments = append(r.adjustments, adjustment)
+	return nil
+}
+
+func (r *MemoryQuantityAdjustmentRepository) ListByCollectionIDs(_ context.Context, collectionIDs []entities.CollectionID, from, to time.Time) ([]*entities.QuantityAdjustment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var adjustments []*entities.QuantityAdjustment
+	for _, a := range r.adjustments {
+		if slices.Contains(collectionIDs, a.CollectionID()) && !a.AdjustedAt().Before(from) && a.AdjustedAt().Before(to) {
+			adjustments = append(adjustments, a)
+		}
+	}
+	sort.SliceStable(adjustments, func(i, j int) bool { return adjustments[i].AdjustedAt().Before(adjustments[j].AdjustedAt()) })
+	return adjustments, nil
+}
+
+func (r *MemoryQuantityAdjustmentRepository) DeleteByObjectIDs(_ context.Context, objectIDs []entities.ObjectID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before := len(r.adjustments)
+	r.adjustments = slices.DeleteFunc(r.adjustments, func(a *entities.QuantityAdjustment) bool {
+		return slices.Contains(objectIDs, a.ObjectID())
+	})
+	return int64(before - len(r.adjustments)), nil
+}
+
+// MemorySnapshotRepository is an in-memory repositories.CollectionSnapshotRepository.
+type MemorySnapshotRepository struct {
+	mu        sync.RWMutex
+	snapshots map[entities.CollectionSnapshotID]*entities.CollectionSnapshot
+}
+
+func NewMemorySnapshotRepository() *MemorySnapshotRepository {
+	return &MemorySnapshotRepository{snapshots: make(map[entities.CollectionSnapshotID]*entities.CollectionSnapshot)}
+}
+
+func (r *MemorySnapshotRepository) Create(_ context.Context, snapshot *entities.CollectionSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshots[snapshot.ID()] = snapshot
+	return nil
+}
+
+func (r *MemorySnapshotRepository) GetByID(_ context.Context, id entities.CollectionSnapshotID) (*entities.CollectionSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshot, ok := r.snapshots[id]
+	if !ok {
+		return nil, entities.ErrCollectionSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+func (r *MemorySnapshotRepository) ListByCollectionID(_ context.Context, collectionID entities.CollectionID) ([]*entities.CollectionSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var snapshots []*entities.CollectionSnapshot
+	for _, snapshot := range r.snapshots {
+		if snapshot.CollectionID() == collectionID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].CreatedAt().After(snapshots[j].CreatedAt()) })
+	return snapshots, nil
+}
+
+func (r *MemorySnapshotRepository) Delete(_ context.Context, id entities.CollectionSnapshotID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.snapshots[id]; !ok {
+		return entities.ErrCollectionSnapshotNotFound
+	}
+	delete(r.snapshots, id)
+	return nil
+}
+
+func (r *MemorySnapshotRepository) DeleteByCollectionID(_ context.Context, collectionID entities.CollectionID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, snapshot := range r.snapshots {
+		if snapshot.CollectionID() == collectionID {
+			delete(r.snapshots, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryMediaRepository is an in-memory repositories.MediaRepository.
+type MemoryMediaRepository struct {
+	mu    sync.RWMutex
+	media map[entities.MediaID]*entities.Media
+}
+
+func NewMemoryMediaRepository() *MemoryMediaRepository {
+	return &MemoryMediaRepository{media: make(map[entities.MediaID]*entities.Media)}
+}
+
+func (r *MemoryMediaRepository) Create(_ context.Context, media *entities.Media) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.media[media.ID()] = media
+	return nil
+}
+
+func (r *MemoryMediaRepository) GetByID(_ context.Context, id entities.MediaID) (*entities.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	media, ok := r.media[id]
+	if !ok {
+		return nil, entities.ErrMediaNotFound
+	}
+	return media, nil
+}
+
+func (r *MemoryMediaRepository) ListByOwner(_ context.Context, kind entities.MediaOwnerKind, ownerID string, page entities.MediaPage) ([]*entities.Media, int64, error) {
+	return r.list(func(m *entities.Media) bool { return m.OwnerKind() == kind && m.OwnerID() == ownerID }, page)
+}
+
+func (r *MemoryMediaRepository) ListByCollectionID(_ context.Context, collectionID entities.CollectionID, page entities.MediaPage) ([]*entities.Media, int64, error) {
+	return r.list(func(m *entities.Media) bool { return m.CollectionID() == collectionID }, page)
+}
+
+func (r *MemoryMediaRepository) CountByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string) (int64, error) {
+	_, total, err := r.ListByOwner(ctx, kind, ownerID, entities.MediaPage{})
+	return total, err
+}
+
+func (r *MemoryMediaRepository) Delete(_ context.Context, id entities.MediaID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.media[id]; !ok {
+		return entities.ErrMediaNotFound
+	}
+	delete(r.media, id)
+	return nil
+}
+
+func (r *MemoryMediaRepository) DeleteByOwner(_ context.Context, kind entities.MediaOwnerKind, ownerID string) (int64, error) {
+	return r.deleteWhere(func(m *entities.Media) bool { return m.OwnerKind() == kind && m.OwnerID() == ownerID }), nil
+}
+
+func (r *MemoryMediaRepository) DeleteByCollectionID(_ context.Context, collectionID entities.CollectionID) (int64, error) {
+	return r.deleteWhere(func(m *entities.Media) bool { return m.CollectionID() == collectionID }), nil
+}
+
+func (r *MemoryMediaRepository) list(match func(*entities.Media) bool, page entities.MediaPage) ([]*entities.Media, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var media []*entities.Media
+	for _, m := range r.media {
+		if match(m) {
+			media = append(media, m)
+		}
+	}
+	sort.Slice(media, func(i, j int) bool { return media[i].CreatedAt().After(media[j].CreatedAt()) })
+	return paginate(media, page.Limit, page.Offset), int64(len(media)), nil
+}
+
+func (r *MemoryMediaRepository) deleteWhere(match func(*entities.Media) bool) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, m := range r.media {
+		if match(m) {
+			delete(r.media, id)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// MemoryCommentRepository is an in-memory repositories.CommentRepository.
+type MemoryCommentRepository struct {
+	mu       sync.RWMutex
+	comments map[entities.CommentID]*entities.Comment
+	reads    map[commentReadKey]time.Time
+}
+
+type commentReadKey struct {
+	userID       entities.UserID
+	collectionID entities.CollectionID
+}
+
+func NewMemoryCommentRepository() *MemoryCommentRepository {
+	return &MemoryCommentRepository{
+		comments: make(map[entities.CommentID]*entities.Comment),
+		reads:    make(map[commentReadKey]time.Time),
+	}
+}
+
+func (r *MemoryCommentRepository) Create(_ context.Context, comment *entities.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.comments[comment.ID()] = comment
+	return nil
+}
+
+func (r *MemoryCommentRepository) GetByID(_ context.Context, id entities.CommentID) (*entities.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, entities.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func (r *MemoryCommentRepository) List(_ context.Context, collectionID entities.CollectionID, objectID *entities.ObjectID, before time.Time, limit int) ([]*entities.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var comments []*entities.Comment
+	for _, comment := range r.comments {
+		if comment.CollectionID() != collectionID {
+			continue
+		}
+		if objectID != nil && (comment.ObjectID() == nil || !comment.ObjectID().Equals(*objectID)) {
+			continue
+		}
+		if !before.IsZero() && !comment.CreatedAt().Before(before) {
+			continue
+		}
+		comments = append(comments, comment)
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].CreatedAt().After(comments[j].CreatedAt()) })
+	return paginate(comments, limit, 0), nil
+}
+
+func (r *MemoryCommentRepository) Delete(_ context.Context, id entities.CommentID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.comments[id]; !ok {
+		return entities.ErrCommentNotFound
+	}
+	delete(r.comments, id)
+	return nil
+}
+
+func (r *MemoryCommentRepository) DeleteByCollectionID(_ context.Context, collectionID entities.CollectionID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.reads {
+		if key.collectionID == collectionID {
+			delete(r.reads, key)
+		}
+	}
+	return r.deleteWhere(func(c *entities.Comment) bool { return c.CollectionID() == collectionID }), nil
+}
+
+func (r *MemoryCommentRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.reads {
+		if key.userID == userID {
+			delete(r.reads, key)
+		}
+	}
+	return r.deleteWhere(func(c *entities.Comment) bool { return c.IsAuthoredBy(userID) }), nil
+}
+
+func (r *MemoryCommentRepository) MarkRead(_ context.Context, userID entities.UserID, collectionID entities.CollectionID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := commentReadKey{userID: userID, collectionID: collectionID}
+	if at.After(r.reads[key]) {
+		r.reads[key] = at
+	}
+	return nil
+}
+
+func (r *MemoryCommentRepository) CountUnread(_ context.Context, userID entities.UserID, collectionIDs []entities.CollectionID) ([]entities.CommentUnread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var unread []entities.CommentUnread
+	for _, collectionID := range collectionIDs {
+		readAt := r.reads[commentReadKey{userID: userID, collectionID: collectionID}]
+		counts := entities.CommentUnread{CollectionID: collectionID}
+		for _, comment := range r.comments {
+			if comment.CollectionID() != collectionID || comment.IsAuthoredBy(userID) || !comment.CreatedAt().After(readAt) {
+				continue
+			}
+			counts.Count++
+			if comment.Mentioned(userID) {
+				counts.Mentions++
+			}
+		}
+		if counts.Count > 0 {
+			unread = append(unread, counts)
+		}
+	}
+	return unread, nil
+}
+
+// deleteWhere must be called with the lock held.
+func (r *MemoryCommentRepository) deleteWhere(match func(*entities.Comment) bool) int64 {
+	var deleted int64
+	for id, comment := range r.comments {
+		if match(comment) {
+			delete(r.comments, id)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// MemoryCategoryRepository is an in-memory repositories.CategoryRepository.
+type MemoryCategoryRepository struct {
+	mu         sync.RWMutex
+	categories map[entities.CategoryID]*entities.Category
+}
+
+func NewMemoryCategoryRepository() *MemoryCategoryRepository {
+	return &MemoryCategoryRepository{categories: make(map[entities.CategoryID]*entities.Category)}
+}
+
+func (r *MemoryCategoryRepository) Create(_ context.Context, category *entities.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.categories[category.ID()]; ok {
+		return errors.New("category already exists")
+	}
+	r.categories[category.ID()] = category
+	return nil
+}
+
+func (r *MemoryCategoryRepository) GetByID(_ context.Context, id entities.CategoryID) (*entities.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, errors.New("category not found")
+	}
+	return category, nil
+}
+
+func (r *MemoryCategoryRepository) GetByName(_ context.Context, name entities.CategoryName) (*entities.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, category := range r.categories {
+		if category.Name().String() == name.String() {
+			return category, nil
+		}
+	}
+	return nil, errors.New("category not found")
+}
+
+func (r *MemoryCategoryRepository) List(_ context.Context, limit, offset int) ([]*entities.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	categories := make([]*entities.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name().String() < categories[j].Name().String() })
+	return paginate(categories, limit, offset), nil
+}
+
+func (r *MemoryCategoryRepository) Update(_ context.Context, category *entities.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.categories[category.ID()]; !ok {
+		return errors.New("category not found")
+	}
+	r.categories[category.ID()] = category
+	return nil
+}
+
+func (r *MemoryCategoryRepository) Delete(_ context.Context, id entities.CategoryID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.categories[id]; !ok {
+		return errors.New("category not found")
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+func (r *MemoryCategoryRepository) Exists(_ context.Context, id entities.CategoryID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.categories[id]
+	return ok, nil
+}
+
+// MemoryContainerTemplateRepository is an in-memory repositories.ContainerTemplateRepository.
+type MemoryContainerTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[entities.ContainerTemplateID]*entities.ContainerTemplate
+}
+
+func NewMemoryContainerTemplateRepository() *MemoryContainerTemplateRepository {
+	return &MemoryContainerTemplateRepository{templates: make(map[entities.ContainerTemplateID]*entities.ContainerTemplate)}
+}
+
+func (r *MemoryContainerTemplateRepository) Create(_ context.Context, template *entities.ContainerTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[template.ID()] = template
+	return nil
+}
+
+func (r *MemoryContainerTemplateRepository) GetByID(_ context.Context, id entities.ContainerTemplateID) (*entities.ContainerTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	template, ok := r.templates[id]
+	if !ok {
+		return nil, entities.ErrContainerTemplateNotFound
+	}
+	return template, nil
+}
+
+func (r *MemoryContainerTemplateRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.ContainerTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var templates []*entities.ContainerTemplate
+	for _, template := range r.templates {
+		if owner := template.UserID(); owner != nil && *owner == userID {
+			templates = append(templates, template)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name() < templates[j].Name() })
+	return templates, nil
+}
+
+func (r *MemoryContainerTemplateRepository) Delete(_ context.Context, id entities.ContainerTemplateID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates[id]; !ok {
+		return entities.ErrContainerTemplateNotFound
+	}
+	delete(r.templates, id)
+	return nil
+}
+
+func (r *MemoryContainerTemplateRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, template := range r.templates {
+		if owner := template.UserID(); owner != nil && *owner == userID {
+			delete(r.templates, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryDigestSubscriptionRepository is an in-memory repositories.DigestSubscriptionRepository.
+type MemoryDigestSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[entities.UserID]*entities.DigestSubscription
+}
+
+func NewMemoryDigestSubscriptionRepository() *MemoryDigestSubscriptionRepository {
+	return &MemoryDigestSubscriptionRepository{subscriptions: make(map[entities.UserID]*entities.DigestSubscription)}
+}
+
+func (r *MemoryDigestSubscriptionRepository) GetByUserID(_ context.Context, userID entities.UserID) (*entities.DigestSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	subscription, ok := r.subscriptions[userID]
+	if !ok {
+		return nil, entities.ErrDigestSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (r *MemoryDigestSubscriptionRepository) GetByUnsubscribeToken(_ context.Context, token string) (*entities.DigestSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, subscription := range r.subscriptions {
+		if subscription.UnsubscribeToken() == token {
+			return subscription, nil
+		}
+	}
+	return nil, entities.ErrDigestSubscriptionNotFound
+}
+
+func (r *MemoryDigestSubscriptionRepository) Save(_ context.Context, subscription *entities.DigestSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[subscription.UserID()] = subscription
+	return nil
+}
+
+func (r *MemoryDigestSubscriptionRepository) ListActive(_ context.Context) ([]*entities.DigestSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var subscriptions []*entities.DigestSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.Frequency() != entities.DigestFrequencyOff {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *MemoryDigestSubscriptionRepository) DeleteByUserID(_ context.Context, userID entities.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, userID)
+	return nil
+}
+
+// MemoryUserPreferencesRepository is an in-memory repositories.UserPreferencesRepository.
+type MemoryUserPreferencesRepository struct {
+	mu          sync.RWMutex
+	preferences map[entities.UserID]*entities.UserPreferences
+}
+
+func NewMemoryUserPreferencesRepository() *MemoryUserPreferencesRepository {
+	return &MemoryUserPreferencesRepository{preferences: make(map[entities.UserID]*entities.UserPreferences)}
+}
+
+func (r *MemoryUserPreferencesRepository) GetByUserID(_ context.Context, userID entities.UserID) (*entities.UserPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	preferences, ok := r.preferences[userID]
+	if !ok {
+		return nil, entities.ErrUserPreferencesNotFound
+	}
+	return preferences, nil
+}
+
+func (r *MemoryUserPreferencesRepository) Save(_ context.Context, preferences *entities.UserPreferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.preferences[preferences.UserID()] = preferences
+	return nil
+}
+
+func (r *MemoryUserPreferencesRepository) DeleteByUserID(_ context.Context, userID entities.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.preferences, userID)
+	return nil
+}
+
+// MemoryAccountStatusRepository is an in-memory repositories.AccountStatusRepository.
+type MemoryAccountStatusRepository struct {
+	mu       sync.RWMutex
+	statuses map[entities.UserID]*entities.AccountStatus
+}
+
+func NewMemoryAccountStatusRepository() *MemoryAccountStatusRepository {
+	return &MemoryAccountStatusRepository{statuses: make(map[entities.UserID]*entities.AccountStatus)}
+}
+
+func (r *MemoryAccountStatusRepository) GetByUserID(_ context.Context, userID entities.UserID) (*entities.AccountStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status, ok := r.statuses[userID]
+	if !ok {
+		return nil, entities.ErrAccountStatusNotFound
+	}
+	return status, nil
+}
+
+func (r *MemoryAccountStatusRepository) Save(_ context.Context, status *entities.AccountStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses[status.UserID()] = status
+	return nil
+}
+
+func (r *MemoryAccountStatusRepository) List(_ context.Context) ([]*entities.AccountStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	statuses := make([]*entities.AccountStatus, 0, len(r.statuses))
+	for _, status := range r.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].LastSeenAt().After(statuses[j].LastSeenAt()) })
+	return statuses, nil
+}
+
+func (r *MemoryAccountStatusRepository) DeleteByUserID(_ context.Context, userID entities.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.statuses, userID)
+	return nil
+}
+
+// MemorySessionRepository is an in-memory repositories.SessionRepository.
+type MemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*entities.Session
+}
+
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{sessions: make(map[string]*entities.Session)}
+}
+
+func (r *MemorySessionRepository) GetByID(_ context.Context, id string) (*entities.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, entities.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (r *MemorySessionRepository) Save(_ context.Context, session *entities.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID()] = session
+	return nil
+}
+
+func (r *MemorySessionRepository) ListByUserID(_ context.Context, userID entities.UserID) ([]*entities.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var sessions []*entities.Session
+	for _, session := range r.sessions {
+		if session.UserID().Equals(userID) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastSeenAt().After(sessions[j].LastSeenAt()) })
+	return sessions, nil
+}
+
+func (r *MemorySessionRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, session := range r.sessions {
+		if session.UserID().Equals(userID) {
+			delete(r.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryGroupQuotaRepository is an in-memory repositories.GroupQuotaRepository.
+type MemoryGroupQuotaRepository struct {
+	mu     sync.RWMutex
+	quotas map[string]*entities.GroupQuota
+}
+
+func NewMemoryGroupQuotaRepository() *MemoryGroupQuotaRepository {
+	return &MemoryGroupQuotaRepository{quotas: make(map[string]*entities.GroupQuota)}
+}
+
+func (r *MemoryGroupQuotaRepository) GetByGroupID(_ context.Context, groupID entities.GroupID) (*entities.GroupQuota, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	quota, ok := r.quotas[groupID.String()]
+	if !ok {
+		return nil, entities.ErrGroupQuotaNotFound
+	}
+	return quota, nil
+}
+
+func (r *MemoryGroupQuotaRepository) Save(_ context.Context, quota *entities.GroupQuota) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quotas[quota.GroupID().String()] = quota
+	return nil
+}
+
+// MemoryOAuthClientRepository is an in-memory repositories.OAuthClientRepository.
+type MemoryOAuthClientRepository struct {
+	mu      sync.RWMutex
+	clients map[string]*entities.OAuthClient
+}
+
+func NewMemoryOAuthClientRepository() *MemoryOAuthClientRepository {
+	return &MemoryOAuthClientRepository{clients: make(map[string]*entities.OAuthClient)}
+}
+
+func (r *MemoryOAuthClientRepository) List(_ context.Context) ([]*entities.OAuthClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clients := make([]*entities.OAuthClient, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].CreatedAt().Before(clients[j].CreatedAt()) })
+	return clients, nil
+}
+
+func (r *MemoryOAuthClientRepository) GetByClientID(_ context.Context, clientID string) (*entities.OAuthClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	client, ok := r.clients[clientID]
+	if !ok {
+		return nil, entities.ErrOAuthClientNotFound
+	}
+	return client, nil
+}
+
+func (r *MemoryOAuthClientRepository) Save(_ context.Context, client *entities.OAuthClient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients[client.ClientID()] = client
+	return nil
+}
+
+func (r *MemoryOAuthClientRepository) Delete(_ context.Context, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.clients[clientID]; !ok {
+		return entities.ErrOAuthClientNotFound
+	}
+	delete(r.clients, clientID)
+	return nil
+}
+
+// MemoryFeatureFlagRepository is an in-memory repositories.FeatureFlagRepository.
+type MemoryFeatureFlagRepository struct {
+	mu    sync.RWMutex
+	flags map[string]*entities.FeatureFlag
+}
+
+func NewMemoryFeatureFlagRepository() *MemoryFeatureFlagRepository {
+	return &MemoryFeatureFlagRepository{flags: make(map[string]*entities.FeatureFlag)}
+}
+
+func (r *MemoryFeatureFlagRepository) List(_ context.Context) ([]*entities.FeatureFlag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	flags := make([]*entities.FeatureFlag, 0, len(r.flags))
+	for _, flag := range r.flags {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name() < flags[j].Name() })
+	return flags, nil
+}
+
+func (r *MemoryFeatureFlagRepository) Save(_ context.Context, flag *entities.FeatureFlag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flags[flag.Name()] = flag
+	return nil
+}
+
+func (r *MemoryFeatureFlagRepository) Delete(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.flags[name]; !ok {
+		return entities.ErrFeatureFlagNotFound
+	}
+	delete(r.flags, name)
+	return nil
+}
+
+// MemoryInboxRepository is an in-memory repositories.InboxRepository.
+type MemoryInboxRepository struct {
+	mu        sync.RWMutex
+	addresses map[string]*entities.InboxAddress
+	items     map[entities.InboxItemID]*entities.InboxItem
+}
+
+func NewMemoryInboxRepository() *MemoryInboxRepository {
+	return &MemoryInboxRepository{
+		addresses: make(map[string]*entities.InboxAddress),
+		items:     make(map[entities.InboxItemID]*entities.InboxItem),
+	}
+}
+
+func (r *MemoryInboxRepository) GetAddressByUserID(_ context.Context, userID entities.UserID) (*entities.InboxAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	address, ok := r.addresses[userID.String()]
+	if !ok {
+		return nil, entities.ErrInboxAddressNotFound
+	}
+	return address, nil
+}
+
+func (r *MemoryInboxRepository) GetAddressByToken(_ context.Context, token string) (*entities.InboxAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, address := range r.addresses {
+		if address.Token() == token {
+			return address, nil
+		}
+	}
+	return nil, entities.ErrInboxAddressNotFound
+}
+
+func (r *MemoryInboxRepository) SaveAddress(_ context.Context, address *entities.InboxAddress) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addresses[address.UserID().String()] = address
+	return nil
+}
+
+func (r *MemoryInboxRepository) CreateItem(_ context.Context, item *entities.InboxItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[item.ID()] = item
+	return nil
+}
+
+func (r *MemoryInboxRepository) GetItemByID(_ context.Context, id entities.InboxItemID) (*entities.InboxItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	item, ok := r.items[id]
+	if !ok {
+		return nil, entities.ErrInboxItemNotFound
+	}
+	return item, nil
+}
+
+func (r *MemoryInboxRepository) ListItemsByUserID(_ context.Context, userID entities.UserID) ([]*entities.InboxItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var items []*entities.InboxItem
+	for _, item := range r.items {
+		if item.IsOwnedBy(userID) {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ReceivedAt().After(items[j].ReceivedAt()) })
+	return items, nil
+}
+
+func (r *MemoryInboxRepository) CountItemsByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	items, _ := r.ListItemsByUserID(ctx, userID)
+	return int64(len(items)), nil
+}
+
+func (r *MemoryInboxRepository) UpdateItem(_ context.Context, item *entities.InboxItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[item.ID()]; !ok {
+		return entities.ErrInboxItemNotFound
+	}
+	r.items[item.ID()] = item
+	return nil
+}
+
+func (r *MemoryInboxRepository) DeleteItem(_ context.Context, id entities.InboxItemID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[id]; !ok {
+		return entities.ErrInboxItemNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *MemoryInboxRepository) DeleteByUserID(_ context.Context, userID entities.UserID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.addresses, userID.String())
+	var deleted int64
+	for id, item := range r.items {
+		if item.IsOwnedBy(userID) {
+			delete(r.items, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MemoryUsageRepository is an in-memory repositories.UsageRepository that
+// totals the other in-memory repositories on each call.
+type MemoryUsageRepository struct {
+	collections *MemoryCollectionRepository
+	media       *MemoryMediaRepository
+}
+
+func NewMemoryUsageRepository(collections *MemoryCollectionRepository, media *MemoryMediaRepository) *MemoryUsageRepository {
+	return &MemoryUsageRepository{collections: collections, media: media}
+}
+
+func (r *MemoryUsageRepository) UsageByUser(ctx context.Context) (map[string]entities.UserUsage, error) {
+	usage := make(map[string]entities.UserUsage)
+	entry := func(userID entities.UserID) entities.UserUsage {
+		u := usage[userID.String()]
+		u.UserID = userID
+		return u
+	}
+
+	for _, collection := range r.collections.filter(true, func(*entities.Collection) bool { return true }) {
+		u := entry(collection.UserID())
+		u.Collections++
+		for _, c := range collection.Containers() {
+			u.Containers++
+			u.Objects += len(c.Objects())
+		}
+		usage[collection.UserID().String()] = u
+	}
+
+	r.media.mu.RLock()
+	defer r.media.mu.RUnlock()
+	for _, m := range r.media.media {
+		u := entry(m.UploadedBy())
+		u.MediaCount++
+		u.MediaBytes += m.Size()
+		usage[m.UploadedBy().String()] = u
+	}
+
+	delete(usage, "")
+	return usage, nil
+}
+
+func (r *MemoryUsageRepository) UsageByGroup(ctx context.Context, groupID entities.GroupID) (entities.GroupUsage, error) {
+	var usage entities.GroupUsage
+	inGroup := make(map[entities.CollectionID]bool)
+	for _, collection := range r.collections.filter(true, func(c *entities.Collection) bool {
+		return c.GroupID() != nil && c.GroupID().Equals(groupID)
+	}) {
+		inGroup[collection.ID()] = true
+		for _, c := range collection.Containers() {
+			usage.Containers++
+			usage.Objects += len(c.Objects())
+		}
+	}
+
+	r.media.mu.RLock()
+	defer r.media.mu.RUnlock()
+	for _, m := range r.media.media {
+		if inGroup[m.CollectionID()] {
+			usage.StorageBytes += m.Size()
+		}
+	}
+
+	return usage, nil
+}
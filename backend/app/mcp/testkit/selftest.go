@@ -0,0 +1,378 @@
+package testkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/nishiki/backend/app/config"
+)
+
+// Check kinds reported by Run.
+const (
+	KindTool             = "tool"
+	KindResource         = "resource"
+	KindResourceTemplate = "resource_template"
+	KindPrompt           = "prompt"
+)
+
+// CheckResult is the outcome of exercising one tool, resource or prompt.
+type CheckResult struct {
+	Kind string
+	Name string
+	Err  error
+}
+
+// Report lists every check Run performed, in the order it ran them.
+type Report struct {
+	Results []CheckResult
+}
+
+// Failed returns the checks that did not pass.
+func (r *Report) Failed() []CheckResult {
+	var failed []CheckResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (r *Report) add(kind, name string, err error) {
+	r.Results = append(r.Results, CheckResult{Kind: kind, Name: name, Err: err})
+}
+
+// toolFixture describes a representative call for one tool. wantError marks
+// tools whose happy path is an error result, such as unimplemented stubs.
+type toolFixture struct {
+	args      func(Seed) map[string]any
+	wantError bool
+}
+
+// toolFixtures must have an entry for every registered tool: a tool without
+// one fails the self-test so new tools cannot ship untested.
+var toolFixtures = map[string]toolFixture{
+	"create_collection": {args: func(Seed) map[string]any {
+		return map[string]any{"name": "Garage", "object_type": "general"}
+	}},
+	"update_collection": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "name": "Kitchen pantry"}
+	}},
+	"delete_collection": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.EmptyCollection.ID().String()}
+	}},
+	"create_container": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "name": "Freezer", "container_type": "cabinet"}
+	}},
+	"update_container": {args: func(s Seed) map[string]any {
+		return map[string]any{"container_id": s.Pantry.ID().String(), "name": "Top shelf"}
+	}},
+	"delete_container": {args: func(s Seed) map[string]any {
+		return map[string]any{"container_id": s.Pantry.ID().String()}
+	}},
+	"create_object": {args: func(s Seed) map[string]any {
+		return map[string]any{"container_id": s.Pantry.ID().String(), "name": "Oats", "object_type": "food", "quantity": 1, "unit": "kg"}
+	}},
+	"delete_object": {args: func(s Seed) map[string]any {
+		return map[string]any{"container_id": s.Pantry.ID().String(), "object_id": s.Beans.ID().String()}
+	}},
+	"update_object": {args: func(s Seed) map[string]any {
+		return map[string]any{"object_id": s.Rice.ID().String(), "name": "Basmati rice", "tags": []string{"grain"}}
+	}},
+	"copy_object": {args: func(s Seed) map[string]any {
+		return map[string]any{"object_id": s.Rice.ID().String()}
+	}},
+	"adjust_quantity": {args: func(s Seed) map[string]any {
+		return map[string]any{"name": "rice", "delta": -0.5}
+	}},
+	"identify_item": {args: func(s Seed) map[string]any {
+		// A single white pixel
+		return map[string]any{
+			"container_id": s.Pantry.ID().String(),
+			"image":        "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAAAAAA6fptVAAAACklEQVR4nGP4DwABAQEAsTj2FAAAAABJRU5ErkJggg==",
+		}
+	}},
+	"create_group": {args: func(Seed) map[string]any {
+		return map[string]any{"name": "Book club"}
+	}},
+	"add_group_member": {args: func(s Seed) map[string]any {
+		return map[string]any{"group_id": s.Group.ID().String(), "user_id": s.OtherUser.ID().String()}
+	}},
+	"remove_group_member": {args: func(s Seed) map[string]any {
+		return map[string]any{"group_id": s.Group.ID().String(), "user_id": s.OtherUser.ID().String()}
+	}},
+	"join_group": {args: func(Seed) map[string]any {
+		return map[string]any{"invite_code": "ABC123"}
+	}, wantError: true},
+	"update_group": {args: func(s Seed) map[string]any {
+		return map[string]any{"group_id": s.Group.ID().String(), "name": "Family"}
+	}},
+	"delete_group": {args: func(s Seed) map[string]any {
+		return map[string]any{"group_id": s.Group.ID().String()}
+	}},
+	"bulk_import": {args: func(s Seed) map[string]any {
+		return map[string]any{
+			"collection_id":       s.Collection.ID().String(),
+			"distribution_mode":   "target",
+			"target_container_id": s.Pantry.ID().String(),
+			"data":                []map[string]any{{"name": "Lentils", "quantity": 2}},
+		}
+	}},
+	"smart_import": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "csv_data": "name,location\nFlour,Pantry\n"}
+	}},
+	"search_objects": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "query": "rice"}
+	}},
+	"lookup_code": {args: func(Seed) map[string]any {
+		return map[string]any{"code": "0123456789012"}
+	}},
+	"list_meal_plans": {args: func(Seed) map[string]any {
+		return map[string]any{}
+	}},
+	"create_meal_plan": {args: func(s Seed) map[string]any {
+		return map[string]any{
+			"date":        s.MealPlan.Date().Format("2006-01-02"),
+			"meal":        "lunch",
+			"recipe_name": "Bean salad",
+			"ingredients": []map[string]any{{"object_id": s.Beans.ID().String(), "quantity": 1}},
+		}
+	}},
+	"complete_meal_plan": {args: func(s Seed) map[string]any {
+		return map[string]any{"meal_plan_id": s.MealPlan.ID().String()}
+	}},
+	"list_recurrences": {args: func(Seed) map[string]any {
+		return map[string]any{"due_only": true}
+	}},
+	"export_collection": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "format": "json"}
+	}},
+	"valuation_report": {args: func(Seed) map[string]any {
+		return map[string]any{}
+	}},
+	"purchase_history": {args: func(Seed) map[string]any {
+		return map[string]any{"days": 90}
+	}},
+	"get_collection_schema": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String()}
+	}},
+	"update_collection_schema": {args: func(s Seed) map[string]any {
+		return map[string]any{
+			"collection_id": s.Collection.ID().String(),
+			"definitions":   []map[string]any{{"key": "brand", "display_name": "Brand", "type": "text"}},
+		}
+	}},
+	"list_snapshots": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String()}
+	}},
+	"create_snapshot": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "label": "Self-test"}
+	}},
+	"diff_snapshot": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String(), "snapshot_id": s.Snapshot.ID().String()}
+	}},
+	"nutrition_stats": {args: func(s Seed) map[string]any {
+		return map[string]any{"collection_id": s.Collection.ID().String()}
+	}},
+	"lookup_nutrition": {args: func(s Seed) map[string]any {
+		return map[string]any{"object_id": s.Rice.ID().String(), "upc": "0123456789012"}
+	}},
+}
+
+// promptArgValues supplies a value for every prompt argument name, keyed by
+// name so prompts sharing an argument share its sample value.
+var promptArgValues = map[string]func(Seed) string{
+	"receipt_text":  func(Seed) string { return "Milk 2L\nEggs x12\nBread" },
+	"query":         func(Seed) string { return "rice" },
+	"days":          func(Seed) string { return "7" },
+	"collection_id": func(s Seed) string { return s.Collection.ID().String() },
+	"preferences":   func(Seed) string { return "vegetarian" },
+	"description":   func(Seed) string { return "anything by a Japanese author" },
+}
+
+// Run starts a Kit and exercises every tool, resource, resource template and
+// prompt the MCP server registers. Each tool call runs against a freshly
+// seeded Kit so destructive tools cannot affect the others. The returned
+// error is only set when the Kit itself cannot be started; individual
+// failures are recorded in the Report.
+func Run(ctx context.Context, cfg *config.Config) (*Report, error) {
+	kit, err := New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer kit.Close()
+
+	report := &Report{}
+	if err := checkTools(ctx, kit, cfg, report); err != nil {
+		return nil, err
+	}
+	if err := checkResources(ctx, kit, report); err != nil {
+		return nil, err
+	}
+	if err := checkPrompts(ctx, kit, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func checkTools(ctx context.Context, kit *Kit, cfg *config.Config, report *Report) error {
+	list, err := kit.Session.ListTools(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list tools: %w", err)
+	}
+	sort.Slice(list.Tools, func(i, j int) bool { return list.Tools[i].Name < list.Tools[j].Name })
+
+	registered := make([]string, 0, len(list.Tools))
+	for _, tool := range list.Tools {
+		registered = append(registered, tool.Name)
+		report.add(KindTool, tool.Name, checkTool(ctx, cfg, tool))
+	}
+
+	stale := make([]string, 0)
+	for name := range toolFixtures {
+		if !slices.Contains(registered, name) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	for _, name := range stale {
+		report.add(KindTool, name, errors.New("fixture exists but the tool is not registered"))
+	}
+	return nil
+}
+
+func checkTool(ctx context.Context, cfg *config.Config, tool *mcp.Tool) error {
+	if strings.TrimSpace(tool.Description) == "" {
+		return errors.New("missing description")
+	}
+	schema, ok := tool.InputSchema.(map[string]any)
+	if !ok || schema["type"] != "object" {
+		return errors.New("input schema is not an object")
+	}
+	fixture, ok := toolFixtures[tool.Name]
+	if !ok {
+		return errors.New("no self-test fixture; add one to toolFixtures")
+	}
+
+	kit, err := New(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer kit.Close()
+
+	result, err := kit.Session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      tool.Name,
+		Arguments: fixture.args(kit.Seed),
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case result.IsError && !fixture.wantError:
+		return fmt.Errorf("unexpected error result: %s", resultText(result.Content))
+	case !result.IsError && fixture.wantError:
+		return errors.New("expected an error result")
+	case len(result.Content) == 0:
+		return errors.New("empty result")
+	}
+	return nil
+}
+
+func checkResources(ctx context.Context, kit *Kit, report *Report) error {
+	resources, err := kit.Session.ListResources(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list resources: %w", err)
+	}
+	for _, resource := range resources.Resources {
+		report.add(KindResource, resource.URI, readResource(ctx, kit, resource.URI))
+	}
+
+	templates, err := kit.Session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list resource templates: %w", err)
+	}
+	for _, template := range templates.ResourceTemplates {
+		uri, err := expandTemplate(template.URITemplate, kit.Seed)
+		if err == nil {
+			err = readResource(ctx, kit, uri)
+		}
+		report.add(KindResourceTemplate, template.URITemplate, err)
+	}
+	return nil
+}
+
+// expandTemplate fills the {id} placeholder with the seeded entity the
+// template's root segment refers to, and {days} with a fixed window.
+func expandTemplate(uriTemplate string, seed Seed) (string, error) {
+	ids := map[string]string{
+		"nishiki://groups/":      seed.Group.ID().String(),
+		"nishiki://collections/": seed.Collection.ID().String(),
+		"nishiki://containers/":  seed.Pantry.ID().String(),
+	}
+	for prefix, id := range ids {
+		if strings.HasPrefix(uriTemplate, prefix) {
+			return strings.Replace(uriTemplate, "{id}", id, 1), nil
+		}
+	}
+	if strings.HasPrefix(uriTemplate, "nishiki://expiring/") {
+		return strings.Replace(uriTemplate, "{days}", "30", 1), nil
+	}
+	return "", errors.New("no seeded ID for this template; add one to expandTemplate")
+}
+
+func readResource(ctx context.Context, kit *Kit, uri string) error {
+	result, err := kit.Session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		return err
+	}
+	if len(result.Contents) == 0 || result.Contents[0].Text == "" {
+		return errors.New("empty resource contents")
+	}
+	return nil
+}
+
+func checkPrompts(ctx context.Context, kit *Kit, report *Report) error {
+	prompts, err := kit.Session.ListPrompts(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+	for _, prompt := range prompts.Prompts {
+		report.add(KindPrompt, prompt.Name, getPrompt(ctx, kit, prompt))
+	}
+	return nil
+}
+
+func getPrompt(ctx context.Context, kit *Kit, prompt *mcp.Prompt) error {
+	args := make(map[string]string, len(prompt.Arguments))
+	for _, arg := range prompt.Arguments {
+		value, ok := promptArgValues[arg.Name]
+		if !ok {
+			return fmt.Errorf("no sample value for argument %q; add one to promptArgValues", arg.Name)
+		}
+		args[arg.Name] = value(kit.Seed)
+	}
+	result, err := kit.Session.GetPrompt(ctx, &mcp.GetPromptParams{Name: prompt.Name, Arguments: args})
+	if err != nil {
+		return err
+	}
+	if len(result.Messages) == 0 {
+		return errors.New("prompt returned no messages")
+	}
+	return nil
+}
+
+func resultText(content []mcp.Content) string {
+	var parts []string
+	for _, c := range content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
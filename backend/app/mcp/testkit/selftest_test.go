@@ -0,0 +1,27 @@
+package testkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_AllChecksPass(t *testing.T) {
+	t.Parallel()
+
+	report, err := Run(context.Background(), nil)
+	require.NoError(t, err)
+
+	for _, result := range report.Failed() {
+		t.Errorf("%s %s: %v", result.Kind, result.Name, result.Err)
+	}
+
+	kinds := make(map[string]int)
+	for _, result := range report.Results {
+		kinds[result.Kind]++
+	}
+	assert.Len(t, kinds, 4, "expected tools, resources, resource templates and prompts to be checked")
+	assert.Len(t, toolFixtures, kinds[KindTool], "every fixture should match a registered tool")
+}
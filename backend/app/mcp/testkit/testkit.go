@@ -0,0 +1,297 @@
+// Package testkit runs the MCP server against in-memory repositories and a
+// fake auth service, connected to an in-process MCP client. It backs the MCP
+// tests and the --mcp-selftest flag, so every tool, resource and prompt can be
+// exercised without MongoDB or Authentik.
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/container"
+	mcpserver "github.com/nishiki/backend/app/mcp"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	extServices "github.com/nishiki/backend/external/services"
+)
+
+// Seed holds the fixtures every Kit starts with. The collection, container
+// and objects are owned by User; OtherUser exists but is not in Group.
+type Seed struct {
+	User      *entities.User
+	Token     string
+	OtherUser *entities.User
+	Group     *entities.Group
+
+	Collection      *entities.Collection
+	Pantry          *entities.Container
+	Rice            entities.Object
+	Beans           entities.Object
+	EmptyCollection *entities.Collection
+
+	MealPlan   *entities.MealPlan
+	Recurrence *entities.RecurrenceRule
+	Snapshot   *entities.CollectionSnapshot
+}
+
+// Kit is a seeded MCP server with a connected client session. Every request
+// the session sends is handled as Seed.User.
+type Kit struct {
+	Container *container.Container
+	Auth      *FakeAuthService
+	Seed      Seed
+	Session   *mcp.ClientSession
+
+	serverSession *mcp.ServerSession
+}
+
+// New builds a Kit. A nil cfg uses the zero config, which disables snapshot
+// pruning and reserved import columns, with the default photo limits.
+func New(ctx context.Context, cfg *config.Config) (*Kit, error) {
+	if cfg == nil {
+		cfg = &config.Config{Media: config.MediaConfig{MaxUploadSize: 10 << 20, MaxPerOwner: 50}}
+	}
+
+	auth := NewFakeAuthService()
+	c := NewMemoryContainer(cfg, auth)
+	c.SetLogger(slog.New(slog.DiscardHandler))
+
+	seed, err := seedKit(ctx, c, auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed MCP test kit: %w", err)
+	}
+
+	server := mcpserver.NewMCPServer(&mcpserver.MCPContext{
+		Container: c,
+		Notifier:  mcpserver.NewMCPNotifier(),
+	})
+	server.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(mcpserver.WithMCPUser(ctx, seed.User, seed.Token), method, req)
+		}
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect MCP server: %w", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "nishiki-testkit", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		serverSession.Close()
+		return nil, fmt.Errorf("failed to connect MCP client: %w", err)
+	}
+
+	return &Kit{
+		Container:     c,
+		Auth:          auth,
+		Seed:          seed,
+		Session:       session,
+		serverSession: serverSession,
+	}, nil
+}
+
+// NewMemoryContainer wires every repository to an in-memory implementation
+// and the outside services to offline stand-ins: image search finds nothing,
+// nutrition lookups return fixed facts and uploaded media is discarded. The
+// caller sets the logger.
+func NewMemoryContainer(cfg *config.Config, auth services.AuthService) *container.Container {
+	containerRepo := NewMemoryContainerRepository()
+	collectionRepo := NewMemoryCollectionRepository(containerRepo)
+	mediaRepo := NewMemoryMediaRepository()
+	c := &container.Container{
+		ContainerRepo:          containerRepo,
+		CategoryRepo:           NewMemoryCategoryRepository(),
+		CollectionRepo:         collectionRepo,
+		DigestSubscriptionRepo: NewMemoryDigestSubscriptionRepository(),
+		ContainerTemplateRepo:  NewMemoryContainerTemplateRepository(),
+		ObjectMoveRepo:         NewMemoryObjectMoveRepository(),
+		AdjustmentRepo:         NewMemoryQuantityAdjustmentRepository(),
+		MealPlanRepo:           NewMemoryMealPlanRepository(),
+		RecurrenceRuleRepo:     NewMemoryRecurrenceRuleRepository(),
+		AutomationRuleRepo:     NewMemoryAutomationRuleRepository(),
+		WebhookKeyRepo:         NewMemoryWebhookSigningKeyRepository(),
+		WebhookDeliveryRepo:    NewMemoryWebhookDeliveryRepository(),
+		FolderRepo:             NewMemoryCollectionFolderRepository(),
+		ObjectTypeRepo:         NewMemoryCustomObjectTypeRepository(),
+		ObjectCodeRepo:         NewMemoryObjectCodeRepository(),
+		SnapshotRepo:           NewMemorySnapshotRepository(),
+		PreferencesRepo:        NewMemoryUserPreferencesRepository(),
+		MediaRepo:              mediaRepo,
+		CommentRepo:            NewMemoryCommentRepository(),
+		AccountStatusRepo:      NewMemoryAccountStatusRepository(),
+		SessionRepo:            NewMemorySessionRepository(),
+		GroupQuotaRepo:         NewMemoryGroupQuotaRepository(),
+		OAuthClientRepo:        NewMemoryOAuthClientRepository(),
+		UsageRepo:              NewMemoryUsageRepository(collectionRepo, mediaRepo),
+		InboxRepo:              NewMemoryInboxRepository(),
+		ExpiryHistoryRepo:      NewMemoryExpiryHistoryRepository(),
+		FeatureFlagRepo:        NewMemoryFeatureFlagRepository(),
+		AuthService:            auth,
+		ImageSearchService:     noImageSearch{},
+		MediaStorage:           discardMediaStorage{},
+		BarcodeDecoder:         extServices.NewBarcodeDecoder(),
+		NutritionProvider:      fixedNutrition{},
+	}
+	c.SetConfig(cfg)
+	c.SetInMemory()
+	return c
+}
+
+// Close ends the client and server sessions.
+func (k *Kit) Close() error {
+	err := k.Session.Close()
+	k.serverSession.Wait()
+	return err
+}
+
+func seedKit(ctx context.Context, c *container.Container, auth *FakeAuthService) (Seed, error) {
+	var seed Seed
+	var err error
+
+	if seed.User, err = newUser("alex", "alex@example.com"); err != nil {
+		return seed, err
+	}
+	if seed.OtherUser, err = newUser("sam", "sam@example.com"); err != nil {
+		return seed, err
+	}
+	seed.Token = "testkit-token"
+	auth.AddUser(seed.User, seed.Token)
+	auth.AddUser(seed.OtherUser, "")
+
+	if seed.Group, err = auth.CreateGroup(ctx, seed.Token, "Household", seed.User.ID().String()); err != nil {
+		return seed, err
+	}
+
+	name, err := entities.NewCollectionName("Kitchen")
+	if err != nil {
+		return seed, err
+	}
+	collection, err := entities.NewCollection(entities.CollectionProps{
+		UserID:     seed.User.ID(),
+		Name:       name,
+		ObjectType: entities.ObjectTypeFood,
+		Location:   "Home",
+	})
+	if err != nil {
+		return seed, err
+	}
+
+	pantry, err := newContainer(collection.ID(), "Pantry")
+	if err != nil {
+		return seed, err
+	}
+	for _, item := range []struct {
+		name     string
+		quantity float64
+		unit     string
+	}{{"Rice", 2, "kg"}, {"Black beans", 3, "cans"}} {
+		obj, err := newFoodObject(item.name, item.quantity, item.unit)
+		if err != nil {
+			return seed, err
+		}
+		if err := pantry.AddObject(*obj); err != nil {
+			return seed, err
+		}
+	}
+	if err := c.ContainerRepo.Create(ctx, pantry); err != nil {
+		return seed, err
+	}
+	if err := collection.AddContainer(*pantry); err != nil {
+		return seed, err
+	}
+	if err := c.CollectionRepo.Create(ctx, collection); err != nil {
+		return seed, err
+	}
+	seed.Pantry = pantry
+	seed.Rice = pantry.Objects()[0]
+	seed.Beans = pantry.Objects()[1]
+	seed.Collection = collection
+
+	emptyName, err := entities.NewCollectionName("Bookshelf")
+	if err != nil {
+		return seed, err
+	}
+	if seed.EmptyCollection, err = entities.NewCollection(entities.CollectionProps{
+		UserID:     seed.User.ID(),
+		Name:       emptyName,
+		ObjectType: entities.ObjectTypeBook,
+	}); err != nil {
+		return seed, err
+	}
+	if err := c.CollectionRepo.Create(ctx, seed.EmptyCollection); err != nil {
+		return seed, err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if seed.MealPlan, err = entities.NewMealPlan(seed.User.ID(), today, entities.MealTypeDinner, "Rice and beans", []entities.MealIngredient{
+		{ObjectID: seed.Rice.ID(), Name: "Rice", Quantity: 0.5, Unit: "kg"},
+	}); err != nil {
+		return seed, err
+	}
+	if err := c.MealPlanRepo.Create(ctx, seed.MealPlan); err != nil {
+		return seed, err
+	}
+
+	// A weekly rice staple that has already come due
+	weekly := entities.Recurrence{Every: 1, Period: entities.RecurrenceWeekly}
+	if seed.Recurrence, err = entities.NewRecurrenceRule(seed.User.ID(), seed.Rice.ID(), collection.ID(), seed.Rice.Name().String(), weekly, nil, today.AddDate(0, 0, -8)); err != nil {
+		return seed, err
+	}
+	seed.Recurrence.Trigger(time.Now())
+	if err := c.RecurrenceRuleRepo.Create(ctx, seed.Recurrence); err != nil {
+		return seed, err
+	}
+
+	if seed.Snapshot, err = entities.NewCollectionSnapshot(collection, seed.User.ID(), "Seeded"); err != nil {
+		return seed, err
+	}
+	if err := c.SnapshotRepo.Create(ctx, seed.Snapshot); err != nil {
+		return seed, err
+	}
+
+	return seed, nil
+}
+
+func newUser(username, email string) (*entities.User, error) {
+	name, err := entities.NewUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	address, err := entities.NewEmailAddress(email)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewUser(entities.UserProps{Username: name, EmailAddress: address})
+}
+
+func newContainer(collectionID entities.CollectionID, name string) (*entities.Container, error) {
+	containerName, err := entities.NewContainerName(name)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewContainer(entities.ContainerProps{
+		CollectionID:  collectionID,
+		Name:          containerName,
+		ContainerType: entities.ContainerTypeShelf,
+	})
+}
+
+func newFoodObject(name string, quantity float64, unit string) (*entities.Object, error) {
+	objectName, err := entities.NewObjectName(name)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewObject(entities.ObjectProps{
+		Name:       objectName,
+		ObjectType: entities.ObjectTypeFood,
+		Quantity:   &quantity,
+		Unit:       unit,
+	})
+}
@@ -0,0 +1,373 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolRegistry defines every MCP tool's name, description and annotations
+// in one place. registerTools takes each tool from here and ToolDocs
+// documents the same tools for the OpenAPI spec's x-mcp-tools, with the
+// input fields read from the schemas the server infers from the handlers'
+// input types, so the two cannot drift apart.
+var toolRegistry = []mcp.Tool{
+	// Collection tools
+	{
+		Name:        "create_collection",
+		Description: "Create a new inventory collection for a specific object type (food, books, games, etc.)",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "update_collection",
+		Description: "Update a collection's name, location, or tags",
+		Annotations: updateAnnotations,
+	},
+	{
+		Name:        "delete_collection",
+		Description: "Delete a collection (must have no containers)",
+		Annotations: deleteAnnotations,
+	},
+
+	// Container tools
+	{
+		Name:        "create_container",
+		Description: "Create a container within a collection for organizing objects",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "update_container",
+		Description: "Update a container's name, type, location, dimensions, or temperature zone and humidity",
+		Annotations: updateAnnotations,
+	},
+	{
+		Name:        "delete_container",
+		Description: "Delete a container (must have no child containers)",
+		Annotations: deleteAnnotations,
+	},
+
+	// Object tools
+	{
+		Name:        "create_object",
+		Description: "Add an object to a collection, optionally specifying a container",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "delete_object",
+		Description: "Remove an object from a container",
+		Annotations: deleteAnnotations,
+	},
+	{
+		Name:        "update_object",
+		Description: "Update an object's name, properties, tags, restock threshold, condition, or wishlist status, or move it with container_id. A move into another collection of the same object type carries the properties over to its schema; those it has no place for are listed in dropped_properties.",
+		Annotations: updateAnnotations,
+	},
+	{
+		Name:        "copy_object",
+		Description: "Duplicate an object under a new ID, next to the original or into container_id, which may be in another collection of the same object type. Properties the target collection's schema has no place for are listed in dropped_properties.",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "adjust_quantity",
+		Description: "Change an object's quantity by name without needing IDs. Pass either delta (relative) or quantity (absolute). If several objects match equally well the call fails and lists them so you can ask the user which one they meant.",
+		Annotations: adjustAnnotations,
+	},
+	{
+		Name:        "identify_item",
+		Description: "Store a photo of an item that still needs identifying on a draft object named 'Unidentified item' and tagged 'draft', reading any EAN, UPC or ISBN barcode in it into the draft's properties. Pass object_id to add another photo to an existing draft. Returns the draft, the stored image URL and the barcode; look at the photo, call lookup_code with the barcode to find an item the user already has, then fill in the draft with update_object.",
+		Annotations: createAnnotations,
+	},
+
+	// Group tools
+	{
+		Name:        "create_group",
+		Description: "Create a new group for collaborating on collections",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "add_group_member",
+		Description: "Add a user to a group by their numeric user ID.",
+		Annotations: updateAnnotations,
+	},
+	{
+		Name:        "remove_group_member",
+		Description: "Remove a user from a group by their numeric user ID.",
+		Annotations: deleteAnnotations,
+	},
+	{
+		Name:        "join_group",
+		Description: "Join a group using an invite code (currently unavailable — backend returns 501)",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "update_group",
+		Description: "Rename a group.",
+		Annotations: updateAnnotations,
+	},
+	{
+		Name:        "delete_group",
+		Description: "Delete a group by ID.",
+		Annotations: deleteAnnotations,
+	},
+
+	// Import tools
+	{
+		Name:        "bulk_import",
+		Description: "Bulk import objects into a collection. Each item must have a 'name' field; other fields become properties. Use distribution_mode='location' to auto-create containers from a Location column. Sends notifications/progress per row when the call carries a progress token.",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "smart_import",
+		Description: "Parse a raw CSV string, infer property types, sanitize values, auto-create containers from Location column, and import into a collection. Returns the import summary and inferred schema.",
+		Annotations: createAnnotations,
+	},
+
+	// Search tools
+	{
+		Name:        "search_objects",
+		Description: "Search and filter objects in a collection by name, tags, container, or property values. All filters are ANDed together.",
+		Annotations: readOnlyAnnotations,
+	},
+	{
+		Name:        "lookup_code",
+		Description: "Find the objects across all accessible collections whose upc, ean, barcode, isbn or sku property matches a code. Use before creating a scanned item: a match means adjust_quantity should add to it instead.",
+		Annotations: readOnlyAnnotations,
+	},
+
+	// Meal plan tools
+	{
+		Name:        "list_meal_plans",
+		Description: "List planned meals in a date range, ordered by day and meal. Completed meals are included with completed=true.",
+		Annotations: readOnlyAnnotations,
+	},
+	{
+		Name:        "create_meal_plan",
+		Description: "Plan a meal on a day, linking the food objects it uses and how much of each. Quantities are only used up when the meal is completed.",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "complete_meal_plan",
+		Description: "Mark a planned meal as cooked and subtract each linked ingredient from its object's quantity. Ingredients that cannot be adjusted are listed under skipped. A meal can only be completed once.",
+		Annotations: adjustAnnotations,
+	},
+
+	// Recurrence tools
+	{
+		Name:        "list_recurrences",
+		Description: "List the staples that come back onto the shopping list on a schedule, soonest due first. Staples waiting to be bought have due=true; set due_only to get just those.",
+		Annotations: readOnlyAnnotations,
+	},
+
+	// Export tools
+	{
+		Name:        "export_collection",
+		Description: "Export all objects in a collection as CSV or JSON. CSV columns follow the collection's property schema order. Useful for data pipelines and backups.",
+		Annotations: readOnlyAnnotations,
+	},
+
+	{
+		Name:        "valuation_report",
+		Description: "Total what the user's owned objects are worth across all their collections, by currency, with breakdowns by collection, object type, tag and condition, highest first. An object is worth its collection's price field, the first currency property in its schema; unvalued counts the objects without one.",
+		Annotations: readOnlyAnnotations,
+	},
+	{
+		Name:        "purchase_history",
+		Description: "Sum the food the user added over the last days days (default 30, at most 365): spend per currency and per category, priced like valuation_report, the purchases newest first (at most 200), and per category and unit how much was restocked and used through quantity changes. An object's category is its category property, else its first tag; unpriced counts purchases without a price.",
+		Annotations: readOnlyAnnotations,
+	},
+
+	// Schema tools
+	{
+		Name:        "get_collection_schema",
+		Description: "Get the property schema for a collection, which defines the typed fields for its objects.",
+		Annotations: readOnlyAnnotations,
+	},
+	{
+		Name:        "update_collection_schema",
+		Description: "Set or replace the property schema on a collection. This defines typed fields for object properties.",
+		Annotations: updateAnnotations,
+	},
+
+	// Snapshot tools
+	{
+		Name:        "list_snapshots",
+		Description: "List a collection's snapshots, newest first, with how many containers and objects each captured.",
+		Annotations: readOnlyAnnotations,
+	},
+	{
+		Name:        "create_snapshot",
+		Description: "Save a copy of a collection's containers and objects as they are now, so later changes can be compared or undone. The oldest snapshots are dropped once the server's limit is reached.",
+		Annotations: createAnnotations,
+	},
+	{
+		Name:        "diff_snapshot",
+		Description: "Compare a snapshot with the collection as it is now, or with a later snapshot. Lists containers and objects added, removed or changed, with the fields that changed.",
+		Annotations: readOnlyAnnotations,
+	},
+
+	// Nutrition tools
+	{
+		Name:        "nutrition_stats",
+		Description: "Total the calories, protein, carbs and fat available in a food collection (per-serving values × servings × quantity), skipping expired and archived food. Also lists calories per container.",
+		Annotations: readOnlyAnnotations,
+	},
+	{
+		Name:        "lookup_nutrition",
+		Description: "Look up a food object's barcode on OpenFoodFacts and fill in its calories, protein_g, carbs_g, fat_g and serving_size properties. Fails when nutrition lookup is not enabled on the server.",
+		Annotations: &mcp.ToolAnnotations{
+			IdempotentHint:  true,
+			DestructiveHint: new(false),
+			OpenWorldHint:   new(true),
+		},
+	},
+}
+
+// tool returns the registry's definition of the named tool for mcp.AddTool.
+// It panics on a name missing from toolRegistry, which the first server
+// start would hit.
+func tool(name string) *mcp.Tool {
+	i := registryIndex(name)
+	if i == len(toolRegistry) {
+		panic("mcp: tool " + name + " is not in toolRegistry")
+	}
+	t := toolRegistry[i]
+	return &t
+}
+
+// ToolDoc documents a tool in the OpenAPI spec's x-mcp-tools. InputFields
+// maps each input field to "required" or "optional" and its description.
+type ToolDoc struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	InputFields map[string]string `json:"input_fields,omitempty"`
+}
+
+// ToolDocs documents the tools a server registers, in toolRegistry order.
+func ToolDocs(ctx context.Context) ([]ToolDoc, error) {
+	tools, err := listTools(ctx, NewMCPServer(&MCPContext{}))
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]ToolDoc, 0, len(tools))
+	for _, t := range tools {
+		docs = append(docs, ToolDoc{
+			Name:        t.Name,
+			Description: t.Description,
+			InputFields: toolInputFields(t.InputSchema),
+		})
+	}
+	slices.SortStableFunc(docs, func(a, b ToolDoc) int { return registryIndex(a.Name) - registryIndex(b.Name) })
+	return docs, nil
+}
+
+// VerifyTools checks that the tools server registers are the ones in
+// toolRegistry and that docs, as published in the OpenAPI spec, describe
+// them as registered. It reports every difference it finds.
+func VerifyTools(ctx context.Context, server *mcp.Server, docs []ToolDoc) error {
+	tools, err := listTools(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	registered := make(map[string]*mcp.Tool, len(tools))
+	for _, t := range tools {
+		registered[t.Name] = t
+		i := registryIndex(t.Name)
+		if i == len(toolRegistry) {
+			errs = append(errs, fmt.Errorf("tool %s is registered but not in toolRegistry", t.Name))
+			continue
+		}
+		entry := toolRegistry[i]
+		if t.Description != entry.Description || !reflect.DeepEqual(t.Annotations, entry.Annotations) {
+			errs = append(errs, fmt.Errorf("tool %s is registered with a description or annotations other than toolRegistry's", t.Name))
+		}
+	}
+	for _, entry := range toolRegistry {
+		if registered[entry.Name] == nil {
+			errs = append(errs, fmt.Errorf("tool %s is in toolRegistry but not registered", entry.Name))
+		}
+	}
+
+	documented := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		documented[doc.Name] = true
+		t := registered[doc.Name]
+		switch {
+		case t == nil:
+			errs = append(errs, fmt.Errorf("tool %s is documented but not registered", doc.Name))
+		case doc.Description != t.Description:
+			errs = append(errs, fmt.Errorf("tool %s is documented with another description", doc.Name))
+		case !maps.Equal(doc.InputFields, toolInputFields(t.InputSchema)):
+			errs = append(errs, fmt.Errorf("tool %s is documented with other input fields", doc.Name))
+		}
+	}
+	for _, t := range tools {
+		if !documented[t.Name] {
+			errs = append(errs, fmt.Errorf("tool %s is registered but not documented", t.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// listTools lists the tools server registers, as a client sees them.
+func listTools(ctx context.Context, server *mcp.Server) ([]*mcp.Tool, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect MCP server: %w", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "nishiki-tool-registry", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect MCP client: %w", err)
+	}
+	defer session.Close()
+
+	var tools []*mcp.Tool
+	for t, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
+
+// toolInputFields summarizes an input schema's properties for ToolDoc.
+func toolInputFields(schema any) map[string]string {
+	object, _ := schema.(map[string]any)
+	properties, _ := object["properties"].(map[string]any)
+	if len(properties) == 0 {
+		return nil
+	}
+	required, _ := object["required"].([]any)
+	fields := make(map[string]string, len(properties))
+	for name, property := range properties {
+		field := "optional"
+		if slices.Contains(required, any(name)) {
+			field = "required"
+		}
+		if description, _ := property.(map[string]any)["description"].(string); description != "" {
+			field += ": " + strings.TrimSpace(description)
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// registryIndex returns the named tool's position in toolRegistry, or
+// len(toolRegistry) when it is not there.
+func registryIndex(name string) int {
+	if i := slices.IndexFunc(toolRegistry, func(t mcp.Tool) bool { return t.Name == name }); i >= 0 {
+		return i
+	}
+	return len(toolRegistry)
+}
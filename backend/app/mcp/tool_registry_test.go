@@ -0,0 +1,56 @@
+package mcpserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolDocs(t *testing.T) {
+	ctx := context.Background()
+	docs, err := ToolDocs(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, len(toolRegistry))
+
+	for i, doc := range docs {
+		assert.Equal(t, toolRegistry[i].Name, doc.Name)
+		assert.Equal(t, toolRegistry[i].Description, doc.Description)
+	}
+	assert.Equal(t, "required: ID of the collection to update", docs[1].InputFields["collection_id"])
+	assert.Equal(t, "optional: New location (optional)", docs[1].InputFields["location"])
+
+	assert.NoError(t, VerifyTools(ctx, NewMCPServer(&MCPContext{}), docs))
+}
+
+func TestVerifyTools_ReportsDivergence(t *testing.T) {
+	ctx := context.Background()
+	docs, err := ToolDocs(ctx)
+	require.NoError(t, err)
+
+	// Documentation edited by hand or left behind by a removed tool
+	docs[0].Description = "Something else"
+	delete(docs[1].InputFields, "location")
+	docs = append(docs[:2], docs[3:]...)
+	docs = append(docs, ToolDoc{Name: "retired_tool"})
+
+	// A tool registered without going through toolRegistry
+	server := NewMCPServer(&MCPContext{})
+	mcp.AddTool(server, &mcp.Tool{Name: "unlisted_tool"}, func(context.Context, *mcp.CallToolRequest, struct{}) (*mcp.CallToolResult, any, error) {
+		return textResult("ok"), nil, nil
+	})
+
+	err = VerifyTools(ctx, server, docs)
+	require.Error(t, err)
+	for _, want := range []string{
+		"tool create_collection is documented with another description",
+		"tool update_collection is documented with other input fields",
+		"tool delete_collection is registered but not documented",
+		"tool retired_tool is documented but not registered",
+		"tool unlisted_tool is registered but not in toolRegistry",
+	} {
+		assert.ErrorContains(t, err, want)
+	}
+}
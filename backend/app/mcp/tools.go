@@ -2,6 +2,7 @@ package mcpserver
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/csv"
 	"errors"
 	"log/slog"
@@ -32,6 +33,11 @@ var (
 		DestructiveHint: new(false),
 		OpenWorldHint:   new(false),
 	}
+	// adjustAnnotations marks relative updates, which change state on every call.
+	adjustAnnotations = &mcp.ToolAnnotations{
+		DestructiveHint: new(false),
+		OpenWorldHint:   new(false),
+	}
 	deleteAnnotations = &mcp.ToolAnnotations{
 		IdempotentHint: true,
 		OpenWorldHint:  new(false),
@@ -47,6 +53,10 @@ func registerTools(s *mcp.Server, mctx *MCPContext) {
 	registerSchemaTools(s, mctx)
 	registerExportTools(s, mctx)
 	registerSearchTools(s, mctx)
+	registerMealPlanTools(s, mctx)
+	registerRecurrenceTools(s, mctx)
+	registerSnapshotTools(s, mctx)
+	registerNutritionTools(s, mctx)
 }
 
 // invalidFormatErr logs an invalid format error and returns a ToolError result.
@@ -61,16 +71,12 @@ func invalidFormatErr(field, value string, err error) (*mcp.CallToolResult, any,
 func registerCollectionTools(s *mcp.Server, mctx *MCPContext) {
 	type CreateCollectionInput struct {
 		Name       string   `json:"name" jsonschema:"Name of the collection"`
-		ObjectType string   `json:"object_type" jsonschema:"Object type: food, book, videogame, music, boardgame, general"`
+		ObjectType string   `json:"object_type" jsonschema:"Object type: food, book, videogame, music, boardgame, general, or the key of a custom object type defined for the account"`
 		Location   string   `json:"location,omitempty" jsonschema:"Physical location of the collection"`
 		GroupID    string   `json:"group_id,omitempty" jsonschema:"Group ID to share this collection with (optional)"`
 		Tags       []string `json:"tags,omitempty" jsonschema:"Tags for the collection"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "create_collection",
-		Description: "Create a new inventory collection for a specific object type (food, books, games, etc.)",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateCollectionInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("create_collection"), func(ctx context.Context, req *mcp.CallToolRequest, input CreateCollectionInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -111,11 +117,7 @@ func registerCollectionTools(s *mcp.Server, mctx *MCPContext) {
 		Location     string   `json:"location,omitempty" jsonschema:"New location (optional)"`
 		Tags         []string `json:"tags,omitempty" jsonschema:"New tags (optional, replaces existing)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "update_collection",
-		Description: "Update a collection's name, location, or tags",
-		Annotations: updateAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateCollectionInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("update_collection"), func(ctx context.Context, req *mcp.CallToolRequest, input UpdateCollectionInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -153,12 +155,8 @@ func registerCollectionTools(s *mcp.Server, mctx *MCPContext) {
 	type DeleteCollectionInput struct {
 		CollectionID string `json:"collection_id" jsonschema:"ID of the collection to delete"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "delete_collection",
-		Description: "Delete a collection (must have no containers)",
-		Annotations: deleteAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input DeleteCollectionInput) (*mcp.CallToolResult, any, error) {
-		user, _, err := MCPUserFromContext(ctx)
+	mcp.AddTool(s, tool("delete_collection"), func(ctx context.Context, req *mcp.CallToolRequest, input DeleteCollectionInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
 			return r, nil, nil
@@ -172,6 +170,7 @@ func registerCollectionTools(s *mcp.Server, mctx *MCPContext) {
 		_, err = mctx.deleteCollectionUC().Execute(ctx, usecases.DeleteCollectionRequest{
 			CollectionID: collectionID,
 			UserID:       user.ID(),
+			UserToken:    token,
 		})
 		if err != nil {
 			r, _ := errorResult(err)
@@ -196,12 +195,10 @@ func registerContainerTools(s *mcp.Server, mctx *MCPContext) {
 		Width             *float64 `json:"width,omitempty" jsonschema:"Width dimension (optional)"`
 		Depth             *float64 `json:"depth,omitempty" jsonschema:"Depth dimension (optional)"`
 		Rows              *int     `json:"rows,omitempty" jsonschema:"Number of rows (optional)"`
+		TemperatureZone   string   `json:"temperature_zone,omitempty" jsonschema:"Temperature zone (optional): frozen, chilled, ambient"`
+		Humidity          *float64 `json:"humidity,omitempty" jsonschema:"Relative humidity in percent (optional)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "create_container",
-		Description: "Create a container within a collection for organizing objects",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateContainerInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("create_container"), func(ctx context.Context, req *mcp.CallToolRequest, input CreateContainerInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -214,16 +211,18 @@ func registerContainerTools(s *mcp.Server, mctx *MCPContext) {
 		}
 
 		ucReq := usecases.CreateContainerRequest{
-			CollectionID:  collectionID,
-			Name:          input.Name,
-			ContainerType: entities.ContainerType(input.ContainerType),
-			Location:      input.Location,
-			Capacity:      input.Capacity,
-			Width:         input.Width,
-			Depth:         input.Depth,
-			Rows:          input.Rows,
-			UserID:        user.ID(),
-			UserToken:     token,
+			CollectionID:    collectionID,
+			Name:            input.Name,
+			ContainerType:   entities.ContainerType(input.ContainerType),
+			Location:        input.Location,
+			Capacity:        input.Capacity,
+			Width:           input.Width,
+			Depth:           input.Depth,
+			Rows:            input.Rows,
+			TemperatureZone: entities.TemperatureZone(input.TemperatureZone),
+			Humidity:        input.Humidity,
+			UserID:          user.ID(),
+			UserToken:       token,
 		}
 
 		if input.ParentContainerID != "" {
@@ -245,20 +244,18 @@ func registerContainerTools(s *mcp.Server, mctx *MCPContext) {
 	})
 
 	type UpdateContainerInput struct {
-		ContainerID   string   `json:"container_id" jsonschema:"ID of the container to update"`
-		Name          string   `json:"name,omitempty" jsonschema:"New name (optional)"`
-		ContainerType string   `json:"container_type,omitempty" jsonschema:"New type (optional): room, bookshelf, shelf, binder, cabinet, general"`
-		Location      string   `json:"location,omitempty" jsonschema:"New location (optional)"`
-		Capacity      *float64 `json:"capacity,omitempty" jsonschema:"New capacity (optional)"`
-		Width         *float64 `json:"width,omitempty" jsonschema:"New width (optional)"`
-		Depth         *float64 `json:"depth,omitempty" jsonschema:"New depth (optional)"`
-		Rows          *int     `json:"rows,omitempty" jsonschema:"New row count (optional)"`
+		ContainerID     string   `json:"container_id" jsonschema:"ID of the container to update"`
+		Name            string   `json:"name,omitempty" jsonschema:"New name (optional)"`
+		ContainerType   string   `json:"container_type,omitempty" jsonschema:"New type (optional): room, bookshelf, shelf, binder, cabinet, general"`
+		Location        string   `json:"location,omitempty" jsonschema:"New location (optional)"`
+		Capacity        *float64 `json:"capacity,omitempty" jsonschema:"New capacity (optional)"`
+		Width           *float64 `json:"width,omitempty" jsonschema:"New width (optional)"`
+		Depth           *float64 `json:"depth,omitempty" jsonschema:"New depth (optional)"`
+		Rows            *int     `json:"rows,omitempty" jsonschema:"New row count (optional)"`
+		TemperatureZone *string  `json:"temperature_zone,omitempty" jsonschema:"New temperature zone (optional): frozen, chilled, ambient, or empty to clear"`
+		Humidity        *float64 `json:"humidity,omitempty" jsonschema:"New relative humidity in percent (optional)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "update_container",
-		Description: "Update a container's name, type, location, or dimensions",
-		Annotations: updateAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateContainerInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("update_container"), func(ctx context.Context, req *mcp.CallToolRequest, input UpdateContainerInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -297,6 +294,13 @@ func registerContainerTools(s *mcp.Server, mctx *MCPContext) {
 		if input.Rows != nil {
 			ucReq.Rows = &input.Rows
 		}
+		if input.TemperatureZone != nil {
+			zone := entities.TemperatureZone(*input.TemperatureZone)
+			ucReq.TemperatureZone = &zone
+		}
+		if input.Humidity != nil {
+			ucReq.Humidity = &input.Humidity
+		}
 
 		resp, err := mctx.updateContainerUC().Execute(ctx, ucReq)
 		if err != nil {
@@ -311,11 +315,7 @@ func registerContainerTools(s *mcp.Server, mctx *MCPContext) {
 	type DeleteContainerInput struct {
 		ContainerID string `json:"container_id" jsonschema:"ID of the container to delete"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "delete_container",
-		Description: "Delete a container (must have no child containers)",
-		Annotations: deleteAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input DeleteContainerInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("delete_container"), func(ctx context.Context, req *mcp.CallToolRequest, input DeleteContainerInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -350,30 +350,43 @@ func registerObjectTools(s *mcp.Server, mctx *MCPContext) {
 		CollectionID string         `json:"collection_id,omitempty" jsonschema:"ID of the collection (required when container_id is omitted)"`
 		Name         string         `json:"name" jsonschema:"Name of the object"`
 		Description  string         `json:"description,omitempty" jsonschema:"Description (optional)"`
-		ObjectType   string         `json:"object_type" jsonschema:"Object type matching the collection: food, book, videogame, music, boardgame, general"`
+		ObjectType   string         `json:"object_type" jsonschema:"Object type matching the collection: food, book, videogame, music, boardgame, general, or the collection's custom type"`
 		Quantity     *float64       `json:"quantity,omitempty" jsonschema:"Quantity (optional)"`
 		Unit         string         `json:"unit,omitempty" jsonschema:"Unit of quantity e.g. kg, pieces (optional)"`
-		Properties   map[string]any `json:"properties,omitempty" jsonschema:"Type-specific properties e.g. author, ISBN, brand (optional)"`
+		MinQuantity  *float64       `json:"min_quantity,omitempty" jsonschema:"Restock threshold; the object is reported as low stock at or below it (optional)"`
+		Condition    string         `json:"condition,omitempty" jsonschema:"Condition of a book, video game, music or board game: mint, near_mint, good, fair or poor (optional)"`
+		Status       string         `json:"status,omitempty" jsonschema:"owned (default), or wanted/ordered to put the object on the wishlist (optional)"`
+		Properties   map[string]any `json:"properties,omitempty" jsonschema:"Type-specific properties e.g. author, ISBN, brand; see nishiki://object-types for each type's fields (optional)"`
 		Tags         []string       `json:"tags,omitempty" jsonschema:"Tags (optional)"`
 		ExpiresAt    string         `json:"expires_at,omitempty" jsonschema:"Expiration date in RFC3339 format (optional, mainly for food)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "create_object",
-		Description: "Add an object to a collection, optionally specifying a container",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateObjectInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("create_object"), func(ctx context.Context, req *mcp.CallToolRequest, input CreateObjectInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
 			return r, nil, nil
 		}
 
+		condition, err := entities.ParseCondition(input.Condition)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		status, err := entities.ParseObjectStatus(input.Status)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
 		ucReq := usecases.CreateObjectRequest{
 			Name:          input.Name,
 			Description:   input.Description,
 			ObjectType:    entities.ObjectType(input.ObjectType),
 			Quantity:      input.Quantity,
 			Unit:          input.Unit,
+			MinQuantity:   input.MinQuantity,
+			Condition:     condition,
+			Status:        status,
 			RawProperties: input.Properties,
 			Tags:          input.Tags,
 			UserID:        user.ID(),
@@ -410,7 +423,9 @@ func registerObjectTools(s *mcp.Server, mctx *MCPContext) {
 			return r, nil, nil
 		}
 		mctx.notifyResourceUpdated(ctx, "nishiki://containers/"+resp.ContainerID.String())
-		r, err := jsonResult(response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+		objectResp := response.NewObjectResponse(*resp.Object, resp.ContainerID.String())
+		objectResp.StorageWarning = response.NewStorageWarningResponse(resp.StorageWarning)
+		r, err := jsonResult(objectResp)
 		return r, nil, err
 	})
 
@@ -418,11 +433,7 @@ func registerObjectTools(s *mcp.Server, mctx *MCPContext) {
 		ContainerID string `json:"container_id" jsonschema:"ID of the container that holds the object"`
 		ObjectID    string `json:"object_id" jsonschema:"ID of the object to delete"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "delete_object",
-		Description: "Remove an object from a container",
-		Annotations: deleteAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input DeleteObjectInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("delete_object"), func(ctx context.Context, req *mcp.CallToolRequest, input DeleteObjectInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -457,14 +468,13 @@ func registerObjectTools(s *mcp.Server, mctx *MCPContext) {
 		ContainerID string         `json:"container_id,omitempty" jsonschema:"ID of the container to move the object to (optional, keeps current if omitted)"`
 		ObjectID    string         `json:"object_id" jsonschema:"ID of the object to update"`
 		Name        string         `json:"name,omitempty" jsonschema:"New name (optional)"`
-		Properties  map[string]any `json:"properties,omitempty" jsonschema:"New properties (optional, replaces existing)"`
+		Properties  map[string]any `json:"properties,omitempty" jsonschema:"Properties to change (optional, merged into existing ones; a null value removes that property)"`
 		Tags        []string       `json:"tags,omitempty" jsonschema:"New tags (optional, replaces existing)"`
+		MinQuantity *float64       `json:"min_quantity,omitempty" jsonschema:"New restock threshold (optional, negative removes it)"`
+		Condition   *string        `json:"condition,omitempty" jsonschema:"New condition for a collectible: mint, near_mint, good, fair or poor (optional, empty removes it)"`
+		Status      string         `json:"status,omitempty" jsonschema:"New status: owned, wanted or ordered (optional; owned moves a wishlist item into the inventory)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "update_object",
-		Description: "Update an object's name, properties, or tags",
-		Annotations: updateAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateObjectInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("update_object"), func(ctx context.Context, req *mcp.CallToolRequest, input UpdateObjectInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -494,10 +504,28 @@ func registerObjectTools(s *mcp.Server, mctx *MCPContext) {
 		}
 		if input.Properties != nil {
 			ucReq.RawProperties = input.Properties
+			ucReq.MergeProperties = true
 		}
 		if input.Tags != nil {
 			ucReq.Tags = input.Tags
 		}
+		ucReq.MinQuantity = input.MinQuantity
+		if input.Condition != nil {
+			condition, err := entities.ParseCondition(*input.Condition)
+			if err != nil {
+				r, _ := errorResult(err)
+				return r, nil, nil
+			}
+			ucReq.Condition = &condition
+		}
+		if input.Status != "" {
+			status, err := entities.ParseObjectStatus(input.Status)
+			if err != nil {
+				r, _ := errorResult(err)
+				return r, nil, nil
+			}
+			ucReq.Status = &status
+		}
 
 		resp, err := mctx.updateObjectUC().Execute(ctx, ucReq)
 		if err != nil {
@@ -505,9 +533,193 @@ func registerObjectTools(s *mcp.Server, mctx *MCPContext) {
 			return r, nil, nil
 		}
 		mctx.notifyResourceUpdated(ctx, "nishiki://containers/"+resp.ContainerID.String())
-		r, err := jsonResult(response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+		objectResp := response.NewObjectResponse(*resp.Object, resp.ContainerID.String())
+		objectResp.StorageWarning = response.NewStorageWarningResponse(resp.StorageWarning)
+		objectResp.DroppedProperties = resp.DroppedProperties
+		r, err := jsonResult(objectResp)
 		return r, nil, err
 	})
+
+	type CopyObjectInput struct {
+		ObjectID    string `json:"object_id" jsonschema:"ID of the object to copy"`
+		ContainerID string `json:"container_id,omitempty" jsonschema:"Container to put the copy in (optional, defaults to the original's container)"`
+	}
+	mcp.AddTool(s, tool("copy_object"), func(ctx context.Context, req *mcp.CallToolRequest, input CopyObjectInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		objectID, err := entities.ObjectIDFromHex(input.ObjectID)
+		if err != nil {
+			return invalidFormatErr("object_id", input.ObjectID, err)
+		}
+		ucReq := usecases.CopyObjectRequest{
+			ObjectID:  objectID,
+			UserID:    user.ID(),
+			UserToken: token,
+		}
+		if input.ContainerID != "" {
+			containerID, err := entities.ContainerIDFromString(input.ContainerID)
+			if err != nil {
+				return invalidFormatErr("container_id", input.ContainerID, err)
+			}
+			ucReq.ContainerID = &containerID
+		}
+
+		resp, err := mctx.copyObjectUC().Execute(ctx, ucReq)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		mctx.notifyResourceUpdated(ctx, "nishiki://containers/"+resp.ContainerID.String())
+		objectResp := response.NewObjectResponse(*resp.Object, resp.ContainerID.String())
+		objectResp.StorageWarning = response.NewStorageWarningResponse(resp.StorageWarning)
+		objectResp.DroppedProperties = resp.DroppedProperties
+		r, err := jsonResult(objectResp)
+		return r, nil, err
+	})
+
+	type AdjustQuantityInput struct {
+		Name         string   `json:"name" jsonschema:"Object name as the user said it, e.g. eggs (fuzzy matched, plurals and small typos are fine)"`
+		CollectionID string   `json:"collection_id,omitempty" jsonschema:"Only search this collection (optional, searches all accessible collections if omitted)"`
+		Delta        *float64 `json:"delta,omitempty" jsonschema:"Amount to add; negative to use up, e.g. -2 for 'we used two eggs'"`
+		Quantity     *float64 `json:"quantity,omitempty" jsonschema:"Absolute quantity to set instead of a delta"`
+		Unit         string   `json:"unit,omitempty" jsonschema:"Unit of delta/quantity, e.g. g, cups, dozen (optional, converted to the object's unit; defaults to the object's unit)"`
+	}
+	mcp.AddTool(s, tool("adjust_quantity"), func(ctx context.Context, req *mcp.CallToolRequest, input AdjustQuantityInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		ucReq := usecases.AdjustObjectQuantityRequest{
+			ObjectName: input.Name,
+			Delta:      input.Delta,
+			Quantity:   input.Quantity,
+			Unit:       input.Unit,
+			UserID:     user.ID(),
+			UserToken:  token,
+		}
+		if input.CollectionID != "" {
+			collectionID, err := entities.CollectionIDFromString(input.CollectionID)
+			if err != nil {
+				return invalidFormatErr("collection_id", input.CollectionID, err)
+			}
+			ucReq.CollectionID = &collectionID
+		}
+
+		resp, err := mctx.adjustObjectQuantityUC().Execute(ctx, ucReq)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		mctx.notifyResourceUpdated(ctx,
+			"nishiki://containers/"+resp.ContainerID.String(),
+			"nishiki://collections/"+resp.CollectionID.String()+"/objects")
+		r, err := jsonResult(map[string]any{
+			"object":            response.NewObjectResponse(*resp.Object, resp.ContainerID.String()),
+			"collection_id":     resp.CollectionID.String(),
+			"previous_quantity": resp.PreviousQuantity,
+		})
+		return r, nil, err
+	})
+
+	type IdentifyItemInput struct {
+		Image        string `json:"image" jsonschema:"The photo as a data: URI, or base64 with content_type set"`
+		ContentType  string `json:"content_type,omitempty" jsonschema:"image/jpeg, image/png or image/gif (optional with a data: URI)"`
+		Filename     string `json:"filename,omitempty" jsonschema:"Name of the photo's file (optional)"`
+		ObjectID     string `json:"object_id,omitempty" jsonschema:"Draft object to add the photo to (optional, a new draft is created if omitted)"`
+		ContainerID  string `json:"container_id,omitempty" jsonschema:"Container to create the draft in (optional)"`
+		CollectionID string `json:"collection_id,omitempty" jsonschema:"Collection to create the draft in, in its default container (required when object_id and container_id are omitted)"`
+	}
+	mcp.AddTool(s, tool("identify_item"), func(ctx context.Context, req *mcp.CallToolRequest, input IdentifyItemInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		data, contentType, err := decodeImageInput(input.Image, input.ContentType)
+		if err != nil {
+			return invalidFormatErr("image", input.ContentType, err)
+		}
+		ucReq := usecases.IdentifyItemRequest{
+			Image:     usecases.MediaUpload{Filename: input.Filename, ContentType: contentType, Data: data},
+			UserID:    user.ID(),
+			UserToken: token,
+		}
+		if input.ObjectID != "" {
+			objectID, err := entities.ObjectIDFromHex(input.ObjectID)
+			if err != nil {
+				return invalidFormatErr("object_id", input.ObjectID, err)
+			}
+			ucReq.ObjectID = &objectID
+		}
+		if input.ContainerID != "" {
+			containerID, err := entities.ContainerIDFromString(input.ContainerID)
+			if err != nil {
+				return invalidFormatErr("container_id", input.ContainerID, err)
+			}
+			ucReq.ContainerID = &containerID
+		}
+		if input.CollectionID != "" {
+			collectionID, err := entities.CollectionIDFromString(input.CollectionID)
+			if err != nil {
+				return invalidFormatErr("collection_id", input.CollectionID, err)
+			}
+			ucReq.CollectionID = &collectionID
+		}
+
+		resp, err := mctx.identifyItemUC().Execute(ctx, ucReq)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		mctx.notifyResourceUpdated(ctx, "nishiki://containers/"+resp.ContainerID.String())
+		result := map[string]any{
+			"object":        response.NewObjectResponse(*resp.Object, resp.ContainerID.String()),
+			"created":       resp.Created,
+			"image_url":     resp.Media.URL(),
+			"thumbnail_url": resp.Media.ThumbnailURL(),
+			"media_id":      resp.Media.ID().String(),
+		}
+		if resp.Barcode != nil {
+			result["barcode"] = map[string]any{
+				"format":   resp.Barcode.Format,
+				"code":     resp.Barcode.Code,
+				"property": resp.Barcode.PropertyKey(),
+			}
+		}
+		r, err := jsonResult(result)
+		return r, nil, err
+	})
+}
+
+// decodeImageInput decodes a photo passed to a tool as a base64 data: URI, or
+// as plain base64 with its content type given separately
+func decodeImageInput(image, contentType string) ([]byte, string, error) {
+	if rest, ok := strings.CutPrefix(image, "data:"); ok {
+		header, payload, found := strings.Cut(rest, ",")
+		mediaType, isBase64 := strings.CutSuffix(header, ";base64")
+		if !found || !isBase64 {
+			return nil, "", errors.New("data URI must be base64 encoded")
+		}
+		if contentType == "" {
+			contentType = mediaType
+		}
+		image = payload
+	}
+	if contentType == "" {
+		return nil, "", errors.New("content_type is required unless the image is a data: URI")
+	}
+	data, err := base64.StdEncoding.DecodeString(image)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, contentType, nil
 }
 
 // --- Group tools ---
@@ -516,11 +728,7 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 	type CreateGroupInput struct {
 		Name string `json:"name" jsonschema:"Name of the new group"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "create_group",
-		Description: "Create a new group for collaborating on collections",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateGroupInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("create_group"), func(ctx context.Context, req *mcp.CallToolRequest, input CreateGroupInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -545,12 +753,8 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 		GroupID string `json:"group_id" jsonschema:"ID of the group"`
 		UserID  string `json:"user_id" jsonschema:"Numeric user ID to add"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "add_group_member",
-		Description: "Add a user to a group by their numeric user ID.",
-		Annotations: updateAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input AddGroupMemberInput) (*mcp.CallToolResult, any, error) {
-		_, token, err := MCPUserFromContext(ctx)
+	mcp.AddTool(s, tool("add_group_member"), func(ctx context.Context, req *mcp.CallToolRequest, input AddGroupMemberInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
 			return r, nil, nil
@@ -564,6 +768,7 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 		if err := mctx.groupUC().AddMember(ctx, usecases.GroupMemberRequest{
 			GroupID:   groupID,
 			UserID:    input.UserID,
+			ActorID:   user.ID(),
 			UserToken: token,
 		}); err != nil {
 			r, _ := errorResult(err)
@@ -578,12 +783,8 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 		GroupID string `json:"group_id" jsonschema:"ID of the group"`
 		UserID  string `json:"user_id" jsonschema:"Numeric user ID to remove"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "remove_group_member",
-		Description: "Remove a user from a group by their numeric user ID.",
-		Annotations: deleteAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input RemoveGroupMemberInput) (*mcp.CallToolResult, any, error) {
-		_, token, err := MCPUserFromContext(ctx)
+	mcp.AddTool(s, tool("remove_group_member"), func(ctx context.Context, req *mcp.CallToolRequest, input RemoveGroupMemberInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
 			return r, nil, nil
@@ -597,6 +798,7 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 		if err := mctx.groupUC().RemoveMember(ctx, usecases.GroupMemberRequest{
 			GroupID:   groupID,
 			UserID:    input.UserID,
+			ActorID:   user.ID(),
 			UserToken: token,
 		}); err != nil {
 			r, _ := errorResult(err)
@@ -611,11 +813,7 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 	type JoinGroupInput struct {
 		InviteCode string `json:"invite_code" jsonschema:"Invitation code for the group"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "join_group",
-		Description: "Join a group using an invite code (currently unavailable — backend returns 501)",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input JoinGroupInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("join_group"), func(ctx context.Context, req *mcp.CallToolRequest, input JoinGroupInput) (*mcp.CallToolResult, any, error) {
 		r, _ := errorResult(errors.New("backend unimplemented: JoinGroup returns 501. Fix planned in Phase 2"))
 		return r, nil, nil
 	})
@@ -624,11 +822,7 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 		GroupID string `json:"group_id" jsonschema:"ID of the group to update"`
 		Name    string `json:"name" jsonschema:"New name for the group"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "update_group",
-		Description: "Rename a group.",
-		Annotations: updateAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateGroupInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("update_group"), func(ctx context.Context, req *mcp.CallToolRequest, input UpdateGroupInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -658,11 +852,7 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 	type DeleteGroupInput struct {
 		GroupID string `json:"group_id" jsonschema:"ID of the group to delete"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "delete_group",
-		Description: "Delete a group by ID.",
-		Annotations: deleteAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input DeleteGroupInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("delete_group"), func(ctx context.Context, req *mcp.CallToolRequest, input DeleteGroupInput) (*mcp.CallToolResult, any, error) {
 		_, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -692,19 +882,16 @@ func registerGroupTools(s *mcp.Server, mctx *MCPContext) {
 func registerImportTools(s *mcp.Server, mctx *MCPContext) {
 	type BulkImportInput struct {
 		CollectionID      string           `json:"collection_id" jsonschema:"ID of the collection to import into"`
-		Data              []map[string]any `json:"data" jsonschema:"Array of objects to import, each must have a 'name' field"`
+		Data              []map[string]any `json:"data" jsonschema:"Array of objects to import, each must have a 'name' field; see nishiki://object-types for the property fields of the collection's type"`
 		DistributionMode  string           `json:"distribution_mode,omitempty" jsonschema:"How to distribute objects: automatic, location, target, or manual (default)"`
 		TargetContainerID string           `json:"target_container_id,omitempty" jsonschema:"Container ID for target distribution mode (optional)"`
 		DefaultTags       []string         `json:"default_tags,omitempty" jsonschema:"Tags to apply to all imported objects (optional)"`
 		LocationColumn    string           `json:"location_column,omitempty" jsonschema:"Column name used for container mapping in 'location' mode (default: 'location')"`
 		NameColumn        string           `json:"name_column,omitempty" jsonschema:"Column name override for object name (optional, auto-detected by default)"`
 		InferSchema       bool             `json:"infer_schema,omitempty" jsonschema:"Run type inference and save schema to collection (optional)"`
+		SourceFormat      string           `json:"source_format,omitempty" jsonschema:"App the data was exported from: generic (default), auto, grocy, or pantry_csv"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "bulk_import",
-		Description: "Bulk import objects into a collection. Each item must have a 'name' field; other fields become properties. Use distribution_mode='location' to auto-create containers from a Location column.",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input BulkImportInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("bulk_import"), func(ctx context.Context, req *mcp.CallToolRequest, input BulkImportInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -726,6 +913,8 @@ func registerImportTools(s *mcp.Server, mctx *MCPContext) {
 			LocationColumn:   input.LocationColumn,
 			NameColumn:       input.NameColumn,
 			InferSchema:      input.InferSchema,
+			SourceFormat:     usecases.ImportSourceFormat(input.SourceFormat),
+			Progress:         importProgress(ctx, req),
 		}
 
 		if input.TargetContainerID != "" {
@@ -755,11 +944,7 @@ func registerImportTools(s *mcp.Server, mctx *MCPContext) {
 		ObjectType     string   `json:"object_type,omitempty" jsonschema:"Object type override (optional, defaults to collection type)"`
 		DefaultTags    []string `json:"default_tags,omitempty" jsonschema:"Tags to apply to all imported objects (optional)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "smart_import",
-		Description: "Parse a raw CSV string, infer property types, sanitize values, auto-create containers from Location column, and import into a collection. Returns the import summary and inferred schema.",
-		Annotations: createAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input SmartImportInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("smart_import"), func(ctx context.Context, req *mcp.CallToolRequest, input SmartImportInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -809,6 +994,7 @@ func registerImportTools(s *mcp.Server, mctx *MCPContext) {
 			LocationColumn:   locationCol,
 			NameColumn:       input.NameColumn,
 			InferSchema:      true,
+			Progress:         importProgress(ctx, req),
 		}
 
 		resp, err := mctx.bulkImportCollectionUC().Execute(ctx, ucReq)
@@ -831,12 +1017,9 @@ func registerSearchTools(s *mcp.Server, mctx *MCPContext) {
 		Tags            []string          `json:"tags,omitempty" jsonschema:"All listed tags must be present on matching objects (optional)"`
 		ContainerID     string            `json:"container_id,omitempty" jsonschema:"Restrict search to this container ID (optional)"`
 		PropertyFilters map[string]string `json:"property_filters,omitempty" jsonschema:"Key/value pairs: object property must contain the value (case-insensitive, optional)"`
+		Archived        bool              `json:"archived,omitempty" jsonschema:"Search archived objects instead of active ones (optional)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "search_objects",
-		Description: "Search and filter objects in a collection by name, tags, container, or property values. All filters are ANDed together.",
-		Annotations: readOnlyAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input SearchObjectsInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("search_objects"), func(ctx context.Context, req *mcp.CallToolRequest, input SearchObjectsInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -855,6 +1038,7 @@ func registerSearchTools(s *mcp.Server, mctx *MCPContext) {
 			Query:           input.Query,
 			Tags:            input.Tags,
 			PropertyFilters: input.PropertyFilters,
+			Archived:        input.Archived,
 		}
 
 		if input.ContainerID != "" {
@@ -881,6 +1065,213 @@ func registerSearchTools(s *mcp.Server, mctx *MCPContext) {
 		})
 		return r, nil, err
 	})
+
+	type LookupCodeInput struct {
+		Code string `json:"code" jsonschema:"Barcode, ISBN or SKU; spaces and dashes are ignored"`
+	}
+	mcp.AddTool(s, tool("lookup_code"), func(ctx context.Context, req *mcp.CallToolRequest, input LookupCodeInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		resp, err := mctx.lookupObjectCodeUC().Execute(ctx, usecases.LookupObjectCodeRequest{
+			Code:      input.Code,
+			UserID:    user.ID(),
+			UserToken: token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		matches := make([]response.ObjectCodeMatchResponse, len(resp.Objects))
+		for i, match := range resp.Objects {
+			matches[i] = response.ObjectCodeMatchResponse{
+				CollectionID: match.CollectionID.String(),
+				Object:       response.NewObjectResponse(match.Object, match.ContainerID.String()),
+			}
+		}
+		r, err := jsonResult(response.ObjectCodeLookupResponse{Code: resp.Code, Matches: matches})
+		return r, nil, err
+	})
+}
+
+// --- Meal plan tools ---
+
+func registerMealPlanTools(s *mcp.Server, mctx *MCPContext) {
+	type ListMealPlansInput struct {
+		From string `json:"from,omitempty" jsonschema:"First day to include, YYYY-MM-DD (optional, defaults to this week's Monday)"`
+		To   string `json:"to,omitempty" jsonschema:"Day after the last day to include, YYYY-MM-DD (optional, defaults to seven days after from)"`
+	}
+	mcp.AddTool(s, tool("list_meal_plans"), func(ctx context.Context, req *mcp.CallToolRequest, input ListMealPlansInput) (*mcp.CallToolResult, any, error) {
+		user, _, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		if err := mctx.requireFeature(ctx, entities.FeatureMealPlanner); err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		today := entities.MealPlanDay(time.Now())
+		from := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		if input.From != "" {
+			from, err = time.Parse(entities.MealPlanDateLayout, input.From)
+			if err != nil {
+				return invalidFormatErr("from", input.From, err)
+			}
+		}
+		to := from.AddDate(0, 0, 7)
+		if input.To != "" {
+			to, err = time.Parse(entities.MealPlanDateLayout, input.To)
+			if err != nil {
+				return invalidFormatErr("to", input.To, err)
+			}
+		}
+
+		resp, err := mctx.listMealPlansUC().Execute(ctx, usecases.ListMealPlansRequest{
+			From:   from,
+			To:     to,
+			UserID: user.ID(),
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewMealPlanListResponse(from, to, resp.MealPlans))
+		return r, nil, err
+	})
+
+	type MealIngredientInput struct {
+		ObjectID string  `json:"object_id" jsonschema:"ID of the food object the recipe uses"`
+		Quantity float64 `json:"quantity" jsonschema:"Amount the recipe uses, greater than zero"`
+		Unit     string  `json:"unit,omitempty" jsonschema:"Unit of quantity, e.g. g or cups (optional, defaults to the object's unit)"`
+	}
+	type CreateMealPlanInput struct {
+		Date        string                `json:"date" jsonschema:"Day of the meal, YYYY-MM-DD"`
+		Meal        string                `json:"meal" jsonschema:"breakfast, lunch, dinner or snack"`
+		RecipeName  string                `json:"recipe_name" jsonschema:"Name of the recipe or dish"`
+		Ingredients []MealIngredientInput `json:"ingredients,omitempty" jsonschema:"Inventory objects the meal uses (optional)"`
+	}
+	mcp.AddTool(s, tool("create_meal_plan"), func(ctx context.Context, req *mcp.CallToolRequest, input CreateMealPlanInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		if err := mctx.requireFeature(ctx, entities.FeatureMealPlanner); err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		date, err := time.Parse(entities.MealPlanDateLayout, input.Date)
+		if err != nil {
+			return invalidFormatErr("date", input.Date, err)
+		}
+		ingredients := make([]entities.MealIngredient, len(input.Ingredients))
+		for i, ing := range input.Ingredients {
+			objectID, err := entities.ObjectIDFromHex(ing.ObjectID)
+			if err != nil {
+				return invalidFormatErr("object_id", ing.ObjectID, err)
+			}
+			ingredients[i] = entities.MealIngredient{ObjectID: objectID, Quantity: ing.Quantity, Unit: ing.Unit}
+		}
+
+		resp, err := mctx.createMealPlanUC().Execute(ctx, usecases.CreateMealPlanRequest{
+			Date:        date,
+			Meal:        entities.MealType(strings.ToLower(strings.TrimSpace(input.Meal))),
+			RecipeName:  input.RecipeName,
+			Ingredients: ingredients,
+			UserID:      user.ID(),
+			UserToken:   token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewMealPlanResponse(resp.MealPlan))
+		return r, nil, err
+	})
+
+	type CompleteMealPlanInput struct {
+		MealPlanID string `json:"meal_plan_id" jsonschema:"ID of the meal plan to complete"`
+	}
+	mcp.AddTool(s, tool("complete_meal_plan"), func(ctx context.Context, req *mcp.CallToolRequest, input CompleteMealPlanInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		if err := mctx.requireFeature(ctx, entities.FeatureMealPlanner); err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		mealPlanID, err := entities.MealPlanIDFromString(input.MealPlanID)
+		if err != nil {
+			return invalidFormatErr("meal_plan_id", input.MealPlanID, err)
+		}
+
+		resp, err := mctx.completeMealPlanUC().Execute(ctx, usecases.CompleteMealPlanRequest{
+			MealPlanID: mealPlanID,
+			UserID:     user.ID(),
+			UserToken:  token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		adjusted := make([]any, len(resp.Adjusted))
+		for i, adj := range resp.Adjusted {
+			adjusted[i] = response.NewObjectResponse(*adj.Object, adj.ContainerID.String())
+			mctx.notifyResourceUpdated(ctx,
+				"nishiki://containers/"+adj.ContainerID.String(),
+				"nishiki://collections/"+adj.CollectionID.String()+"/objects")
+		}
+		skipped := make([]response.SkippedMealIngredientResponse, len(resp.Skipped))
+		for i, sk := range resp.Skipped {
+			skipped[i] = response.SkippedMealIngredientResponse{
+				Ingredient: response.NewMealIngredientResponse(sk.Ingredient),
+				Reason:     sk.Reason,
+			}
+		}
+		r, err := jsonResult(map[string]any{
+			"meal_plan": response.NewMealPlanResponse(resp.MealPlan),
+			"adjusted":  adjusted,
+			"skipped":   skipped,
+		})
+		return r, nil, err
+	})
+}
+
+// --- Recurrence tools ---
+
+func registerRecurrenceTools(s *mcp.Server, mctx *MCPContext) {
+	type ListRecurrencesInput struct {
+		DueOnly bool `json:"due_only,omitempty" jsonschema:"Only list staples waiting to be bought (optional)"`
+	}
+	mcp.AddTool(s, tool("list_recurrences"), func(ctx context.Context, req *mcp.CallToolRequest, input ListRecurrencesInput) (*mcp.CallToolResult, any, error) {
+		user, _, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		resp, err := mctx.listRecurrenceRulesUC().Execute(ctx, usecases.ListRecurrenceRulesRequest{
+			UserID:  user.ID(),
+			DueOnly: input.DueOnly,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewRecurrenceRuleListResponse(resp.Rules))
+		return r, nil, err
+	})
 }
 
 // --- Export tools ---
@@ -890,11 +1281,7 @@ func registerExportTools(s *mcp.Server, mctx *MCPContext) {
 		CollectionID string `json:"collection_id" jsonschema:"ID of the collection to export"`
 		Format       string `json:"format,omitempty" jsonschema:"Export format: csv or json (default: csv)"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "export_collection",
-		Description: "Export all objects in a collection as CSV or JSON. CSV columns follow the collection's property schema order. Useful for data pipelines and backups.",
-		Annotations: readOnlyAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input ExportCollectionInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("export_collection"), func(ctx context.Context, req *mcp.CallToolRequest, input ExportCollectionInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -946,6 +1333,60 @@ func registerExportTools(s *mcp.Server, mctx *MCPContext) {
 		}
 		return textResult(string(resp.CSV)), nil, nil
 	})
+
+	type ValuationReportInput struct {
+		Format string `json:"format,omitempty" jsonschema:"Report format: json or csv (default: json)"`
+	}
+	mcp.AddTool(s, tool("valuation_report"), func(ctx context.Context, req *mcp.CallToolRequest, input ValuationReportInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		resp, err := mctx.valuationReportUC().Execute(ctx, usecases.GetValuationReportRequest{
+			UserID:    user.ID(),
+			UserToken: token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		if input.Format == "csv" {
+			data, err := usecases.EncodeValuationReportCSV(resp.Report)
+			if err != nil {
+				r, _ := errorResult(err)
+				return r, nil, nil
+			}
+			return textResult(string(data)), nil, nil
+		}
+		r, err := jsonResult(response.NewValuationReportResponse(resp.Report))
+		return r, nil, err
+	})
+
+	type PurchaseHistoryInput struct {
+		Days int `json:"days,omitempty" jsonschema:"Days to look back, 1-365 (default: 30)"`
+	}
+	mcp.AddTool(s, tool("purchase_history"), func(ctx context.Context, req *mcp.CallToolRequest, input PurchaseHistoryInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		resp, err := mctx.purchaseHistoryUC().Execute(ctx, usecases.GetPurchaseHistoryRequest{
+			UserID:    user.ID(),
+			UserToken: token,
+			Days:      input.Days,
+			Now:       time.Now(),
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewPurchaseHistoryResponse(resp.History))
+		return r, nil, err
+	})
 }
 
 // parseCSVString parses a raw CSV string into rows and returns (data, headers, error).
@@ -984,11 +1425,7 @@ func registerSchemaTools(s *mcp.Server, mctx *MCPContext) {
 	type GetCollectionSchemaInput struct {
 		CollectionID string `json:"collection_id" jsonschema:"ID of the collection"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "get_collection_schema",
-		Description: "Get the property schema for a collection, which defines the typed fields for its objects.",
-		Annotations: readOnlyAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetCollectionSchemaInput) (*mcp.CallToolResult, any, error) {
+	mcp.AddTool(s, tool("get_collection_schema"), func(ctx context.Context, req *mcp.CallToolRequest, input GetCollectionSchemaInput) (*mcp.CallToolResult, any, error) {
 		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
@@ -1025,12 +1462,8 @@ func registerSchemaTools(s *mcp.Server, mctx *MCPContext) {
 		CollectionID string                    `json:"collection_id" jsonschema:"ID of the collection to update"`
 		Definitions  []PropertyDefinitionInput `json:"definitions" jsonschema:"Property definitions for the schema"`
 	}
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "update_collection_schema",
-		Description: "Set or replace the property schema on a collection. This defines typed fields for object properties.",
-		Annotations: updateAnnotations,
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateCollectionSchemaInput) (*mcp.CallToolResult, any, error) {
-		user, _, err := MCPUserFromContext(ctx)
+	mcp.AddTool(s, tool("update_collection_schema"), func(ctx context.Context, req *mcp.CallToolRequest, input UpdateCollectionSchemaInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
 		if err != nil {
 			r, _ := errorResult(err)
 			return r, nil, nil
@@ -1057,6 +1490,7 @@ func registerSchemaTools(s *mcp.Server, mctx *MCPContext) {
 			CollectionID:   collectionID,
 			UserID:         user.ID(),
 			PropertySchema: schema,
+			UserToken:      token,
 		})
 		if err != nil {
 			r, _ := errorResult(err)
@@ -1067,3 +1501,171 @@ func registerSchemaTools(s *mcp.Server, mctx *MCPContext) {
 		return r, nil, err
 	})
 }
+
+// --- Snapshot tools ---
+
+func registerSnapshotTools(s *mcp.Server, mctx *MCPContext) {
+	type ListSnapshotsInput struct {
+		CollectionID string `json:"collection_id" jsonschema:"ID of the collection"`
+	}
+	mcp.AddTool(s, tool("list_snapshots"), func(ctx context.Context, req *mcp.CallToolRequest, input ListSnapshotsInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		collectionID, err := entities.CollectionIDFromString(input.CollectionID)
+		if err != nil {
+			return invalidFormatErr("collection_id", input.CollectionID, err)
+		}
+
+		resp, err := mctx.listSnapshotsUC().Execute(ctx, usecases.ListCollectionSnapshotsRequest{
+			CollectionID: collectionID,
+			UserID:       user.ID(),
+			UserToken:    token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewCollectionSnapshotListResponse(resp.Snapshots, mctx.Container.GetConfig().Snapshots.MaxPerCollection))
+		return r, nil, err
+	})
+
+	type CreateSnapshotInput struct {
+		CollectionID string `json:"collection_id" jsonschema:"ID of the collection to snapshot"`
+		Label        string `json:"label,omitempty" jsonschema:"Short description of the snapshot (optional, at most 100 characters)"`
+	}
+	mcp.AddTool(s, tool("create_snapshot"), func(ctx context.Context, req *mcp.CallToolRequest, input CreateSnapshotInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		collectionID, err := entities.CollectionIDFromString(input.CollectionID)
+		if err != nil {
+			return invalidFormatErr("collection_id", input.CollectionID, err)
+		}
+
+		resp, err := mctx.createSnapshotUC().Execute(ctx, usecases.CreateCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			UserID:       user.ID(),
+			UserToken:    token,
+			Label:        input.Label,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewCollectionSnapshotResponse(resp.Snapshot))
+		return r, nil, err
+	})
+
+	type DiffSnapshotInput struct {
+		CollectionID string `json:"collection_id" jsonschema:"ID of the collection"`
+		SnapshotID   string `json:"snapshot_id" jsonschema:"ID of the earlier snapshot"`
+		AgainstID    string `json:"against_id,omitempty" jsonschema:"ID of a later snapshot to compare with (optional, defaults to the current state)"`
+	}
+	mcp.AddTool(s, tool("diff_snapshot"), func(ctx context.Context, req *mcp.CallToolRequest, input DiffSnapshotInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		collectionID, err := entities.CollectionIDFromString(input.CollectionID)
+		if err != nil {
+			return invalidFormatErr("collection_id", input.CollectionID, err)
+		}
+		snapshotID, err := entities.CollectionSnapshotIDFromString(input.SnapshotID)
+		if err != nil {
+			return invalidFormatErr("snapshot_id", input.SnapshotID, err)
+		}
+		var againstID *entities.CollectionSnapshotID
+		if input.AgainstID != "" {
+			id, err := entities.CollectionSnapshotIDFromString(input.AgainstID)
+			if err != nil {
+				return invalidFormatErr("against_id", input.AgainstID, err)
+			}
+			againstID = &id
+		}
+
+		resp, err := mctx.diffSnapshotUC().Execute(ctx, usecases.DiffCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			SnapshotID:   snapshotID,
+			AgainstID:    againstID,
+			UserID:       user.ID(),
+			UserToken:    token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewCollectionSnapshotDiffResponse(resp.From, resp.To, resp.Diff))
+		return r, nil, err
+	})
+}
+
+func registerNutritionTools(s *mcp.Server, mctx *MCPContext) {
+	type NutritionStatsInput struct {
+		CollectionID string `json:"collection_id" jsonschema:"ID of the food collection"`
+	}
+	mcp.AddTool(s, tool("nutrition_stats"), func(ctx context.Context, req *mcp.CallToolRequest, input NutritionStatsInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		collectionID, err := entities.CollectionIDFromString(input.CollectionID)
+		if err != nil {
+			return invalidFormatErr("collection_id", input.CollectionID, err)
+		}
+
+		resp, err := mctx.nutritionStatsUC().Execute(ctx, usecases.GetNutritionStatsRequest{
+			CollectionID: collectionID,
+			UserID:       user.ID(),
+			UserToken:    token,
+			Now:          time.Now(),
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		r, err := jsonResult(response.NewNutritionStatsResponse(resp.Stats))
+		return r, nil, err
+	})
+
+	type LookupNutritionInput struct {
+		ObjectID string `json:"object_id" jsonschema:"ID of the food object"`
+		UPC      string `json:"upc,omitempty" jsonschema:"UPC/EAN barcode to look up (optional, defaults to the object's upc property)"`
+	}
+	mcp.AddTool(s, tool("lookup_nutrition"), func(ctx context.Context, req *mcp.CallToolRequest, input LookupNutritionInput) (*mcp.CallToolResult, any, error) {
+		user, token, err := MCPUserFromContext(ctx)
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+
+		objectID, err := entities.ObjectIDFromHex(input.ObjectID)
+		if err != nil {
+			return invalidFormatErr("object_id", input.ObjectID, err)
+		}
+
+		resp, err := mctx.enrichNutritionUC().Execute(ctx, usecases.EnrichObjectNutritionRequest{
+			ObjectID:  objectID,
+			UPC:       input.UPC,
+			UserID:    user.ID(),
+			UserToken: token,
+		})
+		if err != nil {
+			r, _ := errorResult(err)
+			return r, nil, nil
+		}
+		mctx.notifyResourceUpdated(ctx, "nishiki://containers/"+resp.ContainerID.String())
+		r, err := jsonResult(response.NewObjectResponse(*resp.Object, resp.ContainerID.String()))
+		return r, nil, err
+	})
+}
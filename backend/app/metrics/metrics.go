@@ -0,0 +1,51 @@
+// Package metrics keeps process-wide request counters. They are anonymous
+// totals: nothing about who made a request or what it asked for is kept.
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics counts the HTTP requests served since the process started.
+type Metrics struct {
+	started      time.Time
+	requests     atomic.Int64
+	serverErrors atomic.Int64
+}
+
+// Snapshot is a consistent-enough reading of the counters for reporting.
+type Snapshot struct {
+	StartedAt    time.Time
+	Uptime       time.Duration
+	Requests     int64
+	ServerErrors int64
+	// ErrorRate is the share of requests answered with a 5xx status, 0 when
+	// nothing has been served yet.
+	ErrorRate float64
+}
+
+func New() *Metrics {
+	return &Metrics{started: time.Now()}
+}
+
+// Observe records one finished request by its response status.
+func (m *Metrics) Observe(status int) {
+	m.requests.Add(1)
+	if status >= 500 {
+		m.serverErrors.Add(1)
+	}
+}
+
+func (m *Metrics) Snapshot() Snapshot {
+	s := Snapshot{
+		StartedAt:    m.started,
+		Uptime:       time.Since(m.started),
+		Requests:     m.requests.Load(),
+		ServerErrors: m.serverErrors.Load(),
+	}
+	if s.Requests > 0 {
+		s.ErrorRate = float64(s.ServerErrors) / float64(s.Requests)
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAccountStatusNotFound = errors.New("account not found")
+	ErrAccountDisabled       = errors.New("account is disabled")
+	ErrCannotDisableSelf     = errors.New("admins cannot disable their own account")
+)
+
+// AccountStatus records a user who has signed in, so admins can list users
+// and disable accounts. Users otherwise live only in the identity provider,
+// so the username and email are a snapshot refreshed on each sign-in.
+type AccountStatus struct {
+	userID      UserID
+	username    string
+	email       string
+	disabled    bool
+	disabledAt  *time.Time
+	firstSeenAt time.Time
+	lastSeenAt  time.Time
+}
+
+func NewAccountStatus(user *User, now time.Time) *AccountStatus {
+	return &AccountStatus{
+		userID:      user.ID(),
+		username:    user.Username().String(),
+		email:       user.EmailAddress().String(),
+		firstSeenAt: now,
+		lastSeenAt:  now,
+	}
+}
+
+func ReconstructAccountStatus(userID UserID, username, email string, disabled bool, disabledAt *time.Time, firstSeenAt, lastSeenAt time.Time) *AccountStatus {
+	return &AccountStatus{
+		userID:      userID,
+		username:    username,
+		email:       email,
+		disabled:    disabled,
+		disabledAt:  disabledAt,
+		firstSeenAt: firstSeenAt,
+		lastSeenAt:  lastSeenAt,
+	}
+}
+
+func (a *AccountStatus) UserID() UserID {
+	return a.userID
+}
+
+func (a *AccountStatus) Username() string {
+	return a.username
+}
+
+func (a *AccountStatus) Email() string {
+	return a.email
+}
+
+func (a *AccountStatus) IsDisabled() bool {
+	return a.disabled
+}
+
+func (a *AccountStatus) DisabledAt() *time.Time {
+	return a.disabledAt
+}
+
+func (a *AccountStatus) FirstSeenAt() time.Time {
+	return a.firstSeenAt
+}
+
+func (a *AccountStatus) LastSeenAt() time.Time {
+	return a.lastSeenAt
+}
+
+// Seen refreshes the snapshot of the user's details on a new request
+func (a *AccountStatus) Seen(user *User, now time.Time) {
+	if a.firstSeenAt.IsZero() {
+		a.firstSeenAt = now
+	}
+	a.username = user.Username().String()
+	a.email = user.EmailAddress().String()
+	a.lastSeenAt = now
+}
+
+func (a *AccountStatus) Disable(now time.Time) {
+	if a.disabled {
+		return
+	}
+	a.disabled = true
+	a.disabledAt = &now
+}
+
+func (a *AccountStatus) Enable() {
+	a.disabled = false
+	a.disabledAt = nil
+}
+
+// UserUsage is how much one user stores: the collections they own and the
+// containers, objects and photos in them.
+type UserUsage struct {
+	UserID      UserID
+	Collections int
+	Containers  int
+	Objects     int
+	MediaCount  int
+	MediaBytes  int64
+}
+
+// SystemStats totals the whole deployment.
+type SystemStats struct {
+	Users         int
+	DisabledUsers int
+	Collections   int
+	Containers    int
+	Objects       int
+	MediaCount    int
+	MediaBytes    int64
+}
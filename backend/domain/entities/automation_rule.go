@@ -0,0 +1,344 @@
+package entities
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAutomationRuleID   = errors.New("invalid automation rule ID")
+	ErrInvalidAutomationRuleName = errors.New("automation rule name must be between 1 and 100 characters")
+	ErrInvalidAutomationTrigger  = errors.New("invalid automation trigger")
+	ErrInvalidAutomationAction   = errors.New("invalid automation action")
+	ErrAutomationRuleNotFound    = errors.New("automation rule not found")
+	ErrTooManyAutomationRules    = errors.New("too many automation rules")
+)
+
+const (
+	// MaxAutomationRules caps the rules one user can have
+	MaxAutomationRules = 50
+	// MaxAutomationActions caps the actions one rule can run
+	MaxAutomationActions = 5
+	// maxAutomationExpiryDays is the widest expiry window a rule can watch
+	maxAutomationExpiryDays = 365
+)
+
+type AutomationRuleID struct {
+	value string
+}
+
+func NewAutomationRuleID() AutomationRuleID {
+	return AutomationRuleID{value: uuid.New().String()}
+}
+
+func AutomationRuleIDFromString(id string) (AutomationRuleID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return AutomationRuleID{}, ErrInvalidAutomationRuleID
+	}
+	return AutomationRuleID{value: id}, nil
+}
+
+func (id AutomationRuleID) String() string {
+	return id.value
+}
+
+func (id AutomationRuleID) Equals(other AutomationRuleID) bool {
+	return id.value == other.value
+}
+
+// AutomationTriggerKind is what happens to an object to set a rule off.
+type AutomationTriggerKind string
+
+const (
+	TriggerObjectCreated AutomationTriggerKind = "object_created"
+	TriggerTagAdded      AutomationTriggerKind = "tag_added"
+	// TriggerQuantityBelow fires when the quantity drops under the
+	// threshold, not on every change while it stays there
+	TriggerQuantityBelow AutomationTriggerKind = "quantity_below"
+	// TriggerExpiresWithin fires once, when the object comes within the
+	// given number of days of its expiry date
+	TriggerExpiresWithin AutomationTriggerKind = "expires_within"
+)
+
+func IsValidAutomationTriggerKind(kind string) bool {
+	switch AutomationTriggerKind(kind) {
+	case TriggerObjectCreated, TriggerTagAdded, TriggerQuantityBelow, TriggerExpiresWithin:
+		return true
+	}
+	return false
+}
+
+// AutomationTrigger says which object events set a rule off. Only the field
+// of its kind is used: Tag for tag_added, Threshold for quantity_below and
+// Days for expires_within.
+type AutomationTrigger struct {
+	Kind      AutomationTriggerKind
+	Tag       string
+	Threshold float64
+	Days      int
+	// CollectionID limits the rule to one collection; nil watches them all
+	CollectionID *CollectionID
+}
+
+func (t AutomationTrigger) Validate() error {
+	switch t.Kind {
+	case TriggerObjectCreated:
+	case TriggerTagAdded:
+		if strings.TrimSpace(t.Tag) == "" {
+			return ErrInvalidAutomationTrigger
+		}
+	case TriggerQuantityBelow:
+		if t.Threshold <= 0 {
+			return ErrInvalidAutomationTrigger
+		}
+	case TriggerExpiresWithin:
+		if t.Days < 0 || t.Days > maxAutomationExpiryDays {
+			return ErrInvalidAutomationTrigger
+		}
+	default:
+		return ErrInvalidAutomationTrigger
+	}
+	return nil
+}
+
+// Matches reports whether event sets the trigger off.
+func (t AutomationTrigger) Matches(event ObjectEvent) bool {
+	if t.CollectionID != nil && !t.CollectionID.Equals(event.CollectionID) {
+		return false
+	}
+	object := event.Object
+	switch t.Kind {
+	case TriggerObjectCreated:
+		return event.Kind == ObjectCreated
+	case TriggerTagAdded:
+		return event.Kind != ObjectExpiring && slices.Contains(event.AddedTags(), t.Tag)
+	case TriggerQuantityBelow:
+		if event.Kind == ObjectExpiring || object.Quantity() == nil || *object.Quantity() >= t.Threshold {
+			return false
+		}
+		return event.PreviousQuantity == nil || *event.PreviousQuantity >= t.Threshold
+	case TriggerExpiresWithin:
+		// The window's start moves with time, so the scheduler reports the
+		// span it last checked and the object matches if its window opened
+		// during it
+		if event.Kind != ObjectExpiring || object.ExpiresAt() == nil || object.IsArchived() {
+			return false
+		}
+		opens := object.ExpiresAt().AddDate(0, 0, -t.Days)
+		return opens.After(event.Since) && !opens.After(event.At)
+	}
+	return false
+}
+
+// AutomationActionKind is what a rule does to the object that set it off.
+type AutomationActionKind string
+
+const (
+	ActionAddTag AutomationActionKind = "add_tag"
+	// ActionMoveToContainer moves the object within its collection
+	ActionMoveToContainer AutomationActionKind = "move_to_container"
+	// ActionAddToShoppingList marks the object's recurring staple due,
+	// making the object a monthly staple first if it is not one
+	ActionAddToShoppingList AutomationActionKind = "add_to_shopping_list"
+	// ActionFireWebhook posts the event as JSON to a URL
+	ActionFireWebhook AutomationActionKind = "fire_webhook"
+)
+
+func IsValidAutomationActionKind(kind string) bool {
+	switch AutomationActionKind(kind) {
+	case ActionAddTag, ActionMoveToContainer, ActionAddToShoppingList, ActionFireWebhook:
+		return true
+	}
+	return false
+}
+
+// AutomationAction is one step a rule runs. Only the fields of its kind are
+// used: Tag for add_tag, ContainerID for move_to_container, and URL, Secret
+// and Retry for fire_webhook.
+type AutomationAction struct {
+	Kind        AutomationActionKind
+	Tag         string
+	ContainerID *ContainerID
+	URL         string
+	// Secret signs webhook bodies so the receiver can tell they are ours;
+	// empty signs them with the account's signing keys, if it has any
+	Secret string
+	// Retry says how a webhook that fails is tried again
+	Retry WebhookRetryPolicy
+}
+
+func (a AutomationAction) Validate() error {
+	switch a.Kind {
+	case ActionAddTag:
+		if strings.TrimSpace(a.Tag) == "" {
+			return ErrInvalidAutomationAction
+		}
+	case ActionMoveToContainer:
+		if a.ContainerID == nil {
+			return ErrInvalidAutomationAction
+		}
+	case ActionAddToShoppingList:
+	case ActionFireWebhook:
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidAutomationAction
+		}
+		if err := a.Retry.Validate(); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidAutomationAction
+	}
+	return nil
+}
+
+// AutomationRule runs its actions on objects in the owner's collections
+// whenever its trigger fires. Rules only see changes the owner makes, and
+// what a rule does never sets off another rule.
+type AutomationRule struct {
+	id        AutomationRuleID
+	userID    UserID
+	name      string
+	enabled   bool
+	trigger   AutomationTrigger
+	actions   []AutomationAction
+	lastRunAt *time.Time
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type AutomationRuleProps struct {
+	Name    string
+	Enabled bool
+	Trigger AutomationTrigger
+	Actions []AutomationAction
+}
+
+func NewAutomationRule(userID UserID, props AutomationRuleProps, now time.Time) (*AutomationRule, error) {
+	if err := validateAutomationRule(&props); err != nil {
+		return nil, err
+	}
+	return &AutomationRule{
+		id:        NewAutomationRuleID(),
+		userID:    userID,
+		name:      props.Name,
+		enabled:   props.Enabled,
+		trigger:   props.Trigger,
+		actions:   props.Actions,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructAutomationRule(id AutomationRuleID, userID UserID, props AutomationRuleProps, lastRunAt *time.Time, createdAt, updatedAt time.Time) *AutomationRule {
+	return &AutomationRule{
+		id:        id,
+		userID:    userID,
+		name:      props.Name,
+		enabled:   props.Enabled,
+		trigger:   props.Trigger,
+		actions:   props.Actions,
+		lastRunAt: lastRunAt,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// validateAutomationRule checks props, trimming the name and tags in place
+func validateAutomationRule(props *AutomationRuleProps) error {
+	props.Name = strings.TrimSpace(props.Name)
+	if props.Name == "" || len(props.Name) > 100 {
+		return ErrInvalidAutomationRuleName
+	}
+	props.Trigger.Tag = strings.TrimSpace(props.Trigger.Tag)
+	if err := props.Trigger.Validate(); err != nil {
+		return err
+	}
+	if len(props.Actions) == 0 || len(props.Actions) > MaxAutomationActions {
+		return ErrInvalidAutomationAction
+	}
+	for i := range props.Actions {
+		props.Actions[i].Tag = strings.TrimSpace(props.Actions[i].Tag)
+		if err := props.Actions[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AutomationRule) ID() AutomationRuleID {
+	return r.id
+}
+
+func (r *AutomationRule) UserID() UserID {
+	return r.userID
+}
+
+func (r *AutomationRule) Name() string {
+	return r.name
+}
+
+func (r *AutomationRule) Enabled() bool {
+	return r.enabled
+}
+
+func (r *AutomationRule) Trigger() AutomationTrigger {
+	return r.trigger
+}
+
+func (r *AutomationRule) Actions() []AutomationAction {
+	return slices.Clone(r.actions)
+}
+
+// LastRunAt is when the rule last fired, nil if it never has
+func (r *AutomationRule) LastRunAt() *time.Time {
+	return r.lastRunAt
+}
+
+func (r *AutomationRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *AutomationRule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *AutomationRule) IsOwnedBy(userID UserID) bool {
+	return r.userID.Equals(userID)
+}
+
+// Matches reports whether the rule should run for event.
+func (r *AutomationRule) Matches(event ObjectEvent) bool {
+	return r.enabled && r.userID.Equals(event.UserID) && r.trigger.Matches(event)
+}
+
+// Update replaces the rule's settings. A webhook sent without a secret
+// keeps the secret it had for the same URL, so the editor can save a rule
+// without being shown its secrets.
+func (r *AutomationRule) Update(props AutomationRuleProps, now time.Time) error {
+	for i, action := range props.Actions {
+		if action.Kind != ActionFireWebhook || action.Secret != "" {
+			continue
+		}
+		for _, old := range r.actions {
+			if old.Kind == ActionFireWebhook && old.URL == action.URL {
+				props.Actions[i].Secret = old.Secret
+				break
+			}
+		}
+	}
+	if err := validateAutomationRule(&props); err != nil {
+		return err
+	}
+	r.name = props.Name
+	r.enabled = props.Enabled
+	r.trigger = props.Trigger
+	r.actions = props.Actions
+	r.updatedAt = now
+	return nil
+}
@@ -11,6 +11,7 @@ var (
 	ErrInvalidCollectionID   = errors.New("invalid collection ID")
 	ErrInvalidCollectionName = errors.New("collection name must be between 1 and 255 characters")
 	ErrContainerNotFound     = errors.New("container not found in collection")
+	ErrGroupOwnerRequired    = errors.New("a group-owned collection requires a group")
 )
 
 type CollectionID struct {
@@ -62,6 +63,7 @@ type Collection struct {
 	id             CollectionID
 	userID         UserID   // Owner of the collection
 	groupID        *GroupID // Optional group for sharing this collection
+	groupOwned     bool     // The group, not userID, owns the collection
 	name           CollectionName
 	categoryID     *CategoryID // Optional category for this collection
 	objectType     ObjectType  // Type of objects this collection holds
@@ -69,6 +71,8 @@ type Collection struct {
 	tags           []string
 	location       string
 	propertySchema *PropertySchema // Optional typed schema for object properties
+	shelfLife      []ShelfLife     // Default shelf life of new food objects by category
+	floorPlan      *FloorPlan      // Optional image the containers are placed on
 	createdAt      time.Time
 	updatedAt      time.Time
 }
@@ -76,6 +80,7 @@ type Collection struct {
 type CollectionProps struct {
 	UserID         UserID
 	GroupID        *GroupID
+	GroupOwned     bool
 	Name           CollectionName
 	CategoryID     *CategoryID
 	ObjectType     ObjectType
@@ -85,11 +90,15 @@ type CollectionProps struct {
 }
 
 func NewCollection(props CollectionProps) (*Collection, error) {
+	if props.GroupOwned && props.GroupID == nil {
+		return nil, ErrGroupOwnerRequired
+	}
 	now := time.Now()
 	return &Collection{
 		id:             NewCollectionID(),
 		userID:         props.UserID,
 		groupID:        props.GroupID,
+		groupOwned:     props.GroupOwned,
 		name:           props.Name,
 		categoryID:     props.CategoryID,
 		objectType:     props.ObjectType,
@@ -102,13 +111,14 @@ func NewCollection(props CollectionProps) (*Collection, error) {
 	}, nil
 }
 
-func ReconstructCollection(id CollectionID, userID UserID, groupID *GroupID, name CollectionName,
+func ReconstructCollection(id CollectionID, userID UserID, groupID *GroupID, groupOwned bool, name CollectionName,
 	categoryID *CategoryID, objectType ObjectType, containers []Container, tags []string, location string,
-	propertySchema *PropertySchema, createdAt, updatedAt time.Time) *Collection {
+	propertySchema *PropertySchema, shelfLife []ShelfLife, floorPlan *FloorPlan, createdAt, updatedAt time.Time) *Collection {
 	return &Collection{
 		id:             id,
 		userID:         userID,
 		groupID:        groupID,
+		groupOwned:     groupOwned,
 		name:           name,
 		categoryID:     categoryID,
 		objectType:     objectType,
@@ -116,6 +126,8 @@ func ReconstructCollection(id CollectionID, userID UserID, groupID *GroupID, nam
 		tags:           tags,
 		location:       location,
 		propertySchema: propertySchema,
+		shelfLife:      shelfLife,
+		floorPlan:      floorPlan,
 		createdAt:      createdAt,
 		updatedAt:      updatedAt,
 	}
@@ -133,6 +145,35 @@ func (c *Collection) GroupID() *GroupID {
 	return c.groupID
 }
 
+// IsGroupOwned reports whether the collection belongs to its group rather
+// than to an account. UserID then only records who created or transferred
+// it and grants nothing on its own, so the collection stays with the group
+// when that member leaves.
+func (c *Collection) IsGroupOwned() bool {
+	return c.groupOwned
+}
+
+// IsOwnedBy reports whether userID personally owns the collection.
+func (c *Collection) IsOwnedBy(userID UserID) bool {
+	return !c.groupOwned && c.userID.Equals(userID)
+}
+
+// TransferToGroup hands ownership to groupID, which also becomes the group
+// the collection is shared with.
+func (c *Collection) TransferToGroup(groupID GroupID, transferredBy UserID) {
+	c.groupID = &groupID
+	c.groupOwned = true
+	c.userID = transferredBy
+	c.updatedAt = time.Now()
+}
+
+// TransferToUser hands ownership to userID. Any group sharing is kept.
+func (c *Collection) TransferToUser(userID UserID) {
+	c.userID = userID
+	c.groupOwned = false
+	c.updatedAt = time.Now()
+}
+
 func (c *Collection) Name() CollectionName {
 	return c.name
 }
@@ -235,6 +276,42 @@ func (c *Collection) UpdatePropertySchema(schema *PropertySchema) {
 	c.updatedAt = time.Now()
 }
 
+func (c *Collection) ShelfLife() []ShelfLife {
+	return c.shelfLife
+}
+
+// UpdateShelfLife replaces the collection's default shelf life rules. Objects
+// that already have an expiry are not touched.
+func (c *Collection) UpdateShelfLife(rules []ShelfLife) error {
+	if err := ValidateShelfLives(rules); err != nil {
+		return err
+	}
+	c.shelfLife = rules
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// DefaultExpiry returns when a food object added at from expires under the
+// collection's shelf life rules, matched on its category property or tags.
+// It returns nil for other object types or when no rule applies.
+func (c *Collection) DefaultExpiry(objectType ObjectType, props map[string]TypedValue, tags []string, from time.Time) *time.Time {
+	if objectType != ObjectTypeFood || len(c.shelfLife) == 0 {
+		return nil
+	}
+	return shelfLifeExpiry(c.shelfLife, props, tags, from)
+}
+
+func (c *Collection) FloorPlan() *FloorPlan {
+	return c.floorPlan
+}
+
+// UpdateFloorPlan replaces the collection's floor plan; nil removes it.
+// Container placements are kept.
+func (c *Collection) UpdateFloorPlan(plan *FloorPlan) {
+	c.floorPlan = plan
+	c.updatedAt = time.Now()
+}
+
 func (c *Collection) UpdateCategory(categoryID *CategoryID) error {
 	c.categoryID = categoryID
 	c.updatedAt = time.Now()
@@ -269,6 +346,14 @@ func (c *Collection) TotalObjectCount() int {
 	return count
 }
 
+func (c *Collection) LowStockCount() int {
+	count := 0
+	for _, container := range c.containers {
+		count += container.LowStockCount()
+	}
+	return count
+}
+
 func (c *Collection) Equals(other *Collection) bool {
 	if other == nil {
 		return false
@@ -0,0 +1,251 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCollectionFolderDepth bounds how deep folders nest, counting a top-level
+// folder as depth 1.
+const MaxCollectionFolderDepth = 5
+
+var (
+	ErrInvalidCollectionFolderID   = errors.New("invalid collection folder ID")
+	ErrInvalidCollectionFolderName = errors.New("folder name must be between 1 and 100 characters")
+	ErrCollectionFolderNotFound    = errors.New("collection folder not found")
+	ErrCollectionFolderCycle       = errors.New("a folder cannot be moved into itself or one of its subfolders")
+	ErrCollectionFolderTooDeep     = fmt.Errorf("folders can be nested at most %d deep", MaxCollectionFolderDepth)
+)
+
+type CollectionFolderID struct {
+	value string
+}
+
+func NewCollectionFolderID() CollectionFolderID {
+	return CollectionFolderID{value: uuid.New().String()}
+}
+
+func CollectionFolderIDFromString(id string) (CollectionFolderID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return CollectionFolderID{}, ErrInvalidCollectionFolderID
+	}
+	return CollectionFolderID{value: id}, nil
+}
+
+func (id CollectionFolderID) String() string {
+	return id.value
+}
+
+func (id CollectionFolderID) Equals(other CollectionFolderID) bool {
+	return id.value == other.value
+}
+
+// CollectionFolderStats totals the collections in a folder and all of its
+// subfolders. Archived objects are not counted.
+type CollectionFolderStats struct {
+	Folders     int
+	Collections int
+	Containers  int
+	Objects     int
+	LowStock    int
+	// ExpiringSoon counts objects expiring within the next week; Expired
+	// counts those already past their date.
+	ExpiringSoon int
+	Expired      int
+}
+
+// CollectionFolder groups collections in one user's collection list, e.g. a
+// "Kitchen" folder holding the pantry and freezer. Folders are personal: the
+// same shared collection can sit in a different folder for each member.
+type CollectionFolder struct {
+	id            CollectionFolderID
+	userID        UserID
+	name          string
+	parentID      *CollectionFolderID
+	collectionIDs []CollectionID
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func NewCollectionFolder(userID UserID, name string, parentID *CollectionFolderID) (*CollectionFolder, error) {
+	now := time.Now()
+	folder := &CollectionFolder{
+		id:        NewCollectionFolderID(),
+		userID:    userID,
+		parentID:  parentID,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := folder.setName(name); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+func ReconstructCollectionFolder(id CollectionFolderID, userID UserID, name string, parentID *CollectionFolderID, collectionIDs []CollectionID, createdAt, updatedAt time.Time) *CollectionFolder {
+	return &CollectionFolder{
+		id:            id,
+		userID:        userID,
+		name:          name,
+		parentID:      parentID,
+		collectionIDs: collectionIDs,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+func (f *CollectionFolder) ID() CollectionFolderID {
+	return f.id
+}
+
+func (f *CollectionFolder) UserID() UserID {
+	return f.userID
+}
+
+func (f *CollectionFolder) Name() string {
+	return f.name
+}
+
+// ParentID is the folder this one is nested in, or nil at the top level
+func (f *CollectionFolder) ParentID() *CollectionFolderID {
+	return f.parentID
+}
+
+func (f *CollectionFolder) CollectionIDs() []CollectionID {
+	return f.collectionIDs
+}
+
+func (f *CollectionFolder) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+func (f *CollectionFolder) UpdatedAt() time.Time {
+	return f.updatedAt
+}
+
+func (f *CollectionFolder) IsOwnedBy(userID UserID) bool {
+	return f.userID.Equals(userID)
+}
+
+func (f *CollectionFolder) Rename(name string) error {
+	if err := f.setName(name); err != nil {
+		return err
+	}
+	f.updatedAt = time.Now()
+	return nil
+}
+
+// MoveTo nests the folder in parentID, or moves it to the top level when
+// parentID is nil. Use ValidateFolderParent first to rule out cycles.
+func (f *CollectionFolder) MoveTo(parentID *CollectionFolderID) {
+	f.parentID = parentID
+	f.updatedAt = time.Now()
+}
+
+func (f *CollectionFolder) HasCollection(id CollectionID) bool {
+	return slices.ContainsFunc(f.collectionIDs, id.Equals)
+}
+
+// AddCollection files a collection in the folder. Adding one that is already
+// there is a no-op.
+func (f *CollectionFolder) AddCollection(id CollectionID) {
+	if f.HasCollection(id) {
+		return
+	}
+	f.collectionIDs = append(f.collectionIDs, id)
+	f.updatedAt = time.Now()
+}
+
+// RemoveCollection takes a collection out of the folder and reports whether
+// it was there.
+func (f *CollectionFolder) RemoveCollection(id CollectionID) bool {
+	i := slices.IndexFunc(f.collectionIDs, id.Equals)
+	if i < 0 {
+		return false
+	}
+	f.collectionIDs = slices.Delete(f.collectionIDs, i, i+1)
+	f.updatedAt = time.Now()
+	return true
+}
+
+func (f *CollectionFolder) setName(name string) error {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > 100 {
+		return ErrInvalidCollectionFolderName
+	}
+	f.name = name
+	return nil
+}
+
+// ValidateFolderParent checks that folderID can be nested in parentID among
+// the user's folders: the parent must exist, must not be the folder or one of
+// its subfolders, and the result must stay within MaxCollectionFolderDepth. A
+// zero folderID stands for a folder that does not exist yet.
+func ValidateFolderParent(folders []*CollectionFolder, folderID CollectionFolderID, parentID *CollectionFolderID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	byID := make(map[string]*CollectionFolder, len(folders))
+	for _, f := range folders {
+		byID[f.ID().String()] = f
+	}
+	if _, ok := byID[parentID.String()]; !ok {
+		return ErrCollectionFolderNotFound
+	}
+
+	// Walk up from the parent; meeting the folder itself means a cycle
+	depth := 1
+	for id := parentID; id != nil; depth++ {
+		if id.Equals(folderID) {
+			return ErrCollectionFolderCycle
+		}
+		parent, ok := byID[id.String()]
+		if !ok || depth > len(folders) {
+			break
+		}
+		id = parent.ParentID()
+	}
+
+	if depth+folderSubtreeHeight(folders, folderID)-1 > MaxCollectionFolderDepth {
+		return ErrCollectionFolderTooDeep
+	}
+	return nil
+}
+
+// folderSubtreeHeight is 1 for a folder without subfolders, 2 when its
+// deepest subfolder is a direct child, and so on
+func folderSubtreeHeight(folders []*CollectionFolder, folderID CollectionFolderID) int {
+	height := 1
+	for _, f := range folders {
+		if p := f.ParentID(); p != nil && p.Equals(folderID) && !f.ID().Equals(folderID) {
+			height = max(height, 1+folderSubtreeHeight(folders, f.ID()))
+		}
+	}
+	return height
+}
+
+// FolderDescendants returns the IDs of every folder nested under folderID,
+// at any depth
+func FolderDescendants(folders []*CollectionFolder, folderID CollectionFolderID) []CollectionFolderID {
+	var out []CollectionFolderID
+	seen := map[string]bool{folderID.String(): true}
+	queue := []CollectionFolderID{folderID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, f := range folders {
+			if p := f.ParentID(); p != nil && p.Equals(current) && !seen[f.ID().String()] {
+				seen[f.ID().String()] = true
+				out = append(out, f.ID())
+				queue = append(queue, f.ID())
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,359 @@
+package entities
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCollectionSnapshotID = errors.New("invalid snapshot ID")
+	ErrInvalidSnapshotLabel        = errors.New("snapshot label must be at most 100 characters")
+	ErrCollectionSnapshotNotFound  = errors.New("snapshot not found")
+)
+
+type CollectionSnapshotID struct {
+	value string
+}
+
+func NewCollectionSnapshotID() CollectionSnapshotID {
+	return CollectionSnapshotID{value: uuid.New().String()}
+}
+
+func CollectionSnapshotIDFromString(id string) (CollectionSnapshotID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return CollectionSnapshotID{}, ErrInvalidCollectionSnapshotID
+	}
+	return CollectionSnapshotID{value: id}, nil
+}
+
+func (id CollectionSnapshotID) String() string {
+	return id.value
+}
+
+func (id CollectionSnapshotID) Equals(other CollectionSnapshotID) bool {
+	return id.value == other.value
+}
+
+// CollectionSnapshot is a point-in-time copy of a collection's containers
+// and their objects. Snapshots are never edited; restoring one replaces the
+// collection's current tree with the copy.
+type CollectionSnapshot struct {
+	id             CollectionSnapshotID
+	collectionID   CollectionID
+	collectionName string
+	createdBy      UserID
+	label          string
+	containers     []Container
+	createdAt      time.Time
+}
+
+// NewCollectionSnapshot captures the containers currently loaded on
+// collection.
+func NewCollectionSnapshot(collection *Collection, createdBy UserID, label string) (*CollectionSnapshot, error) {
+	label = strings.TrimSpace(label)
+	if len(label) > 100 {
+		return nil, ErrInvalidSnapshotLabel
+	}
+	return &CollectionSnapshot{
+		id:             NewCollectionSnapshotID(),
+		collectionID:   collection.ID(),
+		collectionName: collection.Name().String(),
+		createdBy:      createdBy,
+		label:          label,
+		containers:     slices.Clone(collection.Containers()),
+		createdAt:      time.Now(),
+	}, nil
+}
+
+func ReconstructCollectionSnapshot(id CollectionSnapshotID, collectionID CollectionID, collectionName string, createdBy UserID, label string, containers []Container, createdAt time.Time) *CollectionSnapshot {
+	return &CollectionSnapshot{
+		id:             id,
+		collectionID:   collectionID,
+		collectionName: collectionName,
+		createdBy:      createdBy,
+		label:          label,
+		containers:     containers,
+		createdAt:      createdAt,
+	}
+}
+
+func (s *CollectionSnapshot) ID() CollectionSnapshotID {
+	return s.id
+}
+
+func (s *CollectionSnapshot) CollectionID() CollectionID {
+	return s.collectionID
+}
+
+// CollectionName is the collection's name when the snapshot was taken.
+func (s *CollectionSnapshot) CollectionName() string {
+	return s.collectionName
+}
+
+func (s *CollectionSnapshot) CreatedBy() UserID {
+	return s.createdBy
+}
+
+func (s *CollectionSnapshot) Label() string {
+	return s.label
+}
+
+func (s *CollectionSnapshot) Containers() []Container {
+	return s.containers
+}
+
+func (s *CollectionSnapshot) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *CollectionSnapshot) ContainerCount() int {
+	return len(s.containers)
+}
+
+func (s *CollectionSnapshot) ObjectCount() int {
+	count := 0
+	for _, c := range s.containers {
+		count += len(c.Objects())
+	}
+	return count
+}
+
+// ContainerRef names a container in a diff.
+type ContainerRef struct {
+	ID   ContainerID
+	Name string
+}
+
+// ContainerChange is a container whose own fields differ between two states.
+type ContainerChange struct {
+	Container ContainerRef
+	// Fields lists what changed: name, type, parent, location.
+	Fields []string
+}
+
+// ObjectChange is an object that differs between two states. Before is nil
+// for added objects and After is nil for removed ones.
+type ObjectChange struct {
+	ID              ObjectID
+	Name            string
+	Before          *Object
+	After           *Object
+	BeforeContainer *ContainerRef
+	AfterContainer  *ContainerRef
+	// Fields lists what changed on a modified object: name, description,
+	// container, quantity, unit, min_quantity, location, tags, properties,
+	// expires_at, archived.
+	Fields []string
+}
+
+// CollectionDiff is what changed between two container trees of the same
+// collection.
+type CollectionDiff struct {
+	ContainersAdded   []ContainerRef
+	ContainersRemoved []ContainerRef
+	ContainersChanged []ContainerChange
+	ObjectsAdded      []ObjectChange
+	ObjectsRemoved    []ObjectChange
+	ObjectsChanged    []ObjectChange
+}
+
+// IsEmpty reports whether both states are the same.
+func (d CollectionDiff) IsEmpty() bool {
+	return len(d.ContainersAdded) == 0 && len(d.ContainersRemoved) == 0 && len(d.ContainersChanged) == 0 &&
+		len(d.ObjectsAdded) == 0 && len(d.ObjectsRemoved) == 0 && len(d.ObjectsChanged) == 0
+}
+
+type placedObject struct {
+	object    Object
+	container ContainerRef
+}
+
+// DiffContainers compares two container trees by container and object ID.
+// An object that moved between containers is reported as changed, not as
+// removed and added.
+func DiffContainers(before, after []Container) CollectionDiff {
+	var diff CollectionDiff
+
+	beforeContainers := make(map[string]Container, len(before))
+	for _, c := range before {
+		beforeContainers[c.ID().String()] = c
+	}
+	afterContainers := make(map[string]Container, len(after))
+	for _, c := range after {
+		afterContainers[c.ID().String()] = c
+	}
+
+	for _, c := range after {
+		old, ok := beforeContainers[c.ID().String()]
+		if !ok {
+			diff.ContainersAdded = append(diff.ContainersAdded, containerRef(c))
+			continue
+		}
+		if fields := changedContainerFields(old, c); len(fields) > 0 {
+			diff.ContainersChanged = append(diff.ContainersChanged, ContainerChange{Container: containerRef(c), Fields: fields})
+		}
+	}
+	for _, c := range before {
+		if _, ok := afterContainers[c.ID().String()]; !ok {
+			diff.ContainersRemoved = append(diff.ContainersRemoved, containerRef(c))
+		}
+	}
+
+	beforeObjects := placeObjects(before)
+	afterObjects := placeObjects(after)
+
+	for _, id := range slices.Sorted(maps.Keys(afterObjects)) {
+		now := afterObjects[id]
+		old, ok := beforeObjects[id]
+		if !ok {
+			diff.ObjectsAdded = append(diff.ObjectsAdded, ObjectChange{
+				ID:             now.object.ID(),
+				Name:           now.object.Name().String(),
+				After:          &now.object,
+				AfterContainer: &now.container,
+			})
+			continue
+		}
+		fields := changedObjectFields(&old.object, &now.object)
+		if !old.container.ID.Equals(now.container.ID) {
+			fields = append(fields, "container")
+		}
+		if len(fields) > 0 {
+			diff.ObjectsChanged = append(diff.ObjectsChanged, ObjectChange{
+				ID:              now.object.ID(),
+				Name:            now.object.Name().String(),
+				Before:          &old.object,
+				After:           &now.object,
+				BeforeContainer: &old.container,
+				AfterContainer:  &now.container,
+				Fields:          fields,
+			})
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(beforeObjects)) {
+		if _, ok := afterObjects[id]; ok {
+			continue
+		}
+		old := beforeObjects[id]
+		diff.ObjectsRemoved = append(diff.ObjectsRemoved, ObjectChange{
+			ID:              old.object.ID(),
+			Name:            old.object.Name().String(),
+			Before:          &old.object,
+			BeforeContainer: &old.container,
+		})
+	}
+
+	return diff
+}
+
+func containerRef(c Container) ContainerRef {
+	return ContainerRef{ID: c.ID(), Name: c.Name().String()}
+}
+
+func placeObjects(containers []Container) map[string]placedObject {
+	placed := make(map[string]placedObject)
+	for _, c := range containers {
+		ref := containerRef(c)
+		for _, obj := range c.Objects() {
+			placed[obj.ID().String()] = placedObject{object: obj, container: ref}
+		}
+	}
+	return placed
+}
+
+func changedContainerFields(before, after Container) []string {
+	var fields []string
+	if before.Name().String() != after.Name().String() {
+		fields = append(fields, "name")
+	}
+	if before.ContainerType() != after.ContainerType() {
+		fields = append(fields, "type")
+	}
+	if !equalContainerIDPtr(before.ParentContainerID(), after.ParentContainerID()) {
+		fields = append(fields, "parent")
+	}
+	if before.Location() != after.Location() {
+		fields = append(fields, "location")
+	}
+	return fields
+}
+
+func changedObjectFields(before, after *Object) []string {
+	var fields []string
+	if before.Name().String() != after.Name().String() {
+		fields = append(fields, "name")
+	}
+	if before.Description().String() != after.Description().String() {
+		fields = append(fields, "description")
+	}
+	if !equalFloatPtr(before.Quantity(), after.Quantity()) {
+		fields = append(fields, "quantity")
+	}
+	if before.Unit() != after.Unit() {
+		fields = append(fields, "unit")
+	}
+	if !equalFloatPtr(before.MinQuantity(), after.MinQuantity()) {
+		fields = append(fields, "min_quantity")
+	}
+	if before.Condition() != after.Condition() {
+		fields = append(fields, "condition")
+	}
+	if before.Status() != after.Status() {
+		fields = append(fields, "status")
+	}
+	if before.Location() != after.Location() {
+		fields = append(fields, "location")
+	}
+	if !slices.Equal(before.Tags(), after.Tags()) {
+		fields = append(fields, "tags")
+	}
+	if !equalProperties(before.Properties(), after.Properties()) {
+		fields = append(fields, "properties")
+	}
+	if !equalTimePtr(before.ExpiresAt(), after.ExpiresAt()) {
+		fields = append(fields, "expires_at")
+	}
+	if before.IsArchived() != after.IsArchived() {
+		fields = append(fields, "archived")
+	}
+	return fields
+}
+
+func equalProperties(a, b map[string]TypedValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, av := range a {
+		bv, ok := b[key]
+		if !ok || av.Type != bv.Type || av.DisplayString() != bv.DisplayString() {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalContainerIDPtr(a, b *ContainerID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equals(*b)
+}
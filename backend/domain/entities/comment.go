@@ -0,0 +1,181 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCommentID   = errors.New("invalid comment ID")
+	ErrCommentNotFound    = errors.New("comment not found")
+	ErrInvalidCommentBody = errors.New("comment must be between 1 and 2000 characters")
+	ErrInvalidCommentPage = errors.New("limit must be between 1 and 100")
+)
+
+// Comment pagination bounds.
+const (
+	DefaultCommentPageLimit = 50
+	MaxCommentPageLimit     = 100
+	MaxCommentLength        = 2000
+)
+
+type CommentID struct {
+	value string
+}
+
+func NewCommentID() CommentID {
+	return CommentID{value: uuid.New().String()}
+}
+
+func CommentIDFromString(id string) (CommentID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return CommentID{}, ErrInvalidCommentID
+	}
+	return CommentID{value: id}, nil
+}
+
+func (id CommentID) String() string {
+	return id.value
+}
+
+func (id CommentID) Equals(other CommentID) bool {
+	return id.value == other.value
+}
+
+// Comment is a note left on a collection, or on one object in it, for the
+// people it is shared with. Comments are not edited; the author or a
+// collection manager can delete them.
+type Comment struct {
+	id           CommentID
+	collectionID CollectionID
+	objectID     *ObjectID
+	authorID     UserID
+	authorName   string
+	body         string
+	mentions     []UserID
+	createdAt    time.Time
+}
+
+type CommentProps struct {
+	CollectionID CollectionID
+	ObjectID     *ObjectID // nil for a comment on the collection itself
+	AuthorID     UserID
+	AuthorName   string
+	Body         string
+	// Mentions are the users named with @username in the body, resolved
+	// against the people who can see the collection.
+	Mentions []UserID
+}
+
+func NewComment(props CommentProps) (*Comment, error) {
+	body := strings.TrimSpace(props.Body)
+	if body == "" || utf8.RuneCountInString(body) > MaxCommentLength {
+		return nil, ErrInvalidCommentBody
+	}
+	return &Comment{
+		id:           NewCommentID(),
+		collectionID: props.CollectionID,
+		objectID:     props.ObjectID,
+		authorID:     props.AuthorID,
+		authorName:   props.AuthorName,
+		body:         body,
+		mentions:     slices.Clone(props.Mentions),
+		createdAt:    time.Now(),
+	}, nil
+}
+
+func ReconstructComment(id CommentID, collectionID CollectionID, objectID *ObjectID, authorID UserID, authorName, body string, mentions []UserID, createdAt time.Time) *Comment {
+	return &Comment{
+		id:           id,
+		collectionID: collectionID,
+		objectID:     objectID,
+		authorID:     authorID,
+		authorName:   authorName,
+		body:         body,
+		mentions:     mentions,
+		createdAt:    createdAt,
+	}
+}
+
+func (c *Comment) ID() CommentID {
+	return c.id
+}
+
+func (c *Comment) CollectionID() CollectionID {
+	return c.collectionID
+}
+
+// ObjectID is the object the comment is about, or nil for a comment on the
+// collection.
+func (c *Comment) ObjectID() *ObjectID {
+	return c.objectID
+}
+
+func (c *Comment) AuthorID() UserID {
+	return c.authorID
+}
+
+// AuthorName is the author's username when the comment was posted.
+func (c *Comment) AuthorName() string {
+	return c.authorName
+}
+
+func (c *Comment) Body() string {
+	return c.body
+}
+
+func (c *Comment) Mentions() []UserID {
+	return c.mentions
+}
+
+func (c *Comment) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *Comment) IsAuthoredBy(userID UserID) bool {
+	return c.authorID.Equals(userID)
+}
+
+func (c *Comment) Mentioned(userID UserID) bool {
+	return slices.ContainsFunc(c.mentions, userID.Equals)
+}
+
+// ParseMentions returns the distinct @username tokens of body, lowercased
+// and without the @. A mention starts at the beginning of the body or after
+// a space and runs over letters, digits and . _ - characters; a trailing
+// dot is taken as punctuation.
+func ParseMentions(body string) []string {
+	var names []string
+	for word := range strings.FieldsSeq(body) {
+		name, ok := strings.CutPrefix(word, "@")
+		if !ok {
+			continue
+		}
+		end := strings.IndexFunc(name, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '_' && r != '-'
+		})
+		if end >= 0 {
+			name = name[:end]
+		}
+		name = strings.ToLower(strings.TrimRight(name, "."))
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CommentUnread counts the comments in a collection posted by others since
+// the user last read it.
+type CommentUnread struct {
+	CollectionID CollectionID
+	Count        int
+	// Mentions is how many of the unread comments mention the user.
+	Mentions int
+}
@@ -103,10 +103,15 @@ type Container struct {
 	objects           []Object      // Objects stored in this container
 	location          string        // Physical location within collection
 	// Physical dimensions for capacity planning
-	width     *float64 // Width in inches
-	depth     *float64 // Depth in inches
-	rows      *int     // Number of rows/shelves
-	capacity  *float64 // Total capacity in units
+	width    *float64 // Width in inches
+	depth    *float64 // Depth in inches
+	rows     *int     // Number of rows/shelves
+	capacity *float64 // Total capacity in units
+	// Storage environment, checked against food that must be kept cold
+	temperatureZone TemperatureZone // "" = not specified
+	humidity        *float64        // Relative humidity in percent
+	// Where the container is on the collection's floor plan
+	placement ContainerPlacement
 	createdAt time.Time
 	updatedAt time.Time
 }
@@ -123,6 +128,8 @@ type ContainerProps struct {
 	Depth             *float64
 	Rows              *int
 	Capacity          *float64
+	TemperatureZone   TemperatureZone
+	Humidity          *float64
 }
 
 func NewContainer(props ContainerProps) (*Container, error) {
@@ -136,6 +143,13 @@ func NewContainer(props ContainerProps) (*Container, error) {
 	if !IsValidContainerType(string(containerType)) {
 		return nil, ErrInvalidContainerType
 	}
+	zone, err := ParseTemperatureZone(string(props.TemperatureZone))
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateHumidity(props.Humidity); err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	return &Container{
@@ -152,12 +166,14 @@ func NewContainer(props ContainerProps) (*Container, error) {
 		depth:             props.Depth,
 		rows:              props.Rows,
 		capacity:          props.Capacity,
+		temperatureZone:   zone,
+		humidity:          props.Humidity,
 		createdAt:         now,
 		updatedAt:         now,
 	}, nil
 }
 
-func ReconstructContainer(id ContainerID, collectionID CollectionID, name ContainerName, containerType ContainerType, parentContainerID *ContainerID, categoryID *CategoryID, groupID *GroupID, objects []Object, location string, width, depth *float64, rows *int, capacity *float64, createdAt, updatedAt time.Time) *Container {
+func ReconstructContainer(id ContainerID, collectionID CollectionID, name ContainerName, containerType ContainerType, parentContainerID *ContainerID, categoryID *CategoryID, groupID *GroupID, objects []Object, location string, width, depth *float64, rows *int, capacity *float64, temperatureZone TemperatureZone, humidity *float64, placement ContainerPlacement, createdAt, updatedAt time.Time) *Container {
 	// Default to general type if not specified
 	if containerType == "" {
 		containerType = ContainerTypeGeneral
@@ -177,6 +193,9 @@ func ReconstructContainer(id ContainerID, collectionID CollectionID, name Contai
 		depth:             depth,
 		rows:              rows,
 		capacity:          capacity,
+		temperatureZone:   temperatureZone,
+		humidity:          humidity,
+		placement:         placement,
 		createdAt:         createdAt,
 		updatedAt:         updatedAt,
 	}
@@ -206,6 +225,17 @@ func (c *Container) Objects() []Object {
 	return append([]Object(nil), c.objects...)
 }
 
+// ActiveObjects returns the objects that have not been archived.
+func (c *Container) ActiveObjects() []Object {
+	var active []Object
+	for _, object := range c.objects {
+		if !object.IsArchived() {
+			active = append(active, object)
+		}
+	}
+	return active
+}
+
 func (c *Container) Location() string {
 	return c.location
 }
@@ -242,6 +272,18 @@ func (c *Container) Capacity() *float64 {
 	return c.capacity
 }
 
+func (c *Container) TemperatureZone() TemperatureZone {
+	return c.temperatureZone
+}
+
+func (c *Container) Humidity() *float64 {
+	return c.humidity
+}
+
+func (c *Container) Placement() ContainerPlacement {
+	return c.placement
+}
+
 // IsLeafContainer returns true if this container type cannot have children
 func (c *Container) IsLeafContainer() bool {
 	return c.containerType == ContainerTypeShelf ||
@@ -251,16 +293,21 @@ func (c *Container) IsLeafContainer() bool {
 
 // CanHaveChildren returns true if this container type can have child containers
 func (c *Container) CanHaveChildren() bool {
-	return c.containerType == ContainerTypeRoom ||
-		c.containerType == ContainerTypeBookshelf ||
-		c.containerType == ContainerTypeGeneral
+	return containerTypeCanHaveChildren(c.containerType)
+}
+
+func containerTypeCanHaveChildren(t ContainerType) bool {
+	return t == ContainerTypeRoom ||
+		t == ContainerTypeBookshelf ||
+		t == ContainerTypeGeneral ||
+		t == ""
 }
 
 // CalculateUsedCapacity calculates the currently used capacity based on objects
 func (c *Container) CalculateUsedCapacity() float64 {
 	// For now, each object counts as 1 unit
 	// Can be enhanced to calculate based on object dimensions
-	return float64(len(c.objects))
+	return float64(c.ObjectCount())
 }
 
 // GetCapacityUtilization returns the percentage of capacity used (0-100)
@@ -335,8 +382,28 @@ func (c *Container) GetObject(objectID ObjectID) (*Object, error) {
 	return nil, ErrObjectNotFoundInContainer
 }
 
+// ObjectCount returns the number of owned objects that have not been
+// archived. Wishlist items are left out.
 func (c *Container) ObjectCount() int {
-	return len(c.objects)
+	count := 0
+	for _, object := range c.objects {
+		if object.IsCounted() {
+			count++
+		}
+	}
+	return count
+}
+
+// LowStockCount returns the number of active objects at or below their min
+// quantity.
+func (c *Container) LowStockCount() int {
+	count := 0
+	for _, object := range c.objects {
+		if object.IsCounted() && object.IsLowStock() {
+			count++
+		}
+	}
+	return count
 }
 
 func (c *Container) GetObjectsByType(objectType ObjectType) []Object {
@@ -391,6 +458,28 @@ func (c *Container) UpdateDimensions(width, depth *float64, rows *int, capacity
 	return nil
 }
 
+// UpdateEnvironment sets the temperature zone ("" clears it) and humidity
+func (c *Container) UpdateEnvironment(zone TemperatureZone, humidity *float64) error {
+	zone, err := ParseTemperatureZone(string(zone))
+	if err != nil {
+		return err
+	}
+	if err := ValidateHumidity(humidity); err != nil {
+		return err
+	}
+	c.temperatureZone = zone
+	c.humidity = humidity
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// UpdatePlacement moves the container on the floor plan; the zero
+// placement takes it off
+func (c *Container) UpdatePlacement(placement ContainerPlacement) {
+	c.placement = placement
+	c.updatedAt = time.Now()
+}
+
 func (c *Container) HasCategory() bool {
 	return c.categoryID != nil && !c.categoryID.IsZero()
 }
@@ -0,0 +1,287 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on user-saved templates so one request cannot create an unbounded
+// number of containers.
+const (
+	MaxContainerTemplateNodes = 200
+	MaxContainerTemplateDepth = 6
+)
+
+var (
+	ErrInvalidContainerTemplateID   = errors.New("invalid container template ID")
+	ErrInvalidContainerTemplateName = errors.New("container template name must be between 1 and 100 characters")
+	ErrEmptyContainerTemplate       = errors.New("container template must contain at least one container")
+	ErrContainerTemplateTooLarge    = fmt.Errorf("container template may contain at most %d containers nested %d deep", MaxContainerTemplateNodes, MaxContainerTemplateDepth)
+	ErrContainerTemplateNotFound    = errors.New("container template not found")
+	ErrBuiltInContainerTemplate     = errors.New("built-in container templates cannot be modified")
+)
+
+// builtInTemplatePrefix marks IDs of templates shipped with the server. User
+// templates use UUIDs, so the two can never collide.
+const builtInTemplatePrefix = "builtin:"
+
+type ContainerTemplateID struct {
+	value string
+}
+
+func NewContainerTemplateID() ContainerTemplateID {
+	return ContainerTemplateID{value: uuid.New().String()}
+}
+
+func ContainerTemplateIDFromString(id string) (ContainerTemplateID, error) {
+	if strings.HasPrefix(id, builtInTemplatePrefix) && len(id) > len(builtInTemplatePrefix) {
+		return ContainerTemplateID{value: id}, nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return ContainerTemplateID{}, ErrInvalidContainerTemplateID
+	}
+	return ContainerTemplateID{value: id}, nil
+}
+
+func (id ContainerTemplateID) String() string {
+	return id.value
+}
+
+func (id ContainerTemplateID) Equals(other ContainerTemplateID) bool {
+	return id.value == other.value
+}
+
+// IsBuiltIn reports whether the ID refers to a server-provided template.
+func (id ContainerTemplateID) IsBuiltIn() bool {
+	return strings.HasPrefix(id.value, builtInTemplatePrefix)
+}
+
+// ContainerTemplateNode describes one container to create, and the
+// containers to nest inside it.
+type ContainerTemplateNode struct {
+	Name     string
+	Type     ContainerType
+	Location string
+	Width    *float64
+	Depth    *float64
+	Rows     *int
+	Capacity *float64
+	Children []ContainerTemplateNode
+}
+
+// ContainerTemplate is a reusable container layout. Built-in templates have
+// no owner; user templates belong to the user that saved them.
+type ContainerTemplate struct {
+	id          ContainerTemplateID
+	userID      *UserID
+	name        string
+	description string
+	nodes       []ContainerTemplateNode
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewContainerTemplate(userID UserID, name, description string, nodes []ContainerTemplateNode) (*ContainerTemplate, error) {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > 100 {
+		return nil, ErrInvalidContainerTemplateName
+	}
+	if err := validateTemplateNodes(nodes); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ContainerTemplate{
+		id:          NewContainerTemplateID(),
+		userID:      &userID,
+		name:        name,
+		description: strings.TrimSpace(description),
+		nodes:       nodes,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+func ReconstructContainerTemplate(id ContainerTemplateID, userID *UserID, name, description string, nodes []ContainerTemplateNode, createdAt, updatedAt time.Time) *ContainerTemplate {
+	return &ContainerTemplate{
+		id:          id,
+		userID:      userID,
+		name:        name,
+		description: description,
+		nodes:       nodes,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (t *ContainerTemplate) ID() ContainerTemplateID {
+	return t.id
+}
+
+// UserID returns the owner, or nil for built-in templates.
+func (t *ContainerTemplate) UserID() *UserID {
+	return t.userID
+}
+
+func (t *ContainerTemplate) Name() string {
+	return t.name
+}
+
+func (t *ContainerTemplate) Description() string {
+	return t.description
+}
+
+func (t *ContainerTemplate) Nodes() []ContainerTemplateNode {
+	return t.nodes
+}
+
+func (t *ContainerTemplate) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *ContainerTemplate) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+func (t *ContainerTemplate) IsBuiltIn() bool {
+	return t.userID == nil
+}
+
+// IsOwnedBy reports whether userID saved this template.
+func (t *ContainerTemplate) IsOwnedBy(userID UserID) bool {
+	return t.userID != nil && t.userID.Equals(userID)
+}
+
+// ContainerCount returns the total number of containers the template creates.
+func (t *ContainerTemplate) ContainerCount() int {
+	return countTemplateNodes(t.nodes)
+}
+
+func countTemplateNodes(nodes []ContainerTemplateNode) int {
+	n := len(nodes)
+	for _, node := range nodes {
+		n += countTemplateNodes(node.Children)
+	}
+	return n
+}
+
+func validateTemplateNodes(nodes []ContainerTemplateNode) error {
+	if len(nodes) == 0 {
+		return ErrEmptyContainerTemplate
+	}
+	if countTemplateNodes(nodes) > MaxContainerTemplateNodes {
+		return ErrContainerTemplateTooLarge
+	}
+	return validateTemplateLevel(nodes, 1)
+}
+
+func validateTemplateLevel(nodes []ContainerTemplateNode, depth int) error {
+	if depth > MaxContainerTemplateDepth {
+		return ErrContainerTemplateTooLarge
+	}
+	for _, node := range nodes {
+		if _, err := NewContainerName(node.Name); err != nil {
+			return err
+		}
+		if node.Type != "" && !IsValidContainerType(string(node.Type)) {
+			return ErrInvalidContainerType
+		}
+		if len(node.Children) > 0 && !containerTypeCanHaveChildren(node.Type) {
+			return fmt.Errorf("container %q of type %s cannot have children", node.Name, node.Type)
+		}
+		if err := validateTemplateLevel(node.Children, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuiltInContainerTemplates returns the layouts every user can start from.
+func BuiltInContainerTemplates() []*ContainerTemplate {
+	shelves := make([]ContainerTemplateNode, 0, 6)
+	for _, letter := range "ABCDEF" {
+		shelves = append(shelves, ContainerTemplateNode{Name: "Shelf " + string(letter), Type: ContainerTypeShelf})
+	}
+
+	builtIn := func(slug, name, description string, nodes ...ContainerTemplateNode) *ContainerTemplate {
+		return ReconstructContainerTemplate(
+			ContainerTemplateID{value: builtInTemplatePrefix + slug},
+			nil, name, description, nodes, time.Time{}, time.Time{},
+		)
+	}
+
+	return []*ContainerTemplate{
+		builtIn("kitchen", "Kitchen", "Pantry, fridge and freezer",
+			ContainerTemplateNode{Name: "Pantry", Type: ContainerTypeCabinet},
+			ContainerTemplateNode{Name: "Fridge", Type: ContainerTypeCabinet},
+			ContainerTemplateNode{Name: "Freezer", Type: ContainerTypeCabinet},
+		),
+		builtIn("library", "Library", "A bookshelf with shelves A to F",
+			ContainerTemplateNode{Name: "Bookshelf", Type: ContainerTypeBookshelf, Children: shelves},
+		),
+		builtIn("garage", "Garage", "Workbench, tool cabinet and storage shelving",
+			ContainerTemplateNode{Name: "Workbench", Type: ContainerTypeGeneral},
+			ContainerTemplateNode{Name: "Tool Cabinet", Type: ContainerTypeCabinet},
+			ContainerTemplateNode{Name: "Storage Shelves", Type: ContainerTypeShelf},
+		),
+		builtIn("office", "Office", "Desk drawers, filing cabinet and binders",
+			ContainerTemplateNode{Name: "Desk Drawers", Type: ContainerTypeCabinet},
+			ContainerTemplateNode{Name: "Filing Cabinet", Type: ContainerTypeCabinet},
+			ContainerTemplateNode{Name: "Binders", Type: ContainerTypeGeneral, Children: []ContainerTemplateNode{
+				{Name: "Binder 1", Type: ContainerTypeBinder},
+				{Name: "Binder 2", Type: ContainerTypeBinder},
+			}},
+		),
+	}
+}
+
+// FindBuiltInContainerTemplate looks up a built-in template by ID.
+func FindBuiltInContainerTemplate(id ContainerTemplateID) (*ContainerTemplate, error) {
+	for _, t := range BuiltInContainerTemplates() {
+		if t.id.Equals(id) {
+			return t, nil
+		}
+	}
+	return nil, ErrContainerTemplateNotFound
+}
+
+// ContainerTemplateNodesFromContainers rebuilds the tree rooted at roots from
+// a flat container list, dropping objects and IDs.
+func ContainerTemplateNodesFromContainers(roots []Container, all []Container) []ContainerTemplateNode {
+	children := make(map[string][]Container)
+	for _, c := range all {
+		if c.ParentContainerID() != nil {
+			key := c.ParentContainerID().String()
+			children[key] = append(children[key], c)
+		}
+	}
+
+	var build func(cs []Container, seen map[string]bool) []ContainerTemplateNode
+	build = func(cs []Container, seen map[string]bool) []ContainerTemplateNode {
+		nodes := make([]ContainerTemplateNode, 0, len(cs))
+		for _, c := range cs {
+			// Guard against parent cycles in stored data.
+			if seen[c.ID().String()] {
+				continue
+			}
+			seen[c.ID().String()] = true
+			nodes = append(nodes, ContainerTemplateNode{
+				Name:     c.Name().String(),
+				Type:     c.ContainerType(),
+				Location: c.Location(),
+				Width:    c.Width(),
+				Depth:    c.Depth(),
+				Rows:     c.Rows(),
+				Capacity: c.Capacity(),
+				Children: build(children[c.ID().String()], seen),
+			})
+		}
+		return nodes
+	}
+
+	return build(roots, make(map[string]bool))
+}
@@ -0,0 +1,228 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCustomObjectTypeID = errors.New("invalid custom object type ID")
+	ErrInvalidObjectTypeKey      = errors.New("object type must be 1 to 32 lowercase letters, digits or underscores, starting with a letter")
+	ErrInvalidObjectTypeName     = errors.New("object type name must be between 1 and 50 characters")
+	ErrInvalidObjectTypeIcon     = errors.New("object type icon must be at most 8 characters")
+	ErrInvalidObjectTypeColor    = errors.New("object type color must be a hex color like #3b82f6")
+	ErrBuiltInObjectType         = errors.New("object type is built in and cannot be redefined")
+	ErrCustomObjectTypeNotFound  = errors.New("custom object type not found")
+	ErrCustomObjectTypeExists    = errors.New("a custom object type with this key already exists")
+	ErrCustomObjectTypeInUse     = errors.New("custom object type is used by a collection")
+	ErrUnknownObjectType         = errors.New("unknown object type: use a built-in type or define a custom one")
+)
+
+var (
+	objectTypeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+	hexColorPattern      = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// IsBuiltIn reports whether the type is one of AllObjectTypes rather than an
+// account's custom type
+func (ot ObjectType) IsBuiltIn() bool {
+	return slices.Contains(AllObjectTypes, ot)
+}
+
+// ValidateObjectTypeKey checks that ot could name an object type: a built-in
+// one or a well-formed custom key. Whether a custom key is defined for the
+// account is checked where collections are created.
+func ValidateObjectTypeKey(ot ObjectType) error {
+	if ot.IsBuiltIn() || objectTypeKeyPattern.MatchString(string(ot)) {
+		return nil
+	}
+	return ErrInvalidObjectTypeKey
+}
+
+// ObjectTypeKeyFromName derives a key from a display name, e.g. "Wine
+// Bottles" becomes "wine_bottles". The result may still be invalid, such as
+// for a name without letters.
+func ObjectTypeKeyFromName(name string) ObjectType {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			underscore = false
+			b.WriteRune(r)
+		default:
+			underscore = true
+		}
+		if b.Len() >= 32 {
+			break
+		}
+	}
+	return ObjectType(b.String())
+}
+
+type CustomObjectTypeID struct {
+	value string
+}
+
+func NewCustomObjectTypeID() CustomObjectTypeID {
+	return CustomObjectTypeID{value: uuid.New().String()}
+}
+
+func CustomObjectTypeIDFromString(id string) (CustomObjectTypeID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return CustomObjectTypeID{}, ErrInvalidCustomObjectTypeID
+	}
+	return CustomObjectTypeID{value: id}, nil
+}
+
+func (id CustomObjectTypeID) String() string {
+	return id.value
+}
+
+func (id CustomObjectTypeID) Equals(other CustomObjectTypeID) bool {
+	return id.value == other.value
+}
+
+// CustomObjectType is an object type an account defines beyond the built-in
+// ones, e.g. "wine" with a bottle icon and vintage and region fields.
+// Collections refer to it by key, which is fixed once created; the schema is
+// copied into collections created with the type.
+type CustomObjectType struct {
+	id        CustomObjectTypeID
+	userID    UserID
+	key       ObjectType
+	name      string
+	icon      string
+	color     string
+	schema    *PropertySchema
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewCustomObjectType defines a type for userID. An empty key is derived from
+// the name.
+func NewCustomObjectType(userID UserID, key ObjectType, name, icon, color string, schema *PropertySchema) (*CustomObjectType, error) {
+	if key == "" {
+		key = ObjectTypeKeyFromName(name)
+	}
+	if key.IsBuiltIn() {
+		return nil, ErrBuiltInObjectType
+	}
+	if !objectTypeKeyPattern.MatchString(string(key)) {
+		return nil, ErrInvalidObjectTypeKey
+	}
+
+	now := time.Now()
+	t := &CustomObjectType{
+		id:        NewCustomObjectTypeID(),
+		userID:    userID,
+		key:       key,
+		schema:    schema,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := t.setDetails(name, icon, color); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func ReconstructCustomObjectType(id CustomObjectTypeID, userID UserID, key ObjectType, name, icon, color string, schema *PropertySchema, createdAt, updatedAt time.Time) *CustomObjectType {
+	return &CustomObjectType{
+		id:        id,
+		userID:    userID,
+		key:       key,
+		name:      name,
+		icon:      icon,
+		color:     color,
+		schema:    schema,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (t *CustomObjectType) ID() CustomObjectTypeID {
+	return t.id
+}
+
+func (t *CustomObjectType) UserID() UserID {
+	return t.userID
+}
+
+// Key is the object_type value collections and objects of this type carry
+func (t *CustomObjectType) Key() ObjectType {
+	return t.key
+}
+
+func (t *CustomObjectType) Name() string {
+	return t.name
+}
+
+// Icon is a short symbol, usually an emoji, shown next to the name
+func (t *CustomObjectType) Icon() string {
+	return t.icon
+}
+
+// Color is a "#rrggbb" hex color, or "" for the app's default
+func (t *CustomObjectType) Color() string {
+	return t.color
+}
+
+// Schema is the field schema new collections of this type start with
+func (t *CustomObjectType) Schema() *PropertySchema {
+	return t.schema
+}
+
+func (t *CustomObjectType) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *CustomObjectType) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+func (t *CustomObjectType) IsOwnedBy(userID UserID) bool {
+	return t.userID.Equals(userID)
+}
+
+// Update changes the display details of the type; its key stays the same
+func (t *CustomObjectType) Update(name, icon, color string) error {
+	if err := t.setDetails(name, icon, color); err != nil {
+		return err
+	}
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// SetSchema replaces the field schema. Collections already created with the
+// type keep the schema they were given.
+func (t *CustomObjectType) SetSchema(schema *PropertySchema) {
+	t.schema = schema
+	t.updatedAt = time.Now()
+}
+
+func (t *CustomObjectType) setDetails(name, icon, color string) error {
+	name = strings.TrimSpace(name)
+	if n := utf8.RuneCountInString(name); n < 1 || n > 50 {
+		return ErrInvalidObjectTypeName
+	}
+	icon = strings.TrimSpace(icon)
+	if utf8.RuneCountInString(icon) > 8 {
+		return ErrInvalidObjectTypeIcon
+	}
+	color = strings.ToLower(strings.TrimSpace(color))
+	if color != "" && !hexColorPattern.MatchString(color) {
+		return ErrInvalidObjectTypeColor
+	}
+	t.name, t.icon, t.color = name, icon, color
+	return nil
+}
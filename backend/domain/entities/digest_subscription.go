@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidDigestFrequency     = errors.New("digest frequency must be one of: off, daily, weekly")
+	ErrDigestSubscriptionNotFound = errors.New("digest subscription not found")
+)
+
+// DigestFrequency controls how often a user receives the summary email.
+type DigestFrequency string
+
+const (
+	DigestFrequencyOff    DigestFrequency = "off"
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// AllDigestFrequencies contains every valid DigestFrequency value.
+var AllDigestFrequencies = []DigestFrequency{
+	DigestFrequencyOff,
+	DigestFrequencyDaily,
+	DigestFrequencyWeekly,
+}
+
+func NewDigestFrequency(value string) (DigestFrequency, error) {
+	for _, f := range AllDigestFrequencies {
+		if string(f) == value {
+			return f, nil
+		}
+	}
+	return "", ErrInvalidDigestFrequency
+}
+
+func (f DigestFrequency) String() string {
+	return string(f)
+}
+
+// Period returns the interval between digests, or zero when disabled.
+func (f DigestFrequency) Period() time.Duration {
+	switch f {
+	case DigestFrequencyDaily:
+		return 24 * time.Hour
+	case DigestFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// DigestSubscription stores a user's email digest preferences. Group
+// memberships come from the user's JWT, which is unavailable to the background
+// sender, so the IDs are snapshotted whenever the user saves their preferences.
+type DigestSubscription struct {
+	userID           UserID
+	email            EmailAddress
+	frequency        DigestFrequency
+	groupIDs         []GroupID
+	unsubscribeToken string
+	lastSentAt       *time.Time
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+func NewDigestSubscription(userID UserID, email EmailAddress, frequency DigestFrequency, groupIDs []GroupID) (*DigestSubscription, error) {
+	token, err := newUnsubscribeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &DigestSubscription{
+		userID:           userID,
+		email:            email,
+		frequency:        frequency,
+		groupIDs:         groupIDs,
+		unsubscribeToken: token,
+		createdAt:        now,
+		updatedAt:        now,
+	}, nil
+}
+
+func ReconstructDigestSubscription(userID UserID, email EmailAddress, frequency DigestFrequency, groupIDs []GroupID, unsubscribeToken string, lastSentAt *time.Time, createdAt, updatedAt time.Time) *DigestSubscription {
+	return &DigestSubscription{
+		userID:           userID,
+		email:            email,
+		frequency:        frequency,
+		groupIDs:         groupIDs,
+		unsubscribeToken: unsubscribeToken,
+		lastSentAt:       lastSentAt,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+	}
+}
+
+func (s *DigestSubscription) UserID() UserID {
+	return s.userID
+}
+
+func (s *DigestSubscription) Email() EmailAddress {
+	return s.email
+}
+
+func (s *DigestSubscription) Frequency() DigestFrequency {
+	return s.frequency
+}
+
+func (s *DigestSubscription) GroupIDs() []GroupID {
+	return s.groupIDs
+}
+
+func (s *DigestSubscription) UnsubscribeToken() string {
+	return s.unsubscribeToken
+}
+
+func (s *DigestSubscription) LastSentAt() *time.Time {
+	return s.lastSentAt
+}
+
+func (s *DigestSubscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *DigestSubscription) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// UpdatePreferences replaces the delivery address, frequency and group snapshot.
+func (s *DigestSubscription) UpdatePreferences(email EmailAddress, frequency DigestFrequency, groupIDs []GroupID) {
+	s.email = email
+	s.frequency = frequency
+	s.groupIDs = groupIDs
+	s.updatedAt = time.Now()
+}
+
+// Unsubscribe turns the digest off.
+func (s *DigestSubscription) Unsubscribe() {
+	s.frequency = DigestFrequencyOff
+	s.updatedAt = time.Now()
+}
+
+// MarkSent records a successful delivery.
+func (s *DigestSubscription) MarkSent(at time.Time) {
+	s.lastSentAt = &at
+	s.updatedAt = time.Now()
+}
+
+// IsDue reports whether a digest should be sent at now. A small slack keeps a
+// scheduler that ticks slightly early from skipping a whole period.
+func (s *DigestSubscription) IsDue(now time.Time) bool {
+	period := s.frequency.Period()
+	if period == 0 {
+		return false
+	}
+	if s.lastSentAt == nil {
+		return true
+	}
+	const slack = 15 * time.Minute
+	return now.Sub(*s.lastSentAt) >= period-slack
+}
+
+// Since returns the start of the window the next digest covers.
+func (s *DigestSubscription) Since(now time.Time) time.Time {
+	if s.lastSentAt != nil {
+		return *s.lastSentAt
+	}
+	return now.Add(-s.frequency.Period())
+}
+
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxExpirySamples is how many of a category's most recent expiry dates are
+// kept; older ones stop counting towards the suggestion.
+const MaxExpirySamples = 20
+
+// ExpiryCategory is the category an object's expiry is remembered under:
+// its category property, else its first tag, lowercased. It is empty when
+// the object has neither.
+func ExpiryCategory(props map[string]TypedValue, tags []string) string {
+	if tv, ok := props[ShelfLifeCategoryProperty]; ok {
+		if category, ok := tv.Val.(string); ok && strings.TrimSpace(category) != "" {
+			return strings.ToLower(strings.TrimSpace(category))
+		}
+	}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			return strings.ToLower(tag)
+		}
+	}
+	return ""
+}
+
+// ExpiryDays is how many whole days after from an object expiring at
+// expires keeps. It is false when the expiry is not 1 to MaxShelfLifeDays
+// days out, which is not worth learning from.
+func ExpiryDays(from, expires time.Time) (int, bool) {
+	days := int(math.Round(expires.Sub(from).Hours() / 24))
+	if days < 1 || days > MaxShelfLifeDays {
+		return 0, false
+	}
+	return days, true
+}
+
+// ExpiryHistory is how long a user's objects of one category were set to
+// keep, most recent last.
+type ExpiryHistory struct {
+	userID    UserID
+	category  string
+	days      []int
+	updatedAt time.Time
+}
+
+func ReconstructExpiryHistory(userID UserID, category string, days []int, updatedAt time.Time) *ExpiryHistory {
+	return &ExpiryHistory{
+		userID:    userID,
+		category:  category,
+		days:      days,
+		updatedAt: updatedAt,
+	}
+}
+
+func (h *ExpiryHistory) UserID() UserID {
+	return h.userID
+}
+
+func (h *ExpiryHistory) Category() string {
+	return h.category
+}
+
+func (h *ExpiryHistory) Days() []int {
+	return h.days
+}
+
+func (h *ExpiryHistory) UpdatedAt() time.Time {
+	return h.updatedAt
+}
+
+// SuggestedDays is the median of the recorded shelf lives, taking the
+// shorter of the middle two so an even split errs towards eating food
+// early. It is 0 when nothing is recorded.
+func (h *ExpiryHistory) SuggestedDays() int {
+	if len(h.days) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(h.days)
+	slices.Sort(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Features the code checks. Each has a default for when neither app.toml
+// nor an admin sets it.
+const (
+	FeatureMealPlanner = "meal_planner"
+)
+
+// DefaultFeatures holds the built-in features and whether they are on for
+// everyone until configured otherwise. Features that already shipped
+// default to on, so adding a flag never takes them away.
+var DefaultFeatures = map[string]bool{
+	FeatureMealPlanner: true,
+}
+
+// MaxFeatureFlagTargets caps the users and groups one flag lists
+const MaxFeatureFlagTargets = 500
+
+var (
+	ErrInvalidFeatureName    = errors.New("feature name must be 1-64 lowercase letters, digits and underscores, starting with a letter")
+	ErrTooManyFeatureTargets = errors.New("a feature flag can list at most 500 users and 500 groups")
+	ErrFeatureFlagNotFound   = errors.New("feature flag not found")
+	ErrFeatureNotEnabled     = errors.New("feature not enabled")
+)
+
+var featureNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidFeatureName reports whether name can name a feature flag
+func ValidFeatureName(name string) bool {
+	return featureNamePattern.MatchString(name)
+}
+
+// FeatureFlag turns a feature on for everyone, or only for the users and
+// groups it lists. Flags come from app.toml or are set by an admin; an
+// admin's flag replaces the configured one of the same name.
+type FeatureFlag struct {
+	name        string
+	description string
+	enabled     bool
+	// users holds user IDs or usernames
+	users []string
+	// groups holds group names, as listed in the token's groups claim
+	groups    []string
+	updatedAt time.Time
+}
+
+type FeatureFlagProps struct {
+	Name        string
+	Description string
+	Enabled     bool
+	Users       []string
+	Groups      []string
+}
+
+func NewFeatureFlag(props FeatureFlagProps, now time.Time) (*FeatureFlag, error) {
+	if !ValidFeatureName(props.Name) {
+		return nil, ErrInvalidFeatureName
+	}
+	users := compactTargets(props.Users)
+	groups := compactTargets(props.Groups)
+	if len(users) > MaxFeatureFlagTargets || len(groups) > MaxFeatureFlagTargets {
+		return nil, ErrTooManyFeatureTargets
+	}
+	return &FeatureFlag{
+		name:        props.Name,
+		description: props.Description,
+		enabled:     props.Enabled,
+		users:       users,
+		groups:      groups,
+		updatedAt:   now,
+	}, nil
+}
+
+func ReconstructFeatureFlag(props FeatureFlagProps, updatedAt time.Time) *FeatureFlag {
+	return &FeatureFlag{
+		name:        props.Name,
+		description: props.Description,
+		enabled:     props.Enabled,
+		users:       props.Users,
+		groups:      props.Groups,
+		updatedAt:   updatedAt,
+	}
+}
+
+// compactTargets drops blanks and duplicates and sorts what is left
+func compactTargets(targets []string) []string {
+	out := make([]string, 0, len(targets))
+	for _, t := range targets {
+		if t != "" {
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return slices.Compact(out)
+}
+
+func (f *FeatureFlag) Name() string {
+	return f.name
+}
+
+func (f *FeatureFlag) Description() string {
+	return f.description
+}
+
+// Enabled reports whether the feature is on for everyone
+func (f *FeatureFlag) Enabled() bool {
+	return f.enabled
+}
+
+func (f *FeatureFlag) Users() []string {
+	return f.users
+}
+
+func (f *FeatureFlag) Groups() []string {
+	return f.groups
+}
+
+// UpdatedAt is when an admin last set the flag; zero for configured flags
+func (f *FeatureFlag) UpdatedAt() time.Time {
+	return f.updatedAt
+}
+
+// EnabledFor reports whether the feature is on for user, a member of the
+// named groups: on for everyone, or listing the user's ID, username or one
+// of the groups.
+func (f *FeatureFlag) EnabledFor(user *User, groups []string) bool {
+	if f.enabled {
+		return true
+	}
+	if slices.Contains(f.users, user.ID().String()) || slices.Contains(f.users, user.Username().String()) {
+		return true
+	}
+	return slices.ContainsFunc(groups, func(g string) bool { return slices.Contains(f.groups, g) })
+}
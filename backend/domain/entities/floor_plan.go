@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrFloorPlanNotFound        = errors.New("collection has no floor plan")
+	ErrInvalidContainerPosition = errors.New("position needs both x and y, each between 0 and 1")
+	ErrInvalidPlacementLabel    = errors.New("floor and room must be at most 100 characters")
+)
+
+// MaxPlacementLabelLength caps a placement's floor and room names.
+const MaxPlacementLabelLength = 100
+
+// FloorPlan is the image a collection's containers are placed on, for
+// example a sketch of a house or a photo of a garage wall. The image is kept
+// by the media storage like a photo; FloorPlan records where it is served.
+type FloorPlan struct {
+	mediaID     MediaID
+	contentType string
+	size        int64
+	width       int
+	height      int
+	url         string
+	uploadedBy  UserID
+	uploadedAt  time.Time
+}
+
+type FloorPlanProps struct {
+	MediaID     MediaID
+	ContentType string
+	Size        int64
+	Width       int
+	Height      int
+	URL         string
+	UploadedBy  UserID
+}
+
+func NewFloorPlan(props FloorPlanProps, now time.Time) (*FloorPlan, error) {
+	if !IsSupportedMediaType(props.ContentType) {
+		return nil, ErrUnsupportedMediaType
+	}
+	return ReconstructFloorPlan(props, now), nil
+}
+
+func ReconstructFloorPlan(props FloorPlanProps, uploadedAt time.Time) *FloorPlan {
+	return &FloorPlan{
+		mediaID:     props.MediaID,
+		contentType: props.ContentType,
+		size:        props.Size,
+		width:       props.Width,
+		height:      props.Height,
+		url:         props.URL,
+		uploadedBy:  props.UploadedBy,
+		uploadedAt:  uploadedAt,
+	}
+}
+
+// MediaID names the image in the media storage.
+func (f *FloorPlan) MediaID() MediaID {
+	return f.mediaID
+}
+
+func (f *FloorPlan) ContentType() string {
+	return f.contentType
+}
+
+func (f *FloorPlan) Size() int64 {
+	return f.size
+}
+
+func (f *FloorPlan) Width() int {
+	return f.width
+}
+
+func (f *FloorPlan) Height() int {
+	return f.height
+}
+
+func (f *FloorPlan) URL() string {
+	return f.url
+}
+
+func (f *FloorPlan) UploadedBy() UserID {
+	return f.uploadedBy
+}
+
+func (f *FloorPlan) UploadedAt() time.Time {
+	return f.uploadedAt
+}
+
+// ContainerPlacement is where a container is on its collection's floor
+// plan. X and Y are fractions of the plan's width and height measured from
+// its top left corner, so they hold when the image is shown at another size
+// or replaced by one of the same layout. Floor and room name the spot, and
+// are all there is for collections without a plan. The zero value is an
+// unplaced container.
+type ContainerPlacement struct {
+	X     *float64
+	Y     *float64
+	Floor string
+	Room  string
+}
+
+// NewContainerPlacement validates a placement; floor and room are trimmed.
+func NewContainerPlacement(x, y *float64, floor, room string) (ContainerPlacement, error) {
+	if (x == nil) != (y == nil) {
+		return ContainerPlacement{}, ErrInvalidContainerPosition
+	}
+	if x != nil && (*x < 0 || *x > 1 || *y < 0 || *y > 1) {
+		return ContainerPlacement{}, ErrInvalidContainerPosition
+	}
+	floor, room = strings.TrimSpace(floor), strings.TrimSpace(room)
+	if len(floor) > MaxPlacementLabelLength || len(room) > MaxPlacementLabelLength {
+		return ContainerPlacement{}, ErrInvalidPlacementLabel
+	}
+	return ContainerPlacement{X: x, Y: y, Floor: floor, Room: room}, nil
+}
+
+// HasPosition reports whether the container is pinned on the floor plan.
+func (p ContainerPlacement) HasPosition() bool {
+	return p.X != nil && p.Y != nil
+}
+
+// IsZero reports whether the container has not been placed at all.
+func (p ContainerPlacement) IsZero() bool {
+	return !p.HasPosition() && p.Floor == "" && p.Room == ""
+}
@@ -8,6 +8,9 @@ import (
 var (
 	ErrInvalidGroupID   = errors.New("invalid group ID")
 	ErrInvalidGroupName = errors.New("group name must be between 1 and 255 characters")
+	ErrInvalidGroupRole = errors.New("role must be member or admin")
+	ErrNotGroupAdmin    = errors.New("only group admins can manage members")
+	ErrNotGroupMember   = errors.New("user is not a member of the group")
 )
 
 type GroupID struct {
@@ -72,6 +75,27 @@ func (d GroupDescription) Equals(other GroupDescription) bool {
 	return d.value == other.value
 }
 
+// GroupRole is what a member may do within a group. Admins manage roles;
+// every other member is a plain member.
+type GroupRole string
+
+const (
+	GroupRoleMember GroupRole = "member"
+	GroupRoleAdmin  GroupRole = "admin"
+)
+
+func ParseGroupRole(role string) (GroupRole, error) {
+	switch GroupRole(role) {
+	case GroupRoleMember, GroupRoleAdmin:
+		return GroupRole(role), nil
+	}
+	return "", ErrInvalidGroupRole
+}
+
+func (r GroupRole) String() string {
+	return string(r)
+}
+
 // Group represents an Authentik group for sharing collections
 // This is a lightweight entity mainly for authentication/authorization
 type Group struct {
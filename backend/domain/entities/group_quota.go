@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrGroupQuotaNotFound  = errors.New("group quota not found")
+	ErrInvalidGroupQuota   = errors.New("quota limits cannot be negative")
+	ErrGroupQuotaExceeded  = errors.New("group quota exceeded")
+	ErrNotGroupQuotaAdmin  = errors.New("only group admins can change quotas")
+	ErrNotGroupQuotaMember = errors.New("only group members can see its quota")
+)
+
+// QuotaResource is one of the things a group quota limits.
+type QuotaResource string
+
+const (
+	QuotaContainers QuotaResource = "containers"
+	QuotaObjects    QuotaResource = "objects"
+	QuotaStorage    QuotaResource = "storage_bytes"
+)
+
+// GroupUsage is what a group's collections hold: their containers, the
+// objects in those, and the bytes of photos attached to either.
+type GroupUsage struct {
+	Containers   int
+	Objects      int
+	StorageBytes int64
+}
+
+// Used returns the usage of resource.
+func (u GroupUsage) Used(resource QuotaResource) int64 {
+	switch resource {
+	case QuotaContainers:
+		return int64(u.Containers)
+	case QuotaObjects:
+		return int64(u.Objects)
+	case QuotaStorage:
+		return u.StorageBytes
+	}
+	return 0
+}
+
+// GroupQuota caps what the collections shared with a group may hold, so a
+// busy household or club cannot fill the server. A limit of zero means
+// unlimited.
+type GroupQuota struct {
+	groupID         GroupID
+	maxContainers   int
+	maxObjects      int
+	maxStorageBytes int64
+	updatedBy       UserID
+	updatedAt       time.Time
+}
+
+type GroupQuotaProps struct {
+	GroupID         GroupID
+	MaxContainers   int
+	MaxObjects      int
+	MaxStorageBytes int64
+	UpdatedBy       UserID
+}
+
+func NewGroupQuota(props GroupQuotaProps) (*GroupQuota, error) {
+	if props.GroupID.IsZero() {
+		return nil, ErrInvalidGroupID
+	}
+	if props.MaxContainers < 0 || props.MaxObjects < 0 || props.MaxStorageBytes < 0 {
+		return nil, ErrInvalidGroupQuota
+	}
+	return &GroupQuota{
+		groupID:         props.GroupID,
+		maxContainers:   props.MaxContainers,
+		maxObjects:      props.MaxObjects,
+		maxStorageBytes: props.MaxStorageBytes,
+		updatedBy:       props.UpdatedBy,
+		updatedAt:       time.Now(),
+	}, nil
+}
+
+func ReconstructGroupQuota(groupID GroupID, maxContainers, maxObjects int, maxStorageBytes int64, updatedBy UserID, updatedAt time.Time) *GroupQuota {
+	return &GroupQuota{
+		groupID:         groupID,
+		maxContainers:   maxContainers,
+		maxObjects:      maxObjects,
+		maxStorageBytes: maxStorageBytes,
+		updatedBy:       updatedBy,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (q *GroupQuota) GroupID() GroupID {
+	return q.groupID
+}
+
+func (q *GroupQuota) MaxContainers() int {
+	return q.maxContainers
+}
+
+func (q *GroupQuota) MaxObjects() int {
+	return q.maxObjects
+}
+
+func (q *GroupQuota) MaxStorageBytes() int64 {
+	return q.maxStorageBytes
+}
+
+func (q *GroupQuota) UpdatedBy() UserID {
+	return q.updatedBy
+}
+
+func (q *GroupQuota) UpdatedAt() time.Time {
+	return q.updatedAt
+}
+
+// Limit returns the limit on resource, zero when it is unlimited.
+func (q *GroupQuota) Limit(resource QuotaResource) int64 {
+	switch resource {
+	case QuotaContainers:
+		return int64(q.maxContainers)
+	case QuotaObjects:
+		return int64(q.maxObjects)
+	case QuotaStorage:
+		return q.maxStorageBytes
+	}
+	return 0
+}
+
+// Allow checks that adding more of resource to usage stays within the
+// limit, returning a *QuotaExceededError when it would not.
+func (q *GroupQuota) Allow(usage GroupUsage, resource QuotaResource, adding int64) error {
+	limit := q.Limit(resource)
+	used := usage.Used(resource)
+	if limit == 0 || used+adding <= limit {
+		return nil
+	}
+	return &QuotaExceededError{
+		GroupID:   q.groupID,
+		Resource:  resource,
+		Limit:     limit,
+		Used:      used,
+		Requested: adding,
+	}
+}
+
+// QuotaExceededError is returned when creating something would take a
+// group past its quota. It matches ErrGroupQuotaExceeded with errors.Is.
+type QuotaExceededError struct {
+	GroupID   GroupID
+	Resource  QuotaResource
+	Limit     int64
+	Used      int64
+	Requested int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s limit is %d, %d used, %d more requested", ErrGroupQuotaExceeded, e.Resource, e.Limit, e.Used, e.Requested)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrGroupQuotaExceeded
+}
@@ -0,0 +1,227 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidInboxItemID   = errors.New("invalid inbox item ID")
+	ErrInboxItemNotFound    = errors.New("inbox item not found")
+	ErrInboxAddressNotFound = errors.New("unknown inbox address")
+	ErrInboxItemEmpty       = errors.New("no items were recognised in the email")
+	ErrInboxFull            = errors.New("inbox is full; confirm or dismiss some items first")
+)
+
+// MaxPendingInboxItems is how many forwarded emails a user may have waiting
+// to be confirmed or dismissed.
+const MaxPendingInboxItems = 100
+
+type InboxItemID struct {
+	value string
+}
+
+func NewInboxItemID() InboxItemID {
+	return InboxItemID{value: uuid.New().String()}
+}
+
+func InboxItemIDFromString(id string) (InboxItemID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return InboxItemID{}, ErrInvalidInboxItemID
+	}
+	return InboxItemID{value: id}, nil
+}
+
+func (id InboxItemID) String() string {
+	return id.value
+}
+
+func (id InboxItemID) Equals(other InboxItemID) bool {
+	return id.value == other.value
+}
+
+// InboxLine is one item read from a forwarded receipt. Quantity and Price
+// are nil when the receipt does not give them; Price is the line's amount
+// as printed.
+type InboxLine struct {
+	Name     string
+	Quantity *float64
+	Unit     string
+	Price    *float64
+}
+
+// InboxItem is a forwarded order confirmation or receipt waiting for its
+// user to pick which lines become objects, and where.
+type InboxItem struct {
+	id         InboxItemID
+	userID     UserID
+	sender     string
+	subject    string
+	lines      []InboxLine
+	receivedAt time.Time
+}
+
+func NewInboxItem(userID UserID, sender, subject string, lines []InboxLine) (*InboxItem, error) {
+	if len(lines) == 0 {
+		return nil, ErrInboxItemEmpty
+	}
+	return &InboxItem{
+		id:         NewInboxItemID(),
+		userID:     userID,
+		sender:     sender,
+		subject:    subject,
+		lines:      lines,
+		receivedAt: time.Now(),
+	}, nil
+}
+
+func ReconstructInboxItem(id InboxItemID, userID UserID, sender, subject string, lines []InboxLine, receivedAt time.Time) *InboxItem {
+	return &InboxItem{
+		id:         id,
+		userID:     userID,
+		sender:     sender,
+		subject:    subject,
+		lines:      lines,
+		receivedAt: receivedAt,
+	}
+}
+
+func (i *InboxItem) ID() InboxItemID {
+	return i.id
+}
+
+func (i *InboxItem) UserID() UserID {
+	return i.userID
+}
+
+func (i *InboxItem) Sender() string {
+	return i.sender
+}
+
+func (i *InboxItem) Subject() string {
+	return i.subject
+}
+
+func (i *InboxItem) Lines() []InboxLine {
+	return i.lines
+}
+
+func (i *InboxItem) ReceivedAt() time.Time {
+	return i.receivedAt
+}
+
+// ReplaceLines keeps lines as the ones still waiting, after the others
+// were turned into objects.
+func (i *InboxItem) ReplaceLines(lines []InboxLine) error {
+	if len(lines) == 0 {
+		return ErrInboxItemEmpty
+	}
+	i.lines = lines
+	return nil
+}
+
+func (i *InboxItem) IsOwnedBy(userID UserID) bool {
+	return i.userID.Equals(userID)
+}
+
+// InboxAddress is a user's private token for the shared inbox address.
+// Mail to base+token@domain lands in that user's inbox, so the token is
+// the credential and can be reset if the address leaks.
+type InboxAddress struct {
+	userID    UserID
+	token     string
+	createdAt time.Time
+}
+
+func NewInboxAddress(userID UserID) (*InboxAddress, error) {
+	token, err := newInboxToken()
+	if err != nil {
+		return nil, err
+	}
+	return &InboxAddress{
+		userID:    userID,
+		token:     token,
+		createdAt: time.Now(),
+	}, nil
+}
+
+func ReconstructInboxAddress(userID UserID, token string, createdAt time.Time) *InboxAddress {
+	return &InboxAddress{
+		userID:    userID,
+		token:     token,
+		createdAt: createdAt,
+	}
+}
+
+func (a *InboxAddress) UserID() UserID {
+	return a.userID
+}
+
+func (a *InboxAddress) Token() string {
+	return a.token
+}
+
+func (a *InboxAddress) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// Reset replaces the token, so mail to the old address is no longer
+// accepted.
+func (a *InboxAddress) Reset() error {
+	token, err := newInboxToken()
+	if err != nil {
+		return err
+	}
+	a.token = token
+	a.createdAt = time.Now()
+	return nil
+}
+
+// Address is the address the user forwards to, given the server's base
+// inbox address such as inbox@example.com.
+func (a *InboxAddress) Address(base string) string {
+	local, domain, ok := strings.Cut(base, "@")
+	if !ok {
+		return ""
+	}
+	return local + "+" + a.token + "@" + domain
+}
+
+// InboxTokenFromRecipient finds the token in whichever of the recipients,
+// a comma-separated address list, is base with a +token. Domains compare
+// case-insensitively.
+func InboxTokenFromRecipient(recipients, base string) (string, bool) {
+	baseLocal, baseDomain, ok := strings.Cut(base, "@")
+	if !ok {
+		return "", false
+	}
+	addrs, err := mail.ParseAddressList(recipients)
+	if err != nil {
+		return "", false
+	}
+	for _, addr := range addrs {
+		local, domain, ok := strings.Cut(addr.Address, "@")
+		if !ok || !strings.EqualFold(domain, baseDomain) {
+			continue
+		}
+		prefix, token, ok := strings.Cut(local, "+")
+		if ok && strings.EqualFold(prefix, baseLocal) && token != "" {
+			return strings.ToLower(token), true
+		}
+	}
+	return "", false
+}
+
+func newInboxToken() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
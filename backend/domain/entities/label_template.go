@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrUnknownLabelTemplate = errors.New("unknown label template")
+
+// LabelTemplate is a label stock that object labels are printed on. Sizes
+// are in millimetres, width along the direction the text runs.
+type LabelTemplate struct {
+	Key    string
+	Name   string
+	Width  float64
+	Height float64
+}
+
+// DefaultLabelTemplate is used until the account picks another.
+const DefaultLabelTemplate = "dymo-30252"
+
+// LabelTemplates are the label stocks labels can be printed on.
+var LabelTemplates = []LabelTemplate{
+	{Key: "dymo-30252", Name: "Dymo 30252 Address", Width: 89, Height: 28},
+	{Key: "dymo-30336", Name: "Dymo 30336 Multi-purpose", Width: 54, Height: 25},
+	{Key: "dymo-11354", Name: "Dymo 11354 Multi-purpose", Width: 57, Height: 32},
+	{Key: "brother-dk-11201", Name: "Brother DK-11201 Address", Width: 90, Height: 29},
+	{Key: "brother-dk-11204", Name: "Brother DK-11204 Multi-purpose", Width: 54, Height: 17},
+	{Key: "brother-dk-11209", Name: "Brother DK-11209 Small address", Width: 62, Height: 29},
+}
+
+// FindLabelTemplate returns the template with the key, DefaultLabelTemplate
+// for an empty one.
+func FindLabelTemplate(key string) (LabelTemplate, error) {
+	if key == "" {
+		key = DefaultLabelTemplate
+	}
+	i := slices.IndexFunc(LabelTemplates, func(t LabelTemplate) bool { return t.Key == key })
+	if i < 0 {
+		return LabelTemplate{}, ErrUnknownLabelTemplate
+	}
+	return LabelTemplates[i], nil
+}
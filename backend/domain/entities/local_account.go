@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrLocalAccountNotFound = errors.New("local account not found")
+	ErrLocalAccountExists   = errors.New("username is already taken")
+)
+
+// LocalAccount is a username/password account kept by Nishiki itself, for
+// the local auth mode that runs without an identity provider. Only the
+// password hash is stored.
+type LocalAccount struct {
+	userID       UserID
+	username     Username
+	emailAddress EmailAddress
+	passwordHash string
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func NewLocalAccount(username Username, emailAddress EmailAddress, passwordHash string) *LocalAccount {
+	now := time.Now()
+	return &LocalAccount{
+		userID:       NewUserID(),
+		username:     username,
+		emailAddress: emailAddress,
+		passwordHash: passwordHash,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+}
+
+func ReconstructLocalAccount(userID UserID, username Username, emailAddress EmailAddress, passwordHash string, createdAt, updatedAt time.Time) *LocalAccount {
+	return &LocalAccount{
+		userID:       userID,
+		username:     username,
+		emailAddress: emailAddress,
+		passwordHash: passwordHash,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+// NormalizeLocalUsername is the form usernames are looked up by, so "Alice"
+// and "alice" are the same account.
+func NormalizeLocalUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func (a *LocalAccount) UserID() UserID {
+	return a.userID
+}
+
+func (a *LocalAccount) Username() Username {
+	return a.username
+}
+
+func (a *LocalAccount) EmailAddress() EmailAddress {
+	return a.emailAddress
+}
+
+func (a *LocalAccount) PasswordHash() string {
+	return a.passwordHash
+}
+
+func (a *LocalAccount) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *LocalAccount) UpdatedAt() time.Time {
+	return a.updatedAt
+}
+
+// User returns the user the account signs in as.
+func (a *LocalAccount) User() *User {
+	return ReconstructUser(a.userID, a.username, a.emailAddress, a.userID.String(), a.createdAt, a.updatedAt)
+}
@@ -0,0 +1,264 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidMealPlanID     = errors.New("invalid meal plan ID")
+	ErrInvalidMealType       = errors.New("meal must be one of breakfast, lunch, dinner or snack")
+	ErrInvalidRecipeName     = errors.New("recipe name must be between 1 and 200 characters")
+	ErrInvalidMealIngredient = errors.New("ingredient quantity must be greater than zero")
+	ErrMealPlanNotFound      = errors.New("meal plan not found")
+	ErrMealPlanCompleted     = errors.New("meal plan is already completed")
+)
+
+// MealPlanDateLayout is the wire format of a meal plan's date.
+const MealPlanDateLayout = "2006-01-02"
+
+type MealPlanID struct {
+	value string
+}
+
+func NewMealPlanID() MealPlanID {
+	return MealPlanID{value: uuid.New().String()}
+}
+
+func MealPlanIDFromString(id string) (MealPlanID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return MealPlanID{}, ErrInvalidMealPlanID
+	}
+	return MealPlanID{value: id}, nil
+}
+
+func (id MealPlanID) String() string {
+	return id.value
+}
+
+func (id MealPlanID) Equals(other MealPlanID) bool {
+	return id.value == other.value
+}
+
+type MealType string
+
+const (
+	MealTypeBreakfast MealType = "breakfast"
+	MealTypeLunch     MealType = "lunch"
+	MealTypeDinner    MealType = "dinner"
+	MealTypeSnack     MealType = "snack"
+)
+
+// MealTypes lists the meals in the order they happen during a day.
+var MealTypes = []MealType{MealTypeBreakfast, MealTypeLunch, MealTypeDinner, MealTypeSnack}
+
+func IsValidMealType(meal string) bool {
+	for _, m := range MealTypes {
+		if string(m) == meal {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank orders meals within a day; unknown meals sort last.
+func (m MealType) Rank() int {
+	for i, mt := range MealTypes {
+		if mt == m {
+			return i
+		}
+	}
+	return len(MealTypes)
+}
+
+// MealIngredient links a planned meal to an inventory object. Name is a
+// snapshot taken when the plan is saved so the plan stays readable after the
+// object is renamed or deleted.
+type MealIngredient struct {
+	ObjectID ObjectID
+	Name     string
+	Quantity float64
+	Unit     string
+}
+
+// MealPlan is one meal a user plans to cook on a given day.
+type MealPlan struct {
+	id          MealPlanID
+	userID      UserID
+	date        time.Time
+	meal        MealType
+	recipeName  string
+	ingredients []MealIngredient
+	completedAt *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewMealPlan(userID UserID, date time.Time, meal MealType, recipeName string, ingredients []MealIngredient) (*MealPlan, error) {
+	now := time.Now()
+	plan := &MealPlan{
+		id:        NewMealPlanID(),
+		userID:    userID,
+		date:      MealPlanDay(date),
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := plan.setMeal(meal); err != nil {
+		return nil, err
+	}
+	if err := plan.setRecipeName(recipeName); err != nil {
+		return nil, err
+	}
+	if err := plan.setIngredients(ingredients); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func ReconstructMealPlan(id MealPlanID, userID UserID, date time.Time, meal MealType, recipeName string, ingredients []MealIngredient, completedAt *time.Time, createdAt, updatedAt time.Time) *MealPlan {
+	return &MealPlan{
+		id:          id,
+		userID:      userID,
+		date:        date,
+		meal:        meal,
+		recipeName:  recipeName,
+		ingredients: ingredients,
+		completedAt: completedAt,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// MealPlanDay truncates t to midnight UTC of its calendar day, so plans are
+// compared by day regardless of the time or zone they were sent with.
+func MealPlanDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (p *MealPlan) ID() MealPlanID {
+	return p.id
+}
+
+func (p *MealPlan) UserID() UserID {
+	return p.userID
+}
+
+func (p *MealPlan) Date() time.Time {
+	return p.date
+}
+
+func (p *MealPlan) Meal() MealType {
+	return p.meal
+}
+
+func (p *MealPlan) RecipeName() string {
+	return p.recipeName
+}
+
+func (p *MealPlan) Ingredients() []MealIngredient {
+	return p.ingredients
+}
+
+func (p *MealPlan) CompletedAt() *time.Time {
+	return p.completedAt
+}
+
+func (p *MealPlan) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *MealPlan) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+func (p *MealPlan) IsOwnedBy(userID UserID) bool {
+	return p.userID.Equals(userID)
+}
+
+func (p *MealPlan) IsCompleted() bool {
+	return p.completedAt != nil
+}
+
+func (p *MealPlan) UpdateDate(date time.Time) error {
+	if p.IsCompleted() {
+		return ErrMealPlanCompleted
+	}
+	p.date = MealPlanDay(date)
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *MealPlan) UpdateMeal(meal MealType) error {
+	if p.IsCompleted() {
+		return ErrMealPlanCompleted
+	}
+	if err := p.setMeal(meal); err != nil {
+		return err
+	}
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *MealPlan) UpdateRecipeName(name string) error {
+	if p.IsCompleted() {
+		return ErrMealPlanCompleted
+	}
+	if err := p.setRecipeName(name); err != nil {
+		return err
+	}
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *MealPlan) UpdateIngredients(ingredients []MealIngredient) error {
+	if p.IsCompleted() {
+		return ErrMealPlanCompleted
+	}
+	if err := p.setIngredients(ingredients); err != nil {
+		return err
+	}
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// MarkCompleted records that the meal was cooked. A plan can only be
+// completed once so its ingredients are never used up twice.
+func (p *MealPlan) MarkCompleted(at time.Time) error {
+	if p.IsCompleted() {
+		return ErrMealPlanCompleted
+	}
+	p.completedAt = &at
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *MealPlan) setMeal(meal MealType) error {
+	if !IsValidMealType(string(meal)) {
+		return ErrInvalidMealType
+	}
+	p.meal = meal
+	return nil
+}
+
+func (p *MealPlan) setRecipeName(name string) error {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > 200 {
+		return ErrInvalidRecipeName
+	}
+	p.recipeName = name
+	return nil
+}
+
+func (p *MealPlan) setIngredients(ingredients []MealIngredient) error {
+	for _, ing := range ingredients {
+		if ing.Quantity <= 0 {
+			return ErrInvalidMealIngredient
+		}
+	}
+	p.ingredients = ingredients
+	return nil
+}
@@ -0,0 +1,230 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidMediaID            = errors.New("invalid media ID")
+	ErrMediaNotFound             = errors.New("media not found")
+	ErrInvalidMediaOwnerKind     = errors.New("media owner kind must be object or container")
+	ErrUnsupportedMediaType      = errors.New("unsupported media type: only JPEG, PNG and GIF images are accepted")
+	ErrMediaTooLarge             = errors.New("photo is too large")
+	ErrTooManyMedia              = errors.New("too many photos for this object or container")
+	ErrInvalidMediaPage          = errors.New("limit must be between 1 and 100 and offset must not be negative")
+	ErrInvalidMediaFilename      = errors.New("filename must be at most 255 characters")
+	ErrMediaOwnerNotInCollection = errors.New("media owner is not in the collection")
+)
+
+// Media pagination bounds.
+const (
+	DefaultMediaPageLimit = 24
+	MaxMediaPageLimit     = 100
+)
+
+// SupportedMediaTypes lists the image content types that can be uploaded.
+var SupportedMediaTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+type MediaID struct {
+	value string
+}
+
+func NewMediaID() MediaID {
+	return MediaID{value: uuid.New().String()}
+}
+
+func MediaIDFromString(id string) (MediaID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return MediaID{}, ErrInvalidMediaID
+	}
+	return MediaID{value: id}, nil
+}
+
+func (id MediaID) String() string {
+	return id.value
+}
+
+func (id MediaID) Equals(other MediaID) bool {
+	return id.value == other.value
+}
+
+// MediaOwnerKind is the kind of entity a photo is attached to.
+type MediaOwnerKind string
+
+const (
+	MediaOwnerObject    MediaOwnerKind = "object"
+	MediaOwnerContainer MediaOwnerKind = "container"
+)
+
+func NewMediaOwnerKind(kind string) (MediaOwnerKind, error) {
+	switch k := MediaOwnerKind(kind); k {
+	case MediaOwnerObject, MediaOwnerContainer:
+		return k, nil
+	}
+	return "", ErrInvalidMediaOwnerKind
+}
+
+func (k MediaOwnerKind) String() string {
+	return string(k)
+}
+
+// MediaPage selects a window of a media listing.
+type MediaPage struct {
+	Limit  int
+	Offset int
+}
+
+// NewMediaPage validates a page request; a zero limit uses
+// DefaultMediaPageLimit.
+func NewMediaPage(limit, offset int) (MediaPage, error) {
+	if limit == 0 {
+		limit = DefaultMediaPageLimit
+	}
+	if limit < 1 || limit > MaxMediaPageLimit || offset < 0 {
+		return MediaPage{}, ErrInvalidMediaPage
+	}
+	return MediaPage{Limit: limit, Offset: offset}, nil
+}
+
+// Media is a photo attached to an object or a container, for example to
+// document a board game's or a book's condition. The image and its thumbnail
+// are kept by the media storage; Media records where they are served.
+type Media struct {
+	id           MediaID
+	collectionID CollectionID
+	ownerKind    MediaOwnerKind
+	ownerID      string
+	uploadedBy   UserID
+	filename     string
+	contentType  string
+	size         int64
+	width        int
+	height       int
+	url          string
+	thumbnailURL string
+	createdAt    time.Time
+}
+
+type MediaProps struct {
+	ID           MediaID
+	CollectionID CollectionID
+	OwnerKind    MediaOwnerKind
+	OwnerID      string
+	UploadedBy   UserID
+	Filename     string
+	ContentType  string
+	Size         int64
+	Width        int
+	Height       int
+	URL          string
+	ThumbnailURL string
+}
+
+func NewMedia(props MediaProps) (*Media, error) {
+	if !IsSupportedMediaType(props.ContentType) {
+		return nil, ErrUnsupportedMediaType
+	}
+	filename := strings.TrimSpace(props.Filename)
+	if len(filename) > 255 {
+		return nil, ErrInvalidMediaFilename
+	}
+	if props.ID == (MediaID{}) {
+		props.ID = NewMediaID()
+	}
+	return &Media{
+		id:           props.ID,
+		collectionID: props.CollectionID,
+		ownerKind:    props.OwnerKind,
+		ownerID:      props.OwnerID,
+		uploadedBy:   props.UploadedBy,
+		filename:     filename,
+		contentType:  props.ContentType,
+		size:         props.Size,
+		width:        props.Width,
+		height:       props.Height,
+		url:          props.URL,
+		thumbnailURL: props.ThumbnailURL,
+		createdAt:    time.Now(),
+	}, nil
+}
+
+func ReconstructMedia(props MediaProps, createdAt time.Time) *Media {
+	return &Media{
+		id:           props.ID,
+		collectionID: props.CollectionID,
+		ownerKind:    props.OwnerKind,
+		ownerID:      props.OwnerID,
+		uploadedBy:   props.UploadedBy,
+		filename:     props.Filename,
+		contentType:  props.ContentType,
+		size:         props.Size,
+		width:        props.Width,
+		height:       props.Height,
+		url:          props.URL,
+		thumbnailURL: props.ThumbnailURL,
+		createdAt:    createdAt,
+	}
+}
+
+// IsSupportedMediaType reports whether photos of contentType can be uploaded.
+func IsSupportedMediaType(contentType string) bool {
+	return slices.Contains(SupportedMediaTypes, contentType)
+}
+
+func (m *Media) ID() MediaID {
+	return m.id
+}
+
+func (m *Media) CollectionID() CollectionID {
+	return m.collectionID
+}
+
+func (m *Media) OwnerKind() MediaOwnerKind {
+	return m.ownerKind
+}
+
+// OwnerID is the ID of the object or container the photo is attached to.
+func (m *Media) OwnerID() string {
+	return m.ownerID
+}
+
+func (m *Media) UploadedBy() UserID {
+	return m.uploadedBy
+}
+
+func (m *Media) Filename() string {
+	return m.filename
+}
+
+func (m *Media) ContentType() string {
+	return m.contentType
+}
+
+func (m *Media) Size() int64 {
+	return m.size
+}
+
+func (m *Media) Width() int {
+	return m.width
+}
+
+func (m *Media) Height() int {
+	return m.height
+}
+
+func (m *Media) URL() string {
+	return m.url
+}
+
+func (m *Media) ThumbnailURL() string {
+	return m.thumbnailURL
+}
+
+func (m *Media) CreatedAt() time.Time {
+	return m.createdAt
+}
@@ -0,0 +1,226 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+var (
+	ErrNotFoodObject     = errors.New("nutrition facts only apply to food objects")
+	ErrMissingUPC        = errors.New("a UPC is required, either in the request or the object's upc property")
+	ErrInvalidUPC        = errors.New("UPC must be 8 to 14 digits")
+	ErrNutritionNotFound = errors.New("no nutrition facts found for this UPC")
+	ErrNutritionDisabled = errors.New("nutrition lookup is not enabled on this server")
+)
+
+// Property keys of the nutrition block on food objects. Amounts are per
+// serving; servings is how many servings one unit of quantity holds.
+const (
+	PropertyUPC         = "upc"
+	PropertyCalories    = "calories"
+	PropertyProtein     = "protein_g"
+	PropertyCarbs       = "carbs_g"
+	PropertyFat         = "fat_g"
+	PropertyServingSize = "serving_size"
+	PropertyServings    = "servings"
+)
+
+// nutritionDefinitions type the nutrition block so raw values are coerced
+// and checked like schema fields on food objects.
+var nutritionDefinitions = []PropertyDefinition{
+	{Key: PropertyUPC, DisplayName: "UPC", Type: PropertyTypeText},
+	{Key: PropertyCalories, DisplayName: "Calories", Type: PropertyTypeNumeric},
+	{Key: PropertyProtein, DisplayName: "Protein (g)", Type: PropertyTypeNumeric},
+	{Key: PropertyCarbs, DisplayName: "Carbs (g)", Type: PropertyTypeNumeric},
+	{Key: PropertyFat, DisplayName: "Fat (g)", Type: PropertyTypeNumeric},
+	{Key: PropertyServingSize, DisplayName: "Serving size", Type: PropertyTypeText},
+	{Key: PropertyServings, DisplayName: "Servings", Type: PropertyTypeNumeric},
+}
+
+// ForObjectType returns the schema objects of the given type are coerced
+// and validated against: the collection's own schema, plus the nutrition
+// block for food. A field the collection defines itself keeps its
+// definition.
+func (ps *PropertySchema) ForObjectType(objectType ObjectType) *PropertySchema {
+	if objectType != ObjectTypeFood {
+		return ps
+	}
+	merged := &PropertySchema{}
+	if ps != nil {
+		merged.Definitions = append(merged.Definitions, ps.Definitions...)
+	}
+	for _, def := range nutritionDefinitions {
+		if ps.GetDefinition(def.Key) == nil {
+			merged.Definitions = append(merged.Definitions, def)
+		}
+	}
+	return merged
+}
+
+// NutritionFacts is the per-serving nutrition block of a food object.
+// A nil amount is unknown, not zero.
+type NutritionFacts struct {
+	Calories    *float64
+	Protein     *float64
+	Carbs       *float64
+	Fat         *float64
+	ServingSize string   // e.g. "30 g"
+	Servings    *float64 // servings per unit of quantity
+}
+
+// IsEmpty reports whether no nutrition value is known.
+func (n NutritionFacts) IsEmpty() bool {
+	return n.Calories == nil && n.Protein == nil && n.Carbs == nil && n.Fat == nil && n.ServingSize == "" && n.Servings == nil
+}
+
+// NutritionFromProperties reads the nutrition block out of object properties.
+// Values of the wrong type are ignored.
+func NutritionFromProperties(props map[string]TypedValue) NutritionFacts {
+	number := func(key string) *float64 {
+		if v, ok := props[key].Val.(float64); ok {
+			return &v
+		}
+		return nil
+	}
+	n := NutritionFacts{
+		Calories: number(PropertyCalories),
+		Protein:  number(PropertyProtein),
+		Carbs:    number(PropertyCarbs),
+		Fat:      number(PropertyFat),
+		Servings: number(PropertyServings),
+	}
+	if s, ok := props[PropertyServingSize].Val.(string); ok {
+		n.ServingSize = s
+	}
+	return n
+}
+
+// ApplyTo returns a copy of props with the known nutrition values set.
+// Values this block does not know are left as they were.
+func (n NutritionFacts) ApplyTo(props map[string]TypedValue) map[string]TypedValue {
+	result := make(map[string]TypedValue, len(props)+6)
+	maps.Copy(result, props)
+	set := func(key string, v *float64) {
+		if v != nil {
+			result[key] = NewTypedValue(PropertyTypeNumeric, *v)
+		}
+	}
+	set(PropertyCalories, n.Calories)
+	set(PropertyProtein, n.Protein)
+	set(PropertyCarbs, n.Carbs)
+	set(PropertyFat, n.Fat)
+	set(PropertyServings, n.Servings)
+	if n.ServingSize != "" {
+		result[PropertyServingSize] = NewTypedValue(PropertyTypeText, n.ServingSize)
+	}
+	return result
+}
+
+// ValidateNutrition checks the values of a food object's nutrition block
+// beyond their type, which ForObjectType's schema covers: amounts and
+// servings must not be negative and a UPC must look like one. Other object
+// types are not checked, as the keys carry no special meaning there.
+// Returns a list of validation error messages; empty slice means valid.
+func ValidateNutrition(objectType ObjectType, props map[string]TypedValue) []string {
+	if objectType != ObjectTypeFood {
+		return nil
+	}
+	var errs []string
+	for _, def := range nutritionDefinitions {
+		if v, ok := props[def.Key].Val.(float64); ok && v < 0 {
+			errs = append(errs, fmt.Sprintf("property %s must not be negative", def.Key))
+		}
+	}
+	if upc, ok := props[PropertyUPC].Val.(string); ok {
+		if _, err := NormalizeUPC(upc); err != nil {
+			errs = append(errs, "property upc must be 8 to 14 digits")
+		}
+	}
+	return errs
+}
+
+// NormalizeUPC strips spaces and dashes from a UPC/EAN barcode and checks
+// that what is left is 8 to 14 digits.
+func NormalizeUPC(upc string) (string, error) {
+	var b strings.Builder
+	for _, r := range upc {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+		default:
+			return "", ErrInvalidUPC
+		}
+	}
+	if b.Len() < 8 || b.Len() > 14 {
+		return "", ErrInvalidUPC
+	}
+	return b.String(), nil
+}
+
+// AvailableCalories is how many calories the object holds in total:
+// calories per serving × servings per unit × quantity. Servings and
+// quantity default to 1 when unset. ok is false when calories are unknown.
+func (o *Object) AvailableCalories() (calories float64, ok bool) {
+	n := NutritionFromProperties(o.properties)
+	if n.Calories == nil {
+		return 0, false
+	}
+	return *n.Calories * o.nutritionMultiplier(n), true
+}
+
+// nutritionMultiplier is how many servings the object holds in total.
+func (o *Object) nutritionMultiplier(n NutritionFacts) float64 {
+	servings := 1.0
+	if n.Servings != nil {
+		servings = *n.Servings
+	}
+	quantity := 1.0
+	if o.quantity != nil {
+		quantity = *o.quantity
+	}
+	return servings * quantity
+}
+
+// AvailableNutrients is the total protein, carbs and fat the object holds,
+// in grams, with the same multiplier as AvailableCalories. Unknown amounts
+// count as zero.
+func (o *Object) AvailableNutrients() (protein, carbs, fat float64) {
+	n := NutritionFromProperties(o.properties)
+	m := o.nutritionMultiplier(n)
+	if n.Protein != nil {
+		protein = *n.Protein * m
+	}
+	if n.Carbs != nil {
+		carbs = *n.Carbs * m
+	}
+	if n.Fat != nil {
+		fat = *n.Fat * m
+	}
+	return protein, carbs, fat
+}
+
+// NutritionStats totals the nutrition facts of a collection's food.
+type NutritionStats struct {
+	// Totals over active, unexpired food objects with a calories value.
+	Calories float64
+	Protein  float64
+	Carbs    float64
+	Fat      float64
+	// WithNutrition and WithoutNutrition count the food objects that were
+	// and were not summed for lack of a calories value.
+	WithNutrition    int
+	WithoutNutrition int
+	ExpiredSkipped   int
+	// ByContainer lists containers holding calories, most first.
+	ByContainer []ContainerNutrition
+}
+
+// ContainerNutrition is the calories held by one container's food.
+type ContainerNutrition struct {
+	ContainerID   ContainerID
+	ContainerName string
+	Calories      float64
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+var (
+	ErrInvalidOAuthClient    = errors.New("provider name, client ID, client secret and an absolute redirect URL are required")
+	ErrOAuthClientNotFound   = errors.New("oauth client not found")
+	ErrOAuthClientExists     = errors.New("an oauth client with this client ID is already registered")
+	ErrOAuthClientConfigured = errors.New("oauth clients from config.toml cannot be changed through the API")
+	ErrOAuthClientsStatic    = errors.New("oauth clients can only be registered with an external identity provider")
+	ErrOAuthProviderFailed   = errors.New("the client's identity provider could not be loaded")
+)
+
+// OAuthClient is an OAuth client registered at runtime by an admin, used
+// alongside the clients listed in config.toml.
+type OAuthClient struct {
+	providerName string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+type OAuthClientProps struct {
+	ProviderName string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+func NewOAuthClient(props OAuthClientProps, now time.Time) (*OAuthClient, error) {
+	if err := validateOAuthClient(props); err != nil {
+		return nil, err
+	}
+	return &OAuthClient{
+		providerName: props.ProviderName,
+		clientID:     props.ClientID,
+		clientSecret: props.ClientSecret,
+		redirectURL:  props.RedirectURL,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+func ReconstructOAuthClient(providerName, clientID, clientSecret, redirectURL string, createdAt, updatedAt time.Time) *OAuthClient {
+	return &OAuthClient{
+		providerName: providerName,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func validateOAuthClient(props OAuthClientProps) error {
+	if props.ProviderName == "" || props.ClientID == "" || props.ClientSecret == "" ||
+		len(props.ProviderName) > 255 || len(props.ClientID) > 255 {
+		return ErrInvalidOAuthClient
+	}
+	u, err := url.Parse(props.RedirectURL)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return ErrInvalidOAuthClient
+	}
+	return nil
+}
+
+func (c *OAuthClient) ProviderName() string {
+	return c.providerName
+}
+
+func (c *OAuthClient) ClientID() string {
+	return c.clientID
+}
+
+func (c *OAuthClient) ClientSecret() string {
+	return c.clientSecret
+}
+
+func (c *OAuthClient) RedirectURL() string {
+	return c.redirectURL
+}
+
+func (c *OAuthClient) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *OAuthClient) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// Update replaces the client's settings. An empty secret keeps the current
+// one, so admins can change the redirect URL without re-entering it.
+func (c *OAuthClient) Update(providerName, clientSecret, redirectURL string, now time.Time) error {
+	if clientSecret == "" {
+		clientSecret = c.clientSecret
+	}
+	props := OAuthClientProps{ProviderName: providerName, ClientID: c.clientID, ClientSecret: clientSecret, RedirectURL: redirectURL}
+	if err := validateOAuthClient(props); err != nil {
+		return err
+	}
+	c.providerName = providerName
+	c.clientSecret = clientSecret
+	c.redirectURL = redirectURL
+	c.updatedAt = now
+	return nil
+}
@@ -10,10 +10,21 @@ import (
 )
 
 var (
-	ErrInvalidObjectID   = errors.New("invalid object ID")
-	ErrInvalidObjectName = errors.New("object name must be between 1 and 255 characters")
+	ErrInvalidObjectID     = errors.New("invalid object ID")
+	ErrInvalidObjectName   = errors.New("object name must be between 1 and 255 characters")
+	ErrInvalidMinQuantity  = errors.New("min_quantity must not be negative")
+	ErrInvalidExpiringDays = errors.New("days must be between 1 and 366")
+	ErrEmptySearchQuery    = errors.New("search query must not be empty")
+	ErrInvalidSearchLimit  = errors.New("limit must be between 1 and 100")
+	ErrObjectTypeMismatch  = errors.New("objects can only move or be copied to a collection of the same object type")
 )
 
+// MaxExpiringDays is the furthest ahead, in days, expiring objects can be listed.
+const MaxExpiringDays = 366
+
+// MaxSearchResults is the most objects one search returns
+const MaxSearchResults = 100
+
 type ObjectID struct {
 	value bson.ObjectID
 }
@@ -22,6 +33,10 @@ func NewObjectID() ObjectID {
 	return ObjectID{value: bson.NewObjectID()}
 }
 
+func ObjectIDFromObjectID(id bson.ObjectID) ObjectID {
+	return ObjectID{value: id}
+}
+
 func ObjectIDFromHex(hex string) (ObjectID, error) {
 	id, err := bson.ObjectIDFromHex(hex)
 	if err != nil {
@@ -92,7 +107,8 @@ const (
 	ObjectTypeGeneral   ObjectType = "general"
 )
 
-// AllObjectTypes contains every valid ObjectType value.
+// AllObjectTypes contains the built-in ObjectType values. Accounts can define
+// more as CustomObjectTypes.
 var AllObjectTypes = []ObjectType{
 	ObjectTypeFood,
 	ObjectTypeBook,
@@ -106,6 +122,15 @@ func (ot ObjectType) String() string {
 	return string(ot)
 }
 
+// StockStatus classifies an object's quantity against its min quantity.
+type StockStatus string
+
+const (
+	StockStatusOK  StockStatus = ""    // above the threshold, or no threshold set
+	StockStatusLow StockStatus = "low" // at or below the threshold
+	StockStatusOut StockStatus = "out" // nothing left
+)
+
 type Object struct {
 	id          ObjectID
 	name        ObjectName
@@ -114,10 +139,15 @@ type Object struct {
 	location    string                // Optional physical location
 	quantity    *float64              // Optional quantity
 	unit        string                // Optional unit (e.g., "kg", "lbs", "pieces")
+	minQuantity *float64              // Optional restock threshold; at or below it the object is low on stock
+	condition   Condition             // Optional grade for collectibles
+	status      ObjectStatus          // Owned, or wanted/ordered for wishlist items
 	properties  map[string]TypedValue // Flexible properties for different object types
 	tags        []string
-	imageURL    string     // URL to cached image (served by backend)
-	expiresAt   *time.Time // Optional expiration date (e.g., for food items)
+	imageURL    string       // URL to cached image (served by backend)
+	expiresAt   *time.Time   // Optional expiration date (e.g., for food items)
+	archivedAt  *time.Time   // Set while the object is archived (e.g., finished or consumed)
+	claim       *ObjectClaim // Last claim by a member; may have expired
 	createdAt   time.Time
 	updatedAt   time.Time
 }
@@ -129,6 +159,9 @@ type ObjectProps struct {
 	Location    string
 	Quantity    *float64
 	Unit        string
+	MinQuantity *float64
+	Condition   Condition
+	Status      ObjectStatus // ObjectStatusOwned when empty
 	Properties  map[string]TypedValue
 	Tags        []string
 	ImageURL    string
@@ -136,6 +169,13 @@ type ObjectProps struct {
 }
 
 func NewObject(props ObjectProps) (*Object, error) {
+	if err := validateCondition(props.ObjectType, props.Condition); err != nil {
+		return nil, err
+	}
+	status, err := ParseObjectStatus(props.Status.String())
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	return &Object{
 		id:          NewObjectID(),
@@ -145,6 +185,9 @@ func NewObject(props ObjectProps) (*Object, error) {
 		location:    props.Location,
 		quantity:    props.Quantity,
 		unit:        props.Unit,
+		minQuantity: props.MinQuantity,
+		condition:   props.Condition,
+		status:      status,
 		properties:  props.Properties,
 		tags:        props.Tags,
 		imageURL:    props.ImageURL,
@@ -154,7 +197,7 @@ func NewObject(props ObjectProps) (*Object, error) {
 	}, nil
 }
 
-func ReconstructObject(id ObjectID, name ObjectName, description ObjectDescription, objectType ObjectType, location string, quantity *float64, unit string, properties map[string]TypedValue, tags []string, imageURL string, expiresAt *time.Time, createdAt, updatedAt time.Time) *Object {
+func ReconstructObject(id ObjectID, name ObjectName, description ObjectDescription, objectType ObjectType, location string, quantity *float64, unit string, minQuantity *float64, condition Condition, status ObjectStatus, properties map[string]TypedValue, tags []string, imageURL string, expiresAt, archivedAt *time.Time, claim *ObjectClaim, createdAt, updatedAt time.Time) *Object {
 	return &Object{
 		id:          id,
 		name:        name,
@@ -163,10 +206,15 @@ func ReconstructObject(id ObjectID, name ObjectName, description ObjectDescripti
 		location:    location,
 		quantity:    quantity,
 		unit:        unit,
+		minQuantity: minQuantity,
+		condition:   condition,
+		status:      status,
 		properties:  properties,
 		tags:        tags,
 		imageURL:    imageURL,
 		expiresAt:   expiresAt,
+		archivedAt:  archivedAt,
+		claim:       claim,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
 	}
@@ -200,6 +248,32 @@ func (o *Object) Unit() string {
 	return o.unit
 }
 
+func (o *Object) MinQuantity() *float64 {
+	return o.minQuantity
+}
+
+// StockStatus reports how the object's quantity compares to its restock
+// threshold. Objects without a quantity or threshold are always in stock, as
+// are wishlist items, which are not held yet.
+func (o *Object) StockStatus() StockStatus {
+	if o.quantity == nil || o.minQuantity == nil || !o.IsOwned() {
+		return StockStatusOK
+	}
+	switch {
+	case *o.quantity <= 0:
+		return StockStatusOut
+	case *o.quantity <= *o.minQuantity:
+		return StockStatusLow
+	default:
+		return StockStatusOK
+	}
+}
+
+// IsLowStock reports whether the object needs restocking.
+func (o *Object) IsLowStock() bool {
+	return o.StockStatus() != StockStatusOK
+}
+
 func (o *Object) ImageURL() string {
 	return o.imageURL
 }
@@ -222,6 +296,16 @@ func (o *Object) ExpiresAt() *time.Time {
 	return o.expiresAt
 }
 
+func (o *Object) ArchivedAt() *time.Time {
+	return o.archivedAt
+}
+
+// IsArchived reports whether the object has been archived. Archived objects
+// are kept for history but left out of default listings and counts.
+func (o *Object) IsArchived() bool {
+	return o.archivedAt != nil
+}
+
 func (o *Object) CreatedAt() time.Time {
 	return o.createdAt
 }
@@ -272,6 +356,15 @@ func (o *Object) UpdateUnit(unit string) error {
 	return nil
 }
 
+func (o *Object) UpdateMinQuantity(minQuantity *float64) error {
+	if minQuantity != nil && *minQuantity < 0 {
+		return ErrInvalidMinQuantity
+	}
+	o.minQuantity = minQuantity
+	o.updatedAt = time.Now()
+	return nil
+}
+
 func (o *Object) UpdateImageURL(imageURL string) error {
 	o.imageURL = imageURL
 	o.updatedAt = time.Now()
@@ -290,12 +383,87 @@ func (o *Object) UpdateTags(tags []string) error {
 	return nil
 }
 
+// Retag removes the remove tags and then appends the add tags it lacks,
+// reporting whether the tags changed. Tags are matched exactly.
+func (o *Object) Retag(add, remove []string) bool {
+	tags := slices.DeleteFunc(slices.Clone(o.tags), func(tag string) bool {
+		return slices.Contains(remove, tag)
+	})
+	for _, tag := range add {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if slices.Equal(tags, o.tags) {
+		return false
+	}
+	o.tags = tags
+	o.updatedAt = time.Now()
+	return true
+}
+
 func (o *Object) UpdateExpiresAt(expiresAt *time.Time) error {
 	o.expiresAt = expiresAt
 	o.updatedAt = time.Now()
 	return nil
 }
 
+// Archive marks the object as archived. Archiving an already archived
+// object keeps the original timestamp.
+func (o *Object) Archive() error {
+	if o.archivedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	o.archivedAt = &now
+	o.updatedAt = now
+	return nil
+}
+
+func (o *Object) Unarchive() error {
+	if o.archivedAt == nil {
+		return nil
+	}
+	o.archivedAt = nil
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// Claim returns the last claim on the object, which may have expired
+func (o *Object) Claim() *ObjectClaim {
+	return o.claim
+}
+
+// ActiveClaim returns the claim on the object unless there is none or it
+// has expired.
+func (o *Object) ActiveClaim(now time.Time) *ObjectClaim {
+	if o.claim == nil || !o.claim.IsActive(now) {
+		return nil
+	}
+	return o.claim
+}
+
+// ClaimFor reserves the object. A member may renew their own claim but not
+// take over another member's until it expires.
+func (o *Object) ClaimFor(claim ObjectClaim) error {
+	if active := o.ActiveClaim(claim.ClaimedAt()); active != nil && !active.UserID().Equals(claim.UserID()) {
+		return ErrObjectClaimed
+	}
+	o.claim = &claim
+	o.updatedAt = claim.ClaimedAt()
+	return nil
+}
+
+// Unclaim drops the claim. Callers decide who may release another
+// member's claim.
+func (o *Object) Unclaim() {
+	if o.claim == nil {
+		return
+	}
+	o.claim = nil
+	o.updatedAt = time.Now()
+}
+
 func (o *Object) Equals(other *Object) bool {
 	if other == nil {
 		return false
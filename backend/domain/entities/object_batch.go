@@ -0,0 +1,16 @@
+package entities
+
+import "errors"
+
+// MaxBatchObjects is the most objects one batch request may name
+const MaxBatchObjects = 500
+
+// BatchOperation is what a batch request does to each of its objects.
+type BatchOperation string
+
+const (
+	BatchMove   BatchOperation = "move"
+	BatchDelete BatchOperation = "delete"
+)
+
+var ErrInvalidBatchOperation = errors.New("invalid batch operation")
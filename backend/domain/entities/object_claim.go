@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultClaimDuration is how long a claim lasts when no end is given
+	DefaultClaimDuration = 24 * time.Hour
+	// MaxClaimDuration caps how far ahead a claim may end
+	MaxClaimDuration = 30 * 24 * time.Hour
+)
+
+var (
+	ErrObjectClaimed      = errors.New("object is claimed by another member")
+	ErrInvalidClaimExpiry = errors.New("claim must end in the future and within 30 days")
+)
+
+// ObjectClaim reserves an object for one member, e.g. an ingredient they
+// plan to cook with, until it expires. Expired claims are ignored rather
+// than removed.
+type ObjectClaim struct {
+	userID    UserID
+	username  string
+	claimedAt time.Time
+	until     time.Time
+}
+
+// NewObjectClaim starts a claim now. A nil until lasts DefaultClaimDuration.
+func NewObjectClaim(userID UserID, username string, now time.Time, until *time.Time) (ObjectClaim, error) {
+	end := now.Add(DefaultClaimDuration)
+	if until != nil {
+		if !until.After(now) || until.Sub(now) > MaxClaimDuration {
+			return ObjectClaim{}, ErrInvalidClaimExpiry
+		}
+		end = *until
+	}
+	return ObjectClaim{userID: userID, username: username, claimedAt: now, until: end}, nil
+}
+
+func ReconstructObjectClaim(userID UserID, username string, claimedAt, until time.Time) ObjectClaim {
+	return ObjectClaim{userID: userID, username: username, claimedAt: claimedAt, until: until}
+}
+
+func (c ObjectClaim) UserID() UserID {
+	return c.userID
+}
+
+// Username is the claimer's name when they claimed, shown on the object
+func (c ObjectClaim) Username() string {
+	return c.username
+}
+
+func (c ObjectClaim) ClaimedAt() time.Time {
+	return c.claimedAt
+}
+
+func (c ObjectClaim) Until() time.Time {
+	return c.until
+}
+
+func (c ObjectClaim) IsActive(now time.Time) bool {
+	return now.Before(c.until)
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidObjectCode = errors.New("code must be 4 to 32 letters or digits")
+)
+
+// ObjectCodeKeys are the property keys holding a barcode, ISBN or SKU that
+// identifies what an object is, so a second scan of the same product finds
+// the first one.
+var ObjectCodeKeys = []string{PropertyUPC, "ean", "barcode", "isbn", "sku"}
+
+// NormalizeObjectCode strips spaces and dashes from a scanned or typed code
+// and upper-cases it, so "0-12345-67890-5" and "012345678905" match, as do
+// ISBN-10s ending in x or X. The result must be 4 to 32 letters or digits.
+func NormalizeObjectCode(code string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		switch {
+		case r >= '0' && r <= '9', r >= 'A' && r <= 'Z':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+		default:
+			return "", ErrInvalidObjectCode
+		}
+	}
+	if b.Len() < 4 || b.Len() > 32 {
+		return "", ErrInvalidObjectCode
+	}
+	return b.String(), nil
+}
+
+// ObjectCodes returns the normalized codes in an object's properties, sorted
+// and without repeats. Values that do not look like a code are skipped.
+func ObjectCodes(props map[string]TypedValue) []string {
+	var codes []string
+	for _, key := range ObjectCodeKeys {
+		raw, ok := props[key].Val.(string)
+		if !ok {
+			continue
+		}
+		if code, err := NormalizeObjectCode(raw); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	return slices.Compact(codes)
+}
+
+// Codes returns the object's normalized barcodes, ISBNs and SKUs
+func (o *Object) Codes() []string {
+	return ObjectCodes(o.properties)
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidCondition      = errors.New("condition must be one of mint, near_mint, good, fair or poor")
+	ErrConditionNotSupported = errors.New("condition is only graded for books, video games, music and board games")
+)
+
+// Condition grades a collectible's physical state on a fixed scale, best first.
+type Condition string
+
+const (
+	ConditionNone     Condition = "" // not graded
+	ConditionMint     Condition = "mint"
+	ConditionNearMint Condition = "near_mint"
+	ConditionGood     Condition = "good"
+	ConditionFair     Condition = "fair"
+	ConditionPoor     Condition = "poor"
+)
+
+// AllConditions lists the grades from best to worst
+var AllConditions = []Condition{
+	ConditionMint,
+	ConditionNearMint,
+	ConditionGood,
+	ConditionFair,
+	ConditionPoor,
+}
+
+// ParseCondition checks a grade from a request. An empty string is
+// ConditionNone.
+func ParseCondition(s string) (Condition, error) {
+	c := Condition(s)
+	if c != ConditionNone && !slices.Contains(AllConditions, c) {
+		return ConditionNone, ErrInvalidCondition
+	}
+	return c, nil
+}
+
+func (c Condition) String() string {
+	return string(c)
+}
+
+// Graded reports whether the object types can be given a condition: the
+// built-in collectible types. Food, general and custom types cannot.
+func (ot ObjectType) Graded() bool {
+	switch ot {
+	case ObjectTypeBook, ObjectTypeVideoGame, ObjectTypeMusic, ObjectTypeBoardGame:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateCondition checks that objects of type ot may be given condition
+func validateCondition(ot ObjectType, condition Condition) error {
+	if condition == ConditionNone {
+		return nil
+	}
+	if !slices.Contains(AllConditions, condition) {
+		return ErrInvalidCondition
+	}
+	if !ot.Graded() {
+		return ErrConditionNotSupported
+	}
+	return nil
+}
+
+func (o *Object) Condition() Condition {
+	return o.condition
+}
+
+// UpdateCondition grades the object, or clears the grade with
+// ConditionNone. Only collectible types can be graded.
+func (o *Object) UpdateCondition(condition Condition) error {
+	if err := validateCondition(o.objectType, condition); err != nil {
+		return err
+	}
+	o.condition = condition
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// ConditionCounts counts the active, owned objects in each grade. Ungraded
+// objects are not counted; a nil map means nothing is graded.
+func (c *Container) ConditionCounts() map[Condition]int {
+	var counts map[Condition]int
+	for _, object := range c.objects {
+		if !object.IsCounted() || object.condition == ConditionNone {
+			continue
+		}
+		if counts == nil {
+			counts = make(map[Condition]int)
+		}
+		counts[object.condition]++
+	}
+	return counts
+}
+
+// ConditionCounts counts the active objects in each grade across the
+// collection's containers
+func (c *Collection) ConditionCounts() map[Condition]int {
+	var counts map[Condition]int
+	for _, container := range c.containers {
+		for condition, n := range container.ConditionCounts() {
+			if counts == nil {
+				counts = make(map[Condition]int)
+			}
+			counts[condition] += n
+		}
+	}
+	return counts
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// ObjectEventKind is what happened to an object.
+type ObjectEventKind string
+
+const (
+	ObjectCreated ObjectEventKind = "object.created"
+	ObjectUpdated ObjectEventKind = "object.updated"
+	// ObjectExpiring is reported by the scheduler for objects whose expiry
+	// date came closer, rather than by a change to the object
+	ObjectExpiring ObjectEventKind = "object.expiring"
+)
+
+// ObjectEvent reports a change to an object, for automation rules to act
+// on. The Previous fields hold what the object had before an update.
+type ObjectEvent struct {
+	Kind ObjectEventKind
+	// UserID is who made the change; their rules are the ones that run
+	UserID       UserID
+	CollectionID CollectionID
+	ContainerID  ContainerID
+	// Object is the object after the change
+	Object           Object
+	PreviousTags     []string
+	PreviousQuantity *float64
+	// Since is the start of the span an ObjectExpiring event covers
+	Since time.Time
+	At    time.Time
+}
+
+// NewObjectCreatedEvent reports a new object. Its tags all count as added.
+func NewObjectCreatedEvent(userID UserID, collectionID CollectionID, containerID ContainerID, object Object, at time.Time) ObjectEvent {
+	return ObjectEvent{
+		Kind:         ObjectCreated,
+		UserID:       userID,
+		CollectionID: collectionID,
+		ContainerID:  containerID,
+		Object:       object,
+		At:           at,
+	}
+}
+
+// NewObjectUpdatedEvent reports a change from previous to object
+func NewObjectUpdatedEvent(userID UserID, collectionID CollectionID, containerID ContainerID, previous, object Object, at time.Time) ObjectEvent {
+	return ObjectEvent{
+		Kind:             ObjectUpdated,
+		UserID:           userID,
+		CollectionID:     collectionID,
+		ContainerID:      containerID,
+		Object:           object,
+		PreviousTags:     previous.Tags(),
+		PreviousQuantity: previous.Quantity(),
+		At:               at,
+	}
+}
+
+// NewObjectExpiringEvent reports that time moved on from since to at for an
+// object with an expiry date
+func NewObjectExpiringEvent(userID UserID, collectionID CollectionID, containerID ContainerID, object Object, since, at time.Time) ObjectEvent {
+	return ObjectEvent{
+		Kind:         ObjectExpiring,
+		UserID:       userID,
+		CollectionID: collectionID,
+		ContainerID:  containerID,
+		Object:       object,
+		Since:        since,
+		At:           at,
+	}
+}
+
+// AddedTags returns the object's tags it did not have before the event
+func (e ObjectEvent) AddedTags() []string {
+	var added []string
+	for _, tag := range e.Object.Tags() {
+		if !slices.Contains(e.PreviousTags, tag) {
+			added = append(added, tag)
+		}
+	}
+	return added
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"time"
+)
+
+// ObjectMove records an object leaving one container for another. Container
+// names and locations are snapshotted so the timeline stays readable after a
+// container is renamed or deleted.
+type ObjectMove struct {
+	objectID      ObjectID
+	from          ObjectPlacement
+	to            ObjectPlacement
+	movedByUserID UserID
+	movedAt       time.Time
+}
+
+// ObjectPlacement identifies where an object was at a point in time.
+type ObjectPlacement struct {
+	ContainerID   ContainerID
+	ContainerName string
+	Location      string
+}
+
+// PlacementOf snapshots container as an ObjectPlacement.
+func PlacementOf(container *Container) ObjectPlacement {
+	return ObjectPlacement{
+		ContainerID:   container.ID(),
+		ContainerName: container.Name().String(),
+		Location:      container.Location(),
+	}
+}
+
+func NewObjectMove(objectID ObjectID, from, to ObjectPlacement, movedBy UserID) *ObjectMove {
+	return &ObjectMove{
+		objectID:      objectID,
+		from:          from,
+		to:            to,
+		movedByUserID: movedBy,
+		movedAt:       time.Now(),
+	}
+}
+
+func ReconstructObjectMove(objectID ObjectID, from, to ObjectPlacement, movedBy UserID, movedAt time.Time) *ObjectMove {
+	return &ObjectMove{
+		objectID:      objectID,
+		from:          from,
+		to:            to,
+		movedByUserID: movedBy,
+		movedAt:       movedAt,
+	}
+}
+
+func (m *ObjectMove) ObjectID() ObjectID {
+	return m.objectID
+}
+
+func (m *ObjectMove) From() ObjectPlacement {
+	return m.from
+}
+
+func (m *ObjectMove) To() ObjectPlacement {
+	return m.to
+}
+
+func (m *ObjectMove) MovedByUserID() UserID {
+	return m.movedByUserID
+}
+
+func (m *ObjectMove) MovedAt() time.Time {
+	return m.movedAt
+}
+
+// ObjectTimelineEntry is one stay of an object in a container. Until is nil
+// for the container the object is in now.
+type ObjectTimelineEntry struct {
+	Placement ObjectPlacement
+	Since     time.Time
+	Until     *time.Time
+	// MovedByUserID is who moved the object here; nil for where it started.
+	MovedByUserID *UserID
+}
+
+// BuildObjectTimeline turns an object's moves, oldest first, into the list of
+// containers it has been in. createdAt anchors the first entry and current
+// is used when the object has never moved.
+func BuildObjectTimeline(createdAt time.Time, current ObjectPlacement, moves []*ObjectMove) []ObjectTimelineEntry {
+	if len(moves) == 0 {
+		return []ObjectTimelineEntry{{Placement: current, Since: createdAt}}
+	}
+
+	timeline := make([]ObjectTimelineEntry, 0, len(moves)+1)
+	timeline = append(timeline, ObjectTimelineEntry{Placement: moves[0].from, Since: createdAt})
+	for _, m := range moves {
+		movedAt := m.movedAt
+		timeline[len(timeline)-1].Until = &movedAt
+		movedBy := m.movedByUserID
+		timeline = append(timeline, ObjectTimelineEntry{
+			Placement:     m.to,
+			Since:         movedAt,
+			MovedByUserID: &movedBy,
+		})
+	}
+
+	// Prefer the live container details for the final stay in case it was
+	// renamed since the last move.
+	if last := &timeline[len(timeline)-1]; last.Placement.ContainerID.Equals(current.ContainerID) {
+		last.Placement = current
+	}
+
+	return timeline
+}
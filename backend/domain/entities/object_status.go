@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrInvalidObjectStatus = errors.New("status must be one of owned, wanted or ordered")
+
+// ObjectStatus tells owned items apart from ones on the wishlist. Objects
+// that are not owned stay in their collection but are left out of counts and
+// stats.
+type ObjectStatus string
+
+const (
+	ObjectStatusOwned   ObjectStatus = "owned"
+	ObjectStatusWanted  ObjectStatus = "wanted"  // on the wishlist
+	ObjectStatusOrdered ObjectStatus = "ordered" // bought, not yet arrived
+)
+
+// AllObjectStatuses lists the statuses in the order an item moves through them
+var AllObjectStatuses = []ObjectStatus{
+	ObjectStatusWanted,
+	ObjectStatusOrdered,
+	ObjectStatusOwned,
+}
+
+// ParseObjectStatus checks a status from a request or the database. An empty
+// string is ObjectStatusOwned, which is what objects saved before statuses
+// existed are.
+func ParseObjectStatus(s string) (ObjectStatus, error) {
+	if s == "" {
+		return ObjectStatusOwned, nil
+	}
+	status := ObjectStatus(s)
+	if !slices.Contains(AllObjectStatuses, status) {
+		return ObjectStatusOwned, ErrInvalidObjectStatus
+	}
+	return status, nil
+}
+
+func (s ObjectStatus) String() string {
+	return string(s)
+}
+
+func (o *Object) Status() ObjectStatus {
+	if o.status == "" {
+		return ObjectStatusOwned
+	}
+	return o.status
+}
+
+// IsOwned reports whether the object is in hand rather than wanted or on
+// order
+func (o *Object) IsOwned() bool {
+	return o.Status() == ObjectStatusOwned
+}
+
+// IsCounted reports whether the object counts towards totals and stats:
+// owned and not archived.
+func (o *Object) IsCounted() bool {
+	return o.IsOwned() && !o.IsArchived()
+}
+
+// UpdateStatus moves the object on or off the wishlist; setting
+// ObjectStatusOwned is how a wanted item is marked as acquired.
+func (o *Object) UpdateStatus(status ObjectStatus) error {
+	if !slices.Contains(AllObjectStatuses, status) {
+		return ErrInvalidObjectStatus
+	}
+	o.status = status
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// WishlistCount returns the number of active objects that are wanted or on
+// order
+func (c *Container) WishlistCount() int {
+	count := 0
+	for _, object := range c.objects {
+		if !object.IsArchived() && !object.IsOwned() {
+			count++
+		}
+	}
+	return count
+}
+
+// WishlistCount returns the number of active objects across the collection
+// that are wanted or on order
+func (c *Collection) WishlistCount() int {
+	count := 0
+	for _, container := range c.containers {
+		count += container.WishlistCount()
+	}
+	return count
+}
@@ -69,7 +69,9 @@ type PropertySchema struct {
 	Definitions []PropertyDefinition `json:"definitions"`
 }
 
-// Validate checks that the properties map conforms to required fields in the schema.
+// Validate checks that the properties map conforms to the schema: required
+// fields are present with a value, and values for defined fields have the
+// defined type (a value that failed coercion is stored as text).
 // Returns a list of validation error messages; empty slice means valid.
 func (ps *PropertySchema) Validate(properties map[string]TypedValue) []string {
 	if ps == nil {
@@ -77,15 +79,31 @@ func (ps *PropertySchema) Validate(properties map[string]TypedValue) []string {
 	}
 	var errs []string
 	for _, def := range ps.Definitions {
-		if def.Required {
-			if _, ok := properties[def.Key]; !ok {
+		tv, ok := properties[def.Key]
+		if !ok || tv.Val == nil {
+			if def.Required {
 				errs = append(errs, "missing required property: "+def.Key)
 			}
+			continue
+		}
+		if !def.Type.accepts(tv.Type) {
+			errs = append(errs, fmt.Sprintf("property %s must be %s", def.Key, def.Type))
 		}
 	}
 	return errs
 }
 
+// accepts reports whether a value of type got satisfies a definition of
+// type pt. Text-like types are interchangeable.
+func (pt PropertyType) accepts(got PropertyType) bool {
+	switch pt {
+	case PropertyTypeText, PropertyTypeGroupedText, PropertyTypeURL:
+		return got == PropertyTypeText || got == PropertyTypeGroupedText || got == PropertyTypeURL
+	default:
+		return got == pt
+	}
+}
+
 // GetDefinition looks up a property definition by key. Returns nil if not found.
 func (ps *PropertySchema) GetDefinition(key string) *PropertyDefinition {
 	if ps == nil {
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidPurchaseHistoryDays = errors.New("days must be between 1 and 365")
+
+const (
+	// DefaultPurchaseHistoryDays and MaxPurchaseHistoryDays bound the window
+	// a purchase history covers
+	DefaultPurchaseHistoryDays = 30
+	MaxPurchaseHistoryDays     = 365
+	// MaxPurchaseHistoryItems caps the purchases a history lists one by one;
+	// the totals still count every purchase
+	MaxPurchaseHistoryItems = 200
+)
+
+// PurchaseHistory sums what a user's food collections took in over a
+// window: the objects added, priced like the valuation report, and the
+// quantity adjustments made to their objects. Objects and adjustments are
+// grouped by category, the ExpiryCategory of the object, "" when it has
+// none; amounts are kept apart by currency.
+type PurchaseHistory struct {
+	From time.Time
+	To   time.Time
+	// Totals holds the spend per currency, highest first.
+	Totals []ValuationTotal
+	// ByCategory holds the spend per category and currency, highest first.
+	ByCategory []ValuationGroup
+	// Purchases lists the objects added, newest first, up to
+	// MaxPurchaseHistoryItems.
+	Purchases []Purchase
+	// Priced and Unpriced count the objects added with and without a price.
+	Priced   int
+	Unpriced int
+	// Adjustments sums the quantity changes per category and unit, most
+	// changes first.
+	Adjustments []AdjustmentSummary
+}
+
+// Purchase is a food object added during the window. Price is nil when the
+// object has none.
+type Purchase struct {
+	ObjectID       ObjectID
+	Name           string
+	CollectionID   CollectionID
+	CollectionName string
+	Category       string
+	Quantity       *float64
+	Unit           string
+	Price          *float64
+	Currency       string
+	AddedAt        time.Time
+}
+
+// AdjustmentSummary totals the quantity changes of one category in one
+// unit: Restocked adds up the increases and Used the decreases.
+type AdjustmentSummary struct {
+	Category    string
+	Unit        string
+	Adjustments int
+	Restocked   float64
+	Used        float64
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"time"
+)
+
+// QuantityAdjustment records a change to an object's quantity, such as
+// using some up or restocking it. The object's name, category and unit are
+// snapshotted so the history stays readable after the object changes.
+type QuantityAdjustment struct {
+	objectID         ObjectID
+	collectionID     CollectionID
+	objectName       string
+	category         string
+	unit             string
+	previous         float64
+	quantity         float64
+	adjustedByUserID UserID
+	adjustedAt       time.Time
+}
+
+// QuantityAdjustmentFromEvent returns the adjustment an update event made,
+// or false when the event left the quantity as it was.
+func QuantityAdjustmentFromEvent(event ObjectEvent) (*QuantityAdjustment, bool) {
+	if event.Kind != ObjectUpdated || event.PreviousQuantity == nil || event.Object.Quantity() == nil {
+		return nil, false
+	}
+	previous, quantity := *event.PreviousQuantity, *event.Object.Quantity()
+	if previous == quantity {
+		return nil, false
+	}
+	return &QuantityAdjustment{
+		objectID:         event.Object.ID(),
+		collectionID:     event.CollectionID,
+		objectName:       event.Object.Name().String(),
+		category:         ExpiryCategory(event.Object.Properties(), event.Object.Tags()),
+		unit:             event.Object.Unit(),
+		previous:         previous,
+		quantity:         quantity,
+		adjustedByUserID: event.UserID,
+		adjustedAt:       event.At,
+	}, true
+}
+
+func ReconstructQuantityAdjustment(
+	objectID ObjectID,
+	collectionID CollectionID,
+	objectName, category, unit string,
+	previous, quantity float64,
+	adjustedBy UserID,
+	adjustedAt time.Time,
+) *QuantityAdjustment {
+	return &QuantityAdjustment{
+		objectID:         objectID,
+		collectionID:     collectionID,
+		objectName:       objectName,
+		category:         category,
+		unit:             unit,
+		previous:         previous,
+		quantity:         quantity,
+		adjustedByUserID: adjustedBy,
+		adjustedAt:       adjustedAt,
+	}
+}
+
+func (a *QuantityAdjustment) ObjectID() ObjectID {
+	return a.objectID
+}
+
+func (a *QuantityAdjustment) CollectionID() CollectionID {
+	return a.collectionID
+}
+
+func (a *QuantityAdjustment) ObjectName() string {
+	return a.objectName
+}
+
+// Category is the object's ExpiryCategory when it was adjusted
+func (a *QuantityAdjustment) Category() string {
+	return a.category
+}
+
+func (a *QuantityAdjustment) Unit() string {
+	return a.unit
+}
+
+func (a *QuantityAdjustment) Previous() float64 {
+	return a.previous
+}
+
+func (a *QuantityAdjustment) Quantity() float64 {
+	return a.quantity
+}
+
+// Delta is the change in quantity, negative when some was used up
+func (a *QuantityAdjustment) Delta() float64 {
+	return a.quantity - a.previous
+}
+
+func (a *QuantityAdjustment) AdjustedByUserID() UserID {
+	return a.adjustedByUserID
+}
+
+func (a *QuantityAdjustment) AdjustedAt() time.Time {
+	return a.adjustedAt
+}
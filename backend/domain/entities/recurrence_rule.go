@@ -0,0 +1,260 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRecurrenceRuleID = errors.New("invalid recurrence rule ID")
+	ErrInvalidRecurrence       = errors.New("recurrence must repeat every 1 to 365 days, weeks or months")
+	ErrInvalidRecurrenceAmount = errors.New("recurrence quantity must be greater than zero")
+	ErrRecurrenceRuleNotFound  = errors.New("recurrence rule not found")
+	ErrRecurrenceRuleExists    = errors.New("object already has a recurrence rule")
+)
+
+const maxRecurrenceEvery = 365
+
+type RecurrenceRuleID struct {
+	value string
+}
+
+func NewRecurrenceRuleID() RecurrenceRuleID {
+	return RecurrenceRuleID{value: uuid.New().String()}
+}
+
+func RecurrenceRuleIDFromString(id string) (RecurrenceRuleID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return RecurrenceRuleID{}, ErrInvalidRecurrenceRuleID
+	}
+	return RecurrenceRuleID{value: id}, nil
+}
+
+func (id RecurrenceRuleID) String() string {
+	return id.value
+}
+
+func (id RecurrenceRuleID) Equals(other RecurrenceRuleID) bool {
+	return id.value == other.value
+}
+
+// RecurrencePeriod is the unit a recurrence repeats in.
+type RecurrencePeriod string
+
+const (
+	RecurrenceDaily   RecurrencePeriod = "day"
+	RecurrenceWeekly  RecurrencePeriod = "week"
+	RecurrenceMonthly RecurrencePeriod = "month"
+)
+
+func IsValidRecurrencePeriod(period string) bool {
+	switch RecurrencePeriod(period) {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// Recurrence repeats every Every periods, e.g. every 2 weeks.
+type Recurrence struct {
+	Every  int
+	Period RecurrencePeriod
+}
+
+func (r Recurrence) Validate() error {
+	if r.Every < 1 || r.Every > maxRecurrenceEvery || !IsValidRecurrencePeriod(string(r.Period)) {
+		return ErrInvalidRecurrence
+	}
+	return nil
+}
+
+// After returns the first time the recurrence comes round after t.
+func (r Recurrence) After(t time.Time) time.Time {
+	switch r.Period {
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7*r.Every)
+	case RecurrenceMonthly:
+		return t.AddDate(0, r.Every, 0)
+	}
+	return t.AddDate(0, 0, r.Every)
+}
+
+// RecurrenceRule puts a staple such as bread back on the user's shopping list
+// on a schedule, whether or not its stock was ever counted down. Name is a
+// snapshot of the object's name so the rule stays readable after the object
+// is renamed or deleted.
+type RecurrenceRule struct {
+	id           RecurrenceRuleID
+	userID       UserID
+	objectID     ObjectID
+	collectionID CollectionID
+	name         string
+	recurrence   Recurrence
+	quantity     *float64 // how much to buy; nil to leave it to the user
+	nextDueAt    time.Time
+	dueSince     *time.Time // when the staple last came due; nil once bought
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewRecurrenceRule creates a rule that first comes due one recurrence after
+// start.
+func NewRecurrenceRule(userID UserID, objectID ObjectID, collectionID CollectionID, name string, recurrence Recurrence, quantity *float64, start time.Time) (*RecurrenceRule, error) {
+	if err := recurrence.Validate(); err != nil {
+		return nil, err
+	}
+	if quantity != nil && *quantity <= 0 {
+		return nil, ErrInvalidRecurrenceAmount
+	}
+	now := time.Now()
+	return &RecurrenceRule{
+		id:           NewRecurrenceRuleID(),
+		userID:       userID,
+		objectID:     objectID,
+		collectionID: collectionID,
+		name:         name,
+		recurrence:   recurrence,
+		quantity:     quantity,
+		nextDueAt:    recurrence.After(start),
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+func ReconstructRecurrenceRule(id RecurrenceRuleID, userID UserID, objectID ObjectID, collectionID CollectionID, name string, recurrence Recurrence, quantity *float64, nextDueAt time.Time, dueSince *time.Time, createdAt, updatedAt time.Time) *RecurrenceRule {
+	return &RecurrenceRule{
+		id:           id,
+		userID:       userID,
+		objectID:     objectID,
+		collectionID: collectionID,
+		name:         name,
+		recurrence:   recurrence,
+		quantity:     quantity,
+		nextDueAt:    nextDueAt,
+		dueSince:     dueSince,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (r *RecurrenceRule) ID() RecurrenceRuleID {
+	return r.id
+}
+
+func (r *RecurrenceRule) UserID() UserID {
+	return r.userID
+}
+
+func (r *RecurrenceRule) ObjectID() ObjectID {
+	return r.objectID
+}
+
+func (r *RecurrenceRule) CollectionID() CollectionID {
+	return r.collectionID
+}
+
+func (r *RecurrenceRule) Name() string {
+	return r.name
+}
+
+func (r *RecurrenceRule) Recurrence() Recurrence {
+	return r.recurrence
+}
+
+func (r *RecurrenceRule) Quantity() *float64 {
+	return r.quantity
+}
+
+func (r *RecurrenceRule) NextDueAt() time.Time {
+	return r.nextDueAt
+}
+
+func (r *RecurrenceRule) DueSince() *time.Time {
+	return r.dueSince
+}
+
+func (r *RecurrenceRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *RecurrenceRule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *RecurrenceRule) IsOwnedBy(userID UserID) bool {
+	return r.userID.Equals(userID)
+}
+
+// IsDue reports whether the staple is waiting to be bought.
+func (r *RecurrenceRule) IsDue() bool {
+	return r.dueSince != nil
+}
+
+// UpdateRecurrence changes the schedule. The next due date is recounted from
+// now, so shortening "every month" to "every week" takes effect this week.
+func (r *RecurrenceRule) UpdateRecurrence(recurrence Recurrence, now time.Time) error {
+	if err := recurrence.Validate(); err != nil {
+		return err
+	}
+	r.recurrence = recurrence
+	r.nextDueAt = recurrence.After(now)
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *RecurrenceRule) UpdateQuantity(quantity *float64) error {
+	if quantity != nil && *quantity <= 0 {
+		return ErrInvalidRecurrenceAmount
+	}
+	r.quantity = quantity
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// UpdateName refreshes the snapshot of the object's name.
+func (r *RecurrenceRule) UpdateName(name string) {
+	if name != r.name {
+		r.name = name
+		r.updatedAt = time.Now()
+	}
+}
+
+// Trigger marks the staple due when its next due date has passed and moves
+// the due date past now, skipping any rounds missed while the scheduler was
+// down. It reports whether the rule changed.
+func (r *RecurrenceRule) Trigger(now time.Time) bool {
+	if r.nextDueAt.After(now) {
+		return false
+	}
+	if r.dueSince == nil {
+		due := r.nextDueAt
+		r.dueSince = &due
+	}
+	for !r.nextDueAt.After(now) {
+		r.nextDueAt = r.recurrence.After(r.nextDueAt)
+	}
+	r.updatedAt = time.Now()
+	return true
+}
+
+// MarkBought takes the staple off the shopping list until it next comes due.
+func (r *RecurrenceRule) MarkBought() {
+	if r.dueSince != nil {
+		r.dueSince = nil
+		r.updatedAt = time.Now()
+	}
+}
+
+// MarkDue puts the staple on the shopping list now, ahead of its schedule.
+// It reports whether the rule changed; a staple already due keeps the time
+// it came due.
+func (r *RecurrenceRule) MarkDue(now time.Time) bool {
+	if r.dueSince != nil {
+		return false
+	}
+	r.dueSince = &now
+	r.updatedAt = time.Now()
+	return true
+}
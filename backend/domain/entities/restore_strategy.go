@@ -0,0 +1,40 @@
+package entities
+
+import "fmt"
+
+// RestoreStrategy decides what happens when a restored collection already exists.
+type RestoreStrategy string
+
+const (
+	// RestoreStrategySkip leaves existing collections untouched.
+	RestoreStrategySkip RestoreStrategy = "skip"
+	// RestoreStrategyOverwrite replaces existing collections with the archived copy.
+	RestoreStrategyOverwrite RestoreStrategy = "overwrite"
+	// RestoreStrategyMerge keeps existing data and adds archived containers and
+	// objects that are missing.
+	RestoreStrategyMerge RestoreStrategy = "merge"
+)
+
+// AllRestoreStrategies contains every valid RestoreStrategy value.
+var AllRestoreStrategies = []RestoreStrategy{
+	RestoreStrategySkip,
+	RestoreStrategyOverwrite,
+	RestoreStrategyMerge,
+}
+
+// NewRestoreStrategy validates a strategy name. An empty value defaults to skip.
+func NewRestoreStrategy(value string) (RestoreStrategy, error) {
+	if value == "" {
+		return RestoreStrategySkip, nil
+	}
+	for _, s := range AllRestoreStrategies {
+		if string(s) == value {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid restore strategy %q: must be skip, overwrite or merge", value)
+}
+
+func (s RestoreStrategy) String() string {
+	return string(s)
+}
@@ -0,0 +1,145 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session has been signed out")
+	// ErrCannotRevokeCurrentSession is returned for a request to revoke the
+	// session it was made from; signing out does that.
+	ErrCannotRevokeCurrentSession = errors.New("the current session cannot be revoked; sign out instead")
+)
+
+// NewSessionID identifies the sign-in a token belongs to from the user and
+// the identity provider's session or the sign-in time. Tokens refreshed from
+// one sign-in keep both, so they share a session; signing in again starts a
+// new one.
+func NewSessionID(subject, signIn string) string {
+	sum := sha256.Sum256([]byte(subject + "|" + signIn))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Session is one sign-in of a user on a device, recorded the first time its
+// token is used. A revoked session is kept so that tokens refreshed from it
+// are still refused; the device has to sign in again. A trusted session is
+// one the user chose to remember: signing out other devices leaves it be.
+type Session struct {
+	id         string
+	userID     UserID
+	device     string
+	ipAddress  string
+	trusted    bool
+	createdAt  time.Time
+	lastSeenAt time.Time
+	expiresAt  time.Time
+	revokedAt  *time.Time
+}
+
+type SessionProps struct {
+	ID        string
+	UserID    UserID
+	Device    string
+	IPAddress string
+	ExpiresAt time.Time
+}
+
+func NewSession(props SessionProps, now time.Time) *Session {
+	return &Session{
+		id:         props.ID,
+		userID:     props.UserID,
+		device:     props.Device,
+		ipAddress:  props.IPAddress,
+		createdAt:  now,
+		lastSeenAt: now,
+		expiresAt:  props.ExpiresAt,
+	}
+}
+
+func ReconstructSession(id string, userID UserID, device, ipAddress string, trusted bool, createdAt, lastSeenAt, expiresAt time.Time, revokedAt *time.Time) *Session {
+	return &Session{
+		id:         id,
+		userID:     userID,
+		device:     device,
+		ipAddress:  ipAddress,
+		trusted:    trusted,
+		createdAt:  createdAt,
+		lastSeenAt: lastSeenAt,
+		expiresAt:  expiresAt,
+		revokedAt:  revokedAt,
+	}
+}
+
+func (s *Session) ID() string {
+	return s.id
+}
+
+func (s *Session) UserID() UserID {
+	return s.userID
+}
+
+// Device describes the browser or app the session was last used from
+func (s *Session) Device() string {
+	return s.device
+}
+
+func (s *Session) IPAddress() string {
+	return s.ipAddress
+}
+
+func (s *Session) IsTrusted() bool {
+	return s.trusted
+}
+
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Session) LastSeenAt() time.Time {
+	return s.lastSeenAt
+}
+
+// ExpiresAt is when the latest token seen for the session expires
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s *Session) RevokedAt() *time.Time {
+	return s.revokedAt
+}
+
+func (s *Session) IsRevoked() bool {
+	return s.revokedAt != nil
+}
+
+// IsActive reports whether the session can still be used: it is not
+// revoked and its latest token, if it has an expiry, has not expired
+func (s *Session) IsActive(now time.Time) bool {
+	return !s.IsRevoked() && (s.expiresAt.IsZero() || now.Before(s.expiresAt))
+}
+
+// Seen records another request from the session, with the device, address
+// and expiry of the token it carried
+func (s *Session) Seen(device, ipAddress string, expiresAt, now time.Time) {
+	s.device = device
+	s.ipAddress = ipAddress
+	if expiresAt.After(s.expiresAt) {
+		s.expiresAt = expiresAt
+	}
+	s.lastSeenAt = now
+}
+
+func (s *Session) SetTrusted(trusted bool) {
+	s.trusted = trusted
+}
+
+func (s *Session) Revoke(now time.Time) {
+	if s.revokedAt != nil {
+		return
+	}
+	s.revokedAt = &now
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxShelfLifeDays caps a default shelf life at ten years.
+const MaxShelfLifeDays = 3650
+
+// ShelfLifeCategoryProperty is the object property matched against shelf
+// life categories. Pantry imports map "category" and "aisle" columns to it.
+const ShelfLifeCategoryProperty = "category"
+
+var (
+	ErrInvalidShelfLife   = fmt.Errorf("shelf life must be between 1 and %d days", MaxShelfLifeDays)
+	ErrDuplicateShelfLife = errors.New("shelf life category listed more than once")
+)
+
+// ShelfLife is how long food of one category keeps once it is added to a
+// collection, e.g. dairy 7 days or frozen 90 days. An empty category is the
+// fallback for food no other rule matches.
+type ShelfLife struct {
+	category string
+	days     int
+}
+
+func NewShelfLife(category string, days int) (ShelfLife, error) {
+	if days < 1 || days > MaxShelfLifeDays {
+		return ShelfLife{}, ErrInvalidShelfLife
+	}
+	return ShelfLife{category: strings.TrimSpace(category), days: days}, nil
+}
+
+func ReconstructShelfLife(category string, days int) ShelfLife {
+	return ShelfLife{category: category, days: days}
+}
+
+func (s ShelfLife) Category() string {
+	return s.category
+}
+
+func (s ShelfLife) Days() int {
+	return s.days
+}
+
+// IsFallback reports whether the rule applies to food without a matching category
+func (s ShelfLife) IsFallback() bool {
+	return s.category == ""
+}
+
+// ValidateShelfLives rejects rule sets that name a category (or the fallback)
+// twice. Categories compare case-insensitively.
+func ValidateShelfLives(rules []ShelfLife) error {
+	seen := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		key := strings.ToLower(r.category)
+		if seen[key] {
+			return fmt.Errorf("%w: %q", ErrDuplicateShelfLife, r.category)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// matchShelfLife picks the rule for an object: its category property first,
+// then its tags in order, then the fallback rule.
+func matchShelfLife(rules []ShelfLife, props map[string]TypedValue, tags []string) (ShelfLife, bool) {
+	find := func(category string) (ShelfLife, bool) {
+		category = strings.TrimSpace(category)
+		for _, r := range rules {
+			if strings.EqualFold(r.category, category) {
+				return r, true
+			}
+		}
+		return ShelfLife{}, false
+	}
+
+	if tv, ok := props[ShelfLifeCategoryProperty]; ok {
+		if category, ok := tv.Val.(string); ok && strings.TrimSpace(category) != "" {
+			if r, ok := find(category); ok {
+				return r, true
+			}
+		}
+	}
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			continue
+		}
+		if r, ok := find(tag); ok {
+			return r, true
+		}
+	}
+	return find("")
+}
+
+// shelfLifeExpiry returns when food added at from expires under the rules, or
+// nil when no rule applies.
+func shelfLifeExpiry(rules []ShelfLife, props map[string]TypedValue, tags []string, from time.Time) *time.Time {
+	r, ok := matchShelfLife(rules, props, tags)
+	if !ok {
+		return nil
+	}
+	expires := from.AddDate(0, 0, r.days)
+	return &expires
+}
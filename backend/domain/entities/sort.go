@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SortField identifies the attribute a listing is ordered by.
+type SortField string
+
+const (
+	SortFieldName      SortField = "name"
+	SortFieldCreatedAt SortField = "created_at"
+	SortFieldUpdatedAt SortField = "updated_at"
+	SortFieldExpiresAt SortField = "expires_at"
+	SortFieldQuantity  SortField = "quantity"
+	// SortFieldCustom orders collections and containers by the positions the
+	// user gave them (see ItemOrder).
+	SortFieldCustom SortField = "custom"
+)
+
+// AllSortFields contains every SortField that applies to objects.
+var AllSortFields = []SortField{
+	SortFieldName,
+	SortFieldCreatedAt,
+	SortFieldUpdatedAt,
+	SortFieldExpiresAt,
+	SortFieldQuantity,
+}
+
+func (f SortField) String() string {
+	return string(f)
+}
+
+// SortOrder is the direction of a sort.
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+func (o SortOrder) String() string {
+	return string(o)
+}
+
+// SortOptions describes how a listing should be ordered. The zero value means
+// storage order.
+type SortOptions struct {
+	Field SortField
+	Order SortOrder
+}
+
+// NewSortOptions validates a sort field and order. An empty field yields the
+// zero SortOptions; an empty order defaults to ascending. allowed restricts the
+// accepted fields (nil accepts every field in AllSortFields).
+func NewSortOptions(field, order string, allowed []SortField) (SortOptions, error) {
+	if field == "" {
+		if order != "" {
+			return SortOptions{}, fmt.Errorf("order requires a sort field")
+		}
+		return SortOptions{}, nil
+	}
+
+	if allowed == nil {
+		allowed = AllSortFields
+	}
+	f := SortField(field)
+	if !slices.Contains(allowed, f) {
+		return SortOptions{}, fmt.Errorf("unsupported sort field %q", field)
+	}
+
+	o := SortOrder(order)
+	switch o {
+	case "":
+		o = SortOrderAsc
+	case SortOrderAsc, SortOrderDesc:
+	default:
+		return SortOptions{}, fmt.Errorf("invalid sort order %q: must be asc or desc", order)
+	}
+
+	return SortOptions{Field: f, Order: o}, nil
+}
+
+// IsZero reports whether no sort was requested.
+func (s SortOptions) IsZero() bool {
+	return s.Field == ""
+}
+
+// Descending reports whether the sort is in descending order.
+func (s SortOptions) Descending() bool {
+	return s.Order == SortOrderDesc
+}
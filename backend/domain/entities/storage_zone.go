@@ -0,0 +1,145 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidTemperatureZone = errors.New("temperature zone must be frozen, chilled or ambient")
+	ErrInvalidHumidity        = errors.New("humidity must be between 0 and 100 percent")
+)
+
+// TemperatureZone is how cold a container keeps what is stored in it. An
+// empty zone means the container does not say.
+type TemperatureZone string
+
+const (
+	TemperatureZoneFrozen  TemperatureZone = "frozen"
+	TemperatureZoneChilled TemperatureZone = "chilled"
+	TemperatureZoneAmbient TemperatureZone = "ambient"
+)
+
+// ParseTemperatureZone validates a zone, accepting "" for no zone
+func ParseTemperatureZone(s string) (TemperatureZone, error) {
+	switch zone := TemperatureZone(strings.ToLower(strings.TrimSpace(s))); zone {
+	case "", TemperatureZoneFrozen, TemperatureZoneChilled, TemperatureZoneAmbient:
+		return zone, nil
+	}
+	return "", ErrInvalidTemperatureZone
+}
+
+// ValidateHumidity checks a relative humidity in percent; nil is unset
+func ValidateHumidity(humidity *float64) error {
+	if humidity != nil && (*humidity < 0 || *humidity > 100) {
+		return ErrInvalidHumidity
+	}
+	return nil
+}
+
+// warmth orders zones from coldest to warmest
+func (z TemperatureZone) warmth() int {
+	switch z {
+	case TemperatureZoneFrozen:
+		return 0
+	case TemperatureZoneChilled:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// foodStorageZones are the food categories that must be kept cold, matched
+// against the category property and tags like shelf life categories.
+var foodStorageZones = map[string]TemperatureZone{
+	"dairy":        TemperatureZoneChilled,
+	"meat":         TemperatureZoneChilled,
+	"poultry":      TemperatureZoneChilled,
+	"fish":         TemperatureZoneChilled,
+	"seafood":      TemperatureZoneChilled,
+	"eggs":         TemperatureZoneChilled,
+	"deli":         TemperatureZoneChilled,
+	"refrigerated": TemperatureZoneChilled,
+	"frozen":       TemperatureZoneFrozen,
+	"ice cream":    TemperatureZoneFrozen,
+}
+
+// requiredStorageZone returns the zone food of the object's category needs:
+// its category property first, then its tags in order.
+func requiredStorageZone(props map[string]TypedValue, tags []string) (string, TemperatureZone, bool) {
+	candidates := make([]string, 0, len(tags)+1)
+	if tv, ok := props[ShelfLifeCategoryProperty]; ok {
+		if category, ok := tv.Val.(string); ok {
+			candidates = append(candidates, category)
+		}
+	}
+	candidates = append(candidates, tags...)
+	for _, category := range candidates {
+		category = strings.TrimSpace(category)
+		if zone, ok := foodStorageZones[strings.ToLower(category)]; ok {
+			return category, zone, true
+		}
+	}
+	return "", "", false
+}
+
+// StorageWarning says a food object sits in a container warmer than its
+// category allows, e.g. dairy on an ambient shelf.
+type StorageWarning struct {
+	objectID   ObjectID
+	objectName string
+	category   string
+	required   TemperatureZone
+	zone       TemperatureZone
+}
+
+func (w StorageWarning) ObjectID() ObjectID {
+	return w.objectID
+}
+
+func (w StorageWarning) Category() string {
+	return w.category
+}
+
+// Required is the zone the object's category needs
+func (w StorageWarning) Required() TemperatureZone {
+	return w.required
+}
+
+// Zone is the zone of the container the object is in
+func (w StorageWarning) Zone() TemperatureZone {
+	return w.zone
+}
+
+func (w StorageWarning) Message() string {
+	need := "refrigeration"
+	if w.required == TemperatureZoneFrozen {
+		need = "freezing"
+	}
+	return fmt.Sprintf("%s is %s, which needs %s, but its container is %s", w.objectName, strings.ToLower(w.category), need, w.zone)
+}
+
+// StorageWarningFor checks a food object against the container's zone. An
+// untyped object counts as collectionType, which may be "" when the
+// collection is not at hand. Containers without a zone are not checked.
+func (c *Container) StorageWarningFor(object Object, collectionType ObjectType) (StorageWarning, bool) {
+	objectType := object.ObjectType()
+	if objectType == "" {
+		objectType = collectionType
+	}
+	if objectType != ObjectTypeFood || c.temperatureZone == "" {
+		return StorageWarning{}, false
+	}
+	category, required, ok := requiredStorageZone(object.Properties(), object.Tags())
+	if !ok || c.temperatureZone.warmth() <= required.warmth() {
+		return StorageWarning{}, false
+	}
+	return StorageWarning{
+		objectID:   object.ID(),
+		objectName: object.Name().String(),
+		category:   category,
+		required:   required,
+		zone:       c.temperatureZone,
+	}, true
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "errors"
+
+var ErrUnknownUnitSystem = errors.New("unit system must be metric or imperial")
+
+// UnitSystem is the system of measurement quantities are shown in.
+type UnitSystem string
+
+const (
+	UnitSystemMetric   UnitSystem = "metric"
+	UnitSystemImperial UnitSystem = "imperial"
+)
+
+// DefaultUnitSystem is used until the account picks another.
+const DefaultUnitSystem = UnitSystemMetric
+
+// ParseUnitSystem returns the unit system named s, DefaultUnitSystem for an
+// empty one.
+func ParseUnitSystem(s string) (UnitSystem, error) {
+	switch UnitSystem(s) {
+	case "":
+		return DefaultUnitSystem, nil
+	case UnitSystemMetric, UnitSystemImperial:
+		return UnitSystem(s), nil
+	}
+	return "", ErrUnknownUnitSystem
+}
@@ -0,0 +1,267 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+const (
+	maxViewKeyLength      = 200
+	maxViewsPerUser       = 500
+	maxViewSortSpecs      = 5
+	maxViewFilters        = 20
+	maxViewPreferenceText = 100
+	maxOrderedItems       = 1000
+)
+
+var (
+	ErrUserPreferencesNotFound = errors.New("user preferences not found")
+	ErrInvalidViewKey          = errors.New("view key must be between 1 and 200 characters and contain no '.' or '$'")
+	ErrInvalidViewPreference   = errors.New("invalid view preference")
+	ErrTooManyViews            = errors.New("too many saved view preferences")
+	ErrInvalidItemOrder        = errors.New("invalid item order")
+	ErrTooManyOrderedItems     = errors.New("too many pinned or reordered items")
+)
+
+// ViewSort is one step of a chained sort. Field is an object field such as
+// name or expires_at, or a property key.
+type ViewSort struct {
+	Field     string
+	Direction string // asc or desc
+}
+
+// ViewPreference is the sort, grouping and filter state of one view, keyed by
+// the view it belongs to, e.g. "collection:<id>".
+type ViewPreference struct {
+	Sort    []ViewSort
+	GroupBy string
+	Filters map[string]string
+}
+
+// IsEmpty reports whether the preference holds no state, in which case it is
+// dropped rather than stored.
+func (p ViewPreference) IsEmpty() bool {
+	return len(p.Sort) == 0 && p.GroupBy == "" && len(p.Filters) == 0
+}
+
+func (p ViewPreference) Validate() error {
+	if len(p.Sort) > maxViewSortSpecs {
+		return fmt.Errorf("%w: at most 5 sort fields", ErrInvalidViewPreference)
+	}
+	for _, s := range p.Sort {
+		if s.Field == "" || len(s.Field) > maxViewPreferenceText {
+			return fmt.Errorf("%w: sort field must be between 1 and 100 characters", ErrInvalidViewPreference)
+		}
+		if s.Direction != "asc" && s.Direction != "desc" {
+			return fmt.Errorf("%w: sort direction must be asc or desc", ErrInvalidViewPreference)
+		}
+	}
+	if len(p.GroupBy) > maxViewPreferenceText {
+		return fmt.Errorf("%w: group_by must be at most 100 characters", ErrInvalidViewPreference)
+	}
+	if len(p.Filters) > maxViewFilters {
+		return fmt.Errorf("%w: at most 20 filters", ErrInvalidViewPreference)
+	}
+	for key, value := range p.Filters {
+		if !validDocumentKey(key, maxViewPreferenceText) || len(value) > maxViewPreferenceText {
+			return fmt.Errorf("%w: filter keys and values must be at most 100 characters and keys contain no '.' or '$'", ErrInvalidViewPreference)
+		}
+	}
+	return nil
+}
+
+// OrderScope names a list the user can reorder.
+type OrderScope string
+
+const (
+	OrderScopeCollections OrderScope = "collections"
+	OrderScopeContainers  OrderScope = "containers"
+)
+
+// ItemOrder is where the user placed one collection or container. Pinned
+// items are listed first under any sort; SortIndex orders items under the
+// custom sort, lowest first, with 0 meaning the item was never placed.
+type ItemOrder struct {
+	SortIndex int
+	Pinned    bool
+}
+
+// IsZero reports whether the item has no placement, in which case it is
+// dropped rather than stored.
+func (o ItemOrder) IsZero() bool {
+	return o.SortIndex == 0 && !o.Pinned
+}
+
+// validDocumentKey reports whether key can be stored as a map key: keys are
+// persisted as document field names, which cannot contain dots or dollars.
+func validDocumentKey(key string, maxLength int) bool {
+	return key != "" && len(key) <= maxLength && !strings.ContainsAny(key, ".$")
+}
+
+// UserPreferences holds a user's per-view display preferences so they follow
+// the user across sessions and devices.
+type UserPreferences struct {
+	userID          UserID
+	views           map[string]ViewPreference
+	collectionOrder map[string]ItemOrder
+	containerOrder  map[string]ItemOrder
+	labelTemplate   string     // key of a LabelTemplate; empty for the default
+	unitSystem      UnitSystem // empty for the default
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+func NewUserPreferences(userID UserID) *UserPreferences {
+	now := time.Now()
+	return &UserPreferences{
+		userID:          userID,
+		views:           make(map[string]ViewPreference),
+		collectionOrder: make(map[string]ItemOrder),
+		containerOrder:  make(map[string]ItemOrder),
+		createdAt:       now,
+		updatedAt:       now,
+	}
+}
+
+func ReconstructUserPreferences(userID UserID, views map[string]ViewPreference, collectionOrder, containerOrder map[string]ItemOrder, labelTemplate string, unitSystem UnitSystem, createdAt, updatedAt time.Time) *UserPreferences {
+	if views == nil {
+		views = make(map[string]ViewPreference)
+	}
+	if collectionOrder == nil {
+		collectionOrder = make(map[string]ItemOrder)
+	}
+	if containerOrder == nil {
+		containerOrder = make(map[string]ItemOrder)
+	}
+	return &UserPreferences{
+		userID:          userID,
+		views:           views,
+		collectionOrder: collectionOrder,
+		containerOrder:  containerOrder,
+		labelTemplate:   labelTemplate,
+		unitSystem:      unitSystem,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (p *UserPreferences) UserID() UserID {
+	return p.userID
+}
+
+// Views returns a copy of the saved preferences keyed by view.
+func (p *UserPreferences) Views() map[string]ViewPreference {
+	return maps.Clone(p.views)
+}
+
+// Order returns a copy of the placements in a scope, keyed by item ID.
+func (p *UserPreferences) Order(scope OrderScope) map[string]ItemOrder {
+	return maps.Clone(p.order(scope))
+}
+
+func (p *UserPreferences) order(scope OrderScope) map[string]ItemOrder {
+	if scope == OrderScopeContainers {
+		return p.containerOrder
+	}
+	return p.collectionOrder
+}
+
+// LabelTemplate returns the key of the label stock object labels are printed
+// on.
+func (p *UserPreferences) LabelTemplate() string {
+	if p.labelTemplate == "" {
+		return DefaultLabelTemplate
+	}
+	return p.labelTemplate
+}
+
+// UnitSystem returns the system of measurement quantities are shown in.
+func (p *UserPreferences) UnitSystem() UnitSystem {
+	if p.unitSystem == "" {
+		return DefaultUnitSystem
+	}
+	return p.unitSystem
+}
+
+func (p *UserPreferences) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *UserPreferences) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// SetView stores the preference for a view, or forgets the view when the
+// preference is empty.
+func (p *UserPreferences) SetView(key string, pref ViewPreference) error {
+	if !validDocumentKey(key, maxViewKeyLength) {
+		return ErrInvalidViewKey
+	}
+	if pref.IsEmpty() {
+		delete(p.views, key)
+		p.updatedAt = time.Now()
+		return nil
+	}
+	if err := pref.Validate(); err != nil {
+		return err
+	}
+	if _, exists := p.views[key]; !exists && len(p.views) >= maxViewsPerUser {
+		return ErrTooManyViews
+	}
+	p.views[key] = pref
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetItemOrder places an item in a scope, or forgets it when the order is
+// zero.
+func (p *UserPreferences) SetItemOrder(scope OrderScope, id string, order ItemOrder) error {
+	if scope != OrderScopeCollections && scope != OrderScopeContainers {
+		return fmt.Errorf("%w: unknown scope %q", ErrInvalidItemOrder, scope)
+	}
+	if !validDocumentKey(id, maxViewKeyLength) {
+		return fmt.Errorf("%w: item ID must be between 1 and 200 characters and contain no '.' or '$'", ErrInvalidItemOrder)
+	}
+	if order.SortIndex < 0 {
+		return fmt.Errorf("%w: sort_index must not be negative", ErrInvalidItemOrder)
+	}
+	orders := p.order(scope)
+	if order.IsZero() {
+		delete(orders, id)
+		p.updatedAt = time.Now()
+		return nil
+	}
+	if _, exists := orders[id]; !exists && len(orders) >= maxOrderedItems {
+		return ErrTooManyOrderedItems
+	}
+	orders[id] = order
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetLabelTemplate picks the label stock for object labels; an empty key goes
+// back to DefaultLabelTemplate.
+func (p *UserPreferences) SetLabelTemplate(key string) error {
+	if key != "" {
+		if _, err := FindLabelTemplate(key); err != nil {
+			return err
+		}
+	}
+	p.labelTemplate = key
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetUnitSystem picks the system of measurement quantities are shown in; an
+// empty name goes back to DefaultUnitSystem.
+func (p *UserPreferences) SetUnitSystem(name string) error {
+	if _, err := ParseUnitSystem(name); err != nil {
+		return err
+	}
+	p.unitSystem = UnitSystem(name)
+	p.updatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,40 @@
+package entities
+
+// ValuationReport totals what a user's objects are worth. Each object is
+// worth the value of its collection's price field, the currency property
+// the collection report also sums; amounts are kept apart by currency, so
+// every total and group is in one currency.
+type ValuationReport struct {
+	// Totals holds one entry per currency, highest first.
+	Totals []ValuationTotal
+	// The breakdowns are ordered highest total first. An object counts
+	// toward each of its tags; ByTag keys untagged objects "". ByCondition
+	// covers the graded object types only and keys ungraded objects "".
+	ByCollection []ValuationGroup
+	ByObjectType []ValuationGroup
+	ByTag        []ValuationGroup
+	ByCondition  []ValuationGroup
+	// Valued and Unvalued count the owned, active objects with and without
+	// a price.
+	Valued   int
+	Unvalued int
+}
+
+// ValuationTotal is the value of every priced object in one currency
+type ValuationTotal struct {
+	Currency string
+	Objects  int
+	Total    float64
+}
+
+// ValuationGroup is the value of the objects in one currency that share a
+// collection, object type, tag or condition
+type ValuationGroup struct {
+	// Key is the collection ID, object type, tag or condition; Name is the
+	// collection's name, or the key for the other breakdowns.
+	Key      string
+	Name     string
+	Currency string
+	Objects  int
+	Total    float64
+}
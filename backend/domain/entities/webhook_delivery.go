@@ -0,0 +1,248 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidWebhookDeliveryID = errors.New("invalid webhook delivery ID")
+	ErrWebhookDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrInvalidWebhookRetry      = errors.New("invalid webhook retry policy")
+)
+
+const (
+	// MaxWebhookRetries caps how often a failed webhook is tried again
+	MaxWebhookRetries = 10
+	// MaxWebhookRetryBackoff is the longest first wait, in seconds, a retry
+	// policy can ask for
+	MaxWebhookRetryBackoff = 3600
+	// maxWebhookRetryDelay caps the wait between two attempts as it doubles
+	maxWebhookRetryDelay = 6 * time.Hour
+	// WebhookDeliveryRetention is how long deliveries are kept for the
+	// deliveries list
+	WebhookDeliveryRetention = 30 * 24 * time.Hour
+)
+
+// WebhookRetryPolicy says how a webhook that fails is tried again: up to
+// MaxRetries more times, waiting Backoff seconds before the first retry and
+// twice as long before each one after it. The zero policy never retries.
+type WebhookRetryPolicy struct {
+	MaxRetries int
+	Backoff    int
+}
+
+func (p WebhookRetryPolicy) Validate() error {
+	if p.MaxRetries < 0 || p.MaxRetries > MaxWebhookRetries {
+		return ErrInvalidWebhookRetry
+	}
+	if p.MaxRetries > 0 && (p.Backoff < 1 || p.Backoff > MaxWebhookRetryBackoff) {
+		return ErrInvalidWebhookRetry
+	}
+	return nil
+}
+
+// Delay is how long to wait before retrying after the given failed attempt
+func (p WebhookRetryPolicy) Delay(attempt int) time.Duration {
+	delay := time.Duration(p.Backoff) * time.Second
+	for i := 1; i < attempt && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookRetryDelay)
+}
+
+type WebhookDeliveryID struct {
+	value string
+}
+
+func NewWebhookDeliveryID() WebhookDeliveryID {
+	return WebhookDeliveryID{value: uuid.New().String()}
+}
+
+func WebhookDeliveryIDFromString(id string) (WebhookDeliveryID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return WebhookDeliveryID{}, ErrInvalidWebhookDeliveryID
+	}
+	return WebhookDeliveryID{value: id}, nil
+}
+
+func (id WebhookDeliveryID) String() string {
+	return id.value
+}
+
+func (id WebhookDeliveryID) Equals(other WebhookDeliveryID) bool {
+	return id.value == other.value
+}
+
+// WebhookDeliveryStatus is where a delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookPending deliveries wait for their next attempt
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookSucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookFailed deliveries ran out of retries
+	WebhookFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one webhook an automation rule sent, or is still
+// trying to send, with the body it posts on every attempt.
+type WebhookDelivery struct {
+	id            WebhookDeliveryID
+	userID        UserID
+	ruleID        AutomationRuleID
+	ruleName      string
+	url           string
+	event         string
+	payload       []byte
+	retry         WebhookRetryPolicy
+	status        WebhookDeliveryStatus
+	attempts      int
+	lastError     string
+	lastAttemptAt *time.Time
+	nextAttemptAt *time.Time
+	createdAt     time.Time
+}
+
+type WebhookDeliveryProps struct {
+	RuleID   AutomationRuleID
+	RuleName string
+	URL      string
+	Event    string
+	// Payload is the JSON body
+	Payload []byte
+	Retry   WebhookRetryPolicy
+}
+
+// NewWebhookDelivery returns a delivery due at once
+func NewWebhookDelivery(userID UserID, props WebhookDeliveryProps, now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:            NewWebhookDeliveryID(),
+		userID:        userID,
+		ruleID:        props.RuleID,
+		ruleName:      props.RuleName,
+		url:           props.URL,
+		event:         props.Event,
+		payload:       props.Payload,
+		retry:         props.Retry,
+		status:        WebhookPending,
+		nextAttemptAt: &now,
+		createdAt:     now,
+	}
+}
+
+func ReconstructWebhookDelivery(
+	id WebhookDeliveryID,
+	userID UserID,
+	props WebhookDeliveryProps,
+	status WebhookDeliveryStatus,
+	attempts int,
+	lastError string,
+	lastAttemptAt, nextAttemptAt *time.Time,
+	createdAt time.Time,
+) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:            id,
+		userID:        userID,
+		ruleID:        props.RuleID,
+		ruleName:      props.RuleName,
+		url:           props.URL,
+		event:         props.Event,
+		payload:       props.Payload,
+		retry:         props.Retry,
+		status:        status,
+		attempts:      attempts,
+		lastError:     lastError,
+		lastAttemptAt: lastAttemptAt,
+		nextAttemptAt: nextAttemptAt,
+		createdAt:     createdAt,
+	}
+}
+
+func (d *WebhookDelivery) ID() WebhookDeliveryID {
+	return d.id
+}
+
+func (d *WebhookDelivery) UserID() UserID {
+	return d.userID
+}
+
+func (d *WebhookDelivery) RuleID() AutomationRuleID {
+	return d.ruleID
+}
+
+func (d *WebhookDelivery) RuleName() string {
+	return d.ruleName
+}
+
+func (d *WebhookDelivery) URL() string {
+	return d.url
+}
+
+func (d *WebhookDelivery) Event() string {
+	return d.event
+}
+
+func (d *WebhookDelivery) Payload() []byte {
+	return d.payload
+}
+
+func (d *WebhookDelivery) Retry() WebhookRetryPolicy {
+	return d.retry
+}
+
+func (d *WebhookDelivery) Status() WebhookDeliveryStatus {
+	return d.status
+}
+
+// Attempts counts the times the webhook was sent, retries included
+func (d *WebhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+// LastError says why the last attempt failed, empty if it succeeded
+func (d *WebhookDelivery) LastError() string {
+	return d.lastError
+}
+
+func (d *WebhookDelivery) LastAttemptAt() *time.Time {
+	return d.lastAttemptAt
+}
+
+// NextAttemptAt is when a pending delivery is tried again, nil otherwise
+func (d *WebhookDelivery) NextAttemptAt() *time.Time {
+	return d.nextAttemptAt
+}
+
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *WebhookDelivery) IsOwnedBy(userID UserID) bool {
+	return d.userID.Equals(userID)
+}
+
+// RecordAttempt notes an attempt made at now that failed with err, or
+// succeeded when err is nil. A failure is scheduled for a retry while the
+// policy allows one and fails the delivery after that.
+func (d *WebhookDelivery) RecordAttempt(err error, now time.Time) {
+	d.attempts++
+	d.lastAttemptAt = &now
+	d.nextAttemptAt = nil
+	if err == nil {
+		d.status = WebhookSucceeded
+		d.lastError = ""
+		return
+	}
+	d.lastError = err.Error()
+	// A failed delivery sent again by hand gets no further retries
+	if retries := d.attempts - 1; retries < d.retry.MaxRetries {
+		next := now.Add(d.retry.Delay(d.attempts))
+		d.status = WebhookPending
+		d.nextAttemptAt = &next
+		return
+	}
+	d.status = WebhookFailed
+}
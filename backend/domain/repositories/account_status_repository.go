@@ -0,0 +1,20 @@
+//go:generate mockgen -source=account_status_repository.go -destination=../../mocks/mock_account_status_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type AccountStatusRepository interface {
+	GetByUserID(ctx context.Context, userID entities.UserID) (*entities.AccountStatus, error)
+	// Save inserts the status or replaces the existing one for the user.
+	Save(ctx context.Context, status *entities.AccountStatus) error
+	// List returns every known account, most recently seen first.
+	List(ctx context.Context) ([]*entities.AccountStatus, error)
+	// DeleteByUserID removes the user's status. It is not an error if there
+	// is none.
+	DeleteByUserID(ctx context.Context, userID entities.UserID) error
+}
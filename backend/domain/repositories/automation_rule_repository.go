@@ -0,0 +1,27 @@
+//go:generate mockgen -source=automation_rule_repository.go -destination=../../mocks/mock_automation_rule_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// AutomationRuleRepository stores users' automation rules.
+type AutomationRuleRepository interface {
+	Create(ctx context.Context, rule *entities.AutomationRule) error
+	GetByID(ctx context.Context, id entities.AutomationRuleID) (*entities.AutomationRule, error)
+	// ListByUserID returns the user's rules, oldest first.
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.AutomationRule, error)
+	// ListEnabledByTrigger returns every user's enabled rules with the
+	// given trigger kind.
+	ListEnabledByTrigger(ctx context.Context, kind entities.AutomationTriggerKind) ([]*entities.AutomationRule, error)
+	Update(ctx context.Context, rule *entities.AutomationRule) error
+	// RecordRun sets when the rule last fired without touching the rest of
+	// it, so a run cannot undo an edit saved meanwhile.
+	RecordRun(ctx context.Context, id entities.AutomationRuleID, at time.Time) error
+	Delete(ctx context.Context, id entities.AutomationRuleID) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
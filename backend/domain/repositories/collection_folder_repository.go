@@ -0,0 +1,21 @@
+//go:generate mockgen -source=collection_folder_repository.go -destination=../../mocks/mock_collection_folder_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// CollectionFolderRepository stores the folders users group their collection
+// lists into.
+type CollectionFolderRepository interface {
+	Create(ctx context.Context, folder *entities.CollectionFolder) error
+	GetByID(ctx context.Context, id entities.CollectionFolderID) (*entities.CollectionFolder, error)
+	// ListByUserID returns all of the user's folders, by name.
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.CollectionFolder, error)
+	Update(ctx context.Context, folder *entities.CollectionFolder) error
+	Delete(ctx context.Context, id entities.CollectionFolderID) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
@@ -14,8 +14,13 @@ type CollectionRepository interface {
 	GetByIDSummary(ctx context.Context, id entities.CollectionID) (*entities.Collection, error)
 	Update(ctx context.Context, collection *entities.Collection) error
 	Delete(ctx context.Context, id entities.CollectionID) error
+	// GetByUserID and GetByUserIDSummary return only collections the user
+	// personally owns; group-owned collections are never included.
 	GetByUserID(ctx context.Context, userID entities.UserID) ([]*entities.Collection, error)
 	GetByUserIDSummary(ctx context.Context, userID entities.UserID) ([]*entities.Collection, error)
+	// GetGroupOwnedSummary returns collections owned by any of the groups,
+	// without containers.
+	GetGroupOwnedSummary(ctx context.Context, groupIDs []entities.GroupID) ([]*entities.Collection, error)
 	GetByGroupID(ctx context.Context, groupID entities.GroupID) ([]*entities.Collection, error)
 	List(ctx context.Context, limit, offset int) ([]*entities.Collection, error)
 	Exists(ctx context.Context, id entities.CollectionID) (bool, error)
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=collection_snapshot_repository.go -destination=../../mocks/mock_collection_snapshot_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// CollectionSnapshotRepository stores point-in-time copies of collections.
+type CollectionSnapshotRepository interface {
+	Create(ctx context.Context, snapshot *entities.CollectionSnapshot) error
+	GetByID(ctx context.Context, id entities.CollectionSnapshotID) (*entities.CollectionSnapshot, error)
+	// ListByCollectionID returns the collection's snapshots, newest first.
+	ListByCollectionID(ctx context.Context, collectionID entities.CollectionID) ([]*entities.CollectionSnapshot, error)
+	Delete(ctx context.Context, id entities.CollectionSnapshotID) error
+	DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error)
+}
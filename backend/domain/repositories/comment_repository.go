@@ -0,0 +1,34 @@
+//go:generate mockgen -source=comment_repository.go -destination=../../mocks/mock_comment_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// CommentRepository stores comments on collections and objects, and when
+// each user last read a collection's comments.
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entities.Comment) error
+	GetByID(ctx context.Context, id entities.CommentID) (*entities.Comment, error)
+	// List returns up to limit comments on the collection posted before the
+	// given time (any time when zero), newest first. With a nil objectID it
+	// lists every comment on the collection, object comments included.
+	List(ctx context.Context, collectionID entities.CollectionID, objectID *entities.ObjectID, before time.Time, limit int) ([]*entities.Comment, error)
+	Delete(ctx context.Context, id entities.CommentID) error
+	// DeleteByCollectionID removes the collection's comments and read markers.
+	DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error)
+	// DeleteByUserID removes the comments the user wrote anywhere and the
+	// user's read markers.
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+
+	// MarkRead records that the user has read the collection's comments up
+	// to at.
+	MarkRead(ctx context.Context, userID entities.UserID, collectionID entities.CollectionID, at time.Time) error
+	// CountUnread counts, for each collection that has any, the comments by
+	// others posted after the user's read marker for it.
+	CountUnread(ctx context.Context, userID entities.UserID, collectionIDs []entities.CollectionID) ([]entities.CommentUnread, error)
+}
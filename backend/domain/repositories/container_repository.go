@@ -4,6 +4,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/nishiki/backend/domain/entities"
 )
@@ -23,5 +24,14 @@ type ContainerRepository interface {
 	FindByObjectID(ctx context.Context, objectID entities.ObjectID) (*entities.Container, error)
 	AddObject(ctx context.Context, containerID entities.ContainerID, object entities.Object) error
 	RemoveObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID) error
+	// ClaimObject stores claim on the object in one conditional write. It
+	// returns entities.ErrObjectClaimed when another member holds a claim
+	// that is still active at claim.ClaimedAt().
+	ClaimObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, claim entities.ObjectClaim) error
+	// ReleaseObjectClaim clears the object's claim. With a holder it only
+	// clears a claim that is the holder's own or no longer active at now,
+	// and returns entities.ErrObjectClaimed otherwise; a nil holder clears
+	// any claim.
+	ReleaseObjectClaim(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, holder *entities.UserID, now time.Time) error
 	GetByCollectionIDWithAccess(ctx context.Context, collectionID entities.CollectionID, userID entities.UserID, groupIDs []entities.GroupID) ([]*entities.Container, error)
 }
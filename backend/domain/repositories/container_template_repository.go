@@ -0,0 +1,19 @@
+//go:generate mockgen -source=container_template_repository.go -destination=../../mocks/mock_container_template_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// ContainerTemplateRepository stores user-saved container templates. Built-in
+// templates live in code and are never persisted.
+type ContainerTemplateRepository interface {
+	Create(ctx context.Context, template *entities.ContainerTemplate) error
+	GetByID(ctx context.Context, id entities.ContainerTemplateID) (*entities.ContainerTemplate, error)
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.ContainerTemplate, error)
+	Delete(ctx context.Context, id entities.ContainerTemplateID) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
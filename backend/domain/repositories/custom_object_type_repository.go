@@ -0,0 +1,24 @@
+//go:generate mockgen -source=custom_object_type_repository.go -destination=../../mocks/mock_custom_object_type_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// CustomObjectTypeRepository stores the object types accounts define beyond
+// the built-in ones.
+type CustomObjectTypeRepository interface {
+	Create(ctx context.Context, objectType *entities.CustomObjectType) error
+	GetByID(ctx context.Context, id entities.CustomObjectTypeID) (*entities.CustomObjectType, error)
+	// GetByKey returns the user's type with the key, or
+	// entities.ErrCustomObjectTypeNotFound.
+	GetByKey(ctx context.Context, userID entities.UserID, key entities.ObjectType) (*entities.CustomObjectType, error)
+	// ListByUserID returns all of the user's types, by name.
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.CustomObjectType, error)
+	Update(ctx context.Context, objectType *entities.CustomObjectType) error
+	Delete(ctx context.Context, id entities.CustomObjectTypeID) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=digest_subscription_repository.go -destination=../../mocks/mock_digest_subscription_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type DigestSubscriptionRepository interface {
+	GetByUserID(ctx context.Context, userID entities.UserID) (*entities.DigestSubscription, error)
+	GetByUnsubscribeToken(ctx context.Context, token string) (*entities.DigestSubscription, error)
+	// Save inserts the subscription or replaces the existing one for the user.
+	Save(ctx context.Context, subscription *entities.DigestSubscription) error
+	// ListActive returns every subscription whose frequency is not off.
+	ListActive(ctx context.Context) ([]*entities.DigestSubscription, error)
+	// DeleteByUserID removes the user's subscription. It is not an error if
+	// the user never had one.
+	DeleteByUserID(ctx context.Context, userID entities.UserID) error
+}
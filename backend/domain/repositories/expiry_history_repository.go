@@ -0,0 +1,20 @@
+//go:generate mockgen -source=expiry_history_repository.go -destination=../../mocks/mock_expiry_history_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// ExpiryHistoryRepository remembers how long each user's objects of a
+// category were set to keep, one entry per (user, category).
+type ExpiryHistoryRepository interface {
+	// AddSample appends days to the category's history, keeping only the
+	// latest entities.MaxExpirySamples. It is safe to call concurrently.
+	AddSample(ctx context.Context, userID entities.UserID, category string, days int) error
+	// ListByUserID returns the user's histories ordered by category.
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.ExpiryHistory, error)
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
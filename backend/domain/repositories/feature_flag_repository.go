@@ -0,0 +1,18 @@
+//go:generate mockgen -source=feature_flag_repository.go -destination=../../mocks/mock_feature_flag_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// FeatureFlagRepository stores the feature flags admins set at runtime
+type FeatureFlagRepository interface {
+	// List returns every stored flag by name.
+	List(ctx context.Context) ([]*entities.FeatureFlag, error)
+	// Save inserts the flag or replaces the one with the same name.
+	Save(ctx context.Context, flag *entities.FeatureFlag) error
+	Delete(ctx context.Context, name string) error
+}
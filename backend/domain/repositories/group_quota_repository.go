@@ -0,0 +1,17 @@
+//go:generate mockgen -source=group_quota_repository.go -destination=../../mocks/mock_group_quota_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type GroupQuotaRepository interface {
+	// GetByGroupID returns entities.ErrGroupQuotaNotFound for a group that
+	// has never had a quota set, which leaves it unlimited.
+	GetByGroupID(ctx context.Context, groupID entities.GroupID) (*entities.GroupQuota, error)
+	// Save inserts the quota or replaces the group's existing one.
+	Save(ctx context.Context, quota *entities.GroupQuota) error
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source=inbox_repository.go -destination=../../mocks/mock_inbox_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// InboxRepository stores users' inbox addresses and the forwarded emails
+// waiting in their inbox.
+type InboxRepository interface {
+	GetAddressByUserID(ctx context.Context, userID entities.UserID) (*entities.InboxAddress, error)
+	GetAddressByToken(ctx context.Context, token string) (*entities.InboxAddress, error)
+	// SaveAddress creates or replaces the user's address.
+	SaveAddress(ctx context.Context, address *entities.InboxAddress) error
+
+	CreateItem(ctx context.Context, item *entities.InboxItem) error
+	GetItemByID(ctx context.Context, id entities.InboxItemID) (*entities.InboxItem, error)
+	// ListItemsByUserID returns the user's pending items, newest first.
+	ListItemsByUserID(ctx context.Context, userID entities.UserID) ([]*entities.InboxItem, error)
+	CountItemsByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+	UpdateItem(ctx context.Context, item *entities.InboxItem) error
+	DeleteItem(ctx context.Context, id entities.InboxItemID) error
+	// DeleteByUserID removes the user's address and every pending item,
+	// returning how many items were removed.
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
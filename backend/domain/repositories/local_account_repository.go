@@ -0,0 +1,19 @@
+//go:generate mockgen -source=local_account_repository.go -destination=../../mocks/mock_local_account_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type LocalAccountRepository interface {
+	// Create fails with entities.ErrLocalAccountExists when the username is
+	// taken, compared case-insensitively.
+	Create(ctx context.Context, account *entities.LocalAccount) error
+	GetByUsername(ctx context.Context, username string) (*entities.LocalAccount, error)
+	GetByUserID(ctx context.Context, userID entities.UserID) (*entities.LocalAccount, error)
+	Count(ctx context.Context) (int64, error)
+	DeleteByUserID(ctx context.Context, userID entities.UserID) error
+}
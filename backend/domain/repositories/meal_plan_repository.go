@@ -0,0 +1,21 @@
+//go:generate mockgen -source=meal_plan_repository.go -destination=../../mocks/mock_meal_plan_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// MealPlanRepository stores the meals users plan to cook.
+type MealPlanRepository interface {
+	Create(ctx context.Context, plan *entities.MealPlan) error
+	GetByID(ctx context.Context, id entities.MealPlanID) (*entities.MealPlan, error)
+	// ListByUserID returns the user's plans dated within [from, to), oldest first.
+	ListByUserID(ctx context.Context, userID entities.UserID, from, to time.Time) ([]*entities.MealPlan, error)
+	Update(ctx context.Context, plan *entities.MealPlan) error
+	Delete(ctx context.Context, id entities.MealPlanID) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -source=media_repository.go -destination=../../mocks/mock_media_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// MediaRepository stores the records of photos attached to objects and
+// containers. Listings are newest first and return the total match count
+// alongside the page.
+type MediaRepository interface {
+	Create(ctx context.Context, media *entities.Media) error
+	GetByID(ctx context.Context, id entities.MediaID) (*entities.Media, error)
+	ListByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string, page entities.MediaPage) ([]*entities.Media, int64, error)
+	ListByCollectionID(ctx context.Context, collectionID entities.CollectionID, page entities.MediaPage) ([]*entities.Media, int64, error)
+	CountByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string) (int64, error)
+	Delete(ctx context.Context, id entities.MediaID) error
+	DeleteByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string) (int64, error)
+	DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error)
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=oauth_client_repository.go -destination=../../mocks/mock_oauth_client_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type OAuthClientRepository interface {
+	// List returns every registered client, oldest first.
+	List(ctx context.Context) ([]*entities.OAuthClient, error)
+	GetByClientID(ctx context.Context, clientID string) (*entities.OAuthClient, error)
+	// Save inserts the client or replaces the one with the same client ID.
+	Save(ctx context.Context, client *entities.OAuthClient) error
+	Delete(ctx context.Context, clientID string) error
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=object_code_repository.go -destination=../../mocks/mock_object_code_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// ObjectCodeRepository indexes objects by barcode, ISBN or SKU per account,
+// with one entry per (account, code) listing every object carrying the
+// code. Add and Remove are idempotent so concurrent writers never conflict.
+// The index is advisory: readers check each hit against the object itself.
+type ObjectCodeRepository interface {
+	Add(ctx context.Context, userID entities.UserID, code string, objectID entities.ObjectID) error
+	Remove(ctx context.Context, userID entities.UserID, code string, objectID entities.ObjectID) error
+	// Lookup returns the objects indexed under the code, oldest first, or
+	// none when the code is unknown.
+	Lookup(ctx context.Context, userID entities.UserID, code string) ([]entities.ObjectID, error)
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=object_move_repository.go -destination=../../mocks/mock_object_move_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// ObjectMoveRepository stores the container move history of objects.
+type ObjectMoveRepository interface {
+	Create(ctx context.Context, move *entities.ObjectMove) error
+	// ListByObjectID returns the object's moves, oldest first.
+	ListByObjectID(ctx context.Context, objectID entities.ObjectID) ([]*entities.ObjectMove, error)
+	// ListByObjectIDs returns the moves of all the given objects, oldest first.
+	ListByObjectIDs(ctx context.Context, objectIDs []entities.ObjectID) ([]*entities.ObjectMove, error)
+	DeleteByObjectIDs(ctx context.Context, objectIDs []entities.ObjectID) (int64, error)
+}
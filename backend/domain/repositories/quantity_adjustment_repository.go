@@ -0,0 +1,20 @@
+//go:generate mockgen -source=quantity_adjustment_repository.go -destination=../../mocks/mock_quantity_adjustment_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// QuantityAdjustmentRepository stores the quantity change history of
+// objects.
+type QuantityAdjustmentRepository interface {
+	Create(ctx context.Context, adjustment *entities.QuantityAdjustment) error
+	// ListByCollectionIDs returns the adjustments made to objects of the
+	// given collections from from up to to, oldest first.
+	ListByCollectionIDs(ctx context.Context, collectionIDs []entities.CollectionID, from, to time.Time) ([]*entities.QuantityAdjustment, error)
+	DeleteByObjectIDs(ctx context.Context, objectIDs []entities.ObjectID) (int64, error)
+}
@@ -0,0 +1,27 @@
+//go:generate mockgen -source=recurrence_rule_repository.go -destination=../../mocks/mock_recurrence_rule_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// RecurrenceRuleRepository stores the schedules that put staples back on
+// users' shopping lists.
+type RecurrenceRuleRepository interface {
+	Create(ctx context.Context, rule *entities.RecurrenceRule) error
+	GetByID(ctx context.Context, id entities.RecurrenceRuleID) (*entities.RecurrenceRule, error)
+	// GetByObjectID returns the user's rule for the object, or
+	// ErrRecurrenceRuleNotFound.
+	GetByObjectID(ctx context.Context, userID entities.UserID, objectID entities.ObjectID) (*entities.RecurrenceRule, error)
+	// ListByUserID returns the user's rules, soonest due first.
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.RecurrenceRule, error)
+	// ListDue returns every user's rules whose next due date is at or before t.
+	ListDue(ctx context.Context, t time.Time) ([]*entities.RecurrenceRule, error)
+	Update(ctx context.Context, rule *entities.RecurrenceRule) error
+	Delete(ctx context.Context, id entities.RecurrenceRuleID) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import "context"
+
+type replicaReadsKey struct{}
+
+// WithReplicaReads returns ctx marked as fine to read slightly stale data.
+// Repositories given such a context may serve their reads from the
+// configured read replica; writes, lookups by ID and tenant checks always
+// use the primary. Only read-only requests should carry it, since a replica
+// may not have caught up with a write made moments earlier.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsKey{}, true)
+}
+
+// ReplicaReads reports whether ctx allows reads from the replica
+func ReplicaReads(ctx context.Context) bool {
+	ok, _ := ctx.Value(replicaReadsKey{}).(bool)
+	return ok
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=session_repository.go -destination=../../mocks/mock_session_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type SessionRepository interface {
+	GetByID(ctx context.Context, id string) (*entities.Session, error)
+	// Save inserts the session or replaces the one with the same ID.
+	Save(ctx context.Context, session *entities.Session) error
+	// ListByUserID returns the user's sessions, revoked and expired ones
+	// included, most recently seen first.
+	ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.Session, error)
+	// DeleteByUserID removes all of the user's sessions and returns how
+	// many there were.
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
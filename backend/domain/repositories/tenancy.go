@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// Tenant is the account a request acts for. Collection and container
+// repositories given a context that carries one limit every query to what
+// the account owns or shares through a group, so a missed access check in
+// a handler still cannot reach another account's data. Contexts without a
+// tenant, as in background jobs, see everything.
+type Tenant struct {
+	UserID entities.UserID
+
+	lookupGroups func(ctx context.Context) ([]*entities.Group, error)
+	once         sync.Once
+	groupIDs     []entities.GroupID
+	err          error
+}
+
+// NewTenant builds the tenant for userID. lookupGroups is called at most
+// once, the first time a repository needs the account's groups.
+func NewTenant(userID entities.UserID, lookupGroups func(ctx context.Context) ([]*entities.Group, error)) *Tenant {
+	return &Tenant{UserID: userID, lookupGroups: lookupGroups}
+}
+
+type tenantKey struct{}
+
+// WithTenant returns ctx carrying tenant
+func WithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant ctx carries, or nil
+func TenantFromContext(ctx context.Context) *Tenant {
+	tenant, _ := ctx.Value(tenantKey{}).(*Tenant)
+	return tenant
+}
+
+// GroupIDs returns the groups the account belongs to
+func (t *Tenant) GroupIDs(ctx context.Context) ([]entities.GroupID, error) {
+	t.once.Do(func() {
+		if t.lookupGroups == nil {
+			return
+		}
+		groups, err := t.lookupGroups(ctx)
+		if err != nil {
+			t.err = err
+			return
+		}
+		t.groupIDs = make([]entities.GroupID, len(groups))
+		for i, group := range groups {
+			t.groupIDs[i] = group.ID()
+		}
+	})
+	return t.groupIDs, t.err
+}
+
+// CanAccess reports whether the account owns the collection or belongs to
+// the group it is shared with
+func (t *Tenant) CanAccess(ctx context.Context, collection *entities.Collection) (bool, error) {
+	if collection.IsOwnedBy(t.UserID) {
+		return true, nil
+	}
+	if collection.GroupID() == nil {
+		return false, nil
+	}
+	groupIDs, err := t.GroupIDs(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range groupIDs {
+		if id.Equals(*collection.GroupID()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ErrOutsideTenant is returned when a repository is asked to create data for
+// an account or group other than the tenant's
+var ErrOutsideTenant = errors.New("access denied: outside the current account")
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=usage_repository.go -destination=../../mocks/mock_usage_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// UsageRepository totals what users store across collections, containers
+// and media, for the admin dashboard and group quotas.
+type UsageRepository interface {
+	// UsageByUser returns the usage of every user who owns anything, keyed by
+	// user ID. Collections count toward the user who created them, media
+	// toward the user who uploaded it.
+	UsageByUser(ctx context.Context) (map[string]entities.UserUsage, error)
+	// UsageByGroup totals the containers, objects and photo bytes in the
+	// collections shared with a group, whoever created them.
+	UsageByGroup(ctx context.Context, groupID entities.GroupID) (entities.GroupUsage, error)
+}
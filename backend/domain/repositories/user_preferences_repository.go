@@ -0,0 +1,15 @@
+//go:generate mockgen -source=user_preferences_repository.go -destination=../../mocks/mock_user_preferences_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+type UserPreferencesRepository interface {
+	GetByUserID(ctx context.Context, userID entities.UserID) (*entities.UserPreferences, error)
+	Save(ctx context.Context, preferences *entities.UserPreferences) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) error
+}
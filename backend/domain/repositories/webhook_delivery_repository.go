@@ -0,0 +1,26 @@
+//go:generate mockgen -source=webhook_delivery_repository.go -destination=../../mocks/mock_webhook_delivery_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// WebhookDeliveryRepository stores the webhooks automation rules sent.
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *entities.WebhookDelivery) error
+	GetByID(ctx context.Context, id entities.WebhookDeliveryID) (*entities.WebhookDelivery, error)
+	// ListByUserID returns up to limit of the user's deliveries, newest first.
+	ListByUserID(ctx context.Context, userID entities.UserID, limit int) ([]*entities.WebhookDelivery, error)
+	// ListDue returns up to limit of every user's pending deliveries whose
+	// next attempt is at or before t, longest waiting first.
+	ListDue(ctx context.Context, t time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+	// DeleteCreatedBefore removes every user's deliveries created before t
+	// that are no longer pending.
+	DeleteCreatedBefore(ctx context.Context, t time.Time) (int64, error)
+	DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error)
+}
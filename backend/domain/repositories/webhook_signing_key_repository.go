@@ -0,0 +1,18 @@
+//go:generate mockgen -source=webhook_signing_key_repository.go -destination=../../mocks/mock_webhook_signing_key_repository.go -package=mocks
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// WebhookSigningKeyRepository stores each account's webhook signing keys.
+type WebhookSigningKeyRepository interface {
+	// GetByUserID returns the user's keys, or ErrWebhookSigningKeyNotFound
+	// if the account never made one.
+	GetByUserID(ctx context.Context, userID entities.UserID) (*entities.WebhookSigningKeys, error)
+	Save(ctx context.Context, keys *entities.WebhookSigningKeys) error
+	DeleteByUserID(ctx context.Context, userID entities.UserID) error
+}
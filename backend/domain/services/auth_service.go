@@ -4,6 +4,9 @@ package services
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strconv"
 
 	"github.com/nishiki/backend/domain/entities"
 )
@@ -16,15 +19,55 @@ type AuthClaims struct {
 	Name      string   `json:"name"`
 	ExpiresAt int64    `json:"exp"`
 	IssuedAt  int64    `json:"iat"`
-	Issuer    string   `json:"iss"`
-	Audience  string   `json:"aud"`
+	AuthTime  int64    `json:"auth_time"`
+	// SID is the identity provider's session, when the token names one
+	SID      string `json:"sid"`
+	Issuer   string `json:"iss"`
+	Audience string `json:"aud"`
+}
+
+// SignedInAt is the Unix time of the sign-in the token was issued for,
+// which refreshed tokens keep. Tokens without an auth_time claim fall back
+// to their issue time.
+func (c *AuthClaims) SignedInAt() int64 {
+	if c.AuthTime != 0 {
+		return c.AuthTime
+	}
+	return c.IssuedAt
+}
+
+// SessionID identifies the sign-in the token belongs to: the identity
+// provider's session when the token names one, else the auth_time claim.
+// Both survive a token refresh. Tokens with neither have no stable sign-in
+// to name, since their issue time changes on every refresh, so ok is false
+// and the session cannot be tracked.
+func (c *AuthClaims) SessionID() (id string, ok bool) {
+	signIn := c.SID
+	if signIn == "" && c.AuthTime != 0 {
+		signIn = strconv.FormatInt(c.AuthTime, 10)
+	}
+	if signIn == "" {
+		return "", false
+	}
+	return entities.NewSessionID(c.Subject, signIn), true
+}
+
+// InGroup reports whether the token lists the group in its groups claim.
+// An empty name never matches.
+func (c *AuthClaims) InGroup(name string) bool {
+	return name != "" && slices.Contains(c.Groups, name)
 }
 
 type AuthService interface {
-	// IssuerBaseURL returns the Authentik base URL that was selected from the
-	// ranked authentik_urls config at startup. Used when advertising the OIDC
-	// issuer to external clients (e.g. MCP OAuth discovery).
-	IssuerBaseURL() string
+	// IssuerURL returns the OIDC issuer of the primary client, resolved from
+	// the ranked candidate URLs at startup. Used when advertising the issuer
+	// to external clients (e.g. MCP OAuth discovery); empty when there is
+	// none to advertise.
+	IssuerURL() string
+	// CheckHealth fetches the OIDC discovery document for the primary client
+	// to confirm the identity provider is still reachable. Used by the
+	// readiness probe.
+	CheckHealth(ctx context.Context) error
 	ValidateToken(ctx context.Context, token string) (*AuthClaims, error)
 	GetUserFromClaims(ctx context.Context, claims *AuthClaims) (*entities.User, error)
 	CreateUserFromClaims(ctx context.Context, claims *AuthClaims) (*entities.User, error)
@@ -33,7 +76,26 @@ type AuthService interface {
 	GetOIDCConfig(ctx context.Context, clientID string) (map[string]any, error)
 	ProxyTokenExchange(ctx context.Context, tokenRequest map[string]any) ([]byte, int, error)
 
-	// Group and user fetching from Authentik (now requires user's JWT token)
+	GroupDirectory
+}
+
+// ErrGroupsReadOnly is returned by a GroupDirectory that only reads groups,
+// for changes that have to be made in the identity provider instead.
+var ErrGroupsReadOnly = errors.New("groups are managed by the identity provider")
+
+// ErrGroupSyncFailed is wrapped around a change the identity provider
+// rejected or never answered, with its reason, so callers can tell the user
+// the group is out of sync rather than reporting a generic failure.
+var ErrGroupSyncFailed = errors.New("identity provider sync failed")
+
+// ErrInviteeNotFound is returned by InviteUserByEmail when no account is
+// registered under the address.
+var ErrInviteeNotFound = errors.New("no account with that email")
+
+// GroupDirectory looks up and manages the groups users share collections
+// through. Where groups live depends on the identity provider: Authentik's
+// API, or read-only from a claim in the user's token.
+type GroupDirectory interface {
 	CreateGroup(ctx context.Context, userToken, name string, creatorID string) (*entities.Group, error)
 	GetUserGroups(ctx context.Context, userToken, userID string) ([]*entities.Group, error)
 	GetGroupUsers(ctx context.Context, userToken, groupID string) ([]*entities.User, error)
@@ -43,4 +105,52 @@ type AuthService interface {
 	DeleteGroup(ctx context.Context, userToken, groupID string) error
 	AddUserToGroup(ctx context.Context, userToken, groupID, userID string) error
 	RemoveUserFromGroup(ctx context.Context, userToken, groupID, userID string) error
+	// InviteUserByEmail adds the account registered under email to the group
+	// and returns it, or ErrInviteeNotFound.
+	InviteUserByEmail(ctx context.Context, userToken, groupID, email string) (*entities.User, error)
+	// GetGroupMemberRoles maps the user IDs of the group's admins to
+	// GroupRoleAdmin; members missing from the map are plain members.
+	GetGroupMemberRoles(ctx context.Context, userToken, groupID string) (map[string]entities.GroupRole, error)
+	SetGroupMemberRole(ctx context.Context, userToken, groupID, userID string, role entities.GroupRole) error
+}
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrRegistrationClosed = errors.New("registration is closed")
+	ErrInvalidPassword    = errors.New("password must be between 8 and 72 bytes")
+	ErrInvalidRedirectURL = errors.New("redirect_uri is not allowed")
+)
+
+// OAuthClientRegistry is implemented by an AuthService whose OAuth clients
+// can be registered at runtime as well as listed in config.toml.
+type OAuthClientRegistry interface {
+	// ReloadClients replaces the runtime clients with registered, keeping
+	// the configured ones. It fails without changing anything when a client
+	// reuses a configured client ID (entities.ErrOAuthClientConfigured) or
+	// its provider cannot be reached.
+	ReloadClients(ctx context.Context, registered []*entities.OAuthClient) error
+}
+
+// IssuedToken is an access token issued by Nishiki itself, with the refresh
+// token that renews it.
+type IssuedToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // seconds
+}
+
+// PasswordAuthenticator is implemented by an AuthService that keeps its own
+// username/password accounts instead of delegating to an identity provider.
+type PasswordAuthenticator interface {
+	// RegistrationOpen reports whether Register would accept a new account.
+	RegistrationOpen(ctx context.Context) (bool, error)
+	Register(ctx context.Context, username, email, password string) (*IssuedToken, error)
+	Login(ctx context.Context, username, password string) (*IssuedToken, error)
+	// Authorize checks the credentials entered on the sign-in page and
+	// returns a one-time authorization code for redirectURI, which
+	// ProxyTokenExchange redeems. codeChallenge is the PKCE S256 challenge.
+	Authorize(ctx context.Context, username, password, redirectURI, codeChallenge string) (string, error)
+	// CheckRedirectURL returns ErrInvalidRedirectURL unless the sign-in page
+	// may send codes to redirectURI.
+	CheckRedirectURL(redirectURI string) error
 }
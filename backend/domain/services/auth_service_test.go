@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestAuthClaims_SessionID(t *testing.T) {
+	t.Run("identity provider session", func(t *testing.T) {
+		claims := AuthClaims{Subject: "u1", SID: "abc", AuthTime: 100, IssuedAt: 200}
+		id, ok := claims.SessionID()
+		assert.True(t, ok)
+		assert.Equal(t, entities.NewSessionID("u1", "abc"), id)
+	})
+
+	t.Run("sign-in time survives a refresh", func(t *testing.T) {
+		first := AuthClaims{Subject: "u1", AuthTime: 100, IssuedAt: 100}
+		refreshed := AuthClaims{Subject: "u1", AuthTime: 100, IssuedAt: 4000}
+		firstID, ok := first.SessionID()
+		assert.True(t, ok)
+		refreshedID, ok := refreshed.SessionID()
+		assert.True(t, ok)
+		assert.Equal(t, firstID, refreshedID)
+	})
+
+	t.Run("no stable sign-in is not tracked", func(t *testing.T) {
+		claims := AuthClaims{Subject: "u1", IssuedAt: 200}
+		id, ok := claims.SessionID()
+		assert.False(t, ok)
+		assert.Empty(t, id)
+	})
+}
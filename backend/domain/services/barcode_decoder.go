@@ -0,0 +1,46 @@
+//go:generate mockgen -source=barcode_decoder.go -destination=../../mocks/mock_barcode_decoder.go -package=mocks
+
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// BarcodeFormat is the symbology a barcode was printed in.
+type BarcodeFormat string
+
+const (
+	BarcodeEAN13 BarcodeFormat = "ean13"
+	BarcodeUPCA  BarcodeFormat = "upca"
+	BarcodeEAN8  BarcodeFormat = "ean8"
+)
+
+// Barcode is a product code read from a photo.
+type Barcode struct {
+	Format BarcodeFormat
+	Code   string
+}
+
+// PropertyKey is the object property the code is kept under: upc for UPC-A,
+// isbn for a book's EAN-13 and ean for the rest. Each is one of
+// entities.ObjectCodeKeys, so lookups by code find the object.
+func (b Barcode) PropertyKey() string {
+	switch {
+	case b.Format == BarcodeUPCA:
+		return entities.PropertyUPC
+	case b.Format == BarcodeEAN13 && (strings.HasPrefix(b.Code, "978") || strings.HasPrefix(b.Code, "979")):
+		return "isbn"
+	}
+	return "ean"
+}
+
+// BarcodeDecoder reads retail barcodes from photos.
+type BarcodeDecoder interface {
+	// Decode returns the first barcode found in the image, or nil when there
+	// is none. Data that is not an image of contentType is rejected with
+	// entities.ErrUnsupportedMediaType.
+	Decode(ctx context.Context, contentType string, data []byte) (*Barcode, error)
+}
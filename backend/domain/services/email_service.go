@@ -0,0 +1,41 @@
+//go:generate mockgen -source=email_service.go -destination=../../mocks/mock_email_service.go -package=mocks
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// DigestItem is a single object listed in a digest email.
+type DigestItem struct {
+	Name           string
+	CollectionName string
+	Quantity       *float64
+	Unit           string
+	ExpiresAt      *time.Time
+	UpdatedAt      time.Time
+}
+
+// DigestReport is the content of one user's digest email.
+type DigestReport struct {
+	Frequency      entities.DigestFrequency
+	Since          time.Time
+	Expiring       []DigestItem
+	LowStock       []DigestItem
+	RecentActivity []DigestItem
+	UnsubscribeURL string
+}
+
+// IsEmpty reports whether the report has nothing worth sending.
+func (r *DigestReport) IsEmpty() bool {
+	return len(r.Expiring) == 0 && len(r.LowStock) == 0 && len(r.RecentActivity) == 0
+}
+
+// EmailService delivers outgoing mail.
+type EmailService interface {
+	// SendDigest renders the report and sends it to the given address.
+	SendDigest(ctx context.Context, to entities.EmailAddress, report *DigestReport) error
+}
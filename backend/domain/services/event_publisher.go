@@ -0,0 +1,14 @@
+//go:generate mockgen -source=event_publisher.go -destination=../../mocks/mock_event_publisher.go -package=mocks
+
+package services
+
+import (
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// EventPublisher hands object events to whatever reacts to them, such as
+// automation rules. Publish must not block the change that raised the
+// event, and a lost event must not fail it.
+type EventPublisher interface {
+	Publish(event entities.ObjectEvent)
+}
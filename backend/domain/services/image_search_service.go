@@ -22,4 +22,6 @@ type ImageSearchService interface {
 	// to the local cache, and returns the serving URL.
 	// Returns empty string and nil error if no image was found.
 	SearchAndCache(ctx context.Context, name string, objectType entities.ObjectType, properties map[string]entities.TypedValue) (string, error)
+	// CheckHealth verifies the image cache directory is present and writable.
+	CheckHealth(ctx context.Context) error
 }
@@ -0,0 +1,55 @@
+//go:generate mockgen -source=label_renderer.go -destination=../../mocks/mock_label_renderer.go -package=mocks
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// LabelFormat is the file type a label is rendered as.
+type LabelFormat string
+
+const (
+	LabelFormatPDF LabelFormat = "pdf"
+	LabelFormatPNG LabelFormat = "png"
+)
+
+// ParseLabelFormat parses a format name, defaulting to PDF when empty.
+func ParseLabelFormat(s string) (LabelFormat, error) {
+	switch LabelFormat(s) {
+	case "":
+		return LabelFormatPDF, nil
+	case LabelFormatPDF, LabelFormatPNG:
+		return LabelFormat(s), nil
+	}
+	return "", fmt.Errorf("invalid label format %q: must be pdf or png", s)
+}
+
+// ContentType returns the MIME type of the rendered label.
+func (f LabelFormat) ContentType() string {
+	if f == LabelFormatPNG {
+		return "image/png"
+	}
+	return "application/pdf"
+}
+
+// ObjectLabel is the content of a printed object label.
+type ObjectLabel struct {
+	Name string
+	// ContainerPath is where the object lives, e.g. "Kitchen / Pantry".
+	ContainerPath string
+	ExpiresAt     *time.Time
+	// Code is encoded in the label's QR code.
+	Code     string
+	Template entities.LabelTemplate
+}
+
+// LabelRenderer turns object labels into printable files sized to their
+// label stock.
+type LabelRenderer interface {
+	RenderLabel(ctx context.Context, label *ObjectLabel, format LabelFormat) ([]byte, error)
+}
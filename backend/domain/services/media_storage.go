@@ -0,0 +1,33 @@
+//go:generate mockgen -source=media_storage.go -destination=../../mocks/mock_media_storage.go -package=mocks
+
+package services
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// StoredMedia describes a saved photo and its thumbnail.
+type StoredMedia struct {
+	URL          string
+	ThumbnailURL string
+	Width        int
+	Height       int
+}
+
+// MediaStorage keeps uploaded photos and generates their thumbnails. Photos
+// are grouped by collection so a deleted collection's files can be removed
+// together.
+type MediaStorage interface {
+	// Save decodes the image to check it matches contentType, then stores it
+	// with a thumbnail. Undecodable data is rejected with
+	// entities.ErrUnsupportedMediaType.
+	Save(ctx context.Context, collectionID entities.CollectionID, id entities.MediaID, contentType string, data []byte) (*StoredMedia, error)
+	// Delete removes a photo and its thumbnail.
+	Delete(ctx context.Context, collectionID entities.CollectionID, id entities.MediaID) error
+	// DeleteCollection removes every photo stored for a collection.
+	DeleteCollection(ctx context.Context, collectionID entities.CollectionID) error
+	// CheckHealth verifies the media directory is present and writable.
+	CheckHealth(ctx context.Context) error
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=nutrition_provider.go -destination=../../mocks/mock_nutrition_provider.go -package=mocks
+
+package services
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// NutritionProvider looks up the nutrition facts of packaged food.
+type NutritionProvider interface {
+	// LookupUPC returns the per-serving nutrition facts of the product with
+	// the given normalized UPC/EAN. Unknown products, and products listed
+	// without nutrition facts, return entities.ErrNutritionNotFound.
+	LookupUPC(ctx context.Context, upc string) (*entities.NutritionFacts, error)
+	// CheckHealth verifies the nutrition database is reachable.
+	CheckHealth(ctx context.Context) error
+}
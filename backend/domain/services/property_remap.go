@@ -0,0 +1,93 @@
+package services
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// RemapProperties converts an object's properties from the schema of the
+// collection it is in to that of the collection it is moving or being copied
+// to. A property keeps its key when the target defines it, and otherwise
+// takes the key of the target definition with the same display name. Values
+// are coerced to the target definition's type.
+//
+// A target without a schema takes every property as it is. Otherwise the
+// properties the target does not define, and the values that cannot be
+// coerced to the target's type, are dropped; their keys are returned sorted.
+func (s *TypeInferenceService) RemapProperties(props map[string]entities.TypedValue, from, to *entities.PropertySchema) (map[string]entities.TypedValue, []string) {
+	remapped := make(map[string]entities.TypedValue, len(props))
+	if to == nil || len(to.Definitions) == 0 {
+		for key, tv := range props {
+			remapped[key] = tv
+		}
+		return remapped, nil
+	}
+
+	var dropped []string
+	for key, tv := range props {
+		def := to.GetDefinition(key)
+		if def == nil {
+			def = definitionNamed(to, displayName(from, key))
+		}
+		if def == nil {
+			dropped = append(dropped, key)
+			continue
+		}
+		if _, taken := props[def.Key]; taken && def.Key != key {
+			// The target key is filled by the object's own property of that name
+			dropped = append(dropped, key)
+			continue
+		}
+		converted, ok := s.convertValue(tv, def)
+		if !ok {
+			dropped = append(dropped, key)
+			continue
+		}
+		remapped[def.Key] = converted
+	}
+	slices.Sort(dropped)
+	return remapped, dropped
+}
+
+// convertValue coerces a stored value to a definition's type. It reports
+// false when the value does not fit, e.g. "mint" for a numeric field.
+func (s *TypeInferenceService) convertValue(tv entities.TypedValue, def *entities.PropertyDefinition) (entities.TypedValue, bool) {
+	if tv.Type == def.Type || tv.Val == nil {
+		tv.Type = def.Type
+		// An amount keeps the currency it was entered in
+		if def.Type == entities.PropertyTypeCurrency && tv.Currency == "" {
+			tv.Currency = def.CurrencyCode
+		}
+		return tv, true
+	}
+	// DisplayString writes approximate dates as "~1969", which coerces back
+	converted := s.CoerceValueWithDef(tv.DisplayString(), def)
+	if converted.Type != def.Type {
+		return tv, false
+	}
+	return converted, true
+}
+
+// displayName is the display name of key in schema, the key itself when the
+// schema does not define it
+func displayName(schema *entities.PropertySchema, key string) string {
+	if def := schema.GetDefinition(key); def != nil && def.DisplayName != "" {
+		return def.DisplayName
+	}
+	return key
+}
+
+// definitionNamed finds the definition whose display name or key matches
+// name, ignoring case and spacing
+func definitionNamed(schema *entities.PropertySchema, name string) *entities.PropertyDefinition {
+	key := ToSnakeCase(name)
+	for i := range schema.Definitions {
+		def := &schema.Definitions[i]
+		if strings.EqualFold(def.DisplayName, name) || def.Key == key {
+			return def
+		}
+	}
+	return nil
+}
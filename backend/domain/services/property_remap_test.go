@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestRemapProperties(t *testing.T) {
+	s := NewTypeInferenceService(nil)
+	from := &entities.PropertySchema{Definitions: []entities.PropertyDefinition{
+		{Key: "author", DisplayName: "Author", Type: entities.PropertyTypeText},
+		{Key: "pub_year", DisplayName: "Published", Type: entities.PropertyTypeText},
+		{Key: "price", DisplayName: "Price", Type: entities.PropertyTypeCurrency, CurrencyCode: "USD"},
+		{Key: "shelf", DisplayName: "Shelf", Type: entities.PropertyTypeText},
+		{Key: "pages", DisplayName: "Pages", Type: entities.PropertyTypeText},
+	}}
+	to := &entities.PropertySchema{Definitions: []entities.PropertyDefinition{
+		{Key: "author", DisplayName: "Author", Type: entities.PropertyTypeGroupedText},
+		{Key: "published", DisplayName: "Published", Type: entities.PropertyTypeDate},
+		{Key: "price", DisplayName: "Price", Type: entities.PropertyTypeCurrency, CurrencyCode: "EUR"},
+		{Key: "pages", DisplayName: "Pages", Type: entities.PropertyTypeNumeric},
+	}}
+	props := map[string]entities.TypedValue{
+		"author":   {Type: entities.PropertyTypeText, Val: "Ursula K. Le Guin"},
+		"pub_year": {Type: entities.PropertyTypeText, Val: "~1969"},
+		"price":    {Type: entities.PropertyTypeCurrency, Val: 9.99, Currency: "USD"},
+		"shelf":    {Type: entities.PropertyTypeText, Val: "Top"},
+		"pages":    {Type: entities.PropertyTypeText, Val: "about 300"},
+	}
+
+	remapped, dropped := s.RemapProperties(props, from, to)
+
+	assert.Equal(t, map[string]entities.TypedValue{
+		"author":    {Type: entities.PropertyTypeGroupedText, Val: "Ursula K. Le Guin"},
+		"published": {Type: entities.PropertyTypeDate, Val: time.Date(1969, 1, 1, 0, 0, 0, 0, time.UTC), Approx: true},
+		"price":     {Type: entities.PropertyTypeCurrency, Val: 9.99, Currency: "USD"},
+	}, remapped)
+	assert.Equal(t, []string{"pages", "shelf"}, dropped)
+}
+
+func TestRemapProperties_SchemalessTargetKeepsAll(t *testing.T) {
+	s := NewTypeInferenceService(nil)
+	props := map[string]entities.TypedValue{
+		"color": {Type: entities.PropertyTypeText, Val: "red"},
+	}
+
+	remapped, dropped := s.RemapProperties(props, nil, nil)
+
+	assert.Equal(t, props, remapped)
+	assert.Empty(t, dropped)
+}
@@ -0,0 +1,69 @@
+//go:generate mockgen -source=report_renderer.go -destination=../../mocks/mock_report_renderer.go -package=mocks
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReportLayout controls how much detail a printed report contains.
+type ReportLayout string
+
+const (
+	// ReportLayoutSummary lists one line per container with totals only.
+	ReportLayoutSummary ReportLayout = "summary"
+	// ReportLayoutDetailed lists every object under its container.
+	ReportLayoutDetailed ReportLayout = "detailed"
+)
+
+// ParseReportLayout parses a layout name, defaulting to detailed when empty.
+func ParseReportLayout(s string) (ReportLayout, error) {
+	switch ReportLayout(s) {
+	case "":
+		return ReportLayoutDetailed, nil
+	case ReportLayoutSummary, ReportLayoutDetailed:
+		return ReportLayout(s), nil
+	}
+	return "", fmt.Errorf("invalid report layout %q: must be summary or detailed", s)
+}
+
+// ReportItem is a single object listed in a report.
+type ReportItem struct {
+	Name      string
+	Quantity  *float64
+	Unit      string
+	Value     *float64 // the object's currency property, if any
+	ExpiresAt *time.Time
+	ImageURL  string
+}
+
+// ReportContainer groups the objects stored in one container.
+type ReportContainer struct {
+	Name        string
+	Location    string
+	ObjectCount int
+	TotalValue  float64
+	NextExpiry  *time.Time
+	Items       []ReportItem
+}
+
+// CollectionReport is the content of a printable collection report.
+type CollectionReport struct {
+	CollectionName string
+	Location       string
+	Layout         ReportLayout
+	IncludeImages  bool
+	CurrencyCode   string
+	GeneratedAt    time.Time
+	ObjectCount    int
+	TotalValue     float64
+	Containers     []ReportContainer
+}
+
+// ReportRenderer turns reports into printable documents.
+type ReportRenderer interface {
+	// RenderCollectionReport renders the report as a PDF document.
+	RenderCollectionReport(ctx context.Context, report *CollectionReport) ([]byte, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// NewTenant builds the repository tenant for a signed-in user, looking
+// their groups up through authService with their token when first needed.
+func NewTenant(authService AuthService, user *entities.User, token string) *repositories.Tenant {
+	return repositories.NewTenant(user.ID(), func(ctx context.Context) ([]*entities.Group, error) {
+		return authService.GetUserGroups(ctx, token, user.ID().String())
+	})
+}
@@ -18,7 +18,7 @@ type TypeInferenceService struct {
 // defaultReservedColumns is the built-in list used when none is supplied.
 var defaultReservedColumns = []string{
 	"name", "title", "item",
-	"description", "quantity", "tags", "location",
+	"description", "quantity", "unit", "expires_at", "tags", "location",
 }
 
 // NewTypeInferenceService creates a new TypeInferenceService.
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+var (
+	ErrUnknownUnit       = errors.New("unknown unit")
+	ErrIncompatibleUnits = errors.New("incompatible units")
+)
+
+type unitDimension string
+
+const (
+	dimensionMass   unitDimension = "mass"
+	dimensionVolume unitDimension = "volume"
+	dimensionCount  unitDimension = "count"
+)
+
+// unitDef describes a unit by its dimension and how many base units (grams,
+// millilitres, or single items) one of it is worth.
+type unitDef struct {
+	dimension unitDimension
+	factor    float64
+}
+
+var knownUnits = map[string]unitDef{
+	// Mass (base: gram)
+	"mg": {dimensionMass, 0.001}, "milligram": {dimensionMass, 0.001},
+	"g": {dimensionMass, 1}, "gram": {dimensionMass, 1},
+	"kg": {dimensionMass, 1000}, "kilogram": {dimensionMass, 1000},
+	"oz": {dimensionMass, 28.349523125}, "ounce": {dimensionMass, 28.349523125},
+	"lb": {dimensionMass, 453.59237}, "lbs": {dimensionMass, 453.59237}, "pound": {dimensionMass, 453.59237},
+
+	// Volume (base: millilitre)
+	"ml": {dimensionVolume, 1}, "milliliter": {dimensionVolume, 1}, "millilitre": {dimensionVolume, 1},
+	"cl": {dimensionVolume, 10}, "dl": {dimensionVolume, 100},
+	"l": {dimensionVolume, 1000}, "liter": {dimensionVolume, 1000}, "litre": {dimensionVolume, 1000},
+	"tsp": {dimensionVolume, 4.92892159375}, "teaspoon": {dimensionVolume, 4.92892159375},
+	"tbsp": {dimensionVolume, 14.78676478125}, "tablespoon": {dimensionVolume, 14.78676478125},
+	"fl oz": {dimensionVolume, 29.5735295625}, "floz": {dimensionVolume, 29.5735295625},
+	"cup":  {dimensionVolume, 236.5882365},
+	"pint": {dimensionVolume, 473.176473}, "pt": {dimensionVolume, 473.176473},
+	"quart": {dimensionVolume, 946.352946}, "qt": {dimensionVolume, 946.352946},
+	"gallon": {dimensionVolume, 3785.411784}, "gal": {dimensionVolume, 3785.411784},
+
+	// Count (base: one item). No unit at all counts items too.
+	"":   {dimensionCount, 1},
+	"pc": {dimensionCount, 1}, "pcs": {dimensionCount, 1}, "piece": {dimensionCount, 1},
+	"ea": {dimensionCount, 1}, "each": {dimensionCount, 1},
+	"item": {dimensionCount, 1}, "unit": {dimensionCount, 1}, "count": {dimensionCount, 1},
+	"pair":  {dimensionCount, 2},
+	"dozen": {dimensionCount, 12}, "dz": {dimensionCount, 12},
+}
+
+// NormalizeUnit lowercases and trims a unit and reduces plurals to their
+// singular form, so "Cups" and "cup" or "eggs" and "egg" compare equal.
+func NormalizeUnit(unit string) string {
+	u := strings.Join(strings.Fields(strings.ToLower(unit)), " ")
+	u = strings.TrimSuffix(u, ".")
+	if _, ok := knownUnits[u]; ok {
+		return u
+	}
+	if stem, found := strings.CutSuffix(u, "es"); found {
+		if _, ok := knownUnits[stem]; ok {
+			return stem
+		}
+		for _, ending := range []string{"s", "x", "z", "ch", "sh"} {
+			if strings.HasSuffix(stem, ending) {
+				return stem
+			}
+		}
+	}
+	if stem, found := strings.CutSuffix(u, "s"); found && !strings.HasSuffix(stem, "s") {
+		return stem
+	}
+	return u
+}
+
+// IsKnownUnit reports whether unit names a measure ConvertQuantity
+// understands, such as "kg" or "cups". The empty unit is not counted.
+func IsKnownUnit(unit string) bool {
+	u := NormalizeUnit(unit)
+	_, ok := knownUnits[u]
+	return ok && u != ""
+}
+
+// SameUnit reports whether two units name the same thing after normalization.
+func SameUnit(a, b string) bool {
+	return NormalizeUnit(a) == NormalizeUnit(b)
+}
+
+// ConvertQuantity converts amount from one unit to another. Units that
+// normalize to the same string convert 1:1 even when they are not known
+// (e.g. "eggs" to "egg"); otherwise both must be known and share a dimension.
+func ConvertQuantity(amount float64, from, to string) (float64, error) {
+	if SameUnit(from, to) {
+		return amount, nil
+	}
+
+	fromDef, ok := knownUnits[NormalizeUnit(from)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, from)
+	}
+	toDef, ok := knownUnits[NormalizeUnit(to)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, to)
+	}
+	if fromDef.dimension != toDef.dimension {
+		return 0, fmt.Errorf("%w: cannot convert %s (%s) to %s (%s)", ErrIncompatibleUnits, from, fromDef.dimension, to, toDef.dimension)
+	}
+
+	return amount * fromDef.factor / toDef.factor, nil
+}
+
+// systemUnits are the units quantities are shown in for each unit system and
+// dimension, largest first. Units of the other system are converted to the
+// largest of these the amount holds at least one of.
+var systemUnits = map[entities.UnitSystem]map[unitDimension][]string{
+	entities.UnitSystemMetric: {
+		dimensionMass:   {"kg", "g"},
+		dimensionVolume: {"l", "ml"},
+	},
+	entities.UnitSystemImperial: {
+		dimensionMass:   {"lb", "oz"},
+		dimensionVolume: {"gal", "qt", "fl oz"},
+	},
+}
+
+// unitSystemOf names the system a unit belongs to. Teaspoons, tablespoons and
+// cups are used with both, and counts with neither, so they have none.
+func unitSystemOf(unit string) entities.UnitSystem {
+	switch unit {
+	case "mg", "milligram", "g", "gram", "kg", "kilogram",
+		"ml", "milliliter", "millilitre", "cl", "dl", "l", "liter", "litre":
+		return entities.UnitSystemMetric
+	case "oz", "ounce", "lb", "lbs", "pound",
+		"fl oz", "floz", "pint", "pt", "quart", "qt", "gallon", "gal":
+		return entities.UnitSystemImperial
+	}
+	return ""
+}
+
+// DisplayQuantity expresses a quantity in the given unit system, so 12 oz is
+// shown as 340.19 g to a metric user and 1500 g as 3.31 lb to an imperial
+// one. Quantities already in the system, in units both systems use, or in
+// units that are not known are returned as they are.
+func DisplayQuantity(amount float64, unit string, system entities.UnitSystem) (float64, string) {
+	from := NormalizeUnit(unit)
+	if owner := unitSystemOf(from); owner == "" || owner == system {
+		return amount, unit
+	}
+	candidates := systemUnits[system][knownUnits[from].dimension]
+	if len(candidates) == 0 {
+		return amount, unit
+	}
+	base := amount * knownUnits[from].factor
+	to := candidates[len(candidates)-1]
+	for _, candidate := range candidates {
+		if math.Abs(base) >= knownUnits[candidate].factor {
+			to = candidate
+			break
+		}
+	}
+	return math.Round(base/knownUnits[to].factor*100) / 100, to
+}
+
+// SuggestedUnits returns the units offered when a quantity is entered by a
+// user of the unit system, mass before volume and smallest first.
+func SuggestedUnits(system entities.UnitSystem) []string {
+	if system != entities.UnitSystemImperial {
+		system = entities.UnitSystemMetric
+	}
+	var suggested []string
+	for _, dimension := range []unitDimension{dimensionMass, dimensionVolume} {
+		units := slices.Clone(systemUnits[system][dimension])
+		slices.Reverse(units)
+		suggested = append(suggested, units...)
+	}
+	return suggested
+}
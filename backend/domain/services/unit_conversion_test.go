@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestDisplayQuantity(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     float64
+		unit       string
+		system     entities.UnitSystem
+		wantAmount float64
+		wantUnit   string
+	}{
+		{"ounces to grams", 12, "oz", entities.UnitSystemMetric, 340.19, "g"},
+		{"pounds to kilograms", 5, "lbs", entities.UnitSystemMetric, 2.27, "kg"},
+		{"gallon to litres", 1, "Gallon", entities.UnitSystemMetric, 3.79, "l"},
+		{"grams to pounds", 1500, "g", entities.UnitSystemImperial, 3.31, "lb"},
+		{"grams to ounces", 250, "grams", entities.UnitSystemImperial, 8.82, "oz"},
+		{"litres to quarts", 2, "l", entities.UnitSystemImperial, 2.11, "qt"},
+		{"millilitres to fluid ounces", 330, "ml", entities.UnitSystemImperial, 11.16, "fl oz"},
+		{"already metric", 1500, "g", entities.UnitSystemMetric, 1500, "g"},
+		{"already imperial", 12, "oz", entities.UnitSystemImperial, 12, "oz"},
+		{"cups are shared", 2, "cups", entities.UnitSystemMetric, 2, "cups"},
+		{"counts are kept", 6, "eggs", entities.UnitSystemImperial, 6, "eggs"},
+		{"no unit", 3, "", entities.UnitSystemImperial, 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, unit := DisplayQuantity(tt.amount, tt.unit, tt.system)
+			assert.InDelta(t, tt.wantAmount, amount, 1e-9)
+			assert.Equal(t, tt.wantUnit, unit)
+		})
+	}
+}
+
+func TestSuggestedUnits(t *testing.T) {
+	assert.Equal(t, []string{"g", "kg", "ml", "l"}, SuggestedUnits(entities.UnitSystemMetric))
+	assert.Equal(t, []string{"oz", "lb", "fl oz", "qt", "gal"}, SuggestedUnits(entities.UnitSystemImperial))
+	assert.Equal(t, SuggestedUnits(entities.UnitSystemMetric), SuggestedUnits(""))
+}
@@ -0,0 +1,35 @@
+//go:generate mockgen -source=webhook_sender.go -destination=../../mocks/mock_webhook_sender.go -package=mocks
+
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+// Errors a WebhookSender fails with. They are recorded on deliveries and
+// shown to the rule's owner, so they name the class of failure only; the
+// underlying network error stays in the server log.
+var (
+	ErrWebhookAddressBlocked = errors.New("address not allowed")
+	ErrWebhookTimeout        = errors.New("timeout")
+	ErrWebhookUnreachable    = errors.New("connection failed")
+)
+
+// Webhook is one call to a user's webhook.
+type Webhook struct {
+	URL string
+	// Event names what happened, e.g. "object.created"
+	Event string
+	// Secrets each sign the body; with none it is sent unsigned
+	Secrets []string
+	// Body is the JSON sent
+	Body []byte
+}
+
+// WebhookSender posts events to URLs users registered.
+type WebhookSender interface {
+	// Send returns one of the errors above when the URL cannot be reached,
+	// or an error naming the status when it does not answer with a 2xx.
+	Send(ctx context.Context, hook Webhook) error
+}
@@ -0,0 +1,375 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// AdjustObjectQuantityRequest identifies an object by name rather than ID so
+// callers can act on phrases like "we used two eggs". Callers that already
+// know the object, such as completed meal plans, set ObjectID instead.
+// Exactly one of Delta and Quantity must be set.
+type AdjustObjectQuantityRequest struct {
+	ObjectName   string
+	ObjectID     *entities.ObjectID     // optional; skips name matching when set
+	CollectionID *entities.CollectionID // optional; searches every accessible collection when nil
+	Delta        *float64               // change relative to the current quantity (negative to use up)
+	Quantity     *float64               // absolute quantity to set
+	Unit         string                 // unit of Delta/Quantity; converted to the object's unit
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type AdjustObjectQuantityResponse struct {
+	Object           *entities.Object
+	ContainerID      entities.ContainerID
+	CollectionID     entities.CollectionID
+	PreviousQuantity *float64
+}
+
+// QuantityMatchCandidate is an object that matched the requested name equally
+// well as another, returned when the match is ambiguous.
+type QuantityMatchCandidate struct {
+	ObjectID       entities.ObjectID
+	Name           string
+	CollectionName string
+}
+
+// AmbiguousObjectMatchError is returned when several objects match the name
+// equally well. Callers should ask the user which one they meant.
+type AmbiguousObjectMatchError struct {
+	Query      string
+	Candidates []QuantityMatchCandidate
+}
+
+func (e *AmbiguousObjectMatchError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		names[i] = fmt.Sprintf("%s (%s)", c.Name, c.CollectionName)
+	}
+	return fmt.Sprintf("ambiguous object name %q: matches %s", e.Query, strings.Join(names, ", "))
+}
+
+type AdjustObjectQuantityUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	authService    services.AuthService
+	events         services.EventPublisher
+}
+
+func NewAdjustObjectQuantityUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, authService services.AuthService, events services.EventPublisher) *AdjustObjectQuantityUseCase {
+	return &AdjustObjectQuantityUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		authService:    authService,
+		events:         events,
+	}
+}
+
+type quantityMatch struct {
+	score      int
+	object     entities.Object
+	container  *entities.Container
+	collection *entities.Collection
+}
+
+func (uc *AdjustObjectQuantityUseCase) Execute(ctx context.Context, req AdjustObjectQuantityRequest) (*AdjustObjectQuantityResponse, error) {
+	if req.ObjectID == nil && strings.TrimSpace(req.ObjectName) == "" {
+		return nil, errors.New("object name is required")
+	}
+	if (req.Delta == nil) == (req.Quantity == nil) {
+		return nil, errors.New("exactly one of delta or quantity is required")
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	groupIDs := make([]entities.GroupID, len(userGroups))
+	for i, g := range userGroups {
+		groupIDs[i] = g.ID()
+	}
+
+	var best quantityMatch
+	if req.ObjectID != nil {
+		best, err = uc.matchByID(ctx, req, groupIDs)
+	} else {
+		best, err = uc.matchByName(ctx, req, groupIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated := best.object
+	fromUnit, targetUnit := req.Unit, updated.Unit()
+	switch {
+	case targetUnit == "":
+		// Adopt the caller's unit for objects that have never been measured.
+		targetUnit = fromUnit
+	case fromUnit == "":
+		// No unit given: assume the caller meant the object's own unit.
+		fromUnit = targetUnit
+	}
+
+	var amount float64
+	if req.Delta != nil {
+		amount = *req.Delta
+	} else {
+		amount = *req.Quantity
+	}
+	amount, err = services.ConvertQuantity(amount, fromUnit, targetUnit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot adjust %s: %w", updated.Name().String(), err)
+	}
+
+	previous := updated.Quantity()
+	newQuantity := amount
+	if req.Delta != nil {
+		if previous != nil {
+			newQuantity += *previous
+		}
+		// Using more than is recorded just empties the object.
+		newQuantity = max(newQuantity, 0)
+	}
+	if newQuantity < 0 {
+		return nil, errors.New("quantity cannot be negative")
+	}
+
+	if err := updated.UpdateQuantity(&newQuantity); err != nil {
+		return nil, fmt.Errorf("failed to update object quantity: %w", err)
+	}
+	if targetUnit != updated.Unit() {
+		if err := updated.UpdateUnit(targetUnit); err != nil {
+			return nil, fmt.Errorf("failed to update object unit: %w", err)
+		}
+	}
+
+	if err := best.container.UpdateObject(updated.ID(), updated); err != nil {
+		return nil, fmt.Errorf("failed to update object in container: %w", err)
+	}
+	if err := uc.containerRepo.Update(ctx, best.container); err != nil {
+		return nil, fmt.Errorf("failed to save container: %w", err)
+	}
+
+	publishEvent(uc.events, entities.NewObjectUpdatedEvent(req.UserID, best.collection.ID(), best.container.ID(), best.object, updated, time.Now()))
+
+	return &AdjustObjectQuantityResponse{
+		Object:           &updated,
+		ContainerID:      best.container.ID(),
+		CollectionID:     best.collection.ID(),
+		PreviousQuantity: previous,
+	}, nil
+}
+
+// matchByName finds the single active object whose name best matches
+// req.ObjectName in the collections the user can access.
+func (uc *AdjustObjectQuantityUseCase) matchByName(ctx context.Context, req AdjustObjectQuantityRequest, groupIDs []entities.GroupID) (quantityMatch, error) {
+	collections, err := uc.candidateCollections(ctx, req, groupIDs)
+	if err != nil {
+		return quantityMatch{}, err
+	}
+
+	var matches []quantityMatch
+	for _, col := range collections {
+		containers, err := uc.containerRepo.GetByCollectionIDWithAccess(ctx, col.ID(), req.UserID, groupIDs)
+		if err != nil {
+			return quantityMatch{}, fmt.Errorf("failed to get containers: %w", err)
+		}
+		for _, c := range containers {
+			for _, obj := range c.ActiveObjects() {
+				if score := objectNameMatchScore(req.ObjectName, obj.Name().String()); score > 0 {
+					matches = append(matches, quantityMatch{score: score, object: obj, container: c, collection: col})
+				}
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return quantityMatch{}, fmt.Errorf("object not found: nothing matches %q", req.ObjectName)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	best := matches[0]
+	if len(matches) > 1 && matches[1].score == best.score {
+		ambiguous := &AmbiguousObjectMatchError{Query: req.ObjectName}
+		for _, m := range matches {
+			if m.score != best.score {
+				break
+			}
+			ambiguous.Candidates = append(ambiguous.Candidates, QuantityMatchCandidate{
+				ObjectID:       m.object.ID(),
+				Name:           m.object.Name().String(),
+				CollectionName: m.collection.Name().String(),
+			})
+		}
+		return quantityMatch{}, ambiguous
+	}
+	return best, nil
+}
+
+// matchByID loads the object with req.ObjectID, checking that the user can
+// access its collection.
+func (uc *AdjustObjectQuantityUseCase) matchByID(ctx context.Context, req AdjustObjectQuantityRequest, groupIDs []entities.GroupID) (quantityMatch, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, *req.ObjectID)
+	if err != nil {
+		return quantityMatch{}, fmt.Errorf("object not found: %w", err)
+	}
+	object, err := container.GetObject(*req.ObjectID)
+	if err != nil || object.IsArchived() {
+		return quantityMatch{}, fmt.Errorf("object not found: %s", req.ObjectID)
+	}
+
+	collection, err := uc.collectionRepo.GetByIDSummary(ctx, container.CollectionID())
+	if err != nil {
+		return quantityMatch{}, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, groupID := range groupIDs {
+			if groupID.Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return quantityMatch{}, errors.New("access denied: user does not have access to this collection")
+	}
+
+	return quantityMatch{object: *object, container: container, collection: collection}, nil
+}
+
+// candidateCollections returns the collections to search: the requested one,
+// or every collection the user owns or shares through a group.
+func (uc *AdjustObjectQuantityUseCase) candidateCollections(ctx context.Context, req AdjustObjectQuantityRequest, groupIDs []entities.GroupID) ([]*entities.Collection, error) {
+	if req.CollectionID != nil {
+		collection, err := uc.collectionRepo.GetByIDSummary(ctx, *req.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("collection not found: %w", err)
+		}
+
+		hasAccess := collection.IsOwnedBy(req.UserID)
+		if !hasAccess && collection.GroupID() != nil {
+			for _, groupID := range groupIDs {
+				if groupID.Equals(*collection.GroupID()) {
+					hasAccess = true
+					break
+				}
+			}
+		}
+		if !hasAccess {
+			return nil, errors.New("access denied: user does not have access to this collection")
+		}
+		return []*entities.Collection{collection}, nil
+	}
+
+	collections, err := uc.collectionRepo.GetByUserIDSummary(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(collections))
+	for _, c := range collections {
+		seen[c.ID().String()] = struct{}{}
+	}
+	for _, groupID := range groupIDs {
+		shared, err := uc.collectionRepo.GetByGroupID(ctx, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group collections: %w", err)
+		}
+		for _, c := range shared {
+			if _, ok := seen[c.ID().String()]; ok {
+				continue
+			}
+			seen[c.ID().String()] = struct{}{}
+			collections = append(collections, c)
+		}
+	}
+
+	return collections, nil
+}
+
+// objectNameMatchScore rates how well name matches query, 0 meaning no match.
+// Exact matches beat whole-word matches, which beat substrings, which beat
+// names within a small edit distance (typos, missing letters).
+func objectNameMatchScore(query, name string) int {
+	q := normalizeObjectName(query)
+	n := normalizeObjectName(name)
+	if q == "" || n == "" {
+		return 0
+	}
+
+	switch {
+	case q == n:
+		return 100
+	case containsAllWords(n, q):
+		return 80
+	case strings.Contains(n, q) || strings.Contains(q, n):
+		return 60
+	}
+
+	limit := max(1, len(q)/4)
+	if d := levenshtein(q, n); d <= limit {
+		return 40 - d
+	}
+	for _, word := range strings.Fields(n) {
+		if d := levenshtein(q, word); d <= limit {
+			return 30 - d
+		}
+	}
+	return 0
+}
+
+// normalizeObjectName lowercases a name and reduces each word to its
+// singular form so "Eggs" matches "egg".
+func normalizeObjectName(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		words[i] = services.NormalizeUnit(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// containsAllWords reports whether every word of query appears as a word in name.
+func containsAllWords(name, query string) bool {
+	words := make(map[string]struct{})
+	for _, w := range strings.Fields(name) {
+		words[w] = struct{}{}
+	}
+	for _, w := range strings.Fields(query) {
+		if _, ok := words[w]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestAdjustObjectQuantityUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewAdjustObjectQuantityUseCase(mockCollectionRepo, mockContainerRepo, mockAuthService, nil)
+
+	ptr := func(f float64) *float64 { return &f }
+
+	// setup returns a collection holding objs in a single container and wires
+	// the mocks to find it through the user's collection list.
+	setup := func(userID entities.UserID, objs ...*entities.Object) *entities.Container {
+		collectionID := entities.NewCollectionID()
+		objects := make([]entities.Object, len(objs))
+		for i, o := range objs {
+			objects[i] = *o
+		}
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(objects...))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColName("Kitchen"))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{collection}, nil)
+		mockContainerRepo.EXPECT().GetByCollectionIDWithAccess(gomock.Any(), collectionID, userID, gomock.Any()).Return([]*entities.Container{container}, nil)
+		return container
+	}
+
+	t.Run("success - delta with fuzzy plural name", func(t *testing.T) {
+		userID := entities.NewUserID()
+		eggs := NewTestObject(ObjName("Eggs"), ObjQuantity(12))
+		setup(userID, eggs, NewTestObject(ObjName("Milk")))
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "egg", Delta: ptr(-2), UserID: userID, UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, eggs.ID(), resp.Object.ID())
+		require.NotNil(t, resp.PreviousQuantity)
+		assert.InDelta(t, 12, *resp.PreviousQuantity, 0.001)
+		assert.InDelta(t, 10, *resp.Object.Quantity(), 0.001)
+	})
+
+	t.Run("success - converts units into the object's unit", func(t *testing.T) {
+		userID := entities.NewUserID()
+		flour := NewTestObject(ObjName("Flour"), ObjQuantity(2), ObjUnit("kg"))
+		setup(userID, flour)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "flour", Delta: ptr(-500), Unit: "grams", UserID: userID, UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 1.5, *resp.Object.Quantity(), 0.001)
+		assert.Equal(t, "kg", resp.Object.Unit())
+	})
+
+	t.Run("success - absolute quantity tolerates a typo", func(t *testing.T) {
+		userID := entities.NewUserID()
+		setup(userID, NewTestObject(ObjName("Tomatoes"), ObjQuantity(1)))
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "tomatos", Quantity: ptr(6), UserID: userID, UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 6, *resp.Object.Quantity(), 0.001)
+	})
+
+	t.Run("delta larger than stock empties the object", func(t *testing.T) {
+		userID := entities.NewUserID()
+		setup(userID, NewTestObject(ObjName("Eggs"), ObjQuantity(1)))
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "eggs", Delta: ptr(-3), UserID: userID, UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 0, *resp.Object.Quantity(), 0.001)
+	})
+
+	t.Run("success - object ID skips name matching", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		red := NewTestObject(ObjName("Red Apple"), ObjQuantity(4))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*red, *NewTestObject(ObjName("Green Apple"))))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), red.ID()).Return(container, nil)
+		mockCollectionRepo.EXPECT().GetByIDSummary(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+
+		id := red.ID()
+		resp, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectID: &id, Delta: ptr(-1), UserID: userID, UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, red.ID(), resp.Object.ID())
+		assert.InDelta(t, 3, *resp.Object.Quantity(), 0.001)
+	})
+
+	t.Run("error - ambiguous match lists candidates", func(t *testing.T) {
+		userID := entities.NewUserID()
+		setup(userID, NewTestObject(ObjName("Red Apple")), NewTestObject(ObjName("Green Apple")))
+
+		_, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "apple", Delta: ptr(-1), UserID: userID, UserToken: "test-token",
+		})
+
+		var ambiguous *AmbiguousObjectMatchError
+		require.ErrorAs(t, err, &ambiguous)
+		assert.Len(t, ambiguous.Candidates, 2)
+	})
+
+	t.Run("error - incompatible units", func(t *testing.T) {
+		userID := entities.NewUserID()
+		setup(userID, NewTestObject(ObjName("Milk"), ObjQuantity(1), ObjUnit("l")))
+
+		_, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "milk", Delta: ptr(-200), Unit: "g", UserID: userID, UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, services.ErrIncompatibleUnits))
+	})
+
+	t.Run("error - no match", func(t *testing.T) {
+		userID := entities.NewUserID()
+		setup(userID, NewTestObject(ObjName("Milk")))
+
+		_, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "bread", Delta: ptr(-1), UserID: userID, UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("error - delta and quantity both set", func(t *testing.T) {
+		_, err := useCase.Execute(context.Background(), AdjustObjectQuantityRequest{
+			ObjectName: "milk", Delta: ptr(-1), Quantity: ptr(2), UserID: entities.NewUserID(), UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type ArchiveObjectRequest struct {
+	ObjectID entities.ObjectID
+	// Archived archives the object when true and restores it when false.
+	Archived  bool
+	UserID    entities.UserID
+	UserToken string
+}
+
+type ArchiveObjectResponse struct {
+	Object      *entities.Object
+	ContainerID entities.ContainerID
+}
+
+type ArchiveObjectUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewArchiveObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *ArchiveObjectUseCase {
+	return &ArchiveObjectUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *ArchiveObjectUseCase) Execute(ctx context.Context, req ArchiveObjectRequest) (*ArchiveObjectResponse, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, container.CollectionID())
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	existing, err := container.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found in container: %w", err)
+	}
+
+	updated := *existing
+	if req.Archived {
+		err = updated.Archive()
+	} else {
+		err = updated.Unarchive()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update archived state: %w", err)
+	}
+
+	if err := container.UpdateObject(req.ObjectID, updated); err != nil {
+		return nil, fmt.Errorf("failed to update object in container: %w", err)
+	}
+
+	if err := uc.containerRepo.Update(ctx, container); err != nil {
+		return nil, fmt.Errorf("failed to save container: %w", err)
+	}
+
+	return &ArchiveObjectResponse{
+		Object:      &updated,
+		ContainerID: container.ID(),
+	}, nil
+}
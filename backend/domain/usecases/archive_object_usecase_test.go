@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestArchiveObjectUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewArchiveObjectUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+
+	t.Run("success - archive object", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID))
+		other := NewTestObject()
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID), CtrObjects(*obj, *other))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *entities.Container) error {
+			assert.Equal(t, 1, c.ObjectCount())
+			assert.Len(t, c.Objects(), 2)
+			return nil
+		})
+
+		resp, err := useCase.Execute(context.Background(), ArchiveObjectRequest{
+			ObjectID:  objectID,
+			Archived:  true,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Object.IsArchived())
+		assert.Equal(t, containerID, resp.ContainerID)
+	})
+
+	t.Run("success - unarchive object", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjArchivedAt(time.Now().Add(-time.Hour)))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), ArchiveObjectRequest{
+			ObjectID:  objectID,
+			Archived:  false,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Object.IsArchived())
+		assert.Equal(t, 1, container.ObjectCount())
+	})
+
+	t.Run("error - access denied", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(entities.NewUserID()))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		_, err := useCase.Execute(context.Background(), ArchiveObjectRequest{
+			ObjectID:  objectID,
+			Archived:  true,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
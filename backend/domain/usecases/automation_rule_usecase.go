@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CreateAutomationRuleRequest struct {
+	Props     entities.AutomationRuleProps
+	UserID    entities.UserID
+	UserToken string
+}
+
+type UpdateAutomationRuleRequest struct {
+	RuleID    entities.AutomationRuleID
+	Props     entities.AutomationRuleProps
+	UserID    entities.UserID
+	UserToken string
+}
+
+// AutomationRuleUseCase manages a user's automation rules.
+type AutomationRuleUseCase struct {
+	ruleRepo       repositories.AutomationRuleRepository
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	authService    services.AuthService
+}
+
+func NewAutomationRuleUseCase(
+	ruleRepo repositories.AutomationRuleRepository,
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	authService services.AuthService,
+) *AutomationRuleUseCase {
+	return &AutomationRuleUseCase{
+		ruleRepo:       ruleRepo,
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		authService:    authService,
+	}
+}
+
+// List returns the user's rules, oldest first.
+func (uc *AutomationRuleUseCase) List(ctx context.Context, userID entities.UserID) ([]*entities.AutomationRule, error) {
+	rules, err := uc.ruleRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Create adds a rule, up to entities.MaxAutomationRules per user.
+func (uc *AutomationRuleUseCase) Create(ctx context.Context, req CreateAutomationRuleRequest) (*entities.AutomationRule, error) {
+	rules, err := uc.ruleRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+	if len(rules) >= entities.MaxAutomationRules {
+		return nil, fmt.Errorf("%w: the limit is %d", entities.ErrTooManyAutomationRules, entities.MaxAutomationRules)
+	}
+
+	rule, err := entities.NewAutomationRule(req.UserID, req.Props, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkTargets(ctx, rule, req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save automation rule: %w", err)
+	}
+	return rule, nil
+}
+
+// Update replaces the rule's name, trigger, actions and whether it is on.
+func (uc *AutomationRuleUseCase) Update(ctx context.Context, req UpdateAutomationRuleRequest) (*entities.AutomationRule, error) {
+	rule, err := uc.ownedRule(ctx, req.RuleID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Update(req.Props, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.checkTargets(ctx, rule, req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save automation rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (uc *AutomationRuleUseCase) Delete(ctx context.Context, ruleID entities.AutomationRuleID, userID entities.UserID) error {
+	if _, err := uc.ownedRule(ctx, ruleID, userID); err != nil {
+		return err
+	}
+
+	if err := uc.ruleRepo.Delete(ctx, ruleID); err != nil {
+		return fmt.Errorf("failed to delete automation rule: %w", err)
+	}
+	return nil
+}
+
+// ownedRule loads the user's rule. Other users' rules are reported as
+// missing so IDs cannot be probed.
+func (uc *AutomationRuleUseCase) ownedRule(ctx context.Context, ruleID entities.AutomationRuleID, userID entities.UserID) (*entities.AutomationRule, error) {
+	rule, err := uc.ruleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if !rule.IsOwnedBy(userID) {
+		return nil, entities.ErrAutomationRuleNotFound
+	}
+	return rule, nil
+}
+
+// checkTargets makes sure the user can reach the collection the rule
+// watches and the containers it moves objects to. A rule watching one
+// collection can only move objects within it.
+func (uc *AutomationRuleUseCase) checkTargets(ctx context.Context, rule *entities.AutomationRule, userID entities.UserID, userToken string) error {
+	scope := rule.Trigger().CollectionID
+	if scope != nil {
+		if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, *scope, userID, userToken); err != nil {
+			return err
+		}
+	}
+
+	for _, action := range rule.Actions() {
+		if action.Kind != entities.ActionMoveToContainer {
+			continue
+		}
+		container, err := uc.containerRepo.GetByID(ctx, *action.ContainerID)
+		if err != nil {
+			return fmt.Errorf("container not found: %w", err)
+		}
+		if scope != nil && !container.CollectionID().Equals(*scope) {
+			return fmt.Errorf("%w: the container is not in the collection the rule watches", entities.ErrInvalidAutomationAction)
+		}
+		if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, container.CollectionID(), userID, userToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestAutomationRuleUseCase(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRuleRepo := mocks.NewMockAutomationRuleRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewAutomationRuleUseCase(mockRuleRepo, mockCollectionRepo, mockContainerRepo, mockAuthService)
+
+	userID := entities.NewUserID()
+	tagNew := entities.AutomationRuleProps{
+		Name:    "Tag new things",
+		Enabled: true,
+		Trigger: entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+		Actions: []entities.AutomationAction{{Kind: entities.ActionAddTag, Tag: "new"}},
+	}
+
+	t.Run("success - creates a rule", func(t *testing.T) {
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(nil, nil)
+		mockRuleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		rule, err := useCase.Create(context.Background(), CreateAutomationRuleRequest{Props: tagNew, UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Tag new things", rule.Name())
+		assert.True(t, rule.IsOwnedBy(userID))
+	})
+
+	t.Run("error - too many rules", func(t *testing.T) {
+		existing := make([]*entities.AutomationRule, entities.MaxAutomationRules)
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(existing, nil)
+
+		_, err := useCase.Create(context.Background(), CreateAutomationRuleRequest{Props: tagNew, UserID: userID})
+
+		assert.ErrorIs(t, err, entities.ErrTooManyAutomationRules)
+	})
+
+	t.Run("error - move target outside the watched collection", func(t *testing.T) {
+		collection := NewTestCollection(ColUserID(userID))
+		elsewhere := NewTestContainer(CtrCollectionID(entities.NewCollectionID()))
+		collectionID := collection.ID()
+		targetID := elsewhere.ID()
+		props := entities.AutomationRuleProps{
+			Name:    "File it",
+			Enabled: true,
+			Trigger: entities.AutomationTrigger{Kind: entities.TriggerObjectCreated, CollectionID: &collectionID},
+			Actions: []entities.AutomationAction{{Kind: entities.ActionMoveToContainer, ContainerID: &targetID}},
+		}
+
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(nil, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), targetID).Return(elsewhere, nil)
+
+		_, err := useCase.Create(context.Background(), CreateAutomationRuleRequest{Props: props, UserID: userID})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidAutomationAction)
+	})
+
+	t.Run("success - update keeps an unchanged webhook's secret", func(t *testing.T) {
+		hook := entities.AutomationAction{Kind: entities.ActionFireWebhook, URL: "https://example.com/hook", Secret: "s3cret"}
+		rule, err := entities.NewAutomationRule(userID, entities.AutomationRuleProps{
+			Name:    "Notify",
+			Enabled: true,
+			Trigger: entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+			Actions: []entities.AutomationAction{hook},
+		}, time.Now())
+		require.NoError(t, err)
+
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID()).Return(rule, nil)
+		mockRuleRepo.EXPECT().Update(gomock.Any(), rule).Return(nil)
+
+		updated, err := useCase.Update(context.Background(), UpdateAutomationRuleRequest{
+			RuleID: rule.ID(),
+			Props: entities.AutomationRuleProps{
+				Name:    "Notify me",
+				Trigger: entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+				Actions: []entities.AutomationAction{{Kind: entities.ActionFireWebhook, URL: hook.URL}},
+			},
+			UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Notify me", updated.Name())
+		assert.False(t, updated.Enabled())
+		assert.Equal(t, "s3cret", updated.Actions()[0].Secret)
+	})
+
+	t.Run("error - another user's rule is not found", func(t *testing.T) {
+		rule, err := entities.NewAutomationRule(entities.NewUserID(), tagNew, time.Now())
+		require.NoError(t, err)
+
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID()).Return(rule, nil)
+
+		err = useCase.Delete(context.Background(), rule.ID(), userID)
+
+		assert.ErrorIs(t, err, entities.ErrAutomationRuleNotFound)
+	})
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type BackupAccountRequest struct {
+	UserID entities.UserID
+}
+
+type BackupAccountResponse struct {
+	// Collections owned by the user, with containers and objects loaded.
+	Collections []*entities.Collection
+	// Digest is nil when the user never saved digest preferences.
+	Digest     *entities.DigestSubscription
+	ExportedAt time.Time
+}
+
+type BackupAccountUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	digestRepo     repositories.DigestSubscriptionRepository
+}
+
+func NewBackupAccountUseCase(collectionRepo repositories.CollectionRepository, digestRepo repositories.DigestSubscriptionRepository) *BackupAccountUseCase {
+	return &BackupAccountUseCase{
+		collectionRepo: collectionRepo,
+		digestRepo:     digestRepo,
+	}
+}
+
+// Execute gathers everything the user owns. Collections shared with the user
+// through a group belong to someone else and are not included.
+func (uc *BackupAccountUseCase) Execute(ctx context.Context, req BackupAccountRequest) (*BackupAccountResponse, error) {
+	collections, err := uc.collectionRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	digest, err := uc.digestRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		if !errors.Is(err, entities.ErrDigestSubscriptionNotFound) {
+			return nil, fmt.Errorf("failed to get digest preferences: %w", err)
+		}
+		digest = nil
+	}
+
+	return &BackupAccountResponse{
+		Collections: collections,
+		Digest:      digest,
+		ExportedAt:  time.Now(),
+	}, nil
+}
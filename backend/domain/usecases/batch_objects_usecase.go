@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+var ErrBatchObjectNotFound = errors.New("object not found in this collection")
+
+type BatchObjectsRequest struct {
+	CollectionID entities.CollectionID
+	ObjectIDs    []entities.ObjectID
+	Operation    entities.BatchOperation
+	// ContainerID is where a move puts the objects; it must be in the
+	// same collection
+	ContainerID *entities.ContainerID
+	UserID      entities.UserID
+	UserToken   string
+}
+
+type BatchObjectsResponse struct {
+	// Changed is how many objects were moved or deleted. Objects already
+	// in the target container of a move are not counted.
+	Changed int
+}
+
+// BatchObjectsUseCase moves or deletes several objects of a collection in
+// one request, for multi-select in the object lists.
+type BatchObjectsUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	codeRepo       repositories.ObjectCodeRepository
+	moveRepo       repositories.ObjectMoveRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
+	authService    services.AuthService
+}
+
+func NewBatchObjectsUseCase(
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	moveRepo repositories.ObjectMoveRepository,
+	mediaRepo repositories.MediaRepository,
+	mediaStorage services.MediaStorage,
+	authService services.AuthService,
+) *BatchObjectsUseCase {
+	return &BatchObjectsUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		codeRepo:       codeRepo,
+		moveRepo:       moveRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
+		authService:    authService,
+	}
+}
+
+// Execute applies the operation to every named object. Every object must
+// be found in the collection before anything is saved, so a stale selection
+// changes nothing.
+func (uc *BatchObjectsUseCase) Execute(ctx context.Context, req BatchObjectsRequest) (*BatchObjectsResponse, error) {
+	if len(req.ObjectIDs) == 0 {
+		return nil, errors.New("at least one object is required")
+	}
+	if len(req.ObjectIDs) > entities.MaxBatchObjects {
+		return nil, fmt.Errorf("at most %d objects can be changed at once", entities.MaxBatchObjects)
+	}
+	switch req.Operation {
+	case entities.BatchMove:
+		if req.ContainerID == nil {
+			return nil, fmt.Errorf("%w: a move needs a container", entities.ErrInvalidBatchOperation)
+		}
+	case entities.BatchDelete:
+	default:
+		return nil, fmt.Errorf("%w: %q", entities.ErrInvalidBatchOperation, req.Operation)
+	}
+
+	if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	containers, err := uc.containerRepo.GetByCollectionID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containers: %w", err)
+	}
+	holders := make(map[entities.ObjectID]*entities.Container, len(req.ObjectIDs))
+	for _, container := range containers {
+		for _, object := range container.Objects() {
+			holders[object.ID()] = container
+		}
+	}
+	var ids []entities.ObjectID
+	for _, id := range req.ObjectIDs {
+		if holders[id] == nil {
+			return nil, fmt.Errorf("%w: %s", ErrBatchObjectNotFound, id)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	if req.Operation == entities.BatchDelete {
+		return uc.delete(ctx, ids, holders, req.UserID)
+	}
+
+	var target *entities.Container
+	for _, container := range containers {
+		if container.ID().Equals(*req.ContainerID) {
+			target = container
+			break
+		}
+	}
+	if target == nil {
+		return nil, errors.New("container not found in this collection")
+	}
+	return uc.move(ctx, ids, holders, target, req.UserID)
+}
+
+// move takes the objects out of their containers and puts them in target,
+// recording each move like one made by hand
+func (uc *BatchObjectsUseCase) move(ctx context.Context, ids []entities.ObjectID, holders map[entities.ObjectID]*entities.Container, target *entities.Container, userID entities.UserID) (*BatchObjectsResponse, error) {
+	resp := &BatchObjectsResponse{}
+	var moves []*entities.ObjectMove
+	dirty := []*entities.Container{target}
+	for _, id := range ids {
+		current := holders[id]
+		if current.ID().Equals(target.ID()) {
+			continue
+		}
+		object, err := current.GetObject(id)
+		if err != nil {
+			return nil, err
+		}
+		if err := current.RemoveObject(id); err != nil {
+			return nil, err
+		}
+		if err := target.AddObject(*object); err != nil {
+			return nil, err
+		}
+		moves = append(moves, entities.NewObjectMove(id, entities.PlacementOf(current), entities.PlacementOf(target), userID))
+		if !slices.Contains(dirty, current) {
+			dirty = append(dirty, current)
+		}
+		resp.Changed++
+	}
+	if resp.Changed == 0 {
+		return resp, nil
+	}
+
+	// Record the moves before touching the containers so a failure leaves
+	// at worst a history entry for a move that did not happen
+	for _, move := range moves {
+		if err := uc.moveRepo.Create(ctx, move); err != nil {
+			return nil, fmt.Errorf("failed to record object move: %w", err)
+		}
+	}
+	for _, container := range dirty {
+		if err := uc.containerRepo.Update(ctx, container); err != nil {
+			return nil, fmt.Errorf("failed to save container: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// delete removes the objects from their containers, then their codes and
+// photos
+func (uc *BatchObjectsUseCase) delete(ctx context.Context, ids []entities.ObjectID, holders map[entities.ObjectID]*entities.Container, userID entities.UserID) (*BatchObjectsResponse, error) {
+	var dirty []*entities.Container
+	codes := make(map[entities.ObjectID][]string, len(ids))
+	for _, id := range ids {
+		container := holders[id]
+		object, err := container.GetObject(id)
+		if err != nil {
+			return nil, err
+		}
+		codes[id] = object.Codes()
+		if err := container.RemoveObject(id); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(dirty, container) {
+			dirty = append(dirty, container)
+		}
+	}
+	for _, container := range dirty {
+		if err := uc.containerRepo.Update(ctx, container); err != nil {
+			return nil, fmt.Errorf("failed to save container: %w", err)
+		}
+	}
+	for _, id := range ids {
+		_ = syncObjectCodes(ctx, uc.codeRepo, userID, id, codes[id], nil)
+		if err := deleteOwnerMedia(ctx, uc.mediaRepo, uc.mediaStorage, entities.MediaOwnerObject, id.String()); err != nil {
+			return nil, err
+		}
+	}
+	return &BatchObjectsResponse{Changed: len(ids)}, nil
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestBatchObjectsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	mockMoveRepo := mocks.NewMockObjectMoveRepository(mockCtrl)
+	mockMediaRepo := mocks.NewMockMediaRepository(mockCtrl)
+	mockMediaStorage := mocks.NewMockMediaStorage(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewBatchObjectsUseCase(mockContainerRepo, mockCollectionRepo, mockCodeRepo, mockMoveRepo, mockMediaRepo, mockMediaStorage, mockAuthService)
+
+	t.Run("success - moves objects and skips those already in the target", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		rice := NewTestObject(ObjName("Rice"))
+		oats := NewTestObject(ObjName("Oats"))
+		milk := NewTestObject(ObjName("Milk"))
+		pantry := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*rice, *milk))
+		shelf := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*oats))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		shelfID := shelf.ID()
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{pantry, shelf}, nil)
+		mockMoveRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), shelf).Return(nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), pantry).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), BatchObjectsRequest{
+			CollectionID: collectionID,
+			ObjectIDs:    []entities.ObjectID{rice.ID(), oats.ID(), rice.ID()},
+			Operation:    entities.BatchMove,
+			ContainerID:  &shelfID,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Changed, "oats were already on the shelf")
+		_, err = shelf.GetObject(rice.ID())
+		assert.NoError(t, err)
+		_, err = pantry.GetObject(rice.ID())
+		assert.ErrorIs(t, err, entities.ErrObjectNotFoundInContainer)
+		_, err = pantry.GetObject(milk.ID())
+		assert.NoError(t, err, "unselected objects stay put")
+	})
+
+	t.Run("success - deletes objects, their codes and their photos", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		rice := NewTestObject(ObjName("Rice"), ObjProps(Props("upc", "0123456789012")))
+		oats := NewTestObject(ObjName("Oats"))
+		pantry := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*rice, *oats))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{pantry}, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), pantry).Return(nil)
+		mockCodeRepo.EXPECT().Remove(gomock.Any(), userID, "0123456789012", rice.ID()).Return(nil)
+		mockMediaRepo.EXPECT().ListByOwner(gomock.Any(), entities.MediaOwnerObject, rice.ID().String(), gomock.Any()).Return(nil, int64(0), nil)
+		mockMediaRepo.EXPECT().ListByOwner(gomock.Any(), entities.MediaOwnerObject, oats.ID().String(), gomock.Any()).Return(nil, int64(0), nil)
+
+		resp, err := useCase.Execute(context.Background(), BatchObjectsRequest{
+			CollectionID: collectionID,
+			ObjectIDs:    []entities.ObjectID{rice.ID(), oats.ID()},
+			Operation:    entities.BatchDelete,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Changed)
+		assert.Empty(t, pantry.Objects())
+	})
+
+	t.Run("error - an object outside the collection changes nothing", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		rice := NewTestObject(ObjName("Rice"))
+		pantry := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*rice))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{pantry}, nil)
+
+		_, err := useCase.Execute(context.Background(), BatchObjectsRequest{
+			CollectionID: collectionID,
+			ObjectIDs:    []entities.ObjectID{rice.ID(), entities.NewObjectID()},
+			Operation:    entities.BatchDelete,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		assert.ErrorIs(t, err, ErrBatchObjectNotFound)
+		assert.Len(t, pantry.Objects(), 1)
+	})
+
+	t.Run("error - target container in another collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		rice := NewTestObject(ObjName("Rice"))
+		pantry := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*rice))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		elsewhere := entities.NewContainerID()
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{pantry}, nil)
+
+		_, err := useCase.Execute(context.Background(), BatchObjectsRequest{
+			CollectionID: collectionID,
+			ObjectIDs:    []entities.ObjectID{rice.ID()},
+			Operation:    entities.BatchMove,
+			ContainerID:  &elsewhere,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "container not found")
+	})
+
+	t.Run("error - access denied to another user's collection", func(t *testing.T) {
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(entities.NewUserID()))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		_, err := useCase.Execute(context.Background(), BatchObjectsRequest{
+			CollectionID: collectionID,
+			ObjectIDs:    []entities.ObjectID{entities.NewObjectID()},
+			Operation:    entities.BatchDelete,
+			UserID:       entities.NewUserID(),
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - invalid operations are rejected before any lookup", func(t *testing.T) {
+		_, err := useCase.Execute(context.Background(), BatchObjectsRequest{
+			ObjectIDs: []entities.ObjectID{entities.NewObjectID()},
+			Operation: "archive",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidBatchOperation)
+
+		_, err = useCase.Execute(context.Background(), BatchObjectsRequest{
+			ObjectIDs: []entities.ObjectID{entities.NewObjectID()},
+			Operation: entities.BatchMove,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidBatchOperation, "a move needs a container")
+	})
+}
@@ -0,0 +1,298 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// ContainerPathSeparator joins container names into a parent path, e.g.
+// "Garage/Shelf A".
+const ContainerPathSeparator = "/"
+
+// ContainerRow is one container to create, as read from a CSV line or a JSON
+// entry.
+type ContainerRow struct {
+	Name string
+	Type string
+	// ParentPath names the parent by the container names from the collection
+	// root down, joined with ContainerPathSeparator. Empty puts the container
+	// at the root. It may name a container that already exists or one created
+	// by another row.
+	ParentPath string
+	Location   string
+}
+
+type BulkCreateContainersRequest struct {
+	CollectionID entities.CollectionID
+	Rows         []ContainerRow
+	// DryRun validates the rows and reports what would be created without
+	// saving anything.
+	DryRun    bool
+	UserID    entities.UserID
+	UserToken string
+}
+
+type BulkCreateContainersResponse struct {
+	// Created are listed parents before children.
+	Created []*entities.Container
+	// Errors lists every row that cannot be created, such as one whose parent
+	// path does not resolve. When there are any, nothing is saved.
+	Errors []string
+}
+
+// BulkCreateContainersUseCase sets up a container hierarchy from a flat list
+// of rows, for users moving a whole household in. Rows are checked together
+// and either all are created or none.
+type BulkCreateContainersUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	authService    services.AuthService
+}
+
+func NewBulkCreateContainersUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, authService services.AuthService) *BulkCreateContainersUseCase {
+	return &BulkCreateContainersUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		authService:    authService,
+	}
+}
+
+// plannedRow is a row that passed validation, with its parent resolved
+type plannedRow struct {
+	line          int // 1-based row number, as reported in errors
+	name          entities.ContainerName
+	containerType entities.ContainerType
+	location      string
+	path          string
+	parentPath    string
+}
+
+func (uc *BulkCreateContainersUseCase) Execute(ctx context.Context, req BulkCreateContainersRequest) (*BulkCreateContainersResponse, error) {
+	if len(req.Rows) == 0 {
+		return nil, fmt.Errorf("%w: no containers listed", ErrInvalidContainerTree)
+	}
+	if len(req.Rows) > MaxContainerTreeNodes {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContainerTree, ErrContainerTreeTooLarge)
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	// Check access: user is owner OR user is member of collection's group
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	plan, rowErrors := planContainerRows(collection, req.Rows)
+	resp := &BulkCreateContainersResponse{Errors: rowErrors}
+	if len(rowErrors) > 0 {
+		return resp, nil
+	}
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, int64(len(plan))); err != nil {
+		return nil, err
+	}
+
+	existing := containerPaths(collection.Containers())
+	ids := make(map[string]entities.ContainerID, len(plan))
+	for _, p := range plan {
+		var parentID *entities.ContainerID
+		if p.parentPath != "" {
+			id, ok := ids[p.parentPath]
+			if !ok {
+				id = existing[p.parentPath][0].ID()
+			}
+			parentID = &id
+		}
+
+		container, err := entities.NewContainer(entities.ContainerProps{
+			CollectionID:      collection.ID(),
+			Name:              p.name,
+			ContainerType:     p.containerType,
+			ParentContainerID: parentID,
+			Location:          p.location,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create container entity: %w", err)
+		}
+		if !req.DryRun {
+			if err := uc.containerRepo.Create(ctx, container); err != nil {
+				return nil, fmt.Errorf("failed to save container %q: %w", p.path, err)
+			}
+		}
+		if err := collection.AddContainer(*container); err != nil {
+			return nil, fmt.Errorf("failed to add container to collection: %w", err)
+		}
+		ids[p.path] = container.ID()
+		resp.Created = append(resp.Created, container)
+	}
+
+	if !req.DryRun {
+		if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+			return nil, fmt.Errorf("failed to update collection: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// planContainerRows validates every row against the collection and the other
+// rows, returning them ordered parents first along with one message per
+// problem found.
+func planContainerRows(collection *entities.Collection, rows []ContainerRow) ([]plannedRow, []string) {
+	existing := containerPaths(collection.Containers())
+	var errs []string
+	rowError := func(line int, format string, args ...any) {
+		errs = append(errs, fmt.Sprintf("row %d: ", line)+fmt.Sprintf(format, args...))
+	}
+
+	var plan []plannedRow
+	listed := make(map[string]int, len(rows)) // path -> index into plan
+	for i, row := range rows {
+		line := i + 1
+		name, err := entities.NewContainerName(row.Name)
+		if err != nil {
+			rowError(line, "%v", err)
+			continue
+		}
+		containerType := entities.ContainerType(strings.ToLower(strings.TrimSpace(row.Type)))
+		if containerType == "" {
+			containerType = entities.ContainerTypeGeneral
+		}
+		if !entities.IsValidContainerType(string(containerType)) {
+			rowError(line, "unknown type %q", row.Type)
+			continue
+		}
+		if strings.Contains(name.String(), ContainerPathSeparator) {
+			rowError(line, "name %q may not contain %q", name, ContainerPathSeparator)
+			continue
+		}
+
+		parentPath := cleanContainerPath(row.ParentPath)
+		path := joinContainerPath(parentPath, name.String())
+		if len(existing[path]) > 0 {
+			rowError(line, "container %q already exists", path)
+			continue
+		}
+		if first, ok := listed[path]; ok {
+			rowError(line, "container %q is already listed in row %d", path, plan[first].line)
+			continue
+		}
+		listed[path] = len(plan)
+		plan = append(plan, plannedRow{
+			line:          line,
+			name:          name,
+			containerType: containerType,
+			location:      strings.TrimSpace(row.Location),
+			path:          path,
+			parentPath:    parentPath,
+		})
+	}
+
+	// Resolve parents now that every row's path is known, so a row may come
+	// before the row that creates its parent
+	for _, p := range plan {
+		if p.parentPath == "" {
+			continue
+		}
+		if i, ok := listed[p.parentPath]; ok {
+			if !containerTypeCanHold(plan[i].containerType) {
+				rowError(p.line, "parent %q of type %s cannot hold containers", p.parentPath, plan[i].containerType)
+			}
+			continue
+		}
+		switch matches := existing[p.parentPath]; {
+		case len(matches) == 0:
+			rowError(p.line, "parent %q not found", p.parentPath)
+		case len(matches) > 1:
+			rowError(p.line, "parent %q is ambiguous: %d containers have that path", p.parentPath, len(matches))
+		case !matches[0].CanHaveChildren():
+			rowError(p.line, "parent %q of type %s cannot hold containers", p.parentPath, matches[0].ContainerType())
+		}
+	}
+
+	depth := func(p plannedRow) int { return strings.Count(p.path, ContainerPathSeparator) + 1 }
+	for _, p := range plan {
+		if depth(p) > MaxContainerTreeDepth {
+			rowError(p.line, "container %q is nested more than %d deep", p.path, MaxContainerTreeDepth)
+		}
+	}
+	slices.SortStableFunc(plan, func(a, b plannedRow) int { return cmp.Compare(depth(a), depth(b)) })
+	return plan, errs
+}
+
+// containerPaths indexes containers by their path from the collection root.
+// Siblings may share a name, so a path can match several containers.
+func containerPaths(containers []entities.Container) map[string][]*entities.Container {
+	byID := make(map[string]*entities.Container, len(containers))
+	for i := range containers {
+		byID[containers[i].ID().String()] = &containers[i]
+	}
+
+	paths := make(map[string][]*entities.Container, len(containers))
+	for i := range containers {
+		c := &containers[i]
+		names := []string{c.Name().String()}
+		seen := map[string]bool{c.ID().String(): true}
+		for parent := c.ParentContainerID(); parent != nil; {
+			p, ok := byID[parent.String()]
+			if !ok || seen[p.ID().String()] {
+				break
+			}
+			seen[p.ID().String()] = true
+			names = append(names, p.Name().String())
+			parent = p.ParentContainerID()
+		}
+		slices.Reverse(names)
+		path := strings.Join(names, ContainerPathSeparator)
+		paths[path] = append(paths[path], c)
+	}
+	return paths
+}
+
+// cleanContainerPath trims the spaces and stray separators people leave in
+// spreadsheet cells: " Garage / Shelf A/" is "Garage/Shelf A".
+func cleanContainerPath(path string) string {
+	var names []string
+	for name := range strings.SplitSeq(path, ContainerPathSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ContainerPathSeparator)
+}
+
+func joinContainerPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + ContainerPathSeparator + name
+}
@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestBulkCreateContainersUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *BulkCreateContainersUseCase
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		quotaRepo      *mocks.MockGroupQuotaRepository
+		usageRepo      *mocks.MockUsageRepository
+		authService    *mocks.MockAuthService
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			quotaRepo:      mocks.NewMockGroupQuotaRepository(mockCtrl),
+			usageRepo:      mocks.NewMockUsageRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewBulkCreateContainersUseCase(f.containerRepo, f.collectionRepo, f.quotaRepo, f.usageRepo, f.authService)
+		return f
+	}
+
+	userID := entities.NewUserID()
+
+	// kitchen holds a pantry shelf
+	newHouse := func() (*entities.Collection, *entities.Container) {
+		collectionID := entities.NewCollectionID()
+		kitchen := NewTestContainer(CtrName("Kitchen"), CtrType(entities.ContainerTypeRoom), CtrCollectionID(collectionID))
+		pantry := NewTestContainer(CtrName("Pantry"), CtrType(entities.ContainerTypeShelf), CtrCollectionID(collectionID), CtrParentID(kitchen.ID()))
+		col := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(*kitchen, *pantry))
+		return col, kitchen
+	}
+
+	t.Run("rows nest under existing containers and each other in any order", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, kitchen := newHouse()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.containerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+		f.collectionRepo.EXPECT().Update(gomock.Any(), col).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), BulkCreateContainersRequest{
+			CollectionID: col.ID(),
+			Rows: []ContainerRow{
+				{Name: "Top shelf", Type: "shelf", ParentPath: "Garage / Rack/"},
+				{Name: "Garage", Type: "Room", Location: "Detached"},
+				{Name: "Rack", ParentPath: "Garage"},
+				{Name: "Spice drawer", Type: "cabinet", ParentPath: "Kitchen"},
+			},
+			UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Errors)
+		require.Len(t, resp.Created, 4)
+		byName := make(map[string]*entities.Container)
+		for _, c := range resp.Created {
+			byName[c.Name().String()] = c
+		}
+		assert.Equal(t, "Detached", byName["Garage"].Location())
+		assert.Nil(t, byName["Garage"].ParentContainerID())
+		assert.Equal(t, byName["Garage"].ID(), *byName["Rack"].ParentContainerID())
+		assert.Equal(t, byName["Rack"].ID(), *byName["Top shelf"].ParentContainerID())
+		assert.Equal(t, kitchen.ID(), *byName["Spice drawer"].ParentContainerID())
+		assert.Equal(t, entities.ContainerTypeGeneral, byName["Rack"].ContainerType())
+		assert.Equal(t, "Garage", resp.Created[0].Name().String(), "parents come first")
+		assert.Len(t, col.Containers(), 6)
+	})
+
+	t.Run("unresolved parents reject every row", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, _ := newHouse()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), BulkCreateContainersRequest{
+			CollectionID: col.ID(),
+			Rows: []ContainerRow{
+				{Name: "Attic", Type: "room"},
+				{Name: "Box", ParentPath: "Atic"},
+				{Name: "Jar", ParentPath: "Kitchen/Pantry"},
+				{Name: "Pantry", ParentPath: "Kitchen"},
+				{Name: "Crate", Type: "crate"},
+			},
+			UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Created)
+		assert.ElementsMatch(t, []string{
+			`row 2: parent "Atic" not found`,
+			`row 3: parent "Kitchen/Pantry" of type shelf cannot hold containers`,
+			`row 4: container "Kitchen/Pantry" already exists`,
+			`row 5: unknown type "crate"`,
+		}, resp.Errors)
+		assert.Len(t, col.Containers(), 2)
+	})
+
+	t.Run("dry run reports the containers without saving", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, _ := newHouse()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), BulkCreateContainersRequest{
+			CollectionID: col.ID(),
+			Rows:         []ContainerRow{{Name: "Cellar", Type: "room"}, {Name: "Wine rack", ParentPath: "Cellar"}},
+			DryRun:       true,
+			UserID:       userID,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Errors)
+		assert.Len(t, resp.Created, 2)
+	})
+
+	t.Run("rows past the group's container quota create nothing", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		groupID, _ := entities.GroupIDFromString("family")
+		group := NewTestGroup(GrpID(groupID))
+		col := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return([]*entities.Group{group}, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		expectGroupQuota(f.quotaRepo, f.usageRepo, groupID, entities.QuotaContainers, 3, 1)
+
+		_, err := f.useCase.Execute(context.Background(), BulkCreateContainersRequest{
+			CollectionID: col.ID(),
+			Rows:         []ContainerRow{{Name: "Shed"}, {Name: "Attic"}, {Name: "Cellar"}},
+			UserID:       userID,
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, int64(3), quotaErr.Requested)
+	})
+
+	t.Run("another user's collection is denied", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, _ := newHouse()
+		other := entities.NewUserID()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), other.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), BulkCreateContainersRequest{
+			CollectionID: col.ID(),
+			Rows:         []ContainerRow{{Name: "Shed"}},
+			UserID:       other,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/repositories"
@@ -21,9 +22,20 @@ type BulkImportCollectionRequest struct {
 	Data              []map[string]any
 	DefaultTags       []string
 	UserToken         string
-	LocationColumn    string // column name for container mapping (default: "location")
-	NameColumn        string // column name override for object name
-	InferSchema       bool   // run type inference and save schema to collection
+	LocationColumn    string             // column name for container mapping (default: "location")
+	NameColumn        string             // column name override for object name
+	InferSchema       bool               // run type inference and save schema to collection
+	SourceFormat      ImportSourceFormat // app the data was exported from; empty means generic
+	// Progress, when set, is called as rows are processed with the number
+	// handled so far and the number to handle.
+	Progress func(done, total int)
+}
+
+// reportProgress calls req.Progress if the caller asked for updates.
+func (req BulkImportCollectionRequest) reportProgress(done, total int) {
+	if req.Progress != nil {
+		req.Progress(done, total)
+	}
 }
 
 type BulkImportCollectionResponse struct {
@@ -35,6 +47,7 @@ type BulkImportCollectionResponse struct {
 	Assignments       map[string]int           `json:"assignments,omitempty"` // containerID -> count
 	ContainersCreated int                      `json:"containers_created,omitempty"`
 	InferredSchema    *entities.PropertySchema `json:"inferred_schema,omitempty"`
+	SourceFormat      ImportSourceFormat       `json:"source_format,omitempty"`
 }
 
 type CapacityWarning struct {
@@ -49,6 +62,9 @@ type CapacityWarning struct {
 type BulkImportCollectionUseCase struct {
 	collectionRepo     repositories.CollectionRepository
 	containerRepo      repositories.ContainerRepository
+	codeRepo           repositories.ObjectCodeRepository
+	quotaRepo          repositories.GroupQuotaRepository
+	usageRepo          repositories.UsageRepository
 	authService        services.AuthService
 	typeInference      *services.TypeInferenceService
 	imageSearchService services.ImageSearchService
@@ -61,6 +77,9 @@ type BulkImportCollectionUseCase struct {
 func NewBulkImportCollectionUseCase(
 	collectionRepo repositories.CollectionRepository,
 	containerRepo repositories.ContainerRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
 	authService services.AuthService,
 	reservedColumns []string,
 	imageSearchService services.ImageSearchService,
@@ -69,6 +88,9 @@ func NewBulkImportCollectionUseCase(
 	return &BulkImportCollectionUseCase{
 		collectionRepo:     collectionRepo,
 		containerRepo:      containerRepo,
+		codeRepo:           codeRepo,
+		quotaRepo:          quotaRepo,
+		usageRepo:          usageRepo,
 		authService:        authService,
 		typeInference:      services.NewTypeInferenceService(reservedColumns),
 		imageSearchService: imageSearchService,
@@ -76,6 +98,14 @@ func NewBulkImportCollectionUseCase(
 	}
 }
 
+// CheckAccess reports whether the import in req may start: the collection
+// must exist and the user must own it or belong to its group. Execute
+// checks again, as access may change while a queued import waits.
+func (uc *BulkImportCollectionUseCase) CheckAccess(ctx context.Context, req BulkImportCollectionRequest) error {
+	_, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	return err
+}
+
 func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImportCollectionRequest) (*BulkImportCollectionResponse, error) {
 	// Verify user access to the collection
 	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
@@ -90,7 +120,7 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 
 	// Check access: user is owner OR user is member of collection's group
 	hasAccess := false
-	if collection.UserID().Equals(req.UserID) {
+	if collection.IsOwnedBy(req.UserID) {
 		hasAccess = true
 	} else if collection.GroupID() != nil {
 		for _, group := range userGroups {
@@ -105,6 +135,19 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 		return nil, errors.New("access denied")
 	}
 
+	// Rewrite other apps' exports into Nishiki columns. Column overrides refer
+	// to the original headers, so fall back to the canonical names.
+	if req.SourceFormat != "" && req.SourceFormat != ImportSourceGeneric {
+		req.Data, req.SourceFormat, err = adaptImportRows(req.SourceFormat, req.Data)
+		if err != nil {
+			return nil, err
+		}
+		if req.SourceFormat != ImportSourceGeneric {
+			req.NameColumn = ""
+			req.LocationColumn = ""
+		}
+	}
+
 	// Run type inference if requested
 	var inferredSchema *entities.PropertySchema
 	if req.InferSchema && len(req.Data) > 0 {
@@ -164,6 +207,9 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 			return nil, fmt.Errorf("failed to check existing containers: %w", err)
 		}
 		if len(existingContainers) == 0 {
+			if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, 1); err != nil {
+				return nil, err
+			}
 			containerName, err := entities.NewContainerName("Default Container")
 			if err != nil {
 				return nil, fmt.Errorf("failed to create container name: %w", err)
@@ -233,6 +279,9 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 			targetContainers = append(targetContainers, &containers[0])
 		} else {
 			// Create a default container for bulk import
+			if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, 1); err != nil {
+				return nil, err
+			}
 			containerName, err := entities.NewContainerName("Default Container")
 			if err != nil {
 				return nil, fmt.Errorf("failed to create container name: %w", err)
@@ -261,10 +310,13 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 
 	// Process the bulk import data
 	imported := 0
+	var added []*entities.Object
 	failed := 0
 	var errors []string
 
-	for _, item := range req.Data {
+	for i, item := range req.Data {
+		req.reportProgress(i, len(req.Data))
+
 		// Extract name
 		name, ok := resolveNameField(item, req.NameColumn)
 		if !ok {
@@ -278,6 +330,7 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 
 		// Extract reserved fields
 		desc, quantity := resolveReservedFields(item)
+		unit, expiresAt := resolveStockFields(item)
 		tags := resolveTagsField(item, req.DefaultTags)
 
 		// Extract and coerce properties (all fields except reserved columns)
@@ -289,6 +342,9 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 			}
 		}
 		properties := uc.typeInference.CoerceRow(rawProps, activeSchema)
+		if expiresAt == nil {
+			expiresAt = collection.DefaultExpiry(objectType, properties, tags, time.Now())
+		}
 
 		// Create the object
 		objectName, err := entities.NewObjectName(name)
@@ -303,6 +359,8 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 			Description: entities.NewObjectDescription(desc),
 			ObjectType:  objectType,
 			Quantity:    quantity,
+			Unit:        unit,
+			ExpiresAt:   expiresAt,
 			Properties:  properties,
 			Tags:        tags,
 		})
@@ -321,13 +379,20 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 			continue
 		}
 
+		added = append(added, newObject)
 		imported++
 	}
+	req.reportProgress(len(req.Data), len(req.Data))
+
+	if err := uc.checkObjectQuota(ctx, collection, added); err != nil {
+		return nil, err
+	}
 
 	// Save the updated container with objects
 	if err := uc.containerRepo.Update(ctx, targetContainer); err != nil {
 		return nil, fmt.Errorf("failed to save container with imported objects: %w", err)
 	}
+	uc.indexImportedCodes(ctx, req.UserID, added)
 
 	// If a new container was created (default case), also update the collection
 	if len(collection.Containers()) > 0 && collection.Containers()[len(collection.Containers())-1].ID().Equals(targetContainer.ID()) {
@@ -350,6 +415,7 @@ func (uc *BulkImportCollectionUseCase) Execute(ctx context.Context, req BulkImpo
 		CapacityWarnings: []CapacityWarning{}, // TODO: Calculate capacity warnings
 		Assignments:      assignments,
 		InferredSchema:   inferredSchema,
+		SourceFormat:     req.SourceFormat,
 	}, nil
 }
 
@@ -358,12 +424,15 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 	containerMap := autoDistData.containerMap
 
 	imported := 0
+	var added []*entities.Object
 	failed := 0
 	var errors []string
 	assignments := make(map[string]int)
 
 	// Process each assignment from the distribution plan
-	for _, assignment := range plan.Assignments {
+	for i, assignment := range plan.Assignments {
+		req.reportProgress(i, len(plan.Assignments))
+
 		// Get the object data for this assignment
 		if assignment.ObjectIndex >= len(req.Data) {
 			errors = append(errors, fmt.Sprintf("invalid object index: %d", assignment.ObjectIndex))
@@ -386,6 +455,7 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 
 		// Extract reserved fields
 		desc, quantity := resolveReservedFields(item)
+		unit, expiresAt := resolveStockFields(item)
 		tags := resolveTagsField(item, req.DefaultTags)
 
 		// Extract and coerce properties (all fields except reserved columns)
@@ -397,6 +467,9 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 			}
 		}
 		properties := uc.typeInference.CoerceRow(rawProps, activeSchema)
+		if expiresAt == nil {
+			expiresAt = collection.DefaultExpiry(objectType, properties, tags, time.Now())
+		}
 
 		// Create the object
 		objectName, err := entities.NewObjectName(name)
@@ -411,6 +484,8 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 			Description: entities.NewObjectDescription(desc),
 			ObjectType:  objectType,
 			Quantity:    quantity,
+			Unit:        unit,
+			ExpiresAt:   expiresAt,
 			Properties:  properties,
 			Tags:        tags,
 		})
@@ -441,12 +516,18 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 			slog.String("container_id", container.ID().String()),
 			slog.Int("container_objects", len(container.Objects())))
 
+		added = append(added, newObject)
 		imported++
 
 		// Track assignments
 		containerIDStr := assignment.ContainerID.String()
 		assignments[containerIDStr]++
 	}
+	req.reportProgress(len(plan.Assignments), len(plan.Assignments))
+
+	if err := uc.checkObjectQuota(ctx, collection, added); err != nil {
+		return nil, err
+	}
 
 	// Update all affected containers
 	uc.logger.Debug("AutoDist: updating containers",
@@ -462,6 +543,7 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 			slog.String("container_id", container.ID().String()))
 	}
 	uc.logger.Debug("AutoDist: all containers updated")
+	uc.indexImportedCodes(ctx, req.UserID, added)
 
 	total := imported + failed
 
@@ -486,6 +568,7 @@ func (uc *BulkImportCollectionUseCase) executeAutomaticDistribution(ctx context.
 		CapacityWarnings: capacityWarnings,
 		Assignments:      assignments,
 		InferredSchema:   inferredSchema,
+		SourceFormat:     req.SourceFormat,
 	}, nil
 }
 
@@ -534,6 +617,15 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 		locationToContainer[strings.ToLower(c.Name().String())] = c
 	}
 
+	const defaultContainerName = "Default"
+	defaultKey := strings.ToLower(defaultContainerName)
+
+	// The containers to create count against the group's quota before any is made
+	newContainers := countNewLocations(uniqueLocations, locationToContainer, defaultKey)
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, int64(newContainers)); err != nil {
+		return nil, err
+	}
+
 	// Create containers for new location values
 	containersCreated := 0
 	for loc := range uniqueLocations {
@@ -571,8 +663,6 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 	}
 
 	// Ensure a default container exists for objects with no location
-	const defaultContainerName = "Default"
-	defaultKey := strings.ToLower(defaultContainerName)
 	if _, exists := locationToContainer[defaultKey]; !exists {
 		containerName, _ := entities.NewContainerName(defaultContainerName)
 		defaultContainer, err := entities.NewContainer(entities.ContainerProps{
@@ -592,6 +682,7 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 
 	// Import objects into their containers
 	imported := 0
+	var added []*entities.Object
 	failed := 0
 	var errors []string
 	assignments := make(map[string]int)
@@ -600,7 +691,9 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 
 	objectType := collection.ObjectType()
 
-	for _, item := range req.Data {
+	for i, item := range req.Data {
+		req.reportProgress(i, len(req.Data))
+
 		// Resolve name
 		name, ok := resolveNameField(item, nameCol)
 		if !ok {
@@ -625,6 +718,7 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 
 		// Extract reserved fields
 		desc, quantity := resolveReservedFields(item)
+		unit, expiresAt := resolveStockFields(item)
 		tags := resolveTagsField(item, req.DefaultTags)
 
 		// Build and coerce properties (exclude reserved columns and location column)
@@ -640,6 +734,9 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 			rawProps[nk] = value
 		}
 		properties := uc.typeInference.CoerceRow(rawProps, activeSchema)
+		if expiresAt == nil {
+			expiresAt = collection.DefaultExpiry(objectType, properties, tags, time.Now())
+		}
 
 		objectName, err := entities.NewObjectName(name)
 		if err != nil {
@@ -653,6 +750,8 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 			Description: entities.NewObjectDescription(desc),
 			ObjectType:  objectType,
 			Quantity:    quantity,
+			Unit:        unit,
+			ExpiresAt:   expiresAt,
 			Properties:  properties,
 			Tags:        tags,
 		})
@@ -672,8 +771,14 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 
 		dirtyContainers[container.ID().String()] = container
 		assignments[container.ID().String()]++
+		added = append(added, newObject)
 		imported++
 	}
+	req.reportProgress(len(req.Data), len(req.Data))
+
+	if err := uc.checkObjectQuota(ctx, collection, added); err != nil {
+		return nil, err
+	}
 
 	// Persist all modified containers
 	for _, c := range dirtyContainers {
@@ -681,6 +786,7 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 			return nil, fmt.Errorf("failed to save container %s: %w", c.ID().String(), err)
 		}
 	}
+	uc.indexImportedCodes(ctx, req.UserID, added)
 
 	total := imported + failed
 	return &BulkImportCollectionResponse{
@@ -692,9 +798,31 @@ func (uc *BulkImportCollectionUseCase) executeLocationDistribution(
 		Assignments:       assignments,
 		ContainersCreated: containersCreated,
 		InferredSchema:    inferredSchema,
+		SourceFormat:      req.SourceFormat,
 	}, nil
 }
 
+// countNewLocations returns how many containers a location import creates:
+// one per location with a valid name and no container yet, plus the default
+// container for rows without a location. Keys of existing are lower case.
+func countNewLocations(locations map[string]struct{}, existing map[string]*entities.Container, defaultKey string) int {
+	pending := make(map[string]struct{})
+	for loc := range locations {
+		lowerLoc := strings.ToLower(loc)
+		if _, exists := existing[lowerLoc]; exists {
+			continue
+		}
+		if _, err := entities.NewContainerName(loc); err != nil {
+			continue
+		}
+		pending[lowerLoc] = struct{}{}
+	}
+	if _, exists := existing[defaultKey]; !exists {
+		pending[defaultKey] = struct{}{}
+	}
+	return len(pending)
+}
+
 // resolveReservedFields extracts description and quantity from a data row.
 // These are reserved columns that get stripped from properties but need to be
 // mapped to top-level Object fields.
@@ -767,6 +895,23 @@ func getRowValue(row map[string]any, col string) (any, bool) {
 	return nil, false
 }
 
+// checkObjectQuota reports whether the collection's group has room for the
+// objects the import is about to save.
+func (uc *BulkImportCollectionUseCase) checkObjectQuota(ctx context.Context, collection *entities.Collection, added []*entities.Object) error {
+	if len(added) == 0 {
+		return nil
+	}
+	return checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaObjects, int64(len(added)))
+}
+
+// indexImportedCodes adds the imported objects' codes to the account's code
+// index. The objects are saved by now, so a failure is only logged.
+func (uc *BulkImportCollectionUseCase) indexImportedCodes(ctx context.Context, userID entities.UserID, objects []*entities.Object) {
+	if err := indexObjectCodes(ctx, uc.codeRepo, userID, objects...); err != nil {
+		uc.logger.Warn("Failed to index imported object codes", slog.Any("error", err))
+	}
+}
+
 // searchObjectImage searches for an image for the given object and returns the
 // serving URL. Returns empty string on failure or when image search is disabled.
 func (uc *BulkImportCollectionUseCase) searchObjectImage(ctx context.Context, object *entities.Object) {
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestBulkImportCollectionUseCase_Progress(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	collectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	containerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	codeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	authService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewBulkImportCollectionUseCase(collectionRepo, containerRepo, codeRepo, nil, nil, authService, nil, nil, slog.New(slog.DiscardHandler))
+
+	userID := entities.NewUserID()
+	collection := NewTestCollection(ColUserID(userID))
+	container := NewTestContainer(CtrCollectionID(collection.ID()))
+	containerID := container.ID()
+
+	authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return(nil, nil)
+	collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+	containerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+	containerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+
+	var calls [][2]int
+	resp, err := useCase.Execute(context.Background(), BulkImportCollectionRequest{
+		UserID:            userID,
+		CollectionID:      collection.ID(),
+		TargetContainerID: &containerID,
+		DistributionMode:  "target",
+		Data: []map[string]any{
+			{"name": "Rice"},
+			{"description": "no name"},
+			{"name": "Beans"},
+		},
+		UserToken: "token",
+		Progress: func(done, total int) {
+			calls = append(calls, [2]int{done, total})
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Imported)
+	assert.Equal(t, 1, resp.Failed)
+	assert.Equal(t, [][2]int{{0, 3}, {1, 3}, {2, 3}, {3, 3}}, calls)
+}
+
+func TestBulkImportCollectionUseCase_GroupQuota(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *BulkImportCollectionUseCase
+		collectionRepo *mocks.MockCollectionRepository
+		containerRepo  *mocks.MockContainerRepository
+		quotaRepo      *mocks.MockGroupQuotaRepository
+		usageRepo      *mocks.MockUsageRepository
+		authService    *mocks.MockAuthService
+	}
+
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			quotaRepo:      mocks.NewMockGroupQuotaRepository(mockCtrl),
+			usageRepo:      mocks.NewMockUsageRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewBulkImportCollectionUseCase(f.collectionRepo, f.containerRepo, mocks.NewMockObjectCodeRepository(mockCtrl), f.quotaRepo, f.usageRepo, f.authService, nil, nil, slog.New(slog.DiscardHandler))
+		return f
+	}
+
+	userID := entities.NewUserID()
+	groupID, _ := entities.GroupIDFromString("family")
+
+	t.Run("rows past the object quota save nothing", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		collection := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+		container := NewTestContainer(CtrCollectionID(collection.ID()))
+		containerID := container.ID()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		expectGroupQuota(f.quotaRepo, f.usageRepo, groupID, entities.QuotaObjects, 10, 9)
+
+		_, err := f.useCase.Execute(context.Background(), BulkImportCollectionRequest{
+			UserID:            userID,
+			CollectionID:      collection.ID(),
+			TargetContainerID: &containerID,
+			DistributionMode:  "target",
+			Data:              []map[string]any{{"name": "Rice"}, {"description": "no name"}, {"name": "Beans"}},
+			UserToken:         "token",
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, entities.QuotaObjects, quotaErr.Resource)
+		assert.Equal(t, int64(2), quotaErr.Requested)
+	})
+
+	t.Run("locations past the container quota create no containers", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		collection := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+		shed := NewTestContainer(CtrCollectionID(collection.ID()), CtrName("Shed"))
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.containerRepo.EXPECT().GetByCollectionID(gomock.Any(), collection.ID()).Return([]*entities.Container{shed}, nil)
+		expectGroupQuota(f.quotaRepo, f.usageRepo, groupID, entities.QuotaContainers, 3, 1)
+
+		_, err := f.useCase.Execute(context.Background(), BulkImportCollectionRequest{
+			UserID:           userID,
+			CollectionID:     collection.ID(),
+			DistributionMode: "location",
+			Data: []map[string]any{
+				{"name": "Rake", "location": "shed"},
+				{"name": "Lamp", "location": "Attic"},
+				{"name": "Wine", "location": "Cellar"},
+			},
+			UserToken: "token",
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, entities.QuotaContainers, quotaErr.Resource)
+		// Attic, Cellar and the default container; the shed already exists
+		assert.Equal(t, int64(3), quotaErr.Requested)
+	})
+}
@@ -35,15 +35,21 @@ type BulkImportObjectsResponse struct {
 type BulkImportObjectsUseCase struct {
 	containerRepo      repositories.ContainerRepository
 	collectionRepo     repositories.CollectionRepository
+	codeRepo           repositories.ObjectCodeRepository
+	quotaRepo          repositories.GroupQuotaRepository
+	usageRepo          repositories.UsageRepository
 	authService        services.AuthService
 	imageSearchService services.ImageSearchService
 	logger             *slog.Logger
 }
 
-func NewBulkImportObjectsUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService, imageSearchService services.ImageSearchService, logger *slog.Logger) *BulkImportObjectsUseCase {
+func NewBulkImportObjectsUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, codeRepo repositories.ObjectCodeRepository, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, authService services.AuthService, imageSearchService services.ImageSearchService, logger *slog.Logger) *BulkImportObjectsUseCase {
 	return &BulkImportObjectsUseCase{
 		containerRepo:      containerRepo,
 		collectionRepo:     collectionRepo,
+		codeRepo:           codeRepo,
+		quotaRepo:          quotaRepo,
+		usageRepo:          usageRepo,
 		authService:        authService,
 		imageSearchService: imageSearchService,
 		logger:             logger,
@@ -69,7 +75,7 @@ func (uc *BulkImportObjectsUseCase) Execute(ctx context.Context, req BulkImportO
 	}
 
 	// Check access: user is owner OR user is member of collection's group
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -88,6 +94,7 @@ func (uc *BulkImportObjectsUseCase) Execute(ctx context.Context, req BulkImportO
 	}
 
 	// Process each object
+	var imported []*entities.Object
 	for i, objectData := range req.Objects {
 		// Create object name value object
 		objectName, err := entities.NewObjectName(objectData.Name)
@@ -129,14 +136,21 @@ func (uc *BulkImportObjectsUseCase) Execute(ctx context.Context, req BulkImportO
 			continue
 		}
 
+		imported = append(imported, object)
 		response.Imported++
 	}
 
 	// Save updated container if any objects were imported
 	if response.Imported > 0 {
+		if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaObjects, int64(response.Imported)); err != nil {
+			return nil, err
+		}
 		if err := uc.containerRepo.Update(ctx, container); err != nil {
 			return nil, fmt.Errorf("failed to save container: %w", err)
 		}
+		if err := indexObjectCodes(ctx, uc.codeRepo, req.UserID, imported...); err != nil {
+			uc.logger.Warn("Failed to index imported object codes", slog.Any("error", err))
+		}
 	}
 
 	return response, nil
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestBulkImportObjectsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *BulkImportObjectsUseCase
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		codeRepo       *mocks.MockObjectCodeRepository
+		quotaRepo      *mocks.MockGroupQuotaRepository
+		usageRepo      *mocks.MockUsageRepository
+		authService    *mocks.MockAuthService
+	}
+
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			codeRepo:       mocks.NewMockObjectCodeRepository(mockCtrl),
+			quotaRepo:      mocks.NewMockGroupQuotaRepository(mockCtrl),
+			usageRepo:      mocks.NewMockUsageRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewBulkImportObjectsUseCase(f.containerRepo, f.collectionRepo, f.codeRepo, f.quotaRepo, f.usageRepo, f.authService, nil, slog.New(slog.DiscardHandler))
+		return f
+	}
+
+	userID := entities.NewUserID()
+	rows := []ObjectImportData{
+		{Name: "Flour", ObjectType: entities.ObjectTypeGeneral},
+		{Name: "Sugar", ObjectType: entities.ObjectTypeGeneral},
+	}
+
+	t.Run("imports the rows into the container", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col := NewTestCollection(ColUserID(userID))
+		ctr := NewTestContainer(CtrCollectionID(col.ID()))
+
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), ctr.ID()).Return(ctr, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.containerRepo.EXPECT().Update(gomock.Any(), ctr).Return(nil)
+		f.codeRepo.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		resp, err := f.useCase.Execute(context.Background(), BulkImportObjectsRequest{
+			ContainerID: ctr.ID(),
+			Objects:     rows,
+			UserID:      userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Imported)
+		assert.Len(t, ctr.Objects(), 2)
+	})
+
+	t.Run("rows past the group's object quota import nothing", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		groupID, _ := entities.GroupIDFromString("family")
+		col := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+		ctr := NewTestContainer(CtrCollectionID(col.ID()))
+
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), ctr.ID()).Return(ctr, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		expectGroupQuota(f.quotaRepo, f.usageRepo, groupID, entities.QuotaObjects, 10, 9)
+
+		_, err := f.useCase.Execute(context.Background(), BulkImportObjectsRequest{
+			ContainerID: ctr.ID(),
+			Objects:     rows,
+			UserID:      userID,
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, int64(2), quotaErr.Requested)
+	})
+}
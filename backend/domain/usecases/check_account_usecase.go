@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// accountCheckInterval is how long a user's account status is trusted
+// before it is read, and their last-seen time written, again.
+const accountCheckInterval = time.Minute
+
+type CheckAccountRequest struct {
+	User *entities.User
+}
+
+// CheckAccountUseCase runs on every authenticated request: it records users
+// the first time they are seen and turns away disabled accounts. Results are
+// cached per user for accountCheckInterval so most requests skip the
+// database; Forget drops a user's entry when an admin changes it.
+type CheckAccountUseCase struct {
+	accountStatusRepo repositories.AccountStatusRepository
+
+	mu      sync.Mutex
+	checked map[string]accountCheck
+}
+
+type accountCheck struct {
+	disabled bool
+	at       time.Time
+}
+
+func NewCheckAccountUseCase(accountStatusRepo repositories.AccountStatusRepository) *CheckAccountUseCase {
+	return &CheckAccountUseCase{
+		accountStatusRepo: accountStatusRepo,
+		checked:           make(map[string]accountCheck),
+	}
+}
+
+// Execute returns entities.ErrAccountDisabled for a disabled account. Other
+// errors mean the status could not be read or saved.
+func (uc *CheckAccountUseCase) Execute(ctx context.Context, req CheckAccountRequest) error {
+	userID := req.User.ID().String()
+	now := time.Now()
+
+	uc.mu.Lock()
+	check, ok := uc.checked[userID]
+	uc.mu.Unlock()
+	if ok && now.Sub(check.at) < accountCheckInterval {
+		if check.disabled {
+			return entities.ErrAccountDisabled
+		}
+		return nil
+	}
+
+	status, err := uc.accountStatusRepo.GetByUserID(ctx, req.User.ID())
+	switch {
+	case errors.Is(err, entities.ErrAccountStatusNotFound):
+		status = entities.NewAccountStatus(req.User, now)
+	case err != nil:
+		return fmt.Errorf("failed to get account status: %w", err)
+	default:
+		status.Seen(req.User, now)
+	}
+
+	if err := uc.accountStatusRepo.Save(ctx, status); err != nil {
+		return fmt.Errorf("failed to save account status: %w", err)
+	}
+
+	uc.mu.Lock()
+	uc.checked[userID] = accountCheck{disabled: status.IsDisabled(), at: now}
+	uc.mu.Unlock()
+
+	if status.IsDisabled() {
+		return entities.ErrAccountDisabled
+	}
+	return nil
+}
+
+// Forget drops the cached status of a user so the next request reads it
+// again.
+func (uc *CheckAccountUseCase) Forget(userID entities.UserID) {
+	uc.mu.Lock()
+	delete(uc.checked, userID.String())
+	uc.mu.Unlock()
+}
@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCheckAccountUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		accountStatusRepo *mocks.MockAccountStatusRepository
+		useCase           *CheckAccountUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{accountStatusRepo: mocks.NewMockAccountStatusRepository(mockCtrl)}
+		f.useCase = NewCheckAccountUseCase(f.accountStatusRepo)
+		return f
+	}
+	user := func() *entities.User {
+		username, _ := entities.NewUsername("alice")
+		email, _ := entities.NewEmailAddress("alice@example.com")
+		return entities.ReconstructUser(entities.NewUserID(), username, email, "", time.Now(), time.Now())
+	}
+
+	t.Run("success - records a user seen for the first time", func(t *testing.T) {
+		f := setup(t)
+		u := user()
+
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(nil, entities.ErrAccountStatusNotFound)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, status *entities.AccountStatus) error {
+			assert.Equal(t, u.ID(), status.UserID())
+			assert.Equal(t, "alice", status.Username())
+			assert.Equal(t, "alice@example.com", status.Email())
+			assert.False(t, status.FirstSeenAt().IsZero())
+			return nil
+		})
+
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckAccountRequest{User: u}))
+	})
+
+	t.Run("success - later requests within the interval skip the repository", func(t *testing.T) {
+		f := setup(t)
+		u := user()
+		firstSeen := time.Now().Add(-24 * time.Hour)
+		status := entities.ReconstructAccountStatus(u.ID(), "old-name", "", false, nil, firstSeen, firstSeen)
+
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(status, nil).Times(1)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), status).Return(nil).Times(1)
+
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckAccountRequest{User: u}))
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckAccountRequest{User: u}))
+		assert.Equal(t, "alice", status.Username())
+		assert.Equal(t, firstSeen, status.FirstSeenAt())
+		assert.True(t, status.LastSeenAt().After(firstSeen))
+	})
+
+	t.Run("error - disabled account is refused, also from the cache", func(t *testing.T) {
+		f := setup(t)
+		u := user()
+		disabledAt := time.Now().Add(-time.Hour)
+		status := entities.ReconstructAccountStatus(u.ID(), "alice", "", true, &disabledAt, disabledAt, disabledAt)
+
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(status, nil).Times(1)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), status).Return(nil).Times(1)
+
+		err := f.useCase.Execute(context.Background(), CheckAccountRequest{User: u})
+		assert.ErrorIs(t, err, entities.ErrAccountDisabled)
+		err = f.useCase.Execute(context.Background(), CheckAccountRequest{User: u})
+		assert.ErrorIs(t, err, entities.ErrAccountDisabled)
+	})
+
+	t.Run("success - Forget makes the next request read the status again", func(t *testing.T) {
+		f := setup(t)
+		u := user()
+		status := entities.NewAccountStatus(u, time.Now())
+
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(status, nil).Times(2)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), status).Return(nil).Times(2)
+
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckAccountRequest{User: u}))
+		status.Disable(time.Now())
+		f.useCase.Forget(u.ID())
+		err := f.useCase.Execute(context.Background(), CheckAccountRequest{User: u})
+		assert.ErrorIs(t, err, entities.ErrAccountDisabled)
+	})
+
+	t.Run("error - repository failure is not cached", func(t *testing.T) {
+		f := setup(t)
+		u := user()
+		status := entities.NewAccountStatus(u, time.Now())
+
+		gomock.InOrder(
+			f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(nil, errors.New("connection refused")),
+			f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(status, nil),
+		)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), status).Return(nil)
+
+		err := f.useCase.Execute(context.Background(), CheckAccountRequest{User: u})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, entities.ErrAccountDisabled)
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckAccountRequest{User: u}))
+	})
+}
@@ -0,0 +1,171 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// sessionCheckInterval is how long a session's revoked state is trusted
+// before it is read, and its last-seen time written, again. Another server
+// instance sees a revocation within this interval.
+const sessionCheckInterval = time.Minute
+
+type CheckSessionRequest struct {
+	UserID    entities.UserID
+	SessionID string
+	UserAgent string
+	IPAddress string
+	// ExpiresAt is the expiry of the token the request carried
+	ExpiresAt time.Time
+}
+
+// CheckSessionUseCase runs on every authenticated request next to
+// CheckAccountUseCase: it records each sign-in the first time one of its
+// tokens is used and turns away sessions the user has signed out from
+// another device. Results are cached per session for sessionCheckInterval;
+// Forget drops a session's entry when it is revoked, and entries older than
+// the interval are swept out as new ones are added.
+type CheckSessionUseCase struct {
+	sessionRepo repositories.SessionRepository
+
+	mu      sync.Mutex
+	checked map[string]sessionCheck
+	swept   time.Time
+}
+
+type sessionCheck struct {
+	revoked bool
+	at      time.Time
+}
+
+func NewCheckSessionUseCase(sessionRepo repositories.SessionRepository) *CheckSessionUseCase {
+	return &CheckSessionUseCase{
+		sessionRepo: sessionRepo,
+		checked:     make(map[string]sessionCheck),
+	}
+}
+
+// Execute returns entities.ErrSessionRevoked for a revoked session. Other
+// errors mean the session could not be read or saved; callers let such
+// requests through, so a session store outage does not sign everyone out.
+// A session the cache still holds as revoked stays refused while the store
+// cannot be read.
+func (uc *CheckSessionUseCase) Execute(ctx context.Context, req CheckSessionRequest) error {
+	now := time.Now()
+
+	uc.mu.Lock()
+	check, ok := uc.checked[req.SessionID]
+	uc.mu.Unlock()
+	if ok && now.Sub(check.at) < sessionCheckInterval {
+		if check.revoked {
+			return entities.ErrSessionRevoked
+		}
+		return nil
+	}
+
+	device := describeDevice(req.UserAgent)
+	session, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+	switch {
+	case errors.Is(err, entities.ErrSessionNotFound):
+		session = entities.NewSession(entities.SessionProps{
+			ID:        req.SessionID,
+			UserID:    req.UserID,
+			Device:    device,
+			IPAddress: req.IPAddress,
+			ExpiresAt: req.ExpiresAt,
+		}, now)
+	case err != nil:
+		if ok && check.revoked {
+			return entities.ErrSessionRevoked
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	case session.IsRevoked():
+		uc.remember(req.SessionID, true, now)
+		return entities.ErrSessionRevoked
+	default:
+		session.Seen(device, req.IPAddress, req.ExpiresAt, now)
+	}
+
+	if err := uc.sessionRepo.Save(ctx, session); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	uc.remember(req.SessionID, false, now)
+	return nil
+}
+
+func (uc *CheckSessionUseCase) remember(sessionID string, revoked bool, now time.Time) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.checked[sessionID] = sessionCheck{revoked: revoked, at: now}
+	// Expired entries would be read again anyway; dropping them once an
+	// interval keeps the cache to the sessions in recent use. Revoked ones
+	// go too: the store still says they are revoked.
+	if now.Sub(uc.swept) < sessionCheckInterval {
+		return
+	}
+	for id, check := range uc.checked {
+		if now.Sub(check.at) >= sessionCheckInterval {
+			delete(uc.checked, id)
+		}
+	}
+	uc.swept = now
+}
+
+// Forget drops the cached state of a session so the next request reads it
+// again.
+func (uc *CheckSessionUseCase) Forget(sessionID string) {
+	uc.mu.Lock()
+	delete(uc.checked, sessionID)
+	uc.mu.Unlock()
+}
+
+// describeDevice names the browser and system in a User-Agent header, e.g.
+// "Firefox on Linux". Clients that are not browsers are named by their
+// product token.
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	for _, b := range []struct{ token, name string }{
+		// Order matters: Edge and Opera also claim Chrome, Chrome claims Safari
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	} {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	if browser == "" {
+		product, _, _ := strings.Cut(userAgent, " ")
+		name, _, _ := strings.Cut(product, "/")
+		return name
+	}
+
+	for _, sys := range []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"CrOS", "ChromeOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, sys.token) {
+			return browser + " on " + sys.name
+		}
+	}
+	return browser
+}
@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCheckSessionUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		sessionRepo *mocks.MockSessionRepository
+		useCase     *CheckSessionUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{sessionRepo: mocks.NewMockSessionRepository(mockCtrl)}
+		f.useCase = NewCheckSessionUseCase(f.sessionRepo)
+		return f
+	}
+	const firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+	userID := entities.NewUserID()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("success - records a session seen for the first time", func(t *testing.T) {
+		f := setup(t)
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(nil, entities.ErrSessionNotFound)
+		f.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Session) error {
+			assert.Equal(t, "s1", s.ID())
+			assert.Equal(t, userID, s.UserID())
+			assert.Equal(t, "Firefox on Linux", s.Device())
+			assert.Equal(t, "203.0.113.7", s.IPAddress())
+			assert.Equal(t, expiresAt, s.ExpiresAt())
+			assert.False(t, s.IsTrusted())
+			return nil
+		})
+
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckSessionRequest{
+			UserID: userID, SessionID: "s1", UserAgent: firefox, IPAddress: "203.0.113.7", ExpiresAt: expiresAt,
+		}))
+	})
+
+	t.Run("success - later requests within the interval skip the repository", func(t *testing.T) {
+		f := setup(t)
+		created := time.Now().Add(-24 * time.Hour)
+		session := entities.ReconstructSession("s1", userID, "Chrome on Android", "198.51.100.1", true, created, created, created.Add(time.Hour), nil)
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(session, nil).Times(1)
+		f.sessionRepo.EXPECT().Save(gomock.Any(), session).Return(nil).Times(1)
+
+		req := CheckSessionRequest{UserID: userID, SessionID: "s1", UserAgent: firefox, IPAddress: "203.0.113.7", ExpiresAt: expiresAt}
+		require.NoError(t, f.useCase.Execute(context.Background(), req))
+		require.NoError(t, f.useCase.Execute(context.Background(), req))
+		assert.Equal(t, "Firefox on Linux", session.Device())
+		assert.Equal(t, expiresAt, session.ExpiresAt(), "a refreshed token extends the session")
+		assert.Equal(t, created, session.CreatedAt())
+		assert.True(t, session.IsTrusted())
+	})
+
+	t.Run("error - revoked session is refused, also from the cache", func(t *testing.T) {
+		f := setup(t)
+		revokedAt := time.Now().Add(-time.Hour)
+		session := entities.ReconstructSession("s1", userID, "Safari on iOS", "", false, revokedAt, revokedAt, expiresAt, &revokedAt)
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(session, nil).Times(1)
+
+		req := CheckSessionRequest{UserID: userID, SessionID: "s1", ExpiresAt: expiresAt}
+		assert.ErrorIs(t, f.useCase.Execute(context.Background(), req), entities.ErrSessionRevoked)
+		assert.ErrorIs(t, f.useCase.Execute(context.Background(), req), entities.ErrSessionRevoked)
+	})
+
+	t.Run("success - Forget makes the next request read the session again", func(t *testing.T) {
+		f := setup(t)
+		session := entities.NewSession(entities.SessionProps{ID: "s1", UserID: userID, ExpiresAt: expiresAt}, time.Now())
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(session, nil).Times(2)
+		f.sessionRepo.EXPECT().Save(gomock.Any(), session).Return(nil).Times(1)
+
+		req := CheckSessionRequest{UserID: userID, SessionID: "s1", ExpiresAt: expiresAt}
+		require.NoError(t, f.useCase.Execute(context.Background(), req))
+		session.Revoke(time.Now())
+		f.useCase.Forget("s1")
+		assert.ErrorIs(t, f.useCase.Execute(context.Background(), req), entities.ErrSessionRevoked)
+	})
+
+	t.Run("error - an unreadable store is reported, not taken as revoked", func(t *testing.T) {
+		f := setup(t)
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(nil, errors.New("connection refused"))
+
+		err := f.useCase.Execute(context.Background(), CheckSessionRequest{UserID: userID, SessionID: "s1", ExpiresAt: expiresAt})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, entities.ErrSessionRevoked)
+	})
+
+	t.Run("error - a session known revoked stays refused while the store is down", func(t *testing.T) {
+		f := setup(t)
+		f.useCase.checked["s1"] = sessionCheck{revoked: true, at: time.Now().Add(-2 * sessionCheckInterval)}
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(nil, errors.New("connection refused"))
+
+		err := f.useCase.Execute(context.Background(), CheckSessionRequest{UserID: userID, SessionID: "s1", ExpiresAt: expiresAt})
+		assert.ErrorIs(t, err, entities.ErrSessionRevoked)
+	})
+
+	t.Run("success - entries older than the interval are evicted", func(t *testing.T) {
+		f := setup(t)
+		stale := time.Now().Add(-2 * sessionCheckInterval)
+		for _, id := range []string{"old1", "old2"} {
+			f.useCase.checked[id] = sessionCheck{at: stale}
+		}
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "s1").Return(nil, entities.ErrSessionNotFound)
+		f.sessionRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, f.useCase.Execute(context.Background(), CheckSessionRequest{UserID: userID, SessionID: "s1", ExpiresAt: expiresAt}))
+		assert.Len(t, f.useCase.checked, 1)
+		assert.Contains(t, f.useCase.checked, "s1")
+	})
+}
+
+func TestDescribeDevice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"", "Unknown device"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0", "Firefox on Linux"},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36", "Chrome on Android"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1", "Safari on iOS"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.0.0", "Edge on Windows"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15", "Safari on macOS"},
+		{"Go-http-client/1.1", "Go-http-client"},
+		{"MCP-stdio", "MCP-stdio"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, describeDevice(tt.userAgent), tt.userAgent)
+	}
+}
@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type ClaimObjectRequest struct {
+	ObjectID entities.ObjectID
+	// Claimed reserves the object when true and releases the claim when false.
+	Claimed bool
+	// Until ends the claim; nil lasts entities.DefaultClaimDuration.
+	Until     *time.Time
+	UserID    entities.UserID
+	Username  string
+	UserToken string
+}
+
+type ClaimObjectResponse struct {
+	Object      *entities.Object
+	ContainerID entities.ContainerID
+}
+
+// ClaimObjectUseCase lets members of a collection reserve an object so two
+// people do not plan to use the same one. Anyone with access may claim an
+// unclaimed object or one whose claim expired; only the claimer and the
+// collection owner may release an active claim.
+type ClaimObjectUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewClaimObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *ClaimObjectUseCase {
+	return &ClaimObjectUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *ClaimObjectUseCase) Execute(ctx context.Context, req ClaimObjectRequest) (*ClaimObjectResponse, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, container.CollectionID())
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	existing, err := container.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found in container: %w", err)
+	}
+
+	// The checks below give a clear error early; the repository repeats the
+	// claim check in its write so two members cannot both win a race.
+	now := time.Now()
+	updated := *existing
+	if req.Claimed {
+		if updated.IsArchived() {
+			return nil, errors.New("archived objects cannot be claimed")
+		}
+		claim, err := entities.NewObjectClaim(req.UserID, req.Username, now, req.Until)
+		if err != nil {
+			return nil, err
+		}
+		if err := updated.ClaimFor(claim); err != nil {
+			return nil, err
+		}
+		if err := uc.containerRepo.ClaimObject(ctx, container.ID(), req.ObjectID, claim); err != nil {
+			if errors.Is(err, entities.ErrObjectClaimed) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to save claim: %w", err)
+		}
+	} else {
+		// The collection owner may release any claim, everyone else only
+		// their own or an expired one.
+		var holder *entities.UserID
+		if !collection.IsOwnedBy(req.UserID) {
+			holder = &req.UserID
+		}
+		active := updated.ActiveClaim(now)
+		if holder != nil && active != nil && !active.UserID().Equals(*holder) {
+			return nil, entities.ErrObjectClaimed
+		}
+		updated.Unclaim()
+		if err := uc.containerRepo.ReleaseObjectClaim(ctx, container.ID(), req.ObjectID, holder, now); err != nil {
+			if errors.Is(err, entities.ErrObjectClaimed) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to release claim: %w", err)
+		}
+	}
+
+	return &ClaimObjectResponse{
+		Object:      &updated,
+		ContainerID: container.ID(),
+	}, nil
+}
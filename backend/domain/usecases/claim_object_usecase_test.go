@@ -0,0 +1,241 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestClaimObjectUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewClaimObjectUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+
+	// setup returns a shared collection owned by owner, with member in its
+	// group, holding a single object with the given options.
+	setup := func(owner, member entities.UserID, objOpts ...func(*objectOpts)) (entities.ObjectID, *entities.Container) {
+		collectionID := entities.NewCollectionID()
+		objectID := entities.NewObjectID()
+		group := NewTestGroup()
+		groupID := group.ID()
+
+		obj := NewTestObject(append([]func(*objectOpts){ObjID(objectID)}, objOpts...)...)
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(owner), ColGroupID(&groupID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, userID string) ([]*entities.Group, error) {
+				if userID == member.String() {
+					return []*entities.Group{group}, nil
+				}
+				return []*entities.Group{}, nil
+			})
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		return objectID, container
+	}
+
+	t.Run("success - claim with default duration", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		objectID, container := setup(owner, member)
+		mockContainerRepo.EXPECT().ClaimObject(gomock.Any(), container.ID(), objectID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entities.ContainerID, _ entities.ObjectID, claim entities.ObjectClaim) error {
+				assert.Equal(t, member, claim.UserID())
+				return nil
+			})
+
+		resp, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			UserID:    member,
+			Username:  "alice",
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		claim := resp.Object.ActiveClaim(time.Now())
+		require.NotNil(t, claim)
+		assert.Equal(t, member, claim.UserID())
+		assert.Equal(t, "alice", claim.Username())
+		assert.WithinDuration(t, time.Now().Add(entities.DefaultClaimDuration), claim.Until(), time.Minute)
+	})
+
+	t.Run("success - renew own claim", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		existing := entities.ReconstructObjectClaim(member, "alice", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		objectID, container := setup(owner, member, ObjClaim(existing))
+		mockContainerRepo.EXPECT().ClaimObject(gomock.Any(), container.ID(), objectID, gomock.Any()).Return(nil)
+
+		until := time.Now().Add(72 * time.Hour)
+		resp, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			Until:     &until,
+			UserID:    member,
+			Username:  "alice",
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, until, resp.Object.ActiveClaim(time.Now()).Until(), time.Second)
+	})
+
+	t.Run("success - take over expired claim", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		expired := entities.ReconstructObjectClaim(entities.NewUserID(), "bob", time.Now().Add(-48*time.Hour), time.Now().Add(-time.Hour))
+		objectID, container := setup(owner, member, ObjClaim(expired))
+		mockContainerRepo.EXPECT().ClaimObject(gomock.Any(), container.ID(), objectID, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			UserID:    member,
+			Username:  "alice",
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, member, resp.Object.ActiveClaim(time.Now()).UserID())
+	})
+
+	t.Run("success - owner releases member's claim", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		active := entities.ReconstructObjectClaim(member, "alice", time.Now(), time.Now().Add(time.Hour))
+		objectID, container := setup(owner, member, ObjClaim(active))
+		mockContainerRepo.EXPECT().ReleaseObjectClaim(gomock.Any(), container.ID(), objectID, nil, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   false,
+			UserID:    owner,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Object.Claim())
+	})
+
+	t.Run("error - claimed by another member", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		active := entities.ReconstructObjectClaim(owner, "owner", time.Now(), time.Now().Add(time.Hour))
+		objectID, _ := setup(owner, member, ObjClaim(active))
+
+		_, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			UserID:    member,
+			Username:  "alice",
+			UserToken: "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrObjectClaimed)
+	})
+
+	t.Run("error - claimed concurrently by another member", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		objectID, container := setup(owner, member)
+		mockContainerRepo.EXPECT().ClaimObject(gomock.Any(), container.ID(), objectID, gomock.Any()).Return(entities.ErrObjectClaimed)
+
+		_, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			UserID:    member,
+			Username:  "alice",
+			UserToken: "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrObjectClaimed)
+	})
+
+	t.Run("success - member releases own claim", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		active := entities.ReconstructObjectClaim(member, "alice", time.Now(), time.Now().Add(time.Hour))
+		objectID, container := setup(owner, member, ObjClaim(active))
+		mockContainerRepo.EXPECT().ReleaseObjectClaim(gomock.Any(), container.ID(), objectID, &member, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   false,
+			UserID:    member,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Object.Claim())
+	})
+
+	t.Run("error - member cannot release another member's claim", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		active := entities.ReconstructObjectClaim(owner, "owner", time.Now(), time.Now().Add(time.Hour))
+		objectID, _ := setup(owner, member, ObjClaim(active))
+
+		_, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   false,
+			UserID:    member,
+			UserToken: "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrObjectClaimed)
+	})
+
+	t.Run("error - until in the past", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		objectID, _ := setup(owner, member)
+
+		until := time.Now().Add(-time.Minute)
+		_, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			Until:     &until,
+			UserID:    member,
+			UserToken: "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrInvalidClaimExpiry)
+	})
+
+	t.Run("error - archived object", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		objectID, _ := setup(owner, member, ObjArchivedAt(time.Now().Add(-time.Hour)))
+
+		_, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			UserID:    member,
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be claimed")
+	})
+
+	t.Run("error - access denied", func(t *testing.T) {
+		owner, member := entities.NewUserID(), entities.NewUserID()
+		objectID, _ := setup(owner, member)
+
+		_, err := useCase.Execute(context.Background(), ClaimObjectRequest{
+			ObjectID:  objectID,
+			Claimed:   true,
+			UserID:    entities.NewUserID(),
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
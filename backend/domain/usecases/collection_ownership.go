@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// canManageCollection reports whether userID may perform owner-only actions
+// (rename, schema changes, snapshots) on the collection: its personal owner,
+// or any member of the owning group when the collection is group-owned.
+// Deleting and transferring take canControlCollection.
+func canManageCollection(ctx context.Context, authService services.AuthService, collection *entities.Collection, userID entities.UserID, userToken string) (bool, error) {
+	if collection.IsOwnedBy(userID) {
+		return true, nil
+	}
+	if !collection.IsGroupOwned() {
+		return false, nil
+	}
+	return isGroupMember(ctx, authService, *collection.GroupID(), userID, userToken)
+}
+
+// canControlCollection reports whether userID may delete the collection or
+// give it away: its personal owner, or an admin of the owning group when the
+// collection is group-owned. Plain members cannot, so no single member can
+// take a shared collection out of the group.
+func canControlCollection(ctx context.Context, authService services.AuthService, collection *entities.Collection, userID entities.UserID, userToken string) (bool, error) {
+	if collection.IsOwnedBy(userID) {
+		return true, nil
+	}
+	if !collection.IsGroupOwned() {
+		return false, nil
+	}
+	groupID := *collection.GroupID()
+	isMember, err := isGroupMember(ctx, authService, groupID, userID, userToken)
+	if err != nil || !isMember {
+		return false, err
+	}
+	roles, err := authService.GetGroupMemberRoles(ctx, userToken, groupID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to get member roles: %w", err)
+	}
+	return roles[userID.String()] == entities.GroupRoleAdmin, nil
+}
+
+func isGroupMember(ctx context.Context, authService services.AuthService, groupID entities.GroupID, userID entities.UserID, userToken string) (bool, error) {
+	userGroups, err := authService.GetUserGroups(ctx, userToken, userID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	for _, group := range userGroups {
+		if group.ID().Equals(groupID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getManagedCollection loads a collection with its containers and checks
+// that userID may perform owner-only actions on it.
+func getManagedCollection(ctx context.Context, collectionRepo repositories.CollectionRepository, authService services.AuthService, collectionID entities.CollectionID, userID entities.UserID, userToken string) (*entities.Collection, error) {
+	collection, err := collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, errors.New("collection not found")
+	}
+
+	canManage, err := canManageCollection(ctx, authService, collection, userID, userToken)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("access denied: only collection owner can manage snapshots")
+	}
+	return collection, nil
+}
+
+// getAccessibleCollection loads a collection and checks that userID owns it
+// or belongs to the group it is shared with.
+func getAccessibleCollection(ctx context.Context, collectionRepo repositories.CollectionRepository, authService services.AuthService, collectionID entities.CollectionID, userID entities.UserID, userToken string) (*entities.Collection, error) {
+	collection, err := collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, errors.New("collection not found")
+	}
+	if collection.IsOwnedBy(userID) {
+		return collection, nil
+	}
+	if collection.GroupID() != nil {
+		member, err := isGroupMember(ctx, authService, *collection.GroupID(), userID, userToken)
+		if err != nil {
+			return nil, err
+		}
+		if member {
+			return collection, nil
+		}
+	}
+	return nil, errors.New("access denied: user does not have access to this collection")
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CompleteMealPlanRequest struct {
+	MealPlanID entities.MealPlanID
+	UserID     entities.UserID
+	UserToken  string
+}
+
+// SkippedMealIngredient is an ingredient whose object could not be adjusted,
+// for example because it was deleted or its unit no longer converts.
+type SkippedMealIngredient struct {
+	Ingredient entities.MealIngredient
+	Reason     string
+}
+
+type CompleteMealPlanResponse struct {
+	MealPlan *entities.MealPlan
+	Adjusted []*AdjustObjectQuantityResponse
+	Skipped  []SkippedMealIngredient
+}
+
+type CompleteMealPlanUseCase struct {
+	mealPlanRepo repositories.MealPlanRepository
+	adjustUC     *AdjustObjectQuantityUseCase
+}
+
+func NewCompleteMealPlanUseCase(
+	mealPlanRepo repositories.MealPlanRepository,
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	authService services.AuthService,
+	events services.EventPublisher,
+) *CompleteMealPlanUseCase {
+	return &CompleteMealPlanUseCase{
+		mealPlanRepo: mealPlanRepo,
+		adjustUC:     NewAdjustObjectQuantityUseCase(collectionRepo, containerRepo, authService, events),
+	}
+}
+
+// Execute marks the meal as cooked and uses up each linked ingredient through
+// the quantity adjustment, converting to the object's unit. Ingredients that
+// cannot be adjusted are reported instead of failing the whole meal.
+func (uc *CompleteMealPlanUseCase) Execute(ctx context.Context, req CompleteMealPlanRequest) (*CompleteMealPlanResponse, error) {
+	plan, err := uc.mealPlanRepo.GetByID(ctx, req.MealPlanID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrMealPlanNotFound
+	}
+
+	// Save the completion before touching quantities so a retry after a
+	// partial failure cannot use the ingredients up twice
+	if err := plan.MarkCompleted(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.mealPlanRepo.Update(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to save meal plan: %w", err)
+	}
+
+	resp := &CompleteMealPlanResponse{MealPlan: plan}
+	for _, ing := range plan.Ingredients() {
+		objectID := ing.ObjectID
+		delta := -ing.Quantity
+		adjusted, err := uc.adjustUC.Execute(ctx, AdjustObjectQuantityRequest{
+			ObjectID:  &objectID,
+			Delta:     &delta,
+			Unit:      ing.Unit,
+			UserID:    req.UserID,
+			UserToken: req.UserToken,
+		})
+		if err != nil {
+			resp.Skipped = append(resp.Skipped, SkippedMealIngredient{Ingredient: ing, Reason: err.Error()})
+			continue
+		}
+		resp.Adjusted = append(resp.Adjusted, adjusted)
+	}
+
+	return resp, nil
+}
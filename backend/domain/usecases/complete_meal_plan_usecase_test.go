@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCompleteMealPlanUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockMealPlanRepo := mocks.NewMockMealPlanRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewCompleteMealPlanUseCase(mockMealPlanRepo, mockCollectionRepo, mockContainerRepo, mockAuthService, nil)
+
+	newPlan := func(userID entities.UserID, ingredients ...entities.MealIngredient) *entities.MealPlan {
+		return entities.ReconstructMealPlan(entities.NewMealPlanID(), userID, entities.MealPlanDay(time.Now()),
+			entities.MealTypeDinner, "Omelette", ingredients, nil, time.Now(), time.Now())
+	}
+
+	t.Run("success - uses up ingredients and reports missing objects", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		eggs := NewTestObject(ObjName("Eggs"), ObjQuantity(6))
+		butter := NewTestObject(ObjName("Butter"), ObjQuantity(0.25), ObjUnit("kg"))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*eggs, *butter))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		gone := entities.NewObjectID()
+
+		plan := newPlan(userID,
+			entities.MealIngredient{ObjectID: eggs.ID(), Name: "Eggs", Quantity: 3},
+			entities.MealIngredient{ObjectID: butter.ID(), Name: "Butter", Quantity: 20, Unit: "g"},
+			entities.MealIngredient{ObjectID: gone, Name: "Chives", Quantity: 1},
+		)
+
+		mockMealPlanRepo.EXPECT().GetByID(gomock.Any(), plan.ID()).Return(plan, nil)
+		mockMealPlanRepo.EXPECT().Update(gomock.Any(), plan).DoAndReturn(func(_ context.Context, p *entities.MealPlan) error {
+			assert.True(t, p.IsCompleted(), "completion is saved before quantities change")
+			return nil
+		})
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil).Times(3)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), eggs.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), butter.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), gone).Return(nil, errors.New("object not found"))
+		mockCollectionRepo.EXPECT().GetByIDSummary(gomock.Any(), collectionID).Return(collection, nil).Times(2)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(nil).Times(2)
+
+		resp, err := useCase.Execute(context.Background(), CompleteMealPlanRequest{
+			MealPlanID: plan.ID(),
+			UserID:     userID,
+			UserToken:  "test-token",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Adjusted, 2)
+		assert.InDelta(t, 3, *resp.Adjusted[0].Object.Quantity(), 0.001)
+		assert.InDelta(t, 0.23, *resp.Adjusted[1].Object.Quantity(), 0.001, "grams are converted to the object's kg")
+		require.Len(t, resp.Skipped, 1)
+		assert.Equal(t, "Chives", resp.Skipped[0].Ingredient.Name)
+		assert.NotNil(t, resp.MealPlan.CompletedAt())
+	})
+
+	t.Run("error - already completed", func(t *testing.T) {
+		userID := entities.NewUserID()
+		plan := newPlan(userID)
+		require.NoError(t, plan.MarkCompleted(time.Now()))
+
+		mockMealPlanRepo.EXPECT().GetByID(gomock.Any(), plan.ID()).Return(plan, nil)
+
+		resp, err := useCase.Execute(context.Background(), CompleteMealPlanRequest{
+			MealPlanID: plan.ID(),
+			UserID:     userID,
+			UserToken:  "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrMealPlanCompleted)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - another user's plan is not found", func(t *testing.T) {
+		plan := newPlan(entities.NewUserID())
+
+		mockMealPlanRepo.EXPECT().GetByID(gomock.Any(), plan.ID()).Return(plan, nil)
+
+		resp, err := useCase.Execute(context.Background(), CompleteMealPlanRequest{
+			MealPlanID: plan.ID(),
+			UserID:     entities.NewUserID(),
+			UserToken:  "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrMealPlanNotFound)
+		assert.Nil(t, resp)
+	})
+}
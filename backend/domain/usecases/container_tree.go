@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// Limits on an imported container tree so one document cannot create an
+// unbounded number of containers.
+const (
+	MaxContainerTreeNodes = 1000
+	MaxContainerTreeDepth = 12
+)
+
+var ErrContainerTreeTooLarge = fmt.Errorf("container tree may contain at most %d containers nested %d deep", MaxContainerTreeNodes, MaxContainerTreeDepth)
+
+// containerTreeHeader explains the format at the top of every export so the
+// file can be edited without reading the docs.
+const containerTreeHeader = `# Container layout. Objects are not included.
+#
+# Edit this file and import it again to change the layout:
+#   - rename a container by changing its name
+#   - move a container by moving it under another one (keep its id)
+#   - add a container by writing a new entry without an id
+# Containers removed from this file are left as they are, never deleted.
+# Types: room, bookshelf, shelf, binder, cabinet, general (the default).
+# Only rooms, bookshelves and general containers can hold other containers.
+`
+
+// containerTreeDocument is the YAML form of a collection's container tree.
+type containerTreeDocument struct {
+	Collection string              `yaml:"collection,omitempty"`
+	Containers []containerTreeNode `yaml:"containers"`
+}
+
+type containerTreeNode struct {
+	ID       string              `yaml:"id,omitempty"`
+	Name     string              `yaml:"name"`
+	Type     string              `yaml:"type,omitempty"`
+	Location string              `yaml:"location,omitempty"`
+	Width    *float64            `yaml:"width,omitempty"`
+	Depth    *float64            `yaml:"depth,omitempty"`
+	Rows     *int                `yaml:"rows,omitempty"`
+	Capacity *float64            `yaml:"capacity,omitempty"`
+	Children []containerTreeNode `yaml:"children,omitempty"`
+}
+
+// buildContainerTree nests containers under their parents, keeping the
+// collection's order. Containers whose parent is missing are placed at the
+// root so nothing is lost from the export.
+func buildContainerTree(containers []entities.Container) []containerTreeNode {
+	known := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		known[c.ID().String()] = true
+	}
+
+	children := make(map[string][]entities.Container)
+	var roots []entities.Container
+	for _, c := range containers {
+		parent := c.ParentContainerID()
+		if parent == nil || !known[parent.String()] || parent.Equals(c.ID()) {
+			roots = append(roots, c)
+			continue
+		}
+		children[parent.String()] = append(children[parent.String()], c)
+	}
+
+	var build func(level []entities.Container) []containerTreeNode
+	build = func(level []entities.Container) []containerTreeNode {
+		nodes := make([]containerTreeNode, 0, len(level))
+		for _, c := range level {
+			node := containerTreeNode{
+				ID:       c.ID().String(),
+				Name:     c.Name().String(),
+				Location: c.Location(),
+				Width:    c.Width(),
+				Depth:    c.Depth(),
+				Rows:     c.Rows(),
+				Capacity: c.Capacity(),
+			}
+			if c.ContainerType() != entities.ContainerTypeGeneral {
+				node.Type = string(c.ContainerType())
+			}
+			node.Children = build(children[c.ID().String()])
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(roots)
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CopyObjectRequest struct {
+	ObjectID    entities.ObjectID
+	ContainerID *entities.ContainerID // nil = next to the original
+	UserID      entities.UserID
+	UserToken   string
+}
+
+type CopyObjectResponse struct {
+	Object         *entities.Object
+	ContainerID    entities.ContainerID
+	StorageWarning *entities.StorageWarning // nil unless the container is too warm for the object
+	// DroppedProperties are the keys left out of a copy into a collection
+	// whose schema has no place for them
+	DroppedProperties []string
+}
+
+// CopyObjectUseCase duplicates an object under a new ID, into any container
+// of a collection of the same object type the user can access.
+type CopyObjectUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	codeRepo       repositories.ObjectCodeRepository
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	authService    services.AuthService
+	typeInference  *services.TypeInferenceService
+}
+
+func NewCopyObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, codeRepo repositories.ObjectCodeRepository, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, authService services.AuthService) *CopyObjectUseCase {
+	return &CopyObjectUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		codeRepo:       codeRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		authService:    authService,
+		typeInference:  services.NewTypeInferenceService(nil),
+	}
+}
+
+// Execute copies everything but the object's ID, timestamps, archived state
+// and claim. Properties are carried over to the target collection's schema
+// like a move does.
+func (uc *CopyObjectUseCase) Execute(ctx context.Context, req CopyObjectRequest) (*CopyObjectResponse, error) {
+	sourceContainer, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+	sourceCollection, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, sourceContainer.CollectionID(), req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	original, err := sourceContainer.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found in container: %w", err)
+	}
+
+	targetContainer, targetCollection := sourceContainer, sourceCollection
+	if req.ContainerID != nil && !req.ContainerID.Equals(sourceContainer.ID()) {
+		targetContainer, err = uc.containerRepo.GetByID(ctx, *req.ContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("target container not found: %w", err)
+		}
+		if !targetContainer.CollectionID().Equals(sourceCollection.ID()) {
+			targetCollection, err = getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, targetContainer.CollectionID(), req.UserID, req.UserToken)
+			if err != nil {
+				return nil, err
+			}
+			if targetCollection.ObjectType() != sourceCollection.ObjectType() {
+				return nil, entities.ErrObjectTypeMismatch
+			}
+		}
+	}
+
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, targetCollection, entities.QuotaObjects, 1); err != nil {
+		return nil, err
+	}
+
+	props := original.Properties()
+	var dropped []string
+	if targetCollection != sourceCollection {
+		props, dropped = uc.typeInference.RemapProperties(props,
+			sourceCollection.PropertySchema().ForObjectType(original.ObjectType()),
+			targetCollection.PropertySchema().ForObjectType(original.ObjectType()))
+	}
+
+	copied, err := entities.NewObject(entities.ObjectProps{
+		Name:        original.Name(),
+		Description: original.Description(),
+		ObjectType:  original.ObjectType(),
+		Location:    original.Location(),
+		Quantity:    original.Quantity(),
+		Unit:        original.Unit(),
+		MinQuantity: original.MinQuantity(),
+		Condition:   original.Condition(),
+		Status:      original.Status(),
+		Properties:  props,
+		Tags:        original.Tags(),
+		ImageURL:    original.ImageURL(),
+		ExpiresAt:   original.ExpiresAt(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object entity: %w", err)
+	}
+
+	if err := uc.containerRepo.AddObject(ctx, targetContainer.ID(), *copied); err != nil {
+		return nil, fmt.Errorf("failed to add object to container: %w", err)
+	}
+
+	// Best effort, as in CreateObjectUseCase
+	_ = indexObjectCodes(ctx, uc.codeRepo, req.UserID, copied)
+
+	return &CopyObjectResponse{
+		Object:            copied,
+		ContainerID:       targetContainer.ID(),
+		StorageWarning:    storageWarning(targetContainer, *copied, targetCollection.ObjectType()),
+		DroppedProperties: dropped,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCopyObjectUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	mockQuotaRepo := mocks.NewMockGroupQuotaRepository(mockCtrl)
+	mockUsageRepo := mocks.NewMockUsageRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewCopyObjectUseCase(mockContainerRepo, mockCollectionRepo, mockCodeRepo, mockQuotaRepo, mockUsageRepo, mockAuthService)
+
+	t.Run("success - copy next to the original", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjName("Tent"), ObjTags("camping"), ObjProps(Props("brand", "Acme")))
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CopyObjectRequest{
+			ObjectID:  objectID,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, objectID, resp.Object.ID())
+		assert.Equal(t, containerID, resp.ContainerID)
+		assert.Equal(t, "Tent", resp.Object.Name().String())
+		assert.Equal(t, []string{"camping"}, resp.Object.Tags())
+		assert.Equal(t, Props("brand", "Acme"), resp.Object.Properties())
+		assert.Empty(t, resp.DroppedProperties)
+	})
+
+	t.Run("success - copy into another collection remaps properties", func(t *testing.T) {
+		userID := entities.NewUserID()
+		fromCollectionID := entities.NewCollectionID()
+		toCollectionID := entities.NewCollectionID()
+		toID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjType(entities.ObjectTypeBook), ObjProps(Props("author", "Le Guin", "shelf", "Top")))
+		from := NewTestContainer(CtrCollectionID(fromCollectionID), CtrObjects(*obj))
+		to := NewTestContainer(CtrID(toID), CtrCollectionID(toCollectionID))
+		fromCollection := NewTestCollection(ColID(fromCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook))
+		toCollection := NewTestCollection(ColID(toCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook), ColSchema(&entities.PropertySchema{
+			Definitions: []entities.PropertyDefinition{{Key: "author", DisplayName: "Author", Type: entities.PropertyTypeText}},
+		}))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(from, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), fromCollectionID).Return(fromCollection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), toID).Return(to, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), toCollectionID).Return(toCollection, nil)
+		mockContainerRepo.EXPECT().AddObject(gomock.Any(), toID, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CopyObjectRequest{
+			ObjectID:    objectID,
+			ContainerID: &toID,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, toID, resp.ContainerID)
+		assert.Equal(t, Props("author", "Le Guin"), resp.Object.Properties())
+		assert.Equal(t, []string{"shelf"}, resp.DroppedProperties)
+		// The original is left alone
+		original, err := from.GetObject(objectID)
+		require.NoError(t, err)
+		assert.Len(t, original.Properties(), 2)
+	})
+
+	t.Run("error - copy into a collection of another object type", func(t *testing.T) {
+		userID := entities.NewUserID()
+		fromCollectionID := entities.NewCollectionID()
+		toCollectionID := entities.NewCollectionID()
+		toID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjType(entities.ObjectTypeBook))
+		from := NewTestContainer(CtrCollectionID(fromCollectionID), CtrObjects(*obj))
+		to := NewTestContainer(CtrID(toID), CtrCollectionID(toCollectionID))
+		fromCollection := NewTestCollection(ColID(fromCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook))
+		toCollection := NewTestCollection(ColID(toCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeFood))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(from, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), fromCollectionID).Return(fromCollection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), toID).Return(to, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), toCollectionID).Return(toCollection, nil)
+
+		resp, err := useCase.Execute(context.Background(), CopyObjectRequest{
+			ObjectID:    objectID,
+			ContainerID: &toID,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		assert.ErrorIs(t, err, entities.ErrObjectTypeMismatch)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - group's object quota reached", func(t *testing.T) {
+		userID := entities.NewUserID()
+		groupID, _ := entities.GroupIDFromString("family")
+		collectionID := entities.NewCollectionID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColGroupID(&groupID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		expectGroupQuota(mockQuotaRepo, mockUsageRepo, groupID, entities.QuotaObjects, 5, 5)
+
+		resp, err := useCase.Execute(context.Background(), CopyObjectRequest{
+			ObjectID:  objectID,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, int64(1), quotaErr.Requested)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - object not found", func(t *testing.T) {
+		objectID := entities.NewObjectID()
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(nil, errors.New("container not found"))
+
+		resp, err := useCase.Execute(context.Background(), CopyObjectRequest{
+			ObjectID:  objectID,
+			UserID:    entities.NewUserID(),
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "object not found")
+		assert.Nil(t, resp)
+	})
+}
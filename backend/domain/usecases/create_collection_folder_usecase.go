@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type CreateCollectionFolderRequest struct {
+	Name string
+	// ParentID nests the new folder in one of the user's folders; nil puts it
+	// at the top level.
+	ParentID *entities.CollectionFolderID
+	UserID   entities.UserID
+}
+
+type CreateCollectionFolderResponse struct {
+	Folder *entities.CollectionFolder
+}
+
+type CreateCollectionFolderUseCase struct {
+	folderRepo repositories.CollectionFolderRepository
+}
+
+func NewCreateCollectionFolderUseCase(folderRepo repositories.CollectionFolderRepository) *CreateCollectionFolderUseCase {
+	return &CreateCollectionFolderUseCase{
+		folderRepo: folderRepo,
+	}
+}
+
+func (uc *CreateCollectionFolderUseCase) Execute(ctx context.Context, req CreateCollectionFolderRequest) (*CreateCollectionFolderResponse, error) {
+	folder, err := entities.NewCollectionFolder(req.UserID, req.Name, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil {
+		folders, err := uc.folderRepo.ListByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collection folders: %w", err)
+		}
+		if err := entities.ValidateFolderParent(folders, folder.ID(), req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.folderRepo.Create(ctx, folder); err != nil {
+		return nil, fmt.Errorf("failed to create collection folder: %w", err)
+	}
+
+	return &CreateCollectionFolderResponse{
+		Folder: folder,
+	}, nil
+}
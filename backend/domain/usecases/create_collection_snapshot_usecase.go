@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CreateCollectionSnapshotRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+	Label        string
+}
+
+type CreateCollectionSnapshotResponse struct {
+	Snapshot *entities.CollectionSnapshot
+	// Pruned is how many old snapshots were dropped to stay within the
+	// per-collection limit.
+	Pruned int
+}
+
+type CreateCollectionSnapshotUseCase struct {
+	collectionRepo   repositories.CollectionRepository
+	snapshotRepo     repositories.CollectionSnapshotRepository
+	authService      services.AuthService
+	maxPerCollection int
+}
+
+// NewCreateCollectionSnapshotUseCase keeps at most maxPerCollection snapshots
+// per collection; 0 keeps them all.
+func NewCreateCollectionSnapshotUseCase(collectionRepo repositories.CollectionRepository, snapshotRepo repositories.CollectionSnapshotRepository, authService services.AuthService, maxPerCollection int) *CreateCollectionSnapshotUseCase {
+	return &CreateCollectionSnapshotUseCase{
+		collectionRepo:   collectionRepo,
+		snapshotRepo:     snapshotRepo,
+		authService:      authService,
+		maxPerCollection: maxPerCollection,
+	}
+}
+
+func (uc *CreateCollectionSnapshotUseCase) Execute(ctx context.Context, req CreateCollectionSnapshotRequest) (*CreateCollectionSnapshotResponse, error) {
+	collection, err := getManagedCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := entities.NewCollectionSnapshot(collection, req.UserID, req.Label)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.snapshotRepo.Create(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	pruned, err := pruneCollectionSnapshots(ctx, uc.snapshotRepo, req.CollectionID, uc.maxPerCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateCollectionSnapshotResponse{Snapshot: snapshot, Pruned: pruned}, nil
+}
+
+// pruneCollectionSnapshots deletes the oldest snapshots beyond limit, except
+// those listed in keep.
+func pruneCollectionSnapshots(ctx context.Context, snapshotRepo repositories.CollectionSnapshotRepository, collectionID entities.CollectionID, limit int, keep ...entities.CollectionSnapshotID) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	snapshots, err := snapshotRepo.ListByCollectionID(ctx, collectionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	if len(snapshots) <= limit {
+		return 0, nil
+	}
+
+	// Listed newest first, so walk back from the oldest
+	pruned := 0
+	for i := len(snapshots) - 1; i >= 0 && len(snapshots)-pruned > limit; i-- {
+		if slices.ContainsFunc(keep, snapshots[i].ID().Equals) {
+			continue
+		}
+		if err := snapshotRepo.Delete(ctx, snapshots[i].ID()); err != nil {
+			return pruned, fmt.Errorf("failed to prune snapshot: %w", err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func newStoredSnapshot(collectionID entities.CollectionID, createdAt time.Time, containers ...entities.Container) *entities.CollectionSnapshot {
+	return entities.ReconstructCollectionSnapshot(
+		entities.NewCollectionSnapshotID(),
+		collectionID,
+		"Pantry",
+		entities.NewUserID(),
+		"",
+		containers,
+		createdAt,
+	)
+}
+
+func TestCreateCollectionSnapshotUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockSnapshotRepo := mocks.NewMockCollectionSnapshotRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	t.Run("success - captures containers and objects", func(t *testing.T) {
+		useCase := NewCreateCollectionSnapshotUseCase(mockCollectionRepo, mockSnapshotRepo, mockAuthService, 0)
+
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		ctr := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*NewTestObject(ObjName("Rice")), *NewTestObject(ObjName("Beans"))))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(*ctr))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			UserID:       userID,
+			Label:        "  Spring clean  ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Spring clean", resp.Snapshot.Label())
+		assert.Equal(t, 1, resp.Snapshot.ContainerCount())
+		assert.Equal(t, 2, resp.Snapshot.ObjectCount())
+		assert.Equal(t, 0, resp.Pruned)
+	})
+
+	t.Run("success - prunes oldest beyond limit", func(t *testing.T) {
+		useCase := NewCreateCollectionSnapshotUseCase(mockCollectionRepo, mockSnapshotRepo, mockAuthService, 2)
+
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		now := time.Now()
+		newest := newStoredSnapshot(collectionID, now)
+		middle := newStoredSnapshot(collectionID, now.Add(-time.Hour))
+		oldest := newStoredSnapshot(collectionID, now.Add(-2*time.Hour))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockSnapshotRepo.EXPECT().ListByCollectionID(gomock.Any(), collectionID).Return([]*entities.CollectionSnapshot{newest, middle, oldest}, nil)
+		mockSnapshotRepo.EXPECT().Delete(gomock.Any(), oldest.ID()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCollectionSnapshotRequest{CollectionID: collectionID, UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Pruned)
+	})
+
+	t.Run("error - label too long", func(t *testing.T) {
+		useCase := NewCreateCollectionSnapshotUseCase(mockCollectionRepo, mockSnapshotRepo, mockAuthService, 0)
+
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			UserID:       userID,
+			Label:        strings.Repeat("x", 101),
+		})
+
+		require.ErrorIs(t, err, entities.ErrInvalidSnapshotLabel)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - not the owner", func(t *testing.T) {
+		useCase := NewCreateCollectionSnapshotUseCase(mockCollectionRepo, mockSnapshotRepo, mockAuthService, 0)
+
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCollectionSnapshotRequest{CollectionID: collectionID, UserID: entities.NewUserID()})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
+
+func TestPruneCollectionSnapshots_KeepsListedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSnapshotRepo := mocks.NewMockCollectionSnapshotRepository(mockCtrl)
+
+	collectionID := entities.NewCollectionID()
+	now := time.Now()
+	newest := newStoredSnapshot(collectionID, now)
+	middle := newStoredSnapshot(collectionID, now.Add(-time.Hour))
+	oldest := newStoredSnapshot(collectionID, now.Add(-2*time.Hour))
+
+	mockSnapshotRepo.EXPECT().ListByCollectionID(gomock.Any(), collectionID).Return([]*entities.CollectionSnapshot{newest, middle, oldest}, nil)
+	mockSnapshotRepo.EXPECT().Delete(gomock.Any(), middle.ID()).Return(nil)
+
+	pruned, err := pruneCollectionSnapshots(context.Background(), mockSnapshotRepo, collectionID, 2, oldest.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+}
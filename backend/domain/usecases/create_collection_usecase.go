@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/repositories"
@@ -13,6 +14,7 @@ import (
 type CreateCollectionRequest struct {
 	UserID         entities.UserID
 	GroupID        *entities.GroupID
+	GroupOwned     bool
 	Name           string
 	ObjectType     entities.ObjectType
 	Tags           []string
@@ -27,16 +29,34 @@ type CreateCollectionResponse struct {
 
 type CreateCollectionUseCase struct {
 	collectionRepo repositories.CollectionRepository
+	typeRepo       repositories.CustomObjectTypeRepository
 	authService    services.AuthService
 }
 
-func NewCreateCollectionUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *CreateCollectionUseCase {
+func NewCreateCollectionUseCase(collectionRepo repositories.CollectionRepository, typeRepo repositories.CustomObjectTypeRepository, authService services.AuthService) *CreateCollectionUseCase {
 	return &CreateCollectionUseCase{
 		collectionRepo: collectionRepo,
+		typeRepo:       typeRepo,
 		authService:    authService,
 	}
 }
 
+// resolveObjectType checks that ot is a built-in type or one of the user's
+// custom types, returning the custom type's definition or nil for a built-in.
+func resolveObjectType(ctx context.Context, typeRepo repositories.CustomObjectTypeRepository, userID entities.UserID, ot entities.ObjectType) (*entities.CustomObjectType, error) {
+	if ot.IsBuiltIn() {
+		return nil, nil
+	}
+	objectType, err := typeRepo.GetByKey(ctx, userID, ot)
+	if errors.Is(err, entities.ErrCustomObjectTypeNotFound) {
+		return nil, fmt.Errorf("%w: %s", entities.ErrUnknownObjectType, ot)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object type: %w", err)
+	}
+	return objectType, nil
+}
+
 func (uc *CreateCollectionUseCase) Execute(ctx context.Context, req CreateCollectionRequest) (*CreateCollectionResponse, error) {
 	// If GroupID is provided, verify user is member of the group
 	if req.GroupID != nil {
@@ -64,15 +84,28 @@ func (uc *CreateCollectionUseCase) Execute(ctx context.Context, req CreateCollec
 		return nil, fmt.Errorf("invalid collection name: %w", err)
 	}
 
+	customType, err := resolveObjectType(ctx, uc.typeRepo, req.UserID, req.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+	// A custom type's fields are the starting schema unless one is given
+	propertySchema := req.PropertySchema
+	if propertySchema == nil && customType != nil && customType.Schema() != nil {
+		schema := *customType.Schema()
+		schema.Definitions = slices.Clone(schema.Definitions)
+		propertySchema = &schema
+	}
+
 	// Create new collection
 	collection, err := entities.NewCollection(entities.CollectionProps{
 		UserID:         req.UserID,
 		GroupID:        req.GroupID,
+		GroupOwned:     req.GroupOwned,
 		Name:           collectionName,
 		ObjectType:     req.ObjectType,
 		Tags:           req.Tags,
 		Location:       req.Location,
-		PropertySchema: req.PropertySchema,
+		PropertySchema: propertySchema,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create collection entity: %w", err)
@@ -23,7 +23,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 		mockAuthService := mocks.NewMockAuthService(mockCtrl)
-		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 		userID := entities.NewUserID()
 		req := CreateCollectionRequest{
@@ -49,7 +49,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 		mockAuthService := mocks.NewMockAuthService(mockCtrl)
-		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 		userID := entities.NewUserID()
 		groupID := entities.NewGroupID()
@@ -79,7 +79,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 		mockAuthService := mocks.NewMockAuthService(mockCtrl)
-		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 		resp, err := useCase.Execute(context.Background(), CreateCollectionRequest{
 			UserID: entities.NewUserID(), Name: "", ObjectType: entities.ObjectTypeGeneral, UserToken: "test-token",
@@ -96,7 +96,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 		mockAuthService := mocks.NewMockAuthService(mockCtrl)
-		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 		userID := entities.NewUserID()
 		groupID, _ := entities.GroupIDFromString("group-123")
@@ -122,7 +122,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 		mockAuthService := mocks.NewMockAuthService(mockCtrl)
-		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 		userID := entities.NewUserID()
 		groupID := entities.NewGroupID()
@@ -145,7 +145,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 		mockAuthService := mocks.NewMockAuthService(mockCtrl)
-		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 		mockCollectionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("database connection failed"))
 
@@ -159,6 +159,52 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to save collection")
 	})
 
+	t.Run("success - custom object type starts with its schema", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+		mockTypeRepo := mocks.NewMockCustomObjectTypeRepository(mockCtrl)
+		useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockTypeRepo, mocks.NewMockAuthService(mockCtrl))
+
+		userID := entities.NewUserID()
+		schema := &entities.PropertySchema{Definitions: []entities.PropertyDefinition{
+			{Key: "vintage", DisplayName: "Vintage", Type: entities.PropertyTypeNumeric},
+		}}
+		wine, err := entities.NewCustomObjectType(userID, "", "Wine", "🍷", "#7f1d1d", schema)
+		require.NoError(t, err)
+
+		mockTypeRepo.EXPECT().GetByKey(gomock.Any(), userID, entities.ObjectType("wine")).Return(wine, nil)
+		mockCollectionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCollectionRequest{
+			UserID: userID, Name: "Cellar", ObjectType: "wine", UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ObjectType("wine"), resp.Collection.ObjectType())
+		require.NotNil(t, resp.Collection.PropertySchema())
+		assert.Equal(t, "vintage", resp.Collection.PropertySchema().Definitions[0].Key)
+	})
+
+	t.Run("error - undefined custom object type", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockTypeRepo := mocks.NewMockCustomObjectTypeRepository(mockCtrl)
+		useCase := NewCreateCollectionUseCase(mocks.NewMockCollectionRepository(mockCtrl), mockTypeRepo, mocks.NewMockAuthService(mockCtrl))
+
+		userID := entities.NewUserID()
+		mockTypeRepo.EXPECT().GetByKey(gomock.Any(), userID, entities.ObjectType("wine")).Return(nil, entities.ErrCustomObjectTypeNotFound)
+
+		resp, err := useCase.Execute(context.Background(), CreateCollectionRequest{
+			UserID: userID, Name: "Cellar", ObjectType: "wine", UserToken: "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrUnknownObjectType)
+		assert.Nil(t, resp)
+	})
+
 	t.Run("success - create collection with all object types", func(t *testing.T) {
 		userID := entities.NewUserID()
 		for _, objType := range entities.AllObjectTypes {
@@ -168,7 +214,7 @@ func TestCreateCollectionUseCase_Execute(t *testing.T) {
 
 				mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 				mockAuthService := mocks.NewMockAuthService(mockCtrl)
-				useCase := NewCreateCollectionUseCase(mockCollectionRepo, mockAuthService)
+				useCase := NewCreateCollectionUseCase(mockCollectionRepo, mocks.NewMockCustomObjectTypeRepository(mockCtrl), mockAuthService)
 
 				mockCollectionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CreateCommentRequest struct {
+	// CollectionID is the collection commented on. It is ignored when
+	// ObjectID is set: object comments go to the object's collection.
+	CollectionID entities.CollectionID
+	ObjectID     *entities.ObjectID
+	UserID       entities.UserID
+	Username     string
+	UserToken    string
+	Body         string
+}
+
+type CreateCommentResponse struct {
+	Comment *entities.Comment
+}
+
+type CreateCommentUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	commentRepo    repositories.CommentRepository
+	authService    services.AuthService
+}
+
+func NewCreateCommentUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, commentRepo repositories.CommentRepository, authService services.AuthService) *CreateCommentUseCase {
+	return &CreateCommentUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		commentRepo:    commentRepo,
+		authService:    authService,
+	}
+}
+
+// Execute posts the comment. @username mentions are resolved against the
+// people who can see the collection; names that match nobody stay plain text.
+func (uc *CreateCommentUseCase) Execute(ctx context.Context, req CreateCommentRequest) (*CreateCommentResponse, error) {
+	collection, err := getCommentCollection(ctx, uc.collectionRepo, uc.containerRepo, uc.authService, req.CollectionID, req.ObjectID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := uc.resolveMentions(ctx, collection, req.Body, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := entities.NewComment(entities.CommentProps{
+		CollectionID: collection.ID(),
+		ObjectID:     req.ObjectID,
+		AuthorID:     req.UserID,
+		AuthorName:   req.Username,
+		Body:         req.Body,
+		Mentions:     mentions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.commentRepo.Create(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to save comment: %w", err)
+	}
+
+	return &CreateCommentResponse{Comment: comment}, nil
+}
+
+// resolveMentions maps the body's @usernames to the IDs of the collection's
+// owner or group members. The directory is only asked when there is a
+// mention to resolve.
+func (uc *CreateCommentUseCase) resolveMentions(ctx context.Context, collection *entities.Collection, body, userToken string) ([]entities.UserID, error) {
+	names := entities.ParseMentions(body)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var members []*entities.User
+	if collection.GroupID() != nil {
+		users, err := uc.authService.GetGroupUsers(ctx, userToken, collection.GroupID().String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group users: %w", err)
+		}
+		members = users
+	}
+	if !collection.IsGroupOwned() {
+		owner, err := uc.authService.GetUserByID(ctx, userToken, collection.UserID().String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection owner: %w", err)
+		}
+		members = append(members, owner)
+	}
+
+	var mentions []entities.UserID
+	for _, name := range names {
+		for _, member := range members {
+			if strings.EqualFold(member.Username().String(), name) {
+				mentions = append(mentions, member.ID())
+				break
+			}
+		}
+	}
+	return mentions, nil
+}
+
+// getCommentCollection loads the collection a comment is on, the object's
+// when objectID is set, and checks that userID can see it.
+func getCommentCollection(ctx context.Context, collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, authService services.AuthService, collectionID entities.CollectionID, objectID *entities.ObjectID, userID entities.UserID, userToken string) (*entities.Collection, error) {
+	if objectID != nil {
+		container, err := containerRepo.FindByObjectID(ctx, *objectID)
+		if err != nil {
+			return nil, fmt.Errorf("object not found: %w", err)
+		}
+		collectionID = container.CollectionID()
+	}
+	return getAccessibleCollection(ctx, collectionRepo, authService, collectionID, userID, userToken)
+}
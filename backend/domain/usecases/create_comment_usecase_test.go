@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCreateCommentUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		collectionRepo *mocks.MockCollectionRepository
+		containerRepo  *mocks.MockContainerRepository
+		commentRepo    *mocks.MockCommentRepository
+		authService    *mocks.MockAuthService
+		useCase        *CreateCommentUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			commentRepo:    mocks.NewMockCommentRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewCreateCommentUseCase(f.collectionRepo, f.containerRepo, f.commentRepo, f.authService)
+		return f
+	}
+	user := func(name string) *entities.User {
+		username, _ := entities.NewUsername(name)
+		return entities.ReconstructUser(entities.NewUserID(), username, entities.EmailAddress{}, "", time.Now(), time.Now())
+	}
+
+	t.Run("success - resolves mentions of group members and the owner", func(t *testing.T) {
+		f := setup(t)
+		owner := user("Alice")
+		bob := user("bob")
+		group := NewTestGroup()
+		groupID := group.ID()
+		collection := NewTestCollection(ColUserID(owner.ID()), ColGroupID(&groupID))
+
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "test-token", bob.ID().String()).Return([]*entities.Group{group}, nil)
+		f.authService.EXPECT().GetGroupUsers(gomock.Any(), "test-token", groupID.String()).Return([]*entities.User{bob}, nil)
+		f.authService.EXPECT().GetUserByID(gomock.Any(), "test-token", owner.ID().String()).Return(owner, nil)
+		f.commentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), CreateCommentRequest{
+			CollectionID: collection.ID(),
+			UserID:       bob.ID(),
+			Username:     "bob",
+			UserToken:    "test-token",
+			Body:         "  @alice buy more of this brand, @nobody. ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "@alice buy more of this brand, @nobody.", resp.Comment.Body())
+		assert.Equal(t, "bob", resp.Comment.AuthorName())
+		assert.Equal(t, []entities.UserID{owner.ID()}, resp.Comment.Mentions())
+		assert.Nil(t, resp.Comment.ObjectID())
+	})
+
+	t.Run("success - object comment goes to the object's collection", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject()
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+		objectID := object.ID()
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.commentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), CreateCommentRequest{
+			ObjectID: &objectID,
+			UserID:   userID,
+			Body:     "Nearly empty",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, collection.ID(), resp.Comment.CollectionID())
+		require.NotNil(t, resp.Comment.ObjectID())
+		assert.True(t, resp.Comment.ObjectID().Equals(objectID))
+		assert.Empty(t, resp.Comment.Mentions())
+	})
+
+	t.Run("error - rejects an empty comment", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), CreateCommentRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			Body:         "   ",
+		})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidCommentBody)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - user outside the collection cannot comment", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection()
+
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), CreateCommentRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			Body:         "Hello",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+		assert.Nil(t, resp)
+	})
+}
+
+func TestDeleteCommentUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		collectionRepo *mocks.MockCollectionRepository
+		commentRepo    *mocks.MockCommentRepository
+		authService    *mocks.MockAuthService
+		useCase        *DeleteCommentUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			commentRepo:    mocks.NewMockCommentRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewDeleteCommentUseCase(f.collectionRepo, f.commentRepo, f.authService)
+		return f
+	}
+	newComment := func(collectionID entities.CollectionID, authorID entities.UserID) *entities.Comment {
+		comment, _ := entities.NewComment(entities.CommentProps{CollectionID: collectionID, AuthorID: authorID, Body: "note"})
+		return comment
+	}
+
+	t.Run("success - author deletes their comment", func(t *testing.T) {
+		f := setup(t)
+		authorID := entities.NewUserID()
+		comment := newComment(entities.NewCollectionID(), authorID)
+
+		f.commentRepo.EXPECT().GetByID(gomock.Any(), comment.ID()).Return(comment, nil)
+		f.commentRepo.EXPECT().Delete(gomock.Any(), comment.ID()).Return(nil)
+
+		err := f.useCase.Execute(context.Background(), DeleteCommentRequest{CommentID: comment.ID(), UserID: authorID})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("success - collection owner deletes a member's comment", func(t *testing.T) {
+		f := setup(t)
+		ownerID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(ownerID))
+		comment := newComment(collection.ID(), entities.NewUserID())
+
+		f.commentRepo.EXPECT().GetByID(gomock.Any(), comment.ID()).Return(comment, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.commentRepo.EXPECT().Delete(gomock.Any(), comment.ID()).Return(nil)
+
+		err := f.useCase.Execute(context.Background(), DeleteCommentRequest{CommentID: comment.ID(), UserID: ownerID})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error - group member cannot delete someone else's comment", func(t *testing.T) {
+		f := setup(t)
+		memberID := entities.NewUserID()
+		group := NewTestGroup()
+		groupID := group.ID()
+		collection := NewTestCollection(ColGroupID(&groupID))
+		comment := newComment(collection.ID(), collection.UserID())
+
+		f.commentRepo.EXPECT().GetByID(gomock.Any(), comment.ID()).Return(comment, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "", memberID.String()).Return([]*entities.Group{group}, nil)
+
+		err := f.useCase.Execute(context.Background(), DeleteCommentRequest{CommentID: comment.ID(), UserID: memberID})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - comment in a collection the user cannot see is not found", func(t *testing.T) {
+		f := setup(t)
+		collection := NewTestCollection()
+		comment := newComment(collection.ID(), collection.UserID())
+
+		f.commentRepo.EXPECT().GetByID(gomock.Any(), comment.ID()).Return(comment, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		err := f.useCase.Execute(context.Background(), DeleteCommentRequest{CommentID: comment.ID(), UserID: entities.NewUserID()})
+
+		assert.ErrorIs(t, err, entities.ErrCommentNotFound)
+	})
+}
@@ -21,6 +21,8 @@ type CreateContainerRequest struct {
 	Depth             *float64
 	Rows              *int
 	Capacity          *float64
+	TemperatureZone   entities.TemperatureZone
+	Humidity          *float64
 	UserID            entities.UserID
 	UserToken         string
 }
@@ -32,13 +34,17 @@ type CreateContainerResponse struct {
 type CreateContainerUseCase struct {
 	containerRepo  repositories.ContainerRepository
 	collectionRepo repositories.CollectionRepository
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
 	authService    services.AuthService
 }
 
-func NewCreateContainerUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *CreateContainerUseCase {
+func NewCreateContainerUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, authService services.AuthService) *CreateContainerUseCase {
 	return &CreateContainerUseCase{
 		containerRepo:  containerRepo,
 		collectionRepo: collectionRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
 		authService:    authService,
 	}
 }
@@ -57,7 +63,7 @@ func (uc *CreateContainerUseCase) Execute(ctx context.Context, req CreateContain
 	}
 
 	// Check access: user is owner OR user is member of collection's group
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -71,6 +77,10 @@ func (uc *CreateContainerUseCase) Execute(ctx context.Context, req CreateContain
 		return nil, errors.New("access denied: user does not have access to this collection")
 	}
 
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, 1); err != nil {
+		return nil, err
+	}
+
 	// Create container name value object
 	containerName, err := entities.NewContainerName(req.Name)
 	if err != nil {
@@ -105,6 +115,8 @@ func (uc *CreateContainerUseCase) Execute(ctx context.Context, req CreateContain
 		Depth:             req.Depth,
 		Rows:              req.Rows,
 		Capacity:          req.Capacity,
+		TemperatureZone:   req.TemperatureZone,
+		Humidity:          req.Humidity,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container entity: %w", err)
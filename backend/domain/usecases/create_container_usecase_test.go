@@ -22,9 +22,11 @@ func TestCreateContainerUseCase_Execute(t *testing.T) {
 
 	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockQuotaRepo := mocks.NewMockGroupQuotaRepository(mockCtrl)
+	mockUsageRepo := mocks.NewMockUsageRepository(mockCtrl)
 	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewCreateContainerUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+	useCase := NewCreateContainerUseCase(mockContainerRepo, mockCollectionRepo, mockQuotaRepo, mockUsageRepo, mockAuthService)
 
 	t.Run("success - create container", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -123,6 +125,7 @@ func TestCreateContainerUseCase_Execute(t *testing.T) {
 
 		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return([]*entities.Group{userGroup}, nil)
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		mockQuotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(nil, entities.ErrGroupQuotaNotFound)
 		mockContainerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
 
 		resp, err := useCase.Execute(context.Background(), CreateContainerRequest{
@@ -133,4 +136,59 @@ func TestCreateContainerUseCase_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to save container")
 		assert.Nil(t, resp)
 	})
+
+	t.Run("success - within the group's quota", func(t *testing.T) {
+		userID := entities.NewUserID()
+		groupID, _ := entities.GroupIDFromString("group-within")
+		collectionID := entities.NewCollectionID()
+
+		collectionName, _ := entities.NewCollectionName(fake.Company())
+		testCollection, _ := entities.NewCollection(entities.CollectionProps{
+			UserID: userID, GroupID: &groupID, Name: collectionName, ObjectType: entities.ObjectTypeGeneral,
+		})
+		quota, _ := entities.NewGroupQuota(entities.GroupQuotaProps{GroupID: groupID, MaxContainers: 3})
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return([]*entities.Group{NewTestGroup(GrpID(groupID))}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		mockQuotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(quota, nil)
+		mockUsageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(entities.GroupUsage{Containers: 2}, nil)
+		mockContainerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateContainerRequest{
+			CollectionID: collectionID, Name: fake.Word(), UserID: userID, UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("error - group quota reached", func(t *testing.T) {
+		userID := entities.NewUserID()
+		groupID, _ := entities.GroupIDFromString("group-full")
+		collectionID := entities.NewCollectionID()
+
+		collectionName, _ := entities.NewCollectionName(fake.Company())
+		testCollection, _ := entities.NewCollection(entities.CollectionProps{
+			UserID: userID, GroupID: &groupID, Name: collectionName, ObjectType: entities.ObjectTypeGeneral,
+		})
+		quota, _ := entities.NewGroupQuota(entities.GroupQuotaProps{GroupID: groupID, MaxContainers: 3})
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return([]*entities.Group{NewTestGroup(GrpID(groupID))}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(testCollection, nil)
+		mockQuotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(quota, nil)
+		mockUsageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(entities.GroupUsage{Containers: 3}, nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateContainerRequest{
+			CollectionID: collectionID, Name: fake.Word(), UserID: userID, UserToken: "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrGroupQuotaExceeded)
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, entities.QuotaContainers, quotaErr.Resource)
+		assert.Equal(t, int64(3), quotaErr.Limit)
+		assert.Equal(t, int64(3), quotaErr.Used)
+		assert.Nil(t, resp)
+	})
 }
@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CreateContainersFromTemplateRequest struct {
+	CollectionID entities.CollectionID
+	TemplateID   entities.ContainerTemplateID
+	// ParentContainerID nests the template's top-level containers under an
+	// existing container instead of at the collection root.
+	ParentContainerID *entities.ContainerID
+	GroupID           *entities.GroupID
+	UserID            entities.UserID
+	UserToken         string
+}
+
+type CreateContainersFromTemplateResponse struct {
+	// Containers are listed parents before children.
+	Containers []*entities.Container
+}
+
+type CreateContainersFromTemplateUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	templateRepo   repositories.ContainerTemplateRepository
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	authService    services.AuthService
+}
+
+func NewCreateContainersFromTemplateUseCase(
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	templateRepo repositories.ContainerTemplateRepository,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
+	authService services.AuthService,
+) *CreateContainersFromTemplateUseCase {
+	return &CreateContainersFromTemplateUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		templateRepo:   templateRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *CreateContainersFromTemplateUseCase) Execute(ctx context.Context, req CreateContainersFromTemplateRequest) (*CreateContainersFromTemplateResponse, error) {
+	template, err := uc.resolveTemplate(ctx, req.TemplateID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	// Check access: user is owner OR user is member of collection's group
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	if req.ParentContainerID != nil {
+		parent, err := collection.GetContainer(*req.ParentContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("parent container not found: %w", err)
+		}
+		if !parent.CanHaveChildren() {
+			return nil, fmt.Errorf("parent container type %s cannot have children", parent.ContainerType())
+		}
+	}
+
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, int64(template.ContainerCount())); err != nil {
+		return nil, err
+	}
+
+	created := make([]*entities.Container, 0, template.ContainerCount())
+	if err := uc.createNodes(ctx, collection, template.Nodes(), req.ParentContainerID, req.GroupID, &created); err != nil {
+		return nil, err
+	}
+
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	return &CreateContainersFromTemplateResponse{
+		Containers: created,
+	}, nil
+}
+
+// resolveTemplate returns a built-in template or one the user saved. Another
+// user's template is reported as not found.
+func (uc *CreateContainersFromTemplateUseCase) resolveTemplate(ctx context.Context, id entities.ContainerTemplateID, userID entities.UserID) (*entities.ContainerTemplate, error) {
+	if id.IsBuiltIn() {
+		return entities.FindBuiltInContainerTemplate(id)
+	}
+
+	template, err := uc.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !template.IsOwnedBy(userID) {
+		return nil, entities.ErrContainerTemplateNotFound
+	}
+	return template, nil
+}
+
+func (uc *CreateContainersFromTemplateUseCase) createNodes(
+	ctx context.Context,
+	collection *entities.Collection,
+	nodes []entities.ContainerTemplateNode,
+	parentID *entities.ContainerID,
+	groupID *entities.GroupID,
+	created *[]*entities.Container,
+) error {
+	for _, node := range nodes {
+		name, err := entities.NewContainerName(node.Name)
+		if err != nil {
+			return fmt.Errorf("invalid container name %q: %w", node.Name, err)
+		}
+
+		container, err := entities.NewContainer(entities.ContainerProps{
+			CollectionID:      collection.ID(),
+			Name:              name,
+			ContainerType:     node.Type,
+			ParentContainerID: parentID,
+			GroupID:           groupID,
+			Location:          node.Location,
+			Width:             node.Width,
+			Depth:             node.Depth,
+			Rows:              node.Rows,
+			Capacity:          node.Capacity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create container entity: %w", err)
+		}
+
+		if err := uc.containerRepo.Create(ctx, container); err != nil {
+			return fmt.Errorf("failed to save container %q: %w", node.Name, err)
+		}
+		if err := collection.AddContainer(*container); err != nil {
+			return fmt.Errorf("failed to add container to collection: %w", err)
+		}
+		*created = append(*created, container)
+
+		if len(node.Children) > 0 {
+			id := container.ID()
+			if err := uc.createNodes(ctx, collection, node.Children, &id, groupID, created); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
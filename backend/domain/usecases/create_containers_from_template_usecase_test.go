@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCreateContainersFromTemplateUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *CreateContainersFromTemplateUseCase
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		templateRepo   *mocks.MockContainerTemplateRepository
+		quotaRepo      *mocks.MockGroupQuotaRepository
+		usageRepo      *mocks.MockUsageRepository
+		authService    *mocks.MockAuthService
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			templateRepo:   mocks.NewMockContainerTemplateRepository(mockCtrl),
+			quotaRepo:      mocks.NewMockGroupQuotaRepository(mockCtrl),
+			usageRepo:      mocks.NewMockUsageRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewCreateContainersFromTemplateUseCase(f.containerRepo, f.collectionRepo, f.templateRepo, f.quotaRepo, f.usageRepo, f.authService)
+		return f
+	}
+
+	userID := entities.NewUserID()
+	libraryID, _ := entities.ContainerTemplateIDFromString("builtin:library")
+
+	t.Run("built-in template nests children under their parent", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection(ColUserID(userID))
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.containerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(7)
+		f.collectionRepo.EXPECT().Update(gomock.Any(), col).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), CreateContainersFromTemplateRequest{
+			CollectionID: col.ID(), TemplateID: libraryID, UserID: userID,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Containers, 7)
+		bookshelf := resp.Containers[0]
+		assert.Equal(t, "Bookshelf", bookshelf.Name().String())
+		assert.Nil(t, bookshelf.ParentContainerID())
+		for _, shelf := range resp.Containers[1:] {
+			require.NotNil(t, shelf.ParentContainerID())
+			assert.Equal(t, bookshelf.ID(), *shelf.ParentContainerID())
+			assert.Equal(t, entities.ContainerTypeShelf, shelf.ContainerType())
+		}
+		assert.Equal(t, "Shelf F", resp.Containers[6].Name().String())
+		assert.Len(t, col.Containers(), 7)
+	})
+
+	t.Run("template past the group's container quota creates nothing", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		groupID, _ := entities.GroupIDFromString("family")
+		col := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return([]*entities.Group{NewTestGroup(GrpID(groupID))}, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		expectGroupQuota(f.quotaRepo, f.usageRepo, groupID, entities.QuotaContainers, 10, 4)
+
+		_, err := f.useCase.Execute(context.Background(), CreateContainersFromTemplateRequest{
+			CollectionID: col.ID(), TemplateID: libraryID, UserID: userID,
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, int64(7), quotaErr.Requested)
+	})
+
+	t.Run("rejects parent that cannot have children", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		shelf := NewTestContainer(CtrType(entities.ContainerTypeShelf))
+		col := NewTestCollection(ColUserID(userID), ColContainers(*shelf))
+		parentID := shelf.ID()
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), CreateContainersFromTemplateRequest{
+			CollectionID: col.ID(), TemplateID: libraryID, ParentContainerID: &parentID, UserID: userID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot have children")
+	})
+
+	t.Run("other user's template is not found", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		template, err := entities.NewContainerTemplate(entities.NewUserID(), "Theirs", "", []entities.ContainerTemplateNode{{Name: "Box"}})
+		require.NoError(t, err)
+		f.templateRepo.EXPECT().GetByID(gomock.Any(), template.ID()).Return(template, nil)
+
+		_, err = f.useCase.Execute(context.Background(), CreateContainersFromTemplateRequest{
+			CollectionID: entities.NewCollectionID(), TemplateID: template.ID(), UserID: userID,
+		})
+
+		require.ErrorIs(t, err, entities.ErrContainerTemplateNotFound)
+	})
+
+	t.Run("access denied for foreign collection", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection()
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), CreateContainersFromTemplateRequest{
+			CollectionID: col.ID(), TemplateID: libraryID, UserID: userID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type CreateCustomObjectTypeRequest struct {
+	// Key is what collections of the type carry as their object_type; empty
+	// derives it from Name.
+	Key    entities.ObjectType
+	Name   string
+	Icon   string
+	Color  string
+	Schema *entities.PropertySchema
+	UserID entities.UserID
+}
+
+type CreateCustomObjectTypeResponse struct {
+	ObjectType *entities.CustomObjectType
+}
+
+type CreateCustomObjectTypeUseCase struct {
+	typeRepo repositories.CustomObjectTypeRepository
+}
+
+func NewCreateCustomObjectTypeUseCase(typeRepo repositories.CustomObjectTypeRepository) *CreateCustomObjectTypeUseCase {
+	return &CreateCustomObjectTypeUseCase{
+		typeRepo: typeRepo,
+	}
+}
+
+func (uc *CreateCustomObjectTypeUseCase) Execute(ctx context.Context, req CreateCustomObjectTypeRequest) (*CreateCustomObjectTypeResponse, error) {
+	objectType, err := entities.NewCustomObjectType(req.UserID, req.Key, req.Name, req.Icon, req.Color, req.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.typeRepo.GetByKey(ctx, req.UserID, objectType.Key())
+	switch {
+	case err == nil:
+		return nil, entities.ErrCustomObjectTypeExists
+	case !errors.Is(err, entities.ErrCustomObjectTypeNotFound):
+		return nil, fmt.Errorf("failed to check custom object types: %w", err)
+	}
+
+	if err := uc.typeRepo.Create(ctx, objectType); err != nil {
+		return nil, fmt.Errorf("failed to create custom object type: %w", err)
+	}
+
+	return &CreateCustomObjectTypeResponse{
+		ObjectType: objectType,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCreateCustomObjectTypeUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTypeRepo := mocks.NewMockCustomObjectTypeRepository(mockCtrl)
+
+	useCase := NewCreateCustomObjectTypeUseCase(mockTypeRepo)
+
+	t.Run("success - key derived from name", func(t *testing.T) {
+		userID := entities.NewUserID()
+
+		mockTypeRepo.EXPECT().GetByKey(gomock.Any(), userID, entities.ObjectType("wine_bottles")).Return(nil, entities.ErrCustomObjectTypeNotFound)
+		mockTypeRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCustomObjectTypeRequest{
+			Name:   "Wine Bottles",
+			Icon:   "🍷",
+			Color:  "#7F1D1D",
+			UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ObjectType("wine_bottles"), resp.ObjectType.Key())
+		assert.Equal(t, "#7f1d1d", resp.ObjectType.Color())
+		assert.True(t, resp.ObjectType.IsOwnedBy(userID))
+	})
+
+	t.Run("error - key already defined", func(t *testing.T) {
+		userID := entities.NewUserID()
+		existing, err := entities.NewCustomObjectType(userID, "wine", "Wine", "", "", nil)
+		require.NoError(t, err)
+
+		mockTypeRepo.EXPECT().GetByKey(gomock.Any(), userID, entities.ObjectType("wine")).Return(existing, nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateCustomObjectTypeRequest{
+			Key:    "wine",
+			Name:   "More Wine",
+			UserID: userID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrCustomObjectTypeExists)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - built-in key", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), CreateCustomObjectTypeRequest{
+			Key:    entities.ObjectTypeBook,
+			Name:   "Books",
+			UserID: entities.NewUserID(),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrBuiltInObjectType)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - invalid color", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), CreateCustomObjectTypeRequest{
+			Name:   "Tools",
+			Color:  "blue",
+			UserID: entities.NewUserID(),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidObjectTypeColor)
+		assert.Nil(t, resp)
+	})
+}
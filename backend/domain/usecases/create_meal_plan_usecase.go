@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CreateMealPlanRequest struct {
+	Date       time.Time
+	Meal       entities.MealType
+	RecipeName string
+	// Ingredients only need ObjectID and Quantity; names are filled in from
+	// the objects and an empty unit defaults to the object's unit.
+	Ingredients []entities.MealIngredient
+	UserID      entities.UserID
+	UserToken   string
+}
+
+type CreateMealPlanResponse struct {
+	MealPlan *entities.MealPlan
+}
+
+type CreateMealPlanUseCase struct {
+	mealPlanRepo   repositories.MealPlanRepository
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewCreateMealPlanUseCase(
+	mealPlanRepo repositories.MealPlanRepository,
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	authService services.AuthService,
+) *CreateMealPlanUseCase {
+	return &CreateMealPlanUseCase{
+		mealPlanRepo:   mealPlanRepo,
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *CreateMealPlanUseCase) Execute(ctx context.Context, req CreateMealPlanRequest) (*CreateMealPlanResponse, error) {
+	ingredients, err := resolveMealIngredients(ctx, uc.containerRepo, uc.collectionRepo, uc.authService, req.Ingredients, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := entities.NewMealPlan(req.UserID, req.Date, req.Meal, req.RecipeName, ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mealPlanRepo.Create(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to save meal plan: %w", err)
+	}
+
+	return &CreateMealPlanResponse{
+		MealPlan: plan,
+	}, nil
+}
+
+// resolveMealIngredients checks that every ingredient is an active object in
+// a collection the user can access, and fills in its name and default unit.
+func resolveMealIngredients(
+	ctx context.Context,
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	authService services.AuthService,
+	ingredients []entities.MealIngredient,
+	userID entities.UserID,
+	userToken string,
+) ([]entities.MealIngredient, error) {
+	if len(ingredients) == 0 {
+		return nil, nil
+	}
+
+	userGroups, err := authService.GetUserGroups(ctx, userToken, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	// Ingredients usually share a collection; check each one only once
+	checked := make(map[string]bool)
+	resolved := make([]entities.MealIngredient, len(ingredients))
+	for i, ing := range ingredients {
+		container, err := containerRepo.FindByObjectID(ctx, ing.ObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("object %s not found: %w", ing.ObjectID, err)
+		}
+		object, err := container.GetObject(ing.ObjectID)
+		if err != nil || object.IsArchived() {
+			return nil, fmt.Errorf("object %s not found", ing.ObjectID)
+		}
+
+		collectionID := container.CollectionID()
+		if !checked[collectionID.String()] {
+			collection, err := collectionRepo.GetByIDSummary(ctx, collectionID)
+			if err != nil {
+				return nil, fmt.Errorf("collection not found: %w", err)
+			}
+
+			hasAccess := collection.IsOwnedBy(userID)
+			if !hasAccess && collection.GroupID() != nil {
+				for _, group := range userGroups {
+					if group.ID().Equals(*collection.GroupID()) {
+						hasAccess = true
+						break
+					}
+				}
+			}
+			if !hasAccess {
+				return nil, errors.New("access denied: user does not have access to this collection")
+			}
+			checked[collectionID.String()] = true
+		}
+
+		unit := ing.Unit
+		if unit == "" {
+			unit = object.Unit()
+		}
+		resolved[i] = entities.MealIngredient{
+			ObjectID: ing.ObjectID,
+			Name:     object.Name().String(),
+			Quantity: ing.Quantity,
+			Unit:     unit,
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestCreateMealPlanUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockMealPlanRepo := mocks.NewMockMealPlanRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewCreateMealPlanUseCase(mockMealPlanRepo, mockContainerRepo, mockCollectionRepo, mockAuthService)
+
+	t.Run("success - links ingredients with names and default units", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		pasta := NewTestObject(ObjName("Spaghetti"), ObjQuantity(500), ObjUnit("g"))
+		tomatoes := NewTestObject(ObjName("Tomatoes"), ObjQuantity(6))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*pasta, *tomatoes))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), pasta.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), tomatoes.ID()).Return(container, nil)
+		mockCollectionRepo.EXPECT().GetByIDSummary(gomock.Any(), collectionID).Return(collection, nil)
+		mockMealPlanRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateMealPlanRequest{
+			Date:       time.Date(2025, 3, 10, 18, 30, 0, 0, time.UTC),
+			Meal:       entities.MealTypeDinner,
+			RecipeName: "Spaghetti pomodoro",
+			Ingredients: []entities.MealIngredient{
+				{ObjectID: pasta.ID(), Quantity: 200},
+				{ObjectID: tomatoes.ID(), Quantity: 3, Unit: "pcs"},
+			},
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		plan := resp.MealPlan
+		assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), plan.Date())
+		assert.Equal(t, []entities.MealIngredient{
+			{ObjectID: pasta.ID(), Name: "Spaghetti", Quantity: 200, Unit: "g"},
+			{ObjectID: tomatoes.ID(), Name: "Tomatoes", Quantity: 3, Unit: "pcs"},
+		}, plan.Ingredients())
+	})
+
+	t.Run("error - ingredient in another user's collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		obj := NewTestObject()
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(entities.NewUserID()))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), obj.ID()).Return(container, nil)
+		mockCollectionRepo.EXPECT().GetByIDSummary(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateMealPlanRequest{
+			Date:        time.Now(),
+			Meal:        entities.MealTypeLunch,
+			RecipeName:  "Salad",
+			Ingredients: []entities.MealIngredient{{ObjectID: obj.ID(), Quantity: 1}},
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - invalid meal", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), CreateMealPlanRequest{
+			Date:       time.Now(),
+			Meal:       "brunch",
+			RecipeName: "Pancakes",
+			UserID:     entities.NewUserID(),
+			UserToken:  "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrInvalidMealType)
+		assert.Nil(t, resp)
+	})
+}
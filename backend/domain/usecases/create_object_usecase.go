@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/nishiki/backend/domain/entities"
@@ -20,6 +21,9 @@ type CreateObjectRequest struct {
 	Location      string
 	Quantity      *float64
 	Unit          string
+	MinQuantity   *float64                       // restock threshold; nil = not tracked
+	Condition     entities.Condition             // collectibles only
+	Status        entities.ObjectStatus          // empty = owned
 	Properties    map[string]entities.TypedValue // for direct callers (bulk import)
 	RawProperties map[string]any                 // for HTTP/MCP callers; coerced in Execute()
 	Tags          []string
@@ -29,22 +33,33 @@ type CreateObjectRequest struct {
 }
 
 type CreateObjectResponse struct {
-	Object      *entities.Object
-	ContainerID entities.ContainerID
+	Object         *entities.Object
+	ContainerID    entities.ContainerID
+	StorageWarning *entities.StorageWarning // nil unless the container is too warm for the object
 }
 
 type CreateObjectUseCase struct {
 	containerRepo  repositories.ContainerRepository
 	collectionRepo repositories.CollectionRepository
+	codeRepo       repositories.ObjectCodeRepository
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	expiryRepo     repositories.ExpiryHistoryRepository
 	authService    services.AuthService
+	events         services.EventPublisher
 	typeInference  *services.TypeInferenceService
 }
 
-func NewCreateObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *CreateObjectUseCase {
+func NewCreateObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, codeRepo repositories.ObjectCodeRepository, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, expiryRepo repositories.ExpiryHistoryRepository, authService services.AuthService, events services.EventPublisher) *CreateObjectUseCase {
 	return &CreateObjectUseCase{
 		containerRepo:  containerRepo,
 		collectionRepo: collectionRepo,
+		codeRepo:       codeRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		expiryRepo:     expiryRepo,
 		authService:    authService,
+		events:         events,
 		typeInference:  services.NewTypeInferenceService(nil),
 	}
 }
@@ -85,7 +100,7 @@ func (uc *CreateObjectUseCase) Execute(ctx context.Context, req CreateObjectRequ
 	}
 
 	// Check access: user is owner OR user is member of collection's group
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -99,6 +114,16 @@ func (uc *CreateObjectUseCase) Execute(ctx context.Context, req CreateObjectRequ
 		return nil, errors.New("access denied: user does not have access to this collection")
 	}
 
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaObjects, 1); err != nil {
+		return nil, err
+	}
+
+	// Custom types are defined per collection owner; objects can only carry
+	// the one their collection was created with
+	if req.ObjectType != "" && !req.ObjectType.IsBuiltIn() && req.ObjectType != collection.ObjectType() {
+		return nil, fmt.Errorf("%w: %s", entities.ErrUnknownObjectType, req.ObjectType)
+	}
+
 	// Create object name value object
 	objectName, err := entities.NewObjectName(req.Name)
 	if err != nil {
@@ -109,9 +134,26 @@ func (uc *CreateObjectUseCase) Execute(ctx context.Context, req CreateObjectRequ
 	objectDesc := entities.NewObjectDescription(req.Description)
 
 	// Coerce raw properties from HTTP/MCP if provided
+	schema := collection.PropertySchema().ForObjectType(req.ObjectType)
 	props := req.Properties
 	if len(req.RawProperties) > 0 {
-		props = uc.typeInference.CoerceRawProperties(req.RawProperties, collection.PropertySchema())
+		props = uc.typeInference.CoerceRawProperties(req.RawProperties, schema)
+	}
+	errs := schema.Validate(props)
+	errs = append(errs, entities.ValidateNutrition(req.ObjectType, props)...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid properties: %s", strings.Join(errs, "; "))
+	}
+
+	// Food without an explicit expiry gets the collection's default shelf
+	// life. Objects created without a type take the collection's.
+	expiresAt := req.ExpiresAt
+	if expiresAt == nil {
+		objectType := req.ObjectType
+		if objectType == "" {
+			objectType = collection.ObjectType()
+		}
+		expiresAt = collection.DefaultExpiry(objectType, props, req.Tags, time.Now())
 	}
 
 	// Create new object
@@ -122,9 +164,12 @@ func (uc *CreateObjectUseCase) Execute(ctx context.Context, req CreateObjectRequ
 		Location:    req.Location,
 		Quantity:    req.Quantity,
 		Unit:        req.Unit,
+		MinQuantity: req.MinQuantity,
+		Condition:   req.Condition,
+		Status:      req.Status,
 		Properties:  props,
 		Tags:        req.Tags,
-		ExpiresAt:   req.ExpiresAt,
+		ExpiresAt:   expiresAt,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create object entity: %w", err)
@@ -135,12 +180,48 @@ func (uc *CreateObjectUseCase) Execute(ctx context.Context, req CreateObjectRequ
 		return nil, fmt.Errorf("failed to add object to container: %w", err)
 	}
 
+	// The object is saved; a missed index entry only costs a later
+	// "already have this" suggestion, so it does not fail the create.
+	_ = indexObjectCodes(ctx, uc.codeRepo, req.UserID, object)
+
+	// Only dates the user picked are learned from; the collection's default
+	// would just teach the suggestions what they already say.
+	if req.ExpiresAt != nil {
+		_ = recordExpiry(ctx, uc.expiryRepo, req.UserID, object, time.Now())
+	}
+
+	publishEvent(uc.events, entities.NewObjectCreatedEvent(req.UserID, collection.ID(), container.ID(), *object, time.Now()))
+
 	return &CreateObjectResponse{
-		Object:      object,
-		ContainerID: container.ID(),
+		Object:         object,
+		ContainerID:    container.ID(),
+		StorageWarning: storageWarning(container, *object, collection.ObjectType()),
 	}, nil
 }
 
+// storageWarning is the container's StorageWarningFor as a nil-able pointer
+func storageWarning(container *entities.Container, object entities.Object, collectionType entities.ObjectType) *entities.StorageWarning {
+	if w, ok := container.StorageWarningFor(object, collectionType); ok {
+		return &w
+	}
+	return nil
+}
+
+// recordExpiry adds how long after from the object expires to its
+// category's history, for suggesting expiry dates later. Objects without a
+// category or an expiry teach nothing.
+func recordExpiry(ctx context.Context, expiryRepo repositories.ExpiryHistoryRepository, userID entities.UserID, object *entities.Object, from time.Time) error {
+	category := entities.ExpiryCategory(object.Properties(), object.Tags())
+	if category == "" || object.ExpiresAt() == nil {
+		return nil
+	}
+	days, ok := entities.ExpiryDays(from, *object.ExpiresAt())
+	if !ok {
+		return nil
+	}
+	return expiryRepo.AddSample(ctx, userID, category, days)
+}
+
 const defaultContainerName = "General"
 
 // findOrCreateDefaultContainer returns the default "General" container for a collection,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,9 +22,13 @@ func TestCreateObjectUseCase_Execute(t *testing.T) {
 
 	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	mockQuotaRepo := mocks.NewMockGroupQuotaRepository(mockCtrl)
+	mockUsageRepo := mocks.NewMockUsageRepository(mockCtrl)
+	mockExpiryRepo := mocks.NewMockExpiryHistoryRepository(mockCtrl)
 	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewCreateObjectUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+	useCase := NewCreateObjectUseCase(mockContainerRepo, mockCollectionRepo, mockCodeRepo, mockQuotaRepo, mockUsageRepo, mockExpiryRepo, mockAuthService, nil)
 
 	t.Run("success - create object as collection owner", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -56,6 +61,65 @@ func TestCreateObjectUseCase_Execute(t *testing.T) {
 		assert.Equal(t, entities.ObjectTypeGeneral, resp.Object.ObjectType())
 	})
 
+	t.Run("success - dairy in an ambient container warns", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID), CtrTemperatureZone(entities.TemperatureZoneAmbient))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		req := CreateObjectRequest{
+			ContainerID: &containerID,
+			Name:        "Milk",
+			ObjectType:  entities.ObjectTypeFood,
+			Properties:  Props("category", "Dairy"),
+			UserID:      userID,
+			UserToken:   "test-token",
+		}
+
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.StorageWarning)
+		assert.Equal(t, entities.TemperatureZoneChilled, resp.StorageWarning.Required())
+		assert.Equal(t, entities.TemperatureZoneAmbient, resp.StorageWarning.Zone())
+		assert.Equal(t, "Milk is dairy, which needs refrigeration, but its container is ambient", resp.StorageWarning.Message())
+	})
+
+	t.Run("success - dairy in a chilled container does not warn", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID), CtrTemperatureZone(entities.TemperatureZoneChilled))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		req := CreateObjectRequest{
+			ContainerID: &containerID,
+			Name:        "Milk",
+			ObjectType:  entities.ObjectTypeFood,
+			Tags:        []string{"dairy"},
+			UserID:      userID,
+			UserToken:   "test-token",
+		}
+
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.StorageWarning)
+	})
+
 	t.Run("success - create object in group collection", func(t *testing.T) {
 		userID := entities.NewUserID()
 		groupID := entities.NewGroupID()
@@ -80,6 +144,7 @@ func TestCreateObjectUseCase_Execute(t *testing.T) {
 		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
 		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{testGroup}, nil)
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockQuotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(nil, entities.ErrGroupQuotaNotFound)
 		mockContainerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).Return(nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
@@ -89,6 +154,34 @@ func TestCreateObjectUseCase_Execute(t *testing.T) {
 		assert.NotNil(t, resp.Object)
 	})
 
+	t.Run("error - group object quota reached", func(t *testing.T) {
+		userID := entities.NewUserID()
+		groupID, _ := entities.GroupIDFromString("group-full")
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColGroupID(&groupID))
+		quota, _ := entities.NewGroupQuota(entities.GroupQuotaProps{GroupID: groupID, MaxObjects: 100})
+
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockQuotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(quota, nil)
+		mockUsageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(entities.GroupUsage{Objects: 100}, nil)
+
+		resp, err := useCase.Execute(context.Background(), CreateObjectRequest{
+			ContainerID: &containerID,
+			Name:        "One Too Many",
+			ObjectType:  entities.ObjectTypeGeneral,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrGroupQuotaExceeded)
+		assert.Nil(t, resp)
+	})
+
 	t.Run("error - container not found", func(t *testing.T) {
 		userID := entities.NewUserID()
 		containerID := entities.NewContainerID()
@@ -198,6 +291,104 @@ func TestCreateObjectUseCase_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid object name")
 	})
 
+	t.Run("error - properties violate collection field definitions", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColSchema(&entities.PropertySchema{
+			Definitions: []entities.PropertyDefinition{
+				{Key: "vintage", DisplayName: "Vintage", Type: entities.PropertyTypeNumeric},
+				{Key: "region", DisplayName: "Region", Type: entities.PropertyTypeText, Required: true},
+			},
+		}))
+
+		req := CreateObjectRequest{
+			ContainerID:   &containerID,
+			Name:          "Rioja Reserva",
+			ObjectType:    entities.ObjectTypeGeneral,
+			RawProperties: map[string]any{"vintage": "recent"},
+			UserID:        userID,
+			UserToken:     "test-token",
+		}
+
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "invalid properties")
+		assert.Contains(t, err.Error(), "property vintage must be numeric")
+		assert.Contains(t, err.Error(), "missing required property: region")
+	})
+
+	t.Run("success - food nutrition values are coerced to numbers", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		req := CreateObjectRequest{
+			ContainerID:   &containerID,
+			Name:          "Oats",
+			ObjectType:    entities.ObjectTypeFood,
+			RawProperties: map[string]any{"calories": "150", "protein_g": 5.0, "upc": "0123456789012"},
+			UserID:        userID,
+			UserToken:     "test-token",
+		}
+
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).Return(nil)
+		mockCodeRepo.EXPECT().Add(gomock.Any(), userID, "0123456789012", gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		calories, ok := resp.Object.AvailableCalories()
+		require.True(t, ok)
+		assert.InDelta(t, 150, calories, 0.001)
+		upc, _ := resp.Object.GetProperty(entities.PropertyUPC)
+		assert.Equal(t, "0123456789012", upc.Val)
+	})
+
+	t.Run("error - invalid food nutrition values", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		req := CreateObjectRequest{
+			ContainerID:   &containerID,
+			Name:          "Oats",
+			ObjectType:    entities.ObjectTypeFood,
+			RawProperties: map[string]any{"calories": "lots", "fat_g": -1.0, "upc": "12ab"},
+			UserID:        userID,
+			UserToken:     "test-token",
+		}
+
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "property calories must be numeric")
+		assert.Contains(t, err.Error(), "property fat_g must not be negative")
+		assert.Contains(t, err.Error(), "property upc must be 8 to 14 digits")
+	})
+
 	t.Run("error - auth service failure", func(t *testing.T) {
 		userID := entities.NewUserID()
 		collectionID := entities.NewCollectionID()
@@ -257,3 +448,90 @@ func TestCreateObjectUseCase_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to add object to container")
 	})
 }
+
+func TestCreateObjectUseCase_DefaultExpiry(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	mockQuotaRepo := mocks.NewMockGroupQuotaRepository(mockCtrl)
+	mockUsageRepo := mocks.NewMockUsageRepository(mockCtrl)
+	mockExpiryRepo := mocks.NewMockExpiryHistoryRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewCreateObjectUseCase(mockContainerRepo, mockCollectionRepo, mockCodeRepo, mockQuotaRepo, mockUsageRepo, mockExpiryRepo, mockAuthService, nil)
+
+	rules := []entities.ShelfLife{
+		entities.ReconstructShelfLife("dairy", 7),
+		entities.ReconstructShelfLife("frozen", 90),
+		entities.ReconstructShelfLife("", 30),
+	}
+	explicit := time.Now().AddDate(0, 0, 10)
+
+	tests := []struct {
+		name       string
+		objectType entities.ObjectType
+		props      map[string]entities.TypedValue
+		tags       []string
+		expiresAt  *time.Time
+		wantDays   int // 0 means no expiry is filled in
+	}{
+		{"category property", entities.ObjectTypeFood, Props("category", "Dairy"), []string{"frozen"}, nil, 7},
+		{"tag match", entities.ObjectTypeFood, Props(), []string{"frozen"}, nil, 90},
+		{"fallback rule", entities.ObjectTypeFood, Props("category", "snacks"), nil, nil, 30},
+		{"untyped object takes collection type", "", Props("category", "dairy"), nil, nil, 7},
+		{"explicit expiry kept", entities.ObjectTypeFood, Props("category", "dairy"), nil, &explicit, 0},
+		{"non-food ignored", entities.ObjectTypeGeneral, Props("category", "dairy"), nil, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := entities.NewUserID()
+			collectionID := entities.NewCollectionID()
+			containerID := entities.NewContainerID()
+
+			container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID))
+			collection := NewTestCollection(ColID(collectionID), ColUserID(userID),
+				ColObjectType(entities.ObjectTypeFood), ColShelfLife(rules...))
+
+			mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+			mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+			mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+			mockContainerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).Return(nil)
+			// Only the date the user picked is learned from
+			if tt.expiresAt != nil {
+				mockExpiryRepo.EXPECT().AddSample(gomock.Any(), userID, "dairy", 10).Return(nil)
+			}
+
+			before := time.Now()
+			resp, err := useCase.Execute(context.Background(), CreateObjectRequest{
+				ContainerID: &containerID,
+				Name:        "Milk",
+				ObjectType:  tt.objectType,
+				Properties:  tt.props,
+				Tags:        tt.tags,
+				ExpiresAt:   tt.expiresAt,
+				UserID:      userID,
+				UserToken:   "test-token",
+			})
+			require.NoError(t, err)
+
+			got := resp.Object.ExpiresAt()
+			switch {
+			case tt.expiresAt != nil:
+				require.NotNil(t, got)
+				assert.True(t, got.Equal(*tt.expiresAt))
+			case tt.wantDays == 0:
+				assert.Nil(t, got)
+			default:
+				require.NotNil(t, got)
+				want := before.AddDate(0, 0, tt.wantDays)
+				assert.WithinDuration(t, want, *got, time.Minute)
+			}
+		})
+	}
+}
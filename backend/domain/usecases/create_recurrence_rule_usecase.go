@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type CreateRecurrenceRuleRequest struct {
+	ObjectID   entities.ObjectID
+	Recurrence entities.Recurrence
+	// Quantity is how much to buy each time; nil leaves it to the user.
+	Quantity  *float64
+	UserID    entities.UserID
+	UserToken string
+}
+
+type CreateRecurrenceRuleResponse struct {
+	Rule *entities.RecurrenceRule
+}
+
+type CreateRecurrenceRuleUseCase struct {
+	recurrenceRepo repositories.RecurrenceRuleRepository
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewCreateRecurrenceRuleUseCase(
+	recurrenceRepo repositories.RecurrenceRuleRepository,
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	authService services.AuthService,
+) *CreateRecurrenceRuleUseCase {
+	return &CreateRecurrenceRuleUseCase{
+		recurrenceRepo: recurrenceRepo,
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+// Execute schedules the object as a staple. The first round comes due one
+// recurrence from now; each user has at most one rule per object.
+func (uc *CreateRecurrenceRuleUseCase) Execute(ctx context.Context, req CreateRecurrenceRuleRequest) (*CreateRecurrenceRuleResponse, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+	object, err := container.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+	if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, container.CollectionID(), req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	switch _, err := uc.recurrenceRepo.GetByObjectID(ctx, req.UserID, req.ObjectID); {
+	case err == nil:
+		return nil, entities.ErrRecurrenceRuleExists
+	case !errors.Is(err, entities.ErrRecurrenceRuleNotFound):
+		return nil, fmt.Errorf("failed to check recurrence rules: %w", err)
+	}
+
+	rule, err := entities.NewRecurrenceRule(req.UserID, req.ObjectID, container.CollectionID(), object.Name().String(), req.Recurrence, req.Quantity, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recurrenceRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save recurrence rule: %w", err)
+	}
+
+	return &CreateRecurrenceRuleResponse{
+		Rule: rule,
+	}, nil
+}
@@ -0,0 +1,241 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type DeleteAccountRequest struct {
+	UserID entities.UserID
+}
+
+type DeleteAccountResponse struct {
+	CollectionsDeleted int64
+	ContainersDeleted  int64
+	ObjectsDeleted     int64
+	MovesDeleted       int64
+	TemplatesDeleted   int64
+	MealPlansDeleted   int64
+	SnapshotsDeleted   int64
+	MediaDeleted       int64
+	CommentsDeleted    int64
+}
+
+type DeleteAccountUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	objectMoveRepo repositories.ObjectMoveRepository
+	adjustmentRepo repositories.QuantityAdjustmentRepository
+	templateRepo   repositories.ContainerTemplateRepository
+	mealPlanRepo   repositories.MealPlanRepository
+	recurrenceRepo repositories.RecurrenceRuleRepository
+	folderRepo     repositories.CollectionFolderRepository
+	codeRepo       repositories.ObjectCodeRepository
+	snapshotRepo   repositories.CollectionSnapshotRepository
+	digestRepo     repositories.DigestSubscriptionRepository
+	prefsRepo      repositories.UserPreferencesRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
+	commentRepo    repositories.CommentRepository
+	typeRepo       repositories.CustomObjectTypeRepository
+	inboxRepo      repositories.InboxRepository
+	expiryRepo     repositories.ExpiryHistoryRepository
+	automationRepo repositories.AutomationRuleRepository
+	deliveryRepo   repositories.WebhookDeliveryRepository
+	webhookKeyRepo repositories.WebhookSigningKeyRepository
+	sessionRepo    repositories.SessionRepository
+	statusRepo     repositories.AccountStatusRepository
+}
+
+func NewDeleteAccountUseCase(
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	objectMoveRepo repositories.ObjectMoveRepository,
+	adjustmentRepo repositories.QuantityAdjustmentRepository,
+	templateRepo repositories.ContainerTemplateRepository,
+	mealPlanRepo repositories.MealPlanRepository,
+	recurrenceRepo repositories.RecurrenceRuleRepository,
+	folderRepo repositories.CollectionFolderRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	snapshotRepo repositories.CollectionSnapshotRepository,
+	digestRepo repositories.DigestSubscriptionRepository,
+	prefsRepo repositories.UserPreferencesRepository,
+	mediaRepo repositories.MediaRepository,
+	mediaStorage services.MediaStorage,
+	commentRepo repositories.CommentRepository,
+	typeRepo repositories.CustomObjectTypeRepository,
+	inboxRepo repositories.InboxRepository,
+	expiryRepo repositories.ExpiryHistoryRepository,
+	automationRepo repositories.AutomationRuleRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	webhookKeyRepo repositories.WebhookSigningKeyRepository,
+	sessionRepo repositories.SessionRepository,
+	statusRepo repositories.AccountStatusRepository,
+) *DeleteAccountUseCase {
+	return &DeleteAccountUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		objectMoveRepo: objectMoveRepo,
+		adjustmentRepo: adjustmentRepo,
+		templateRepo:   templateRepo,
+		mealPlanRepo:   mealPlanRepo,
+		recurrenceRepo: recurrenceRepo,
+		folderRepo:     folderRepo,
+		codeRepo:       codeRepo,
+		snapshotRepo:   snapshotRepo,
+		digestRepo:     digestRepo,
+		prefsRepo:      prefsRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
+		commentRepo:    commentRepo,
+		typeRepo:       typeRepo,
+		inboxRepo:      inboxRepo,
+		expiryRepo:     expiryRepo,
+		automationRepo: automationRepo,
+		deliveryRepo:   deliveryRepo,
+		webhookKeyRepo: webhookKeyRepo,
+		sessionRepo:    sessionRepo,
+		statusRepo:     statusRepo,
+	}
+}
+
+// Execute removes everything stored for the user: owned collections with
+// their containers, objects, photos, snapshots and comments, the move and
+// quantity history of those objects, comments the user left elsewhere,
+// saved container templates, meal plans, collection folders, custom object
+// types, the email inbox, expiry history, automation rules with their webhook deliveries and
+// signing keys, digest preferences, view preferences, sign-in sessions and
+// the account status kept for admins. Collections shared with the user
+// through a group belong to someone else and are left alone. The identity
+// itself lives in the auth provider and is not touched here.
+func (uc *DeleteAccountUseCase) Execute(ctx context.Context, req DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	collections, err := uc.collectionRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	resp := &DeleteAccountResponse{}
+
+	var objectIDs []entities.ObjectID
+	for _, col := range collections {
+		for _, obj := range col.GetAllObjects() {
+			objectIDs = append(objectIDs, obj.ID())
+		}
+	}
+	resp.ObjectsDeleted = int64(len(objectIDs))
+
+	// History goes first: once the objects are gone their IDs can no longer
+	// be found.
+	resp.MovesDeleted, err = uc.objectMoveRepo.DeleteByObjectIDs(ctx, objectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete object history: %w", err)
+	}
+	if _, err := uc.adjustmentRepo.DeleteByObjectIDs(ctx, objectIDs); err != nil {
+		return nil, fmt.Errorf("failed to delete quantity history: %w", err)
+	}
+
+	for _, col := range collections {
+		deleted, err := uc.containerRepo.DeleteByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete containers of collection %s: %w", col.ID(), err)
+		}
+		resp.ContainersDeleted += deleted
+
+		if err := uc.collectionRepo.Delete(ctx, col.ID()); err != nil {
+			return nil, fmt.Errorf("failed to delete collection %s: %w", col.ID(), err)
+		}
+		resp.CollectionsDeleted++
+
+		snapshots, err := uc.snapshotRepo.DeleteByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete snapshots of collection %s: %w", col.ID(), err)
+		}
+		resp.SnapshotsDeleted += snapshots
+
+		media, err := deleteCollectionMedia(ctx, uc.mediaRepo, uc.mediaStorage, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete photos of collection %s: %w", col.ID(), err)
+		}
+		resp.MediaDeleted += media
+
+		comments, err := uc.commentRepo.DeleteByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete comments of collection %s: %w", col.ID(), err)
+		}
+		resp.CommentsDeleted += comments
+	}
+
+	comments, err := uc.commentRepo.DeleteByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete comments: %w", err)
+	}
+	resp.CommentsDeleted += comments
+
+	resp.TemplatesDeleted, err = uc.templateRepo.DeleteByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete container templates: %w", err)
+	}
+
+	resp.MealPlansDeleted, err = uc.mealPlanRepo.DeleteByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete meal plans: %w", err)
+	}
+
+	if _, err := uc.recurrenceRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete recurrence rules: %w", err)
+	}
+
+	if _, err := uc.folderRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete collection folders: %w", err)
+	}
+
+	if _, err := uc.typeRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete custom object types: %w", err)
+	}
+
+	if _, err := uc.codeRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete object code index: %w", err)
+	}
+
+	if _, err := uc.inboxRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete inbox: %w", err)
+	}
+
+	if _, err := uc.expiryRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete expiry history: %w", err)
+	}
+
+	if _, err := uc.automationRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete automation rules: %w", err)
+	}
+
+	if _, err := uc.deliveryRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	if err := uc.webhookKeyRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete webhook signing keys: %w", err)
+	}
+
+	if err := uc.digestRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete digest preferences: %w", err)
+	}
+
+	if err := uc.prefsRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete view preferences: %w", err)
+	}
+
+	if _, err := uc.sessionRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+
+	if err := uc.statusRepo.DeleteByUserID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete account status: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,212 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestDeleteAccountUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		collectionRepo *mocks.MockCollectionRepository
+		containerRepo  *mocks.MockContainerRepository
+		objectMoveRepo *mocks.MockObjectMoveRepository
+		adjustmentRepo *mocks.MockQuantityAdjustmentRepository
+		templateRepo   *mocks.MockContainerTemplateRepository
+		mealPlanRepo   *mocks.MockMealPlanRepository
+		recurrenceRepo *mocks.MockRecurrenceRuleRepository
+		folderRepo     *mocks.MockCollectionFolderRepository
+		typeRepo       *mocks.MockCustomObjectTypeRepository
+		codeRepo       *mocks.MockObjectCodeRepository
+		snapshotRepo   *mocks.MockCollectionSnapshotRepository
+		digestRepo     *mocks.MockDigestSubscriptionRepository
+		prefsRepo      *mocks.MockUserPreferencesRepository
+		mediaRepo      *mocks.MockMediaRepository
+		mediaStorage   *mocks.MockMediaStorage
+		commentRepo    *mocks.MockCommentRepository
+		inboxRepo      *mocks.MockInboxRepository
+		expiryRepo     *mocks.MockExpiryHistoryRepository
+		automationRepo *mocks.MockAutomationRuleRepository
+		deliveryRepo   *mocks.MockWebhookDeliveryRepository
+		webhookKeyRepo *mocks.MockWebhookSigningKeyRepository
+		sessionRepo    *mocks.MockSessionRepository
+		statusRepo     *mocks.MockAccountStatusRepository
+		useCase        *DeleteAccountUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			objectMoveRepo: mocks.NewMockObjectMoveRepository(mockCtrl),
+			adjustmentRepo: mocks.NewMockQuantityAdjustmentRepository(mockCtrl),
+			templateRepo:   mocks.NewMockContainerTemplateRepository(mockCtrl),
+			mealPlanRepo:   mocks.NewMockMealPlanRepository(mockCtrl),
+			recurrenceRepo: mocks.NewMockRecurrenceRuleRepository(mockCtrl),
+			folderRepo:     mocks.NewMockCollectionFolderRepository(mockCtrl),
+			typeRepo:       mocks.NewMockCustomObjectTypeRepository(mockCtrl),
+			codeRepo:       mocks.NewMockObjectCodeRepository(mockCtrl),
+			snapshotRepo:   mocks.NewMockCollectionSnapshotRepository(mockCtrl),
+			digestRepo:     mocks.NewMockDigestSubscriptionRepository(mockCtrl),
+			prefsRepo:      mocks.NewMockUserPreferencesRepository(mockCtrl),
+			mediaRepo:      mocks.NewMockMediaRepository(mockCtrl),
+			mediaStorage:   mocks.NewMockMediaStorage(mockCtrl),
+			commentRepo:    mocks.NewMockCommentRepository(mockCtrl),
+			inboxRepo:      mocks.NewMockInboxRepository(mockCtrl),
+			expiryRepo:     mocks.NewMockExpiryHistoryRepository(mockCtrl),
+			automationRepo: mocks.NewMockAutomationRuleRepository(mockCtrl),
+			deliveryRepo:   mocks.NewMockWebhookDeliveryRepository(mockCtrl),
+			webhookKeyRepo: mocks.NewMockWebhookSigningKeyRepository(mockCtrl),
+			sessionRepo:    mocks.NewMockSessionRepository(mockCtrl),
+			statusRepo:     mocks.NewMockAccountStatusRepository(mockCtrl),
+		}
+		f.useCase = NewDeleteAccountUseCase(f.collectionRepo, f.containerRepo, f.objectMoveRepo, f.adjustmentRepo, f.templateRepo, f.mealPlanRepo, f.recurrenceRepo, f.folderRepo, f.codeRepo, f.snapshotRepo, f.digestRepo, f.prefsRepo, f.mediaRepo, f.mediaStorage, f.commentRepo, f.typeRepo, f.inboxRepo, f.expiryRepo, f.automationRepo, f.deliveryRepo, f.webhookKeyRepo, f.sessionRepo, f.statusRepo)
+		return f
+	}
+
+	t.Run("success - cascades through everything the user owns", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		lamp := NewTestObject(ObjName("Lamp"))
+		mug := NewTestObject(ObjName("Mug"))
+		kitchen := NewTestCollection(ColUserID(userID), ColContainers(
+			*NewTestContainer(CtrObjects(*lamp)),
+			*NewTestContainer(CtrObjects(*mug)),
+		))
+		empty := NewTestCollection(ColUserID(userID))
+
+		gomock.InOrder(
+			f.collectionRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return([]*entities.Collection{kitchen, empty}, nil),
+			f.objectMoveRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.InAnyOrder([]entities.ObjectID{lamp.ID(), mug.ID()})).Return(int64(3), nil),
+			f.adjustmentRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.InAnyOrder([]entities.ObjectID{lamp.ID(), mug.ID()})).Return(int64(2), nil),
+		)
+		f.containerRepo.EXPECT().DeleteByCollectionID(gomock.Any(), kitchen.ID()).Return(int64(2), nil)
+		f.collectionRepo.EXPECT().Delete(gomock.Any(), kitchen.ID()).Return(nil)
+		f.snapshotRepo.EXPECT().DeleteByCollectionID(gomock.Any(), kitchen.ID()).Return(int64(5), nil)
+		f.mediaRepo.EXPECT().DeleteByCollectionID(gomock.Any(), kitchen.ID()).Return(int64(6), nil)
+		f.mediaStorage.EXPECT().DeleteCollection(gomock.Any(), kitchen.ID()).Return(nil)
+		f.commentRepo.EXPECT().DeleteByCollectionID(gomock.Any(), kitchen.ID()).Return(int64(7), nil)
+		f.containerRepo.EXPECT().DeleteByCollectionID(gomock.Any(), empty.ID()).Return(int64(0), nil)
+		f.collectionRepo.EXPECT().Delete(gomock.Any(), empty.ID()).Return(nil)
+		f.snapshotRepo.EXPECT().DeleteByCollectionID(gomock.Any(), empty.ID()).Return(int64(0), nil)
+		f.mediaRepo.EXPECT().DeleteByCollectionID(gomock.Any(), empty.ID()).Return(int64(0), nil)
+		f.mediaStorage.EXPECT().DeleteCollection(gomock.Any(), empty.ID()).Return(nil)
+		f.commentRepo.EXPECT().DeleteByCollectionID(gomock.Any(), empty.ID()).Return(int64(0), nil)
+		f.commentRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(2), nil)
+		f.templateRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(1), nil)
+		f.mealPlanRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(4), nil)
+		f.recurrenceRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(2), nil)
+		f.folderRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(2), nil)
+		f.typeRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(1), nil)
+		f.codeRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(4), nil)
+		f.inboxRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.expiryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.automationRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.deliveryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.webhookKeyRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.digestRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.prefsRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(3), nil)
+		f.statusRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), DeleteAccountRequest{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, &DeleteAccountResponse{
+			CollectionsDeleted: 2,
+			ContainersDeleted:  2,
+			ObjectsDeleted:     2,
+			MovesDeleted:       3,
+			TemplatesDeleted:   1,
+			MealPlansDeleted:   4,
+			SnapshotsDeleted:   5,
+			MediaDeleted:       6,
+			CommentsDeleted:    9,
+		}, resp)
+	})
+
+	t.Run("success - account without collections", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.collectionRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, nil)
+		f.objectMoveRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.Len(0)).Return(int64(0), nil)
+		f.adjustmentRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.Len(0)).Return(int64(0), nil)
+		f.commentRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.templateRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.mealPlanRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.recurrenceRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.folderRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.typeRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.codeRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.inboxRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.expiryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.automationRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.deliveryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.webhookKeyRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.digestRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.prefsRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.statusRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), DeleteAccountRequest{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, &DeleteAccountResponse{}, resp)
+	})
+
+	t.Run("error - stops before deleting collections when history fails", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID), ColContainers(*NewTestContainer(CtrObjects(*NewTestObject()))))
+
+		f.collectionRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return([]*entities.Collection{collection}, nil)
+		f.objectMoveRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db down"))
+
+		resp, err := f.useCase.Execute(context.Background(), DeleteAccountRequest{UserID: userID})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "failed to delete object history")
+	})
+
+	t.Run("error - reports a failure to end the sessions", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.collectionRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, nil)
+		f.objectMoveRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.Len(0)).Return(int64(0), nil)
+		f.adjustmentRepo.EXPECT().DeleteByObjectIDs(gomock.Any(), gomock.Len(0)).Return(int64(0), nil)
+		f.commentRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.templateRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.mealPlanRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.recurrenceRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.folderRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.typeRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.codeRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.inboxRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.expiryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.automationRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.deliveryRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		f.webhookKeyRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.digestRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.prefsRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(nil)
+		f.sessionRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return(int64(0), errors.New("db down"))
+
+		resp, err := f.useCase.Execute(context.Background(), DeleteAccountRequest{UserID: userID})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "failed to delete sessions")
+	})
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type DeleteCollectionFolderRequest struct {
+	FolderID entities.CollectionFolderID
+	UserID   entities.UserID
+}
+
+type DeleteCollectionFolderUseCase struct {
+	folderRepo repositories.CollectionFolderRepository
+}
+
+func NewDeleteCollectionFolderUseCase(folderRepo repositories.CollectionFolderRepository) *DeleteCollectionFolderUseCase {
+	return &DeleteCollectionFolderUseCase{
+		folderRepo: folderRepo,
+	}
+}
+
+// Execute deletes the folder but nothing in it: its subfolders and
+// collections move up into its parent, or to the top level when it has none.
+func (uc *DeleteCollectionFolderUseCase) Execute(ctx context.Context, req DeleteCollectionFolderRequest) error {
+	folder, err := uc.folderRepo.GetByID(ctx, req.FolderID)
+	if err != nil {
+		return err
+	}
+	if !folder.IsOwnedBy(req.UserID) {
+		return entities.ErrCollectionFolderNotFound
+	}
+
+	folders, err := uc.folderRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to list collection folders: %w", err)
+	}
+
+	for _, f := range folders {
+		if p := f.ParentID(); p == nil || !p.Equals(folder.ID()) {
+			continue
+		}
+		f.MoveTo(folder.ParentID())
+		if err := uc.folderRepo.Update(ctx, f); err != nil {
+			return fmt.Errorf("failed to move subfolder %s: %w", f.ID(), err)
+		}
+	}
+
+	if parentID := folder.ParentID(); parentID != nil && len(folder.CollectionIDs()) > 0 {
+		for _, f := range folders {
+			if !f.ID().Equals(*parentID) {
+				continue
+			}
+			for _, id := range folder.CollectionIDs() {
+				f.AddCollection(id)
+			}
+			if err := uc.folderRepo.Update(ctx, f); err != nil {
+				return fmt.Errorf("failed to move collections to parent folder: %w", err)
+			}
+		}
+	}
+
+	if err := uc.folderRepo.Delete(ctx, folder.ID()); err != nil {
+		return fmt.Errorf("failed to delete collection folder: %w", err)
+	}
+
+	return nil
+}
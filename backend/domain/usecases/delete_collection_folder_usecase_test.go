@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestDeleteCollectionFolderUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFolderRepo := mocks.NewMockCollectionFolderRepository(mockCtrl)
+
+	useCase := NewDeleteCollectionFolderUseCase(mockFolderRepo)
+
+	t.Run("success - contents move up to the parent", func(t *testing.T) {
+		userID := entities.NewUserID()
+		pantryID := entities.NewCollectionID()
+		freezerID := entities.NewCollectionID()
+		home := NewTestFolder(userID, "Home", nil, freezerID)
+		kitchen := NewTestFolder(userID, "Kitchen", home, pantryID)
+		drawers := NewTestFolder(userID, "Drawers", kitchen)
+		garage := NewTestFolder(userID, "Garage", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), kitchen.ID()).Return(kitchen, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{drawers, garage, home, kitchen}, nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), drawers).Return(nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), home).Return(nil)
+		mockFolderRepo.EXPECT().Delete(gomock.Any(), kitchen.ID()).Return(nil)
+
+		err := useCase.Execute(context.Background(), DeleteCollectionFolderRequest{
+			FolderID: kitchen.ID(),
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, drawers.ParentID())
+		assert.Equal(t, home.ID(), *drawers.ParentID())
+		assert.Equal(t, []entities.CollectionID{freezerID, pantryID}, home.CollectionIDs())
+	})
+
+	t.Run("success - top level folder leaves collections unfiled", func(t *testing.T) {
+		userID := entities.NewUserID()
+		home := NewTestFolder(userID, "Home", nil, entities.NewCollectionID())
+		kitchen := NewTestFolder(userID, "Kitchen", home)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), home.ID()).Return(home, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{home, kitchen}, nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), kitchen).Return(nil)
+		mockFolderRepo.EXPECT().Delete(gomock.Any(), home.ID()).Return(nil)
+
+		err := useCase.Execute(context.Background(), DeleteCollectionFolderRequest{
+			FolderID: home.ID(),
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, kitchen.ParentID())
+	})
+
+	t.Run("error - another user's folder", func(t *testing.T) {
+		folder := NewTestFolder(entities.NewUserID(), "Theirs", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), folder.ID()).Return(folder, nil)
+
+		err := useCase.Execute(context.Background(), DeleteCollectionFolderRequest{
+			FolderID: folder.ID(),
+			UserID:   entities.NewUserID(),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrCollectionFolderNotFound)
+	})
+}
@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type DeleteCollectionSnapshotRequest struct {
+	CollectionID entities.CollectionID
+	SnapshotID   entities.CollectionSnapshotID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type DeleteCollectionSnapshotUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	snapshotRepo   repositories.CollectionSnapshotRepository
+	authService    services.AuthService
+}
+
+func NewDeleteCollectionSnapshotUseCase(collectionRepo repositories.CollectionRepository, snapshotRepo repositories.CollectionSnapshotRepository, authService services.AuthService) *DeleteCollectionSnapshotUseCase {
+	return &DeleteCollectionSnapshotUseCase{
+		collectionRepo: collectionRepo,
+		snapshotRepo:   snapshotRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *DeleteCollectionSnapshotUseCase) Execute(ctx context.Context, req DeleteCollectionSnapshotRequest) error {
+	if _, err := getManagedCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken); err != nil {
+		return err
+	}
+	if _, err := getCollectionSnapshot(ctx, uc.snapshotRepo, req.CollectionID, req.SnapshotID); err != nil {
+		return err
+	}
+
+	return uc.snapshotRepo.Delete(ctx, req.SnapshotID)
+}
+
+// getCollectionSnapshot loads a snapshot, reporting snapshots of other
+// collections as not found.
+func getCollectionSnapshot(ctx context.Context, snapshotRepo repositories.CollectionSnapshotRepository, collectionID entities.CollectionID, snapshotID entities.CollectionSnapshotID) (*entities.CollectionSnapshot, error) {
+	snapshot, err := snapshotRepo.GetByID(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if !snapshot.CollectionID().Equals(collectionID) {
+		return nil, entities.ErrCollectionSnapshotNotFound
+	}
+	return snapshot, nil
+}
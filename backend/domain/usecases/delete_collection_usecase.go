@@ -7,28 +7,43 @@ import (
 
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
 )
 
 type DeleteCollectionRequest struct {
 	CollectionID entities.CollectionID
 	UserID       entities.UserID
 	Force        bool
+	UserToken    string
 }
 
 type DeleteCollectionResponse struct {
 	Success           bool
 	ContainersDeleted int64
+	SnapshotsDeleted  int64
+	MediaDeleted      int64
+	CommentsDeleted   int64
 }
 
 type DeleteCollectionUseCase struct {
 	collectionRepo repositories.CollectionRepository
 	containerRepo  repositories.ContainerRepository
+	snapshotRepo   repositories.CollectionSnapshotRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
+	commentRepo    repositories.CommentRepository
+	authService    services.AuthService
 }
 
-func NewDeleteCollectionUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository) *DeleteCollectionUseCase {
+func NewDeleteCollectionUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, snapshotRepo repositories.CollectionSnapshotRepository, mediaRepo repositories.MediaRepository, mediaStorage services.MediaStorage, commentRepo repositories.CommentRepository, authService services.AuthService) *DeleteCollectionUseCase {
 	return &DeleteCollectionUseCase{
 		collectionRepo: collectionRepo,
 		containerRepo:  containerRepo,
+		snapshotRepo:   snapshotRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
+		commentRepo:    commentRepo,
+		authService:    authService,
 	}
 }
 
@@ -39,9 +54,13 @@ func (uc *DeleteCollectionUseCase) Execute(ctx context.Context, req DeleteCollec
 		return nil, errors.New("collection not found")
 	}
 
-	// Validate access - only owner can delete
-	if !collection.UserID().Equals(req.UserID) {
-		return nil, errors.New("access denied: only collection owner can delete")
+	// Validate access - only the owner (or an admin of the owning group) can delete
+	canControl, err := canControlCollection(ctx, uc.authService, collection, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if !canControl {
+		return nil, errors.New("access denied: only collection owner or group admin can delete")
 	}
 
 	// If collection has containers, require force flag
@@ -63,8 +82,26 @@ func (uc *DeleteCollectionUseCase) Execute(ctx context.Context, req DeleteCollec
 		return nil, fmt.Errorf("failed to delete collection: %w", err)
 	}
 
+	snapshotsDeleted, err := uc.snapshotRepo.DeleteByCollectionID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete snapshots: %w", err)
+	}
+
+	mediaDeleted, err := deleteCollectionMedia(ctx, uc.mediaRepo, uc.mediaStorage, req.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	commentsDeleted, err := uc.commentRepo.DeleteByCollectionID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete comments: %w", err)
+	}
+
 	return &DeleteCollectionResponse{
 		Success:           true,
 		ContainersDeleted: containersDeleted,
+		SnapshotsDeleted:  snapshotsDeleted,
+		MediaDeleted:      mediaDeleted,
+		CommentsDeleted:   commentsDeleted,
 	}, nil
 }
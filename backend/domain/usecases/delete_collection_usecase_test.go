@@ -21,8 +21,13 @@ func TestDeleteCollectionUseCase_Execute(t *testing.T) {
 
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
 	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockSnapshotRepo := mocks.NewMockCollectionSnapshotRepository(mockCtrl)
+	mockMediaRepo := mocks.NewMockMediaRepository(mockCtrl)
+	mockMediaStorage := mocks.NewMockMediaStorage(mockCtrl)
+	mockCommentRepo := mocks.NewMockCommentRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewDeleteCollectionUseCase(mockCollectionRepo, mockContainerRepo)
+	useCase := NewDeleteCollectionUseCase(mockCollectionRepo, mockContainerRepo, mockSnapshotRepo, mockMediaRepo, mockMediaStorage, mockCommentRepo, mockAuthService)
 
 	t.Run("success - delete empty collection", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -34,6 +39,10 @@ func TestDeleteCollectionUseCase_Execute(t *testing.T) {
 
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
 		mockCollectionRepo.EXPECT().Delete(gomock.Any(), collectionID).Return(nil)
+		mockSnapshotRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+		mockMediaRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+		mockMediaStorage.EXPECT().DeleteCollection(gomock.Any(), collectionID).Return(nil)
+		mockCommentRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -55,6 +64,10 @@ func TestDeleteCollectionUseCase_Execute(t *testing.T) {
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
 		mockContainerRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(1), nil)
 		mockCollectionRepo.EXPECT().Delete(gomock.Any(), collectionID).Return(nil)
+		mockSnapshotRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+		mockMediaRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+		mockMediaStorage.EXPECT().DeleteCollection(gomock.Any(), collectionID).Return(nil)
+		mockCommentRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -115,6 +128,72 @@ func TestDeleteCollectionUseCase_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "access denied")
 	})
 
+	t.Run("success - group admin deletes group-owned collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		group := NewTestGroup()
+		groupID := group.ID()
+
+		collection := NewTestCollection(ColID(collectionID), ColGroupID(&groupID), ColGroupOwned())
+
+		req := DeleteCollectionRequest{CollectionID: collectionID, UserID: userID, UserToken: "test-token"}
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "test-token", groupID.String()).
+			Return(map[string]entities.GroupRole{userID.String(): entities.GroupRoleAdmin}, nil)
+		mockCollectionRepo.EXPECT().Delete(gomock.Any(), collectionID).Return(nil)
+		mockSnapshotRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+		mockMediaRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+		mockMediaStorage.EXPECT().DeleteCollection(gomock.Any(), collectionID).Return(nil)
+		mockCommentRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(0), nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+	})
+
+	t.Run("error - plain member cannot delete group-owned collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		group := NewTestGroup()
+		groupID := group.ID()
+
+		collection := NewTestCollection(ColID(collectionID), ColGroupID(&groupID), ColGroupOwned())
+
+		req := DeleteCollectionRequest{CollectionID: collectionID, UserID: userID, UserToken: "test-token"}
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "test-token", groupID.String()).Return(map[string]entities.GroupRole{}, nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - creator who left the group loses a group-owned collection", func(t *testing.T) {
+		creatorID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		groupID := entities.NewGroupID()
+
+		collection := NewTestCollection(ColID(collectionID), ColUserID(creatorID), ColGroupID(&groupID), ColGroupOwned())
+
+		req := DeleteCollectionRequest{CollectionID: collectionID, UserID: creatorID, UserToken: "test-token"}
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", creatorID.String()).Return([]*entities.Group{}, nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
 	t.Run("error - repository delete failure", func(t *testing.T) {
 		userID := entities.NewUserID()
 		collectionID := entities.NewCollectionID()
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type DeleteCommentRequest struct {
+	CommentID entities.CommentID
+	UserID    entities.UserID
+	UserToken string
+}
+
+type DeleteCommentUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	commentRepo    repositories.CommentRepository
+	authService    services.AuthService
+}
+
+func NewDeleteCommentUseCase(collectionRepo repositories.CollectionRepository, commentRepo repositories.CommentRepository, authService services.AuthService) *DeleteCommentUseCase {
+	return &DeleteCommentUseCase{
+		collectionRepo: collectionRepo,
+		commentRepo:    commentRepo,
+		authService:    authService,
+	}
+}
+
+// Execute deletes a comment for its author, or for anyone who can manage the
+// collection it is on.
+func (uc *DeleteCommentUseCase) Execute(ctx context.Context, req DeleteCommentRequest) error {
+	comment, err := uc.commentRepo.GetByID(ctx, req.CommentID)
+	if err != nil {
+		return err
+	}
+
+	if !comment.IsAuthoredBy(req.UserID) {
+		collection, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, comment.CollectionID(), req.UserID, req.UserToken)
+		if err != nil {
+			// Comments in collections the user cannot see do not exist for them
+			return entities.ErrCommentNotFound
+		}
+		canManage, err := canManageCollection(ctx, uc.authService, collection, req.UserID, req.UserToken)
+		if err != nil {
+			return err
+		}
+		if !canManage {
+			return errors.New("access denied: only the author or the collection owner can delete a comment")
+		}
+	}
+
+	return uc.commentRepo.Delete(ctx, req.CommentID)
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type DeleteContainerTemplateRequest struct {
+	TemplateID entities.ContainerTemplateID
+	UserID     entities.UserID
+}
+
+type DeleteContainerTemplateUseCase struct {
+	templateRepo repositories.ContainerTemplateRepository
+}
+
+func NewDeleteContainerTemplateUseCase(templateRepo repositories.ContainerTemplateRepository) *DeleteContainerTemplateUseCase {
+	return &DeleteContainerTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+func (uc *DeleteContainerTemplateUseCase) Execute(ctx context.Context, req DeleteContainerTemplateRequest) error {
+	if req.TemplateID.IsBuiltIn() {
+		return entities.ErrBuiltInContainerTemplate
+	}
+
+	template, err := uc.templateRepo.GetByID(ctx, req.TemplateID)
+	if err != nil {
+		return err
+	}
+	// Other users' templates are reported as missing rather than forbidden so
+	// template IDs cannot be probed.
+	if !template.IsOwnedBy(req.UserID) {
+		return entities.ErrContainerTemplateNotFound
+	}
+
+	if err := uc.templateRepo.Delete(ctx, req.TemplateID); err != nil {
+		return fmt.Errorf("failed to delete container template: %w", err)
+	}
+
+	return nil
+}
@@ -23,13 +23,17 @@ type DeleteContainerResponse struct {
 type DeleteContainerUseCase struct {
 	containerRepo  repositories.ContainerRepository
 	collectionRepo repositories.CollectionRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
 	authService    services.AuthService
 }
 
-func NewDeleteContainerUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *DeleteContainerUseCase {
+func NewDeleteContainerUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, mediaRepo repositories.MediaRepository, mediaStorage services.MediaStorage, authService services.AuthService) *DeleteContainerUseCase {
 	return &DeleteContainerUseCase{
 		containerRepo:  containerRepo,
 		collectionRepo: collectionRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
 		authService:    authService,
 	}
 }
@@ -52,7 +56,7 @@ func (uc *DeleteContainerUseCase) Execute(ctx context.Context, req DeleteContain
 		return nil, errors.New("collection not found")
 	}
 
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -89,5 +93,15 @@ func (uc *DeleteContainerUseCase) Execute(ctx context.Context, req DeleteContain
 		return nil, fmt.Errorf("failed to delete container: %w", err)
 	}
 
+	// Photos of the container and of the objects that went with it
+	if err := deleteOwnerMedia(ctx, uc.mediaRepo, uc.mediaStorage, entities.MediaOwnerContainer, req.ContainerID.String()); err != nil {
+		return nil, err
+	}
+	for _, obj := range container.Objects() {
+		if err := deleteOwnerMedia(ctx, uc.mediaRepo, uc.mediaStorage, entities.MediaOwnerObject, obj.ID().String()); err != nil {
+			return nil, err
+		}
+	}
+
 	return &DeleteContainerResponse{Success: true}, nil
 }
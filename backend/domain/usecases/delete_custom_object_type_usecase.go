@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type DeleteCustomObjectTypeRequest struct {
+	TypeID entities.CustomObjectTypeID
+	UserID entities.UserID
+}
+
+type DeleteCustomObjectTypeUseCase struct {
+	typeRepo       repositories.CustomObjectTypeRepository
+	collectionRepo repositories.CollectionRepository
+}
+
+func NewDeleteCustomObjectTypeUseCase(typeRepo repositories.CustomObjectTypeRepository, collectionRepo repositories.CollectionRepository) *DeleteCustomObjectTypeUseCase {
+	return &DeleteCustomObjectTypeUseCase{
+		typeRepo:       typeRepo,
+		collectionRepo: collectionRepo,
+	}
+}
+
+// Execute deletes the type unless one of the user's collections still uses
+// it; those would be left with a type nothing describes.
+func (uc *DeleteCustomObjectTypeUseCase) Execute(ctx context.Context, req DeleteCustomObjectTypeRequest) error {
+	objectType, err := uc.typeRepo.GetByID(ctx, req.TypeID)
+	if err != nil {
+		return err
+	}
+	if !objectType.IsOwnedBy(req.UserID) {
+		return entities.ErrCustomObjectTypeNotFound
+	}
+
+	collections, err := uc.collectionRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get collections: %w", err)
+	}
+	for _, col := range collections {
+		if col.ObjectType() == objectType.Key() {
+			return fmt.Errorf("%w: %s", entities.ErrCustomObjectTypeInUse, col.Name())
+		}
+	}
+
+	if err := uc.typeRepo.Delete(ctx, objectType.ID()); err != nil {
+		return fmt.Errorf("failed to delete custom object type: %w", err)
+	}
+
+	return nil
+}
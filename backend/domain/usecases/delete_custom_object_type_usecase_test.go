@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestDeleteCustomObjectTypeUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTypeRepo := mocks.NewMockCustomObjectTypeRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+
+	useCase := NewDeleteCustomObjectTypeUseCase(mockTypeRepo, mockCollectionRepo)
+
+	newWine := func(t *testing.T, userID entities.UserID) *entities.CustomObjectType {
+		t.Helper()
+		wine, err := entities.NewCustomObjectType(userID, "wine", "Wine", "", "", nil)
+		require.NoError(t, err)
+		return wine
+	}
+
+	t.Run("success - unused type", func(t *testing.T) {
+		userID := entities.NewUserID()
+		wine := newWine(t, userID)
+
+		mockTypeRepo.EXPECT().GetByID(gomock.Any(), wine.ID()).Return(wine, nil)
+		mockCollectionRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return([]*entities.Collection{
+			NewTestCollection(ColUserID(userID), ColObjectType(entities.ObjectTypeFood)),
+		}, nil)
+		mockTypeRepo.EXPECT().Delete(gomock.Any(), wine.ID()).Return(nil)
+
+		err := useCase.Execute(context.Background(), DeleteCustomObjectTypeRequest{TypeID: wine.ID(), UserID: userID})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error - type used by a collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		wine := newWine(t, userID)
+
+		mockTypeRepo.EXPECT().GetByID(gomock.Any(), wine.ID()).Return(wine, nil)
+		mockCollectionRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return([]*entities.Collection{
+			NewTestCollection(ColUserID(userID), ColName("Cellar"), ColObjectType("wine")),
+		}, nil)
+
+		err := useCase.Execute(context.Background(), DeleteCustomObjectTypeRequest{TypeID: wine.ID(), UserID: userID})
+
+		require.ErrorIs(t, err, entities.ErrCustomObjectTypeInUse)
+		assert.Contains(t, err.Error(), "Cellar")
+	})
+
+	t.Run("error - another user's type", func(t *testing.T) {
+		wine := newWine(t, entities.NewUserID())
+
+		mockTypeRepo.EXPECT().GetByID(gomock.Any(), wine.ID()).Return(wine, nil)
+
+		err := useCase.Execute(context.Background(), DeleteCustomObjectTypeRequest{TypeID: wine.ID(), UserID: entities.NewUserID()})
+
+		assert.ErrorIs(t, err, entities.ErrCustomObjectTypeNotFound)
+	})
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type DeleteMealPlanRequest struct {
+	MealPlanID entities.MealPlanID
+	UserID     entities.UserID
+}
+
+type DeleteMealPlanUseCase struct {
+	mealPlanRepo repositories.MealPlanRepository
+}
+
+func NewDeleteMealPlanUseCase(mealPlanRepo repositories.MealPlanRepository) *DeleteMealPlanUseCase {
+	return &DeleteMealPlanUseCase{
+		mealPlanRepo: mealPlanRepo,
+	}
+}
+
+// Execute deletes the plan. Quantities used up by completing it are not
+// restored.
+func (uc *DeleteMealPlanUseCase) Execute(ctx context.Context, req DeleteMealPlanRequest) error {
+	plan, err := uc.mealPlanRepo.GetByID(ctx, req.MealPlanID)
+	if err != nil {
+		return err
+	}
+	if !plan.IsOwnedBy(req.UserID) {
+		return entities.ErrMealPlanNotFound
+	}
+
+	if err := uc.mealPlanRepo.Delete(ctx, req.MealPlanID); err != nil {
+		return fmt.Errorf("failed to delete meal plan: %w", err)
+	}
+
+	return nil
+}
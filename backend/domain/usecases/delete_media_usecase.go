@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type DeleteMediaRequest struct {
+	MediaID   entities.MediaID
+	UserID    entities.UserID
+	UserToken string
+}
+
+type DeleteMediaUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
+	authService    services.AuthService
+}
+
+func NewDeleteMediaUseCase(collectionRepo repositories.CollectionRepository, mediaRepo repositories.MediaRepository, mediaStorage services.MediaStorage, authService services.AuthService) *DeleteMediaUseCase {
+	return &DeleteMediaUseCase{
+		collectionRepo: collectionRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
+		authService:    authService,
+	}
+}
+
+func (uc *DeleteMediaUseCase) Execute(ctx context.Context, req DeleteMediaRequest) error {
+	media, err := uc.mediaRepo.GetByID(ctx, req.MediaID)
+	if err != nil {
+		return err
+	}
+	if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, media.CollectionID(), req.UserID, req.UserToken); err != nil {
+		return err
+	}
+
+	if err := uc.mediaRepo.Delete(ctx, req.MediaID); err != nil {
+		return err
+	}
+	if err := uc.mediaStorage.Delete(ctx, media.CollectionID(), media.ID()); err != nil {
+		return fmt.Errorf("failed to delete photo file: %w", err)
+	}
+	return nil
+}
+
+// deleteOwnerMedia removes the photos attached to a deleted object or
+// container, files included.
+func deleteOwnerMedia(ctx context.Context, mediaRepo repositories.MediaRepository, mediaStorage services.MediaStorage, kind entities.MediaOwnerKind, ownerID string) error {
+	var media []*entities.Media
+	for offset := 0; ; offset += entities.MaxMediaPageLimit {
+		page, _, err := mediaRepo.ListByOwner(ctx, kind, ownerID, entities.MediaPage{Limit: entities.MaxMediaPageLimit, Offset: offset})
+		if err != nil {
+			return fmt.Errorf("failed to list photos: %w", err)
+		}
+		media = append(media, page...)
+		if len(page) < entities.MaxMediaPageLimit {
+			break
+		}
+	}
+	if len(media) == 0 {
+		return nil
+	}
+
+	if _, err := mediaRepo.DeleteByOwner(ctx, kind, ownerID); err != nil {
+		return fmt.Errorf("failed to delete photos: %w", err)
+	}
+	for _, m := range media {
+		if err := mediaStorage.Delete(ctx, m.CollectionID(), m.ID()); err != nil {
+			return fmt.Errorf("failed to delete photo file: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteCollectionMedia removes every photo of a deleted collection, files
+// included, and returns how many there were.
+func deleteCollectionMedia(ctx context.Context, mediaRepo repositories.MediaRepository, mediaStorage services.MediaStorage, collectionID entities.CollectionID) (int64, error) {
+	deleted, err := mediaRepo.DeleteByCollectionID(ctx, collectionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete photos: %w", err)
+	}
+	if err := mediaStorage.DeleteCollection(ctx, collectionID); err != nil {
+		return deleted, fmt.Errorf("failed to delete photo files: %w", err)
+	}
+	return deleted, nil
+}
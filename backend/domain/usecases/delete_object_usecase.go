@@ -24,13 +24,17 @@ type DeleteObjectResponse struct {
 type DeleteObjectUseCase struct {
 	containerRepo  repositories.ContainerRepository
 	collectionRepo repositories.CollectionRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
 	authService    services.AuthService
 }
 
-func NewDeleteObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *DeleteObjectUseCase {
+func NewDeleteObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, mediaRepo repositories.MediaRepository, mediaStorage services.MediaStorage, authService services.AuthService) *DeleteObjectUseCase {
 	return &DeleteObjectUseCase{
 		containerRepo:  containerRepo,
 		collectionRepo: collectionRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
 		authService:    authService,
 	}
 }
@@ -60,7 +64,7 @@ func (uc *DeleteObjectUseCase) Execute(ctx context.Context, req DeleteObjectRequ
 	}
 
 	// Check access: user is owner OR user is member of collection's group
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -79,6 +83,10 @@ func (uc *DeleteObjectUseCase) Execute(ctx context.Context, req DeleteObjectRequ
 		return nil, fmt.Errorf("failed to remove object from container: %w", err)
 	}
 
+	if err := deleteOwnerMedia(ctx, uc.mediaRepo, uc.mediaStorage, entities.MediaOwnerObject, req.ObjectID.String()); err != nil {
+		return nil, err
+	}
+
 	return &DeleteObjectResponse{
 		Success: true,
 	}, nil
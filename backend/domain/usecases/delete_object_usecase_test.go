@@ -21,9 +21,11 @@ func TestDeleteObjectUseCase_Execute(t *testing.T) {
 
 	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockMediaRepo := mocks.NewMockMediaRepository(mockCtrl)
+	mockMediaStorage := mocks.NewMockMediaStorage(mockCtrl)
 	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewDeleteObjectUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+	useCase := NewDeleteObjectUseCase(mockContainerRepo, mockCollectionRepo, mockMediaRepo, mockMediaStorage, mockAuthService)
 
 	t.Run("success - delete object from container", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -46,6 +48,7 @@ func TestDeleteObjectUseCase_Execute(t *testing.T) {
 		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
 		mockContainerRepo.EXPECT().RemoveObject(gomock.Any(), containerID, objectID).Return(nil)
+		mockMediaRepo.EXPECT().ListByOwner(gomock.Any(), entities.MediaOwnerObject, objectID.String(), gomock.Any()).Return(nil, int64(0), nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -78,6 +81,7 @@ func TestDeleteObjectUseCase_Execute(t *testing.T) {
 		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{testGroup}, nil)
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
 		mockContainerRepo.EXPECT().RemoveObject(gomock.Any(), containerID, objectID).Return(nil)
+		mockMediaRepo.EXPECT().ListByOwner(gomock.Any(), entities.MediaOwnerObject, objectID.String(), gomock.Any()).Return(nil, int64(0), nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -182,6 +186,7 @@ func TestDeleteObjectUseCase_Execute(t *testing.T) {
 		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
 		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
 		mockContainerRepo.EXPECT().RemoveObject(gomock.Any(), containerID, objectID).Return(nil)
+		mockMediaRepo.EXPECT().ListByOwner(gomock.Any(), entities.MediaOwnerObject, objectID.String(), gomock.Any()).Return(nil, int64(0), nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
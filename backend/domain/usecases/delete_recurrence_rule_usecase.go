@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type DeleteRecurrenceRuleRequest struct {
+	RuleID entities.RecurrenceRuleID
+	UserID entities.UserID
+}
+
+type DeleteRecurrenceRuleUseCase struct {
+	recurrenceRepo repositories.RecurrenceRuleRepository
+}
+
+func NewDeleteRecurrenceRuleUseCase(recurrenceRepo repositories.RecurrenceRuleRepository) *DeleteRecurrenceRuleUseCase {
+	return &DeleteRecurrenceRuleUseCase{
+		recurrenceRepo: recurrenceRepo,
+	}
+}
+
+// Execute stops the staple from recurring. The object itself is kept.
+func (uc *DeleteRecurrenceRuleUseCase) Execute(ctx context.Context, req DeleteRecurrenceRuleRequest) error {
+	rule, err := uc.recurrenceRepo.GetByID(ctx, req.RuleID)
+	if err != nil {
+		return err
+	}
+	if !rule.IsOwnedBy(req.UserID) {
+		return entities.ErrRecurrenceRuleNotFound
+	}
+
+	if err := uc.recurrenceRepo.Delete(ctx, req.RuleID); err != nil {
+		return fmt.Errorf("failed to delete recurrence rule: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type DiffCollectionSnapshotRequest struct {
+	CollectionID entities.CollectionID
+	SnapshotID   entities.CollectionSnapshotID
+	// AgainstID is a later snapshot to compare with. Nil compares with the
+	// collection as it is now.
+	AgainstID *entities.CollectionSnapshotID
+	UserID    entities.UserID
+	UserToken string
+}
+
+type DiffCollectionSnapshotResponse struct {
+	From *entities.CollectionSnapshot
+	// To is nil when comparing with the current state.
+	To   *entities.CollectionSnapshot
+	Diff entities.CollectionDiff
+}
+
+type DiffCollectionSnapshotUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	snapshotRepo   repositories.CollectionSnapshotRepository
+	authService    services.AuthService
+}
+
+func NewDiffCollectionSnapshotUseCase(collectionRepo repositories.CollectionRepository, snapshotRepo repositories.CollectionSnapshotRepository, authService services.AuthService) *DiffCollectionSnapshotUseCase {
+	return &DiffCollectionSnapshotUseCase{
+		collectionRepo: collectionRepo,
+		snapshotRepo:   snapshotRepo,
+		authService:    authService,
+	}
+}
+
+// Execute reports what changed from the snapshot to the later state: objects
+// added since the snapshot are "added", objects gone since are "removed".
+func (uc *DiffCollectionSnapshotUseCase) Execute(ctx context.Context, req DiffCollectionSnapshotRequest) (*DiffCollectionSnapshotResponse, error) {
+	collection, err := getManagedCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := getCollectionSnapshot(ctx, uc.snapshotRepo, req.CollectionID, req.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DiffCollectionSnapshotResponse{From: from}
+	after := collection.Containers()
+	if req.AgainstID != nil {
+		resp.To, err = getCollectionSnapshot(ctx, uc.snapshotRepo, req.CollectionID, *req.AgainstID)
+		if err != nil {
+			return nil, err
+		}
+		after = resp.To.Containers()
+	}
+
+	resp.Diff = entities.DiffContainers(from.Containers(), after)
+	return resp, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestDiffCollectionSnapshotUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockSnapshotRepo := mocks.NewMockCollectionSnapshotRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewDiffCollectionSnapshotUseCase(mockCollectionRepo, mockSnapshotRepo, mockAuthService)
+
+	userID := entities.NewUserID()
+	collectionID := entities.NewCollectionID()
+	shelfID := entities.NewContainerID()
+	riceID := entities.NewObjectID()
+	beansID := entities.NewObjectID()
+	flourID := entities.NewObjectID()
+
+	before := []entities.Container{
+		*NewTestContainer(CtrID(shelfID), CtrName("Shelf"), CtrCollectionID(collectionID), CtrObjects(
+			*NewTestObject(ObjID(riceID), ObjName("Rice"), ObjQuantity(2)),
+			*NewTestObject(ObjID(beansID), ObjName("Beans")),
+			*NewTestObject(ObjID(flourID), ObjName("Flour")),
+		)),
+	}
+	bin := NewTestContainer(CtrName("Bin"), CtrCollectionID(collectionID), CtrObjects(
+		*NewTestObject(ObjID(beansID), ObjName("Beans")),
+		*NewTestObject(ObjName("Oats")),
+	))
+	after := []entities.Container{
+		*NewTestContainer(CtrID(shelfID), CtrName("Top shelf"), CtrCollectionID(collectionID), CtrObjects(
+			*NewTestObject(ObjID(riceID), ObjName("Rice"), ObjQuantity(1)),
+		)),
+		*bin,
+	}
+
+	t.Run("success - against current state", func(t *testing.T) {
+		snapshot := newStoredSnapshot(collectionID, time.Now().Add(-time.Hour), before...)
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(after...))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().GetByID(gomock.Any(), snapshot.ID()).Return(snapshot, nil)
+
+		resp, err := useCase.Execute(context.Background(), DiffCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			SnapshotID:   snapshot.ID(),
+			UserID:       userID,
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.To)
+		diff := resp.Diff
+
+		require.Len(t, diff.ContainersAdded, 1)
+		assert.Equal(t, "Bin", diff.ContainersAdded[0].Name)
+		assert.Empty(t, diff.ContainersRemoved)
+		require.Len(t, diff.ContainersChanged, 1)
+		assert.Equal(t, []string{"name"}, diff.ContainersChanged[0].Fields)
+
+		require.Len(t, diff.ObjectsAdded, 1)
+		assert.Equal(t, "Oats", diff.ObjectsAdded[0].Name)
+		require.Len(t, diff.ObjectsRemoved, 1)
+		assert.Equal(t, "Flour", diff.ObjectsRemoved[0].Name)
+
+		changed := make(map[string][]string)
+		for _, c := range diff.ObjectsChanged {
+			changed[c.Name] = c.Fields
+		}
+		assert.Equal(t, map[string][]string{
+			"Rice":  {"quantity"},
+			"Beans": {"container"},
+		}, changed)
+	})
+
+	t.Run("success - against a later snapshot", func(t *testing.T) {
+		from := newStoredSnapshot(collectionID, time.Now().Add(-2*time.Hour), before...)
+		to := newStoredSnapshot(collectionID, time.Now().Add(-time.Hour), before...)
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(after...))
+		toID := to.ID()
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().GetByID(gomock.Any(), from.ID()).Return(from, nil)
+		mockSnapshotRepo.EXPECT().GetByID(gomock.Any(), toID).Return(to, nil)
+
+		resp, err := useCase.Execute(context.Background(), DiffCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			SnapshotID:   from.ID(),
+			AgainstID:    &toID,
+			UserID:       userID,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.To)
+		assert.True(t, resp.Diff.IsEmpty())
+	})
+}
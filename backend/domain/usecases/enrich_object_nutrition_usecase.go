@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type EnrichObjectNutritionRequest struct {
+	ObjectID entities.ObjectID
+	// UPC to look up; when empty the object's upc property is used.
+	UPC       string
+	UserID    entities.UserID
+	UserToken string
+}
+
+type EnrichObjectNutritionResponse struct {
+	Object      *entities.Object
+	ContainerID entities.ContainerID
+}
+
+type EnrichObjectNutritionUseCase struct {
+	containerRepo     repositories.ContainerRepository
+	collectionRepo    repositories.CollectionRepository
+	codeRepo          repositories.ObjectCodeRepository
+	authService       services.AuthService
+	nutritionProvider services.NutritionProvider
+}
+
+// NewEnrichObjectNutritionUseCase builds the use case; nutritionProvider is
+// nil when lookups are disabled.
+func NewEnrichObjectNutritionUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, codeRepo repositories.ObjectCodeRepository, authService services.AuthService, nutritionProvider services.NutritionProvider) *EnrichObjectNutritionUseCase {
+	return &EnrichObjectNutritionUseCase{
+		containerRepo:     containerRepo,
+		collectionRepo:    collectionRepo,
+		codeRepo:          codeRepo,
+		authService:       authService,
+		nutritionProvider: nutritionProvider,
+	}
+}
+
+// Execute looks up the food object's UPC and fills in its nutrition block.
+// Values the provider does not know are kept as the user entered them, and
+// the UPC is stored on the object for later lookups.
+func (uc *EnrichObjectNutritionUseCase) Execute(ctx context.Context, req EnrichObjectNutritionRequest) (*EnrichObjectNutritionResponse, error) {
+	if uc.nutritionProvider == nil {
+		return nil, entities.ErrNutritionDisabled
+	}
+
+	container, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+
+	if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, container.CollectionID(), req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	existing, err := container.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found in container: %w", err)
+	}
+	if existing.ObjectType() != entities.ObjectTypeFood {
+		return nil, entities.ErrNotFoodObject
+	}
+
+	upc := req.UPC
+	if upc == "" {
+		upc, _ = existing.Properties()[entities.PropertyUPC].Val.(string)
+	}
+	if upc == "" {
+		return nil, entities.ErrMissingUPC
+	}
+	upc, err = entities.NormalizeUPC(upc)
+	if err != nil {
+		return nil, err
+	}
+
+	facts, err := uc.nutritionProvider.LookupUPC(ctx, upc)
+	if err != nil {
+		return nil, fmt.Errorf("nutrition lookup failed: %w", err)
+	}
+
+	props := facts.ApplyTo(existing.Properties())
+	props[entities.PropertyUPC] = entities.NewTypedValue(entities.PropertyTypeText, upc)
+
+	updated := *existing
+	if err := updated.UpdateProperties(props); err != nil {
+		return nil, fmt.Errorf("failed to update object properties: %w", err)
+	}
+
+	if err := container.UpdateObject(req.ObjectID, updated); err != nil {
+		return nil, fmt.Errorf("failed to update object in container: %w", err)
+	}
+
+	if err := uc.containerRepo.Update(ctx, container); err != nil {
+		return nil, fmt.Errorf("failed to save container: %w", err)
+	}
+
+	// Best effort, as in CreateObjectUseCase
+	_ = syncObjectCodes(ctx, uc.codeRepo, req.UserID, req.ObjectID, existing.Codes(), updated.Codes())
+
+	return &EnrichObjectNutritionResponse{
+		Object:      &updated,
+		ContainerID: container.ID(),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestEnrichObjectNutritionUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		codeRepo       *mocks.MockObjectCodeRepository
+		authService    *mocks.MockAuthService
+		provider       *mocks.MockNutritionProvider
+		useCase        *EnrichObjectNutritionUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			codeRepo:       mocks.NewMockObjectCodeRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+			provider:       mocks.NewMockNutritionProvider(mockCtrl),
+		}
+		f.useCase = NewEnrichObjectNutritionUseCase(f.containerRepo, f.collectionRepo, f.codeRepo, f.authService, f.provider)
+		return f
+	}
+
+	t.Run("success - fills in facts and keeps the stored UPC", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject(ObjType(entities.ObjectTypeFood), ObjProps(Props("upc", "0-12345-67890-5", "fat_g", 1.5)))
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+
+		calories, protein := 110.0, 4.0
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.provider.EXPECT().LookupUPC(gomock.Any(), "012345678905").
+			Return(&entities.NutritionFacts{Calories: &calories, Protein: &protein, ServingSize: "40 g"}, nil)
+		f.containerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), EnrichObjectNutritionRequest{
+			ObjectID: object.ID(),
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		facts := entities.NutritionFromProperties(resp.Object.Properties())
+		require.NotNil(t, facts.Calories)
+		assert.InDelta(t, 110, *facts.Calories, 0.001)
+		require.NotNil(t, facts.Fat)
+		assert.InDelta(t, 1.5, *facts.Fat, 0.001)
+		assert.Equal(t, "40 g", facts.ServingSize)
+		upc, _ := resp.Object.GetProperty(entities.PropertyUPC)
+		assert.Equal(t, "012345678905", upc.Val)
+	})
+
+	t.Run("error - not a food object", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject()
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		_, err := f.useCase.Execute(context.Background(), EnrichObjectNutritionRequest{
+			ObjectID: object.ID(),
+			UPC:      "12345678",
+			UserID:   userID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrNotFoodObject)
+	})
+
+	t.Run("error - no UPC to look up", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject(ObjType(entities.ObjectTypeFood))
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		_, err := f.useCase.Execute(context.Background(), EnrichObjectNutritionRequest{
+			ObjectID: object.ID(),
+			UserID:   userID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrMissingUPC)
+	})
+
+	t.Run("error - provider failure is wrapped", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject(ObjType(entities.ObjectTypeFood))
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.provider.EXPECT().LookupUPC(gomock.Any(), "12345678").Return(nil, errors.New("timeout"))
+
+		_, err := f.useCase.Execute(context.Background(), EnrichObjectNutritionRequest{
+			ObjectID: object.ID(),
+			UPC:      "1234 5678",
+			UserID:   userID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nutrition lookup failed")
+	})
+
+	t.Run("error - lookup disabled", func(t *testing.T) {
+		f := setup(t)
+		useCase := NewEnrichObjectNutritionUseCase(f.containerRepo, f.collectionRepo, f.codeRepo, f.authService, nil)
+
+		_, err := useCase.Execute(context.Background(), EnrichObjectNutritionRequest{ObjectID: entities.NewObjectID()})
+
+		assert.ErrorIs(t, err, entities.ErrNutritionDisabled)
+	})
+}
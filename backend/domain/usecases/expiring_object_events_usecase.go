@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ExpiringObjectEventsRequest struct {
+	// Since is when the previous check ran
+	Since time.Time
+	Now   time.Time
+}
+
+type ExpiringObjectEventsResponse struct {
+	Events []entities.ObjectEvent
+}
+
+// ExpiringObjectEventsUseCase is run by the automation scheduler to find
+// the objects whose expiry date came within an expires_within rule's window
+// since the last check. There is no change to report these as, so the
+// scheduler publishes the events it returns.
+type ExpiringObjectEventsUseCase struct {
+	ruleRepo       repositories.AutomationRuleRepository
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+}
+
+func NewExpiringObjectEventsUseCase(
+	ruleRepo repositories.AutomationRuleRepository,
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+) *ExpiringObjectEventsUseCase {
+	return &ExpiringObjectEventsUseCase{
+		ruleRepo:       ruleRepo,
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+	}
+}
+
+// Execute returns one event per object and rule owner for objects in the
+// owners' own collections. Collections shared through a group are left out:
+// without the owner's token there is no way to tell which groups they are in.
+func (uc *ExpiringObjectEventsUseCase) Execute(ctx context.Context, req ExpiringObjectEventsRequest) (*ExpiringObjectEventsResponse, error) {
+	rules, err := uc.ruleRepo.ListEnabledByTrigger(ctx, entities.TriggerExpiresWithin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiry rules: %w", err)
+	}
+
+	byUser := make(map[entities.UserID][]*entities.AutomationRule)
+	var users []entities.UserID
+	for _, rule := range rules {
+		if _, ok := byUser[rule.UserID()]; !ok {
+			users = append(users, rule.UserID())
+		}
+		byUser[rule.UserID()] = append(byUser[rule.UserID()], rule)
+	}
+
+	resp := &ExpiringObjectEventsResponse{}
+	for _, userID := range users {
+		collections, err := uc.collectionRepo.GetByUserIDSummary(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collections: %w", err)
+		}
+		for _, collection := range collections {
+			containers, err := uc.containerRepo.GetByCollectionID(ctx, collection.ID())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get containers: %w", err)
+			}
+			for _, container := range containers {
+				for _, object := range container.ActiveObjects() {
+					if object.ExpiresAt() == nil {
+						continue
+					}
+					event := entities.NewObjectExpiringEvent(userID, collection.ID(), container.ID(), object, req.Since, req.Now)
+					for _, rule := range byUser[userID] {
+						if rule.Matches(event) {
+							resp.Events = append(resp.Events, event)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// EncodeExpiryCalendar renders the objects as an RFC 5545 iCalendar feed with
+// one all-day event per expiry date. Event UIDs are the object IDs, so
+// calendar apps update an event in place when its expiry date changes.
+func EncodeExpiryCalendar(objects []ExpiringObject, now time.Time) []byte {
+	var buf bytes.Buffer
+	writeCalendarLine(&buf, "BEGIN:VCALENDAR")
+	writeCalendarLine(&buf, "VERSION:2.0")
+	writeCalendarLine(&buf, "PRODID:-//Nishiki//Inventory//EN")
+	writeCalendarLine(&buf, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&buf, "METHOD:PUBLISH")
+	writeCalendarLine(&buf, "X-WR-CALNAME:Nishiki expiry dates")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, item := range objects {
+		// All-day events use the date as the user entered it, not UTC
+		day := *item.Object.ExpiresAt()
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+		writeCalendarLine(&buf, "BEGIN:VEVENT")
+		writeCalendarLine(&buf, "UID:"+item.Object.ID().String()+"@nishiki")
+		writeCalendarLine(&buf, "DTSTAMP:"+stamp)
+		writeCalendarLine(&buf, "DTSTART;VALUE=DATE:"+start.Format("20060102"))
+		writeCalendarLine(&buf, "DTEND;VALUE=DATE:"+start.AddDate(0, 0, 1).Format("20060102"))
+		writeCalendarLine(&buf, "SUMMARY:"+escapeCalendarText(item.Object.Name().String()+" expires"))
+		writeCalendarLine(&buf, "DESCRIPTION:"+escapeCalendarText(item.CollectionName+" › "+item.ContainerName))
+		writeCalendarLine(&buf, "TRANSP:TRANSPARENT")
+		writeCalendarLine(&buf, "END:VEVENT")
+	}
+
+	writeCalendarLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+var calendarTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeCalendarText(s string) string {
+	return calendarTextEscaper.Replace(s)
+}
+
+// writeCalendarLine ends the line with CRLF and folds it every 75 octets as
+// RFC 5545 requires, never splitting a UTF-8 sequence.
+func writeCalendarLine(buf *bytes.Buffer, line string) {
+	const maxOctets = 75
+	width := maxOctets
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts
+		width = maxOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeExpiryCalendar(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	milk := NewTestObject(ObjName("Milk, whole; 2L"), ObjExpiresAt(time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC)))
+	long := NewTestObject(ObjName(strings.Repeat("Sauerkraut ", 10)), ObjExpiresAt(now))
+
+	ics := string(EncodeExpiryCalendar([]ExpiringObject{
+		{Object: *milk, CollectionName: "Kitchen", ContainerName: "Fridge"},
+		{Object: *long, CollectionName: "Kitchen", ContainerName: "Cellar"},
+	}, now))
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.Contains(t, ics, "UID:"+milk.ID().String()+"@nishiki\r\n")
+	assert.Contains(t, ics, "DTSTAMP:20260501T120000Z\r\n")
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20260504\r\nDTEND;VALUE=DATE:20260505\r\n")
+	assert.Contains(t, ics, `SUMMARY:Milk\, whole\; 2L expires`)
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75, "line not folded: %q", line)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("Sauerkraut ", 10)+" expires\r\n")
+}
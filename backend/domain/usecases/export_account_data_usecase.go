@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ExportAccountDataRequest struct {
+	UserID entities.UserID
+}
+
+type ExportAccountDataResponse struct {
+	// Collections owned by the user, with containers and objects loaded.
+	Collections []*entities.Collection
+	// Digest is nil when the user never saved digest preferences.
+	Digest    *entities.DigestSubscription
+	Templates []*entities.ContainerTemplate
+	// Moves is the container history of every object in Collections.
+	Moves      []*entities.ObjectMove
+	ExportedAt time.Time
+}
+
+type ExportAccountDataUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	digestRepo     repositories.DigestSubscriptionRepository
+	templateRepo   repositories.ContainerTemplateRepository
+	objectMoveRepo repositories.ObjectMoveRepository
+}
+
+func NewExportAccountDataUseCase(
+	collectionRepo repositories.CollectionRepository,
+	digestRepo repositories.DigestSubscriptionRepository,
+	templateRepo repositories.ContainerTemplateRepository,
+	objectMoveRepo repositories.ObjectMoveRepository,
+) *ExportAccountDataUseCase {
+	return &ExportAccountDataUseCase{
+		collectionRepo: collectionRepo,
+		digestRepo:     digestRepo,
+		templateRepo:   templateRepo,
+		objectMoveRepo: objectMoveRepo,
+	}
+}
+
+// Execute gathers the user's inventory data: the backup archive contents plus
+// saved templates and object history. Meal plans, preferences, media,
+// comments, folders, custom types, recurrences, sessions, inbox items,
+// automations and webhooks are not included.
+func (uc *ExportAccountDataUseCase) Execute(ctx context.Context, req ExportAccountDataRequest) (*ExportAccountDataResponse, error) {
+	collections, err := uc.collectionRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	digest, err := uc.digestRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		if !errors.Is(err, entities.ErrDigestSubscriptionNotFound) {
+			return nil, fmt.Errorf("failed to get digest preferences: %w", err)
+		}
+		digest = nil
+	}
+
+	templates, err := uc.templateRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container templates: %w", err)
+	}
+
+	var objectIDs []entities.ObjectID
+	for _, col := range collections {
+		for _, obj := range col.GetAllObjects() {
+			objectIDs = append(objectIDs, obj.ID())
+		}
+	}
+	moves, err := uc.objectMoveRepo.ListByObjectIDs(ctx, objectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object history: %w", err)
+	}
+
+	return &ExportAccountDataResponse{
+		Collections: collections,
+		Digest:      digest,
+		Templates:   templates,
+		Moves:       moves,
+		ExportedAt:  time.Now(),
+	}, nil
+}
@@ -60,7 +60,7 @@ func (uc *ExportCollectionUseCase) Execute(ctx context.Context, req ExportCollec
 		return nil, fmt.Errorf("collection not found: %w", err)
 	}
 
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
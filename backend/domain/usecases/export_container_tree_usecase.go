@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type ExportContainerTreeRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type ExportContainerTreeResponse struct {
+	YAML           []byte
+	CollectionName string
+}
+
+// ExportContainerTreeUseCase writes a collection's containers, without their
+// objects, as a YAML document that ImportContainerTreeUseCase reads back.
+type ExportContainerTreeUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewExportContainerTreeUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *ExportContainerTreeUseCase {
+	return &ExportContainerTreeUseCase{
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *ExportContainerTreeUseCase) Execute(ctx context.Context, req ExportContainerTreeRequest) (*ExportContainerTreeResponse, error) {
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	doc := containerTreeDocument{
+		Collection: collection.Name().String(),
+		Containers: buildContainerTree(collection.Containers()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(containerTreeHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode container tree: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode container tree: %w", err)
+	}
+
+	return &ExportContainerTreeResponse{
+		YAML:           buf.Bytes(),
+		CollectionName: collection.Name().String(),
+	}, nil
+}
@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// featureFlagCheckInterval is how long the stored flags are trusted before
+// they are read again, so other instances pick up an admin's change
+const featureFlagCheckInterval = 30 * time.Second
+
+// Where a feature's flag comes from, lowest precedence first
+const (
+	FeatureSourceDefault = "default"
+	FeatureSourceConfig  = "config"
+	FeatureSourceAdmin   = "admin"
+)
+
+// ResolvedFeatureFlag is the flag in effect for a feature and where it came
+// from
+type ResolvedFeatureFlag struct {
+	Flag   *entities.FeatureFlag
+	Source string
+}
+
+// FeatureFlagUseCase evaluates feature flags for a user and manages the
+// ones admins set. A feature's flag is the admin's if there is one, else the
+// one in app.toml, else entities.DefaultFeatures; features none of them
+// name are off.
+type FeatureFlagUseCase struct {
+	flagRepo repositories.FeatureFlagRepository
+	// configured returns the flags in app.toml, read on every call so a
+	// config reload takes effect
+	configured func() []*entities.FeatureFlag
+
+	mu       sync.Mutex
+	stored   []*entities.FeatureFlag
+	storedAt time.Time
+}
+
+func NewFeatureFlagUseCase(flagRepo repositories.FeatureFlagRepository, configured func() []*entities.FeatureFlag) *FeatureFlagUseCase {
+	return &FeatureFlagUseCase{flagRepo: flagRepo, configured: configured}
+}
+
+// List returns the flag in effect for every known feature, by name
+func (uc *FeatureFlagUseCase) List(ctx context.Context) ([]ResolvedFeatureFlag, error) {
+	stored, err := uc.loadStored(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]ResolvedFeatureFlag)
+	for name, enabled := range entities.DefaultFeatures {
+		flag := entities.ReconstructFeatureFlag(entities.FeatureFlagProps{Name: name, Enabled: enabled}, time.Time{})
+		flags[name] = ResolvedFeatureFlag{Flag: flag, Source: FeatureSourceDefault}
+	}
+	if uc.configured != nil {
+		for _, flag := range uc.configured() {
+			flags[flag.Name()] = ResolvedFeatureFlag{Flag: flag, Source: FeatureSourceConfig}
+		}
+	}
+	for _, flag := range stored {
+		flags[flag.Name()] = ResolvedFeatureFlag{Flag: flag, Source: FeatureSourceAdmin}
+	}
+
+	resolved := make([]ResolvedFeatureFlag, 0, len(flags))
+	for _, flag := range flags {
+		resolved = append(resolved, flag)
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Flag.Name() < resolved[j].Flag.Name() })
+	return resolved, nil
+}
+
+// Evaluate returns whether each known feature is on for user, a member of
+// the named groups
+func (uc *FeatureFlagUseCase) Evaluate(ctx context.Context, user *entities.User, groups []string) (map[string]bool, error) {
+	flags, err := uc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	features := make(map[string]bool, len(flags))
+	for _, f := range flags {
+		features[f.Flag.Name()] = f.Flag.EnabledFor(user, groups)
+	}
+	return features, nil
+}
+
+// IsEnabled reports whether one feature is on for user, a member of the
+// named groups. Unknown features are off.
+func (uc *FeatureFlagUseCase) IsEnabled(ctx context.Context, name string, user *entities.User, groups []string) (bool, error) {
+	features, err := uc.Evaluate(ctx, user, groups)
+	if err != nil {
+		return false, err
+	}
+	return features[name], nil
+}
+
+// Set stores an admin's flag, replacing any configured one of the same name
+func (uc *FeatureFlagUseCase) Set(ctx context.Context, props entities.FeatureFlagProps) (*entities.FeatureFlag, error) {
+	flag, err := entities.NewFeatureFlag(props, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.flagRepo.Save(ctx, flag); err != nil {
+		return nil, fmt.Errorf("failed to save feature flag: %w", err)
+	}
+	uc.forget()
+	return flag, nil
+}
+
+// Remove deletes an admin's flag, so the configured or default one applies
+// again
+func (uc *FeatureFlagUseCase) Remove(ctx context.Context, name string) error {
+	if err := uc.flagRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+	uc.forget()
+	return nil
+}
+
+func (uc *FeatureFlagUseCase) loadStored(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if !uc.storedAt.IsZero() && time.Since(uc.storedAt) < featureFlagCheckInterval {
+		return uc.stored, nil
+	}
+
+	stored, err := uc.flagRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	uc.stored = stored
+	uc.storedAt = time.Now()
+	return stored, nil
+}
+
+func (uc *FeatureFlagUseCase) forget() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.storedAt = time.Time{}
+}
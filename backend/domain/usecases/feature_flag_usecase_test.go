@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestFeatureFlagUseCase_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	alice, bob, carol := newGroupMember(t, "alice"), newGroupMember(t, "bob"), newGroupMember(t, "carol")
+	configured := func() []*entities.FeatureFlag {
+		return []*entities.FeatureFlag{
+			entities.ReconstructFeatureFlag(entities.FeatureFlagProps{Name: entities.FeatureMealPlanner, Users: []string{"alice"}, Groups: []string{"beta"}}, time.Time{}),
+			entities.ReconstructFeatureFlag(entities.FeatureFlagProps{Name: "barcode_labels", Enabled: true}, time.Time{}),
+		}
+	}
+	stored := []*entities.FeatureFlag{
+		entities.ReconstructFeatureFlag(entities.FeatureFlagProps{Name: "barcode_labels", Users: []string{carol.ID().String()}}, time.Now()),
+	}
+
+	mockCtrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockFeatureFlagRepository(mockCtrl)
+	// Read once, then served from the cache
+	mockRepo.EXPECT().List(gomock.Any()).Return(stored, nil).Times(1)
+	useCase := NewFeatureFlagUseCase(mockRepo, configured)
+
+	features, err := useCase.Evaluate(context.Background(), alice, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{entities.FeatureMealPlanner: true, "barcode_labels": false}, features, "listed by username; the admin's flag replaces the configured one")
+
+	features, err = useCase.Evaluate(context.Background(), bob, []string{"beta"})
+	require.NoError(t, err)
+	assert.True(t, features[entities.FeatureMealPlanner], "listed group")
+
+	enabled, err := useCase.IsEnabled(context.Background(), entities.FeatureMealPlanner, carol, []string{"other"})
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	enabled, err = useCase.IsEnabled(context.Background(), "barcode_labels", carol, nil)
+	require.NoError(t, err)
+	assert.True(t, enabled, "listed by ID")
+	enabled, err = useCase.IsEnabled(context.Background(), "unknown", carol, nil)
+	require.NoError(t, err)
+	assert.False(t, enabled, "unknown features are off")
+}
+
+func TestFeatureFlagUseCase_List(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockFeatureFlagRepository(mockCtrl)
+	mockRepo.EXPECT().List(gomock.Any()).Return(nil, nil)
+
+	flags, err := NewFeatureFlagUseCase(mockRepo, nil).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, flags, len(entities.DefaultFeatures))
+	assert.Equal(t, entities.FeatureMealPlanner, flags[0].Flag.Name())
+	assert.Equal(t, FeatureSourceDefault, flags[0].Source)
+	assert.True(t, flags[0].Flag.Enabled(), "shipped features default to on")
+}
+
+func TestFeatureFlagUseCase_SetAndRemove(t *testing.T) {
+	t.Parallel()
+
+	t.Run("set clears the cache", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockFeatureFlagRepository(mockCtrl)
+		useCase := NewFeatureFlagUseCase(mockRepo, nil)
+
+		var saved *entities.FeatureFlag
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any()).Return(nil, nil),
+			mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, flag *entities.FeatureFlag) error {
+				saved = flag
+				return nil
+			}),
+			mockRepo.EXPECT().List(gomock.Any()).DoAndReturn(func(context.Context) ([]*entities.FeatureFlag, error) {
+				return []*entities.FeatureFlag{saved}, nil
+			}),
+		)
+
+		_, err := useCase.List(context.Background())
+		require.NoError(t, err)
+		flag, err := useCase.Set(context.Background(), entities.FeatureFlagProps{Name: entities.FeatureMealPlanner, Users: []string{"bob", "", "alice", "bob"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, flag.Users())
+
+		flags, err := useCase.List(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, FeatureSourceAdmin, flags[0].Source)
+		assert.False(t, flags[0].Flag.Enabled())
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockFeatureFlagRepository(mockCtrl)
+
+		_, err := NewFeatureFlagUseCase(mockRepo, nil).Set(context.Background(), entities.FeatureFlagProps{Name: "Meal Planner"})
+		assert.ErrorIs(t, err, entities.ErrInvalidFeatureName)
+	})
+
+	t.Run("remove unknown", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockFeatureFlagRepository(mockCtrl)
+		mockRepo.EXPECT().Delete(gomock.Any(), "nope").Return(entities.ErrFeatureFlagNotFound)
+
+		err := NewFeatureFlagUseCase(mockRepo, nil).Remove(context.Background(), "nope")
+		assert.ErrorIs(t, err, entities.ErrFeatureFlagNotFound)
+	})
+}
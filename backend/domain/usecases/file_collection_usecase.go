@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type FileCollectionRequest struct {
+	FolderID     entities.CollectionFolderID
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type FileCollectionResponse struct {
+	Folder *entities.CollectionFolder
+}
+
+type FileCollectionUseCase struct {
+	folderRepo     repositories.CollectionFolderRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewFileCollectionUseCase(folderRepo repositories.CollectionFolderRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *FileCollectionUseCase {
+	return &FileCollectionUseCase{
+		folderRepo:     folderRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+// Execute puts a collection the user can see into one of their folders. A
+// collection sits in at most one folder per user, so it is taken out of any
+// other folder it was in.
+func (uc *FileCollectionUseCase) Execute(ctx context.Context, req FileCollectionRequest) (*FileCollectionResponse, error) {
+	folder, err := uc.folderRepo.GetByID(ctx, req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	if !folder.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrCollectionFolderNotFound
+	}
+
+	if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	folders, err := uc.folderRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection folders: %w", err)
+	}
+	for _, f := range folders {
+		if f.ID().Equals(folder.ID()) || !f.RemoveCollection(req.CollectionID) {
+			continue
+		}
+		if err := uc.folderRepo.Update(ctx, f); err != nil {
+			return nil, fmt.Errorf("failed to remove collection from folder %s: %w", f.ID(), err)
+		}
+	}
+
+	folder.AddCollection(req.CollectionID)
+	if err := uc.folderRepo.Update(ctx, folder); err != nil {
+		return nil, fmt.Errorf("failed to update collection folder: %w", err)
+	}
+
+	return &FileCollectionResponse{
+		Folder: folder,
+	}, nil
+}
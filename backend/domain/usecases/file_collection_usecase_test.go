@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestFileCollectionUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFolderRepo := mocks.NewMockCollectionFolderRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewFileCollectionUseCase(mockFolderRepo, mockCollectionRepo, mockAuthService)
+
+	t.Run("success - shared collection moves out of its old folder", func(t *testing.T) {
+		userID := entities.NewUserID()
+		group := NewTestGroup()
+		collection := NewTestCollection(ColUserID(entities.NewUserID()), ColGroupID(new(group.ID())))
+		kitchen := NewTestFolder(userID, "Kitchen", nil, collection.ID())
+		garage := NewTestFolder(userID, "Garage", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), garage.ID()).Return(garage, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{garage, kitchen}, nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), kitchen).Return(nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), garage).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), FileCollectionRequest{
+			FolderID:     garage.ID(),
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []entities.CollectionID{collection.ID()}, resp.Folder.CollectionIDs())
+		assert.Empty(t, kitchen.CollectionIDs())
+	})
+
+	t.Run("error - collection not shared with the user", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(entities.NewUserID()))
+		folder := NewTestFolder(userID, "Kitchen", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), folder.ID()).Return(folder, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		_, err := useCase.Execute(context.Background(), FileCollectionRequest{
+			FolderID:     folder.ID(),
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type FindDuplicateObjectsRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+// DuplicateObjectGroup is a set of active objects that look like the same
+// thing and are candidates for MergeObjectsUseCase.
+type DuplicateObjectGroup struct {
+	Key     string // normalized name shared by the objects
+	Objects []ObjectWithContainerID
+}
+
+type FindDuplicateObjectsResponse struct {
+	Groups []DuplicateObjectGroup
+}
+
+type FindDuplicateObjectsUseCase struct {
+	containerRepo repositories.ContainerRepository
+	authService   services.AuthService
+}
+
+func NewFindDuplicateObjectsUseCase(containerRepo repositories.ContainerRepository, authService services.AuthService) *FindDuplicateObjectsUseCase {
+	return &FindDuplicateObjectsUseCase{
+		containerRepo: containerRepo,
+		authService:   authService,
+	}
+}
+
+// Execute groups the collection's active objects by normalized name and
+// returns the groups with more than one member, in order of first appearance.
+func (uc *FindDuplicateObjectsUseCase) Execute(ctx context.Context, req FindDuplicateObjectsRequest) (*FindDuplicateObjectsResponse, error) {
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	groupIDs := make([]entities.GroupID, len(userGroups))
+	for i, g := range userGroups {
+		groupIDs[i] = g.ID()
+	}
+
+	containers, err := uc.containerRepo.GetByCollectionIDWithAccess(ctx, req.CollectionID, req.UserID, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containers: %w", err)
+	}
+
+	var keys []string
+	byKey := make(map[string][]ObjectWithContainerID)
+	for _, c := range containers {
+		for _, obj := range c.Objects() {
+			if obj.IsArchived() {
+				continue
+			}
+			key := duplicateKey(obj.Name().String())
+			if _, seen := byKey[key]; !seen {
+				keys = append(keys, key)
+			}
+			byKey[key] = append(byKey[key], ObjectWithContainerID{Object: obj, ContainerID: c.ID()})
+		}
+	}
+
+	groups := []DuplicateObjectGroup{}
+	for _, key := range keys {
+		if len(byKey[key]) > 1 {
+			groups = append(groups, DuplicateObjectGroup{Key: key, Objects: byKey[key]})
+		}
+	}
+
+	return &FindDuplicateObjectsResponse{Groups: groups}, nil
+}
+
+// duplicateKey normalizes a name so "Black  Beans" and "black beans" match.
+func duplicateKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type UploadFloorPlanRequest struct {
+	CollectionID entities.CollectionID
+	File         MediaUpload
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type RemoveFloorPlanRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+// ContainerPlacementUpdate moves one container on the floor plan.
+type ContainerPlacementUpdate struct {
+	ContainerID entities.ContainerID
+	Placement   entities.ContainerPlacement
+}
+
+type PlaceContainersRequest struct {
+	CollectionID entities.CollectionID
+	Placements   []ContainerPlacementUpdate
+	UserID       entities.UserID
+	UserToken    string
+}
+
+// FloorPlanUseCase manages a collection's floor plan image and where its
+// containers are on it.
+type FloorPlanUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	mediaStorage   services.MediaStorage
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	authService    services.AuthService
+	maxUploadSize  int64
+}
+
+func NewFloorPlanUseCase(
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	mediaStorage services.MediaStorage,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
+	authService services.AuthService,
+	maxUploadSize int64,
+) *FloorPlanUseCase {
+	return &FloorPlanUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		mediaStorage:   mediaStorage,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		authService:    authService,
+		maxUploadSize:  maxUploadSize,
+	}
+}
+
+// Upload stores a new floor plan image, replacing the collection's current
+// one. Containers keep their positions.
+func (uc *FloorPlanUseCase) Upload(ctx context.Context, req UploadFloorPlanRequest) (*entities.FloorPlan, error) {
+	collection, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(req.File.Data)) > uc.maxUploadSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", entities.ErrMediaTooLarge, uc.maxUploadSize)
+	}
+	if !entities.IsSupportedMediaType(req.File.ContentType) {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaStorage, int64(len(req.File.Data))); err != nil {
+		return nil, err
+	}
+
+	id := entities.NewMediaID()
+	stored, err := uc.mediaStorage.Save(ctx, collection.ID(), id, req.File.ContentType, req.File.Data)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := entities.NewFloorPlan(entities.FloorPlanProps{
+		MediaID:     id,
+		ContentType: req.File.ContentType,
+		Size:        int64(len(req.File.Data)),
+		Width:       stored.Width,
+		Height:      stored.Height,
+		URL:         stored.URL,
+		UploadedBy:  req.UserID,
+	}, time.Now())
+	if err != nil {
+		_ = uc.mediaStorage.Delete(ctx, collection.ID(), id)
+		return nil, err
+	}
+
+	previous := collection.FloorPlan()
+	collection.UpdateFloorPlan(plan)
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		// Don't leave an unreferenced file behind
+		_ = uc.mediaStorage.Delete(ctx, collection.ID(), id)
+		return nil, fmt.Errorf("failed to save floor plan: %w", err)
+	}
+	if previous != nil {
+		_ = uc.mediaStorage.Delete(ctx, collection.ID(), previous.MediaID())
+	}
+	return plan, nil
+}
+
+// Remove deletes the collection's floor plan image. Container placements
+// are kept, so a new plan of the same layout can be uploaded later.
+func (uc *FloorPlanUseCase) Remove(ctx context.Context, req RemoveFloorPlanRequest) error {
+	collection, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	if err != nil {
+		return err
+	}
+	plan := collection.FloorPlan()
+	if plan == nil {
+		return entities.ErrFloorPlanNotFound
+	}
+
+	collection.UpdateFloorPlan(nil)
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		return fmt.Errorf("failed to remove floor plan: %w", err)
+	}
+	return uc.mediaStorage.Delete(ctx, collection.ID(), plan.MediaID())
+}
+
+// PlaceContainers sets where each listed container is; containers not listed
+// are left where they are. Every container is checked before any is saved.
+func (uc *FloorPlanUseCase) PlaceContainers(ctx context.Context, req PlaceContainersRequest) ([]*entities.Container, error) {
+	collection, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]*entities.Container, len(req.Placements))
+	for i, p := range req.Placements {
+		container, err := uc.containerRepo.GetByID(ctx, p.ContainerID)
+		if err != nil || !container.CollectionID().Equals(collection.ID()) {
+			return nil, fmt.Errorf("container %s: %w", p.ContainerID, entities.ErrContainerNotFound)
+		}
+		containers[i] = container
+	}
+
+	for i, container := range containers {
+		container.UpdatePlacement(req.Placements[i].Placement)
+		if err := uc.containerRepo.Update(ctx, container); err != nil {
+			return nil, fmt.Errorf("failed to place container %s: %w", container.ID(), err)
+		}
+	}
+	return containers, nil
+}
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestFloorPlanUseCase(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		collectionRepo *mocks.MockCollectionRepository
+		containerRepo  *mocks.MockContainerRepository
+		mediaStorage   *mocks.MockMediaStorage
+		useCase        *FloorPlanUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			mediaStorage:   mocks.NewMockMediaStorage(mockCtrl),
+		}
+		f.useCase = NewFloorPlanUseCase(f.collectionRepo, f.containerRepo, f.mediaStorage, mocks.NewMockGroupQuotaRepository(mockCtrl), mocks.NewMockUsageRepository(mockCtrl), mocks.NewMockAuthService(mockCtrl), 10)
+		return f
+	}
+
+	t.Run("upload replaces the previous plan and deletes its image", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		previous := entities.ReconstructFloorPlan(entities.FloorPlanProps{MediaID: entities.NewMediaID(), ContentType: "image/png"}, time.Now())
+		collection.UpdateFloorPlan(previous)
+
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.mediaStorage.EXPECT().Save(gomock.Any(), collection.ID(), gomock.Any(), "image/png", []byte("png")).
+			Return(&services.StoredMedia{URL: "/media/plan.png", Width: 800, Height: 600}, nil)
+		f.collectionRepo.EXPECT().Update(gomock.Any(), collection).Return(nil)
+		f.mediaStorage.EXPECT().Delete(gomock.Any(), collection.ID(), previous.MediaID()).Return(nil)
+
+		plan, err := f.useCase.Upload(context.Background(), UploadFloorPlanRequest{
+			CollectionID: collection.ID(),
+			File:         MediaUpload{Filename: "plan.png", ContentType: "image/png", Data: []byte("png")},
+			UserID:       userID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "/media/plan.png", plan.URL())
+		assert.Equal(t, 800, plan.Width())
+		assert.Same(t, plan, collection.FloorPlan())
+	})
+
+	t.Run("upload rejects an image over the size limit", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		_, err := f.useCase.Upload(context.Background(), UploadFloorPlanRequest{
+			CollectionID: collection.ID(),
+			File:         MediaUpload{Filename: "plan.jpg", ContentType: "image/jpeg", Data: []byte("way too big")},
+			UserID:       userID,
+		})
+		assert.ErrorIs(t, err, entities.ErrMediaTooLarge)
+	})
+
+	t.Run("remove without a plan", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		err := f.useCase.Remove(context.Background(), RemoveFloorPlanRequest{CollectionID: collection.ID(), UserID: userID})
+		assert.ErrorIs(t, err, entities.ErrFloorPlanNotFound)
+	})
+
+	t.Run("place containers", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		freezer := NewTestContainer(CtrCollectionID(collection.ID()))
+		x, y := 0.25, 0.75
+		placement, err := entities.NewContainerPlacement(&x, &y, "Ground", " Garage ")
+		require.NoError(t, err)
+
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), freezer.ID()).Return(freezer, nil)
+		f.containerRepo.EXPECT().Update(gomock.Any(), freezer).Return(nil)
+
+		containers, err := f.useCase.PlaceContainers(context.Background(), PlaceContainersRequest{
+			CollectionID: collection.ID(),
+			Placements:   []ContainerPlacementUpdate{{ContainerID: freezer.ID(), Placement: placement}},
+			UserID:       userID,
+		})
+		require.NoError(t, err)
+		require.Len(t, containers, 1)
+		assert.Equal(t, 0.25, *containers[0].Placement().X)
+		assert.Equal(t, "Garage", containers[0].Placement().Room)
+	})
+
+	t.Run("place containers saves nothing when one is in another collection", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		own := NewTestContainer(CtrCollectionID(collection.ID()))
+		other := NewTestContainer(CtrCollectionID(entities.NewCollectionID()))
+
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), own.ID()).Return(own, nil)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), other.ID()).Return(other, nil)
+
+		_, err := f.useCase.PlaceContainers(context.Background(), PlaceContainersRequest{
+			CollectionID: collection.ID(),
+			Placements: []ContainerPlacementUpdate{
+				{ContainerID: own.ID(), Placement: entities.ContainerPlacement{Room: "Kitchen"}},
+				{ContainerID: other.ID(), Placement: entities.ContainerPlacement{Room: "Kitchen"}},
+			},
+			UserID: userID,
+		})
+		assert.ErrorIs(t, err, entities.ErrContainerNotFound)
+	})
+}
+
+func TestNewContainerPlacement(t *testing.T) {
+	t.Parallel()
+
+	half, over := 0.5, 1.5
+	_, err := entities.NewContainerPlacement(&half, nil, "", "")
+	assert.ErrorIs(t, err, entities.ErrInvalidContainerPosition, "x without y")
+	_, err = entities.NewContainerPlacement(&half, &over, "", "")
+	assert.ErrorIs(t, err, entities.ErrInvalidContainerPosition, "outside the plan")
+
+	placement, err := entities.NewContainerPlacement(nil, nil, "  ", "")
+	require.NoError(t, err)
+	assert.True(t, placement.IsZero(), "blank floor and room take the container off the plan")
+}
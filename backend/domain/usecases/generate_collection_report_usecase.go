@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GenerateCollectionReportRequest struct {
+	CollectionID  entities.CollectionID
+	Layout        services.ReportLayout
+	IncludeImages bool
+	UserID        entities.UserID
+	UserToken     string
+}
+
+type GenerateCollectionReportResponse struct {
+	PDF            []byte
+	CollectionName string
+}
+
+type GenerateCollectionReportUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+	reportRenderer services.ReportRenderer
+}
+
+func NewGenerateCollectionReportUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService, reportRenderer services.ReportRenderer) *GenerateCollectionReportUseCase {
+	return &GenerateCollectionReportUseCase{
+		collectionRepo: collectionRepo,
+		authService:    authService,
+		reportRenderer: reportRenderer,
+	}
+}
+
+func (uc *GenerateCollectionReportUseCase) Execute(ctx context.Context, req GenerateCollectionReportRequest) (*GenerateCollectionReportResponse, error) {
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	layout := req.Layout
+	if layout == "" {
+		layout = services.ReportLayoutDetailed
+	}
+
+	valueKey, currencyCode := reportValueProperty(collection)
+	report := &services.CollectionReport{
+		CollectionName: collection.Name().String(),
+		Location:       collection.Location(),
+		Layout:         layout,
+		IncludeImages:  req.IncludeImages,
+		CurrencyCode:   currencyCode,
+		GeneratedAt:    time.Now().UTC(),
+	}
+
+	for _, container := range collection.Containers() {
+		section := services.ReportContainer{
+			Name:     container.Name().String(),
+			Location: container.Location(),
+		}
+		for _, obj := range container.ActiveObjects() {
+			item := services.ReportItem{
+				Name:      obj.Name().String(),
+				Quantity:  obj.Quantity(),
+				Unit:      obj.Unit(),
+				ExpiresAt: obj.ExpiresAt(),
+			}
+			if req.IncludeImages {
+				item.ImageURL = obj.ImageURL()
+			}
+			if tv, ok := obj.Properties()[valueKey]; ok && valueKey != "" {
+				if v, ok := tv.Val.(float64); ok {
+					item.Value = &v
+					section.TotalValue += v
+				}
+			}
+			if item.ExpiresAt != nil && (section.NextExpiry == nil || item.ExpiresAt.Before(*section.NextExpiry)) {
+				section.NextExpiry = item.ExpiresAt
+			}
+			section.Items = append(section.Items, item)
+		}
+		slices.SortFunc(section.Items, func(a, b services.ReportItem) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+		section.ObjectCount = len(section.Items)
+
+		report.ObjectCount += section.ObjectCount
+		report.TotalValue += section.TotalValue
+		report.Containers = append(report.Containers, section)
+	}
+
+	pdf, err := uc.reportRenderer.RenderCollectionReport(ctx, report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return &GenerateCollectionReportResponse{
+		PDF:            pdf,
+		CollectionName: collection.Name().String(),
+	}, nil
+}
+
+// reportValueProperty picks the property that holds an object's value: the
+// first currency property in the collection's schema, or the first currency
+// property found on any object when the collection has no schema.
+func reportValueProperty(collection *entities.Collection) (key, currencyCode string) {
+	return valueProperty(collection.PropertySchema(), collection.GetAllObjects())
+}
+
+// valueProperty is reportValueProperty for a schema and the objects it
+// applies to, for callers that load the containers separately
+func valueProperty(schema *entities.PropertySchema, objects []entities.Object) (key, currencyCode string) {
+	if schema != nil {
+		for _, def := range schema.Definitions {
+			if def.Type == entities.PropertyTypeCurrency {
+				return def.Key, def.CurrencyCode
+			}
+		}
+		return "", ""
+	}
+
+	var keys []string
+	for _, obj := range objects {
+		for k, tv := range obj.Properties() {
+			if tv.Type == entities.PropertyTypeCurrency && !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return "", ""
+	}
+	slices.Sort(keys)
+	for _, obj := range objects {
+		if tv, ok := obj.Properties()[keys[0]]; ok && tv.Currency != "" {
+			return keys[0], tv.Currency
+		}
+	}
+	return keys[0], ""
+}
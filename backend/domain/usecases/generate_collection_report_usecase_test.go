@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGenerateCollectionReportUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	mockRenderer := mocks.NewMockReportRenderer(mockCtrl)
+
+	useCase := NewGenerateCollectionReportUseCase(mockCollectionRepo, mockAuthService, mockRenderer)
+
+	price := func(v float64) map[string]entities.TypedValue {
+		return map[string]entities.TypedValue{"price": {Type: entities.PropertyTypeCurrency, Val: v}}
+	}
+	schema := &entities.PropertySchema{Definitions: []entities.PropertyDefinition{
+		{Key: "price", DisplayName: "Price", Type: entities.PropertyTypeCurrency, CurrencyCode: "USD"},
+	}}
+
+	t.Run("success - groups by container with totals", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		soon := time.Now().Add(48 * time.Hour)
+		later := time.Now().Add(30 * 24 * time.Hour)
+
+		pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID), CtrObjects(
+			*NewTestObject(ObjName("rice"), ObjProps(price(4)), ObjExpiresAt(later)),
+			*NewTestObject(ObjName("Beans"), ObjProps(price(2.5)), ObjExpiresAt(soon)),
+			*NewTestObject(ObjName("Old flour"), ObjProps(price(100)), ObjArchivedAt(time.Now())),
+		))
+		fridge := NewTestContainer(CtrName("Fridge"), CtrCollectionID(collectionID), CtrObjects(
+			*NewTestObject(ObjName("Milk")),
+		))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColName("Kitchen"), ColSchema(schema), ColContainers(*pantry, *fridge))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockRenderer.EXPECT().RenderCollectionReport(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report *services.CollectionReport) ([]byte, error) {
+			assert.Equal(t, "Kitchen", report.CollectionName)
+			assert.Equal(t, services.ReportLayoutDetailed, report.Layout)
+			assert.Equal(t, "USD", report.CurrencyCode)
+			assert.Equal(t, 3, report.ObjectCount)
+			assert.InDelta(t, 6.5, report.TotalValue, 0.001)
+
+			require.Len(t, report.Containers, 2)
+			pantry := report.Containers[0]
+			assert.Equal(t, "Pantry", pantry.Name)
+			assert.Equal(t, 2, pantry.ObjectCount)
+			require.Len(t, pantry.Items, 2)
+			assert.Equal(t, "Beans", pantry.Items[0].Name)
+			assert.Equal(t, "rice", pantry.Items[1].Name)
+			require.NotNil(t, pantry.NextExpiry)
+			assert.WithinDuration(t, soon, *pantry.NextExpiry, time.Second)
+			assert.Nil(t, report.Containers[1].Items[0].Value)
+			return []byte("%PDF"), nil
+		})
+
+		resp, err := useCase.Execute(context.Background(), GenerateCollectionReportRequest{
+			CollectionID: collectionID,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("%PDF"), resp.PDF)
+		assert.Equal(t, "Kitchen", resp.CollectionName)
+	})
+
+	t.Run("success - images only included when requested", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collection := collectionWithObjects(userID, nil, nil, []entities.Object{*NewTestObject(ObjName("Lamp"))})
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockRenderer.EXPECT().RenderCollectionReport(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report *services.CollectionReport) ([]byte, error) {
+			assert.Equal(t, services.ReportLayoutSummary, report.Layout)
+			assert.False(t, report.IncludeImages)
+			assert.Empty(t, report.CurrencyCode)
+			return []byte("%PDF"), nil
+		})
+
+		_, err := useCase.Execute(context.Background(), GenerateCollectionReportRequest{
+			CollectionID: collection.ID(),
+			Layout:       services.ReportLayoutSummary,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error - access denied", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collection := collectionWithObjects(entities.NewUserID(), nil, nil, nil)
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		_, err := useCase.Execute(context.Background(), GenerateCollectionReportRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - renderer failure", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collection := collectionWithObjects(userID, nil, nil, nil)
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockRenderer.EXPECT().RenderCollectionReport(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+
+		_, err := useCase.Execute(context.Background(), GenerateCollectionReportRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to render report")
+	})
+}
+
+func TestParseReportLayout(t *testing.T) {
+	t.Parallel()
+
+	layout, err := services.ParseReportLayout("")
+	require.NoError(t, err)
+	assert.Equal(t, services.ReportLayoutDetailed, layout)
+
+	layout, err = services.ParseReportLayout("summary")
+	require.NoError(t, err)
+	assert.Equal(t, services.ReportLayoutSummary, layout)
+
+	_, err = services.ParseReportLayout("poster")
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GenerateObjectLabelRequest struct {
+	ObjectID entities.ObjectID
+	Format   services.LabelFormat
+	// Template overrides the label template from the user's preferences.
+	Template  string
+	UserID    entities.UserID
+	UserToken string
+}
+
+type GenerateObjectLabelResponse struct {
+	Label      []byte
+	ObjectName string
+}
+
+// GenerateObjectLabelUseCase renders a printable label for an object with
+// its name, a QR code of its ID, its expiry and the path of containers it
+// sits in, sized to the label stock the user prints on.
+type GenerateObjectLabelUseCase struct {
+	containerRepo   repositories.ContainerRepository
+	collectionRepo  repositories.CollectionRepository
+	preferencesRepo repositories.UserPreferencesRepository
+	authService     services.AuthService
+	labelRenderer   services.LabelRenderer
+}
+
+func NewGenerateObjectLabelUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, preferencesRepo repositories.UserPreferencesRepository, authService services.AuthService, labelRenderer services.LabelRenderer) *GenerateObjectLabelUseCase {
+	return &GenerateObjectLabelUseCase{
+		containerRepo:   containerRepo,
+		collectionRepo:  collectionRepo,
+		preferencesRepo: preferencesRepo,
+		authService:     authService,
+		labelRenderer:   labelRenderer,
+	}
+}
+
+func (uc *GenerateObjectLabelUseCase) Execute(ctx context.Context, req GenerateObjectLabelRequest) (*GenerateObjectLabelResponse, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, container.CollectionID())
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	object, err := container.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found in container: %w", err)
+	}
+
+	templateKey := req.Template
+	if templateKey == "" {
+		preferences, err := uc.preferencesRepo.GetByUserID(ctx, req.UserID)
+		switch {
+		case errors.Is(err, entities.ErrUserPreferencesNotFound):
+		case err != nil:
+			return nil, fmt.Errorf("failed to get user preferences: %w", err)
+		default:
+			templateKey = preferences.LabelTemplate()
+		}
+	}
+	template, err := entities.FindLabelTemplate(templateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = services.LabelFormatPDF
+	}
+
+	label, err := uc.labelRenderer.RenderLabel(ctx, &services.ObjectLabel{
+		Name:          object.Name().String(),
+		ContainerPath: labelContainerPath(collection, container),
+		ExpiresAt:     object.ExpiresAt(),
+		Code:          object.ID().String(),
+		Template:      template,
+	}, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render label: %w", err)
+	}
+
+	return &GenerateObjectLabelResponse{
+		Label:      label,
+		ObjectName: object.Name().String(),
+	}, nil
+}
+
+// labelContainerPath names the container and its ancestors from the
+// collection root down, e.g. "Kitchen / Pantry". The collection's copy of the
+// containers is used for the ancestors; a broken parent link ends the path.
+func labelContainerPath(collection *entities.Collection, container *entities.Container) string {
+	names := []string{container.Name().String()}
+	seen := map[string]bool{container.ID().String(): true}
+	for parent := container.ParentContainerID(); parent != nil && !seen[parent.String()]; {
+		p, err := collection.GetContainer(*parent)
+		if err != nil {
+			break
+		}
+		seen[parent.String()] = true
+		names = append(names, p.Name().String())
+		parent = p.ParentContainerID()
+	}
+	slices.Reverse(names)
+	return strings.Join(names, " / ")
+}
@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGenerateObjectLabelUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase         *GenerateObjectLabelUseCase
+		containerRepo   *mocks.MockContainerRepository
+		collectionRepo  *mocks.MockCollectionRepository
+		preferencesRepo *mocks.MockUserPreferencesRepository
+		authService     *mocks.MockAuthService
+		labelRenderer   *mocks.MockLabelRenderer
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:   mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo:  mocks.NewMockCollectionRepository(mockCtrl),
+			preferencesRepo: mocks.NewMockUserPreferencesRepository(mockCtrl),
+			authService:     mocks.NewMockAuthService(mockCtrl),
+			labelRenderer:   mocks.NewMockLabelRenderer(mockCtrl),
+		}
+		f.useCase = NewGenerateObjectLabelUseCase(f.containerRepo, f.collectionRepo, f.preferencesRepo, f.authService, f.labelRenderer)
+		return f
+	}
+
+	userID := entities.NewUserID()
+	expires := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	// the jam sits on the pantry shelf in the kitchen
+	newPantry := func() (*entities.Collection, *entities.Container, *entities.Object) {
+		collectionID := entities.NewCollectionID()
+		jam := NewTestObject(ObjName("Strawberry jam"), ObjExpiresAt(expires))
+		kitchen := NewTestContainer(CtrName("Kitchen"), CtrType(entities.ContainerTypeRoom), CtrCollectionID(collectionID))
+		pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID), CtrParentID(kitchen.ID()), CtrObjects(*jam))
+		col := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(*kitchen, *pantry))
+		return col, pantry, jam
+	}
+
+	t.Run("uses the template from the user's preferences", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, pantry, jam := newPantry()
+		prefs := entities.NewUserPreferences(userID)
+		require.NoError(t, prefs.SetLabelTemplate("brother-dk-11204"))
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), jam.ID()).Return(pantry, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(prefs, nil)
+		f.labelRenderer.EXPECT().RenderLabel(gomock.Any(), gomock.Any(), services.LabelFormatPNG).
+			DoAndReturn(func(_ context.Context, label *services.ObjectLabel, _ services.LabelFormat) ([]byte, error) {
+				assert.Equal(t, "Strawberry jam", label.Name)
+				assert.Equal(t, "Kitchen / Pantry", label.ContainerPath)
+				assert.Equal(t, jam.ID().String(), label.Code)
+				require.NotNil(t, label.ExpiresAt)
+				assert.True(t, expires.Equal(*label.ExpiresAt))
+				assert.Equal(t, "brother-dk-11204", label.Template.Key)
+				return []byte("png"), nil
+			})
+
+		resp, err := f.useCase.Execute(context.Background(), GenerateObjectLabelRequest{
+			ObjectID: jam.ID(),
+			Format:   services.LabelFormatPNG,
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("png"), resp.Label)
+		assert.Equal(t, "Strawberry jam", resp.ObjectName)
+	})
+
+	t.Run("requested template wins and defaults to pdf", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, pantry, jam := newPantry()
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), jam.ID()).Return(pantry, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.labelRenderer.EXPECT().RenderLabel(gomock.Any(), gomock.Any(), services.LabelFormatPDF).
+			DoAndReturn(func(_ context.Context, label *services.ObjectLabel, _ services.LabelFormat) ([]byte, error) {
+				assert.Equal(t, "dymo-11354", label.Template.Key)
+				return []byte("%PDF"), nil
+			})
+
+		_, err := f.useCase.Execute(context.Background(), GenerateObjectLabelRequest{
+			ObjectID: jam.ID(),
+			Template: "dymo-11354",
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("users without preferences get the default template", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, pantry, jam := newPantry()
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), jam.ID()).Return(pantry, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrUserPreferencesNotFound)
+		f.labelRenderer.EXPECT().RenderLabel(gomock.Any(), gomock.Any(), services.LabelFormatPDF).
+			DoAndReturn(func(_ context.Context, label *services.ObjectLabel, _ services.LabelFormat) ([]byte, error) {
+				assert.Equal(t, entities.DefaultLabelTemplate, label.Template.Key)
+				return []byte("%PDF"), nil
+			})
+
+		_, err := f.useCase.Execute(context.Background(), GenerateObjectLabelRequest{ObjectID: jam.ID(), UserID: userID})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown template is rejected", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, pantry, jam := newPantry()
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), jam.ID()).Return(pantry, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), GenerateObjectLabelRequest{
+			ObjectID: jam.ID(),
+			Template: "avery-5160",
+			UserID:   userID,
+		})
+
+		require.ErrorIs(t, err, entities.ErrUnknownLabelTemplate)
+	})
+
+	t.Run("another user's object is denied", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, pantry, jam := newPantry()
+		other := entities.NewUserID()
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), jam.ID()).Return(pantry, nil)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), other.String()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), GenerateObjectLabelRequest{ObjectID: jam.ID(), UserID: other})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// folderExpiringWithin is how far ahead folder stats look for food that is
+// about to expire.
+const folderExpiringWithin = 7 * 24 * time.Hour
+
+type GetCollectionFolderStatsRequest struct {
+	FolderID  entities.CollectionFolderID
+	UserID    entities.UserID
+	UserToken string
+	// Now decides which objects have expired or are about to.
+	Now time.Time
+}
+
+type GetCollectionFolderStatsResponse struct {
+	Stats entities.CollectionFolderStats
+}
+
+type GetCollectionFolderStatsUseCase struct {
+	folderRepo     repositories.CollectionFolderRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewGetCollectionFolderStatsUseCase(folderRepo repositories.CollectionFolderRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *GetCollectionFolderStatsUseCase {
+	return &GetCollectionFolderStatsUseCase{
+		folderRepo:     folderRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+// Execute counts what is filed under the folder. Collections that were
+// deleted or are no longer shared with the user are skipped rather than
+// failing the whole request.
+func (uc *GetCollectionFolderStatsUseCase) Execute(ctx context.Context, req GetCollectionFolderStatsRequest) (*GetCollectionFolderStatsResponse, error) {
+	folder, err := uc.folderRepo.GetByID(ctx, req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	if !folder.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrCollectionFolderNotFound
+	}
+
+	folders, err := uc.folderRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection folders: %w", err)
+	}
+	descendants := entities.FolderDescendants(folders, folder.ID())
+	collectionIDs := append([]entities.CollectionID(nil), folder.CollectionIDs()...)
+	for _, f := range folders {
+		for _, id := range descendants {
+			if f.ID().Equals(id) {
+				collectionIDs = append(collectionIDs, f.CollectionIDs()...)
+			}
+		}
+	}
+
+	stats := entities.CollectionFolderStats{Folders: len(descendants)}
+	if len(collectionIDs) == 0 {
+		return &GetCollectionFolderStatsResponse{Stats: stats}, nil
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	expiringBefore := req.Now.Add(folderExpiringWithin)
+	for _, id := range collectionIDs {
+		collection, err := uc.collectionRepo.GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+		hasAccess := collection.IsOwnedBy(req.UserID)
+		if !hasAccess && collection.GroupID() != nil {
+			for _, group := range userGroups {
+				if group.ID().Equals(*collection.GroupID()) {
+					hasAccess = true
+					break
+				}
+			}
+		}
+		if !hasAccess {
+			continue
+		}
+
+		stats.Collections++
+		stats.Containers += collection.ContainerCount()
+		for _, obj := range collection.GetAllObjects() {
+			if !obj.IsCounted() {
+				continue
+			}
+			stats.Objects++
+			if obj.IsLowStock() {
+				stats.LowStock++
+			}
+			if exp := obj.ExpiresAt(); exp != nil {
+				switch {
+				case exp.Before(req.Now):
+					stats.Expired++
+				case exp.Before(expiringBefore):
+					stats.ExpiringSoon++
+				}
+			}
+		}
+	}
+
+	return &GetCollectionFolderStatsResponse{
+		Stats: stats,
+	}, nil
+}
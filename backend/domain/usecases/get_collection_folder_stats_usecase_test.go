@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetCollectionFolderStatsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFolderRepo := mocks.NewMockCollectionFolderRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewGetCollectionFolderStatsUseCase(mockFolderRepo, mockCollectionRepo, mockAuthService)
+
+	t.Run("success - counts subfolders and skips missing collections", func(t *testing.T) {
+		userID := entities.NewUserID()
+		now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		pantry := NewTestCollection(ColUserID(userID), ColContainers(
+			*NewTestContainer(CtrObjects(
+				*NewTestObject(ObjExpiresAt(now.Add(-time.Hour))),
+				*NewTestObject(ObjExpiresAt(now.Add(48 * time.Hour))),
+				*NewTestObject(ObjExpiresAt(now.Add(30 * 24 * time.Hour))),
+			)),
+			*NewTestContainer(CtrObjects(
+				*NewTestObject(ObjQuantity(1), ObjMinQuantity(2)),
+				*NewTestObject(ObjArchivedAt(now.Add(-24 * time.Hour))),
+			)),
+		))
+		freezer := NewTestCollection(ColUserID(userID), ColContainers(*NewTestContainer()))
+		deletedID := entities.NewCollectionID()
+
+		kitchen := NewTestFolder(userID, "Kitchen", nil, pantry.ID())
+		cold := NewTestFolder(userID, "Cold", kitchen, freezer.ID(), deletedID)
+		garage := NewTestFolder(userID, "Garage", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), kitchen.ID()).Return(kitchen, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{cold, garage, kitchen}, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), pantry.ID()).Return(pantry, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), freezer.ID()).Return(freezer, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), deletedID).Return(nil, errors.New("collection not found"))
+
+		resp, err := useCase.Execute(context.Background(), GetCollectionFolderStatsRequest{
+			FolderID:  kitchen.ID(),
+			UserID:    userID,
+			UserToken: "test-token",
+			Now:       now,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.CollectionFolderStats{
+			Folders:      1,
+			Collections:  2,
+			Containers:   3,
+			Objects:      4,
+			LowStock:     1,
+			ExpiringSoon: 1,
+			Expired:      1,
+		}, resp.Stats)
+	})
+
+	t.Run("success - empty folder", func(t *testing.T) {
+		userID := entities.NewUserID()
+		folder := NewTestFolder(userID, "Empty", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), folder.ID()).Return(folder, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{folder}, nil)
+
+		resp, err := useCase.Execute(context.Background(), GetCollectionFolderStatsRequest{
+			FolderID:  folder.ID(),
+			UserID:    userID,
+			UserToken: "test-token",
+			Now:       time.Now(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.CollectionFolderStats{}, resp.Stats)
+	})
+}
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/nishiki/backend/domain/entities"
@@ -14,15 +15,20 @@ type GetCollectionObjectsRequest struct {
 	CollectionID    entities.CollectionID
 	UserID          entities.UserID
 	UserToken       string
-	Query           string                // name contains (case-insensitive)
-	Tags            []string              // all listed tags must be present
-	ContainerID     *entities.ContainerID // only objects in this container
-	PropertyFilters map[string]string     // property key → substring match (case-insensitive)
+	Query           string                  // name contains (case-insensitive)
+	Tags            []string                // all listed tags must be present
+	ContainerID     *entities.ContainerID   // only objects in this container
+	PropertyFilters map[string]string       // property key → substring match (case-insensitive)
+	Conditions      []entities.Condition    // graded with any of these
+	Statuses        []entities.ObjectStatus // in any of these statuses; all when empty
+	Sort            entities.SortOptions    // optional ordering; see ObjectSortFields
+	Archived        bool                    // list archived objects instead of active ones
 }
 
 type ObjectWithContainerID struct {
-	Object      entities.Object
-	ContainerID entities.ContainerID
+	Object         entities.Object
+	ContainerID    entities.ContainerID
+	StorageWarning *entities.StorageWarning
 }
 
 type GetCollectionObjectsResponse struct {
@@ -74,7 +80,8 @@ func (uc *GetCollectionObjectsUseCase) Execute(ctx context.Context, req GetColle
 	var allObjects []ObjectWithContainerID
 	for _, c := range containers {
 		for _, obj := range c.Objects() {
-			allObjects = append(allObjects, ObjectWithContainerID{Object: obj, ContainerID: c.ID()})
+			// The collection is not loaded here, so untyped objects go unchecked
+			allObjects = append(allObjects, ObjectWithContainerID{Object: obj, ContainerID: c.ID(), StorageWarning: storageWarning(c, obj, "")})
 		}
 	}
 
@@ -82,6 +89,9 @@ func (uc *GetCollectionObjectsUseCase) Execute(ctx context.Context, req GetColle
 	var filtered []ObjectWithContainerID
 	query := strings.ToLower(req.Query)
 	for _, item := range allObjects {
+		if item.Object.IsArchived() != req.Archived {
+			continue
+		}
 		if query != "" && !strings.Contains(strings.ToLower(item.Object.Name().String()), query) {
 			continue
 		}
@@ -91,8 +101,15 @@ func (uc *GetCollectionObjectsUseCase) Execute(ctx context.Context, req GetColle
 		if !matchesPropertyFilters(item.Object, req.PropertyFilters) {
 			continue
 		}
+		if len(req.Conditions) > 0 && !slices.Contains(req.Conditions, item.Object.Condition()) {
+			continue
+		}
+		if len(req.Statuses) > 0 && !slices.Contains(req.Statuses, item.Object.Status()) {
+			continue
+		}
 		filtered = append(filtered, item)
 	}
+	sortObjects(filtered, req.Sort)
 
 	return &GetCollectionObjectsResponse{
 		Objects: filtered,
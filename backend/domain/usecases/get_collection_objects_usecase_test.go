@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,15 +29,16 @@ func TestGetCollectionObjectsUseCase_Filters(t *testing.T) {
 	userGroups := []*entities.Group{}
 
 	obj1 := *NewTestObject(ObjName("Apple Juice"), ObjTags("food", "beverage"), ObjProps(Props("brand", "Tropicana", "for_sale", "true")))
-	obj2 := *NewTestObject(ObjName("Banana Smoothie"), ObjTags("food"), ObjProps(Props("brand", "Dole")))
-	obj3 := *NewTestObject(ObjName("Code Book"), ObjTags("book"), ObjProps(Props("author", "Clean Coder")))
+	obj2 := *NewTestObject(ObjName("Banana Smoothie"), ObjStatus(entities.ObjectStatusWanted), ObjTags("food"), ObjProps(Props("brand", "Dole")))
+	obj3 := *NewTestObject(ObjName("Code Book"), ObjType(entities.ObjectTypeBook), ObjCondition(entities.ConditionGood), ObjTags("book"), ObjProps(Props("author", "Clean Coder")))
+	obj4 := *NewTestObject(ObjName("Finished Book"), ObjTags("book"), ObjArchivedAt(time.Now()))
 
 	collectionID := entities.NewCollectionID()
 	cid1 := entities.NewContainerID()
 	cid2 := entities.NewContainerID()
 
 	c1 := NewTestContainer(CtrID(cid1), CtrCollectionID(collectionID), CtrName("Container A"), CtrObjects(obj1, obj2))
-	c2 := NewTestContainer(CtrID(cid2), CtrCollectionID(collectionID), CtrName("Container B"), CtrObjects(obj3))
+	c2 := NewTestContainer(CtrID(cid2), CtrCollectionID(collectionID), CtrName("Container B"), CtrObjects(obj3, obj4))
 
 	collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(*c1, *c2))
 
@@ -51,7 +53,7 @@ func TestGetCollectionObjectsUseCase_Filters(t *testing.T) {
 		mockContainerRepo.EXPECT().GetByCollectionIDWithAccess(gomock.Any(), collection.ID(), userID, gomock.Any()).Return(containerPtrs, nil)
 	}
 
-	t.Run("no filters returns all objects", func(t *testing.T) {
+	t.Run("no filters returns all active objects", func(t *testing.T) {
 		setupMocks()
 		resp, err := uc.Execute(context.Background(), GetCollectionObjectsRequest{
 			CollectionID: collection.ID(), UserID: userID, UserToken: "tok",
@@ -143,6 +145,106 @@ func TestGetCollectionObjectsUseCase_Filters(t *testing.T) {
 		require.Len(t, resp.Objects, 1)
 		assert.Equal(t, "Banana Smoothie", resp.Objects[0].Object.Name().String())
 	})
+
+	t.Run("condition filter matches any listed grade", func(t *testing.T) {
+		setupMocks()
+		resp, err := uc.Execute(context.Background(), GetCollectionObjectsRequest{
+			CollectionID: collection.ID(), UserID: userID, UserToken: "tok",
+			Conditions: []entities.Condition{entities.ConditionMint, entities.ConditionGood},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Objects, 1)
+		assert.Equal(t, "Code Book", resp.Objects[0].Object.Name().String())
+	})
+
+	t.Run("status filter separates the wishlist", func(t *testing.T) {
+		setupMocks()
+		resp, err := uc.Execute(context.Background(), GetCollectionObjectsRequest{
+			CollectionID: collection.ID(), UserID: userID, UserToken: "tok",
+			Statuses: []entities.ObjectStatus{entities.ObjectStatusWanted, entities.ObjectStatusOrdered},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Objects, 1)
+		assert.Equal(t, "Banana Smoothie", resp.Objects[0].Object.Name().String())
+
+		setupMocks()
+		resp, err = uc.Execute(context.Background(), GetCollectionObjectsRequest{
+			CollectionID: collection.ID(), UserID: userID, UserToken: "tok",
+			Statuses: []entities.ObjectStatus{entities.ObjectStatusOwned},
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.Objects, 2)
+	})
+
+	t.Run("archived filter returns only archived objects", func(t *testing.T) {
+		setupMocks()
+		resp, err := uc.Execute(context.Background(), GetCollectionObjectsRequest{
+			CollectionID: collection.ID(), UserID: userID, UserToken: "tok", Archived: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Objects, 1)
+		assert.Equal(t, "Finished Book", resp.Objects[0].Object.Name().String())
+	})
+}
+
+func TestGetCollectionObjectsUseCase_Sort(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	uc := NewGetCollectionObjectsUseCase(mockCollectionRepo, mockContainerRepo, mockAuthService)
+
+	userID := entities.NewUserID()
+	now := time.Now()
+
+	milk := *NewTestObject(ObjName("milk"), ObjQuantity(2), ObjExpiresAt(now.Add(48*time.Hour)))
+	bread := *NewTestObject(ObjName("Bread"), ObjQuantity(1), ObjExpiresAt(now.Add(24*time.Hour)))
+	salt := *NewTestObject(ObjName("Salt"))
+
+	collectionID := entities.NewCollectionID()
+	c := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(milk, bread, salt))
+
+	execute := func(field entities.SortField, order entities.SortOrder) []string {
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "tok", userID.String()).Return([]*entities.Group{}, nil)
+		mockContainerRepo.EXPECT().GetByCollectionIDWithAccess(gomock.Any(), collectionID, userID, gomock.Any()).Return([]*entities.Container{c}, nil)
+
+		resp, err := uc.Execute(context.Background(), GetCollectionObjectsRequest{
+			CollectionID: collectionID, UserID: userID, UserToken: "tok",
+			Sort: entities.SortOptions{Field: field, Order: order},
+		})
+		require.NoError(t, err)
+		names := make([]string, len(resp.Objects))
+		for i, item := range resp.Objects {
+			names[i] = item.Object.Name().String()
+		}
+		return names
+	}
+
+	t.Run("no sort keeps storage order", func(t *testing.T) {
+		assert.Equal(t, []string{"milk", "Bread", "Salt"}, execute("", ""))
+	})
+
+	t.Run("name ascending is case-insensitive", func(t *testing.T) {
+		assert.Equal(t, []string{"Bread", "milk", "Salt"}, execute(entities.SortFieldName, entities.SortOrderAsc))
+	})
+
+	t.Run("name descending", func(t *testing.T) {
+		assert.Equal(t, []string{"Salt", "milk", "Bread"}, execute(entities.SortFieldName, entities.SortOrderDesc))
+	})
+
+	t.Run("expires_at puts missing values last", func(t *testing.T) {
+		assert.Equal(t, []string{"Bread", "milk", "Salt"}, execute(entities.SortFieldExpiresAt, entities.SortOrderAsc))
+		assert.Equal(t, []string{"milk", "Bread", "Salt"}, execute(entities.SortFieldExpiresAt, entities.SortOrderDesc))
+	})
+
+	t.Run("quantity descending puts missing values last", func(t *testing.T) {
+		assert.Equal(t, []string{"milk", "Bread", "Salt"}, execute(entities.SortFieldQuantity, entities.SortOrderDesc))
+	})
 }
 
 func TestGetCollectionObjectsUseCase_AccessControl(t *testing.T) {
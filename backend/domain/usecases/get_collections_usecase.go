@@ -14,6 +14,7 @@ type GetCollectionsRequest struct {
 	UserID       entities.UserID
 	CollectionID *entities.CollectionID // Optional - for single collection
 	UserToken    string
+	Sort         entities.SortOptions // Optional ordering for the list; see CollectionSortFields. Pinned collections come first.
 }
 
 type GetCollectionsResponse struct {
@@ -21,14 +22,16 @@ type GetCollectionsResponse struct {
 }
 
 type GetCollectionsUseCase struct {
-	collectionRepo repositories.CollectionRepository
-	authService    services.AuthService
+	collectionRepo  repositories.CollectionRepository
+	preferencesRepo repositories.UserPreferencesRepository
+	authService     services.AuthService
 }
 
-func NewGetCollectionsUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *GetCollectionsUseCase {
+func NewGetCollectionsUseCase(collectionRepo repositories.CollectionRepository, preferencesRepo repositories.UserPreferencesRepository, authService services.AuthService) *GetCollectionsUseCase {
 	return &GetCollectionsUseCase{
-		collectionRepo: collectionRepo,
-		authService:    authService,
+		collectionRepo:  collectionRepo,
+		preferencesRepo: preferencesRepo,
+		authService:     authService,
 	}
 }
 
@@ -48,18 +51,24 @@ func (uc *GetCollectionsUseCase) Execute(ctx context.Context, req GetCollections
 		return &GetCollectionsResponse{Collections: []*entities.Collection{collection}}, nil
 	}
 
-	// Get all collections for user (summary: no container data, avoids N+1 queries)
-	collections, err := uc.collectionRepo.GetByUserIDSummary(ctx, req.UserID)
+	collections, err := listAccessibleCollections(ctx, uc.collectionRepo, uc.authService, req.UserID, req.UserToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get collections: %w", err)
+		return nil, err
 	}
+	sortCollections(collections, req.Sort)
+
+	order, err := userItemOrder(ctx, uc.preferencesRepo, req.UserID, entities.OrderScopeCollections)
+	if err != nil {
+		return nil, err
+	}
+	applyItemOrder(collections, func(c *entities.Collection) string { return c.ID().String() }, order, req.Sort)
 
 	return &GetCollectionsResponse{Collections: collections}, nil
 }
 
 func (uc *GetCollectionsUseCase) validateCollectionAccess(ctx context.Context, collection *entities.Collection, userID entities.UserID, userToken string) error {
 	// User owns collection
-	if collection.UserID().Equals(userID) {
+	if collection.IsOwnedBy(userID) {
 		return nil
 	}
 
@@ -79,3 +88,38 @@ func (uc *GetCollectionsUseCase) validateCollectionAccess(ctx context.Context, c
 
 	return errors.New("access denied")
 }
+
+// listAccessibleCollections returns the user's own collections plus those
+// owned by their groups, as summaries without container data.
+func listAccessibleCollections(ctx context.Context, collectionRepo repositories.CollectionRepository, authService services.AuthService, userID entities.UserID, userToken string) ([]*entities.Collection, error) {
+	// Summary: no container data, avoids N+1 queries
+	collections, err := collectionRepo.GetByUserIDSummary(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	// Plus those owned by the user's groups, which have no personal owner
+	userGroups, err := authService.GetUserGroups(ctx, userToken, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	groupOwned, err := groupOwnedCollections(ctx, collectionRepo, userGroups)
+	if err != nil {
+		return nil, err
+	}
+	return append(collections, groupOwned...), nil
+}
+
+// groupOwnedCollections returns the summaries of the collections owned by
+// any of groups.
+func groupOwnedCollections(ctx context.Context, collectionRepo repositories.CollectionRepository, groups []*entities.Group) ([]*entities.Collection, error) {
+	groupIDs := make([]entities.GroupID, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = group.ID()
+	}
+	groupOwned, err := collectionRepo.GetGroupOwnedSummary(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group collections: %w", err)
+	}
+	return groupOwned, nil
+}
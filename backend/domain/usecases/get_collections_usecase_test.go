@@ -20,9 +20,10 @@ func TestGetCollectionsUseCase_Execute(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockPreferencesRepo := mocks.NewMockUserPreferencesRepository(mockCtrl)
 	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewGetCollectionsUseCase(mockCollectionRepo, mockAuthService)
+	useCase := NewGetCollectionsUseCase(mockCollectionRepo, mockPreferencesRepo, mockAuthService)
 
 	t.Run("success - get all user collections", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -36,6 +37,9 @@ func TestGetCollectionsUseCase_Execute(t *testing.T) {
 		req := GetCollectionsRequest{UserID: userID, UserToken: "test-token"}
 
 		mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return(collections, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), gomock.Len(0)).Return(nil, nil)
+		mockPreferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrUserPreferencesNotFound)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -44,6 +48,74 @@ func TestGetCollectionsUseCase_Execute(t *testing.T) {
 		assert.Len(t, resp.Collections, 3)
 	})
 
+	t.Run("success - includes collections owned by the user's groups", func(t *testing.T) {
+		userID := entities.NewUserID()
+		group := NewTestGroup()
+		groupID := group.ID()
+
+		owned := NewTestCollection(ColUserID(userID), ColName("Mine"))
+		shared := NewTestCollection(ColName("Household"), ColGroupID(&groupID), ColGroupOwned())
+
+		req := GetCollectionsRequest{UserID: userID, UserToken: "test-token"}
+
+		mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{owned}, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), []entities.GroupID{groupID}).Return([]*entities.Collection{shared}, nil)
+		mockPreferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrUserPreferencesNotFound)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Collections, 2)
+		assert.True(t, resp.Collections[1].IsGroupOwned())
+	})
+
+	t.Run("success - pinned collections first, then the user's custom order", func(t *testing.T) {
+		userID := entities.NewUserID()
+
+		pantry := NewTestCollection(ColUserID(userID), ColName("Pantry"))
+		garage := NewTestCollection(ColUserID(userID), ColName("Garage"))
+		books := NewTestCollection(ColUserID(userID), ColName("Books"))
+		attic := NewTestCollection(ColUserID(userID), ColName("Attic"))
+
+		preferences := entities.NewUserPreferences(userID)
+		require.NoError(t, preferences.SetItemOrder(entities.OrderScopeCollections, garage.ID().String(), entities.ItemOrder{SortIndex: 2, Pinned: true}))
+		require.NoError(t, preferences.SetItemOrder(entities.OrderScopeCollections, books.ID().String(), entities.ItemOrder{SortIndex: 1}))
+		require.NoError(t, preferences.SetItemOrder(entities.OrderScopeCollections, pantry.ID().String(), entities.ItemOrder{SortIndex: 3}))
+
+		names := func(collections []*entities.Collection) []string {
+			out := make([]string, len(collections))
+			for i, c := range collections {
+				out[i] = c.Name().String()
+			}
+			return out
+		}
+		expectList := func() {
+			mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{pantry, garage, books, attic}, nil)
+			mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+			mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), gomock.Len(0)).Return(nil, nil)
+			mockPreferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(preferences, nil)
+		}
+
+		expectList()
+		resp, err := useCase.Execute(context.Background(), GetCollectionsRequest{
+			UserID:    userID,
+			UserToken: "test-token",
+			Sort:      entities.SortOptions{Field: entities.SortFieldName, Order: entities.SortOrderAsc},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Garage", "Attic", "Books", "Pantry"}, names(resp.Collections))
+
+		expectList()
+		resp, err = useCase.Execute(context.Background(), GetCollectionsRequest{
+			UserID:    userID,
+			UserToken: "test-token",
+			Sort:      entities.SortOptions{Field: entities.SortFieldCustom, Order: entities.SortOrderAsc},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Garage", "Books", "Pantry", "Attic"}, names(resp.Collections))
+	})
+
 	t.Run("success - get single collection owned by user", func(t *testing.T) {
 		userID := entities.NewUserID()
 		collectionID := entities.NewCollectionID()
@@ -90,6 +162,9 @@ func TestGetCollectionsUseCase_Execute(t *testing.T) {
 		req := GetCollectionsRequest{UserID: userID, UserToken: "test-token"}
 
 		mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{}, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), gomock.Len(0)).Return(nil, nil)
+		mockPreferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrUserPreferencesNotFound)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
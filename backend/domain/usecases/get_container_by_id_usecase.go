@@ -54,7 +54,7 @@ func (uc *GetContainerByIDUseCase) Execute(ctx context.Context, req GetContainer
 	}
 
 	// Check access: user is owner OR user is member of collection's group
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -13,6 +13,7 @@ type GetContainersByCollectionRequest struct {
 	CollectionID entities.CollectionID
 	UserID       entities.UserID
 	UserToken    string
+	Sort         entities.SortOptions // Optional ordering; see ContainerSortFields. Pinned containers come first.
 }
 
 type GetContainersByCollectionResponse struct {
@@ -20,16 +21,18 @@ type GetContainersByCollectionResponse struct {
 }
 
 type GetContainersByCollectionUseCase struct {
-	containerRepo  repositories.ContainerRepository
-	collectionRepo repositories.CollectionRepository
-	authService    services.AuthService
+	containerRepo   repositories.ContainerRepository
+	collectionRepo  repositories.CollectionRepository
+	preferencesRepo repositories.UserPreferencesRepository
+	authService     services.AuthService
 }
 
-func NewGetContainersByCollectionUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *GetContainersByCollectionUseCase {
+func NewGetContainersByCollectionUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, preferencesRepo repositories.UserPreferencesRepository, authService services.AuthService) *GetContainersByCollectionUseCase {
 	return &GetContainersByCollectionUseCase{
-		containerRepo:  containerRepo,
-		collectionRepo: collectionRepo,
-		authService:    authService,
+		containerRepo:   containerRepo,
+		collectionRepo:  collectionRepo,
+		preferencesRepo: preferencesRepo,
+		authService:     authService,
 	}
 }
 
@@ -50,6 +53,13 @@ func (uc *GetContainersByCollectionUseCase) Execute(ctx context.Context, req Get
 	if err != nil {
 		return nil, fmt.Errorf("failed to get containers for collection: %w", err)
 	}
+	sortContainers(containers, req.Sort)
+
+	order, err := userItemOrder(ctx, uc.preferencesRepo, req.UserID, entities.OrderScopeContainers)
+	if err != nil {
+		return nil, err
+	}
+	applyItemOrder(containers, func(c *entities.Container) string { return c.ID().String() }, order, req.Sort)
 
 	return &GetContainersByCollectionResponse{
 		Containers: containers,
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetDashboardRequest struct {
+	UserID    entities.UserID
+	UserToken string
+	Now       time.Time
+	// ExpiringDays is how far past Now an expiry date counts as expiring, up
+	// to entities.MaxExpiringDays.
+	ExpiringDays int
+	Sort         entities.SortOptions // Ordering for the collections; see CollectionSortFields
+}
+
+// DashboardCollectionStats counts a collection's owned, active objects.
+type DashboardCollectionStats struct {
+	ObjectCount   int
+	LowStockCount int
+	// ExpiringCount counts the objects expiring within ExpiringDays that
+	// have not expired yet; ExpiredCount those past their expiry date.
+	ExpiringCount int
+	ExpiredCount  int
+}
+
+type GetDashboardResponse struct {
+	Groups []*entities.Group
+	// Collections are ordered as GetCollectionsUseCase orders them. Stats
+	// holds each one's counts at the same index.
+	Collections []*entities.Collection
+	Stats       []DashboardCollectionStats
+}
+
+// GetDashboardUseCase gathers what the dashboard shows on sign-in, so the
+// client needs one request instead of one per list.
+type GetDashboardUseCase struct {
+	collectionRepo  repositories.CollectionRepository
+	containerRepo   repositories.ContainerRepository
+	preferencesRepo repositories.UserPreferencesRepository
+	authService     services.AuthService
+}
+
+func NewGetDashboardUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, preferencesRepo repositories.UserPreferencesRepository, authService services.AuthService) *GetDashboardUseCase {
+	return &GetDashboardUseCase{
+		collectionRepo:  collectionRepo,
+		containerRepo:   containerRepo,
+		preferencesRepo: preferencesRepo,
+		authService:     authService,
+	}
+}
+
+func (uc *GetDashboardUseCase) Execute(ctx context.Context, req GetDashboardRequest) (*GetDashboardResponse, error) {
+	if req.ExpiringDays < 1 || req.ExpiringDays > entities.MaxExpiringDays {
+		return nil, entities.ErrInvalidExpiringDays
+	}
+
+	groups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups for user: %w", err)
+	}
+
+	// The groups are needed anyway, so listAccessibleCollections' lookup of
+	// them is not repeated
+	collections, err := uc.collectionRepo.GetByUserIDSummary(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+	groupOwned, err := groupOwnedCollections(ctx, uc.collectionRepo, groups)
+	if err != nil {
+		return nil, err
+	}
+	collections = append(collections, groupOwned...)
+	sortCollections(collections, req.Sort)
+
+	order, err := userItemOrder(ctx, uc.preferencesRepo, req.UserID, entities.OrderScopeCollections)
+	if err != nil {
+		return nil, err
+	}
+	applyItemOrder(collections, func(c *entities.Collection) string { return c.ID().String() }, order, req.Sort)
+
+	stats := make([]DashboardCollectionStats, len(collections))
+	before := req.Now.AddDate(0, 0, req.ExpiringDays)
+	for i, col := range collections {
+		// The list holds summaries, so the counts come from the containers
+		containers, err := uc.containerRepo.GetByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers for collection %s: %w", col.ID().String(), err)
+		}
+		for _, container := range containers {
+			stats[i].ObjectCount += container.ObjectCount()
+			stats[i].LowStockCount += container.LowStockCount()
+			for _, obj := range container.ActiveObjects() {
+				exp := obj.ExpiresAt()
+				switch {
+				case exp == nil || !obj.IsOwned() || !exp.Before(before):
+				case exp.Before(req.Now):
+					stats[i].ExpiredCount++
+				default:
+					stats[i].ExpiringCount++
+				}
+			}
+		}
+	}
+
+	return &GetDashboardResponse{
+		Groups:      groups,
+		Collections: collections,
+		Stats:       stats,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetDashboardUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockPreferencesRepo := mocks.NewMockUserPreferencesRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewGetDashboardUseCase(mockCollectionRepo, mockContainerRepo, mockPreferencesRepo, mockAuthService)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	userID := entities.NewUserID()
+	groupID := entities.NewGroupID()
+	group := NewTestGroup(GrpID(groupID), GrpName("Household"))
+
+	kitchenID := entities.NewCollectionID()
+	kitchen := NewTestCollection(ColID(kitchenID), ColUserID(userID), ColName("Kitchen"))
+	sharedID := entities.NewCollectionID()
+	shared := NewTestCollection(ColID(sharedID), ColGroupID(&groupID), ColGroupOwned(), ColName("Garage"))
+
+	fridge := NewTestContainer(CtrCollectionID(kitchenID), CtrObjects(
+		*NewTestObject(ObjName("Milk"), ObjExpiresAt(now.AddDate(0, 0, 3)), ObjQuantity(1), ObjMinQuantity(2)),
+		*NewTestObject(ObjName("Old bread"), ObjExpiresAt(now.AddDate(0, 0, -1))),
+		*NewTestObject(ObjName("Jam"), ObjExpiresAt(now.AddDate(0, 2, 0))),
+		*NewTestObject(ObjName("Archived yogurt"), ObjExpiresAt(now.AddDate(0, 0, 1)), ObjArchivedAt(now)),
+		*NewTestObject(ObjName("Wanted cheese"), ObjExpiresAt(now.AddDate(0, 0, 1)), ObjStatus(entities.ObjectStatusWanted)),
+	))
+	shelf := NewTestContainer(CtrCollectionID(sharedID), CtrObjects(
+		*NewTestObject(ObjName("Oil"), ObjQuantity(0), ObjMinQuantity(1)),
+	))
+
+	mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{group}, nil)
+	mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{kitchen}, nil)
+	mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), []entities.GroupID{groupID}).Return([]*entities.Collection{shared}, nil)
+	mockPreferencesRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrUserPreferencesNotFound)
+	mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), kitchenID).Return([]*entities.Container{fridge}, nil)
+	mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), sharedID).Return([]*entities.Container{shelf}, nil)
+
+	resp, err := useCase.Execute(context.Background(), GetDashboardRequest{
+		UserID:       userID,
+		UserToken:    "token",
+		Now:          now,
+		ExpiringDays: 7,
+		Sort:         entities.SortOptions{Field: entities.SortFieldName, Order: entities.SortOrderAsc},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Groups, 1)
+	assert.Equal(t, groupID, resp.Groups[0].ID())
+	require.Len(t, resp.Collections, 2)
+	assert.Equal(t, "Garage", resp.Collections[0].Name().String())
+	assert.Equal(t, "Kitchen", resp.Collections[1].Name().String())
+	assert.Equal(t, []DashboardCollectionStats{
+		{ObjectCount: 1, LowStockCount: 1},
+		{ObjectCount: 3, LowStockCount: 1, ExpiringCount: 1, ExpiredCount: 1},
+	}, resp.Stats)
+}
+
+func TestGetDashboardUseCase_InvalidDays(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	useCase := NewGetDashboardUseCase(mocks.NewMockCollectionRepository(mockCtrl), mocks.NewMockContainerRepository(mockCtrl), mocks.NewMockUserPreferencesRepository(mockCtrl), mocks.NewMockAuthService(mockCtrl))
+
+	for _, days := range []int{0, entities.MaxExpiringDays + 1} {
+		_, err := useCase.Execute(context.Background(), GetDashboardRequest{UserID: entities.NewUserID(), ExpiringDays: days})
+		assert.ErrorIs(t, err, entities.ErrInvalidExpiringDays)
+	}
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type GetDigestPreferencesRequest struct {
+	UserID entities.UserID
+	// Email is reported back when the user has never saved preferences.
+	Email entities.EmailAddress
+}
+
+type GetDigestPreferencesResponse struct {
+	Email      entities.EmailAddress
+	Frequency  entities.DigestFrequency
+	LastSentAt *time.Time
+}
+
+type GetDigestPreferencesUseCase struct {
+	digestRepo repositories.DigestSubscriptionRepository
+}
+
+func NewGetDigestPreferencesUseCase(digestRepo repositories.DigestSubscriptionRepository) *GetDigestPreferencesUseCase {
+	return &GetDigestPreferencesUseCase{
+		digestRepo: digestRepo,
+	}
+}
+
+func (uc *GetDigestPreferencesUseCase) Execute(ctx context.Context, req GetDigestPreferencesRequest) (*GetDigestPreferencesResponse, error) {
+	subscription, err := uc.digestRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, entities.ErrDigestSubscriptionNotFound) {
+			return &GetDigestPreferencesResponse{
+				Email:     req.Email,
+				Frequency: entities.DigestFrequencyOff,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get digest preferences: %w", err)
+	}
+
+	return &GetDigestPreferencesResponse{
+		Email:      subscription.Email(),
+		Frequency:  subscription.Frequency(),
+		LastSentAt: subscription.LastSentAt(),
+	}, nil
+}
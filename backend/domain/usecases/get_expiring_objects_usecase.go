@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetExpiringObjectsRequest struct {
+	UserID    entities.UserID
+	UserToken string
+	Now       time.Time
+	// Days is how far past Now an expiry date still counts, up to
+	// entities.MaxExpiringDays. Objects that have already expired are always
+	// included.
+	Days int
+}
+
+// ExpiringObject is an object with a due expiry date and where to find it.
+type ExpiringObject struct {
+	Object         entities.Object
+	ContainerID    entities.ContainerID
+	ContainerName  string
+	CollectionID   entities.CollectionID
+	CollectionName string
+}
+
+type GetExpiringObjectsResponse struct {
+	// Objects are ordered by expiry date, soonest first.
+	Objects []ExpiringObject
+}
+
+type GetExpiringObjectsUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	authService    services.AuthService
+}
+
+func NewGetExpiringObjectsUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, authService services.AuthService) *GetExpiringObjectsUseCase {
+	return &GetExpiringObjectsUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		authService:    authService,
+	}
+}
+
+// Execute lists the active objects across every collection the user can see
+// whose expiry date falls within req.Days of req.Now.
+func (uc *GetExpiringObjectsUseCase) Execute(ctx context.Context, req GetExpiringObjectsRequest) (*GetExpiringObjectsResponse, error) {
+	if req.Days < 1 || req.Days > entities.MaxExpiringDays {
+		return nil, entities.ErrInvalidExpiringDays
+	}
+
+	collections, err := listAccessibleCollections(ctx, uc.collectionRepo, uc.authService, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	before := req.Now.AddDate(0, 0, req.Days)
+	expiring := []ExpiringObject{}
+	for _, col := range collections {
+		containers, err := uc.containerRepo.GetByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers for collection %s: %w", col.ID().String(), err)
+		}
+		for _, container := range containers {
+			for _, obj := range container.ActiveObjects() {
+				if exp := obj.ExpiresAt(); exp == nil || !exp.Before(before) || !obj.IsOwned() {
+					continue
+				}
+				expiring = append(expiring, ExpiringObject{
+					Object:         obj,
+					ContainerID:    container.ID(),
+					ContainerName:  container.Name().String(),
+					CollectionID:   col.ID(),
+					CollectionName: col.Name().String(),
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(expiring, func(a, b ExpiringObject) int {
+		return a.Object.ExpiresAt().Compare(*b.Object.ExpiresAt())
+	})
+
+	return &GetExpiringObjectsResponse{Objects: expiring}, nil
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetExpiringObjectsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewGetExpiringObjectsUseCase(mockCollectionRepo, mockContainerRepo, mockAuthService)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	userID := entities.NewUserID()
+	collectionID := entities.NewCollectionID()
+	collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColName("Kitchen"))
+
+	fridge := NewTestContainer(CtrName("Fridge"), CtrCollectionID(collectionID), CtrObjects(
+		*NewTestObject(ObjName("Milk"), ObjExpiresAt(now.AddDate(0, 0, 3))),
+		*NewTestObject(ObjName("Old bread"), ObjExpiresAt(now.AddDate(0, 0, -1))),
+		*NewTestObject(ObjName("Jam"), ObjExpiresAt(now.AddDate(0, 2, 0))),
+		*NewTestObject(ObjName("Archived yogurt"), ObjExpiresAt(now.AddDate(0, 0, 1)), ObjArchivedAt(now)),
+		*NewTestObject(ObjName("Salt")),
+	))
+	pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID), CtrObjects(
+		*NewTestObject(ObjName("Eggs"), ObjExpiresAt(now.AddDate(0, 0, 1))),
+	))
+
+	mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{}, nil)
+	mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{collection}, nil)
+	mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), gomock.Len(0)).Return(nil, nil)
+	mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{fridge, pantry}, nil)
+
+	resp, err := useCase.Execute(context.Background(), GetExpiringObjectsRequest{
+		UserID:    userID,
+		UserToken: "token",
+		Now:       now,
+		Days:      7,
+	})
+
+	require.NoError(t, err)
+	names := make([]string, len(resp.Objects))
+	for i, item := range resp.Objects {
+		names[i] = item.Object.Name().String()
+	}
+	assert.Equal(t, []string{"Old bread", "Eggs", "Milk"}, names)
+	assert.Equal(t, "Pantry", resp.Objects[1].ContainerName)
+	assert.Equal(t, "Kitchen", resp.Objects[1].CollectionName)
+	assert.Equal(t, collectionID, resp.Objects[1].CollectionID)
+}
+
+func TestGetExpiringObjectsUseCase_InvalidDays(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	useCase := NewGetExpiringObjectsUseCase(mocks.NewMockCollectionRepository(mockCtrl), mocks.NewMockContainerRepository(mockCtrl), mocks.NewMockAuthService(mockCtrl))
+
+	for _, days := range []int{0, -3, entities.MaxExpiringDays + 1} {
+		_, err := useCase.Execute(context.Background(), GetExpiringObjectsRequest{UserID: entities.NewUserID(), Days: days})
+		assert.ErrorIs(t, err, entities.ErrInvalidExpiringDays)
+	}
+}
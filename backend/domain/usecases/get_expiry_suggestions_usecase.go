@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type GetExpirySuggestionsRequest struct {
+	UserID entities.UserID
+	// Category limits the suggestions to one category; empty lists all.
+	Category string
+}
+
+// ExpirySuggestion is how many days objects of a category usually keep,
+// going by the Samples expiry dates the user set for them.
+type ExpirySuggestion struct {
+	Category string
+	Days     int
+	Samples  int
+}
+
+type GetExpirySuggestionsResponse struct {
+	Suggestions []ExpirySuggestion
+}
+
+type GetExpirySuggestionsUseCase struct {
+	expiryRepo repositories.ExpiryHistoryRepository
+}
+
+func NewGetExpirySuggestionsUseCase(expiryRepo repositories.ExpiryHistoryRepository) *GetExpirySuggestionsUseCase {
+	return &GetExpirySuggestionsUseCase{
+		expiryRepo: expiryRepo,
+	}
+}
+
+func (uc *GetExpirySuggestionsUseCase) Execute(ctx context.Context, req GetExpirySuggestionsRequest) (*GetExpirySuggestionsResponse, error) {
+	histories, err := uc.expiryRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiry history: %w", err)
+	}
+
+	// Categories are recorded lowercased, as ExpiryCategory returns them
+	category := strings.ToLower(strings.TrimSpace(req.Category))
+	resp := &GetExpirySuggestionsResponse{Suggestions: []ExpirySuggestion{}}
+	for _, h := range histories {
+		if category != "" && h.Category() != category {
+			continue
+		}
+		if len(h.Days()) == 0 {
+			continue
+		}
+		resp.Suggestions = append(resp.Suggestions, ExpirySuggestion{
+			Category: h.Category(),
+			Days:     h.SuggestedDays(),
+			Samples:  len(h.Days()),
+		})
+	}
+	return resp, nil
+}
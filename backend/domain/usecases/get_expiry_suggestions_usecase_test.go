@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetExpirySuggestionsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	userID := entities.NewUserID()
+	histories := []*entities.ExpiryHistory{
+		entities.ReconstructExpiryHistory(userID, "dairy", []int{7, 5, 9, 6}, time.Now()),
+		entities.ReconstructExpiryHistory(userID, "frozen", []int{90}, time.Now()),
+	}
+
+	t.Run("lists every category with its median", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		expiryRepo := mocks.NewMockExpiryHistoryRepository(mockCtrl)
+		expiryRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(histories, nil)
+
+		resp, err := NewGetExpirySuggestionsUseCase(expiryRepo).Execute(context.Background(), GetExpirySuggestionsRequest{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []ExpirySuggestion{
+			{Category: "dairy", Days: 6, Samples: 4},
+			{Category: "frozen", Days: 90, Samples: 1},
+		}, resp.Suggestions)
+	})
+
+	t.Run("filters by category ignoring case", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		expiryRepo := mocks.NewMockExpiryHistoryRepository(mockCtrl)
+		expiryRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(histories, nil)
+
+		resp, err := NewGetExpirySuggestionsUseCase(expiryRepo).Execute(context.Background(), GetExpirySuggestionsRequest{UserID: userID, Category: " Frozen "})
+
+		require.NoError(t, err)
+		assert.Equal(t, []ExpirySuggestion{{Category: "frozen", Days: 90, Samples: 1}}, resp.Suggestions)
+	})
+
+	t.Run("empty without history", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		expiryRepo := mocks.NewMockExpiryHistoryRepository(mockCtrl)
+		expiryRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(nil, nil)
+
+		resp, err := NewGetExpirySuggestionsUseCase(expiryRepo).Execute(context.Background(), GetExpirySuggestionsRequest{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Suggestions)
+		assert.NotNil(t, resp.Suggestions)
+	})
+}
+
+func TestRecordExpiry(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		props        map[string]entities.TypedValue
+		tags         []string
+		expiresAt    time.Time // zero means no expiry
+		wantCategory string    // empty means nothing is recorded
+		wantDays     int
+	}{
+		{"category property", Props("category", " Dairy "), []string{"milk"}, from.AddDate(0, 0, 7), "dairy", 7},
+		{"first tag without a category", Props(), []string{" ", "Frozen", "meat"}, from.AddDate(0, 0, 90), "frozen", 90},
+		{"rounds to whole days", Props("category", "bread"), nil, from.Add(4*24*time.Hour + 13*time.Hour), "bread", 5},
+		{"no category", Props(), nil, from.AddDate(0, 0, 7), "", 0},
+		{"no expiry", Props("category", "dairy"), nil, time.Time{}, "", 0},
+		{"already expired", Props("category", "dairy"), nil, from.AddDate(0, 0, -1), "", 0},
+		{"beyond ten years", Props("category", "pantry"), nil, from.AddDate(11, 0, 0), "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			expiryRepo := mocks.NewMockExpiryHistoryRepository(mockCtrl)
+			userID := entities.NewUserID()
+			opts := []func(*objectOpts){ObjProps(tt.props), ObjTags(tt.tags...)}
+			if !tt.expiresAt.IsZero() {
+				opts = append(opts, ObjExpiresAt(tt.expiresAt))
+			}
+			object := NewTestObject(opts...)
+			if tt.wantCategory != "" {
+				expiryRepo.EXPECT().AddSample(gomock.Any(), userID, tt.wantCategory, tt.wantDays).Return(nil)
+			}
+
+			require.NoError(t, recordExpiry(context.Background(), expiryRepo, userID, object, from))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetNutritionStatsRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+	// Now decides which objects have expired and no longer count.
+	Now time.Time
+}
+
+type GetNutritionStatsResponse struct {
+	Stats entities.NutritionStats
+}
+
+type GetNutritionStatsUseCase struct {
+	containerRepo repositories.ContainerRepository
+	authService   services.AuthService
+}
+
+func NewGetNutritionStatsUseCase(containerRepo repositories.ContainerRepository, authService services.AuthService) *GetNutritionStatsUseCase {
+	return &GetNutritionStatsUseCase{
+		containerRepo: containerRepo,
+		authService:   authService,
+	}
+}
+
+// Execute sums the nutrition facts of the food in a collection. Archived
+// objects are left out, and so are expired ones since they are not really
+// available to eat.
+func (uc *GetNutritionStatsUseCase) Execute(ctx context.Context, req GetNutritionStatsRequest) (*GetNutritionStatsResponse, error) {
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	groupIDs := make([]entities.GroupID, len(userGroups))
+	for i, g := range userGroups {
+		groupIDs[i] = g.ID()
+	}
+
+	containers, err := uc.containerRepo.GetByCollectionIDWithAccess(ctx, req.CollectionID, req.UserID, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containers: %w", err)
+	}
+
+	stats := entities.NutritionStats{ByContainer: []entities.ContainerNutrition{}}
+	for _, c := range containers {
+		var containerCalories float64
+		for _, obj := range c.Objects() {
+			if !obj.IsCounted() || obj.ObjectType() != entities.ObjectTypeFood {
+				continue
+			}
+			if exp := obj.ExpiresAt(); exp != nil && exp.Before(req.Now) {
+				stats.ExpiredSkipped++
+				continue
+			}
+			calories, ok := obj.AvailableCalories()
+			if !ok {
+				stats.WithoutNutrition++
+				continue
+			}
+			protein, carbs, fat := obj.AvailableNutrients()
+			stats.WithNutrition++
+			stats.Calories += calories
+			stats.Protein += protein
+			stats.Carbs += carbs
+			stats.Fat += fat
+			containerCalories += calories
+		}
+		if containerCalories > 0 {
+			stats.ByContainer = append(stats.ByContainer, entities.ContainerNutrition{
+				ContainerID:   c.ID(),
+				ContainerName: c.Name().String(),
+				Calories:      containerCalories,
+			})
+		}
+	}
+
+	slices.SortStableFunc(stats.ByContainer, func(a, b entities.ContainerNutrition) int {
+		return cmp.Compare(b.Calories, a.Calories)
+	})
+
+	return &GetNutritionStatsResponse{Stats: stats}, nil
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetNutritionStatsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewGetNutritionStatsUseCase(mockContainerRepo, mockAuthService)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	userID := entities.NewUserID()
+	collectionID := entities.NewCollectionID()
+
+	food := func(name string, props map[string]entities.TypedValue, opts ...func(*objectOpts)) entities.Object {
+		return *NewTestObject(append([]func(*objectOpts){ObjName(name), ObjType(entities.ObjectTypeFood), ObjProps(props)}, opts...)...)
+	}
+	numeric := func(v float64) entities.TypedValue { return entities.NewTypedValue(entities.PropertyTypeNumeric, v) }
+
+	pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID), CtrObjects(
+		// 2 boxes × 10 servings × 100 kcal
+		food("Cereal", map[string]entities.TypedValue{"calories": numeric(100), "servings": numeric(10), "protein_g": numeric(2)}, ObjQuantity(2)),
+		food("Salt", map[string]entities.TypedValue{}),
+		food("Old bread", map[string]entities.TypedValue{"calories": numeric(80)}, ObjExpiresAt(now.AddDate(0, 0, -1))),
+		food("Archived soup", map[string]entities.TypedValue{"calories": numeric(300)}, ObjArchivedAt(now)),
+		*NewTestObject(ObjName("Candle"), ObjProps(map[string]entities.TypedValue{"calories": numeric(999)})),
+	))
+	fridge := NewTestContainer(CtrName("Fridge"), CtrCollectionID(collectionID), CtrObjects(
+		food("Milk", map[string]entities.TypedValue{"calories": numeric(120), "fat_g": numeric(5)}, ObjExpiresAt(now.AddDate(0, 0, 3))),
+	))
+	freezer := NewTestContainer(CtrName("Freezer"), CtrCollectionID(collectionID))
+
+	mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{}, nil)
+	mockContainerRepo.EXPECT().GetByCollectionIDWithAccess(gomock.Any(), collectionID, userID, []entities.GroupID{}).
+		Return([]*entities.Container{fridge, pantry, freezer}, nil)
+
+	resp, err := useCase.Execute(context.Background(), GetNutritionStatsRequest{
+		CollectionID: collectionID,
+		UserID:       userID,
+		UserToken:    "token",
+		Now:          now,
+	})
+
+	require.NoError(t, err)
+	stats := resp.Stats
+	assert.InDelta(t, 2120, stats.Calories, 0.001)
+	assert.InDelta(t, 40, stats.Protein, 0.001)
+	assert.InDelta(t, 5, stats.Fat, 0.001)
+	assert.Equal(t, 2, stats.WithNutrition)
+	assert.Equal(t, 1, stats.WithoutNutrition)
+	assert.Equal(t, 1, stats.ExpiredSkipped)
+	require.Len(t, stats.ByContainer, 2)
+	assert.Equal(t, "Pantry", stats.ByContainer[0].ContainerName)
+	assert.InDelta(t, 2000, stats.ByContainer[0].Calories, 0.001)
+	assert.Equal(t, "Fridge", stats.ByContainer[1].ContainerName)
+}
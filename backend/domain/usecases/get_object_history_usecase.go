@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetObjectHistoryRequest struct {
+	ObjectID  entities.ObjectID
+	UserID    entities.UserID
+	UserToken string
+}
+
+type GetObjectHistoryResponse struct {
+	Object *entities.Object
+	// Timeline lists every container the object has been in, oldest first.
+	Timeline []entities.ObjectTimelineEntry
+}
+
+type GetObjectHistoryUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	moveRepo       repositories.ObjectMoveRepository
+	authService    services.AuthService
+}
+
+func NewGetObjectHistoryUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, moveRepo repositories.ObjectMoveRepository, authService services.AuthService) *GetObjectHistoryUseCase {
+	return &GetObjectHistoryUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		moveRepo:       moveRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *GetObjectHistoryUseCase) Execute(ctx context.Context, req GetObjectHistoryRequest) (*GetObjectHistoryResponse, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found: %w", err)
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, container.CollectionID())
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	object, err := container.GetObject(req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("object not found in container: %w", err)
+	}
+
+	moves, err := uc.moveRepo.ListByObjectID(ctx, req.ObjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object history: %w", err)
+	}
+
+	return &GetObjectHistoryResponse{
+		Object:   object,
+		Timeline: entities.BuildObjectTimeline(object.CreatedAt(), entities.PlacementOf(container), moves),
+	}, nil
+}
@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetPurchaseHistoryRequest struct {
+	UserID    entities.UserID
+	UserToken string
+	// Days is how far back the history goes; zero means
+	// entities.DefaultPurchaseHistoryDays
+	Days int
+	Now  time.Time
+}
+
+type GetPurchaseHistoryResponse struct {
+	History entities.PurchaseHistory
+}
+
+type GetPurchaseHistoryUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	adjustmentRepo repositories.QuantityAdjustmentRepository
+	authService    services.AuthService
+}
+
+func NewGetPurchaseHistoryUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, adjustmentRepo repositories.QuantityAdjustmentRepository, authService services.AuthService) *GetPurchaseHistoryUseCase {
+	return &GetPurchaseHistoryUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		adjustmentRepo: adjustmentRepo,
+		authService:    authService,
+	}
+}
+
+// Execute sums the owned objects added to the food collections the user can
+// see over the last Days days, archived ones included since eating
+// something does not undo buying it, and the quantity adjustments made to
+// objects of those collections.
+func (uc *GetPurchaseHistoryUseCase) Execute(ctx context.Context, req GetPurchaseHistoryRequest) (*GetPurchaseHistoryResponse, error) {
+	days := cmp.Or(req.Days, entities.DefaultPurchaseHistoryDays)
+	if days < 1 || days > entities.MaxPurchaseHistoryDays {
+		return nil, entities.ErrInvalidPurchaseHistoryDays
+	}
+	history := entities.PurchaseHistory{From: req.Now.AddDate(0, 0, -days), To: req.Now}
+
+	collections, err := listAccessibleCollections(ctx, uc.collectionRepo, uc.authService, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals, byCategory valuationTally
+	var collectionIDs []entities.CollectionID
+	for _, col := range collections {
+		if col.ObjectType() != entities.ObjectTypeFood {
+			continue
+		}
+		collectionIDs = append(collectionIDs, col.ID())
+
+		// The list holds summaries, so the objects come from the containers
+		containers, err := uc.containerRepo.GetByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers for collection %s: %w", col.ID().String(), err)
+		}
+		var objects []entities.Object
+		for _, container := range containers {
+			for _, obj := range container.Objects() {
+				if obj.IsOwned() && !obj.CreatedAt().Before(history.From) && obj.CreatedAt().Before(history.To) {
+					objects = append(objects, obj)
+				}
+			}
+		}
+
+		valueKey, currencyCode := valueProperty(col.PropertySchema(), objects)
+		for _, obj := range objects {
+			purchase := entities.Purchase{
+				ObjectID:       obj.ID(),
+				Name:           obj.Name().String(),
+				CollectionID:   col.ID(),
+				CollectionName: col.Name().String(),
+				Category:       entities.ExpiryCategory(obj.Properties(), obj.Tags()),
+				Quantity:       obj.Quantity(),
+				Unit:           obj.Unit(),
+				AddedAt:        obj.CreatedAt(),
+			}
+			tv, ok := obj.Properties()[valueKey]
+			if value, isNumber := tv.Val.(float64); valueKey != "" && ok && isNumber {
+				purchase.Price = &value
+				purchase.Currency = cmp.Or(tv.Currency, currencyCode)
+				history.Priced++
+				totals.add("", "", purchase.Currency, value)
+				byCategory.add(purchase.Category, purchase.Category, purchase.Currency, value)
+			} else {
+				history.Unpriced++
+			}
+			history.Purchases = append(history.Purchases, purchase)
+		}
+	}
+
+	slices.SortFunc(history.Purchases, func(a, b entities.Purchase) int {
+		return b.AddedAt.Compare(a.AddedAt)
+	})
+	if len(history.Purchases) > entities.MaxPurchaseHistoryItems {
+		history.Purchases = history.Purchases[:entities.MaxPurchaseHistoryItems]
+	}
+	for _, g := range totals.sorted() {
+		history.Totals = append(history.Totals, entities.ValuationTotal{Currency: g.Currency, Objects: g.Objects, Total: g.Total})
+	}
+	history.ByCategory = byCategory.sorted()
+
+	adjustments, err := uc.adjustmentRepo.ListByCollectionIDs(ctx, collectionIDs, history.From, history.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quantity adjustments: %w", err)
+	}
+	history.Adjustments = summarizeAdjustments(adjustments)
+
+	return &GetPurchaseHistoryResponse{History: history}, nil
+}
+
+// summarizeAdjustments totals adjustments per category and unit, most
+// changes first, ties by category and unit
+func summarizeAdjustments(adjustments []*entities.QuantityAdjustment) []entities.AdjustmentSummary {
+	var summaries []entities.AdjustmentSummary
+	for _, a := range adjustments {
+		i := slices.IndexFunc(summaries, func(s entities.AdjustmentSummary) bool {
+			return s.Category == a.Category() && s.Unit == a.Unit()
+		})
+		if i < 0 {
+			summaries = append(summaries, entities.AdjustmentSummary{Category: a.Category(), Unit: a.Unit()})
+			i = len(summaries) - 1
+		}
+		summaries[i].Adjustments++
+		if delta := a.Delta(); delta > 0 {
+			summaries[i].Restocked += delta
+		} else {
+			summaries[i].Used -= delta
+		}
+	}
+	slices.SortFunc(summaries, func(a, b entities.AdjustmentSummary) int {
+		return cmp.Or(
+			cmp.Compare(b.Adjustments, a.Adjustments),
+			cmp.Compare(a.Category, b.Category),
+			cmp.Compare(a.Unit, b.Unit),
+		)
+	})
+	return summaries
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetPurchaseHistoryUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAdjustmentRepo := mocks.NewMockQuantityAdjustmentRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewGetPurchaseHistoryUseCase(mockCollectionRepo, mockContainerRepo, mockAdjustmentRepo, mockAuthService)
+
+	userID := entities.NewUserID()
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	from := now.AddDate(0, 0, -30)
+
+	pantryID := entities.NewCollectionID()
+	pantry := NewTestCollection(ColID(pantryID), ColUserID(userID), ColName("Pantry"), ColObjectType(entities.ObjectTypeFood), ColSchema(&entities.PropertySchema{
+		Definitions: []entities.PropertyDefinition{{Key: "price", DisplayName: "Price", Type: entities.PropertyTypeCurrency, CurrencyCode: "USD"}},
+	}))
+	// Not food, so neither its containers nor its adjustments are read
+	books := NewTestCollection(ColUserID(userID), ColName("Books"), ColObjectType(entities.ObjectTypeBook))
+
+	milkID := entities.NewObjectID()
+	fridge := NewTestContainer(CtrCollectionID(pantryID), CtrObjects(
+		*NewTestObject(ObjID(milkID), ObjName("Milk"), ObjTags("Dairy"), ObjQuantity(2), ObjUnit("L"), ObjCreatedAt(now.AddDate(0, 0, -2)), ObjProps(Props("price", price(3.5, "")))),
+		*NewTestObject(ObjName("Cheese"), ObjTags("dairy"), ObjCreatedAt(now.AddDate(0, 0, -10)), ObjArchivedAt(now.AddDate(0, 0, -1)), ObjProps(Props("price", price(8, "")))),
+		*NewTestObject(ObjName("Bread"), ObjCreatedAt(now.AddDate(0, 0, -5))),
+		*NewTestObject(ObjName("Old rice"), ObjCreatedAt(now.AddDate(0, 0, -40)), ObjProps(Props("price", price(12, "")))),
+		*NewTestObject(ObjName("Saffron"), ObjStatus(entities.ObjectStatusWanted), ObjCreatedAt(now.AddDate(0, 0, -1)), ObjProps(Props("price", price(20, "")))),
+	))
+
+	adjustments := []*entities.QuantityAdjustment{
+		entities.ReconstructQuantityAdjustment(milkID, pantryID, "Milk", "dairy", "L", 2, 1.5, userID, now.AddDate(0, 0, -1)),
+		entities.ReconstructQuantityAdjustment(milkID, pantryID, "Milk", "dairy", "L", 1.5, 3, userID, now.AddDate(0, 0, -1)),
+		entities.ReconstructQuantityAdjustment(entities.NewObjectID(), pantryID, "Eggs", "", "", 12, 6, userID, now.AddDate(0, 0, -3)),
+	}
+
+	mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{pantry, books}, nil)
+	mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return(nil, nil)
+	mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), []entities.GroupID{}).Return(nil, nil)
+	mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), pantryID).Return([]*entities.Container{fridge}, nil)
+	mockAdjustmentRepo.EXPECT().ListByCollectionIDs(gomock.Any(), []entities.CollectionID{pantryID}, from, now).Return(adjustments, nil)
+
+	resp, err := useCase.Execute(context.Background(), GetPurchaseHistoryRequest{UserID: userID, UserToken: "token", Now: now})
+
+	require.NoError(t, err)
+	history := resp.History
+	assert.Equal(t, from, history.From)
+	assert.Equal(t, now, history.To)
+	assert.Equal(t, 2, history.Priced)
+	assert.Equal(t, 1, history.Unpriced)
+	assert.Equal(t, []entities.ValuationTotal{{Currency: "USD", Objects: 2, Total: 11.5}}, history.Totals)
+	assert.Equal(t, []entities.ValuationGroup{{Key: "dairy", Name: "dairy", Currency: "USD", Objects: 2, Total: 11.5}}, history.ByCategory)
+
+	// Newest first; the archived cheese still counts as bought
+	require.Len(t, history.Purchases, 3)
+	assert.Equal(t, []string{"Milk", "Bread", "Cheese"}, []string{history.Purchases[0].Name, history.Purchases[1].Name, history.Purchases[2].Name})
+	milk := history.Purchases[0]
+	assert.Equal(t, milkID, milk.ObjectID)
+	assert.Equal(t, "Pantry", milk.CollectionName)
+	assert.Equal(t, "dairy", milk.Category)
+	assert.Equal(t, "L", milk.Unit)
+	require.NotNil(t, milk.Price)
+	assert.InDelta(t, 3.5, *milk.Price, 0.001)
+	assert.Equal(t, "USD", milk.Currency)
+	assert.Nil(t, history.Purchases[1].Price)
+
+	assert.Equal(t, []entities.AdjustmentSummary{
+		{Category: "dairy", Unit: "L", Adjustments: 2, Restocked: 1.5, Used: 0.5},
+		{Category: "", Unit: "", Adjustments: 1, Used: 6},
+	}, history.Adjustments)
+}
+
+func TestGetPurchaseHistoryUseCase_Execute_InvalidDays(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	useCase := NewGetPurchaseHistoryUseCase(
+		mocks.NewMockCollectionRepository(mockCtrl),
+		mocks.NewMockContainerRepository(mockCtrl),
+		mocks.NewMockQuantityAdjustmentRepository(mockCtrl),
+		mocks.NewMockAuthService(mockCtrl),
+	)
+
+	for _, days := range []int{-1, entities.MaxPurchaseHistoryDays + 1} {
+		_, err := useCase.Execute(context.Background(), GetPurchaseHistoryRequest{UserID: entities.NewUserID(), Days: days, Now: time.Now()})
+		assert.ErrorIs(t, err, entities.ErrInvalidPurchaseHistoryDays)
+	}
+}
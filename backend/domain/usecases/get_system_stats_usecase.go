@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type GetSystemStatsResponse struct {
+	Stats entities.SystemStats
+}
+
+// GetSystemStatsUseCase totals users and storage across the deployment, for
+// admins. Callers check the admin role.
+type GetSystemStatsUseCase struct {
+	accountStatusRepo repositories.AccountStatusRepository
+	usageRepo         repositories.UsageRepository
+}
+
+func NewGetSystemStatsUseCase(accountStatusRepo repositories.AccountStatusRepository, usageRepo repositories.UsageRepository) *GetSystemStatsUseCase {
+	return &GetSystemStatsUseCase{
+		accountStatusRepo: accountStatusRepo,
+		usageRepo:         usageRepo,
+	}
+}
+
+func (uc *GetSystemStatsUseCase) Execute(ctx context.Context) (*GetSystemStatsResponse, error) {
+	accounts, err := listAccountOverviews(ctx, uc.accountStatusRepo, uc.usageRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats entities.SystemStats
+	for _, account := range accounts {
+		stats.Users++
+		if account.Status.IsDisabled() {
+			stats.DisabledUsers++
+		}
+		stats.Collections += account.Usage.Collections
+		stats.Containers += account.Usage.Containers
+		stats.Objects += account.Usage.Objects
+		stats.MediaCount += account.Usage.MediaCount
+		stats.MediaBytes += account.Usage.MediaBytes
+	}
+
+	return &GetSystemStatsResponse{Stats: stats}, nil
+}
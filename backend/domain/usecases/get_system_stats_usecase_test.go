@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGetSystemStatsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	accountStatusRepo := mocks.NewMockAccountStatusRepository(mockCtrl)
+	usageRepo := mocks.NewMockUsageRepository(mockCtrl)
+	useCase := NewGetSystemStatsUseCase(accountStatusRepo, usageRepo)
+
+	now := time.Now()
+	active := entities.ReconstructAccountStatus(entities.NewUserID(), "alice", "", false, nil, now, now)
+	disabled := entities.ReconstructAccountStatus(entities.NewUserID(), "bob", "", true, &now, now, now)
+	untracked := entities.NewUserID()
+
+	accountStatusRepo.EXPECT().List(gomock.Any()).Return([]*entities.AccountStatus{active, disabled}, nil)
+	usageRepo.EXPECT().UsageByUser(gomock.Any()).Return(map[string]entities.UserUsage{
+		active.UserID().String(): {UserID: active.UserID(), Collections: 2, Containers: 5, Objects: 40, MediaCount: 3, MediaBytes: 3000},
+		untracked.String():       {UserID: untracked, Collections: 1, Containers: 1, Objects: 2},
+	}, nil)
+
+	resp, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.SystemStats{
+		Users:         3,
+		DisabledUsers: 1,
+		Collections:   3,
+		Containers:    6,
+		Objects:       42,
+		MediaCount:    3,
+		MediaBytes:    3000,
+	}, resp.Stats)
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetUnreadCommentsRequest struct {
+	UserID    entities.UserID
+	UserToken string
+}
+
+type GetUnreadCommentsResponse struct {
+	// Unread lists only the collections with unread comments.
+	Unread []entities.CommentUnread
+}
+
+type GetUnreadCommentsUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	commentRepo    repositories.CommentRepository
+	authService    services.AuthService
+}
+
+func NewGetUnreadCommentsUseCase(collectionRepo repositories.CollectionRepository, commentRepo repositories.CommentRepository, authService services.AuthService) *GetUnreadCommentsUseCase {
+	return &GetUnreadCommentsUseCase{
+		collectionRepo: collectionRepo,
+		commentRepo:    commentRepo,
+		authService:    authService,
+	}
+}
+
+// Execute counts the unread comments across the collections the user sees
+// in their collection list.
+func (uc *GetUnreadCommentsUseCase) Execute(ctx context.Context, req GetUnreadCommentsRequest) (*GetUnreadCommentsResponse, error) {
+	collections, err := listAccessibleCollections(ctx, uc.collectionRepo, uc.authService, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionIDs := make([]entities.CollectionID, len(collections))
+	for i, collection := range collections {
+		collectionIDs[i] = collection.ID()
+	}
+
+	unread, err := uc.commentRepo.CountUnread(ctx, req.UserID, collectionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread comments: %w", err)
+	}
+
+	return &GetUnreadCommentsResponse{Unread: unread}, nil
+}
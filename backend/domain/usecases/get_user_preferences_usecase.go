@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type GetUserPreferencesRequest struct {
+	UserID entities.UserID
+}
+
+type GetUserPreferencesResponse struct {
+	Preferences *entities.UserPreferences
+}
+
+type GetUserPreferencesUseCase struct {
+	preferencesRepo repositories.UserPreferencesRepository
+}
+
+func NewGetUserPreferencesUseCase(preferencesRepo repositories.UserPreferencesRepository) *GetUserPreferencesUseCase {
+	return &GetUserPreferencesUseCase{
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+func (uc *GetUserPreferencesUseCase) Execute(ctx context.Context, req GetUserPreferencesRequest) (*GetUserPreferencesResponse, error) {
+	preferences, err := uc.preferencesRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserPreferencesNotFound) {
+			return &GetUserPreferencesResponse{
+				Preferences: entities.NewUserPreferences(req.UserID),
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+
+	return &GetUserPreferencesResponse{
+		Preferences: preferences,
+	}, nil
+}
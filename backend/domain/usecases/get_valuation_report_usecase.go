@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type GetValuationReportRequest struct {
+	UserID    entities.UserID
+	UserToken string
+}
+
+type GetValuationReportResponse struct {
+	Report entities.ValuationReport
+}
+
+type GetValuationReportUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	authService    services.AuthService
+}
+
+func NewGetValuationReportUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, authService services.AuthService) *GetValuationReportUseCase {
+	return &GetValuationReportUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		authService:    authService,
+	}
+}
+
+// Execute values the owned, active objects in every collection the user can
+// see. Archived and wishlist objects are left out.
+func (uc *GetValuationReportUseCase) Execute(ctx context.Context, req GetValuationReportRequest) (*GetValuationReportResponse, error) {
+	collections, err := listAccessibleCollections(ctx, uc.collectionRepo, uc.authService, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals, byCollection, byType, byTag, byCondition valuationTally
+	var report entities.ValuationReport
+	for _, col := range collections {
+		// The list holds summaries, so the objects come from the containers
+		containers, err := uc.containerRepo.GetByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers for collection %s: %w", col.ID().String(), err)
+		}
+		var objects []entities.Object
+		for _, container := range containers {
+			for _, obj := range container.ActiveObjects() {
+				if obj.IsCounted() {
+					objects = append(objects, obj)
+				}
+			}
+		}
+
+		valueKey, currencyCode := valueProperty(col.PropertySchema(), objects)
+		for _, obj := range objects {
+			tv, ok := obj.Properties()[valueKey]
+			value, isNumber := tv.Val.(float64)
+			if valueKey == "" || !ok || !isNumber {
+				report.Unvalued++
+				continue
+			}
+			currency := cmp.Or(tv.Currency, currencyCode)
+
+			report.Valued++
+			totals.add("", "", currency, value)
+			byCollection.add(col.ID().String(), col.Name().String(), currency, value)
+			byType.add(obj.ObjectType().String(), obj.ObjectType().String(), currency, value)
+			tags := obj.Tags()
+			if len(tags) == 0 {
+				tags = []string{""}
+			}
+			for _, tag := range tags {
+				byTag.add(tag, tag, currency, value)
+			}
+			if obj.ObjectType().Graded() {
+				byCondition.add(obj.Condition().String(), obj.Condition().String(), currency, value)
+			}
+		}
+	}
+
+	for _, g := range totals.sorted() {
+		report.Totals = append(report.Totals, entities.ValuationTotal{Currency: g.Currency, Objects: g.Objects, Total: g.Total})
+	}
+	report.ByCollection = byCollection.sorted()
+	report.ByObjectType = byType.sorted()
+	report.ByTag = byTag.sorted()
+	report.ByCondition = byCondition.sorted()
+
+	return &GetValuationReportResponse{Report: report}, nil
+}
+
+// valuationTally sums one breakdown of the valuation report
+type valuationTally struct {
+	groups []entities.ValuationGroup
+}
+
+func (t *valuationTally) add(key, name, currency string, value float64) {
+	i := slices.IndexFunc(t.groups, func(g entities.ValuationGroup) bool {
+		return g.Key == key && g.Currency == currency
+	})
+	if i < 0 {
+		t.groups = append(t.groups, entities.ValuationGroup{Key: key, Name: name, Currency: currency})
+		i = len(t.groups) - 1
+	}
+	t.groups[i].Objects++
+	t.groups[i].Total += value
+}
+
+// sorted returns the groups highest total first, ties by name and currency
+func (t *valuationTally) sorted() []entities.ValuationGroup {
+	groups := slices.Clone(t.groups)
+	slices.SortFunc(groups, func(a, b entities.ValuationGroup) int {
+		return cmp.Or(
+			cmp.Compare(b.Total, a.Total),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Currency, b.Currency),
+		)
+	})
+	return groups
+}
+
+// EncodeValuationReportCSV writes the report as one row per total and group,
+// with the breakdown the row belongs to in the first column
+func EncodeValuationReportCSV(report entities.ValuationReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"breakdown", "key", "name", "currency", "objects", "total"}}
+	for _, t := range report.Totals {
+		rows = append(rows, []string{"total", "", "", t.Currency, strconv.Itoa(t.Objects), formatAmount(t.Total)})
+	}
+	for _, breakdown := range []struct {
+		name   string
+		groups []entities.ValuationGroup
+	}{
+		{"collection", report.ByCollection},
+		{"object_type", report.ByObjectType},
+		{"tag", report.ByTag},
+		{"condition", report.ByCondition},
+	} {
+		for _, g := range breakdown.groups {
+			rows = append(rows, []string{breakdown.name, g.Key, g.Name, g.Currency, strconv.Itoa(g.Objects), formatAmount(g.Total)})
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
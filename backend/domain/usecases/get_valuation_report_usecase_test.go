@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func price(v float64, currency string) entities.TypedValue {
+	return entities.TypedValue{Type: entities.PropertyTypeCurrency, Val: v, Currency: currency}
+}
+
+func TestGetValuationReportUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewGetValuationReportUseCase(mockCollectionRepo, mockContainerRepo, mockAuthService)
+
+	userID := entities.NewUserID()
+	groupID := entities.NewGroupID()
+
+	booksID := entities.NewCollectionID()
+	books := NewTestCollection(ColID(booksID), ColUserID(userID), ColName("Books"), ColObjectType(entities.ObjectTypeBook), ColSchema(&entities.PropertySchema{
+		Definitions: []entities.PropertyDefinition{{Key: "price", DisplayName: "Price", Type: entities.PropertyTypeCurrency, CurrencyCode: "USD"}},
+	}))
+	gearID := entities.NewCollectionID()
+	gear := NewTestCollection(ColID(gearID), ColGroupID(&groupID), ColGroupOwned(), ColName("Gear"))
+
+	shelf := NewTestContainer(CtrCollectionID(booksID), CtrObjects(
+		*NewTestObject(ObjType(entities.ObjectTypeBook), ObjCondition(entities.ConditionMint), ObjTags("signed", "scifi"), ObjProps(Props("price", price(40, "")))),
+		*NewTestObject(ObjType(entities.ObjectTypeBook), ObjTags("scifi"), ObjProps(Props("price", price(10, "EUR")))),
+		*NewTestObject(ObjType(entities.ObjectTypeBook), ObjProps(Props("title", "No price"))),
+		*NewTestObject(ObjType(entities.ObjectTypeBook), ObjStatus(entities.ObjectStatusWanted), ObjProps(Props("price", price(99, "USD")))),
+	))
+	// No schema: the currency property found on the objects counts
+	garage := NewTestContainer(CtrCollectionID(gearID), CtrObjects(
+		*NewTestObject(ObjProps(Props("cost", price(150, "USD")))),
+	))
+
+	mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{NewTestGroup(GrpID(groupID))}, nil)
+	mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{books}, nil)
+	mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), []entities.GroupID{groupID}).Return([]*entities.Collection{gear}, nil)
+	mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), booksID).Return([]*entities.Container{shelf}, nil)
+	mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), gearID).Return([]*entities.Container{garage}, nil)
+
+	resp, err := useCase.Execute(context.Background(), GetValuationReportRequest{UserID: userID, UserToken: "token"})
+
+	require.NoError(t, err)
+	report := resp.Report
+	assert.Equal(t, 3, report.Valued)
+	assert.Equal(t, 1, report.Unvalued)
+	assert.Equal(t, []entities.ValuationTotal{
+		{Currency: "USD", Objects: 2, Total: 190},
+		{Currency: "EUR", Objects: 1, Total: 10},
+	}, report.Totals)
+	assert.Equal(t, []entities.ValuationGroup{
+		{Key: gearID.String(), Name: "Gear", Currency: "USD", Objects: 1, Total: 150},
+		{Key: booksID.String(), Name: "Books", Currency: "USD", Objects: 1, Total: 40},
+		{Key: booksID.String(), Name: "Books", Currency: "EUR", Objects: 1, Total: 10},
+	}, report.ByCollection)
+	assert.Equal(t, []entities.ValuationGroup{
+		{Key: "", Name: "", Currency: "USD", Objects: 1, Total: 150},
+		{Key: "scifi", Name: "scifi", Currency: "USD", Objects: 1, Total: 40},
+		{Key: "signed", Name: "signed", Currency: "USD", Objects: 1, Total: 40},
+		{Key: "scifi", Name: "scifi", Currency: "EUR", Objects: 1, Total: 10},
+	}, report.ByTag)
+	// General objects cannot be graded
+	assert.Equal(t, []entities.ValuationGroup{
+		{Key: "mint", Name: "mint", Currency: "USD", Objects: 1, Total: 40},
+		{Key: "", Name: "", Currency: "EUR", Objects: 1, Total: 10},
+	}, report.ByCondition)
+	require.Len(t, report.ByObjectType, 3)
+	assert.Equal(t, "general", report.ByObjectType[0].Key)
+
+	csv, err := EncodeValuationReportCSV(report)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	assert.Equal(t, "breakdown,key,name,currency,objects,total", lines[0])
+	assert.Equal(t, "total,,,USD,2,190.00", lines[1])
+	assert.Contains(t, lines, "tag,signed,signed,USD,1,40.00")
+}
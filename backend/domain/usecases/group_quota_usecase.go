@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// GroupQuotaUseCase reads and sets the limits on what a group's shared
+// collections may hold.
+type GroupQuotaUseCase struct {
+	quotaRepo   repositories.GroupQuotaRepository
+	usageRepo   repositories.UsageRepository
+	authService services.AuthService
+}
+
+func NewGroupQuotaUseCase(quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, authService services.AuthService) *GroupQuotaUseCase {
+	return &GroupQuotaUseCase{
+		quotaRepo:   quotaRepo,
+		usageRepo:   usageRepo,
+		authService: authService,
+	}
+}
+
+type GetGroupQuotaRequest struct {
+	GroupID   entities.GroupID
+	UserID    entities.UserID
+	UserToken string
+}
+
+// GroupQuotaResponse is a group's quota next to what it uses. Quota is nil
+// for a group without one. CanEdit tells whether the caller may change it.
+type GroupQuotaResponse struct {
+	GroupID entities.GroupID
+	Quota   *entities.GroupQuota
+	Usage   entities.GroupUsage
+	CanEdit bool
+}
+
+// GetQuota returns the group's quota and usage to any of its members.
+func (uc *GroupQuotaUseCase) GetQuota(ctx context.Context, req GetGroupQuotaRequest) (*GroupQuotaResponse, error) {
+	groups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	if !slices.ContainsFunc(groups, func(g *entities.Group) bool { return g.ID().Equals(req.GroupID) }) {
+		return nil, entities.ErrNotGroupQuotaMember
+	}
+
+	isAdmin, err := uc.isAdmin(ctx, req.GroupID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	return uc.status(ctx, req.GroupID, isAdmin)
+}
+
+type SetGroupQuotaRequest struct {
+	GroupID         entities.GroupID
+	MaxContainers   int
+	MaxObjects      int
+	MaxStorageBytes int64
+	UserID          entities.UserID
+	UserToken       string
+}
+
+// SetQuota replaces the group's limits. Only its admins may. Lowering a
+// limit below what the group already uses removes nothing; it only stops
+// more from being added.
+func (uc *GroupQuotaUseCase) SetQuota(ctx context.Context, req SetGroupQuotaRequest) (*GroupQuotaResponse, error) {
+	isAdmin, err := uc.isAdmin(ctx, req.GroupID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, entities.ErrNotGroupQuotaAdmin
+	}
+
+	quota, err := entities.NewGroupQuota(entities.GroupQuotaProps{
+		GroupID:         req.GroupID,
+		MaxContainers:   req.MaxContainers,
+		MaxObjects:      req.MaxObjects,
+		MaxStorageBytes: req.MaxStorageBytes,
+		UpdatedBy:       req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.quotaRepo.Save(ctx, quota); err != nil {
+		return nil, fmt.Errorf("failed to save group quota: %w", err)
+	}
+
+	return uc.status(ctx, req.GroupID, true)
+}
+
+func (uc *GroupQuotaUseCase) isAdmin(ctx context.Context, groupID entities.GroupID, userID entities.UserID, userToken string) (bool, error) {
+	roles, err := uc.authService.GetGroupMemberRoles(ctx, userToken, groupID.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to get member roles: %w", err)
+	}
+	return roles[userID.String()] == entities.GroupRoleAdmin, nil
+}
+
+func (uc *GroupQuotaUseCase) status(ctx context.Context, groupID entities.GroupID, canEdit bool) (*GroupQuotaResponse, error) {
+	quota, err := uc.quotaRepo.GetByGroupID(ctx, groupID)
+	if err != nil && !errors.Is(err, entities.ErrGroupQuotaNotFound) {
+		return nil, fmt.Errorf("failed to get group quota: %w", err)
+	}
+	usage, err := uc.usageRepo.UsageByGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group usage: %w", err)
+	}
+	return &GroupQuotaResponse{GroupID: groupID, Quota: quota, Usage: usage, CanEdit: canEdit}, nil
+}
+
+// checkGroupQuota refuses adding more of resource to a collection whose
+// group would go past its quota, with a *entities.QuotaExceededError.
+// Personal collections and groups without a quota are not limited.
+func checkGroupQuota(ctx context.Context, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, collection *entities.Collection, resource entities.QuotaResource, adding int64) error {
+	if collection.GroupID() == nil {
+		return nil
+	}
+	quota, err := quotaRepo.GetByGroupID(ctx, *collection.GroupID())
+	if errors.Is(err, entities.ErrGroupQuotaNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get group quota: %w", err)
+	}
+	if quota.Limit(resource) == 0 {
+		return nil
+	}
+
+	usage, err := usageRepo.UsageByGroup(ctx, quota.GroupID())
+	if err != nil {
+		return fmt.Errorf("failed to get group usage: %w", err)
+	}
+	return quota.Allow(usage, resource, adding)
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestGroupQuotaUseCase(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		quotaRepo   *mocks.MockGroupQuotaRepository
+		usageRepo   *mocks.MockUsageRepository
+		authService *mocks.MockAuthService
+		useCase     *GroupQuotaUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			quotaRepo:   mocks.NewMockGroupQuotaRepository(mockCtrl),
+			usageRepo:   mocks.NewMockUsageRepository(mockCtrl),
+			authService: mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewGroupQuotaUseCase(f.quotaRepo, f.usageRepo, f.authService)
+		return f
+	}
+	groupID, _ := entities.GroupIDFromString("group-1")
+
+	t.Run("success - member sees quota and usage", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		quota, _ := entities.NewGroupQuota(entities.GroupQuotaProps{GroupID: groupID, MaxContainers: 10})
+		usage := entities.GroupUsage{Containers: 4, Objects: 30, StorageBytes: 2048}
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{NewTestGroup(GrpID(groupID))}, nil)
+		f.authService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", groupID.String()).Return(map[string]entities.GroupRole{}, nil)
+		f.quotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(quota, nil)
+		f.usageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(usage, nil)
+
+		resp, err := f.useCase.GetQuota(context.Background(), GetGroupQuotaRequest{GroupID: groupID, UserID: userID, UserToken: "token"})
+
+		require.NoError(t, err)
+		assert.Equal(t, quota, resp.Quota)
+		assert.Equal(t, usage, resp.Usage)
+		assert.False(t, resp.CanEdit)
+	})
+
+	t.Run("success - group without a quota is unlimited", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{NewTestGroup(GrpID(groupID))}, nil)
+		f.authService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", groupID.String()).Return(map[string]entities.GroupRole{userID.String(): entities.GroupRoleAdmin}, nil)
+		f.quotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(nil, entities.ErrGroupQuotaNotFound)
+		f.usageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(entities.GroupUsage{}, nil)
+
+		resp, err := f.useCase.GetQuota(context.Background(), GetGroupQuotaRequest{GroupID: groupID, UserID: userID, UserToken: "token"})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Quota)
+		assert.True(t, resp.CanEdit)
+	})
+
+	t.Run("error - outsiders cannot see the quota", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{}, nil)
+
+		_, err := f.useCase.GetQuota(context.Background(), GetGroupQuotaRequest{GroupID: groupID, UserID: userID, UserToken: "token"})
+
+		require.ErrorIs(t, err, entities.ErrNotGroupQuotaMember)
+	})
+
+	t.Run("success - admin sets the quota", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.authService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", groupID.String()).Return(map[string]entities.GroupRole{userID.String(): entities.GroupRoleAdmin}, nil)
+		var saved *entities.GroupQuota
+		f.quotaRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, q *entities.GroupQuota) error {
+			saved = q
+			return nil
+		})
+		f.quotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).DoAndReturn(func(context.Context, entities.GroupID) (*entities.GroupQuota, error) {
+			return saved, nil
+		})
+		f.usageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(entities.GroupUsage{}, nil)
+
+		resp, err := f.useCase.SetQuota(context.Background(), SetGroupQuotaRequest{
+			GroupID: groupID, MaxContainers: 5, MaxObjects: 200, MaxStorageBytes: 1 << 30, UserID: userID, UserToken: "token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, resp.Quota.MaxContainers())
+		assert.Equal(t, 200, resp.Quota.MaxObjects())
+		assert.Equal(t, int64(1<<30), resp.Quota.MaxStorageBytes())
+		assert.Equal(t, userID, resp.Quota.UpdatedBy())
+		assert.True(t, resp.CanEdit)
+	})
+
+	t.Run("error - plain members cannot set the quota", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.authService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", groupID.String()).Return(map[string]entities.GroupRole{}, nil)
+
+		_, err := f.useCase.SetQuota(context.Background(), SetGroupQuotaRequest{GroupID: groupID, MaxContainers: 5, UserID: userID, UserToken: "token"})
+
+		require.ErrorIs(t, err, entities.ErrNotGroupQuotaAdmin)
+	})
+
+	t.Run("error - negative limits", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.authService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", groupID.String()).Return(map[string]entities.GroupRole{userID.String(): entities.GroupRoleAdmin}, nil)
+
+		_, err := f.useCase.SetQuota(context.Background(), SetGroupQuotaRequest{GroupID: groupID, MaxObjects: -1, UserID: userID, UserToken: "token"})
+
+		require.ErrorIs(t, err, entities.ErrInvalidGroupQuota)
+	})
+}
+
+func TestGroupQuota_Allow(t *testing.T) {
+	t.Parallel()
+
+	groupID, _ := entities.GroupIDFromString("group-1")
+	quota, err := entities.NewGroupQuota(entities.GroupQuotaProps{GroupID: groupID, MaxStorageBytes: 1000})
+	require.NoError(t, err)
+
+	usage := entities.GroupUsage{Containers: 500, StorageBytes: 900}
+
+	assert.NoError(t, quota.Allow(usage, entities.QuotaContainers, 1), "zero limit is unlimited")
+	assert.NoError(t, quota.Allow(usage, entities.QuotaStorage, 100), "filling the quota exactly is allowed")
+
+	err = quota.Allow(usage, entities.QuotaStorage, 101)
+	var quotaErr *entities.QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, entities.QuotaExceededError{
+		GroupID: groupID, Resource: entities.QuotaStorage, Limit: 1000, Used: 900, Requested: 101,
+	}, *quotaErr)
+}
@@ -2,7 +2,9 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/services"
@@ -61,19 +63,148 @@ func (uc *GroupUseCase) DeleteGroup(ctx context.Context, req DeleteGroupRequest)
 type GroupMemberRequest struct {
 	GroupID   entities.GroupID
 	UserID    string
+	ActorID   entities.UserID
 	UserToken string
 }
 
+// AddMember adds a user to the group. Only members may, and only admins
+// once the group has any.
 func (uc *GroupUseCase) AddMember(ctx context.Context, req GroupMemberRequest) error {
+	if _, err := uc.requireMemberManager(ctx, req.GroupID, req.ActorID, req.UserToken); err != nil {
+		return err
+	}
 	if err := uc.authService.AddUserToGroup(ctx, req.UserToken, req.GroupID.String(), req.UserID); err != nil {
 		return fmt.Errorf("failed to add member: %w", err)
 	}
 	return nil
 }
 
+// RemoveMember removes a user from the group, with the same rules as
+// AddMember.
 func (uc *GroupUseCase) RemoveMember(ctx context.Context, req GroupMemberRequest) error {
+	if _, err := uc.requireMemberManager(ctx, req.GroupID, req.ActorID, req.UserToken); err != nil {
+		return err
+	}
 	if err := uc.authService.RemoveUserFromGroup(ctx, req.UserToken, req.GroupID.String(), req.UserID); err != nil {
 		return fmt.Errorf("failed to remove member: %w", err)
 	}
 	return nil
 }
+
+type InviteGroupMemberRequest struct {
+	GroupID   entities.GroupID
+	Email     string
+	ActorID   entities.UserID
+	UserToken string
+}
+
+// InviteMember adds the user registered under req.Email to the group. Only
+// members may invite, and only admins once the group has any. An address
+// with no account succeeds without adding anyone, so invitations cannot be
+// used to find out who has one.
+func (uc *GroupUseCase) InviteMember(ctx context.Context, req InviteGroupMemberRequest) error {
+	email, err := entities.NewEmailAddress(req.Email)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.requireMemberManager(ctx, req.GroupID, req.ActorID, req.UserToken); err != nil {
+		return err
+	}
+
+	if _, err := uc.authService.InviteUserByEmail(ctx, req.UserToken, req.GroupID.String(), email.String()); err != nil {
+		if errors.Is(err, services.ErrInviteeNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to invite member: %w", err)
+	}
+	return nil
+}
+
+// requireMemberManager returns the group's members when actorID may manage
+// them: a member, and an admin once the group has any. Outsiders are told
+// the group does not exist.
+func (uc *GroupUseCase) requireMemberManager(ctx context.Context, groupID entities.GroupID, actorID entities.UserID, userToken string) ([]*entities.User, error) {
+	users, err := uc.authService.GetGroupUsers(ctx, userToken, groupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group users: %w", err)
+	}
+	if !slices.ContainsFunc(users, func(u *entities.User) bool { return u.ID().Equals(actorID) }) {
+		return nil, errors.New("group not found")
+	}
+	roles, err := uc.authService.GetGroupMemberRoles(ctx, userToken, groupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member roles: %w", err)
+	}
+	if len(roles) > 0 && roles[actorID.String()] != entities.GroupRoleAdmin {
+		return nil, entities.ErrNotGroupAdmin
+	}
+	return users, nil
+}
+
+// GroupMember is a user in a group with their role there.
+type GroupMember struct {
+	User *entities.User
+	Role entities.GroupRole
+}
+
+type ListGroupMembersRequest struct {
+	GroupID   entities.GroupID
+	ActorID   entities.UserID
+	UserToken string
+}
+
+func (uc *GroupUseCase) ListMembers(ctx context.Context, req ListGroupMembersRequest) ([]GroupMember, error) {
+	users, err := uc.authService.GetGroupUsers(ctx, req.UserToken, req.GroupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group users: %w", err)
+	}
+	// Outsiders are told the group does not exist
+	if !slices.ContainsFunc(users, func(u *entities.User) bool { return u.ID().Equals(req.ActorID) }) {
+		return nil, errors.New("group not found")
+	}
+	roles, err := uc.authService.GetGroupMemberRoles(ctx, req.UserToken, req.GroupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member roles: %w", err)
+	}
+
+	members := make([]GroupMember, len(users))
+	for i, user := range users {
+		role, ok := roles[user.ID().String()]
+		if !ok {
+			role = entities.GroupRoleMember
+		}
+		members[i] = GroupMember{User: user, Role: role}
+	}
+	return members, nil
+}
+
+type SetGroupMemberRoleRequest struct {
+	GroupID   entities.GroupID
+	UserID    string
+	Role      string
+	ActorID   entities.UserID
+	UserToken string
+}
+
+// SetMemberRole changes a member's role. Only admins may do so, except in a
+// group that has no admins yet, where any member can appoint the first one.
+// The user whose role changes has to be a member too.
+func (uc *GroupUseCase) SetMemberRole(ctx context.Context, req SetGroupMemberRoleRequest) error {
+	role, err := entities.ParseGroupRole(req.Role)
+	if err != nil {
+		return err
+	}
+
+	users, err := uc.requireMemberManager(ctx, req.GroupID, req.ActorID, req.UserToken)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(users, func(u *entities.User) bool { return u.ID().String() == req.UserID }) {
+		return entities.ErrNotGroupMember
+	}
+
+	if err := uc.authService.SetGroupMemberRole(ctx, req.UserToken, req.GroupID.String(), req.UserID, role); err != nil {
+		return fmt.Errorf("failed to set member role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func newGroupMember(t *testing.T, name string) *entities.User {
+	t.Helper()
+	username, err := entities.NewUsername(name)
+	require.NoError(t, err)
+	email, err := entities.NewEmailAddress(name + "@example.com")
+	require.NoError(t, err)
+	return entities.ReconstructUser(entities.NewUserID(), username, email, "", time.Now(), time.Now())
+}
+
+func TestGroupUseCase_ListMembers(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+	useCase := NewGroupUseCase(mockAuthService)
+
+	groupID, _ := entities.GroupIDFromString("family")
+	alice, bob := newGroupMember(t, "alice"), newGroupMember(t, "bob")
+
+	mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return([]*entities.User{alice, bob}, nil)
+	mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").
+		Return(map[string]entities.GroupRole{alice.ID().String(): entities.GroupRoleAdmin}, nil)
+
+	members, err := useCase.ListMembers(context.Background(), ListGroupMembersRequest{GroupID: groupID, ActorID: bob.ID(), UserToken: "token"})
+
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, entities.GroupRoleAdmin, members[0].Role)
+	assert.Equal(t, entities.GroupRoleMember, members[1].Role)
+
+	mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return([]*entities.User{alice, bob}, nil)
+	_, err = useCase.ListMembers(context.Background(), ListGroupMembersRequest{GroupID: groupID, ActorID: entities.NewUserID(), UserToken: "token"})
+	assert.EqualError(t, err, "group not found")
+}
+
+func TestGroupUseCase_SetMemberRole(t *testing.T) {
+	t.Parallel()
+
+	groupID, _ := entities.GroupIDFromString("family")
+	admin, member := newGroupMember(t, "alice"), newGroupMember(t, "bob")
+	users := []*entities.User{admin, member}
+	roles := map[string]entities.GroupRole{admin.ID().String(): entities.GroupRoleAdmin}
+
+	t.Run("admin promotes member", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil)
+		mockAuthService.EXPECT().SetGroupMemberRole(gomock.Any(), "token", "family", member.ID().String(), entities.GroupRoleAdmin).Return(nil)
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: member.ID().String(), Role: "admin", ActorID: admin.ID(), UserToken: "token",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("member cannot change roles", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil)
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: member.ID().String(), Role: "admin", ActorID: member.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, entities.ErrNotGroupAdmin)
+	})
+
+	t.Run("first admin of a group without admins", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(map[string]entities.GroupRole{}, nil)
+		mockAuthService.EXPECT().SetGroupMemberRole(gomock.Any(), "token", "family", member.ID().String(), entities.GroupRoleAdmin).Return(nil)
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: member.ID().String(), Role: "admin", ActorID: member.ID(), UserToken: "token",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("non-member cannot appoint the first admin", func(t *testing.T) {
+		t.Parallel()
+		outsider := newGroupMember(t, "mallory")
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: outsider.ID().String(), Role: "admin", ActorID: outsider.ID(), UserToken: "token",
+		})
+		assert.EqualError(t, err, "group not found")
+	})
+
+	t.Run("non-member cannot be made admin", func(t *testing.T) {
+		t.Parallel()
+		outsider := newGroupMember(t, "mallory")
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(map[string]entities.GroupRole{}, nil)
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: outsider.ID().String(), Role: "admin", ActorID: member.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, entities.ErrNotGroupMember)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: member.ID().String(), Role: "owner", ActorID: admin.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidGroupRole)
+	})
+
+	t.Run("sync failure is kept", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil)
+		mockAuthService.EXPECT().SetGroupMemberRole(gomock.Any(), "token", "family", member.ID().String(), entities.GroupRoleMember).
+			Return(fmt.Errorf("%w: update group roles: permission denied", services.ErrGroupSyncFailed))
+
+		err := NewGroupUseCase(mockAuthService).SetMemberRole(context.Background(), SetGroupMemberRoleRequest{
+			GroupID: groupID, UserID: member.ID().String(), Role: "member", ActorID: admin.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, services.ErrGroupSyncFailed)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
+
+func TestGroupUseCase_InviteMember(t *testing.T) {
+	t.Parallel()
+
+	groupID, _ := entities.GroupIDFromString("family")
+	admin, member := newGroupMember(t, "alice"), newGroupMember(t, "bob")
+	users := []*entities.User{admin, member}
+	roles := map[string]entities.GroupRole{admin.ID().String(): entities.GroupRoleAdmin}
+
+	t.Run("admin invites", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil)
+		mockAuthService.EXPECT().InviteUserByEmail(gomock.Any(), "token", "family", "carol@example.com").Return(newGroupMember(t, "carol"), nil)
+
+		err := NewGroupUseCase(mockAuthService).InviteMember(context.Background(), InviteGroupMemberRequest{
+			GroupID: groupID, Email: "carol@example.com", ActorID: admin.ID(), UserToken: "token",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown address looks the same as a known one", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil)
+		mockAuthService.EXPECT().InviteUserByEmail(gomock.Any(), "token", "family", "nobody@example.com").Return(nil, services.ErrInviteeNotFound)
+
+		err := NewGroupUseCase(mockAuthService).InviteMember(context.Background(), InviteGroupMemberRequest{
+			GroupID: groupID, Email: "nobody@example.com", ActorID: admin.ID(), UserToken: "token",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("member cannot invite once the group has admins", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil)
+
+		err := NewGroupUseCase(mockAuthService).InviteMember(context.Background(), InviteGroupMemberRequest{
+			GroupID: groupID, Email: "carol@example.com", ActorID: member.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, entities.ErrNotGroupAdmin)
+	})
+
+	t.Run("non-member cannot invite", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil)
+
+		err := NewGroupUseCase(mockAuthService).InviteMember(context.Background(), InviteGroupMemberRequest{
+			GroupID: groupID, Email: "mallory@example.com", ActorID: entities.NewUserID(), UserToken: "token",
+		})
+		assert.EqualError(t, err, "group not found")
+	})
+}
+
+func TestGroupUseCase_AddRemoveMember(t *testing.T) {
+	t.Parallel()
+
+	groupID, _ := entities.GroupIDFromString("family")
+	admin, member := newGroupMember(t, "alice"), newGroupMember(t, "bob")
+	users := []*entities.User{admin, member}
+	roles := map[string]entities.GroupRole{admin.ID().String(): entities.GroupRoleAdmin}
+	carol := newGroupMember(t, "carol")
+
+	t.Run("admin adds and removes", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil).Times(2)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil).Times(2)
+		mockAuthService.EXPECT().AddUserToGroup(gomock.Any(), "token", "family", carol.ID().String()).Return(nil)
+		mockAuthService.EXPECT().RemoveUserFromGroup(gomock.Any(), "token", "family", member.ID().String()).Return(nil)
+		useCase := NewGroupUseCase(mockAuthService)
+
+		require.NoError(t, useCase.AddMember(context.Background(), GroupMemberRequest{
+			GroupID: groupID, UserID: carol.ID().String(), ActorID: admin.ID(), UserToken: "token",
+		}))
+		require.NoError(t, useCase.RemoveMember(context.Background(), GroupMemberRequest{
+			GroupID: groupID, UserID: member.ID().String(), ActorID: admin.ID(), UserToken: "token",
+		}))
+	})
+
+	t.Run("member cannot add or remove once the group has admins", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil).Times(2)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "token", "family").Return(roles, nil).Times(2)
+		useCase := NewGroupUseCase(mockAuthService)
+
+		err := useCase.AddMember(context.Background(), GroupMemberRequest{
+			GroupID: groupID, UserID: carol.ID().String(), ActorID: member.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, entities.ErrNotGroupAdmin)
+		err = useCase.RemoveMember(context.Background(), GroupMemberRequest{
+			GroupID: groupID, UserID: admin.ID().String(), ActorID: member.ID(), UserToken: "token",
+		})
+		assert.ErrorIs(t, err, entities.ErrNotGroupAdmin)
+	})
+
+	t.Run("outsider cannot add or remove", func(t *testing.T) {
+		t.Parallel()
+		mockAuthService := mocks.NewMockAuthService(gomock.NewController(t))
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "token", "family").Return(users, nil).Times(2)
+		useCase := NewGroupUseCase(mockAuthService)
+
+		err := useCase.AddMember(context.Background(), GroupMemberRequest{
+			GroupID: groupID, UserID: carol.ID().String(), ActorID: carol.ID(), UserToken: "token",
+		})
+		assert.EqualError(t, err, "group not found")
+		err = useCase.RemoveMember(context.Background(), GroupMemberRequest{
+			GroupID: groupID, UserID: admin.ID().String(), ActorID: carol.ID(), UserToken: "token",
+		})
+		assert.EqualError(t, err, "group not found")
+	})
+}
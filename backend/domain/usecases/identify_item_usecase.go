@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// Drafts created for a photo carry this name and tag until whoever
+// identifies the item fills in its fields.
+const (
+	DraftObjectName = "Unidentified item"
+	DraftObjectTag  = "draft"
+)
+
+type IdentifyItemRequest struct {
+	// ObjectID is a draft to add the photo to. When nil a new draft is
+	// created in ContainerID, or in CollectionID's default container.
+	ObjectID     *entities.ObjectID
+	ContainerID  *entities.ContainerID
+	CollectionID *entities.CollectionID
+	Image        MediaUpload
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type IdentifyItemResponse struct {
+	Object      *entities.Object
+	ContainerID entities.ContainerID
+	Media       *entities.Media
+	Barcode     *services.Barcode // nil when the photo shows none
+	Created     bool              // a new draft was created
+}
+
+// IdentifyItemUseCase hands a photo of an unknown item to someone who can
+// identify it: the photo is stored on a draft object, along with any barcode
+// read from it, so the draft only needs its fields completed.
+type IdentifyItemUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	barcodeDecoder services.BarcodeDecoder
+	maxUploadSize  int64
+	createUC       *CreateObjectUseCase
+	updateUC       *UpdateObjectUseCase
+	deleteUC       *DeleteObjectUseCase
+	uploadUC       *UploadMediaUseCase
+}
+
+func NewIdentifyItemUseCase(
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	moveRepo repositories.ObjectMoveRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	mediaRepo repositories.MediaRepository,
+	mediaStorage services.MediaStorage,
+	barcodeDecoder services.BarcodeDecoder,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
+	expiryRepo repositories.ExpiryHistoryRepository,
+	authService services.AuthService,
+	maxUploadSize int64,
+	maxPerOwner int,
+) *IdentifyItemUseCase {
+	// Drafts are half-made, so they set off no automation rules
+	return &IdentifyItemUseCase{
+		containerRepo:  containerRepo,
+		barcodeDecoder: barcodeDecoder,
+		maxUploadSize:  maxUploadSize,
+		createUC:       NewCreateObjectUseCase(containerRepo, collectionRepo, codeRepo, quotaRepo, usageRepo, expiryRepo, authService, nil),
+		updateUC:       NewUpdateObjectUseCase(containerRepo, collectionRepo, moveRepo, codeRepo, authService, nil),
+		deleteUC:       NewDeleteObjectUseCase(containerRepo, collectionRepo, mediaRepo, mediaStorage, authService),
+		uploadUC:       NewUploadMediaUseCase(containerRepo, collectionRepo, mediaRepo, mediaStorage, quotaRepo, usageRepo, authService, maxUploadSize, maxPerOwner),
+	}
+}
+
+// Execute reads the photo before touching anything, so an unusable one
+// leaves no draft behind. A barcode is stored under its property key on a
+// new draft, and on an existing one that has no code yet.
+func (uc *IdentifyItemUseCase) Execute(ctx context.Context, req IdentifyItemRequest) (*IdentifyItemResponse, error) {
+	if int64(len(req.Image.Data)) > uc.maxUploadSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", entities.ErrMediaTooLarge, uc.maxUploadSize)
+	}
+	barcode, err := uc.barcodeDecoder.Decode(ctx, req.Image.ContentType, req.Image.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &IdentifyItemResponse{Barcode: barcode}
+	var objectID entities.ObjectID
+	if req.ObjectID != nil {
+		objectID = *req.ObjectID
+	} else {
+		created, err := uc.createDraft(ctx, req, barcode)
+		if err != nil {
+			return nil, err
+		}
+		resp.Object, resp.ContainerID, resp.Created = created.Object, created.ContainerID, true
+		objectID = created.Object.ID()
+	}
+
+	uploaded, err := uc.uploadUC.Execute(ctx, UploadMediaRequest{
+		OwnerKind: entities.MediaOwnerObject,
+		OwnerID:   objectID.String(),
+		Files:     []MediaUpload{req.Image},
+		UserID:    req.UserID,
+		UserToken: req.UserToken,
+	})
+	if err == nil && len(uploaded.Failed) > 0 {
+		err = uploaded.Failed[0].Err
+	}
+	if err != nil {
+		if resp.Created {
+			// Don't leave a draft without its photo
+			_, _ = uc.deleteUC.Execute(ctx, DeleteObjectRequest{ContainerID: &resp.ContainerID, ObjectID: objectID, UserID: req.UserID, UserToken: req.UserToken})
+		}
+		return nil, err
+	}
+	resp.Media = uploaded.Media[0]
+
+	if !resp.Created {
+		// The upload checked access to the object
+		if resp.Object, resp.ContainerID, err = uc.linkBarcode(ctx, req, barcode); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (uc *IdentifyItemUseCase) createDraft(ctx context.Context, req IdentifyItemRequest, barcode *services.Barcode) (*CreateObjectResponse, error) {
+	if req.ContainerID == nil && req.CollectionID == nil {
+		return nil, errors.New("either object_id, container_id or collection_id is required")
+	}
+	draft := CreateObjectRequest{
+		ContainerID:  req.ContainerID,
+		CollectionID: req.CollectionID,
+		Name:         DraftObjectName,
+		Tags:         []string{DraftObjectTag},
+		UserID:       req.UserID,
+		UserToken:    req.UserToken,
+	}
+	if barcode != nil {
+		draft.RawProperties = map[string]any{barcode.PropertyKey(): barcode.Code}
+	}
+	return uc.createUC.Execute(ctx, draft)
+}
+
+// linkBarcode stores the barcode on an existing object that has no code yet
+// and returns the object as it now is
+func (uc *IdentifyItemUseCase) linkBarcode(ctx context.Context, req IdentifyItemRequest, barcode *services.Barcode) (*entities.Object, entities.ContainerID, error) {
+	container, err := uc.containerRepo.FindByObjectID(ctx, *req.ObjectID)
+	if err != nil {
+		return nil, entities.ContainerID{}, fmt.Errorf("object not found: %w", err)
+	}
+	object, err := container.GetObject(*req.ObjectID)
+	if err != nil {
+		return nil, entities.ContainerID{}, err
+	}
+	if barcode == nil || len(object.Codes()) > 0 {
+		return object, container.ID(), nil
+	}
+
+	updated, err := uc.updateUC.Execute(ctx, UpdateObjectRequest{
+		ObjectID:        *req.ObjectID,
+		RawProperties:   map[string]any{barcode.PropertyKey(): barcode.Code},
+		MergeProperties: true,
+		UserID:          req.UserID,
+		UserToken:       req.UserToken,
+	})
+	if err != nil {
+		return nil, entities.ContainerID{}, err
+	}
+	return updated.Object, updated.ContainerID, nil
+}
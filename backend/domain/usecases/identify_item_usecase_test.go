@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestIdentifyItemUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		codeRepo       *mocks.MockObjectCodeRepository
+		mediaRepo      *mocks.MockMediaRepository
+		mediaStorage   *mocks.MockMediaStorage
+		decoder        *mocks.MockBarcodeDecoder
+		authService    *mocks.MockAuthService
+		useCase        *IdentifyItemUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			codeRepo:       mocks.NewMockObjectCodeRepository(mockCtrl),
+			mediaRepo:      mocks.NewMockMediaRepository(mockCtrl),
+			mediaStorage:   mocks.NewMockMediaStorage(mockCtrl),
+			decoder:        mocks.NewMockBarcodeDecoder(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewIdentifyItemUseCase(f.containerRepo, f.collectionRepo, mocks.NewMockObjectMoveRepository(mockCtrl), f.codeRepo, f.mediaRepo, f.mediaStorage, f.decoder, mocks.NewMockGroupQuotaRepository(mockCtrl), mocks.NewMockUsageRepository(mockCtrl), mocks.NewMockExpiryHistoryRepository(mockCtrl), f.authService, 10, 3)
+		return f
+	}
+	photo := MediaUpload{Filename: "can.png", ContentType: "image/png", Data: []byte("png")}
+
+	t.Run("success - creates a draft holding the photo and its barcode", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		container := NewTestContainer(CtrCollectionID(collection.ID()))
+		containerID := container.ID()
+		barcode := &services.Barcode{Format: services.BarcodeUPCA, Code: "036000291452"}
+
+		f.decoder.EXPECT().Decode(gomock.Any(), "image/png", []byte("png")).Return(barcode, nil)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil).Times(2)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return(nil, nil)
+		var draft entities.Object
+		f.containerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entities.ContainerID, object entities.Object) error {
+				draft = object
+				return nil
+			})
+		f.codeRepo.EXPECT().Add(gomock.Any(), userID, "036000291452", gomock.Any()).Return(nil)
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), gomock.Any()).Return(container, nil)
+		f.mediaRepo.EXPECT().CountByOwner(gomock.Any(), entities.MediaOwnerObject, gomock.Any()).Return(int64(0), nil)
+		f.mediaStorage.EXPECT().Save(gomock.Any(), collection.ID(), gomock.Any(), "image/png", []byte("png")).
+			Return(&services.StoredMedia{URL: "/media/can.png", ThumbnailURL: "/media/can_thumb.jpg"}, nil)
+		f.mediaRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), IdentifyItemRequest{
+			ContainerID: &containerID,
+			Image:       photo,
+			UserID:      userID,
+			UserToken:   "token",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Created)
+		assert.Equal(t, barcode, resp.Barcode)
+		assert.Equal(t, DraftObjectName, draft.Name().String())
+		assert.Equal(t, []string{DraftObjectTag}, draft.Tags())
+		assert.Equal(t, "036000291452", draft.Properties()["upc"].Val)
+		assert.Equal(t, "/media/can.png", resp.Media.URL())
+		assert.Equal(t, draft.ID().String(), resp.Media.OwnerID())
+	})
+
+	t.Run("error - an unreadable photo creates nothing", func(t *testing.T) {
+		f := setup(t)
+		collectionID := entities.NewCollectionID()
+
+		f.decoder.EXPECT().Decode(gomock.Any(), "image/png", []byte("png")).Return(nil, entities.ErrUnsupportedMediaType)
+
+		_, err := f.useCase.Execute(context.Background(), IdentifyItemRequest{
+			CollectionID: &collectionID,
+			Image:        photo,
+			UserID:       entities.NewUserID(),
+			UserToken:    "token",
+		})
+
+		assert.ErrorIs(t, err, entities.ErrUnsupportedMediaType)
+	})
+
+	t.Run("error - a photo over the size limit is not read", func(t *testing.T) {
+		f := setup(t)
+		collectionID := entities.NewCollectionID()
+
+		_, err := f.useCase.Execute(context.Background(), IdentifyItemRequest{
+			CollectionID: &collectionID,
+			Image:        MediaUpload{ContentType: "image/png", Data: make([]byte, 11)},
+			UserID:       entities.NewUserID(),
+			UserToken:    "token",
+		})
+
+		assert.ErrorIs(t, err, entities.ErrMediaTooLarge)
+	})
+}
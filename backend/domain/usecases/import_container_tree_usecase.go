@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// ErrInvalidContainerTree wraps every problem found in an imported document,
+// so callers can report them as bad input.
+var ErrInvalidContainerTree = errors.New("invalid container tree")
+
+type ImportContainerTreeRequest struct {
+	CollectionID entities.CollectionID
+	YAML         []byte
+	// DryRun validates the document and reports what would change without
+	// saving anything.
+	DryRun    bool
+	UserID    entities.UserID
+	UserToken string
+}
+
+type ImportContainerTreeResponse struct {
+	// Created and Updated are listed parents before children.
+	Created   []*entities.Container
+	Updated   []*entities.Container
+	Unchanged int
+	// Unlisted counts containers of the collection missing from the document.
+	// They are left where they are.
+	Unlisted int
+}
+
+// ImportContainerTreeUseCase applies an edited container tree to a
+// collection: entries with an id rename or move that container, entries
+// without one create a new container. Nothing is ever deleted.
+type ImportContainerTreeUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	codeRepo       repositories.ObjectCodeRepository
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	authService    services.AuthService
+}
+
+func NewImportContainerTreeUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, codeRepo repositories.ObjectCodeRepository, quotaRepo repositories.GroupQuotaRepository, usageRepo repositories.UsageRepository, authService services.AuthService) *ImportContainerTreeUseCase {
+	return &ImportContainerTreeUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		codeRepo:       codeRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		authService:    authService,
+	}
+}
+
+// plannedContainer is one document entry after validation
+type plannedContainer struct {
+	node          containerTreeNode
+	name          entities.ContainerName
+	containerType entities.ContainerType
+	existing      *entities.Container // nil for a new container
+	parent        int                 // index into the plan, -1 for the collection root
+}
+
+func (uc *ImportContainerTreeUseCase) Execute(ctx context.Context, req ImportContainerTreeRequest) (*ImportContainerTreeResponse, error) {
+	var doc containerTreeDocument
+	if err := yaml.Unmarshal(req.YAML, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContainerTree, err)
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	// Check access: user is owner OR user is member of collection's group
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	plan, err := planContainerTree(collection, doc.Containers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ImportContainerTreeResponse{}
+	listed := 0
+	for _, p := range plan {
+		if p.existing != nil {
+			listed++
+		}
+	}
+	resp.Unlisted = len(collection.Containers()) - listed
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, int64(len(plan)-listed)); err != nil {
+		return nil, err
+	}
+
+	ids := make([]entities.ContainerID, len(plan))
+	for i, p := range plan {
+		var parentID *entities.ContainerID
+		if p.parent >= 0 {
+			id := ids[p.parent]
+			parentID = &id
+		}
+
+		if p.existing == nil {
+			container, err := entities.NewContainer(entities.ContainerProps{
+				CollectionID:      collection.ID(),
+				Name:              p.name,
+				ContainerType:     p.containerType,
+				ParentContainerID: parentID,
+				Location:          p.node.Location,
+				Width:             p.node.Width,
+				Depth:             p.node.Depth,
+				Rows:              p.node.Rows,
+				Capacity:          p.node.Capacity,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create container entity: %w", err)
+			}
+			if !req.DryRun {
+				if err := uc.containerRepo.Create(ctx, container); err != nil {
+					return nil, fmt.Errorf("failed to save container %q: %w", p.node.Name, err)
+				}
+				_ = indexContainerCodes(ctx, uc.codeRepo, req.UserID, container)
+			}
+			if err := collection.AddContainer(*container); err != nil {
+				return nil, fmt.Errorf("failed to add container to collection: %w", err)
+			}
+			ids[i] = container.ID()
+			resp.Created = append(resp.Created, container)
+			continue
+		}
+
+		container := p.existing
+		ids[i] = container.ID()
+		if !applyContainerTreeNode(container, p, parentID) {
+			resp.Unchanged++
+			continue
+		}
+		if !req.DryRun {
+			if err := uc.containerRepo.Update(ctx, container); err != nil {
+				return nil, fmt.Errorf("failed to save container %q: %w", p.node.Name, err)
+			}
+		}
+		if err := collection.UpdateContainer(container.ID(), *container); err != nil {
+			return nil, fmt.Errorf("failed to update container in collection: %w", err)
+		}
+		resp.Updated = append(resp.Updated, container)
+	}
+
+	if !req.DryRun && len(resp.Created) > 0 {
+		if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+			return nil, fmt.Errorf("failed to update collection: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// planContainerTree validates the document against the collection and
+// flattens it parents first. Every check runs before anything is saved, so a
+// bad document changes nothing.
+func planContainerTree(collection *entities.Collection, nodes []containerTreeNode) ([]plannedContainer, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("%w: no containers listed", ErrInvalidContainerTree)
+	}
+
+	var plan []plannedContainer
+	seen := make(map[string]bool)
+
+	var walk func(nodes []containerTreeNode, parent, depth int) error
+	walk = func(nodes []containerTreeNode, parent, depth int) error {
+		if depth > MaxContainerTreeDepth {
+			return fmt.Errorf("%w: %w", ErrInvalidContainerTree, ErrContainerTreeTooLarge)
+		}
+		if parent >= 0 && len(nodes) > 0 && !containerTypeCanHold(plan[parent].containerType) {
+			return fmt.Errorf("%w: container %q of type %s cannot have children", ErrInvalidContainerTree, plan[parent].node.Name, plan[parent].containerType)
+		}
+		for _, node := range nodes {
+			if len(plan) >= MaxContainerTreeNodes {
+				return fmt.Errorf("%w: %w", ErrInvalidContainerTree, ErrContainerTreeTooLarge)
+			}
+
+			name, err := entities.NewContainerName(node.Name)
+			if err != nil {
+				return fmt.Errorf("%w: container %q: %w", ErrInvalidContainerTree, node.Name, err)
+			}
+			containerType := entities.ContainerType(node.Type)
+			if containerType == "" {
+				containerType = entities.ContainerTypeGeneral
+			}
+			if !entities.IsValidContainerType(string(containerType)) {
+				return fmt.Errorf("%w: container %q: unknown type %q", ErrInvalidContainerTree, node.Name, node.Type)
+			}
+
+			p := plannedContainer{node: node, name: name, containerType: containerType, parent: parent}
+			if node.ID != "" {
+				id, err := entities.ContainerIDFromString(node.ID)
+				if err != nil {
+					return fmt.Errorf("%w: container %q has an invalid id", ErrInvalidContainerTree, node.Name)
+				}
+				if seen[id.String()] {
+					return fmt.Errorf("%w: container id %s is listed more than once", ErrInvalidContainerTree, id)
+				}
+				seen[id.String()] = true
+				existing, err := collection.GetContainer(id)
+				if err != nil {
+					return fmt.Errorf("%w: container %q: id %s is not in this collection", ErrInvalidContainerTree, node.Name, id)
+				}
+				p.existing = existing
+			}
+
+			plan = append(plan, p)
+			if err := walk(node.Children, len(plan)-1, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(nodes, -1, 1); err != nil {
+		return nil, err
+	}
+
+	if err := checkUnlistedContainers(collection, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// checkUnlistedContainers makes sure containers left out of the document
+// still fit once it is applied: a listed container whose new type cannot hold
+// children must not still be the parent of an unlisted one. Listed containers
+// only ever move under other listed ones, so the tree cannot gain a cycle.
+func checkUnlistedContainers(collection *entities.Collection, plan []plannedContainer) error {
+	listed := make(map[string]int, len(plan))
+	for i, p := range plan {
+		if p.existing != nil {
+			listed[p.existing.ID().String()] = i
+		}
+	}
+
+	for _, c := range collection.Containers() {
+		parent := c.ParentContainerID()
+		if _, ok := listed[c.ID().String()]; ok || parent == nil {
+			continue
+		}
+		if i, ok := listed[parent.String()]; ok && !containerTypeCanHold(plan[i].containerType) {
+			return fmt.Errorf("%w: container %q of type %s still holds %q, which is not listed", ErrInvalidContainerTree, plan[i].node.Name, plan[i].containerType, c.Name())
+		}
+	}
+	return nil
+}
+
+func containerTypeCanHold(t entities.ContainerType) bool {
+	return t == entities.ContainerTypeRoom ||
+		t == entities.ContainerTypeBookshelf ||
+		t == entities.ContainerTypeGeneral
+}
+
+// applyContainerTreeNode copies the entry's fields onto an existing container
+// and reports whether anything changed
+func applyContainerTreeNode(c *entities.Container, p plannedContainer, parentID *entities.ContainerID) bool {
+	changed := false
+	if !c.Name().Equals(p.name) {
+		_ = c.UpdateName(p.name)
+		changed = true
+	}
+	if c.ContainerType() != p.containerType {
+		_ = c.UpdateContainerType(p.containerType)
+		changed = true
+	}
+	if !sameContainerID(c.ParentContainerID(), parentID) {
+		_ = c.UpdateParentContainer(parentID)
+		changed = true
+	}
+	if c.Location() != p.node.Location {
+		_ = c.UpdateLocation(p.node.Location)
+		changed = true
+	}
+	if !samePtr(c.Width(), p.node.Width) || !samePtr(c.Depth(), p.node.Depth) ||
+		!samePtr(c.Rows(), p.node.Rows) || !samePtr(c.Capacity(), p.node.Capacity) {
+		_ = c.UpdateDimensions(p.node.Width, p.node.Depth, p.node.Rows, p.node.Capacity)
+		changed = true
+	}
+	return changed
+}
+
+func sameContainerID(a, b *entities.ContainerID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equals(*b)
+}
+
+func samePtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,199 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestImportContainerTreeUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *ImportContainerTreeUseCase
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		quotaRepo      *mocks.MockGroupQuotaRepository
+		usageRepo      *mocks.MockUsageRepository
+		authService    *mocks.MockAuthService
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			quotaRepo:      mocks.NewMockGroupQuotaRepository(mockCtrl),
+			usageRepo:      mocks.NewMockUsageRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewImportContainerTreeUseCase(f.containerRepo, f.collectionRepo, mocks.NewMockObjectCodeRepository(mockCtrl), f.quotaRepo, f.usageRepo, f.authService)
+		return f
+	}
+
+	userID := entities.NewUserID()
+
+	// kitchen holds pantry; garage is at the root
+	newHouse := func() (*entities.Collection, *entities.Container, *entities.Container, *entities.Container) {
+		collectionID := entities.NewCollectionID()
+		kitchen := NewTestContainer(CtrName("Kitchen"), CtrType(entities.ContainerTypeRoom), CtrCollectionID(collectionID))
+		pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID), CtrParentID(kitchen.ID()))
+		garage := NewTestContainer(CtrName("Garage"), CtrType(entities.ContainerTypeRoom), CtrCollectionID(collectionID))
+		col := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(*kitchen, *pantry, *garage))
+		return col, kitchen, pantry, garage
+	}
+
+	t.Run("exported tree round-trips with renames, moves and new containers", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, kitchen, pantry, garage := newHouse()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return(nil, nil).Times(2)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil).Times(2)
+
+		exported, err := NewExportContainerTreeUseCase(f.collectionRepo, f.authService).Execute(context.Background(), ExportContainerTreeRequest{
+			CollectionID: col.ID(), UserID: userID,
+		})
+		require.NoError(t, err)
+		doc := string(exported.YAML)
+		assert.True(t, strings.HasPrefix(doc, "# Container layout."))
+		assert.Contains(t, doc, "name: Pantry")
+		assert.Contains(t, doc, "type: room")
+
+		// Rename the pantry, move it to the garage and add a shelf under it.
+		edited := fmt.Sprintf(`containers:
+  - id: %s
+    name: Kitchen
+    type: room
+  - id: %s
+    name: Garage
+    type: room
+    children:
+      - id: %s
+        name: Garage pantry
+        children:
+          - name: Top shelf
+            type: shelf
+`, kitchen.ID(), garage.ID(), pantry.ID())
+
+		f.containerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		f.containerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		f.collectionRepo.EXPECT().Update(gomock.Any(), col).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), ImportContainerTreeRequest{
+			CollectionID: col.ID(), YAML: []byte(edited), UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Unchanged)
+		assert.Zero(t, resp.Unlisted)
+		require.Len(t, resp.Updated, 1)
+		moved := resp.Updated[0]
+		assert.Equal(t, "Garage pantry", moved.Name().String())
+		require.NotNil(t, moved.ParentContainerID())
+		assert.Equal(t, garage.ID(), *moved.ParentContainerID())
+		require.Len(t, resp.Created, 1)
+		shelf := resp.Created[0]
+		assert.Equal(t, entities.ContainerTypeShelf, shelf.ContainerType())
+		require.NotNil(t, shelf.ParentContainerID())
+		assert.Equal(t, pantry.ID(), *shelf.ParentContainerID())
+		assert.Len(t, col.Containers(), 4)
+	})
+
+	t.Run("dry run reports changes without saving", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		col, kitchen, _, _ := newHouse()
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), ImportContainerTreeRequest{
+			CollectionID: col.ID(),
+			YAML:         []byte(fmt.Sprintf("containers:\n  - id: %s\n    name: Cookhouse\n    type: room\n  - name: Attic\n", kitchen.ID())),
+			DryRun:       true,
+			UserID:       userID,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Updated, 1)
+		assert.Len(t, resp.Created, 1)
+		assert.Equal(t, 2, resp.Unlisted)
+	})
+
+	t.Run("rejects invalid documents before saving anything", func(t *testing.T) {
+		t.Parallel()
+		col, kitchen, _, garage := newHouse()
+
+		cases := map[string]string{
+			"unknown id":     fmt.Sprintf("containers:\n  - id: %s\n    name: Ghost\n", entities.NewContainerID()),
+			"duplicate id":   fmt.Sprintf("containers:\n  - id: %s\n    name: A\n  - id: %[1]s\n    name: B\n", garage.ID()),
+			"unknown type":   "containers:\n  - name: Box\n    type: crate\n",
+			"shelf children": "containers:\n  - name: Shelf\n    type: shelf\n    children:\n      - name: Box\n",
+			"orphaned child": fmt.Sprintf("containers:\n  - id: %s\n    name: Kitchen\n    type: shelf\n", kitchen.ID()),
+			"empty":          "containers: []\n",
+			"malformed":      "containers: [",
+		}
+		for name, doc := range cases {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				f := setup(t)
+				f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+				f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil).AnyTimes()
+
+				_, err := f.useCase.Execute(context.Background(), ImportContainerTreeRequest{
+					CollectionID: col.ID(), YAML: []byte(doc), UserID: userID,
+				})
+
+				require.ErrorIs(t, err, ErrInvalidContainerTree)
+			})
+		}
+	})
+
+	t.Run("new containers past the group's quota create nothing", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+		groupID, _ := entities.GroupIDFromString("family")
+		group := NewTestGroup(GrpID(groupID))
+		box := NewTestContainer(CtrName("Box"))
+		col := NewTestCollection(ColUserID(userID), ColGroupID(&groupID), ColContainers(*box))
+
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), userID.String()).Return([]*entities.Group{group}, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		expectGroupQuota(f.quotaRepo, f.usageRepo, groupID, entities.QuotaContainers, 2, 1)
+
+		doc := "containers:\n  - id: " + box.ID().String() + "\n    name: Box\n  - name: Crate\n  - name: Bin\n"
+		_, err := f.useCase.Execute(context.Background(), ImportContainerTreeRequest{
+			CollectionID: col.ID(), YAML: []byte(doc), UserID: userID,
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, int64(2), quotaErr.Requested, "only new containers count")
+	})
+
+	t.Run("access denied for foreign collection", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection()
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), ImportContainerTreeRequest{
+			CollectionID: col.ID(), YAML: []byte("containers:\n  - name: Box\n"), UserID: userID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
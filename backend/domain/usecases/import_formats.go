@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/domain/services"
+)
+
+// ImportSourceFormat identifies the app an import file was exported from.
+// Non-generic formats are rewritten into Nishiki's own column names before
+// the regular import runs.
+type ImportSourceFormat string
+
+const (
+	ImportSourceGeneric   ImportSourceFormat = "generic"    // columns already use Nishiki names
+	ImportSourceAuto      ImportSourceFormat = "auto"       // detect from the rows
+	ImportSourceGrocy     ImportSourceFormat = "grocy"      // Grocy stock/product JSON
+	ImportSourcePantryCSV ImportSourceFormat = "pantry_csv" // pantry and grocery app CSV exports
+)
+
+// grocyNeverExpires is the best-before date Grocy stores for products that
+// do not expire.
+const grocyNeverExpires = "2999-12-31"
+
+// pantryColumnAliases maps the header spellings used by common pantry apps
+// (Pantry Party, Pantry Check, NoWaste, spreadsheet templates) to Nishiki
+// columns. Keys are snake_case.
+var pantryColumnAliases = map[string]string{
+	"item_name":        "name",
+	"product":          "name",
+	"product_name":     "name",
+	"food":             "name",
+	"qty":              "quantity",
+	"amount":           "quantity",
+	"count":            "quantity",
+	"quantity":         "quantity",
+	"units":            "unit",
+	"unit":             "unit",
+	"measure":          "unit",
+	"uom":              "unit",
+	"expiration":       "expires_at",
+	"expiration_date":  "expires_at",
+	"expiry":           "expires_at",
+	"expiry_date":      "expires_at",
+	"exp_date":         "expires_at",
+	"best_before":      "expires_at",
+	"best_by":          "expires_at",
+	"use_by":           "expires_at",
+	"storage":          "location",
+	"storage_location": "location",
+	"stored_in":        "location",
+	"where":            "location",
+	"notes":            "description",
+	"note":             "description",
+	"upc":              "barcode",
+	"ean":              "barcode",
+	"barcode":          "barcode",
+	"category":         "category",
+	"aisle":            "category",
+}
+
+// importDateLayouts are the date formats accepted for expiry columns.
+var importDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/06",
+	"2006/01/02",
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+// DetectImportSourceFormat guesses which app produced the rows from the first
+// row's shape.
+func DetectImportSourceFormat(rows []map[string]any) ImportSourceFormat {
+	if len(rows) == 0 {
+		return ImportSourceGeneric
+	}
+	row := rows[0]
+	if _, ok := row["product"].(map[string]any); ok {
+		return ImportSourceGrocy
+	}
+	_, hasProductID := row["product_id"]
+	_, hasBestBefore := row["best_before_date"]
+	_, hasStockUnit := row["qu_id_stock"]
+	if hasProductID || hasBestBefore || hasStockUnit {
+		return ImportSourceGrocy
+	}
+	for key := range row {
+		nk := services.ToSnakeCase(key)
+		if target, ok := pantryColumnAliases[nk]; ok && target != nk {
+			return ImportSourcePantryCSV
+		}
+	}
+	return ImportSourceGeneric
+}
+
+// adaptImportRows rewrites rows exported by another app into Nishiki columns
+// (name, description, quantity, unit, expires_at, location, tags plus free
+// properties). It returns the format that was applied, which differs from
+// the requested one when auto-detecting.
+func adaptImportRows(format ImportSourceFormat, rows []map[string]any) ([]map[string]any, ImportSourceFormat, error) {
+	if format == ImportSourceAuto {
+		format = DetectImportSourceFormat(rows)
+	}
+
+	var adapt func(map[string]any) map[string]any
+	switch format {
+	case ImportSourceGeneric:
+		return rows, format, nil
+	case ImportSourceGrocy:
+		adapt = adaptGrocyRow
+	case ImportSourcePantryCSV:
+		adapt = adaptPantryRow
+	default:
+		return nil, "", fmt.Errorf("unsupported source format: %s", format)
+	}
+
+	out := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		out[i] = adapt(row)
+	}
+	return out, format, nil
+}
+
+// adaptGrocyRow handles both Grocy stock entries (/api/stock, with a nested
+// "product") and bare product objects (/api/objects/products). Location and
+// quantity unit names are only present when the export embeds them, as
+// /api/stock/products/{id} does.
+func adaptGrocyRow(row map[string]any) map[string]any {
+	product, ok := row["product"].(map[string]any)
+	if !ok {
+		product = row
+	}
+
+	out := make(map[string]any)
+	setIfPresent(out, "name", product["name"])
+	if desc := stringValue(product["description"]); desc != "" {
+		out["description"] = desc
+	}
+
+	for _, key := range []string{"amount", "stock_amount", "amount_aggregated"} {
+		if q, ok := floatValue(row[key]); ok {
+			out["quantity"] = q
+			break
+		}
+	}
+
+	for _, key := range []string{"best_before_date", "next_due_date"} {
+		if d := stringValue(row[key]); d != "" && d != grocyNeverExpires {
+			out["expires_at"] = d
+			break
+		}
+	}
+
+	if loc, ok := row["location"].(map[string]any); ok {
+		setIfPresent(out, "location", loc["name"])
+	}
+	if unit, ok := row["quantity_unit_stock"].(map[string]any); ok {
+		setIfPresent(out, "unit", unit["name"])
+	}
+
+	if barcodes := stringValue(product["barcode"]); barcodes != "" {
+		out["barcode"] = barcodes
+	}
+	if minStock, ok := floatValue(product["min_stock_amount"]); ok && minStock > 0 {
+		out["min_stock_amount"] = minStock
+	}
+	return out
+}
+
+// adaptPantryRow renames known pantry-app headers and keeps everything else
+// as a property.
+func adaptPantryRow(row map[string]any) map[string]any {
+	out := make(map[string]any, len(row))
+	// Sorted so the first of two columns with the same meaning wins every time
+	for _, key := range slices.Sorted(maps.Keys(row)) {
+		value := row[key]
+		target, ok := pantryColumnAliases[services.ToSnakeCase(key)]
+		if !ok {
+			target = key
+		}
+		if _, taken := out[target]; taken {
+			continue
+		}
+		out[target] = value
+	}
+	return out
+}
+
+// resolveStockFields extracts unit and expiry from a data row. An expiry that
+// does not parse as a date is left unset rather than failing the row.
+func resolveStockFields(item map[string]any) (unit string, expiresAt *time.Time) {
+	if v, ok := getRowValue(item, "unit"); ok {
+		unit = stringValue(v)
+	}
+	if v, ok := getRowValue(item, "expires_at"); ok {
+		raw := stringValue(v)
+		for _, layout := range importDateLayouts {
+			if t, err := time.Parse(layout, raw); err == nil {
+				expiresAt = &t
+				break
+			}
+		}
+	}
+	return unit, expiresAt
+}
+
+func setIfPresent(out map[string]any, key string, value any) {
+	if s := stringValue(value); s != "" {
+		out[key] = s
+	}
+}
+
+func stringValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", v))
+}
+
+// floatValue accepts numbers and numeric strings; Grocy serializes amounts
+// as strings in older versions.
+func floatValue(v any) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectImportSourceFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rows []map[string]any
+		want ImportSourceFormat
+	}{
+		{"empty", nil, ImportSourceGeneric},
+		{"grocy stock entry", []map[string]any{{"product_id": "1", "amount": "2", "product": map[string]any{"name": "Milk"}}}, ImportSourceGrocy},
+		{"grocy product object", []map[string]any{{"name": "Milk", "qu_id_stock": "3"}}, ImportSourceGrocy},
+		{"pantry csv headers", []map[string]any{{"Item Name": "Rice", "Qty": 2.0, "Expiration Date": "2025-01-31"}}, ImportSourcePantryCSV},
+		{"nishiki columns", []map[string]any{{"name": "Rice", "quantity": 2.0, "unit": "kg"}}, ImportSourceGeneric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectImportSourceFormat(tt.rows))
+		})
+	}
+}
+
+func TestAdaptImportRows(t *testing.T) {
+	t.Parallel()
+
+	t.Run("grocy stock entries", func(t *testing.T) {
+		rows := []map[string]any{
+			{
+				"product_id":       "4",
+				"amount":           "3",
+				"best_before_date": "2025-03-01",
+				"product":          map[string]any{"name": "Oat Milk", "description": "Barista", "min_stock_amount": "2"},
+				"location":         map[string]any{"name": "Fridge"},
+				"quantity_unit_stock": map[string]any{
+					"name": "Carton",
+				},
+			},
+			{
+				"product_id":       "5",
+				"amount":           1.0,
+				"best_before_date": grocyNeverExpires,
+				"product":          map[string]any{"name": "Salt"},
+			},
+		}
+
+		got, format, err := adaptImportRows(ImportSourceAuto, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportSourceGrocy, format)
+		assert.Equal(t, map[string]any{
+			"name":             "Oat Milk",
+			"description":      "Barista",
+			"quantity":         3.0,
+			"expires_at":       "2025-03-01",
+			"location":         "Fridge",
+			"unit":             "Carton",
+			"min_stock_amount": 2.0,
+		}, got[0])
+		assert.Equal(t, map[string]any{"name": "Salt", "quantity": 1.0}, got[1], "never-expiring products have no expiry")
+	})
+
+	t.Run("pantry csv renames known headers and keeps the rest", func(t *testing.T) {
+		rows := []map[string]any{{
+			"Item Name":       "Black Beans",
+			"Qty":             4.0,
+			"Units":           "cans",
+			"Expiration Date": "06/30/2026",
+			"Storage":         "Pantry",
+			"Brand":           "Goya",
+		}}
+
+		got, format, err := adaptImportRows(ImportSourcePantryCSV, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportSourcePantryCSV, format)
+		assert.Equal(t, map[string]any{
+			"name":       "Black Beans",
+			"quantity":   4.0,
+			"unit":       "cans",
+			"expires_at": "06/30/2026",
+			"location":   "Pantry",
+			"Brand":      "Goya",
+		}, got[0])
+	})
+
+	t.Run("generic rows pass through", func(t *testing.T) {
+		rows := []map[string]any{{"name": "Rice"}}
+
+		got, format, err := adaptImportRows(ImportSourceGeneric, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportSourceGeneric, format)
+		assert.Equal(t, rows, got)
+	})
+
+	t.Run("error - unknown format", func(t *testing.T) {
+		_, _, err := adaptImportRows("libib", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported source format")
+	})
+}
+
+func TestResolveStockFields(t *testing.T) {
+	t.Parallel()
+
+	unit, expiresAt := resolveStockFields(map[string]any{"Unit": "kg", "expires_at": "06/30/2026"})
+	assert.Equal(t, "kg", unit)
+	require.NotNil(t, expiresAt)
+	assert.Equal(t, time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), *expiresAt)
+
+	unit, expiresAt = resolveStockFields(map[string]any{"expires_at": "someday"})
+	assert.Empty(t, unit)
+	assert.Nil(t, expiresAt, "unparseable dates are dropped")
+}
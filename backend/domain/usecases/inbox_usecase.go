@@ -0,0 +1,277 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// maxInboxSubjectLength and maxInboxSenderLength cut off what a forwarded
+// email may store of its headers
+const (
+	maxInboxSubjectLength = 200
+	maxInboxSenderLength  = 320
+)
+
+// InboxUseCase turns forwarded order confirmations and receipts into
+// pending items, which the user confirms into objects or dismisses.
+type InboxUseCase struct {
+	inboxRepo      repositories.InboxRepository
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	createUC       *CreateObjectUseCase
+}
+
+func NewInboxUseCase(
+	inboxRepo repositories.InboxRepository,
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
+	expiryRepo repositories.ExpiryHistoryRepository,
+	authService services.AuthService,
+	events services.EventPublisher,
+) *InboxUseCase {
+	return &InboxUseCase{
+		inboxRepo:      inboxRepo,
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		createUC:       NewCreateObjectUseCase(containerRepo, collectionRepo, codeRepo, quotaRepo, usageRepo, expiryRepo, authService, events),
+	}
+}
+
+// GetAddress returns the user's inbox address, creating it on first use.
+func (uc *InboxUseCase) GetAddress(ctx context.Context, userID entities.UserID) (*entities.InboxAddress, error) {
+	address, err := uc.inboxRepo.GetAddressByUserID(ctx, userID)
+	if err == nil {
+		return address, nil
+	}
+	if !errors.Is(err, entities.ErrInboxAddressNotFound) {
+		return nil, err
+	}
+
+	address, err = entities.NewInboxAddress(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inbox address: %w", err)
+	}
+	if err := uc.inboxRepo.SaveAddress(ctx, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// ResetAddress gives the user a new inbox address. Mail sent to the old one
+// is refused from then on; items already received stay.
+func (uc *InboxUseCase) ResetAddress(ctx context.Context, userID entities.UserID) (*entities.InboxAddress, error) {
+	address, err := uc.GetAddress(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := address.Reset(); err != nil {
+		return nil, fmt.Errorf("failed to reset inbox address: %w", err)
+	}
+	if err := uc.inboxRepo.SaveAddress(ctx, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+type ReceiveInboxEmailRequest struct {
+	// Recipients is the address list the message was delivered to; the one
+	// at BaseAddress with a +token picks the user.
+	Recipients  string
+	BaseAddress string
+	Sender      string
+	Subject     string
+	// Body is the plain-text part of the message.
+	Body string
+}
+
+// Receive files a forwarded email in its user's inbox. It fails with
+// ErrInboxAddressNotFound for mail to an unknown address, ErrInboxItemEmpty
+// when no item could be read from the body and ErrInboxFull when the user
+// already has MaxPendingInboxItems waiting.
+func (uc *InboxUseCase) Receive(ctx context.Context, req ReceiveInboxEmailRequest) (*entities.InboxItem, error) {
+	token, ok := entities.InboxTokenFromRecipient(req.Recipients, req.BaseAddress)
+	if !ok {
+		return nil, entities.ErrInboxAddressNotFound
+	}
+	address, err := uc.inboxRepo.GetAddressByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.inboxRepo.CountItemsByUserID(ctx, address.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if count >= entities.MaxPendingInboxItems {
+		return nil, entities.ErrInboxFull
+	}
+
+	item, err := entities.NewInboxItem(
+		address.UserID(),
+		truncateRunes(strings.TrimSpace(req.Sender), maxInboxSenderLength),
+		truncateRunes(strings.TrimSpace(req.Subject), maxInboxSubjectLength),
+		parseReceipt(req.Body),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.inboxRepo.CreateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// List returns the user's pending items, newest first.
+func (uc *InboxUseCase) List(ctx context.Context, userID entities.UserID) ([]*entities.InboxItem, error) {
+	return uc.inboxRepo.ListItemsByUserID(ctx, userID)
+}
+
+type ConfirmInboxItemRequest struct {
+	ItemID       entities.InboxItemID
+	CollectionID entities.CollectionID
+	// ContainerID must be in the collection; nil files the objects in the
+	// collection's default container.
+	ContainerID *entities.ContainerID
+	// Lines are the lines to create, as corrected by the user; lines left
+	// out are dropped. Nil creates every line as received.
+	Lines     []entities.InboxLine
+	UserID    entities.UserID
+	UserToken string
+}
+
+// InboxLineError is a line that could not be turned into an object.
+type InboxLineError struct {
+	Line  entities.InboxLine
+	Error string
+}
+
+type ConfirmInboxItemResponse struct {
+	Created     []*entities.Object
+	ContainerID entities.ContainerID
+	// Failed lines stay in the inbox item to be tried again.
+	Failed []InboxLineError
+}
+
+// Confirm creates an object for each line of the item. A line's price is
+// stored in the collection's first currency property, if it has one. Once
+// every line is created the item leaves the inbox; lines that failed stay
+// in it. When no line can be created the first failure is returned, so an
+// access or quota error reads as such.
+func (uc *InboxUseCase) Confirm(ctx context.Context, req ConfirmInboxItemRequest) (*ConfirmInboxItemResponse, error) {
+	item, err := uc.getOwnedItem(ctx, req.ItemID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := req.Lines
+	if lines == nil {
+		lines = item.Lines()
+	}
+	if len(lines) == 0 {
+		return nil, entities.ErrInboxItemEmpty
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+	if req.ContainerID != nil {
+		container, err := uc.containerRepo.GetByID(ctx, *req.ContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("container not found: %w", err)
+		}
+		if !container.CollectionID().Equals(req.CollectionID) {
+			return nil, errors.New("container not found in the collection")
+		}
+	}
+	priceKey, _ := valueProperty(collection.PropertySchema().ForObjectType(collection.ObjectType()), nil)
+
+	resp := &ConfirmInboxItemResponse{}
+	var firstErr error
+	for _, line := range lines {
+		create := CreateObjectRequest{
+			ContainerID: req.ContainerID,
+			Name:        line.Name,
+			Quantity:    line.Quantity,
+			Unit:        line.Unit,
+			UserID:      req.UserID,
+			UserToken:   req.UserToken,
+		}
+		if req.ContainerID == nil {
+			create.CollectionID = &req.CollectionID
+		}
+		if priceKey != "" && line.Price != nil {
+			create.RawProperties = map[string]any{priceKey: *line.Price}
+		}
+
+		created, err := uc.createUC.Execute(ctx, create)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			resp.Failed = append(resp.Failed, InboxLineError{Line: line, Error: err.Error()})
+			continue
+		}
+		resp.Created = append(resp.Created, created.Object)
+		resp.ContainerID = created.ContainerID
+	}
+
+	if len(resp.Created) == 0 {
+		return nil, firstErr
+	}
+
+	if len(resp.Failed) == 0 {
+		if err := uc.inboxRepo.DeleteItem(ctx, item.ID()); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	remaining := make([]entities.InboxLine, len(resp.Failed))
+	for i, failed := range resp.Failed {
+		remaining[i] = failed.Line
+	}
+	if err := item.ReplaceLines(remaining); err != nil {
+		return nil, err
+	}
+	if err := uc.inboxRepo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Dismiss removes the item from the inbox without creating anything.
+func (uc *InboxUseCase) Dismiss(ctx context.Context, itemID entities.InboxItemID, userID entities.UserID) error {
+	if _, err := uc.getOwnedItem(ctx, itemID, userID); err != nil {
+		return err
+	}
+	return uc.inboxRepo.DeleteItem(ctx, itemID)
+}
+
+func (uc *InboxUseCase) getOwnedItem(ctx context.Context, itemID entities.InboxItemID, userID entities.UserID) (*entities.InboxItem, error) {
+	item, err := uc.inboxRepo.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !item.IsOwnedBy(userID) {
+		return nil, entities.ErrInboxItemNotFound
+	}
+	return item, nil
+}
+
+// truncateRunes cuts s to at most n runes
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
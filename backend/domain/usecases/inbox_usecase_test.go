@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestInboxUseCase_Receive(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*mocks.MockInboxRepository, *InboxUseCase) {
+		mockCtrl := gomock.NewController(t)
+		inboxRepo := mocks.NewMockInboxRepository(mockCtrl)
+		uc := NewInboxUseCase(inboxRepo, mocks.NewMockContainerRepository(mockCtrl), mocks.NewMockCollectionRepository(mockCtrl), mocks.NewMockObjectCodeRepository(mockCtrl), mocks.NewMockGroupQuotaRepository(mockCtrl), mocks.NewMockUsageRepository(mockCtrl), mocks.NewMockExpiryHistoryRepository(mockCtrl), mocks.NewMockAuthService(mockCtrl), nil)
+		return inboxRepo, uc
+	}
+	userID := entities.NewUserID()
+	address := entities.ReconstructInboxAddress(userID, "3f9a2c", time.Now())
+	req := ReceiveInboxEmailRequest{
+		Recipients:  `"Nishiki" <Inbox+3F9A2C@Example.com>`,
+		BaseAddress: "inbox@example.com",
+		Sender:      "shop@example.com",
+		Subject:     "Your order",
+		Body:        "2 x Whole Milk  $3.49\nTotal $3.49\n",
+	}
+
+	t.Run("success - files the email in the user's inbox", func(t *testing.T) {
+		inboxRepo, uc := setup(t)
+		inboxRepo.EXPECT().GetAddressByToken(gomock.Any(), "3f9a2c").Return(address, nil)
+		inboxRepo.EXPECT().CountItemsByUserID(gomock.Any(), userID).Return(int64(3), nil)
+		inboxRepo.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Return(nil)
+
+		item, err := uc.Receive(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.True(t, item.IsOwnedBy(userID))
+		assert.Equal(t, "Your order", item.Subject())
+		require.Len(t, item.Lines(), 1)
+		assert.Equal(t, "Whole Milk", item.Lines()[0].Name)
+	})
+
+	t.Run("error - mail to another address", func(t *testing.T) {
+		_, uc := setup(t)
+		other := req
+		other.Recipients = "someone@example.com"
+
+		_, err := uc.Receive(context.Background(), other)
+
+		assert.ErrorIs(t, err, entities.ErrInboxAddressNotFound)
+	})
+
+	t.Run("error - inbox full", func(t *testing.T) {
+		inboxRepo, uc := setup(t)
+		inboxRepo.EXPECT().GetAddressByToken(gomock.Any(), "3f9a2c").Return(address, nil)
+		inboxRepo.EXPECT().CountItemsByUserID(gomock.Any(), userID).Return(int64(entities.MaxPendingInboxItems), nil)
+
+		_, err := uc.Receive(context.Background(), req)
+
+		assert.ErrorIs(t, err, entities.ErrInboxFull)
+	})
+
+	t.Run("error - nothing recognised", func(t *testing.T) {
+		inboxRepo, uc := setup(t)
+		inboxRepo.EXPECT().GetAddressByToken(gomock.Any(), "3f9a2c").Return(address, nil)
+		inboxRepo.EXPECT().CountItemsByUserID(gomock.Any(), userID).Return(int64(0), nil)
+		empty := req
+		empty.Body = "Your package has shipped."
+
+		_, err := uc.Receive(context.Background(), empty)
+
+		assert.ErrorIs(t, err, entities.ErrInboxItemEmpty)
+	})
+}
+
+func TestInboxUseCase_Confirm(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		inboxRepo      *mocks.MockInboxRepository
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		authService    *mocks.MockAuthService
+		useCase        *InboxUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			inboxRepo:      mocks.NewMockInboxRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewInboxUseCase(f.inboxRepo, f.containerRepo, f.collectionRepo, mocks.NewMockObjectCodeRepository(mockCtrl), mocks.NewMockGroupQuotaRepository(mockCtrl), mocks.NewMockUsageRepository(mockCtrl), mocks.NewMockExpiryHistoryRepository(mockCtrl), f.authService, nil)
+		return f
+	}
+	price := 3.49
+	two := 2.0
+	userID := entities.NewUserID()
+	collection := NewTestCollection(ColUserID(userID), ColSchema(&entities.PropertySchema{Definitions: []entities.PropertyDefinition{
+		{Key: "price", DisplayName: "Price", Type: entities.PropertyTypeCurrency, CurrencyCode: "USD"},
+	}}))
+	container := NewTestContainer(CtrCollectionID(collection.ID()))
+	containerID := container.ID()
+	newItem := func() *entities.InboxItem {
+		item, _ := entities.NewInboxItem(userID, "shop@example.com", "Your order", []entities.InboxLine{
+			{Name: "Whole Milk", Quantity: &two, Price: &price},
+			{Name: "Bread"},
+		})
+		return item
+	}
+	// Each line looks up the container and collection and checks access;
+	// Confirm looks both up once more before the first line
+	expectLines := func(f fixture, lines int) {
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil).Times(lines + 1)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil).Times(lines + 1)
+		f.authService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return(nil, nil).Times(lines)
+	}
+	expectAdds := func(f fixture, n int) *[]entities.Object {
+		var added []entities.Object
+		f.containerRepo.EXPECT().AddObject(gomock.Any(), containerID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entities.ContainerID, object entities.Object) error {
+				added = append(added, object)
+				return nil
+			}).Times(n)
+		return &added
+	}
+
+	t.Run("success - creates every line and clears the item", func(t *testing.T) {
+		f := setup(t)
+		item := newItem()
+		f.inboxRepo.EXPECT().GetItemByID(gomock.Any(), item.ID()).Return(item, nil)
+		expectLines(f, 2)
+		added := expectAdds(f, 2)
+		f.inboxRepo.EXPECT().DeleteItem(gomock.Any(), item.ID()).Return(nil)
+
+		resp, err := f.useCase.Confirm(context.Background(), ConfirmInboxItemRequest{
+			ItemID:       item.ID(),
+			CollectionID: collection.ID(),
+			ContainerID:  &containerID,
+			UserID:       userID,
+			UserToken:    "token",
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Created, 2)
+		assert.Empty(t, resp.Failed)
+		require.Len(t, *added, 2)
+		milk, bread := (*added)[0], (*added)[1]
+		assert.Equal(t, "Whole Milk", milk.Name().String())
+		assert.Equal(t, 2.0, *milk.Quantity())
+		assert.Equal(t, 3.49, milk.Properties()["price"].Val)
+		assert.Equal(t, "USD", milk.Properties()["price"].Currency)
+		assert.Empty(t, bread.Properties())
+	})
+
+	t.Run("partial - failed lines stay in the inbox", func(t *testing.T) {
+		f := setup(t)
+		item := newItem()
+		f.inboxRepo.EXPECT().GetItemByID(gomock.Any(), item.ID()).Return(item, nil)
+		expectLines(f, 2)
+		expectAdds(f, 1)
+		f.inboxRepo.EXPECT().UpdateItem(gomock.Any(), item).Return(nil)
+
+		resp, err := f.useCase.Confirm(context.Background(), ConfirmInboxItemRequest{
+			ItemID:       item.ID(),
+			CollectionID: collection.ID(),
+			ContainerID:  &containerID,
+			Lines:        []entities.InboxLine{{Name: "Milk"}, {Name: ""}},
+			UserID:       userID,
+			UserToken:    "token",
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Created, 1)
+		require.Len(t, resp.Failed, 1)
+		assert.Equal(t, []entities.InboxLine{{Name: ""}}, item.Lines())
+	})
+
+	t.Run("error - another user's item", func(t *testing.T) {
+		f := setup(t)
+		item := newItem()
+		f.inboxRepo.EXPECT().GetItemByID(gomock.Any(), item.ID()).Return(item, nil)
+
+		_, err := f.useCase.Confirm(context.Background(), ConfirmInboxItemRequest{
+			ItemID:       item.ID(),
+			CollectionID: collection.ID(),
+			UserID:       entities.NewUserID(),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrInboxItemNotFound)
+	})
+
+	t.Run("error - container from another collection", func(t *testing.T) {
+		f := setup(t)
+		item := newItem()
+		other := NewTestContainer()
+		otherID := other.ID()
+		f.inboxRepo.EXPECT().GetItemByID(gomock.Any(), item.ID()).Return(item, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), otherID).Return(other, nil)
+
+		_, err := f.useCase.Confirm(context.Background(), ConfirmInboxItemRequest{
+			ItemID:       item.ID(),
+			CollectionID: collection.ID(),
+			ContainerID:  &otherID,
+			UserID:       userID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
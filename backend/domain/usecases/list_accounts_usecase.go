@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// AccountOverview is one user on the admin dashboard
+type AccountOverview struct {
+	Status *entities.AccountStatus
+	Usage  entities.UserUsage
+}
+
+type ListAccountsResponse struct {
+	Accounts []AccountOverview
+}
+
+// ListAccountsUseCase lists every user with what they store, for admins.
+// Callers check the admin role.
+type ListAccountsUseCase struct {
+	accountStatusRepo repositories.AccountStatusRepository
+	usageRepo         repositories.UsageRepository
+}
+
+func NewListAccountsUseCase(accountStatusRepo repositories.AccountStatusRepository, usageRepo repositories.UsageRepository) *ListAccountsUseCase {
+	return &ListAccountsUseCase{
+		accountStatusRepo: accountStatusRepo,
+		usageRepo:         usageRepo,
+	}
+}
+
+func (uc *ListAccountsUseCase) Execute(ctx context.Context) (*ListAccountsResponse, error) {
+	accounts, err := listAccountOverviews(ctx, uc.accountStatusRepo, uc.usageRepo)
+	if err != nil {
+		return nil, err
+	}
+	return &ListAccountsResponse{Accounts: accounts}, nil
+}
+
+// listAccountOverviews joins account statuses with usage, most recently seen
+// first. Users who own data but have not signed in since accounts were
+// tracked are listed last with only their ID.
+func listAccountOverviews(ctx context.Context, accountStatusRepo repositories.AccountStatusRepository, usageRepo repositories.UsageRepository) ([]AccountOverview, error) {
+	statuses, err := accountStatusRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	usage, err := usageRepo.UsageByUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	accounts := make([]AccountOverview, 0, len(statuses))
+	for _, status := range statuses {
+		id := status.UserID().String()
+		u := usage[id]
+		u.UserID = status.UserID()
+		accounts = append(accounts, AccountOverview{Status: status, Usage: u})
+		delete(usage, id)
+	}
+
+	untracked := make([]AccountOverview, 0, len(usage))
+	for _, u := range usage {
+		status := entities.ReconstructAccountStatus(u.UserID, "", "", false, nil, time.Time{}, time.Time{})
+		untracked = append(untracked, AccountOverview{Status: status, Usage: u})
+	}
+	slices.SortFunc(untracked, func(a, b AccountOverview) int {
+		return cmp.Compare(a.Usage.UserID.String(), b.Usage.UserID.String())
+	})
+
+	return append(accounts, untracked...), nil
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ListCollectionFoldersRequest struct {
+	UserID entities.UserID
+}
+
+type ListCollectionFoldersResponse struct {
+	// Folders are ordered by name; clients build the tree from ParentID.
+	Folders []*entities.CollectionFolder
+}
+
+type ListCollectionFoldersUseCase struct {
+	folderRepo repositories.CollectionFolderRepository
+}
+
+func NewListCollectionFoldersUseCase(folderRepo repositories.CollectionFolderRepository) *ListCollectionFoldersUseCase {
+	return &ListCollectionFoldersUseCase{
+		folderRepo: folderRepo,
+	}
+}
+
+func (uc *ListCollectionFoldersUseCase) Execute(ctx context.Context, req ListCollectionFoldersRequest) (*ListCollectionFoldersResponse, error) {
+	folders, err := uc.folderRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection folders: %w", err)
+	}
+
+	return &ListCollectionFoldersResponse{
+		Folders: folders,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type ListCollectionSnapshotsRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type ListCollectionSnapshotsResponse struct {
+	// Snapshots are ordered newest first.
+	Snapshots []*entities.CollectionSnapshot
+}
+
+type ListCollectionSnapshotsUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	snapshotRepo   repositories.CollectionSnapshotRepository
+	authService    services.AuthService
+}
+
+func NewListCollectionSnapshotsUseCase(collectionRepo repositories.CollectionRepository, snapshotRepo repositories.CollectionSnapshotRepository, authService services.AuthService) *ListCollectionSnapshotsUseCase {
+	return &ListCollectionSnapshotsUseCase{
+		collectionRepo: collectionRepo,
+		snapshotRepo:   snapshotRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *ListCollectionSnapshotsUseCase) Execute(ctx context.Context, req ListCollectionSnapshotsRequest) (*ListCollectionSnapshotsResponse, error) {
+	if _, err := getManagedCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := uc.snapshotRepo.ListByCollectionID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	return &ListCollectionSnapshotsResponse{Snapshots: snapshots}, nil
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type ListCommentsRequest struct {
+	// CollectionID is ignored when ObjectID is set, as for CreateCommentRequest.
+	CollectionID entities.CollectionID
+	ObjectID     *entities.ObjectID
+	// Before pages back through older comments; zero starts at the newest.
+	Before    time.Time
+	Limit     int
+	UserID    entities.UserID
+	UserToken string
+}
+
+type ListCommentsResponse struct {
+	// Comments are ordered newest first.
+	Comments []*entities.Comment
+}
+
+type ListCommentsUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	commentRepo    repositories.CommentRepository
+	authService    services.AuthService
+}
+
+func NewListCommentsUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, commentRepo repositories.CommentRepository, authService services.AuthService) *ListCommentsUseCase {
+	return &ListCommentsUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		commentRepo:    commentRepo,
+		authService:    authService,
+	}
+}
+
+// Execute lists the comments on an object, or on a collection including
+// those on its objects.
+func (uc *ListCommentsUseCase) Execute(ctx context.Context, req ListCommentsRequest) (*ListCommentsResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = entities.DefaultCommentPageLimit
+	}
+	if limit < 0 || limit > entities.MaxCommentPageLimit {
+		return nil, entities.ErrInvalidCommentPage
+	}
+
+	collection, err := getCommentCollection(ctx, uc.collectionRepo, uc.containerRepo, uc.authService, req.CollectionID, req.ObjectID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := uc.commentRepo.List(ctx, collection.ID(), req.ObjectID, req.Before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return &ListCommentsResponse{Comments: comments}, nil
+}
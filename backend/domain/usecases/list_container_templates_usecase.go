@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ListContainerTemplatesRequest struct {
+	UserID entities.UserID
+}
+
+type ListContainerTemplatesResponse struct {
+	// Templates lists the built-in templates followed by the user's own.
+	Templates []*entities.ContainerTemplate
+}
+
+type ListContainerTemplatesUseCase struct {
+	templateRepo repositories.ContainerTemplateRepository
+}
+
+func NewListContainerTemplatesUseCase(templateRepo repositories.ContainerTemplateRepository) *ListContainerTemplatesUseCase {
+	return &ListContainerTemplatesUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+func (uc *ListContainerTemplatesUseCase) Execute(ctx context.Context, req ListContainerTemplatesRequest) (*ListContainerTemplatesResponse, error) {
+	userTemplates, err := uc.templateRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list container templates: %w", err)
+	}
+
+	return &ListContainerTemplatesResponse{
+		Templates: append(entities.BuiltInContainerTemplates(), userTemplates...),
+	}, nil
+}
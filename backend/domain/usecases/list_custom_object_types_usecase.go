@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ListCustomObjectTypesRequest struct {
+	UserID entities.UserID
+}
+
+type ListCustomObjectTypesResponse struct {
+	// ObjectTypes are the user's own types ordered by name; the built-in
+	// ones are entities.AllObjectTypes.
+	ObjectTypes []*entities.CustomObjectType
+}
+
+type ListCustomObjectTypesUseCase struct {
+	typeRepo repositories.CustomObjectTypeRepository
+}
+
+func NewListCustomObjectTypesUseCase(typeRepo repositories.CustomObjectTypeRepository) *ListCustomObjectTypesUseCase {
+	return &ListCustomObjectTypesUseCase{
+		typeRepo: typeRepo,
+	}
+}
+
+func (uc *ListCustomObjectTypesUseCase) Execute(ctx context.Context, req ListCustomObjectTypesRequest) (*ListCustomObjectTypesResponse, error) {
+	types, err := uc.typeRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom object types: %w", err)
+	}
+
+	return &ListCustomObjectTypesResponse{
+		ObjectTypes: types,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// maxMealPlanRangeDays bounds how many days one listing may cover.
+const maxMealPlanRangeDays = 92
+
+type ListMealPlansRequest struct {
+	// From and To select plans dated on or after From and before To.
+	From   time.Time
+	To     time.Time
+	UserID entities.UserID
+}
+
+type ListMealPlansResponse struct {
+	// MealPlans are ordered by date, then by meal within the day.
+	MealPlans []*entities.MealPlan
+}
+
+type ListMealPlansUseCase struct {
+	mealPlanRepo repositories.MealPlanRepository
+}
+
+func NewListMealPlansUseCase(mealPlanRepo repositories.MealPlanRepository) *ListMealPlansUseCase {
+	return &ListMealPlansUseCase{
+		mealPlanRepo: mealPlanRepo,
+	}
+}
+
+func (uc *ListMealPlansUseCase) Execute(ctx context.Context, req ListMealPlansRequest) (*ListMealPlansResponse, error) {
+	from := entities.MealPlanDay(req.From)
+	to := entities.MealPlanDay(req.To)
+	if !to.After(from) {
+		return nil, errors.New("to must be after from")
+	}
+	if to.Sub(from) > maxMealPlanRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("meal plans can be listed for at most %d days at a time", maxMealPlanRangeDays)
+	}
+
+	plans, err := uc.mealPlanRepo.ListByUserID(ctx, req.UserID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meal plans: %w", err)
+	}
+
+	slices.SortStableFunc(plans, func(a, b *entities.MealPlan) int {
+		if c := a.Date().Compare(b.Date()); c != 0 {
+			return c
+		}
+		return a.Meal().Rank() - b.Meal().Rank()
+	})
+
+	return &ListMealPlansResponse{
+		MealPlans: plans,
+	}, nil
+}
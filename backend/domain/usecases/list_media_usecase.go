@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type ListMediaRequest struct {
+	// CollectionID alone lists the collection's gallery: the photos of all
+	// its objects and containers. With OwnerKind set it must be the owner's
+	// collection, and may be nil.
+	CollectionID *entities.CollectionID
+	OwnerKind    entities.MediaOwnerKind
+	OwnerID      string
+	Page         entities.MediaPage
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type ListMediaResponse struct {
+	// Media are ordered newest first.
+	Media []*entities.Media
+	Total int64
+	Page  entities.MediaPage
+}
+
+type ListMediaUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	mediaRepo      repositories.MediaRepository
+	authService    services.AuthService
+}
+
+func NewListMediaUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, mediaRepo repositories.MediaRepository, authService services.AuthService) *ListMediaUseCase {
+	return &ListMediaUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		mediaRepo:      mediaRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *ListMediaUseCase) Execute(ctx context.Context, req ListMediaRequest) (*ListMediaResponse, error) {
+	var media []*entities.Media
+	var total int64
+
+	if req.OwnerKind == "" {
+		if req.CollectionID == nil {
+			return nil, entities.ErrInvalidMediaOwnerKind
+		}
+		if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, *req.CollectionID, req.UserID, req.UserToken); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if media, total, err = uc.mediaRepo.ListByCollectionID(ctx, *req.CollectionID, req.Page); err != nil {
+			return nil, fmt.Errorf("failed to list photos: %w", err)
+		}
+	} else {
+		collection, err := getMediaOwnerCollection(ctx, uc.containerRepo, uc.collectionRepo, uc.authService, req.OwnerKind, req.OwnerID, req.UserID, req.UserToken)
+		if err != nil {
+			return nil, err
+		}
+		if req.CollectionID != nil && !req.CollectionID.Equals(collection.ID()) {
+			return nil, entities.ErrMediaOwnerNotInCollection
+		}
+
+		if media, total, err = uc.mediaRepo.ListByOwner(ctx, req.OwnerKind, req.OwnerID, req.Page); err != nil {
+			return nil, fmt.Errorf("failed to list photos: %w", err)
+		}
+	}
+
+	return &ListMediaResponse{Media: media, Total: total, Page: req.Page}, nil
+}
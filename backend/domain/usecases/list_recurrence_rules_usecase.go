@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ListRecurrenceRulesRequest struct {
+	UserID entities.UserID
+	// ObjectID, when set, keeps only the object's rule.
+	ObjectID *entities.ObjectID
+	// DueOnly keeps only staples waiting to be bought: the shopping list.
+	DueOnly bool
+}
+
+type ListRecurrenceRulesResponse struct {
+	// Rules are ordered by next due date, soonest first.
+	Rules []*entities.RecurrenceRule
+}
+
+type ListRecurrenceRulesUseCase struct {
+	recurrenceRepo repositories.RecurrenceRuleRepository
+}
+
+func NewListRecurrenceRulesUseCase(recurrenceRepo repositories.RecurrenceRuleRepository) *ListRecurrenceRulesUseCase {
+	return &ListRecurrenceRulesUseCase{
+		recurrenceRepo: recurrenceRepo,
+	}
+}
+
+func (uc *ListRecurrenceRulesUseCase) Execute(ctx context.Context, req ListRecurrenceRulesRequest) (*ListRecurrenceRulesResponse, error) {
+	rules, err := uc.recurrenceRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrence rules: %w", err)
+	}
+
+	filtered := rules[:0]
+	for _, rule := range rules {
+		if req.ObjectID != nil && !rule.ObjectID().Equals(*req.ObjectID) {
+			continue
+		}
+		if req.DueOnly && !rule.IsDue() {
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+
+	return &ListRecurrenceRulesResponse{
+		Rules: filtered,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type ListSessionsRequest struct {
+	UserID entities.UserID
+}
+
+type ListSessionsResponse struct {
+	Sessions []*entities.Session
+}
+
+// ListSessionsUseCase lists the devices a user is signed in on
+type ListSessionsUseCase struct {
+	sessionRepo repositories.SessionRepository
+}
+
+func NewListSessionsUseCase(sessionRepo repositories.SessionRepository) *ListSessionsUseCase {
+	return &ListSessionsUseCase{sessionRepo: sessionRepo}
+}
+
+// Execute returns the user's active sessions, most recently seen first.
+// Revoked sessions and those whose tokens have expired are left out.
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, req ListSessionsRequest) (*ListSessionsResponse, error) {
+	sessions, err := uc.sessionRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	active := make([]*entities.Session, 0, len(sessions))
+	for _, s := range sessions {
+		if s.IsActive(now) {
+			active = append(active, s)
+		}
+	}
+	return &ListSessionsResponse{Sessions: active}, nil
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type LookupObjectCodeRequest struct {
+	Code      string // barcode, ISBN or SKU as scanned or typed
+	UserID    entities.UserID
+	UserToken string
+}
+
+// ObjectCodeMatch is an object already carrying the looked-up code, with
+// where it lives so the caller can offer to increase its quantity.
+type ObjectCodeMatch struct {
+	Object       entities.Object
+	ContainerID  entities.ContainerID
+	CollectionID entities.CollectionID
+}
+
+type LookupObjectCodeResponse struct {
+	Code    string // the normalized code
+	Objects []ObjectCodeMatch
+}
+
+type LookupObjectCodeUseCase struct {
+	codeRepo       repositories.ObjectCodeRepository
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewLookupObjectCodeUseCase(codeRepo repositories.ObjectCodeRepository, containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *LookupObjectCodeUseCase {
+	return &LookupObjectCodeUseCase{
+		codeRepo:       codeRepo,
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+// Execute returns the account's active objects carrying the code. Each index
+// hit is checked against the object itself: entries for objects that were
+// deleted or no longer carry the code are dropped from the index, and
+// objects in collections the user can no longer reach are left out.
+func (uc *LookupObjectCodeUseCase) Execute(ctx context.Context, req LookupObjectCodeRequest) (*LookupObjectCodeResponse, error) {
+	code, err := entities.NormalizeObjectCode(req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := uc.codeRepo.Lookup(ctx, req.UserID, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up code: %w", err)
+	}
+
+	resp := &LookupObjectCodeResponse{Code: code, Objects: []ObjectCodeMatch{}}
+	accessible := make(map[entities.CollectionID]bool)
+	for _, id := range ids {
+		container, err := uc.containerRepo.FindByObjectID(ctx, id)
+		if err != nil {
+			if !strings.Contains(err.Error(), "not found") {
+				return nil, fmt.Errorf("failed to find object: %w", err)
+			}
+			_ = uc.codeRepo.Remove(ctx, req.UserID, code, id)
+			continue
+		}
+		obj, err := container.GetObject(id)
+		if err != nil || !slices.Contains(obj.Codes(), code) {
+			_ = uc.codeRepo.Remove(ctx, req.UserID, code, id)
+			continue
+		}
+		if obj.IsArchived() {
+			continue
+		}
+
+		collectionID := container.CollectionID()
+		ok, seen := accessible[collectionID]
+		if !seen {
+			_, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, collectionID, req.UserID, req.UserToken)
+			ok = err == nil
+			accessible[collectionID] = ok
+		}
+		if !ok {
+			continue
+		}
+
+		resp.Objects = append(resp.Objects, ObjectCodeMatch{
+			Object:       *obj,
+			ContainerID:  container.ID(),
+			CollectionID: collectionID,
+		})
+	}
+
+	return resp, nil
+}
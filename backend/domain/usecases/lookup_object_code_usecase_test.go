@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestLookupObjectCodeUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		codeRepo       *mocks.MockObjectCodeRepository
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		authService    *mocks.MockAuthService
+		useCase        *LookupObjectCodeUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			codeRepo:       mocks.NewMockObjectCodeRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewLookupObjectCodeUseCase(f.codeRepo, f.containerRepo, f.collectionRepo, f.authService)
+		return f
+	}
+
+	t.Run("success - returns objects carrying the normalized code", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject(ObjProps(Props("upc", "0-12345-67890-5")))
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+
+		f.codeRepo.EXPECT().Lookup(gomock.Any(), userID, "012345678905").Return([]entities.ObjectID{object.ID()}, nil)
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), LookupObjectCodeRequest{Code: "0 12345 67890 5", UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, "012345678905", resp.Code)
+		require.Len(t, resp.Objects, 1)
+		assert.Equal(t, object.ID(), resp.Objects[0].Object.ID())
+		assert.Equal(t, container.ID(), resp.Objects[0].ContainerID)
+		assert.Equal(t, collection.ID(), resp.Objects[0].CollectionID)
+	})
+
+	t.Run("success - stale entries are dropped from the index", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		deletedID := entities.NewObjectID()
+		relabeled := NewTestObject(ObjProps(Props("sku", "OTHER-1")))
+		container := NewTestContainer(CtrObjects(*relabeled))
+
+		f.codeRepo.EXPECT().Lookup(gomock.Any(), userID, "ABC123").Return([]entities.ObjectID{deletedID, relabeled.ID()}, nil)
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), deletedID).Return(nil, errors.New("container not found"))
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), relabeled.ID()).Return(container, nil)
+		f.codeRepo.EXPECT().Remove(gomock.Any(), userID, "ABC123", deletedID).Return(nil)
+		f.codeRepo.EXPECT().Remove(gomock.Any(), userID, "ABC123", relabeled.ID()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), LookupObjectCodeRequest{Code: "abc-123", UserID: userID})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Objects)
+	})
+
+	t.Run("success - skips archived objects and unreachable collections", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		archived := NewTestObject(ObjProps(Props("isbn", "0306406152")), ObjArchivedAt(time.Now()))
+		shared := NewTestObject(ObjProps(Props("isbn", "0306406152")))
+		other := NewTestCollection(ColUserID(entities.NewUserID()))
+		archivedIn := NewTestContainer(CtrObjects(*archived))
+		sharedIn := NewTestContainer(CtrCollectionID(other.ID()), CtrObjects(*shared))
+
+		f.codeRepo.EXPECT().Lookup(gomock.Any(), userID, "0306406152").Return([]entities.ObjectID{archived.ID(), shared.ID()}, nil)
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), archived.ID()).Return(archivedIn, nil)
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), shared.ID()).Return(sharedIn, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), other.ID()).Return(other, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), LookupObjectCodeRequest{Code: "0306406152", UserID: userID})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Objects)
+	})
+
+	t.Run("error - invalid code", func(t *testing.T) {
+		f := setup(t)
+
+		_, err := f.useCase.Execute(context.Background(), LookupObjectCodeRequest{Code: "12", UserID: entities.NewUserID()})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidObjectCode)
+	})
+
+	t.Run("error - index failure is wrapped", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.codeRepo.EXPECT().Lookup(gomock.Any(), userID, "ABCD").Return(nil, errors.New("timeout"))
+
+		_, err := f.useCase.Execute(context.Background(), LookupObjectCodeRequest{Code: "abcd", UserID: userID})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to look up code")
+	})
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type MarkCommentsReadRequest struct {
+	CollectionID entities.CollectionID
+	// ReadAt is the time of the newest comment the user has seen; zero
+	// means now. Passing the time the listing was loaded keeps comments
+	// posted since unread.
+	ReadAt    time.Time
+	UserID    entities.UserID
+	UserToken string
+}
+
+type MarkCommentsReadUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	commentRepo    repositories.CommentRepository
+	authService    services.AuthService
+}
+
+func NewMarkCommentsReadUseCase(collectionRepo repositories.CollectionRepository, commentRepo repositories.CommentRepository, authService services.AuthService) *MarkCommentsReadUseCase {
+	return &MarkCommentsReadUseCase{
+		collectionRepo: collectionRepo,
+		commentRepo:    commentRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *MarkCommentsReadUseCase) Execute(ctx context.Context, req MarkCommentsReadRequest) error {
+	if _, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken); err != nil {
+		return err
+	}
+
+	readAt := req.ReadAt
+	if readAt.IsZero() || readAt.After(time.Now()) {
+		readAt = time.Now()
+	}
+
+	if err := uc.commentRepo.MarkRead(ctx, req.UserID, req.CollectionID, readAt); err != nil {
+		return fmt.Errorf("failed to mark comments read: %w", err)
+	}
+	return nil
+}
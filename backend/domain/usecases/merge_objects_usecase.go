@@ -0,0 +1,271 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type MergeObjectsRequest struct {
+	ObjectIDs []entities.ObjectID
+	// Preview computes the merged object without saving anything.
+	Preview   bool
+	UserID    entities.UserID
+	UserToken string
+}
+
+type MergeObjectsResponse struct {
+	// Object is the surviving object with the others folded into it.
+	Object      *entities.Object
+	ContainerID entities.ContainerID
+	// MergedIDs are the objects that were (or, in a preview, would be) removed.
+	MergedIDs []entities.ObjectID
+}
+
+type MergeObjectsUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	codeRepo       repositories.ObjectCodeRepository
+	authService    services.AuthService
+}
+
+func NewMergeObjectsUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, codeRepo repositories.ObjectCodeRepository, authService services.AuthService) *MergeObjectsUseCase {
+	return &MergeObjectsUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		codeRepo:       codeRepo,
+		authService:    authService,
+	}
+}
+
+// Execute folds two or more objects of one collection into the oldest of
+// them: quantities are added (converted to the survivor's unit), tags are
+// unioned, and properties, description, image and expiry missing on the
+// survivor are taken from the others.
+func (uc *MergeObjectsUseCase) Execute(ctx context.Context, req MergeObjectsRequest) (*MergeObjectsResponse, error) {
+	ids := make([]entities.ObjectID, 0, len(req.ObjectIDs))
+	for _, id := range req.ObjectIDs {
+		if !slices.ContainsFunc(ids, id.Equals) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return nil, errors.New("at least two distinct objects are required to merge")
+	}
+
+	// Objects may live in different containers; load each container once so
+	// edits to objects sharing a container land on the same entity
+	containers := make(map[string]*entities.Container)
+	objectContainer := make(map[string]*entities.Container, len(ids))
+	objects := make([]entities.Object, 0, len(ids))
+	for _, id := range ids {
+		found, err := uc.containerRepo.FindByObjectID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("object %s not found: %w", id, err)
+		}
+		container, ok := containers[found.ID().String()]
+		if !ok {
+			container = found
+			containers[found.ID().String()] = container
+		}
+		object, err := container.GetObject(id)
+		if err != nil {
+			return nil, fmt.Errorf("object %s not found in container: %w", id, err)
+		}
+		if object.IsArchived() {
+			return nil, fmt.Errorf("archived objects cannot be merged: %s", object.Name())
+		}
+		objectContainer[id.String()] = container
+		objects = append(objects, *object)
+	}
+
+	collectionID := objectContainer[ids[0].String()].CollectionID()
+	for _, container := range containers {
+		if !container.CollectionID().Equals(collectionID) {
+			return nil, errors.New("objects must belong to the same collection")
+		}
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	// The oldest object survives so its created_at is preserved
+	slices.SortStableFunc(objects, func(a, b entities.Object) int { return a.CreatedAt().Compare(b.CreatedAt()) })
+	survivor := objects[0]
+	others := objects[1:]
+	survivorCodes := survivor.Codes()
+	if err := mergeObjectsInto(&survivor, others); err != nil {
+		return nil, err
+	}
+
+	survivorContainer := objectContainer[survivor.ID().String()]
+	mergedIDs := make([]entities.ObjectID, len(others))
+	for i, other := range others {
+		mergedIDs[i] = other.ID()
+	}
+
+	resp := &MergeObjectsResponse{
+		Object:      &survivor,
+		ContainerID: survivorContainer.ID(),
+		MergedIDs:   mergedIDs,
+	}
+	if req.Preview {
+		return resp, nil
+	}
+
+	if err := survivorContainer.UpdateObject(survivor.ID(), survivor); err != nil {
+		return nil, fmt.Errorf("failed to update object in container: %w", err)
+	}
+	dirty := map[string]*entities.Container{survivorContainer.ID().String(): survivorContainer}
+	for _, other := range others {
+		container := objectContainer[other.ID().String()]
+		if err := container.RemoveObject(other.ID()); err != nil {
+			return nil, fmt.Errorf("failed to remove merged object: %w", err)
+		}
+		dirty[container.ID().String()] = container
+	}
+
+	// Save the survivor's container first so a failure part way through
+	// leaves duplicates rather than losing quantity
+	if err := uc.containerRepo.Update(ctx, survivorContainer); err != nil {
+		return nil, fmt.Errorf("failed to save container: %w", err)
+	}
+	delete(dirty, survivorContainer.ID().String())
+	for _, key := range slices.Sorted(maps.Keys(dirty)) {
+		if err := uc.containerRepo.Update(ctx, dirty[key]); err != nil {
+			return nil, fmt.Errorf("failed to save container: %w", err)
+		}
+	}
+
+	// The survivor may have picked up codes from the others, whose own
+	// entries now point at removed objects
+	_ = syncObjectCodes(ctx, uc.codeRepo, req.UserID, survivor.ID(), survivorCodes, survivor.Codes())
+	for _, other := range others {
+		_ = syncObjectCodes(ctx, uc.codeRepo, req.UserID, other.ID(), other.Codes(), nil)
+	}
+
+	return resp, nil
+}
+
+// mergeObjectsInto folds others into survivor. Values already set on the
+// survivor win; others only fill gaps, except quantities (summed), tags
+// (unioned) and expiry (earliest, so nothing is kept past its date).
+func mergeObjectsInto(survivor *entities.Object, others []entities.Object) error {
+	unit := survivor.Unit()
+	for _, other := range others {
+		if unit == "" && other.Quantity() != nil {
+			unit = other.Unit()
+		}
+	}
+
+	var total *float64
+	for _, object := range append([]entities.Object{*survivor}, others...) {
+		if object.Quantity() == nil {
+			continue
+		}
+		amount := *object.Quantity()
+		if object.Unit() != "" && unit != "" && !services.SameUnit(object.Unit(), unit) {
+			converted, err := services.ConvertQuantity(amount, object.Unit(), unit)
+			if err != nil {
+				return fmt.Errorf("cannot merge quantities of %s: %w", object.Name(), err)
+			}
+			amount = converted
+		}
+		sum := amount
+		if total != nil {
+			sum += *total
+		}
+		total = &sum
+	}
+
+	tags := slices.Clone(survivor.Tags())
+	properties := maps.Clone(survivor.Properties())
+	if properties == nil {
+		properties = make(map[string]entities.TypedValue)
+	}
+	description := survivor.Description()
+	imageURL := survivor.ImageURL()
+	minQuantity := survivor.MinQuantity()
+	condition := survivor.Condition()
+	expiresAt := survivor.ExpiresAt()
+
+	for _, other := range others {
+		for _, tag := range other.Tags() {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		for key, value := range other.Properties() {
+			if _, ok := properties[key]; !ok {
+				properties[key] = value
+			}
+		}
+		if description.String() == "" {
+			description = other.Description()
+		}
+		if imageURL == "" {
+			imageURL = other.ImageURL()
+		}
+		if minQuantity == nil {
+			minQuantity = other.MinQuantity()
+		}
+		if condition == entities.ConditionNone && survivor.ObjectType().Graded() {
+			condition = other.Condition()
+		}
+		if other.ExpiresAt() != nil && (expiresAt == nil || other.ExpiresAt().Before(*expiresAt)) {
+			expiresAt = other.ExpiresAt()
+		}
+	}
+
+	if err := survivor.UpdateQuantity(total); err != nil {
+		return err
+	}
+	if err := survivor.UpdateUnit(unit); err != nil {
+		return err
+	}
+	if err := survivor.UpdateTags(tags); err != nil {
+		return err
+	}
+	if err := survivor.UpdateProperties(properties); err != nil {
+		return err
+	}
+	if err := survivor.UpdateDescription(description); err != nil {
+		return err
+	}
+	if err := survivor.UpdateImageURL(imageURL); err != nil {
+		return err
+	}
+	if err := survivor.UpdateMinQuantity(minQuantity); err != nil {
+		return err
+	}
+	if err := survivor.UpdateCondition(condition); err != nil {
+		return err
+	}
+	return survivor.UpdateExpiresAt(expiresAt)
+}
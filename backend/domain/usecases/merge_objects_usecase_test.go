@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestMergeObjectsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewMergeObjectsUseCase(mockContainerRepo, mockCollectionRepo, mockCodeRepo, mockAuthService)
+
+	t.Run("success - merges into the oldest object across containers", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		oldest := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		soon := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		newer := NewTestObject(ObjName("Rice"), ObjQuantity(500), ObjUnit("g"), ObjTags("grain", "bulk"),
+			ObjProps(Props("brand", "Tilda", "origin", "India")), ObjExpiresAt(soon), ObjCreatedAt(oldest.AddDate(0, 3, 0)))
+		older := NewTestObject(ObjName("rice"), ObjQuantity(1), ObjUnit("kg"), ObjTags("grain"),
+			ObjProps(Props("brand", "Uncle Ben's")), ObjCreatedAt(oldest))
+		pantry := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*newer))
+		shelf := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*older))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), newer.ID()).Return(pantry, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), older.ID()).Return(shelf, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		gomock.InOrder(
+			mockContainerRepo.EXPECT().Update(gomock.Any(), shelf).Return(nil),
+			mockContainerRepo.EXPECT().Update(gomock.Any(), pantry).Return(nil),
+		)
+
+		resp, err := useCase.Execute(context.Background(), MergeObjectsRequest{
+			ObjectIDs: []entities.ObjectID{newer.ID(), older.ID(), newer.ID()},
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, older.ID(), resp.Object.ID())
+		assert.Equal(t, shelf.ID(), resp.ContainerID)
+		assert.Equal(t, []entities.ObjectID{newer.ID()}, resp.MergedIDs)
+		assert.Equal(t, oldest, resp.Object.CreatedAt())
+		assert.InDelta(t, 1.5, *resp.Object.Quantity(), 1e-9, "grams are converted to the survivor's kg")
+		assert.Equal(t, "kg", resp.Object.Unit())
+		assert.Equal(t, []string{"grain", "bulk"}, resp.Object.Tags())
+		assert.Equal(t, Props("brand", "Uncle Ben's", "origin", "India"), resp.Object.Properties())
+		assert.Equal(t, soon, *resp.Object.ExpiresAt())
+		assert.Empty(t, pantry.Objects())
+		assert.Len(t, shelf.Objects(), 1)
+	})
+
+	t.Run("success - moves merged codes to the survivor", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		older := NewTestObject(ObjCreatedAt(time.Now().Add(-time.Hour)))
+		newer := NewTestObject(ObjProps(Props("upc", "0123456789012")), ObjCreatedAt(time.Now()))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*older, *newer))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), older.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), newer.ID()).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+		mockCodeRepo.EXPECT().Add(gomock.Any(), userID, "0123456789012", older.ID()).Return(nil)
+		mockCodeRepo.EXPECT().Remove(gomock.Any(), userID, "0123456789012", newer.ID()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), MergeObjectsRequest{
+			ObjectIDs: []entities.ObjectID{older.ID(), newer.ID()},
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0123456789012"}, resp.Object.Codes())
+	})
+
+	t.Run("success - preview does not save", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		a := NewTestObject(ObjQuantity(2))
+		b := NewTestObject(ObjQuantity(3))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*a, *b))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), a.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), b.ID()).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), MergeObjectsRequest{
+			ObjectIDs: []entities.ObjectID{a.ID(), b.ID()},
+			Preview:   true,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 5.0, *resp.Object.Quantity(), 1e-9)
+		assert.Len(t, container.Objects(), 2)
+	})
+
+	t.Run("error - objects in different collections", func(t *testing.T) {
+		a := NewTestObject()
+		b := NewTestObject()
+		first := NewTestContainer(CtrObjects(*a))
+		second := NewTestContainer(CtrObjects(*b))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), a.ID()).Return(first, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), b.ID()).Return(second, nil)
+
+		resp, err := useCase.Execute(context.Background(), MergeObjectsRequest{
+			ObjectIDs: []entities.ObjectID{a.ID(), b.ID()},
+			UserID:    entities.NewUserID(),
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "same collection")
+	})
+
+	t.Run("error - incompatible units", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		a := NewTestObject(ObjQuantity(1), ObjUnit("kg"))
+		b := NewTestObject(ObjQuantity(2), ObjUnit("l"))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*a, *b))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), a.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), b.ID()).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), MergeObjectsRequest{
+			ObjectIDs: []entities.ObjectID{a.ID(), b.ID()},
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "cannot merge quantities")
+	})
+
+	t.Run("error - single object", func(t *testing.T) {
+		id := entities.NewObjectID()
+
+		resp, err := useCase.Execute(context.Background(), MergeObjectsRequest{
+			ObjectIDs: []entities.ObjectID{id, id},
+			UserID:    entities.NewUserID(),
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "at least two")
+	})
+}
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// OAuthClientUseCase manages the OAuth clients admins register at runtime.
+// Every change is applied to the auth service first and only stored once it
+// took, so the stored clients are always ones the backend could load.
+type OAuthClientUseCase struct {
+	clientRepo repositories.OAuthClientRepository
+	registry   services.OAuthClientRegistry
+	mu         sync.Mutex
+}
+
+// NewOAuthClientUseCase takes the registry from an auth service that
+// implements services.OAuthClientRegistry; with nil, changes are refused
+// with entities.ErrOAuthClientsStatic.
+func NewOAuthClientUseCase(clientRepo repositories.OAuthClientRepository, registry services.OAuthClientRegistry) *OAuthClientUseCase {
+	return &OAuthClientUseCase{clientRepo: clientRepo, registry: registry}
+}
+
+func (uc *OAuthClientUseCase) List(ctx context.Context) ([]*entities.OAuthClient, error) {
+	clients, err := uc.clientRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+	return clients, nil
+}
+
+// Load hands the stored clients to the auth service, at startup and then
+// periodically, since changes made here only reach this instance.
+func (uc *OAuthClientUseCase) Load(ctx context.Context) error {
+	if uc.registry == nil {
+		return nil
+	}
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	clients, err := uc.List(ctx)
+	if err != nil {
+		return err
+	}
+	if err := uc.registry.ReloadClients(ctx, clients); err != nil {
+		return fmt.Errorf("failed to load oauth clients: %w", err)
+	}
+	return nil
+}
+
+type RegisterOAuthClientRequest struct {
+	ProviderName string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+func (uc *OAuthClientUseCase) Register(ctx context.Context, req RegisterOAuthClientRequest) (*entities.OAuthClient, error) {
+	if uc.registry == nil {
+		return nil, entities.ErrOAuthClientsStatic
+	}
+	client, err := entities.NewOAuthClient(entities.OAuthClientProps{
+		ProviderName: req.ProviderName,
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		RedirectURL:  req.RedirectURL,
+	}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	clients, err := uc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(clients, func(c *entities.OAuthClient) bool { return c.ClientID() == client.ClientID() }) {
+		return nil, entities.ErrOAuthClientExists
+	}
+	if err := uc.apply(ctx, append(clients, client), client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+type UpdateOAuthClientRequest struct {
+	ClientID     string
+	ProviderName string
+	// ClientSecret keeps the current secret when empty.
+	ClientSecret string
+	RedirectURL  string
+}
+
+func (uc *OAuthClientUseCase) Update(ctx context.Context, req UpdateOAuthClientRequest) (*entities.OAuthClient, error) {
+	if uc.registry == nil {
+		return nil, entities.ErrOAuthClientsStatic
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	clients, err := uc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(clients, func(c *entities.OAuthClient) bool { return c.ClientID() == req.ClientID })
+	if i < 0 {
+		return nil, entities.ErrOAuthClientNotFound
+	}
+	updated := entities.ReconstructOAuthClient(clients[i].ProviderName(), clients[i].ClientID(), clients[i].ClientSecret(), clients[i].RedirectURL(), clients[i].CreatedAt(), clients[i].UpdatedAt())
+	if err := updated.Update(req.ProviderName, req.ClientSecret, req.RedirectURL, time.Now()); err != nil {
+		return nil, err
+	}
+	clients[i] = updated
+	if err := uc.apply(ctx, clients, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (uc *OAuthClientUseCase) Remove(ctx context.Context, clientID string) error {
+	if uc.registry == nil {
+		return entities.ErrOAuthClientsStatic
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	clients, err := uc.List(ctx)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(clients, func(c *entities.OAuthClient) bool { return c.ClientID() == clientID })
+	if i < 0 {
+		return entities.ErrOAuthClientNotFound
+	}
+	if err := uc.registry.ReloadClients(ctx, slices.Delete(clients, i, i+1)); err != nil {
+		return fmt.Errorf("failed to reload oauth clients: %w", err)
+	}
+	if err := uc.clientRepo.Delete(ctx, clientID); err != nil {
+		return fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+	return nil
+}
+
+// apply reloads the auth service with clients and then stores changed.
+func (uc *OAuthClientUseCase) apply(ctx context.Context, clients []*entities.OAuthClient, changed *entities.OAuthClient) error {
+	if err := uc.registry.ReloadClients(ctx, clients); err != nil {
+		if errors.Is(err, entities.ErrOAuthClientConfigured) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", entities.ErrOAuthProviderFailed, err)
+	}
+	if err := uc.clientRepo.Save(ctx, changed); err != nil {
+		return fmt.Errorf("failed to save oauth client: %w", err)
+	}
+	return nil
+}
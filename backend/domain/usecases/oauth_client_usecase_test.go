@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestOAuthClientUseCase_Register(t *testing.T) {
+	t.Parallel()
+
+	existing := entities.ReconstructOAuthClient("nishiki-web", "web", "secret", "https://app.example.com/callback", time.Now(), time.Now())
+	req := RegisterOAuthClientRequest{
+		ProviderName: "nishiki-mobile",
+		ClientID:     "mobile",
+		ClientSecret: "s3cret",
+		RedirectURL:  "nishiki://callback",
+	}
+
+	t.Run("reloads then stores", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockOAuthClientRepository(mockCtrl)
+		mockRegistry := mocks.NewMockOAuthClientRegistry(mockCtrl)
+
+		mockRepo.EXPECT().List(gomock.Any()).Return([]*entities.OAuthClient{existing}, nil)
+		gomock.InOrder(
+			mockRegistry.EXPECT().ReloadClients(gomock.Any(), gomock.Len(2)).Return(nil),
+			mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		client, err := NewOAuthClientUseCase(mockRepo, mockRegistry).Register(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "mobile", client.ClientID())
+	})
+
+	t.Run("provider unreachable is not stored", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockOAuthClientRepository(mockCtrl)
+		mockRegistry := mocks.NewMockOAuthClientRegistry(mockCtrl)
+
+		mockRepo.EXPECT().List(gomock.Any()).Return(nil, nil)
+		mockRegistry.EXPECT().ReloadClients(gomock.Any(), gomock.Len(1)).Return(errors.New("oidc provider init failed"))
+
+		_, err := NewOAuthClientUseCase(mockRepo, mockRegistry).Register(context.Background(), req)
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate client ID", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockOAuthClientRepository(mockCtrl)
+
+		mockRepo.EXPECT().List(gomock.Any()).Return([]*entities.OAuthClient{existing}, nil)
+
+		dup := req
+		dup.ClientID = "web"
+		_, err := NewOAuthClientUseCase(mockRepo, mocks.NewMockOAuthClientRegistry(mockCtrl)).Register(context.Background(), dup)
+		assert.ErrorIs(t, err, entities.ErrOAuthClientExists)
+	})
+
+	t.Run("invalid redirect URL", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+
+		bad := req
+		bad.RedirectURL = "/callback"
+		_, err := NewOAuthClientUseCase(mocks.NewMockOAuthClientRepository(mockCtrl), mocks.NewMockOAuthClientRegistry(mockCtrl)).Register(context.Background(), bad)
+		assert.ErrorIs(t, err, entities.ErrInvalidOAuthClient)
+	})
+
+	t.Run("static auth service", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+
+		_, err := NewOAuthClientUseCase(mocks.NewMockOAuthClientRepository(mockCtrl), nil).Register(context.Background(), req)
+		assert.ErrorIs(t, err, entities.ErrOAuthClientsStatic)
+	})
+}
+
+func TestOAuthClientUseCase_UpdateKeepsSecret(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockOAuthClientRepository(mockCtrl)
+	mockRegistry := mocks.NewMockOAuthClientRegistry(mockCtrl)
+	existing := entities.ReconstructOAuthClient("nishiki-web", "web", "secret", "https://app.example.com/callback", time.Now(), time.Now())
+
+	mockRepo.EXPECT().List(gomock.Any()).Return([]*entities.OAuthClient{existing}, nil)
+	mockRegistry.EXPECT().ReloadClients(gomock.Any(), gomock.Len(1)).Return(nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	updated, err := NewOAuthClientUseCase(mockRepo, mockRegistry).Update(context.Background(), UpdateOAuthClientRequest{
+		ClientID:     "web",
+		ProviderName: "nishiki-web",
+		RedirectURL:  "https://new.example.com/callback",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", updated.ClientSecret())
+	assert.Equal(t, "https://new.example.com/callback", updated.RedirectURL())
+	assert.Equal(t, "https://app.example.com/callback", existing.RedirectURL(), "stored client changed before the reload")
+}
+
+func TestOAuthClientUseCase_Remove(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockOAuthClientRepository(mockCtrl)
+	mockRegistry := mocks.NewMockOAuthClientRegistry(mockCtrl)
+	existing := entities.ReconstructOAuthClient("nishiki-web", "web", "secret", "https://app.example.com/callback", time.Now(), time.Now())
+	useCase := NewOAuthClientUseCase(mockRepo, mockRegistry)
+
+	mockRepo.EXPECT().List(gomock.Any()).DoAndReturn(func(context.Context) ([]*entities.OAuthClient, error) {
+		return []*entities.OAuthClient{existing}, nil
+	}).Times(2)
+	mockRegistry.EXPECT().ReloadClients(gomock.Any(), gomock.Len(0)).Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), "web").Return(nil)
+
+	require.NoError(t, useCase.Remove(context.Background(), "web"))
+	assert.ErrorIs(t, useCase.Remove(context.Background(), "gone"), entities.ErrOAuthClientNotFound)
+}
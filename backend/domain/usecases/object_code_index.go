@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// syncObjectCodes brings the account's code index in line with an object
+// whose codes went from before to after, touching only the codes that
+// changed. Both lists come from Object.Codes, so they are sorted.
+func syncObjectCodes(ctx context.Context, codeRepo repositories.ObjectCodeRepository, userID entities.UserID, objectID entities.ObjectID, before, after []string) error {
+	var errs []error
+	for _, code := range before {
+		if !slices.Contains(after, code) {
+			errs = append(errs, codeRepo.Remove(ctx, userID, code, objectID))
+		}
+	}
+	for _, code := range after {
+		if !slices.Contains(before, code) {
+			errs = append(errs, codeRepo.Add(ctx, userID, code, objectID))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// indexObjectCodes adds new objects' codes to the account's code index
+func indexObjectCodes(ctx context.Context, codeRepo repositories.ObjectCodeRepository, userID entities.UserID, objects ...*entities.Object) error {
+	var errs []error
+	for _, obj := range objects {
+		errs = append(errs, syncObjectCodes(ctx, codeRepo, userID, obj.ID(), nil, obj.Codes()))
+	}
+	return errors.Join(errs...)
+}
+
+// indexContainerCodes adds the codes of every object in a new container
+func indexContainerCodes(ctx context.Context, codeRepo repositories.ObjectCodeRepository, userID entities.UserID, container *entities.Container) error {
+	objects := container.Objects()
+	ptrs := make([]*entities.Object, len(objects))
+	for i := range objects {
+		ptrs[i] = &objects[i]
+	}
+	return indexObjectCodes(ctx, codeRepo, userID, ptrs...)
+}
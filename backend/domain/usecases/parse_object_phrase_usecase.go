@@ -0,0 +1,244 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// ParseObjectPhraseRequest carries a spoken or typed phrase such as "three
+// cans of tomatoes in the pantry". With CollectionID set, the place named in
+// the phrase is matched against that collection's containers.
+type ParseObjectPhraseRequest struct {
+	Text         string
+	CollectionID *entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+// ParseObjectPhraseResponse is a best guess at the fields of a new object.
+// Nothing is saved; callers prefill a create form with it.
+type ParseObjectPhraseResponse struct {
+	Name      string
+	Quantity  *float64
+	Unit      string
+	Location  string
+	Container *entities.Container // nil when no container matches Location
+}
+
+type ParseObjectPhraseUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	authService    services.AuthService
+}
+
+func NewParseObjectPhraseUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, authService services.AuthService) *ParseObjectPhraseUseCase {
+	return &ParseObjectPhraseUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *ParseObjectPhraseUseCase) Execute(ctx context.Context, req ParseObjectPhraseRequest) (*ParseObjectPhraseResponse, error) {
+	phrase := parseObjectPhrase(req.Text)
+	if phrase.name == "" {
+		return nil, errors.New("text must name an object")
+	}
+
+	resp := &ParseObjectPhraseResponse{
+		Name:     phrase.name,
+		Quantity: phrase.quantity,
+		Unit:     phrase.unit,
+		Location: phrase.location,
+	}
+	if req.CollectionID == nil {
+		return resp, nil
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, *req.CollectionID)
+	if err != nil {
+		return nil, errors.New("collection not found")
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	if phrase.location == "" {
+		return resp, nil
+	}
+	containers, err := uc.containerRepo.GetByCollectionID(ctx, collection.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containers: %w", err)
+	}
+	best := 0
+	for _, c := range containers {
+		if score := objectNameMatchScore(phrase.location, c.Name().String()); score > best {
+			best = score
+			resp.Container = c
+		}
+	}
+
+	return resp, nil
+}
+
+// objectPhrase is what parseObjectPhrase pulls out of a phrase
+type objectPhrase struct {
+	name     string
+	quantity *float64
+	unit     string
+	location string
+}
+
+// phraseNumbers maps spoken numbers to their values. Tens combine with a
+// following digit word, as in "twenty five".
+var phraseNumbers = map[string]float64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13,
+	"fourteen": 14, "fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18,
+	"nineteen": 19, "twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+// phrasePackages are containers people count groceries in. They are kept as
+// the unit alongside the measures services.IsKnownUnit knows.
+var phrasePackages = map[string]bool{
+	"can": true, "tin": true, "bottle": true, "jar": true, "box": true, "bag": true,
+	"pack": true, "packet": true, "package": true, "carton": true, "loaf": true,
+	"loaves": true, "bunch": true, "roll": true, "tube": true, "case": true, "bar": true,
+}
+
+// phraseVerbs are leading words of a command ("add two apples") that are not
+// part of the object
+var phraseVerbs = map[string]bool{"add": true, "put": true, "store": true}
+
+// phrasePlaces introduce where an object goes. They only count when followed
+// by one of phraseArticles, so "beans in tomato sauce" stays a name.
+var (
+	phrasePlaces   = map[string]bool{"in": true, "on": true, "at": true, "into": true, "inside": true, "to": true}
+	phraseArticles = map[string]bool{"the": true, "my": true, "our": true}
+)
+
+// parseObjectPhrase splits a phrase like "add three cans of tomatoes in the
+// pantry" into quantity 3, unit "cans", name "tomatoes" and location "pantry".
+// Parts it cannot find are left empty.
+func parseObjectPhrase(text string) objectPhrase {
+	var words []string
+	for _, w := range strings.Fields(text) {
+		if w = strings.Trim(w, ".,!?;:\"'"); w != "" {
+			words = append(words, w)
+		}
+	}
+	lower := make([]string, len(words))
+	for i, w := range words {
+		lower[i] = strings.ToLower(w)
+	}
+
+	var phrase objectPhrase
+	if len(words) > 0 && phraseVerbs[lower[0]] {
+		words, lower = words[1:], lower[1:]
+	}
+
+	// The last place phrase wins: "beans in sauce in the pantry"
+	for i := len(lower) - 3; i > 0; i-- {
+		if phrasePlaces[lower[i]] && phraseArticles[lower[i+1]] {
+			phrase.location = strings.Join(words[i+2:], " ")
+			words, lower = words[:i], lower[:i]
+			break
+		}
+	}
+
+	i := 0
+	quantity, n := parsePhraseQuantity(lower)
+	i += n
+	if i < len(lower)-1 && lower[i] == "dozen" {
+		quantity = max(quantity, 1) * 12
+		i++
+	}
+	if quantity > 0 {
+		phrase.quantity = &quantity
+	}
+
+	// Only take a unit when something is left over to be the name
+	if i < len(lower)-1 && (phrasePackages[services.NormalizeUnit(lower[i])] || phrasePackages[lower[i]] || services.IsKnownUnit(lower[i])) {
+		phrase.unit = lower[i]
+		i++
+	}
+	if i < len(lower)-1 && lower[i] == "of" {
+		i++
+	}
+
+	phrase.name = strings.Join(words[i:], " ")
+	return phrase
+}
+
+// parsePhraseQuantity reads a number from the start of words, as digits or
+// words ("3", "three", "twenty five", "a", "half a"), and reports how many
+// words it used. It returns 0, 0 when words does not start with a number.
+func parsePhraseQuantity(words []string) (float64, int) {
+	if len(words) == 0 {
+		return 0, 0
+	}
+	next := ""
+	if len(words) > 1 {
+		next = words[1]
+	}
+
+	w := words[0]
+	if v, err := strconv.ParseFloat(w, 64); err == nil && v > 0 {
+		return v, 1
+	}
+	switch w {
+	case "a", "an":
+		switch next {
+		case "couple":
+			return 2, 2
+		case "half":
+			return 0.5, 2
+		}
+		return 1, 1
+	case "half":
+		if next == "a" || next == "an" {
+			return 0.5, 2
+		}
+		return 0.5, 1
+	case "couple":
+		return 2, 1
+	}
+
+	if tens, ones, ok := strings.Cut(w, "-"); ok {
+		t, tok := phraseNumbers[tens]
+		o, ook := phraseNumbers[ones]
+		if tok && ook && t >= 20 && o < 10 {
+			return t + o, 1
+		}
+	}
+	v, ok := phraseNumbers[w]
+	if !ok {
+		return 0, 0
+	}
+	if o, ok := phraseNumbers[next]; ok && v >= 20 && o < 10 {
+		return v + o, 2
+	}
+	return v, 1
+}
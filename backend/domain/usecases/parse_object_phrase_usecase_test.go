@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestParseObjectPhrase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		name     string
+		quantity float64 // 0 means none
+		unit     string
+		location string
+	}{
+		{"three cans of tomatoes in the pantry", "tomatoes", 3, "cans", "pantry"},
+		{"Add 2 kg of flour to the top shelf.", "flour", 2, "kg", "top shelf"},
+		{"a dozen eggs", "eggs", 12, "", ""},
+		{"twenty five paper plates", "paper plates", 25, "", ""},
+		{"twenty-one jars of honey", "honey", 21, "jars", ""},
+		{"half a gallon of milk in my fridge", "milk", 0.5, "gallon", "fridge"},
+		{"a couple of apples", "apples", 2, "", ""},
+		{"beans in tomato sauce in the cupboard", "beans in tomato sauce", 0, "", "cupboard"},
+		{"olive oil", "olive oil", 0, "", ""},
+		// A unit word with nothing after it is the name
+		{"two cans", "cans", 2, "", ""},
+	}
+
+	for _, tt := range tests {
+		got := parseObjectPhrase(tt.text)
+		assert.Equal(t, tt.name, got.name, tt.text)
+		assert.Equal(t, tt.unit, got.unit, tt.text)
+		assert.Equal(t, tt.location, got.location, tt.text)
+		if tt.quantity == 0 {
+			assert.Nil(t, got.quantity, tt.text)
+		} else if assert.NotNil(t, got.quantity, tt.text) {
+			assert.InDelta(t, tt.quantity, *got.quantity, 1e-9, tt.text)
+		}
+	}
+}
+
+func TestParseObjectPhraseUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewParseObjectPhraseUseCase(mockCollectionRepo, mockContainerRepo, mockAuthService)
+
+	t.Run("success - place matches a container", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID))
+		fridge := NewTestContainer(CtrName("Fridge"), CtrCollectionID(collectionID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{fridge, pantry}, nil)
+
+		resp, err := useCase.Execute(context.Background(), ParseObjectPhraseRequest{
+			Text:         "three cans of tomatoes in the pantry",
+			CollectionID: &collectionID,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tomatoes", resp.Name)
+		require.NotNil(t, resp.Container)
+		assert.Equal(t, pantry.ID(), resp.Container.ID())
+	})
+
+	t.Run("success - no collection skips container matching", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), ParseObjectPhraseRequest{
+			Text:      "two bottles of wine in the cellar",
+			UserID:    entities.NewUserID(),
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "wine", resp.Name)
+		assert.Equal(t, "cellar", resp.Location)
+		assert.Nil(t, resp.Container)
+	})
+
+	t.Run("error - access denied", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(entities.NewUserID()))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+
+		_, err := useCase.Execute(context.Background(), ParseObjectPhraseRequest{
+			Text:         "milk in the fridge",
+			CollectionID: &collectionID,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - nothing named", func(t *testing.T) {
+		_, err := useCase.Execute(context.Background(), ParseObjectPhraseRequest{
+			Text:      "  ...  ",
+			UserID:    entities.NewUserID(),
+			UserToken: "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must name an object")
+	})
+}
@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// maxReceiptLines caps how many items one forwarded email can add to the
+// inbox
+const maxReceiptLines = 200
+
+// maxReceiptNameLength skips lines too long to be an item name, which are
+// usually prose
+const maxReceiptNameLength = 120
+
+var (
+	// receiptPriceRe matches an amount at the end of a line: "$3.49",
+	// "3,49 €", "12.00 USD", "Milk.....3.49". A leading minus marks a
+	// discount.
+	receiptPriceRe = regexp.MustCompile(`(?:^|[\s.])(-?)\s*(?:[$€£]\s?)?(\d+)[.,](\d{2})\s*(?:[$€£]|[A-Z]{3})?$`)
+	// receiptHeaderRe matches the header block of a forwarded message
+	receiptHeaderRe = regexp.MustCompile(`(?i)^(?:from|to|cc|subject|date|sent|reply-to)\s*:`)
+	// receiptSkipRe matches the lines of a receipt that are not items
+	receiptSkipRe = regexp.MustCompile(`(?i)\b(?:total|subtotal|sub-total|tax|vat|gst|shipping|handling|delivery|discount|savings|saved|coupon|tip|fee|balance|payment|paid|change|refund|visa|mastercard|amex)\b`)
+	// "Qty: 2" on its own line, quantity of the item above
+	receiptQtyLineRe = regexp.MustCompile(`(?i)^(?:qty|quantity)\s*:?\s*(\d+(?:[.,]\d+)?)$`)
+	// "2 x Milk", "2x Milk"
+	receiptQtyFirstRe = regexp.MustCompile(`(?i)^(\d+(?:[.,]\d+)?)\s*[x×*]\s+(.+)$`)
+	// "Milk x2", "Milk × 2"
+	receiptQtyLastRe = regexp.MustCompile(`(?i)^(.+?)\s+[x×*]\s*(\d+(?:[.,]\d+)?)$`)
+	// "Milk Qty: 2", "Milk (qty 2)"
+	receiptQtyLabelRe = regexp.MustCompile(`(?i)^(.+?)\s*[(\[]?\s*(?:qty|quantity)\s*:?\s*(\d+(?:[.,]\d+)?)\s*[)\]]?$`)
+	// "2 kg Apples", "500g Flour", "2 Apples"
+	receiptLeadingNumberRe = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*(.+)$`)
+	receiptBulletRe        = regexp.MustCompile(`^(?:[-*•·]|\d+[.)])\s+`)
+)
+
+// parseReceipt reads the items out of the plain-text body of a forwarded
+// order confirmation or receipt. A line is an item when it carries a
+// quantity ("2 x Milk", "Milk x2", "2 kg Apples") or a price ("Milk
+// $3.49"); a "Qty: 2" line gives the quantity of the line above it, and a
+// line holding only a price gives the price of the item above it. Totals,
+// taxes, shipping and payment lines are skipped, and so is the header block
+// of a forwarded message. Anything it cannot read is left for the user to
+// add by hand.
+func parseReceipt(body string) []entities.InboxLine {
+	var lines []entities.InboxLine
+	pendingName := "" // a text line that may be named by a "Qty:" line below
+	last := -1        // the item a price-only line below would belong to
+
+	for raw := range strings.SplitSeq(body, "\n") {
+		text := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(raw), "> "))
+		if text == "" {
+			continue
+		}
+		if receiptHeaderRe.MatchString(text) {
+			pendingName, last = "", -1
+			continue
+		}
+
+		rest, price, discount := splitReceiptPrice(text)
+		if discount || (rest != "" && receiptSkipRe.MatchString(rest)) {
+			pendingName, last = "", -1
+			continue
+		}
+
+		if rest == "" {
+			if price != nil && last >= 0 && lines[last].Price == nil {
+				lines[last].Price = price
+			}
+			continue
+		}
+
+		if m := receiptQtyLineRe.FindStringSubmatch(rest); m != nil {
+			if pendingName != "" {
+				lines = append(lines, entities.InboxLine{Name: pendingName, Quantity: parseReceiptNumber(m[1]), Price: price})
+				last = len(lines) - 1
+			} else if last >= 0 && lines[last].Quantity == nil {
+				lines[last].Quantity = parseReceiptNumber(m[1])
+			}
+			pendingName = ""
+			continue
+		}
+
+		line, ok := parseReceiptLine(rest, price != nil)
+		if !ok {
+			pendingName = receiptName(rest)
+			last = -1
+			continue
+		}
+		line.Price = price
+		lines = append(lines, line)
+		pendingName, last = "", len(lines)-1
+
+		if len(lines) == maxReceiptLines {
+			break
+		}
+	}
+	return lines
+}
+
+// splitReceiptPrice takes the amount off the end of a line
+func splitReceiptPrice(text string) (rest string, price *float64, discount bool) {
+	m := receiptPriceRe.FindStringSubmatchIndex(text)
+	if m == nil {
+		return text, nil, false
+	}
+	amount, err := strconv.ParseFloat(text[m[4]:m[5]]+"."+text[m[6]:m[7]], 64)
+	if err != nil {
+		return text, nil, false
+	}
+	return strings.TrimSpace(text[:m[0]]), &amount, m[3] > m[2]
+}
+
+// parseReceiptLine reads the name and quantity of an item line with its
+// price already taken off. Without a price, only a line with an explicit
+// quantity counts as an item.
+func parseReceiptLine(text string, priced bool) (entities.InboxLine, bool) {
+	var line entities.InboxLine
+	if m := receiptQtyFirstRe.FindStringSubmatch(text); m != nil {
+		line.Quantity, line.Name = parseReceiptNumber(m[1]), m[2]
+	} else if m := receiptQtyLastRe.FindStringSubmatch(text); m != nil {
+		line.Name, line.Quantity = m[1], parseReceiptNumber(m[2])
+	} else if m := receiptQtyLabelRe.FindStringSubmatch(text); m != nil {
+		line.Name, line.Quantity = m[1], parseReceiptNumber(m[2])
+	} else if m := receiptLeadingNumberRe.FindStringSubmatch(text); m != nil && hasReceiptUnit(m[2]) {
+		unit, name := splitReceiptUnit(m[2])
+		line.Quantity, line.Unit, line.Name = parseReceiptNumber(m[1]), unit, name
+	} else if m != nil && priced && strings.HasPrefix(text, m[1]+" ") {
+		// "2 Apples $3.98"; without the price a leading number is too
+		// often part of the text to be read as a quantity
+		line.Quantity, line.Name = parseReceiptNumber(m[1]), m[2]
+	} else if priced {
+		line.Name = text
+	} else {
+		return line, false
+	}
+
+	line.Name = receiptName(line.Name)
+	return line, line.Name != ""
+}
+
+func hasReceiptUnit(text string) bool {
+	unit, _ := splitReceiptUnit(text)
+	return unit != ""
+}
+
+// splitReceiptUnit splits a known unit off the start of text, trying two
+// words ("fl oz") before one. The unit is "" when text does not start with
+// one.
+func splitReceiptUnit(text string) (unit, rest string) {
+	fields := strings.Fields(text)
+	for n := min(2, len(fields)-1); n >= 1; n-- {
+		candidate := strings.Join(fields[:n], " ")
+		if services.IsKnownUnit(candidate) {
+			return services.NormalizeUnit(candidate), strings.TrimPrefix(strings.Join(fields[n:], " "), "of ")
+		}
+	}
+	return "", text
+}
+
+// receiptName cleans an item name of bullets, dot leaders and trailing
+// separators, returning "" for text that cannot be a name
+func receiptName(text string) string {
+	name := receiptBulletRe.ReplaceAllString(text, "")
+	name = strings.TrimRight(name, " .·-:@\t")
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxReceiptNameLength || !strings.ContainsFunc(name, unicode.IsLetter) {
+		return ""
+	}
+	return name
+}
+
+func parseReceiptNumber(s string) *float64 {
+	v, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil || v <= 0 {
+		return nil
+	}
+	return &v
+}
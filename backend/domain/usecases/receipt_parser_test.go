@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestParseReceipt(t *testing.T) {
+	t.Parallel()
+
+	// want lists name, quantity (0 = none), unit and price (0 = none)
+	type line struct {
+		name     string
+		quantity float64
+		unit     string
+		price    float64
+	}
+	tests := []struct {
+		name string
+		body string
+		want []line
+	}{
+		{
+			name: "grocery receipt with totals",
+			body: "FRESH MART\n2 x Whole Milk   $3.49\nBananas x3  1.20\n2 kg Apples  4,50 €\nSubtotal  $9.19\nTax 0.55\nTOTAL $9.74\nVisa ending 1234  $9.74\n",
+			want: []line{
+				{"Whole Milk", 2, "", 3.49},
+				{"Bananas", 3, "", 1.20},
+				{"Apples", 2, "kg", 4.50},
+			},
+		},
+		{
+			name: "forwarded order confirmation",
+			body: "---------- Forwarded message ---------\nFrom: Shop <orders@shop.example>\nDate: Mon, 12 Oct 2026\nSubject: Your order\n\nThanks for your order!\n\nOrganic Rolled Oats\nQty: 2\n$7.98\n\nAA Batteries, 24 pack\nQuantity: 1\n$12.99\n\nShipping: $0.00\nOrder total: $20.97\n",
+			want: []line{
+				{"Organic Rolled Oats", 2, "", 7.98},
+				{"AA Batteries, 24 pack", 1, "", 12.99},
+			},
+		},
+		{
+			name: "quoted reply with bullets and dot leaders",
+			body: "> - Coffee beans (qty 2)\n> * Olive Oil........12.00\n> 500 g of Flour\n",
+			want: []line{
+				{"Coffee beans", 2, "", 0},
+				{"Olive Oil", 0, "", 12.00},
+				{"Flour", 500, "g", 0},
+			},
+		},
+		{
+			name: "discounts and prose are skipped",
+			body: "Hi, here is your receipt for 3 items.\nSD card 64GB  $9.99\nMember discount  -$1.00\nCoupon -0.50\n2% Milk $2.99\n",
+			want: []line{
+				{"SD card 64GB", 0, "", 9.99},
+				{"2% Milk", 0, "", 2.99},
+			},
+		},
+		{
+			name: "nothing recognisable",
+			body: "Your package has shipped and will arrive on Tuesday.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseReceipt(tt.body)
+			require.Len(t, got, len(tt.want))
+			for i, want := range tt.want {
+				assertReceiptLine(t, got[i], want.name, want.quantity, want.unit, want.price)
+			}
+		})
+	}
+}
+
+func assertReceiptLine(t *testing.T, got entities.InboxLine, name string, quantity float64, unit string, price float64) {
+	t.Helper()
+	assert.Equal(t, name, got.Name)
+	assert.Equal(t, unit, got.Unit, name)
+	if quantity == 0 {
+		assert.Nil(t, got.Quantity, name)
+	} else if assert.NotNil(t, got.Quantity, name) {
+		assert.InDelta(t, quantity, *got.Quantity, 1e-9, name)
+	}
+	if price == 0 {
+		assert.Nil(t, got.Price, name)
+	} else if assert.NotNil(t, got.Price, name) {
+		assert.InDelta(t, price, *got.Price, 1e-9, name)
+	}
+}
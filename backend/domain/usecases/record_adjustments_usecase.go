@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// RecordAdjustmentsUseCase keeps the quantity change history the purchase
+// history report reads, from the object events of every change.
+type RecordAdjustmentsUseCase struct {
+	adjustmentRepo repositories.QuantityAdjustmentRepository
+	logger         *slog.Logger
+}
+
+func NewRecordAdjustmentsUseCase(adjustmentRepo repositories.QuantityAdjustmentRepository, logger *slog.Logger) *RecordAdjustmentsUseCase {
+	return &RecordAdjustmentsUseCase{
+		adjustmentRepo: adjustmentRepo,
+		logger:         logger,
+	}
+}
+
+// Handle records the quantity change event made, if any. A failure is
+// logged; the change itself already went through.
+func (uc *RecordAdjustmentsUseCase) Handle(ctx context.Context, event entities.ObjectEvent) {
+	adjustment, ok := entities.QuantityAdjustmentFromEvent(event)
+	if !ok {
+		return
+	}
+	if err := uc.adjustmentRepo.Create(ctx, adjustment); err != nil {
+		uc.logger.Warn("Failed to record quantity adjustment",
+			slog.String("object_id", event.Object.ID().String()), slog.Any("error", err))
+	}
+}
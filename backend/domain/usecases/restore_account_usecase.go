@@ -0,0 +1,275 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// RestoreDigestSettings carries the digest preferences from a backup.
+type RestoreDigestSettings struct {
+	Email     entities.EmailAddress
+	Frequency entities.DigestFrequency
+}
+
+type RestoreAccountRequest struct {
+	UserID    entities.UserID
+	UserToken string
+	Strategy  entities.RestoreStrategy
+	// Collections from the archive, already owned by UserID and carrying
+	// their containers and objects.
+	Collections []*entities.Collection
+	Digest      *RestoreDigestSettings
+}
+
+type RestoreAccountResponse struct {
+	CollectionsCreated int
+	CollectionsUpdated int
+	CollectionsSkipped int
+	ContainersCreated  int
+	ObjectsRestored    int
+	// Errors lists collections that could not be restored. The rest of the
+	// archive is still applied.
+	Errors []string
+}
+
+type RestoreAccountUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	codeRepo       repositories.ObjectCodeRepository
+	digestRepo     repositories.DigestSubscriptionRepository
+	authService    services.AuthService
+}
+
+func NewRestoreAccountUseCase(
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	digestRepo repositories.DigestSubscriptionRepository,
+	authService services.AuthService,
+) *RestoreAccountUseCase {
+	return &RestoreAccountUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		codeRepo:       codeRepo,
+		digestRepo:     digestRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *RestoreAccountUseCase) Execute(ctx context.Context, req RestoreAccountRequest) (*RestoreAccountResponse, error) {
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	groupIDs := make([]entities.GroupID, len(userGroups))
+	for i, g := range userGroups {
+		groupIDs[i] = g.ID()
+	}
+
+	resp := &RestoreAccountResponse{}
+	for _, col := range req.Collections {
+		if err := checkRestoreGroups(col, groupIDs); err != nil {
+			resp.CollectionsSkipped++
+			resp.Errors = append(resp.Errors, fmt.Sprintf("collection %q: %v", col.Name().String(), err))
+			continue
+		}
+
+		if err := uc.restoreCollection(ctx, req, col, resp); err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("collection %q: %v", col.Name().String(), err))
+		}
+	}
+
+	if req.Digest != nil {
+		if err := uc.restoreDigest(ctx, req, groupIDs); err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("digest preferences: %v", err))
+		}
+	}
+
+	return resp, nil
+}
+
+func (uc *RestoreAccountUseCase) restoreCollection(ctx context.Context, req RestoreAccountRequest, col *entities.Collection, resp *RestoreAccountResponse) error {
+	exists, err := uc.collectionRepo.Exists(ctx, col.ID())
+	if err != nil {
+		return fmt.Errorf("failed to check collection: %w", err)
+	}
+
+	if !exists {
+		if err := uc.checkContainerIDs(ctx, col, nil); err != nil {
+			resp.CollectionsSkipped++
+			return err
+		}
+		if err := uc.createContainers(ctx, req.UserID, col.Containers(), resp); err != nil {
+			return err
+		}
+		if err := uc.collectionRepo.Create(ctx, col); err != nil {
+			return fmt.Errorf("failed to create collection: %w", err)
+		}
+		resp.CollectionsCreated++
+		return nil
+	}
+
+	existing, err := uc.collectionRepo.GetByID(ctx, col.ID())
+	if err != nil {
+		return fmt.Errorf("failed to get collection: %w", err)
+	}
+	if !existing.IsOwnedBy(req.UserID) {
+		resp.CollectionsSkipped++
+		return errors.New("access denied: collection belongs to another user")
+	}
+
+	switch req.Strategy {
+	case entities.RestoreStrategyOverwrite:
+		// Nothing is deleted until the archived containers are known to fit.
+		if err := uc.checkContainerIDs(ctx, col, existing); err != nil {
+			resp.CollectionsSkipped++
+			return err
+		}
+		if _, err := uc.containerRepo.DeleteByCollectionID(ctx, col.ID()); err != nil {
+			return fmt.Errorf("failed to clear containers: %w", err)
+		}
+		if err := uc.createContainers(ctx, req.UserID, col.Containers(), resp); err != nil {
+			return err
+		}
+		if err := uc.collectionRepo.Update(ctx, col); err != nil {
+			return fmt.Errorf("failed to update collection: %w", err)
+		}
+		resp.CollectionsUpdated++
+
+	case entities.RestoreStrategyMerge:
+		if err := uc.mergeCollection(ctx, req.UserID, existing, col, resp); err != nil {
+			return err
+		}
+		resp.CollectionsUpdated++
+
+	default:
+		resp.CollectionsSkipped++
+	}
+
+	return nil
+}
+
+// mergeCollection adds archived containers and objects missing from existing.
+// Items present in both are left as they are.
+func (uc *RestoreAccountUseCase) mergeCollection(ctx context.Context, userID entities.UserID, existing, archived *entities.Collection, resp *RestoreAccountResponse) error {
+	addedContainers := false
+	for _, container := range archived.Containers() {
+		current, err := existing.GetContainer(container.ID())
+		if err != nil {
+			if err := uc.createContainers(ctx, userID, []entities.Container{container}, resp); err != nil {
+				return err
+			}
+			if err := existing.AddContainer(container); err != nil {
+				return fmt.Errorf("failed to add container to collection: %w", err)
+			}
+			addedContainers = true
+			continue
+		}
+
+		for _, obj := range container.Objects() {
+			if _, err := current.GetObject(obj.ID()); err == nil {
+				continue
+			}
+			if err := uc.containerRepo.AddObject(ctx, container.ID(), obj); err != nil {
+				return fmt.Errorf("failed to add object %q: %w", obj.Name().String(), err)
+			}
+			_ = indexObjectCodes(ctx, uc.codeRepo, userID, &obj)
+			resp.ObjectsRestored++
+		}
+	}
+
+	if addedContainers {
+		if err := uc.collectionRepo.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update collection: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkContainerIDs refuses an archived collection whose container IDs repeat
+// or are taken by a collection other than existing, which is nil when the
+// collection is new. IDs are kept so a backup restores onto itself cleanly.
+func (uc *RestoreAccountUseCase) checkContainerIDs(ctx context.Context, col, existing *entities.Collection) error {
+	seen := make(map[string]bool, len(col.Containers()))
+	for _, container := range col.Containers() {
+		id := container.ID()
+		if seen[id.String()] {
+			return fmt.Errorf("container %s appears more than once", id.String())
+		}
+		seen[id.String()] = true
+
+		if existing != nil {
+			if _, err := existing.GetContainer(id); err == nil {
+				continue
+			}
+		}
+		taken, err := uc.containerRepo.Exists(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check container: %w", err)
+		}
+		if taken {
+			return fmt.Errorf("container %s already exists in another collection", id.String())
+		}
+	}
+	return nil
+}
+
+// createContainers saves the containers and indexes their objects' codes.
+// Codes of containers an overwrite removed are left for lookups to prune.
+func (uc *RestoreAccountUseCase) createContainers(ctx context.Context, userID entities.UserID, containers []entities.Container, resp *RestoreAccountResponse) error {
+	for i := range containers {
+		if err := uc.containerRepo.Create(ctx, &containers[i]); err != nil {
+			return fmt.Errorf("failed to create container %q: %w", containers[i].Name().String(), err)
+		}
+		_ = indexContainerCodes(ctx, uc.codeRepo, userID, &containers[i])
+		resp.ContainersCreated++
+		resp.ObjectsRestored += len(containers[i].Objects())
+	}
+	return nil
+}
+
+// restoreDigest applies archived digest preferences unless the user already
+// has some and the strategy is not overwrite.
+func (uc *RestoreAccountUseCase) restoreDigest(ctx context.Context, req RestoreAccountRequest, groupIDs []entities.GroupID) error {
+	subscription, err := uc.digestRepo.GetByUserID(ctx, req.UserID)
+	switch {
+	case errors.Is(err, entities.ErrDigestSubscriptionNotFound):
+		subscription, err = entities.NewDigestSubscription(req.UserID, req.Digest.Email, req.Digest.Frequency, groupIDs)
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case req.Strategy == entities.RestoreStrategyOverwrite:
+		subscription.UpdatePreferences(req.Digest.Email, req.Digest.Frequency, groupIDs)
+	default:
+		return nil
+	}
+
+	return uc.digestRepo.Save(ctx, subscription)
+}
+
+// checkRestoreGroups rejects collections shared with a group the user is not
+// a member of, so a backup cannot be used to attach data to foreign groups.
+func checkRestoreGroups(col *entities.Collection, groupIDs []entities.GroupID) error {
+	isMember := func(id *entities.GroupID) bool {
+		return id == nil || slices.ContainsFunc(groupIDs, id.Equals)
+	}
+
+	if !isMember(col.GroupID()) {
+		return fmt.Errorf("access denied: not a member of group %s", col.GroupID().String())
+	}
+	for _, container := range col.Containers() {
+		if !isMember(container.GroupID()) {
+			return fmt.Errorf("access denied: not a member of group %s", container.GroupID().String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestRestoreAccountUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *RestoreAccountUseCase
+		collectionRepo *mocks.MockCollectionRepository
+		containerRepo  *mocks.MockContainerRepository
+		codeRepo       *mocks.MockObjectCodeRepository
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			codeRepo:       mocks.NewMockObjectCodeRepository(mockCtrl),
+		}
+		authService := mocks.NewMockAuthService(mockCtrl)
+		authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		f.useCase = NewRestoreAccountUseCase(f.collectionRepo, f.containerRepo, f.codeRepo, mocks.NewMockDigestSubscriptionRepository(mockCtrl), authService)
+		return f
+	}
+
+	userID := entities.NewUserID()
+
+	t.Run("creates missing collection", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		coded := NewTestObject(ObjProps(Props("upc", "0123456789012")))
+		container := NewTestContainer(CtrObjects(*NewTestObject(), *coded))
+		col := NewTestCollection(ColUserID(userID), ColContainers(*container))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(false, nil)
+		f.containerRepo.EXPECT().Exists(gomock.Any(), container.ID()).Return(false, nil)
+		f.containerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		f.codeRepo.EXPECT().Add(gomock.Any(), userID, "0123456789012", coded.ID()).Return(nil)
+		f.collectionRepo.EXPECT().Create(gomock.Any(), col).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategySkip, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsCreated)
+		assert.Equal(t, 1, resp.ContainersCreated)
+		assert.Equal(t, 2, resp.ObjectsRestored)
+		assert.Empty(t, resp.Errors)
+	})
+
+	t.Run("skip leaves existing collection", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection(ColUserID(userID))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategySkip, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsSkipped)
+	})
+
+	t.Run("overwrite replaces containers", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		container := NewTestContainer()
+		col := NewTestCollection(ColUserID(userID), ColContainers(*container))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(NewTestCollection(ColID(col.ID()), ColUserID(userID)), nil)
+		f.containerRepo.EXPECT().Exists(gomock.Any(), container.ID()).Return(false, nil)
+		f.containerRepo.EXPECT().DeleteByCollectionID(gomock.Any(), col.ID()).Return(int64(3), nil)
+		f.containerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		f.collectionRepo.EXPECT().Update(gomock.Any(), col).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategyOverwrite, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsUpdated)
+	})
+
+	t.Run("overwrite keeps its own container IDs", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		container := NewTestContainer()
+		col := NewTestCollection(ColUserID(userID), ColContainers(*container))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(NewTestCollection(ColID(col.ID()), ColUserID(userID), ColContainers(*container)), nil)
+		f.containerRepo.EXPECT().DeleteByCollectionID(gomock.Any(), col.ID()).Return(int64(1), nil)
+		f.containerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		f.collectionRepo.EXPECT().Update(gomock.Any(), col).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategyOverwrite, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsUpdated)
+	})
+
+	t.Run("overwrite deletes nothing when a container ID is taken", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		container := NewTestContainer()
+		col := NewTestCollection(ColUserID(userID), ColContainers(*container))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(NewTestCollection(ColID(col.ID()), ColUserID(userID)), nil)
+		f.containerRepo.EXPECT().Exists(gomock.Any(), container.ID()).Return(true, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategyOverwrite, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsSkipped)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0], "already exists in another collection")
+	})
+
+	t.Run("overwrite deletes nothing when container IDs repeat", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		container := NewTestContainer()
+		col := NewTestCollection(ColUserID(userID), ColContainers(*container, *container))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(NewTestCollection(ColID(col.ID()), ColUserID(userID), ColContainers(*container)), nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategyOverwrite, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsSkipped)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0], "more than once")
+	})
+
+	t.Run("merge adds only missing objects", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		kept := NewTestObject(ObjName("Kept"))
+		missing := NewTestObject(ObjName("Missing"))
+		containerID := entities.NewContainerID()
+		existingContainer := NewTestContainer(CtrID(containerID), CtrObjects(*kept))
+		archivedContainer := NewTestContainer(CtrID(containerID), CtrObjects(*kept, *missing))
+		existing := NewTestCollection(ColUserID(userID), ColContainers(*existingContainer))
+		archived := NewTestCollection(ColID(existing.ID()), ColUserID(userID), ColContainers(*archivedContainer))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), existing.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), existing.ID()).Return(existing, nil)
+		f.containerRepo.EXPECT().AddObject(gomock.Any(), containerID, *missing).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategyMerge, Collections: []*entities.Collection{archived},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsUpdated)
+		assert.Equal(t, 1, resp.ObjectsRestored)
+	})
+
+	t.Run("rejects collection owned by another user", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection(ColUserID(userID))
+
+		f.collectionRepo.EXPECT().Exists(gomock.Any(), col.ID()).Return(true, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(NewTestCollection(ColID(col.ID())), nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategyOverwrite, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsSkipped)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0], "access denied")
+	})
+
+	t.Run("rejects foreign group", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		groupID, _ := entities.GroupIDFromString("other-group")
+		col := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+
+		resp, err := f.useCase.Execute(context.Background(), RestoreAccountRequest{
+			UserID: userID, Strategy: entities.RestoreStrategySkip, Collections: []*entities.Collection{col},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CollectionsSkipped)
+		require.Len(t, resp.Errors, 1)
+	})
+}
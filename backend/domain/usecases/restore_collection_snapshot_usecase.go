@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type RestoreCollectionSnapshotRequest struct {
+	CollectionID entities.CollectionID
+	SnapshotID   entities.CollectionSnapshotID
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type RestoreCollectionSnapshotResponse struct {
+	Collection *entities.Collection
+	// Backup captures the tree the restore replaced, so the restore itself
+	// can be undone.
+	Backup             *entities.CollectionSnapshot
+	ContainersRestored int
+	ObjectsRestored    int
+	// Skipped lists containers and objects left out because their ID now
+	// belongs to another collection.
+	Skipped []string
+}
+
+type RestoreCollectionSnapshotUseCase struct {
+	collectionRepo   repositories.CollectionRepository
+	containerRepo    repositories.ContainerRepository
+	codeRepo         repositories.ObjectCodeRepository
+	snapshotRepo     repositories.CollectionSnapshotRepository
+	quotaRepo        repositories.GroupQuotaRepository
+	usageRepo        repositories.UsageRepository
+	authService      services.AuthService
+	maxPerCollection int
+}
+
+func NewRestoreCollectionSnapshotUseCase(
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	codeRepo repositories.ObjectCodeRepository,
+	snapshotRepo repositories.CollectionSnapshotRepository,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
+	authService services.AuthService,
+	maxPerCollection int,
+) *RestoreCollectionSnapshotUseCase {
+	return &RestoreCollectionSnapshotUseCase{
+		collectionRepo:   collectionRepo,
+		containerRepo:    containerRepo,
+		codeRepo:         codeRepo,
+		snapshotRepo:     snapshotRepo,
+		quotaRepo:        quotaRepo,
+		usageRepo:        usageRepo,
+		authService:      authService,
+		maxPerCollection: maxPerCollection,
+	}
+}
+
+// Execute replaces the collection's containers and objects with the
+// snapshot's copy. The current tree is snapshotted first.
+func (uc *RestoreCollectionSnapshotUseCase) Execute(ctx context.Context, req RestoreCollectionSnapshotRequest) (*RestoreCollectionSnapshotResponse, error) {
+	collection, err := getManagedCollection(ctx, uc.collectionRepo, uc.authService, req.CollectionID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := getCollectionSnapshot(ctx, uc.snapshotRepo, req.CollectionID, req.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restoring a larger tree than the current one adds to the group's
+	// usage, so the difference must fit before anything is deleted
+	containers, objects := len(snapshot.Containers())-len(collection.Containers()), 0
+	for _, c := range snapshot.Containers() {
+		objects += len(c.Objects())
+	}
+	for _, c := range collection.Containers() {
+		objects -= len(c.Objects())
+	}
+	if containers > 0 {
+		if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaContainers, int64(containers)); err != nil {
+			return nil, err
+		}
+	}
+	if objects > 0 {
+		if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaObjects, int64(objects)); err != nil {
+			return nil, err
+		}
+	}
+
+	backup, err := entities.NewCollectionSnapshot(collection, req.UserID, restoreBackupLabel(snapshot))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.snapshotRepo.Create(ctx, backup); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot of current state: %w", err)
+	}
+
+	if _, err := uc.containerRepo.DeleteByCollectionID(ctx, req.CollectionID); err != nil {
+		return nil, fmt.Errorf("failed to clear containers: %w", err)
+	}
+	// The backup holds its own copy of the list, unlike Containers()
+	for _, c := range backup.Containers() {
+		if err := collection.RemoveContainer(c.ID()); err != nil {
+			return nil, fmt.Errorf("failed to detach container: %w", err)
+		}
+	}
+
+	resp := &RestoreCollectionSnapshotResponse{Backup: backup}
+	for _, container := range snapshot.Containers() {
+		taken, err := uc.containerRepo.Exists(ctx, container.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to check container: %w", err)
+		}
+		if taken {
+			resp.Skipped = append(resp.Skipped, fmt.Sprintf("container %q is now in another collection", container.Name().String()))
+			continue
+		}
+
+		var moved []entities.ObjectID
+		for _, obj := range container.Objects() {
+			_, err := uc.containerRepo.FindByObjectID(ctx, obj.ID())
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					continue
+				}
+				return nil, fmt.Errorf("failed to check object %q: %w", obj.Name().String(), err)
+			}
+			resp.Skipped = append(resp.Skipped, fmt.Sprintf("object %q is now in another collection", obj.Name().String()))
+			moved = append(moved, obj.ID())
+		}
+		for _, id := range moved {
+			if err := container.RemoveObject(id); err != nil {
+				return nil, fmt.Errorf("failed to drop object: %w", err)
+			}
+		}
+
+		if err := uc.containerRepo.Create(ctx, &container); err != nil {
+			return nil, fmt.Errorf("failed to restore container %q: %w", container.Name().String(), err)
+		}
+		// Codes of the replaced tree are left for lookups to prune
+		_ = indexContainerCodes(ctx, uc.codeRepo, req.UserID, &container)
+		if err := collection.AddContainer(container); err != nil {
+			return nil, fmt.Errorf("failed to add container to collection: %w", err)
+		}
+		resp.ContainersRestored++
+		resp.ObjectsRestored += len(container.Objects())
+	}
+
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	// The backup counts towards the limit, but the snapshot just restored
+	// is kept even if it is the oldest
+	if _, err := pruneCollectionSnapshots(ctx, uc.snapshotRepo, req.CollectionID, uc.maxPerCollection, snapshot.ID()); err != nil {
+		return nil, err
+	}
+
+	resp.Collection = collection
+	return resp, nil
+}
+
+// restoreBackupLabel names the snapshot taken before restoring s, falling
+// back to its date when the label would not fit.
+func restoreBackupLabel(s *entities.CollectionSnapshot) string {
+	if label := fmt.Sprintf("Before restoring %q", s.Label()); s.Label() != "" && len(label) <= 100 {
+		return label
+	}
+	return "Before restoring snapshot of " + s.CreatedAt().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestRestoreCollectionSnapshotUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
+	mockSnapshotRepo := mocks.NewMockCollectionSnapshotRepository(mockCtrl)
+	mockQuotaRepo := mocks.NewMockGroupQuotaRepository(mockCtrl)
+	mockUsageRepo := mocks.NewMockUsageRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewRestoreCollectionSnapshotUseCase(mockCollectionRepo, mockContainerRepo, mockCodeRepo, mockSnapshotRepo, mockQuotaRepo, mockUsageRepo, mockAuthService, 0)
+
+	t.Run("success - replaces containers and skips moved objects", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+
+		rice := NewTestObject(ObjName("Rice"), ObjProps(Props("upc", "0123456789012")))
+		beans := NewTestObject(ObjName("Beans"), ObjProps(Props("upc", "0987654321098")))
+		shelf := NewTestContainer(CtrName("Shelf"), CtrCollectionID(collectionID), CtrObjects(*rice, *beans))
+		snapshot := newStoredSnapshot(collectionID, time.Now().Add(-time.Hour), *shelf)
+
+		bin := NewTestContainer(CtrName("Bin"), CtrCollectionID(collectionID))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColContainers(*bin))
+		elsewhere := NewTestContainer(CtrName("Other pantry"))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().GetByID(gomock.Any(), snapshot.ID()).Return(snapshot, nil)
+		mockSnapshotRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, backup *entities.CollectionSnapshot) error {
+				require.Len(t, backup.Containers(), 1)
+				assert.Equal(t, "Bin", backup.Containers()[0].Name().String())
+				return nil
+			})
+		mockContainerRepo.EXPECT().DeleteByCollectionID(gomock.Any(), collectionID).Return(int64(1), nil)
+		mockContainerRepo.EXPECT().Exists(gomock.Any(), shelf.ID()).Return(false, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), rice.ID()).Return(nil, errors.New("container not found"))
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), beans.ID()).Return(elsewhere, nil)
+		mockContainerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, c *entities.Container) error {
+				require.Len(t, c.Objects(), 1)
+				assert.Equal(t, rice.ID(), c.Objects()[0].ID())
+				return nil
+			})
+		// Only the restored object is indexed, not the one that moved away
+		mockCodeRepo.EXPECT().Add(gomock.Any(), userID, "0123456789012", rice.ID()).Return(nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), collection).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), RestoreCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			SnapshotID:   snapshot.ID(),
+			UserID:       userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.ContainersRestored)
+		assert.Equal(t, 1, resp.ObjectsRestored)
+		require.Len(t, resp.Skipped, 1)
+		assert.Contains(t, resp.Skipped[0], "Beans")
+		require.Len(t, resp.Collection.Containers(), 1)
+		assert.Equal(t, shelf.ID(), resp.Collection.Containers()[0].ID())
+		// The snapshot itself is untouched by dropping the moved object
+		assert.Equal(t, 2, snapshot.ObjectCount())
+	})
+
+	t.Run("error - restored objects past the group's quota delete nothing", func(t *testing.T) {
+		userID := entities.NewUserID()
+		groupID, _ := entities.GroupIDFromString("family")
+		collectionID := entities.NewCollectionID()
+
+		shelf := NewTestContainer(CtrName("Shelf"), CtrCollectionID(collectionID), CtrObjects(*NewTestObject(), *NewTestObject(), *NewTestObject()))
+		snapshot := newStoredSnapshot(collectionID, time.Now().Add(-time.Hour), *shelf)
+		bin := NewTestContainer(CtrName("Bin"), CtrCollectionID(collectionID), CtrObjects(*NewTestObject()))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColGroupID(&groupID), ColContainers(*bin))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().GetByID(gomock.Any(), snapshot.ID()).Return(snapshot, nil)
+		expectGroupQuota(mockQuotaRepo, mockUsageRepo, groupID, entities.QuotaObjects, 10, 9)
+
+		resp, err := useCase.Execute(context.Background(), RestoreCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			SnapshotID:   snapshot.ID(),
+			UserID:       userID,
+		})
+
+		var quotaErr *entities.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		// Three objects come back in place of the one there now
+		assert.Equal(t, int64(2), quotaErr.Requested)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - snapshot belongs to another collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		snapshot := newStoredSnapshot(entities.NewCollectionID(), time.Now())
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockSnapshotRepo.EXPECT().GetByID(gomock.Any(), snapshot.ID()).Return(snapshot, nil)
+
+		resp, err := useCase.Execute(context.Background(), RestoreCollectionSnapshotRequest{
+			CollectionID: collectionID,
+			SnapshotID:   snapshot.ID(),
+			UserID:       userID,
+		})
+
+		require.ErrorIs(t, err, entities.ErrCollectionSnapshotNotFound)
+		assert.Nil(t, resp)
+	})
+}
@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// RetagFilter picks the objects of a collection to retag. Every field set
+// must match; an empty filter matches all of the collection's objects.
+type RetagFilter struct {
+	Tag         string
+	ContainerID *entities.ContainerID
+	ObjectType  entities.ObjectType // untyped objects count as the collection's type
+	ObjectIDs   []entities.ObjectID
+}
+
+type RetagObjectsRequest struct {
+	CollectionID entities.CollectionID
+	Filter       RetagFilter
+	AddTags      []string
+	RemoveTags   []string
+	UserID       entities.UserID
+	UserToken    string
+}
+
+type RetagObjectsResponse struct {
+	// Matched is how many objects the filter picked, Updated how many of
+	// them had their tags changed.
+	Matched int
+	Updated int
+}
+
+type RetagObjectsUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewRetagObjectsUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *RetagObjectsUseCase {
+	return &RetagObjectsUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+// Execute adds and removes tags on every active object of the collection
+// matching the filter. All objects are retagged in memory before anything is
+// saved, so an invalid request or a missing container changes nothing.
+func (uc *RetagObjectsUseCase) Execute(ctx context.Context, req RetagObjectsRequest) (*RetagObjectsResponse, error) {
+	if len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+		return nil, errors.New("at least one tag to add or remove is required")
+	}
+	for _, tag := range req.AddTags {
+		if slices.Contains(req.RemoveTags, tag) {
+			return nil, fmt.Errorf("tag %q cannot be both added and removed", tag)
+		}
+	}
+
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	var containers []*entities.Container
+	if req.Filter.ContainerID != nil {
+		container, err := uc.containerRepo.GetByID(ctx, *req.Filter.ContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("container not found: %w", err)
+		}
+		if !container.CollectionID().Equals(req.CollectionID) {
+			return nil, errors.New("container not found in this collection")
+		}
+		containers = []*entities.Container{container}
+	} else {
+		containers, err = uc.containerRepo.GetByCollectionID(ctx, req.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers: %w", err)
+		}
+	}
+
+	resp := &RetagObjectsResponse{}
+	var dirty []*entities.Container
+	for _, container := range containers {
+		changed := false
+		for _, object := range container.ActiveObjects() {
+			if !req.Filter.matches(object, collection.ObjectType()) {
+				continue
+			}
+			resp.Matched++
+			if !object.Retag(req.AddTags, req.RemoveTags) {
+				continue
+			}
+			if err := container.UpdateObject(object.ID(), object); err != nil {
+				return nil, fmt.Errorf("failed to update object in container: %w", err)
+			}
+			resp.Updated++
+			changed = true
+		}
+		if changed {
+			dirty = append(dirty, container)
+		}
+	}
+
+	for _, container := range dirty {
+		if err := uc.containerRepo.Update(ctx, container); err != nil {
+			return nil, fmt.Errorf("failed to save container: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (f RetagFilter) matches(object entities.Object, collectionType entities.ObjectType) bool {
+	if f.Tag != "" && !object.HasTag(f.Tag) {
+		return false
+	}
+	if f.ObjectType != "" {
+		objectType := object.ObjectType()
+		if objectType == "" {
+			objectType = collectionType
+		}
+		if objectType != f.ObjectType {
+			return false
+		}
+	}
+	if len(f.ObjectIDs) > 0 && !slices.ContainsFunc(f.ObjectIDs, object.ID().Equals) {
+		return false
+	}
+	return true
+}
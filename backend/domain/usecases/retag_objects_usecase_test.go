@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestRetagObjectsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewRetagObjectsUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+
+	t.Run("success - retags matching objects and saves only changed containers", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		rice := NewTestObject(ObjName("Rice"), ObjTags("grain", "old"))
+		oats := NewTestObject(ObjName("Oats"), ObjTags("grain", "sale"))
+		milk := NewTestObject(ObjName("Milk"), ObjTags("dairy"))
+		flour := NewTestObject(ObjName("Flour"), ObjTags("grain", "old"), ObjArchivedAt(time.Now()))
+		pantry := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*rice, *milk, *flour))
+		shelf := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*oats))
+		fridge := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*milk))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{pantry, shelf, fridge}, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), pantry).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), RetagObjectsRequest{
+			CollectionID: collectionID,
+			Filter:       RetagFilter{Tag: "grain"},
+			AddTags:      []string{"sale"},
+			RemoveTags:   []string{"old"},
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Matched)
+		assert.Equal(t, 1, resp.Updated, "oats already had sale and lacked old")
+		saved, err := pantry.GetObject(rice.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"grain", "sale"}, saved.Tags())
+		archived, err := pantry.GetObject(flour.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"grain", "old"}, archived.Tags(), "archived objects are left alone")
+	})
+
+	t.Run("success - filters by container, type and object IDs", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		book := NewTestObject(ObjName("Dune"), ObjType(""))
+		typed := NewTestObject(ObjName("Catan"), ObjType(entities.ObjectTypeBoardGame))
+		other := NewTestObject(ObjName("Emma"), ObjType(""))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*book, *typed, *other))
+		containerID := container.ID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), RetagObjectsRequest{
+			CollectionID: collectionID,
+			Filter: RetagFilter{
+				ContainerID: &containerID,
+				ObjectType:  entities.ObjectTypeBook,
+				ObjectIDs:   []entities.ObjectID{book.ID(), typed.ID()},
+			},
+			AddTags:   []string{"favourite"},
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Matched)
+		assert.Equal(t, 1, resp.Updated)
+		saved, err := container.GetObject(book.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"favourite"}, saved.Tags())
+	})
+
+	t.Run("error - tag both added and removed", func(t *testing.T) {
+		resp, err := useCase.Execute(context.Background(), RetagObjectsRequest{
+			CollectionID: entities.NewCollectionID(),
+			AddTags:      []string{"sale"},
+			RemoveTags:   []string{"sale"},
+			UserID:       entities.NewUserID(),
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "both added and removed")
+	})
+
+	t.Run("error - container from another collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		container := NewTestContainer()
+		containerID := container.ID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), containerID).Return(container, nil)
+
+		resp, err := useCase.Execute(context.Background(), RetagObjectsRequest{
+			CollectionID: collectionID,
+			Filter:       RetagFilter{ContainerID: &containerID},
+			AddTags:      []string{"sale"},
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "container not found")
+	})
+
+	t.Run("error - access denied", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(entities.NewUserID()))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), RetagObjectsRequest{
+			CollectionID: collectionID,
+			RemoveTags:   []string{"sale"},
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - saving fails", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*NewTestObject()))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{container}, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(errors.New("db down"))
+
+		resp, err := useCase.Execute(context.Background(), RetagObjectsRequest{
+			CollectionID: collectionID,
+			AddTags:      []string{"sale"},
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "failed to save container")
+	})
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type RevokeSessionsRequest struct {
+	UserID entities.UserID
+	// CurrentSessionID is the session the request was made from, which is
+	// never revoked
+	CurrentSessionID string
+	// SessionID is the one session to revoke. When empty, every other
+	// active session that is not trusted is revoked.
+	SessionID string
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int
+}
+
+// RevokeSessionsUseCase signs a user out of other devices. A revoked
+// session's tokens, and any refreshed from them, are refused from the
+// device's next request on.
+type RevokeSessionsUseCase struct {
+	sessionRepo  repositories.SessionRepository
+	checkSession *CheckSessionUseCase
+}
+
+func NewRevokeSessionsUseCase(sessionRepo repositories.SessionRepository, checkSession *CheckSessionUseCase) *RevokeSessionsUseCase {
+	return &RevokeSessionsUseCase{
+		sessionRepo:  sessionRepo,
+		checkSession: checkSession,
+	}
+}
+
+func (uc *RevokeSessionsUseCase) Execute(ctx context.Context, req RevokeSessionsRequest) (*RevokeSessionsResponse, error) {
+	if req.SessionID != "" && req.SessionID == req.CurrentSessionID {
+		return nil, entities.ErrCannotRevokeCurrentSession
+	}
+
+	now := time.Now()
+	var targets []*entities.Session
+	if req.SessionID != "" {
+		session, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		// Another user's session is reported as missing rather than forbidden
+		if !session.UserID().Equals(req.UserID) || !session.IsActive(now) {
+			return nil, entities.ErrSessionNotFound
+		}
+		targets = append(targets, session)
+	} else {
+		sessions, err := uc.sessionRepo.ListByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+		for _, s := range sessions {
+			if s.ID() != req.CurrentSessionID && !s.IsTrusted() && s.IsActive(now) {
+				targets = append(targets, s)
+			}
+		}
+	}
+
+	for _, s := range targets {
+		s.Revoke(now)
+		if err := uc.sessionRepo.Save(ctx, s); err != nil {
+			return nil, fmt.Errorf("failed to save session: %w", err)
+		}
+		uc.checkSession.Forget(s.ID())
+	}
+	return &RevokeSessionsResponse{Revoked: len(targets)}, nil
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestRevokeSessionsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		sessionRepo  *mocks.MockSessionRepository
+		checkSession *CheckSessionUseCase
+		useCase      *RevokeSessionsUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{sessionRepo: mocks.NewMockSessionRepository(mockCtrl)}
+		f.checkSession = NewCheckSessionUseCase(f.sessionRepo)
+		f.useCase = NewRevokeSessionsUseCase(f.sessionRepo, f.checkSession)
+		return f
+	}
+	userID := entities.NewUserID()
+	now := time.Now()
+	session := func(id string, trusted bool) *entities.Session {
+		return entities.ReconstructSession(id, userID, "Firefox on Linux", "", trusted, now, now, now.Add(time.Hour), nil)
+	}
+
+	t.Run("success - signs out every other untrusted session", func(t *testing.T) {
+		f := setup(t)
+		current, other, trusted := session("current", false), session("other", false), session("trusted", true)
+		expired := entities.ReconstructSession("expired", userID, "", "", false, now, now, now.Add(-time.Minute), nil)
+
+		f.sessionRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.Session{current, other, trusted, expired}, nil)
+		f.sessionRepo.EXPECT().Save(gomock.Any(), other).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), RevokeSessionsRequest{UserID: userID, CurrentSessionID: "current"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Revoked)
+		assert.True(t, other.IsRevoked())
+		assert.False(t, current.IsRevoked())
+		assert.False(t, trusted.IsRevoked())
+	})
+
+	t.Run("success - revokes one trusted session and drops its cached check", func(t *testing.T) {
+		f := setup(t)
+		trusted := session("trusted", true)
+
+		// The check caches the session as active
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "trusted").Return(trusted, nil).Times(3)
+		f.sessionRepo.EXPECT().Save(gomock.Any(), trusted).Return(nil).Times(2)
+		require.NoError(t, f.checkSession.Execute(context.Background(), CheckSessionRequest{UserID: userID, SessionID: "trusted"}))
+
+		resp, err := f.useCase.Execute(context.Background(), RevokeSessionsRequest{UserID: userID, CurrentSessionID: "current", SessionID: "trusted"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Revoked)
+		err = f.checkSession.Execute(context.Background(), CheckSessionRequest{UserID: userID, SessionID: "trusted"})
+		assert.ErrorIs(t, err, entities.ErrSessionRevoked)
+	})
+
+	t.Run("error - the current session", func(t *testing.T) {
+		f := setup(t)
+
+		_, err := f.useCase.Execute(context.Background(), RevokeSessionsRequest{UserID: userID, CurrentSessionID: "current", SessionID: "current"})
+		assert.ErrorIs(t, err, entities.ErrCannotRevokeCurrentSession)
+	})
+
+	t.Run("error - another user's session is not found", func(t *testing.T) {
+		f := setup(t)
+		theirs := entities.ReconstructSession("theirs", entities.NewUserID(), "", "", false, now, now, now.Add(time.Hour), nil)
+
+		f.sessionRepo.EXPECT().GetByID(gomock.Any(), "theirs").Return(theirs, nil)
+
+		_, err := f.useCase.Execute(context.Background(), RevokeSessionsRequest{UserID: userID, CurrentSessionID: "current", SessionID: "theirs"})
+		assert.ErrorIs(t, err, entities.ErrSessionNotFound)
+		assert.False(t, theirs.IsRevoked())
+	})
+}
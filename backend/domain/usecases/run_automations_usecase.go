@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// ErrWebhooksUnavailable is returned for webhooks when the server has no
+// way to send them
+var ErrWebhooksUnavailable = errors.New("webhooks are not available on this server")
+
+// AutomationWebhookPayload is the JSON body a rule's webhook receives.
+type AutomationWebhookPayload struct {
+	Event        string                  `json:"event"`
+	RuleID       string                  `json:"rule_id"`
+	RuleName     string                  `json:"rule_name"`
+	CollectionID string                  `json:"collection_id"`
+	ContainerID  string                  `json:"container_id"`
+	Object       AutomationWebhookObject `json:"object"`
+	OccurredAt   time.Time               `json:"occurred_at"`
+}
+
+// AutomationWebhookObject is the object a webhook is about, as it was when
+// the rule fired.
+type AutomationWebhookObject struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Quantity  *float64   `json:"quantity,omitempty"`
+	Unit      string     `json:"unit,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// RunAutomationsUseCase runs automation rules as object events come in. Its
+// actions write through the repositories rather than the object use cases,
+// so they raise no events of their own and one rule cannot set off another.
+type RunAutomationsUseCase struct {
+	ruleRepo       repositories.AutomationRuleRepository
+	containerRepo  repositories.ContainerRepository
+	moveRepo       repositories.ObjectMoveRepository
+	recurrenceRepo repositories.RecurrenceRuleRepository
+	webhooks       webhookDeliverer
+	logger         *slog.Logger
+}
+
+func NewRunAutomationsUseCase(
+	ruleRepo repositories.AutomationRuleRepository,
+	containerRepo repositories.ContainerRepository,
+	moveRepo repositories.ObjectMoveRepository,
+	recurrenceRepo repositories.RecurrenceRuleRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	keyRepo repositories.WebhookSigningKeyRepository,
+	webhookSender services.WebhookSender,
+	logger *slog.Logger,
+) *RunAutomationsUseCase {
+	return &RunAutomationsUseCase{
+		ruleRepo:       ruleRepo,
+		containerRepo:  containerRepo,
+		moveRepo:       moveRepo,
+		recurrenceRepo: recurrenceRepo,
+		webhooks:       webhookDeliverer{deliveryRepo: deliveryRepo, keyRepo: keyRepo, sender: webhookSender},
+		logger:         logger,
+	}
+}
+
+// Handle runs the actions of each of the acting user's rules that event
+// sets off, in order. A failed action is logged and the rest still run.
+func (uc *RunAutomationsUseCase) Handle(ctx context.Context, event entities.ObjectEvent) {
+	rules, err := uc.ruleRepo.ListByUserID(ctx, event.UserID)
+	if err != nil {
+		uc.logger.Warn("Failed to load automation rules",
+			slog.String("user_id", event.UserID.String()), slog.Any("error", err))
+		return
+	}
+
+	for _, rule := range rules {
+		if !rule.Matches(event) {
+			continue
+		}
+		for _, action := range rule.Actions() {
+			if err := uc.runAction(ctx, rule, action, event); err != nil {
+				uc.logger.Warn("Automation action failed",
+					slog.String("rule_id", rule.ID().String()),
+					slog.String("action", string(action.Kind)),
+					slog.String("object_id", event.Object.ID().String()),
+					slog.Any("error", err))
+			}
+		}
+		// Only shown in the rules editor, so not worth failing over
+		_ = uc.ruleRepo.RecordRun(ctx, rule.ID(), time.Now())
+	}
+}
+
+func (uc *RunAutomationsUseCase) runAction(ctx context.Context, rule *entities.AutomationRule, action entities.AutomationAction, event entities.ObjectEvent) error {
+	switch action.Kind {
+	case entities.ActionAddTag:
+		return uc.addTag(ctx, event.Object.ID(), action.Tag)
+	case entities.ActionMoveToContainer:
+		return uc.move(ctx, rule.UserID(), event.Object.ID(), *action.ContainerID)
+	case entities.ActionAddToShoppingList:
+		return uc.addToShoppingList(ctx, rule.UserID(), event)
+	case entities.ActionFireWebhook:
+		return uc.fireWebhook(ctx, rule, action, event)
+	}
+	return entities.ErrInvalidAutomationAction
+}
+
+// addTag tags the object as it is now, which may differ from the event's
+// copy if an earlier action changed it
+func (uc *RunAutomationsUseCase) addTag(ctx context.Context, objectID entities.ObjectID, tag string) error {
+	container, err := uc.containerRepo.FindByObjectID(ctx, objectID)
+	if err != nil {
+		return fmt.Errorf("object not found: %w", err)
+	}
+	object, err := container.GetObject(objectID)
+	if err != nil {
+		return err
+	}
+	if !object.Retag([]string{tag}, nil) {
+		return nil
+	}
+	if err := container.UpdateObject(objectID, *object); err != nil {
+		return err
+	}
+	return uc.containerRepo.Update(ctx, container)
+}
+
+// move puts the object in the target container, which must be in the same
+// collection, and records the move like one made by hand
+func (uc *RunAutomationsUseCase) move(ctx context.Context, userID entities.UserID, objectID entities.ObjectID, targetID entities.ContainerID) error {
+	current, err := uc.containerRepo.FindByObjectID(ctx, objectID)
+	if err != nil {
+		return fmt.Errorf("object not found: %w", err)
+	}
+	if current.ID().Equals(targetID) {
+		return nil
+	}
+	target, err := uc.containerRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("target container not found: %w", err)
+	}
+	if !target.CollectionID().Equals(current.CollectionID()) {
+		return errors.New("target container is in another collection")
+	}
+	object, err := current.GetObject(objectID)
+	if err != nil {
+		return err
+	}
+
+	move := entities.NewObjectMove(objectID, entities.PlacementOf(current), entities.PlacementOf(target), userID)
+	if err := uc.moveRepo.Create(ctx, move); err != nil {
+		return fmt.Errorf("failed to record object move: %w", err)
+	}
+	if err := current.RemoveObject(objectID); err != nil {
+		return err
+	}
+	if err := uc.containerRepo.Update(ctx, current); err != nil {
+		return fmt.Errorf("failed to save old container: %w", err)
+	}
+	if err := target.AddObject(*object); err != nil {
+		return err
+	}
+	return uc.containerRepo.Update(ctx, target)
+}
+
+// addToShoppingList marks the object's staple due. An object that is not a
+// staple yet becomes a monthly one, which the user can change later.
+func (uc *RunAutomationsUseCase) addToShoppingList(ctx context.Context, userID entities.UserID, event entities.ObjectEvent) error {
+	now := time.Now()
+	staple, err := uc.recurrenceRepo.GetByObjectID(ctx, userID, event.Object.ID())
+	switch {
+	case errors.Is(err, entities.ErrRecurrenceRuleNotFound):
+		monthly := entities.Recurrence{Every: 1, Period: entities.RecurrenceMonthly}
+		staple, err = entities.NewRecurrenceRule(userID, event.Object.ID(), event.CollectionID, event.Object.Name().String(), monthly, nil, now)
+		if err != nil {
+			return err
+		}
+		staple.MarkDue(now)
+		return uc.recurrenceRepo.Create(ctx, staple)
+	case err != nil:
+		return fmt.Errorf("failed to check recurrence rules: %w", err)
+	}
+	if !staple.MarkDue(now) {
+		return nil
+	}
+	return uc.recurrenceRepo.Update(ctx, staple)
+}
+
+// fireWebhook records a delivery of the event and makes its first attempt.
+// A failure is retried later if the action's retry policy allows.
+func (uc *RunAutomationsUseCase) fireWebhook(ctx context.Context, rule *entities.AutomationRule, action entities.AutomationAction, event entities.ObjectEvent) error {
+	if uc.webhooks.sender == nil {
+		return ErrWebhooksUnavailable
+	}
+	object := event.Object
+	body, err := json.Marshal(AutomationWebhookPayload{
+		Event:        string(event.Kind),
+		RuleID:       rule.ID().String(),
+		RuleName:     rule.Name(),
+		CollectionID: event.CollectionID.String(),
+		ContainerID:  event.ContainerID.String(),
+		Object: AutomationWebhookObject{
+			ID:        object.ID().String(),
+			Name:      object.Name().String(),
+			Quantity:  object.Quantity(),
+			Unit:      object.Unit(),
+			Tags:      object.Tags(),
+			ExpiresAt: object.ExpiresAt(),
+		},
+		OccurredAt: event.At,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook: %w", err)
+	}
+
+	delivery := entities.NewWebhookDelivery(rule.UserID(), entities.WebhookDeliveryProps{
+		RuleID:   rule.ID(),
+		RuleName: rule.Name(),
+		URL:      action.URL,
+		Event:    string(event.Kind),
+		Payload:  body,
+		Retry:    action.Retry,
+	}, time.Now())
+	if err := uc.webhooks.deliveryRepo.Create(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	if err := uc.webhooks.attempt(ctx, delivery, action.Secret); err != nil {
+		return err
+	}
+	if delivery.Status() != entities.WebhookSucceeded {
+		return fmt.Errorf("webhook not delivered (%s): %s", delivery.Status(), delivery.LastError())
+	}
+	return nil
+}
+
+// publishEvent hands event to events, which callers may leave nil
+func publishEvent(events services.EventPublisher, event entities.ObjectEvent) {
+	if events != nil {
+		events.Publish(event)
+	}
+}
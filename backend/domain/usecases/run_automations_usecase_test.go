@@ -0,0 +1,257 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestRunAutomationsUseCase_Handle(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRuleRepo := mocks.NewMockAutomationRuleRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+	mockMoveRepo := mocks.NewMockObjectMoveRepository(mockCtrl)
+	mockRecurrenceRepo := mocks.NewMockRecurrenceRuleRepository(mockCtrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(mockCtrl)
+	mockKeyRepo := mocks.NewMockWebhookSigningKeyRepository(mockCtrl)
+	mockWebhookSender := mocks.NewMockWebhookSender(mockCtrl)
+
+	useCase := NewRunAutomationsUseCase(mockRuleRepo, mockContainerRepo, mockMoveRepo, mockRecurrenceRepo, mockDeliveryRepo, mockKeyRepo, mockWebhookSender, slog.New(slog.DiscardHandler))
+
+	now := time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC)
+	userID := entities.NewUserID()
+
+	newRule := func(t *testing.T, trigger entities.AutomationTrigger, actions ...entities.AutomationAction) *entities.AutomationRule {
+		t.Helper()
+		rule, err := entities.NewAutomationRule(userID, entities.AutomationRuleProps{
+			Name:    "Rule",
+			Enabled: true,
+			Trigger: trigger,
+			Actions: actions,
+		}, now)
+		require.NoError(t, err)
+		return rule
+	}
+
+	t.Run("success - a new tag adds another", func(t *testing.T) {
+		previous := NewTestObject(ObjName("Chicken"))
+		tagged := *previous
+		tagged.Retag([]string{"freezer"}, nil)
+		container := NewTestContainer(CtrObjects(tagged))
+		rule := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerTagAdded, Tag: "freezer"},
+			entities.AutomationAction{Kind: entities.ActionAddTag, Tag: "frozen"})
+
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{rule}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), tagged.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+		mockRuleRepo.EXPECT().RecordRun(gomock.Any(), rule.ID(), gomock.Any()).Return(nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectUpdatedEvent(userID, container.CollectionID(), container.ID(), *previous, tagged, now))
+
+		saved, err := container.GetObject(tagged.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"freezer", "frozen"}, saved.Tags())
+	})
+
+	t.Run("success - dropping below the threshold puts the object on the shopping list", func(t *testing.T) {
+		previous := NewTestObject(ObjName("Milk"), ObjQuantity(3))
+		current := NewTestObject(ObjID(previous.ID()), ObjName("Milk"), ObjQuantity(1))
+		collectionID := entities.NewCollectionID()
+		rule := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerQuantityBelow, Threshold: 2},
+			entities.AutomationAction{Kind: entities.ActionAddToShoppingList})
+
+		var created *entities.RecurrenceRule
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{rule}, nil)
+		mockRecurrenceRepo.EXPECT().GetByObjectID(gomock.Any(), userID, current.ID()).Return(nil, entities.ErrRecurrenceRuleNotFound)
+		mockRecurrenceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *entities.RecurrenceRule) error {
+			created = r
+			return nil
+		})
+		mockRuleRepo.EXPECT().RecordRun(gomock.Any(), rule.ID(), gomock.Any()).Return(nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectUpdatedEvent(userID, collectionID, entities.NewContainerID(), *previous, *current, now))
+
+		require.NotNil(t, created)
+		assert.True(t, created.IsDue())
+		assert.Equal(t, "Milk", created.Name())
+		assert.Equal(t, collectionID, created.CollectionID())
+	})
+
+	t.Run("success - staying below the threshold does not fire again", func(t *testing.T) {
+		previous := NewTestObject(ObjQuantity(1))
+		current := NewTestObject(ObjID(previous.ID()), ObjQuantity(0.5))
+		rule := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerQuantityBelow, Threshold: 2},
+			entities.AutomationAction{Kind: entities.ActionAddToShoppingList})
+
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{rule}, nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectUpdatedEvent(userID, entities.NewCollectionID(), entities.NewContainerID(), *previous, *current, now))
+	})
+
+	t.Run("success - moves the object within its collection and records the move", func(t *testing.T) {
+		object := NewTestObject(ObjName("Ice cream"))
+		collectionID := entities.NewCollectionID()
+		fridge := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*object))
+		freezer := NewTestContainer(CtrCollectionID(collectionID))
+		freezerID := freezer.ID()
+		rule := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+			entities.AutomationAction{Kind: entities.ActionMoveToContainer, ContainerID: &freezerID})
+
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{rule}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(fridge, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), freezerID).Return(freezer, nil)
+		mockMoveRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), fridge).Return(nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), freezer).Return(nil)
+		mockRuleRepo.EXPECT().RecordRun(gomock.Any(), rule.ID(), gomock.Any()).Return(nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectCreatedEvent(userID, collectionID, fridge.ID(), *object, now))
+
+		_, err := freezer.GetObject(object.ID())
+		require.NoError(t, err)
+		_, err = fridge.GetObject(object.ID())
+		assert.Error(t, err)
+	})
+
+	t.Run("partial - a failed webhook does not stop the next action", func(t *testing.T) {
+		object := NewTestObject(ObjName("Flour"))
+		container := NewTestContainer(CtrObjects(*object))
+		rule := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+			entities.AutomationAction{
+				Kind:   entities.ActionFireWebhook,
+				URL:    "https://example.com/hook",
+				Secret: "s3cret",
+				Retry:  entities.WebhookRetryPolicy{MaxRetries: 3, Backoff: 60},
+			},
+			entities.AutomationAction{Kind: entities.ActionAddTag, Tag: "baking"})
+
+		var delivery *entities.WebhookDelivery
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{rule}, nil)
+		mockDeliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *entities.WebhookDelivery) error {
+			delivery = d
+			return nil
+		})
+		mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hook services.Webhook) error {
+			assert.Equal(t, "https://example.com/hook", hook.URL)
+			assert.Equal(t, []string{"s3cret"}, hook.Secrets)
+			assert.Equal(t, string(entities.ObjectCreated), hook.Event)
+			var payload AutomationWebhookPayload
+			require.NoError(t, json.Unmarshal(hook.Body, &payload))
+			assert.Equal(t, rule.ID().String(), payload.RuleID)
+			assert.Equal(t, "Flour", payload.Object.Name)
+			return errors.New("connection refused")
+		})
+		mockDeliveryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), container).Return(nil)
+		mockRuleRepo.EXPECT().RecordRun(gomock.Any(), rule.ID(), gomock.Any()).Return(nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectCreatedEvent(userID, container.CollectionID(), container.ID(), *object, now))
+
+		require.NotNil(t, delivery)
+		assert.Equal(t, entities.WebhookPending, delivery.Status(), "a retry is scheduled")
+		assert.Equal(t, 1, delivery.Attempts())
+		assert.Equal(t, "connection refused", delivery.LastError())
+		require.NotNil(t, delivery.NextAttemptAt())
+	})
+
+	t.Run("success - webhooks without a secret are signed with the account's keys", func(t *testing.T) {
+		object := NewTestObject()
+		rule := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+			entities.AutomationAction{Kind: entities.ActionFireWebhook, URL: "https://example.com/hook"})
+		keys := entities.NewWebhookSigningKeys(userID, "old", now)
+		require.NoError(t, keys.Rotate("new", time.Hour, time.Now()))
+
+		var delivery *entities.WebhookDelivery
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{rule}, nil)
+		mockDeliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *entities.WebhookDelivery) error {
+			delivery = d
+			return nil
+		})
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(keys, nil)
+		mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hook services.Webhook) error {
+			assert.Equal(t, []string{"new", "old"}, hook.Secrets, "both keys sign during the grace period")
+			return nil
+		})
+		mockDeliveryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockRuleRepo.EXPECT().RecordRun(gomock.Any(), rule.ID(), gomock.Any()).Return(nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectCreatedEvent(userID, entities.NewCollectionID(), entities.NewContainerID(), *object, now))
+
+		require.NotNil(t, delivery)
+		assert.Equal(t, entities.WebhookSucceeded, delivery.Status())
+		assert.Nil(t, delivery.NextAttemptAt())
+	})
+
+	t.Run("success - disabled rules and other users' changes are ignored", func(t *testing.T) {
+		object := NewTestObject()
+		disabled := newRule(t,
+			entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+			entities.AutomationAction{Kind: entities.ActionAddTag, Tag: "new"})
+		require.NoError(t, disabled.Update(entities.AutomationRuleProps{
+			Name:    "Rule",
+			Trigger: disabled.Trigger(),
+			Actions: disabled.Actions(),
+		}, now))
+		other := entities.NewUserID()
+
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.AutomationRule{disabled}, nil)
+		mockRuleRepo.EXPECT().ListByUserID(gomock.Any(), other).Return(nil, nil)
+
+		useCase.Handle(context.Background(), entities.NewObjectCreatedEvent(userID, entities.NewCollectionID(), entities.NewContainerID(), *object, now))
+		useCase.Handle(context.Background(), entities.NewObjectCreatedEvent(other, entities.NewCollectionID(), entities.NewContainerID(), *object, now))
+	})
+}
+
+func TestAutomationTrigger_ExpiresWithin(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+	at := since.Add(time.Hour)
+	trigger := entities.AutomationTrigger{Kind: entities.TriggerExpiresWithin, Days: 3}
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{"window opened during the span", since.AddDate(0, 0, 3).Add(30 * time.Minute), true},
+		{"window opened at the end of the span", at.AddDate(0, 0, 3), true},
+		{"window opened before the span", since.AddDate(0, 0, 3), false},
+		{"window opens after the span", at.AddDate(0, 0, 3).Add(time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := NewTestObject(ObjExpiresAt(tt.expiresAt))
+			event := entities.NewObjectExpiringEvent(entities.NewUserID(), entities.NewCollectionID(), entities.NewContainerID(), *object, since, at)
+			assert.Equal(t, tt.want, trigger.Matches(event))
+		})
+	}
+
+	t.Run("changes to an object never match", func(t *testing.T) {
+		object := NewTestObject(ObjExpiresAt(since.AddDate(0, 0, 3).Add(30 * time.Minute)))
+		event := entities.NewObjectCreatedEvent(entities.NewUserID(), entities.NewCollectionID(), entities.NewContainerID(), *object, at)
+		assert.False(t, trigger.Matches(event))
+	})
+}
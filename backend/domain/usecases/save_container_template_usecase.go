@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type SaveContainerTemplateRequest struct {
+	CollectionID entities.CollectionID
+	// RootContainerID limits the template to one container and everything
+	// nested inside it. When nil, the collection's whole container tree is used.
+	RootContainerID *entities.ContainerID
+	Name            string
+	Description     string
+	UserID          entities.UserID
+	UserToken       string
+}
+
+type SaveContainerTemplateResponse struct {
+	Template *entities.ContainerTemplate
+}
+
+type SaveContainerTemplateUseCase struct {
+	templateRepo   repositories.ContainerTemplateRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewSaveContainerTemplateUseCase(
+	templateRepo repositories.ContainerTemplateRepository,
+	collectionRepo repositories.CollectionRepository,
+	authService services.AuthService,
+) *SaveContainerTemplateUseCase {
+	return &SaveContainerTemplateUseCase{
+		templateRepo:   templateRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *SaveContainerTemplateUseCase) Execute(ctx context.Context, req SaveContainerTemplateRequest) (*SaveContainerTemplateResponse, error) {
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	// Check access: user is owner OR user is member of collection's group
+	hasAccess := collection.IsOwnedBy(req.UserID)
+	if !hasAccess && collection.GroupID() != nil {
+		for _, group := range userGroups {
+			if group.ID().Equals(*collection.GroupID()) {
+				hasAccess = true
+				break
+			}
+		}
+	}
+
+	if !hasAccess {
+		return nil, errors.New("access denied: user does not have access to this collection")
+	}
+
+	containers := collection.Containers()
+
+	var roots []entities.Container
+	if req.RootContainerID != nil {
+		root, err := collection.GetContainer(*req.RootContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("container not found: %w", err)
+		}
+		roots = []entities.Container{*root}
+	} else {
+		for _, c := range containers {
+			// Containers whose parent is outside the collection are treated
+			// as roots too, so nothing is silently dropped.
+			if c.ParentContainerID() == nil {
+				roots = append(roots, c)
+				continue
+			}
+			if _, err := collection.GetContainer(*c.ParentContainerID()); err != nil {
+				roots = append(roots, c)
+			}
+		}
+	}
+
+	nodes := entities.ContainerTemplateNodesFromContainers(roots, containers)
+	template, err := entities.NewContainerTemplate(req.UserID, req.Name, req.Description, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save container template: %w", err)
+	}
+
+	return &SaveContainerTemplateResponse{
+		Template: template,
+	}, nil
+}
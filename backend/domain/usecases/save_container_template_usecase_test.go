@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestSaveContainerTemplateUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		useCase        *SaveContainerTemplateUseCase
+		collectionRepo *mocks.MockCollectionRepository
+		templateRepo   *mocks.MockContainerTemplateRepository
+	}
+
+	setup := func(t *testing.T) fixture {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			templateRepo:   mocks.NewMockContainerTemplateRepository(mockCtrl),
+		}
+		authService := mocks.NewMockAuthService(mockCtrl)
+		authService.EXPECT().GetUserGroups(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		f.useCase = NewSaveContainerTemplateUseCase(f.templateRepo, f.collectionRepo, authService)
+		return f
+	}
+
+	userID := entities.NewUserID()
+	room := NewTestContainer(CtrName("Garage"), CtrType(entities.ContainerTypeRoom), CtrObjects(*NewTestObject()))
+	rack := NewTestContainer(CtrName("Rack"), CtrType(entities.ContainerTypeShelf), CtrParentID(room.ID()))
+	closet := NewTestContainer(CtrName("Closet"), CtrType(entities.ContainerTypeCabinet))
+
+	t.Run("whole collection tree", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection(ColUserID(userID), ColContainers(*rack, *room, *closet))
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.templateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), SaveContainerTemplateRequest{
+			CollectionID: col.ID(), Name: "My House", UserID: userID,
+		})
+
+		require.NoError(t, err)
+		nodes := resp.Template.Nodes()
+		require.Len(t, nodes, 2)
+		assert.Equal(t, "Garage", nodes[0].Name)
+		require.Len(t, nodes[0].Children, 1)
+		assert.Equal(t, "Rack", nodes[0].Children[0].Name)
+		assert.Equal(t, "Closet", nodes[1].Name)
+		assert.True(t, resp.Template.IsOwnedBy(userID))
+	})
+
+	t.Run("single subtree", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection(ColUserID(userID), ColContainers(*room, *rack, *closet))
+		rootID := room.ID()
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+		f.templateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), SaveContainerTemplateRequest{
+			CollectionID: col.ID(), RootContainerID: &rootID, Name: "Garage", UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Template.ContainerCount())
+	})
+
+	t.Run("empty collection is rejected", func(t *testing.T) {
+		t.Parallel()
+		f := setup(t)
+
+		col := NewTestCollection(ColUserID(userID))
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), col.ID()).Return(col, nil)
+
+		_, err := f.useCase.Execute(context.Background(), SaveContainerTemplateRequest{
+			CollectionID: col.ID(), Name: "Nothing", UserID: userID,
+		})
+
+		require.ErrorIs(t, err, entities.ErrEmptyContainerTemplate)
+	})
+}
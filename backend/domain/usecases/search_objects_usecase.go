@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// searchSnippetContext is how many bytes of text are kept on each side of
+// the first match in a snippet
+const searchSnippetContext = 40
+
+// Fields a search match can be found in, best first
+const (
+	SearchFieldName        = "name"
+	SearchFieldTags        = "tags"
+	SearchFieldDescription = "description"
+	SearchFieldLocation    = "location"
+	SearchFieldProperties  = "properties"
+)
+
+type SearchObjectsRequest struct {
+	UserID    entities.UserID
+	UserToken string
+	Query     string
+	// Limit caps the results, up to entities.MaxSearchResults
+	Limit int
+}
+
+// TextRange is a half-open byte range [Start, End) of a snippet
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// SearchMatch is an object matching every term of a query, where to find it,
+// and the text it matched with the matched terms marked.
+type SearchMatch struct {
+	Object         entities.Object
+	ContainerID    entities.ContainerID
+	ContainerName  string
+	CollectionID   entities.CollectionID
+	CollectionName string
+	// Field is the best field a term matched in, one of the SearchField
+	// constants
+	Field string
+	// Snippet is the text of Field around the first match, with "…" where
+	// it was cut
+	Snippet    string
+	Highlights []TextRange
+}
+
+type SearchObjectsResponse struct {
+	// Matches are ordered by where the query matched, names first, then by
+	// object name.
+	Matches []SearchMatch
+	// Truncated is set when more objects matched than Limit
+	Truncated bool
+}
+
+type SearchObjectsUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	authService    services.AuthService
+}
+
+func NewSearchObjectsUseCase(collectionRepo repositories.CollectionRepository, containerRepo repositories.ContainerRepository, authService services.AuthService) *SearchObjectsUseCase {
+	return &SearchObjectsUseCase{
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		authService:    authService,
+	}
+}
+
+// Execute finds the active objects in every collection the user can see
+// whose name, tags, description, location or property values contain each
+// whitespace-separated term of the query, ignoring case.
+func (uc *SearchObjectsUseCase) Execute(ctx context.Context, req SearchObjectsRequest) (*SearchObjectsResponse, error) {
+	terms := strings.Fields(req.Query)
+	if len(terms) == 0 {
+		return nil, entities.ErrEmptySearchQuery
+	}
+	if req.Limit < 1 || req.Limit > entities.MaxSearchResults {
+		return nil, entities.ErrInvalidSearchLimit
+	}
+
+	collections, err := listAccessibleCollections(ctx, uc.collectionRepo, uc.authService, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []SearchMatch{}
+	for _, col := range collections {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		containers, err := uc.containerRepo.GetByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers for collection %s: %w", col.ID().String(), err)
+		}
+		for _, container := range containers {
+			for _, obj := range container.ActiveObjects() {
+				match, ok := matchObject(obj, terms)
+				if !ok {
+					continue
+				}
+				match.ContainerID = container.ID()
+				match.ContainerName = container.Name().String()
+				match.CollectionID = col.ID()
+				match.CollectionName = col.Name().String()
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b SearchMatch) int {
+		if c := searchFieldRank(a.Field) - searchFieldRank(b.Field); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.Object.Name().String()), strings.ToLower(b.Object.Name().String()))
+	})
+
+	resp := &SearchObjectsResponse{Matches: matches}
+	if len(matches) > req.Limit {
+		resp.Matches = matches[:req.Limit]
+		resp.Truncated = true
+	}
+	return resp, nil
+}
+
+// searchField is one searchable text of an object
+type searchField struct {
+	name string
+	text string
+}
+
+func objectSearchFields(obj entities.Object) []searchField {
+	fields := []searchField{
+		{SearchFieldName, obj.Name().String()},
+		{SearchFieldTags, strings.Join(obj.Tags(), ", ")},
+		{SearchFieldDescription, obj.Description().String()},
+		{SearchFieldLocation, obj.Location()},
+	}
+	props := obj.Properties()
+	values := make([]string, 0, len(props))
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		if v := props[k].DisplayString(); v != "" {
+			values = append(values, k+": "+v)
+		}
+	}
+	return append(fields, searchField{SearchFieldProperties, strings.Join(values, ", ")})
+}
+
+// matchObject reports whether every term occurs in some field of obj and
+// builds the snippet from the best field holding a term
+func matchObject(obj entities.Object, terms []string) (SearchMatch, bool) {
+	fields := objectSearchFields(obj)
+	for _, term := range terms {
+		if !slices.ContainsFunc(fields, func(f searchField) bool { return indexFold(f.text, term) >= 0 }) {
+			return SearchMatch{}, false
+		}
+	}
+
+	for _, f := range fields {
+		ranges := findTerms(f.text, terms)
+		if len(ranges) == 0 {
+			continue
+		}
+		snippet, highlights := snippetAround(f.text, ranges)
+		return SearchMatch{Object: obj, Field: f.name, Snippet: snippet, Highlights: highlights}, true
+	}
+	return SearchMatch{}, false
+}
+
+// findTerms returns every non-overlapping occurrence of the terms in text,
+// in order
+func findTerms(text string, terms []string) []TextRange {
+	var ranges []TextRange
+	for _, term := range terms {
+		for offset := 0; offset < len(text); {
+			i := indexFold(text[offset:], term)
+			if i < 0 {
+				break
+			}
+			start := offset + i
+			ranges = append(ranges, TextRange{Start: start, End: start + len(term)})
+			offset = start + len(term)
+		}
+	}
+	slices.SortFunc(ranges, func(a, b TextRange) int { return a.Start - b.Start })
+
+	// Terms that overlap ("can" and "candle") are merged into one range
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// snippetAround cuts text to searchSnippetContext bytes either side of the
+// first range, on rune boundaries, and shifts the ranges that remain
+func snippetAround(text string, ranges []TextRange) (string, []TextRange) {
+	start := max(ranges[0].Start-searchSnippetContext, 0)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	end := min(ranges[0].End+searchSnippetContext, len(text))
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	prefix := ""
+	if start > 0 {
+		prefix = "…"
+	}
+	snippet := prefix + text[start:end]
+	if end < len(text) {
+		snippet += "…"
+	}
+
+	shift := len(prefix) - start
+	highlights := []TextRange{}
+	for _, r := range ranges {
+		if r.Start < start || r.End > end {
+			continue
+		}
+		highlights = append(highlights, TextRange{Start: r.Start + shift, End: r.End + shift})
+	}
+	return snippet, highlights
+}
+
+// indexFold is strings.Index ignoring case
+func indexFold(s, substr string) int {
+	for i := range s {
+		if len(s)-i < len(substr) {
+			break
+		}
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func searchFieldRank(field string) int {
+	return slices.Index([]string{SearchFieldName, SearchFieldTags, SearchFieldDescription, SearchFieldLocation, SearchFieldProperties}, field)
+}
@@ -0,0 +1,115 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestSearchObjectsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	userID := entities.NewUserID()
+	collectionID := entities.NewCollectionID()
+	collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColName("Kitchen"))
+	pantry := NewTestContainer(CtrName("Pantry"), CtrCollectionID(collectionID), CtrObjects(
+		*NewTestObject(ObjName("Tomato soup"), ObjTags("canned")),
+		*NewTestObject(ObjName("Olive oil"), ObjDesc("Cold pressed, good for tomato salad")),
+		*NewTestObject(ObjName("Beans"), ObjTags("canned", "tomato sauce")),
+		*NewTestObject(ObjName("Canned tomatoes"), ObjArchivedAt(NewTestObject().CreatedAt())),
+		*NewTestObject(ObjName("Rice")),
+	))
+
+	search := func(t *testing.T, query string, limit int) *SearchObjectsResponse {
+		t.Helper()
+		mockCtrl := gomock.NewController(t)
+		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+		mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+		mockAuthService := mocks.NewMockAuthService(mockCtrl)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByUserIDSummary(gomock.Any(), userID).Return([]*entities.Collection{collection}, nil)
+		mockCollectionRepo.EXPECT().GetGroupOwnedSummary(gomock.Any(), gomock.Len(0)).Return(nil, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collectionID).Return([]*entities.Container{pantry}, nil)
+
+		resp, err := NewSearchObjectsUseCase(mockCollectionRepo, mockContainerRepo, mockAuthService).Execute(context.Background(), SearchObjectsRequest{
+			UserID: userID, UserToken: "token", Query: query, Limit: limit,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	names := func(resp *SearchObjectsResponse) []string {
+		out := make([]string, len(resp.Matches))
+		for i, m := range resp.Matches {
+			out[i] = m.Object.Name().String()
+		}
+		return out
+	}
+
+	t.Run("ranks name matches first and skips archived", func(t *testing.T) {
+		t.Parallel()
+		resp := search(t, "TOMATO", 20)
+
+		assert.Equal(t, []string{"Tomato soup", "Beans", "Olive oil"}, names(resp))
+		assert.False(t, resp.Truncated)
+
+		soup := resp.Matches[0]
+		assert.Equal(t, SearchFieldName, soup.Field)
+		assert.Equal(t, "Pantry", soup.ContainerName)
+		assert.Equal(t, "Kitchen", soup.CollectionName)
+		assert.Equal(t, []TextRange{{Start: 0, End: 6}}, soup.Highlights)
+		assert.Equal(t, SearchFieldTags, resp.Matches[1].Field)
+	})
+
+	t.Run("every term must match", func(t *testing.T) {
+		t.Parallel()
+		resp := search(t, "canned sauce", 20)
+
+		require.Equal(t, []string{"Beans"}, names(resp))
+		match := resp.Matches[0]
+		assert.Equal(t, "canned, tomato sauce", match.Snippet)
+		for _, h := range match.Highlights {
+			assert.Contains(t, []string{"canned", "sauce"}, match.Snippet[h.Start:h.End])
+		}
+		assert.Len(t, match.Highlights, 2)
+	})
+
+	t.Run("limit truncates", func(t *testing.T) {
+		t.Parallel()
+		resp := search(t, "o", 2)
+
+		assert.Len(t, resp.Matches, 2)
+		assert.True(t, resp.Truncated)
+	})
+}
+
+func TestSearchObjectsUseCase_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	useCase := NewSearchObjectsUseCase(mocks.NewMockCollectionRepository(mockCtrl), mocks.NewMockContainerRepository(mockCtrl), mocks.NewMockAuthService(mockCtrl))
+
+	_, err := useCase.Execute(context.Background(), SearchObjectsRequest{Query: "  ", Limit: 10})
+	assert.ErrorIs(t, err, entities.ErrEmptySearchQuery)
+
+	_, err = useCase.Execute(context.Background(), SearchObjectsRequest{Query: "milk", Limit: entities.MaxSearchResults + 1})
+	assert.ErrorIs(t, err, entities.ErrInvalidSearchLimit)
+}
+
+func TestSnippetAround(t *testing.T) {
+	t.Parallel()
+
+	text := strings.Repeat("é", 30) + "needle" + strings.Repeat("x", 60)
+	snippet, highlights := snippetAround(text, findTerms(text, []string{"NEEDLE"}))
+
+	require.Len(t, highlights, 1)
+	assert.Equal(t, "needle", snippet[highlights[0].Start:highlights[0].End])
+	assert.True(t, strings.HasPrefix(snippet, "…é"), "cut on a rune boundary: %q", snippet)
+	assert.True(t, strings.HasSuffix(snippet, "x…"))
+}
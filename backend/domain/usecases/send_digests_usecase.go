@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type SendDigestsRequest struct {
+	Now                time.Time
+	ExpiringWithin     time.Duration
+	LowStockThreshold  float64
+	UnsubscribeBaseURL string // e.g. https://host:3001; the token path is appended
+}
+
+type SendDigestsResponse struct {
+	Sent    int
+	Skipped int // due but nothing to report
+	Failed  int
+}
+
+type SendDigestsUseCase struct {
+	digestRepo     repositories.DigestSubscriptionRepository
+	collectionRepo repositories.CollectionRepository
+	containerRepo  repositories.ContainerRepository
+	emailService   services.EmailService
+	logger         *slog.Logger
+}
+
+func NewSendDigestsUseCase(
+	digestRepo repositories.DigestSubscriptionRepository,
+	collectionRepo repositories.CollectionRepository,
+	containerRepo repositories.ContainerRepository,
+	emailService services.EmailService,
+	logger *slog.Logger,
+) *SendDigestsUseCase {
+	return &SendDigestsUseCase{
+		digestRepo:     digestRepo,
+		collectionRepo: collectionRepo,
+		containerRepo:  containerRepo,
+		emailService:   emailService,
+		logger:         logger,
+	}
+}
+
+// Execute sends every digest that is due at req.Now. A failure for one user is
+// logged and counted but does not stop the others.
+func (uc *SendDigestsUseCase) Execute(ctx context.Context, req SendDigestsRequest) (*SendDigestsResponse, error) {
+	subscriptions, err := uc.digestRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscriptions: %w", err)
+	}
+
+	resp := &SendDigestsResponse{}
+	for _, sub := range subscriptions {
+		if !sub.IsDue(req.Now) {
+			continue
+		}
+
+		report, err := uc.buildReport(ctx, sub, req)
+		if err != nil {
+			uc.logger.Error("Failed to build digest",
+				slog.String("user_id", sub.UserID().String()),
+				slog.Any("error", err))
+			resp.Failed++
+			continue
+		}
+
+		if !report.IsEmpty() {
+			if err := uc.emailService.SendDigest(ctx, sub.Email(), report); err != nil {
+				uc.logger.Error("Failed to send digest",
+					slog.String("user_id", sub.UserID().String()),
+					slog.Any("error", err))
+				resp.Failed++
+				continue
+			}
+			resp.Sent++
+		} else {
+			resp.Skipped++
+		}
+
+		// Mark empty digests as sent too, so the next one covers a fresh period
+		// rather than being retried on every tick.
+		sub.MarkSent(req.Now)
+		if err := uc.digestRepo.Save(ctx, sub); err != nil {
+			uc.logger.Error("Failed to record digest delivery",
+				slog.String("user_id", sub.UserID().String()),
+				slog.Any("error", err))
+		}
+	}
+
+	return resp, nil
+}
+
+func (uc *SendDigestsUseCase) buildReport(ctx context.Context, sub *entities.DigestSubscription, req SendDigestsRequest) (*services.DigestReport, error) {
+	collections, err := uc.accessibleCollections(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	since := sub.Since(req.Now)
+	expiringBefore := req.Now.Add(req.ExpiringWithin)
+
+	report := &services.DigestReport{
+		Frequency:      sub.Frequency(),
+		Since:          since,
+		UnsubscribeURL: req.UnsubscribeBaseURL + "/digest/unsubscribe?token=" + url.QueryEscape(sub.UnsubscribeToken()),
+	}
+
+	for _, col := range collections {
+		containers, err := uc.containerRepo.GetByCollectionID(ctx, col.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get containers for collection %s: %w", col.ID().String(), err)
+		}
+
+		isGroupCollection := col.GroupID() != nil
+		for _, container := range containers {
+			for _, obj := range container.ActiveObjects() {
+				item := services.DigestItem{
+					Name:           obj.Name().String(),
+					CollectionName: col.Name().String(),
+					Quantity:       obj.Quantity(),
+					Unit:           obj.Unit(),
+					ExpiresAt:      obj.ExpiresAt(),
+					UpdatedAt:      obj.UpdatedAt(),
+				}
+
+				if exp := obj.ExpiresAt(); exp != nil && !exp.Before(req.Now) && exp.Before(expiringBefore) {
+					report.Expiring = append(report.Expiring, item)
+				}
+				if isDigestLowStock(obj, req.LowStockThreshold) {
+					report.LowStock = append(report.LowStock, item)
+				}
+				if isGroupCollection && obj.UpdatedAt().After(since) {
+					report.RecentActivity = append(report.RecentActivity, item)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(report.Expiring, func(a, b services.DigestItem) int {
+		return a.ExpiresAt.Compare(*b.ExpiresAt)
+	})
+	slices.SortFunc(report.LowStock, func(a, b services.DigestItem) int {
+		return cmp.Compare(*a.Quantity, *b.Quantity)
+	})
+	slices.SortFunc(report.RecentActivity, func(a, b services.DigestItem) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+
+	return report, nil
+}
+
+// accessibleCollections returns the user's own collections plus those shared
+// with the groups captured on the subscription, without duplicates.
+func (uc *SendDigestsUseCase) accessibleCollections(ctx context.Context, sub *entities.DigestSubscription) ([]*entities.Collection, error) {
+	owned, err := uc.collectionRepo.GetByUserID(ctx, sub.UserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	seen := make(map[string]bool, len(owned))
+	collections := make([]*entities.Collection, 0, len(owned))
+	for _, col := range owned {
+		seen[col.ID().String()] = true
+		collections = append(collections, col)
+	}
+
+	for _, groupID := range sub.GroupIDs() {
+		groupCollections, err := uc.collectionRepo.GetByGroupID(ctx, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collections for group %s: %w", groupID.String(), err)
+		}
+		for _, col := range groupCollections {
+			if !seen[col.ID().String()] {
+				seen[col.ID().String()] = true
+				collections = append(collections, col)
+			}
+		}
+	}
+
+	return collections, nil
+}
+
+// isDigestLowStock uses the object's own min quantity when set and the
+// digest-wide threshold otherwise.
+func isDigestLowStock(obj entities.Object, threshold float64) bool {
+	if obj.MinQuantity() != nil {
+		return obj.IsLowStock()
+	}
+	q := obj.Quantity()
+	return q != nil && *q <= threshold
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestSendDigestsUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	email, _ := entities.NewEmailAddress("user@example.com")
+
+	newSubscription := func(t *testing.T, lastSent *time.Time) *entities.DigestSubscription {
+		t.Helper()
+		return entities.ReconstructDigestSubscription(
+			entities.NewUserID(), email, entities.DigestFrequencyDaily, nil,
+			"token", lastSent, now, now,
+		)
+	}
+
+	request := SendDigestsRequest{
+		Now:                now,
+		ExpiringWithin:     7 * 24 * time.Hour,
+		LowStockThreshold:  1,
+		UnsubscribeBaseURL: "https://nishiki.example",
+	}
+
+	t.Run("success - expiring and low stock items", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		mockDigestRepo := mocks.NewMockDigestSubscriptionRepository(mockCtrl)
+		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+		mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+		mockEmail := mocks.NewMockEmailService(mockCtrl)
+		useCase := NewSendDigestsUseCase(mockDigestRepo, mockCollectionRepo, mockContainerRepo, mockEmail, slog.New(slog.DiscardHandler))
+
+		sub := newSubscription(t, nil)
+		collection := NewTestCollection(ColUserID(sub.UserID()), ColName("Pantry"))
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(
+			*NewTestObject(ObjName("Milk"), ObjExpiresAt(now.Add(48*time.Hour))),
+			*NewTestObject(ObjName("Rice"), ObjQuantity(0.5)),
+			*NewTestObject(ObjName("Flour"), ObjQuantity(5), ObjExpiresAt(now.Add(90*24*time.Hour))),
+			*NewTestObject(ObjName("Eggs"), ObjQuantity(4), ObjMinQuantity(6)),
+			*NewTestObject(ObjName("Salt"), ObjQuantity(0.5), ObjMinQuantity(0.25)),
+		))
+
+		mockDigestRepo.EXPECT().ListActive(gomock.Any()).Return([]*entities.DigestSubscription{sub}, nil)
+		mockCollectionRepo.EXPECT().GetByUserID(gomock.Any(), sub.UserID()).Return([]*entities.Collection{collection}, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collection.ID()).Return([]*entities.Container{container}, nil)
+
+		var sent *services.DigestReport
+		mockEmail.EXPECT().SendDigest(gomock.Any(), email, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ entities.EmailAddress, r *services.DigestReport) error {
+				sent = r
+				return nil
+			})
+		mockDigestRepo.EXPECT().Save(gomock.Any(), sub).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Sent)
+		require.NotNil(t, sent)
+		require.Len(t, sent.Expiring, 1)
+		assert.Equal(t, "Milk", sent.Expiring[0].Name)
+		assert.Equal(t, "Pantry", sent.Expiring[0].CollectionName)
+		require.Len(t, sent.LowStock, 2, "own min_quantity overrides the digest threshold")
+		assert.Equal(t, "Rice", sent.LowStock[0].Name)
+		assert.Equal(t, "Eggs", sent.LowStock[1].Name)
+		assert.Empty(t, sent.RecentActivity)
+		assert.Contains(t, sent.UnsubscribeURL, "token=token")
+		require.NotNil(t, sub.LastSentAt())
+		assert.True(t, sub.LastSentAt().Equal(now))
+	})
+
+	t.Run("skips subscriptions that are not due", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		mockDigestRepo := mocks.NewMockDigestSubscriptionRepository(mockCtrl)
+		useCase := NewSendDigestsUseCase(mockDigestRepo, mocks.NewMockCollectionRepository(mockCtrl),
+			mocks.NewMockContainerRepository(mockCtrl), mocks.NewMockEmailService(mockCtrl), slog.New(slog.DiscardHandler))
+
+		lastSent := now.Add(-time.Hour)
+		mockDigestRepo.EXPECT().ListActive(gomock.Any()).Return([]*entities.DigestSubscription{newSubscription(t, &lastSent)}, nil)
+
+		resp, err := useCase.Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Zero(t, resp.Sent)
+		assert.Zero(t, resp.Skipped)
+	})
+
+	t.Run("empty report is not emailed", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		mockDigestRepo := mocks.NewMockDigestSubscriptionRepository(mockCtrl)
+		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+		useCase := NewSendDigestsUseCase(mockDigestRepo, mockCollectionRepo, mocks.NewMockContainerRepository(mockCtrl),
+			mocks.NewMockEmailService(mockCtrl), slog.New(slog.DiscardHandler))
+
+		sub := newSubscription(t, nil)
+		mockDigestRepo.EXPECT().ListActive(gomock.Any()).Return([]*entities.DigestSubscription{sub}, nil)
+		mockCollectionRepo.EXPECT().GetByUserID(gomock.Any(), sub.UserID()).Return(nil, nil)
+		mockDigestRepo.EXPECT().Save(gomock.Any(), sub).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Skipped)
+	})
+
+	t.Run("send failure leaves subscription due", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtrl := gomock.NewController(t)
+		mockDigestRepo := mocks.NewMockDigestSubscriptionRepository(mockCtrl)
+		mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+		mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+		mockEmail := mocks.NewMockEmailService(mockCtrl)
+		useCase := NewSendDigestsUseCase(mockDigestRepo, mockCollectionRepo, mockContainerRepo, mockEmail, slog.New(slog.DiscardHandler))
+
+		sub := newSubscription(t, nil)
+		collection := NewTestCollection(ColUserID(sub.UserID()))
+		container := NewTestContainer(CtrObjects(*NewTestObject(ObjQuantity(0))))
+
+		mockDigestRepo.EXPECT().ListActive(gomock.Any()).Return([]*entities.DigestSubscription{sub}, nil)
+		mockCollectionRepo.EXPECT().GetByUserID(gomock.Any(), sub.UserID()).Return([]*entities.Collection{collection}, nil)
+		mockContainerRepo.EXPECT().GetByCollectionID(gomock.Any(), collection.ID()).Return([]*entities.Container{container}, nil)
+		mockEmail.EXPECT().SendDigest(gomock.Any(), email, gomock.Any()).Return(errors.New("smtp down"))
+
+		resp, err := useCase.Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Failed)
+		assert.Nil(t, sub.LastSentAt())
+	})
+}
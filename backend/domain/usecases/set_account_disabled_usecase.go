@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type SetAccountDisabledRequest struct {
+	UserID   entities.UserID
+	Disabled bool
+	// AdminID is the admin making the change, who may not disable themselves.
+	AdminID entities.UserID
+}
+
+type SetAccountDisabledResponse struct {
+	Status *entities.AccountStatus
+}
+
+// SetAccountDisabledUseCase disables or re-enables an account. A disabled
+// user's requests are refused from their next request on; their data is
+// kept. Callers check the admin role.
+type SetAccountDisabledUseCase struct {
+	accountStatusRepo repositories.AccountStatusRepository
+	checkAccount      *CheckAccountUseCase
+}
+
+func NewSetAccountDisabledUseCase(accountStatusRepo repositories.AccountStatusRepository, checkAccount *CheckAccountUseCase) *SetAccountDisabledUseCase {
+	return &SetAccountDisabledUseCase{
+		accountStatusRepo: accountStatusRepo,
+		checkAccount:      checkAccount,
+	}
+}
+
+func (uc *SetAccountDisabledUseCase) Execute(ctx context.Context, req SetAccountDisabledRequest) (*SetAccountDisabledResponse, error) {
+	if req.Disabled && req.UserID.Equals(req.AdminID) {
+		return nil, entities.ErrCannotDisableSelf
+	}
+
+	status, err := uc.accountStatusRepo.GetByUserID(ctx, req.UserID)
+	switch {
+	case errors.Is(err, entities.ErrAccountStatusNotFound):
+		// A user who owns data but has not signed in since accounts were
+		// tracked can still be disabled; their details fill in on sign-in.
+		status = entities.ReconstructAccountStatus(req.UserID, "", "", false, nil, time.Time{}, time.Time{})
+	case err != nil:
+		return nil, fmt.Errorf("failed to get account status: %w", err)
+	}
+
+	if req.Disabled {
+		status.Disable(time.Now())
+	} else {
+		status.Enable()
+	}
+
+	if err := uc.accountStatusRepo.Save(ctx, status); err != nil {
+		return nil, fmt.Errorf("failed to save account status: %w", err)
+	}
+	uc.checkAccount.Forget(req.UserID)
+
+	return &SetAccountDisabledResponse{Status: status}, nil
+}
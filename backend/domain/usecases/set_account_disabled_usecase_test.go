@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestSetAccountDisabledUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		accountStatusRepo *mocks.MockAccountStatusRepository
+		checkAccount      *CheckAccountUseCase
+		useCase           *SetAccountDisabledUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{accountStatusRepo: mocks.NewMockAccountStatusRepository(mockCtrl)}
+		f.checkAccount = NewCheckAccountUseCase(f.accountStatusRepo)
+		f.useCase = NewSetAccountDisabledUseCase(f.accountStatusRepo, f.checkAccount)
+		return f
+	}
+	user := func() *entities.User {
+		username, _ := entities.NewUsername("bob")
+		return entities.ReconstructUser(entities.NewUserID(), username, entities.EmailAddress{}, "", time.Now(), time.Now())
+	}
+
+	t.Run("success - disabling takes effect on the user's next request", func(t *testing.T) {
+		f := setup(t)
+		u := user()
+		status := entities.NewAccountStatus(u, time.Now())
+
+		// The user's first request caches their account as enabled
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), u.ID()).Return(status, nil).Times(3)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), status).Return(nil).Times(3)
+		require.NoError(t, f.checkAccount.Execute(context.Background(), CheckAccountRequest{User: u}))
+
+		resp, err := f.useCase.Execute(context.Background(), SetAccountDisabledRequest{
+			UserID:   u.ID(),
+			Disabled: true,
+			AdminID:  entities.NewUserID(),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Status.IsDisabled())
+		assert.NotNil(t, resp.Status.DisabledAt())
+		err = f.checkAccount.Execute(context.Background(), CheckAccountRequest{User: u})
+		assert.ErrorIs(t, err, entities.ErrAccountDisabled)
+	})
+
+	t.Run("success - enabling clears the disabled time", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		disabledAt := time.Now().Add(-time.Hour)
+		status := entities.ReconstructAccountStatus(userID, "bob", "", true, &disabledAt, disabledAt, disabledAt)
+
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(status, nil)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), status).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), SetAccountDisabledRequest{UserID: userID, AdminID: entities.NewUserID()})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Status.IsDisabled())
+		assert.Nil(t, resp.Status.DisabledAt())
+	})
+
+	t.Run("success - user never seen gets a status", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+
+		f.accountStatusRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrAccountStatusNotFound)
+		f.accountStatusRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), SetAccountDisabledRequest{
+			UserID:   userID,
+			Disabled: true,
+			AdminID:  entities.NewUserID(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, resp.Status.UserID())
+		assert.True(t, resp.Status.IsDisabled())
+	})
+
+	t.Run("error - admins cannot disable themselves", func(t *testing.T) {
+		f := setup(t)
+		adminID := entities.NewUserID()
+
+		_, err := f.useCase.Execute(context.Background(), SetAccountDisabledRequest{
+			UserID:   adminID,
+			Disabled: true,
+			AdminID:  adminID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrCannotDisableSelf)
+	})
+}
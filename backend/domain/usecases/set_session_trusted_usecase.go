@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type SetSessionTrustedRequest struct {
+	UserID    entities.UserID
+	SessionID string
+	Trusted   bool
+}
+
+type SetSessionTrustedResponse struct {
+	Session *entities.Session
+}
+
+// SetSessionTrustedUseCase remembers a device, or forgets it again. Trusted
+// sessions stay signed in when the user signs out other devices; they can
+// still be revoked one at a time.
+type SetSessionTrustedUseCase struct {
+	sessionRepo repositories.SessionRepository
+}
+
+func NewSetSessionTrustedUseCase(sessionRepo repositories.SessionRepository) *SetSessionTrustedUseCase {
+	return &SetSessionTrustedUseCase{sessionRepo: sessionRepo}
+}
+
+func (uc *SetSessionTrustedUseCase) Execute(ctx context.Context, req SetSessionTrustedRequest) (*SetSessionTrustedResponse, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.UserID().Equals(req.UserID) || !session.IsActive(time.Now()) {
+		return nil, entities.ErrSessionNotFound
+	}
+
+	session.SetTrusted(req.Trusted)
+	if err := uc.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	return &SetSessionTrustedResponse{Session: session}, nil
+}
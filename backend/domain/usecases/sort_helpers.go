@@ -0,0 +1,192 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// Fields supported by each listing. Collection summaries carry no object data,
+// so they cannot be ordered by expiry or quantity. Only collections and
+// containers can be placed by hand.
+var (
+	CollectionSortFields = []entities.SortField{
+		entities.SortFieldName,
+		entities.SortFieldCreatedAt,
+		entities.SortFieldUpdatedAt,
+		entities.SortFieldCustom,
+	}
+	ContainerSortFields = append(slices.Clone(entities.AllSortFields), entities.SortFieldCustom)
+	ObjectSortFields    = entities.AllSortFields
+)
+
+// sortCollections orders collections in place. Quantity and expiry are not
+// available on collections and leave the order unchanged. The custom sort
+// orders by name here so that collections the user never placed end up in
+// name order; applyItemOrder then moves the placed ones.
+func sortCollections(collections []*entities.Collection, opts entities.SortOptions) {
+	if opts.IsZero() {
+		return
+	}
+	slices.SortStableFunc(collections, func(a, b *entities.Collection) int {
+		switch opts.Field {
+		case entities.SortFieldName, entities.SortFieldCustom:
+			return applyOrder(compareNames(a.Name().String(), b.Name().String()), opts)
+		case entities.SortFieldCreatedAt:
+			return applyOrder(a.CreatedAt().Compare(b.CreatedAt()), opts)
+		case entities.SortFieldUpdatedAt:
+			return applyOrder(a.UpdatedAt().Compare(b.UpdatedAt()), opts)
+		}
+		return 0
+	})
+}
+
+// sortContainers orders containers in place. Quantity sorts by object count
+// and expiry by the earliest-expiring object in each container. As with
+// collections, the custom sort starts from name order.
+func sortContainers(containers []*entities.Container, opts entities.SortOptions) {
+	if opts.IsZero() {
+		return
+	}
+	slices.SortStableFunc(containers, func(a, b *entities.Container) int {
+		switch opts.Field {
+		case entities.SortFieldName, entities.SortFieldCustom:
+			return applyOrder(compareNames(a.Name().String(), b.Name().String()), opts)
+		case entities.SortFieldCreatedAt:
+			return applyOrder(a.CreatedAt().Compare(b.CreatedAt()), opts)
+		case entities.SortFieldUpdatedAt:
+			return applyOrder(a.UpdatedAt().Compare(b.UpdatedAt()), opts)
+		case entities.SortFieldQuantity:
+			return applyOrder(cmp.Compare(a.ObjectCount(), b.ObjectCount()), opts)
+		case entities.SortFieldExpiresAt:
+			return compareOptionalTimes(earliestExpiry(a), earliestExpiry(b), opts)
+		}
+		return 0
+	})
+}
+
+// sortObjects orders objects in place.
+func sortObjects(items []ObjectWithContainerID, opts entities.SortOptions) {
+	if opts.IsZero() {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b ObjectWithContainerID) int {
+		switch opts.Field {
+		case entities.SortFieldName:
+			return applyOrder(compareNames(a.Object.Name().String(), b.Object.Name().String()), opts)
+		case entities.SortFieldCreatedAt:
+			return applyOrder(a.Object.CreatedAt().Compare(b.Object.CreatedAt()), opts)
+		case entities.SortFieldUpdatedAt:
+			return applyOrder(a.Object.UpdatedAt().Compare(b.Object.UpdatedAt()), opts)
+		case entities.SortFieldExpiresAt:
+			return compareOptionalTimes(a.Object.ExpiresAt(), b.Object.ExpiresAt(), opts)
+		case entities.SortFieldQuantity:
+			return compareOptionalFloats(a.Object.Quantity(), b.Object.Quantity(), opts)
+		}
+		return 0
+	})
+}
+
+// userItemOrder loads the user's placements in a scope. A user who never
+// saved preferences has none.
+func userItemOrder(ctx context.Context, preferencesRepo repositories.UserPreferencesRepository, userID entities.UserID, scope entities.OrderScope) (map[string]entities.ItemOrder, error) {
+	preferences, err := preferencesRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, entities.ErrUserPreferencesNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+	return preferences.Order(scope), nil
+}
+
+// applyItemOrder moves pinned items to the front, keeping the existing order
+// among pinned and among unpinned items. Under the custom sort each part is
+// ordered by sort index instead, with items never placed last in the order
+// they came in.
+func applyItemOrder[T any](items []T, id func(T) string, order map[string]entities.ItemOrder, opts entities.SortOptions) {
+	if len(order) == 0 {
+		return
+	}
+	custom := opts.Field == entities.SortFieldCustom
+	slices.SortStableFunc(items, func(a, b T) int {
+		oa, ob := order[id(a)], order[id(b)]
+		if oa.Pinned != ob.Pinned {
+			if oa.Pinned {
+				return -1
+			}
+			return 1
+		}
+		if !custom {
+			return 0
+		}
+		switch {
+		case oa.SortIndex == ob.SortIndex:
+			return 0
+		case oa.SortIndex == 0:
+			return 1
+		case ob.SortIndex == 0:
+			return -1
+		}
+		return cmp.Compare(oa.SortIndex, ob.SortIndex)
+	})
+}
+
+// compareNames compares names case-insensitively, falling back to a
+// case-sensitive comparison so the order is deterministic.
+func compareNames(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func applyOrder(c int, opts entities.SortOptions) int {
+	if opts.Descending() {
+		return -c
+	}
+	return c
+}
+
+// compareOptionalTimes sorts missing values last regardless of direction.
+func compareOptionalTimes(a, b *time.Time, opts entities.SortOptions) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return applyOrder(a.Compare(*b), opts)
+}
+
+// compareOptionalFloats sorts missing values last regardless of direction.
+func compareOptionalFloats(a, b *float64, opts entities.SortOptions) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return applyOrder(cmp.Compare(*a, *b), opts)
+}
+
+func earliestExpiry(c *entities.Container) *time.Time {
+	var earliest *time.Time
+	for _, obj := range c.ActiveObjects() {
+		if exp := obj.ExpiresAt(); exp != nil && (earliest == nil || exp.Before(*earliest)) {
+			earliest = exp
+		}
+	}
+	return earliest
+}
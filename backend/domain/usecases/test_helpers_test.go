@@ -3,7 +3,10 @@ package usecases
 import (
 	"time"
 
+	"go.uber.org/mock/gomock"
+
 	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
 )
 
 // --- TypedValue helpers ---
@@ -36,9 +39,11 @@ func Props(kvs ...any) map[string]entities.TypedValue {
 // TestObject builds a minimal reconstructed Object. Override fields via opts.
 func NewTestObject(opts ...func(*objectOpts)) *entities.Object {
 	o := objectOpts{
-		name:  "Test Object",
-		props: map[string]entities.TypedValue{},
-		tags:  []string{},
+		name:       "Test Object",
+		objectType: entities.ObjectTypeGeneral,
+		props:      map[string]entities.TypedValue{},
+		tags:       []string{},
+		createdAt:  time.Now(),
 	}
 	for _, fn := range opts {
 		fn(&o)
@@ -46,21 +51,28 @@ func NewTestObject(opts ...func(*objectOpts)) *entities.Object {
 	objName, _ := entities.NewObjectName(o.name)
 	return entities.ReconstructObject(
 		o.id.orNew(), objName, entities.NewObjectDescription(o.desc),
-		entities.ObjectTypeGeneral, "", o.quantity, o.unit,
-		o.props, o.tags, "", o.expiresAt,
-		time.Now(), time.Now(),
+		o.objectType, "", o.quantity, o.unit, o.minQuantity, o.condition, o.status,
+		o.props, o.tags, "", o.expiresAt, o.archivedAt, o.claim,
+		o.createdAt, time.Now(),
 	)
 }
 
 type objectOpts struct {
-	id        optionalID[entities.ObjectID]
-	name      string
-	desc      string
-	unit      string
-	quantity  *float64
-	props     map[string]entities.TypedValue
-	tags      []string
-	expiresAt *time.Time
+	id          optionalID[entities.ObjectID]
+	name        string
+	desc        string
+	objectType  entities.ObjectType
+	unit        string
+	quantity    *float64
+	minQuantity *float64
+	condition   entities.Condition
+	status      entities.ObjectStatus
+	props       map[string]entities.TypedValue
+	tags        []string
+	expiresAt   *time.Time
+	archivedAt  *time.Time
+	claim       *entities.ObjectClaim
+	createdAt   time.Time
 }
 
 func ObjName(n string) func(*objectOpts)           { return func(o *objectOpts) { o.name = n } }
@@ -69,10 +81,25 @@ func ObjID(id entities.ObjectID) func(*objectOpts) { return func(o *objectOpts)
 func ObjProps(p map[string]entities.TypedValue) func(*objectOpts) {
 	return func(o *objectOpts) { o.props = p }
 }
+func ObjType(t entities.ObjectType) func(*objectOpts) {
+	return func(o *objectOpts) { o.objectType = t }
+}
 func ObjTags(t ...string) func(*objectOpts)      { return func(o *objectOpts) { o.tags = t } }
 func ObjUnit(u string) func(*objectOpts)         { return func(o *objectOpts) { o.unit = u } }
 func ObjQuantity(q float64) func(*objectOpts)    { return func(o *objectOpts) { o.quantity = &q } }
-func ObjExpiresAt(t time.Time) func(*objectOpts) { return func(o *objectOpts) { o.expiresAt = &t } }
+func ObjMinQuantity(q float64) func(*objectOpts) { return func(o *objectOpts) { o.minQuantity = &q } }
+func ObjCondition(c entities.Condition) func(*objectOpts) {
+	return func(o *objectOpts) { o.condition = c }
+}
+func ObjStatus(s entities.ObjectStatus) func(*objectOpts) {
+	return func(o *objectOpts) { o.status = s }
+}
+func ObjExpiresAt(t time.Time) func(*objectOpts)  { return func(o *objectOpts) { o.expiresAt = &t } }
+func ObjArchivedAt(t time.Time) func(*objectOpts) { return func(o *objectOpts) { o.archivedAt = &t } }
+func ObjCreatedAt(t time.Time) func(*objectOpts)  { return func(o *objectOpts) { o.createdAt = t } }
+func ObjClaim(c entities.ObjectClaim) func(*objectOpts) {
+	return func(o *objectOpts) { o.claim = &c }
+}
 
 // TestContainer builds a minimal reconstructed Container. Override fields via opts.
 func NewTestContainer(opts ...func(*containerOpts)) *entities.Container {
@@ -89,7 +116,8 @@ func NewTestContainer(opts ...func(*containerOpts)) *entities.Container {
 		o.id.orNew(), o.collectionID.orNew(), name, o.ctype,
 		o.parentID, nil, o.groupID,
 		o.objects, o.location,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, o.zone, nil,
+		entities.ContainerPlacement{},
 		time.Now(), time.Now(),
 	)
 }
@@ -103,6 +131,7 @@ type containerOpts struct {
 	groupID      *entities.GroupID
 	objects      []entities.Object
 	location     string
+	zone         entities.TemperatureZone
 }
 
 func CtrName(n string) func(*containerOpts) { return func(o *containerOpts) { o.name = n } }
@@ -119,6 +148,15 @@ func CtrObjects(objs ...entities.Object) func(*containerOpts) {
 	return func(o *containerOpts) { o.objects = objs }
 }
 func CtrLocation(l string) func(*containerOpts) { return func(o *containerOpts) { o.location = l } }
+func CtrType(t entities.ContainerType) func(*containerOpts) {
+	return func(o *containerOpts) { o.ctype = t }
+}
+func CtrParentID(id entities.ContainerID) func(*containerOpts) {
+	return func(o *containerOpts) { o.parentID = &id }
+}
+func CtrTemperatureZone(z entities.TemperatureZone) func(*containerOpts) {
+	return func(o *containerOpts) { o.zone = z }
+}
 
 // TestCollection builds a minimal reconstructed Collection. Override fields via opts.
 func NewTestCollection(opts ...func(*collectionOpts)) *entities.Collection {
@@ -133,8 +171,9 @@ func NewTestCollection(opts ...func(*collectionOpts)) *entities.Collection {
 	}
 	name, _ := entities.NewCollectionName(o.name)
 	return entities.ReconstructCollection(
-		o.id.orNew(), o.userID.orNew(), o.groupID, name, nil,
-		o.objectType, o.containers, o.tags, o.location, o.schema,
+		o.id.orNew(), o.userID.orNew(), o.groupID, o.groupOwned, name, nil,
+		o.objectType, o.containers, o.tags, o.location, o.schema, o.shelfLife,
+		nil,
 		time.Now(), time.Now(),
 	)
 }
@@ -143,12 +182,14 @@ type collectionOpts struct {
 	id         optionalID[entities.CollectionID]
 	userID     optionalID[entities.UserID]
 	groupID    *entities.GroupID
+	groupOwned bool
 	name       string
 	objectType entities.ObjectType
 	containers []entities.Container
 	tags       []string
 	location   string
 	schema     *entities.PropertySchema
+	shelfLife  []entities.ShelfLife
 }
 
 func ColName(n string) func(*collectionOpts) { return func(o *collectionOpts) { o.name = n } }
@@ -161,6 +202,7 @@ func ColUserID(id entities.UserID) func(*collectionOpts) {
 func ColGroupID(id *entities.GroupID) func(*collectionOpts) {
 	return func(o *collectionOpts) { o.groupID = id }
 }
+func ColGroupOwned() func(*collectionOpts) { return func(o *collectionOpts) { o.groupOwned = true } }
 func ColContainers(c ...entities.Container) func(*collectionOpts) {
 	return func(o *collectionOpts) { o.containers = c }
 }
@@ -169,6 +211,12 @@ func ColLocation(l string) func(*collectionOpts) { return func(o *collectionOpts
 func ColSchema(s *entities.PropertySchema) func(*collectionOpts) {
 	return func(o *collectionOpts) { o.schema = s }
 }
+func ColObjectType(t entities.ObjectType) func(*collectionOpts) {
+	return func(o *collectionOpts) { o.objectType = t }
+}
+func ColShelfLife(rules ...entities.ShelfLife) func(*collectionOpts) {
+	return func(o *collectionOpts) { o.shelfLife = rules }
+}
 
 // TestGroup builds a minimal reconstructed Group. Override fields via opts.
 func NewTestGroup(opts ...func(*groupOpts)) *entities.Group {
@@ -196,6 +244,19 @@ func GrpName(n string) func(*groupOpts)          { return func(o *groupOpts) { o
 func GrpID(id entities.GroupID) func(*groupOpts) { return func(o *groupOpts) { o.id.set(id) } }
 func GrpDesc(d string) func(*groupOpts)          { return func(o *groupOpts) { o.desc = d } }
 
+// NewTestFolder returns a folder owned by userID, nested in parent when it
+// is not nil.
+func NewTestFolder(userID entities.UserID, name string, parent *entities.CollectionFolder, collections ...entities.CollectionID) *entities.CollectionFolder {
+	var parentID *entities.CollectionFolderID
+	if parent != nil {
+		parentID = new(parent.ID())
+	}
+	return entities.ReconstructCollectionFolder(
+		entities.NewCollectionFolderID(), userID, name, parentID, collections,
+		time.Now(), time.Now(),
+	)
+}
+
 // --- generic optional ID ---
 
 type entityID interface {
@@ -235,3 +296,21 @@ func (o optionalID[T]) orNew() T {
 		panic("unsupported ID type")
 	}
 }
+
+// --- Quota helpers ---
+
+// expectGroupQuota sets groupID's limit on containers or objects and reports
+// used of it as taken.
+func expectGroupQuota(quotaRepo *mocks.MockGroupQuotaRepository, usageRepo *mocks.MockUsageRepository, groupID entities.GroupID, resource entities.QuotaResource, limit, used int) {
+	props := entities.GroupQuotaProps{GroupID: groupID}
+	usage := entities.GroupUsage{}
+	switch resource {
+	case entities.QuotaContainers:
+		props.MaxContainers, usage.Containers = limit, used
+	case entities.QuotaObjects:
+		props.MaxObjects, usage.Objects = limit, used
+	}
+	quota, _ := entities.NewGroupQuota(props)
+	quotaRepo.EXPECT().GetByGroupID(gomock.Any(), groupID).Return(quota, nil)
+	usageRepo.EXPECT().UsageByGroup(gomock.Any(), groupID).Return(usage, nil)
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type TransferCollectionRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	UserToken    string
+	// Exactly one of ToGroupID or ToUserID is set.
+	ToGroupID *entities.GroupID
+	ToUserID  *entities.UserID
+}
+
+type TransferCollectionResponse struct {
+	Collection *entities.Collection
+}
+
+type TransferCollectionUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewTransferCollectionUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *TransferCollectionUseCase {
+	return &TransferCollectionUseCase{
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+// Execute hands the collection to a group the caller belongs to, or to a
+// user who is a member of the group the collection is shared with.
+func (uc *TransferCollectionUseCase) Execute(ctx context.Context, req TransferCollectionRequest) (*TransferCollectionResponse, error) {
+	if (req.ToGroupID == nil) == (req.ToUserID == nil) {
+		return nil, errors.New("exactly one transfer target is required")
+	}
+
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, errors.New("collection not found")
+	}
+
+	canControl, err := canControlCollection(ctx, uc.authService, collection, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if !canControl {
+		return nil, errors.New("access denied: only collection owner or group admin can transfer")
+	}
+
+	if req.ToGroupID != nil {
+		isMember, err := isGroupMember(ctx, uc.authService, *req.ToGroupID, req.UserID, req.UserToken)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, errors.New("access denied: not a member of the target group")
+		}
+		collection.TransferToGroup(*req.ToGroupID, req.UserID)
+	} else {
+		if collection.IsOwnedBy(*req.ToUserID) {
+			return nil, errors.New("collection already belongs to this user")
+		}
+		// The recipient must already be able to see the collection, which
+		// also keeps collections from being pushed onto arbitrary accounts
+		if collection.GroupID() == nil {
+			return nil, errors.New("recipient must be a member of the collection's group: collection is not shared with a group")
+		}
+		members, err := uc.authService.GetGroupUsers(ctx, req.UserToken, collection.GroupID().String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group members: %w", err)
+		}
+		isMember := false
+		for _, member := range members {
+			if member.ID().Equals(*req.ToUserID) {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			return nil, errors.New("recipient must be a member of the collection's group")
+		}
+		collection.TransferToUser(*req.ToUserID)
+	}
+
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	return &TransferCollectionResponse{Collection: collection}, nil
+}
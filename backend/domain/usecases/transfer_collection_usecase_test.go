@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestTransferCollectionUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewTransferCollectionUseCase(mockCollectionRepo, mockAuthService)
+
+	member := func(id entities.UserID) *entities.User {
+		return entities.ReconstructUser(id, entities.Username{}, entities.EmailAddress{}, "", time.Now(), time.Now())
+	}
+
+	t.Run("success - owner hands collection to their group", func(t *testing.T) {
+		userID := entities.NewUserID()
+		group := NewTestGroup()
+		collection := NewTestCollection(ColUserID(userID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), collection).Return(nil)
+
+		groupID := group.ID()
+		resp, err := useCase.Execute(context.Background(), TransferCollectionRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+			ToGroupID:    &groupID,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Collection.IsGroupOwned())
+		assert.Equal(t, groupID, *resp.Collection.GroupID())
+		assert.False(t, resp.Collection.IsOwnedBy(userID))
+	})
+
+	t.Run("success - group admin hands group-owned collection to another member", func(t *testing.T) {
+		userID := entities.NewUserID()
+		recipientID := entities.NewUserID()
+		group := NewTestGroup()
+		groupID := group.ID()
+		collection := NewTestCollection(ColGroupID(&groupID), ColGroupOwned())
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "test-token", groupID.String()).
+			Return(map[string]entities.GroupRole{userID.String(): entities.GroupRoleAdmin}, nil)
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "test-token", groupID.String()).
+			Return([]*entities.User{member(userID), member(recipientID)}, nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), collection).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), TransferCollectionRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+			ToUserID:     &recipientID,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Collection.IsGroupOwned())
+		assert.True(t, resp.Collection.IsOwnedBy(recipientID))
+		assert.Equal(t, groupID, *resp.Collection.GroupID(), "group sharing is kept")
+	})
+
+	t.Run("error - plain member cannot take a group-owned collection", func(t *testing.T) {
+		userID := entities.NewUserID()
+		adminID := entities.NewUserID()
+		group := NewTestGroup()
+		groupID := group.ID()
+		collection := NewTestCollection(ColGroupID(&groupID), ColGroupOwned())
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{group}, nil)
+		mockAuthService.EXPECT().GetGroupMemberRoles(gomock.Any(), "test-token", groupID.String()).
+			Return(map[string]entities.GroupRole{adminID.String(): entities.GroupRoleAdmin}, nil)
+
+		resp, err := useCase.Execute(context.Background(), TransferCollectionRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+			ToUserID:     &userID,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - recipient outside the collection's group", func(t *testing.T) {
+		userID := entities.NewUserID()
+		recipientID := entities.NewUserID()
+		groupID := entities.NewGroupID()
+		collection := NewTestCollection(ColUserID(userID), ColGroupID(&groupID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockAuthService.EXPECT().GetGroupUsers(gomock.Any(), "test-token", groupID.String()).
+			Return([]*entities.User{member(userID)}, nil)
+
+		resp, err := useCase.Execute(context.Background(), TransferCollectionRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+			ToUserID:     &recipientID,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "recipient must be a member")
+	})
+
+	t.Run("error - not a member of the target group", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		groupID := entities.NewGroupID()
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+
+		resp, err := useCase.Execute(context.Background(), TransferCollectionRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+			ToGroupID:    &groupID,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - non-owner cannot transfer", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collection := NewTestCollection()
+		groupID := entities.NewGroupID()
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		resp, err := useCase.Execute(context.Background(), TransferCollectionRequest{
+			CollectionID: collection.ID(),
+			UserID:       userID,
+			UserToken:    "test-token",
+			ToGroupID:    &groupID,
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "only collection owner or group admin can transfer")
+	})
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type TriggerRecurrenceRulesRequest struct {
+	Now time.Time
+}
+
+type TriggerRecurrenceRulesResponse struct {
+	Triggered int // rules that came due
+	Removed   int // rules dropped because their object was deleted
+	Failed    int
+}
+
+// TriggerRecurrenceRulesUseCase is run by the recurrence scheduler to put
+// staples whose time has come back on their owners' shopping lists.
+type TriggerRecurrenceRulesUseCase struct {
+	recurrenceRepo repositories.RecurrenceRuleRepository
+	containerRepo  repositories.ContainerRepository
+	logger         *slog.Logger
+}
+
+func NewTriggerRecurrenceRulesUseCase(
+	recurrenceRepo repositories.RecurrenceRuleRepository,
+	containerRepo repositories.ContainerRepository,
+	logger *slog.Logger,
+) *TriggerRecurrenceRulesUseCase {
+	return &TriggerRecurrenceRulesUseCase{
+		recurrenceRepo: recurrenceRepo,
+		containerRepo:  containerRepo,
+		logger:         logger,
+	}
+}
+
+// Execute marks every rule whose due date has passed as due. A rule whose
+// object no longer exists is deleted; one that fails to save is retried on
+// the next run.
+func (uc *TriggerRecurrenceRulesUseCase) Execute(ctx context.Context, req TriggerRecurrenceRulesRequest) (*TriggerRecurrenceRulesResponse, error) {
+	rules, err := uc.recurrenceRepo.ListDue(ctx, req.Now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due recurrence rules: %w", err)
+	}
+
+	resp := &TriggerRecurrenceRulesResponse{}
+	for _, rule := range rules {
+		container, err := uc.containerRepo.FindByObjectID(ctx, rule.ObjectID())
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			if err := uc.recurrenceRepo.Delete(ctx, rule.ID()); err != nil {
+				uc.logger.Warn("Failed to delete recurrence rule of a deleted object",
+					slog.String("rule_id", rule.ID().String()), slog.Any("error", err))
+				resp.Failed++
+				continue
+			}
+			resp.Removed++
+			continue
+		}
+		if err != nil {
+			uc.logger.Warn("Failed to look up recurring object",
+				slog.String("rule_id", rule.ID().String()), slog.Any("error", err))
+			resp.Failed++
+			continue
+		}
+		if object, err := container.GetObject(rule.ObjectID()); err == nil {
+			rule.UpdateName(object.Name().String())
+		}
+
+		rule.Trigger(req.Now)
+		if err := uc.recurrenceRepo.Update(ctx, rule); err != nil {
+			uc.logger.Warn("Failed to save triggered recurrence rule",
+				slog.String("rule_id", rule.ID().String()), slog.Any("error", err))
+			resp.Failed++
+			continue
+		}
+		resp.Triggered++
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestTriggerRecurrenceRulesUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRecurrenceRepo := mocks.NewMockRecurrenceRuleRepository(mockCtrl)
+	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
+
+	useCase := NewTriggerRecurrenceRulesUseCase(mockRecurrenceRepo, mockContainerRepo, slog.New(slog.DiscardHandler))
+
+	now := time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC)
+	weekly := entities.Recurrence{Every: 1, Period: entities.RecurrenceWeekly}
+
+	t.Run("success - marks due, skips missed rounds and refreshes the name", func(t *testing.T) {
+		bread := NewTestObject(ObjName("Sourdough"))
+		container := NewTestContainer(CtrObjects(*bread))
+		start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+		rule, err := entities.NewRecurrenceRule(entities.NewUserID(), bread.ID(), container.CollectionID(), "Bread", weekly, nil, start)
+		require.NoError(t, err)
+
+		mockRecurrenceRepo.EXPECT().ListDue(gomock.Any(), now).Return([]*entities.RecurrenceRule{rule}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), bread.ID()).Return(container, nil)
+		mockRecurrenceRepo.EXPECT().Update(gomock.Any(), rule).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), TriggerRecurrenceRulesRequest{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Triggered)
+		assert.True(t, rule.IsDue())
+		assert.Equal(t, start.AddDate(0, 0, 7), *rule.DueSince())
+		assert.Equal(t, start.AddDate(0, 0, 21), rule.NextDueAt())
+		assert.Equal(t, "Sourdough", rule.Name())
+	})
+
+	t.Run("success - deletes the rule of a deleted object", func(t *testing.T) {
+		gone := entities.NewObjectID()
+		rule, err := entities.NewRecurrenceRule(entities.NewUserID(), gone, entities.NewCollectionID(), "Milk", weekly, nil, now.AddDate(0, 0, -7))
+		require.NoError(t, err)
+
+		mockRecurrenceRepo.EXPECT().ListDue(gomock.Any(), now).Return([]*entities.RecurrenceRule{rule}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), gone).Return(nil, errors.New("container not found"))
+		mockRecurrenceRepo.EXPECT().Delete(gomock.Any(), rule.ID()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), TriggerRecurrenceRulesRequest{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.Triggered)
+		assert.Equal(t, 1, resp.Removed)
+	})
+
+	t.Run("partial - a failed save is counted and the rest still run", func(t *testing.T) {
+		eggs := NewTestObject(ObjName("Eggs"))
+		milk := NewTestObject(ObjName("Milk"))
+		container := NewTestContainer(CtrObjects(*eggs, *milk))
+		first, err := entities.NewRecurrenceRule(entities.NewUserID(), eggs.ID(), container.CollectionID(), "Eggs", weekly, nil, now.AddDate(0, 0, -7))
+		require.NoError(t, err)
+		second, err := entities.NewRecurrenceRule(entities.NewUserID(), milk.ID(), container.CollectionID(), "Milk", weekly, nil, now.AddDate(0, 0, -7))
+		require.NoError(t, err)
+
+		mockRecurrenceRepo.EXPECT().ListDue(gomock.Any(), now).Return([]*entities.RecurrenceRule{first, second}, nil)
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), gomock.Any()).Return(container, nil).Times(2)
+		mockRecurrenceRepo.EXPECT().Update(gomock.Any(), first).Return(errors.New("connection reset"))
+		mockRecurrenceRepo.EXPECT().Update(gomock.Any(), second).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), TriggerRecurrenceRulesRequest{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Triggered)
+		assert.Equal(t, 1, resp.Failed)
+	})
+
+	t.Run("success - a bought staple is not put back before its next round", func(t *testing.T) {
+		rule, err := entities.NewRecurrenceRule(entities.NewUserID(), entities.NewObjectID(), entities.NewCollectionID(), "Coffee", weekly, nil, now.AddDate(0, 0, -7))
+		require.NoError(t, err)
+		rule.Trigger(now)
+		rule.MarkBought()
+
+		assert.False(t, rule.Trigger(now))
+		assert.False(t, rule.IsDue())
+		assert.True(t, rule.Trigger(now.AddDate(0, 0, 7)))
+		assert.True(t, rule.IsDue())
+	})
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UnfileCollectionRequest struct {
+	FolderID     entities.CollectionFolderID
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+}
+
+type UnfileCollectionResponse struct {
+	Folder *entities.CollectionFolder
+}
+
+type UnfileCollectionUseCase struct {
+	folderRepo repositories.CollectionFolderRepository
+}
+
+func NewUnfileCollectionUseCase(folderRepo repositories.CollectionFolderRepository) *UnfileCollectionUseCase {
+	return &UnfileCollectionUseCase{
+		folderRepo: folderRepo,
+	}
+}
+
+// Execute takes a collection out of a folder, back to the top level of the
+// user's list. The collection itself is not checked, so IDs of collections
+// that have since been deleted or unshared can still be cleaned up.
+func (uc *UnfileCollectionUseCase) Execute(ctx context.Context, req UnfileCollectionRequest) (*UnfileCollectionResponse, error) {
+	folder, err := uc.folderRepo.GetByID(ctx, req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	if !folder.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrCollectionFolderNotFound
+	}
+
+	if folder.RemoveCollection(req.CollectionID) {
+		if err := uc.folderRepo.Update(ctx, folder); err != nil {
+			return nil, fmt.Errorf("failed to update collection folder: %w", err)
+		}
+	}
+
+	return &UnfileCollectionResponse{
+		Folder: folder,
+	}, nil
+}
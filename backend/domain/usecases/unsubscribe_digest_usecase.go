@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UnsubscribeDigestRequest struct {
+	Token string
+}
+
+type UnsubscribeDigestUseCase struct {
+	digestRepo repositories.DigestSubscriptionRepository
+}
+
+func NewUnsubscribeDigestUseCase(digestRepo repositories.DigestSubscriptionRepository) *UnsubscribeDigestUseCase {
+	return &UnsubscribeDigestUseCase{
+		digestRepo: digestRepo,
+	}
+}
+
+// Execute turns off the digest identified by the token from an email link.
+// No login is required; the token is the credential.
+func (uc *UnsubscribeDigestUseCase) Execute(ctx context.Context, req UnsubscribeDigestRequest) error {
+	subscription, err := uc.digestRepo.GetByUnsubscribeToken(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	subscription.Unsubscribe()
+
+	if err := uc.digestRepo.Save(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to save digest preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UpdateCollectionFolderRequest struct {
+	FolderID entities.CollectionFolderID
+	Name     *string
+	// ParentID moves the folder into another folder. ToRoot moves it to the
+	// top level instead; the two cannot be combined.
+	ParentID *entities.CollectionFolderID
+	ToRoot   bool
+	UserID   entities.UserID
+}
+
+type UpdateCollectionFolderResponse struct {
+	Folder *entities.CollectionFolder
+}
+
+type UpdateCollectionFolderUseCase struct {
+	folderRepo repositories.CollectionFolderRepository
+}
+
+func NewUpdateCollectionFolderUseCase(folderRepo repositories.CollectionFolderRepository) *UpdateCollectionFolderUseCase {
+	return &UpdateCollectionFolderUseCase{
+		folderRepo: folderRepo,
+	}
+}
+
+func (uc *UpdateCollectionFolderUseCase) Execute(ctx context.Context, req UpdateCollectionFolderRequest) (*UpdateCollectionFolderResponse, error) {
+	folder, err := uc.folderRepo.GetByID(ctx, req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' folders are reported as missing so IDs cannot be probed
+	if !folder.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrCollectionFolderNotFound
+	}
+
+	if req.Name != nil {
+		if err := folder.Rename(*req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case req.ToRoot:
+		folder.MoveTo(nil)
+	case req.ParentID != nil:
+		folders, err := uc.folderRepo.ListByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collection folders: %w", err)
+		}
+		if err := entities.ValidateFolderParent(folders, folder.ID(), req.ParentID); err != nil {
+			return nil, err
+		}
+		folder.MoveTo(req.ParentID)
+	}
+
+	if err := uc.folderRepo.Update(ctx, folder); err != nil {
+		return nil, fmt.Errorf("failed to update collection folder: %w", err)
+	}
+
+	return &UpdateCollectionFolderResponse{
+		Folder: folder,
+	}, nil
+}
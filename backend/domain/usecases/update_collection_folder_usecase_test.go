@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestUpdateCollectionFolderUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFolderRepo := mocks.NewMockCollectionFolderRepository(mockCtrl)
+
+	useCase := NewUpdateCollectionFolderUseCase(mockFolderRepo)
+
+	t.Run("success - rename and move to top level", func(t *testing.T) {
+		userID := entities.NewUserID()
+		home := NewTestFolder(userID, "Home", nil)
+		kitchen := NewTestFolder(userID, "Kitchen", home)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), kitchen.ID()).Return(kitchen, nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), kitchen).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), UpdateCollectionFolderRequest{
+			FolderID: kitchen.ID(),
+			Name:     new("  Cooking "),
+			ToRoot:   true,
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Cooking", resp.Folder.Name())
+		assert.Nil(t, resp.Folder.ParentID())
+	})
+
+	t.Run("success - move into another folder", func(t *testing.T) {
+		userID := entities.NewUserID()
+		home := NewTestFolder(userID, "Home", nil)
+		garage := NewTestFolder(userID, "Garage", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), garage.ID()).Return(garage, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{garage, home}, nil)
+		mockFolderRepo.EXPECT().Update(gomock.Any(), garage).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), UpdateCollectionFolderRequest{
+			FolderID: garage.ID(),
+			ParentID: new(home.ID()),
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.Folder.ParentID())
+		assert.Equal(t, home.ID(), *resp.Folder.ParentID())
+	})
+
+	t.Run("error - move into own subfolder", func(t *testing.T) {
+		userID := entities.NewUserID()
+		home := NewTestFolder(userID, "Home", nil)
+		kitchen := NewTestFolder(userID, "Kitchen", home)
+		pantry := NewTestFolder(userID, "Pantry", kitchen)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), home.ID()).Return(home, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return([]*entities.CollectionFolder{home, kitchen, pantry}, nil)
+
+		_, err := useCase.Execute(context.Background(), UpdateCollectionFolderRequest{
+			FolderID: home.ID(),
+			ParentID: new(pantry.ID()),
+			UserID:   userID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrCollectionFolderCycle)
+	})
+
+	t.Run("error - nesting too deep", func(t *testing.T) {
+		userID := entities.NewUserID()
+		folders := []*entities.CollectionFolder{NewTestFolder(userID, "1", nil)}
+		for i := 1; i < entities.MaxCollectionFolderDepth; i++ {
+			folders = append(folders, NewTestFolder(userID, "nested", folders[i-1]))
+		}
+		box := NewTestFolder(userID, "Box", nil)
+		inBox := NewTestFolder(userID, "In box", box)
+		folders = append(folders, box, inBox)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), box.ID()).Return(box, nil)
+		mockFolderRepo.EXPECT().ListByUserID(gomock.Any(), userID).Return(folders, nil)
+
+		// Box would sit at level five, pushing its subfolder to six
+		_, err := useCase.Execute(context.Background(), UpdateCollectionFolderRequest{
+			FolderID: box.ID(),
+			ParentID: new(folders[entities.MaxCollectionFolderDepth-2].ID()),
+			UserID:   userID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrCollectionFolderTooDeep)
+	})
+
+	t.Run("error - another user's folder", func(t *testing.T) {
+		folder := NewTestFolder(entities.NewUserID(), "Theirs", nil)
+
+		mockFolderRepo.EXPECT().GetByID(gomock.Any(), folder.ID()).Return(folder, nil)
+
+		_, err := useCase.Execute(context.Background(), UpdateCollectionFolderRequest{
+			FolderID: folder.ID(),
+			Name:     new("Mine now"),
+			UserID:   entities.NewUserID(),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrCollectionFolderNotFound)
+	})
+}
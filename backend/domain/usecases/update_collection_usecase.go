@@ -15,7 +15,8 @@ type UpdateCollectionRequest struct {
 	UserID       entities.UserID
 	Name         *string
 	ObjectType   *string
-	Tags         []string
+	Tags         []string // empty = keep, unless ClearTags is set
+	ClearTags    bool
 	Location     *string
 	UserToken    string
 }
@@ -26,12 +27,14 @@ type UpdateCollectionResponse struct {
 
 type UpdateCollectionUseCase struct {
 	collectionRepo repositories.CollectionRepository
+	typeRepo       repositories.CustomObjectTypeRepository
 	authService    services.AuthService
 }
 
-func NewUpdateCollectionUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *UpdateCollectionUseCase {
+func NewUpdateCollectionUseCase(collectionRepo repositories.CollectionRepository, typeRepo repositories.CustomObjectTypeRepository, authService services.AuthService) *UpdateCollectionUseCase {
 	return &UpdateCollectionUseCase{
 		collectionRepo: collectionRepo,
+		typeRepo:       typeRepo,
 		authService:    authService,
 	}
 }
@@ -43,8 +46,12 @@ func (uc *UpdateCollectionUseCase) Execute(ctx context.Context, req UpdateCollec
 		return nil, errors.New("collection not found")
 	}
 
-	// Validate access - only owner can update
-	if !collection.UserID().Equals(req.UserID) {
+	// Validate access - only the owner (or a member of the owning group) can update
+	canManage, err := canManageCollection(ctx, uc.authService, collection, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
 		return nil, errors.New("access denied: only collection owner can update")
 	}
 
@@ -61,8 +68,12 @@ func (uc *UpdateCollectionUseCase) Execute(ctx context.Context, req UpdateCollec
 
 	// Determine effective values for reconstruction
 	objectType := collection.ObjectType()
-	if req.ObjectType != nil {
+	if req.ObjectType != nil && entities.ObjectType(*req.ObjectType) != objectType {
 		objectType = entities.ObjectType(*req.ObjectType)
+		// Custom types belong to the collection's owner, not whoever edits it
+		if _, err := resolveObjectType(ctx, uc.typeRepo, collection.UserID(), objectType); err != nil {
+			return nil, err
+		}
 	}
 
 	location := collection.Location()
@@ -73,14 +84,17 @@ func (uc *UpdateCollectionUseCase) Execute(ctx context.Context, req UpdateCollec
 	tags := collection.Tags()
 	if len(req.Tags) > 0 {
 		tags = req.Tags
+	} else if req.ClearTags {
+		tags = nil
 	}
 
 	// Reconstruct with updated fields
-	if req.ObjectType != nil || req.Location != nil || len(req.Tags) > 0 {
+	if req.ObjectType != nil || req.Location != nil || len(req.Tags) > 0 || req.ClearTags {
 		collection = entities.ReconstructCollection(
 			collection.ID(),
 			collection.UserID(),
 			collection.GroupID(),
+			collection.IsGroupOwned(),
 			collection.Name(),
 			collection.CategoryID(),
 			objectType,
@@ -88,6 +102,8 @@ func (uc *UpdateCollectionUseCase) Execute(ctx context.Context, req UpdateCollec
 			tags,
 			location,
 			collection.PropertySchema(),
+			collection.ShelfLife(),
+			collection.FloorPlan(),
 			collection.CreatedAt(),
 			collection.UpdatedAt(),
 		)
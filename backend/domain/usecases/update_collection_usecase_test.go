@@ -20,9 +20,10 @@ func TestUpdateCollectionUseCase_Execute(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockTypeRepo := mocks.NewMockCustomObjectTypeRepository(mockCtrl)
 	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewUpdateCollectionUseCase(mockCollectionRepo, mockAuthService)
+	useCase := NewUpdateCollectionUseCase(mockCollectionRepo, mockTypeRepo, mockAuthService)
 
 	t.Run("success - update collection name", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -91,6 +92,69 @@ func TestUpdateCollectionUseCase_Execute(t *testing.T) {
 		require.NotNil(t, resp)
 	})
 
+	t.Run("success - clear tags", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+
+		existing := NewTestCollection(ColID(collectionID), ColUserID(userID), ColTags("old-tag"), ColLocation("Location"))
+
+		req := UpdateCollectionRequest{
+			CollectionID: collectionID, UserID: userID, ClearTags: true, UserToken: "test-token",
+		}
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(existing, nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Collection.Tags())
+		assert.Equal(t, "Location", resp.Collection.Location())
+	})
+
+	t.Run("success - change to a custom object type", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+
+		existing := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		wine, err := entities.NewCustomObjectType(userID, "wine", "Wine", "", "", nil)
+		require.NoError(t, err)
+
+		objectType := "wine"
+		req := UpdateCollectionRequest{
+			CollectionID: collectionID, UserID: userID, ObjectType: &objectType, UserToken: "test-token",
+		}
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(existing, nil)
+		mockTypeRepo.EXPECT().GetByKey(gomock.Any(), userID, entities.ObjectType("wine")).Return(wine, nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.ObjectType("wine"), resp.Collection.ObjectType())
+	})
+
+	t.Run("error - undefined custom object type", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+
+		existing := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		objectType := "spaceships"
+		req := UpdateCollectionRequest{
+			CollectionID: collectionID, UserID: userID, ObjectType: &objectType, UserToken: "test-token",
+		}
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(existing, nil)
+		mockTypeRepo.EXPECT().GetByKey(gomock.Any(), userID, entities.ObjectType("spaceships")).Return(nil, entities.ErrCustomObjectTypeNotFound)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.ErrorIs(t, err, entities.ErrUnknownObjectType)
+		assert.Nil(t, resp)
+	})
+
 	t.Run("error - collection not found", func(t *testing.T) {
 		userID := entities.NewUserID()
 		collectionID := entities.NewCollectionID()
@@ -22,6 +22,8 @@ type UpdateContainerRequest struct {
 	Depth             **float64 // Double pointer to allow setting to nil
 	Rows              **int     // Double pointer to allow setting to nil
 	Capacity          **float64 // Double pointer to allow setting to nil
+	TemperatureZone   *entities.TemperatureZone
+	Humidity          **float64 // Double pointer to allow setting to nil
 	UserID            entities.UserID
 	UserToken         string
 }
@@ -64,7 +66,7 @@ func (uc *UpdateContainerUseCase) Execute(ctx context.Context, req UpdateContain
 	}
 
 	// Check access: user is owner OR user is member of collection's group
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -178,6 +180,22 @@ func (uc *UpdateContainerUseCase) Execute(ctx context.Context, req UpdateContain
 		}
 	}
 
+	// Update environment if either attribute is provided
+	if req.TemperatureZone != nil || req.Humidity != nil {
+		zone := container.TemperatureZone()
+		humidity := container.Humidity()
+		if req.TemperatureZone != nil {
+			zone = *req.TemperatureZone
+		}
+		if req.Humidity != nil {
+			humidity = *req.Humidity
+		}
+
+		if err := container.UpdateEnvironment(zone, humidity); err != nil {
+			return nil, fmt.Errorf("failed to update environment: %w", err)
+		}
+	}
+
 	// Save updated container
 	if err := uc.containerRepo.Update(ctx, container); err != nil {
 		return nil, fmt.Errorf("failed to save updated container: %w", err)
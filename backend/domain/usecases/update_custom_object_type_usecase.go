@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UpdateCustomObjectTypeRequest struct {
+	TypeID entities.CustomObjectTypeID
+	Name   *string
+	Icon   *string
+	Color  *string
+	// Schema replaces the field schema new collections of the type start
+	// with; an empty schema removes it.
+	Schema *entities.PropertySchema
+	UserID entities.UserID
+}
+
+type UpdateCustomObjectTypeResponse struct {
+	ObjectType *entities.CustomObjectType
+}
+
+type UpdateCustomObjectTypeUseCase struct {
+	typeRepo repositories.CustomObjectTypeRepository
+}
+
+func NewUpdateCustomObjectTypeUseCase(typeRepo repositories.CustomObjectTypeRepository) *UpdateCustomObjectTypeUseCase {
+	return &UpdateCustomObjectTypeUseCase{
+		typeRepo: typeRepo,
+	}
+}
+
+func (uc *UpdateCustomObjectTypeUseCase) Execute(ctx context.Context, req UpdateCustomObjectTypeRequest) (*UpdateCustomObjectTypeResponse, error) {
+	objectType, err := uc.typeRepo.GetByID(ctx, req.TypeID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' types are reported as missing so IDs cannot be probed
+	if !objectType.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrCustomObjectTypeNotFound
+	}
+
+	name, icon, color := objectType.Name(), objectType.Icon(), objectType.Color()
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.Icon != nil {
+		icon = *req.Icon
+	}
+	if req.Color != nil {
+		color = *req.Color
+	}
+	if err := objectType.Update(name, icon, color); err != nil {
+		return nil, err
+	}
+
+	if req.Schema != nil {
+		schema := req.Schema
+		if len(schema.Definitions) == 0 {
+			schema = nil
+		}
+		objectType.SetSchema(schema)
+	}
+
+	if err := uc.typeRepo.Update(ctx, objectType); err != nil {
+		return nil, fmt.Errorf("failed to update custom object type: %w", err)
+	}
+
+	return &UpdateCustomObjectTypeResponse{
+		ObjectType: objectType,
+	}, nil
+}
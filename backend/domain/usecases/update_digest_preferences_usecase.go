@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type UpdateDigestPreferencesRequest struct {
+	UserID    entities.UserID
+	UserToken string
+	Email     entities.EmailAddress
+	Frequency entities.DigestFrequency
+}
+
+type UpdateDigestPreferencesResponse struct {
+	Subscription *entities.DigestSubscription
+}
+
+type UpdateDigestPreferencesUseCase struct {
+	digestRepo  repositories.DigestSubscriptionRepository
+	authService services.AuthService
+}
+
+func NewUpdateDigestPreferencesUseCase(digestRepo repositories.DigestSubscriptionRepository, authService services.AuthService) *UpdateDigestPreferencesUseCase {
+	return &UpdateDigestPreferencesUseCase{
+		digestRepo:  digestRepo,
+		authService: authService,
+	}
+}
+
+func (uc *UpdateDigestPreferencesUseCase) Execute(ctx context.Context, req UpdateDigestPreferencesRequest) (*UpdateDigestPreferencesResponse, error) {
+	// The digest job runs without a user token, so snapshot the group
+	// memberships now while one is available.
+	userGroups, err := uc.authService.GetUserGroups(ctx, req.UserToken, req.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	groupIDs := make([]entities.GroupID, len(userGroups))
+	for i, g := range userGroups {
+		groupIDs[i] = g.ID()
+	}
+
+	subscription, err := uc.digestRepo.GetByUserID(ctx, req.UserID)
+	switch {
+	case errors.Is(err, entities.ErrDigestSubscriptionNotFound):
+		subscription, err = entities.NewDigestSubscription(req.UserID, req.Email, req.Frequency, groupIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create digest subscription: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get digest preferences: %w", err)
+	default:
+		subscription.UpdatePreferences(req.Email, req.Frequency, groupIDs)
+	}
+
+	if err := uc.digestRepo.Save(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to save digest preferences: %w", err)
+	}
+
+	return &UpdateDigestPreferencesResponse{
+		Subscription: subscription,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UpdateItemOrderRequest struct {
+	UserID entities.UserID
+	Scope  entities.OrderScope
+	// Items maps collection or container IDs to their new placement. Items
+	// not listed keep theirs; a zero placement unpins and unplaces the item.
+	Items map[string]entities.ItemOrder
+}
+
+type UpdateItemOrderResponse struct {
+	Preferences *entities.UserPreferences
+}
+
+type UpdateItemOrderUseCase struct {
+	preferencesRepo repositories.UserPreferencesRepository
+}
+
+func NewUpdateItemOrderUseCase(preferencesRepo repositories.UserPreferencesRepository) *UpdateItemOrderUseCase {
+	return &UpdateItemOrderUseCase{
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+// Execute merges the given placements into the user's saved order. The order
+// is personal, so it is stored with the user's preferences rather than on the
+// collections and containers that group members share.
+func (uc *UpdateItemOrderUseCase) Execute(ctx context.Context, req UpdateItemOrderRequest) (*UpdateItemOrderResponse, error) {
+	preferences, err := uc.preferencesRepo.GetByUserID(ctx, req.UserID)
+	switch {
+	case errors.Is(err, entities.ErrUserPreferencesNotFound):
+		preferences = entities.NewUserPreferences(req.UserID)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+
+	for id, order := range req.Items {
+		if err := preferences.SetItemOrder(req.Scope, id, order); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.preferencesRepo.Save(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("failed to save user preferences: %w", err)
+	}
+
+	return &UpdateItemOrderResponse{
+		Preferences: preferences,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type UpdateMealPlanRequest struct {
+	MealPlanID entities.MealPlanID
+	Date       *time.Time
+	Meal       *entities.MealType
+	RecipeName *string
+	// Ingredients replaces the linked objects when non-nil; an empty slice
+	// removes them all.
+	Ingredients []entities.MealIngredient
+	UserID      entities.UserID
+	UserToken   string
+}
+
+type UpdateMealPlanResponse struct {
+	MealPlan *entities.MealPlan
+}
+
+type UpdateMealPlanUseCase struct {
+	mealPlanRepo   repositories.MealPlanRepository
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewUpdateMealPlanUseCase(
+	mealPlanRepo repositories.MealPlanRepository,
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	authService services.AuthService,
+) *UpdateMealPlanUseCase {
+	return &UpdateMealPlanUseCase{
+		mealPlanRepo:   mealPlanRepo,
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		authService:    authService,
+	}
+}
+
+func (uc *UpdateMealPlanUseCase) Execute(ctx context.Context, req UpdateMealPlanRequest) (*UpdateMealPlanResponse, error) {
+	plan, err := uc.mealPlanRepo.GetByID(ctx, req.MealPlanID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' plans are reported as missing so IDs cannot be probed
+	if !plan.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrMealPlanNotFound
+	}
+
+	if req.Date != nil {
+		if err := plan.UpdateDate(*req.Date); err != nil {
+			return nil, err
+		}
+	}
+	if req.Meal != nil {
+		if err := plan.UpdateMeal(*req.Meal); err != nil {
+			return nil, err
+		}
+	}
+	if req.RecipeName != nil {
+		if err := plan.UpdateRecipeName(*req.RecipeName); err != nil {
+			return nil, err
+		}
+	}
+	if req.Ingredients != nil {
+		ingredients, err := resolveMealIngredients(ctx, uc.containerRepo, uc.collectionRepo, uc.authService, req.Ingredients, req.UserID, req.UserToken)
+		if err != nil {
+			return nil, err
+		}
+		if ingredients == nil {
+			ingredients = []entities.MealIngredient{}
+		}
+		if err := plan.UpdateIngredients(ingredients); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.mealPlanRepo.Update(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to save meal plan: %w", err)
+	}
+
+	return &UpdateMealPlanResponse{
+		MealPlan: plan,
+	}, nil
+}
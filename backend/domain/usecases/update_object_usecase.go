@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/repositories"
@@ -16,32 +19,51 @@ type UpdateObjectRequest struct {
 	Name          *string
 	Description   *string
 	Location      *string
-	Quantity      *float64
+	Quantity      *float64 // nil = keep, unless ClearQuantity is set
 	Unit          *string
+	MinQuantity   *float64                       // negative clears the threshold
+	Condition     *entities.Condition            // ConditionNone clears the grade
+	Status        *entities.ObjectStatus         // ObjectStatusOwned marks a wishlist item acquired
 	Properties    map[string]entities.TypedValue // for direct callers
 	RawProperties map[string]any                 // for HTTP/MCP callers; coerced in Execute()
-	Tags          []string
-	UserID        entities.UserID
-	UserToken     string
+	// MergeProperties applies RawProperties on top of the object's current
+	// properties instead of replacing them; a nil value removes the key
+	MergeProperties bool
+	ClearQuantity   bool // remove the quantity
+	Tags            []string
+	ExpiresAt       *time.Time // nil = keep, unless ClearExpiresAt is set
+	ClearExpiresAt  bool       // remove the expiry date
+	UserID          entities.UserID
+	UserToken       string
 }
 
 type UpdateObjectResponse struct {
-	Object      *entities.Object
-	ContainerID entities.ContainerID
+	Object         *entities.Object
+	ContainerID    entities.ContainerID
+	StorageWarning *entities.StorageWarning // nil unless the container is too warm for the object
+	// DroppedProperties are the keys left behind by a move to a collection
+	// whose schema has no place for them
+	DroppedProperties []string
 }
 
 type UpdateObjectUseCase struct {
 	containerRepo  repositories.ContainerRepository
 	collectionRepo repositories.CollectionRepository
+	moveRepo       repositories.ObjectMoveRepository
+	codeRepo       repositories.ObjectCodeRepository
 	authService    services.AuthService
+	events         services.EventPublisher
 	typeInference  *services.TypeInferenceService
 }
 
-func NewUpdateObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService) *UpdateObjectUseCase {
+func NewUpdateObjectUseCase(containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, moveRepo repositories.ObjectMoveRepository, codeRepo repositories.ObjectCodeRepository, authService services.AuthService, events services.EventPublisher) *UpdateObjectUseCase {
 	return &UpdateObjectUseCase{
 		containerRepo:  containerRepo,
 		collectionRepo: collectionRepo,
+		moveRepo:       moveRepo,
+		codeRepo:       codeRepo,
 		authService:    authService,
+		events:         events,
 		typeInference:  services.NewTypeInferenceService(nil),
 	}
 }
@@ -64,7 +86,7 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 		return nil, fmt.Errorf("collection not found: %w", err)
 	}
 
-	hasAccess := collection.UserID().Equals(req.UserID)
+	hasAccess := collection.IsOwnedBy(req.UserID)
 	if !hasAccess && collection.GroupID() != nil {
 		for _, group := range userGroups {
 			if group.ID().Equals(*collection.GroupID()) {
@@ -109,7 +131,7 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 		}
 	}
 
-	if req.Quantity != nil {
+	if req.Quantity != nil || req.ClearQuantity {
 		if err := updatedObject.UpdateQuantity(req.Quantity); err != nil {
 			return nil, fmt.Errorf("failed to update object quantity: %w", err)
 		}
@@ -121,8 +143,34 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 		}
 	}
 
-	if req.RawProperties != nil {
-		schema := collection.PropertySchema()
+	if req.MinQuantity != nil {
+		minQuantity := req.MinQuantity
+		if *minQuantity < 0 {
+			minQuantity = nil
+		}
+		if err := updatedObject.UpdateMinQuantity(minQuantity); err != nil {
+			return nil, fmt.Errorf("failed to update object min quantity: %w", err)
+		}
+	}
+
+	if req.Condition != nil {
+		if err := updatedObject.UpdateCondition(*req.Condition); err != nil {
+			return nil, fmt.Errorf("failed to update object condition: %w", err)
+		}
+	}
+
+	if req.Status != nil {
+		if err := updatedObject.UpdateStatus(*req.Status); err != nil {
+			return nil, fmt.Errorf("failed to update object status: %w", err)
+		}
+	}
+
+	schema := collection.PropertySchema().ForObjectType(updatedObject.ObjectType())
+	if req.RawProperties != nil && req.MergeProperties {
+		if err := updatedObject.UpdateProperties(uc.mergeProperties(updatedObject.Properties(), req.RawProperties, schema)); err != nil {
+			return nil, fmt.Errorf("failed to update object properties: %w", err)
+		}
+	} else if req.RawProperties != nil {
 		coerced := uc.typeInference.CoerceRawProperties(req.RawProperties, schema)
 		if err := updatedObject.UpdateProperties(coerced); err != nil {
 			return nil, fmt.Errorf("failed to update object properties: %w", err)
@@ -132,6 +180,15 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 			return nil, fmt.Errorf("failed to update object properties: %w", err)
 		}
 	}
+	// Only check the schema when properties are written, so objects created
+	// before a field became required can still be renamed or moved
+	if req.RawProperties != nil || req.Properties != nil {
+		errs := schema.Validate(updatedObject.Properties())
+		errs = append(errs, entities.ValidateNutrition(updatedObject.ObjectType(), updatedObject.Properties())...)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("invalid properties: %s", strings.Join(errs, "; "))
+		}
+	}
 
 	if req.Tags != nil {
 		if err := updatedObject.UpdateTags(req.Tags); err != nil {
@@ -139,8 +196,15 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 		}
 	}
 
+	if req.ExpiresAt != nil || req.ClearExpiresAt {
+		if err := updatedObject.UpdateExpiresAt(req.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to update object expiry: %w", err)
+		}
+	}
+
 	// Determine target container
 	targetContainer := currentContainer
+	var droppedProperties []string
 	if req.ContainerID != nil && !req.ContainerID.Equals(currentContainer.ID()) {
 		// Moving to a different container
 		targetContainer, err = uc.containerRepo.GetByID(ctx, *req.ContainerID)
@@ -148,6 +212,31 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 			return nil, fmt.Errorf("target container not found: %w", err)
 		}
 
+		// A move to another collection of the same type carries the
+		// properties over to that collection's schema
+		if !targetContainer.CollectionID().Equals(currentContainer.CollectionID()) {
+			targetCollection, err := getAccessibleCollection(ctx, uc.collectionRepo, uc.authService, targetContainer.CollectionID(), req.UserID, req.UserToken)
+			if err != nil {
+				return nil, err
+			}
+			if targetCollection.ObjectType() != collection.ObjectType() {
+				return nil, entities.ErrObjectTypeMismatch
+			}
+			targetSchema := targetCollection.PropertySchema().ForObjectType(updatedObject.ObjectType())
+			props, dropped := uc.typeInference.RemapProperties(updatedObject.Properties(), schema, targetSchema)
+			if err := updatedObject.UpdateProperties(props); err != nil {
+				return nil, fmt.Errorf("failed to update object properties: %w", err)
+			}
+			droppedProperties = dropped
+		}
+
+		// Record the move before touching either container so a failure
+		// here leaves the object where it was.
+		move := entities.NewObjectMove(req.ObjectID, entities.PlacementOf(currentContainer), entities.PlacementOf(targetContainer), req.UserID)
+		if err := uc.moveRepo.Create(ctx, move); err != nil {
+			return nil, fmt.Errorf("failed to record object move: %w", err)
+		}
+
 		// Remove from old container
 		if err := currentContainer.RemoveObject(req.ObjectID); err != nil {
 			return nil, fmt.Errorf("failed to remove object from current container: %w", err)
@@ -172,8 +261,30 @@ func (uc *UpdateObjectUseCase) Execute(ctx context.Context, req UpdateObjectRequ
 		return nil, fmt.Errorf("failed to save container: %w", err)
 	}
 
+	// Best effort, as in CreateObjectUseCase
+	_ = syncObjectCodes(ctx, uc.codeRepo, req.UserID, req.ObjectID, existingObject.Codes(), updatedObject.Codes())
+
+	publishEvent(uc.events, entities.NewObjectUpdatedEvent(req.UserID, targetContainer.CollectionID(), targetContainer.ID(), *existingObject, updatedObject, time.Now()))
+
 	return &UpdateObjectResponse{
-		Object:      &updatedObject,
-		ContainerID: targetContainer.ID(),
+		Object:            &updatedObject,
+		ContainerID:       targetContainer.ID(),
+		StorageWarning:    storageWarning(targetContainer, updatedObject, collection.ObjectType()),
+		DroppedProperties: droppedProperties,
 	}, nil
 }
+
+// mergeProperties returns current with raw applied on top of it, coercing
+// the new values like a full update would. A nil value removes the key.
+func (uc *UpdateObjectUseCase) mergeProperties(current map[string]entities.TypedValue, raw map[string]any, schema *entities.PropertySchema) map[string]entities.TypedValue {
+	set := make(map[string]any, len(raw))
+	for key, value := range raw {
+		if value == nil {
+			delete(current, key)
+			continue
+		}
+		set[key] = value
+	}
+	maps.Copy(current, uc.typeInference.CoerceRawProperties(set, schema))
+	return current
+}
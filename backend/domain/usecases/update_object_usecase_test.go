@@ -21,9 +21,11 @@ func TestUpdateObjectUseCase_Execute(t *testing.T) {
 
 	mockContainerRepo := mocks.NewMockContainerRepository(mockCtrl)
 	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockMoveRepo := mocks.NewMockObjectMoveRepository(mockCtrl)
+	mockCodeRepo := mocks.NewMockObjectCodeRepository(mockCtrl)
 	mockAuthService := mocks.NewMockAuthService(mockCtrl)
 
-	useCase := NewUpdateObjectUseCase(mockContainerRepo, mockCollectionRepo, mockAuthService)
+	useCase := NewUpdateObjectUseCase(mockContainerRepo, mockCollectionRepo, mockMoveRepo, mockCodeRepo, mockAuthService, nil)
 
 	t.Run("success - update object name", func(t *testing.T) {
 		userID := entities.NewUserID()
@@ -91,6 +93,247 @@ func TestUpdateObjectUseCase_Execute(t *testing.T) {
 		require.NotNil(t, resp)
 	})
 
+	t.Run("success - merge properties keeps unsent ones", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjProps(Props("brand", "Acme", "color", "red", "size", "large")), ObjTags("kept"))
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		req := UpdateObjectRequest{
+			ObjectID:        objectID,
+			RawProperties:   map[string]any{"color": "blue", "size": nil},
+			MergeProperties: true,
+			UserID:          userID,
+			UserToken:       "test-token",
+		}
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		props := resp.Object.Properties()
+		assert.Equal(t, "Acme", props["brand"].Val)
+		assert.Equal(t, "blue", props["color"].Val)
+		assert.NotContains(t, props, "size")
+		assert.Equal(t, []string{"kept"}, resp.Object.Tags())
+	})
+
+	t.Run("success - min quantity marks low stock and negative clears it", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		containerID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjQuantity(2))
+		container := NewTestContainer(CtrID(containerID), CtrCollectionID(collectionID), CtrObjects(*obj))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil).Times(2)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil).Times(2)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil).Times(2)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		threshold := 2.0
+		resp, err := useCase.Execute(context.Background(), UpdateObjectRequest{
+			ObjectID:    objectID,
+			MinQuantity: &threshold,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.StockStatusLow, resp.Object.StockStatus(), "quantity at the threshold is low")
+
+		none := -1.0
+		resp, err = useCase.Execute(context.Background(), UpdateObjectRequest{
+			ObjectID:    objectID,
+			MinQuantity: &none,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Object.MinQuantity())
+		assert.False(t, resp.Object.IsLowStock())
+	})
+
+	t.Run("condition grades collectibles only", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		bookID := entities.NewObjectID()
+		toolID := entities.NewObjectID()
+
+		book := NewTestObject(ObjID(bookID), ObjType(entities.ObjectTypeBook))
+		tool := NewTestObject(ObjID(toolID))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*book, *tool))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), gomock.Any()).Return(container, nil).Times(2)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil).Times(2)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil).Times(2)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		nearMint := entities.ConditionNearMint
+		resp, err := useCase.Execute(context.Background(), UpdateObjectRequest{
+			ObjectID:  bookID,
+			Condition: &nearMint,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.ConditionNearMint, resp.Object.Condition())
+
+		_, err = useCase.Execute(context.Background(), UpdateObjectRequest{
+			ObjectID:  toolID,
+			Condition: &nearMint,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+		assert.ErrorIs(t, err, entities.ErrConditionNotSupported)
+	})
+
+	t.Run("marking a wanted object owned counts it", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		objectID := entities.NewObjectID()
+
+		wanted := NewTestObject(ObjID(objectID), ObjStatus(entities.ObjectStatusWanted), ObjQuantity(0), ObjMinQuantity(1))
+		container := NewTestContainer(CtrCollectionID(collectionID), CtrObjects(*wanted))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+		require.Equal(t, 0, container.ObjectCount())
+		require.Equal(t, 1, container.WishlistCount())
+		require.False(t, wanted.IsLowStock())
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(container, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		owned := entities.ObjectStatusOwned
+		resp, err := useCase.Execute(context.Background(), UpdateObjectRequest{
+			ObjectID:  objectID,
+			Status:    &owned,
+			UserID:    userID,
+			UserToken: "test-token",
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Object.IsOwned())
+		assert.True(t, resp.Object.IsLowStock())
+		assert.Equal(t, 1, container.ObjectCount())
+		assert.Equal(t, 0, container.WishlistCount())
+	})
+
+	t.Run("success - move records history", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		fromID := entities.NewContainerID()
+		toID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID))
+		from := NewTestContainer(CtrID(fromID), CtrName("Kitchen"), CtrCollectionID(collectionID), CtrObjects(*obj))
+		to := NewTestContainer(CtrID(toID), CtrName("Garage"), CtrCollectionID(collectionID))
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(from, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), toID).Return(to, nil)
+		mockMoveRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *entities.ObjectMove) error {
+			assert.Equal(t, objectID, m.ObjectID())
+			assert.Equal(t, "Kitchen", m.From().ContainerName)
+			assert.Equal(t, "Garage", m.To().ContainerName)
+			assert.Equal(t, userID, m.MovedByUserID())
+			return nil
+		})
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		resp, err := useCase.Execute(context.Background(), UpdateObjectRequest{
+			ContainerID: &toID,
+			ObjectID:    objectID,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, toID, resp.ContainerID)
+	})
+
+	t.Run("success - move to another collection remaps properties", func(t *testing.T) {
+		userID := entities.NewUserID()
+		fromCollectionID := entities.NewCollectionID()
+		toCollectionID := entities.NewCollectionID()
+		fromID := entities.NewContainerID()
+		toID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjType(entities.ObjectTypeBook), ObjProps(Props("author", "Le Guin", "shelf", "Top")))
+		from := NewTestContainer(CtrID(fromID), CtrCollectionID(fromCollectionID), CtrObjects(*obj))
+		to := NewTestContainer(CtrID(toID), CtrCollectionID(toCollectionID))
+		fromCollection := NewTestCollection(ColID(fromCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook))
+		toCollection := NewTestCollection(ColID(toCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook), ColSchema(&entities.PropertySchema{
+			Definitions: []entities.PropertyDefinition{{Key: "author", DisplayName: "Author", Type: entities.PropertyTypeText}},
+		}))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(from, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), fromCollectionID).Return(fromCollection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), toID).Return(to, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), toCollectionID).Return(toCollection, nil)
+		mockMoveRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockContainerRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		resp, err := useCase.Execute(context.Background(), UpdateObjectRequest{
+			ContainerID: &toID,
+			ObjectID:    objectID,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, toID, resp.ContainerID)
+		assert.Equal(t, []string{"shelf"}, resp.DroppedProperties)
+		assert.Equal(t, Props("author", "Le Guin"), resp.Object.Properties())
+	})
+
+	t.Run("error - move to a collection of another object type", func(t *testing.T) {
+		userID := entities.NewUserID()
+		fromCollectionID := entities.NewCollectionID()
+		toCollectionID := entities.NewCollectionID()
+		toID := entities.NewContainerID()
+		objectID := entities.NewObjectID()
+
+		obj := NewTestObject(ObjID(objectID), ObjType(entities.ObjectTypeBook))
+		from := NewTestContainer(CtrCollectionID(fromCollectionID), CtrObjects(*obj))
+		to := NewTestContainer(CtrID(toID), CtrCollectionID(toCollectionID))
+		fromCollection := NewTestCollection(ColID(fromCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeBook))
+		toCollection := NewTestCollection(ColID(toCollectionID), ColUserID(userID), ColObjectType(entities.ObjectTypeFood))
+
+		mockContainerRepo.EXPECT().FindByObjectID(gomock.Any(), objectID).Return(from, nil)
+		mockAuthService.EXPECT().GetUserGroups(gomock.Any(), "test-token", userID.String()).Return([]*entities.Group{}, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), fromCollectionID).Return(fromCollection, nil)
+		mockContainerRepo.EXPECT().GetByID(gomock.Any(), toID).Return(to, nil)
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), toCollectionID).Return(toCollection, nil)
+
+		resp, err := useCase.Execute(context.Background(), UpdateObjectRequest{
+			ContainerID: &toID,
+			ObjectID:    objectID,
+			UserID:      userID,
+			UserToken:   "test-token",
+		})
+
+		assert.ErrorIs(t, err, entities.ErrObjectTypeMismatch)
+		assert.Nil(t, resp)
+	})
+
 	t.Run("error - object not found", func(t *testing.T) {
 		userID := entities.NewUserID()
 		containerID := entities.NewContainerID()
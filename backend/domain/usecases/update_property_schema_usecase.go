@@ -7,12 +7,14 @@ import (
 
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
 )
 
 type UpdatePropertySchemaRequest struct {
 	CollectionID   entities.CollectionID
 	UserID         entities.UserID
 	PropertySchema *entities.PropertySchema
+	UserToken      string
 }
 
 type UpdatePropertySchemaResponse struct {
@@ -21,10 +23,11 @@ type UpdatePropertySchemaResponse struct {
 
 type UpdatePropertySchemaUseCase struct {
 	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
 }
 
-func NewUpdatePropertySchemaUseCase(collectionRepo repositories.CollectionRepository) *UpdatePropertySchemaUseCase {
-	return &UpdatePropertySchemaUseCase{collectionRepo: collectionRepo}
+func NewUpdatePropertySchemaUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *UpdatePropertySchemaUseCase {
+	return &UpdatePropertySchemaUseCase{collectionRepo: collectionRepo, authService: authService}
 }
 
 func (uc *UpdatePropertySchemaUseCase) Execute(ctx context.Context, req UpdatePropertySchemaRequest) (*UpdatePropertySchemaResponse, error) {
@@ -33,7 +36,11 @@ func (uc *UpdatePropertySchemaUseCase) Execute(ctx context.Context, req UpdatePr
 		return nil, errors.New("collection not found")
 	}
 
-	if !collection.UserID().Equals(req.UserID) {
+	canManage, err := canManageCollection(ctx, uc.authService, collection, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
 		return nil, errors.New("access denied: only collection owner can update schema")
 	}
 
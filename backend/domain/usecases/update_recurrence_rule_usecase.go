@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UpdateRecurrenceRuleRequest struct {
+	RuleID     entities.RecurrenceRuleID
+	Recurrence *entities.Recurrence
+	// Quantity replaces how much to buy when set; 0 clears it.
+	Quantity *float64
+	// Bought takes the staple off the shopping list until it next comes due.
+	Bought bool
+	UserID entities.UserID
+}
+
+type UpdateRecurrenceRuleResponse struct {
+	Rule *entities.RecurrenceRule
+}
+
+type UpdateRecurrenceRuleUseCase struct {
+	recurrenceRepo repositories.RecurrenceRuleRepository
+}
+
+func NewUpdateRecurrenceRuleUseCase(recurrenceRepo repositories.RecurrenceRuleRepository) *UpdateRecurrenceRuleUseCase {
+	return &UpdateRecurrenceRuleUseCase{
+		recurrenceRepo: recurrenceRepo,
+	}
+}
+
+func (uc *UpdateRecurrenceRuleUseCase) Execute(ctx context.Context, req UpdateRecurrenceRuleRequest) (*UpdateRecurrenceRuleResponse, error) {
+	rule, err := uc.recurrenceRepo.GetByID(ctx, req.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' rules are reported as missing so IDs cannot be probed
+	if !rule.IsOwnedBy(req.UserID) {
+		return nil, entities.ErrRecurrenceRuleNotFound
+	}
+
+	if req.Recurrence != nil {
+		if err := rule.UpdateRecurrence(*req.Recurrence, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if req.Quantity != nil {
+		quantity := req.Quantity
+		if *quantity == 0 {
+			quantity = nil
+		}
+		if err := rule.UpdateQuantity(quantity); err != nil {
+			return nil, err
+		}
+	}
+	if req.Bought {
+		rule.MarkBought()
+	}
+
+	if err := uc.recurrenceRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save recurrence rule: %w", err)
+	}
+
+	return &UpdateRecurrenceRuleResponse{
+		Rule: rule,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+type UpdateShelfLifeRequest struct {
+	CollectionID entities.CollectionID
+	UserID       entities.UserID
+	ShelfLife    []entities.ShelfLife
+	UserToken    string
+}
+
+type UpdateShelfLifeResponse struct {
+	Collection *entities.Collection
+}
+
+// UpdateShelfLifeUseCase replaces the default shelf life rules that give new
+// food objects an expiry when none is supplied
+type UpdateShelfLifeUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	authService    services.AuthService
+}
+
+func NewUpdateShelfLifeUseCase(collectionRepo repositories.CollectionRepository, authService services.AuthService) *UpdateShelfLifeUseCase {
+	return &UpdateShelfLifeUseCase{collectionRepo: collectionRepo, authService: authService}
+}
+
+func (uc *UpdateShelfLifeUseCase) Execute(ctx context.Context, req UpdateShelfLifeRequest) (*UpdateShelfLifeResponse, error) {
+	collection, err := uc.collectionRepo.GetByID(ctx, req.CollectionID)
+	if err != nil {
+		return nil, errors.New("collection not found")
+	}
+
+	canManage, err := canManageCollection(ctx, uc.authService, collection, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("access denied: only collection owner can update shelf life defaults")
+	}
+
+	if err := collection.UpdateShelfLife(req.ShelfLife); err != nil {
+		return nil, err
+	}
+
+	if err := uc.collectionRepo.Update(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to save collection: %w", err)
+	}
+
+	return &UpdateShelfLifeResponse{Collection: collection}, nil
+}
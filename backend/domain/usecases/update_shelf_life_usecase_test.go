@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestUpdateShelfLifeUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCollectionRepo := mocks.NewMockCollectionRepository(mockCtrl)
+	mockAuthService := mocks.NewMockAuthService(mockCtrl)
+
+	useCase := NewUpdateShelfLifeUseCase(mockCollectionRepo, mockAuthService)
+
+	mustShelfLife := func(category string, days int) entities.ShelfLife {
+		r, err := entities.NewShelfLife(category, days)
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("success - owner replaces rules", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColShelfLife(mustShelfLife("bread", 5)))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), collection).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), UpdateShelfLifeRequest{
+			CollectionID: collectionID,
+			UserID:       userID,
+			ShelfLife:    []entities.ShelfLife{mustShelfLife("dairy", 7), mustShelfLife("frozen", 90)},
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		rules := resp.Collection.ShelfLife()
+		require.Len(t, rules, 2)
+		assert.Equal(t, "dairy", rules[0].Category())
+		assert.Equal(t, 90, rules[1].Days())
+	})
+
+	t.Run("success - empty list clears rules", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID), ColShelfLife(mustShelfLife("dairy", 7)))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+		mockCollectionRepo.EXPECT().Update(gomock.Any(), collection).Return(nil)
+
+		resp, err := useCase.Execute(context.Background(), UpdateShelfLifeRequest{
+			CollectionID: collectionID,
+			UserID:       userID,
+			UserToken:    "test-token",
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Collection.ShelfLife())
+	})
+
+	t.Run("error - duplicate category", func(t *testing.T) {
+		userID := entities.NewUserID()
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(userID))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		_, err := useCase.Execute(context.Background(), UpdateShelfLifeRequest{
+			CollectionID: collectionID,
+			UserID:       userID,
+			ShelfLife:    []entities.ShelfLife{mustShelfLife("Dairy", 7), mustShelfLife("dairy", 10)},
+			UserToken:    "test-token",
+		})
+
+		require.ErrorIs(t, err, entities.ErrDuplicateShelfLife)
+	})
+
+	t.Run("error - not the owner", func(t *testing.T) {
+		collectionID := entities.NewCollectionID()
+		collection := NewTestCollection(ColID(collectionID), ColUserID(entities.NewUserID()))
+
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(collection, nil)
+
+		_, err := useCase.Execute(context.Background(), UpdateShelfLifeRequest{
+			CollectionID: collectionID,
+			UserID:       entities.NewUserID(),
+			ShelfLife:    []entities.ShelfLife{mustShelfLife("dairy", 7)},
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - collection not found", func(t *testing.T) {
+		collectionID := entities.NewCollectionID()
+		mockCollectionRepo.EXPECT().GetByID(gomock.Any(), collectionID).Return(nil, errors.New("not found"))
+
+		_, err := useCase.Execute(context.Background(), UpdateShelfLifeRequest{
+			CollectionID: collectionID,
+			UserID:       entities.NewUserID(),
+			UserToken:    "test-token",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "collection not found")
+	})
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+type UpdateUserPreferencesRequest struct {
+	UserID entities.UserID
+	// Views maps view keys to their new preferences. Views not listed keep
+	// their saved preferences; an empty preference forgets the view.
+	Views map[string]entities.ViewPreference
+	// LabelTemplate, when set, replaces the label stock; "" resets it.
+	LabelTemplate *string
+	// UnitSystem, when set, replaces the unit system; "" resets it.
+	UnitSystem *string
+}
+
+type UpdateUserPreferencesResponse struct {
+	Preferences *entities.UserPreferences
+}
+
+type UpdateUserPreferencesUseCase struct {
+	preferencesRepo repositories.UserPreferencesRepository
+}
+
+func NewUpdateUserPreferencesUseCase(preferencesRepo repositories.UserPreferencesRepository) *UpdateUserPreferencesUseCase {
+	return &UpdateUserPreferencesUseCase{
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+// Execute merges the given views into the saved preferences. Merging per view
+// rather than replacing the whole document keeps two devices that are open at
+// the same time from wiping each other's views.
+func (uc *UpdateUserPreferencesUseCase) Execute(ctx context.Context, req UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error) {
+	preferences, err := uc.preferencesRepo.GetByUserID(ctx, req.UserID)
+	switch {
+	case errors.Is(err, entities.ErrUserPreferencesNotFound):
+		preferences = entities.NewUserPreferences(req.UserID)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+
+	for key, view := range req.Views {
+		if err := preferences.SetView(key, view); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.LabelTemplate != nil {
+		if err := preferences.SetLabelTemplate(*req.LabelTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.UnitSystem != nil {
+		if err := preferences.SetUnitSystem(*req.UnitSystem); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.preferencesRepo.Save(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("failed to save user preferences: %w", err)
+	}
+
+	return &UpdateUserPreferencesResponse{
+		Preferences: preferences,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// MediaUpload is one photo in a batch upload.
+type MediaUpload struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type UploadMediaRequest struct {
+	// CollectionID, when set, must be the collection the owner belongs to.
+	CollectionID *entities.CollectionID
+	OwnerKind    entities.MediaOwnerKind
+	OwnerID      string
+	Files        []MediaUpload
+	UserID       entities.UserID
+	UserToken    string
+}
+
+// MediaUploadError reports a photo of the batch that was not saved.
+type MediaUploadError struct {
+	Filename string
+	Err      error
+}
+
+type UploadMediaResponse struct {
+	Media  []*entities.Media
+	Failed []MediaUploadError
+}
+
+type UploadMediaUseCase struct {
+	containerRepo  repositories.ContainerRepository
+	collectionRepo repositories.CollectionRepository
+	mediaRepo      repositories.MediaRepository
+	mediaStorage   services.MediaStorage
+	quotaRepo      repositories.GroupQuotaRepository
+	usageRepo      repositories.UsageRepository
+	authService    services.AuthService
+	maxUploadSize  int64
+	maxPerOwner    int
+}
+
+func NewUploadMediaUseCase(
+	containerRepo repositories.ContainerRepository,
+	collectionRepo repositories.CollectionRepository,
+	mediaRepo repositories.MediaRepository,
+	mediaStorage services.MediaStorage,
+	quotaRepo repositories.GroupQuotaRepository,
+	usageRepo repositories.UsageRepository,
+	authService services.AuthService,
+	maxUploadSize int64,
+	maxPerOwner int,
+) *UploadMediaUseCase {
+	return &UploadMediaUseCase{
+		containerRepo:  containerRepo,
+		collectionRepo: collectionRepo,
+		mediaRepo:      mediaRepo,
+		mediaStorage:   mediaStorage,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+		authService:    authService,
+		maxUploadSize:  maxUploadSize,
+		maxPerOwner:    maxPerOwner,
+	}
+}
+
+// Execute saves each photo independently: one that is too large or not an
+// image is reported in Failed without stopping the rest of the batch. The
+// whole batch is refused when it would take the owner past its photo limit
+// or the collection's group past its storage quota.
+func (uc *UploadMediaUseCase) Execute(ctx context.Context, req UploadMediaRequest) (*UploadMediaResponse, error) {
+	collection, err := getMediaOwnerCollection(ctx, uc.containerRepo, uc.collectionRepo, uc.authService, req.OwnerKind, req.OwnerID, req.UserID, req.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if req.CollectionID != nil && !req.CollectionID.Equals(collection.ID()) {
+		return nil, entities.ErrMediaOwnerNotInCollection
+	}
+
+	existing, err := uc.mediaRepo.CountByOwner(ctx, req.OwnerKind, req.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count photos: %w", err)
+	}
+	if int(existing)+len(req.Files) > uc.maxPerOwner {
+		return nil, fmt.Errorf("%w: at most %d allowed, %d already attached", entities.ErrTooManyMedia, uc.maxPerOwner, existing)
+	}
+
+	var batchBytes int64
+	for _, file := range req.Files {
+		batchBytes += int64(len(file.Data))
+	}
+	if err := checkGroupQuota(ctx, uc.quotaRepo, uc.usageRepo, collection, entities.QuotaStorage, batchBytes); err != nil {
+		return nil, err
+	}
+
+	resp := &UploadMediaResponse{}
+	for _, file := range req.Files {
+		media, err := uc.save(ctx, collection.ID(), req, file)
+		if err != nil {
+			resp.Failed = append(resp.Failed, MediaUploadError{Filename: file.Filename, Err: err})
+			continue
+		}
+		resp.Media = append(resp.Media, media)
+	}
+
+	return resp, nil
+}
+
+func (uc *UploadMediaUseCase) save(ctx context.Context, collectionID entities.CollectionID, req UploadMediaRequest, file MediaUpload) (*entities.Media, error) {
+	if int64(len(file.Data)) > uc.maxUploadSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", entities.ErrMediaTooLarge, uc.maxUploadSize)
+	}
+	if !entities.IsSupportedMediaType(file.ContentType) {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+
+	id := entities.NewMediaID()
+	stored, err := uc.mediaStorage.Save(ctx, collectionID, id, file.ContentType, file.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := entities.NewMedia(entities.MediaProps{
+		ID:           id,
+		CollectionID: collectionID,
+		OwnerKind:    req.OwnerKind,
+		OwnerID:      req.OwnerID,
+		UploadedBy:   req.UserID,
+		Filename:     file.Filename,
+		ContentType:  file.ContentType,
+		Size:         int64(len(file.Data)),
+		Width:        stored.Width,
+		Height:       stored.Height,
+		URL:          stored.URL,
+		ThumbnailURL: stored.ThumbnailURL,
+	})
+	if err == nil {
+		err = uc.mediaRepo.Create(ctx, media)
+	}
+	if err != nil {
+		// Don't leave an unreferenced file behind
+		_ = uc.mediaStorage.Delete(ctx, collectionID, id)
+		return nil, err
+	}
+	return media, nil
+}
+
+// getMediaOwnerCollection finds the collection of the object or container a
+// photo is attached to and checks that userID may access it.
+func getMediaOwnerCollection(ctx context.Context, containerRepo repositories.ContainerRepository, collectionRepo repositories.CollectionRepository, authService services.AuthService, kind entities.MediaOwnerKind, ownerID string, userID entities.UserID, userToken string) (*entities.Collection, error) {
+	var container *entities.Container
+	switch kind {
+	case entities.MediaOwnerObject:
+		objectID, err := entities.ObjectIDFromHex(ownerID)
+		if err != nil {
+			return nil, err
+		}
+		if container, err = containerRepo.FindByObjectID(ctx, objectID); err != nil {
+			return nil, fmt.Errorf("object not found: %w", err)
+		}
+	case entities.MediaOwnerContainer:
+		containerID, err := entities.ContainerIDFromString(ownerID)
+		if err != nil {
+			return nil, err
+		}
+		if container, err = containerRepo.GetByID(ctx, containerID); err != nil {
+			return nil, fmt.Errorf("container not found: %w", err)
+		}
+	default:
+		return nil, entities.ErrInvalidMediaOwnerKind
+	}
+
+	return getAccessibleCollection(ctx, collectionRepo, authService, container.CollectionID(), userID, userToken)
+}
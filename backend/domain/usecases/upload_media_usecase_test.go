@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestUploadMediaUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	type fixture struct {
+		containerRepo  *mocks.MockContainerRepository
+		collectionRepo *mocks.MockCollectionRepository
+		mediaRepo      *mocks.MockMediaRepository
+		mediaStorage   *mocks.MockMediaStorage
+		authService    *mocks.MockAuthService
+		useCase        *UploadMediaUseCase
+	}
+	setup := func(t *testing.T) fixture {
+		mockCtrl := gomock.NewController(t)
+		f := fixture{
+			containerRepo:  mocks.NewMockContainerRepository(mockCtrl),
+			collectionRepo: mocks.NewMockCollectionRepository(mockCtrl),
+			mediaRepo:      mocks.NewMockMediaRepository(mockCtrl),
+			mediaStorage:   mocks.NewMockMediaStorage(mockCtrl),
+			authService:    mocks.NewMockAuthService(mockCtrl),
+		}
+		f.useCase = NewUploadMediaUseCase(f.containerRepo, f.collectionRepo, f.mediaRepo, f.mediaStorage, mocks.NewMockGroupQuotaRepository(mockCtrl), mocks.NewMockUsageRepository(mockCtrl), f.authService, 10, 3)
+		return f
+	}
+
+	t.Run("success - saves the valid photos and reports the rest", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		object := NewTestObject()
+		container := NewTestContainer(CtrCollectionID(collection.ID()), CtrObjects(*object))
+
+		f.containerRepo.EXPECT().FindByObjectID(gomock.Any(), object.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.mediaRepo.EXPECT().CountByOwner(gomock.Any(), entities.MediaOwnerObject, object.ID().String()).Return(int64(0), nil)
+		f.mediaStorage.EXPECT().Save(gomock.Any(), collection.ID(), gomock.Any(), "image/png", []byte("png")).
+			Return(&services.StoredMedia{URL: "/media/box.png", ThumbnailURL: "/media/box_thumb.jpg", Width: 4, Height: 3}, nil)
+		f.mediaRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := f.useCase.Execute(context.Background(), UploadMediaRequest{
+			OwnerKind: entities.MediaOwnerObject,
+			OwnerID:   object.ID().String(),
+			Files: []MediaUpload{
+				{Filename: "box.png", ContentType: "image/png", Data: []byte("png")},
+				{Filename: "notes.txt", ContentType: "text/plain", Data: []byte("txt")},
+				{Filename: "huge.jpg", ContentType: "image/jpeg", Data: []byte("way too big")},
+			},
+			UserID: userID,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Media, 1)
+		assert.Equal(t, "box.png", resp.Media[0].Filename())
+		assert.Equal(t, "/media/box_thumb.jpg", resp.Media[0].ThumbnailURL())
+		assert.Equal(t, collection.ID(), resp.Media[0].CollectionID())
+		require.Len(t, resp.Failed, 2)
+		assert.ErrorIs(t, resp.Failed[0].Err, entities.ErrUnsupportedMediaType)
+		assert.ErrorIs(t, resp.Failed[1].Err, entities.ErrMediaTooLarge)
+	})
+
+	t.Run("error - refuses a batch past the per-owner limit", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		container := NewTestContainer(CtrCollectionID(collection.ID()))
+
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), container.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.mediaRepo.EXPECT().CountByOwner(gomock.Any(), entities.MediaOwnerContainer, container.ID().String()).Return(int64(2), nil)
+
+		resp, err := f.useCase.Execute(context.Background(), UploadMediaRequest{
+			OwnerKind: entities.MediaOwnerContainer,
+			OwnerID:   container.ID().String(),
+			Files: []MediaUpload{
+				{Filename: "a.png", ContentType: "image/png", Data: []byte("a")},
+				{Filename: "b.png", ContentType: "image/png", Data: []byte("b")},
+			},
+			UserID: userID,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, entities.ErrTooManyMedia)
+	})
+
+	t.Run("error - owner outside the requested collection", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		container := NewTestContainer(CtrCollectionID(collection.ID()))
+		otherID := entities.NewCollectionID()
+
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), container.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), UploadMediaRequest{
+			CollectionID: &otherID,
+			OwnerKind:    entities.MediaOwnerContainer,
+			OwnerID:      container.ID().String(),
+			Files:        []MediaUpload{{Filename: "a.png", ContentType: "image/png", Data: []byte("a")}},
+			UserID:       userID,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, entities.ErrMediaOwnerNotInCollection)
+	})
+
+	t.Run("error - stranger cannot attach photos", func(t *testing.T) {
+		f := setup(t)
+		collection := NewTestCollection()
+		container := NewTestContainer(CtrCollectionID(collection.ID()))
+
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), container.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+
+		resp, err := f.useCase.Execute(context.Background(), UploadMediaRequest{
+			OwnerKind: entities.MediaOwnerContainer,
+			OwnerID:   container.ID().String(),
+			Files:     []MediaUpload{{Filename: "a.png", ContentType: "image/png", Data: []byte("a")}},
+			UserID:    entities.NewUserID(),
+		})
+
+		assert.Nil(t, resp)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("error - storage failure is reported per file", func(t *testing.T) {
+		f := setup(t)
+		userID := entities.NewUserID()
+		collection := NewTestCollection(ColUserID(userID))
+		container := NewTestContainer(CtrCollectionID(collection.ID()))
+
+		f.containerRepo.EXPECT().GetByID(gomock.Any(), container.ID()).Return(container, nil)
+		f.collectionRepo.EXPECT().GetByID(gomock.Any(), collection.ID()).Return(collection, nil)
+		f.mediaRepo.EXPECT().CountByOwner(gomock.Any(), entities.MediaOwnerContainer, container.ID().String()).Return(int64(0), nil)
+		f.mediaStorage.EXPECT().Save(gomock.Any(), collection.ID(), gomock.Any(), "image/gif", gomock.Any()).Return(nil, errors.New("disk full"))
+
+		resp, err := f.useCase.Execute(context.Background(), UploadMediaRequest{
+			OwnerKind: entities.MediaOwnerContainer,
+			OwnerID:   container.ID().String(),
+			Files:     []MediaUpload{{Filename: "a.gif", ContentType: "image/gif", Data: []byte("a")}},
+			UserID:    userID,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Media)
+		require.Len(t, resp.Failed, 1)
+		assert.Equal(t, "a.gif", resp.Failed[0].Filename)
+	})
+}
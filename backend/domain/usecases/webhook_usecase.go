@@ -0,0 +1,217 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+const (
+	// recentWebhookDeliveries is how many deliveries the deliveries list shows
+	recentWebhookDeliveries = 50
+	// webhookRetryBatch caps the retries one scheduler run sends
+	webhookRetryBatch = 100
+)
+
+// webhookDeliverer sends a rule's webhook deliveries and records how each
+// attempt went. It is shared by the first attempt, made as the event comes
+// in, and the retries that follow.
+type webhookDeliverer struct {
+	deliveryRepo repositories.WebhookDeliveryRepository
+	keyRepo      repositories.WebhookSigningKeyRepository
+	sender       services.WebhookSender
+}
+
+// attempt sends delivery once, signed with secret or, without one, the
+// account's signing keys, and saves the outcome. It only fails when the
+// attempt cannot be made or saved; whether the webhook got through is
+// recorded on delivery.
+func (d webhookDeliverer) attempt(ctx context.Context, delivery *entities.WebhookDelivery, secret string) error {
+	if d.sender == nil {
+		return ErrWebhooksUnavailable
+	}
+	now := time.Now()
+	secrets, err := d.secrets(ctx, delivery.UserID(), secret, now)
+	if err != nil {
+		return err
+	}
+
+	err = d.sender.Send(ctx, services.Webhook{
+		URL:     delivery.URL(),
+		Event:   delivery.Event(),
+		Secrets: secrets,
+		Body:    delivery.Payload(),
+	})
+	delivery.RecordAttempt(err, now)
+	if err := d.deliveryRepo.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// secrets returns the keys a webhook sent at now is signed with: the
+// webhook's own secret, else every valid account key, else none
+func (d webhookDeliverer) secrets(ctx context.Context, userID entities.UserID, secret string, now time.Time) ([]string, error) {
+	if secret != "" {
+		return []string{secret}, nil
+	}
+	keys, err := d.keyRepo.GetByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, entities.ErrWebhookSigningKeyNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to load webhook signing keys: %w", err)
+	}
+	return keys.Secrets(now), nil
+}
+
+type RetryWebhooksResponse struct {
+	Sent   int
+	Failed int
+}
+
+// WebhookUseCase manages an account's webhook signing keys and the
+// deliveries its automation rules made.
+type WebhookUseCase struct {
+	ruleRepo  repositories.AutomationRuleRepository
+	deliverer webhookDeliverer
+	logger    *slog.Logger
+}
+
+func NewWebhookUseCase(
+	ruleRepo repositories.AutomationRuleRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	keyRepo repositories.WebhookSigningKeyRepository,
+	sender services.WebhookSender,
+	logger *slog.Logger,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		ruleRepo:  ruleRepo,
+		deliverer: webhookDeliverer{deliveryRepo: deliveryRepo, keyRepo: keyRepo, sender: sender},
+		logger:    logger,
+	}
+}
+
+// SigningKeys returns the user's keys, or ErrWebhookSigningKeyNotFound
+// before the first rotation.
+func (uc *WebhookUseCase) SigningKeys(ctx context.Context, userID entities.UserID) (*entities.WebhookSigningKeys, error) {
+	return uc.deliverer.keyRepo.GetByUserID(ctx, userID)
+}
+
+// RotateSigningKey makes a new signing key for the user, keeping the old one
+// valid for grace. The first rotation creates the account's key.
+func (uc *WebhookUseCase) RotateSigningKey(ctx context.Context, userID entities.UserID, grace time.Duration) (*entities.WebhookSigningKeys, error) {
+	key, err := entities.NewWebhookSigningKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook signing key: %w", err)
+	}
+	now := time.Now()
+
+	keys, err := uc.deliverer.keyRepo.GetByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, entities.ErrWebhookSigningKeyNotFound):
+		keys = entities.NewWebhookSigningKeys(userID, key, now)
+	case err != nil:
+		return nil, fmt.Errorf("failed to load webhook signing keys: %w", err)
+	default:
+		if err := keys.Rotate(key, grace, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.deliverer.keyRepo.Save(ctx, keys); err != nil {
+		return nil, fmt.Errorf("failed to save webhook signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+// ListDeliveries returns the user's most recent deliveries, newest first.
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, userID entities.UserID) ([]*entities.WebhookDelivery, error) {
+	deliveries, err := uc.deliverer.deliveryRepo.ListByUserID(ctx, userID, recentWebhookDeliveries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Redeliver sends one of the user's deliveries again now, whatever became
+// of it before, and returns it with the new attempt recorded.
+func (uc *WebhookUseCase) Redeliver(ctx context.Context, deliveryID entities.WebhookDeliveryID, userID entities.UserID) (*entities.WebhookDelivery, error) {
+	delivery, err := uc.deliverer.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' deliveries are reported as missing so IDs cannot be probed
+	if !delivery.IsOwnedBy(userID) {
+		return nil, entities.ErrWebhookDeliveryNotFound
+	}
+
+	if err := uc.send(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// RetryDue sends every pending delivery whose next attempt has come.
+func (uc *WebhookUseCase) RetryDue(ctx context.Context, now time.Time) (RetryWebhooksResponse, error) {
+	var resp RetryWebhooksResponse
+	due, err := uc.deliverer.deliveryRepo.ListDue(ctx, now, webhookRetryBatch)
+	if err != nil {
+		return resp, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	for _, delivery := range due {
+		err := uc.send(ctx, delivery)
+		if err == nil && delivery.Status() == entities.WebhookSucceeded {
+			resp.Sent++
+			continue
+		}
+		if err == nil {
+			err = errors.New(delivery.LastError())
+		}
+		resp.Failed++
+		uc.logger.Warn("Webhook retry failed",
+			slog.String("delivery_id", delivery.ID().String()),
+			slog.Int("attempts", delivery.Attempts()),
+			slog.String("status", string(delivery.Status())),
+			slog.Any("error", err))
+	}
+	return resp, nil
+}
+
+// PruneDeliveries removes finished deliveries older than
+// entities.WebhookDeliveryRetention.
+func (uc *WebhookUseCase) PruneDeliveries(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := uc.deliverer.deliveryRepo.DeleteCreatedBefore(ctx, now.Add(-entities.WebhookDeliveryRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune webhook deliveries: %w", err)
+	}
+	return deleted, nil
+}
+
+// send attempts delivery with the secret its rule has for the URL now, so a
+// secret changed since the first attempt is picked up. Deliveries of
+// deleted rules fall back to the account's keys.
+func (uc *WebhookUseCase) send(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	var secret string
+	rule, err := uc.ruleRepo.GetByID(ctx, delivery.RuleID())
+	switch {
+	case errors.Is(err, entities.ErrAutomationRuleNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to load automation rule: %w", err)
+	default:
+		for _, action := range rule.Actions() {
+			if action.Kind == entities.ActionFireWebhook && action.URL == delivery.URL() {
+				secret = action.Secret
+				break
+			}
+		}
+	}
+	return uc.deliverer.attempt(ctx, delivery, secret)
+}
@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/mocks"
+)
+
+func TestWebhookUseCase_RotateSigningKey(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKeyRepo := mocks.NewMockWebhookSigningKeyRepository(mockCtrl)
+	useCase := NewWebhookUseCase(nil, nil, mockKeyRepo, nil, slog.New(slog.DiscardHandler))
+	userID := entities.NewUserID()
+
+	t.Run("success - the first rotation creates the key", func(t *testing.T) {
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrWebhookSigningKeyNotFound)
+		mockKeyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		keys, err := useCase.RotateSigningKey(context.Background(), userID, entities.DefaultWebhookKeyGrace)
+		require.NoError(t, err)
+		assert.Regexp(t, `^whsec_[0-9a-f]{64}$`, keys.Current())
+		assert.Empty(t, keys.Previous())
+	})
+
+	t.Run("success - the old key signs until the grace period ends", func(t *testing.T) {
+		keys := entities.NewWebhookSigningKeys(userID, "whsec_old", time.Now().Add(-time.Hour))
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(keys, nil)
+		mockKeyRepo.EXPECT().Save(gomock.Any(), keys).Return(nil)
+
+		rotated, err := useCase.RotateSigningKey(context.Background(), userID, 2*time.Hour)
+		require.NoError(t, err)
+		assert.NotEqual(t, "whsec_old", rotated.Current())
+		assert.Equal(t, []string{rotated.Current(), "whsec_old"}, rotated.Secrets(time.Now()))
+		assert.Equal(t, []string{rotated.Current()}, rotated.Secrets(time.Now().Add(3*time.Hour)))
+	})
+
+	t.Run("success - no grace drops the old key at once", func(t *testing.T) {
+		keys := entities.NewWebhookSigningKeys(userID, "whsec_old", time.Now().Add(-time.Hour))
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(keys, nil)
+		mockKeyRepo.EXPECT().Save(gomock.Any(), keys).Return(nil)
+
+		rotated, err := useCase.RotateSigningKey(context.Background(), userID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{rotated.Current()}, rotated.Secrets(time.Now()))
+	})
+
+	t.Run("error - grace beyond the limit", func(t *testing.T) {
+		keys := entities.NewWebhookSigningKeys(userID, "whsec_old", time.Now())
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(keys, nil)
+
+		_, err := useCase.RotateSigningKey(context.Background(), userID, entities.MaxWebhookKeyGrace+time.Hour)
+		assert.ErrorIs(t, err, entities.ErrInvalidWebhookKeyGrace)
+		assert.Equal(t, "whsec_old", keys.Current())
+	})
+}
+
+func TestWebhookUseCase_RetryDue(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRuleRepo := mocks.NewMockAutomationRuleRepository(mockCtrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(mockCtrl)
+	mockKeyRepo := mocks.NewMockWebhookSigningKeyRepository(mockCtrl)
+	mockWebhookSender := mocks.NewMockWebhookSender(mockCtrl)
+
+	useCase := NewWebhookUseCase(mockRuleRepo, mockDeliveryRepo, mockKeyRepo, mockWebhookSender, slog.New(slog.DiscardHandler))
+
+	now := time.Now()
+	userID := entities.NewUserID()
+	rule, err := entities.NewAutomationRule(userID, entities.AutomationRuleProps{
+		Name:    "Rule",
+		Enabled: true,
+		Trigger: entities.AutomationTrigger{Kind: entities.TriggerObjectCreated},
+		Actions: []entities.AutomationAction{{Kind: entities.ActionFireWebhook, URL: "https://example.com/hook", Secret: "rotated"}},
+	}, now)
+	require.NoError(t, err)
+
+	newDelivery := func(retry entities.WebhookRetryPolicy, attempts int) *entities.WebhookDelivery {
+		last := now.Add(-time.Minute)
+		return entities.ReconstructWebhookDelivery(entities.NewWebhookDeliveryID(), userID, entities.WebhookDeliveryProps{
+			RuleID:  rule.ID(),
+			URL:     "https://example.com/hook",
+			Event:   string(entities.ObjectCreated),
+			Payload: []byte(`{}`),
+			Retry:   retry,
+		}, entities.WebhookPending, attempts, "timeout", &last, &now, now.Add(-time.Hour))
+	}
+
+	t.Run("success - a retry uses the rule's secret as it is now", func(t *testing.T) {
+		delivery := newDelivery(entities.WebhookRetryPolicy{MaxRetries: 3, Backoff: 30}, 1)
+
+		mockDeliveryRepo.EXPECT().ListDue(gomock.Any(), now, gomock.Any()).Return([]*entities.WebhookDelivery{delivery}, nil)
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID()).Return(rule, nil)
+		mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hook services.Webhook) error {
+			assert.Equal(t, []string{"rotated"}, hook.Secrets)
+			assert.Equal(t, `{}`, string(hook.Body))
+			return nil
+		})
+		mockDeliveryRepo.EXPECT().Update(gomock.Any(), delivery).Return(nil)
+
+		resp, err := useCase.RetryDue(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, RetryWebhooksResponse{Sent: 1}, resp)
+		assert.Equal(t, entities.WebhookSucceeded, delivery.Status())
+		assert.Equal(t, 2, delivery.Attempts())
+		assert.Empty(t, delivery.LastError())
+	})
+
+	t.Run("partial - the wait doubles and the last retry fails the delivery", func(t *testing.T) {
+		retrying := newDelivery(entities.WebhookRetryPolicy{MaxRetries: 3, Backoff: 30}, 2)
+		exhausted := newDelivery(entities.WebhookRetryPolicy{MaxRetries: 3, Backoff: 30}, 3)
+
+		mockDeliveryRepo.EXPECT().ListDue(gomock.Any(), now, gomock.Any()).Return([]*entities.WebhookDelivery{retrying, exhausted}, nil)
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID()).Return(rule, nil).Times(2)
+		mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("webhook answered with status 503")).Times(2)
+		mockDeliveryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		resp, err := useCase.RetryDue(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, RetryWebhooksResponse{Failed: 2}, resp)
+
+		assert.Equal(t, entities.WebhookPending, retrying.Status())
+		require.NotNil(t, retrying.NextAttemptAt())
+		assert.Equal(t, 2*time.Minute, retrying.NextAttemptAt().Sub(*retrying.LastAttemptAt()), "third attempt waits 30s doubled twice")
+
+		assert.Equal(t, entities.WebhookFailed, exhausted.Status())
+		assert.Nil(t, exhausted.NextAttemptAt())
+		assert.Equal(t, "webhook answered with status 503", exhausted.LastError())
+	})
+
+	t.Run("success - deliveries of deleted rules are signed with the account's keys", func(t *testing.T) {
+		delivery := newDelivery(entities.WebhookRetryPolicy{MaxRetries: 1, Backoff: 30}, 1)
+		keys := entities.NewWebhookSigningKeys(userID, "whsec_account", now)
+
+		mockDeliveryRepo.EXPECT().ListDue(gomock.Any(), now, gomock.Any()).Return([]*entities.WebhookDelivery{delivery}, nil)
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID()).Return(nil, entities.ErrAutomationRuleNotFound)
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(keys, nil)
+		mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hook services.Webhook) error {
+			assert.Equal(t, []string{"whsec_account"}, hook.Secrets)
+			return nil
+		})
+		mockDeliveryRepo.EXPECT().Update(gomock.Any(), delivery).Return(nil)
+
+		resp, err := useCase.RetryDue(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Sent)
+	})
+}
+
+func TestWebhookUseCase_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRuleRepo := mocks.NewMockAutomationRuleRepository(mockCtrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(mockCtrl)
+	mockKeyRepo := mocks.NewMockWebhookSigningKeyRepository(mockCtrl)
+	mockWebhookSender := mocks.NewMockWebhookSender(mockCtrl)
+
+	useCase := NewWebhookUseCase(mockRuleRepo, mockDeliveryRepo, mockKeyRepo, mockWebhookSender, slog.New(slog.DiscardHandler))
+
+	userID := entities.NewUserID()
+	failed := entities.NewWebhookDelivery(userID, entities.WebhookDeliveryProps{
+		RuleID:  entities.NewAutomationRuleID(),
+		URL:     "https://example.com/hook",
+		Event:   string(entities.ObjectUpdated),
+		Payload: []byte(`{"event":"object.updated"}`),
+	}, time.Now())
+	failed.RecordAttempt(errors.New("connection refused"), time.Now())
+	require.Equal(t, entities.WebhookFailed, failed.Status())
+
+	t.Run("success - a failed delivery is sent again", func(t *testing.T) {
+		mockDeliveryRepo.EXPECT().GetByID(gomock.Any(), failed.ID()).Return(failed, nil)
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), failed.RuleID()).Return(nil, entities.ErrAutomationRuleNotFound)
+		mockKeyRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, entities.ErrWebhookSigningKeyNotFound)
+		mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hook services.Webhook) error {
+			assert.Empty(t, hook.Secrets, "unsigned without a key")
+			return nil
+		})
+		mockDeliveryRepo.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+		delivery, err := useCase.Redeliver(context.Background(), failed.ID(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.WebhookSucceeded, delivery.Status())
+		assert.Equal(t, 2, delivery.Attempts())
+	})
+
+	t.Run("error - another user's delivery is not found", func(t *testing.T) {
+		mockDeliveryRepo.EXPECT().GetByID(gomock.Any(), failed.ID()).Return(failed, nil)
+
+		_, err := useCase.Redeliver(context.Background(), failed.ID(), entities.NewUserID())
+		assert.ErrorIs(t, err, entities.ErrWebhookDeliveryNotFound)
+	})
+}
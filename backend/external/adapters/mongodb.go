@@ -18,6 +18,14 @@ type MongoDatabase struct {
 	client   *mongo.Client
 	database *mongo.Database
 	config   config.DatabaseConfig
+
+	// readClient and readDatabase serve the heavy reads when the config
+	// has a read_uri; both are nil otherwise
+	readClient   *mongo.Client
+	readDatabase *mongo.Database
+
+	// ping checks a new client can reach its server; tests replace it
+	ping func(ctx context.Context, client *mongo.Client, rp *readpref.ReadPref) error
 }
 
 type MongoTransaction struct {
@@ -28,32 +36,64 @@ type MongoTransaction struct {
 func NewMongoDatabase(config config.DatabaseConfig) *MongoDatabase {
 	return &MongoDatabase{
 		config: config,
+		ping: func(ctx context.Context, client *mongo.Client, rp *readpref.ReadPref) error {
+			return client.Ping(ctx, rp)
+		},
 	}
 }
 
 func (m *MongoDatabase) Connect(ctx context.Context) error {
 	timeout := time.Duration(m.config.Timeout) * time.Second
-	uri := m.config.GetURI()
-	clientOptions := options.Client().ApplyURI(uri).SetTimeout(timeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	client, err := mongo.Connect(clientOptions)
+	client, err := mongo.Connect(m.clientOptions(m.config.GetURI()))
 	if err != nil {
 		return fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
-
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	if err := client.Ping(timeoutCtx, readpref.Primary()); err != nil {
+	if err := m.ping(timeoutCtx, client, readpref.Primary()); err != nil {
+		_ = client.Disconnect(ctx)
 		return fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
+	var readClient *mongo.Client
+	if m.config.ReadURI != "" {
+		readClient, err = mongo.Connect(m.clientOptions(m.config.ReadURI))
+		if err != nil {
+			_ = client.Disconnect(ctx)
+			return fmt.Errorf("failed to connect to the MongoDB read_uri: %w", err)
+		}
+		// A nil read preference pings the member the read_uri reads from
+		if err := m.ping(timeoutCtx, readClient, nil); err != nil {
+			_ = readClient.Disconnect(ctx)
+			_ = client.Disconnect(ctx)
+			return fmt.Errorf("failed to ping the MongoDB read_uri: %w", err)
+		}
+	}
+
+	// Only keep the clients once both are up, so a failed Connect leaves
+	// nothing open
 	m.client = client
 	m.database = client.Database(m.config.Database)
-
+	if readClient != nil {
+		m.readClient = readClient
+		m.readDatabase = readClient.Database(m.config.Database)
+	}
 	return nil
 }
 
+// clientOptions applies the configured connection pool and timeout to uri.
+// A max_pool_size of 0 leaves the pool unbounded, as it does for the driver.
+// Pool settings given in uri itself win over the config.
+func (m *MongoDatabase) clientOptions(uri string) *options.ClientOptions {
+	return options.Client().
+		SetMaxPoolSize(uint64(m.config.MaxPoolSize)).
+		SetMinPoolSize(uint64(m.config.MinPoolSize)).
+		SetMaxConnIdleTime(time.Duration(m.config.MaxConnIdleTime) * time.Second).
+		ApplyURI(uri).
+		SetTimeout(time.Duration(m.config.Timeout) * time.Second)
+}
+
 func (m *MongoDatabase) Disconnect(ctx context.Context) error {
 	if m.client == nil {
 		return nil
@@ -62,6 +102,11 @@ func (m *MongoDatabase) Disconnect(ctx context.Context) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if m.readClient != nil {
+		if err := m.readClient.Disconnect(timeoutCtx); err != nil {
+			return err
+		}
+	}
 	return m.client.Disconnect(timeoutCtx)
 }
 
@@ -73,7 +118,15 @@ func (m *MongoDatabase) Health(ctx context.Context) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	return m.client.Ping(timeoutCtx, readpref.Primary())
+	if err := m.client.Ping(timeoutCtx, readpref.Primary()); err != nil {
+		return err
+	}
+	if m.readClient != nil {
+		if err := m.readClient.Ping(timeoutCtx, nil); err != nil {
+			return fmt.Errorf("read_uri: %w", err)
+		}
+	}
+	return nil
 }
 
 func (m *MongoDatabase) StartTransaction(ctx context.Context) (adapters.Transaction, error) {
@@ -102,6 +155,15 @@ func (m *MongoDatabase) Database() *mongo.Database {
 	return m.database
 }
 
+// ReadDatabase is the database heavy reads may be served from: the one
+// behind the read_uri when configured, the primary otherwise
+func (m *MongoDatabase) ReadDatabase() *mongo.Database {
+	if m.readDatabase != nil {
+		return m.readDatabase
+	}
+	return m.database
+}
+
 func (m *MongoDatabase) Client() *mongo.Client {
 	return m.client
 }
@@ -0,0 +1,83 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+
+	"github.com/nishiki/backend/app/config"
+)
+
+func TestMongoDatabase_ConnectReadURIFailure(t *testing.T) {
+	t.Parallel()
+
+	db := NewMongoDatabase(config.DatabaseConfig{
+		URI:      "mongodb://127.0.0.1:27017",
+		ReadURI:  "mongodb://127.0.0.1:27018",
+		Database: "nishiki",
+		Timeout:  1,
+	})
+	// mongo.Connect does not dial, so only the pings need faking
+	var primary *mongo.Client
+	db.ping = func(ctx context.Context, client *mongo.Client, rp *readpref.ReadPref) error {
+		if rp != nil {
+			primary = client
+			return nil
+		}
+		return errors.New("no reachable servers")
+	}
+
+	err := db.Connect(t.Context())
+
+	require.ErrorContains(t, err, "read_uri")
+	assert.Nil(t, db.Client(), "a failed connect keeps no client")
+	assert.Nil(t, db.Database())
+	require.NotNil(t, primary)
+	assert.ErrorIs(t, primary.Ping(t.Context(), nil), mongo.ErrClientDisconnected, "the primary client should be closed")
+	assert.NoError(t, db.Disconnect(t.Context()))
+}
+
+func TestMongoDatabase_ConnectWithReadURI(t *testing.T) {
+	t.Parallel()
+
+	db := NewMongoDatabase(config.DatabaseConfig{
+		URI:      "mongodb://127.0.0.1:27017",
+		ReadURI:  "mongodb://127.0.0.1:27018",
+		Database: "nishiki",
+		Timeout:  1,
+	})
+	db.ping = func(context.Context, *mongo.Client, *readpref.ReadPref) error { return nil }
+
+	require.NoError(t, db.Connect(t.Context()))
+	t.Cleanup(func() { _ = db.Disconnect(context.Background()) })
+
+	assert.NotSame(t, db.Database(), db.ReadDatabase())
+	assert.Equal(t, "nishiki", db.ReadDatabase().Name())
+}
+
+func TestMongoDatabase_ClientOptionsPoolSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  config.DatabaseConfig
+		uri  string
+		want uint64
+	}{
+		{name: "configured", cfg: config.DatabaseConfig{MaxPoolSize: 20}, uri: "mongodb://db.lan", want: 20},
+		{name: "zero is unlimited", cfg: config.DatabaseConfig{MaxPoolSize: 0}, uri: "mongodb://db.lan", want: 0},
+		{name: "uri wins", cfg: config.DatabaseConfig{MaxPoolSize: 20}, uri: "mongodb://db.lan/?maxPoolSize=5", want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewMongoDatabase(tt.cfg).clientOptions(tt.uri)
+			require.NotNil(t, opts.MaxPoolSize)
+			assert.Equal(t, tt.want, *opts.MaxPoolSize)
+		})
+	}
+}
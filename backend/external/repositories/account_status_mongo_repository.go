@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type accountStatusDocument struct {
+	UserID      string     `bson:"_id"`
+	Username    string     `bson:"username"`
+	Email       string     `bson:"email"`
+	Disabled    bool       `bson:"disabled"`
+	DisabledAt  *time.Time `bson:"disabled_at,omitempty"`
+	FirstSeenAt time.Time  `bson:"first_seen_at"`
+	LastSeenAt  time.Time  `bson:"last_seen_at"`
+}
+
+type MongoAccountStatusRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoAccountStatusRepository(db *adapters.MongoDatabase) repositories.AccountStatusRepository {
+	return &MongoAccountStatusRepository{
+		db:         db,
+		collection: db.Database().Collection("account_statuses"),
+	}
+}
+
+func (r *MongoAccountStatusRepository) GetByUserID(ctx context.Context, userID entities.UserID) (*entities.AccountStatus, error) {
+	var doc accountStatusDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrAccountStatusNotFound
+		}
+		return nil, fmt.Errorf("failed to get account status: %w", err)
+	}
+
+	return documentToAccountStatus(&doc)
+}
+
+func (r *MongoAccountStatusRepository) Save(ctx context.Context, status *entities.AccountStatus) error {
+	doc := accountStatusToDocument(status)
+
+	filter := bson.M{"_id": doc.UserID}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save account status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoAccountStatusRepository) List(ctx context.Context) ([]*entities.AccountStatus, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account statuses: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var statuses []*entities.AccountStatus
+	for cursor.Next(ctx) {
+		var doc accountStatusDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode account status: %w", err)
+		}
+
+		status, err := documentToAccountStatus(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert account status: %w", err)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return statuses, nil
+}
+
+func (r *MongoAccountStatusRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID.String()}); err != nil {
+		return fmt.Errorf("failed to delete account status: %w", err)
+	}
+
+	return nil
+}
+
+func accountStatusToDocument(s *entities.AccountStatus) *accountStatusDocument {
+	return &accountStatusDocument{
+		UserID:      s.UserID().String(),
+		Username:    s.Username(),
+		Email:       s.Email(),
+		Disabled:    s.IsDisabled(),
+		DisabledAt:  s.DisabledAt(),
+		FirstSeenAt: s.FirstSeenAt(),
+		LastSeenAt:  s.LastSeenAt(),
+	}
+}
+
+func documentToAccountStatus(doc *accountStatusDocument) (*entities.AccountStatus, error) {
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructAccountStatus(userID, doc.Username, doc.Email, doc.Disabled, doc.DisabledAt, doc.FirstSeenAt, doc.LastSeenAt), nil
+}
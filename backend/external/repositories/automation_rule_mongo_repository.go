@@ -0,0 +1,265 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type automationRuleDocument struct {
+	ID        string                     `bson:"_id"`
+	UserID    string                     `bson:"user_id"`
+	Name      string                     `bson:"name"`
+	Enabled   bool                       `bson:"enabled"`
+	Trigger   automationTriggerDocument  `bson:"trigger"`
+	Actions   []automationActionDocument `bson:"actions"`
+	LastRunAt *time.Time                 `bson:"last_run_at,omitempty"`
+	CreatedAt time.Time                  `bson:"created_at"`
+	UpdatedAt time.Time                  `bson:"updated_at"`
+}
+
+type automationTriggerDocument struct {
+	Kind         string  `bson:"kind"`
+	Tag          string  `bson:"tag,omitempty"`
+	Threshold    float64 `bson:"threshold,omitempty"`
+	Days         int     `bson:"days,omitempty"`
+	CollectionID string  `bson:"collection_id,omitempty"`
+}
+
+type automationActionDocument struct {
+	Kind         string `bson:"kind"`
+	Tag          string `bson:"tag,omitempty"`
+	ContainerID  string `bson:"container_id,omitempty"`
+	URL          string `bson:"url,omitempty"`
+	Secret       string `bson:"secret,omitempty"`
+	MaxRetries   int    `bson:"max_retries,omitempty"`
+	RetryBackoff int    `bson:"retry_backoff,omitempty"`
+}
+
+type MongoAutomationRuleRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoAutomationRuleRepository(db *adapters.MongoDatabase) repositories.AutomationRuleRepository {
+	return &MongoAutomationRuleRepository{
+		db:         db,
+		collection: db.Database().Collection("automation_rules"),
+	}
+}
+
+func (r *MongoAutomationRuleRepository) Create(ctx context.Context, rule *entities.AutomationRule) error {
+	if _, err := r.collection.InsertOne(ctx, automationRuleToDocument(rule)); err != nil {
+		return fmt.Errorf("failed to create automation rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoAutomationRuleRepository) GetByID(ctx context.Context, id entities.AutomationRuleID) (*entities.AutomationRule, error) {
+	var doc automationRuleDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrAutomationRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get automation rule: %w", err)
+	}
+
+	return documentToAutomationRule(&doc)
+}
+
+func (r *MongoAutomationRuleRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.AutomationRule, error) {
+	return r.find(ctx, bson.M{"user_id": userID.String()})
+}
+
+func (r *MongoAutomationRuleRepository) ListEnabledByTrigger(ctx context.Context, kind entities.AutomationTriggerKind) ([]*entities.AutomationRule, error) {
+	return r.find(ctx, bson.M{"enabled": true, "trigger.kind": string(kind)})
+}
+
+func (r *MongoAutomationRuleRepository) find(ctx context.Context, filter bson.M) ([]*entities.AutomationRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rules []*entities.AutomationRule
+	for cursor.Next(ctx) {
+		var doc automationRuleDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode automation rule: %w", err)
+		}
+
+		rule, err := documentToAutomationRule(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert automation rule: %w", err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *MongoAutomationRuleRepository) Update(ctx context.Context, rule *entities.AutomationRule) error {
+	doc := automationRuleToDocument(rule)
+	// last_run_at belongs to RecordRun
+	update := bson.M{"$set": bson.M{
+		"name":       doc.Name,
+		"enabled":    doc.Enabled,
+		"trigger":    doc.Trigger,
+		"actions":    doc.Actions,
+		"updated_at": doc.UpdatedAt,
+	}}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update automation rule: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrAutomationRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoAutomationRuleRepository) RecordRun(ctx context.Context, id entities.AutomationRuleID, at time.Time) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id.String()}, bson.M{"$set": bson.M{"last_run_at": at}})
+	if err != nil {
+		return fmt.Errorf("failed to record automation run: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrAutomationRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoAutomationRuleRepository) Delete(ctx context.Context, id entities.AutomationRuleID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete automation rule: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrAutomationRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoAutomationRuleRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete automation rules by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func automationRuleToDocument(rule *entities.AutomationRule) *automationRuleDocument {
+	trigger := rule.Trigger()
+	doc := &automationRuleDocument{
+		ID:      rule.ID().String(),
+		UserID:  rule.UserID().String(),
+		Name:    rule.Name(),
+		Enabled: rule.Enabled(),
+		Trigger: automationTriggerDocument{
+			Kind:      string(trigger.Kind),
+			Tag:       trigger.Tag,
+			Threshold: trigger.Threshold,
+			Days:      trigger.Days,
+		},
+		LastRunAt: rule.LastRunAt(),
+		CreatedAt: rule.CreatedAt(),
+		UpdatedAt: rule.UpdatedAt(),
+	}
+	if trigger.CollectionID != nil {
+		doc.Trigger.CollectionID = trigger.CollectionID.String()
+	}
+	for _, action := range rule.Actions() {
+		actionDoc := automationActionDocument{
+			Kind:         string(action.Kind),
+			Tag:          action.Tag,
+			URL:          action.URL,
+			Secret:       action.Secret,
+			MaxRetries:   action.Retry.MaxRetries,
+			RetryBackoff: action.Retry.Backoff,
+		}
+		if action.ContainerID != nil {
+			actionDoc.ContainerID = action.ContainerID.String()
+		}
+		doc.Actions = append(doc.Actions, actionDoc)
+	}
+	return doc
+}
+
+func documentToAutomationRule(doc *automationRuleDocument) (*entities.AutomationRule, error) {
+	id, err := entities.AutomationRuleIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	trigger := entities.AutomationTrigger{
+		Kind:      entities.AutomationTriggerKind(doc.Trigger.Kind),
+		Tag:       doc.Trigger.Tag,
+		Threshold: doc.Trigger.Threshold,
+		Days:      doc.Trigger.Days,
+	}
+	if doc.Trigger.CollectionID != "" {
+		collectionID, err := entities.CollectionIDFromString(doc.Trigger.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid collection ID: %w", err)
+		}
+		trigger.CollectionID = &collectionID
+	}
+
+	actions := make([]entities.AutomationAction, 0, len(doc.Actions))
+	for _, a := range doc.Actions {
+		action := entities.AutomationAction{
+			Kind:   entities.AutomationActionKind(a.Kind),
+			Tag:    a.Tag,
+			URL:    a.URL,
+			Secret: a.Secret,
+			Retry:  entities.WebhookRetryPolicy{MaxRetries: a.MaxRetries, Backoff: a.RetryBackoff},
+		}
+		if a.ContainerID != "" {
+			containerID, err := entities.ContainerIDFromString(a.ContainerID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid container ID: %w", err)
+			}
+			action.ContainerID = &containerID
+		}
+		actions = append(actions, action)
+	}
+
+	return entities.ReconstructAutomationRule(id, userID, entities.AutomationRuleProps{
+		Name:    doc.Name,
+		Enabled: doc.Enabled,
+		Trigger: trigger,
+		Actions: actions,
+	}, doc.LastRunAt, doc.CreatedAt, doc.UpdatedAt), nil
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type collectionFolderDocument struct {
+	ID            string    `bson:"_id"`
+	UserID        string    `bson:"user_id"`
+	Name          string    `bson:"name"`
+	ParentID      *string   `bson:"parent_id,omitempty"`
+	CollectionIDs []string  `bson:"collection_ids"`
+	CreatedAt     time.Time `bson:"created_at"`
+	UpdatedAt     time.Time `bson:"updated_at"`
+}
+
+type MongoCollectionFolderRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoCollectionFolderRepository(db *adapters.MongoDatabase) repositories.CollectionFolderRepository {
+	return &MongoCollectionFolderRepository{
+		db:         db,
+		collection: db.Database().Collection("collection_folders"),
+	}
+}
+
+func (r *MongoCollectionFolderRepository) Create(ctx context.Context, folder *entities.CollectionFolder) error {
+	if _, err := r.collection.InsertOne(ctx, collectionFolderToDocument(folder)); err != nil {
+		return fmt.Errorf("failed to create collection folder: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoCollectionFolderRepository) GetByID(ctx context.Context, id entities.CollectionFolderID) (*entities.CollectionFolder, error) {
+	var doc collectionFolderDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrCollectionFolderNotFound
+		}
+		return nil, fmt.Errorf("failed to get collection folder: %w", err)
+	}
+
+	return documentToCollectionFolder(&doc)
+}
+
+func (r *MongoCollectionFolderRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.CollectionFolder, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection folders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var folders []*entities.CollectionFolder
+	for cursor.Next(ctx) {
+		var doc collectionFolderDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode collection folder: %w", err)
+		}
+
+		folder, err := documentToCollectionFolder(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert collection folder: %w", err)
+		}
+
+		folders = append(folders, folder)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return folders, nil
+}
+
+func (r *MongoCollectionFolderRepository) Update(ctx context.Context, folder *entities.CollectionFolder) error {
+	filter := bson.M{"_id": folder.ID().String()}
+	update := bson.M{"$set": collectionFolderToDocument(folder)}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update collection folder: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrCollectionFolderNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoCollectionFolderRepository) Delete(ctx context.Context, id entities.CollectionFolderID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete collection folder: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrCollectionFolderNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoCollectionFolderRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete collection folders by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func collectionFolderToDocument(f *entities.CollectionFolder) *collectionFolderDocument {
+	var parentID *string
+	if p := f.ParentID(); p != nil {
+		s := p.String()
+		parentID = &s
+	}
+
+	collectionIDs := make([]string, len(f.CollectionIDs()))
+	for i, id := range f.CollectionIDs() {
+		collectionIDs[i] = id.String()
+	}
+
+	return &collectionFolderDocument{
+		ID:            f.ID().String(),
+		UserID:        f.UserID().String(),
+		Name:          f.Name(),
+		ParentID:      parentID,
+		CollectionIDs: collectionIDs,
+		CreatedAt:     f.CreatedAt(),
+		UpdatedAt:     f.UpdatedAt(),
+	}
+}
+
+func documentToCollectionFolder(doc *collectionFolderDocument) (*entities.CollectionFolder, error) {
+	id, err := entities.CollectionFolderIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var parentID *entities.CollectionFolderID
+	if doc.ParentID != nil {
+		p, err := entities.CollectionFolderIDFromString(*doc.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent folder ID: %w", err)
+		}
+		parentID = &p
+	}
+
+	collectionIDs := make([]entities.CollectionID, 0, len(doc.CollectionIDs))
+	for _, raw := range doc.CollectionIDs {
+		cid, err := entities.CollectionIDFromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid collection ID: %w", err)
+		}
+		collectionIDs = append(collectionIDs, cid)
+	}
+
+	return entities.ReconstructCollectionFolder(
+		id,
+		userID,
+		doc.Name,
+		parentID,
+		collectionIDs,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
@@ -27,10 +27,27 @@ type propertySchemaDoc struct {
 	Definitions []propertyDefinitionDoc `bson:"definitions"`
 }
 
+type shelfLifeDoc struct {
+	Category string `bson:"category"`
+	Days     int    `bson:"days"`
+}
+
+type floorPlanDoc struct {
+	MediaID     string    `bson:"media_id"`
+	ContentType string    `bson:"content_type"`
+	Size        int64     `bson:"size"`
+	Width       int       `bson:"width"`
+	Height      int       `bson:"height"`
+	URL         string    `bson:"url"`
+	UploadedBy  string    `bson:"uploaded_by"`
+	UploadedAt  time.Time `bson:"uploaded_at"`
+}
+
 type collectionDocument struct {
 	ID             string             `bson:"_id"`
 	UserID         string             `bson:"user_id"`
 	GroupID        *string            `bson:"group_id,omitempty"`
+	GroupOwned     bool               `bson:"group_owned"` // no omitempty: $set must clear it on transfer back
 	Name           string             `bson:"name"`
 	CategoryID     *string            `bson:"category_id,omitempty"`
 	ObjectType     string             `bson:"object_type"`
@@ -38,6 +55,8 @@ type collectionDocument struct {
 	Tags           []string           `bson:"tags"`
 	Location       string             `bson:"location"`
 	PropertySchema *propertySchemaDoc `bson:"property_schema,omitempty"`
+	ShelfLife      []shelfLifeDoc     `bson:"shelf_life"` // no omitempty: $set must clear it when the rules are removed
+	FloorPlan      *floorPlanDoc      `bson:"floor_plan"` // no omitempty: $set must clear it when the plan is removed
 	CreatedAt      time.Time          `bson:"created_at"`
 	UpdatedAt      time.Time          `bson:"updated_at"`
 }
@@ -57,6 +76,9 @@ func NewMongoCollectionRepository(db *adapters.MongoDatabase) repositories.Colle
 }
 
 func (r *MongoCollectionRepository) Create(ctx context.Context, collection *entities.Collection) error {
+	if err := checkTenantCollection(ctx, collection); err != nil {
+		return err
+	}
 	doc := collectionToDocument(collection)
 
 	_, err := r.collection.InsertOne(ctx, doc)
@@ -71,9 +93,13 @@ func (r *MongoCollectionRepository) Create(ctx context.Context, collection *enti
 }
 
 func (r *MongoCollectionRepository) GetByID(ctx context.Context, id entities.CollectionID) (*entities.Collection, error) {
-	var doc collectionDocument
+	filter, err := tenantCollectionFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	var doc collectionDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, errors.New("collection not found")
@@ -81,13 +107,17 @@ func (r *MongoCollectionRepository) GetByID(ctx context.Context, id entities.Col
 		return nil, fmt.Errorf("failed to get collection: %w", err)
 	}
 
-	return r.documentToCollection(ctx, &doc)
+	return r.documentToCollection(ctx, &doc, r.containerCollection)
 }
 
 func (r *MongoCollectionRepository) GetByIDSummary(ctx context.Context, id entities.CollectionID) (*entities.Collection, error) {
-	var doc collectionDocument
+	filter, err := tenantCollectionFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	var doc collectionDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, errors.New("collection not found")
@@ -99,9 +129,15 @@ func (r *MongoCollectionRepository) GetByIDSummary(ctx context.Context, id entit
 }
 
 func (r *MongoCollectionRepository) Update(ctx context.Context, collection *entities.Collection) error {
+	if err := checkTenantCollection(ctx, collection); err != nil {
+		return err
+	}
 	doc := collectionToDocument(collection)
 
-	filter := bson.M{"_id": collection.ID().String()}
+	filter, err := tenantCollectionFilter(ctx, bson.M{"_id": collection.ID().String()})
+	if err != nil {
+		return err
+	}
 	update := bson.M{"$set": doc}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -117,7 +153,10 @@ func (r *MongoCollectionRepository) Update(ctx context.Context, collection *enti
 }
 
 func (r *MongoCollectionRepository) Delete(ctx context.Context, id entities.CollectionID) error {
-	filter := bson.M{"_id": id.String()}
+	filter, err := tenantCollectionFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return err
+	}
 
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
@@ -131,10 +170,20 @@ func (r *MongoCollectionRepository) Delete(ctx context.Context, id entities.Coll
 	return nil
 }
 
+// ownedByUserFilter matches collections the user personally owns. Group-owned
+// collections keep the user_id of whoever created or transferred them, so
+// they are excluded explicitly.
+func ownedByUserFilter(userID entities.UserID) bson.M {
+	return bson.M{"user_id": userID.String(), "group_owned": bson.M{"$ne": true}}
+}
+
 func (r *MongoCollectionRepository) GetByUserID(ctx context.Context, userID entities.UserID) ([]*entities.Collection, error) {
-	filter := bson.M{"user_id": userID.String()}
+	filter, err := tenantCollectionFilter(ctx, ownedByUserFilter(userID))
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get collections by user ID: %w", err)
 	}
@@ -147,7 +196,7 @@ func (r *MongoCollectionRepository) GetByUserID(ctx context.Context, userID enti
 			return nil, fmt.Errorf("failed to decode collection: %w", err)
 		}
 
-		collection, err := r.documentToCollection(ctx, &doc)
+		collection, err := r.documentToCollection(ctx, &doc, readCollection(ctx, r.db, r.containerCollection))
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert collection: %w", err)
 		}
@@ -163,11 +212,30 @@ func (r *MongoCollectionRepository) GetByUserID(ctx context.Context, userID enti
 }
 
 func (r *MongoCollectionRepository) GetByUserIDSummary(ctx context.Context, userID entities.UserID) ([]*entities.Collection, error) {
-	filter := bson.M{"user_id": userID.String()}
+	return r.findSummaries(ctx, ownedByUserFilter(userID), "failed to get collections by user ID")
+}
 
-	cursor, err := r.collection.Find(ctx, filter)
+func (r *MongoCollectionRepository) GetGroupOwnedSummary(ctx context.Context, groupIDs []entities.GroupID) ([]*entities.Collection, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(groupIDs))
+	for i, id := range groupIDs {
+		ids[i] = id.String()
+	}
+	filter := bson.M{"group_id": bson.M{"$in": ids}, "group_owned": true}
+	return r.findSummaries(ctx, filter, "failed to get group-owned collections")
+}
+
+func (r *MongoCollectionRepository) findSummaries(ctx context.Context, filter bson.M, errMsg string) ([]*entities.Collection, error) {
+	filter, err := tenantCollectionFilter(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get collections by user ID: %w", err)
+		return nil, err
+	}
+
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
 	}
 	defer cursor.Close(ctx)
 
@@ -249,6 +317,7 @@ func documentToCollectionSummary(doc *collectionDocument) (*entities.Collection,
 		id,
 		userID,
 		groupID,
+		doc.GroupOwned,
 		name,
 		categoryID,
 		entities.ObjectType(doc.ObjectType),
@@ -256,15 +325,20 @@ func documentToCollectionSummary(doc *collectionDocument) (*entities.Collection,
 		doc.Tags,
 		doc.Location,
 		propertySchema,
+		documentToShelfLife(doc.ShelfLife),
+		documentToFloorPlan(doc.FloorPlan),
 		doc.CreatedAt,
 		doc.UpdatedAt,
 	), nil
 }
 
 func (r *MongoCollectionRepository) GetByGroupID(ctx context.Context, groupID entities.GroupID) ([]*entities.Collection, error) {
-	filter := bson.M{"group_id": groupID.String()}
+	filter, err := tenantCollectionFilter(ctx, bson.M{"group_id": groupID.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get collections by group ID: %w", err)
 	}
@@ -277,7 +351,7 @@ func (r *MongoCollectionRepository) GetByGroupID(ctx context.Context, groupID en
 			return nil, fmt.Errorf("failed to decode collection: %w", err)
 		}
 
-		collection, err := r.documentToCollection(ctx, &doc)
+		collection, err := r.documentToCollection(ctx, &doc, readCollection(ctx, r.db, r.containerCollection))
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert collection: %w", err)
 		}
@@ -302,7 +376,12 @@ func (r *MongoCollectionRepository) List(ctx context.Context, limit, offset int)
 	}
 	opts.SetSort(bson.M{"created_at": 1})
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	filter, err := tenantCollectionFilter(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list collections: %w", err)
 	}
@@ -315,7 +394,7 @@ func (r *MongoCollectionRepository) List(ctx context.Context, limit, offset int)
 			return nil, fmt.Errorf("failed to decode collection: %w", err)
 		}
 
-		collection, err := r.documentToCollection(ctx, &doc)
+		collection, err := r.documentToCollection(ctx, &doc, readCollection(ctx, r.db, r.containerCollection))
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert collection: %w", err)
 		}
@@ -331,7 +410,12 @@ func (r *MongoCollectionRepository) List(ctx context.Context, limit, offset int)
 }
 
 func (r *MongoCollectionRepository) Exists(ctx context.Context, id entities.CollectionID) (bool, error) {
-	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id.String()})
+	filter, err := tenantCollectionFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return false, fmt.Errorf("failed to check collection existence: %w", err)
 	}
@@ -348,6 +432,7 @@ func collectionToDocument(collection *entities.Collection) *collectionDocument {
 	doc := &collectionDocument{
 		ID:         collection.ID().String(),
 		UserID:     collection.UserID().String(),
+		GroupOwned: collection.IsGroupOwned(),
 		Name:       collection.Name().String(),
 		ObjectType: collection.ObjectType().String(),
 		Containers: containerIDs,
@@ -383,9 +468,57 @@ func collectionToDocument(collection *entities.Collection) *collectionDocument {
 		doc.PropertySchema = schemaDoc
 	}
 
+	for _, rule := range collection.ShelfLife() {
+		doc.ShelfLife = append(doc.ShelfLife, shelfLifeDoc{Category: rule.Category(), Days: rule.Days()})
+	}
+
+	if plan := collection.FloorPlan(); plan != nil {
+		doc.FloorPlan = &floorPlanDoc{
+			MediaID:     plan.MediaID().String(),
+			ContentType: plan.ContentType(),
+			Size:        plan.Size(),
+			Width:       plan.Width(),
+			Height:      plan.Height(),
+			URL:         plan.URL(),
+			UploadedBy:  plan.UploadedBy().String(),
+			UploadedAt:  plan.UploadedAt(),
+		}
+	}
+
 	return doc
 }
 
+func documentToFloorPlan(doc *floorPlanDoc) *entities.FloorPlan {
+	if doc == nil {
+		return nil
+	}
+	mediaID, err := entities.MediaIDFromString(doc.MediaID)
+	if err != nil {
+		return nil
+	}
+	uploadedBy, _ := entities.UserIDFromString(doc.UploadedBy)
+	return entities.ReconstructFloorPlan(entities.FloorPlanProps{
+		MediaID:     mediaID,
+		ContentType: doc.ContentType,
+		Size:        doc.Size,
+		Width:       doc.Width,
+		Height:      doc.Height,
+		URL:         doc.URL,
+		UploadedBy:  uploadedBy,
+	}, doc.UploadedAt)
+}
+
+func documentToShelfLife(docs []shelfLifeDoc) []entities.ShelfLife {
+	if len(docs) == 0 {
+		return nil
+	}
+	rules := make([]entities.ShelfLife, len(docs))
+	for i, d := range docs {
+		rules[i] = entities.ReconstructShelfLife(d.Category, d.Days)
+	}
+	return rules
+}
+
 func objectToDocument(object entities.Object) objectDocument {
 	return objectDocument{
 		ID:          object.ID().String(),
@@ -395,16 +528,37 @@ func objectToDocument(object entities.Object) objectDocument {
 		Location:    object.Location(),
 		Quantity:    object.Quantity(),
 		Unit:        object.Unit(),
+		MinQuantity: object.MinQuantity(),
+		Condition:   object.Condition().String(),
+		Status:      object.Status().String(),
 		Properties:  object.Properties(),
 		Tags:        object.Tags(),
 		ImageURL:    object.ImageURL(),
 		ExpiresAt:   object.ExpiresAt(),
+		ArchivedAt:  object.ArchivedAt(),
+		Claim:       objectClaimToDocument(object.Claim()),
 		CreatedAt:   object.CreatedAt(),
 		UpdatedAt:   object.UpdatedAt(),
 	}
 }
 
-func (r *MongoCollectionRepository) documentToCollection(ctx context.Context, doc *collectionDocument) (*entities.Collection, error) {
+// objectClaimToDocument keeps only claims that have not expired, so stale
+// claims are dropped the next time their container is saved.
+func objectClaimToDocument(claim *entities.ObjectClaim) *objectClaimDocument {
+	if claim == nil || !claim.IsActive(time.Now()) {
+		return nil
+	}
+	return &objectClaimDocument{
+		UserID:    claim.UserID().String(),
+		Username:  claim.Username(),
+		ClaimedAt: claim.ClaimedAt(),
+		Until:     claim.Until(),
+	}
+}
+
+// documentToCollection builds the collection in doc, loading its containers
+// from containerColl: the primary or the read replica, as the caller read doc
+func (r *MongoCollectionRepository) documentToCollection(ctx context.Context, doc *collectionDocument, containerColl *mongo.Collection) (*entities.Collection, error) {
 	id, err := entities.CollectionIDFromString(doc.ID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid collection ID: %w", err)
@@ -440,7 +594,7 @@ func (r *MongoCollectionRepository) documentToCollection(ctx context.Context, do
 	// Load containers for this collection
 	containers := make([]entities.Container, 0, len(doc.Containers))
 	if len(doc.Containers) > 0 {
-		cursor, err := r.containerCollection.Find(ctx, bson.M{
+		cursor, err := containerColl.Find(ctx, bson.M{
 			"_id": bson.M{"$in": doc.Containers},
 		})
 		if err != nil {
@@ -489,6 +643,7 @@ func (r *MongoCollectionRepository) documentToCollection(ctx context.Context, do
 		id,
 		userID,
 		groupID,
+		doc.GroupOwned,
 		name,
 		categoryID,
 		entities.ObjectType(doc.ObjectType),
@@ -496,6 +651,8 @@ func (r *MongoCollectionRepository) documentToCollection(ctx context.Context, do
 		doc.Tags,
 		doc.Location,
 		propertySchema,
+		documentToShelfLife(doc.ShelfLife),
+		documentToFloorPlan(doc.FloorPlan),
 		doc.CreatedAt,
 		doc.UpdatedAt,
 	), nil
@@ -542,11 +699,28 @@ func documentToObject(doc objectDocument) (*entities.Object, error) {
 		doc.Location,
 		doc.Quantity,
 		doc.Unit,
+		doc.MinQuantity,
+		entities.Condition(doc.Condition),
+		entities.ObjectStatus(doc.Status),
 		doc.Properties,
 		doc.Tags,
 		doc.ImageURL,
 		doc.ExpiresAt,
+		doc.ArchivedAt,
+		documentToObjectClaim(doc.Claim),
 		doc.CreatedAt,
 		doc.UpdatedAt,
 	), nil
 }
+
+func documentToObjectClaim(doc *objectClaimDocument) *entities.ObjectClaim {
+	if doc == nil {
+		return nil
+	}
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil
+	}
+	claim := entities.ReconstructObjectClaim(userID, doc.Username, doc.ClaimedAt, doc.Until)
+	return &claim
+}
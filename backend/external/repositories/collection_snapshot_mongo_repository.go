@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+// collectionSnapshotDocument embeds containers in the same shape as the
+// containers collection, objects included.
+type collectionSnapshotDocument struct {
+	ID             string              `bson:"_id"`
+	CollectionID   string              `bson:"collection_id"`
+	CollectionName string              `bson:"collection_name"`
+	CreatedBy      string              `bson:"created_by"`
+	Label          string              `bson:"label,omitempty"`
+	Containers     []containerDocument `bson:"containers"`
+	CreatedAt      time.Time           `bson:"created_at"`
+}
+
+type MongoCollectionSnapshotRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoCollectionSnapshotRepository(db *adapters.MongoDatabase) repositories.CollectionSnapshotRepository {
+	return &MongoCollectionSnapshotRepository{
+		db:         db,
+		collection: db.Database().Collection("collection_snapshots"),
+	}
+}
+
+func (r *MongoCollectionSnapshotRepository) Create(ctx context.Context, snapshot *entities.CollectionSnapshot) error {
+	if _, err := r.collection.InsertOne(ctx, collectionSnapshotToDocument(snapshot)); err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoCollectionSnapshotRepository) GetByID(ctx context.Context, id entities.CollectionSnapshotID) (*entities.CollectionSnapshot, error) {
+	var doc collectionSnapshotDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrCollectionSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	return documentToCollectionSnapshot(&doc)
+}
+
+func (r *MongoCollectionSnapshotRepository) ListByCollectionID(ctx context.Context, collectionID entities.CollectionID) ([]*entities.CollectionSnapshot, error) {
+	filter := bson.M{"collection_id": collectionID.String()}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []*entities.CollectionSnapshot
+	for cursor.Next(ctx) {
+		var doc collectionSnapshotDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+		}
+
+		snapshot, err := documentToCollectionSnapshot(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert snapshot: %w", err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func (r *MongoCollectionSnapshotRepository) Delete(ctx context.Context, id entities.CollectionSnapshotID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrCollectionSnapshotNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoCollectionSnapshotRepository) DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"collection_id": collectionID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete snapshots by collection ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func collectionSnapshotToDocument(s *entities.CollectionSnapshot) *collectionSnapshotDocument {
+	containers := make([]containerDocument, len(s.Containers()))
+	for i := range s.Containers() {
+		containers[i] = *containerToDocument(&s.Containers()[i])
+	}
+
+	return &collectionSnapshotDocument{
+		ID:             s.ID().String(),
+		CollectionID:   s.CollectionID().String(),
+		CollectionName: s.CollectionName(),
+		CreatedBy:      s.CreatedBy().String(),
+		Label:          s.Label(),
+		Containers:     containers,
+		CreatedAt:      s.CreatedAt(),
+	}
+}
+
+func documentToCollectionSnapshot(doc *collectionSnapshotDocument) (*entities.CollectionSnapshot, error) {
+	id, err := entities.CollectionSnapshotIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionID, err := entities.CollectionIDFromString(doc.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collection ID: %w", err)
+	}
+
+	createdBy, err := entities.UserIDFromString(doc.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	containers := make([]entities.Container, len(doc.Containers))
+	for i := range doc.Containers {
+		container, err := documentToContainer(&doc.Containers[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert container: %w", err)
+		}
+		containers[i] = *container
+	}
+
+	return entities.ReconstructCollectionSnapshot(
+		id,
+		collectionID,
+		doc.CollectionName,
+		createdBy,
+		doc.Label,
+		containers,
+		doc.CreatedAt,
+	), nil
+}
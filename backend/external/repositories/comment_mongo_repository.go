@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type commentDocument struct {
+	ID           string    `bson:"_id"`
+	CollectionID string    `bson:"collection_id"`
+	ObjectID     string    `bson:"object_id,omitempty"`
+	AuthorID     string    `bson:"author_id"`
+	AuthorName   string    `bson:"author_name"`
+	Body         string    `bson:"body"`
+	Mentions     []string  `bson:"mentions,omitempty"`
+	CreatedAt    time.Time `bson:"created_at"`
+}
+
+// commentReadDocument is when a user last read a collection's comments.
+type commentReadDocument struct {
+	ID           string    `bson:"_id"` // user ID + ":" + collection ID
+	UserID       string    `bson:"user_id"`
+	CollectionID string    `bson:"collection_id"`
+	ReadAt       time.Time `bson:"read_at"`
+}
+
+type MongoCommentRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+	reads      *mongo.Collection
+}
+
+func NewMongoCommentRepository(db *adapters.MongoDatabase) repositories.CommentRepository {
+	return &MongoCommentRepository{
+		db:         db,
+		collection: db.Database().Collection("comments"),
+		reads:      db.Database().Collection("comment_reads"),
+	}
+}
+
+func (r *MongoCommentRepository) Create(ctx context.Context, comment *entities.Comment) error {
+	if _, err := r.collection.InsertOne(ctx, commentToDocument(comment)); err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoCommentRepository) GetByID(ctx context.Context, id entities.CommentID) (*entities.Comment, error) {
+	var doc commentDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return documentToComment(&doc)
+}
+
+func (r *MongoCommentRepository) List(ctx context.Context, collectionID entities.CollectionID, objectID *entities.ObjectID, before time.Time, limit int) ([]*entities.Comment, error) {
+	filter := bson.M{"collection_id": collectionID.String()}
+	if objectID != nil {
+		filter["object_id"] = objectID.String()
+	}
+	if !before.IsZero() {
+		filter["created_at"] = bson.M{"$lt": before}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*entities.Comment
+	for cursor.Next(ctx) {
+		var doc commentDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode comment: %w", err)
+		}
+
+		comment, err := documentToComment(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert comment: %w", err)
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (r *MongoCommentRepository) Delete(ctx context.Context, id entities.CommentID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoCommentRepository) DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error) {
+	filter := bson.M{"collection_id": collectionID.String()}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete comments by collection ID: %w", err)
+	}
+
+	if _, err := r.reads.DeleteMany(ctx, filter); err != nil {
+		return 0, fmt.Errorf("failed to delete comment read markers by collection ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (r *MongoCommentRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"author_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete comments by user ID: %w", err)
+	}
+
+	if _, err := r.reads.DeleteMany(ctx, bson.M{"user_id": userID.String()}); err != nil {
+		return 0, fmt.Errorf("failed to delete comment read markers by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (r *MongoCommentRepository) MarkRead(ctx context.Context, userID entities.UserID, collectionID entities.CollectionID, at time.Time) error {
+	filter := bson.M{"_id": userID.String() + ":" + collectionID.String()}
+	// $max never moves a marker back, e.g. when an older client marks late
+	update := bson.M{
+		"$set": bson.M{"user_id": userID.String(), "collection_id": collectionID.String()},
+		"$max": bson.M{"read_at": at},
+	}
+
+	if _, err := r.reads.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to save comment read marker: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoCommentRepository) CountUnread(ctx context.Context, userID entities.UserID, collectionIDs []entities.CollectionID) ([]entities.CommentUnread, error) {
+	if len(collectionIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(collectionIDs))
+	for i, id := range collectionIDs {
+		ids[i] = id.String()
+	}
+
+	markers := make(map[string]time.Time)
+	cursor, err := r.reads.Find(ctx, bson.M{"user_id": userID.String(), "collection_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment read markers: %w", err)
+	}
+	for cursor.Next(ctx) {
+		var doc commentReadDocument
+		if err := cursor.Decode(&doc); err != nil {
+			cursor.Close(ctx)
+			return nil, fmt.Errorf("failed to decode comment read marker: %w", err)
+		}
+		markers[doc.CollectionID] = doc.ReadAt
+	}
+	cursor.Close(ctx)
+
+	// One clause per collection, each with its own read marker
+	clauses := make(bson.A, len(ids))
+	for i, id := range ids {
+		clauses[i] = bson.M{"collection_id": id, "created_at": bson.M{"$gt": markers[id]}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"author_id": bson.M{"$ne": userID.String()}, "$or": clauses}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$collection_id",
+			"count": bson.M{"$sum": 1},
+			"mentions": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$in": bson.A{userID.String(), bson.M{"$ifNull": bson.A{"$mentions", bson.A{}}}}},
+				1, 0,
+			}}},
+		}}},
+	}
+
+	cursor, err = r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var unread []entities.CommentUnread
+	for cursor.Next(ctx) {
+		var row struct {
+			CollectionID string `bson:"_id"`
+			Count        int    `bson:"count"`
+			Mentions     int    `bson:"mentions"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("failed to decode unread count: %w", err)
+		}
+
+		collectionID, err := entities.CollectionIDFromString(row.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid collection ID: %w", err)
+		}
+
+		unread = append(unread, entities.CommentUnread{CollectionID: collectionID, Count: row.Count, Mentions: row.Mentions})
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return unread, nil
+}
+
+func commentToDocument(c *entities.Comment) *commentDocument {
+	doc := &commentDocument{
+		ID:           c.ID().String(),
+		CollectionID: c.CollectionID().String(),
+		AuthorID:     c.AuthorID().String(),
+		AuthorName:   c.AuthorName(),
+		Body:         c.Body(),
+		CreatedAt:    c.CreatedAt(),
+	}
+	if c.ObjectID() != nil {
+		doc.ObjectID = c.ObjectID().String()
+	}
+	for _, id := range c.Mentions() {
+		doc.Mentions = append(doc.Mentions, id.String())
+	}
+
+	return doc
+}
+
+func documentToComment(doc *commentDocument) (*entities.Comment, error) {
+	id, err := entities.CommentIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionID, err := entities.CollectionIDFromString(doc.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collection ID: %w", err)
+	}
+
+	var objectID *entities.ObjectID
+	if doc.ObjectID != "" {
+		id, err := entities.ObjectIDFromHex(doc.ObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object ID: %w", err)
+		}
+		objectID = &id
+	}
+
+	authorID, err := entities.UserIDFromString(doc.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	mentions := make([]entities.UserID, 0, len(doc.Mentions))
+	for _, m := range doc.Mentions {
+		userID, err := entities.UserIDFromString(m)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mentioned user ID: %w", err)
+		}
+		mentions = append(mentions, userID)
+	}
+
+	return entities.ReconstructComment(
+		id,
+		collectionID,
+		objectID,
+		authorID,
+		doc.AuthorName,
+		doc.Body,
+		mentions,
+		doc.CreatedAt,
+	), nil
+}
@@ -24,45 +24,98 @@ type objectDocument struct {
 	Location    string                         `bson:"location,omitempty"`
 	Quantity    *float64                       `bson:"quantity,omitempty"`
 	Unit        string                         `bson:"unit,omitempty"`
+	MinQuantity *float64                       `bson:"min_quantity,omitempty"`
+	Condition   string                         `bson:"condition,omitempty"`
+	Status      string                         `bson:"status,omitempty"` // empty for objects saved before statuses
 	Properties  map[string]entities.TypedValue `bson:"properties"`
 	Tags        []string                       `bson:"tags"`
 	ImageURL    string                         `bson:"image_url,omitempty"`
 	ExpiresAt   *time.Time                     `bson:"expires_at,omitempty"`
+	ArchivedAt  *time.Time                     `bson:"archived_at,omitempty"`
+	Claim       *objectClaimDocument           `bson:"claim,omitempty"`
 	CreatedAt   time.Time                      `bson:"created_at"`
 	UpdatedAt   time.Time                      `bson:"updated_at"`
 }
 
+type objectClaimDocument struct {
+	UserID    string    `bson:"user_id"`
+	Username  string    `bson:"username"`
+	ClaimedAt time.Time `bson:"claimed_at"`
+	Until     time.Time `bson:"until"`
+}
+
+type containerPlacementDocument struct {
+	X     *float64 `bson:"x,omitempty"`
+	Y     *float64 `bson:"y,omitempty"`
+	Floor string   `bson:"floor,omitempty"`
+	Room  string   `bson:"room,omitempty"`
+}
+
 type containerDocument struct {
-	ID                string           `bson:"_id"`
-	CollectionID      string           `bson:"collection_id"`
-	Name              string           `bson:"name"`
-	Type              string           `bson:"type"`
-	ParentContainerID *string          `bson:"parent_container_id,omitempty"`
-	CategoryID        *string          `bson:"category_id,omitempty"`
-	GroupID           *string          `bson:"group_id,omitempty"`
-	Objects           []objectDocument `bson:"objects"`
-	Location          string           `bson:"location"`
-	Width             *float64         `bson:"width,omitempty"`
-	Depth             *float64         `bson:"depth,omitempty"`
-	Rows              *int             `bson:"rows,omitempty"`
-	Capacity          *float64         `bson:"capacity,omitempty"`
-	CreatedAt         time.Time        `bson:"created_at"`
-	UpdatedAt         time.Time        `bson:"updated_at"`
+	ID                string                      `bson:"_id"`
+	CollectionID      string                      `bson:"collection_id"`
+	Name              string                      `bson:"name"`
+	Type              string                      `bson:"type"`
+	ParentContainerID *string                     `bson:"parent_container_id,omitempty"`
+	CategoryID        *string                     `bson:"category_id,omitempty"`
+	GroupID           *string                     `bson:"group_id,omitempty"`
+	Objects           []objectDocument            `bson:"objects"`
+	Location          string                      `bson:"location"`
+	Width             *float64                    `bson:"width,omitempty"`
+	Depth             *float64                    `bson:"depth,omitempty"`
+	Rows              *int                        `bson:"rows,omitempty"`
+	Capacity          *float64                    `bson:"capacity,omitempty"`
+	TemperatureZone   string                      `bson:"temperature_zone,omitempty"`
+	Humidity          *float64                    `bson:"humidity,omitempty"`
+	Placement         *containerPlacementDocument `bson:"placement"` // no omitempty: $set must clear it when the container is taken off the plan
+	CreatedAt         time.Time                   `bson:"created_at"`
+	UpdatedAt         time.Time                   `bson:"updated_at"`
 }
 
 type MongoContainerRepository struct {
 	db         *adapters.MongoDatabase
 	collection *mongo.Collection
+	// collections is read to limit queries to the tenant's containers
+	collections *mongo.Collection
 }
 
 func NewMongoContainerRepository(db *adapters.MongoDatabase) repositories.ContainerRepository {
 	return &MongoContainerRepository{
-		db:         db,
-		collection: db.Database().Collection("containers"),
+		db:          db,
+		collection:  db.Database().Collection("containers"),
+		collections: db.Database().Collection("collections"),
 	}
 }
 
+// tenantFilter narrows filter to the containers in the tenant's collections
+func (r *MongoContainerRepository) tenantFilter(ctx context.Context, filter bson.M) (bson.M, error) {
+	return tenantContainerFilter(ctx, r.collections, filter)
+}
+
+// checkTenantCollection refuses to put a container in a collection the
+// tenant cannot see
+func (r *MongoContainerRepository) checkTenantCollection(ctx context.Context, collectionID entities.CollectionID) error {
+	if repositories.TenantFromContext(ctx) == nil {
+		return nil
+	}
+	filter, err := tenantCollectionFilter(ctx, bson.M{"_id": collectionID.String()})
+	if err != nil {
+		return err
+	}
+	count, err := r.collections.CountDocuments(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to check container collection: %w", err)
+	}
+	if count == 0 {
+		return repositories.ErrOutsideTenant
+	}
+	return nil
+}
+
 func (r *MongoContainerRepository) Create(ctx context.Context, container *entities.Container) error {
+	if err := r.checkTenantCollection(ctx, container.CollectionID()); err != nil {
+		return err
+	}
 	doc := containerToDocument(container)
 
 	_, err := r.collection.InsertOne(ctx, doc)
@@ -77,9 +130,13 @@ func (r *MongoContainerRepository) Create(ctx context.Context, container *entiti
 }
 
 func (r *MongoContainerRepository) GetByID(ctx context.Context, id entities.ContainerID) (*entities.Container, error) {
-	var doc containerDocument
+	filter, err := r.tenantFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	var doc containerDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, errors.New("container not found")
@@ -99,12 +156,18 @@ func (r *MongoContainerRepository) GetByID(ctx context.Context, id entities.Cont
 }
 
 func (r *MongoContainerRepository) Update(ctx context.Context, container *entities.Container) error {
+	if err := r.checkTenantCollection(ctx, container.CollectionID()); err != nil {
+		return err
+	}
 	doc := containerToDocument(container)
 
 	log.Printf("[ContainerRepo] Updating container %s: converting %d objects to document", container.ID().String(), len(container.Objects()))
 	log.Printf("[ContainerRepo] Document has %d objects after conversion", len(doc.Objects))
 
-	filter := bson.M{"_id": container.ID().String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"_id": container.ID().String()})
+	if err != nil {
+		return err
+	}
 	update := bson.M{"$set": doc}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -122,7 +185,10 @@ func (r *MongoContainerRepository) Update(ctx context.Context, container *entiti
 }
 
 func (r *MongoContainerRepository) Delete(ctx context.Context, id entities.ContainerID) error {
-	filter := bson.M{"_id": id.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return err
+	}
 
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
@@ -137,7 +203,10 @@ func (r *MongoContainerRepository) Delete(ctx context.Context, id entities.Conta
 }
 
 func (r *MongoContainerRepository) DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error) {
-	filter := bson.M{"collection_id": collectionID.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"collection_id": collectionID.String()})
+	if err != nil {
+		return 0, err
+	}
 
 	result, err := r.collection.DeleteMany(ctx, filter)
 	if err != nil {
@@ -148,9 +217,12 @@ func (r *MongoContainerRepository) DeleteByCollectionID(ctx context.Context, col
 }
 
 func (r *MongoContainerRepository) GetByGroupID(ctx context.Context, groupID entities.GroupID) ([]*entities.Container, error) {
-	filter := bson.M{"group_id": groupID.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"group_id": groupID.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get containers by group ID: %w", err)
 	}
@@ -179,9 +251,12 @@ func (r *MongoContainerRepository) GetByGroupID(ctx context.Context, groupID ent
 }
 
 func (r *MongoContainerRepository) GetByCollectionID(ctx context.Context, collectionID entities.CollectionID) ([]*entities.Container, error) {
-	filter := bson.M{"collection_id": collectionID.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"collection_id": collectionID.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get containers by collection ID: %w", err)
 	}
@@ -210,9 +285,12 @@ func (r *MongoContainerRepository) GetByCollectionID(ctx context.Context, collec
 }
 
 func (r *MongoContainerRepository) GetChildContainers(ctx context.Context, parentID entities.ContainerID) ([]*entities.Container, error) {
-	filter := bson.M{"parent_container_id": parentID.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"parent_container_id": parentID.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get child containers: %w", err)
 	}
@@ -250,7 +328,12 @@ func (r *MongoContainerRepository) List(ctx context.Context, limit, offset int)
 	}
 	opts.SetSort(bson.M{"created_at": 1})
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	filter, err := r.tenantFilter(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
@@ -279,7 +362,12 @@ func (r *MongoContainerRepository) List(ctx context.Context, limit, offset int)
 }
 
 func (r *MongoContainerRepository) Exists(ctx context.Context, id entities.ContainerID) (bool, error) {
-	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id.String()})
+	filter, err := r.tenantFilter(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return false, fmt.Errorf("failed to check container existence: %w", err)
 	}
@@ -289,12 +377,15 @@ func (r *MongoContainerRepository) Exists(ctx context.Context, id entities.Conta
 
 func (r *MongoContainerRepository) GetContainersWithExpiredFood(ctx context.Context, groupID entities.GroupID) ([]*entities.Container, error) {
 	now := time.Now()
-	filter := bson.M{
+	filter, err := r.tenantFilter(ctx, bson.M{
 		"group_id":     groupID.String(),
 		"foods.expiry": bson.M{"$lt": now},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := readCollection(ctx, r.db, r.collection).Find(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get containers with expired food: %w", err)
 	}
@@ -335,8 +426,13 @@ func (r *MongoContainerRepository) GetByCollectionIDWithAccess(ctx context.Conte
 		accessOr = append(accessOr, bson.M{"_collection.group_id": bson.M{"$in": groupIDStrings}})
 	}
 
+	match, err := r.tenantFilter(ctx, bson.M{"collection_id": collectionID.String()})
+	if err != nil {
+		return nil, err
+	}
+
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"collection_id": collectionID.String()}}},
+		{{Key: "$match", Value: match}},
 		{{Key: "$lookup", Value: bson.M{
 			"from":         "collections",
 			"localField":   "collection_id",
@@ -348,7 +444,7 @@ func (r *MongoContainerRepository) GetByCollectionIDWithAccess(ctx context.Conte
 		{{Key: "$project", Value: bson.M{"_collection": 0}}},
 	}
 
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	cursor, err := readCollection(ctx, r.db, r.collection).Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get containers with access check: %w", err)
 	}
@@ -376,7 +472,10 @@ func (r *MongoContainerRepository) GetByCollectionIDWithAccess(ctx context.Conte
 
 func (r *MongoContainerRepository) AddObject(ctx context.Context, containerID entities.ContainerID, object entities.Object) error {
 	doc := objectToDocument(object)
-	filter := bson.M{"_id": containerID.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"_id": containerID.String()})
+	if err != nil {
+		return err
+	}
 	update := bson.M{"$push": bson.M{"objects": doc}}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -390,7 +489,10 @@ func (r *MongoContainerRepository) AddObject(ctx context.Context, containerID en
 }
 
 func (r *MongoContainerRepository) RemoveObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID) error {
-	filter := bson.M{"_id": containerID.String()}
+	filter, err := r.tenantFilter(ctx, bson.M{"_id": containerID.String()})
+	if err != nil {
+		return err
+	}
 	update := bson.M{"$pull": bson.M{"objects": bson.M{"id": objectID.String()}}}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -403,6 +505,54 @@ func (r *MongoContainerRepository) RemoveObject(ctx context.Context, containerID
 	return nil
 }
 
+func (r *MongoContainerRepository) ClaimObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, claim entities.ObjectClaim) error {
+	update := bson.M{"$set": bson.M{
+		"objects.$.claim":      objectClaimToDocument(&claim),
+		"objects.$.updated_at": claim.ClaimedAt(),
+	}}
+	claimer := claim.UserID()
+	return r.updateUnclaimedObject(ctx, containerID, objectID, &claimer, claim.ClaimedAt(), update)
+}
+
+func (r *MongoContainerRepository) ReleaseObjectClaim(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, holder *entities.UserID, now time.Time) error {
+	update := bson.M{
+		"$unset": bson.M{"objects.$.claim": ""},
+		"$set":   bson.M{"objects.$.updated_at": now},
+	}
+	return r.updateUnclaimedObject(ctx, containerID, objectID, holder, now, update)
+}
+
+// updateUnclaimedObject applies update to the object unless someone other
+// than holder has a claim on it that is still active at now. A nil holder
+// skips the claim condition. The object is matched inside the filter so the
+// check and the write happen atomically.
+func (r *MongoContainerRepository) updateUnclaimedObject(ctx context.Context, containerID entities.ContainerID, objectID entities.ObjectID, holder *entities.UserID, now time.Time, update bson.M) error {
+	match := bson.M{"id": objectID.String()}
+	if holder != nil {
+		match["$or"] = bson.A{
+			bson.M{"claim": nil},
+			bson.M{"claim.until": bson.M{"$lte": now}},
+			bson.M{"claim.user_id": holder.String()},
+		}
+	}
+	filter, err := r.tenantFilter(ctx, bson.M{
+		"_id":     containerID.String(),
+		"objects": bson.M{"$elemMatch": match},
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update object claim: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return entities.ErrObjectClaimed
+	}
+	return nil
+}
+
 func containerToDocument(container *entities.Container) *containerDocument {
 	objects := make([]objectDocument, len(container.Objects()))
 	for i, object := range container.Objects() {
@@ -441,11 +591,28 @@ func containerToDocument(container *entities.Container) *containerDocument {
 		Depth:             container.Depth(),
 		Rows:              container.Rows(),
 		Capacity:          container.Capacity(),
+		TemperatureZone:   string(container.TemperatureZone()),
+		Humidity:          container.Humidity(),
+		Placement:         containerPlacementToDocument(container.Placement()),
 		CreatedAt:         container.CreatedAt(),
 		UpdatedAt:         container.UpdatedAt(),
 	}
 }
 
+func containerPlacementToDocument(placement entities.ContainerPlacement) *containerPlacementDocument {
+	if placement.IsZero() {
+		return nil
+	}
+	return &containerPlacementDocument{X: placement.X, Y: placement.Y, Floor: placement.Floor, Room: placement.Room}
+}
+
+func documentToContainerPlacement(doc *containerPlacementDocument) entities.ContainerPlacement {
+	if doc == nil {
+		return entities.ContainerPlacement{}
+	}
+	return entities.ContainerPlacement{X: doc.X, Y: doc.Y, Floor: doc.Floor, Room: doc.Room}
+}
+
 func documentToContainer(doc *containerDocument) (*entities.Container, error) {
 	id, err := entities.ContainerIDFromString(doc.ID)
 	if err != nil {
@@ -515,15 +682,22 @@ func documentToContainer(doc *containerDocument) (*entities.Container, error) {
 		doc.Depth,
 		doc.Rows,
 		doc.Capacity,
+		entities.TemperatureZone(doc.TemperatureZone),
+		doc.Humidity,
+		documentToContainerPlacement(doc.Placement),
 		doc.CreatedAt,
 		doc.UpdatedAt,
 	), nil
 }
 
 func (r *MongoContainerRepository) FindByObjectID(ctx context.Context, objectID entities.ObjectID) (*entities.Container, error) {
-	var doc containerDocument
+	filter, err := r.tenantFilter(ctx, bson.M{"objects.id": objectID.String()})
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.collection.FindOne(ctx, bson.M{"objects.id": objectID.String()}).Decode(&doc)
+	var doc containerDocument
+	err = r.collection.FindOne(ctx, filter).Decode(&doc)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, errors.New("container not found")
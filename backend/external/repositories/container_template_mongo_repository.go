@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type containerTemplateNodeDocument struct {
+	Name     string                          `bson:"name"`
+	Type     string                          `bson:"type"`
+	Location string                          `bson:"location,omitempty"`
+	Width    *float64                        `bson:"width,omitempty"`
+	Depth    *float64                        `bson:"depth,omitempty"`
+	Rows     *int                            `bson:"rows,omitempty"`
+	Capacity *float64                        `bson:"capacity,omitempty"`
+	Children []containerTemplateNodeDocument `bson:"children,omitempty"`
+}
+
+type containerTemplateDocument struct {
+	ID          string                          `bson:"_id"`
+	UserID      string                          `bson:"user_id"`
+	Name        string                          `bson:"name"`
+	Description string                          `bson:"description,omitempty"`
+	Nodes       []containerTemplateNodeDocument `bson:"nodes"`
+	CreatedAt   time.Time                       `bson:"created_at"`
+	UpdatedAt   time.Time                       `bson:"updated_at"`
+}
+
+type MongoContainerTemplateRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoContainerTemplateRepository(db *adapters.MongoDatabase) repositories.ContainerTemplateRepository {
+	return &MongoContainerTemplateRepository{
+		db:         db,
+		collection: db.Database().Collection("container_templates"),
+	}
+}
+
+func (r *MongoContainerTemplateRepository) Create(ctx context.Context, template *entities.ContainerTemplate) error {
+	if template.IsBuiltIn() {
+		return entities.ErrBuiltInContainerTemplate
+	}
+
+	if _, err := r.collection.InsertOne(ctx, containerTemplateToDocument(template)); err != nil {
+		return fmt.Errorf("failed to create container template: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoContainerTemplateRepository) GetByID(ctx context.Context, id entities.ContainerTemplateID) (*entities.ContainerTemplate, error) {
+	var doc containerTemplateDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrContainerTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get container template: %w", err)
+	}
+
+	return documentToContainerTemplate(&doc)
+}
+
+func (r *MongoContainerTemplateRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.ContainerTemplate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list container templates: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var templates []*entities.ContainerTemplate
+	for cursor.Next(ctx) {
+		var doc containerTemplateDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode container template: %w", err)
+		}
+
+		template, err := documentToContainerTemplate(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert container template: %w", err)
+		}
+
+		templates = append(templates, template)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (r *MongoContainerTemplateRepository) Delete(ctx context.Context, id entities.ContainerTemplateID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete container template: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrContainerTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoContainerTemplateRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete container templates by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func containerTemplateToDocument(t *entities.ContainerTemplate) *containerTemplateDocument {
+	return &containerTemplateDocument{
+		ID:          t.ID().String(),
+		UserID:      t.UserID().String(),
+		Name:        t.Name(),
+		Description: t.Description(),
+		Nodes:       templateNodesToDocuments(t.Nodes()),
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+	}
+}
+
+func templateNodesToDocuments(nodes []entities.ContainerTemplateNode) []containerTemplateNodeDocument {
+	docs := make([]containerTemplateNodeDocument, len(nodes))
+	for i, n := range nodes {
+		docs[i] = containerTemplateNodeDocument{
+			Name:     n.Name,
+			Type:     string(n.Type),
+			Location: n.Location,
+			Width:    n.Width,
+			Depth:    n.Depth,
+			Rows:     n.Rows,
+			Capacity: n.Capacity,
+			Children: templateNodesToDocuments(n.Children),
+		}
+	}
+	return docs
+}
+
+func documentToContainerTemplate(doc *containerTemplateDocument) (*entities.ContainerTemplate, error) {
+	id, err := entities.ContainerTemplateIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructContainerTemplate(
+		id,
+		&userID,
+		doc.Name,
+		doc.Description,
+		documentsToTemplateNodes(doc.Nodes),
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
+
+func documentsToTemplateNodes(docs []containerTemplateNodeDocument) []entities.ContainerTemplateNode {
+	nodes := make([]entities.ContainerTemplateNode, len(docs))
+	for i, d := range docs {
+		nodes[i] = entities.ContainerTemplateNode{
+			Name:     d.Name,
+			Type:     entities.ContainerType(d.Type),
+			Location: d.Location,
+			Width:    d.Width,
+			Depth:    d.Depth,
+			Rows:     d.Rows,
+			Capacity: d.Capacity,
+			Children: documentsToTemplateNodes(d.Children),
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type customObjectTypeDocument struct {
+	ID        string             `bson:"_id"`
+	UserID    string             `bson:"user_id"`
+	Key       string             `bson:"key"`
+	Name      string             `bson:"name"`
+	Icon      string             `bson:"icon,omitempty"`
+	Color     string             `bson:"color,omitempty"`
+	Schema    *propertySchemaDoc `bson:"schema,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+type MongoCustomObjectTypeRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoCustomObjectTypeRepository(db *adapters.MongoDatabase) repositories.CustomObjectTypeRepository {
+	return &MongoCustomObjectTypeRepository{
+		db:         db,
+		collection: db.Database().Collection("custom_object_types"),
+	}
+}
+
+func (r *MongoCustomObjectTypeRepository) Create(ctx context.Context, objectType *entities.CustomObjectType) error {
+	if _, err := r.collection.InsertOne(ctx, customObjectTypeToDocument(objectType)); err != nil {
+		return fmt.Errorf("failed to create custom object type: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoCustomObjectTypeRepository) GetByID(ctx context.Context, id entities.CustomObjectTypeID) (*entities.CustomObjectType, error) {
+	return r.findOne(ctx, bson.M{"_id": id.String()})
+}
+
+func (r *MongoCustomObjectTypeRepository) GetByKey(ctx context.Context, userID entities.UserID, key entities.ObjectType) (*entities.CustomObjectType, error) {
+	return r.findOne(ctx, bson.M{"user_id": userID.String(), "key": key.String()})
+}
+
+func (r *MongoCustomObjectTypeRepository) findOne(ctx context.Context, filter bson.M) (*entities.CustomObjectType, error) {
+	var doc customObjectTypeDocument
+
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrCustomObjectTypeNotFound
+		}
+		return nil, fmt.Errorf("failed to get custom object type: %w", err)
+	}
+
+	return documentToCustomObjectType(&doc)
+}
+
+func (r *MongoCustomObjectTypeRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.CustomObjectType, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom object types: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var types []*entities.CustomObjectType
+	for cursor.Next(ctx) {
+		var doc customObjectTypeDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode custom object type: %w", err)
+		}
+
+		objectType, err := documentToCustomObjectType(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert custom object type: %w", err)
+		}
+
+		types = append(types, objectType)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return types, nil
+}
+
+func (r *MongoCustomObjectTypeRepository) Update(ctx context.Context, objectType *entities.CustomObjectType) error {
+	filter := bson.M{"_id": objectType.ID().String()}
+	update := bson.M{"$set": customObjectTypeToDocument(objectType)}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update custom object type: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrCustomObjectTypeNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoCustomObjectTypeRepository) Delete(ctx context.Context, id entities.CustomObjectTypeID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete custom object type: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrCustomObjectTypeNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoCustomObjectTypeRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete custom object types by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func customObjectTypeToDocument(t *entities.CustomObjectType) *customObjectTypeDocument {
+	return &customObjectTypeDocument{
+		ID:        t.ID().String(),
+		UserID:    t.UserID().String(),
+		Key:       t.Key().String(),
+		Name:      t.Name(),
+		Icon:      t.Icon(),
+		Color:     t.Color(),
+		Schema:    propertySchemaToDocument(t.Schema()),
+		CreatedAt: t.CreatedAt(),
+		UpdatedAt: t.UpdatedAt(),
+	}
+}
+
+func documentToCustomObjectType(doc *customObjectTypeDocument) (*entities.CustomObjectType, error) {
+	id, err := entities.CustomObjectTypeIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructCustomObjectType(
+		id,
+		userID,
+		entities.ObjectType(doc.Key),
+		doc.Name,
+		doc.Icon,
+		doc.Color,
+		documentToPropertySchema(doc.Schema),
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
+
+func propertySchemaToDocument(schema *entities.PropertySchema) *propertySchemaDoc {
+	if schema == nil {
+		return nil
+	}
+	doc := &propertySchemaDoc{Definitions: make([]propertyDefinitionDoc, len(schema.Definitions))}
+	for i, def := range schema.Definitions {
+		doc.Definitions[i] = propertyDefinitionDoc{
+			Key:          def.Key,
+			DisplayName:  def.DisplayName,
+			Type:         string(def.Type),
+			Required:     def.Required,
+			CurrencyCode: def.CurrencyCode,
+		}
+	}
+	return doc
+}
+
+func documentToPropertySchema(doc *propertySchemaDoc) *entities.PropertySchema {
+	if doc == nil {
+		return nil
+	}
+	schema := &entities.PropertySchema{Definitions: make([]entities.PropertyDefinition, len(doc.Definitions))}
+	for i, def := range doc.Definitions {
+		schema.Definitions[i] = entities.PropertyDefinition{
+			Key:          def.Key,
+			DisplayName:  def.DisplayName,
+			Type:         entities.PropertyType(def.Type),
+			Required:     def.Required,
+			CurrencyCode: def.CurrencyCode,
+		}
+	}
+	return schema
+}
@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type digestSubscriptionDocument struct {
+	UserID           string     `bson:"_id"`
+	Email            string     `bson:"email"`
+	Frequency        string     `bson:"frequency"`
+	GroupIDs         []string   `bson:"group_ids"`
+	UnsubscribeToken string     `bson:"unsubscribe_token"`
+	LastSentAt       *time.Time `bson:"last_sent_at,omitempty"`
+	CreatedAt        time.Time  `bson:"created_at"`
+	UpdatedAt        time.Time  `bson:"updated_at"`
+}
+
+type MongoDigestSubscriptionRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoDigestSubscriptionRepository(db *adapters.MongoDatabase) repositories.DigestSubscriptionRepository {
+	return &MongoDigestSubscriptionRepository{
+		db:         db,
+		collection: db.Database().Collection("digest_subscriptions"),
+	}
+}
+
+func (r *MongoDigestSubscriptionRepository) GetByUserID(ctx context.Context, userID entities.UserID) (*entities.DigestSubscription, error) {
+	return r.findOne(ctx, bson.M{"_id": userID.String()})
+}
+
+func (r *MongoDigestSubscriptionRepository) GetByUnsubscribeToken(ctx context.Context, token string) (*entities.DigestSubscription, error) {
+	return r.findOne(ctx, bson.M{"unsubscribe_token": token})
+}
+
+func (r *MongoDigestSubscriptionRepository) findOne(ctx context.Context, filter bson.M) (*entities.DigestSubscription, error) {
+	var doc digestSubscriptionDocument
+
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrDigestSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get digest subscription: %w", err)
+	}
+
+	return documentToDigestSubscription(&doc)
+}
+
+func (r *MongoDigestSubscriptionRepository) Save(ctx context.Context, subscription *entities.DigestSubscription) error {
+	doc := digestSubscriptionToDocument(subscription)
+
+	filter := bson.M{"_id": doc.UserID}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoDigestSubscriptionRepository) ListActive(ctx context.Context) ([]*entities.DigestSubscription, error) {
+	filter := bson.M{"frequency": bson.M{"$ne": entities.DigestFrequencyOff.String()}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []*entities.DigestSubscription
+	for cursor.Next(ctx) {
+		var doc digestSubscriptionDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode digest subscription: %w", err)
+		}
+
+		subscription, err := documentToDigestSubscription(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert digest subscription: %w", err)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *MongoDigestSubscriptionRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID.String()}); err != nil {
+		return fmt.Errorf("failed to delete digest subscription: %w", err)
+	}
+
+	return nil
+}
+
+func digestSubscriptionToDocument(s *entities.DigestSubscription) *digestSubscriptionDocument {
+	groupIDs := make([]string, len(s.GroupIDs()))
+	for i, id := range s.GroupIDs() {
+		groupIDs[i] = id.String()
+	}
+
+	return &digestSubscriptionDocument{
+		UserID:           s.UserID().String(),
+		Email:            s.Email().String(),
+		Frequency:        s.Frequency().String(),
+		GroupIDs:         groupIDs,
+		UnsubscribeToken: s.UnsubscribeToken(),
+		LastSentAt:       s.LastSentAt(),
+		CreatedAt:        s.CreatedAt(),
+		UpdatedAt:        s.UpdatedAt(),
+	}
+}
+
+func documentToDigestSubscription(doc *digestSubscriptionDocument) (*entities.DigestSubscription, error) {
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	email, err := entities.NewEmailAddress(doc.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email address: %w", err)
+	}
+
+	frequency, err := entities.NewDigestFrequency(doc.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]entities.GroupID, 0, len(doc.GroupIDs))
+	for _, id := range doc.GroupIDs {
+		groupID, err := entities.GroupIDFromString(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group ID: %w", err)
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	return entities.ReconstructDigestSubscription(
+		userID,
+		email,
+		frequency,
+		groupIDs,
+		doc.UnsubscribeToken,
+		doc.LastSentAt,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+// expiryHistoryDocument is one user's history for a category, keyed like
+// objectCodeDocument so the _id index keeps it unique.
+type expiryHistoryDocument struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	Category  string    `bson:"category"`
+	Days      []int     `bson:"days"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+type MongoExpiryHistoryRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoExpiryHistoryRepository(db *adapters.MongoDatabase) repositories.ExpiryHistoryRepository {
+	return &MongoExpiryHistoryRepository{
+		db:         db,
+		collection: db.Database().Collection("expiry_history"),
+	}
+}
+
+func expiryHistoryKey(userID entities.UserID, category string) string {
+	return userID.String() + ":" + category
+}
+
+func (r *MongoExpiryHistoryRepository) AddSample(ctx context.Context, userID entities.UserID, category string, days int) error {
+	filter := bson.M{"_id": expiryHistoryKey(userID, category)}
+	update := bson.M{
+		"$setOnInsert": bson.M{"user_id": userID.String(), "category": category},
+		"$push":        bson.M{"days": bson.M{"$each": bson.A{days}, "$slice": -entities.MaxExpirySamples}},
+		"$set":         bson.M{"updated_at": time.Now()},
+	}
+	opts := options.UpdateOne().SetUpsert(true)
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if mongo.IsDuplicateKeyError(err) {
+		// Lost the upsert race; the entry exists now, so the retry updates it
+		_, err = r.collection.UpdateOne(ctx, filter, update, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record expiry sample: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoExpiryHistoryRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.ExpiryHistory, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "category", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiry history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var histories []*entities.ExpiryHistory
+	for cursor.Next(ctx) {
+		var doc expiryHistoryDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode expiry history: %w", err)
+		}
+
+		histories = append(histories, entities.ReconstructExpiryHistory(userID, doc.Category, doc.Days, doc.UpdatedAt))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return histories, nil
+}
+
+func (r *MongoExpiryHistoryRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expiry history by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type featureFlagDocument struct {
+	Name        string    `bson:"_id"`
+	Description string    `bson:"description,omitempty"`
+	Enabled     bool      `bson:"enabled"`
+	Users       []string  `bson:"users,omitempty"`
+	Groups      []string  `bson:"groups,omitempty"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+type MongoFeatureFlagRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoFeatureFlagRepository(db *adapters.MongoDatabase) repositories.FeatureFlagRepository {
+	return &MongoFeatureFlagRepository{
+		db:         db,
+		collection: db.Database().Collection("feature_flags"),
+	}
+}
+
+func (r *MongoFeatureFlagRepository) List(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var flags []*entities.FeatureFlag
+	for cursor.Next(ctx) {
+		var doc featureFlagDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode feature flag: %w", err)
+		}
+		flags = append(flags, documentToFeatureFlag(&doc))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return flags, nil
+}
+
+func (r *MongoFeatureFlagRepository) Save(ctx context.Context, flag *entities.FeatureFlag) error {
+	doc := featureFlagToDocument(flag)
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": doc.Name}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save feature flag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoFeatureFlagRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return entities.ErrFeatureFlagNotFound
+	}
+
+	return nil
+}
+
+func featureFlagToDocument(f *entities.FeatureFlag) *featureFlagDocument {
+	return &featureFlagDocument{
+		Name:        f.Name(),
+		Description: f.Description(),
+		Enabled:     f.Enabled(),
+		Users:       f.Users(),
+		Groups:      f.Groups(),
+		UpdatedAt:   f.UpdatedAt(),
+	}
+}
+
+func documentToFeatureFlag(doc *featureFlagDocument) *entities.FeatureFlag {
+	return entities.ReconstructFeatureFlag(entities.FeatureFlagProps{
+		Name:        doc.Name,
+		Description: doc.Description,
+		Enabled:     doc.Enabled,
+		Users:       doc.Users,
+		Groups:      doc.Groups,
+	}, doc.UpdatedAt)
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type groupQuotaDocument struct {
+	GroupID         string    `bson:"_id"`
+	MaxContainers   int       `bson:"max_containers"`
+	MaxObjects      int       `bson:"max_objects"`
+	MaxStorageBytes int64     `bson:"max_storage_bytes"`
+	UpdatedBy       string    `bson:"updated_by"`
+	UpdatedAt       time.Time `bson:"updated_at"`
+}
+
+type MongoGroupQuotaRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoGroupQuotaRepository(db *adapters.MongoDatabase) repositories.GroupQuotaRepository {
+	return &MongoGroupQuotaRepository{
+		db:         db,
+		collection: db.Database().Collection("group_quotas"),
+	}
+}
+
+func (r *MongoGroupQuotaRepository) GetByGroupID(ctx context.Context, groupID entities.GroupID) (*entities.GroupQuota, error) {
+	var doc groupQuotaDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": groupID.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrGroupQuotaNotFound
+		}
+		return nil, fmt.Errorf("failed to get group quota: %w", err)
+	}
+
+	return documentToGroupQuota(&doc)
+}
+
+func (r *MongoGroupQuotaRepository) Save(ctx context.Context, quota *entities.GroupQuota) error {
+	doc := groupQuotaToDocument(quota)
+
+	filter := bson.M{"_id": doc.GroupID}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save group quota: %w", err)
+	}
+
+	return nil
+}
+
+func groupQuotaToDocument(q *entities.GroupQuota) *groupQuotaDocument {
+	return &groupQuotaDocument{
+		GroupID:         q.GroupID().String(),
+		MaxContainers:   q.MaxContainers(),
+		MaxObjects:      q.MaxObjects(),
+		MaxStorageBytes: q.MaxStorageBytes(),
+		UpdatedBy:       q.UpdatedBy().String(),
+		UpdatedAt:       q.UpdatedAt(),
+	}
+}
+
+func documentToGroupQuota(doc *groupQuotaDocument) (*entities.GroupQuota, error) {
+	groupID, err := entities.GroupIDFromString(doc.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group ID: %w", err)
+	}
+	// The admin who set the quota is informational; a bad value does not
+	// stop the quota from being enforced
+	updatedBy, _ := entities.UserIDFromString(doc.UpdatedBy)
+
+	return entities.ReconstructGroupQuota(groupID, doc.MaxContainers, doc.MaxObjects, doc.MaxStorageBytes, updatedBy, doc.UpdatedAt), nil
+}
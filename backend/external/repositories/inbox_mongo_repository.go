@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type inboxAddressDocument struct {
+	UserID    string    `bson:"_id"`
+	Token     string    `bson:"token"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type inboxLineDocument struct {
+	Name     string   `bson:"name"`
+	Quantity *float64 `bson:"quantity,omitempty"`
+	Unit     string   `bson:"unit,omitempty"`
+	Price    *float64 `bson:"price,omitempty"`
+}
+
+type inboxItemDocument struct {
+	ID         string              `bson:"_id"`
+	UserID     string              `bson:"user_id"`
+	Sender     string              `bson:"sender"`
+	Subject    string              `bson:"subject"`
+	Lines      []inboxLineDocument `bson:"lines"`
+	ReceivedAt time.Time           `bson:"received_at"`
+}
+
+type MongoInboxRepository struct {
+	db        *adapters.MongoDatabase
+	addresses *mongo.Collection
+	items     *mongo.Collection
+}
+
+func NewMongoInboxRepository(db *adapters.MongoDatabase) repositories.InboxRepository {
+	return &MongoInboxRepository{
+		db:        db,
+		addresses: db.Database().Collection("inbox_addresses"),
+		items:     db.Database().Collection("inbox_items"),
+	}
+}
+
+func (r *MongoInboxRepository) GetAddressByUserID(ctx context.Context, userID entities.UserID) (*entities.InboxAddress, error) {
+	return r.findAddress(ctx, bson.M{"_id": userID.String()})
+}
+
+func (r *MongoInboxRepository) GetAddressByToken(ctx context.Context, token string) (*entities.InboxAddress, error) {
+	return r.findAddress(ctx, bson.M{"token": token})
+}
+
+func (r *MongoInboxRepository) findAddress(ctx context.Context, filter bson.M) (*entities.InboxAddress, error) {
+	var doc inboxAddressDocument
+
+	err := r.addresses.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrInboxAddressNotFound
+		}
+		return nil, fmt.Errorf("failed to get inbox address: %w", err)
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructInboxAddress(userID, doc.Token, doc.CreatedAt), nil
+}
+
+func (r *MongoInboxRepository) SaveAddress(ctx context.Context, address *entities.InboxAddress) error {
+	doc := &inboxAddressDocument{
+		UserID:    address.UserID().String(),
+		Token:     address.Token(),
+		CreatedAt: address.CreatedAt(),
+	}
+
+	filter := bson.M{"_id": doc.UserID}
+	_, err := r.addresses.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save inbox address: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoInboxRepository) CreateItem(ctx context.Context, item *entities.InboxItem) error {
+	if _, err := r.items.InsertOne(ctx, inboxItemToDocument(item)); err != nil {
+		return fmt.Errorf("failed to create inbox item: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoInboxRepository) GetItemByID(ctx context.Context, id entities.InboxItemID) (*entities.InboxItem, error) {
+	var doc inboxItemDocument
+
+	err := r.items.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrInboxItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get inbox item: %w", err)
+	}
+
+	return documentToInboxItem(&doc)
+}
+
+func (r *MongoInboxRepository) ListItemsByUserID(ctx context.Context, userID entities.UserID) ([]*entities.InboxItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: -1}})
+
+	cursor, err := r.items.Find(ctx, bson.M{"user_id": userID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inbox items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*entities.InboxItem
+	for cursor.Next(ctx) {
+		var doc inboxItemDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode inbox item: %w", err)
+		}
+
+		item, err := documentToInboxItem(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert inbox item: %w", err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *MongoInboxRepository) CountItemsByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	count, err := r.items.CountDocuments(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count inbox items: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *MongoInboxRepository) UpdateItem(ctx context.Context, item *entities.InboxItem) error {
+	doc := inboxItemToDocument(item)
+
+	result, err := r.items.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc)
+	if err != nil {
+		return fmt.Errorf("failed to update inbox item: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrInboxItemNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoInboxRepository) DeleteItem(ctx context.Context, id entities.InboxItemID) error {
+	result, err := r.items.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete inbox item: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrInboxItemNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoInboxRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	if _, err := r.addresses.DeleteOne(ctx, bson.M{"_id": userID.String()}); err != nil {
+		return 0, fmt.Errorf("failed to delete inbox address: %w", err)
+	}
+
+	result, err := r.items.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete inbox items by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func inboxItemToDocument(item *entities.InboxItem) *inboxItemDocument {
+	lines := make([]inboxLineDocument, len(item.Lines()))
+	for i, line := range item.Lines() {
+		lines[i] = inboxLineDocument{
+			Name:     line.Name,
+			Quantity: line.Quantity,
+			Unit:     line.Unit,
+			Price:    line.Price,
+		}
+	}
+
+	return &inboxItemDocument{
+		ID:         item.ID().String(),
+		UserID:     item.UserID().String(),
+		Sender:     item.Sender(),
+		Subject:    item.Subject(),
+		Lines:      lines,
+		ReceivedAt: item.ReceivedAt(),
+	}
+}
+
+func documentToInboxItem(doc *inboxItemDocument) (*entities.InboxItem, error) {
+	id, err := entities.InboxItemIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	lines := make([]entities.InboxLine, len(doc.Lines))
+	for i, d := range doc.Lines {
+		lines[i] = entities.InboxLine{
+			Name:     d.Name,
+			Quantity: d.Quantity,
+			Unit:     d.Unit,
+			Price:    d.Price,
+		}
+	}
+
+	return entities.ReconstructInboxItem(id, userID, doc.Sender, doc.Subject, lines, doc.ReceivedAt), nil
+}
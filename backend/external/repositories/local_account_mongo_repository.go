@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+// localAccountDocument is keyed by the normalized username so the unique
+// _id index keeps usernames unique.
+type localAccountDocument struct {
+	Username     string    `bson:"_id"`
+	DisplayName  string    `bson:"username"`
+	UserID       string    `bson:"user_id"`
+	Email        string    `bson:"email"`
+	PasswordHash string    `bson:"password_hash"`
+	CreatedAt    time.Time `bson:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at"`
+}
+
+type MongoLocalAccountRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoLocalAccountRepository(db *adapters.MongoDatabase) repositories.LocalAccountRepository {
+	return &MongoLocalAccountRepository{
+		db:         db,
+		collection: db.Database().Collection("local_accounts"),
+	}
+}
+
+func (r *MongoLocalAccountRepository) Create(ctx context.Context, account *entities.LocalAccount) error {
+	doc := &localAccountDocument{
+		Username:     entities.NormalizeLocalUsername(account.Username().String()),
+		DisplayName:  account.Username().String(),
+		UserID:       account.UserID().String(),
+		Email:        account.EmailAddress().String(),
+		PasswordHash: account.PasswordHash(),
+		CreatedAt:    account.CreatedAt(),
+		UpdatedAt:    account.UpdatedAt(),
+	}
+
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return entities.ErrLocalAccountExists
+		}
+		return fmt.Errorf("failed to create local account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoLocalAccountRepository) GetByUsername(ctx context.Context, username string) (*entities.LocalAccount, error) {
+	return r.findOne(ctx, bson.M{"_id": entities.NormalizeLocalUsername(username)})
+}
+
+func (r *MongoLocalAccountRepository) GetByUserID(ctx context.Context, userID entities.UserID) (*entities.LocalAccount, error) {
+	return r.findOne(ctx, bson.M{"user_id": userID.String()})
+}
+
+func (r *MongoLocalAccountRepository) Count(ctx context.Context) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count local accounts: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *MongoLocalAccountRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID.String()}); err != nil {
+		return fmt.Errorf("failed to delete local account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoLocalAccountRepository) findOne(ctx context.Context, filter bson.M) (*entities.LocalAccount, error) {
+	var doc localAccountDocument
+
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrLocalAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get local account: %w", err)
+	}
+
+	return documentToLocalAccount(&doc)
+}
+
+func documentToLocalAccount(doc *localAccountDocument) (*entities.LocalAccount, error) {
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	username, err := entities.NewUsername(doc.DisplayName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid username: %w", err)
+	}
+
+	email, err := entities.NewEmailAddress(doc.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email: %w", err)
+	}
+
+	return entities.ReconstructLocalAccount(userID, username, email, doc.PasswordHash, doc.CreatedAt, doc.UpdatedAt), nil
+}
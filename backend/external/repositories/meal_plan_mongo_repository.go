@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type mealIngredientDocument struct {
+	ObjectID bson.ObjectID `bson:"object_id"`
+	Name     string        `bson:"name"`
+	Quantity float64       `bson:"quantity"`
+	Unit     string        `bson:"unit,omitempty"`
+}
+
+type mealPlanDocument struct {
+	ID          string                   `bson:"_id"`
+	UserID      string                   `bson:"user_id"`
+	Date        time.Time                `bson:"date"`
+	Meal        string                   `bson:"meal"`
+	RecipeName  string                   `bson:"recipe_name"`
+	Ingredients []mealIngredientDocument `bson:"ingredients"`
+	CompletedAt *time.Time               `bson:"completed_at,omitempty"`
+	CreatedAt   time.Time                `bson:"created_at"`
+	UpdatedAt   time.Time                `bson:"updated_at"`
+}
+
+type MongoMealPlanRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoMealPlanRepository(db *adapters.MongoDatabase) repositories.MealPlanRepository {
+	return &MongoMealPlanRepository{
+		db:         db,
+		collection: db.Database().Collection("meal_plans"),
+	}
+}
+
+func (r *MongoMealPlanRepository) Create(ctx context.Context, plan *entities.MealPlan) error {
+	if _, err := r.collection.InsertOne(ctx, mealPlanToDocument(plan)); err != nil {
+		return fmt.Errorf("failed to create meal plan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoMealPlanRepository) GetByID(ctx context.Context, id entities.MealPlanID) (*entities.MealPlan, error) {
+	var doc mealPlanDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrMealPlanNotFound
+		}
+		return nil, fmt.Errorf("failed to get meal plan: %w", err)
+	}
+
+	return documentToMealPlan(&doc)
+}
+
+func (r *MongoMealPlanRepository) ListByUserID(ctx context.Context, userID entities.UserID, from, to time.Time) ([]*entities.MealPlan, error) {
+	filter := bson.M{
+		"user_id": userID.String(),
+		"date":    bson.M{"$gte": from, "$lt": to},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meal plans: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var plans []*entities.MealPlan
+	for cursor.Next(ctx) {
+		var doc mealPlanDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode meal plan: %w", err)
+		}
+
+		plan, err := documentToMealPlan(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert meal plan: %w", err)
+		}
+
+		plans = append(plans, plan)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return plans, nil
+}
+
+func (r *MongoMealPlanRepository) Update(ctx context.Context, plan *entities.MealPlan) error {
+	doc := mealPlanToDocument(plan)
+
+	filter := bson.M{"_id": plan.ID().String()}
+	update := bson.M{"$set": doc}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update meal plan: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrMealPlanNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoMealPlanRepository) Delete(ctx context.Context, id entities.MealPlanID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete meal plan: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrMealPlanNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoMealPlanRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete meal plans by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func mealPlanToDocument(p *entities.MealPlan) *mealPlanDocument {
+	ingredients := make([]mealIngredientDocument, len(p.Ingredients()))
+	for i, ing := range p.Ingredients() {
+		ingredients[i] = mealIngredientDocument{
+			ObjectID: ing.ObjectID.ObjectID(),
+			Name:     ing.Name,
+			Quantity: ing.Quantity,
+			Unit:     ing.Unit,
+		}
+	}
+
+	return &mealPlanDocument{
+		ID:          p.ID().String(),
+		UserID:      p.UserID().String(),
+		Date:        p.Date(),
+		Meal:        string(p.Meal()),
+		RecipeName:  p.RecipeName(),
+		Ingredients: ingredients,
+		CompletedAt: p.CompletedAt(),
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
+	}
+}
+
+func documentToMealPlan(doc *mealPlanDocument) (*entities.MealPlan, error) {
+	id, err := entities.MealPlanIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	ingredients := make([]entities.MealIngredient, len(doc.Ingredients))
+	for i, d := range doc.Ingredients {
+		ingredients[i] = entities.MealIngredient{
+			ObjectID: entities.ObjectIDFromObjectID(d.ObjectID),
+			Name:     d.Name,
+			Quantity: d.Quantity,
+			Unit:     d.Unit,
+		}
+	}
+
+	return entities.ReconstructMealPlan(
+		id,
+		userID,
+		doc.Date.UTC(),
+		entities.MealType(doc.Meal),
+		doc.RecipeName,
+		ingredients,
+		doc.CompletedAt,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type mediaDocument struct {
+	ID           string    `bson:"_id"`
+	CollectionID string    `bson:"collection_id"`
+	OwnerKind    string    `bson:"owner_kind"`
+	OwnerID      string    `bson:"owner_id"`
+	UploadedBy   string    `bson:"uploaded_by"`
+	Filename     string    `bson:"filename,omitempty"`
+	ContentType  string    `bson:"content_type"`
+	Size         int64     `bson:"size"`
+	Width        int       `bson:"width"`
+	Height       int       `bson:"height"`
+	URL          string    `bson:"url"`
+	ThumbnailURL string    `bson:"thumbnail_url"`
+	CreatedAt    time.Time `bson:"created_at"`
+}
+
+type MongoMediaRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoMediaRepository(db *adapters.MongoDatabase) repositories.MediaRepository {
+	return &MongoMediaRepository{
+		db:         db,
+		collection: db.Database().Collection("media"),
+	}
+}
+
+func (r *MongoMediaRepository) Create(ctx context.Context, media *entities.Media) error {
+	if _, err := r.collection.InsertOne(ctx, mediaToDocument(media)); err != nil {
+		return fmt.Errorf("failed to create media: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoMediaRepository) GetByID(ctx context.Context, id entities.MediaID) (*entities.Media, error) {
+	var doc mediaDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	return documentToMedia(&doc)
+}
+
+func (r *MongoMediaRepository) ListByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string, page entities.MediaPage) ([]*entities.Media, int64, error) {
+	return r.list(ctx, bson.M{"owner_kind": kind.String(), "owner_id": ownerID}, page)
+}
+
+func (r *MongoMediaRepository) ListByCollectionID(ctx context.Context, collectionID entities.CollectionID, page entities.MediaPage) ([]*entities.Media, int64, error) {
+	return r.list(ctx, bson.M{"collection_id": collectionID.String()}, page)
+}
+
+func (r *MongoMediaRepository) list(ctx context.Context, filter bson.M, page entities.MediaPage) ([]*entities.Media, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(page.Offset)).
+		SetLimit(int64(page.Limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list media: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var media []*entities.Media
+	for cursor.Next(ctx) {
+		var doc mediaDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode media: %w", err)
+		}
+
+		m, err := documentToMedia(&doc)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to convert media: %w", err)
+		}
+
+		media = append(media, m)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return media, total, nil
+}
+
+func (r *MongoMediaRepository) CountByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"owner_kind": kind.String(), "owner_id": ownerID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *MongoMediaRepository) Delete(ctx context.Context, id entities.MediaID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrMediaNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoMediaRepository) DeleteByOwner(ctx context.Context, kind entities.MediaOwnerKind, ownerID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"owner_kind": kind.String(), "owner_id": ownerID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete media by owner: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (r *MongoMediaRepository) DeleteByCollectionID(ctx context.Context, collectionID entities.CollectionID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"collection_id": collectionID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete media by collection ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func mediaToDocument(m *entities.Media) *mediaDocument {
+	return &mediaDocument{
+		ID:           m.ID().String(),
+		CollectionID: m.CollectionID().String(),
+		OwnerKind:    m.OwnerKind().String(),
+		OwnerID:      m.OwnerID(),
+		UploadedBy:   m.UploadedBy().String(),
+		Filename:     m.Filename(),
+		ContentType:  m.ContentType(),
+		Size:         m.Size(),
+		Width:        m.Width(),
+		Height:       m.Height(),
+		URL:          m.URL(),
+		ThumbnailURL: m.ThumbnailURL(),
+		CreatedAt:    m.CreatedAt(),
+	}
+}
+
+func documentToMedia(doc *mediaDocument) (*entities.Media, error) {
+	id, err := entities.MediaIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+	collectionID, err := entities.CollectionIDFromString(doc.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := entities.NewMediaOwnerKind(doc.OwnerKind)
+	if err != nil {
+		return nil, err
+	}
+	uploadedBy, err := entities.UserIDFromString(doc.UploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ReconstructMedia(entities.MediaProps{
+		ID:           id,
+		CollectionID: collectionID,
+		OwnerKind:    kind,
+		OwnerID:      doc.OwnerID,
+		UploadedBy:   uploadedBy,
+		Filename:     doc.Filename,
+		ContentType:  doc.ContentType,
+		Size:         doc.Size,
+		Width:        doc.Width,
+		Height:       doc.Height,
+		URL:          doc.URL,
+		ThumbnailURL: doc.ThumbnailURL,
+	}, doc.CreatedAt), nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type oauthClientDocument struct {
+	ClientID     string    `bson:"_id"`
+	ProviderName string    `bson:"provider_name"`
+	ClientSecret string    `bson:"client_secret"`
+	RedirectURL  string    `bson:"redirect_url"`
+	CreatedAt    time.Time `bson:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at"`
+}
+
+type MongoOAuthClientRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoOAuthClientRepository(db *adapters.MongoDatabase) repositories.OAuthClientRepository {
+	return &MongoOAuthClientRepository{
+		db:         db,
+		collection: db.Database().Collection("oauth_clients"),
+	}
+}
+
+func (r *MongoOAuthClientRepository) List(ctx context.Context) ([]*entities.OAuthClient, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var clients []*entities.OAuthClient
+	for cursor.Next(ctx) {
+		var doc oauthClientDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode oauth client: %w", err)
+		}
+		clients = append(clients, documentToOAuthClient(&doc))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return clients, nil
+}
+
+func (r *MongoOAuthClientRepository) GetByClientID(ctx context.Context, clientID string) (*entities.OAuthClient, error) {
+	var doc oauthClientDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": clientID}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrOAuthClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	return documentToOAuthClient(&doc), nil
+}
+
+func (r *MongoOAuthClientRepository) Save(ctx context.Context, client *entities.OAuthClient) error {
+	doc := oauthClientToDocument(client)
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": doc.ClientID}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save oauth client: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoOAuthClientRepository) Delete(ctx context.Context, clientID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": clientID})
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return entities.ErrOAuthClientNotFound
+	}
+
+	return nil
+}
+
+func oauthClientToDocument(c *entities.OAuthClient) *oauthClientDocument {
+	return &oauthClientDocument{
+		ClientID:     c.ClientID(),
+		ProviderName: c.ProviderName(),
+		ClientSecret: c.ClientSecret(),
+		RedirectURL:  c.RedirectURL(),
+		CreatedAt:    c.CreatedAt(),
+		UpdatedAt:    c.UpdatedAt(),
+	}
+}
+
+func documentToOAuthClient(doc *oauthClientDocument) *entities.OAuthClient {
+	return entities.ReconstructOAuthClient(doc.ProviderName, doc.ClientID, doc.ClientSecret, doc.RedirectURL, doc.CreatedAt, doc.UpdatedAt)
+}
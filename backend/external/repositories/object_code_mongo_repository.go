@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+// objectCodeDocument is one account's entry for a code. The _id is the
+// account and code together, so Mongo's own _id index keeps the entry
+// unique without a separate index to create.
+type objectCodeDocument struct {
+	ID        string          `bson:"_id"`
+	UserID    string          `bson:"user_id"`
+	Code      string          `bson:"code"`
+	ObjectIDs []bson.ObjectID `bson:"object_ids"`
+	UpdatedAt time.Time       `bson:"updated_at"`
+}
+
+type MongoObjectCodeRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoObjectCodeRepository(db *adapters.MongoDatabase) repositories.ObjectCodeRepository {
+	return &MongoObjectCodeRepository{
+		db:         db,
+		collection: db.Database().Collection("object_codes"),
+	}
+}
+
+func objectCodeKey(userID entities.UserID, code string) string {
+	return userID.String() + ":" + code
+}
+
+func (r *MongoObjectCodeRepository) Add(ctx context.Context, userID entities.UserID, code string, objectID entities.ObjectID) error {
+	filter := bson.M{"_id": objectCodeKey(userID, code)}
+	update := bson.M{
+		"$setOnInsert": bson.M{"user_id": userID.String(), "code": code},
+		"$addToSet":    bson.M{"object_ids": objectID.ObjectID()},
+		"$set":         bson.M{"updated_at": time.Now()},
+	}
+	opts := options.UpdateOne().SetUpsert(true)
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if mongo.IsDuplicateKeyError(err) {
+		// Another writer inserted the entry between our match and insert;
+		// the entry exists now, so the retry updates it.
+		_, err = r.collection.UpdateOne(ctx, filter, update, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to index object code: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoObjectCodeRepository) Remove(ctx context.Context, userID entities.UserID, code string, objectID entities.ObjectID) error {
+	key := objectCodeKey(userID, code)
+	update := bson.M{
+		"$pull": bson.M{"object_ids": objectID.ObjectID()},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": key}, update); err != nil {
+		return fmt.Errorf("failed to unindex object code: %w", err)
+	}
+
+	// Drop the entry once nothing carries the code; an Add racing this only
+	// matches when the list is still empty, so it never loses an object.
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": key, "object_ids": bson.M{"$size": 0}}); err != nil {
+		return fmt.Errorf("failed to delete empty object code: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoObjectCodeRepository) Lookup(ctx context.Context, userID entities.UserID, code string) ([]entities.ObjectID, error) {
+	var doc objectCodeDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": objectCodeKey(userID, code)}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up object code: %w", err)
+	}
+
+	ids := make([]entities.ObjectID, len(doc.ObjectIDs))
+	for i, id := range doc.ObjectIDs {
+		ids[i] = entities.ObjectIDFromObjectID(id)
+	}
+	return ids, nil
+}
+
+func (r *MongoObjectCodeRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete object codes by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type objectPlacementDocument struct {
+	ContainerID   string `bson:"container_id"`
+	ContainerName string `bson:"container_name"`
+	Location      string `bson:"location,omitempty"`
+}
+
+type objectMoveDocument struct {
+	ID            bson.ObjectID           `bson:"_id"`
+	ObjectID      bson.ObjectID           `bson:"object_id"`
+	From          objectPlacementDocument `bson:"from"`
+	To            objectPlacementDocument `bson:"to"`
+	MovedByUserID string                  `bson:"moved_by_user_id"`
+	MovedAt       time.Time               `bson:"moved_at"`
+}
+
+type MongoObjectMoveRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoObjectMoveRepository(db *adapters.MongoDatabase) repositories.ObjectMoveRepository {
+	return &MongoObjectMoveRepository{
+		db:         db,
+		collection: db.Database().Collection("object_moves"),
+	}
+}
+
+func (r *MongoObjectMoveRepository) Create(ctx context.Context, move *entities.ObjectMove) error {
+	doc := objectMoveDocument{
+		ID:            bson.NewObjectID(),
+		ObjectID:      move.ObjectID().ObjectID(),
+		From:          placementToDocument(move.From()),
+		To:            placementToDocument(move.To()),
+		MovedByUserID: move.MovedByUserID().String(),
+		MovedAt:       move.MovedAt(),
+	}
+
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("failed to record object move: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoObjectMoveRepository) ListByObjectID(ctx context.Context, objectID entities.ObjectID) ([]*entities.ObjectMove, error) {
+	return r.find(ctx, bson.M{"object_id": objectID.ObjectID()})
+}
+
+func (r *MongoObjectMoveRepository) ListByObjectIDs(ctx context.Context, objectIDs []entities.ObjectID) ([]*entities.ObjectMove, error) {
+	if len(objectIDs) == 0 {
+		return nil, nil
+	}
+	return r.find(ctx, bson.M{"object_id": bson.M{"$in": objectIDsToBSON(objectIDs)}})
+}
+
+func (r *MongoObjectMoveRepository) DeleteByObjectIDs(ctx context.Context, objectIDs []entities.ObjectID) (int64, error) {
+	if len(objectIDs) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"object_id": bson.M{"$in": objectIDsToBSON(objectIDs)}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete object moves: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (r *MongoObjectMoveRepository) find(ctx context.Context, filter bson.M) ([]*entities.ObjectMove, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "moved_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object moves: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var moves []*entities.ObjectMove
+	for cursor.Next(ctx) {
+		var doc objectMoveDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode object move: %w", err)
+		}
+
+		move, err := documentToObjectMove(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert object move: %w", err)
+		}
+
+		moves = append(moves, move)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return moves, nil
+}
+
+func objectIDsToBSON(ids []entities.ObjectID) []bson.ObjectID {
+	out := make([]bson.ObjectID, len(ids))
+	for i, id := range ids {
+		out[i] = id.ObjectID()
+	}
+	return out
+}
+
+func placementToDocument(p entities.ObjectPlacement) objectPlacementDocument {
+	return objectPlacementDocument{
+		ContainerID:   p.ContainerID.String(),
+		ContainerName: p.ContainerName,
+		Location:      p.Location,
+	}
+}
+
+func documentToPlacement(doc objectPlacementDocument) (entities.ObjectPlacement, error) {
+	containerID, err := entities.ContainerIDFromString(doc.ContainerID)
+	if err != nil {
+		return entities.ObjectPlacement{}, fmt.Errorf("invalid container ID: %w", err)
+	}
+
+	return entities.ObjectPlacement{
+		ContainerID:   containerID,
+		ContainerName: doc.ContainerName,
+		Location:      doc.Location,
+	}, nil
+}
+
+func documentToObjectMove(doc *objectMoveDocument) (*entities.ObjectMove, error) {
+	from, err := documentToPlacement(doc.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := documentToPlacement(doc.To)
+	if err != nil {
+		return nil, err
+	}
+
+	movedBy, err := entities.UserIDFromString(doc.MovedByUserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructObjectMove(
+		entities.ObjectIDFromObjectID(doc.ObjectID),
+		from,
+		to,
+		movedBy,
+		doc.MovedAt,
+	), nil
+}
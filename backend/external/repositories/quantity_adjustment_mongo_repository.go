@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type quantityAdjustmentDocument struct {
+	ID               bson.ObjectID `bson:"_id"`
+	ObjectID         bson.ObjectID `bson:"object_id"`
+	CollectionID     string        `bson:"collection_id"`
+	ObjectName       string        `bson:"object_name"`
+	Category         string        `bson:"category,omitempty"`
+	Unit             string        `bson:"unit,omitempty"`
+	Previous         float64       `bson:"previous"`
+	Quantity         float64       `bson:"quantity"`
+	AdjustedByUserID string        `bson:"adjusted_by_user_id"`
+	AdjustedAt       time.Time     `bson:"adjusted_at"`
+}
+
+type MongoQuantityAdjustmentRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoQuantityAdjustmentRepository(db *adapters.MongoDatabase) repositories.QuantityAdjustmentRepository {
+	return &MongoQuantityAdjustmentRepository{
+		db:         db,
+		collection: db.Database().Collection("quantity_adjustments"),
+	}
+}
+
+func (r *MongoQuantityAdjustmentRepository) Create(ctx context.Context, adjustment *entities.QuantityAdjustment) error {
+	doc := quantityAdjustmentDocument{
+		ID:               bson.NewObjectID(),
+		ObjectID:         adjustment.ObjectID().ObjectID(),
+		CollectionID:     adjustment.CollectionID().String(),
+		ObjectName:       adjustment.ObjectName(),
+		Category:         adjustment.Category(),
+		Unit:             adjustment.Unit(),
+		Previous:         adjustment.Previous(),
+		Quantity:         adjustment.Quantity(),
+		AdjustedByUserID: adjustment.AdjustedByUserID().String(),
+		AdjustedAt:       adjustment.AdjustedAt(),
+	}
+
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("failed to record quantity adjustment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoQuantityAdjustmentRepository) ListByCollectionIDs(ctx context.Context, collectionIDs []entities.CollectionID, from, to time.Time) ([]*entities.QuantityAdjustment, error) {
+	if len(collectionIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(collectionIDs))
+	for i, id := range collectionIDs {
+		ids[i] = id.String()
+	}
+	filter := bson.M{
+		"collection_id": bson.M{"$in": ids},
+		"adjusted_at":   bson.M{"$gte": from, "$lt": to},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "adjusted_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quantity adjustments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var adjustments []*entities.QuantityAdjustment
+	for cursor.Next(ctx) {
+		var doc quantityAdjustmentDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode quantity adjustment: %w", err)
+		}
+
+		adjustment, err := documentToQuantityAdjustment(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert quantity adjustment: %w", err)
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+func (r *MongoQuantityAdjustmentRepository) DeleteByObjectIDs(ctx context.Context, objectIDs []entities.ObjectID) (int64, error) {
+	if len(objectIDs) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"object_id": bson.M{"$in": objectIDsToBSON(objectIDs)}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete quantity adjustments: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func documentToQuantityAdjustment(doc *quantityAdjustmentDocument) (*entities.QuantityAdjustment, error) {
+	collectionID, err := entities.CollectionIDFromString(doc.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collection ID: %w", err)
+	}
+
+	adjustedBy, err := entities.UserIDFromString(doc.AdjustedByUserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructQuantityAdjustment(
+		entities.ObjectIDFromObjectID(doc.ObjectID),
+		collectionID,
+		doc.ObjectName,
+		doc.Category,
+		doc.Unit,
+		doc.Previous,
+		doc.Quantity,
+		adjustedBy,
+		doc.AdjustedAt,
+	), nil
+}
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type recurrenceRuleDocument struct {
+	ID           string        `bson:"_id"`
+	UserID       string        `bson:"user_id"`
+	ObjectID     bson.ObjectID `bson:"object_id"`
+	CollectionID string        `bson:"collection_id"`
+	Name         string        `bson:"name"`
+	Every        int           `bson:"every"`
+	Period       string        `bson:"period"`
+	Quantity     *float64      `bson:"quantity,omitempty"`
+	NextDueAt    time.Time     `bson:"next_due_at"`
+	DueSince     *time.Time    `bson:"due_since,omitempty"`
+	CreatedAt    time.Time     `bson:"created_at"`
+	UpdatedAt    time.Time     `bson:"updated_at"`
+}
+
+type MongoRecurrenceRuleRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoRecurrenceRuleRepository(db *adapters.MongoDatabase) repositories.RecurrenceRuleRepository {
+	return &MongoRecurrenceRuleRepository{
+		db:         db,
+		collection: db.Database().Collection("recurrence_rules"),
+	}
+}
+
+func (r *MongoRecurrenceRuleRepository) Create(ctx context.Context, rule *entities.RecurrenceRule) error {
+	if _, err := r.collection.InsertOne(ctx, recurrenceRuleToDocument(rule)); err != nil {
+		return fmt.Errorf("failed to create recurrence rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoRecurrenceRuleRepository) GetByID(ctx context.Context, id entities.RecurrenceRuleID) (*entities.RecurrenceRule, error) {
+	return r.findOne(ctx, bson.M{"_id": id.String()})
+}
+
+func (r *MongoRecurrenceRuleRepository) GetByObjectID(ctx context.Context, userID entities.UserID, objectID entities.ObjectID) (*entities.RecurrenceRule, error) {
+	return r.findOne(ctx, bson.M{"user_id": userID.String(), "object_id": objectID.ObjectID()})
+}
+
+func (r *MongoRecurrenceRuleRepository) findOne(ctx context.Context, filter bson.M) (*entities.RecurrenceRule, error) {
+	var doc recurrenceRuleDocument
+
+	err := r.collection.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrRecurrenceRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get recurrence rule: %w", err)
+	}
+
+	return documentToRecurrenceRule(&doc)
+}
+
+func (r *MongoRecurrenceRuleRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.RecurrenceRule, error) {
+	return r.find(ctx, bson.M{"user_id": userID.String()})
+}
+
+func (r *MongoRecurrenceRuleRepository) ListDue(ctx context.Context, t time.Time) ([]*entities.RecurrenceRule, error) {
+	return r.find(ctx, bson.M{"next_due_at": bson.M{"$lte": t}})
+}
+
+func (r *MongoRecurrenceRuleRepository) find(ctx context.Context, filter bson.M) ([]*entities.RecurrenceRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "next_due_at", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrence rules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rules []*entities.RecurrenceRule
+	for cursor.Next(ctx) {
+		var doc recurrenceRuleDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode recurrence rule: %w", err)
+		}
+
+		rule, err := documentToRecurrenceRule(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert recurrence rule: %w", err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *MongoRecurrenceRuleRepository) Update(ctx context.Context, rule *entities.RecurrenceRule) error {
+	// Replaced whole so a cleared due_since is dropped
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": rule.ID().String()}, recurrenceRuleToDocument(rule))
+	if err != nil {
+		return fmt.Errorf("failed to update recurrence rule: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrRecurrenceRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoRecurrenceRuleRepository) Delete(ctx context.Context, id entities.RecurrenceRuleID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("failed to delete recurrence rule: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entities.ErrRecurrenceRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoRecurrenceRuleRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete recurrence rules by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func recurrenceRuleToDocument(rule *entities.RecurrenceRule) *recurrenceRuleDocument {
+	return &recurrenceRuleDocument{
+		ID:           rule.ID().String(),
+		UserID:       rule.UserID().String(),
+		ObjectID:     rule.ObjectID().ObjectID(),
+		CollectionID: rule.CollectionID().String(),
+		Name:         rule.Name(),
+		Every:        rule.Recurrence().Every,
+		Period:       string(rule.Recurrence().Period),
+		Quantity:     rule.Quantity(),
+		NextDueAt:    rule.NextDueAt(),
+		DueSince:     rule.DueSince(),
+		CreatedAt:    rule.CreatedAt(),
+		UpdatedAt:    rule.UpdatedAt(),
+	}
+}
+
+func documentToRecurrenceRule(doc *recurrenceRuleDocument) (*entities.RecurrenceRule, error) {
+	id, err := entities.RecurrenceRuleIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	collectionID, err := entities.CollectionIDFromString(doc.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collection ID: %w", err)
+	}
+
+	return entities.ReconstructRecurrenceRule(
+		id,
+		userID,
+		entities.ObjectIDFromObjectID(doc.ObjectID),
+		collectionID,
+		doc.Name,
+		entities.Recurrence{Every: doc.Every, Period: entities.RecurrencePeriod(doc.Period)},
+		doc.Quantity,
+		doc.NextDueAt,
+		doc.DueSince,
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+// readCollection returns primary, or the same collection behind the read
+// replica when ctx allows replica reads. Without a read_uri configured both
+// are the primary.
+func readCollection(ctx context.Context, db *adapters.MongoDatabase, primary *mongo.Collection) *mongo.Collection {
+	if !repositories.ReplicaReads(ctx) {
+		return primary
+	}
+	return db.ReadDatabase().Collection(primary.Name())
+}
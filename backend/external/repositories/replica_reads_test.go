@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// TestLookupsByIDReadThePrimary checks that single-document lookups stay on
+// the primary under a replica-reads context. The repositories have no database
+// adapter, so a read routed to the replica would panic; the primary client
+// points at a closed port, so reads through it fail naming that address.
+func TestLookupsByIDReadThePrimary(t *testing.T) {
+	t.Parallel()
+
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://127.0.0.1:1/?serverSelectionTimeoutMS=50&connectTimeoutMS=50"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Disconnect(t.Context()) })
+	db := client.Database("nishiki")
+
+	ctx := repositories.WithReplicaReads(t.Context())
+	collections := &MongoCollectionRepository{collection: db.Collection("collections"), containerCollection: db.Collection("containers")}
+	containers := &MongoContainerRepository{collection: db.Collection("containers"), collections: db.Collection("collections")}
+
+	tests := map[string]func() error{
+		"collection GetByID": func() error { _, err := collections.GetByID(ctx, entities.NewCollectionID()); return err },
+		"collection GetByIDSummary": func() error {
+			_, err := collections.GetByIDSummary(ctx, entities.NewCollectionID())
+			return err
+		},
+		"collection Exists": func() error { _, err := collections.Exists(ctx, entities.NewCollectionID()); return err },
+		"container GetByID": func() error { _, err := containers.GetByID(ctx, entities.NewContainerID()); return err },
+		"container Exists":  func() error { _, err := containers.Exists(ctx, entities.NewContainerID()); return err },
+		"container FindByObjectID": func() error {
+			_, err := containers.FindByObjectID(ctx, entities.NewObjectID())
+			return err
+		},
+	}
+	for name, lookup := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var err error
+			require.NotPanics(t, func() { err = lookup() })
+			assert.ErrorContains(t, err, "127.0.0.1:1")
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type sessionDocument struct {
+	ID         string     `bson:"_id"`
+	UserID     string     `bson:"user_id"`
+	Device     string     `bson:"device"`
+	IPAddress  string     `bson:"ip_address"`
+	Trusted    bool       `bson:"trusted"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	LastSeenAt time.Time  `bson:"last_seen_at"`
+	ExpiresAt  time.Time  `bson:"expires_at"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty"`
+}
+
+type MongoSessionRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoSessionRepository(db *adapters.MongoDatabase) repositories.SessionRepository {
+	return &MongoSessionRepository{
+		db:         db,
+		collection: db.Database().Collection("sessions"),
+	}
+}
+
+func (r *MongoSessionRepository) GetByID(ctx context.Context, id string) (*entities.Session, error) {
+	var doc sessionDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return documentToSession(&doc)
+}
+
+func (r *MongoSessionRepository) Save(ctx context.Context, session *entities.Session) error {
+	doc := sessionToDocument(session)
+
+	filter := bson.M{"_id": doc.ID}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoSessionRepository) ListByUserID(ctx context.Context, userID entities.UserID) ([]*entities.Session, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID.String()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []*entities.Session
+	for cursor.Next(ctx) {
+		var doc sessionDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode session: %w", err)
+		}
+
+		session, err := documentToSession(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert session: %w", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *MongoSessionRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func sessionToDocument(s *entities.Session) *sessionDocument {
+	return &sessionDocument{
+		ID:         s.ID(),
+		UserID:     s.UserID().String(),
+		Device:     s.Device(),
+		IPAddress:  s.IPAddress(),
+		Trusted:    s.IsTrusted(),
+		CreatedAt:  s.CreatedAt(),
+		LastSeenAt: s.LastSeenAt(),
+		ExpiresAt:  s.ExpiresAt(),
+		RevokedAt:  s.RevokedAt(),
+	}
+}
+
+func documentToSession(doc *sessionDocument) (*entities.Session, error) {
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructSession(doc.ID, userID, doc.Device, doc.IPAddress, doc.Trusted, doc.CreatedAt, doc.LastSeenAt, doc.ExpiresAt, doc.RevokedAt), nil
+}
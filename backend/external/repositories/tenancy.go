@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+)
+
+// tenantCollectionFilter narrows a filter on the collections collection to
+// the documents the context's tenant may see: those it owns personally and
+// those shared with one of its groups. Without a tenant filter is returned
+// as is.
+func tenantCollectionFilter(ctx context.Context, filter bson.M) (bson.M, error) {
+	tenant := repositories.TenantFromContext(ctx)
+	if tenant == nil {
+		return filter, nil
+	}
+
+	access := bson.A{ownedByUserFilter(tenant.UserID)}
+	groupIDs, err := tenant.GroupIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant groups: %w", err)
+	}
+	if len(groupIDs) > 0 {
+		ids := make([]string, len(groupIDs))
+		for i, id := range groupIDs {
+			ids[i] = id.String()
+		}
+		access = append(access, bson.M{"group_id": bson.M{"$in": ids}})
+	}
+	return bson.M{"$and": bson.A{filter, bson.M{"$or": access}}}, nil
+}
+
+// tenantContainerFilter narrows a filter on the containers collection to
+// those in collections the context's tenant may see
+func tenantContainerFilter(ctx context.Context, collections *mongo.Collection, filter bson.M) (bson.M, error) {
+	if repositories.TenantFromContext(ctx) == nil {
+		return filter, nil
+	}
+
+	collectionFilter, err := tenantCollectionFilter(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	if err := collections.Distinct(ctx, "_id", collectionFilter).Decode(&ids); err != nil {
+		return nil, fmt.Errorf("failed to get tenant collections: %w", err)
+	}
+	return bson.M{"$and": bson.A{filter, bson.M{"collection_id": bson.M{"$in": ids}}}}, nil
+}
+
+// checkTenantCollection refuses to write a collection the context's tenant
+// could not read back
+func checkTenantCollection(ctx context.Context, collection *entities.Collection) error {
+	tenant := repositories.TenantFromContext(ctx)
+	if tenant == nil {
+		return nil
+	}
+	ok, err := tenant.CanAccess(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("failed to check tenant access: %w", err)
+	}
+	if !ok {
+		return repositories.ErrOutsideTenant
+	}
+	return nil
+}
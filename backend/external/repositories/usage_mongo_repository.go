@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+// MongoUsageRepository totals usage with aggregations over the collections,
+// containers and media collections. It reads only and keeps no state.
+type MongoUsageRepository struct {
+	db          *adapters.MongoDatabase
+	collections *mongo.Collection
+	containers  *mongo.Collection
+	media       *mongo.Collection
+}
+
+func NewMongoUsageRepository(db *adapters.MongoDatabase) repositories.UsageRepository {
+	return &MongoUsageRepository{
+		db:          db,
+		collections: db.Database().Collection("collections"),
+		containers:  db.Database().Collection("containers"),
+		media:       db.Database().Collection("media"),
+	}
+}
+
+// usageRow is one user's totals from any of the three aggregations
+type usageRow struct {
+	UserID      string `bson:"_id"`
+	Collections int    `bson:"collections"`
+	Containers  int    `bson:"containers"`
+	Objects     int    `bson:"objects"`
+	MediaCount  int    `bson:"media_count"`
+	MediaBytes  int64  `bson:"media_bytes"`
+}
+
+func (r *MongoUsageRepository) UsageByUser(ctx context.Context) (map[string]entities.UserUsage, error) {
+	usage := make(map[string]entities.UserUsage)
+	add := func(row usageRow) {
+		u := usage[row.UserID]
+		u.UserID, _ = entities.UserIDFromString(row.UserID)
+		u.Collections += row.Collections
+		u.Containers += row.Containers
+		u.Objects += row.Objects
+		u.MediaCount += row.MediaCount
+		u.MediaBytes += row.MediaBytes
+		usage[row.UserID] = u
+	}
+
+	collectionsPipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$user_id", "collections": bson.M{"$sum": 1}}}},
+	}
+	if err := r.aggregate(ctx, r.collections, collectionsPipeline, add); err != nil {
+		return nil, fmt.Errorf("failed to count collections: %w", err)
+	}
+
+	containersPipeline := mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "collections",
+			"localField":   "collection_id",
+			"foreignField": "_id",
+			"as":           "_collection",
+		}}},
+		{{Key: "$unwind", Value: "$_collection"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$_collection.user_id",
+			"containers": bson.M{"$sum": 1},
+			"objects":    bson.M{"$sum": bson.M{"$size": bson.M{"$ifNull": bson.A{"$objects", bson.A{}}}}},
+		}}},
+	}
+	if err := r.aggregate(ctx, r.containers, containersPipeline, add); err != nil {
+		return nil, fmt.Errorf("failed to count containers: %w", err)
+	}
+
+	mediaPipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$uploaded_by",
+			"media_count": bson.M{"$sum": 1},
+			"media_bytes": bson.M{"$sum": "$size"},
+		}}},
+	}
+	if err := r.aggregate(ctx, r.media, mediaPipeline, add); err != nil {
+		return nil, fmt.Errorf("failed to total media: %w", err)
+	}
+
+	delete(usage, "")
+	return usage, nil
+}
+
+func (r *MongoUsageRepository) UsageByGroup(ctx context.Context, groupID entities.GroupID) (entities.GroupUsage, error) {
+	var usage entities.GroupUsage
+	add := func(row usageRow) {
+		usage.Containers += row.Containers
+		usage.Objects += row.Objects
+		usage.StorageBytes += row.MediaBytes
+	}
+
+	containersPipeline := append(inGroupStages(groupID),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":        nil,
+			"containers": bson.M{"$sum": 1},
+			"objects":    bson.M{"$sum": bson.M{"$size": bson.M{"$ifNull": bson.A{"$objects", bson.A{}}}}},
+		}}},
+	)
+	if err := r.aggregate(ctx, r.containers, containersPipeline, add); err != nil {
+		return entities.GroupUsage{}, fmt.Errorf("failed to count group containers: %w", err)
+	}
+
+	mediaPipeline := append(inGroupStages(groupID),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":         nil,
+			"media_count": bson.M{"$sum": 1},
+			"media_bytes": bson.M{"$sum": "$size"},
+		}}},
+	)
+	if err := r.aggregate(ctx, r.media, mediaPipeline, add); err != nil {
+		return entities.GroupUsage{}, fmt.Errorf("failed to total group media: %w", err)
+	}
+
+	return usage, nil
+}
+
+// inGroupStages keeps the containers or media whose collection is shared
+// with groupID. They join to the collection to find its group, so anything
+// counts wherever its collection is shared now.
+func inGroupStages(groupID entities.GroupID) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "collections",
+			"localField":   "collection_id",
+			"foreignField": "_id",
+			"as":           "_collection",
+		}}},
+		{{Key: "$match", Value: bson.M{"_collection.group_id": groupID.String()}}},
+	}
+}
+
+func (r *MongoUsageRepository) aggregate(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline, add func(usageRow)) error {
+	cursor, err := readCollection(ctx, r.db, collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row usageRow
+		if err := cursor.Decode(&row); err != nil {
+			return fmt.Errorf("failed to decode usage: %w", err)
+		}
+		add(row)
+	}
+
+	return cursor.Err()
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type viewSortDocument struct {
+	Field     string `bson:"field"`
+	Direction string `bson:"direction"`
+}
+
+type viewPreferenceDocument struct {
+	Sort    []viewSortDocument `bson:"sort,omitempty"`
+	GroupBy string             `bson:"group_by,omitempty"`
+	Filters map[string]string  `bson:"filters,omitempty"`
+}
+
+type itemOrderDocument struct {
+	SortIndex int  `bson:"sort_index,omitempty"`
+	Pinned    bool `bson:"pinned,omitempty"`
+}
+
+type userPreferencesDocument struct {
+	UserID          string                            `bson:"_id"`
+	Views           map[string]viewPreferenceDocument `bson:"views"`
+	CollectionOrder map[string]itemOrderDocument      `bson:"collection_order,omitempty"`
+	ContainerOrder  map[string]itemOrderDocument      `bson:"container_order,omitempty"`
+	LabelTemplate   string                            `bson:"label_template,omitempty"`
+	UnitSystem      string                            `bson:"unit_system,omitempty"`
+	CreatedAt       time.Time                         `bson:"created_at"`
+	UpdatedAt       time.Time                         `bson:"updated_at"`
+}
+
+type MongoUserPreferencesRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoUserPreferencesRepository(db *adapters.MongoDatabase) repositories.UserPreferencesRepository {
+	return &MongoUserPreferencesRepository{
+		db:         db,
+		collection: db.Database().Collection("user_preferences"),
+	}
+}
+
+func (r *MongoUserPreferencesRepository) GetByUserID(ctx context.Context, userID entities.UserID) (*entities.UserPreferences, error) {
+	var doc userPreferencesDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrUserPreferencesNotFound
+		}
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+
+	return documentToUserPreferences(&doc)
+}
+
+func (r *MongoUserPreferencesRepository) Save(ctx context.Context, preferences *entities.UserPreferences) error {
+	doc := userPreferencesToDocument(preferences)
+
+	filter := bson.M{"_id": doc.UserID}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save user preferences: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoUserPreferencesRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID.String()}); err != nil {
+		return fmt.Errorf("failed to delete user preferences: %w", err)
+	}
+
+	return nil
+}
+
+func userPreferencesToDocument(p *entities.UserPreferences) *userPreferencesDocument {
+	views := make(map[string]viewPreferenceDocument, len(p.Views()))
+	for key, view := range p.Views() {
+		sorts := make([]viewSortDocument, len(view.Sort))
+		for i, s := range view.Sort {
+			sorts[i] = viewSortDocument{Field: s.Field, Direction: s.Direction}
+		}
+		views[key] = viewPreferenceDocument{
+			Sort:    sorts,
+			GroupBy: view.GroupBy,
+			Filters: view.Filters,
+		}
+	}
+
+	return &userPreferencesDocument{
+		UserID:          p.UserID().String(),
+		Views:           views,
+		CollectionOrder: itemOrderToDocuments(p.Order(entities.OrderScopeCollections)),
+		ContainerOrder:  itemOrderToDocuments(p.Order(entities.OrderScopeContainers)),
+		LabelTemplate:   p.LabelTemplate(),
+		UnitSystem:      string(p.UnitSystem()),
+		CreatedAt:       p.CreatedAt(),
+		UpdatedAt:       p.UpdatedAt(),
+	}
+}
+
+func itemOrderToDocuments(orders map[string]entities.ItemOrder) map[string]itemOrderDocument {
+	docs := make(map[string]itemOrderDocument, len(orders))
+	for id, o := range orders {
+		docs[id] = itemOrderDocument{SortIndex: o.SortIndex, Pinned: o.Pinned}
+	}
+	return docs
+}
+
+func documentsToItemOrder(docs map[string]itemOrderDocument) map[string]entities.ItemOrder {
+	orders := make(map[string]entities.ItemOrder, len(docs))
+	for id, d := range docs {
+		orders[id] = entities.ItemOrder{SortIndex: d.SortIndex, Pinned: d.Pinned}
+	}
+	return orders
+}
+
+func documentToUserPreferences(doc *userPreferencesDocument) (*entities.UserPreferences, error) {
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	views := make(map[string]entities.ViewPreference, len(doc.Views))
+	for key, view := range doc.Views {
+		sorts := make([]entities.ViewSort, len(view.Sort))
+		for i, s := range view.Sort {
+			sorts[i] = entities.ViewSort{Field: s.Field, Direction: s.Direction}
+		}
+		views[key] = entities.ViewPreference{
+			Sort:    sorts,
+			GroupBy: view.GroupBy,
+			Filters: view.Filters,
+		}
+	}
+
+	return entities.ReconstructUserPreferences(userID, views,
+		documentsToItemOrder(doc.CollectionOrder), documentsToItemOrder(doc.ContainerOrder),
+		doc.LabelTemplate, entities.UnitSystem(doc.UnitSystem), doc.CreatedAt, doc.UpdatedAt), nil
+}
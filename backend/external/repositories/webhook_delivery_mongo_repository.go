@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type webhookDeliveryDocument struct {
+	ID            string     `bson:"_id"`
+	UserID        string     `bson:"user_id"`
+	RuleID        string     `bson:"rule_id"`
+	RuleName      string     `bson:"rule_name"`
+	URL           string     `bson:"url"`
+	Event         string     `bson:"event"`
+	Payload       string     `bson:"payload"`
+	MaxRetries    int        `bson:"max_retries,omitempty"`
+	RetryBackoff  int        `bson:"retry_backoff,omitempty"`
+	Status        string     `bson:"status"`
+	Attempts      int        `bson:"attempts"`
+	LastError     string     `bson:"last_error,omitempty"`
+	LastAttemptAt *time.Time `bson:"last_attempt_at,omitempty"`
+	NextAttemptAt *time.Time `bson:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at"`
+}
+
+type MongoWebhookDeliveryRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoWebhookDeliveryRepository(db *adapters.MongoDatabase) repositories.WebhookDeliveryRepository {
+	return &MongoWebhookDeliveryRepository{
+		db:         db,
+		collection: db.Database().Collection("webhook_deliveries"),
+	}
+}
+
+func (r *MongoWebhookDeliveryRepository) Create(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	if _, err := r.collection.InsertOne(ctx, webhookDeliveryToDocument(delivery)); err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoWebhookDeliveryRepository) GetByID(ctx context.Context, id entities.WebhookDeliveryID) (*entities.WebhookDelivery, error) {
+	var doc webhookDeliveryDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return documentToWebhookDelivery(&doc)
+}
+
+func (r *MongoWebhookDeliveryRepository) ListByUserID(ctx context.Context, userID entities.UserID, limit int) ([]*entities.WebhookDelivery, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+	return r.find(ctx, bson.M{"user_id": userID.String()}, opts)
+}
+
+func (r *MongoWebhookDeliveryRepository) ListDue(ctx context.Context, t time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          string(entities.WebhookPending),
+		"next_attempt_at": bson.M{"$lte": t},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).SetLimit(int64(limit))
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoWebhookDeliveryRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]*entities.WebhookDelivery, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*entities.WebhookDelivery
+	for cursor.Next(ctx) {
+		var doc webhookDeliveryDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook delivery: %w", err)
+		}
+
+		delivery, err := documentToWebhookDelivery(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *MongoWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	doc := webhookDeliveryToDocument(delivery)
+	// Only the outcome of attempts changes; a null next_attempt_at is never due
+	update := bson.M{"$set": bson.M{
+		"status":          doc.Status,
+		"attempts":        doc.Attempts,
+		"last_error":      doc.LastError,
+		"last_attempt_at": doc.LastAttemptAt,
+		"next_attempt_at": doc.NextAttemptAt,
+	}}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entities.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (r *MongoWebhookDeliveryRepository) DeleteCreatedBefore(ctx context.Context, t time.Time) (int64, error) {
+	filter := bson.M{
+		"created_at": bson.M{"$lt": t},
+		"status":     bson.M{"$ne": string(entities.WebhookPending)},
+	}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune webhook deliveries: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (r *MongoWebhookDeliveryRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID.String()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries by user ID: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func webhookDeliveryToDocument(d *entities.WebhookDelivery) *webhookDeliveryDocument {
+	return &webhookDeliveryDocument{
+		ID:            d.ID().String(),
+		UserID:        d.UserID().String(),
+		RuleID:        d.RuleID().String(),
+		RuleName:      d.RuleName(),
+		URL:           d.URL(),
+		Event:         d.Event(),
+		Payload:       string(d.Payload()),
+		MaxRetries:    d.Retry().MaxRetries,
+		RetryBackoff:  d.Retry().Backoff,
+		Status:        string(d.Status()),
+		Attempts:      d.Attempts(),
+		LastError:     d.LastError(),
+		LastAttemptAt: d.LastAttemptAt(),
+		NextAttemptAt: d.NextAttemptAt(),
+		CreatedAt:     d.CreatedAt(),
+	}
+}
+
+func documentToWebhookDelivery(doc *webhookDeliveryDocument) (*entities.WebhookDelivery, error) {
+	id, err := entities.WebhookDeliveryIDFromString(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	ruleID, err := entities.AutomationRuleIDFromString(doc.RuleID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid automation rule ID: %w", err)
+	}
+
+	props := entities.WebhookDeliveryProps{
+		RuleID:   ruleID,
+		RuleName: doc.RuleName,
+		URL:      doc.URL,
+		Event:    doc.Event,
+		Payload:  []byte(doc.Payload),
+		Retry:    entities.WebhookRetryPolicy{MaxRetries: doc.MaxRetries, Backoff: doc.RetryBackoff},
+	}
+	return entities.ReconstructWebhookDelivery(id, userID, props, entities.WebhookDeliveryStatus(doc.Status),
+		doc.Attempts, doc.LastError, doc.LastAttemptAt, doc.NextAttemptAt, doc.CreatedAt), nil
+}
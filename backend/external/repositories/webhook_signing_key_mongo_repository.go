@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/external/adapters"
+)
+
+type webhookSigningKeysDocument struct {
+	UserID            string     `bson:"_id"`
+	Current           string     `bson:"current"`
+	CurrentCreatedAt  time.Time  `bson:"current_created_at"`
+	Previous          string     `bson:"previous,omitempty"`
+	PreviousExpiresAt *time.Time `bson:"previous_expires_at,omitempty"`
+}
+
+type MongoWebhookSigningKeyRepository struct {
+	db         *adapters.MongoDatabase
+	collection *mongo.Collection
+}
+
+func NewMongoWebhookSigningKeyRepository(db *adapters.MongoDatabase) repositories.WebhookSigningKeyRepository {
+	return &MongoWebhookSigningKeyRepository{
+		db:         db,
+		collection: db.Database().Collection("webhook_signing_keys"),
+	}
+}
+
+func (r *MongoWebhookSigningKeyRepository) GetByUserID(ctx context.Context, userID entities.UserID) (*entities.WebhookSigningKeys, error) {
+	var doc webhookSigningKeysDocument
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID.String()}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrWebhookSigningKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook signing keys: %w", err)
+	}
+
+	userID, err = entities.UserIDFromString(doc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entities.ReconstructWebhookSigningKeys(userID, doc.Current, doc.CurrentCreatedAt, doc.Previous, doc.PreviousExpiresAt), nil
+}
+
+func (r *MongoWebhookSigningKeyRepository) Save(ctx context.Context, keys *entities.WebhookSigningKeys) error {
+	doc := &webhookSigningKeysDocument{
+		UserID:            keys.UserID().String(),
+		Current:           keys.Current(),
+		CurrentCreatedAt:  keys.CurrentCreatedAt(),
+		Previous:          keys.Previous(),
+		PreviousExpiresAt: keys.PreviousExpiresAt(),
+	}
+
+	filter := bson.M{"_id": doc.UserID}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save webhook signing keys: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MongoWebhookSigningKeyRepository) DeleteByUserID(ctx context.Context, userID entities.UserID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID.String()}); err != nil {
+		return fmt.Errorf("failed to delete webhook signing keys: %w", err)
+	}
+
+	return nil
+}
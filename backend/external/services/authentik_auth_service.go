@@ -2,23 +2,18 @@ package services
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json/v2"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
-	"net"
+	"maps"
 	"net/http"
-	"net/url"
-	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/coreos/go-oidc/v3/oidc"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/cast"
 	"goauthentik.io/api/v3"
 
@@ -38,21 +33,30 @@ type AuthentikValidationError struct {
 	Code           string   `json:"code"`
 }
 
-type clientProvider struct {
-	config   config.OAuthClient
-	provider *oidc.Provider
-	verifier *oidc.IDTokenVerifier
-}
-
 type groupCacheEntry struct {
 	groups    []*entities.Group
 	expiresAt time.Time
 }
 
-type AuthentikAuthService struct {
+// NewAuthentikAuthService connects to Authentik at the first reachable
+// config.AuthentikURLs entry. Each client is an Authentik application whose
+// issuer is <base>/application/o/<provider_name>/.
+func NewAuthentikAuthService(cfg config.AuthConfig, groups services.GroupDirectory, logger *slog.Logger) (*OIDCAuthService, error) {
+	return newOIDCAuthService(cfg, issuerLayout{
+		name:       "authentik",
+		candidates: cfg.AuthentikURLs,
+		issuerURL: func(base string, client config.OAuthClient) string {
+			return fmt.Sprintf("%s/application/o/%s/", base, client.ProviderName)
+		},
+		unreachable: ErrAuthentikUnreachable,
+	}, groups, logger)
+}
+
+// AuthentikGroupDirectory keeps groups in Authentik, managed through its API
+// with the configured API token. Only groups carrying the nishiki role count.
+type AuthentikGroupDirectory struct {
 	config       config.AuthConfig
-	authentikURL string // resolved base URL selected at startup from config.AuthentikURLs
-	clients      map[string]*clientProvider // client_id -> provider/verifier
+	authentikURL string
 	logger       *slog.Logger
 	httpClient   *http.Client
 	apiConfig    *api.Configuration
@@ -60,360 +64,25 @@ type AuthentikAuthService struct {
 	groupCacheMu sync.RWMutex
 }
 
-// IssuerBaseURL returns the Authentik base URL that was selected from
-// config.AuthentikURLs at startup. Callers (e.g. MCP OAuth discovery) use
-// this to advertise an issuer that is known-reachable from this backend.
-func (s *AuthentikAuthService) IssuerBaseURL() string {
-	return s.authentikURL
-}
-
-// classifyNetworkError returns a short tag describing the failure mode of
-// err, suitable for structured logging. Useful for telling DNS, TLS, and
-// connection failures apart when a probe fails.
-func classifyNetworkError(err error) string {
-	if err == nil {
-		return ""
-	}
-	var dnsErr *net.DNSError
-	if errors.As(err, &dnsErr) {
-		return "dns"
-	}
-	var certErr *tls.CertificateVerificationError
-	if errors.As(err, &certErr) {
-		return "tls_cert_verify"
-	}
-	var hostnameErr x509.HostnameError
-	if errors.As(err, &hostnameErr) {
-		return "tls_hostname"
-	}
-	var unknownAuthErr x509.UnknownAuthorityError
-	if errors.As(err, &unknownAuthErr) {
-		return "tls_unknown_authority"
-	}
-	var urlErr *url.Error
-	if errors.As(err, &urlErr) {
-		if strings.Contains(urlErr.Err.Error(), "tls") || strings.Contains(urlErr.Err.Error(), "x509") {
-			return "tls"
-		}
-	}
-	var netOpErr *net.OpError
-	if errors.As(err, &netOpErr) {
-		return "network_" + netOpErr.Op
-	}
-	if errors.Is(err, context.DeadlineExceeded) {
-		return "timeout"
-	}
-	return "other"
-}
-
-func NewAuthentikAuthService(config config.AuthConfig, logger *slog.Logger) (*AuthentikAuthService, error) {
-	ctx := context.Background()
-
-	// Create HTTP client with optional self-signed certificate support
-	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	if config.AllowSelfSigned {
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		}
-		logger.Warn("Self-signed certificates are enabled - this should only be used in development")
-	}
-
-	ctx = oidc.ClientContext(ctx, httpClient)
-
-	if len(config.Clients) == 0 {
-		return nil, ErrAuthConfigInvalid.With(map[string]any{"reason": "no oauth clients configured"})
-	}
-	if len(config.AuthentikURLs) == 0 {
-		return nil, ErrAuthConfigInvalid.With(map[string]any{"reason": "no authentik urls configured"})
-	}
-
-	// Probe each configured URL in order. First one that successfully yields an
-	// OIDC discovery document for the first client's provider wins. Probe
-	// errors are classified (dns/tls/etc.) to make the logs useful when a URL
-	// is unreachable or serving a bad cert.
-	firstClient := config.Clients[0]
-	type probeAttempt struct {
-		Index    int    `json:"index"`
-		URL      string `json:"url"`
-		Category string `json:"category"`
-		Error    string `json:"error"`
-	}
-	var (
-		resolvedURL   string
-		firstProvider *oidc.Provider
-		attempts      []probeAttempt
-		lastErr       error
-	)
-	logger.Info("Probing authentik URLs",
-		slog.Int("count", len(config.AuthentikURLs)),
-		slog.String("first_provider", firstClient.ProviderName))
-	for i, baseURL := range config.AuthentikURLs {
-		baseURL = strings.TrimRight(baseURL, "/")
-		providerURL := fmt.Sprintf("%s/application/o/%s/", baseURL, firstClient.ProviderName)
-		attemptStart := time.Now()
-		logger.Info("Probing authentik URL",
-			slog.Int("index", i),
-			slog.String("url", baseURL),
-			slog.String("provider_url", providerURL))
-		provider, err := oidc.NewProvider(ctx, providerURL)
-		if err != nil {
-			category := classifyNetworkError(err)
-			logger.Warn("Authentik URL probe failed",
-				slog.Int("index", i),
-				slog.String("url", baseURL),
-				slog.String("error_category", category),
-				slog.Duration("elapsed", time.Since(attemptStart)),
-				slog.String("error", err.Error()))
-			attempts = append(attempts, probeAttempt{
-				Index:    i,
-				URL:      baseURL,
-				Category: category,
-				Error:    err.Error(),
-			})
-			lastErr = err
-			continue
-		}
-		logger.Info("Authentik URL probe succeeded",
-			slog.Int("index", i),
-			slog.String("url", baseURL),
-			slog.Duration("elapsed", time.Since(attemptStart)))
-		resolvedURL = baseURL
-		firstProvider = provider
-		break
-	}
-	if resolvedURL == "" {
-		return nil, ErrAuthentikUnreachable.With(map[string]any{
-			"attempts":       attempts,
-			"candidate_urls": config.AuthentikURLs,
-		}).Wrap(lastErr)
-	}
-
-	// Initialize all OAuth clients against the resolved base URL. The first
-	// client reuses the provider we already fetched during probing.
-	clients := make(map[string]*clientProvider)
-	for idx, clientConfig := range config.Clients {
-		providerURL := fmt.Sprintf("%s/application/o/%s/", resolvedURL, clientConfig.ProviderName)
-		logger.Info("Creating OIDC provider",
-			slog.String("provider_name", clientConfig.ProviderName),
-			slog.String("provider_url", providerURL))
-
-		var (
-			provider *oidc.Provider
-			err      error
-		)
-		if idx == 0 {
-			provider = firstProvider
-		} else {
-			provider, err = oidc.NewProvider(ctx, providerURL)
-			if err != nil {
-				return nil, ErrOIDCProviderInit.With(map[string]any{
-					"provider_name": clientConfig.ProviderName,
-					"provider_url":  providerURL,
-					"resolved_url":  resolvedURL,
-				}).Wrap(err)
-			}
-		}
-
-		// Create ID token verifier
-		verifier := provider.Verifier(&oidc.Config{
-			ClientID: clientConfig.ClientID,
-		})
-
-		clients[clientConfig.ClientID] = &clientProvider{
-			config:   clientConfig,
-			provider: provider,
-			verifier: verifier,
-		}
-
-		logger.Info("OAuth client initialized successfully",
-			slog.String("provider_name", clientConfig.ProviderName),
-			slog.String("client_id", clientConfig.ClientID))
-	}
-
-	// Create Authentik API configuration against the resolved URL.
+// NewAuthentikGroupDirectory talks to the Authentik API at authentikURL.
+func NewAuthentikGroupDirectory(cfg config.AuthConfig, authentikURL string, httpClient *http.Client, logger *slog.Logger) *AuthentikGroupDirectory {
 	apiConfig := api.NewConfiguration()
-	apiConfig.Host = strings.TrimPrefix(resolvedURL, "https://")
+	apiConfig.Host = strings.TrimPrefix(authentikURL, "https://")
 	apiConfig.Host = strings.TrimPrefix(apiConfig.Host, "http://")
 	apiConfig.Scheme = "https"
-	if strings.HasPrefix(resolvedURL, "http://") {
+	if strings.HasPrefix(authentikURL, "http://") {
 		apiConfig.Scheme = "http"
 	}
 	apiConfig.HTTPClient = httpClient
 
-	logger.Info("Authentik auth service initialized",
-		slog.String("resolved_url", resolvedURL),
-		slog.Int("candidate_count", len(config.AuthentikURLs)),
-		slog.Int("failed_candidates", len(attempts)))
-
-	return &AuthentikAuthService{
-		config:       config,
-		authentikURL: resolvedURL,
-		clients:      clients,
+	return &AuthentikGroupDirectory{
+		config:       cfg,
+		authentikURL: authentikURL,
 		logger:       logger,
 		httpClient:   httpClient,
 		apiConfig:    apiConfig,
 		groupCache:   make(map[string]*groupCacheEntry),
-	}, nil
-}
-
-func (s *AuthentikAuthService) ValidateToken(ctx context.Context, tokenString string) (*services.AuthClaims, error) {
-	// Remove "Bearer " prefix if present
-	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-
-	// Try to verify token with each client until one succeeds
-	var lastErr error
-	for clientID, client := range s.clients {
-		idToken, err := client.verifier.Verify(ctx, tokenString)
-		if err != nil {
-			lastErr = err
-			continue
-		}
-
-		// Extract claims
-		var claims services.AuthClaims
-		if err := idToken.Claims(&claims); err != nil {
-			s.logger.Error("Failed to extract claims", slog.Any("error", err))
-			lastErr = err
-			continue
-		}
-
-		// Validate token expiration
-		if time.Now().Unix() > claims.ExpiresAt {
-			s.logger.Warn("Token has expired", slog.Int64("exp", claims.ExpiresAt))
-			lastErr = errors.New("token has expired")
-			continue
-		}
-
-		s.logger.Debug("Token validated successfully",
-			slog.String("client_id", clientID),
-			slog.String("subject", claims.Subject),
-			slog.String("username", claims.Username),
-			slog.String("email", claims.Email))
-
-		return &claims, nil
-	}
-
-	s.logger.Error("Token verification failed for all clients", slog.Any("error", lastErr))
-	return nil, fmt.Errorf("token verification failed: %w", lastErr)
-}
-
-// getClientByRedirectURL finds the appropriate OAuth client based on the redirect_uri
-func (s *AuthentikAuthService) getClientByRedirectURL(redirectURI string) (*clientProvider, error) {
-	if redirectURI == "" {
-		return nil, errors.New("redirect_uri is required")
-	}
-
-	// Try exact match first
-	for _, client := range s.clients {
-		if client.config.RedirectURL == redirectURI {
-			s.logger.Debug("Matched client by redirect_uri (exact)",
-				slog.String("redirect_uri", redirectURI),
-				slog.String("provider_name", client.config.ProviderName))
-			return client, nil
-		}
-	}
-
-	// Try matching by origin if exact match fails
-	requestURL, err := url.Parse(redirectURI)
-	if err != nil {
-		return nil, fmt.Errorf("invalid redirect_uri: %w", err)
-	}
-	requestOrigin := fmt.Sprintf("%s://%s", requestURL.Scheme, requestURL.Host)
-
-	for _, client := range s.clients {
-		configURL, err := url.Parse(client.config.RedirectURL)
-		if err != nil {
-			s.logger.Warn("Invalid redirect URL for client",
-				slog.String("provider_name", client.config.ProviderName),
-				slog.String("redirect_url", client.config.RedirectURL))
-			continue
-		}
-
-		configOrigin := fmt.Sprintf("%s://%s", configURL.Scheme, configURL.Host)
-
-		if requestOrigin == configOrigin {
-			s.logger.Debug("Matched client by origin",
-				slog.String("origin", requestOrigin),
-				slog.String("provider_name", client.config.ProviderName))
-			return client, nil
-		}
-	}
-
-	return nil, fmt.Errorf("no OAuth client configured for redirect_uri: %s", redirectURI)
-}
-
-// getClientByClientID finds the appropriate OAuth client based on client_id
-func (s *AuthentikAuthService) getClientByClientID(clientID string) (*clientProvider, error) {
-	if clientID == "" {
-		return nil, errors.New("client_id is required")
-	}
-
-	client, ok := s.clients[clientID]
-	if !ok {
-		return nil, fmt.Errorf("no OAuth client configured for client_id: %s", clientID)
-	}
-
-	s.logger.Debug("Matched client by client_id",
-		slog.String("client_id", clientID),
-		slog.String("provider_name", client.config.ProviderName))
-
-	return client, nil
-}
-
-func (s *AuthentikAuthService) GetUserFromClaims(ctx context.Context, claims *services.AuthClaims) (*entities.User, error) {
-	// Create user entity from Authentik claims
-	return s.createUserFromClaims(claims)
-}
-
-func (s *AuthentikAuthService) CreateUserFromClaims(ctx context.Context, claims *services.AuthClaims) (*entities.User, error) {
-	// Create user entity from Authentik claims
-	return s.createUserFromClaims(claims)
-}
-
-func (s *AuthentikAuthService) createUserFromClaims(claims *services.AuthClaims) (*entities.User, error) {
-	// Create username
-	username, err := entities.NewUsername(claims.Username)
-	if err != nil {
-		return nil, fmt.Errorf("invalid username in claims: %w", err)
-	}
-
-	// Create email address
-	email, err := entities.NewEmailAddress(claims.Email)
-	if err != nil {
-		return nil, fmt.Errorf("invalid email in claims: %w", err)
-	}
-
-	// Create user ID from Authentik subject
-	userID, err := entities.UserIDFromString(claims.Subject)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID in claims: %w", err)
 	}
-
-	// Create user entity (using ReconstructUser since we have all the data)
-	user := entities.ReconstructUser(userID, username, email, claims.Subject, time.Now(), time.Now())
-
-	return user, nil
-}
-
-// ParseTokenClaims parses JWT token without verification (for debugging)
-func (s *AuthentikAuthService) ParseTokenClaims(tokenString string) (*jwt.MapClaims, error) {
-	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-
-	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
-	}
-
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		return &claims, nil
-	}
-
-	return nil, errors.New("invalid token claims")
 }
 
 // Authentik API response structures
@@ -448,7 +117,7 @@ type AuthentikUsersResponse struct {
 
 // GetUserGroups fetches groups the user is a member of using JWT token claims and Authentik API with API token.
 // Results are cached per user for 60 seconds to eliminate repeated API calls on hot paths.
-func (s *AuthentikAuthService) GetUserGroups(ctx context.Context, userToken, userID string) ([]*entities.Group, error) {
+func (s *AuthentikGroupDirectory) GetUserGroups(ctx context.Context, userToken, userID string) ([]*entities.Group, error) {
 	s.logger.Debug("Extracting user groups from JWT token",
 		slog.String("user_id", userID))
 
@@ -462,7 +131,7 @@ func (s *AuthentikAuthService) GetUserGroups(ctx context.Context, userToken, use
 	s.groupCacheMu.RUnlock()
 
 	// Parse token without validation (already validated by auth middleware)
-	rawClaims, err := s.ParseTokenClaims(userToken)
+	rawClaims, err := parseTokenClaims(userToken)
 	if err != nil {
 		s.logger.Error("Failed to parse token claims", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to parse token claims: %w", err)
@@ -470,7 +139,7 @@ func (s *AuthentikAuthService) GetUserGroups(ctx context.Context, userToken, use
 
 	// Extract groups from claims
 	var groupNames []string
-	if groupsRaw, ok := (*rawClaims)["groups"]; ok {
+	if groupsRaw, ok := rawClaims["groups"]; ok {
 		if groupsSlice, ok := groupsRaw.([]any); ok {
 			for _, g := range groupsSlice {
 				if groupName, ok := g.(string); ok {
@@ -547,7 +216,7 @@ func (s *AuthentikAuthService) GetUserGroups(ctx context.Context, userToken, use
 }
 
 // hasNishikiRole checks if a group has the 'nishiki' role (legacy method)
-func (s *AuthentikAuthService) hasNishikiRole(group AuthentikGroup) bool {
+func (s *AuthentikGroupDirectory) hasNishikiRole(group AuthentikGroup) bool {
 	// Check if group has 'nishiki' role in attributes
 	if role, exists := group.Attributes["role"]; exists {
 		if roleStr, ok := role.(string); ok && roleStr == "nishiki" {
@@ -560,7 +229,7 @@ func (s *AuthentikAuthService) hasNishikiRole(group AuthentikGroup) bool {
 }
 
 // HasNishikiRoleFromAPI checks if a group has the 'nishiki' role using API client response
-func (s *AuthentikAuthService) HasNishikiRoleFromAPI(group api.Group) bool {
+func (s *AuthentikGroupDirectory) HasNishikiRoleFromAPI(group api.Group) bool {
 	// Check if group has 'nishiki' role in the roles_obj array
 	if group.RolesObj != nil {
 		for _, role := range group.RolesObj {
@@ -588,7 +257,7 @@ func (s *AuthentikAuthService) HasNishikiRoleFromAPI(group api.Group) bool {
 }
 
 // CreateGroup creates a new group in Authentik with nishiki role using API token
-func (s *AuthentikAuthService) CreateGroup(ctx context.Context, userToken, name string, creatorID string) (*entities.Group, error) {
+func (s *AuthentikGroupDirectory) CreateGroup(ctx context.Context, userToken, name string, creatorID string) (*entities.Group, error) {
 	// Create authenticated API client using configured API token
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
@@ -599,7 +268,8 @@ func (s *AuthentikAuthService) CreateGroup(ctx context.Context, userToken, name
 
 	// Create group request with nishiki role attribute
 	attributes := map[string]any{
-		"role": "nishiki",
+		"role":               "nishiki",
+		groupAdminsAttribute: []string{creatorID},
 	}
 	groupRequest := api.GroupRequest{
 		Name:        name,
@@ -680,7 +350,7 @@ func (s *AuthentikAuthService) CreateGroup(ctx context.Context, userToken, name
 }
 
 // addUserToGroup adds a user to a group in Authentik (legacy method)
-func (s *AuthentikAuthService) addUserToGroup(ctx context.Context, groupID, userID string) error {
+func (s *AuthentikGroupDirectory) addUserToGroup(ctx context.Context, groupID, userID string) error {
 	url := fmt.Sprintf("%s/api/v3/core/groups/%s/add_user/", s.authentikURL, groupID)
 
 	payload := map[string]string{
@@ -718,12 +388,12 @@ func (s *AuthentikAuthService) addUserToGroup(ctx context.Context, groupID, user
 }
 
 // addUserToGroupWithToken adds a user to a group in Authentik using API token (internal use during group creation).
-func (s *AuthentikAuthService) addUserToGroupWithToken(ctx context.Context, groupID, userID string) error {
+func (s *AuthentikGroupDirectory) addUserToGroupWithToken(ctx context.Context, groupID, userID string) error {
 	return s.AddUserToGroup(ctx, "", groupID, userID)
 }
 
 // AddUserToGroup adds a user to a group in Authentik using the configured API token.
-func (s *AuthentikAuthService) AddUserToGroup(ctx context.Context, userToken, groupID, userID string) error {
+func (s *AuthentikGroupDirectory) AddUserToGroup(ctx context.Context, userToken, groupID, userID string) error {
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
 
@@ -731,18 +401,18 @@ func (s *AuthentikAuthService) AddUserToGroup(ctx context.Context, userToken, gr
 		slog.String("group_id", groupID),
 		slog.String("user_id", userID))
 
-	_, err := apiClient.CoreApi.CoreGroupsAddUserCreate(auth, groupID).UserAccountRequest(api.UserAccountRequest{
+	httpResp, err := apiClient.CoreApi.CoreGroupsAddUserCreate(auth, groupID).UserAccountRequest(api.UserAccountRequest{
 		Pk: cast.ToInt32(userID),
 	}).Execute()
 	if err != nil {
 		s.logger.Error("Failed to add user to group", slog.Any("error", err))
-		return fmt.Errorf("failed to add user to group: %w", err)
+		return authentikSyncError("add user to group", httpResp, err)
 	}
 	return nil
 }
 
 // RemoveUserFromGroup removes a user from a group in Authentik using the configured API token.
-func (s *AuthentikAuthService) RemoveUserFromGroup(ctx context.Context, userToken, groupID, userID string) error {
+func (s *AuthentikGroupDirectory) RemoveUserFromGroup(ctx context.Context, userToken, groupID, userID string) error {
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
 
@@ -750,18 +420,142 @@ func (s *AuthentikAuthService) RemoveUserFromGroup(ctx context.Context, userToke
 		slog.String("group_id", groupID),
 		slog.String("user_id", userID))
 
-	_, err := apiClient.CoreApi.CoreGroupsRemoveUserCreate(auth, groupID).UserAccountRequest(api.UserAccountRequest{
+	httpResp, err := apiClient.CoreApi.CoreGroupsRemoveUserCreate(auth, groupID).UserAccountRequest(api.UserAccountRequest{
 		Pk: cast.ToInt32(userID),
 	}).Execute()
 	if err != nil {
 		s.logger.Error("Failed to remove user from group", slog.Any("error", err))
-		return fmt.Errorf("failed to remove user from group: %w", err)
+		return authentikSyncError("remove user from group", httpResp, err)
+	}
+	return nil
+}
+
+// InviteUserByEmail adds the Authentik user registered under email to the
+// group. Authentik has to know the address already; no account is created.
+func (s *AuthentikGroupDirectory) InviteUserByEmail(ctx context.Context, userToken, groupID, email string) (*entities.User, error) {
+	apiClient := api.NewAPIClient(s.apiConfig)
+	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
+
+	s.logger.Debug("Inviting user to group by email",
+		slog.String("group_id", groupID))
+
+	usersResp, httpResp, err := apiClient.CoreApi.CoreUsersList(auth).Email(email).Execute()
+	if err != nil {
+		s.logger.Error("Failed to look up user by email", slog.Any("error", err))
+		return nil, authentikSyncError("look up user", httpResp, err)
+	}
+	if len(usersResp.Results) == 0 {
+		return nil, services.ErrInviteeNotFound
+	}
+
+	userID := cast.ToString(usersResp.Results[0].Pk)
+	if err := s.AddUserToGroup(ctx, userToken, groupID, userID); err != nil {
+		return nil, err
+	}
+	return s.GetUserByID(ctx, userToken, userID)
+}
+
+// GetGroupMemberRoles reads the group's admins from its nishiki_admins
+// attribute.
+func (s *AuthentikGroupDirectory) GetGroupMemberRoles(ctx context.Context, userToken, groupID string) (map[string]entities.GroupRole, error) {
+	apiClient := api.NewAPIClient(s.apiConfig)
+	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
+
+	group, httpResp, err := apiClient.CoreApi.CoreGroupsRetrieve(auth, groupID).Execute()
+	if err != nil {
+		s.logger.Error("Failed to fetch group from Authentik", slog.Any("error", err))
+		return nil, authentikSyncError("fetch group", httpResp, err)
+	}
+
+	roles := make(map[string]entities.GroupRole)
+	for _, id := range groupAdmins(group.Attributes) {
+		roles[id] = entities.GroupRoleAdmin
+	}
+	return roles, nil
+}
+
+// SetGroupMemberRole adds the user to or drops them from the group's
+// nishiki_admins attribute, leaving the other attributes as they are.
+func (s *AuthentikGroupDirectory) SetGroupMemberRole(ctx context.Context, userToken, groupID, userID string, role entities.GroupRole) error {
+	apiClient := api.NewAPIClient(s.apiConfig)
+	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
+
+	s.logger.Debug("Setting group member role",
+		slog.String("group_id", groupID),
+		slog.String("user_id", userID),
+		slog.String("role", role.String()))
+
+	group, httpResp, err := apiClient.CoreApi.CoreGroupsRetrieve(auth, groupID).Execute()
+	if err != nil {
+		s.logger.Error("Failed to fetch group from Authentik", slog.Any("error", err))
+		return authentikSyncError("fetch group", httpResp, err)
+	}
+
+	admins := slices.DeleteFunc(groupAdmins(group.Attributes), func(id string) bool { return id == userID })
+	if role == entities.GroupRoleAdmin {
+		admins = append(admins, userID)
+	}
+	attributes := maps.Clone(group.Attributes)
+	if attributes == nil {
+		attributes = make(map[string]any)
+	}
+	attributes[groupAdminsAttribute] = admins
+
+	_, httpResp, err = apiClient.CoreApi.CoreGroupsPartialUpdate(auth, groupID).PatchedGroupRequest(api.PatchedGroupRequest{
+		Attributes: attributes,
+	}).Execute()
+	if err != nil {
+		s.logger.Error("Failed to update group roles in Authentik", slog.Any("error", err))
+		return authentikSyncError("update group roles", httpResp, err)
 	}
 	return nil
 }
 
+// groupAdminsAttribute is the Authentik group attribute listing the user IDs
+// of the group's admins.
+const groupAdminsAttribute = "nishiki_admins"
+
+func groupAdmins(attributes map[string]any) []string {
+	raw, _ := attributes[groupAdminsAttribute].([]any)
+	admins := make([]string, 0, len(raw))
+	for _, id := range raw {
+		if idStr := cast.ToString(id); idStr != "" {
+			admins = append(admins, idStr)
+		}
+	}
+	return admins
+}
+
+// authentikSyncError wraps a failed Authentik API call in
+// services.ErrGroupSyncFailed with the reason Authentik gave, so it reaches
+// the user instead of only the server log. A 404 is reported as not found.
+func authentikSyncError(op string, httpResp *http.Response, err error) error {
+	var reason string
+	var apiErr *api.GenericOpenAPIError
+	if errors.As(err, &apiErr) {
+		var forbidden AuthentikForbiddenError
+		var validation AuthentikValidationError
+		switch {
+		case json.Unmarshal(apiErr.Body(), &forbidden) == nil && forbidden.Detail != "":
+			reason = forbidden.Detail
+		case json.Unmarshal(apiErr.Body(), &validation) == nil && len(validation.NonFieldErrors) > 0:
+			reason = validation.NonFieldErrors[0]
+		}
+	}
+	if httpResp == nil {
+		return fmt.Errorf("%w: %s: authentik unreachable: %w", services.ErrGroupSyncFailed, op, err)
+	}
+	if httpResp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("failed to %s: not found", op)
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("authentik returned status %d", httpResp.StatusCode)
+	}
+	return fmt.Errorf("%w: %s: %s", services.ErrGroupSyncFailed, op, reason)
+}
+
 // GetGroupUsers fetches users that are members of a group from Authentik using API token
-func (s *AuthentikAuthService) GetGroupUsers(ctx context.Context, userToken, groupID string) ([]*entities.User, error) {
+func (s *AuthentikGroupDirectory) GetGroupUsers(ctx context.Context, userToken, groupID string) ([]*entities.User, error) {
 	// Create authenticated API client using configured API token
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
@@ -808,7 +602,7 @@ func (s *AuthentikAuthService) GetGroupUsers(ctx context.Context, userToken, gro
 }
 
 // GetUserByID fetches a single user by ID from Authentik using API token
-func (s *AuthentikAuthService) GetUserByID(ctx context.Context, userToken, userID string) (*entities.User, error) {
+func (s *AuthentikGroupDirectory) GetUserByID(ctx context.Context, userToken, userID string) (*entities.User, error) {
 	// Create authenticated API client using configured API token
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
@@ -843,7 +637,7 @@ func (s *AuthentikAuthService) GetUserByID(ctx context.Context, userToken, userI
 }
 
 // GetGroupByID fetches a single group by ID from Authentik using API token
-func (s *AuthentikAuthService) GetGroupByID(ctx context.Context, userToken, groupID string) (*entities.Group, error) {
+func (s *AuthentikGroupDirectory) GetGroupByID(ctx context.Context, userToken, groupID string) (*entities.Group, error) {
 	// Create authenticated API client using configured API token
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
@@ -874,7 +668,7 @@ func (s *AuthentikAuthService) GetGroupByID(ctx context.Context, userToken, grou
 }
 
 // UpdateGroup renames a group in Authentik using the configured API token.
-func (s *AuthentikAuthService) UpdateGroup(ctx context.Context, userToken, groupID, name string) (*entities.Group, error) {
+func (s *AuthentikGroupDirectory) UpdateGroup(ctx context.Context, userToken, groupID, name string) (*entities.Group, error) {
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
 
@@ -917,7 +711,7 @@ func (s *AuthentikAuthService) UpdateGroup(ctx context.Context, userToken, group
 }
 
 // DeleteGroup removes a group from Authentik using the configured API token.
-func (s *AuthentikAuthService) DeleteGroup(ctx context.Context, userToken, groupID string) error {
+func (s *AuthentikGroupDirectory) DeleteGroup(ctx context.Context, userToken, groupID string) error {
 	apiClient := api.NewAPIClient(s.apiConfig)
 	auth := context.WithValue(ctx, api.ContextAccessToken, s.config.APIToken)
 
@@ -942,138 +736,3 @@ func (s *AuthentikAuthService) DeleteGroup(ctx context.Context, userToken, group
 	}
 	return nil
 }
-
-// GetOIDCConfig fetches OIDC discovery configuration from Authentik and modifies token endpoint
-func (s *AuthentikAuthService) GetOIDCConfig(ctx context.Context, clientID string) (map[string]any, error) {
-	// Get the appropriate client
-	client, err := s.getClientByClientID(clientID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find OAuth client: %w", err)
-	}
-
-	// Build discovery URL
-	discoveryURL := fmt.Sprintf("%s/application/o/%s/.well-known/openid-configuration",
-		s.authentikURL, client.config.ProviderName)
-
-	s.logger.Debug("Fetching OIDC discovery config",
-		slog.String("url", discoveryURL),
-		slog.String("provider_name", client.config.ProviderName))
-
-	// Make request to Authentik
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		s.logger.Error("Failed to fetch OIDC config from Authentik",
-			slog.String("error", err.Error()),
-			slog.String("url", discoveryURL))
-		return nil, fmt.Errorf("failed to fetch OIDC configuration: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("authentik returned status %d for OIDC config", resp.StatusCode)
-	}
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		s.logger.Error("Failed to read OIDC config response", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("failed to read OIDC configuration: %w", err)
-	}
-
-	// Parse JSON response
-	var oidcConfig map[string]any
-	if err := json.Unmarshal(body, &oidcConfig); err != nil {
-		s.logger.Error("Failed to parse OIDC config JSON", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("failed to parse OIDC configuration: %w", err)
-	}
-
-	// Replace token_endpoint with our proxy
-	backendURL := os.Getenv("BACKEND_URL")
-	if backendURL == "" {
-		backendURL = "http://localhost:3001" // Default fallback
-	}
-	oidcConfig["token_endpoint"] = backendURL + "/auth/token"
-
-	s.logger.Debug("OIDC config fetched successfully",
-		slog.String("token_endpoint", oidcConfig["token_endpoint"].(string)),
-		slog.String("provider_name", client.config.ProviderName))
-
-	return oidcConfig, nil
-}
-
-// ProxyTokenExchange forwards token exchange requests to Authentik with client credentials
-func (s *AuthentikAuthService) ProxyTokenExchange(ctx context.Context, tokenRequest map[string]any) ([]byte, int, error) {
-	s.logger.Debug("Processing token exchange request",
-		slog.String("grant_type", fmt.Sprintf("%v", tokenRequest["grant_type"])))
-
-	// Determine which client to use.
-	// Code exchange requests include redirect_uri; refresh token requests do not — fall back to client_id.
-	redirectURI, _ := tokenRequest["redirect_uri"].(string)
-	clientIDParam, _ := tokenRequest["client_id"].(string)
-	var client *clientProvider
-	var clientLookupErr error
-	if redirectURI != "" {
-		client, clientLookupErr = s.getClientByRedirectURL(redirectURI)
-	} else if clientIDParam != "" {
-		client, clientLookupErr = s.getClientByClientID(clientIDParam)
-	} else {
-		return nil, http.StatusBadRequest, errors.New("failed to determine OAuth client: no redirect_uri or client_id provided")
-	}
-	if clientLookupErr != nil {
-		s.logger.Error("Failed to determine OAuth client", slog.String("error", clientLookupErr.Error()))
-		return nil, http.StatusBadRequest, fmt.Errorf("failed to determine OAuth client: %w", clientLookupErr)
-	}
-
-	// Add client credentials from matched client config
-	tokenRequest["client_id"] = client.config.ClientID
-	tokenRequest["client_secret"] = client.config.ClientSecret
-
-	// Get token URL from OIDC provider configuration
-	tokenURL := client.provider.Endpoint().TokenURL
-
-	// Convert to form data
-	formData := url.Values{}
-	for key, value := range tokenRequest {
-		formData.Set(key, fmt.Sprintf("%v", value))
-	}
-
-	s.logger.Debug("Forwarding token request to Authentik",
-		slog.String("url", tokenURL),
-		slog.String("provider_name", client.config.ProviderName))
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(formData.Encode()))
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// Make POST request to Authentik
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		s.logger.Error("Failed to forward token request to Authentik",
-			slog.String("error", err.Error()),
-			slog.String("url", tokenURL))
-		return nil, 0, fmt.Errorf("failed to exchange token: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		s.logger.Error("Failed to read token exchange response", slog.String("error", err.Error()))
-		return nil, 0, fmt.Errorf("failed to read token response: %w", err)
-	}
-
-	s.logger.Debug("Token exchange completed",
-		slog.Int("status_code", resp.StatusCode),
-		slog.Int("response_size", len(body)),
-		slog.String("provider_name", client.config.ProviderName))
-
-	return body, resp.StatusCode, nil
-}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// A small reader for the EAN-13, UPC-A and EAN-8 barcodes printed on retail
+// packaging. Photos are scanned along evenly spaced rows and columns, each
+// line thresholded into bars and spaces, and every run of bars that starts
+// with a guard pattern is matched against the digit patterns. The check
+// digit rejects misreads. The construction follows ISO/IEC 15420.
+
+// barcodeScanLines is how many rows, and as many columns, are scanned.
+const barcodeScanLines = 24
+
+// eanDigitWidths are the space, bar, space, bar module widths of each digit
+// in the L code. Right-hand digits start with a bar but have the same widths,
+// and G-code digits have them reversed.
+var eanDigitWidths = [10][4]float64{
+	{3, 2, 1, 1},
+	{2, 2, 2, 1},
+	{2, 1, 2, 2},
+	{1, 4, 1, 1},
+	{1, 1, 3, 2},
+	{1, 2, 3, 1},
+	{1, 1, 1, 4},
+	{1, 3, 1, 2},
+	{1, 2, 1, 3},
+	{3, 1, 1, 2},
+}
+
+// ean13Parities maps the L/G pattern of an EAN-13's left-hand digits to the
+// leading digit it encodes.
+var ean13Parities = map[string]byte{
+	"LLLLLL": '0', "LLGLGG": '1', "LLGGLG": '2', "LLGGGL": '3', "LGLLGG": '4',
+	"LGGLLG": '5', "LGGGLL": '6', "LGLGLG": '7', "LGLGGL": '8', "LGGLGL": '9',
+}
+
+// BarcodeDecoder reads retail barcodes from JPEG, PNG and GIF photos.
+type BarcodeDecoder struct{}
+
+func NewBarcodeDecoder() *BarcodeDecoder {
+	return &BarcodeDecoder{}
+}
+
+var _ services.BarcodeDecoder = (*BarcodeDecoder)(nil)
+
+func (d *BarcodeDecoder) Decode(_ context.Context, contentType string, data []byte) (*services.Barcode, error) {
+	format, ok := mediaFormats[contentType]
+	if !ok {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+	cfg, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format.format {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+	if cfg.Width*cfg.Height > maxMediaPixels {
+		return nil, fmt.Errorf("%w: at most %d pixels", entities.ErrMediaTooLarge, maxMediaPixels)
+	}
+	img, err := decodeMedia(format.format, data)
+	if err != nil {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+
+	b := img.Bounds()
+	for i := range barcodeScanLines {
+		y := b.Min.Y + (i+1)*b.Dy()/(barcodeScanLines+1)
+		if code := decodeScanLine(img, b.Min.X, y, 1, 0, b.Dx()); code != nil {
+			return code, nil
+		}
+	}
+	for i := range barcodeScanLines {
+		x := b.Min.X + (i+1)*b.Dx()/(barcodeScanLines+1)
+		if code := decodeScanLine(img, x, b.Min.Y, 0, 1, b.Dy()); code != nil {
+			return code, nil
+		}
+	}
+	return nil, nil
+}
+
+// decodeScanLine reads n pixels from (x, y) in steps of (dx, dy) and looks
+// for a barcode along them in both directions.
+func decodeScanLine(img image.Image, x, y, dx, dy, n int) *services.Barcode {
+	lum := make([]uint8, n)
+	lo, hi := uint8(255), uint8(0)
+	for i := range n {
+		lum[i] = color.GrayModel.Convert(img.At(x+i*dx, y+i*dy)).(color.Gray).Y
+		lo, hi = min(lo, lum[i]), max(hi, lum[i])
+	}
+	// Too little contrast to hold any bars
+	if hi-lo < 48 {
+		return nil
+	}
+	threshold := (int(lo) + int(hi)) / 2
+
+	// runs alternate space, bar, space...: bars are at odd indexes
+	runs := []float64{0}
+	dark := false
+	for _, l := range lum {
+		if isDark := int(l) < threshold; isDark != dark {
+			runs = append(runs, 0)
+			dark = isDark
+		}
+		runs[len(runs)-1]++
+	}
+
+	if code := decodeRuns(runs); code != nil {
+		return code
+	}
+	// Upside down
+	slices.Reverse(runs)
+	if len(runs)%2 == 0 {
+		runs = append([]float64{0}, runs...)
+	}
+	return decodeRuns(runs)
+}
+
+func decodeRuns(runs []float64) *services.Barcode {
+	for start := 1; start < len(runs); start += 2 {
+		if code := decodeEAN(runs, start, 6); code != nil {
+			return code
+		}
+		if code := decodeEAN(runs, start, 4); code != nil {
+			return code
+		}
+	}
+	return nil
+}
+
+// decodeEAN reads an EAN-13 (half 6) or EAN-8 (half 4) whose start guard is
+// the bar at runs[start].
+func decodeEAN(runs []float64, start, half int) *services.Barcode {
+	count := 3 + 4*half + 5 + 4*half + 3
+	if start+count > len(runs) {
+		return nil
+	}
+	symbol := runs[start : start+count]
+	var total float64
+	for _, w := range symbol {
+		total += w
+	}
+	module := total / float64(11+14*half)
+
+	// A quiet zone on both sides, unless the symbol runs to the edge
+	if runs[start-1] < 3*module && start > 1 {
+		return nil
+	}
+	if end := start + count; end < len(runs) && runs[end] < 3*module {
+		return nil
+	}
+	middle := 3 + 4*half
+	if !isGuard(symbol[:3], module) || !isGuard(symbol[middle:middle+5], module) || !isGuard(symbol[count-3:], module) {
+		return nil
+	}
+
+	digits := make([]byte, 0, 2*half+1)
+	parities := make([]byte, 0, half)
+	for i := range half {
+		digit, parity, ok := decodeEANDigit(symbol[3+4*i : 7+4*i])
+		if !ok {
+			return nil
+		}
+		digits = append(digits, digit)
+		parities = append(parities, parity)
+	}
+	for i := range half {
+		at := middle + 5 + 4*i
+		digit, parity, ok := decodeEANDigit(symbol[at : at+4])
+		if !ok || parity != 'L' {
+			return nil
+		}
+		digits = append(digits, digit)
+	}
+
+	format := services.BarcodeEAN8
+	if half == 6 {
+		first, ok := ean13Parities[string(parities)]
+		if !ok {
+			return nil
+		}
+		digits = append([]byte{first}, digits...)
+		format = services.BarcodeEAN13
+	} else if slices.Contains(parities, 'G') {
+		return nil
+	}
+	if !validEANCheckDigit(digits) {
+		return nil
+	}
+
+	code := string(digits)
+	if format == services.BarcodeEAN13 && code[0] == '0' {
+		return &services.Barcode{Format: services.BarcodeUPCA, Code: code[1:]}
+	}
+	return &services.Barcode{Format: format, Code: code}
+}
+
+// isGuard reports whether every run of a guard pattern is about one module wide
+func isGuard(runs []float64, module float64) bool {
+	for _, w := range runs {
+		if w < 0.4*module || w > 1.8*module {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeEANDigit matches four runs against the digit patterns, reporting
+// whether the closest is in the L or the G code
+func decodeEANDigit(runs []float64) (byte, byte, bool) {
+	var total float64
+	for _, w := range runs {
+		total += w
+	}
+	best, bestDigit, bestParity := 1.0, byte(0), byte(0)
+	for digit, widths := range eanDigitWidths {
+		var l, g float64
+		for i, w := range runs {
+			n := w * 7 / total
+			l += (n - widths[i]) * (n - widths[i])
+			g += (n - widths[3-i]) * (n - widths[3-i])
+		}
+		if l < best {
+			best, bestDigit, bestParity = l, byte('0'+digit), 'L'
+		}
+		if g < best {
+			best, bestDigit, bestParity = g, byte('0'+digit), 'G'
+		}
+	}
+	return bestDigit, bestParity, bestParity != 0
+}
+
+// validEANCheckDigit checks the last digit against the others, which are
+// weighted 3 and 1 alternately from the right
+func validEANCheckDigit(digits []byte) bool {
+	sum := 0
+	for i, weight := len(digits)-2, 3; i >= 0; i, weight = i-1, 4-weight {
+		sum += int(digits[i]-'0') * weight
+	}
+	return (10-sum%10)%10 == int(digits[len(digits)-1]-'0')
+}
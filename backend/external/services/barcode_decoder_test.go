@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+func TestBarcodeDecoder_Decode(t *testing.T) {
+	decoder := NewBarcodeDecoder()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		img    image.Image
+		want   *services.Barcode
+		wantIn string
+	}{
+		{
+			name: "EAN-13",
+			img:  renderEAN(t, "4006381333931", 3, false, false),
+			want: &services.Barcode{Format: services.BarcodeEAN13, Code: "4006381333931"},
+		},
+		{
+			name: "UPC-A with blurred fractional modules",
+			img:  renderEAN(t, "0036000291452", 2.6, false, false),
+			want: &services.Barcode{Format: services.BarcodeUPCA, Code: "036000291452"},
+		},
+		{
+			name: "ISBN upside down",
+			img:  renderEAN(t, "9780306406157", 2, true, false),
+			want: &services.Barcode{Format: services.BarcodeEAN13, Code: "9780306406157"},
+		},
+		{
+			name: "EAN-8 on its side",
+			img:  renderEAN(t, "96385074", 3, false, true),
+			want: &services.Barcode{Format: services.BarcodeEAN8, Code: "96385074"},
+		},
+		{
+			name: "wrong check digit",
+			img:  renderEAN(t, "4006381333932", 3, false, false),
+		},
+		{
+			name: "no barcode",
+			img:  image.NewGray(image.Rect(0, 0, 200, 100)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, png.Encode(&buf, tt.img))
+			got, err := decoder.Decode(ctx, "image/png", buf.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := decoder.Decode(ctx, "image/jpeg", []byte("not an image"))
+	assert.ErrorIs(t, err, entities.ErrUnsupportedMediaType)
+}
+
+func TestBarcode_PropertyKey(t *testing.T) {
+	assert.Equal(t, "upc", services.Barcode{Format: services.BarcodeUPCA, Code: "036000291452"}.PropertyKey())
+	assert.Equal(t, "isbn", services.Barcode{Format: services.BarcodeEAN13, Code: "9780306406157"}.PropertyKey())
+	assert.Equal(t, "ean", services.Barcode{Format: services.BarcodeEAN13, Code: "4006381333931"}.PropertyKey())
+	assert.Equal(t, "ean", services.Barcode{Format: services.BarcodeEAN8, Code: "96385074"}.PropertyKey())
+}
+
+// renderEAN draws an EAN-13 or EAN-8 with modules of the given width in
+// pixels, averaging the pixels that straddle two modules. The check digit is
+// drawn as given so bad ones can be tested.
+func renderEAN(t *testing.T, code string, module float64, flip, rotate bool) image.Image {
+	t.Helper()
+	digits := []byte(code)
+	parities := "LLLL"
+	if len(digits) == 13 {
+		for p, d := range ean13Parities {
+			if d == digits[0] {
+				parities = p
+			}
+		}
+		digits = digits[1:]
+	}
+	half := len(digits) / 2
+
+	var bars strings.Builder
+	bars.WriteString("101")
+	for i, d := range digits {
+		if i == half {
+			bars.WriteString("01010")
+		}
+		widths := eanDigitWidths[d-'0']
+		if i < half && parities[i] == 'G' {
+			widths = [4]float64{widths[3], widths[2], widths[1], widths[0]}
+		}
+		dark := i >= half
+		for _, w := range widths {
+			bars.WriteString(strings.Repeat(map[bool]string{true: "1", false: "0"}[dark], int(w)))
+			dark = !dark
+		}
+	}
+	bars.WriteString("101")
+	modules := "0000000000" + bars.String() + "0000000000"
+
+	width := int(math.Ceil(float64(len(modules)) * module))
+	height := 80
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for x := range width {
+		// Coverage of this pixel by dark modules
+		var ink float64
+		for m := int(float64(x) / module); m < len(modules) && float64(m)*module < float64(x+1); m++ {
+			if modules[m] == '1' {
+				ink += min(float64(x+1), float64(m+1)*module) - max(float64(x), float64(m)*module)
+			}
+		}
+		px := x
+		if flip {
+			px = width - 1 - x
+		}
+		for y := range height {
+			img.SetGray(px, y, color.Gray{Y: uint8(255 - 235*ink)})
+		}
+	}
+	if !rotate {
+		return img
+	}
+	rotated := image.NewGray(image.Rect(0, 0, height, width))
+	for x := range width {
+		for y := range height {
+			rotated.SetGray(y, x, img.GrayAt(x, y))
+		}
+	}
+	return rotated
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// ClaimsGroupDirectory reads a user's groups from a claim in their access
+// token, for providers without an API Nishiki can manage groups through.
+// A group's ID and name are both the claim value with the configured prefix
+// removed. Groups are created and joined in the provider, so every change
+// returns services.ErrGroupsReadOnly.
+type ClaimsGroupDirectory struct {
+	claim  string
+	prefix string
+}
+
+func NewClaimsGroupDirectory(claim, prefix string) *ClaimsGroupDirectory {
+	return &ClaimsGroupDirectory{claim: claim, prefix: prefix}
+}
+
+// groupNames returns the groups listed in the token's claim that carry the
+// prefix, with the prefix removed.
+func (d *ClaimsGroupDirectory) groupNames(rawClaims jwt.MapClaims) []string {
+	var values []string
+	switch raw := rawClaims[d.claim].(type) {
+	case []any:
+		for _, v := range raw {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+	case string:
+		// Some providers send a single group as a plain string
+		values = append(values, raw)
+	}
+
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		name, ok := strings.CutPrefix(value, d.prefix)
+		if !ok || name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func (d *ClaimsGroupDirectory) hasGroup(rawClaims jwt.MapClaims, groupID string) bool {
+	for _, name := range d.groupNames(rawClaims) {
+		if name == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *ClaimsGroupDirectory) GetUserGroups(ctx context.Context, userToken, userID string) ([]*entities.Group, error) {
+	rawClaims, err := parseTokenClaims(userToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+
+	names := d.groupNames(rawClaims)
+	groups := make([]*entities.Group, 0, len(names))
+	for _, name := range names {
+		group, err := claimGroup(name)
+		if err != nil {
+			continue
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// GetGroupByID only finds the caller's own groups, since the token is the
+// only place groups are listed.
+func (d *ClaimsGroupDirectory) GetGroupByID(ctx context.Context, userToken, groupID string) (*entities.Group, error) {
+	rawClaims, err := parseTokenClaims(userToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+	if !d.hasGroup(rawClaims, groupID) {
+		return nil, errors.New("group not found")
+	}
+	return claimGroup(groupID)
+}
+
+// GetGroupUsers lists only the caller: other members are not known until
+// they sign in with their own token.
+func (d *ClaimsGroupDirectory) GetGroupUsers(ctx context.Context, userToken, groupID string) ([]*entities.User, error) {
+	rawClaims, err := parseTokenClaims(userToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+	if !d.hasGroup(rawClaims, groupID) {
+		return nil, errors.New("group not found")
+	}
+	user, err := userFromClaims(claimsFromToken(rawClaims))
+	if err != nil {
+		return nil, err
+	}
+	return []*entities.User{user}, nil
+}
+
+// GetUserByID only finds the caller.
+func (d *ClaimsGroupDirectory) GetUserByID(ctx context.Context, userToken, userID string) (*entities.User, error) {
+	rawClaims, err := parseTokenClaims(userToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+	claims := claimsFromToken(rawClaims)
+	if claims.Subject != userID {
+		return nil, errors.New("user not found")
+	}
+	return userFromClaims(claims)
+}
+
+func (d *ClaimsGroupDirectory) CreateGroup(ctx context.Context, userToken, name string, creatorID string) (*entities.Group, error) {
+	return nil, services.ErrGroupsReadOnly
+}
+
+func (d *ClaimsGroupDirectory) UpdateGroup(ctx context.Context, userToken, groupID, name string) (*entities.Group, error) {
+	return nil, services.ErrGroupsReadOnly
+}
+
+func (d *ClaimsGroupDirectory) DeleteGroup(ctx context.Context, userToken, groupID string) error {
+	return services.ErrGroupsReadOnly
+}
+
+func (d *ClaimsGroupDirectory) AddUserToGroup(ctx context.Context, userToken, groupID, userID string) error {
+	return services.ErrGroupsReadOnly
+}
+
+func (d *ClaimsGroupDirectory) RemoveUserFromGroup(ctx context.Context, userToken, groupID, userID string) error {
+	return services.ErrGroupsReadOnly
+}
+
+func (d *ClaimsGroupDirectory) InviteUserByEmail(ctx context.Context, userToken, groupID, email string) (*entities.User, error) {
+	return nil, services.ErrGroupsReadOnly
+}
+
+// GetGroupMemberRoles reports no admins: the claim carries membership only.
+func (d *ClaimsGroupDirectory) GetGroupMemberRoles(ctx context.Context, userToken, groupID string) (map[string]entities.GroupRole, error) {
+	return map[string]entities.GroupRole{}, nil
+}
+
+func (d *ClaimsGroupDirectory) SetGroupMemberRole(ctx context.Context, userToken, groupID, userID string, role entities.GroupRole) error {
+	return services.ErrGroupsReadOnly
+}
+
+func claimGroup(name string) (*entities.Group, error) {
+	id, err := entities.GroupIDFromString(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group ID: %w", err)
+	}
+	groupName, err := entities.NewGroupName(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group name: %w", err)
+	}
+	return entities.ReconstructGroup(id, groupName, entities.NewGroupDescription(""), time.Now(), time.Now()), nil
+}
+
+func claimsFromToken(rawClaims jwt.MapClaims) *services.AuthClaims {
+	claims := &services.AuthClaims{}
+	claims.Subject, _ = rawClaims["sub"].(string)
+	claims.Email, _ = rawClaims["email"].(string)
+	claims.Username, _ = rawClaims["preferred_username"].(string)
+	return claims
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+func claimsToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-key"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestClaimsGroupDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := NewClaimsGroupDirectory("groups", "nishiki-")
+	token := "Bearer " + claimsToken(t, jwt.MapClaims{
+		"sub":                "user-1",
+		"email":              "alice@example.com",
+		"preferred_username": "alice",
+		"groups":             []any{"nishiki-family", "admins", "nishiki-"},
+	})
+
+	groups, err := dir.GetUserGroups(ctx, token, "user-1")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "family", groups[0].ID().String())
+	assert.Equal(t, "family", groups[0].Name().String())
+
+	group, err := dir.GetGroupByID(ctx, token, "family")
+	require.NoError(t, err)
+	assert.Equal(t, "family", group.ID().String())
+	_, err = dir.GetGroupByID(ctx, token, "admins")
+	assert.EqualError(t, err, "group not found")
+
+	users, err := dir.GetGroupUsers(ctx, token, "family")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "alice", users[0].Username().String())
+
+	user, err := dir.GetUserByID(ctx, token, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.EmailAddress().String())
+	_, err = dir.GetUserByID(ctx, token, "user-2")
+	assert.Error(t, err)
+
+	_, err = dir.CreateGroup(ctx, token, "friends", "user-1")
+	assert.ErrorIs(t, err, services.ErrGroupsReadOnly)
+	assert.ErrorIs(t, dir.AddUserToGroup(ctx, token, "family", "user-2"), services.ErrGroupsReadOnly)
+	assert.ErrorIs(t, dir.SetGroupMemberRole(ctx, token, "family", "user-2", entities.GroupRoleAdmin), services.ErrGroupsReadOnly)
+}
+
+func TestClaimsGroupDirectory_SingleStringClaim(t *testing.T) {
+	dir := NewClaimsGroupDirectory("roles", "")
+	token := claimsToken(t, jwt.MapClaims{"sub": "user-1", "roles": "household"})
+
+	groups, err := dir.GetUserGroups(context.Background(), token, "user-1")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "household", groups[0].Name().String())
+}
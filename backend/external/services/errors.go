@@ -13,10 +13,11 @@ type AuthError struct {
 	cause error
 }
 
-// Sentinel errors for the Authentik auth service.
+// Sentinel errors for the auth services.
 var (
 	ErrAuthConfigInvalid    = &AuthError{code: "auth config invalid"}
 	ErrAuthentikUnreachable = &AuthError{code: "authentik unreachable"}
+	ErrIssuerUnreachable    = &AuthError{code: "oidc issuer unreachable"}
 	ErrOIDCProviderInit     = &AuthError{code: "oidc provider init failed"}
 )
 
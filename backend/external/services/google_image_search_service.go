@@ -49,6 +49,17 @@ func NewGoogleImageSearchService(cfg config.ImagesConfig, logger *slog.Logger) (
 	}, nil
 }
 
+// CheckHealth confirms the cache directory exists and accepts writes.
+func (s *GoogleImageSearchService) CheckHealth(_ context.Context) error {
+	f, err := os.CreateTemp(s.cacheDir, ".health-*")
+	if err != nil {
+		return fmt.Errorf("image cache directory %s is not writable: %w", s.cacheDir, err)
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
+
 type googleSearchResponse struct {
 	Items []struct {
 		Link string `json:"link"`
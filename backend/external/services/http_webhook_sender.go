@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// Headers sent with every webhook. The signature is the hex HMAC-SHA256,
+// keyed with the webhook's secret, of the timestamp, a dot and the body, so
+// a receiver can reject both forged and replayed calls. While a rotated
+// signing key is in its grace period the header carries one signature per
+// key, comma separated, and a receiver accepts the call if any matches.
+const (
+	WebhookEventHeader     = "X-Nishiki-Event"
+	WebhookTimestampHeader = "X-Nishiki-Timestamp"
+	WebhookSignatureHeader = "X-Nishiki-Signature"
+)
+
+// HTTPWebhookSender posts webhooks as JSON. Redirects are not followed, and
+// unless private webhooks are allowed every connection is checked after DNS
+// resolution, so neither a redirect nor a rebinding DNS answer can point a
+// webhook at an internal address.
+type HTTPWebhookSender struct {
+	client *http.Client
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func NewHTTPWebhookSender(cfg config.AutomationsConfig, logger *slog.Logger) *HTTPWebhookSender {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !cfg.AllowPrivateWebhooks {
+		dialer.Control = publicAddressOnly
+	}
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Timeout: time.Duration(cfg.WebhookTimeout) * time.Second,
+			// No proxy: it would make the connection, bypassing the address check
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: 10 * time.Second,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, hook services.Webhook) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(hook.Body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Nishiki-Webhook/1.0")
+	req.Header.Set(WebhookEventHeader, hook.Event)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	if len(hook.Secrets) > 0 {
+		signatures := make([]string, len(hook.Secrets))
+		for i, secret := range hook.Secrets {
+			signatures[i] = "sha256=" + SignWebhook(secret, timestamp, hook.Body)
+		}
+		req.Header.Set(WebhookSignatureHeader, strings.Join(signatures, ", "))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Warn("Webhook not delivered",
+			slog.String("host", req.URL.Host),
+			slog.String("event", hook.Event),
+			slog.Any("error", err))
+		return sendErrorClass(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendErrorClass reduces a failed request to one of the errors a delivery
+// records, so raw dial errors never reach the rule's owner
+func sendErrorClass(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, services.ErrWebhookAddressBlocked):
+		return services.ErrWebhookAddressBlocked
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return services.ErrWebhookTimeout
+	default:
+		return services.ErrWebhookUnreachable
+	}
+}
+
+// publicAddressOnly is a net.Dialer.Control hook refusing connections to
+// addresses that are not on the public internet
+func publicAddressOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return services.ErrWebhookAddressBlocked
+	}
+	if !isPublicAddr(addrPort.Addr()) {
+		return services.ErrWebhookAddressBlocked
+	}
+	return nil
+}
+
+// nonPublicRanges are the internal ranges netip has no predicate for: "this
+// network" and the carrier-grade NAT space of RFC 6598
+var nonPublicRanges = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicRanges {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// SignWebhook returns the hex signature of a webhook body sent at timestamp
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
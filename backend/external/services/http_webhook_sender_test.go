@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/services"
+)
+
+func TestHTTPWebhookSender_Send(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	// The test server listens on loopback
+	sender := NewHTTPWebhookSender(config.AutomationsConfig{WebhookTimeout: 5, AllowPrivateWebhooks: true}, slog.New(slog.DiscardHandler))
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	t.Run("signed", func(t *testing.T) {
+		err := sender.Send(context.Background(), services.Webhook{
+			URL:     server.URL,
+			Event:   "object.created",
+			Secrets: []string{"s3cret"},
+			Body:    []byte(`{"name":"Milk"}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.JSONEq(t, `{"name":"Milk"}`, string(body))
+		assert.Equal(t, "object.created", got.Header.Get(WebhookEventHeader))
+		assert.Equal(t, "1700000000", got.Header.Get(WebhookTimestampHeader))
+		assert.Equal(t, "sha256="+SignWebhook("s3cret", "1700000000", body), got.Header.Get(WebhookSignatureHeader))
+	})
+
+	t.Run("signed with each key during a rotation", func(t *testing.T) {
+		err := sender.Send(context.Background(), services.Webhook{
+			URL:     server.URL,
+			Event:   "object.created",
+			Secrets: []string{"new", "old"},
+			Body:    []byte(`{}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t,
+			"sha256="+SignWebhook("new", "1700000000", body)+", sha256="+SignWebhook("old", "1700000000", body),
+			got.Header.Get(WebhookSignatureHeader))
+	})
+
+	t.Run("unsigned without a secret", func(t *testing.T) {
+		require.NoError(t, sender.Send(context.Background(), services.Webhook{URL: server.URL, Event: "object.updated", Body: []byte(`{}`)}))
+		assert.Empty(t, got.Header.Get(WebhookSignatureHeader))
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		status = http.StatusGone
+		err := sender.Send(context.Background(), services.Webhook{URL: server.URL, Event: "object.updated", Body: []byte(`{}`)})
+		assert.ErrorContains(t, err, "410")
+	})
+
+	t.Run("unreachable is reported without the dial error", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		err := sender.Send(context.Background(), services.Webhook{URL: closed.URL, Event: "object.updated", Body: []byte(`{}`)})
+		assert.Equal(t, services.ErrWebhookUnreachable, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+		err := sender.Send(ctx, services.Webhook{URL: server.URL, Event: "object.updated", Body: []byte(`{}`)})
+		assert.Equal(t, services.ErrWebhookTimeout, err)
+	})
+}
+
+func TestHTTPWebhookSender_Send_PrivateAddresses(t *testing.T) {
+	t.Parallel()
+
+	reached := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	t.Cleanup(internal.Close)
+
+	sender := NewHTTPWebhookSender(config.AutomationsConfig{WebhookTimeout: 5}, slog.New(slog.DiscardHandler))
+
+	for _, url := range []string{
+		internal.URL,
+		"http://127.0.0.1:9/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]:9/hook",
+		"http://[::ffff:10.0.0.1]/hook",
+		"http://localhost:9/hook",
+	} {
+		err := sender.Send(context.Background(), services.Webhook{URL: url, Event: "object.created", Body: []byte(`{}`)})
+		assert.ErrorIs(t, err, services.ErrWebhookAddressBlocked, url)
+	}
+	assert.False(t, reached)
+}
+
+func TestHTTPWebhookSender_Send_DoesNotFollowRedirects(t *testing.T) {
+	t.Parallel()
+
+	reached := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	t.Cleanup(internal.Close)
+	redirector := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(redirector.Close)
+
+	// Loopback is allowed so the redirector can be reached at all; the
+	// redirect to the internal server must still not be followed
+	sender := NewHTTPWebhookSender(config.AutomationsConfig{WebhookTimeout: 5, AllowPrivateWebhooks: true}, slog.New(slog.DiscardHandler))
+
+	err := sender.Send(context.Background(), services.Webhook{URL: redirector.URL, Event: "object.created", Body: []byte(`{}`)})
+	assert.ErrorContains(t, err, "307")
+	assert.False(t, reached)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::1", false},
+		{"::", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isPublicAddr(netip.MustParseAddr(tt.addr)), tt.addr)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	t.Parallel()
+
+	// Known HMAC-SHA256 of "1.{}" keyed with "key"
+	assert.Equal(t, "1ba6b8171186efc613e8bcc0cbdab2748f24984d7c5a84faa2637afa0e40d224", SignWebhook("key", "1", []byte("{}")))
+	assert.NotEqual(t, SignWebhook("key", "1", []byte("{}")), SignWebhook("key", "2", []byte("{}")), "timestamp is signed")
+	assert.NotEqual(t, SignWebhook("key", "1", []byte("{}")), SignWebhook("other", "1", []byte("{}")))
+}
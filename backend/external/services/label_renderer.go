@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/nishiki/backend/domain/services"
+)
+
+const (
+	labelMargin  = 2.0   // mm, also the QR code's quiet zone
+	labelGap     = 2.0   // mm between the QR code and the text
+	labelPNGDPI  = 300.0 // resolution of PNG labels, what label printers print at
+	labelMMPerPt = 25.4 / 72
+)
+
+// labelLayout places the parts of a label, in millimetres. The QR code is a
+// square on the left as tall as the label allows; the name, container path
+// and expiry are stacked to its right, the name getting as many lines as fit.
+type labelLayout struct {
+	qrSize    float64
+	textX     float64
+	textWidth float64
+	namePt    float64 // font size of the name
+	detailPt  float64 // font size of the path and expiry
+	details   []string
+}
+
+func newLabelLayout(label *services.ObjectLabel) labelLayout {
+	t := label.Template
+	l := labelLayout{qrSize: t.Height - 2*labelMargin}
+	l.textX = labelMargin + l.qrSize + labelGap
+	l.textWidth = t.Width - l.textX - labelMargin
+
+	// Text scales with the label height: about 11pt names on a 28mm label
+	l.namePt = min(14, max(7, t.Height*0.4))
+	l.detailPt = max(6, l.namePt*0.7)
+
+	if label.ContainerPath != "" {
+		l.details = append(l.details, label.ContainerPath)
+	}
+	if label.ExpiresAt != nil {
+		l.details = append(l.details, "Expires "+label.ExpiresAt.Format("Jan 2, 2006"))
+	}
+	return l
+}
+
+// nameLines is how many lines of the name fit above the details
+func (l labelLayout) nameLines(height float64) int {
+	free := height - 2*labelMargin - float64(len(l.details))*l.detailPt*labelMMPerPt*1.2
+	return max(1, int(free/(l.namePt*labelMMPerPt*1.2)))
+}
+
+// LabelRenderer renders object labels as PDF or PNG files the size of the
+// label stock, ready for a Dymo or Brother label printer.
+type LabelRenderer struct {
+	fontsOnce sync.Once
+	fontsErr  error
+	regular   *opentype.Font
+	bold      *opentype.Font
+}
+
+func NewLabelRenderer() *LabelRenderer {
+	return &LabelRenderer{}
+}
+
+func (r *LabelRenderer) RenderLabel(_ context.Context, label *services.ObjectLabel, format services.LabelFormat) ([]byte, error) {
+	qr, err := encodeQR(label.Code)
+	if err != nil {
+		return nil, err
+	}
+	if format == services.LabelFormatPNG {
+		return r.renderPNG(label, qr)
+	}
+	return r.renderPDF(label, qr)
+}
+
+func (r *LabelRenderer) renderPDF(label *services.ObjectLabel, qr *qrCode) ([]byte, error) {
+	t := label.Template
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		Size:           fpdf.SizeType{Wd: t.Width, Ht: t.Height},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle(label.Name, true)
+	pdf.SetCreator("Nishiki", true)
+	pdf.AddPage()
+
+	l := newLabelLayout(label)
+	module := l.qrSize / float64(qr.size)
+	pdf.SetFillColor(0, 0, 0)
+	for y := range qr.size {
+		for x := range qr.size {
+			if qr.modules[y][x] {
+				pdf.Rect(labelMargin+float64(x)*module, labelMargin+float64(y)*module, module, module, "F")
+			}
+		}
+	}
+
+	// The core fonts only cover cp1252, so text is translated from UTF-8
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	y := labelMargin
+	pdf.SetFont("Helvetica", "B", l.namePt)
+	lineHeight := l.namePt * labelMMPerPt * 1.2
+	for _, line := range fitLines(splitPDFLines(pdf, tr(label.Name), l.textWidth), l.nameLines(t.Height)) {
+		pdf.SetXY(l.textX, y)
+		pdf.CellFormat(l.textWidth, lineHeight, line, "", 0, "L", false, 0, "")
+		y += lineHeight
+	}
+
+	pdf.SetFont("Helvetica", "", l.detailPt)
+	pdf.SetTextColor(60, 60, 60)
+	lineHeight = l.detailPt * labelMMPerPt * 1.2
+	for _, detail := range l.details {
+		pdf.SetXY(l.textX, y)
+		pdf.CellFormat(l.textWidth, lineHeight, fitLines(splitPDFLines(pdf, tr(detail), l.textWidth), 1)[0], "", 0, "L", false, 0, "")
+		y += lineHeight
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *LabelRenderer) renderPNG(label *services.ObjectLabel, qr *qrCode) ([]byte, error) {
+	if err := r.loadFonts(); err != nil {
+		return nil, err
+	}
+	px := func(mm float64) int { return int(mm / 25.4 * labelPNGDPI) }
+
+	t := label.Template
+	img := image.NewGray(image.Rect(0, 0, px(t.Width), px(t.Height)))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	l := newLabelLayout(label)
+	module := float64(px(l.qrSize)) / float64(qr.size)
+	origin := px(labelMargin)
+	for y := range qr.size {
+		for x := range qr.size {
+			if qr.modules[y][x] {
+				rect := image.Rect(
+					origin+int(float64(x)*module), origin+int(float64(y)*module),
+					origin+int(float64(x+1)*module), origin+int(float64(y+1)*module))
+				draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	nameFace, err := opentype.NewFace(r.bold, &opentype.FaceOptions{Size: l.namePt, DPI: labelPNGDPI})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load label font: %w", err)
+	}
+	defer nameFace.Close()
+	detailFace, err := opentype.NewFace(r.regular, &opentype.FaceOptions{Size: l.detailPt, DPI: labelPNGDPI})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load label font: %w", err)
+	}
+	defer detailFace.Close()
+
+	width := fixed.I(px(l.textWidth))
+	y := px(labelMargin)
+	write := func(face font.Face, pt float64, c color.Color, lines []string) {
+		lineHeight := px(pt * labelMMPerPt * 1.2)
+		for _, line := range lines {
+			d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face}
+			d.Dot = fixed.P(px(l.textX), y+face.Metrics().Ascent.Ceil())
+			d.DrawString(line)
+			y += lineHeight
+		}
+	}
+	write(nameFace, l.namePt, color.Black, fitLines(wrapText(nameFace, label.Name, width), l.nameLines(t.Height)))
+	for _, detail := range l.details {
+		write(detailFace, l.detailPt, color.Gray{Y: 60}, fitLines(wrapText(detailFace, detail, width), 1))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to write png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *LabelRenderer) loadFonts() error {
+	r.fontsOnce.Do(func() {
+		if r.regular, r.fontsErr = opentype.Parse(goregular.TTF); r.fontsErr != nil {
+			return
+		}
+		r.bold, r.fontsErr = opentype.Parse(gobold.TTF)
+	})
+	if r.fontsErr != nil {
+		return fmt.Errorf("failed to load label font: %w", r.fontsErr)
+	}
+	return nil
+}
+
+// splitPDFLines wraps text already translated to the core font encoding;
+// SplitText expects UTF-8 and cannot be used after translation.
+func splitPDFLines(pdf *fpdf.Fpdf, text string, width float64) []string {
+	var lines []string
+	for _, line := range pdf.SplitLines([]byte(text), width) {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// wrapText breaks text into lines no wider than width, splitting at spaces.
+func wrapText(face font.Face, text string, width fixed.Int26_6) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := strings.TrimSpace(line + " " + word)
+		if line != "" && font.MeasureString(face, candidate) > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	return append(lines, line)
+}
+
+// fitLines keeps the first n lines, marking the last one with an ellipsis
+// when text was cut.
+func fitLines(lines []string, n int) []string {
+	if len(lines) == 0 {
+		return []string{""}
+	}
+	if len(lines) <= n {
+		return lines
+	}
+	lines = lines[:n]
+	lines[n-1] = strings.TrimRight(lines[n-1], " ") + "..."
+	return lines
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+func TestLabelRenderer_RenderLabel(t *testing.T) {
+	renderer := NewLabelRenderer()
+	expires := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	for _, template := range entities.LabelTemplates {
+		label := &services.ObjectLabel{
+			Name:          "Crème brûlée with an uncommonly long name that cannot fit on one line",
+			ContainerPath: "Kitchen / Fridge / Top shelf",
+			ExpiresAt:     &expires,
+			Code:          "0198f5a2-7c1e-7b3a-9d2e-4f6a8b0c1d2e",
+			Template:      template,
+		}
+
+		t.Run(template.Key+"/pdf", func(t *testing.T) {
+			out, err := renderer.RenderLabel(context.Background(), label, services.LabelFormatPDF)
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+		})
+
+		t.Run(template.Key+"/png", func(t *testing.T) {
+			out, err := renderer.RenderLabel(context.Background(), label, services.LabelFormatPNG)
+			require.NoError(t, err)
+			img, err := png.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			// 300 dpi
+			assert.Equal(t, int(template.Width/25.4*300), img.Bounds().Dx())
+			assert.Equal(t, int(template.Height/25.4*300), img.Bounds().Dy())
+		})
+	}
+}
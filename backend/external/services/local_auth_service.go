@@ -0,0 +1,442 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/repositories"
+	"github.com/nishiki/backend/domain/services"
+)
+
+const (
+	// localIssuer is the iss and aud of tokens issued in local auth mode.
+	localIssuer = "nishiki"
+
+	tokenUseAccess  = "access"
+	tokenUseRefresh = "refresh"
+
+	authorizationCodeLifetime = time.Minute
+	minPasswordLength         = 8
+	maxPasswordLength         = 72 // bcrypt ignores anything longer
+)
+
+// dummyPasswordHash is compared against when a username does not exist, so
+// unknown and known usernames take as long to reject.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("nishiki-dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// localClaims are the claims of access and refresh tokens issued in local
+// auth mode. TokenUse keeps a refresh token from being used as an access
+// token.
+type localClaims struct {
+	jwt.RegisteredClaims
+	Email    string `json:"email,omitempty"`
+	Username string `json:"preferred_username,omitempty"`
+	AuthTime int64  `json:"auth_time"`
+	TokenUse string `json:"token_use"`
+}
+
+type authorizationCode struct {
+	userID        entities.UserID
+	redirectURI   string
+	codeChallenge string
+	authTime      time.Time
+	expiresAt     time.Time
+}
+
+// LocalAuthService keeps username/password accounts in Nishiki itself and
+// issues JWTs signed with the configured secret, for self-hosters without an
+// identity provider. It also plays the provider's part in the frontend's
+// authorization code flow: the sign-in page calls Authorize, and
+// ProxyTokenExchange redeems the code.
+type LocalAuthService struct {
+	services.GroupDirectory
+
+	config   config.LocalAuthConfig
+	accounts repositories.LocalAccountRepository
+	logger   *slog.Logger
+	secret   []byte
+
+	// registerMu serializes registration so only one first account is
+	// created while registration is otherwise closed
+	registerMu sync.Mutex
+
+	codesMu sync.Mutex
+	codes   map[string]*authorizationCode
+}
+
+func NewLocalAuthService(cfg config.AuthConfig, accounts repositories.LocalAccountRepository, logger *slog.Logger) *LocalAuthService {
+	return &LocalAuthService{
+		GroupDirectory: newGroupDirectory(cfg, "", newAuthHTTPClient(cfg, logger), logger),
+		config:         cfg.Local,
+		accounts:       accounts,
+		logger:         logger,
+		secret:         []byte(cfg.Local.Secret),
+		codes:          make(map[string]*authorizationCode),
+	}
+}
+
+// IssuerURL is empty: there is no OAuth issuer for MCP clients to use.
+func (s *LocalAuthService) IssuerURL() string {
+	return ""
+}
+
+// CheckHealth always succeeds; the accounts live in the database, which the
+// readiness probe checks on its own.
+func (s *LocalAuthService) CheckHealth(context.Context) error {
+	return nil
+}
+
+func (s *LocalAuthService) ValidateToken(_ context.Context, tokenString string) (*services.AuthClaims, error) {
+	claims, err := s.parseToken(tokenString, tokenUseAccess)
+	if err != nil {
+		return nil, fmt.Errorf("token verification failed: %w", err)
+	}
+
+	return &services.AuthClaims{
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		Username:  claims.Username,
+		Name:      claims.Username,
+		ExpiresAt: claims.ExpiresAt.Unix(),
+		IssuedAt:  claims.IssuedAt.Unix(),
+		AuthTime:  claims.AuthTime,
+		Issuer:    claims.Issuer,
+		Audience:  localIssuer,
+	}, nil
+}
+
+func (s *LocalAuthService) parseToken(tokenString, tokenUse string) (*localClaims, error) {
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+	var claims localClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(*jwt.Token) (any, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(localIssuer),
+		jwt.WithAudience(localIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenUse != tokenUse {
+		return nil, fmt.Errorf("not an %s token", tokenUse)
+	}
+
+	return &claims, nil
+}
+
+func (s *LocalAuthService) GetUserFromClaims(ctx context.Context, claims *services.AuthClaims) (*entities.User, error) {
+	return userFromClaims(claims)
+}
+
+func (s *LocalAuthService) CreateUserFromClaims(ctx context.Context, claims *services.AuthClaims) (*entities.User, error) {
+	return userFromClaims(claims)
+}
+
+// RegistrationOpen is true when registration is allowed, or until the first
+// account exists.
+func (s *LocalAuthService) RegistrationOpen(ctx context.Context) (bool, error) {
+	if s.config.AllowRegistration {
+		return true, nil
+	}
+	count, err := s.accounts.Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+func (s *LocalAuthService) Register(ctx context.Context, username, email, password string) (*services.IssuedToken, error) {
+	name, err := entities.NewUsername(strings.TrimSpace(username))
+	if err != nil {
+		return nil, err
+	}
+	address, err := entities.NewEmailAddress(strings.TrimSpace(email))
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return nil, services.ErrInvalidPassword
+	}
+
+	s.registerMu.Lock()
+	defer s.registerMu.Unlock()
+
+	open, err := s.RegistrationOpen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return nil, services.ErrRegistrationClosed
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	account := entities.NewLocalAccount(name, address, string(hash))
+	if err := s.accounts.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Local account registered",
+		slog.String("user_id", account.UserID().String()),
+		slog.String("username", name.String()))
+
+	return s.issue(account, time.Now())
+}
+
+func (s *LocalAuthService) Login(ctx context.Context, username, password string) (*services.IssuedToken, error) {
+	account, err := s.authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(account, time.Now())
+}
+
+// authenticate returns the account when the password matches, and
+// ErrInvalidCredentials for an unknown username or a wrong password alike.
+func (s *LocalAuthService) authenticate(ctx context.Context, username, password string) (*entities.LocalAccount, error) {
+	account, err := s.accounts.GetByUsername(ctx, username)
+	if err != nil {
+		if !errors.Is(err, entities.ErrLocalAccountNotFound) {
+			return nil, err
+		}
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return nil, services.ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(account.PasswordHash()), []byte(password)); err != nil {
+		s.logger.Warn("Local sign-in failed", slog.String("username", username))
+		return nil, services.ErrInvalidCredentials
+	}
+
+	return account, nil
+}
+
+func (s *LocalAuthService) CheckRedirectURL(redirectURI string) error {
+	if redirectURI == "" || !slices.Contains(s.config.RedirectURLs, redirectURI) {
+		return services.ErrInvalidRedirectURL
+	}
+	return nil
+}
+
+func (s *LocalAuthService) Authorize(ctx context.Context, username, password, redirectURI, codeChallenge string) (string, error) {
+	if err := s.CheckRedirectURL(redirectURI); err != nil {
+		return "", err
+	}
+
+	account, err := s.authenticate(ctx, username, password)
+	if err != nil {
+		return "", err
+	}
+
+	code := rand.Text()
+	now := time.Now()
+
+	s.codesMu.Lock()
+	defer s.codesMu.Unlock()
+
+	for c, pending := range s.codes {
+		if now.After(pending.expiresAt) {
+			delete(s.codes, c)
+		}
+	}
+	s.codes[code] = &authorizationCode{
+		userID:        account.UserID(),
+		redirectURI:   redirectURI,
+		codeChallenge: codeChallenge,
+		authTime:      now,
+		expiresAt:     now.Add(authorizationCodeLifetime),
+	}
+
+	return code, nil
+}
+
+// redeemCode removes the code and returns it when it is still valid for
+// redirectURI and codeVerifier.
+func (s *LocalAuthService) redeemCode(code, redirectURI, codeVerifier string) (*authorizationCode, error) {
+	s.codesMu.Lock()
+	pending, ok := s.codes[code]
+	delete(s.codes, code)
+	s.codesMu.Unlock()
+
+	if !ok || time.Now().After(pending.expiresAt) {
+		return nil, errors.New("authorization code is invalid or expired")
+	}
+	if pending.redirectURI != redirectURI {
+		return nil, errors.New("redirect_uri does not match the authorization request")
+	}
+	if pending.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(codeVerifier))
+		challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+		if subtle.ConstantTimeCompare([]byte(challenge), []byte(pending.codeChallenge)) != 1 {
+			return nil, errors.New("code_verifier does not match the code challenge")
+		}
+	}
+
+	return pending, nil
+}
+
+// GetOIDCConfig describes the local endpoints in the shape of an OIDC
+// discovery document.
+func (s *LocalAuthService) GetOIDCConfig(context.Context, string) (map[string]any, error) {
+	backendURL := os.Getenv("BACKEND_URL")
+	if backendURL == "" {
+		backendURL = "http://localhost:3001" // Default fallback
+	}
+
+	return map[string]any{
+		"issuer":                           localIssuer,
+		"authorization_endpoint":           backendURL + "/auth/local/authorize",
+		"token_endpoint":                   backendURL + "/auth/token",
+		"end_session_endpoint":             backendURL + "/auth/local/end-session",
+		"grant_types_supported":            []string{"authorization_code", "refresh_token", "password"},
+		"code_challenge_methods_supported": []string{"S256"},
+	}, nil
+}
+
+// ProxyTokenExchange answers the token endpoint itself: authorization codes
+// from the sign-in page, refresh tokens and, for scripts, passwords. Grant
+// errors are OAuth error responses rather than Go errors, as a provider
+// would send them.
+func (s *LocalAuthService) ProxyTokenExchange(ctx context.Context, tokenRequest map[string]any) ([]byte, int, error) {
+	param := func(key string) string {
+		value, _ := tokenRequest[key].(string)
+		return value
+	}
+
+	var (
+		account  *entities.LocalAccount
+		authTime time.Time
+		err      error
+	)
+	switch grantType := param("grant_type"); grantType {
+	case "authorization_code":
+		var pending *authorizationCode
+		pending, err = s.redeemCode(param("code"), param("redirect_uri"), param("code_verifier"))
+		if err == nil {
+			account, err = s.accounts.GetByUserID(ctx, pending.userID)
+			authTime = pending.authTime
+		}
+	case "refresh_token":
+		var claims *localClaims
+		claims, err = s.parseToken(param("refresh_token"), tokenUseRefresh)
+		if err == nil {
+			account, err = s.accountForSubject(ctx, claims.Subject)
+			authTime = time.Unix(claims.AuthTime, 0)
+		}
+	case "password":
+		account, err = s.authenticate(ctx, param("username"), param("password"))
+		authTime = time.Now()
+	default:
+		return oauthError("unsupported_grant_type", fmt.Sprintf("grant_type %q is not supported", grantType))
+	}
+	if err != nil {
+		s.logger.Warn("Local token exchange rejected", slog.Any("error", err))
+		return oauthError("invalid_grant", err.Error())
+	}
+
+	token, err := s.issue(account, authTime)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"access_token":  token.AccessToken,
+		"refresh_token": token.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    token.ExpiresIn,
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return body, http.StatusOK, nil
+}
+
+func (s *LocalAuthService) accountForSubject(ctx context.Context, subject string) (*entities.LocalAccount, error) {
+	userID, err := entities.UserIDFromString(subject)
+	if err != nil {
+		return nil, err
+	}
+	return s.accounts.GetByUserID(ctx, userID)
+}
+
+func oauthError(code, description string) ([]byte, int, error) {
+	body, err := json.Marshal(map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return body, http.StatusBadRequest, nil
+}
+
+// issue signs an access token and a refresh token for the account. authTime
+// is when the password was last entered, carried over on refresh.
+func (s *LocalAuthService) issue(account *entities.LocalAccount, authTime time.Time) (*services.IssuedToken, error) {
+	now := time.Now()
+	tokenLifetime := time.Duration(s.config.TokenLifetime) * time.Second
+	refreshLifetime := time.Duration(s.config.RefreshLifetime) * time.Second
+
+	sign := func(lifetime time.Duration, tokenUse string, withProfile bool) (string, error) {
+		claims := localClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    localIssuer,
+				Subject:   account.UserID().String(),
+				Audience:  jwt.ClaimStrings{localIssuer},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+			},
+			AuthTime: authTime.Unix(),
+			TokenUse: tokenUse,
+		}
+		if withProfile {
+			claims.Email = account.EmailAddress().String()
+			claims.Username = account.Username().String()
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	}
+
+	accessToken, err := sign(tokenLifetime, tokenUseAccess, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	refreshToken, err := sign(refreshLifetime, tokenUseRefresh, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
+	}
+
+	return &services.IssuedToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.config.TokenLifetime,
+	}, nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json/v2"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+// memoryLocalAccounts is a LocalAccountRepository backed by a map.
+type memoryLocalAccounts struct {
+	mu       sync.Mutex
+	accounts map[string]*entities.LocalAccount
+}
+
+func (m *memoryLocalAccounts) Create(_ context.Context, account *entities.LocalAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := entities.NormalizeLocalUsername(account.Username().String())
+	if _, ok := m.accounts[key]; ok {
+		return entities.ErrLocalAccountExists
+	}
+	m.accounts[key] = account
+	return nil
+}
+
+func (m *memoryLocalAccounts) GetByUsername(_ context.Context, username string) (*entities.LocalAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if account, ok := m.accounts[entities.NormalizeLocalUsername(username)]; ok {
+		return account, nil
+	}
+	return nil, entities.ErrLocalAccountNotFound
+}
+
+func (m *memoryLocalAccounts) GetByUserID(_ context.Context, userID entities.UserID) (*entities.LocalAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, account := range m.accounts {
+		if account.UserID().Equals(userID) {
+			return account, nil
+		}
+	}
+	return nil, entities.ErrLocalAccountNotFound
+}
+
+func (m *memoryLocalAccounts) Count(context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.accounts)), nil
+}
+
+func (m *memoryLocalAccounts) DeleteByUserID(_ context.Context, userID entities.UserID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, account := range m.accounts {
+		if account.UserID().Equals(userID) {
+			delete(m.accounts, key)
+		}
+	}
+	return nil
+}
+
+func newTestLocalAuthService(allowRegistration bool) *LocalAuthService {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	cfg := config.AuthConfig{
+		Mode:       config.AuthModeLocal,
+		GroupClaim: "groups",
+		Local: config.LocalAuthConfig{
+			Secret:            "0123456789abcdef0123456789abcdef",
+			TokenLifetime:     3600,
+			RefreshLifetime:   86400,
+			AllowRegistration: allowRegistration,
+			RedirectURLs:      []string{"http://localhost:8080/auth/callback"},
+		},
+	}
+	return NewLocalAuthService(cfg, &memoryLocalAccounts{accounts: make(map[string]*entities.LocalAccount)}, logger)
+}
+
+func TestLocalAuthService_RegisterAndLogin(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestLocalAuthService(false)
+
+	open, err := svc.RegistrationOpen(ctx)
+	require.NoError(t, err)
+	assert.True(t, open, "the first account can always register")
+
+	_, err = svc.Register(ctx, "alice", "alice@example.com", "short")
+	assert.ErrorIs(t, err, services.ErrInvalidPassword)
+
+	token, err := svc.Register(ctx, "Alice", "alice@example.com", "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, 3600, token.ExpiresIn)
+
+	claims, err := svc.ValidateToken(ctx, "Bearer "+token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", claims.Username)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.NotZero(t, claims.AuthTime)
+
+	user, err := svc.GetUserFromClaims(ctx, claims)
+	require.NoError(t, err)
+	assert.Equal(t, claims.Subject, user.ID().String())
+
+	_, err = svc.Register(ctx, "bob", "bob@example.com", "correct horse")
+	assert.ErrorIs(t, err, services.ErrRegistrationClosed)
+
+	_, err = svc.Login(ctx, "alice", "wrong password")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	_, err = svc.Login(ctx, "nobody", "correct horse")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+
+	token, err = svc.Login(ctx, "alice", "correct horse")
+	require.NoError(t, err)
+	_, err = svc.ValidateToken(ctx, token.RefreshToken)
+	assert.Error(t, err, "a refresh token is not an access token")
+}
+
+func TestLocalAuthService_OpenRegistration(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestLocalAuthService(true)
+
+	_, err := svc.Register(ctx, "alice", "alice@example.com", "correct horse")
+	require.NoError(t, err)
+	_, err = svc.Register(ctx, "bob", "bob@example.com", "correct horse")
+	require.NoError(t, err)
+	_, err = svc.Register(ctx, "ALICE", "other@example.com", "correct horse")
+	assert.ErrorIs(t, err, entities.ErrLocalAccountExists)
+}
+
+func TestLocalAuthService_ValidateToken_RejectsOtherSecrets(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestLocalAuthService(false)
+	token, err := svc.Register(ctx, "alice", "alice@example.com", "correct horse")
+	require.NoError(t, err)
+
+	other := newTestLocalAuthService(false)
+	other.secret = []byte("a-different-secret-of-32-bytes!!")
+	_, err = other.ValidateToken(ctx, token.AccessToken)
+	assert.Error(t, err)
+}
+
+func exchange(t *testing.T, svc *LocalAuthService, req map[string]any) (map[string]any, int) {
+	t.Helper()
+	body, status, err := svc.ProxyTokenExchange(context.Background(), req)
+	require.NoError(t, err)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(body, &resp))
+	return resp, status
+}
+
+func TestLocalAuthService_AuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestLocalAuthService(false)
+	_, err := svc.Register(ctx, "alice", "alice@example.com", "correct horse")
+	require.NoError(t, err)
+
+	redirectURI := "http://localhost:8080/auth/callback"
+	verifier := "a-code-verifier-that-is-long-enough-for-pkce-0123456789"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	_, err = svc.Authorize(ctx, "alice", "correct horse", "https://evil.example/callback", challenge)
+	assert.ErrorIs(t, err, services.ErrInvalidRedirectURL)
+	_, err = svc.Authorize(ctx, "alice", "wrong password", redirectURI, challenge)
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+
+	code, err := svc.Authorize(ctx, "alice", "correct horse", redirectURI, challenge)
+	require.NoError(t, err)
+
+	resp, status := exchange(t, svc, map[string]any{
+		"grant_type": "authorization_code", "code": code, "redirect_uri": redirectURI, "code_verifier": "wrong",
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", resp["error"])
+
+	// A failed attempt burns the code
+	code, err = svc.Authorize(ctx, "alice", "correct horse", redirectURI, challenge)
+	require.NoError(t, err)
+	resp, status = exchange(t, svc, map[string]any{
+		"grant_type": "authorization_code", "code": code, "redirect_uri": redirectURI, "code_verifier": verifier,
+	})
+	require.Equal(t, http.StatusOK, status, resp)
+	assert.Equal(t, "Bearer", resp["token_type"])
+	_, err = svc.ValidateToken(ctx, resp["access_token"].(string))
+	require.NoError(t, err)
+
+	_, status = exchange(t, svc, map[string]any{
+		"grant_type": "authorization_code", "code": code, "redirect_uri": redirectURI, "code_verifier": verifier,
+	})
+	assert.Equal(t, http.StatusBadRequest, status, "codes are single use")
+
+	refreshed, status := exchange(t, svc, map[string]any{
+		"grant_type": "refresh_token", "refresh_token": resp["refresh_token"],
+	})
+	require.Equal(t, http.StatusOK, status, refreshed)
+	_, err = svc.ValidateToken(ctx, refreshed["access_token"].(string))
+	require.NoError(t, err)
+
+	_, status = exchange(t, svc, map[string]any{
+		"grant_type": "refresh_token", "refresh_token": resp["access_token"],
+	})
+	assert.Equal(t, http.StatusBadRequest, status, "an access token is not a refresh token")
+
+	resp, status = exchange(t, svc, map[string]any{"grant_type": "client_credentials"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "unsupported_grant_type", resp["error"])
+}
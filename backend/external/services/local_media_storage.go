@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+const (
+	// mediaURLPrefix is where the HTTP routes serve the media directory.
+	mediaURLPrefix = "/media/"
+	// thumbnailSize bounds the longer side of a thumbnail, in pixels.
+	thumbnailSize = 320
+	// maxMediaPixels refuses images that would take too much memory to decode.
+	maxMediaPixels = 50_000_000
+)
+
+var mediaFormats = map[string]struct {
+	format string
+	ext    string
+}{
+	"image/jpeg": {"jpeg", ".jpg"},
+	"image/png":  {"png", ".png"},
+	"image/gif":  {"gif", ".gif"},
+}
+
+// LocalMediaStorage keeps photos on the local filesystem, one directory per
+// collection. File names are random media IDs, so the directory is served
+// without auth like the image cache.
+type LocalMediaStorage struct {
+	dir    string
+	logger *slog.Logger
+}
+
+func NewLocalMediaStorage(cfg config.MediaConfig, logger *slog.Logger) (*LocalMediaStorage, error) {
+	dir := cfg.Dir
+	if dir == "" {
+		dir = "./media"
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory %s: %w", dir, err)
+	}
+
+	return &LocalMediaStorage{dir: dir, logger: logger}, nil
+}
+
+var _ services.MediaStorage = (*LocalMediaStorage)(nil)
+
+func (s *LocalMediaStorage) Save(_ context.Context, collectionID entities.CollectionID, id entities.MediaID, contentType string, data []byte) (*services.StoredMedia, error) {
+	format, ok := mediaFormats[contentType]
+	if !ok {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+
+	cfg, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format.format {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+	if cfg.Width*cfg.Height > maxMediaPixels {
+		return nil, fmt.Errorf("%w: at most %d pixels", entities.ErrMediaTooLarge, maxMediaPixels)
+	}
+
+	img, err := decodeMedia(format.format, data)
+	if err != nil {
+		return nil, entities.ErrUnsupportedMediaType
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(img, thumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	collectionDir := filepath.Join(s.dir, collectionID.String())
+	if err := os.MkdirAll(collectionDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	name := id.String() + format.ext
+	thumbName := id.String() + "_thumb.jpg"
+	if err := os.WriteFile(filepath.Join(collectionDir, name), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write photo: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(collectionDir, thumbName), thumb.Bytes(), 0o644); err != nil {
+		os.Remove(filepath.Join(collectionDir, name))
+		return nil, fmt.Errorf("failed to write thumbnail: %w", err)
+	}
+
+	s.logger.Debug("Photo stored",
+		slog.String("collection_id", collectionID.String()),
+		slog.String("media_id", id.String()),
+		slog.Int("bytes", len(data)))
+
+	prefix := mediaURLPrefix + collectionID.String() + "/"
+	return &services.StoredMedia{
+		URL:          prefix + name,
+		ThumbnailURL: prefix + thumbName,
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+	}, nil
+}
+
+func (s *LocalMediaStorage) Delete(_ context.Context, collectionID entities.CollectionID, id entities.MediaID) error {
+	matches, err := filepath.Glob(filepath.Join(s.dir, collectionID.String(), id.String()+"*"))
+	if err != nil {
+		return err
+	}
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+func (s *LocalMediaStorage) DeleteCollection(_ context.Context, collectionID entities.CollectionID) error {
+	return os.RemoveAll(filepath.Join(s.dir, collectionID.String()))
+}
+
+// CheckHealth confirms the media directory exists and accepts writes.
+func (s *LocalMediaStorage) CheckHealth(_ context.Context) error {
+	f, err := os.CreateTemp(s.dir, ".health-*")
+	if err != nil {
+		return fmt.Errorf("media directory %s is not writable: %w", s.dir, err)
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
+
+func decodeMedia(format string, data []byte) (image.Image, error) {
+	r := bytes.NewReader(data)
+	switch format {
+	case "jpeg":
+		return jpeg.Decode(r)
+	case "png":
+		return png.Decode(r)
+	default:
+		return gif.Decode(r)
+	}
+}
+
+// thumbnail scales img down so its longer side is at most size, averaging
+// the source pixels each thumbnail pixel covers. Smaller images are only
+// flattened onto white.
+func thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if w > size || h > size {
+		if w >= h {
+			tw, th = size, max(1, h*size/w)
+		} else {
+			tw, th = max(1, w*size/h), size
+		}
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Over)
+	if tw == w && th == h {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := range th {
+		y0, y1 := y*h/th, max((y+1)*h/th, y*h/th+1)
+		for x := range tw {
+			x0, x1 := x*w/tw, max((x+1)*w/tw, x*w/tw+1)
+			var r, g, bl, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4:]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					bl += uint32(p[2])
+					n++
+				}
+			}
+			d := dst.Pix[y*dst.Stride+x*4:]
+			d[0], d[1], d[2], d[3] = uint8(r/n), uint8(g/n), uint8(bl/n), 0xff
+		}
+	}
+	return dst
+}
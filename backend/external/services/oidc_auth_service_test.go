@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCAuthService_GetOIDCConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse map[string]any
+		serverStatus   int
+		backendURL     string
+		expectError    bool
+		expectedToken  string
+	}{
+		{
+			name: "successful_oidc_config_retrieval",
+			serverResponse: map[string]any{
+				"issuer":                 "https://auth.example.com/application/o/nishiki/",
+				"authorization_endpoint": "https://auth.example.com/application/o/nishiki/auth/",
+				"token_endpoint":         "https://auth.example.com/application/o/nishiki/token/",
+				"userinfo_endpoint":      "https://auth.example.com/application/o/nishiki/userinfo/",
+				"jwks_uri":               "https://auth.example.com/application/o/nishiki/jwks/",
+			},
+			serverStatus:  http.StatusOK,
+			backendURL:    "http://localhost:3001",
+			expectError:   false,
+			expectedToken: "http://localhost:3001/auth/token",
+		},
+		{
+			name:          "server_error_response",
+			serverStatus:  http.StatusInternalServerError,
+			expectError:   true,
+			expectedToken: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock server
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.serverStatus == http.StatusOK {
+					w.Header().Set("Content-Type", "application/json")
+					require.NoError(t, json.MarshalWrite(w, tt.serverResponse))
+				} else {
+					w.WriteHeader(tt.serverStatus)
+				}
+			}))
+			defer mockServer.Close()
+
+			// Setup service
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+			cfg := config.AuthConfig{
+				AuthentikURLs: []string{mockServer.URL},
+				Clients: []config.OAuthClient{
+					{
+						ProviderName: "nishiki",
+						ClientID:     "test-client",
+						ClientSecret: "test-secret",
+						RedirectURL:  "http://localhost:3001/callback",
+					},
+				},
+			}
+
+			// Create mock provider for the test client
+			clients := make(map[string]*clientProvider)
+			clients["test-client"] = &clientProvider{
+				config: cfg.Clients[0],
+				issuer: mockServer.URL + "/application/o/nishiki/",
+			}
+
+			service := &OIDCAuthService{
+				config:     cfg,
+				baseURL:    mockServer.URL,
+				clients:    clients,
+				logger:     logger,
+				httpClient: &http.Client{Timeout: 5 * time.Second},
+			}
+
+			// Set environment variable if provided
+			if tt.backendURL != "" {
+				os.Setenv("BACKEND_URL", tt.backendURL)
+				defer os.Unsetenv("BACKEND_URL")
+			}
+
+			// Execute
+			result, err := service.GetOIDCConfig(context.Background(), "test-client")
+
+			// Assert
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+				return
+			}
+
+			if result == nil {
+				t.Fatal("Expected result but got nil")
+			}
+
+			// Verify token endpoint was replaced
+			actual := result["token_endpoint"]
+			if actual != tt.expectedToken {
+				t.Errorf("Expected token_endpoint %q, got %q", tt.expectedToken, actual)
+			}
+
+			// Verify other fields are preserved
+			if tt.serverResponse != nil {
+				for key, expected := range tt.serverResponse {
+					if key == "token_endpoint" {
+						continue // We expect this to be modified
+					}
+					actual := result[key]
+					if actual != expected {
+						t.Errorf("Expected %s to be %v, got %v", key, expected, actual)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOIDCAuthService_ProxyTokenExchange(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputRequest      map[string]any
+		serverResponse    map[string]any
+		serverStatus      int
+		expectError       bool
+		expectedStatus    int
+		verifyCredentials bool
+	}{
+		{
+			name: "successful_authorization_code_exchange",
+			inputRequest: map[string]any{
+				"grant_type":   "authorization_code",
+				"code":         "test-auth-code",
+				"redirect_uri": "http://localhost:3000/callback",
+			},
+			serverResponse: map[string]any{
+				"access_token":  "access-token-123",
+				"token_type":    "Bearer",
+				"expires_in":    3600,
+				"refresh_token": "refresh-token-123",
+				"id_token":      "id.token.jwt",
+			},
+			serverStatus:      http.StatusOK,
+			expectError:       false,
+			expectedStatus:    http.StatusOK,
+			verifyCredentials: true,
+		},
+		{
+			name: "successful_refresh_token_exchange",
+			inputRequest: map[string]any{
+				"grant_type":    "refresh_token",
+				"refresh_token": "refresh-token-123",
+				"client_id":     "test-client-id",
+			},
+			serverResponse: map[string]any{
+				"access_token":  "new-access-token-456",
+				"token_type":    "Bearer",
+				"expires_in":    3600,
+				"refresh_token": "new-refresh-token-456",
+				"id_token":      "new.id.token.jwt",
+			},
+			serverStatus:      http.StatusOK,
+			expectError:       false,
+			expectedStatus:    http.StatusOK,
+			verifyCredentials: true,
+		},
+		{
+			name: "server_error_invalid_grant",
+			inputRequest: map[string]any{
+				"grant_type": "authorization_code",
+				"code":       "invalid-code",
+				"client_id":  "test-client-id",
+			},
+			serverResponse: map[string]any{
+				"error":             "invalid_grant",
+				"error_description": "Authorization code is invalid",
+			},
+			serverStatus:   http.StatusBadRequest,
+			expectError:    false, // Error should be in response, not Go error
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedFormData map[string]string
+
+			// Setup mock server
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Capture form data for verification
+				r.ParseForm()
+				receivedFormData = make(map[string]string)
+				for key, values := range r.Form {
+					if len(values) > 0 {
+						receivedFormData[key] = values[0]
+					}
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				require.NoError(t, json.MarshalWrite(w, tt.serverResponse))
+			}))
+			defer mockServer.Close()
+
+			// Setup service
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+			cfg := config.AuthConfig{
+				AuthentikURLs: []string{mockServer.URL},
+				Clients: []config.OAuthClient{
+					{
+						ProviderName: "nishiki",
+						ClientID:     "test-client-id",
+						ClientSecret: "test-client-secret",
+						RedirectURL:  "http://localhost:3000/callback",
+					},
+				},
+			}
+
+			// Create mock provider for the test client
+			clients := make(map[string]*clientProvider)
+
+			clients["test-client-id"] = &clientProvider{
+				config:   cfg.Clients[0],
+				tokenURL: mockServer.URL + "/application/o/token/",
+			}
+
+			service := &OIDCAuthService{
+				config:     cfg,
+				baseURL:    mockServer.URL,
+				clients:    clients,
+				logger:     logger,
+				httpClient: &http.Client{Timeout: 5 * time.Second},
+			}
+
+			// Execute
+			responseBody, statusCode, err := service.ProxyTokenExchange(context.Background(), tt.inputRequest)
+
+			// Assert error expectation
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+				return
+			}
+
+			// Assert status code
+			if statusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, statusCode)
+			}
+
+			// Assert response body is valid JSON
+			var actualResponse map[string]any
+			if err := json.Unmarshal(responseBody, &actualResponse); err != nil {
+				t.Errorf("Response body is not valid JSON: %v", err)
+				return
+			}
+
+			// Verify response content matches expected
+			for key, expected := range tt.serverResponse {
+				actual, exists := actualResponse[key]
+				if !exists {
+					t.Errorf("Expected response to contain key %q", key)
+					continue
+				}
+
+				// Handle numeric values (JSON unmarshaling converts to float64)
+				if expectedInt, ok := expected.(int); ok {
+					if actualFloat, ok := actual.(float64); ok {
+						if int(actualFloat) != expectedInt {
+							t.Errorf("Expected %s to be %v, got %v", key, expected, actual)
+						}
+						continue
+					}
+				}
+
+				if actual != expected {
+					t.Errorf("Expected %s to be %v, got %v", key, expected, actual)
+				}
+			}
+
+			// Verify client credentials were injected
+			if tt.verifyCredentials {
+				if receivedFormData["client_id"] != "test-client-id" {
+					t.Errorf("Expected client_id to be 'test-client-id', got %q", receivedFormData["client_id"])
+				}
+				if receivedFormData["client_secret"] != "test-client-secret" {
+					t.Errorf("Expected client_secret to be 'test-client-secret', got %q", receivedFormData["client_secret"])
+				}
+
+				// Verify original request data was preserved
+				for key, expected := range tt.inputRequest {
+					actual := receivedFormData[key]
+					expectedStr := fmt.Sprintf("%v", expected)
+					if actual != expectedStr {
+						t.Errorf("Expected form field %s to be %q, got %q", key, expectedStr, actual)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOIDCAuthService_ReloadClients(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issuer, ok := strings.CutSuffix(r.URL.Path, ".well-known/openid-configuration")
+		if !ok || strings.Contains(issuer, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.MarshalWrite(w, map[string]any{
+			"issuer":                 server.URL + issuer,
+			"authorization_endpoint": server.URL + issuer + "authorize/",
+			"token_endpoint":         server.URL + issuer + "token/",
+			"jwks_uri":               server.URL + issuer + "jwks/",
+		}))
+	}))
+	defer server.Close()
+
+	configured := &clientProvider{config: config.OAuthClient{ProviderName: "nishiki", ClientID: "web"}}
+	service := &OIDCAuthService{
+		layout: issuerLayout{issuerURL: func(base string, client config.OAuthClient) string {
+			return fmt.Sprintf("%s/application/o/%s/", base, client.ProviderName)
+		}},
+		baseURL:    server.URL,
+		configured: map[string]*clientProvider{"web": configured},
+		clients:    map[string]*clientProvider{"web": configured},
+		logger:     slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	mobile, err := entities.NewOAuthClient(entities.OAuthClientProps{
+		ProviderName: "nishiki-mobile", ClientID: "mobile", ClientSecret: "s3cret", RedirectURL: "nishiki://callback",
+	}, now)
+	require.NoError(t, err)
+	require.NoError(t, service.ReloadClients(ctx, []*entities.OAuthClient{mobile}))
+
+	client, err := service.getClientByClientID("mobile")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/application/o/nishiki-mobile/", client.issuer)
+	assert.Equal(t, server.URL+"/application/o/nishiki-mobile/token/", client.tokenURL)
+	_, err = service.getClientByClientID("web")
+	require.NoError(t, err)
+
+	// A provider that cannot be fetched leaves the current clients alone
+	broken, err := entities.NewOAuthClient(entities.OAuthClientProps{
+		ProviderName: "missing", ClientID: "broken", ClientSecret: "s3cret", RedirectURL: "https://example.com/cb",
+	}, now)
+	require.NoError(t, err)
+	require.ErrorIs(t, service.ReloadClients(ctx, []*entities.OAuthClient{mobile, broken}), ErrOIDCProviderInit)
+	_, err = service.getClientByClientID("mobile")
+	require.NoError(t, err)
+
+	shadow, err := entities.NewOAuthClient(entities.OAuthClientProps{
+		ProviderName: "nishiki", ClientID: "web", ClientSecret: "s3cret", RedirectURL: "https://example.com/cb",
+	}, now)
+	require.NoError(t, err)
+	require.ErrorIs(t, service.ReloadClients(ctx, []*entities.OAuthClient{shadow}), entities.ErrOAuthClientConfigured)
+
+	// Dropping a registration removes it, the configured client stays
+	require.NoError(t, service.ReloadClients(ctx, nil))
+	_, err = service.getClientByClientID("mobile")
+	require.Error(t, err)
+	_, err = service.getClientByClientID("web")
+	require.NoError(t, err)
+}
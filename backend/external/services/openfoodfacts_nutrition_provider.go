@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+// OpenFoodFactsNutritionProvider looks up nutrition facts in the
+// OpenFoodFacts product database.
+type OpenFoodFactsNutritionProvider struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+	logger    *slog.Logger
+}
+
+func NewOpenFoodFactsNutritionProvider(cfg config.NutritionConfig, logger *slog.Logger) *OpenFoodFactsNutritionProvider {
+	return &OpenFoodFactsNutritionProvider{
+		baseURL:   strings.TrimRight(cfg.BaseURL, "/"),
+		userAgent: cfg.UserAgent,
+		client: &http.Client{
+			Timeout: time.Duration(cfg.Timeout) * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+type openFoodFactsResponse struct {
+	Status  int `json:"status"`
+	Product struct {
+		ServingSize string `json:"serving_size"`
+		// Nutriments values are usually numbers but some products carry
+		// them as strings.
+		Nutriments map[string]any `json:"nutriments"`
+	} `json:"product"`
+}
+
+// CheckHealth confirms the OpenFoodFacts API answers.
+func (p *OpenFoodFactsNutritionProvider) CheckHealth(ctx context.Context) error {
+	req, err := p.newRequest(ctx, p.baseURL+"/api/v2/product/0.json?fields=code")
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("openfoodfacts unreachable: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("openfoodfacts returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LookupUPC fetches the product's nutriments. Per-serving values are
+// preferred; products listed only per 100 g are reported with a 100 g
+// serving instead.
+func (p *OpenFoodFactsNutritionProvider) LookupUPC(ctx context.Context, upc string) (*entities.NutritionFacts, error) {
+	u := fmt.Sprintf("%s/api/v2/product/%s.json?fields=serving_size,nutriments", p.baseURL, url.PathEscape(upc))
+	req, err := p.newRequest(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openfoodfacts lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, entities.ErrNutritionNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("openfoodfacts returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result openFoodFactsResponse
+	if err := json.UnmarshalRead(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode openfoodfacts response: %w", err)
+	}
+	if result.Status != 1 {
+		return nil, entities.ErrNutritionNotFound
+	}
+
+	facts := nutritionFromNutriments(result.Product.Nutriments, "_serving")
+	facts.ServingSize = result.Product.ServingSize
+	if facts.Calories == nil {
+		facts = nutritionFromNutriments(result.Product.Nutriments, "_100g")
+		facts.ServingSize = "100 g"
+	}
+	if facts.Calories == nil && facts.Protein == nil && facts.Carbs == nil && facts.Fat == nil {
+		return nil, entities.ErrNutritionNotFound
+	}
+
+	p.logger.Debug("Nutrition facts found", slog.String("upc", upc))
+	return &facts, nil
+}
+
+func (p *OpenFoodFactsNutritionProvider) newRequest(ctx context.Context, u string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// nutritionFromNutriments reads the nutriments with the given suffix,
+// "_serving" or "_100g".
+func nutritionFromNutriments(nutriments map[string]any, suffix string) entities.NutritionFacts {
+	number := func(key string) *float64 {
+		switch v := nutriments[key+suffix].(type) {
+		case float64:
+			return &v
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return &f
+			}
+		}
+		return nil
+	}
+	return entities.NutritionFacts{
+		Calories: number("energy-kcal"),
+		Protein:  number("proteins"),
+		Carbs:    number("carbohydrates"),
+		Fat:      number("fat"),
+	}
+}
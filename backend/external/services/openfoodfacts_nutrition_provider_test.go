@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestOpenFoodFactsNutritionProvider_LookupUPC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Nishiki-test", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/product/0123456789012.json":
+			_, _ = w.Write([]byte(`{"status":1,"product":{"serving_size":"30 g","nutriments":{
+				"energy-kcal_serving":120,"proteins_serving":"3.5","carbohydrates_serving":20,"fat_serving":2,
+				"energy-kcal_100g":400}}}`))
+		case "/api/v2/product/11112222.json":
+			_, _ = w.Write([]byte(`{"status":1,"product":{"nutriments":{"energy-kcal_100g":52,"fat_100g":0.2}}}`))
+		case "/api/v2/product/33334444.json":
+			_, _ = w.Write([]byte(`{"status":1,"product":{"nutriments":{}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":0,"status_verbose":"product not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewOpenFoodFactsNutritionProvider(config.NutritionConfig{
+		BaseURL:   server.URL + "/",
+		UserAgent: "Nishiki-test",
+		Timeout:   5,
+	}, slog.New(slog.DiscardHandler))
+
+	t.Run("per serving values", func(t *testing.T) {
+		facts, err := provider.LookupUPC(context.Background(), "0123456789012")
+		require.NoError(t, err)
+		require.NotNil(t, facts.Calories)
+		assert.InDelta(t, 120, *facts.Calories, 0.001)
+		require.NotNil(t, facts.Protein)
+		assert.InDelta(t, 3.5, *facts.Protein, 0.001)
+		assert.Equal(t, "30 g", facts.ServingSize)
+	})
+
+	t.Run("falls back to per 100 g", func(t *testing.T) {
+		facts, err := provider.LookupUPC(context.Background(), "11112222")
+		require.NoError(t, err)
+		require.NotNil(t, facts.Calories)
+		assert.InDelta(t, 52, *facts.Calories, 0.001)
+		assert.Nil(t, facts.Protein)
+		assert.Equal(t, "100 g", facts.ServingSize)
+	})
+
+	t.Run("product without nutriments", func(t *testing.T) {
+		_, err := provider.LookupUPC(context.Background(), "33334444")
+		assert.ErrorIs(t, err, entities.ErrNutritionNotFound)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		_, err := provider.LookupUPC(context.Background(), "99998888")
+		assert.ErrorIs(t, err, entities.ErrNutritionNotFound)
+	})
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/services"
+)
+
+const (
+	reportMargin      = 15.0
+	reportLineHeight  = 6.0
+	reportImageHeight = 12.0
+)
+
+// reportColumn is one column of a report table; widths are in millimetres.
+type reportColumn struct {
+	title string
+	width float64
+	align string
+}
+
+// PDFReportRenderer renders collection reports as A4 PDF documents. Object
+// images are read from the local image cache; remote images are skipped.
+type PDFReportRenderer struct {
+	imageCacheDir string
+	logger        *slog.Logger
+}
+
+func NewPDFReportRenderer(cfg config.ImagesConfig, logger *slog.Logger) *PDFReportRenderer {
+	cacheDir := cfg.CacheDir
+	if cacheDir == "" {
+		cacheDir = "./image_cache"
+	}
+	return &PDFReportRenderer{
+		imageCacheDir: cacheDir,
+		logger:        logger,
+	}
+}
+
+func (r *PDFReportRenderer) RenderCollectionReport(_ context.Context, report *services.CollectionReport) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(reportMargin, reportMargin, reportMargin)
+	pdf.SetAutoPageBreak(true, reportMargin)
+	pdf.SetTitle(report.CollectionName+" inventory report", true)
+	pdf.SetCreator("Nishiki", true)
+	pdf.AliasNbPages("")
+
+	// The core fonts only cover cp1252, so names are translated from UTF-8.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-reportMargin + 5)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, tr(report.CollectionName), "", 0, "L", false, 0, "")
+		pdf.SetX(reportMargin)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	r.writeHeader(pdf, tr, report)
+
+	switch report.Layout {
+	case services.ReportLayoutSummary:
+		r.writeSummary(pdf, tr, report)
+	default:
+		r.writeDetailed(pdf, tr, report)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *PDFReportRenderer) writeHeader(pdf *fpdf.Fpdf, tr func(string) string, report *services.CollectionReport) {
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, tr(report.CollectionName), "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(90, 90, 90)
+	meta := "Generated " + report.GeneratedAt.Format("Jan 2, 2006 15:04 MST")
+	if report.Location != "" {
+		meta = report.Location + " · " + meta
+	}
+	pdf.CellFormat(0, reportLineHeight, tr(meta), "", 1, "L", false, 0, "")
+
+	totals := fmt.Sprintf("%d containers · %d objects", len(report.Containers), report.ObjectCount)
+	if report.TotalValue > 0 {
+		totals += " · total value " + formatReportMoney(report.TotalValue, report.CurrencyCode)
+	}
+	pdf.CellFormat(0, reportLineHeight, tr(totals), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+}
+
+func (r *PDFReportRenderer) writeSummary(pdf *fpdf.Fpdf, tr func(string) string, report *services.CollectionReport) {
+	columns := []reportColumn{
+		{"Container", 60, "L"},
+		{"Location", 45, "L"},
+		{"Objects", 20, "R"},
+		{"Value", 30, "R"},
+		{"Next expiry", 25, "R"},
+	}
+	r.writeTableHeader(pdf, columns)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, c := range report.Containers {
+		r.ensureSpace(pdf, reportLineHeight, columns)
+		cells := []string{
+			c.Name,
+			c.Location,
+			strconv.Itoa(c.ObjectCount),
+			formatReportValue(c.TotalValue, report.CurrencyCode),
+			formatReportDate(c.NextExpiry),
+		}
+		r.writeRow(pdf, tr, columns, cells, reportLineHeight)
+	}
+}
+
+func (r *PDFReportRenderer) writeDetailed(pdf *fpdf.Fpdf, tr func(string) string, report *services.CollectionReport) {
+	columns := []reportColumn{
+		{"Name", 85, "L"},
+		{"Quantity", 30, "R"},
+		{"Value", 30, "R"},
+		{"Expires", 35, "R"},
+	}
+	rowHeight := reportLineHeight
+	if report.IncludeImages {
+		columns = append([]reportColumn{{"", reportImageHeight + 2, "L"}}, columns...)
+		columns[1].width -= reportImageHeight + 2
+		rowHeight = reportImageHeight
+	}
+
+	for i, c := range report.Containers {
+		if i > 0 {
+			pdf.Ln(4)
+		}
+		// Keep the container heading together with its first row.
+		r.ensureSpace(pdf, 2*reportLineHeight+2+rowHeight, nil)
+
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Helvetica", "B", 12)
+		heading := c.Name
+		if c.Location != "" {
+			heading += " (" + c.Location + ")"
+		}
+		pdf.CellFormat(0, reportLineHeight+1, tr(heading), "", 1, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetTextColor(90, 90, 90)
+		detail := fmt.Sprintf("%d objects", c.ObjectCount)
+		if c.TotalValue > 0 {
+			detail += " · value " + formatReportMoney(c.TotalValue, report.CurrencyCode)
+		}
+		pdf.CellFormat(0, reportLineHeight-1, tr(detail), "", 1, "L", false, 0, "")
+
+		if len(c.Items) == 0 {
+			continue
+		}
+		r.writeTableHeader(pdf, columns)
+
+		pdf.SetFont("Helvetica", "", 10)
+		for _, item := range c.Items {
+			if r.ensureSpace(pdf, rowHeight, columns) {
+				pdf.SetFont("Helvetica", "", 10)
+			}
+			cells := []string{
+				item.Name,
+				formatReportQuantity(item.Quantity, item.Unit),
+				formatReportValue(derefFloat(item.Value), report.CurrencyCode),
+				formatReportDate(item.ExpiresAt),
+			}
+			if report.IncludeImages {
+				r.writeImage(pdf, item.ImageURL, pdf.GetX()+1, pdf.GetY()+1, reportImageHeight-2)
+				cells = append([]string{""}, cells...)
+			}
+			r.writeRow(pdf, tr, columns, cells, rowHeight)
+		}
+	}
+}
+
+func (r *PDFReportRenderer) writeTableHeader(pdf *fpdf.Fpdf, columns []reportColumn) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFillColor(235, 235, 235)
+	for _, col := range columns {
+		pdf.CellFormat(col.width, reportLineHeight, col.title, "", 0, col.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func (r *PDFReportRenderer) writeRow(pdf *fpdf.Fpdf, tr func(string) string, columns []reportColumn, cells []string, height float64) {
+	for i, col := range columns {
+		text := tr(cells[i])
+		// Leave room for the cell padding on both sides.
+		for text != "" && pdf.GetStringWidth(text) > col.width-2 {
+			text = strings.TrimRight(text[:len(text)-1], " ")
+			if pdf.GetStringWidth(text+"...") <= col.width-2 {
+				text += "..."
+				break
+			}
+		}
+		pdf.CellFormat(col.width, height, text, "B", 0, col.align, false, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// ensureSpace starts a new page, repeating the table header if columns is
+// set, when fewer than height millimetres remain. It reports whether a page
+// break happened.
+func (r *PDFReportRenderer) ensureSpace(pdf *fpdf.Fpdf, height float64, columns []reportColumn) bool {
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+height <= pageHeight-reportMargin {
+		return false
+	}
+	pdf.AddPage()
+	if columns != nil {
+		r.writeTableHeader(pdf, columns)
+	}
+	return true
+}
+
+// writeImage draws a cached object image at the given position. Images that
+// are missing, remote, or in a format the PDF library cannot embed are
+// skipped so one bad file does not fail the whole report.
+func (r *PDFReportRenderer) writeImage(pdf *fpdf.Fpdf, imageURL string, x, y, size float64) {
+	name, ok := strings.CutPrefix(imageURL, "/images/")
+	if !ok || name == "" {
+		return
+	}
+	path := filepath.Join(r.imageCacheDir, filepath.Base(name))
+
+	var imageType string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		imageType = "JPG"
+	case ".png":
+		imageType = "PNG"
+	case ".gif":
+		imageType = "GIF"
+	default:
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+
+	opts := fpdf.ImageOptions{ImageType: imageType}
+	info := pdf.RegisterImageOptions(path, opts)
+	if pdf.Err() {
+		r.logger.Warn("Skipping unreadable report image",
+			slog.String("path", path),
+			slog.Any("error", pdf.Error()))
+		pdf.ClearError()
+		return
+	}
+
+	// Fit the image inside a size x size box, keeping its aspect ratio.
+	w, h := size, size
+	if iw, ih := info.Width(), info.Height(); iw > 0 && ih > 0 {
+		if iw > ih {
+			h = size * ih / iw
+		} else {
+			w = size * iw / ih
+		}
+	}
+	pdf.ImageOptions(path, x+(size-w)/2, y+(size-h)/2, w, h, false, opts, 0, "")
+}
+
+func formatReportQuantity(q *float64, unit string) string {
+	if q == nil {
+		return ""
+	}
+	s := strconv.FormatFloat(*q, 'f', -1, 64)
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
+
+func formatReportValue(v float64, currencyCode string) string {
+	if v == 0 {
+		return ""
+	}
+	return formatReportMoney(v, currencyCode)
+}
+
+func formatReportMoney(v float64, currencyCode string) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	if currencyCode != "" {
+		s = currencyCode + " " + s
+	}
+	return s
+}
+
+func formatReportDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("Jan 2, 2006")
+}
+
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/services"
+)
+
+func TestPDFReportRenderer_RenderCollectionReport(t *testing.T) {
+	cacheDir := t.TempDir()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	f, err := os.Create(filepath.Join(cacheDir, "lamp.png"))
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, img))
+	require.NoError(t, f.Close())
+	// Not an image at all; the renderer must skip it rather than fail.
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "broken.jpg"), []byte("nope"), 0o644))
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	renderer := NewPDFReportRenderer(config.ImagesConfig{CacheDir: cacheDir}, logger)
+
+	qty, value := 2.0, 19.99
+	expires := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	items := []services.ReportItem{
+		{Name: "Lamp", Quantity: &qty, Unit: "pcs", Value: &value, ImageURL: "/images/lamp.png"},
+		{Name: "Crème brûlée", ExpiresAt: &expires, ImageURL: "/images/broken.jpg"},
+		{Name: "Remote", ImageURL: "https://example.com/x.png"},
+	}
+	// Enough rows to spill onto a second page.
+	for range 60 {
+		items = append(items, services.ReportItem{Name: "A rather long object name that will not fit in its column at all"})
+	}
+
+	for _, layout := range []services.ReportLayout{services.ReportLayoutSummary, services.ReportLayoutDetailed} {
+		t.Run(string(layout), func(t *testing.T) {
+			out, err := renderer.RenderCollectionReport(context.Background(), &services.CollectionReport{
+				CollectionName: "Living room",
+				Location:       "Home",
+				Layout:         layout,
+				IncludeImages:  true,
+				CurrencyCode:   "EUR",
+				GeneratedAt:    time.Now(),
+				ObjectCount:    len(items),
+				TotalValue:     value,
+				Containers: []services.ReportContainer{
+					{Name: "Shelf", Location: "North wall", ObjectCount: len(items), TotalValue: value, NextExpiry: &expires, Items: items},
+					{Name: "Empty box"},
+				},
+			})
+
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package services
+
+import (
+	"errors"
+	"math"
+)
+
+// A small QR code encoder for object labels: byte mode at error correction
+// level M, versions 1 to 10, which holds up to 213 bytes. The construction
+// follows ISO/IEC 18004.
+
+var errQRTooLong = errors.New("qr code content is too long")
+
+// qrBlocks describes how a version's codewords split into error correction
+// blocks at level M.
+type qrBlocks struct {
+	ecPerBlock int
+	group1     int // blocks with dataPerBlock data codewords
+	group2     int // blocks with dataPerBlock+1 data codewords
+	data       int // data codewords per block in group 1
+}
+
+var qrVersionsM = [...]qrBlocks{
+	1:  {10, 1, 0, 16},
+	2:  {16, 1, 0, 28},
+	3:  {26, 1, 0, 44},
+	4:  {18, 2, 0, 32},
+	5:  {24, 2, 0, 43},
+	6:  {16, 4, 0, 27},
+	7:  {18, 4, 0, 31},
+	8:  {22, 2, 2, 38},
+	9:  {22, 3, 2, 36},
+	10: {26, 4, 1, 43},
+}
+
+var qrAlignmentCenters = [...][]int{
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+func (b qrBlocks) dataCodewords() int {
+	return b.group1*b.data + b.group2*(b.data+1)
+}
+
+// qrCode is an encoded symbol; modules[y][x] is true for a dark module.
+type qrCode struct {
+	size     int
+	modules  [][]bool
+	function [][]bool // modules taken by patterns, not data
+}
+
+// encodeQR encodes text in the smallest version that fits.
+func encodeQR(text string) (*qrCode, error) {
+	data := []byte(text)
+	version := 0
+	for v := 1; v < len(qrVersionsM); v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= qrVersionsM[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errQRTooLong
+	}
+
+	q := newQRCode(version)
+	q.drawCodewords(qrAddErrorCorrection(qrDataCodewords(data, version), qrVersionsM[version]))
+
+	best, bestPenalty := 0, math.MaxInt
+	for mask := range 8 {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask) // masking twice undoes it
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+	return q, nil
+}
+
+// qrDataCodewords lays out the mode, length, bytes, terminator and padding.
+func qrDataCodewords(data []byte, version int) []byte {
+	capacity := qrVersionsM[version].dataCodewords()
+	var bits []bool
+	appendBits := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, v>>i&1 == 1)
+		}
+	}
+	appendBits(0b0100, 4) // byte mode
+	if version >= 10 {
+		appendBits(len(data), 16)
+	} else {
+		appendBits(len(data), 8)
+	}
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+	appendBits(0, min(4, capacity*8-len(bits)))
+	appendBits(0, (8-len(bits)%8)%8)
+
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := range 8 {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// qrAddErrorCorrection splits the data into blocks, appends each block's
+// Reed-Solomon codewords and interleaves the result.
+func qrAddErrorCorrection(data []byte, layout qrBlocks) []byte {
+	divisor := qrReedSolomonDivisor(layout.ecPerBlock)
+	var blocks, ecc [][]byte
+	offset := 0
+	for i := range layout.group1 + layout.group2 {
+		n := layout.data
+		if i >= layout.group1 {
+			n++
+		}
+		block := data[offset : offset+n]
+		offset += n
+		blocks = append(blocks, block)
+		ecc = append(ecc, qrReedSolomonRemainder(block, divisor))
+	}
+
+	var out []byte
+	for i := range layout.data + 1 {
+		for _, block := range blocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := range layout.ecPerBlock {
+		for _, block := range ecc {
+			out = append(out, block[i])
+		}
+	}
+	return out
+}
+
+// qrMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func qrMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= qrMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// newQRCode draws the finder, timing and alignment patterns and reserves the
+// format and version areas.
+func newQRCode(version int) *qrCode {
+	size := version*4 + 17
+	q := &qrCode{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range size {
+		q.modules[y] = make([]bool, size)
+		q.function[y] = make([]bool, size)
+	}
+
+	for i := range size {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(size-4, 3)
+	q.drawFinder(3, size-4)
+
+	centers := qrAlignmentCenters[version]
+	last := len(centers) - 1
+	for i, cx := range centers {
+		for j, cy := range centers {
+			// Skip the three corners taken by finders
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignment(cx, cy)
+		}
+	}
+
+	q.drawFormatBits(0) // reserves the area; redrawn once the mask is chosen
+	q.drawVersion(version)
+	return q
+}
+
+func (q *qrCode) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// drawFinder draws a finder pattern and its light separator around (cx, cy).
+func (q *qrCode) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			q.set(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+func (q *qrCode) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// qrFormatBits returns the 15-bit format information for level M and mask.
+func qrFormatBits(mask int) int {
+	data := 0b00<<3 | mask // level M
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (q *qrCode) drawFormatBits(mask int) {
+	bits := qrFormatBits(mask)
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	// Around the top-left finder
+	for i := range 6 {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := range 8 {
+		q.set(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.size-15+i, bit(i))
+	}
+	q.set(8, q.size-8, true) // always dark
+}
+
+// qrVersionBits returns the 18-bit version information, used from version 7.
+func qrVersionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+func (q *qrCode) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	bits := qrVersionBits(version)
+	for i := range 18 {
+		dark := bits>>i&1 == 1
+		a, b := q.size-11+i%3, i/3
+		q.set(a, b, dark)
+		q.set(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the zigzag order, two columns at a
+// time from the bottom right, skipping the vertical timing pattern.
+func (q *qrCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range q.size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if upward {
+					y = q.size - 1 - vert
+				}
+				if q.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y][x] = data[i>>3]>>(7-i&7)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+func (q *qrCode) applyMask(mask int) {
+	for y := range q.size {
+		for x := range q.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol by the rules of ISO/IEC 18004 §7.8.3; the mask
+// with the lowest score is the easiest to scan.
+func (q *qrCode) penalty() int {
+	score := 0
+	line := func(get func(i int) bool) {
+		run := 1
+		for i := 1; i <= q.size; i++ {
+			if i < q.size && get(i) == get(i-1) {
+				run++
+				continue
+			}
+			if run >= 5 {
+				score += run - 2
+			}
+			run = 1
+		}
+		// Finder-like 1:1:3:1:1 patterns with four light modules on a side
+		pattern := []bool{true, false, true, true, true, false, true}
+		for i := 0; i+7 <= q.size; i++ {
+			match := true
+			for j, dark := range pattern {
+				if get(i+j) != dark {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+			lightBefore, lightAfter := true, true
+			for j := 1; j <= 4; j++ {
+				lightBefore = lightBefore && (i-j < 0 || !get(i-j))
+				lightAfter = lightAfter && (i+6+j >= q.size || !get(i+6+j))
+			}
+			if lightBefore || lightAfter {
+				score += 40
+			}
+		}
+	}
+
+	dark := 0
+	for y := range q.size {
+		line(func(x int) bool { return q.modules[y][x] })
+		line(func(x int) bool { return q.modules[x][y] })
+		for x := range q.size {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if q.modules[y][x+1] == c && q.modules[y+1][x] == c && q.modules[y+1][x+1] == c {
+					score += 3
+				}
+			}
+		}
+	}
+	// Ten points for every 5% the dark share strays from half
+	total := q.size * q.size
+	if k := (abs(dark*20-total*10)+total-1)/total - 1; k > 0 {
+		score += k * 10
+	}
+	return score
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
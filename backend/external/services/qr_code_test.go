@@ -0,0 +1,44 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRReedSolomon(t *testing.T) {
+	// Version 1-M "HELLO WORLD" from the ISO/IEC 18004 worked example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ecc := qrReedSolomonRemainder(data, qrReedSolomonDivisor(10))
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ecc)
+}
+
+func TestQRFormatAndVersionBits(t *testing.T) {
+	assert.Equal(t, 0b101010000010010, qrFormatBits(0))
+	assert.Equal(t, 0b100000011001110, qrFormatBits(5))
+	assert.Equal(t, 0b100101010100000, qrFormatBits(7))
+	assert.Equal(t, 0x07C94, qrVersionBits(7))
+	assert.Equal(t, 0x0A4D3, qrVersionBits(10))
+}
+
+func TestEncodeQR(t *testing.T) {
+	q, err := encodeQR("65f1c0ffee")
+	require.NoError(t, err)
+	assert.Equal(t, 21, q.size, "short text fits version 1")
+
+	// Finder pattern in the top left: dark ring, light ring, dark core
+	assert.True(t, q.modules[0][0])
+	assert.False(t, q.modules[1][1])
+	assert.True(t, q.modules[3][3])
+	assert.False(t, q.modules[7][7], "separator is light")
+	assert.True(t, q.modules[q.size-8][8], "dark module")
+
+	q, err = encodeQR(strings.Repeat("a", 200))
+	require.NoError(t, err)
+	assert.Equal(t, 57, q.size, "200 bytes need version 10")
+
+	_, err = encodeQR(strings.Repeat("a", 214))
+	assert.ErrorIs(t, err, errQRTooLong)
+}
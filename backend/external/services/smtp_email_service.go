@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+)
+
+//go:embed templates/digest.html
+var templateFS embed.FS
+
+var digestTemplate = template.Must(template.New("digest.html").Funcs(template.FuncMap{
+	"formatDate":     formatDigestDate,
+	"formatQuantity": formatDigestQuantity,
+}).ParseFS(templateFS, "templates/digest.html"))
+
+type SMTPEmailService struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     *mail.Address
+	logger   *slog.Logger
+}
+
+func NewSMTPEmailService(cfg config.EmailConfig, logger *slog.Logger) (*SMTPEmailService, error) {
+	if cfg.SMTPHost == "" {
+		return nil, errors.New("smtp_host is required when email is enabled")
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", cfg.From, err)
+	}
+
+	return &SMTPEmailService{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     from,
+		logger:   logger,
+	}, nil
+}
+
+func (s *SMTPEmailService) SendDigest(ctx context.Context, to entities.EmailAddress, report *services.DigestReport) error {
+	var body bytes.Buffer
+	if err := digestTemplate.Execute(&body, report); err != nil {
+		return fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your Nishiki %s digest", report.Frequency)
+	headers := map[string]string{
+		"List-Unsubscribe":      "<" + report.UnsubscribeURL + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+	if err := s.send(ctx, to.String(), subject, body.String(), headers); err != nil {
+		return err
+	}
+
+	s.logger.Info("Digest email sent",
+		slog.String("to", to.String()),
+		slog.String("frequency", report.Frequency.String()))
+	return nil
+}
+
+func (s *SMTPEmailService) send(ctx context.Context, to, subject, htmlBody string, extraHeaders map[string]string) error {
+	var msg strings.Builder
+	msg.WriteString("From: " + s.from.String() + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	for k, v := range extraHeaders {
+		msg.WriteString(k + ": " + v + "\r\n")
+	}
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(htmlBody)
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	// Port 465 speaks TLS from the first byte; smtp.SendMail only handles
+	// STARTTLS, so the connection has to be set up by hand.
+	if s.port == 465 {
+		return s.sendImplicitTLS(ctx, addr, auth, to, []byte(msg.String()))
+	}
+
+	if err := smtp.SendMail(addr, auth, s.from.Address, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+func (s *SMTPEmailService) sendImplicitTLS(ctx context.Context, addr string, auth smtp.Auth, to string, msg []byte) error {
+	dialer := &tls.Dialer{Config: &tls.Config{ServerName: s.host}}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth failed: %w", err)
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("smtp RCPT TO failed: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func formatDigestDate(v any) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format("Jan 2, 2006")
+	case *time.Time:
+		if t != nil {
+			return t.Format("Jan 2, 2006")
+		}
+	}
+	return ""
+}
+
+func formatDigestQuantity(q *float64, unit string) string {
+	if q == nil {
+		return ""
+	}
+	s := strconv.FormatFloat(*q, 'f', -1, 64)
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
@@ -5,10 +5,12 @@ go 1.26.0
 require (
 	github.com/brianvoe/gofakeit/v7 v7.14.1
 	github.com/coreos/go-oidc/v3 v3.17.0
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-swagno/swagno/v3 v3.2.0
 	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/google/uuid v1.6.0
 	github.com/modelcontextprotocol/go-sdk v1.5.0
+	github.com/nishiki/frontend v0.0.0
 	github.com/samber/slog-multi v1.8.0
 	github.com/spf13/cast v1.10.0
 	github.com/spf13/viper v1.21.0
@@ -16,7 +18,10 @@ require (
 	github.com/swczk/go-seqlogger v0.2.0
 	go.mongodb.org/mongo-driver/v2 v2.5.1
 	go.uber.org/mock v0.6.0
+	go.yaml.in/yaml/v3 v3.0.4
 	goauthentik.io/api/v3 v3.2026020.16
+	golang.org/x/crypto v0.49.0
+	golang.org/x/image v0.26.0
 )
 
 require (
@@ -41,8 +46,6 @@ require (
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
-	go.yaml.in/yaml/v3 v3.0.4 // indirect
-	golang.org/x/crypto v0.49.0 // indirect
 	golang.org/x/oauth2 v0.36.0 // indirect
 	golang.org/x/sync v0.20.0 // indirect
 	golang.org/x/sys v0.42.0 // indirect
@@ -50,3 +53,5 @@ require (
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/nishiki/frontend => ../frontend
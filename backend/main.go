@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"net/http"
@@ -17,22 +19,64 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/nishiki/backend/app/config"
+	"github.com/nishiki/backend/app/configcheck"
 	"github.com/nishiki/backend/app/container"
+	"github.com/nishiki/backend/app/demo"
+	"github.com/nishiki/backend/app/http/middleware"
+	"github.com/nishiki/backend/app/http/openapi"
 	"github.com/nishiki/backend/app/http/routes"
+	"github.com/nishiki/backend/app/jobs"
 	mcpserver "github.com/nishiki/backend/app/mcp"
+	"github.com/nishiki/backend/app/mcp/testkit"
 	"github.com/nishiki/backend/domain/entities"
 	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/backend/domain/usecases"
+	gioweb "github.com/nishiki/frontend/gio-web"
+	"github.com/nishiki/frontend/pkg/webserve"
 )
 
 func main() {
+	mcpStdio := flag.Bool("mcp", false, "serve MCP over stdin/stdout as the user whose token is in NISHIKI_TOKEN")
+	mcpSelfTest := flag.Bool("mcp-selftest", false, "exercise every MCP tool, resource and prompt against in-memory data, then exit")
+	demoMode := flag.Bool("demo", false, "run on seeded in-memory data with sign-in bypassed; needs neither MongoDB nor Authentik")
+	checkConfig := flag.Bool("check-config", false, "validate app.toml and check that MongoDB and the identity provider answer, then exit")
+	printDefaultConfig := flag.Bool("print-default-config", false, "print an app.toml with every setting at its default and what it does, then exit")
+	flag.Parse()
+
+	if *printDefaultConfig {
+		os.Stdout.Write(config.Sample())
+		return
+	}
+	if *checkConfig {
+		os.Exit(runConfigCheck())
+	}
+
 	// Load configuration
-	cfg, err := config.Load()
+	load := config.Load
+	if *demoMode {
+		load = config.LoadDemo
+	}
+	cfg, err := load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	if *mcpSelfTest {
+		os.Exit(runMCPSelfTest(cfg))
+	}
+	if *mcpStdio {
+		os.Exit(runMCPStdio(cfg))
+	}
+
 	// Initialize dependency container
-	appContainer, err := container.NewContainer(cfg)
+	var appContainer *container.Container
+	if *demoMode {
+		// Nothing to email the digest to, and nowhere to keep its state
+		cfg.Digest.Enabled = false
+		appContainer, err = demo.NewContainer(context.Background(), cfg)
+	} else {
+		appContainer, err = container.NewContainer(cfg)
+	}
 	if err != nil {
 		log.Fatalf("Failed to initialize container: %v", err)
 	}
@@ -42,6 +86,19 @@ func main() {
 
 	// --- REST server ---
 	restHandler := routes.Setup(appContainer)
+	if *demoMode {
+		restHandler = demo.WithLogin(restHandler)
+		logger.Warn("Demo mode: data is in memory and every request is signed in as the demo user",
+			slog.String("user", demo.Username))
+	}
+	if cfg.Server.Frontend {
+		restHandler, err = withFrontend(restHandler)
+		if err != nil {
+			logger.Error("Failed to serve the web app", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logger.Info("Serving the web app", slog.String("api_prefix", routes.FrontendAPIPrefix))
+	}
 	useHTTPS := cfg.Server.TLS.Enabled && fileExists(cfg.Server.TLS.CertFile) && fileExists(cfg.Server.TLS.KeyFile)
 
 	restServer := &http.Server{
@@ -66,6 +123,12 @@ func main() {
 		Notifier:  mcpserver.NewMCPNotifier(),
 	}
 	mcpSrv := mcpserver.NewMCPServer(mctx)
+	if cfg.Server.Debug {
+		if err := verifyMCPTools(mcpSrv); err != nil {
+			logger.Error("MCP tools differ from the OpenAPI spec's x-mcp-tools", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
 
 	// Auth factory: validates Bearer token and injects user into context.
 	factory := func(r *http.Request) *mcp.Server {
@@ -77,7 +140,7 @@ func main() {
 		if err != nil {
 			return nil
 		}
-		user, err := resolveOrCreateUser(r.Context(), appContainer, claims)
+		user, err := resolveOrCreateUser(r.Context(), appContainer, claims, middleware.NewSessionInfo(r, claims))
 		if err != nil {
 			return nil
 		}
@@ -86,21 +149,54 @@ func main() {
 	}
 
 	oauthDiscovery := &mcpserver.OAuthProtectedResource{
-		Issuer: mcpIssuerURL(appContainer.AuthService, cfg),
+		Issuer: appContainer.AuthService.IssuerURL(),
 	}
 
+	// The MCP handlers ignore the path, and the SSE handler builds its message
+	// endpoint from it, so /v1 is kept rather than stripped
+	mcpVersion := middleware.APIVersionMiddleware(middleware.APIVersionConfig{
+		Sunset:     cfg.Server.LegacyAPISunsetTime(),
+		KeepPrefix: true,
+	})
 	mcpHTTPServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Server.MCPPort),
-		Handler: oauthDiscovery.Wrap(mcp.NewStreamableHTTPHandler(factory, &mcp.StreamableHTTPOptions{Stateless: true})),
+		Handler: oauthDiscovery.Wrap(mcpVersion(mcp.NewStreamableHTTPHandler(factory, &mcp.StreamableHTTPOptions{Stateless: true}))),
 	}
 	mcpSSEServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Server.MCPSSEPort),
-		Handler: oauthDiscovery.Wrap(mcp.NewSSEHandler(factory, nil)),
+		Handler: oauthDiscovery.Wrap(mcpVersion(mcp.NewSSEHandler(factory, nil))),
 	}
 
 	// Start DB connection monitor
 	mctx.Notifier.StartConnectionMonitor(context.Background(), mctx)
 
+	// Start email digest scheduler
+	var digestScheduler *jobs.DigestScheduler
+	if cfg.Digest.Enabled {
+		digestScheduler = jobs.NewDigestScheduler(appContainer, logger)
+		digestScheduler.Start(context.Background())
+		logger.Info("Digest scheduler started", slog.Int("check_interval_minutes", cfg.Digest.CheckInterval))
+	}
+
+	// Start recurring staple scheduler
+	recurrenceScheduler := jobs.NewRecurrenceScheduler(appContainer, logger)
+	recurrenceScheduler.Start(context.Background())
+	logger.Info("Recurrence scheduler started", slog.Int("check_interval_minutes", cfg.Recurrence.CheckInterval))
+
+	// Start automation expiry scheduler
+	automationScheduler := jobs.NewAutomationScheduler(appContainer, logger)
+	automationScheduler.Start(context.Background())
+	logger.Info("Automation scheduler started", slog.Int("check_interval_minutes", cfg.Automations.CheckInterval))
+
+	// Start webhook retry scheduler
+	webhookRetryScheduler := jobs.NewWebhookRetryScheduler(appContainer, logger)
+	webhookRetryScheduler.Start(context.Background())
+	logger.Info("Webhook retry scheduler started", slog.Int("retry_interval_seconds", cfg.Automations.WebhookRetryInterval))
+
+	// Pick up OAuth clients registered through another instance
+	oauthClientScheduler := jobs.NewOAuthClientScheduler(appContainer, logger)
+	oauthClientScheduler.Start(context.Background())
+
 	// --- Start all servers ---
 	go func() {
 		var err error
@@ -135,6 +231,15 @@ func main() {
 
 	logger.Info("All servers started successfully")
 
+	// SIGHUP re-reads app.toml and applies what can change without a restart
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			reloadConfig(appContainer, *demoMode)
+		}
+	}()
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -142,6 +247,13 @@ func main() {
 
 	logger.Info("Shutting down servers...")
 	mctx.Notifier.Stop()
+	if digestScheduler != nil {
+		digestScheduler.Stop()
+	}
+	recurrenceScheduler.Stop()
+	automationScheduler.Stop()
+	webhookRetryScheduler.Stop()
+	oauthClientScheduler.Stop()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -165,6 +277,51 @@ func main() {
 	}
 }
 
+// withFrontend puts the web app bundled into this binary in front of the API,
+// which moves under routes.FrontendAPIPrefix; the app is told so when its
+// page loads.
+func withFrontend(api http.Handler) (http.Handler, error) {
+	if !gioweb.HasApp() {
+		return nil, errors.New("this binary was built without app.wasm; run `just build` in frontend/ and rebuild the backend")
+	}
+	app, err := webserve.New(gioweb.FS(), webserve.Options{
+		BackendURL:   routes.FrontendAPIPrefix,
+		ConfigureApp: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return routes.WithFrontend(api, app), nil
+}
+
+// reloadConfig reads the configuration again and switches the server to it.
+// An invalid configuration is logged with what changed and the running one
+// is kept; changes that need a restart are logged and otherwise ignored.
+func reloadConfig(c *container.Container, demo bool) {
+	logger := c.GetLogger()
+	cfg, changes, err := config.Reload(c.GetConfig(), demo)
+	if err != nil {
+		logger.Error("Config reload rejected", slog.Any("error", err))
+		return
+	}
+	if err := c.ApplyConfig(cfg); err != nil {
+		logger.Error("Config reload failed", slog.Any("error", err))
+		return
+	}
+
+	for _, change := range changes {
+		if change.Restart {
+			logger.Warn("Config change needs a restart", slog.String("setting", change.Key))
+			continue
+		}
+		logger.Info("Config change applied",
+			slog.String("setting", change.Key),
+			slog.String("old", change.Old),
+			slog.String("new", change.New))
+	}
+	logger.Info("Config reloaded", slog.Int("changes", len(changes)))
+}
+
 // bearerToken extracts the token from the Authorization header or ?token= query param.
 func bearerToken(r *http.Request) string {
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
@@ -176,8 +333,9 @@ func bearerToken(r *http.Request) string {
 	return ""
 }
 
-// resolveOrCreateUser looks up or creates a user from JWT claims.
-func resolveOrCreateUser(ctx context.Context, c *container.Container, claims *services.AuthClaims) (*entities.User, error) {
+// resolveOrCreateUser looks up or creates a user from JWT claims and refuses
+// accounts an admin has disabled and sessions signed out from another device.
+func resolveOrCreateUser(ctx context.Context, c *container.Container, claims *services.AuthClaims, session middleware.SessionInfo) (*entities.User, error) {
 	user, err := c.AuthService.GetUserFromClaims(ctx, claims)
 	if err != nil {
 		user, err = c.AuthService.CreateUserFromClaims(ctx, claims)
@@ -185,25 +343,165 @@ func resolveOrCreateUser(ctx context.Context, c *container.Container, claims *se
 			return nil, fmt.Errorf("resolve user from claims: %w", err)
 		}
 	}
+	if err := c.CheckAccount().Execute(ctx, usecases.CheckAccountRequest{User: user}); err != nil {
+		if errors.Is(err, entities.ErrAccountDisabled) {
+			return nil, err
+		}
+		c.GetLogger().Warn("Failed to check account status", slog.Any("error", err))
+	}
+	if session.ID == "" {
+		// The token names no sign-in that survives a refresh
+		return user, nil
+	}
+	err = c.CheckSession().Execute(ctx, usecases.CheckSessionRequest{
+		UserID:    user.ID(),
+		SessionID: session.ID,
+		UserAgent: session.UserAgent,
+		IPAddress: session.IPAddress,
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrSessionRevoked) {
+			return nil, err
+		}
+		c.GetLogger().Warn("Failed to check session", slog.Any("error", err))
+	}
 	return user, nil
 }
 
+// runMCPStdio serves MCP over stdin/stdout for one user, the way
+// claude-desktop runs a local server. The token in NISHIKI_TOKEN is validated
+// once at startup and every request runs as its user. Logs go to stderr so
+// they stay out of the JSON-RPC stream. Returns the exit code.
+func runMCPStdio(cfg *config.Config) int {
+	token := os.Getenv("NISHIKI_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "NISHIKI_TOKEN must be set to use --mcp")
+		return 1
+	}
+
+	cfg.Logging.Stderr = true
+	appContainer, err := container.NewContainer(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize container: %v\n", err)
+		return 1
+	}
+	defer appContainer.Close()
+	logger := appContainer.GetLogger()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	claims, err := appContainer.AuthService.ValidateToken(ctx, token)
+	if err != nil {
+		logger.Error("NISHIKI_TOKEN is not valid", slog.Any("error", err))
+		return 1
+	}
+	sessionID, _ := claims.SessionID()
+	user, err := resolveOrCreateUser(ctx, appContainer, claims, middleware.SessionInfo{ID: sessionID, UserAgent: "MCP-stdio"})
+	if err != nil {
+		logger.Error("Failed to resolve MCP user", slog.Any("error", err))
+		return 1
+	}
+
+	mctx := &mcpserver.MCPContext{
+		Container: appContainer,
+		Notifier:  mcpserver.NewMCPNotifier(),
+	}
+	mctx.Notifier.StartConnectionMonitor(ctx, mctx)
+	defer mctx.Notifier.Stop()
+
+	mcpSrv := mcpserver.NewMCPServer(mctx)
+	if cfg.Server.Debug {
+		if err := verifyMCPTools(mcpSrv); err != nil {
+			logger.Error("MCP tools differ from the OpenAPI spec's x-mcp-tools", slog.Any("error", err))
+			return 1
+		}
+	}
+	mcpSrv.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(mcpserver.WithMCPUser(ctx, user, token), method, req)
+		}
+	})
+
+	logger.Info("Starting MCP stdio server", slog.String("user_id", user.ID().String()))
+	err = mcpSrv.Run(ctx, &mcpserver.BatchingStdioTransport{Reader: os.Stdin, Writer: os.Stdout})
+	if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, io.EOF) {
+		logger.Error("MCP stdio server failed", slog.Any("error", err))
+		return 1
+	}
+	return 0
+}
+
+// runMCPSelfTest runs the MCP test kit against the loaded configuration and
+// prints one line per check. It needs neither MongoDB nor Authentik, so
+// operators can verify a build before deploying it. Returns the exit code.
+func runMCPSelfTest(cfg *config.Config) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	report, err := testkit.Run(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MCP self-test could not start: %v\n", err)
+		return 1
+	}
+	for _, result := range report.Results {
+		status := "ok"
+		if result.Err != nil {
+			status = "FAIL"
+		}
+		fmt.Printf("%-4s  %-17s  %s\n", status, result.Kind, result.Name)
+		if result.Err != nil {
+			fmt.Printf("      %v\n", result.Err)
+		}
+	}
+	failed := len(report.Failed())
+	fmt.Printf("\n%d checks, %d failed\n", len(report.Results), failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runConfigCheck validates the configuration and checks the services it
+// names, printing one line per check with what to change under each that
+// failed. Returns the exit code.
+func runConfigCheck() int {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	report := configcheck.Run(ctx)
+	for _, result := range report.Results {
+		status := "ok"
+		if result.Err != nil {
+			status = "FAIL"
+		}
+		fmt.Printf("%-4s  %-17s  %s\n", status, result.Name, result.Detail)
+		if result.Err != nil {
+			fmt.Printf("      %v\n", result.Err)
+			fmt.Printf("      %s\n", result.Hint)
+		}
+	}
+	if failed := len(report.Failed()); failed > 0 {
+		fmt.Printf("\n%d of %d checks failed\n", failed, len(report.Results))
+		return 1
+	}
+	fmt.Println("\nConfiguration OK")
+	return 0
+}
+
+// verifyMCPTools checks that the OpenAPI spec documents the tools server
+// registers as they are registered. Debug builds run it at startup so a tool
+// changed without its registry entry fails fast.
+func verifyMCPTools(server *mcp.Server) error {
+	docs, err := openapi.MCPToolDocs()
+	if err != nil {
+		return err
+	}
+	return mcpserver.VerifyTools(context.Background(), server, docs)
+}
+
 func fileExists(filename string) bool {
 	_, err := os.Stat(filename)
 	return !os.IsNotExist(err)
 }
-
-// mcpIssuerURL derives the OAuth issuer URL advertised to MCP clients. It
-// uses the Authentik base URL that the auth service resolved from the ranked
-// authentik_urls config at startup, so the issuer we advertise is guaranteed
-// to be reachable from this backend (and, in the common colocated deployment,
-// from the host running mcp-remote). Returns "" if no client is configured.
-func mcpIssuerURL(auth services.AuthService, cfg *config.Config) string {
-	base := auth.IssuerBaseURL()
-	if base == "" || len(cfg.Auth.Clients) == 0 {
-		return ""
-	}
-	return fmt.Sprintf("%s/application/o/%s/",
-		strings.TrimRight(base, "/"),
-		cfg.Auth.Clients[0].ProviderName)
-}
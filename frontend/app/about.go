@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// openAboutDialog opens the About dialog and fetches the server's status
+// for it
+func (ga *GioApp) openAboutDialog() {
+	ga.widgetState.aboutDialog.Reset()
+	ga.aboutStatus = nil
+	ga.aboutStatusErr = ""
+	ga.showAbout = true
+
+	ga.goSafe(func() {
+		status, err := ga.statusClient.Get()
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to fetch server status", "error", err)
+				ga.aboutStatusErr = "Server status unavailable: " + err.Error()
+				return
+			}
+			ga.aboutStatus = status
+		})
+	})
+}
+
+func (ga *GioApp) closeAboutDialog() {
+	ga.showAbout = false
+	ga.widgetState.aboutDialog.Reset()
+}
+
+// aboutRows lists the label and value pairs the About dialog shows: this
+// app's version, then the server's once it has answered
+func (ga *GioApp) aboutRows() [][2]string {
+	rows := [][2]string{{"App version", appVersion()}}
+	if ga.config != nil {
+		rows = append(rows, [2]string{"Backend", ga.config.BackendURL})
+	}
+	status := ga.aboutStatus
+	if status == nil {
+		return rows
+	}
+	version := status.Version
+	if status.Commit != "" {
+		version += " (" + status.Commit + ")"
+	}
+	return append(rows,
+		[2]string{"Server version", version},
+		[2]string{"Server uptime", formatUptime(time.Duration(status.UptimeSeconds) * time.Second)},
+		[2]string{"Requests served", fmt.Sprintf("%d", status.Requests)},
+		[2]string{"Error rate", fmt.Sprintf("%.2f%%", status.ErrorRate*100)},
+	)
+}
+
+// formatUptime renders d in its two largest units, e.g. "3d 4h" or "12m 5s"
+func formatUptime(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	}
+}
+
+// renderAboutDialog shows the app and server versions and the server's
+// health as reported by its public status endpoint
+func (ga *GioApp) renderAboutDialog(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.aboutClose.Clicked(gtx) {
+		ga.closeAboutDialog()
+		return layout.Dimensions{}
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.aboutDialog, "About Nishiki")
+	dialogStyle.Width = unit.Dp(400)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		var children []layout.FlexChild
+		for _, row := range ga.aboutRows() {
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							label := material.Body2(ga.theme.Theme, row[0]+":")
+							label.Color = theme.ColorTextSecondary
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(material.Body1(ga.theme.Theme, row[1]).Layout),
+					)
+				})
+			}))
+		}
+		children = append(children,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				msg := ""
+				switch {
+				case ga.aboutStatusErr != "":
+					msg = ga.aboutStatusErr
+				case ga.aboutStatus == nil:
+					msg = "Checking server status..."
+				default:
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, msg)
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceStart}.Layout(gtx,
+					layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.aboutClose, "Close")),
+				)
+			}),
+		)
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+
+	if dismissed {
+		ga.closeAboutDialog()
+	}
+
+	return dims
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{42 * time.Second, "42s"},
+		{12*time.Minute + 5*time.Second, "12m 5s"},
+		{2*time.Hour + 30*time.Minute + 10*time.Second, "2h 30m"},
+		{3*24*time.Hour + 4*time.Hour + 59*time.Minute, "3d 4h"},
+	}
+	for _, tt := range tests {
+		if got := formatUptime(tt.in); got != tt.want {
+			t.Errorf("formatUptime(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
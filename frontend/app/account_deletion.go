@@ -0,0 +1,225 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	apiCommon "github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// downloadDataExport fetches the personal data export and saves it as a file
+func (ga *GioApp) downloadDataExport() {
+	if ga.dataExportInProgress || ga.currentUser == nil {
+		return
+	}
+	ga.dataExportInProgress = true
+	ga.dataExportStatus = "Preparing data export..."
+	accountID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		data, err := ga.accountsClient.ExportData(accountID)
+		var path string
+		if err == nil {
+			filename := "nishiki-data-export-" + time.Now().Format("2006-01-02") + ".json"
+			path, err = saveDownload(filename, data, "application/json")
+		}
+
+		ga.do(func() {
+			ga.dataExportInProgress = false
+			switch {
+			case apiCommon.IsCode(err, apiCommon.CodeReauthRequired):
+				ga.reauthRequired = true
+				ga.dataExportStatus = "Please sign in again before exporting your data."
+			case err != nil:
+				ga.logger.Error("Failed to export account data", "error", err)
+				ga.dataExportStatus = "Export failed: " + err.Error()
+			case path != "":
+				ga.logger.Info("Data export saved", "path", path, "bytes", len(data))
+				ga.dataExportStatus = fmt.Sprintf("Data export saved to %s", path)
+			default:
+				ga.logger.Info("Data export downloaded", "bytes", len(data))
+				ga.dataExportStatus = "Data export downloaded"
+			}
+		})
+	})
+}
+
+// openDeleteAccountDialog opens the account deletion confirmation dialog.
+func (ga *GioApp) openDeleteAccountDialog() {
+	ga.widgetState.deleteAccountEditor.SetText("")
+	ga.widgetState.deleteAccountEditor.SingleLine = true
+	ga.widgetState.deleteAccountDialog.Reset()
+	ga.deleteAccountErr = ""
+	ga.showDeleteAccount = true
+}
+
+func (ga *GioApp) closeDeleteAccountDialog() {
+	ga.showDeleteAccount = false
+	ga.widgetState.deleteAccountDialog.Reset()
+}
+
+// renderAccountDataSection renders the Download My Data and Delete Account
+// actions on the profile view.
+func (ga *GioApp) renderAccountDataSection(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.dataExportButton.Clicked(gtx) {
+		ga.downloadDataExport()
+	}
+	if ga.widgetState.deleteAccountButton.Clicked(gtx) {
+		ga.openDeleteAccountDialog()
+	}
+	if ga.widgetState.reauthButton.Clicked(gtx) {
+		ga.handleReauth()
+	}
+
+	label := "Download My Data"
+	if ga.dataExportInProgress {
+		label = "Downloading..."
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+						widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.dataExportButton, label))
+				}),
+				layout.Rigid(widgets.DangerButton(ga.theme.Theme, &ga.widgetState.deleteAccountButton, "Delete Account")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if ga.dataExportStatus == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				status := material.Body2(ga.theme.Theme, ga.dataExportStatus)
+				status.Color = theme.ColorTextSecondary
+				return status.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !ga.reauthRequired || ga.showDeleteAccount {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx,
+				widgets.AccentButton(ga.theme.Theme, &ga.widgetState.reauthButton, "Sign In Again"))
+		}),
+	)
+}
+
+// renderDeleteAccountDialog asks the user to type their username before
+// permanently deleting everything they own.
+func (ga *GioApp) renderDeleteAccountDialog(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.deleteAccountCancel.Clicked(gtx) {
+		ga.closeDeleteAccountDialog()
+		return layout.Dimensions{}
+	}
+
+	// The Sign In Again button is handled by renderAccountDataSection, which
+	// runs first in the same frame
+	if ga.widgetState.deleteAccountConfirm.Clicked(gtx) {
+		ga.handleDeleteAccount()
+	}
+
+	username := ""
+	if ga.currentUser != nil {
+		username = ga.currentUser.Name
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.deleteAccountDialog, "Delete Account")
+	dialogStyle.Width = unit.Dp(440)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					msg := material.Body1(ga.theme.Theme, "This permanently deletes all of your collections, containers, objects, their history and your saved templates. Collections shared with you by others are not affected. This cannot be undone; download your data first if you want a copy.")
+					return msg.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, fmt.Sprintf("Type %q to confirm", username), &ga.widgetState.deleteAccountEditor, username)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.deleteAccountErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					errLabel := material.Body2(ga.theme.Theme, ga.deleteAccountErr)
+					errLabel.Color = theme.ColorDanger
+					return errLabel.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				deleteLabel := "Delete Account"
+				if ga.deleteAccountInFlight {
+					deleteLabel = "Deleting..."
+				}
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceStart}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.deleteAccountCancel, "Cancel"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if !ga.reauthRequired {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.AccentButton(ga.theme.Theme, &ga.widgetState.reauthButton, "Sign In Again"))
+					}),
+					layout.Rigid(widgets.DangerButton(ga.theme.Theme, &ga.widgetState.deleteAccountConfirm, deleteLabel)),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeDeleteAccountDialog()
+	}
+
+	return dims
+}
+
+// handleDeleteAccount sends the deletion request and signs the user out once
+// the backend confirms.
+func (ga *GioApp) handleDeleteAccount() {
+	if ga.deleteAccountInFlight || ga.currentUser == nil {
+		return
+	}
+	confirm := strings.TrimSpace(ga.widgetState.deleteAccountEditor.Text())
+	if confirm != ga.currentUser.Name {
+		ga.deleteAccountErr = "The username does not match."
+		return
+	}
+
+	ga.deleteAccountInFlight = true
+	ga.deleteAccountErr = ""
+	accountID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		err := ga.accountsClient.Delete(accountID, confirm)
+		ga.do(func() {
+			ga.deleteAccountInFlight = false
+			switch {
+			case apiCommon.IsCode(err, apiCommon.CodeReauthRequired):
+				ga.reauthRequired = true
+				ga.deleteAccountErr = "For your security, please sign in again before deleting your account."
+			case err != nil:
+				ga.logger.Error("Failed to delete account", "error", err)
+				ga.deleteAccountErr = "Delete failed: " + err.Error()
+			default:
+				ga.logger.Info("Account deleted", "user_id", accountID)
+				ga.closeDeleteAccountDialog()
+				ga.handleLogout()
+			}
+		})
+	})
+}
@@ -0,0 +1,278 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// AdminUserItemState holds widget state for a single row of the admin user list
+type AdminUserItemState struct {
+	toggleButton widget.Clickable
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "3.2 MB"
+func formatBytes(n int64) string {
+	const step = 1024
+	if n < step {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(step), 0
+	for v := n / step; v >= step; v /= step {
+		div *= step
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// adminUserName names a user in the admin list. Users who own data but have
+// not signed in since accounts were tracked are known only by ID.
+func adminUserName(u types.AdminUser) string {
+	if u.Username == "" {
+		return u.ID
+	}
+	return u.Username
+}
+
+// adminUserUsage summarizes what a user stores
+func adminUserUsage(u types.AdminUser) string {
+	parts := []string{
+		fmt.Sprintf("%d collection(s)", u.Usage.Collections),
+		fmt.Sprintf("%d container(s)", u.Usage.Containers),
+		fmt.Sprintf("%d object(s)", u.Usage.Objects),
+	}
+	if u.Usage.MediaCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d photo(s), %s", u.Usage.MediaCount, formatBytes(u.Usage.MediaBytes)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// resetAdmin drops the loaded admin data when the session ends
+func (ga *GioApp) resetAdmin() {
+	ga.isAdmin = false
+	ga.adminUsers = nil
+	ga.adminStats = nil
+	ga.adminLoaded = false
+	ga.adminErr = ""
+	ga.adminUpdating = ""
+}
+
+// ensureAdminLoaded fetches the user list and system stats unless they are
+// already loaded or on their way
+func (ga *GioApp) ensureAdminLoaded() {
+	if !ga.isAdmin || ga.adminLoaded || ga.adminLoading {
+		return
+	}
+	ga.adminLoading = true
+	ga.adminErr = ""
+
+	ga.goSafe(func() {
+		users, err := ga.adminClient.ListUsers()
+		var stats *types.SystemStats
+		if err == nil {
+			stats, err = ga.adminClient.Stats()
+		}
+
+		ga.do(func() {
+			ga.adminLoading = false
+			ga.adminLoaded = true
+			if err != nil {
+				ga.logger.Error("Failed to load admin dashboard", "error", err)
+				ga.adminErr = "Could not load users: " + err.Error()
+				return
+			}
+			ga.adminUsers = users.Users
+			ga.adminStats = stats
+		})
+	})
+}
+
+// setUserDisabled disables or re-enables an account, then reloads the list
+func (ga *GioApp) setUserDisabled(user types.AdminUser, disabled bool) {
+	if ga.adminUpdating != "" {
+		return
+	}
+	ga.adminUpdating = user.ID
+	ga.adminErr = ""
+
+	ga.goSafe(func() {
+		err := ga.adminClient.SetDisabled(user.ID, disabled)
+
+		ga.do(func() {
+			ga.adminUpdating = ""
+			if err != nil {
+				ga.logger.Error("Failed to update account", "user_id", user.ID, "disabled", disabled, "error", err)
+				ga.adminErr = fmt.Sprintf("Could not update %s: %s", adminUserName(user), err.Error())
+				return
+			}
+			ga.logger.Info("Account updated", "user_id", user.ID, "disabled", disabled)
+			ga.adminLoaded = false
+		})
+	})
+}
+
+// renderAdminView renders the admin dashboard: system totals and every user
+// with what they store and a button to disable or re-enable them
+func (ga *GioApp) renderAdminView(gtx layout.Context) layout.Dimensions {
+	if !ga.isAdmin {
+		ga.currentView = ViewDashboardGio
+		return ga.renderDashboardView(gtx)
+	}
+	if ga.widgetState.adminRefresh.Clicked(gtx) && !ga.adminLoading {
+		ga.adminLoaded = false
+	}
+	ga.ensureAdminLoaded()
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderHeader(gtx, "Admin")
+		}),
+
+		// Totals and status
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing4), Right: unit.Dp(theme.Spacing4)}.Layout(gtx, ga.renderAdminSummary)
+		}),
+
+		// Users
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing3),
+				Bottom: unit.Dp(theme.Spacing20), // Space for bottom menu
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				if ga.adminLoading && len(ga.adminUsers) == 0 {
+					return widgets.SkeletonList(skeletonRows)(gtx)
+				}
+				return material.List(ga.theme.Theme, &ga.widgetState.adminUsersList).Layout(gtx, len(ga.adminUsers), func(gtx layout.Context, i int) layout.Dimensions {
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderAdminUser(gtx, ga.adminUsers[i])
+					})
+				})
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBottomMenu(gtx, ViewDashboardGio)
+		}),
+	)
+}
+
+// renderAdminSummary renders the system stats with a refresh button and the
+// loading or error message below them
+func (ga *GioApp) renderAdminSummary(gtx layout.Context) layout.Dimensions {
+	var summary string
+	if s := ga.adminStats; s != nil {
+		summary = fmt.Sprintf("%d users (%d disabled) · %d collections · %d containers · %d objects · %d photos, %s",
+			s.Users, s.DisabledUsers, s.Collections, s.Containers, s.Objects, s.MediaCount, formatBytes(s.MediaBytes))
+	}
+
+	status, statusColor := "", theme.ColorTextSecondary
+	switch {
+	case ga.adminErr != "":
+		status, statusColor = ga.adminErr, theme.ColorDanger
+	case ga.adminLoading && len(ga.adminUsers) > 0:
+		status = "Refreshing..."
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, summary)
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.adminRefresh, "Refresh")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if status == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, status)
+				label.Color = statusColor
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
+
+// renderAdminUser renders one user card. The signed-in admin gets no button:
+// the backend refuses to let admins disable themselves.
+func (ga *GioApp) renderAdminUser(gtx layout.Context, user types.AdminUser) layout.Dimensions {
+	item := ga.widgetState.adminUserItems[user.ID]
+	if item == nil {
+		item = &AdminUserItemState{}
+		ga.widgetState.adminUserItems[user.ID] = item
+	}
+	if item.toggleButton.Clicked(gtx) {
+		ga.setUserDisabled(user, !user.Disabled)
+	}
+	isSelf := ga.currentUser != nil && ga.currentUser.ID == user.ID
+
+	details := user.Email
+	if user.LastSeenAt != nil {
+		if details != "" {
+			details += " · "
+		}
+		details += "last seen " + formatHistoryTime(*user.LastSeenAt)
+	}
+
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						name := adminUserName(user)
+						if user.Disabled {
+							name += " (disabled)"
+						}
+						label := material.Body1(ga.theme.Theme, name)
+						label.Font.Weight = font.Bold
+						if user.Disabled {
+							label.Color = theme.ColorTextSecondary
+						}
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if details == "" {
+							return layout.Dimensions{}
+						}
+						label := material.Caption(ga.theme.Theme, details)
+						label.Color = theme.ColorTextSecondary
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := material.Body2(ga.theme.Theme, adminUserUsage(user))
+						return label.Layout(gtx)
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				switch {
+				case isSelf:
+					return layout.Dimensions{}
+				case ga.adminUpdating == user.ID:
+					label := material.Body2(ga.theme.Theme, "Saving...")
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				case user.Disabled:
+					return widgets.AccentButton(ga.theme.Theme, &item.toggleButton, "Enable")(gtx)
+				default:
+					return widgets.DangerButton(ga.theme.Theme, &item.toggleButton, "Disable")(gtx)
+				}
+			}),
+		)
+	})
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{3355443, "3.2 MB"},
+		{5 << 30, "5.0 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAdminUserNameAndUsage(t *testing.T) {
+	u := types.AdminUser{ID: "0190c5a2-user", Username: "alice"}
+	u.Usage.Collections = 2
+	u.Usage.Containers = 5
+	u.Usage.Objects = 40
+
+	if got := adminUserName(u); got != "alice" {
+		t.Errorf("name = %q, want alice", got)
+	}
+	if got, want := adminUserUsage(u), "2 collection(s) · 5 container(s) · 40 object(s)"; got != want {
+		t.Errorf("usage = %q, want %q", got, want)
+	}
+
+	u.Usage.MediaCount = 3
+	u.Usage.MediaBytes = 2048
+	if got, want := adminUserUsage(u), "2 collection(s) · 5 container(s) · 40 object(s) · 3 photo(s), 2.0 KB"; got != want {
+		t.Errorf("usage with photos = %q, want %q", got, want)
+	}
+
+	// Users who have not signed in since tracking began are known by ID
+	if got := adminUserName(types.AdminUser{ID: "0190c5a2-user"}); got != "0190c5a2-user" {
+		t.Errorf("name without username = %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// resetArchivedObjects forgets the archived list for the current collection,
+// refetching it if the section is open
+func (ga *GioApp) resetArchivedObjects() {
+	ga.archivedObjects = nil
+	ga.archivedObjectsLoaded = false
+	if ga.showArchivedObjects {
+		ga.fetchArchivedObjects()
+	}
+}
+
+// fetchArchivedObjects loads the archived objects for the selected collection
+func (ga *GioApp) fetchArchivedObjects() {
+	if ga.selectedCollection == nil || ga.currentUser == nil || ga.archivedObjectsLoading {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.archivedObjectsLoading = true
+
+	ga.goSafe(func() {
+		objects, err := ga.objectsClient.ListArchivedByCollection(userID, collectionID)
+
+		ga.do(func() {
+			ga.archivedObjectsLoading = false
+			if ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load archived objects", "collection_id", collectionID, "error", err)
+				ga.showAPIErrorDialog("Failed to load archived objects: " + err.Error())
+				return
+			}
+			ga.archivedObjects = objects
+			ga.archivedObjectsLoaded = true
+		})
+	})
+}
+
+// setObjectArchived archives or restores an object, moving it between the
+// active and archived lists straight away and back again if the server refuses
+func (ga *GioApp) setObjectArchived(obj Object, archived bool) {
+	if ga.currentUser == nil {
+		return
+	}
+	userID := ga.currentUser.ID
+	ga.logger.Info("Setting object archived state", "object_id", obj.ID, "archived", archived)
+
+	send := func() (*Object, error) { return ga.objectsClient.SetArchived(userID, obj.ID, archived) }
+	if archived {
+		ga.optimisticUpdate("archive object", func() {
+			ga.removeObject(obj.ID, obj.ContainerID)
+		}, func() {
+			ga.addObject(obj)
+		}, func() (func(), error) {
+			updated, err := send()
+			if err != nil {
+				return nil, err
+			}
+			return func() {
+				if ga.archivedObjectsLoaded {
+					ga.archivedObjects = append([]Object{*updated}, ga.archivedObjects...)
+				}
+			}, nil
+		})
+		return
+	}
+
+	index := slices.IndexFunc(ga.archivedObjects, func(a Object) bool { return a.ID == obj.ID })
+	ga.optimisticUpdate("restore object", func() {
+		if index >= 0 {
+			ga.archivedObjects = slices.Delete(ga.archivedObjects, index, index+1)
+		}
+		ga.addObject(obj)
+	}, func() {
+		ga.removeObject(obj.ID, obj.ContainerID)
+		if index >= 0 {
+			ga.archivedObjects = reinsert(ga.archivedObjects, index, obj)
+		}
+	}, func() (func(), error) {
+		updated, err := send()
+		if err != nil {
+			return nil, err
+		}
+		return func() { ga.updateObject(*updated, obj.ContainerID) }, nil
+	})
+}
+
+// renderArchivedSection renders the collapsible list of archived objects
+// below the active objects
+func (ga *GioApp) renderArchivedSection(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.archivedToggle.Clicked(gtx) {
+		ga.showArchivedObjects = !ga.showArchivedObjects
+		if ga.showArchivedObjects && !ga.archivedObjectsLoaded {
+			ga.fetchArchivedObjects()
+		}
+	}
+
+	if n := len(ga.archivedObjects); len(ga.widgetState.archivedRestoreButtons) < n {
+		ga.widgetState.archivedRestoreButtons = make([]widget.Clickable, n)
+	}
+	for i, obj := range ga.archivedObjects {
+		if ga.widgetState.archivedRestoreButtons[i].Clicked(gtx) {
+			ga.setObjectArchived(obj, false)
+		}
+	}
+
+	title := "▸ Archived"
+	if ga.showArchivedObjects {
+		title = "▾ Archived"
+	}
+	if ga.archivedObjectsLoaded {
+		title += fmt.Sprintf(" (%d)", len(ga.archivedObjects))
+	}
+
+	return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return widgets.ClickArea(gtx, &ga.widgetState.archivedToggle, title, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, title)
+					label.Font.Weight = font.Bold
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if !ga.showArchivedObjects {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, ga.renderArchivedList)
+			}),
+		)
+	})
+}
+
+func (ga *GioApp) renderArchivedList(gtx layout.Context) layout.Dimensions {
+	switch {
+	case ga.archivedObjectsLoading && !ga.archivedObjectsLoaded:
+		return widgets.SkeletonList(2)(gtx)
+	case len(ga.archivedObjects) == 0:
+		label := material.Body2(ga.theme.Theme, "No archived objects")
+		label.Color = theme.ColorTextSecondary
+		return label.Layout(gtx)
+	}
+
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(unit.Dp(240)))
+	list := &ga.widgetState.archivedList
+	list.Axis = layout.Vertical
+	return list.Layout(gtx, len(ga.archivedObjects), func(gtx layout.Context, index int) layout.Dimensions {
+		obj := ga.archivedObjects[index]
+		return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return material.Body2(ga.theme.Theme, obj.Name).Layout(gtx)
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							detail := ga.archivedObjectDetail(obj)
+							if detail == "" {
+								return layout.Dimensions{}
+							}
+							label := material.Caption(ga.theme.Theme, detail)
+							label.Color = theme.ColorTextSecondary
+							return label.Layout(gtx)
+						}),
+					)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return widgets.AccentButton(ga.theme.Theme, &ga.widgetState.archivedRestoreButtons[index], "Restore")(gtx)
+				}),
+			)
+		})
+	})
+}
+
+// archivedObjectDetail describes where an archived object lives and when it
+// was archived
+func (ga *GioApp) archivedObjectDetail(obj Object) string {
+	var detail string
+	for _, c := range ga.containers {
+		if c.ID == obj.ContainerID {
+			detail = c.Name
+			break
+		}
+	}
+	if obj.ArchivedAt != nil {
+		if detail != "" {
+			detail += " · "
+		}
+		detail += "archived " + obj.ArchivedAt.Local().Format("Jan 2, 2006")
+	}
+	return detail
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
+	"sync"
 	"syscall/js"
 
 	"github.com/nishiki/frontend/config"
@@ -22,6 +23,13 @@ type AuthService struct {
 	codeVerifier string
 	logger       *slog.Logger
 	backendURL   string
+	logoutURL    string
+	// keyPrefix keeps each profile's tokens apart in localStorage
+	keyPrefix string
+
+	// refreshMu lets one request refresh an expired token while the others
+	// wait for it (see GetAccessToken)
+	refreshMu sync.Mutex
 }
 
 // TokenStorage handles storing and retrieving tokens from localStorage
@@ -34,20 +42,41 @@ func NewAuthService(config *config.Config, logger *slog.Logger) *AuthService {
 		redirectURL: config.RedirectURL,
 		state:       generateRandomString(32),
 		logger:      logger,
+		logoutURL:   config.EndSessionEndpoint(),
+		keyPrefix:   profileStorageKeyPrefix(config),
 	}
 }
 
 // InitiateLogin redirects the user to Authentik for authentication
 func (as *AuthService) InitiateLogin() error {
+	return as.initiateLogin()
+}
+
+// InitiateReauth redirects to Authentik forcing a fresh credential prompt.
+// The callback picks the pending flag up via TakeReauthPending.
+func (as *AuthService) InitiateReauth() error {
+	as.storeInLocalStorage("reauth_pending", "1")
+	return as.initiateLogin(reauthParams()...)
+}
+
+// TakeReauthPending reports whether the current callback completes a
+// reauthentication, clearing the flag.
+func (as *AuthService) TakeReauthPending() bool {
+	_, err := as.getFromLocalStorage("reauth_pending")
+	as.removeFromLocalStorage("reauth_pending")
+	return err == nil
+}
+
+func (as *AuthService) initiateLogin(opts ...oauth2.AuthCodeOption) error {
 	// Generate PKCE code verifier and challenge
 	as.codeVerifier = generateRandomString(128)
 	codeChallenge := generateCodeChallenge(as.codeVerifier)
 
 	// Build authorization URL with PKCE
-	authURL := as.config.AuthCodeURL(as.state,
+	authURL := as.config.AuthCodeURL(as.state, append([]oauth2.AuthCodeOption{
 		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
 		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
-	)
+	}, opts...)...)
 
 	// Debug: Log the auth URL being generated
 	as.logger.Debug("Generated OAuth2 authorization URL", "url", authURL)
@@ -195,9 +224,8 @@ func (as *AuthService) Logout() error {
 	// Clear all auth-related data from localStorage
 	as.ClearToken()
 
-	// Optionally redirect to Authentik logout endpoint
-	logoutURL := fmt.Sprintf("%s/application/o/nishiki/end-session/", as.config.Endpoint.AuthURL[:len(as.config.Endpoint.AuthURL)-len("/application/o/authorize/")])
-	return as.redirectTo(logoutURL)
+	// End the provider session too, so the next login asks for credentials
+	return as.redirectTo(as.logoutURL)
 }
 
 // GetAccessToken returns the current access token string
@@ -208,7 +236,14 @@ func (as *AuthService) GetAccessToken() (string, error) {
 	}
 
 	if !token.Valid() {
-		// Try to refresh the token
+		// Requests sent together all find the token expired. The provider
+		// rotates refresh tokens, so only the first may refresh; the rest
+		// use the token it stored.
+		as.refreshMu.Lock()
+		defer as.refreshMu.Unlock()
+		if token, err := as.GetStoredToken(); err == nil && token.Valid() {
+			return token.AccessToken, nil
+		}
 		refreshedToken, err := as.RefreshToken()
 		if err != nil {
 			return "", fmt.Errorf("token expired and refresh failed: %w", err)
@@ -232,12 +267,12 @@ func (as *AuthService) storeToken(token *oauth2.Token) error {
 
 func (as *AuthService) storeInLocalStorage(key, value string) {
 	localStorage := js.Global().Get("localStorage")
-	localStorage.Call("setItem", key, value)
+	localStorage.Call("setItem", as.keyPrefix+key, value)
 }
 
 func (as *AuthService) getFromLocalStorage(key string) (string, error) {
 	localStorage := js.Global().Get("localStorage")
-	value := localStorage.Call("getItem", key)
+	value := localStorage.Call("getItem", as.keyPrefix+key)
 	if value.IsNull() {
 		return "", fmt.Errorf("key %s not found in localStorage", key)
 	}
@@ -246,14 +281,31 @@ func (as *AuthService) getFromLocalStorage(key string) (string, error) {
 
 func (as *AuthService) removeFromLocalStorage(key string) {
 	localStorage := js.Global().Get("localStorage")
-	localStorage.Call("removeItem", key)
+	localStorage.Call("removeItem", as.keyPrefix+key)
+}
+
+// StoreAccountID remembers the signed-in account, so the next start can load
+// its dashboard without first asking the backend who is signed in.
+func (as *AuthService) StoreAccountID(accountID string) {
+	as.storeInLocalStorage("account_id", accountID)
+}
+
+// StoredAccountID returns the account remembered by StoreAccountID, or "".
+func (as *AuthService) StoredAccountID() string {
+	accountID, err := as.getFromLocalStorage("account_id")
+	if err != nil {
+		return ""
+	}
+	return accountID
 }
 
 // ClearToken removes the stored token from localStorage
 func (as *AuthService) ClearToken() {
 	as.removeFromLocalStorage("access_token")
+	as.removeFromLocalStorage("account_id")
 	as.removeFromLocalStorage("auth_state")
 	as.removeFromLocalStorage("code_verifier")
+	as.removeFromLocalStorage("reauth_pending")
 }
 
 func (as *AuthService) redirectTo(url string) error {
@@ -25,9 +25,17 @@ type AuthService struct {
 	config      *oauth2.Config
 	logger      *slog.Logger
 	redirectURL string
+	logoutURL   string
 
-	mu    sync.RWMutex
-	token *oauth2.Token
+	// browse opens a URL for the user to sign in or out; openBrowser outside tests
+	browse func(url string) error
+
+	mu        sync.RWMutex
+	token     *oauth2.Token
+	accountID string
+	// refreshMu lets one request refresh an expired token while the others
+	// wait for it (see GetAccessToken)
+	refreshMu sync.Mutex
 }
 
 // TokenStorage is unused on desktop; tokens live in AuthService directly.
@@ -39,12 +47,24 @@ func NewAuthService(config *config.Config, logger *slog.Logger) *AuthService {
 		config:      newOAuth2Config(config),
 		redirectURL: config.RedirectURL,
 		logger:      logger,
+		logoutURL:   config.EndSessionEndpoint(),
+		browse:      openBrowser,
 	}
 }
 
 // DesktopLogin runs the full OAuth PKCE flow: opens the system browser, starts
 // a local HTTP server to receive the callback, and exchanges the code for a token.
 func (as *AuthService) DesktopLogin() (*oauth2.Token, error) {
+	return as.desktopLogin()
+}
+
+// DesktopReauth runs the login flow again, forcing the provider to ask for
+// credentials so the new token has a fresh auth_time.
+func (as *AuthService) DesktopReauth() (*oauth2.Token, error) {
+	return as.desktopLogin(reauthParams()...)
+}
+
+func (as *AuthService) desktopLogin(opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
 	codeVerifier := generateRandomString(128)
 	codeChallenge := generateCodeChallenge(codeVerifier)
 	state := generateRandomString(32)
@@ -88,11 +108,11 @@ func (as *AuthService) DesktopLogin() (*oauth2.Token, error) {
 	}()
 	defer func() { _ = server.Close() }()
 
-	authURL := as.config.AuthCodeURL(state,
+	authURL := as.config.AuthCodeURL(state, append([]oauth2.AuthCodeOption{
 		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
 		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
-	)
-	if err := openBrowser(authURL); err != nil {
+	}, opts...)...)
+	if err := as.browse(authURL); err != nil {
 		return nil, fmt.Errorf("failed to open browser: %w", err)
 	}
 
@@ -120,6 +140,9 @@ func (as *AuthService) DesktopLogin() (*oauth2.Token, error) {
 // InitiateLogin is a no-op on desktop; the full flow is in DesktopLogin.
 func (as *AuthService) InitiateLogin() error { return nil }
 
+// TakeReauthPending is always false on desktop; DesktopReauth completes in place.
+func (as *AuthService) TakeReauthPending() bool { return false }
+
 // HandleCallback is not used on desktop; the callback is captured by DesktopLogin.
 func (as *AuthService) HandleCallback() (*oauth2.Token, error) {
 	return nil, errors.New("desktop: callback is handled by the local HTTP server in DesktopLogin")
@@ -163,6 +186,13 @@ func (as *AuthService) GetAccessToken() (string, error) {
 		return "", err
 	}
 	if !token.Valid() {
+		// Only the first of the requests finding the token expired refreshes
+		// it, as the provider rotates refresh tokens; the rest use its token
+		as.refreshMu.Lock()
+		defer as.refreshMu.Unlock()
+		if token, err := as.GetStoredToken(); err == nil && token.Valid() {
+			return token.AccessToken, nil
+		}
 		refreshed, err := as.RefreshToken()
 		if err != nil {
 			return "", fmt.Errorf("token expired and refresh failed: %w", err)
@@ -172,19 +202,30 @@ func (as *AuthService) GetAccessToken() (string, error) {
 	return token.AccessToken, nil
 }
 
+// StoreAccountID remembers the signed-in account until the token is cleared.
+func (as *AuthService) StoreAccountID(accountID string) {
+	as.mu.Lock()
+	as.accountID = accountID
+	as.mu.Unlock()
+}
+
+// StoredAccountID returns the account remembered by StoreAccountID, or "".
+func (as *AuthService) StoredAccountID() string {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+	return as.accountID
+}
+
 func (as *AuthService) ClearToken() {
 	as.mu.Lock()
 	as.token = nil
+	as.accountID = ""
 	as.mu.Unlock()
 }
 
 func (as *AuthService) Logout() error {
 	as.ClearToken()
-	base := as.config.Endpoint.AuthURL
-	suffix := "/application/o/authorize/"
-	logoutBase := base[:len(base)-len(suffix)]
-	logoutURL := logoutBase + "/application/o/nishiki/end-session/"
-	return openBrowser(logoutURL)
+	return as.browse(as.logoutURL)
 }
 
 // redirectListenAddr returns "host:port" from the configured redirect URL.
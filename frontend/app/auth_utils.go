@@ -15,13 +15,32 @@ func newOAuth2Config(config *config.Config) *oauth2.Config {
 		RedirectURL: config.RedirectURL,
 		Scopes:      []string{"openid", "profile", "email", "groups", "offline_access"},
 		Endpoint: oauth2.Endpoint{
-			AuthURL:   config.AuthURL + "/application/o/authorize/",
-			TokenURL:  config.BackendURL + "/auth/token",
+			AuthURL:   config.AuthorizeEndpoint(),
+			TokenURL:  config.APIURL() + "/auth/token",
 			AuthStyle: oauth2.AuthStyleInParams,
 		},
 	}
 }
 
+// reauthParams force the provider to ask for credentials again instead of
+// reusing its session, so the issued token carries a fresh auth_time.
+func reauthParams() []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam("prompt", "login"),
+		oauth2.SetAuthURLParam("max_age", "0"),
+	}
+}
+
+// profileStorageKeyPrefix prefixes the keys a profile stores its tokens
+// under. The top-level profile has none, so it keeps the keys it used before
+// profiles existed.
+func profileStorageKeyPrefix(cfg *config.Config) string {
+	if cfg.ProfileKey() == "" {
+		return ""
+	}
+	return "profile." + cfg.ProfileKey() + "."
+}
+
 func generateRandomString(length int) string {
 	bytes := make([]byte, length)
 	// Never actually returns an error
@@ -0,0 +1,449 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+const (
+	// defaultWebhookGraceHours and maxWebhookGraceHours mirror the backend's
+	// default and limit on how long a rotated key keeps signing
+	defaultWebhookGraceHours = 24
+	maxWebhookGraceHours     = 168
+	// webhookPayloadPreview caps the body a delivery card shows
+	webhookPayloadPreview = 600
+)
+
+// webhookKeyText describes the account's signing key, e.g.
+// "Key ending 3f9a, made Jan 2, 15:04"
+func webhookKeyText(key *types.WebhookSigningKey) string {
+	if key == nil || !key.Configured {
+		return "No signing key yet. Webhooks of rules without a secret of their own are sent unsigned."
+	}
+	text := "Key ending " + key.Hint
+	if key.CreatedAt != nil {
+		text += ", made " + key.CreatedAt.Local().Format(inboxDateLayout)
+	}
+	if key.PreviousExpiresAt != nil {
+		text += ". The previous key also signs until " + key.PreviousExpiresAt.Local().Format(inboxDateLayout)
+	}
+	return text
+}
+
+// webhookDeliveryText says how a delivery went, e.g.
+// "Retrying Jan 2, 15:04 · 2 of 4 attempts made"
+func webhookDeliveryText(d types.WebhookDelivery) string {
+	switch d.Status {
+	case "succeeded":
+		if d.Attempts > 1 {
+			return fmt.Sprintf("Sent after %d attempts", d.Attempts)
+		}
+		return "Sent"
+	case "pending":
+		if d.NextAttemptAt != nil {
+			return fmt.Sprintf("Retrying %s · %d of %d attempts made",
+				d.NextAttemptAt.Local().Format(inboxDateLayout), d.Attempts, d.MaxRetries+1)
+		}
+		return "Sending"
+	case "failed":
+		if d.Attempts > 1 {
+			return fmt.Sprintf("Failed after %d attempts", d.Attempts)
+		}
+		return "Failed"
+	}
+	return d.Status
+}
+
+// webhookPayloadText indents a delivery's JSON body for reading, cutting it
+// off past webhookPayloadPreview characters
+func webhookPayloadText(payload string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(payload), "", "  "); err == nil {
+		payload = buf.String()
+	}
+	if runes := []rune(payload); len(runes) > webhookPayloadPreview {
+		return string(runes[:webhookPayloadPreview]) + "…"
+	}
+	return payload
+}
+
+// webhookGraceHours reads the grace field; blank means the default
+func webhookGraceHours(text string) (int, string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return defaultWebhookGraceHours, ""
+	}
+	hours, err := strconv.Atoi(text)
+	if err != nil || hours < 0 || hours > maxWebhookGraceHours {
+		return 0, fmt.Sprintf("Enter between 0 and %d hours", maxWebhookGraceHours)
+	}
+	return hours, ""
+}
+
+// openWebhooksDialog shows the signing key and recent deliveries, fetching
+// both
+func (ga *GioApp) openWebhooksDialog() {
+	ws := ga.widgetState
+	ws.webhookGraceEditor.SingleLine = true
+	ws.webhookGraceEditor.SetText(strconv.Itoa(defaultWebhookGraceHours))
+	ws.webhookKeyEditor.SingleLine = true
+	ws.webhookKeyEditor.ReadOnly = true
+	ga.webhookNewKey = ""
+	ga.webhooksErr = ""
+	ga.showWebhooksDialog = true
+	ga.loadWebhooks()
+}
+
+// closeWebhooksDialog hides the dialog, forgetting the key a rotation made
+func (ga *GioApp) closeWebhooksDialog() {
+	ga.showWebhooksDialog = false
+	ga.webhookSigningKey = nil
+	ga.webhookNewKey = ""
+	ga.webhookDeliveries = nil
+	ga.webhooksErr = ""
+	ga.webhookRotating = false
+	ga.webhookResending = ""
+	ga.widgetState.webhookKeyEditor.SetText("")
+	clear(ga.widgetState.webhookDeliveryItems)
+}
+
+// loadWebhooks fetches the signing key and the recent deliveries
+func (ga *GioApp) loadWebhooks() {
+	if ga.currentUser == nil || ga.webhooksLoading {
+		return
+	}
+	ga.webhooksLoading = true
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		key, keyErr := ga.automationsClient.SigningKey(accountID)
+		list, listErr := ga.automationsClient.Deliveries(accountID)
+
+		ga.doInSession(session, func() {
+			ga.webhooksLoading = false
+			if keyErr != nil {
+				ga.logger.Error("Failed to load webhook signing key", "error", keyErr)
+				ga.webhooksErr = "Could not load the signing key: " + keyErr.Error()
+			} else {
+				ga.webhookSigningKey = key
+			}
+			if listErr != nil {
+				ga.logger.Error("Failed to load webhook deliveries", "error", listErr)
+				ga.webhooksErr = "Could not load deliveries: " + listErr.Error()
+				return
+			}
+			ga.webhookDeliveries = list.Deliveries
+		})
+	})
+}
+
+// rotateWebhookKey makes a new signing key, keeping the old one for the
+// hours in the grace field
+func (ga *GioApp) rotateWebhookKey() {
+	if ga.currentUser == nil || ga.webhookRotating {
+		return
+	}
+	hours, msg := webhookGraceHours(ga.widgetState.webhookGraceEditor.Text())
+	if msg != "" {
+		ga.webhooksErr = msg
+		return
+	}
+	ga.webhookRotating = true
+	ga.webhooksErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		key, err := ga.automationsClient.RotateSigningKey(accountID, hours)
+
+		ga.doInSession(session, func() {
+			ga.webhookRotating = false
+			if err != nil {
+				ga.logger.Error("Failed to rotate webhook signing key", "error", err)
+				ga.webhooksErr = "Could not rotate the signing key: " + err.Error()
+				return
+			}
+			ga.webhookSigningKey = key
+			ga.webhookNewKey = key.Key
+			ga.widgetState.webhookKeyEditor.SetText(key.Key)
+		})
+	})
+}
+
+// resendWebhookDelivery sends a delivery again and shows how it went
+func (ga *GioApp) resendWebhookDelivery(delivery types.WebhookDelivery) {
+	if ga.currentUser == nil || ga.webhookResending != "" {
+		return
+	}
+	ga.webhookResending = delivery.ID
+	ga.webhooksErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		sent, err := ga.automationsClient.RetryDelivery(accountID, delivery.ID)
+
+		ga.doInSession(session, func() {
+			ga.webhookResending = ""
+			if err != nil {
+				ga.logger.Error("Failed to resend webhook delivery", "delivery_id", delivery.ID, "error", err)
+				ga.webhooksErr = "Could not resend the webhook: " + err.Error()
+				return
+			}
+			for i := range ga.webhookDeliveries {
+				if ga.webhookDeliveries[i].ID == sent.ID {
+					ga.webhookDeliveries[i] = *sent
+				}
+			}
+		})
+	})
+}
+
+// renderWebhooksDialog renders the signing key, with rotation, and the
+// recent deliveries
+func (ga *GioApp) renderWebhooksDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showWebhooksDialog {
+		return layout.Dimensions{}
+	}
+
+	ws := ga.widgetState
+	if ws.webhookRotate.Clicked(gtx) {
+		ga.rotateWebhookKey()
+	}
+	if ws.webhookRefresh.Clicked(gtx) {
+		ga.loadWebhooks()
+	}
+	if ws.webhooksClose.Clicked(gtx) {
+		ga.closeWebhooksDialog()
+		return layout.Dimensions{}
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ws.webhooksDialog, "Webhooks")
+	dialogStyle.Width = unit.Dp(560)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return material.List(ga.theme.Theme, &ws.webhooksDialogList).Layout(gtx, 1, func(gtx layout.Context, _ int) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(ga.renderWebhookSigningKey),
+						layout.Rigid(ga.renderWebhookDeliveries),
+					)
+				})
+			}),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.webhooksErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.webhooksErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ws.webhookRefresh, "Refresh"))
+					}),
+					layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ws.webhooksClose, "Done")),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeWebhooksDialog()
+	}
+	return dims
+}
+
+// renderWebhookSigningKey renders the key's status, the grace field and the
+// rotate button, and the new key once a rotation made one
+func (ga *GioApp) renderWebhookSigningKey(gtx layout.Context) layout.Dimensions {
+	ws := ga.widgetState
+	configured := ga.webhookSigningKey != nil && ga.webhookSigningKey.Configured
+	rotateLabel := "Create Key"
+	if configured {
+		rotateLabel = "Rotate Key"
+	}
+	if ga.webhookRotating {
+		rotateLabel = "Rotating..."
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing4)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Body1(ga.theme.Theme, "Signing key")
+				label.Font.Weight = font.Bold
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.webhooksLoading && ga.webhookSigningKey == nil {
+					return ga.mealNoteLabel(gtx, "Loading...")
+				}
+				return ga.mealNoteLabel(gtx, webhookKeyText(ga.webhookSigningKey))
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.webhookNewKey == "" {
+					return layout.Dimensions{}
+				}
+				return ga.renderFormField(gtx, "New key", &ws.webhookKeyEditor, "Copy it now; it is not shown again")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.End}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						if !configured {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderFormField(gtx, "Old key signs for (hours)", &ws.webhookGraceEditor, "0 to 168")
+						})
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx,
+							widgets.AccentButton(ga.theme.Theme, &ws.webhookRotate, rotateLabel))
+					}),
+				)
+			}),
+		)
+	})
+}
+
+// renderWebhookDeliveries renders a card per recent delivery
+func (ga *GioApp) renderWebhookDeliveries(gtx layout.Context) layout.Dimensions {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body1(ga.theme.Theme, "Recent deliveries")
+			label.Font.Weight = font.Bold
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, label.Layout)
+		}),
+	}
+	switch {
+	case ga.webhooksLoading && ga.webhookDeliveries == nil:
+		children = append(children, layout.Rigid(widgets.SkeletonList(skeletonRows)))
+	case len(ga.webhookDeliveries) == 0:
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.mealNoteLabel(gtx, "No webhooks sent in the last 30 days.")
+		}))
+	}
+	for _, d := range ga.webhookDeliveries {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderWebhookDelivery(gtx, d)
+			})
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// renderWebhookDelivery renders a delivery's rule, URL and outcome, with
+// its body on demand and a button to send it again
+func (ga *GioApp) renderWebhookDelivery(gtx layout.Context, d types.WebhookDelivery) layout.Dimensions {
+	state := ga.widgetState.webhookDeliveryItems[d.ID]
+	if state == nil {
+		state = &WebhookDeliveryState{}
+		ga.widgetState.webhookDeliveryItems[d.ID] = state
+	}
+	if state.payloadButton.Clicked(gtx) {
+		state.showPayload = !state.showPayload
+	}
+	if state.resendButton.Clicked(gtx) {
+		ga.resendWebhookDelivery(d)
+	}
+
+	title := d.RuleName
+	if title == "" {
+		title = d.Event
+	}
+	statusColor := theme.ColorTextSecondary
+	switch d.Status {
+	case "succeeded":
+		statusColor = theme.ColorConditionMint
+	case "pending":
+		statusColor = theme.ColorWarning
+	case "failed":
+		statusColor = theme.ColorDanger
+	}
+	payloadLabel := "Show body"
+	if state.showPayload {
+		payloadLabel = "Hide body"
+	}
+	resendLabel := "Resend"
+	if ga.webhookResending == d.ID {
+		resendLabel = "Sending..."
+	}
+
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body1(ga.theme.Theme, title)
+						label.Font.Weight = font.Bold
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := material.Caption(ga.theme.Theme, d.CreatedAt.Local().Format(inboxDateLayout))
+						label.Color = theme.ColorTextSecondary
+						return label.Layout(gtx)
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(ga.theme.Theme, d.Event+" → "+d.URL)
+				label.Color = theme.ColorTextSecondary
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, webhookDeliveryText(d))
+				label.Color = statusColor
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if d.LastError == "" {
+					return layout.Dimensions{}
+				}
+				label := material.Caption(ga.theme.Theme, d.LastError)
+				label.Color = theme.ColorDanger
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if !state.showPayload {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Caption(ga.theme.Theme, webhookPayloadText(d.Payload))
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+								widgets.CancelButton(ga.theme.Theme, &state.payloadButton, payloadLabel))
+						}),
+						layout.Rigid(widgets.CancelButton(ga.theme.Theme, &state.resendButton, resendLabel)),
+					)
+				})
+			}),
+		)
+	})
+}
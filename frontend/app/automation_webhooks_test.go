@@ -0,0 +1,76 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestWebhookDeliveryText(t *testing.T) {
+	next := time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)
+	tests := []struct {
+		delivery types.WebhookDelivery
+		want     string
+	}{
+		{types.WebhookDelivery{Status: "succeeded", Attempts: 1}, "Sent"},
+		{types.WebhookDelivery{Status: "succeeded", Attempts: 3, MaxRetries: 3}, "Sent after 3 attempts"},
+		{types.WebhookDelivery{Status: "pending", Attempts: 2, MaxRetries: 3, NextAttemptAt: &next}, "Retrying Jan 2, 15:04 · 2 of 4 attempts made"},
+		{types.WebhookDelivery{Status: "failed", Attempts: 1}, "Failed"},
+		{types.WebhookDelivery{Status: "failed", Attempts: 4, MaxRetries: 3}, "Failed after 4 attempts"},
+	}
+	for _, tt := range tests {
+		if got := webhookDeliveryText(tt.delivery); got != tt.want {
+			t.Errorf("webhookDeliveryText(%+v) = %q, want %q", tt.delivery, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookPayloadText(t *testing.T) {
+	if got := webhookPayloadText(`{"event":"object.created"}`); got != "{\n  \"event\": \"object.created\"\n}" {
+		t.Errorf("webhookPayloadText() = %q, want it indented", got)
+	}
+	if got := webhookPayloadText("not json"); got != "not json" {
+		t.Errorf("webhookPayloadText() = %q, want a non-JSON body as is", got)
+	}
+
+	long := `"` + strings.Repeat("é", webhookPayloadPreview) + `"`
+	got := []rune(webhookPayloadText(long))
+	if len(got) != webhookPayloadPreview+1 || got[len(got)-1] != '…' {
+		t.Errorf("preview has %d runes, want %d ending in an ellipsis", len(got), webhookPayloadPreview+1)
+	}
+}
+
+func TestWebhookGraceHours(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int
+		wantMsg bool
+	}{
+		{"", defaultWebhookGraceHours, false},
+		{" 0 ", 0, false},
+		{"168", 168, false},
+		{"169", 0, true},
+		{"a day", 0, true},
+	}
+	for _, tt := range tests {
+		got, msg := webhookGraceHours(tt.text)
+		if got != tt.want || (msg != "") != tt.wantMsg {
+			t.Errorf("webhookGraceHours(%q) = %d, %q", tt.text, got, msg)
+		}
+	}
+}
+
+func TestWebhookKeyText(t *testing.T) {
+	if got := webhookKeyText(nil); !strings.HasPrefix(got, "No signing key yet") {
+		t.Errorf("webhookKeyText(nil) = %q", got)
+	}
+	made := time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)
+	expires := made.Add(24 * time.Hour)
+	key := &types.WebhookSigningKey{Configured: true, Hint: "3f9a", CreatedAt: &made, PreviousExpiresAt: &expires}
+	want := "Key ending 3f9a, made Jan 2, 15:04. The previous key also signs until Jan 3, 15:04"
+	if got := webhookKeyText(key); got != want {
+		t.Errorf("webhookKeyText() = %q, want %q", got, want)
+	}
+}
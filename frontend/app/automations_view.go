@@ -0,0 +1,919 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+const (
+	// maxAutomationActions mirrors the backend's limit on actions per rule
+	maxAutomationActions = 5
+	// maxWebhookRetries and maxWebhookRetryBackoff mirror the backend's
+	// limits on a webhook's retry policy
+	maxWebhookRetries      = 10
+	maxWebhookRetryBackoff = 3600
+)
+
+// Trigger and action kinds, in the order the editor offers them
+var (
+	automationTriggerKinds = []string{"object_created", "tag_added", "quantity_below", "expires_within"}
+	automationActionKinds  = []string{"add_tag", "move_to_container", "add_to_shopping_list", "fire_webhook"}
+)
+
+var automationTriggerLabels = map[string]string{
+	"object_created": "Item added",
+	"tag_added":      "Tag added",
+	"quantity_below": "Quantity below",
+	"expires_within": "Expiring soon",
+}
+
+var automationActionLabels = map[string]string{
+	"add_tag":              "Add tag",
+	"move_to_container":    "Move",
+	"add_to_shopping_list": "Shopping list",
+	"fire_webhook":         "Webhook",
+}
+
+// automationActionDraft is an action of the rule being edited
+type automationActionDraft struct {
+	kind         string
+	containerID  string
+	hasSecret    bool // the saved webhook has a secret, kept when none is typed
+	tagEditor    widget.Editor
+	urlEditor    widget.Editor
+	secretEditor widget.Editor
+	// retriesEditor and backoffEditor hold a webhook's retry policy
+	retriesEditor widget.Editor
+	backoffEditor widget.Editor
+	kindButtons   map[string]*widget.Clickable
+	containers    map[string]*widget.Clickable
+	removeButton  widget.Clickable
+}
+
+func newAutomationActionDraft(action types.AutomationAction) *automationActionDraft {
+	d := &automationActionDraft{
+		kind:        action.Kind,
+		containerID: action.ContainerID,
+		hasSecret:   action.HasSecret,
+		kindButtons: make(map[string]*widget.Clickable),
+		containers:  make(map[string]*widget.Clickable),
+	}
+	d.tagEditor.SingleLine = true
+	d.tagEditor.SetText(action.Tag)
+	d.urlEditor.SingleLine = true
+	d.urlEditor.SetText(action.URL)
+	d.secretEditor.SingleLine = true
+	d.secretEditor.Mask = '•'
+	d.retriesEditor.SingleLine = true
+	d.backoffEditor.SingleLine = true
+	if action.MaxRetries > 0 {
+		d.retriesEditor.SetText(strconv.Itoa(action.MaxRetries))
+		d.backoffEditor.SetText(strconv.Itoa(action.RetryBackoff))
+	}
+	return d
+}
+
+// automationTriggerText describes what sets a rule off, e.g.
+// `Tagged "freezer"`
+func automationTriggerText(t types.AutomationTrigger) string {
+	switch t.Kind {
+	case "object_created":
+		return "An item is added"
+	case "tag_added":
+		return fmt.Sprintf("Tagged %q", t.Tag)
+	case "quantity_below":
+		return "Quantity drops below " + strconv.FormatFloat(t.Threshold, 'f', -1, 64)
+	case "expires_within":
+		if t.Days == 1 {
+			return "1 day before expiry"
+		}
+		return fmt.Sprintf("%d days before expiry", t.Days)
+	}
+	return t.Kind
+}
+
+// automationActionText describes an action; containers names the
+// containers the editor knows about
+func automationActionText(a types.AutomationAction, containers map[string]string) string {
+	switch a.Kind {
+	case "add_tag":
+		return fmt.Sprintf("tag %q", a.Tag)
+	case "move_to_container":
+		if name, ok := containers[a.ContainerID]; ok {
+			return "move to " + name
+		}
+		return "move to another container"
+	case "add_to_shopping_list":
+		return "add to the shopping list"
+	case "fire_webhook":
+		switch a.MaxRetries {
+		case 0:
+			return "post to " + a.URL
+		case 1:
+			return "post to " + a.URL + ", retried once"
+		}
+		return fmt.Sprintf("post to %s, retried up to %d times", a.URL, a.MaxRetries)
+	}
+	return a.Kind
+}
+
+// automationSummary is the line under a rule's name, e.g.
+// `Tagged "freezer" → tag "frozen", add to the shopping list`
+func automationSummary(rule types.AutomationRule, containers map[string]string) string {
+	actions := make([]string, len(rule.Actions))
+	for i, a := range rule.Actions {
+		actions[i] = automationActionText(a, containers)
+	}
+	return automationTriggerText(rule.Trigger) + " → " + strings.Join(actions, ", ")
+}
+
+// automationRuleRequest turns a saved rule back into a request, for changes
+// such as switching it off. Webhook secrets are not sent, so they are kept.
+func automationRuleRequest(rule types.AutomationRule) types.AutomationRuleRequest {
+	enabled := rule.Enabled
+	req := types.AutomationRuleRequest{
+		Name:    rule.Name,
+		Enabled: &enabled,
+		Trigger: types.AutomationTriggerRequest{
+			Kind:         rule.Trigger.Kind,
+			Tag:          rule.Trigger.Tag,
+			Threshold:    rule.Trigger.Threshold,
+			Days:         rule.Trigger.Days,
+			CollectionID: rule.Trigger.CollectionID,
+		},
+		Actions: make([]types.AutomationActionRequest, len(rule.Actions)),
+	}
+	for i, a := range rule.Actions {
+		req.Actions[i] = types.AutomationActionRequest{
+			Kind:         a.Kind,
+			Tag:          a.Tag,
+			ContainerID:  a.ContainerID,
+			URL:          a.URL,
+			MaxRetries:   a.MaxRetries,
+			RetryBackoff: a.RetryBackoff,
+		}
+	}
+	return req
+}
+
+// automationActionRequests reads the action drafts; the message says what
+// to fix when one is incomplete
+func automationActionRequests(drafts []*automationActionDraft) ([]types.AutomationActionRequest, string) {
+	if len(drafts) == 0 {
+		return nil, "Add at least one action"
+	}
+	actions := make([]types.AutomationActionRequest, 0, len(drafts))
+	for i, d := range drafts {
+		action := types.AutomationActionRequest{Kind: d.kind}
+		switch d.kind {
+		case "add_tag":
+			action.Tag = strings.TrimSpace(d.tagEditor.Text())
+			if action.Tag == "" {
+				return nil, fmt.Sprintf("Action %d needs a tag", i+1)
+			}
+		case "move_to_container":
+			if d.containerID == "" {
+				return nil, fmt.Sprintf("Action %d needs a container", i+1)
+			}
+			action.ContainerID = d.containerID
+		case "fire_webhook":
+			action.URL = strings.TrimSpace(d.urlEditor.Text())
+			if !strings.HasPrefix(action.URL, "http://") && !strings.HasPrefix(action.URL, "https://") {
+				return nil, fmt.Sprintf("Action %d needs an http:// or https:// URL", i+1)
+			}
+			action.Secret = d.secretEditor.Text()
+			if retries := strings.TrimSpace(d.retriesEditor.Text()); retries != "" {
+				n, err := strconv.Atoi(retries)
+				if err != nil || n < 0 || n > maxWebhookRetries {
+					return nil, fmt.Sprintf("Action %d can retry between 0 and %d times", i+1, maxWebhookRetries)
+				}
+				action.MaxRetries = n
+			}
+			if action.MaxRetries > 0 {
+				backoff, err := strconv.Atoi(strings.TrimSpace(d.backoffEditor.Text()))
+				if err != nil || backoff < 1 || backoff > maxWebhookRetryBackoff {
+					return nil, fmt.Sprintf("Action %d needs a first retry wait of 1 to %d seconds", i+1, maxWebhookRetryBackoff)
+				}
+				action.RetryBackoff = backoff
+			}
+		}
+		actions = append(actions, action)
+	}
+	return actions, ""
+}
+
+// automationTriggerRequest reads the trigger fields for kind; the message
+// says what to fix when they are incomplete
+func automationTriggerRequest(kind, tag, threshold, days, collectionID string) (types.AutomationTriggerRequest, string) {
+	trigger := types.AutomationTriggerRequest{Kind: kind, CollectionID: collectionID}
+	switch kind {
+	case "tag_added":
+		trigger.Tag = strings.TrimSpace(tag)
+		if trigger.Tag == "" {
+			return trigger, "Enter the tag to watch for"
+		}
+	case "quantity_below":
+		value, err := strconv.ParseFloat(strings.TrimSpace(threshold), 64)
+		if err != nil || value <= 0 {
+			return trigger, "Enter a quantity above zero"
+		}
+		trigger.Threshold = value
+	case "expires_within":
+		value, err := strconv.Atoi(strings.TrimSpace(days))
+		if err != nil || value < 0 || value > 365 {
+			return trigger, "Enter between 0 and 365 days"
+		}
+		trigger.Days = value
+	case "":
+		return trigger, "Pick what sets the rule off"
+	}
+	return trigger, ""
+}
+
+// openAutomations shows the rules, fetching them again
+func (ga *GioApp) openAutomations() {
+	ga.automationsLoaded = false
+	ga.automationsErr = ""
+	ga.navigateTo(ViewAutomationsGio)
+}
+
+// resetAutomations drops the loaded rules when the session ends
+func (ga *GioApp) resetAutomations() {
+	ga.automationRules = nil
+	ga.automationsLoaded = false
+	ga.automationsErr = ""
+	ga.automationsBusy = ""
+	ga.automationContainers = nil
+	ga.automationContainersFor = ""
+	ga.closeAutomationDialog()
+	ga.closeWebhooksDialog()
+	clear(ga.widgetState.automationItems)
+}
+
+// ensureAutomationsLoaded fetches the rules unless they are already loaded
+// or on their way
+func (ga *GioApp) ensureAutomationsLoaded() {
+	if ga.currentUser == nil || ga.automationsLoaded || ga.automationsLoading {
+		return
+	}
+	ga.automationsLoading = true
+	ga.automationsErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		list, err := ga.automationsClient.List(accountID)
+
+		ga.doInSession(session, func() {
+			ga.automationsLoading = false
+			ga.automationsLoaded = true
+			if err != nil {
+				ga.logger.Error("Failed to load automation rules", "error", err)
+				ga.automationsErr = "Could not load your rules: " + err.Error()
+				return
+			}
+			ga.automationRules = list.Rules
+		})
+	})
+}
+
+// loadAutomationContainers fetches the containers of the collection the
+// rule being edited watches, for its move actions to pick from
+func (ga *GioApp) loadAutomationContainers(collectionID string) {
+	if ga.currentUser == nil || collectionID == "" || ga.automationContainersFor == collectionID {
+		return
+	}
+	ga.automationContainersFor = collectionID
+	ga.automationContainers = nil
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		containers, err := ga.containersClient.List(accountID, collectionID, types.SortOptions{})
+
+		ga.doInSession(session, func() {
+			if ga.automationContainersFor != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load containers for automation rule", "collection_id", collectionID, "error", err)
+				ga.automationDialogErr = "Could not load containers: " + err.Error()
+				return
+			}
+			ga.automationContainers = containers
+		})
+	})
+}
+
+// automationContainerNames maps the IDs of containers the app has loaded to
+// their names
+func (ga *GioApp) automationContainerNames() map[string]string {
+	names := make(map[string]string, len(ga.containers)+len(ga.automationContainers))
+	for _, c := range ga.containers {
+		names[c.ID] = c.Name
+	}
+	for _, c := range ga.automationContainers {
+		names[c.ID] = c.Name
+	}
+	return names
+}
+
+// storeAutomationRule puts a saved rule in the list in place of its old copy
+func (ga *GioApp) storeAutomationRule(rule types.AutomationRule) {
+	for i := range ga.automationRules {
+		if ga.automationRules[i].ID == rule.ID {
+			ga.automationRules[i] = rule
+			return
+		}
+	}
+	ga.automationRules = append(ga.automationRules, rule)
+}
+
+// openCreateAutomationDialog opens the editor for a new rule
+func (ga *GioApp) openCreateAutomationDialog() {
+	if len(ga.automationRules) >= 50 {
+		ga.automationsErr = "You have 50 rules, the most there can be. Delete one to add another."
+		return
+	}
+	ga.editingAutomation = nil
+	ga.automationTriggerKind = "object_created"
+	ga.automationCollectionID = ""
+	ga.automationActionDrafts = []*automationActionDraft{newAutomationActionDraft(types.AutomationAction{Kind: "add_tag"})}
+	ws := ga.widgetState
+	ws.automationNameEditor.SetText("")
+	ws.automationTagEditor.SetText("")
+	ws.automationThresholdEditor.SetText("")
+	ws.automationDaysEditor.SetText("3")
+	ws.automationEnabled.Value = true
+	ga.openAutomationDialog()
+}
+
+// openEditAutomationDialog opens the editor for a saved rule
+func (ga *GioApp) openEditAutomationDialog(rule types.AutomationRule) {
+	ga.editingAutomation = &rule
+	ga.automationTriggerKind = rule.Trigger.Kind
+	ga.automationCollectionID = rule.Trigger.CollectionID
+	ga.automationActionDrafts = make([]*automationActionDraft, len(rule.Actions))
+	for i, a := range rule.Actions {
+		ga.automationActionDrafts[i] = newAutomationActionDraft(a)
+	}
+	ws := ga.widgetState
+	ws.automationNameEditor.SetText(rule.Name)
+	ws.automationTagEditor.SetText(rule.Trigger.Tag)
+	ws.automationThresholdEditor.SetText("")
+	if rule.Trigger.Threshold > 0 {
+		ws.automationThresholdEditor.SetText(strconv.FormatFloat(rule.Trigger.Threshold, 'f', -1, 64))
+	}
+	ws.automationDaysEditor.SetText(strconv.Itoa(rule.Trigger.Days))
+	ws.automationEnabled.Value = rule.Enabled
+	ga.openAutomationDialog()
+}
+
+func (ga *GioApp) openAutomationDialog() {
+	ws := ga.widgetState
+	ws.automationNameEditor.SingleLine = true
+	ws.automationTagEditor.SingleLine = true
+	ws.automationThresholdEditor.SingleLine = true
+	ws.automationDaysEditor.SingleLine = true
+	ga.automationDialogErr = ""
+	ws.automationDialog.Reset()
+	ga.showAutomationDialog = true
+	ga.loadAutomationContainers(ga.automationCollectionID)
+}
+
+func (ga *GioApp) closeAutomationDialog() {
+	ga.showAutomationDialog = false
+	ga.editingAutomation = nil
+	ga.automationActionDrafts = nil
+	ga.automationDialogErr = ""
+	if ga.widgetState != nil && ga.widgetState.automationDialog != nil {
+		ga.widgetState.automationDialog.Reset()
+	}
+}
+
+// submitAutomationDialog saves the rule being created or edited
+func (ga *GioApp) submitAutomationDialog() {
+	if ga.currentUser == nil || ga.automationSaving {
+		return
+	}
+	ws := ga.widgetState
+	name := strings.TrimSpace(ws.automationNameEditor.Text())
+	if name == "" {
+		ga.automationDialogErr = "Give the rule a name"
+		return
+	}
+	trigger, msg := automationTriggerRequest(ga.automationTriggerKind, ws.automationTagEditor.Text(),
+		ws.automationThresholdEditor.Text(), ws.automationDaysEditor.Text(), ga.automationCollectionID)
+	if msg != "" {
+		ga.automationDialogErr = msg
+		return
+	}
+	actions, msg := automationActionRequests(ga.automationActionDrafts)
+	if msg != "" {
+		ga.automationDialogErr = msg
+		return
+	}
+	enabled := ws.automationEnabled.Value
+	req := types.AutomationRuleRequest{Name: name, Enabled: &enabled, Trigger: trigger, Actions: actions}
+
+	accountID := ga.currentUser.ID
+	editing := ga.editingAutomation
+	session := ga.session
+	ga.automationSaving = true
+	ga.automationDialogErr = ""
+
+	ga.goSafe(func() {
+		var saved *types.AutomationRule
+		var err error
+		if editing == nil {
+			saved, err = ga.automationsClient.Create(accountID, req)
+		} else {
+			saved, err = ga.automationsClient.Update(accountID, editing.ID, req)
+		}
+
+		ga.doInSession(session, func() {
+			ga.automationSaving = false
+			if err != nil {
+				ga.logger.Error("Failed to save automation rule", "error", err)
+				ga.automationDialogErr = "Could not save the rule: " + err.Error()
+				return
+			}
+			ga.storeAutomationRule(*saved)
+			ga.closeAutomationDialog()
+		})
+	})
+}
+
+// setAutomationEnabled switches a rule on or off
+func (ga *GioApp) setAutomationEnabled(rule types.AutomationRule, enabled bool) {
+	if ga.currentUser == nil || ga.automationsBusy != "" {
+		return
+	}
+	req := automationRuleRequest(rule)
+	req.Enabled = &enabled
+	ga.automationsBusy = rule.ID
+	ga.automationsErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		saved, err := ga.automationsClient.Update(accountID, rule.ID, req)
+
+		ga.doInSession(session, func() {
+			ga.automationsBusy = ""
+			if err != nil {
+				ga.logger.Error("Failed to update automation rule", "rule_id", rule.ID, "error", err)
+				ga.automationsErr = "Could not update " + rule.Name + ": " + err.Error()
+				return
+			}
+			ga.storeAutomationRule(*saved)
+		})
+	})
+}
+
+// deleteAutomationRule removes a rule
+func (ga *GioApp) deleteAutomationRule(rule types.AutomationRule) {
+	if ga.currentUser == nil || ga.automationsBusy != "" {
+		return
+	}
+	ga.automationsBusy = rule.ID
+	ga.automationsErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		err := ga.automationsClient.Delete(accountID, rule.ID)
+
+		ga.doInSession(session, func() {
+			ga.automationsBusy = ""
+			if err != nil {
+				ga.logger.Error("Failed to delete automation rule", "rule_id", rule.ID, "error", err)
+				ga.automationsErr = "Could not delete " + rule.Name + ": " + err.Error()
+				return
+			}
+			ga.automationRules = slices.DeleteFunc(ga.automationRules, func(r types.AutomationRule) bool { return r.ID == rule.ID })
+			delete(ga.widgetState.automationItems, rule.ID)
+		})
+	})
+}
+
+// renderAutomationsView renders the user's rules, one card each, with a
+// button to add another
+func (ga *GioApp) renderAutomationsView(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.automationsNew.Clicked(gtx) {
+		ga.openCreateAutomationDialog()
+	}
+	if ga.widgetState.automationsWebhooks.Clicked(gtx) {
+		ga.openWebhooksDialog()
+	}
+	ga.ensureAutomationsLoaded()
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderHeader(gtx, "Automations")
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing4), Right: unit.Dp(theme.Spacing4)}.Layout(gtx, ga.renderAutomationsBar)
+		}),
+
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing3),
+				Bottom: unit.Dp(theme.Spacing20), // Space for bottom menu
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				if ga.automationsLoading && !ga.automationsLoaded {
+					return widgets.SkeletonList(skeletonRows)(gtx)
+				}
+				if len(ga.automationRules) == 0 {
+					return ga.mealNoteLabel(gtx, "No rules yet. A rule can tag, move or restock items as they change.")
+				}
+				names := ga.automationContainerNames()
+				return material.List(ga.theme.Theme, &ga.widgetState.automationsList).Layout(gtx, len(ga.automationRules), func(gtx layout.Context, i int) layout.Dimensions {
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderAutomationRule(gtx, ga.automationRules[i], names)
+					})
+				})
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBottomMenu(gtx, ViewProfileGio)
+		}),
+	)
+}
+
+// renderAutomationsBar renders the webhooks and new rule buttons and the
+// error below them
+func (ga *GioApp) renderAutomationsBar(gtx layout.Context) layout.Dimensions {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, "Rules run on your own changes, in the order they were made.")
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+						widgets.CancelButton(ga.theme.Theme, &ga.widgetState.automationsWebhooks, "Webhooks"))
+				}),
+				layout.Rigid(widgets.AccentButton(ga.theme.Theme, &ga.widgetState.automationsNew, "New Rule")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if ga.automationsErr == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Body2(ga.theme.Theme, ga.automationsErr)
+			label.Color = theme.ColorDanger
+			return label.Layout(gtx)
+		}),
+	)
+}
+
+// renderAutomationRule renders a rule's name, summary and on checkbox, with
+// edit and delete buttons
+func (ga *GioApp) renderAutomationRule(gtx layout.Context, rule types.AutomationRule, containers map[string]string) layout.Dimensions {
+	state := ga.widgetState.automationItems[rule.ID]
+	if state == nil {
+		state = &AutomationItemState{}
+		ga.widgetState.automationItems[rule.ID] = state
+	}
+	busy := ga.automationsBusy == rule.ID
+	if state.enabled.Update(gtx) {
+		ga.setAutomationEnabled(rule, state.enabled.Value)
+	}
+	if !busy {
+		state.enabled.Value = rule.Enabled
+	}
+	if state.editButton.Clicked(gtx) {
+		ga.openEditAutomationDialog(rule)
+	}
+	if state.deleteButton.Clicked(gtx) {
+		ga.deleteAutomationRule(rule)
+	}
+
+	detail := automationSummary(rule, containers)
+	if rule.LastRunAt != nil {
+		detail += " · last ran " + rule.LastRunAt.Local().Format(inboxDateLayout)
+	}
+
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body1(ga.theme.Theme, rule.Name)
+						label.Font.Weight = font.Bold
+						if !rule.Enabled {
+							label.Color = theme.ColorTextSecondary
+						}
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(material.CheckBox(ga.theme.Theme, &state.enabled, "On").Layout),
+				)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(ga.theme.Theme, detail)
+				label.Color = theme.ColorTextSecondary
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+								widgets.CancelButton(ga.theme.Theme, &state.editButton, "Edit"))
+						}),
+						layout.Rigid(widgets.DangerButton(ga.theme.Theme, &state.deleteButton, "Delete")),
+					)
+				})
+			}),
+		)
+	})
+}
+
+// renderAutomationDialog renders the rule editor: name, trigger, the
+// collection it watches and its actions
+func (ga *GioApp) renderAutomationDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showAutomationDialog {
+		return layout.Dimensions{}
+	}
+
+	ws := ga.widgetState
+	if ws.automationDialogSubmit.Clicked(gtx) {
+		ga.submitAutomationDialog()
+	}
+	if ws.automationDialogCancel.Clicked(gtx) {
+		ga.closeAutomationDialog()
+		return layout.Dimensions{}
+	}
+	if ws.automationAddAction.Clicked(gtx) && len(ga.automationActionDrafts) < maxAutomationActions {
+		ga.automationActionDrafts = append(ga.automationActionDrafts, newAutomationActionDraft(types.AutomationAction{Kind: "add_tag"}))
+	}
+	ga.automationActionDrafts = slices.DeleteFunc(ga.automationActionDrafts, func(d *automationActionDraft) bool {
+		return d.removeButton.Clicked(gtx)
+	})
+
+	title := "New Rule"
+	if ga.editingAutomation != nil {
+		title = "Edit Rule"
+	}
+	dialogStyle := widgets.DefaultDialogStyle(ws.automationDialog, title)
+	dialogStyle.Width = unit.Dp(560)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return material.List(ga.theme.Theme, &ws.automationDialogList).Layout(gtx, 1, func(gtx layout.Context, _ int) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return ga.renderFormField(gtx, "Name *", &ws.automationNameEditor, "e.g., Freeze the meat")
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx,
+								material.CheckBox(ga.theme.Theme, &ws.automationEnabled, "On").Layout)
+						}),
+						layout.Rigid(ga.renderAutomationTriggerFields),
+						layout.Rigid(ga.renderAutomationCollectionSelector),
+						layout.Rigid(ga.renderAutomationActions),
+					)
+				})
+			}),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.automationDialogErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.automationDialogErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ws.automationDialogCancel, "Cancel"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := "Save"
+						if ga.automationSaving {
+							label = "Saving..."
+						}
+						return widgets.PrimaryButton(ga.theme.Theme, &ws.automationDialogSubmit, label)(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeAutomationDialog()
+	}
+	return dims
+}
+
+// renderAutomationTriggerFields renders the trigger chips and the field
+// the chosen trigger needs
+func (ga *GioApp) renderAutomationTriggerFields(gtx layout.Context) layout.Dimensions {
+	ws := ga.widgetState
+	chips := make([]layout.Widget, len(automationTriggerKinds))
+	for i, kind := range automationTriggerKinds {
+		btn := ws.automationTriggerButtons[kind]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ws.automationTriggerButtons[kind] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.automationTriggerKind = kind
+		}
+		active := ga.automationTriggerKind == kind
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, automationTriggerLabels[kind], active)
+		}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderChipSelector(gtx, "When *", chips)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			switch ga.automationTriggerKind {
+			case "tag_added":
+				return ga.renderFormField(gtx, "Tag *", &ws.automationTagEditor, "e.g., freezer")
+			case "quantity_below":
+				return ga.renderFormField(gtx, "Quantity *", &ws.automationThresholdEditor, "e.g., 2")
+			case "expires_within":
+				return ga.renderFormField(gtx, "Days before expiry *", &ws.automationDaysEditor, "e.g., 3")
+			}
+			return layout.Dimensions{}
+		}),
+	)
+}
+
+// renderAutomationCollectionSelector renders a chip per collection plus one
+// for all of them; move actions pick from the chosen one's containers
+func (ga *GioApp) renderAutomationCollectionSelector(gtx layout.Context) layout.Dimensions {
+	ws := ga.widgetState
+	options := make([]types.Collection, 0, len(ga.collections)+1)
+	options = append(options, types.Collection{Name: "Any collection"})
+	options = append(options, ga.collections...)
+
+	chips := make([]layout.Widget, len(options))
+	for i, c := range options {
+		btn := ws.automationCollectionButtons[c.ID]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ws.automationCollectionButtons[c.ID] = btn
+		}
+		if btn.Clicked(gtx) && ga.automationCollectionID != c.ID {
+			ga.automationCollectionID = c.ID
+			for _, d := range ga.automationActionDrafts {
+				d.containerID = ""
+			}
+			ga.loadAutomationContainers(c.ID)
+		}
+		active := ga.automationCollectionID == c.ID
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, c.Name, active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "In", chips)
+}
+
+// renderAutomationActions renders each action with its kind chips and
+// fields, and the button to add another
+func (ga *GioApp) renderAutomationActions(gtx layout.Context) layout.Dimensions {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body2(ga.theme.Theme, "Then *")
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}),
+	}
+	for i, d := range ga.automationActionDrafts {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderAutomationAction(gtx, i, d)
+				})
+			})
+		}))
+	}
+	if len(ga.automationActionDrafts) < maxAutomationActions {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx,
+				widgets.CancelButton(ga.theme.Theme, &ga.widgetState.automationAddAction, "Add Action"))
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// renderAutomationAction renders one action of the rule being edited
+func (ga *GioApp) renderAutomationAction(gtx layout.Context, i int, d *automationActionDraft) layout.Dimensions {
+	kinds := make([]layout.Widget, len(automationActionKinds))
+	for j, kind := range automationActionKinds {
+		btn := d.kindButtons[kind]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			d.kindButtons[kind] = btn
+		}
+		if btn.Clicked(gtx) {
+			d.kind = kind
+		}
+		active := d.kind == kind
+		kinds[j] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, automationActionLabels[kind], active)
+		}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderChipSelector(gtx, fmt.Sprintf("Action %d", i+1), kinds)
+				}),
+				layout.Rigid(widgets.CancelButton(ga.theme.Theme, &d.removeButton, "Remove")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			switch d.kind {
+			case "add_tag":
+				return ga.renderFormField(gtx, "Tag *", &d.tagEditor, "e.g., frozen")
+			case "move_to_container":
+				return ga.renderAutomationContainerPicker(gtx, d)
+			case "fire_webhook":
+				secretHint := "Optional; signs each request"
+				if d.hasSecret {
+					secretHint = "Leave blank to keep the current secret"
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return ga.renderFormField(gtx, "URL *", &d.urlEditor, "https://example.com/hook")
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return ga.renderFormField(gtx, "Secret", &d.secretEditor, secretHint)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
+							layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+								return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return ga.renderFormField(gtx, "Retries", &d.retriesEditor, "0 to 10")
+								})
+							}),
+							layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+								return ga.renderFormField(gtx, "First retry after (s)", &d.backoffEditor, "e.g., 60; doubles each time")
+							}),
+						)
+					}),
+				)
+			}
+			return layout.Dimensions{}
+		}),
+	)
+}
+
+// renderAutomationContainerPicker renders a chip per container of the
+// collection the rule watches
+func (ga *GioApp) renderAutomationContainerPicker(gtx layout.Context, d *automationActionDraft) layout.Dimensions {
+	if ga.automationCollectionID == "" {
+		return ga.mealNoteLabel(gtx, "Pick a collection above to move items within it.")
+	}
+	if len(ga.automationContainers) == 0 {
+		return ga.mealNoteLabel(gtx, "Loading containers...")
+	}
+	chips := make([]layout.Widget, len(ga.automationContainers))
+	for i, c := range ga.automationContainers {
+		btn := d.containers[c.ID]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			d.containers[c.ID] = btn
+		}
+		if btn.Clicked(gtx) {
+			d.containerID = c.ID
+		}
+		active := d.containerID == c.ID
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, c.Name, active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Move to *", chips)
+}
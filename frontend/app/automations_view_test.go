@@ -0,0 +1,157 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestAutomationSummary(t *testing.T) {
+	containers := map[string]string{"c1": "Freezer"}
+	tests := []struct {
+		rule types.AutomationRule
+		want string
+	}{
+		{
+			types.AutomationRule{
+				Trigger: types.AutomationTrigger{Kind: "tag_added", Tag: "freezer"},
+				Actions: []types.AutomationAction{{Kind: "move_to_container", ContainerID: "c1"}, {Kind: "add_tag", Tag: "frozen"}},
+			},
+			`Tagged "freezer" → move to Freezer, tag "frozen"`,
+		},
+		{
+			types.AutomationRule{
+				Trigger: types.AutomationTrigger{Kind: "quantity_below", Threshold: 1.5},
+				Actions: []types.AutomationAction{{Kind: "add_to_shopping_list"}},
+			},
+			"Quantity drops below 1.5 → add to the shopping list",
+		},
+		{
+			types.AutomationRule{
+				Trigger: types.AutomationTrigger{Kind: "expires_within", Days: 1},
+				Actions: []types.AutomationAction{{Kind: "fire_webhook", URL: "https://example.com/hook"}},
+			},
+			"1 day before expiry → post to https://example.com/hook",
+		},
+		{
+			types.AutomationRule{
+				Trigger: types.AutomationTrigger{Kind: "object_created"},
+				Actions: []types.AutomationAction{{Kind: "fire_webhook", URL: "https://example.com/hook", MaxRetries: 3, RetryBackoff: 60}},
+			},
+			"An item is added → post to https://example.com/hook, retried up to 3 times",
+		},
+		{
+			types.AutomationRule{
+				Trigger: types.AutomationTrigger{Kind: "object_created"},
+				Actions: []types.AutomationAction{{Kind: "move_to_container", ContainerID: "gone"}},
+			},
+			"An item is added → move to another container",
+		},
+	}
+	for _, tt := range tests {
+		if got := automationSummary(tt.rule, containers); got != tt.want {
+			t.Errorf("automationSummary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAutomationRuleRequest(t *testing.T) {
+	rule := types.AutomationRule{
+		Name:    "Hook",
+		Enabled: true,
+		Trigger: types.AutomationTrigger{Kind: "expires_within", Days: 3, CollectionID: "col1"},
+		Actions: []types.AutomationAction{{Kind: "fire_webhook", URL: "https://example.com/hook", HasSecret: true, MaxRetries: 2, RetryBackoff: 30}},
+	}
+
+	req := automationRuleRequest(rule)
+	if req.Enabled == nil || !*req.Enabled {
+		t.Errorf("Enabled = %v, want true", req.Enabled)
+	}
+	if req.Trigger.Days != 3 || req.Trigger.CollectionID != "col1" {
+		t.Errorf("trigger = %+v, want the rule's", req.Trigger)
+	}
+	if len(req.Actions) != 1 || req.Actions[0].URL != rule.Actions[0].URL || req.Actions[0].Secret != "" {
+		t.Errorf("actions = %+v, want the webhook without a secret so it is kept", req.Actions)
+	}
+	if req.Actions[0].MaxRetries != 2 || req.Actions[0].RetryBackoff != 30 {
+		t.Errorf("retry = %d every %ds, want the rule's 2 every 30s", req.Actions[0].MaxRetries, req.Actions[0].RetryBackoff)
+	}
+}
+
+func TestAutomationTriggerRequest(t *testing.T) {
+	tests := []struct {
+		kind, tag, threshold, days string
+		wantMsg                    bool
+	}{
+		{"object_created", "", "", "", false},
+		{"tag_added", " freezer ", "", "", false},
+		{"tag_added", " ", "", "", true},
+		{"quantity_below", "", "2", "", false},
+		{"quantity_below", "", "0", "", true},
+		{"quantity_below", "", "lots", "", true},
+		{"expires_within", "", "", "0", false},
+		{"expires_within", "", "", "400", true},
+		{"", "", "", "", true},
+	}
+	for _, tt := range tests {
+		trigger, msg := automationTriggerRequest(tt.kind, tt.tag, tt.threshold, tt.days, "")
+		if (msg != "") != tt.wantMsg {
+			t.Errorf("automationTriggerRequest(%q, %q, %q, %q) message = %q", tt.kind, tt.tag, tt.threshold, tt.days, msg)
+		}
+		if tt.kind == "tag_added" && !tt.wantMsg && trigger.Tag != "freezer" {
+			t.Errorf("tag = %q, want it trimmed", trigger.Tag)
+		}
+	}
+}
+
+func TestAutomationActionRequests(t *testing.T) {
+	if _, msg := automationActionRequests(nil); msg == "" {
+		t.Error("a rule without actions was accepted")
+	}
+
+	tag := newAutomationActionDraft(types.AutomationAction{Kind: "add_tag", Tag: " frozen "})
+	hook := newAutomationActionDraft(types.AutomationAction{Kind: "fire_webhook", URL: "https://example.com/hook", HasSecret: true})
+	actions, msg := automationActionRequests([]*automationActionDraft{tag, hook})
+	if msg != "" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	if actions[0].Tag != "frozen" || actions[1].URL != "https://example.com/hook" || actions[1].Secret != "" {
+		t.Errorf("actions = %+v, want the trimmed tag and the webhook with no new secret", actions)
+	}
+
+	move := newAutomationActionDraft(types.AutomationAction{Kind: "move_to_container"})
+	if _, msg := automationActionRequests([]*automationActionDraft{move}); msg == "" {
+		t.Error("a move without a container was accepted")
+	}
+
+	hook.retriesEditor.SetText("3")
+	if _, msg := automationActionRequests([]*automationActionDraft{hook}); msg == "" {
+		t.Error("retries without a first wait were accepted")
+	}
+	hook.backoffEditor.SetText("60")
+	actions, msg = automationActionRequests([]*automationActionDraft{hook})
+	if msg != "" || actions[0].MaxRetries != 3 || actions[0].RetryBackoff != 60 {
+		t.Errorf("actions = %+v (%q), want 3 retries 60s apart", actions, msg)
+	}
+	hook.retriesEditor.SetText("11")
+	if _, msg := automationActionRequests([]*automationActionDraft{hook}); msg == "" {
+		t.Error("more than 10 retries were accepted")
+	}
+
+	hook.urlEditor.SetText("ftp://example.com")
+	if _, msg := automationActionRequests([]*automationActionDraft{hook}); msg == "" {
+		t.Error("a webhook without an http URL was accepted")
+	}
+}
+
+func TestStoreAutomationRule(t *testing.T) {
+	ga := newTestGioApp()
+	ga.automationRules = []types.AutomationRule{{ID: "r1", Name: "Old"}}
+
+	ga.storeAutomationRule(types.AutomationRule{ID: "r1", Name: "New"})
+	ga.storeAutomationRule(types.AutomationRule{ID: "r2", Name: "Other"})
+
+	if len(ga.automationRules) != 2 || ga.automationRules[0].Name != "New" || ga.automationRules[1].ID != "r2" {
+		t.Errorf("rules = %+v, want r1 replaced in place and r2 appended", ga.automationRules)
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// downloadBackup fetches the full account backup and saves it as a file
+func (ga *GioApp) downloadBackup() {
+	if ga.backupInProgress || ga.currentUser == nil {
+		return
+	}
+	ga.backupInProgress = true
+	ga.backupStatus = "Preparing backup..."
+	accountID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		data, err := ga.accountsClient.Backup(accountID)
+		var path string
+		if err == nil {
+			filename := "nishiki-backup-" + time.Now().Format("2006-01-02") + ".json"
+			path, err = saveDownload(filename, data, "application/json")
+		}
+
+		ga.do(func() {
+			ga.backupInProgress = false
+			switch {
+			case err != nil:
+				ga.logger.Error("Failed to download backup", "error", err)
+				ga.backupStatus = "Backup failed: " + err.Error()
+			case path != "":
+				ga.logger.Info("Backup saved", "path", path, "bytes", len(data))
+				ga.backupStatus = fmt.Sprintf("Backup saved to %s", path)
+			default:
+				ga.logger.Info("Backup downloaded", "bytes", len(data))
+				ga.backupStatus = "Backup downloaded"
+			}
+		})
+	})
+}
@@ -0,0 +1,327 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// commentPageSize is how many comments the dialog loads at a time
+const commentPageSize = 50
+
+// openCommentsDialog opens the comment thread of the selected collection
+func (ga *GioApp) openCommentsDialog() {
+	if ga.selectedCollection == nil || ga.currentUser == nil {
+		return
+	}
+	ga.showCommentsDialog = true
+	ga.comments = nil
+	ga.commentsErr = ""
+	ga.widgetState.commentEditor.SetText("")
+	ga.widgetState.commentsDialog.Reset()
+	ga.loadComments()
+}
+
+func (ga *GioApp) closeCommentsDialog() {
+	ga.showCommentsDialog = false
+	ga.comments = nil
+	ga.commentsErr = ""
+	ga.widgetState.commentsDialog.Reset()
+}
+
+// loadComments fetches the newest comments and marks them read
+func (ga *GioApp) loadComments() {
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.commentsLoading = true
+
+	ga.goSafe(func() {
+		list, err := ga.commentsClient.ListCollection(userID, collectionID, time.Time{}, commentPageSize)
+
+		ga.do(func() {
+			ga.commentsLoading = false
+			if !ga.showCommentsDialog || ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to list comments", "collection_id", collectionID, "error", err)
+				ga.commentsErr = "Could not load comments: " + err.Error()
+				return
+			}
+			ga.comments = list.Comments
+			if len(ga.comments) > 0 {
+				ga.markCommentsRead(collectionID, ga.comments[0].CreatedAt)
+			}
+		})
+	})
+}
+
+// markCommentsRead clears the collection's unread badge up to readAt
+func (ga *GioApp) markCommentsRead(collectionID string, readAt time.Time) {
+	userID := ga.currentUser.ID
+	delete(ga.unreadComments, collectionID)
+
+	ga.goSafe(func() {
+		if err := ga.commentsClient.MarkRead(userID, collectionID, readAt); err != nil {
+			ga.logger.Warn("Failed to mark comments read", "collection_id", collectionID, "error", err)
+		}
+	})
+}
+
+// postComment posts the text typed in the dialog on the collection
+func (ga *GioApp) postComment() {
+	if ga.currentUser == nil || ga.selectedCollection == nil || ga.commentPosting {
+		return
+	}
+	body := strings.TrimSpace(ga.widgetState.commentEditor.Text())
+	if body == "" {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.commentPosting = true
+	ga.commentsErr = ""
+
+	ga.goSafe(func() {
+		comment, err := ga.commentsClient.CreateOnCollection(userID, collectionID, body)
+
+		ga.do(func() {
+			ga.commentPosting = false
+			if err != nil {
+				ga.logger.Error("Failed to post comment", "collection_id", collectionID, "error", err)
+				ga.commentsErr = "Could not post: " + err.Error()
+				return
+			}
+			ga.widgetState.commentEditor.SetText("")
+			if ga.showCommentsDialog && ga.selectedCollection != nil && ga.selectedCollection.ID == collectionID {
+				ga.comments = append([]types.Comment{*comment}, ga.comments...)
+			}
+		})
+	})
+}
+
+func (ga *GioApp) deleteComment(commentID string) {
+	if ga.currentUser == nil {
+		return
+	}
+	userID := ga.currentUser.ID
+	ga.commentsErr = ""
+
+	ga.goSafe(func() {
+		err := ga.commentsClient.Delete(userID, commentID)
+
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to delete comment", "comment_id", commentID, "error", err)
+				ga.commentsErr = "Delete failed: " + err.Error()
+				return
+			}
+			ga.comments = slices.DeleteFunc(ga.comments, func(c types.Comment) bool { return c.ID == commentID })
+		})
+	})
+}
+
+// fetchUnreadComments refreshes the unread badges on the collections list
+func (ga *GioApp) fetchUnreadComments() {
+	if ga.currentUser == nil {
+		return
+	}
+	userID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		unread, err := ga.commentsClient.Unread(userID)
+		if err != nil {
+			ga.logger.Warn("Failed to fetch unread comments", "error", err)
+			return
+		}
+		ga.doInSession(session, func() {
+			ga.unreadComments = make(map[string]types.CollectionUnreadComments, len(unread.Collections))
+			for _, u := range unread.Collections {
+				ga.unreadComments[u.CollectionID] = u
+			}
+		})
+	})
+}
+
+// unreadBadgeText is the badge shown on a collection card, marked with an @
+// when someone mentioned the user
+func unreadBadgeText(u types.CollectionUnreadComments) string {
+	if u.Count == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("%d new", u.Count)
+	if u.Mentions > 0 {
+		text = "@ " + text
+	}
+	return text
+}
+
+func (ga *GioApp) renderUnreadBadge(gtx layout.Context, collectionID string) layout.Dimensions {
+	text := unreadBadgeText(ga.unreadComments[collectionID])
+	if text == "" {
+		return layout.Dimensions{}
+	}
+	return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, text)
+		label.Font.Weight = font.Bold
+		label.Color = theme.ColorPrimary
+		if ga.unreadComments[collectionID].Mentions > 0 {
+			label.Color = theme.ColorDanger
+		}
+		return label.Layout(gtx)
+	})
+}
+
+// renderCommentsDialog renders the collection's comment thread, newest first
+func (ga *GioApp) renderCommentsDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showCommentsDialog {
+		return layout.Dimensions{}
+	}
+
+	if n := len(ga.comments); len(ga.widgetState.commentDeleteButtons) < n {
+		ga.widgetState.commentDeleteButtons = make([]widget.Clickable, n)
+	}
+	for i := range ga.comments {
+		if ga.widgetState.commentDeleteButtons[i].Clicked(gtx) {
+			ga.deleteComment(ga.comments[i].ID)
+		}
+	}
+	if ga.widgetState.commentPost.Clicked(gtx) {
+		ga.postComment()
+	}
+	if ga.widgetState.commentsClose.Clicked(gtx) {
+		ga.closeCommentsDialog()
+		return layout.Dimensions{}
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.commentsDialog, "Comments")
+	dialogStyle.Width = unit.Dp(600)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(ga.renderCommentPostRow),
+			layout.Rigid(ga.renderCommentList),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.commentsErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.commentsErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.commentsClose, "Close")),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeCommentsDialog()
+	}
+	return dims
+}
+
+func (ga *GioApp) renderCommentPostRow(gtx layout.Context) layout.Dimensions {
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.End}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "New comment", &ga.widgetState.commentEditor, "e.g. @alex we're low on rice")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := "Post"
+				if ga.commentPosting {
+					label = "Posting..."
+				}
+				return layout.Inset{Left: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx,
+					widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.commentPost, label))
+			}),
+		)
+	})
+}
+
+func (ga *GioApp) renderCommentList(gtx layout.Context) layout.Dimensions {
+	switch {
+	case ga.commentsLoading && len(ga.comments) == 0:
+		return ga.mergeNote(gtx, "Loading comments...")
+	case len(ga.comments) == 0 && ga.commentsErr == "":
+		return ga.mergeNote(gtx, "No comments yet. Use @name to mention someone.")
+	}
+
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(unit.Dp(360)))
+	list := &ga.widgetState.commentList
+	list.Axis = layout.Vertical
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return list.Layout(gtx, len(ga.comments), func(gtx layout.Context, index int) layout.Dimensions {
+			comment := ga.comments[index]
+			mentioned := slices.Contains(comment.Mentions, ga.currentUser.ID)
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Start}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								label := material.Caption(ga.theme.Theme, ga.commentHeading(comment))
+								label.Color = theme.ColorTextSecondary
+								return label.Layout(gtx)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								label := material.Body1(ga.theme.Theme, comment.Body)
+								if mentioned {
+									label.Font.Weight = font.Bold
+									label.Color = theme.ColorPrimary
+								}
+								return label.Layout(gtx)
+							}),
+						)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if !ga.canDeleteComment(comment) {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.commentDeleteButtons[index], "Delete"))
+					}),
+				)
+			})
+		})
+	})
+}
+
+// commentHeading names the author and, for object comments, the object
+func (ga *GioApp) commentHeading(c types.Comment) string {
+	heading := c.AuthorName + " · " + c.CreatedAt.Local().Format("Jan 2, 15:04")
+	if c.ObjectID == "" {
+		return heading
+	}
+	for _, obj := range ga.objects {
+		if obj.ID == c.ObjectID {
+			return heading + " · on " + obj.Name
+		}
+	}
+	return heading + " · on an object"
+}
+
+// canDeleteComment mirrors the backend rule: authors and whoever manages
+// the collection may delete a comment
+func (ga *GioApp) canDeleteComment(c types.Comment) bool {
+	if c.AuthorID == ga.currentUser.ID {
+		return true
+	}
+	collection := ga.selectedCollection
+	return collection != nil && (collection.UserID == ga.currentUser.ID || collection.GroupOwned)
+}
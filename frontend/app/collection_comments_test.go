@@ -0,0 +1,23 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestUnreadBadgeText(t *testing.T) {
+	cases := []struct {
+		unread types.CollectionUnreadComments
+		want   string
+	}{
+		{types.CollectionUnreadComments{}, ""},
+		{types.CollectionUnreadComments{Count: 3}, "3 new"},
+		{types.CollectionUnreadComments{Count: 2, Mentions: 1}, "@ 2 new"},
+	}
+	for _, c := range cases {
+		if got := unreadBadgeText(c.unread); got != c.want {
+			t.Errorf("unreadBadgeText(%+v) = %q, want %q", c.unread, got, c.want)
+		}
+	}
+}
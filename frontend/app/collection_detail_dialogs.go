@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"gioui.org/layout"
 	"gioui.org/unit"
@@ -76,6 +77,14 @@ func (ga *GioApp) renderContainerDialog(gtx layout.Context) layout.Dimensions {
 				return ga.renderFormField(gtx, "Location", &ga.widgetState.containerLocationEditor, "e.g., Living Room, Shelf 3")
 			}),
 
+			// Temperature zone and humidity
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderTemperatureZoneSelector(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "Humidity (%)", &ga.widgetState.containerHumidityEditor, "e.g., 40")
+			}),
+
 			// Buttons
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{
@@ -126,10 +135,26 @@ func (ga *GioApp) renderObjectDialog(gtx layout.Context) layout.Dimensions {
 		return layout.Dimensions{}
 	}
 
+	// Handle voice quick add (create only)
+	if ga.widgetState.objectVoiceButton.Clicked(gtx) {
+		ga.startVoiceQuickAdd()
+	}
+
+	// Handle archive button (edit only)
+	if ga.widgetState.objectDialogArchive.Clicked(gtx) && ga.selectedObject != nil {
+		ga.setObjectArchived(*ga.selectedObject, true)
+		ga.showObjectDialog = false
+		ga.selectedObject = nil
+		ga.clearObjectHistory()
+		ga.widgetState.objectDialog.Reset()
+		return layout.Dimensions{}
+	}
+
 	// Handle cancel button
 	if ga.widgetState.objectDialogCancel.Clicked(gtx) {
 		ga.showObjectDialog = false
 		ga.selectedObject = nil
+		ga.clearObjectHistory()
 		ga.widgetState.objectDialog.Reset()
 		return layout.Dimensions{}
 	}
@@ -152,6 +177,9 @@ func (ga *GioApp) renderObjectDialog(gtx layout.Context) layout.Dimensions {
 				return ga.renderFormField(gtx, "Name *", &ga.widgetState.objectNameEditor, "Enter object name")
 			}),
 
+			// Voice quick add (create only, browsers with speech input)
+			layout.Rigid(ga.renderVoiceQuickAdd),
+
 			// Container selection
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return ga.renderObjectContainerSelector(gtx)
@@ -170,28 +198,79 @@ func (ga *GioApp) renderObjectDialog(gtx layout.Context) layout.Dimensions {
 				}.Layout(gtx,
 					layout.Flexed(0.5, func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							return ga.renderFormField(gtx, "Quantity", &ga.widgetState.objectQuantityEditor, "e.g., 1.5")
+							return ga.renderQuantityField(gtx, "Quantity", &ga.widgetState.objectQuantityStepper, ga.widgetState.objectUnitEditor.Text())
 						})
 					}),
 					layout.Flexed(0.5, func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							return ga.renderFormField(gtx, "Unit", &ga.widgetState.objectUnitEditor, "e.g., kg, lbs")
+							return ga.renderFormField(gtx, "Unit", &ga.widgetState.objectUnitEditor, ga.objectUnitHint())
 						})
 					}),
 				)
 			}),
+			layout.Rigid(ga.renderObjectUnitChips),
+
+			// Restock threshold
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "Low Stock At", &ga.widgetState.objectMinQuantityEditor, "Mark low stock at or below this quantity")
+			}),
+
+			// Condition grade, for collectibles
+			layout.Rigid(ga.renderObjectConditionSelector),
+
+			// Owned, or wanted/ordered for the wishlist
+			layout.Rigid(ga.renderObjectStatusSelector),
+
+			// Schedule putting it back on the shopping list (edit only)
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.objectDialogMode != "edit" {
+					return layout.Dimensions{}
+				}
+				return ga.renderObjectRepeatSelector(gtx)
+			}),
 
 			// Schema-defined property fields
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return ga.renderObjectSchemaFields(gtx)
 			}),
 
+			// Expiry date, suggested from the category's history (create only)
+			layout.Rigid(ga.renderObjectExpiryField),
+
+			// Location history (edit only)
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.objectDialogMode != "edit" {
+					return layout.Dimensions{}
+				}
+				return ga.renderObjectHistory(gtx)
+			}),
+
+			// Schema validation error
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.objectDialogErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					errLabel := material.Body2(ga.theme.Theme, ga.objectDialogErr)
+					errLabel.Color = theme.ColorDanger
+					return errLabel.Layout(gtx)
+				})
+			}),
+
 			// Buttons
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{
 					Axis:    layout.Horizontal,
 					Spacing: layout.SpaceEnd,
 				}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.objectDialogMode != "edit" {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return widgets.AccentButton(ga.theme.Theme, &ga.widgetState.objectDialogArchive, "Archive")(gtx)
+						})
+					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 							return widgets.CancelButton(ga.theme.Theme, &ga.widgetState.objectDialogCancel, "Cancel")(gtx)
@@ -213,6 +292,7 @@ func (ga *GioApp) renderObjectDialog(gtx layout.Context) layout.Dimensions {
 	if dismissed {
 		ga.showObjectDialog = false
 		ga.selectedObject = nil
+		ga.clearObjectHistory()
 		ga.widgetState.objectDialog.Reset()
 	}
 
@@ -267,7 +347,7 @@ func (ga *GioApp) renderDeleteContainerDialog(gtx layout.Context) layout.Dimensi
 					if childCount > 0 {
 						message = fmt.Sprintf("Cannot delete container \"%s\" because it has %d child container(s). Remove or reassign all child containers first.", containerName, childCount)
 					} else {
-						message = fmt.Sprintf("Are you sure you want to delete the container \"%s\"? All objects within it will also be deleted. This action cannot be undone.", containerName)
+						message = fmt.Sprintf("Are you sure you want to delete the container \"%s\"? All objects within it will also be deleted. You can undo this for a few seconds afterwards.", containerName)
 					}
 					label := material.Body1(ga.theme.Theme, message)
 					if childCount > 0 {
@@ -355,7 +435,7 @@ func (ga *GioApp) renderDeleteObjectDialog(gtx layout.Context) layout.Dimensions
 			// Message
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return layout.Inset{Bottom: unit.Dp(theme.Spacing4)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-					message := fmt.Sprintf("Are you sure you want to delete the object \"%s\"? This action cannot be undone.", objectName)
+					message := fmt.Sprintf("Are you sure you want to delete the object \"%s\"? You can undo this for a few seconds afterwards.", objectName)
 					label := material.Body1(ga.theme.Theme, message)
 					return label.Layout(gtx)
 				})
@@ -511,6 +591,11 @@ func (ga *GioApp) handleContainerCreate() {
 		ga.logger.Warn("Container name is required")
 		return
 	}
+	humidity, err := parseHumidity(ga.widgetState.containerHumidityEditor.Text())
+	if err != nil {
+		ga.showAPIErrorDialog(err.Error())
+		return
+	}
 
 	containerType := ga.selectedContainerType
 	if containerType == "" {
@@ -522,14 +607,17 @@ func (ga *GioApp) handleContainerCreate() {
 	collectionID := ga.selectedCollection.ID
 	userID := ga.currentUser.ID
 	parentContainerID := ga.selectedParentContainerID
+	temperatureZone := ga.selectedTemperatureZone
 
-	go func() {
+	ga.goSafe(func() {
 		req := types.CreateContainerRequest{
 			CollectionID:      collectionID,
 			Name:              name,
 			Type:              containerType,
 			Location:          location,
 			ParentContainerID: parentContainerID,
+			TemperatureZone:   temperatureZone,
+			Humidity:          humidity,
 		}
 
 		container, err := ga.containersClient.Create(userID, collectionID, req)
@@ -541,11 +629,12 @@ func (ga *GioApp) handleContainerCreate() {
 
 		ga.logger.Info("Container created successfully", "container_id", container.ID)
 		ga.do(func() { ga.addContainer(*container) })
-	}()
+	})
 
 	// Close dialog
 	ga.showContainerDialog = false
 	ga.selectedContainerType = ""
+	ga.selectedTemperatureZone = ""
 	ga.selectedParentContainerID = nil
 }
 
@@ -563,6 +652,11 @@ func (ga *GioApp) handleContainerUpdate() {
 		ga.logger.Warn("Container name is required")
 		return
 	}
+	humidity, err := parseHumidity(ga.widgetState.containerHumidityEditor.Text())
+	if err != nil {
+		ga.showAPIErrorDialog(err.Error())
+		return
+	}
 
 	ga.logger.Info("Updating container", "container_id", ga.selectedContainer.ID, "name", name)
 
@@ -579,31 +673,63 @@ func (ga *GioApp) handleContainerUpdate() {
 		parentID = new("")
 	}
 
-	go func() {
-		req := types.UpdateContainerRequest{
-			Name:              name,
-			Location:          location,
-			ParentContainerID: parentID,
-		}
-
-		updated, err := ga.containersClient.Update(userID, collectionID, containerID, req)
-		if err != nil {
-			ga.logger.Error("Failed to update container", "error", err)
-			ga.do(func() { ga.showAPIErrorDialog("Failed to update container: " + err.Error()) })
-			return
+	original := *ga.selectedContainer
+	edited := original
+	edited.Name, edited.Location = name, location
+	edited.TemperatureZone = ga.selectedTemperatureZone
+	if humidity != nil {
+		edited.Humidity = humidity
+	}
+	if parentID != nil {
+		edited.ParentContainerID = parentID
+		if *parentID == "" {
+			edited.ParentContainerID = nil
 		}
+	}
 
-		ga.logger.Info("Container updated successfully", "container_id", containerID)
-		ga.do(func() { ga.updateContainer(*updated) })
-	}()
+	patch, err := types.DiffMergePatch(containerPatchBody(original), containerPatchBody(edited))
+	if err != nil {
+		ga.logger.Error("Failed to build container patch", "error", err)
+		return
+	}
 
 	// Close dialog
 	ga.showContainerDialog = false
 	ga.selectedContainer = nil
+	ga.selectedTemperatureZone = ""
 	ga.selectedParentContainerID = nil
+	if len(patch) == 0 {
+		return
+	}
+
+	ga.optimisticUpdate("update container", func() {
+		ga.updateContainer(edited)
+	}, func() {
+		ga.updateContainer(original)
+	}, func() (func(), error) {
+		updated, err := ga.containersClient.Patch(userID, collectionID, containerID, patch)
+		if err != nil {
+			return nil, err
+		}
+		ga.logger.Info("Container updated successfully", "container_id", containerID)
+		return func() { ga.updateContainer(*updated) }, nil
+	})
+}
+
+// containerPatchBody is the part of a container the edit dialog changes, as
+// a patch body for diffing the container before and after an edit
+func containerPatchBody(c Container) types.PatchContainerRequest {
+	return types.PatchContainerRequest{
+		Name:              &c.Name,
+		ParentContainerID: c.ParentContainerID,
+		Location:          &c.Location,
+		TemperatureZone:   &c.TemperatureZone,
+		Humidity:          c.Humidity,
+	}
 }
 
-// handleContainerDelete handles deleting a container
+// handleContainerDelete removes a container and its objects from local state
+// and schedules the DELETE request behind the undo window
 func (ga *GioApp) handleContainerDelete() {
 	if ga.deleteContainerID == "" {
 		ga.logger.Error("No container ID for deletion")
@@ -616,17 +742,39 @@ func (ga *GioApp) handleContainerDelete() {
 	collectionID := ga.selectedCollection.ID
 	userID := ga.currentUser.ID
 
-	go func() {
-		err := ga.containersClient.Delete(userID, collectionID, containerID)
-		if err != nil {
-			ga.logger.Error("Failed to delete container", "error", err)
-			ga.do(func() { ga.showAPIErrorDialog("Failed to delete container: " + err.Error()) })
-			return
+	// Snapshot the container and its objects before removing them so undo can restore them
+	var container Container
+	for _, c := range ga.containers {
+		if c.ID == containerID {
+			container = c
+			break
 		}
+	}
+	var containedObjects []Object
+	for _, obj := range ga.objects {
+		if obj.ContainerID == containerID {
+			containedObjects = append(containedObjects, obj)
+		}
+	}
+	restore := func() {
+		ga.containers = append(ga.containers, container)
+		ga.objects = append(ga.objects, containedObjects...)
+		ga.invalidateObjectCaches()
+	}
 
+	ga.removeContainer(containerID)
+	containersClient := ga.containersClient
+	ga.scheduleDelete(fmt.Sprintf("Deleted container \"%s\"", container.Name), collectionID, restore, func() error {
+		if err := containersClient.Delete(userID, collectionID, containerID); err != nil {
+			return err
+		}
 		ga.logger.Info("Container deleted successfully", "container_id", containerID)
-		ga.do(func() { ga.removeContainer(containerID) })
-	}()
+		return nil
+	}, func(err error) {
+		ga.logger.Error("Failed to delete container", "error", err)
+		ga.restoreDeleted(collectionID, restore)
+		ga.showAPIErrorDialog("Failed to delete container: " + err.Error())
+	})
 
 	// Close dialog
 	ga.showDeleteContainer = false
@@ -642,7 +790,8 @@ func (ga *GioApp) handleObjectCreate() {
 
 	name := ga.widgetState.objectNameEditor.Text()
 	description := ga.widgetState.objectDescriptionEditor.Text()
-	quantityText := ga.widgetState.objectQuantityEditor.Text()
+	quantityText := ga.widgetState.objectQuantityStepper.Text()
+	minQuantityText := strings.TrimSpace(ga.widgetState.objectMinQuantityEditor.Text())
 
 	if name == "" {
 		ga.logger.Warn("Object name is required")
@@ -656,6 +805,10 @@ func (ga *GioApp) handleObjectCreate() {
 			quantity = &val
 		}
 	}
+	var minQuantity *float64
+	if val, err := strconv.ParseFloat(minQuantityText, 64); err == nil && val >= 0 {
+		minQuantity = &val
+	}
 
 	ga.logger.Info("Creating object", "name", name)
 
@@ -664,33 +817,40 @@ func (ga *GioApp) handleObjectCreate() {
 	collectionID := ga.selectedCollection.ID
 	selectedContainerID := ga.selectedContainerID
 	properties := ga.collectObjectProperties()
+	if errs := ga.schemaPropertyErrors(properties); len(errs) > 0 {
+		ga.objectDialogErr = strings.Join(errs, "\n")
+		return
+	}
+	expiresAt, err := parseExpiryDate(ga.widgetState.objectExpiresEditor.Text())
+	if err != nil {
+		ga.objectDialogErr = err.Error()
+		return
+	}
+	// The API learns from the date, so the next suggestion may change
+	if expiresAt != nil {
+		ga.resetExpirySuggestions()
+	}
 
-	go func() {
-		req := types.CreateObjectRequest{
-			Name:        name,
-			Description: description,
-			ObjectType:  objectType,
-			Quantity:    quantity,
-			Unit:        ga.widgetState.objectUnitEditor.Text(),
-			Properties:  properties,
-			Tags:        []string{},
-		}
-
-		// Add container ID if selected
-		if selectedContainerID != nil {
-			req.ContainerID = *selectedContainerID
-		}
+	req := types.CreateObjectRequest{
+		Name:        name,
+		Description: description,
+		ObjectType:  objectType,
+		Quantity:    quantity,
+		Unit:        ga.widgetState.objectUnitEditor.Text(),
+		MinQuantity: minQuantity,
+		Condition:   ga.objectCondition,
+		Status:      ga.objectStatus,
+		Properties:  properties,
+		Tags:        []string{},
+		ExpiresAt:   expiresAt,
+	}
 
-		object, err := ga.objectsClient.Create(userID, req, collectionID)
-		if err != nil {
-			ga.logger.Error("Failed to create object", "error", err)
-			ga.do(func() { ga.showAPIErrorDialog("Failed to create object: " + err.Error()) })
-			return
-		}
+	// Add container ID if selected
+	if selectedContainerID != nil {
+		req.ContainerID = *selectedContainerID
+	}
 
-		ga.logger.Info("Object created successfully", "object_id", object.ID)
-		ga.do(func() { ga.addObject(*object) })
-	}()
+	ga.createObjectChecked(pendingObjectCreate{userID: userID, collectionID: collectionID, req: req})
 
 	// Close dialog
 	ga.showObjectDialog = false
@@ -706,8 +866,9 @@ func (ga *GioApp) handleObjectUpdate() {
 
 	name := ga.widgetState.objectNameEditor.Text()
 	description := ga.widgetState.objectDescriptionEditor.Text()
-	quantityText := ga.widgetState.objectQuantityEditor.Text()
+	quantityText := ga.widgetState.objectQuantityStepper.Text()
 	objectUnit := ga.widgetState.objectUnitEditor.Text()
+	minQuantityText := strings.TrimSpace(ga.widgetState.objectMinQuantityEditor.Text())
 
 	if name == "" {
 		ga.logger.Warn("Object name is required")
@@ -722,6 +883,12 @@ func (ga *GioApp) handleObjectUpdate() {
 		}
 	}
 
+	// An emptied threshold removes it
+	var minQuantity *float64
+	if val, err := strconv.ParseFloat(minQuantityText, 64); err == nil && val >= 0 {
+		minQuantity = &val
+	}
+
 	ga.logger.Info("Updating object", "object_id", ga.selectedObject.ID, "name", name)
 
 	objectID := ga.selectedObject.ID
@@ -741,52 +908,96 @@ func (ga *GioApp) handleObjectUpdate() {
 		rawProps[k] = tv.Val
 	}
 	ga.mergeSchemaProperties(rawProps)
-	tags := ga.selectedObject.Tags
+	if errs := ga.schemaPropertyErrors(rawProps); len(errs) > 0 {
+		ga.objectDialogErr = strings.Join(errs, "\n")
+		return
+	}
 
-	oldContainerID := ""
-	if ga.selectedObject != nil {
-		oldContainerID = ga.selectedObject.ContainerID
+	// Show the edit straight away. Property values stay as typed until the
+	// server's coerced version replaces them.
+	original := *ga.selectedObject
+	edited := original
+	edited.Name, edited.Description, edited.Unit = name, description, objectUnit
+	edited.Quantity, edited.MinQuantity, edited.ContainerID = quantity, minQuantity, containerID
+	edited.Condition = ga.objectCondition
+	if ga.objectStatus != "" {
+		edited.Status = ga.objectStatus
+	}
+	edited.Properties = make(map[string]TypedValue, len(rawProps))
+	for k, v := range rawProps {
+		tv := original.Properties[k]
+		tv.Val = v
+		edited.Properties[k] = tv
+	}
+
+	// Only what the dialog changed is sent, so edits made meanwhile by
+	// someone else to other fields are kept
+	patch, err := types.DiffMergePatch(objectPatchBody(original), objectPatchBody(edited))
+	if err != nil {
+		ga.logger.Error("Failed to build object patch", "error", err)
+		return
 	}
 
-	go func() {
-		req := types.UpdateObjectRequest{
-			ContainerID: containerID,
-			Name:        &name,
-			Description: &description,
-			Quantity:    quantity,
-			Unit:        &objectUnit,
-			Properties:  rawProps,
-			Tags:        tags,
-		}
+	ga.saveObjectRecurrence(objectID)
+	ga.showObjectDialog = false
+	ga.selectedObject = nil
+	ga.clearObjectHistory()
+	if len(patch) == 0 {
+		return
+	}
 
-		updated, err := ga.objectsClient.Update(userID, objectID, req)
+	ga.optimisticUpdate("update object", func() {
+		ga.updateObject(edited, original.ContainerID)
+	}, func() {
+		ga.updateObject(original, containerID)
+	}, func() (func(), error) {
+		updated, err := ga.objectsClient.Patch(userID, objectID, patch)
 		if err != nil {
-			ga.logger.Error("Failed to update object", "error", err)
-			ga.do(func() { ga.showAPIErrorDialog("Failed to update object: " + err.Error()) })
-			return
+			return nil, err
 		}
-
 		ga.logger.Info("Object updated successfully", "object_id", objectID)
-		ga.do(func() { ga.updateObject(*updated, oldContainerID) })
-	}()
+		if updated.StorageWarning != nil {
+			ga.logger.Warn("Object stored too warm", "object_id", objectID, "warning", updated.StorageWarning.Message)
+		}
+		return func() { ga.updateObject(*updated, containerID) }, nil
+	})
+}
 
-	// Close dialog
-	ga.showObjectDialog = false
-	ga.selectedObject = nil
+// objectPatchBody is the editable part of an object as a patch body, for
+// diffing the object before and after an edit
+func objectPatchBody(obj Object) types.PatchObjectRequest {
+	props := make(map[string]any, len(obj.Properties))
+	for k, tv := range obj.Properties {
+		props[k] = tv.Val
+	}
+	return types.PatchObjectRequest{
+		ContainerID: &obj.ContainerID,
+		Name:        &obj.Name,
+		Description: &obj.Description,
+		Location:    &obj.Location,
+		Quantity:    obj.Quantity,
+		Unit:        &obj.Unit,
+		MinQuantity: obj.MinQuantity,
+		Condition:   &obj.Condition,
+		Status:      &obj.Status,
+		Properties:  props,
+		Tags:        obj.Tags,
+	}
 }
 
-// handleObjectDelete handles deleting an object
+// handleObjectDelete removes an object from local state and schedules the
+// DELETE request behind the undo window
 func (ga *GioApp) handleObjectDelete() {
 	if ga.deleteObjectID == "" {
 		ga.logger.Error("No object ID for deletion")
 		return
 	}
 
-	// Find the object to get its container ID
-	var containerID string
+	// Find the object to snapshot it for undo
+	var object Object
 	for _, obj := range ga.objects {
 		if obj.ID == ga.deleteObjectID {
-			containerID = obj.ContainerID
+			object = obj
 			break
 		}
 	}
@@ -794,19 +1005,24 @@ func (ga *GioApp) handleObjectDelete() {
 	ga.logger.Info("Deleting object", "object_id", ga.deleteObjectID)
 
 	objectID := ga.deleteObjectID
+	containerID := object.ContainerID
+	collectionID := ga.selectedCollection.ID
 	userID := ga.currentUser.ID
+	restore := func() { ga.addObject(object) }
 
-	go func() {
-		err := ga.objectsClient.Delete(userID, objectID, containerID)
-		if err != nil {
-			ga.logger.Error("Failed to delete object", "error", err)
-			ga.do(func() { ga.showAPIErrorDialog("Failed to delete object: " + err.Error()) })
-			return
+	ga.removeObject(objectID, containerID)
+	objectsClient := ga.objectsClient
+	ga.scheduleDelete(fmt.Sprintf("Deleted \"%s\"", object.Name), collectionID, restore, func() error {
+		if err := objectsClient.Delete(userID, objectID, containerID); err != nil {
+			return err
 		}
-
 		ga.logger.Info("Object deleted successfully", "object_id", objectID)
-		ga.do(func() { ga.removeObject(objectID, containerID) })
-	}()
+		return nil
+	}, func(err error) {
+		ga.logger.Error("Failed to delete object", "error", err)
+		ga.restoreDeleted(collectionID, restore)
+		ga.showAPIErrorDialog("Failed to delete object: " + err.Error())
+	})
 
 	// Close dialog
 	ga.showDeleteObject = false
@@ -865,6 +1081,60 @@ func (ga *GioApp) mergeSchemaProperties(props map[string]any) {
 	}
 }
 
+// schemaPropertyErrors checks form values against the collection's field
+// definitions the same way the backend does, so the dialog can stay open
+// with a message instead of failing after it closes.
+func (ga *GioApp) schemaPropertyErrors(props map[string]any) []string {
+	if ga.selectedCollection == nil || ga.selectedCollection.PropertySchema == nil {
+		return nil
+	}
+	var errs []string
+	for _, def := range ga.selectedCollection.PropertySchema.Definitions {
+		label := def.DisplayName
+		if label == "" {
+			label = def.Key
+		}
+		val, ok := props[def.Key]
+		str := ""
+		if ok && val != nil {
+			str = strings.TrimSpace(fmt.Sprintf("%v", val))
+		}
+		if str == "" {
+			if def.Required {
+				errs = append(errs, label+" is required")
+			}
+			continue
+		}
+		switch def.Type {
+		case "numeric", "currency":
+			cleaned := strings.TrimLeft(strings.ReplaceAll(str, ",", ""), "$€£¥ ")
+			if _, err := strconv.ParseFloat(cleaned, 64); err != nil {
+				errs = append(errs, label+" must be a number")
+			}
+		case "date":
+			if _, isTime := val.(time.Time); !isTime && !looksLikeDate(str) {
+				errs = append(errs, label+" must be a date (YYYY-MM-DD)")
+			}
+		}
+	}
+	return errs
+}
+
+// looksLikeDate accepts the date forms the backend coerces: ISO dates and
+// timestamps, US-style dates, and approximate years such as "~1998".
+func looksLikeDate(s string) bool {
+	if len(s) == 5 && (s[0] == '~' || s[0] == '<') {
+		_, err := strconv.Atoi(s[1:])
+		return err == nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "01/02/2006", "1/2/2006", "2006/01/02", "January 2, 2006", "Jan 2, 2006"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // renderObjectSchemaFields renders dynamic form fields for schema-defined
 // properties inside a bounded scrollable list so dialogs stay a manageable
 // height when the collection has many defined properties.
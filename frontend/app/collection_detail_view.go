@@ -20,6 +20,7 @@ import (
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 
+	"github.com/nishiki/frontend/pkg/types"
 	"github.com/nishiki/frontend/ui/theme"
 	"github.com/nishiki/frontend/ui/widgets"
 )
@@ -34,6 +35,7 @@ const (
 	ObjectViewCompact ObjectViewLayout = "compact"
 	ObjectViewTable   ObjectViewLayout = "table"
 	ObjectViewTree    ObjectViewLayout = "tree"
+	ObjectViewPhotos  ObjectViewLayout = "photos"
 )
 
 // Container type constants
@@ -64,6 +66,32 @@ var containerTypeLabels = map[string]string{
 	ContainerTypeGeneral:   "General",
 }
 
+// openCreateObjectDialog resets the object editors and shows the create object dialog
+func (ga *GioApp) openCreateObjectDialog() {
+	ga.logger.Info("Opening create object dialog")
+	ga.showObjectDialog = true
+	ga.objectDialogMode = "create"
+	ga.objectDialogErr = ""
+	ga.voiceTranscript = ""
+	ga.selectedContainerID = nil
+	ga.widgetState.objectNameEditor.SetText("")
+	ga.widgetState.objectDescriptionEditor.SetText("")
+	ga.widgetState.objectQuantityStepper.SetText("")
+	ga.widgetState.objectUnitEditor.SetText("")
+	ga.widgetState.objectMinQuantityEditor.SetText("")
+	ga.widgetState.objectExpiresEditor.SetText("")
+	ga.objectCondition = ""
+	ga.objectStatus = ""
+	// Clear schema property editors
+	for _, ed := range ga.widgetState.objectPropertyEditors {
+		ed.SetText("")
+	}
+	for _, b := range ga.widgetState.objectPropertyBools {
+		b.Value = false
+	}
+	ga.ensureExpirySuggestionsLoaded()
+}
+
 // renderCollectionDetailView renders the collection detail view with containers and objects
 func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensions {
 	renderStart := time.Now()
@@ -89,16 +117,21 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 		ga.selectedCollection = nil
 		ga.containers = nil
 		ga.objects = nil
-		ga.activeGroupedTextFilters = nil
 		ga.objectSortSpecs = nil
 		ga.objectGroupByField = ""
+		ga.viewPrefsCollectionID = ""
+		ga.resetCollectionPhotos()
+		ga.resetNutritionStats()
+		ga.resetFloorPlanMap()
 		ga.invalidateObjectCaches()
-		ga.showContainersPanel = false
-		ga.containerViewMode = ""
-		ga.objectViewLayout = ""
 		return layout.Dimensions{}
 	}
 
+	// Restore the saved view on the first frame, and save changes made by
+	// this frame's clicks once it is laid out
+	ga.syncCollectionViewPreference()
+	defer ga.syncCollectionViewPreference()
+
 	// Handle create container button
 	if ga.widgetState.createContainerButton.Clicked(gtx) {
 		ga.logger.Info("Opening create container dialog")
@@ -107,26 +140,14 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 		ga.selectedContainerID = nil
 		ga.widgetState.containerNameEditor.SetText("")
 		ga.widgetState.containerLocationEditor.SetText("")
+		ga.widgetState.containerHumidityEditor.SetText("")
+		ga.selectedTemperatureZone = ""
 		ga.selectedParentContainerID = nil
 	}
 
 	// Handle create object button
 	if ga.widgetState.createObjectButton.Clicked(gtx) {
-		ga.logger.Info("Opening create object dialog")
-		ga.showObjectDialog = true
-		ga.objectDialogMode = "create"
-		ga.selectedContainerID = nil
-		ga.widgetState.objectNameEditor.SetText("")
-		ga.widgetState.objectDescriptionEditor.SetText("")
-		ga.widgetState.objectQuantityEditor.SetText("")
-		ga.widgetState.objectUnitEditor.SetText("")
-		// Clear schema property editors
-		for _, ed := range ga.widgetState.objectPropertyEditors {
-			ed.SetText("")
-		}
-		for _, b := range ga.widgetState.objectPropertyBools {
-			b.Value = false
-		}
+		ga.openCreateObjectDialog()
 	}
 
 	// Handle import button
@@ -140,6 +161,21 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 		ga.openSchemaEditor()
 	}
 
+	// Handle find duplicates button
+	if ga.widgetState.findDuplicatesButton.Clicked(gtx) {
+		ga.openMergeDialog()
+	}
+
+	// Handle snapshots button
+	if ga.widgetState.snapshotsButton.Clicked(gtx) {
+		ga.openSnapshotDialog()
+	}
+
+	// Handle comments button
+	if ga.widgetState.commentsButton.Clicked(gtx) {
+		ga.openCommentsDialog()
+	}
+
 	// Handle container panel toggle
 	if ga.widgetState.toggleContainersButton.Clicked(gtx) {
 		ga.showContainersPanel = !ga.showContainersPanel
@@ -155,6 +191,9 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 	if ga.widgetState.containerViewGroupBtn.Clicked(gtx) {
 		ga.containerViewMode = "grouped"
 	}
+	if ga.widgetState.containerViewMapBtn.Clicked(gtx) {
+		ga.containerViewMode = "map"
+	}
 
 	// Handle object layout toggle
 	if ga.widgetState.objectViewListBtn.Clicked(gtx) {
@@ -175,6 +214,13 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 	if ga.widgetState.objectViewTreeBtn.Clicked(gtx) {
 		ga.objectViewLayout = ObjectViewTree
 	}
+	if ga.widgetState.objectViewPhotosBtn.Clicked(gtx) {
+		if ga.objectViewLayout != ObjectViewPhotos {
+			// Start from the newest photos each time the tab is opened
+			ga.resetCollectionPhotos()
+		}
+		ga.objectViewLayout = ObjectViewPhotos
+	}
 	if ga.widgetState.statsToggleBtn.Clicked(gtx) {
 		ga.showStatsPanel = !ga.showStatsPanel
 	}
@@ -203,30 +249,10 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 						Left:   unit.Dp(theme.Spacing4),
 						Right:  unit.Dp(theme.Spacing4),
 					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						if ga.showContainersPanel && ga.containerViewMode == "grouped" && ga.objectGroupByField == "" {
-							// Grouped mode: objects grouped under container headers (legacy, when no explicit group-by selected)
-							return ga.renderObjectsGroupedByContainer(gtx)
+						if obj := ga.detailObject(); obj != nil {
+							return ga.renderWithObjectDetail(gtx, *obj, ga.renderObjectsArea)
 						}
-						if ga.showContainersPanel && ga.containerViewMode == "split" {
-							// Split view: Containers on left (≤1/3), Objects on right
-							return layout.Flex{
-								Axis:    layout.Horizontal,
-								Spacing: layout.SpaceBetween,
-							}.Layout(gtx,
-								layout.Flexed(0.3, func(gtx layout.Context) layout.Dimensions {
-									return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-										return ga.renderContainersColumn(gtx)
-									})
-								}),
-								layout.Flexed(0.7, func(gtx layout.Context) layout.Dimensions {
-									return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-										return ga.renderObjectsColumn(gtx)
-									})
-								}),
-							)
-						}
-						// Default: objects only (full width)
-						return ga.renderObjectsColumn(gtx)
+						return ga.renderObjectsArea(gtx)
 					})
 				}),
 
@@ -256,12 +282,93 @@ func (ga *GioApp) renderCollectionDetailView(gtx layout.Context) layout.Dimensio
 		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
 			return ga.renderSchemaEditorDialog(gtx)
 		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderMergeDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderRetagDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBatchMoveDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBatchDeleteDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderPhotoDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderCodeMatchDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderSnapshotDialog(gtx)
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderCommentsDialog(gtx)
+		}),
 		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
 			return ga.renderAPIErrorDialog(gtx)
 		}),
 	)
 }
 
+// renderObjectsArea lays out the objects column, with the containers column
+// beside or above it when the containers panel is shown
+func (ga *GioApp) renderObjectsArea(gtx layout.Context) layout.Dimensions {
+	if ga.showContainersPanel && ga.containerViewMode == "grouped" && ga.objectGroupByField == "" {
+		// Grouped mode: objects grouped under container headers (legacy, when no explicit group-by selected)
+		return ga.renderObjectsGroupedByContainer(gtx)
+	}
+	if ga.showContainersPanel && ga.containerViewMode == "split" && ga.sizeClass == sizeCompact {
+		// Too narrow for side-by-side: containers above objects
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Flexed(0.4, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderContainersColumn(gtx)
+				})
+			}),
+			layout.Flexed(0.6, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderObjectsColumn(gtx)
+			}),
+		)
+	}
+	if ga.showContainersPanel && ga.containerViewMode == "split" {
+		// Split view: Containers on left (≤1/3), Objects on right
+		return layout.Flex{
+			Axis:    layout.Horizontal,
+			Spacing: layout.SpaceBetween,
+		}.Layout(gtx,
+			layout.Flexed(0.3, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderContainersColumn(gtx)
+				})
+			}),
+			layout.Flexed(0.7, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderObjectsColumn(gtx)
+				})
+			}),
+		)
+	}
+	if ga.showContainersPanel && ga.containerViewMode == "map" {
+		// Map view: floor plan beside the objects, whose search highlights pins
+		axis := layout.Horizontal
+		if ga.sizeClass == sizeCompact {
+			axis = layout.Vertical
+		}
+		return layout.Flex{Axis: axis}.Layout(gtx,
+			layout.Flexed(0.6, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Right: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, ga.renderFloorPlanPanel)
+			}),
+			layout.Flexed(0.4, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderObjectsColumn(gtx)
+			}),
+		)
+	}
+	// Default: objects only (full width)
+	return ga.renderObjectsColumn(gtx)
+}
+
 // renderCollectionDetailHeader renders the header with collection info and back button
 func (ga *GioApp) renderCollectionDetailHeader(gtx layout.Context) layout.Dimensions {
 	return widgets.Card{
@@ -299,7 +406,7 @@ func (ga *GioApp) renderCollectionDetailHeader(gtx layout.Context) layout.Dimens
 						return label.Layout(gtx)
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						info := fmt.Sprintf("%s • %s", objectTypeLabels[ga.selectedCollection.ObjectType], ga.selectedCollection.Location)
+						info := fmt.Sprintf("%s • %s", ga.objectTypeTitle(ga.selectedCollection.ObjectType), ga.selectedCollection.Location)
 						label := material.Body2(ga.theme.Theme, info)
 						label.Color = theme.ColorWhite
 						return label.Layout(gtx)
@@ -363,6 +470,39 @@ func (ga *GioApp) renderCollectionDetailHeader(gtx layout.Context) layout.Dimens
 					return btn.Layout(gtx)
 				})
 			}),
+
+			// Find duplicates button
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					btn := material.Button(ga.theme.Theme, &ga.widgetState.findDuplicatesButton, "Duplicates")
+					btn.Background = theme.ColorPrimaryDark
+					btn.Color = theme.ColorWhite
+					btn.CornerRadius = unit.Dp(theme.RadiusDefault)
+					return btn.Layout(gtx)
+				})
+			}),
+
+			// Snapshots button
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					btn := material.Button(ga.theme.Theme, &ga.widgetState.snapshotsButton, "Snapshots")
+					btn.Background = theme.ColorPrimaryDark
+					btn.Color = theme.ColorWhite
+					btn.CornerRadius = unit.Dp(theme.RadiusDefault)
+					return btn.Layout(gtx)
+				})
+			}),
+
+			// Comments button
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					btn := material.Button(ga.theme.Theme, &ga.widgetState.commentsButton, "Comments")
+					btn.Background = theme.ColorPrimaryDark
+					btn.Color = theme.ColorWhite
+					btn.CornerRadius = unit.Dp(theme.RadiusDefault)
+					return btn.Layout(gtx)
+				})
+			}),
 		)
 	})
 }
@@ -384,7 +524,7 @@ func (ga *GioApp) renderContainersColumn(gtx layout.Context) layout.Dimensions {
 						return label.Layout(gtx)
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.createContainerButton, "+")(gtx)
+						return widgets.PrimaryIconButton(ga.theme.Theme, &ga.widgetState.createContainerButton, "+", "New container")(gtx)
 					}),
 				)
 			})
@@ -402,14 +542,14 @@ func (ga *GioApp) renderContainersColumn(gtx layout.Context) layout.Dimensions {
 		// Containers list (or loading indicator)
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 			if ga.loadingContainersObjects {
-				return ga.renderLoadingIndicator(gtx, "Loading containers...")
+				return widgets.SkeletonList(skeletonRows)(gtx)
 			}
 			return ga.renderContainersList(gtx)
 		}),
 	)
 }
 
-// renderContainerViewModeToggle renders split/grouped toggle chips when the container panel is visible.
+// renderContainerViewModeToggle renders split/grouped/map toggle chips when the container panel is visible.
 func (ga *GioApp) renderContainerViewModeToggle(gtx layout.Context) layout.Dimensions {
 	if !ga.showContainersPanel {
 		return layout.Dimensions{}
@@ -422,7 +562,12 @@ func (ga *GioApp) renderContainerViewModeToggle(gtx layout.Context) layout.Dimen
 				})
 			}),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return ga.renderFilterChip(gtx, &ga.widgetState.containerViewGroupBtn, "Grouped", ga.containerViewMode == "grouped")
+				return layout.Inset{Right: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderFilterChip(gtx, &ga.widgetState.containerViewGroupBtn, "Grouped", ga.containerViewMode == "grouped")
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFilterChip(gtx, &ga.widgetState.containerViewMapBtn, "Map", ga.containerViewMode == "map")
 			}),
 		)
 	})
@@ -444,13 +589,17 @@ func (ga *GioApp) renderObjectsColumn(gtx layout.Context) layout.Dimensions {
 						label.Font.Weight = font.Bold
 						return label.Layout(gtx)
 					}),
+					layout.Rigid(ga.renderSelectObjectsButton),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.createObjectButton, "+")(gtx)
+						return widgets.PrimaryIconButton(ga.theme.Theme, &ga.widgetState.createObjectButton, "+", "New object")(gtx)
 					}),
 				)
 			})
 		}),
 
+		// Multi-select actions
+		layout.Rigid(ga.renderSelectionToolbar),
+
 		// Container view mode toggle + object layout toggle
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle, Spacing: layout.SpaceBetween}.Layout(gtx,
@@ -492,8 +641,15 @@ func (ga *GioApp) renderObjectsColumn(gtx layout.Context) layout.Dimensions {
 
 		// Objects list/grid/etc (or loading indicator)
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			if ga.objectViewLayout == ObjectViewPhotos {
+				return ga.renderCollectionPhotos(gtx)
+			}
 			if ga.loadingContainersObjects {
-				return ga.renderLoadingIndicator(gtx, "Loading objects...")
+				return widgets.SkeletonList(skeletonRows)(gtx)
+			}
+			if ga.treePartial && ga.treeNeedsAllObjects() {
+				ga.ensureAllObjects()
+				return widgets.SkeletonList(skeletonRows)(gtx)
 			}
 			if ga.objectGroupByField != "" {
 				return ga.renderObjectsGroupedByField(gtx)
@@ -513,6 +669,11 @@ func (ga *GioApp) renderObjectsColumn(gtx layout.Context) layout.Dimensions {
 				return ga.renderObjectsList(gtx)
 			}
 		}),
+
+		// Archived objects (collapsed by default)
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderArchivedSection(gtx)
+		}),
 	)
 }
 
@@ -671,6 +832,25 @@ func (ga *GioApp) cycleSort(field string) {
 	ga.objectSortSpecs = append(ga.objectSortSpecs, sortSpec{field: field, dir: "asc"})
 }
 
+// serverSortFields are the object fields the backend can order by.
+var serverSortFields = map[string]bool{
+	"name":       true,
+	"created_at": true,
+	"updated_at": true,
+	"expires_at": true,
+	"quantity":   true,
+}
+
+// serverObjectSort maps the primary sort spec to backend sort parameters so
+// fetched objects arrive already ordered. Chained and property sorts are still
+// applied locally by sortObjects.
+func (ga *GioApp) serverObjectSort() types.SortOptions {
+	if len(ga.objectSortSpecs) == 0 || !serverSortFields[ga.objectSortSpecs[0].field] {
+		return types.SortOptions{}
+	}
+	return types.SortOptions{Field: ga.objectSortSpecs[0].field, Order: ga.objectSortSpecs[0].dir}
+}
+
 // sortObjects sorts the filtered/indices slices in place according to objectSortSpecs.
 func (ga *GioApp) sortObjects(filtered []Object, indices []int) {
 	defMap := ga.getPropertyDefMap()
@@ -766,6 +946,8 @@ func (s *objectSorter) fieldType(field string) string {
 		return "text"
 	case "quantity":
 		return "numeric"
+	case "created_at", "updated_at", "expires_at":
+		return "date"
 	}
 	if def, ok := s.defMap[field]; ok && def != nil {
 		return def.Type
@@ -810,6 +992,17 @@ func (s *objectSorter) numericValue(obj Object, field string) (float64, bool) {
 }
 
 func (s *objectSorter) dateValue(obj Object, field string) (time.Time, bool) {
+	switch field {
+	case "created_at":
+		return obj.CreatedAt, !obj.CreatedAt.IsZero()
+	case "updated_at":
+		return obj.UpdatedAt, !obj.UpdatedAt.IsZero()
+	case "expires_at":
+		if obj.ExpiresAt == nil {
+			return time.Time{}, false
+		}
+		return *obj.ExpiresAt, true
+	}
 	tv, ok := obj.Properties[field]
 	if !ok || tv.Val == nil {
 		return time.Time{}, false
@@ -901,6 +1094,8 @@ func (ga *GioApp) renderContainerCard(gtx layout.Context, container Container, i
 		ga.containerDialogMode = "edit"
 		ga.widgetState.containerNameEditor.SetText(container.Name)
 		ga.widgetState.containerLocationEditor.SetText(container.Location)
+		ga.widgetState.containerHumidityEditor.SetText(formatHumidity(container.Humidity))
+		ga.selectedTemperatureZone = container.TemperatureZone
 		if container.ParentContainerID != nil {
 			ga.selectedParentContainerID = container.ParentContainerID
 		} else {
@@ -915,6 +1110,18 @@ func (ga *GioApp) renderContainerCard(gtx layout.Context, container Container, i
 		ga.deleteContainerID = container.ID
 	}
 
+	// Handle pin and move buttons
+	if itemState.pinButton.Clicked(gtx) {
+		ga.toggleContainerPin(container.ID)
+	}
+	if itemState.moveUpButton.Clicked(gtx) {
+		ga.moveContainer(index, -1)
+	}
+	if itemState.moveDownButton.Clicked(gtx) {
+		ga.moveContainer(index, 1)
+	}
+	pinned := ga.containerOrder[container.ID].Pinned
+
 	card := widgets.DefaultCard()
 	return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -930,9 +1137,12 @@ func (ga *GioApp) renderContainerCard(gtx layout.Context, container Container, i
 						label.Font.Weight = font.Bold
 						return label.Layout(gtx)
 					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return ga.renderPinnedMarker(gtx, pinned)
+					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							label := material.Body2(ga.theme.Theme, containerTypeLabels[container.Type])
+							label := material.Body2(ga.theme.Theme, containerBadgeText(container))
 							label.Color = theme.ColorAccent
 							return label.Layout(gtx)
 						})
@@ -969,6 +1179,9 @@ func (ga *GioApp) renderContainerCard(gtx layout.Context, container Container, i
 						Axis:    layout.Horizontal,
 						Spacing: layout.SpaceStart,
 					}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return ga.renderOrderButtons(gtx, &itemState.pinButton, &itemState.moveUpButton, &itemState.moveDownButton, pinned)
+						}),
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 								return widgets.AccentButton(ga.theme.Theme, &itemState.editButton, "Edit")(gtx)
@@ -988,50 +1201,13 @@ func (ga *GioApp) renderContainerCard(gtx layout.Context, container Container, i
 func (ga *GioApp) renderObjectCard(gtx layout.Context, object Object, index int) layout.Dimensions {
 	itemState := &ga.widgetState.objectItems[index]
 
+	if ga.objectTapped(gtx, object, &itemState.cardButton, &itemState.longPress) {
+		ga.openObjectDetail(object)
+	}
+
 	// Handle edit button click
 	if itemState.editButton.Clicked(gtx) {
-		ga.logger.Info("Opening edit object dialog", "object_id", object.ID)
-		ga.selectedObject = &object
-		ga.showObjectDialog = true
-		ga.objectDialogMode = "edit"
-		ga.widgetState.objectNameEditor.SetText(object.Name)
-		ga.widgetState.objectDescriptionEditor.SetText(object.Description)
-		if object.Quantity != nil {
-			ga.widgetState.objectQuantityEditor.SetText(fmt.Sprintf("%v", *object.Quantity))
-		} else {
-			ga.widgetState.objectQuantityEditor.SetText("")
-		}
-		ga.widgetState.objectUnitEditor.SetText(object.Unit)
-		if object.ContainerID != "" {
-			cid := object.ContainerID
-			ga.selectedContainerID = &cid
-		} else {
-			ga.selectedContainerID = nil
-		}
-		// Populate schema property editors from existing object properties
-		if ga.selectedCollection != nil && ga.selectedCollection.PropertySchema != nil {
-			for _, def := range ga.selectedCollection.PropertySchema.Definitions {
-				if def.Type == "bool" {
-					b := ga.getObjectPropertyBool(def.Key)
-					b.Value = false
-					if tv, ok := object.Properties[def.Key]; ok {
-						switch v := tv.Val.(type) {
-						case bool:
-							b.Value = v
-						case string:
-							b.Value = strings.EqualFold(v, "true") || v == "1"
-						}
-					}
-				} else {
-					ed := ga.getObjectPropertyEditor(def.Key)
-					if tv, ok := object.Properties[def.Key]; ok {
-						ed.SetText(fmt.Sprintf("%v", tv.Val))
-					} else {
-						ed.SetText("")
-					}
-				}
-			}
-		}
+		ga.openObjectDetail(object)
 	}
 
 	// Handle delete button click
@@ -1041,100 +1217,224 @@ func (ga *GioApp) renderObjectCard(gtx layout.Context, object Object, index int)
 		ga.deleteObjectID = object.ID
 	}
 
-	card := widgets.DefaultCard()
-	return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-			// Object name
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				label := material.Body1(ga.theme.Theme, object.Name)
-				label.Font.Weight = font.Bold
-				return label.Layout(gtx)
-			}),
+	if itemState.photoButton.Clicked(gtx) {
+		ga.openPhotoDialog(object)
+	}
 
-			// Description
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if object.Description != "" {
-					return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						label := material.Body2(ga.theme.Theme, object.Description)
-						label.Color = theme.ColorTextSecondary
-						return label.Layout(gtx)
-					})
-				}
-				return layout.Dimensions{}
-			}),
+	if itemState.ownedButton.Clicked(gtx) {
+		ga.markObjectOwned(object)
+	}
 
-			// Location
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				loc := ga.getObjectEffectiveLocation(object)
-				if loc != "" {
-					return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						label := material.Body2(ga.theme.Theme, loc)
-						label.Color = theme.ColorPrimaryLight
-						return label.Layout(gtx)
-					})
-				}
-				return layout.Dimensions{}
-			}),
+	// Handle claim button click
+	if itemState.claimButton.Clicked(gtx) {
+		ga.setObjectClaimed(object, activeClaim(object, time.Now()) == nil)
+	}
 
-			// Quantity
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if object.Quantity != nil && object.Unit != "" {
-					return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						label := material.Body2(ga.theme.Theme, fmt.Sprintf("Qty: %v %s", *object.Quantity, object.Unit))
-						label.Color = theme.ColorTextSecondary
-						return label.Layout(gtx)
-					})
-				}
-				return layout.Dimensions{}
-			}),
+	// Quick quantity adjustment
+	if object.Quantity != nil {
+		itemState.quantity.SetValue(*object.Quantity)
+		if quantity, ok := itemState.quantity.Update(gtx); ok {
+			ga.setObjectQuantity(object, quantity)
+		}
+	}
+	claim := activeClaim(object, time.Now())
+	claimText := ga.claimButtonText(object)
 
-			// Tags
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if len(object.Tags) > 0 {
-					return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						tagsText := strings.Join(object.Tags, ", ")
-						label := material.Body2(ga.theme.Theme, "Tags: "+tagsText)
-						label.Color = theme.ColorTextSecondary
-						return label.Layout(gtx)
-					})
-				}
-				return layout.Dimensions{}
-			}),
+	card := widgets.DefaultCard()
+	return layout.Stack{}.Layout(gtx,
+		// Click target behind the card's buttons
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			return widgets.ClickArea(gtx, &itemState.cardButton, "Open "+object.Name, func(gtx layout.Context) layout.Dimensions {
+				return layout.Dimensions{Size: gtx.Constraints.Min}
+			})
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					// Object name, stock and storage badges
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if !ga.selectingObjects {
+									return layout.Dimensions{}
+								}
+								return ga.renderObjectSelectBox(gtx, object, itemState)
+							}),
+							layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+								label := material.Body1(ga.theme.Theme, object.Name)
+								label.Font.Weight = font.Bold
+								return label.Layout(gtx)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								return ga.renderStockBadge(gtx, object)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if object.Condition == "" {
+									return layout.Dimensions{}
+								}
+								return layout.Inset{Left: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return ga.renderConditionBadge(gtx, object)
+								})
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if isOwned(object) {
+									return layout.Dimensions{}
+								}
+								return layout.Inset{Left: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return ga.renderStatusBadge(gtx, object)
+								})
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if object.StorageWarning == nil {
+									return layout.Dimensions{}
+								}
+								return layout.Inset{Left: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return ga.renderStorageWarningBadge(gtx, object)
+								})
+							}),
+						)
+					}),
 
-			// Properties
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if len(object.Properties) == 0 {
-					return layout.Dimensions{}
-				}
-				var defs []PropertyDefinition
-				if ga.selectedCollection != nil && ga.selectedCollection.PropertySchema != nil {
-					defs = ga.selectedCollection.PropertySchema.Definitions
-				}
-				return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-					return ga.renderObjectProperties(gtx, object.Properties, defs)
-				})
-			}),
+					// Storage warning
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if object.StorageWarning == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							label := material.Caption(ga.theme.Theme, object.StorageWarning.Message)
+							label.Color = theme.ColorWarning
+							return label.Layout(gtx)
+						})
+					}),
 
-			// Action buttons
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-					return layout.Flex{
-						Axis:    layout.Horizontal,
-						Spacing: layout.SpaceStart,
-					}.Layout(gtx,
-						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								return widgets.AccentButton(ga.theme.Theme, &itemState.editButton, "Edit")(gtx)
+					// Description
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if object.Description != "" {
+							return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								label := material.Body2(ga.theme.Theme, object.Description)
+								label.Color = theme.ColorTextSecondary
+								return label.Layout(gtx)
 							})
-						}),
-						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							return widgets.DangerButton(ga.theme.Theme, &itemState.deleteButton, "Delete")(gtx)
-						}),
-					)
-				})
-			}),
-		)
-	})
+						}
+						return layout.Dimensions{}
+					}),
+
+					// Location
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						loc := ga.getObjectEffectiveLocation(object)
+						if loc != "" {
+							return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								label := material.Body2(ga.theme.Theme, loc)
+								label.Color = theme.ColorPrimaryLight
+								return label.Layout(gtx)
+							})
+						}
+						return layout.Dimensions{}
+					}),
+
+					// Quantity
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if object.Quantity == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									return itemState.quantity.Layout(gtx, ga.theme.Theme, object.Unit)
+								}),
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									converted := ga.convertedQuantityText(object)
+									if converted == "" {
+										return layout.Dimensions{}
+									}
+									label := material.Body2(ga.theme.Theme, "("+converted+")")
+									label.Color = theme.ColorTextSecondary
+									return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, label.Layout)
+								}),
+							)
+						})
+					}),
+
+					// Tags
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if len(object.Tags) > 0 {
+							return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								tagsText := strings.Join(object.Tags, ", ")
+								label := material.Body2(ga.theme.Theme, "Tags: "+tagsText)
+								label.Color = theme.ColorTextSecondary
+								return label.Layout(gtx)
+							})
+						}
+						return layout.Dimensions{}
+					}),
+
+					// Properties
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if len(object.Properties) == 0 {
+							return layout.Dimensions{}
+						}
+						var defs []PropertyDefinition
+						if ga.selectedCollection != nil && ga.selectedCollection.PropertySchema != nil {
+							defs = ga.selectedCollection.PropertySchema.Definitions
+						}
+						return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderObjectProperties(gtx, object.Properties, defs)
+						})
+					}),
+
+					// Claim
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if claim == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderClaimBadge(gtx, claim)
+						})
+					}),
+
+					// Action buttons
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return layout.Flex{
+								Axis:    layout.Horizontal,
+								Spacing: layout.SpaceStart,
+							}.Layout(gtx,
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									if isOwned(object) {
+										return layout.Dimensions{}
+									}
+									return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+										return widgets.PrimaryButton(ga.theme.Theme, &itemState.ownedButton, "Move to owned")(gtx)
+									})
+								}),
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									if claimText == "" {
+										return layout.Dimensions{}
+									}
+									return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+										return widgets.CancelButton(ga.theme.Theme, &itemState.claimButton, claimText)(gtx)
+									})
+								}),
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+										return widgets.CancelButton(ga.theme.Theme, &itemState.photoButton, "Photo")(gtx)
+									})
+								}),
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+										return widgets.AccentButton(ga.theme.Theme, &itemState.editButton, "Edit")(gtx)
+									})
+								}),
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									return widgets.DangerButton(ga.theme.Theme, &itemState.deleteButton, "Delete")(gtx)
+								}),
+							)
+						})
+					}),
+				)
+			})
+		}),
+	)
 }
 
 // renderObjectsGroupedByContainer renders all objects grouped under container section headers.
@@ -1176,13 +1476,17 @@ func (ga *GioApp) renderObjectsGroupedByContainer(gtx layout.Context) layout.Dim
 						label.Font.Weight = font.Bold
 						return label.Layout(gtx)
 					}),
+					layout.Rigid(ga.renderSelectObjectsButton),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.createObjectButton, "+")(gtx)
+						return widgets.PrimaryIconButton(ga.theme.Theme, &ga.widgetState.createObjectButton, "+", "New object")(gtx)
 					}),
 				)
 			})
 		}),
 
+		// Multi-select actions
+		layout.Rigid(ga.renderSelectionToolbar),
+
 		// View mode toggle + layout toggle
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle, Spacing: layout.SpaceBetween}.Layout(gtx,
@@ -1211,6 +1515,9 @@ func (ga *GioApp) renderObjectsGroupedByContainer(gtx layout.Context) layout.Dim
 
 		// Grouped list/grid
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			if ga.objectViewLayout == ObjectViewPhotos {
+				return ga.renderCollectionPhotos(gtx)
+			}
 			if len(groups) == 0 {
 				return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 					label := material.Body1(ga.theme.Theme, "No objects yet")
@@ -1344,6 +1651,7 @@ func (ga *GioApp) renderObjectLayoutToggle(gtx layout.Context) layout.Dimensions
 		{ObjectViewTable, "Table", &ga.widgetState.objectViewTableBtn},
 		{ObjectViewGallery, "Gallery", &ga.widgetState.objectViewGalleryBtn},
 		{ObjectViewTree, "Tree", &ga.widgetState.objectViewTreeBtn},
+		{ObjectViewPhotos, "Photos", &ga.widgetState.objectViewPhotosBtn},
 	}
 	return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		children := make([]layout.FlexChild, len(chips)+1)
@@ -1600,10 +1908,19 @@ func (ga *GioApp) fetchContainersAndObjects() {
 	}
 	collectionID := ga.selectedCollection.ID
 	userID := ga.currentUser.ID
+	objectSort := ga.serverObjectSort()
+	// The tree layout loads each container's objects as it is expanded
+	lazy := ga.routeObjectLayout() == ObjectViewTree
 
 	ga.loadingContainersObjects = true
-
-	go func() {
+	ga.resetTreeObjects(lazy)
+	ga.resetObjectSelection()
+	ga.closeObjectDetail()
+	ga.resetArchivedObjects()
+	ga.resetCollectionPhotos()
+	ga.resetNutritionStats()
+
+	ga.goSafe(func() {
 		fetchStart := time.Now()
 		ga.logger.Info("Fetching containers and objects", "collection_id", collectionID)
 
@@ -1617,19 +1934,22 @@ func (ga *GioApp) fetchContainersAndObjects() {
 		)
 
 		var wg sync.WaitGroup
-		wg.Add(2)
-		go func() {
+		wg.Add(1)
+		ga.goSafe(func() {
 			defer wg.Done()
 			start := time.Now()
-			containers, contErr = ga.containersClient.List(userID, collectionID)
+			containers, contErr = ga.containersClient.List(userID, collectionID, customSort)
 			contTime = time.Since(start)
-		}()
-		go func() {
-			defer wg.Done()
-			start := time.Now()
-			objects, objErr = ga.objectsClient.ListByCollection(userID, collectionID)
-			objTime = time.Since(start)
-		}()
+		})
+		if !lazy {
+			wg.Add(1)
+			ga.goSafe(func() {
+				defer wg.Done()
+				start := time.Now()
+				objects, objErr = ga.objectsClient.ListByCollection(userID, collectionID, objectSort)
+				objTime = time.Since(start)
+			})
+		}
 		wg.Wait()
 
 		ga.logger.Info("Fetch complete",
@@ -1665,16 +1985,6 @@ func (ga *GioApp) fetchContainersAndObjects() {
 			ga.invalidateObjectCaches()
 			ga.logger.Info("State updated", "objects", len(ga.objects), "containers", len(ga.containers))
 		})
-	}()
-}
-
-// renderLoadingIndicator renders a centered loading message.
-func (ga *GioApp) renderLoadingIndicator(gtx layout.Context, msg string) layout.Dimensions {
-	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		label := material.Body1(ga.theme.Theme, msg)
-		label.Color = theme.ColorTextSecondary
-		label.Alignment = text.Middle
-		return label.Layout(gtx)
 	})
 }
 
@@ -1945,13 +2255,18 @@ func (ga *GioApp) renderChipSelector(gtx layout.Context, label string, chips []l
 
 // renderFilterChip renders a single filter chip button, highlighted when active.
 func (ga *GioApp) renderFilterChip(gtx layout.Context, btn *widget.Clickable, label string, active bool) layout.Dimensions {
+	return filterChip(label, active).Layout(gtx, ga.theme.Theme, btn)
+}
+
+// filterChip styles a pill-shaped chip, highlighted when active
+func filterChip(label string, active bool) widgets.Button {
 	bg := theme.ColorSurfaceAlt
 	textColor := theme.ColorTextPrimary
 	if active {
 		bg = theme.ColorPrimary
 		textColor = theme.ColorWhite
 	}
-	b := widgets.Button{
+	return widgets.Button{
 		Text:            label,
 		BackgroundColor: bg,
 		TextColor:       textColor,
@@ -1963,7 +2278,6 @@ func (ga *GioApp) renderFilterChip(gtx layout.Context, btn *widget.Clickable, la
 			Right:  unit.Dp(theme.Spacing2),
 		},
 	}
-	return b.Layout(gtx, ga.theme.Theme, btn)
 }
 
 // sortGroupField describes a field available for sorting/grouping.
@@ -1973,11 +2287,15 @@ type sortGroupField struct {
 }
 
 // getSortableFields returns the list of fields available for sorting.
-// Always includes Name and Location, plus each schema property.
+// Always includes the built-in object fields, plus each schema property.
 func (ga *GioApp) getSortableFields() []sortGroupField {
 	fields := []sortGroupField{
 		{key: "name", displayName: "Name"},
 		{key: "location", displayName: "Location"},
+		{key: "quantity", displayName: "Quantity"},
+		{key: "expires_at", displayName: "Expires"},
+		{key: "created_at", displayName: "Created"},
+		{key: "updated_at", displayName: "Updated"},
 	}
 	if ga.selectedCollection != nil && ga.selectedCollection.PropertySchema != nil {
 		for _, def := range ga.selectedCollection.PropertySchema.Definitions {
@@ -18,6 +18,7 @@ import (
 	"gioui.org/widget/material"
 
 	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
 )
 
 // ============================================================
@@ -128,8 +129,8 @@ func (ga *GioApp) renderObjectsCompact(gtx layout.Context) layout.Dimensions {
 func (ga *GioApp) renderCompactRow(gtx layout.Context, obj Object, index int) layout.Dimensions {
 	itemState := &ga.widgetState.objectItems[index]
 
-	if itemState.editButton.Clicked(gtx) {
-		ga.openObjectEditDialog(obj)
+	if ga.objectTapped(gtx, obj, &itemState.editButton, &itemState.longPress) {
+		ga.openObjectDetail(obj)
 	}
 
 	rowHeight := gtx.Dp(unit.Dp(36))
@@ -144,6 +145,13 @@ func (ga *GioApp) renderCompactRow(gtx layout.Context, obj Object, index int) la
 					Axis:      layout.Horizontal,
 					Alignment: layout.Middle,
 				}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if !ga.selectingObjects {
+							return layout.Dimensions{}
+						}
+						return ga.renderObjectSelectBox(gtx, obj, itemState)
+					}),
+
 					// Name (bold, takes most space)
 					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 						label := material.Body2(ga.theme.Theme, obj.Name)
@@ -166,17 +174,44 @@ func (ga *GioApp) renderCompactRow(gtx layout.Context, obj Object, index int) la
 						})
 					}),
 
+					// Stock and storage badges
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderStockBadge(gtx, obj)
+						})
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if obj.Condition == "" {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderConditionBadge(gtx, obj)
+						})
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if isOwned(obj) {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderStatusBadge(gtx, obj)
+						})
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if obj.StorageWarning == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							return ga.renderStorageWarningBadge(gtx, obj)
+						})
+					}),
+
 					// Quantity (right-aligned)
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						if obj.Quantity == nil {
 							return layout.Dimensions{}
 						}
-						qtyText := fmt.Sprintf("%v", *obj.Quantity)
-						if obj.Unit != "" {
-							qtyText += " " + obj.Unit
-						}
 						return layout.Inset{Left: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							label := material.Body2(ga.theme.Theme, qtyText)
+							label := material.Body2(ga.theme.Theme, ga.objectQuantityText(obj))
 							label.Color = theme.ColorTextSecondary
 							label.MaxLines = 1
 							return label.Layout(gtx)
@@ -187,13 +222,62 @@ func (ga *GioApp) renderCompactRow(gtx layout.Context, obj Object, index int) la
 		}),
 		// Click target over the whole row
 		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
-			return itemState.editButton.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return widgets.ClickArea(gtx, &itemState.editButton, "Open "+obj.Name, func(gtx layout.Context) layout.Dimensions {
 				return layout.Dimensions{Size: image.Point{X: gtx.Constraints.Max.X, Y: rowHeight}}
 			})
 		}),
 	)
 }
 
+// renderStockBadge renders a small "Low" (yellow) or "Out" (red) pill for
+// objects at or below their restock threshold, and nothing otherwise.
+func (ga *GioApp) renderStockBadge(gtx layout.Context, obj Object) layout.Dimensions {
+	var text string
+	var bg color.NRGBA
+	switch obj.StockStatus {
+	case "out":
+		text, bg = "Out", theme.ColorDanger
+	case "low":
+		text, bg = "Low", theme.ColorWarning
+	default:
+		return layout.Dimensions{}
+	}
+	return ga.renderPill(gtx, text, bg)
+}
+
+// renderStorageWarningBadge renders an orange "Too warm" pill for food kept
+// in a container warmer than its category needs, and nothing otherwise.
+func (ga *GioApp) renderStorageWarningBadge(gtx layout.Context, obj Object) layout.Dimensions {
+	if obj.StorageWarning == nil {
+		return layout.Dimensions{}
+	}
+	return ga.renderPill(gtx, "Too warm", theme.ColorWarning)
+}
+
+// renderPill renders a small caption in white on a rounded background
+func (ga *GioApp) renderPill(gtx layout.Context, text string, bg color.NRGBA) layout.Dimensions {
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			rr := gtx.Dp(unit.Dp(theme.Spacing2))
+			defer clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, rr).Push(gtx.Ops).Pop()
+			paint.ColorOp{Color: bg}.Add(gtx.Ops)
+			paint.PaintOp{}.Add(gtx.Ops)
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Left: unit.Dp(theme.Spacing2), Right: unit.Dp(theme.Spacing2),
+				Top: unit.Dp(2), Bottom: unit.Dp(2),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(ga.theme.Theme, text)
+				label.Color = theme.ColorWhite
+				label.Font.Weight = font.Bold
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
+
 // ============================================================
 // Table View
 // ============================================================
@@ -288,7 +372,7 @@ func (ga *GioApp) renderTableHeader(gtx layout.Context, columns []tableColumn) l
 			}
 			btn := ga.getTableHeaderButton(col.key)
 			children[i] = layout.Flexed(col.flex, func(gtx layout.Context) layout.Dimensions {
-				return btn.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return widgets.ClickArea(gtx, btn, "Sort by "+col.displayName, func(gtx layout.Context) layout.Dimensions {
 					lbl := material.Body2(ga.theme.Theme, label)
 					lbl.Font.Weight = font.Bold
 					lbl.Color = theme.ColorTextSecondary
@@ -318,14 +402,18 @@ func (ga *GioApp) getTableHeaderButton(key string) *widget.Clickable {
 func (ga *GioApp) renderTableRow(gtx layout.Context, obj Object, index int, columns []tableColumn) layout.Dimensions {
 	itemState := &ga.widgetState.objectItems[index]
 
-	if itemState.editButton.Clicked(gtx) {
-		ga.openObjectEditDialog(obj)
+	if ga.objectTapped(gtx, obj, &itemState.editButton, &itemState.longPress) {
+		ga.openObjectDetail(obj)
 	}
 
 	defMap := ga.getPropertyDefMap()
 	rowHeight := gtx.Dp(unit.Dp(32))
+	selected := ga.selectedObjectIDs[obj.ID]
 
 	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			return selectionHighlight(gtx, selected)
+		}),
 		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
 			return layout.Inset{
 				Top: unit.Dp(2), Bottom: unit.Dp(2),
@@ -349,7 +437,7 @@ func (ga *GioApp) renderTableRow(gtx layout.Context, obj Object, index int, colu
 			})
 		}),
 		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
-			return itemState.editButton.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return widgets.ClickArea(gtx, &itemState.editButton, "Open "+obj.Name, func(gtx layout.Context) layout.Dimensions {
 				return layout.Dimensions{Size: image.Point{X: gtx.Constraints.Max.X, Y: rowHeight}}
 			})
 		}),
@@ -364,14 +452,7 @@ func (ga *GioApp) tableCellValue(obj Object, key string, defMap map[string]*Prop
 	case "location":
 		return ga.getObjectEffectiveLocation(obj)
 	case "quantity":
-		if obj.Quantity == nil {
-			return ""
-		}
-		q := fmt.Sprintf("%v", *obj.Quantity)
-		if obj.Unit != "" {
-			q += " " + obj.Unit
-		}
-		return q
+		return ga.objectQuantityText(obj)
 	default:
 		if tv, ok := obj.Properties[key]; ok {
 			return RenderPropertyValueFromMap(key, tv, defMap)
@@ -451,8 +532,8 @@ func (ga *GioApp) renderGalleryGrid(gtx layout.Context, indices []int) layout.Di
 func (ga *GioApp) renderObjectThumbnail(gtx layout.Context, obj Object, index int, size int) layout.Dimensions {
 	itemState := &ga.widgetState.objectItems[index]
 
-	if itemState.editButton.Clicked(gtx) {
-		ga.openObjectEditDialog(obj)
+	if ga.objectTapped(gtx, obj, &itemState.editButton, &itemState.longPress) {
+		ga.openObjectDetail(obj)
 	}
 
 	cellH := size + gtx.Dp(unit.Dp(24))
@@ -492,8 +573,13 @@ func (ga *GioApp) renderObjectThumbnail(gtx layout.Context, obj Object, index in
 	})
 	nameOffset.Pop()
 
+	// Tint selected thumbnails in multi-select
+	cellGtx := gtx
+	cellGtx.Constraints = layout.Exact(image.Point{X: size, Y: cellH})
+	selectionHighlight(cellGtx, ga.selectedObjectIDs[obj.ID])
+
 	// Clickable overlay
-	itemState.editButton.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+	widgets.ClickArea(gtx, &itemState.editButton, "Open "+obj.Name, func(gtx layout.Context) layout.Dimensions {
 		return layout.Dimensions{Size: image.Point{X: size, Y: cellH}}
 	})
 
@@ -560,7 +646,7 @@ func (ga *GioApp) renderThumbnailPlaceholder(gtx layout.Context, name string, si
 
 // renderObjectsTree renders objects in an expandable container hierarchy.
 func (ga *GioApp) renderObjectsTree(gtx layout.Context) layout.Dimensions {
-	if len(ga.objects) == 0 {
+	if len(ga.objects) == 0 && (!ga.treePartial || len(ga.containers) == 0) {
 		return ga.renderEmptyObjects(gtx)
 	}
 
@@ -605,6 +691,9 @@ func (ga *GioApp) renderObjectsTree(gtx layout.Context) layout.Dimensions {
 	var walkContainer func(c Container, depth int)
 	walkContainer = func(c Container, depth int) {
 		objCount := len(containerObjs[c.ID])
+		if !ga.treeContainerLoaded(c.ID) {
+			objCount = c.ObjectCount
+		}
 		items = append(items, treeItem{
 			isContainer: true,
 			containerID: c.ID,
@@ -614,6 +703,7 @@ func (ga *GioApp) renderObjectsTree(gtx layout.Context) layout.Dimensions {
 		})
 
 		if ga.treeExpandedNodes[c.ID] {
+			ga.ensureContainerObjects(c.ID)
 			// Child containers
 			for _, child := range childContainers[c.ID] {
 				walkContainer(child, depth+1)
@@ -692,7 +782,7 @@ func (ga *GioApp) renderTreeNode(gtx layout.Context, isContainer bool, container
 		label := fmt.Sprintf("%s %s (%d)", arrow, name, childCount)
 
 		return layout.Inset{Left: indent, Top: unit.Dp(theme.Spacing1), Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			return btn.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return widgets.ClickArea(gtx, btn, label, func(gtx layout.Context) layout.Dimensions {
 				lbl := material.Body1(ga.theme.Theme, label)
 				lbl.Font.Weight = font.Bold
 				lbl.Color = theme.ColorTextPrimary
@@ -703,15 +793,23 @@ func (ga *GioApp) renderTreeNode(gtx layout.Context, isContainer bool, container
 
 	// Object leaf
 	itemState := &ga.widgetState.objectItems[objIndex]
-	if itemState.editButton.Clicked(gtx) {
-		ga.openObjectEditDialog(ga.objects[objIndex])
+	obj := ga.objects[objIndex]
+	if ga.objectTapped(gtx, obj, &itemState.editButton, &itemState.longPress) {
+		ga.openObjectDetail(obj)
 	}
 
 	return layout.Inset{Left: indent, Top: unit.Dp(1), Bottom: unit.Dp(1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		return itemState.editButton.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			lbl := material.Body2(ga.theme.Theme, name)
-			lbl.MaxLines = 1
-			return lbl.Layout(gtx)
+		return widgets.ClickArea(gtx, &itemState.editButton, "Open "+name, func(gtx layout.Context) layout.Dimensions {
+			return layout.Stack{}.Layout(gtx,
+				layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+					return selectionHighlight(gtx, ga.selectedObjectIDs[obj.ID])
+				}),
+				layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+					lbl := material.Body2(ga.theme.Theme, name)
+					lbl.MaxLines = 1
+					return lbl.Layout(gtx)
+				}),
+			)
 		})
 	})
 }
@@ -742,6 +840,10 @@ type statsPropDist struct {
 // whose random order can reorder tied values.
 type statsData struct {
 	total             int
+	lowStock          int
+	outOfStock        int
+	conditions        string // graded objects per condition, best first
+	wishlist          string // wanted and ordered objects, left out of the rest
 	containerBars     []statsContainerBar
 	containerMaxCnt   int
 	tags              []statsTagEntry
@@ -761,12 +863,25 @@ func (ga *GioApp) getStats() *statsData {
 // computeStats builds the stats snapshot from ga.objects and ga.selectedCollection.
 // Ties are broken alphabetically so repeated calls produce identical output.
 func (ga *GioApp) computeStats() *statsData {
-	s := &statsData{total: len(ga.objects)}
+	// Wishlist items are counted on their own line only, as the backend does
+	objects := ownedObjects(ga.objects)
+	s := &statsData{total: len(objects), wishlist: wishlistSummary(ga.objects)}
+
+	for _, obj := range objects {
+		switch obj.StockStatus {
+		case "out":
+			s.outOfStock++
+		case "low":
+			s.lowStock++
+		}
+	}
+
+	s.conditions = conditionSummary(objects)
 
 	// Container distribution
 	containerCounts := make(map[string]int)
 	unassigned := 0
-	for _, obj := range ga.objects {
+	for _, obj := range objects {
 		if obj.ContainerID == "" {
 			unassigned++
 		} else {
@@ -791,7 +906,7 @@ func (ga *GioApp) computeStats() *statsData {
 
 	// Tag cloud
 	tagFreq := make(map[string]int)
-	for _, obj := range ga.objects {
+	for _, obj := range objects {
 		for _, tag := range obj.Tags {
 			tagFreq[tag]++
 		}
@@ -816,7 +931,7 @@ func (ga *GioApp) computeStats() *statsData {
 				continue
 			}
 			valFreq := make(map[string]int)
-			for _, obj := range ga.objects {
+			for _, obj := range objects {
 				if tv, ok := obj.Properties[def.Key]; ok {
 					v := fmt.Sprintf("%v", tv.Val)
 					if v != "" {
@@ -878,6 +993,53 @@ func (ga *GioApp) renderCollectionStats(gtx layout.Context) layout.Dimensions {
 				})
 			}),
 
+			// Restock summary
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				stats := ga.getStats()
+				if stats.lowStock == 0 && stats.outOfStock == 0 {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					lbl := material.Body2(ga.theme.Theme, fmt.Sprintf("Low stock: %d · Out of stock: %d", stats.lowStock, stats.outOfStock))
+					lbl.Color = theme.ColorWarning
+					if stats.outOfStock > 0 {
+						lbl.Color = theme.ColorDanger
+					}
+					return lbl.Layout(gtx)
+				})
+			}),
+
+			// Condition grades, for collectibles
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				stats := ga.getStats()
+				if stats.conditions == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					lbl := material.Body2(ga.theme.Theme, "Condition: "+stats.conditions)
+					lbl.Color = theme.ColorTextSecondary
+					return lbl.Layout(gtx)
+				})
+			}),
+
+			// Wishlist
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				stats := ga.getStats()
+				if stats.wishlist == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					lbl := material.Body2(ga.theme.Theme, "Wishlist: "+stats.wishlist)
+					lbl.Color = theme.ColorStatusWanted
+					return lbl.Layout(gtx)
+				})
+			}),
+
+			// Calories available, for food collections
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderNutritionStats(gtx)
+			}),
+
 			// Objects per container
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return ga.renderContainerDistribution(gtx)
@@ -1035,14 +1197,24 @@ func (ga *GioApp) openObjectEditDialog(obj Object) {
 	ga.selectedObject = &obj
 	ga.showObjectDialog = true
 	ga.objectDialogMode = "edit"
+	ga.objectDialogErr = ""
+	ga.loadObjectHistory(obj.ID)
+	ga.loadObjectRecurrence(obj.ID)
 	ga.widgetState.objectNameEditor.SetText(obj.Name)
 	ga.widgetState.objectDescriptionEditor.SetText(obj.Description)
 	if obj.Quantity != nil {
-		ga.widgetState.objectQuantityEditor.SetText(fmt.Sprintf("%v", *obj.Quantity))
+		ga.widgetState.objectQuantityStepper.SetText(fmt.Sprintf("%v", *obj.Quantity))
 	} else {
-		ga.widgetState.objectQuantityEditor.SetText("")
+		ga.widgetState.objectQuantityStepper.SetText("")
 	}
 	ga.widgetState.objectUnitEditor.SetText(obj.Unit)
+	if obj.MinQuantity != nil {
+		ga.widgetState.objectMinQuantityEditor.SetText(fmt.Sprintf("%v", *obj.MinQuantity))
+	} else {
+		ga.widgetState.objectMinQuantityEditor.SetText("")
+	}
+	ga.objectCondition = obj.Condition
+	ga.objectStatus = obj.Status
 	if obj.ContainerID != "" {
 		cid := obj.ContainerID
 		ga.selectedContainerID = &cid
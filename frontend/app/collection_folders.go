@@ -0,0 +1,624 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gioui.org/io/event"
+	"gioui.org/io/transfer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// collectionDragType is the MIME type a collection card offers when it is
+// dragged onto a folder; the data is the collection ID
+const collectionDragType = "application/x-nishiki-collection"
+
+// childFolders returns the folders directly inside parentID, or the top-level
+// folders when parentID is ""
+func childFolders(folders []CollectionFolder, parentID string) []CollectionFolder {
+	var children []CollectionFolder
+	for _, f := range folders {
+		if folderParentID(f) == parentID {
+			children = append(children, f)
+		}
+	}
+	return children
+}
+
+// folderParentID returns the ID of the folder f is nested in, or ""
+func folderParentID(f CollectionFolder) string {
+	if f.ParentID == nil {
+		return ""
+	}
+	return *f.ParentID
+}
+
+// findFolder returns the folder with the given ID, or nil
+func findFolder(folders []CollectionFolder, id string) *CollectionFolder {
+	for i := range folders {
+		if folders[i].ID == id {
+			return &folders[i]
+		}
+	}
+	return nil
+}
+
+// folderPath returns the folders from the top level down to folderID, for the
+// breadcrumb. It is empty for the top level or a folder that no longer exists.
+func folderPath(folders []CollectionFolder, folderID string) []CollectionFolder {
+	var path []CollectionFolder
+	for id := folderID; id != "" && len(path) < len(folders); {
+		f := findFolder(folders, id)
+		if f == nil {
+			return nil
+		}
+		path = append(path, *f)
+		id = folderParentID(*f)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// folderOfCollection returns the ID of the folder collectionID is filed in,
+// or "" when it is at the top level
+func folderOfCollection(folders []CollectionFolder, collectionID string) string {
+	for _, f := range folders {
+		if slices.Contains(f.CollectionIDs, collectionID) {
+			return f.ID
+		}
+	}
+	return ""
+}
+
+// inFolder reports whether a collection belongs in the list of folderID. The
+// top level lists every collection not filed in a folder.
+func inFolder(folders []CollectionFolder, collectionID, folderID string) bool {
+	return folderOfCollection(folders, collectionID) == folderID
+}
+
+// refileCollection returns a copy of folders with collectionID moved into
+// folderID, or out of every folder when folderID is "". The input is left
+// alone so it can be restored if the server refuses the move.
+func refileCollection(folders []CollectionFolder, collectionID, folderID string) []CollectionFolder {
+	out := make([]CollectionFolder, len(folders))
+	for i, f := range folders {
+		ids := slices.DeleteFunc(slices.Clone(f.CollectionIDs), func(id string) bool { return id == collectionID })
+		if f.ID == folderID {
+			ids = append(ids, collectionID)
+		}
+		f.CollectionIDs = ids
+		out[i] = f
+	}
+	return out
+}
+
+// folderStatsSummary is the one-line total under the breadcrumb,
+// e.g. "2 folders · 5 collections · 140 objects · 3 low stock"
+func folderStatsSummary(s *types.CollectionFolderStats) string {
+	parts := []string{
+		plural(s.Collections, "collection"),
+		plural(s.Objects, "object"),
+	}
+	if s.Folders > 0 {
+		parts = append([]string{plural(s.Folders, "folder")}, parts...)
+	}
+	if s.LowStock > 0 {
+		parts = append(parts, fmt.Sprintf("%d low stock", s.LowStock))
+	}
+	if s.ExpiringSoon > 0 {
+		parts = append(parts, fmt.Sprintf("%d expiring soon", s.ExpiringSoon))
+	}
+	if s.Expired > 0 {
+		parts = append(parts, fmt.Sprintf("%d expired", s.Expired))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// plural formats a count with its noun, adding an "s" unless n is 1
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// fetchCollectionFolders gets the user's folders from the backend
+func (ga *GioApp) fetchCollectionFolders() {
+	if ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	session := ga.session
+	ga.goSafe(func() {
+		folders, err := ga.foldersClient.List(accountID)
+		if err != nil {
+			ga.logger.Error("Failed to fetch collection folders", "error", err)
+			return
+		}
+		ga.doInSession(session, func() {
+			ga.collectionFolders = folders
+			if findFolder(folders, ga.currentFolderID) == nil {
+				ga.openFolder("")
+			}
+			ga.resetFolderStats()
+		})
+	})
+}
+
+// resetCollectionFolders forgets the loaded folders, e.g. on sign out
+func (ga *GioApp) resetCollectionFolders() {
+	ga.collectionFolders = nil
+	ga.currentFolderID = ""
+	ga.collectionDragActive = false
+	ga.resetFolderStats()
+}
+
+// openFolder shows the collections filed in folderID, or the top level when
+// folderID is ""
+func (ga *GioApp) openFolder(folderID string) {
+	if ga.currentFolderID == folderID {
+		return
+	}
+	ga.currentFolderID = folderID
+	ga.resetFolderStats()
+}
+
+// setCollectionFolder replaces a folder with the copy the server returned
+func (ga *GioApp) setCollectionFolder(updated CollectionFolder) {
+	if f := findFolder(ga.collectionFolders, updated.ID); f != nil {
+		*f = updated
+	}
+}
+
+// moveCollectionToFolder files a collection in folderID, or takes it out of
+// its folder when folderID is "". The list updates straight away.
+func (ga *GioApp) moveCollectionToFolder(collectionID, folderID string) {
+	from := folderOfCollection(ga.collectionFolders, collectionID)
+	if from == folderID || ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	before := ga.collectionFolders
+
+	ga.optimisticUpdate("move collection",
+		func() {
+			ga.collectionFolders = refileCollection(before, collectionID, folderID)
+			ga.resetFolderStats()
+		},
+		func() {
+			ga.collectionFolders = before
+			ga.resetFolderStats()
+		},
+		func() (func(), error) {
+			var folder *CollectionFolder
+			var err error
+			if folderID == "" {
+				folder, err = ga.foldersClient.Unfile(accountID, from, collectionID)
+			} else {
+				folder, err = ga.foldersClient.File(accountID, folderID, collectionID)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return func() {
+				ga.setCollectionFolder(*folder)
+				ga.resetFolderStats()
+			}, nil
+		})
+}
+
+// createFolder creates a folder inside the one on screen
+func (ga *GioApp) createFolder(name string) {
+	if ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	req := types.CreateCollectionFolderRequest{Name: name}
+	if ga.currentFolderID != "" {
+		req.ParentID = new(ga.currentFolderID)
+	}
+
+	ga.goSafe(func() {
+		folder, err := ga.foldersClient.Create(accountID, req)
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to create folder", "error", err)
+				ga.showAPIErrorDialog("Failed to create folder: " + err.Error())
+				return
+			}
+			ga.collectionFolders = append(ga.collectionFolders, *folder)
+			ga.resetFolderStats()
+		})
+	})
+}
+
+// renameFolder renames a folder, showing the new name straight away
+func (ga *GioApp) renameFolder(folderID, name string) {
+	f := findFolder(ga.collectionFolders, folderID)
+	if f == nil || f.Name == name || ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	oldName := f.Name
+	setName := func(name string) {
+		if f := findFolder(ga.collectionFolders, folderID); f != nil {
+			f.Name = name
+		}
+	}
+
+	ga.optimisticUpdate("rename folder",
+		func() { setName(name) },
+		func() { setName(oldName) },
+		func() (func(), error) {
+			folder, err := ga.foldersClient.Update(accountID, folderID, types.UpdateCollectionFolderRequest{Name: &name})
+			if err != nil {
+				return nil, err
+			}
+			return func() { ga.setCollectionFolder(*folder) }, nil
+		})
+}
+
+// deleteFolder deletes a folder and goes up to its parent. Its subfolders
+// and collections move up with it, so the folders are fetched again.
+func (ga *GioApp) deleteFolder(folderID string) {
+	f := findFolder(ga.collectionFolders, folderID)
+	if f == nil || ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	ga.openFolder(folderParentID(*f))
+
+	ga.goSafe(func() {
+		err := ga.foldersClient.Delete(accountID, folderID)
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to delete folder", "folder_id", folderID, "error", err)
+				ga.showAPIErrorDialog("Failed to delete folder: " + err.Error())
+				return
+			}
+			ga.fetchCollectionFolders()
+		})
+	})
+}
+
+// resetFolderStats forgets the loaded folder totals so they are fetched again
+// the next time the folder is on screen. A response still in flight is
+// dropped when it arrives.
+func (ga *GioApp) resetFolderStats() {
+	ga.folderStats = nil
+	ga.folderStatsID = ""
+	ga.folderStatsGeneration++
+}
+
+// fetchFolderStats loads the totals of the folder on screen
+func (ga *GioApp) fetchFolderStats() {
+	if ga.currentFolderID == "" || ga.currentUser == nil || ga.folderStatsLoading {
+		return
+	}
+	folderID := ga.currentFolderID
+	accountID := ga.currentUser.ID
+	generation := ga.folderStatsGeneration
+	ga.folderStatsLoading = true
+	ga.folderStatsID = folderID
+
+	ga.goSafe(func() {
+		stats, err := ga.foldersClient.Stats(accountID, folderID)
+
+		ga.do(func() {
+			ga.folderStatsLoading = false
+			if generation != ga.folderStatsGeneration || ga.currentFolderID != folderID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load folder stats", "folder_id", folderID, "error", err)
+				return
+			}
+			ga.folderStats = stats
+		})
+	})
+}
+
+// folderItemState returns the widget state of a folder, creating it on first use
+func (ga *GioApp) folderItemState(folderID string) *FolderItemState {
+	if ga.widgetState.folderItems == nil {
+		ga.widgetState.folderItems = make(map[string]*FolderItemState)
+	}
+	state, ok := ga.widgetState.folderItems[folderID]
+	if !ok {
+		state = &FolderItemState{}
+		ga.widgetState.folderItems[folderID] = state
+	}
+	return state
+}
+
+// openFolderDialog shows the folder name dialog, renaming folderID or
+// creating a new folder when folderID is ""
+func (ga *GioApp) openFolderDialog(folderID string) {
+	name := ""
+	if f := findFolder(ga.collectionFolders, folderID); f != nil {
+		name = f.Name
+	}
+	ga.editingFolderID = folderID
+	ga.widgetState.folderNameEditor.SetText(name)
+	ga.showFolderDialog = true
+}
+
+// closeFolderDialog hides the folder name dialog
+func (ga *GioApp) closeFolderDialog() {
+	ga.showFolderDialog = false
+	ga.editingFolderID = ""
+	ga.widgetState.folderDialog.Reset()
+}
+
+// renderFolderBar renders the breadcrumb of the folder on screen, its
+// subfolders and its totals. The crumbs and subfolders are drop targets for
+// collections dragged by their handle. Nothing is shown while searching,
+// since a search covers every folder.
+func (ga *GioApp) renderFolderBar(gtx layout.Context) layout.Dimensions {
+	ws := ga.widgetState
+	if ws.collectionsSearchField.Text() != "" {
+		return layout.Dimensions{}
+	}
+	if ws.folderCreateButton.Clicked(gtx) {
+		ga.openFolderDialog("")
+	}
+	if ws.folderRenameButton.Clicked(gtx) {
+		ga.openFolderDialog(ga.currentFolderID)
+	}
+	if ws.folderDeleteButton.Clicked(gtx) {
+		ga.deleteFolder(ga.currentFolderID)
+	}
+	if ws.folderRootCrumb.Clicked(gtx) {
+		ga.openFolder("")
+	}
+	if ga.currentFolderID != "" && ga.folderStatsID != ga.currentFolderID {
+		ga.resetFolderStats()
+		ga.fetchFolderStats()
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(ga.renderFolderBreadcrumb),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.currentFolderID == "" || ga.folderStats == nil {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					lbl := material.Caption(ga.theme.Theme, folderStatsSummary(ga.folderStats))
+					lbl.Color = theme.ColorTextSecondary
+					return lbl.Layout(gtx)
+				})
+			}),
+			layout.Rigid(ga.renderSubfolders),
+		)
+	})
+}
+
+// renderFolderBreadcrumb renders the path to the folder on screen followed by
+// the folder actions
+func (ga *GioApp) renderFolderBreadcrumb(gtx layout.Context) layout.Dimensions {
+	ws := ga.widgetState
+	path := folderPath(ga.collectionFolders, ga.currentFolderID)
+
+	crumbs := []layout.Widget{func(gtx layout.Context) layout.Dimensions {
+		return ga.collectionDropTarget(gtx, &ws.folderRootCrumb, "", func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFolderChip(gtx, &ws.folderRootCrumb, "All collections", ga.currentFolderID == "")
+		})
+	}}
+	for _, f := range path {
+		state := ga.folderItemState(f.ID)
+		if state.crumbButton.Clicked(gtx) {
+			ga.openFolder(f.ID)
+		}
+		crumbs = append(crumbs, func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body2(ga.theme.Theme, "›")
+			lbl.Color = theme.ColorTextSecondary
+			return lbl.Layout(gtx)
+		}, func(gtx layout.Context) layout.Dimensions {
+			return ga.collectionDropTarget(gtx, &state.crumbButton, f.ID, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFolderChip(gtx, &state.crumbButton, f.Name, f.ID == ga.currentFolderID)
+			})
+		})
+	}
+
+	crumbs = append(crumbs, widgets.CancelButton(ga.theme.Theme, &ws.folderCreateButton, "+ Folder"))
+	if ga.currentFolderID != "" {
+		crumbs = append(crumbs,
+			widgets.CancelButton(ga.theme.Theme, &ws.folderRenameButton, "Rename"),
+			widgets.DangerButton(ga.theme.Theme, &ws.folderDeleteButton, "Delete folder"),
+		)
+	}
+	return layoutFlowWrap(gtx, gtx.Dp(unit.Dp(theme.Spacing2)), gtx.Dp(unit.Dp(theme.Spacing1)), crumbs...)
+}
+
+// renderSubfolders renders a tile per folder inside the one on screen
+func (ga *GioApp) renderSubfolders(gtx layout.Context) layout.Dimensions {
+	children := childFolders(ga.collectionFolders, ga.currentFolderID)
+	if len(children) == 0 {
+		return layout.Dimensions{}
+	}
+
+	tiles := make([]layout.Widget, len(children))
+	for i, f := range children {
+		state := ga.folderItemState(f.ID)
+		if state.openButton.Clicked(gtx) {
+			ga.openFolder(f.ID)
+		}
+		label := fmt.Sprintf("%s (%d)", f.Name, len(f.CollectionIDs))
+		tiles[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.collectionDropTarget(gtx, &state.openButton, f.ID, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFolderTile(gtx, &state.openButton, label)
+			})
+		}
+	}
+
+	return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layoutFlowWrap(gtx, gtx.Dp(unit.Dp(theme.Spacing2)), gtx.Dp(unit.Dp(theme.Spacing2)), tiles...)
+	})
+}
+
+// renderFolderChip renders a breadcrumb crumb, highlighted while a collection
+// is being dragged so it reads as somewhere to drop it
+func (ga *GioApp) renderFolderChip(gtx layout.Context, btn *widget.Clickable, label string, current bool) layout.Dimensions {
+	if ga.collectionDragActive && !current {
+		b := widgets.Button{
+			Text:            label,
+			BackgroundColor: theme.ColorPrimaryLightest,
+			TextColor:       theme.ColorPrimaryDark,
+			CornerRadius:    unit.Dp(theme.RadiusFull),
+			Inset: layout.Inset{
+				Top:    unit.Dp(theme.Spacing1),
+				Bottom: unit.Dp(theme.Spacing1),
+				Left:   unit.Dp(theme.Spacing2),
+				Right:  unit.Dp(theme.Spacing2),
+			},
+		}
+		return b.Layout(gtx, ga.theme.Theme, btn)
+	}
+	return ga.renderFilterChip(gtx, btn, label, current)
+}
+
+// renderFolderTile renders a subfolder the user can open or drop a
+// collection on
+func (ga *GioApp) renderFolderTile(gtx layout.Context, btn *widget.Clickable, label string) layout.Dimensions {
+	bg := theme.ColorSurfaceAlt
+	if ga.collectionDragActive {
+		bg = theme.ColorPrimaryLightest
+	}
+	b := widgets.Button{
+		Text:            "▸ " + label,
+		BackgroundColor: bg,
+		TextColor:       theme.ColorTextPrimary,
+		CornerRadius:    unit.Dp(theme.RadiusLG),
+		Inset: layout.Inset{
+			Top:    unit.Dp(theme.Spacing3),
+			Bottom: unit.Dp(theme.Spacing3),
+			Left:   unit.Dp(theme.Spacing4),
+			Right:  unit.Dp(theme.Spacing4),
+		},
+	}
+	return b.Layout(gtx, ga.theme.Theme, btn)
+}
+
+// collectionDropTarget lays out w as somewhere a dragged collection can be
+// dropped, filing it in folderID ("" takes it out of its folder). The target
+// area sits under w so the buttons inside stay clickable.
+func (ga *GioApp) collectionDropTarget(gtx layout.Context, tag event.Tag, folderID string, w layout.Widget) layout.Dimensions {
+	for {
+		ev, ok := gtx.Event(transfer.TargetFilter{Target: tag, Type: collectionDragType})
+		if !ok {
+			break
+		}
+		switch e := ev.(type) {
+		case transfer.InitiateEvent:
+			ga.collectionDragActive = true
+		case transfer.CancelEvent:
+			ga.collectionDragActive = false
+		case transfer.DataEvent:
+			ga.collectionDragActive = false
+			data := e.Open()
+			id, err := io.ReadAll(data)
+			data.Close()
+			if err == nil && len(id) > 0 {
+				ga.moveCollectionToFolder(string(id), folderID)
+			}
+		}
+	}
+
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx)
+	call := macro.Stop()
+
+	area := clip.Rect{Max: dims.Size}.Push(gtx.Ops)
+	event.Op(gtx.Ops, tag)
+	call.Add(gtx.Ops)
+	area.Pop()
+	return dims
+}
+
+// renderCollectionDragHandle renders the grip a collection card is dragged
+// onto a folder by, offering the collection ID when it is dropped
+func (ga *GioApp) renderCollectionDragHandle(gtx layout.Context, drag *widget.Draggable, collection Collection) layout.Dimensions {
+	drag.Type = collectionDragType
+	if mime, requested := drag.Update(gtx); requested {
+		drag.Offer(gtx, mime, io.NopCloser(strings.NewReader(collection.ID)))
+	}
+
+	handle := func(gtx layout.Context) layout.Dimensions {
+		return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			lbl := material.H6(ga.theme.Theme, "≡")
+			lbl.Color = theme.ColorTextSecondary
+			return lbl.Layout(gtx)
+		})
+	}
+	preview := func(gtx layout.Context) layout.Dimensions {
+		return widgets.DefaultCard().Layout(gtx, material.Body1(ga.theme.Theme, collection.Name).Layout)
+	}
+	return drag.Layout(gtx, handle, preview)
+}
+
+// renderFolderDialog renders the dialog naming a new folder or renaming one
+func (ga *GioApp) renderFolderDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showFolderDialog {
+		return layout.Dimensions{}
+	}
+
+	ws := ga.widgetState
+	if ws.folderDialogSubmit.Clicked(gtx) {
+		name := strings.TrimSpace(ws.folderNameEditor.Text())
+		if name == "" {
+			ga.logger.Warn("Folder name is required")
+			return layout.Dimensions{}
+		}
+		if ga.editingFolderID == "" {
+			ga.createFolder(name)
+		} else {
+			ga.renameFolder(ga.editingFolderID, name)
+		}
+		ga.closeFolderDialog()
+		return layout.Dimensions{}
+	}
+	if ws.folderDialogCancel.Clicked(gtx) {
+		ga.closeFolderDialog()
+		return layout.Dimensions{}
+	}
+
+	title, submit := "New Folder", "Create"
+	if ga.editingFolderID != "" {
+		title, submit = "Rename Folder", "Rename"
+	}
+	dialogStyle := widgets.DefaultDialogStyle(ws.folderDialog, title)
+	dialogStyle.Width = unit.Dp(420)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "Name *", &ws.folderNameEditor, "e.g., Kitchen")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ws.folderDialogCancel, "Cancel"))
+					}),
+					layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ws.folderDialogSubmit, submit)),
+				)
+			}),
+		)
+	})
+	if dismissed {
+		ga.closeFolderDialog()
+	}
+	return dims
+}
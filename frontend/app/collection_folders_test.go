@@ -0,0 +1,102 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func testFolders() []CollectionFolder {
+	return []CollectionFolder{
+		{ID: "home", Name: "Home", CollectionIDs: []string{"c1"}},
+		{ID: "kitchen", Name: "Kitchen", ParentID: new("home"), CollectionIDs: []string{"c2", "c3"}},
+		{ID: "garage", Name: "Garage"},
+	}
+}
+
+func folderIDs(folders []CollectionFolder) []string {
+	ids := make([]string, len(folders))
+	for i, f := range folders {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
+func TestChildFolders(t *testing.T) {
+	folders := testFolders()
+	if got := folderIDs(childFolders(folders, "")); !slices.Equal(got, []string{"home", "garage"}) {
+		t.Errorf("top-level folders = %v", got)
+	}
+	if got := folderIDs(childFolders(folders, "home")); !slices.Equal(got, []string{"kitchen"}) {
+		t.Errorf("folders in home = %v", got)
+	}
+}
+
+func TestFolderPath(t *testing.T) {
+	folders := testFolders()
+	if got := folderIDs(folderPath(folders, "kitchen")); !slices.Equal(got, []string{"home", "kitchen"}) {
+		t.Errorf("path to kitchen = %v", got)
+	}
+	if got := folderPath(folders, ""); len(got) != 0 {
+		t.Errorf("path to top level = %v", got)
+	}
+	if got := folderPath(folders, "gone"); len(got) != 0 {
+		t.Errorf("path to missing folder = %v", got)
+	}
+
+	// A parent cycle must not loop forever
+	cyclic := []CollectionFolder{{ID: "a", ParentID: new("b")}, {ID: "b", ParentID: new("a")}}
+	if got := folderPath(cyclic, "a"); len(got) > len(cyclic) {
+		t.Errorf("path through a cycle = %v", folderIDs(got))
+	}
+}
+
+func TestInFolder(t *testing.T) {
+	folders := testFolders()
+	tests := []struct {
+		collectionID, folderID string
+		want                   bool
+	}{
+		{"c1", "home", true},
+		{"c1", "", false},
+		{"c2", "kitchen", true},
+		{"c2", "home", false},
+		{"c9", "", true},
+	}
+	for _, tt := range tests {
+		if got := inFolder(folders, tt.collectionID, tt.folderID); got != tt.want {
+			t.Errorf("inFolder(%s, %q) = %v, want %v", tt.collectionID, tt.folderID, got, tt.want)
+		}
+	}
+}
+
+func TestRefileCollection(t *testing.T) {
+	folders := testFolders()
+
+	moved := refileCollection(folders, "c2", "garage")
+	if got := folderOfCollection(moved, "c2"); got != "garage" {
+		t.Errorf("c2 filed in %q, want garage", got)
+	}
+	if got := moved[1].CollectionIDs; !slices.Equal(got, []string{"c3"}) {
+		t.Errorf("kitchen = %v after move, want [c3]", got)
+	}
+	if got := folders[1].CollectionIDs; !slices.Equal(got, []string{"c2", "c3"}) {
+		t.Errorf("input changed to %v", got)
+	}
+
+	unfiled := refileCollection(folders, "c1", "")
+	if got := folderOfCollection(unfiled, "c1"); got != "" {
+		t.Errorf("c1 still filed in %q", got)
+	}
+}
+
+func TestFolderStatsSummary(t *testing.T) {
+	s := &types.CollectionFolderStats{Folders: 2, Collections: 1, Objects: 140, LowStock: 3, Expired: 1}
+	if got, want := folderStatsSummary(s), "2 folders · 1 collection · 140 objects · 3 low stock · 1 expired"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if got, want := folderStatsSummary(&types.CollectionFolderStats{}), "0 collections · 0 objects"; got != want {
+		t.Errorf("empty summary = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// resetNutritionStats forgets the loaded nutrition totals so they are fetched
+// again the next time the stats panel shows a food collection. A response
+// still in flight is dropped when it arrives.
+func (ga *GioApp) resetNutritionStats() {
+	ga.nutritionStats = nil
+	ga.nutritionCollectionID = ""
+	ga.nutritionErr = ""
+	ga.nutritionGeneration++
+}
+
+// fetchNutritionStats loads the selected collection's nutrition totals
+func (ga *GioApp) fetchNutritionStats() {
+	if ga.selectedCollection == nil || ga.currentUser == nil || ga.nutritionLoading {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	generation := ga.nutritionGeneration
+	ga.nutritionLoading = true
+	ga.nutritionCollectionID = collectionID
+
+	ga.goSafe(func() {
+		stats, err := ga.nutritionClient.Stats(userID, collectionID)
+
+		ga.do(func() {
+			ga.nutritionLoading = false
+			if generation != ga.nutritionGeneration || ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load nutrition stats", "collection_id", collectionID, "error", err)
+				ga.nutritionErr = "Could not load nutrition totals: " + err.Error()
+				return
+			}
+			ga.nutritionStats = stats
+		})
+	})
+}
+
+// nutritionSummary is the one-line total shown above the calories chart,
+// e.g. "12,400 kcal · protein 310 g · carbs 1,540 g · fat 420 g"
+func nutritionSummary(s *types.NutritionStats) string {
+	return fmt.Sprintf("%s kcal · protein %s g · carbs %s g · fat %s g",
+		formatThousands(s.Calories), formatThousands(s.ProteinG), formatThousands(s.CarbsG), formatThousands(s.FatG))
+}
+
+// nutritionCoverage notes the food the totals leave out, or "" when none is
+func nutritionCoverage(s *types.NutritionStats) string {
+	var parts []string
+	if s.WithoutNutrition > 0 {
+		parts = append(parts, fmt.Sprintf("%d without nutrition facts", s.WithoutNutrition))
+	}
+	if s.ExpiredSkipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d expired", s.ExpiredSkipped))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Not counted: " + strings.Join(parts, ", ")
+}
+
+// formatThousands rounds v to a whole number with comma separators
+func formatThousands(v float64) string {
+	n := int64(v + 0.5)
+	s := fmt.Sprintf("%d", n)
+	if n < 1000 {
+		return s
+	}
+	var b strings.Builder
+	lead := len(s) % 3
+	if lead > 0 {
+		b.WriteString(s[:lead])
+	}
+	for i := lead; i < len(s); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
+
+// renderNutritionStats renders the pantry nutrition totals and a bar chart
+// of calories per container. Only food collections have them; the totals
+// are fetched the first time the panel shows the collection.
+func (ga *GioApp) renderNutritionStats(gtx layout.Context) layout.Dimensions {
+	if ga.selectedCollection == nil || ga.selectedCollection.ObjectType != "food" {
+		return layout.Dimensions{}
+	}
+	if ga.nutritionCollectionID != ga.selectedCollection.ID {
+		ga.resetNutritionStats()
+		ga.fetchNutritionStats()
+	}
+
+	s := ga.nutritionStats
+	if s == nil {
+		if ga.nutritionErr == "" {
+			return layout.Dimensions{}
+		}
+		return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Caption(ga.theme.Theme, ga.nutritionErr)
+			lbl.Color = theme.ColorDanger
+			return lbl.Layout(gtx)
+		})
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body2(ga.theme.Theme, "Calories Available:")
+			lbl.Font.Weight = font.Bold
+			lbl.Color = theme.ColorTextSecondary
+			return lbl.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return material.Body2(ga.theme.Theme, nutritionSummary(s)).Layout(gtx)
+		}),
+	}
+	if note := nutritionCoverage(s); note != "" {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Caption(ga.theme.Theme, note)
+			lbl.Color = theme.ColorTextSecondary
+			return lbl.Layout(gtx)
+		}))
+	}
+
+	maxCalories := 0.0
+	for _, c := range s.ByContainer {
+		maxCalories = max(maxCalories, c.Calories)
+	}
+	maxWidth := gtx.Constraints.Max.X
+	for _, c := range s.ByContainer {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						lbl := material.Caption(ga.theme.Theme, fmt.Sprintf("%s (%s kcal)", c.ContainerName, formatThousands(c.Calories)))
+						lbl.Color = theme.ColorTextSecondary
+						lbl.MaxLines = 1
+						cgtx := gtx
+						cgtx.Constraints.Min.X = gtx.Dp(unit.Dp(160))
+						cgtx.Constraints.Max.X = gtx.Dp(unit.Dp(160))
+						return lbl.Layout(cgtx)
+					}),
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						barMaxW := maxWidth - gtx.Dp(unit.Dp(170))
+						barW := max(int(float64(barMaxW)*c.Calories/maxCalories), 4)
+						sz := image.Point{X: barW, Y: gtx.Dp(unit.Dp(12))}
+						defer clip.RRect{Rect: image.Rectangle{Max: sz}, SE: 3, SW: 3, NW: 3, NE: 3}.Push(gtx.Ops).Pop()
+						paint.ColorOp{Color: theme.ColorAccent}.Add(gtx.Ops)
+						paint.PaintOp{}.Add(gtx.Ops)
+						return layout.Dimensions{Size: sz}
+					}),
+				)
+			})
+		}))
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestFormatThousands(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0"},
+		{999.4, "999"},
+		{999.6, "1,000"},
+		{12400, "12,400"},
+		{1234567, "1,234,567"},
+	}
+	for _, tt := range tests {
+		if got := formatThousands(tt.in); got != tt.want {
+			t.Errorf("formatThousands(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNutritionSummaryAndCoverage(t *testing.T) {
+	s := &types.NutritionStats{Calories: 12400, ProteinG: 310, CarbsG: 1540.2, FatG: 420, WithoutNutrition: 3, ExpiredSkipped: 1}
+
+	if got, want := nutritionSummary(s), "12,400 kcal · protein 310 g · carbs 1,540 g · fat 420 g"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if got, want := nutritionCoverage(s), "Not counted: 3 without nutrition facts, 1 expired"; got != want {
+		t.Errorf("coverage = %q, want %q", got, want)
+	}
+	if got := nutritionCoverage(&types.NutritionStats{}); got != "" {
+		t.Errorf("coverage with nothing left out = %q", got)
+	}
+}
+
+func TestResetNutritionStatsDropsLoadedTotals(t *testing.T) {
+	ga := newTestGioApp()
+	ga.nutritionStats = &types.NutritionStats{Calories: 100}
+	ga.nutritionCollectionID = "col-1"
+	generation := ga.nutritionGeneration
+
+	ga.resetNutritionStats()
+
+	if ga.nutritionStats != nil || ga.nutritionCollectionID != "" {
+		t.Errorf("totals kept after reset: %+v %q", ga.nutritionStats, ga.nutritionCollectionID)
+	}
+	if ga.nutritionGeneration == generation {
+		t.Error("generation not bumped, a late response would still be applied")
+	}
+}
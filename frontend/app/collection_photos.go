@@ -0,0 +1,205 @@
+package app
+
+import (
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// photosPageSize is how many photos the Photos tab asks for at a time
+const photosPageSize = 24
+
+// resetCollectionPhotos forgets the loaded photos so the Photos tab starts
+// over from the newest next time it is shown. A page still in flight is
+// dropped when it arrives.
+func (ga *GioApp) resetCollectionPhotos() {
+	ga.photos = nil
+	ga.photosTotal = 0
+	ga.photosOffset = 0
+	ga.photosCollectionID = ""
+	ga.photosLoaded = false
+	ga.photosErr = ""
+	ga.photosGeneration++
+}
+
+// fetchPhotosPage loads the next page of the selected collection's photos
+func (ga *GioApp) fetchPhotosPage() {
+	if ga.selectedCollection == nil || ga.currentUser == nil || ga.photosLoading {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	offset := ga.photosOffset
+	generation := ga.photosGeneration
+	ga.photosLoading = true
+
+	ga.goSafe(func() {
+		page, err := ga.mediaClient.ListCollection(userID, collectionID, photosPageSize, offset)
+
+		ga.do(func() {
+			ga.photosLoading = false
+			if generation != ga.photosGeneration || ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load photos", "collection_id", collectionID, "error", err)
+				ga.photosErr = "Could not load photos: " + err.Error()
+				return
+			}
+			ga.photos = appendMediaPage(ga.photos, page.Media)
+			ga.photosOffset = offset + len(page.Media)
+			ga.photosTotal = page.Total
+			ga.photosLoaded = true
+		})
+	})
+}
+
+// hasMorePhotos reports whether the server has photos past the loaded pages
+func (ga *GioApp) hasMorePhotos() bool {
+	return ga.photosLoaded && int64(ga.photosOffset) < ga.photosTotal
+}
+
+// appendMediaPage adds a page to the loaded photos. Photos uploaded while
+// paging shift later pages, so ones already shown are skipped.
+func appendMediaPage(loaded, page []types.Media) []types.Media {
+	seen := make(map[string]bool, len(loaded))
+	for _, m := range loaded {
+		seen[m.ID] = true
+	}
+	for _, m := range page {
+		if !seen[m.ID] {
+			seen[m.ID] = true
+			loaded = append(loaded, m)
+		}
+	}
+	return loaded
+}
+
+// photosNeedNextPage reports whether the gallery has scrolled close enough
+// to the last loaded row to fetch more: within one screen of rows.
+func photosNeedNextPage(pos layout.Position, rows int) bool {
+	return pos.First+2*pos.Count >= rows
+}
+
+// photoCaption names what the photo is attached to, falling back to the
+// uploaded file name for objects and containers not loaded in this view
+func (ga *GioApp) photoCaption(m types.Media) string {
+	switch m.OwnerKind {
+	case "object":
+		for _, obj := range ga.objects {
+			if obj.ID == m.OwnerID {
+				return obj.Name
+			}
+		}
+	case "container":
+		for _, c := range ga.containers {
+			if c.ID == m.OwnerID {
+				return c.Name
+			}
+		}
+	}
+	return m.Filename
+}
+
+// renderCollectionPhotos renders the Photos tab: every photo attached to the
+// collection's objects and containers as a thumbnail grid. Thumbnails are
+// fetched only for rows on screen, and the next page once the user scrolls
+// near the end of the loaded ones.
+func (ga *GioApp) renderCollectionPhotos(gtx layout.Context) layout.Dimensions {
+	if ga.selectedCollection != nil && ga.photosCollectionID != ga.selectedCollection.ID {
+		ga.resetCollectionPhotos()
+		ga.photosCollectionID = ga.selectedCollection.ID
+	}
+	if !ga.photosLoaded && ga.photosErr == "" {
+		ga.fetchPhotosPage()
+	}
+
+	if len(ga.photos) == 0 {
+		if ga.photosErr == "" && !ga.photosLoaded {
+			return widgets.SkeletonList(skeletonRows)(gtx)
+		}
+		msg := "No photos yet. Attach photos to objects or containers to see them here."
+		if ga.photosErr != "" {
+			msg = ga.photosErr
+		}
+		return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			label := material.Body1(ga.theme.Theme, msg)
+			label.Color = theme.ColorTextSecondary
+			label.Alignment = text.Middle
+			return label.Layout(gtx)
+		})
+	}
+
+	cols := galleryColumns(gtx)
+	gap := gtx.Dp(unit.Dp(theme.Spacing1))
+	thumbSize := (gtx.Constraints.Max.X - gap*(cols-1)) / cols
+	rowCount := (len(ga.photos) + cols - 1) / cols
+
+	list := &ga.widgetState.photosList
+	list.Axis = layout.Vertical
+	dims := list.Layout(gtx, rowCount, func(gtx layout.Context, rowIdx int) layout.Dimensions {
+		start := rowIdx * cols
+		end := min(start+cols, len(ga.photos))
+		cellH := thumbSize + gtx.Dp(unit.Dp(24))
+		for ci, m := range ga.photos[start:end] {
+			stack := op.Offset(image.Point{X: ci * (thumbSize + gap)}).Push(gtx.Ops)
+			ga.renderPhotoThumbnail(gtx, m, thumbSize)
+			stack.Pop()
+		}
+		return layout.Dimensions{Size: image.Point{X: gtx.Constraints.Max.X, Y: cellH + gap}}
+	})
+
+	if ga.hasMorePhotos() && ga.photosErr == "" && photosNeedNextPage(list.Position, rowCount) {
+		ga.fetchPhotosPage()
+	}
+	return dims
+}
+
+// renderPhotoThumbnail renders a single photo cell with its caption
+func (ga *GioApp) renderPhotoThumbnail(gtx layout.Context, m types.Media, size int) layout.Dimensions {
+	cellH := size + gtx.Dp(unit.Dp(24))
+
+	bgRect := image.Rectangle{Max: image.Point{X: size, Y: cellH}}
+	radius := gtx.Dp(unit.Dp(theme.RadiusDefault))
+	defer clip.RRect{Rect: bgRect, SE: radius, SW: radius, NW: radius, NE: radius}.Push(gtx.Ops).Pop()
+	paint.ColorOp{Color: theme.ColorSurfaceAlt}.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+
+	caption := ga.photoCaption(m)
+	img, imgErr := ga.getImageStatus(m.ThumbnailURL)
+	switch {
+	case img != nil:
+		wImg := widget.Image{Src: paint.NewImageOp(img), Fit: widget.Contain, Position: layout.Center}
+		cgtx := gtx
+		cgtx.Constraints = layout.Exact(image.Point{X: size, Y: size})
+		wImg.Layout(cgtx)
+	case imgErr != nil:
+		ga.renderBrokenImagePlaceholder(gtx, size)
+	default:
+		ga.renderThumbnailPlaceholder(gtx, caption, size)
+	}
+
+	captionOffset := op.Offset(image.Point{Y: size}).Push(gtx.Ops)
+	captionGtx := gtx
+	captionGtx.Constraints = layout.Exact(image.Point{X: size, Y: gtx.Dp(unit.Dp(24))})
+	layout.Center.Layout(captionGtx, func(gtx layout.Context) layout.Dimensions {
+		lbl := material.Caption(ga.theme.Theme, caption)
+		lbl.MaxLines = 1
+		lbl.Alignment = text.Middle
+		return lbl.Layout(gtx)
+	})
+	captionOffset.Pop()
+
+	return layout.Dimensions{Size: image.Point{X: size, Y: cellH}}
+}
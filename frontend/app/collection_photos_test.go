@@ -0,0 +1,73 @@
+package app
+
+import (
+	"testing"
+
+	"gioui.org/layout"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestAppendMediaPageSkipsPhotosAlreadyShown(t *testing.T) {
+	loaded := []types.Media{{ID: "a"}, {ID: "b"}}
+	// An upload between pages pushes "b" onto the next page as well
+	page := []types.Media{{ID: "b"}, {ID: "c"}}
+
+	got := appendMediaPage(loaded, page)
+	if len(got) != 3 || got[0].ID != "a" || got[1].ID != "b" || got[2].ID != "c" {
+		t.Errorf("unexpected photos %+v", got)
+	}
+}
+
+func TestPhotosNeedNextPage(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  layout.Position
+		rows int
+		want bool
+	}{
+		{"top of a long gallery", layout.Position{First: 0, Count: 3}, 12, false},
+		{"within a screen of the end", layout.Position{First: 6, Count: 3}, 12, true},
+		{"everything on screen", layout.Position{First: 0, Count: 2}, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := photosNeedNextPage(tt.pos, tt.rows); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhotoCaptionNamesTheOwner(t *testing.T) {
+	ga := newTestGioApp()
+	ga.objects = []Object{{ID: "obj-1", Name: "Catan"}}
+	ga.containers = []Container{{ID: "cont-1", Name: "Game shelf"}}
+
+	if got := ga.photoCaption(types.Media{OwnerKind: "object", OwnerID: "obj-1", Filename: "box.jpg"}); got != "Catan" {
+		t.Errorf("object caption = %q", got)
+	}
+	if got := ga.photoCaption(types.Media{OwnerKind: "container", OwnerID: "cont-1", Filename: "shelf.jpg"}); got != "Game shelf" {
+		t.Errorf("container caption = %q", got)
+	}
+	if got := ga.photoCaption(types.Media{OwnerKind: "object", OwnerID: "gone", Filename: "spine.png"}); got != "spine.png" {
+		t.Errorf("fallback caption = %q", got)
+	}
+}
+
+func TestHasMorePhotos(t *testing.T) {
+	ga := newTestGioApp()
+	if ga.hasMorePhotos() {
+		t.Error("nothing loaded yet should not ask for more")
+	}
+	ga.photosLoaded = true
+	ga.photosOffset = 24
+	ga.photosTotal = 30
+	if !ga.hasMorePhotos() {
+		t.Error("expected more photos past offset 24 of 30")
+	}
+	ga.photosOffset = 30
+	if ga.hasMorePhotos() {
+		t.Error("expected no more photos once all 30 are loaded")
+	}
+}
@@ -0,0 +1,512 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// openSnapshotDialog opens the snapshot list for the selected collection
+func (ga *GioApp) openSnapshotDialog() {
+	if ga.selectedCollection == nil || ga.currentUser == nil {
+		return
+	}
+	ga.showSnapshotDialog = true
+	ga.snapshotIndex = -1
+	ga.snapshotDiff = nil
+	ga.snapshotConfirmRestore = false
+	ga.snapshotErr = ""
+	ga.snapshotNotice = ""
+	ga.widgetState.snapshotLabelEditor.SingleLine = true
+	ga.widgetState.snapshotLabelEditor.SetText("")
+	ga.widgetState.snapshotDialog.Reset()
+	ga.loadSnapshots()
+}
+
+func (ga *GioApp) closeSnapshotDialog() {
+	ga.showSnapshotDialog = false
+	ga.snapshots = nil
+	ga.snapshotDiff = nil
+	ga.snapshotErr = ""
+	ga.snapshotNotice = ""
+	ga.widgetState.snapshotDialog.Reset()
+}
+
+// loadSnapshots fetches the collection's snapshots, keeping the selection
+// when the selected snapshot is still listed
+func (ga *GioApp) loadSnapshots() {
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.snapshotsLoading = true
+
+	ga.goSafe(func() {
+		list, err := ga.snapshotsClient.List(userID, collectionID)
+
+		ga.do(func() {
+			ga.snapshotsLoading = false
+			if !ga.showSnapshotDialog || ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to list snapshots", "collection_id", collectionID, "error", err)
+				ga.snapshotErr = "Could not load snapshots: " + err.Error()
+				return
+			}
+
+			selectedID := ga.selectedSnapshotID()
+			ga.snapshots = list.Snapshots
+			ga.snapshotLimit = list.Limit
+			ga.snapshotIndex = -1
+			for i, s := range ga.snapshots {
+				if s.ID == selectedID {
+					ga.snapshotIndex = i
+				}
+			}
+			if ga.snapshotIndex < 0 {
+				ga.snapshotDiff = nil
+			}
+		})
+	})
+}
+
+func (ga *GioApp) selectedSnapshotID() string {
+	if ga.snapshotIndex < 0 || ga.snapshotIndex >= len(ga.snapshots) {
+		return ""
+	}
+	return ga.snapshots[ga.snapshotIndex].ID
+}
+
+// createSnapshot snapshots the collection with the label typed in the dialog
+func (ga *GioApp) createSnapshot() {
+	if ga.currentUser == nil || ga.selectedCollection == nil || ga.snapshotRunning {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	label := strings.TrimSpace(ga.widgetState.snapshotLabelEditor.Text())
+	ga.snapshotRunning = true
+	ga.snapshotErr = ""
+	ga.snapshotNotice = ""
+
+	ga.goSafe(func() {
+		snapshot, err := ga.snapshotsClient.Create(userID, collectionID, label)
+
+		ga.do(func() {
+			ga.snapshotRunning = false
+			if err != nil {
+				ga.logger.Error("Failed to create snapshot", "collection_id", collectionID, "error", err)
+				ga.snapshotErr = "Snapshot failed: " + err.Error()
+				return
+			}
+			ga.logger.Info("Snapshot created", "snapshot_id", snapshot.ID, "objects", snapshot.ObjectCount)
+			ga.widgetState.snapshotLabelEditor.SetText("")
+			ga.snapshotNotice = fmt.Sprintf("Saved %d containers and %d objects", snapshot.ContainerCount, snapshot.ObjectCount)
+			ga.loadSnapshots()
+		})
+	})
+}
+
+// selectSnapshot compares the snapshot with the collection as it is now
+func (ga *GioApp) selectSnapshot(index int) {
+	if ga.currentUser == nil || index == ga.snapshotIndex {
+		return
+	}
+	ga.snapshotIndex = index
+	ga.snapshotDiff = nil
+	ga.snapshotConfirmRestore = false
+	ga.snapshotErr = ""
+	ga.snapshotDiffLoading = true
+
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	snapshotID := ga.snapshots[index].ID
+
+	ga.goSafe(func() {
+		diff, err := ga.snapshotsClient.Diff(userID, collectionID, snapshotID, "")
+
+		ga.do(func() {
+			// A newer selection supersedes this comparison
+			if ga.selectedSnapshotID() != snapshotID {
+				return
+			}
+			ga.snapshotDiffLoading = false
+			if err != nil {
+				ga.logger.Error("Failed to compare snapshot", "snapshot_id", snapshotID, "error", err)
+				ga.snapshotErr = "Could not compare: " + err.Error()
+				return
+			}
+			ga.snapshotDiff = diff
+		})
+	})
+}
+
+// restoreSnapshot rolls the collection back to the selected snapshot and
+// reloads its containers and objects
+func (ga *GioApp) restoreSnapshot() {
+	snapshotID := ga.selectedSnapshotID()
+	if ga.currentUser == nil || snapshotID == "" || ga.snapshotRunning {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.snapshotRunning = true
+	ga.snapshotConfirmRestore = false
+	ga.snapshotErr = ""
+	ga.logger.Info("Restoring snapshot", "collection_id", collectionID, "snapshot_id", snapshotID)
+
+	ga.goSafe(func() {
+		result, err := ga.snapshotsClient.Restore(userID, collectionID, snapshotID)
+
+		ga.do(func() {
+			ga.snapshotRunning = false
+			if err != nil {
+				ga.logger.Error("Failed to restore snapshot", "snapshot_id", snapshotID, "error", err)
+				ga.snapshotErr = "Restore failed: " + err.Error()
+				return
+			}
+
+			notice := fmt.Sprintf("Restored %d containers and %d objects. The previous state was saved as a snapshot.",
+				result.ContainersRestored, result.ObjectsRestored)
+			if len(result.Skipped) > 0 {
+				notice += " Skipped: " + strings.Join(result.Skipped, "; ")
+			}
+			ga.snapshotNotice = notice
+			ga.snapshotIndex = -1
+			ga.snapshotDiff = nil
+			ga.fetchContainersAndObjects()
+			ga.loadSnapshots()
+		})
+	})
+}
+
+func (ga *GioApp) deleteSnapshot() {
+	snapshotID := ga.selectedSnapshotID()
+	if ga.currentUser == nil || snapshotID == "" || ga.snapshotRunning {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.snapshotRunning = true
+	ga.snapshotErr = ""
+
+	ga.goSafe(func() {
+		err := ga.snapshotsClient.Delete(userID, collectionID, snapshotID)
+
+		ga.do(func() {
+			ga.snapshotRunning = false
+			if err != nil {
+				ga.logger.Error("Failed to delete snapshot", "snapshot_id", snapshotID, "error", err)
+				ga.snapshotErr = "Delete failed: " + err.Error()
+				return
+			}
+			ga.snapshotIndex = -1
+			ga.snapshotDiff = nil
+			ga.loadSnapshots()
+		})
+	})
+}
+
+// renderSnapshotDialog renders the collection's snapshots and what changed
+// since the selected one
+func (ga *GioApp) renderSnapshotDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showSnapshotDialog {
+		return layout.Dimensions{}
+	}
+
+	if n := len(ga.snapshots); len(ga.widgetState.snapshotButtons) < n {
+		ga.widgetState.snapshotButtons = make([]widget.Clickable, n)
+	}
+	for i := range ga.snapshots {
+		if ga.widgetState.snapshotButtons[i].Clicked(gtx) {
+			ga.selectSnapshot(i)
+		}
+	}
+	if ga.widgetState.snapshotCreate.Clicked(gtx) {
+		ga.createSnapshot()
+	}
+	if ga.widgetState.snapshotRestore.Clicked(gtx) {
+		if ga.snapshotConfirmRestore {
+			ga.restoreSnapshot()
+		} else {
+			ga.snapshotConfirmRestore = true
+		}
+	}
+	if ga.widgetState.snapshotDelete.Clicked(gtx) {
+		ga.deleteSnapshot()
+	}
+	if ga.widgetState.snapshotClose.Clicked(gtx) {
+		ga.closeSnapshotDialog()
+		return layout.Dimensions{}
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.snapshotDialog, "Snapshots")
+	dialogStyle.Width = unit.Dp(600)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(ga.renderSnapshotCreateRow),
+			layout.Rigid(ga.renderSnapshotList),
+			layout.Rigid(ga.renderSnapshotDiff),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				switch {
+				case ga.snapshotErr != "":
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body2(ga.theme.Theme, ga.snapshotErr)
+						label.Color = theme.ColorDanger
+						return label.Layout(gtx)
+					})
+				case ga.snapshotNotice != "":
+					return ga.mergeNote(gtx, ga.snapshotNotice)
+				}
+				return layout.Dimensions{}
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.snapshotClose, "Close"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.snapshotIndex < 0 {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.snapshotDelete, "Delete"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.snapshotIndex < 0 {
+							return layout.Dimensions{}
+						}
+						label := "Restore"
+						switch {
+						case ga.snapshotRunning:
+							label = "Working..."
+						case ga.snapshotConfirmRestore:
+							label = "Replace current state?"
+						}
+						return widgets.DangerButton(ga.theme.Theme, &ga.widgetState.snapshotRestore, label)(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeSnapshotDialog()
+	}
+	return dims
+}
+
+func (ga *GioApp) renderSnapshotCreateRow(gtx layout.Context) layout.Dimensions {
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.End}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "New snapshot", &ga.widgetState.snapshotLabelEditor, "Label (optional), e.g. Before spring clean")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := "Take snapshot"
+				if ga.snapshotRunning {
+					label = "Saving..."
+				}
+				return layout.Inset{Left: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx,
+					widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.snapshotCreate, label))
+			}),
+		)
+	})
+}
+
+func (ga *GioApp) renderSnapshotList(gtx layout.Context) layout.Dimensions {
+	switch {
+	case ga.snapshotsLoading && len(ga.snapshots) == 0:
+		return ga.mergeNote(gtx, "Loading snapshots...")
+	case len(ga.snapshots) == 0 && ga.snapshotErr == "":
+		return ga.mergeNote(gtx, "No snapshots yet. Imports take one automatically.")
+	}
+
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(unit.Dp(200)))
+	list := &ga.widgetState.snapshotList
+	list.Axis = layout.Vertical
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.snapshotLimit <= 0 {
+					return layout.Dimensions{}
+				}
+				label := material.Caption(ga.theme.Theme, fmt.Sprintf("The %d most recent snapshots are kept", ga.snapshotLimit))
+				label.Color = theme.ColorTextSecondary
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, label.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return list.Layout(gtx, len(ga.snapshots), func(gtx layout.Context, index int) layout.Dimensions {
+					snapshot := ga.snapshots[index]
+					selected := index == ga.snapshotIndex
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return widgets.ClickArea(gtx, &ga.widgetState.snapshotButtons[index], snapshotTitle(snapshot), func(gtx layout.Context) layout.Dimensions {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									label := material.Body1(ga.theme.Theme, snapshotTitle(snapshot))
+									if selected {
+										label.Font.Weight = font.Bold
+										label.Color = theme.ColorPrimary
+									}
+									return label.Layout(gtx)
+								}),
+								layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+									label := material.Caption(ga.theme.Theme, fmt.Sprintf("%d containers · %d objects", snapshot.ContainerCount, snapshot.ObjectCount))
+									label.Color = theme.ColorTextSecondary
+									return label.Layout(gtx)
+								}),
+							)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+// renderSnapshotDiff lists what changed between the selected snapshot and now
+func (ga *GioApp) renderSnapshotDiff(gtx layout.Context) layout.Dimensions {
+	if ga.snapshotIndex < 0 {
+		if len(ga.snapshots) > 0 {
+			return ga.mergeNote(gtx, "Select a snapshot to see what changed since")
+		}
+		return layout.Dimensions{}
+	}
+	if ga.snapshotDiffLoading {
+		return ga.mergeNote(gtx, "Comparing...")
+	}
+	if ga.snapshotDiff == nil {
+		return layout.Dimensions{}
+	}
+
+	lines := snapshotDiffLines(ga.snapshotDiff)
+	if len(lines) == 0 {
+		return ga.mergeNote(gtx, "Nothing has changed since this snapshot")
+	}
+
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(unit.Dp(260)))
+	list := &ga.widgetState.snapshotDiffList
+	list.Axis = layout.Vertical
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return list.Layout(gtx, len(lines), func(gtx layout.Context, index int) layout.Dimensions {
+			line := lines[index]
+			label := material.Body2(ga.theme.Theme, line.text)
+			switch line.kind {
+			case diffAdded:
+				label.Color = theme.ColorAccentDark
+			case diffRemoved:
+				label.Color = theme.ColorDanger
+			case diffHeading:
+				label.Font.Weight = font.Bold
+			default:
+				label.Color = theme.ColorTextSecondary
+			}
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, label.Layout)
+		})
+	})
+}
+
+func snapshotTitle(s types.CollectionSnapshot) string {
+	date := s.CreatedAt.Local().Format("Jan 2, 2006 15:04")
+	if s.Label == "" {
+		return date
+	}
+	return s.Label + " — " + date
+}
+
+type diffLineKind int
+
+const (
+	diffHeading diffLineKind = iota
+	diffAdded
+	diffRemoved
+	diffChanged
+)
+
+type diffLine struct {
+	kind diffLineKind
+	text string
+}
+
+// snapshotDiffLines flattens a diff into headed sections, omitting empty
+// ones
+func snapshotDiffLines(diff *types.CollectionSnapshotDiff) []diffLine {
+	var lines []diffLine
+	section := func(title string, items []diffLine) {
+		if len(items) == 0 {
+			return
+		}
+		lines = append(lines, diffLine{kind: diffHeading, text: fmt.Sprintf("%s (%d)", title, len(items))})
+		lines = append(lines, items...)
+	}
+
+	var items []diffLine
+	for _, c := range diff.ContainersAdded {
+		items = append(items, diffLine{kind: diffAdded, text: "+ " + c.Name})
+	}
+	for _, c := range diff.ContainersRemoved {
+		items = append(items, diffLine{kind: diffRemoved, text: "− " + c.Name})
+	}
+	for _, c := range diff.ContainersChanged {
+		items = append(items, diffLine{kind: diffChanged, text: "~ " + c.Container.Name + ": " + strings.Join(c.Fields, ", ")})
+	}
+	section("Containers", items)
+
+	items = nil
+	for _, o := range diff.ObjectsAdded {
+		items = append(items, diffLine{kind: diffAdded, text: "+ " + o.Name + snapshotObjectPlace(o.AfterContainer)})
+	}
+	for _, o := range diff.ObjectsRemoved {
+		items = append(items, diffLine{kind: diffRemoved, text: "− " + o.Name + snapshotObjectPlace(o.BeforeContainer)})
+	}
+	for _, o := range diff.ObjectsChanged {
+		items = append(items, diffLine{kind: diffChanged, text: "~ " + o.Name + ": " + snapshotObjectChangeDetail(o)})
+	}
+	section("Objects", items)
+
+	return lines
+}
+
+func snapshotObjectPlace(ref *types.SnapshotContainerRef) string {
+	if ref == nil {
+		return ""
+	}
+	return " (" + ref.Name + ")"
+}
+
+// snapshotObjectChangeDetail spells out the changes a reader most often
+// looks for and names the other changed fields
+func snapshotObjectChangeDetail(c types.SnapshotObjectChange) string {
+	parts := make([]string, 0, len(c.Fields))
+	for _, field := range c.Fields {
+		switch {
+		case field == "container" && c.BeforeContainer != nil && c.AfterContainer != nil:
+			parts = append(parts, "moved from "+c.BeforeContainer.Name+" to "+c.AfterContainer.Name)
+		case field == "quantity" && c.Before != nil && c.After != nil:
+			parts = append(parts, "quantity "+snapshotQuantity(c.Before)+" → "+snapshotQuantity(c.After))
+		case field == "name" && c.Before != nil:
+			parts = append(parts, "renamed from "+strconv.Quote(c.Before.Name))
+		default:
+			parts = append(parts, strings.ReplaceAll(field, "_", " "))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func snapshotQuantity(obj *Object) string {
+	if obj.Quantity == nil {
+		return "none"
+	}
+	return strings.TrimSpace(strconv.FormatFloat(*obj.Quantity, 'f', -1, 64) + " " + obj.Unit)
+}
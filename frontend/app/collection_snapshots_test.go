@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestSnapshotObjectChangeDetail(t *testing.T) {
+	before, after := 2.0, 0.5
+	change := types.SnapshotObjectChange{
+		Name:            "Flour",
+		Before:          &Object{Name: "Plain flour", Quantity: &before, Unit: "kg"},
+		After:           &Object{Name: "Flour", Quantity: &after, Unit: "kg"},
+		BeforeContainer: &types.SnapshotContainerRef{Name: "Pantry"},
+		AfterContainer:  &types.SnapshotContainerRef{Name: "Bin"},
+		Fields:          []string{"name", "quantity", "expires_at", "container"},
+	}
+
+	got := snapshotObjectChangeDetail(change)
+	want := `renamed from "Plain flour", quantity 2 kg → 0.5 kg, expires at, moved from Pantry to Bin`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotDiffLinesSkipsEmptySections(t *testing.T) {
+	diff := &types.CollectionSnapshotDiff{
+		ObjectsAdded:   []types.SnapshotObjectChange{{Name: "Oats", AfterContainer: &types.SnapshotContainerRef{Name: "Bin"}}},
+		ObjectsRemoved: []types.SnapshotObjectChange{{Name: "Rice"}},
+	}
+
+	lines := snapshotDiffLines(diff)
+	if len(lines) != 3 {
+		t.Fatalf("expected heading and two objects, got %d lines", len(lines))
+	}
+	if lines[0].kind != diffHeading || lines[0].text != "Objects (2)" {
+		t.Errorf("unexpected heading %+v", lines[0])
+	}
+	if lines[1].text != "+ Oats (Bin)" || lines[2].text != "− Rice" {
+		t.Errorf("unexpected lines %q, %q", lines[1].text, lines[2].text)
+	}
+}
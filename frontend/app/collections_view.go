@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"gioui.org/font"
@@ -35,28 +36,25 @@ var objectTypes = []string{
 	ObjectTypeGeneral,
 }
 
-var objectTypeLabels = map[string]string{
-	ObjectTypeFood:      "Food",
-	ObjectTypeBook:      "Books",
-	ObjectTypeVideoGame: "Video Games",
-	ObjectTypeMusic:     "Music",
-	ObjectTypeBoardGame: "Board Games",
-	ObjectTypeGeneral:   "General",
+// openCreateCollectionDialog resets the collection editors and shows the create collection dialog
+func (ga *GioApp) openCreateCollectionDialog() {
+	ga.logger.Info("Opening create collection dialog")
+	ga.showCollectionDialog = true
+	ga.collectionDialogMode = "create"
+	ga.selectedObjectType = ObjectTypeGeneral
+	ga.selectedGroupID = nil
+	// Clear editors
+	ga.widgetState.collectionNameEditor.SetText("")
+	ga.widgetState.collectionLocationEditor.SetText("")
+	ga.widgetState.collectionTagsEditor.SetText("")
+	ga.widgetState.collectionShelfLifeEditor.SetText("")
 }
 
 // renderCollectionsView renders the collections view with CRUD operations
 func (ga *GioApp) renderCollectionsView(gtx layout.Context) layout.Dimensions {
 	// Handle create button click
 	if ga.widgetState.collectionsCreateButton.Clicked(gtx) {
-		ga.logger.Info("Opening create collection dialog")
-		ga.showCollectionDialog = true
-		ga.collectionDialogMode = "create"
-		ga.selectedObjectType = ObjectTypeGeneral
-		ga.selectedGroupID = nil
-		// Clear editors
-		ga.widgetState.collectionNameEditor.SetText("")
-		ga.widgetState.collectionLocationEditor.SetText("")
-		ga.widgetState.collectionTagsEditor.SetText("")
+		ga.openCreateCollectionDialog()
 	}
 
 	// Handle import-create button click
@@ -94,6 +92,9 @@ func (ga *GioApp) renderCollectionsView(gtx layout.Context) layout.Dimensions {
 						return ga.renderCollectionsToolbar(gtx)
 					}),
 
+					// Folder breadcrumb and subfolders
+					layout.Rigid(ga.renderFolderBar),
+
 					// Collections list
 					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 						return ga.renderCollectionsList(gtx)
@@ -141,7 +142,7 @@ func (ga *GioApp) renderCollectionsToolbar(gtx layout.Context) layout.Dimensions
 
 			// Create button
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.collectionsCreateButton, "+")(gtx)
+				return widgets.PrimaryIconButton(ga.theme.Theme, &ga.widgetState.collectionsCreateButton, "+", "New collection")(gtx)
 			}),
 		)
 	})
@@ -149,6 +150,9 @@ func (ga *GioApp) renderCollectionsToolbar(gtx layout.Context) layout.Dimensions
 
 // renderCollectionsList renders the list of collections
 func (ga *GioApp) renderCollectionsList(gtx layout.Context) layout.Dimensions {
+	if !ga.collectionsLoaded {
+		return widgets.SkeletonList(skeletonRows)(gtx)
+	}
 	if len(ga.collections) == 0 {
 		return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 			label := material.H5(ga.theme.Theme, "No collections yet")
@@ -158,30 +162,48 @@ func (ga *GioApp) renderCollectionsList(gtx layout.Context) layout.Dimensions {
 		})
 	}
 
-	// Filter collections based on search query
+	// Filter collections based on search query; without one, list the
+	// collections filed in the folder on screen
 	searchQuery := strings.ToLower(ga.widgetState.collectionsSearchField.Text())
 	filteredCollections := make([]Collection, 0)
 	filteredIndices := make([]int, 0)
 
 	for i, collection := range ga.collections {
-		if searchQuery == "" ||
-			strings.Contains(strings.ToLower(collection.Name), searchQuery) ||
-			strings.Contains(strings.ToLower(collection.Location), searchQuery) ||
-			strings.Contains(strings.ToLower(collection.ObjectType), searchQuery) {
+		matches := searchQuery == "" && inFolder(ga.collectionFolders, collection.ID, ga.currentFolderID)
+		if searchQuery != "" {
+			matches = strings.Contains(strings.ToLower(collection.Name), searchQuery) ||
+				strings.Contains(strings.ToLower(collection.Location), searchQuery) ||
+				strings.Contains(strings.ToLower(collection.ObjectType), searchQuery)
+		}
+		if matches {
 			filteredCollections = append(filteredCollections, collection)
 			filteredIndices = append(filteredIndices, i)
 		}
 	}
 
-	// Render list using widget state
+	// Render list using widget state, one row of cards per list item
+	cols := ga.sizeClass.collectionColumns()
+	rows := (len(filteredCollections) + cols - 1) / cols
 	list := &ga.widgetState.collectionsList
 	list.Axis = layout.Vertical
-	return list.Layout(gtx, len(filteredCollections), func(gtx layout.Context, index int) layout.Dimensions {
-		collection := filteredCollections[index]
-		originalIndex := filteredIndices[index]
-		return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			return ga.renderCollectionCard(gtx, collection, originalIndex)
-		})
+	return list.Layout(gtx, rows, func(gtx layout.Context, row int) layout.Dimensions {
+		cells := make([]layout.FlexChild, cols)
+		for col := range cols {
+			i := row*cols + col
+			cells[col] = layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				if i >= len(filteredCollections) {
+					return layout.Dimensions{}
+				}
+				inset := layout.Inset{Bottom: unit.Dp(theme.Spacing2)}
+				if col < cols-1 {
+					inset.Right = unit.Dp(theme.Spacing2)
+				}
+				return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderCollectionCard(gtx, filteredCollections[i], filteredIndices[i])
+				})
+			})
+		}
+		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, cells...)
 	})
 }
 
@@ -209,6 +231,7 @@ func (ga *GioApp) renderCollectionCard(gtx layout.Context, collection Collection
 		ga.widgetState.collectionNameEditor.SetText(collection.Name)
 		ga.widgetState.collectionLocationEditor.SetText(collection.Location)
 		ga.widgetState.collectionTagsEditor.SetText(strings.Join(collection.Tags, ", "))
+		ga.widgetState.collectionShelfLifeEditor.SetText(formatShelfLife(collection.ShelfLife))
 	}
 
 	// Handle delete button click
@@ -218,6 +241,18 @@ func (ga *GioApp) renderCollectionCard(gtx layout.Context, collection Collection
 		ga.deleteCollectionID = collection.ID
 	}
 
+	// Handle pin and move buttons
+	if itemState.pinButton.Clicked(gtx) {
+		ga.toggleCollectionPin(collection.ID)
+	}
+	if itemState.moveUpButton.Clicked(gtx) {
+		ga.moveCollection(index, -1)
+	}
+	if itemState.moveDownButton.Clicked(gtx) {
+		ga.moveCollection(index, 1)
+	}
+	pinned := ga.collectionOrder[collection.ID].Pinned
+
 	card := widgets.DefaultCard()
 	return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{
@@ -230,6 +265,11 @@ func (ga *GioApp) renderCollectionCard(gtx layout.Context, collection Collection
 					Alignment: layout.Middle,
 					Spacing:   layout.SpaceBetween,
 				}.Layout(gtx,
+					// Handle for dragging the collection onto a folder
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return ga.renderCollectionDragHandle(gtx, &itemState.dragHandle, collection)
+					}),
+
 					// Collection name
 					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 						label := material.H6(ga.theme.Theme, collection.Name)
@@ -237,11 +277,21 @@ func (ga *GioApp) renderCollectionCard(gtx layout.Context, collection Collection
 						return label.Layout(gtx)
 					}),
 
+					// Pinned marker
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return ga.renderPinnedMarker(gtx, pinned)
+					}),
+
+					// Unread comments badge
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return ga.renderUnreadBadge(gtx, collection.ID)
+					}),
+
 					// Type label
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							label := material.Body2(ga.theme.Theme, objectTypeLabels[collection.ObjectType])
-							label.Color = theme.ColorAccent
+							label := material.Body2(ga.theme.Theme, ga.objectTypeTitle(collection.ObjectType))
+							label.Color = ga.objectTypeColor(collection.ObjectType)
 							return label.Layout(gtx)
 						})
 					}),
@@ -280,6 +330,9 @@ func (ga *GioApp) renderCollectionCard(gtx layout.Context, collection Collection
 						Axis:    layout.Horizontal,
 						Spacing: layout.SpaceStart,
 					}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return ga.renderOrderButtons(gtx, &itemState.pinButton, &itemState.moveUpButton, &itemState.moveDownButton, pinned)
+						}),
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 								return widgets.PrimaryButton(ga.theme.Theme, &itemState.viewButton, "View")(gtx)
@@ -369,6 +422,9 @@ func (ga *GioApp) renderCollectionDialog(gtx layout.Context) layout.Dimensions {
 				return ga.renderFormField(gtx, "Tags", &ga.widgetState.collectionTagsEditor, "Comma-separated tags")
 			}),
 
+			// Default shelf life (food collections)
+			layout.Rigid(ga.renderShelfLifeField),
+
 			// Buttons
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{
@@ -419,10 +475,31 @@ func (ga *GioApp) renderFormField(gtx layout.Context, label string, editor *widg
 	})
 }
 
-// renderObjectTypeSelector renders object type selection chips.
+// renderQuantityField renders a labelled quantity stepper for a form. The
+// form reads the stepper's text when it is saved, so settled values are
+// not acted on here.
+func (ga *GioApp) renderQuantityField(gtx layout.Context, label string, stepper *widgets.QuantityStepper, unitLabel string) layout.Dimensions {
+	stepper.Update(gtx)
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				labelWidget := material.Body2(ga.theme.Theme, label)
+				labelWidget.Color = theme.ColorTextSecondary
+				return labelWidget.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return stepper.Layout(gtx, ga.theme.Theme, strings.TrimSpace(unitLabel))
+			}),
+		)
+	})
+}
+
+// renderObjectTypeSelector renders object type selection chips, the
+// built-in types followed by the user's custom ones.
 func (ga *GioApp) renderObjectTypeSelector(gtx layout.Context) layout.Dimensions {
-	chips := make([]layout.Widget, len(objectTypes))
-	for i, ot := range objectTypes {
+	keys := ga.objectTypeKeys()
+	chips := make([]layout.Widget, len(keys))
+	for i, ot := range keys {
 		if ga.widgetState.collectionTypeButtons[ot] == nil {
 			ga.widgetState.collectionTypeButtons[ot] = &widget.Clickable{}
 		}
@@ -432,7 +509,7 @@ func (ga *GioApp) renderObjectTypeSelector(gtx layout.Context) layout.Dimensions
 		}
 		active := ga.selectedObjectType == ot
 		chips[i] = func(gtx layout.Context) layout.Dimensions {
-			return ga.renderFilterChip(gtx, btn, objectTypeLabels[ot], active)
+			return ga.renderFilterChip(gtx, btn, ga.objectTypeTitle(ot), active)
 		}
 	}
 	return ga.renderChipSelector(gtx, "Object Type *", chips)
@@ -493,9 +570,16 @@ func (ga *GioApp) handleCollectionCreate() {
 		}
 	}
 
+	shelfLife, err := ga.shelfLifeFromDialog()
+	if err != nil {
+		ga.showAPIErrorDialog("Invalid shelf life: " + err.Error())
+		return
+	}
+
 	ga.logger.Info("Creating collection", "name", name, "type", ga.selectedObjectType)
+	userID := ga.currentUser.ID
 
-	go func() {
+	ga.goSafe(func() {
 		req := types.CreateCollectionRequest{
 			Name:       name,
 			ObjectType: ga.selectedObjectType,
@@ -504,7 +588,7 @@ func (ga *GioApp) handleCollectionCreate() {
 			Tags:       tags,
 		}
 
-		collection, err := ga.collectionsClient.Create(ga.currentUser.ID, req)
+		collection, err := ga.collectionsClient.Create(userID, req)
 		if err != nil {
 			ga.logger.Error("Failed to create collection", "error", err)
 			return
@@ -514,7 +598,8 @@ func (ga *GioApp) handleCollectionCreate() {
 		ga.do(func() {
 			ga.collections = append(ga.collections, *collection)
 		})
-	}()
+		ga.saveShelfLife(userID, *collection, shelfLife)
+	})
 
 	// Close dialog
 	ga.showCollectionDialog = false
@@ -548,34 +633,45 @@ func (ga *GioApp) handleCollectionUpdate() {
 		}
 	}
 
-	ga.logger.Info("Updating collection", "collection_id", ga.selectedCollection.ID, "name", name)
-
-	collectionID := ga.selectedCollection.ID
+	shelfLife, err := ga.shelfLifeFromDialog()
+	if err != nil {
+		ga.showAPIErrorDialog("Invalid shelf life: " + err.Error())
+		return
+	}
 
-	go func() {
-		req := types.UpdateCollectionRequest{
-			Name:       name,
-			ObjectType: ga.selectedObjectType,
-			Location:   location,
-			Tags:       tags,
-		}
+	ga.logger.Info("Updating collection", "collection_id", ga.selectedCollection.ID, "name", name)
 
-		updated, err := ga.collectionsClient.Update(ga.currentUser.ID, collectionID, req)
-		if err != nil {
-			ga.logger.Error("Failed to update collection", "error", err)
-			return
-		}
+	userID := ga.currentUser.ID
+	original := *ga.selectedCollection
+	edited := original
+	edited.Name, edited.Location, edited.Tags = name, location, tags
+	if ga.selectedObjectType != "" {
+		edited.ObjectType = ga.selectedObjectType
+	}
+	patch, err := types.DiffMergePatch(collectionPatchBody(original), collectionPatchBody(edited))
+	if err != nil {
+		ga.logger.Error("Failed to build collection patch", "error", err)
+		return
+	}
 
-		ga.logger.Info("Collection updated successfully", "collection_id", collectionID)
-		ga.do(func() {
-			for i, c := range ga.collections {
-				if c.ID == updated.ID {
-					ga.collections[i] = *updated
-					break
-				}
+	ga.optimisticUpdate("update collection", func() {
+		ga.setCollection(edited)
+	}, func() {
+		ga.setCollection(original)
+	}, func() (func(), error) {
+		// Shelf life is saved on its own, so it may be all that changed
+		updated := &original
+		if len(patch) > 0 {
+			patched, err := ga.collectionsClient.Patch(userID, original.ID, patch)
+			if err != nil {
+				return nil, err
 			}
-		})
-	}()
+			updated = patched
+		}
+		ga.logger.Info("Collection updated successfully", "collection_id", original.ID)
+		ga.saveShelfLife(userID, *updated, shelfLife)
+		return func() { ga.setCollection(*updated) }, nil
+	})
 
 	// Close dialog
 	ga.showCollectionDialog = false
@@ -583,6 +679,27 @@ func (ga *GioApp) handleCollectionUpdate() {
 	ga.window.Invalidate()
 }
 
+// collectionPatchBody is the part of a collection the edit dialog changes,
+// as a patch body for diffing the collection before and after an edit
+func collectionPatchBody(c Collection) types.PatchCollectionRequest {
+	return types.PatchCollectionRequest{
+		Name:       &c.Name,
+		ObjectType: &c.ObjectType,
+		Location:   &c.Location,
+		Tags:       c.Tags,
+	}
+}
+
+// setCollection replaces a collection in the list with the given version
+func (ga *GioApp) setCollection(updated Collection) {
+	for i, c := range ga.collections {
+		if c.ID == updated.ID {
+			ga.collections[i] = updated
+			return
+		}
+	}
+}
+
 // collectionObjectCount returns the total object count for the selected collection,
 // using the embedded container data from the collection response.
 func (ga *GioApp) collectionObjectCount() int {
@@ -683,12 +800,26 @@ func (ga *GioApp) handleCollectionDelete() {
 	ga.logger.Info("Deleting collection", "collection_id", ga.deleteCollectionID)
 
 	collectionID := ga.deleteCollectionID
+	userID := ga.currentUser.ID
 
-	go func() {
-		err := ga.collectionsClient.Delete(ga.currentUser.ID, collectionID, true)
+	// Drop the collection from the list right away and put it back where it
+	// was if the server refuses
+	index := slices.IndexFunc(ga.collections, func(c Collection) bool { return c.ID == collectionID })
+	if index < 0 {
+		ga.logger.Error("Collection to delete is not loaded", "collection_id", collectionID)
+		ga.showDeleteCollection = false
+		ga.deleteCollectionID = ""
+		return
+	}
+	removed := ga.collections[index]
+	ga.collections = slices.Delete(ga.collections, index, index+1)
+
+	ga.goSafe(func() {
+		err := ga.collectionsClient.Delete(userID, collectionID, true)
 		if err != nil {
 			ga.logger.Error("Failed to delete collection", "error", err)
 			ga.do(func() {
+				ga.collections = reinsert(ga.collections, index, removed)
 				ga.showDeleteCollectionError = true
 				ga.deleteCollectionErrorMsg = err.Error()
 			})
@@ -696,15 +827,7 @@ func (ga *GioApp) handleCollectionDelete() {
 		}
 
 		ga.logger.Info("Collection deleted successfully", "collection_id", collectionID)
-		ga.do(func() {
-			for i, c := range ga.collections {
-				if c.ID == collectionID {
-					ga.collections = append(ga.collections[:i], ga.collections[i+1:]...)
-					break
-				}
-			}
-		})
-	}()
+	})
 
 	// Close dialog
 	ga.showDeleteCollection = false
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/config"
+	apiCommon "github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// offlineProbeInterval is how often the app checks whether the backend is
+// back while offline. Checks inside the breaker's cooldown fail fast
+// without touching the network.
+const offlineProbeInterval = 2 * time.Second
+
+// newTransport creates the retrying transport of the API clients from the
+// [network] config section.
+func newTransport(cfg config.NetworkConfig) *apiCommon.Transport {
+	return apiCommon.NewTransport(apiCommon.TransportConfig{
+		MaxRetries:       cfg.MaxRetries,
+		RetryDelay:       cfg.RetryDelay,
+		MaxRetryDelay:    cfg.MaxRetryDelay,
+		BreakerThreshold: cfg.BreakerThreshold,
+		BreakerCooldown:  cfg.BreakerCooldown,
+	})
+}
+
+// updateConnectivity syncs the offline banner with the transport's circuit
+// breakers. Going offline starts probing the backend so the banner clears
+// on its own even when nothing else sends a request.
+func (ga *GioApp) updateConnectivity() {
+	wasOffline := ga.offline
+	ga.offline = ga.transport.Offline()
+	if ga.offline && !wasOffline {
+		ga.logger.Warn("Backend unreachable, going offline")
+		ga.goSafe(ga.probeBackend)
+	} else if !ga.offline && wasOffline {
+		ga.logger.Info("Backend reachable again")
+	}
+}
+
+// probeBackend polls the backend's liveness endpoint through the shared
+// transport until its breaker closes. It runs on its own goroutine.
+func (ga *GioApp) probeBackend() {
+	client := &http.Client{Transport: ga.transport, Timeout: 10 * time.Second}
+	url := strings.TrimRight(ga.config.BackendURL, "/") + "/health/live"
+	for ga.transport.Offline() {
+		time.Sleep(offlineProbeInterval)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		if err != nil {
+			return
+		}
+		if resp, err := client.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+}
+
+// renderOfflineBanner shows a strip above the current view while the
+// backend cannot be reached.
+func (ga *GioApp) renderOfflineBanner(gtx layout.Context) layout.Dimensions {
+	if !ga.offline {
+		return layout.Dimensions{}
+	}
+	return layout.Background{}.Layout(gtx,
+		func(gtx layout.Context) layout.Dimensions {
+			defer clip.Rect{Max: gtx.Constraints.Min}.Push(gtx.Ops).Pop()
+			paint.ColorOp{Color: theme.ColorWarning}.Add(gtx.Ops)
+			paint.PaintOp{}.Add(gtx.Ops)
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		},
+		func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(unit.Dp(theme.Spacing2)).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, "Can't reach the server. Changes may not be saved; retrying automatically.")
+				label.Color = theme.ColorWhite
+				return layout.Center.Layout(gtx, label.Layout)
+			})
+		},
+	)
+}
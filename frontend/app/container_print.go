@@ -0,0 +1,152 @@
+package app
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// containerPrintPageSize is how many objects fit on one printed page
+const containerPrintPageSize = 30
+
+// containerPrintRow is one object on a printed container list
+type containerPrintRow struct {
+	Name     string
+	Quantity string
+	Expires  string
+}
+
+// containerPrintPage is one sheet of a printed container list
+type containerPrintPage struct {
+	Number int
+	Rows   []containerPrintRow
+}
+
+// containerPrintRows lists the objects soonest to expire first, then by
+// name, so the list on the freezer door reads in the order to eat things
+func (ga *GioApp) containerPrintRows(objects []Object) []containerPrintRow {
+	sorted := make([]Object, len(objects))
+	copy(sorted, objects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].ExpiresAt, sorted[j].ExpiresAt
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+	})
+
+	rows := make([]containerPrintRow, len(sorted))
+	for i, obj := range sorted {
+		rows[i] = containerPrintRow{Name: obj.Name, Quantity: ga.objectQuantityText(obj)}
+		if obj.ExpiresAt != nil {
+			rows[i].Expires = obj.ExpiresAt.Local().Format("Jan 2, 2006")
+		}
+	}
+	return rows
+}
+
+// paginateContainerPrint splits rows into pages of containerPrintPageSize;
+// an empty container still prints one page
+func paginateContainerPrint(rows []containerPrintRow) []containerPrintPage {
+	pages := []containerPrintPage{{Number: 1}}
+	for i, row := range rows {
+		if i > 0 && i%containerPrintPageSize == 0 {
+			pages = append(pages, containerPrintPage{Number: len(pages) + 1})
+		}
+		last := &pages[len(pages)-1]
+		last.Rows = append(last.Rows, row)
+	}
+	return pages
+}
+
+// containerPrintTemplate lays the list out for paper: no app chrome, one
+// sheet per page with the heading repeated, and the browser's print dialog
+// opened once it loads
+var containerPrintTemplate = template.Must(template.New("container").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+@page { size: auto; margin: 15mm; }
+body { font-family: system-ui, sans-serif; font-size: 11pt; color: #000; margin: 0; }
+.page { break-after: page; }
+.page:last-child { break-after: auto; }
+header { display: flex; justify-content: space-between; align-items: baseline; border-bottom: 2px solid #000; margin-bottom: 4mm; }
+h1 { font-size: 16pt; margin: 0 0 2mm; }
+.meta { font-size: 9pt; }
+table { width: 100%; border-collapse: collapse; }
+th, td { text-align: left; padding: 1.5mm 2mm; border-bottom: 1px solid #999; }
+th { font-size: 9pt; text-transform: uppercase; }
+tr { break-inside: avoid; }
+.quantity, .expires { white-space: nowrap; width: 1%; }
+.empty { font-style: italic; }
+@media screen { body { max-width: 210mm; margin: 10mm auto; } .page { margin-bottom: 10mm; } }
+</style>
+</head>
+<body>
+{{- range .Pages}}
+<section class="page">
+<header>
+<h1>{{$.Title}}</h1>
+<span class="meta">{{with $.Location}}{{.}} · {{end}}Printed {{$.Printed}}{{if gt (len $.Pages) 1}} · Page {{.Number}} of {{len $.Pages}}{{end}}</span>
+</header>
+{{- if .Rows}}
+<table>
+<thead><tr><th>Name</th><th class="quantity">Quantity</th><th class="expires">Expires</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr><td>{{.Name}}</td><td class="quantity">{{.Quantity}}</td><td class="expires">{{.Expires}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p class="empty">This container is empty.</p>
+{{- end}}
+</section>
+{{- end}}
+<script>window.addEventListener("load", function () { window.print(); });</script>
+</body>
+</html>
+`))
+
+// containerPrintHTML renders the printable list of a container's objects
+func (ga *GioApp) containerPrintHTML(container Container, objects []Object, printedAt time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	err := containerPrintTemplate.Execute(&buf, struct {
+		Title    string
+		Location string
+		Printed  string
+		Pages    []containerPrintPage
+	}{
+		Title:    container.Name,
+		Location: container.Location,
+		Printed:  printedAt.Local().Format("Jan 2, 2006"),
+		Pages:    paginateContainerPrint(ga.containerPrintRows(objects)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// printContainerContents opens the container's list of objects in a
+// print-ready page; the desktop app saves it to Downloads instead
+func (ga *GioApp) printContainerContents(container Container, objects []Object) {
+	data, err := ga.containerPrintHTML(container, objects, time.Now())
+	if err == nil {
+		var path string
+		path, err = openPrintable(safeFilename(container.Name, "container")+"-contents.html", data)
+		if err == nil {
+			ga.logger.Info("Container contents ready to print", "container_id", container.ID, "objects", len(objects), "path", path)
+			return
+		}
+	}
+	ga.logger.Error("Failed to print container contents", "container_id", container.ID, "error", err)
+	ga.showAPIErrorDialog("Failed to print contents: " + err.Error())
+}
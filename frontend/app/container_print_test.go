@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContainerPrintRows(t *testing.T) {
+	ga := &GioApp{}
+	quantity := 2.0
+	soon := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	later := soon.AddDate(0, 1, 0)
+	rows := ga.containerPrintRows([]Object{
+		{Name: "peas"},
+		{Name: "Stock", Quantity: &quantity, Unit: "l", ExpiresAt: &later},
+		{Name: "Apples"},
+		{Name: "Chili", ExpiresAt: &soon},
+	})
+
+	var names []string
+	for _, r := range rows {
+		names = append(names, r.Name)
+	}
+	if got, want := strings.Join(names, ","), "Chili,Stock,Apples,peas"; got != want {
+		t.Errorf("order = %s, want %s (soonest to expire first, undated by name)", got, want)
+	}
+	if rows[1].Quantity != "2 l" || rows[1].Expires != "Apr 1, 2026" {
+		t.Errorf("row = %+v, want quantity 2 l expiring Apr 1, 2026", rows[1])
+	}
+	if rows[2].Quantity != "" || rows[2].Expires != "" {
+		t.Errorf("row without quantity or expiry = %+v, want blanks", rows[2])
+	}
+}
+
+func TestPaginateContainerPrint(t *testing.T) {
+	if pages := paginateContainerPrint(nil); len(pages) != 1 || len(pages[0].Rows) != 0 {
+		t.Errorf("empty container = %d pages, want one empty page", len(pages))
+	}
+
+	rows := make([]containerPrintRow, containerPrintPageSize*2+1)
+	pages := paginateContainerPrint(rows)
+	if len(pages) != 3 {
+		t.Fatalf("%d rows = %d pages, want 3", len(rows), len(pages))
+	}
+	if len(pages[0].Rows) != containerPrintPageSize || len(pages[2].Rows) != 1 || pages[2].Number != 3 {
+		t.Errorf("pages = %d, %d, %d rows, want full pages then the remainder", len(pages[0].Rows), len(pages[1].Rows), len(pages[2].Rows))
+	}
+}
+
+func TestContainerPrintHTML(t *testing.T) {
+	ga := &GioApp{}
+	objects := make([]Object, containerPrintPageSize+1)
+	for i := range objects {
+		objects[i] = Object{Name: fmt.Sprintf("Item %02d", i)}
+	}
+	objects[0].Name = "<b>Fish & chips</b>"
+
+	data, err := ga.containerPrintHTML(Container{Name: "Chest freezer", Location: "Garage"}, objects, time.Now())
+	if err != nil {
+		t.Fatalf("containerPrintHTML() error = %v", err)
+	}
+	page := string(data)
+	for _, want := range []string{"<title>Chest freezer</title>", "Garage", "Page 2 of 2", "&lt;b&gt;Fish &amp; chips&lt;/b&gt;", "@page", "window.print()"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("printed page is missing %q", want)
+		}
+	}
+	if strings.Count(page, `<section class="page">`) != 2 {
+		t.Errorf("printed page has %d sheets, want 2", strings.Count(page, `<section class="page">`))
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import "slices"
+
+// A collection opened in the tree layout fetches only its containers, which
+// carry their object counts. A container's objects are fetched the first
+// time it is shown expanded and merged into ga.objects, so a large
+// collection opens as fast as its container list. Expansion is kept per
+// route for the session (see view_state.go), so expanded containers are
+// fetched again when the collection is reopened. Anything that needs every
+// object, such as another layout, search or the stats panel, fetches the
+// rest.
+
+// resetTreeObjects starts over for a new fetch of the collection. With
+// partial set, ga.objects holds only the objects of the containers
+// loaded since.
+func (ga *GioApp) resetTreeObjects(partial bool) {
+	ga.treePartial = partial
+	ga.treeLoadedContainers = make(map[string]bool)
+	ga.treeLoadingContainers = make(map[string]bool)
+	ga.treeLoadingAll = false
+	ga.treeGeneration++
+}
+
+// treeNeedsAllObjects reports whether what is on screen needs the whole
+// collection rather than the expanded containers
+func (ga *GioApp) treeNeedsAllObjects() bool {
+	return ga.objectViewLayout != ObjectViewTree ||
+		ga.objectGroupByField != "" ||
+		ga.showStatsPanel ||
+		ga.widgetState.objectsSearchField.Text() != "" ||
+		len(ga.activeGroupedTextFilters) > 0
+}
+
+// treeContainerLoaded reports whether ga.objects holds the container's objects
+func (ga *GioApp) treeContainerLoaded(containerID string) bool {
+	return !ga.treePartial || ga.treeLoadedContainers[containerID]
+}
+
+// ensureContainerObjects fetches an expanded container's objects unless
+// they are loaded or on their way
+func (ga *GioApp) ensureContainerObjects(containerID string) {
+	if ga.treeContainerLoaded(containerID) || ga.treeLoadingContainers[containerID] ||
+		ga.selectedCollection == nil || ga.currentUser == nil {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	sort := ga.serverObjectSort()
+	generation := ga.treeGeneration
+	ga.treeLoadingContainers[containerID] = true
+
+	ga.goSafe(func() {
+		objects, err := ga.objectsClient.ListByContainer(userID, collectionID, containerID, sort)
+
+		ga.do(func() {
+			if generation != ga.treeGeneration {
+				return
+			}
+			delete(ga.treeLoadingContainers, containerID)
+			if err != nil {
+				ga.logger.Error("Failed to load container objects", "container_id", containerID, "error", err)
+				// Collapse it so expanding again retries
+				ga.treeExpandedNodes[containerID] = false
+				ga.showAPIErrorDialog("Could not load the container's objects: " + err.Error())
+				return
+			}
+			ga.objects = mergeContainerObjects(ga.objects, containerID, objects)
+			ga.treeLoadedContainers[containerID] = true
+			ga.invalidateObjectCaches()
+		})
+	})
+}
+
+// ensureAllObjects fetches the rest of a collection opened in the tree layout
+func (ga *GioApp) ensureAllObjects() {
+	if !ga.treePartial || ga.treeLoadingAll || ga.selectedCollection == nil || ga.currentUser == nil {
+		return
+	}
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	sort := ga.serverObjectSort()
+	generation := ga.treeGeneration
+	ga.treeLoadingAll = true
+
+	ga.goSafe(func() {
+		objects, err := ga.objectsClient.ListByCollection(userID, collectionID, sort)
+
+		ga.do(func() {
+			if generation != ga.treeGeneration {
+				return
+			}
+			ga.treeLoadingAll = false
+			if err != nil {
+				ga.logger.Error("Failed to load objects", "collection_id", collectionID, "error", err)
+				ga.showAPIErrorDialog("Failed to load collection data.\n\nObjects: " + err.Error())
+				// Fall back to the tree so the error is not retried every frame
+				ga.objectViewLayout = ObjectViewTree
+				return
+			}
+			ga.resetTreeObjects(false)
+			ga.objects = objects
+			ga.invalidateObjectCaches()
+		})
+	})
+}
+
+// mergeContainerObjects replaces the objects of containerID in objects with
+// fetched, keeping the rest in place
+func mergeContainerObjects(objects []Object, containerID string, fetched []Object) []Object {
+	objects = slices.DeleteFunc(objects, func(o Object) bool { return o.ContainerID == containerID })
+	for _, o := range fetched {
+		if o.ContainerID == "" {
+			o.ContainerID = containerID
+		}
+		objects = append(objects, o)
+	}
+	return objects
+}
@@ -0,0 +1,84 @@
+package app
+
+import "testing"
+
+func TestMergeContainerObjects(t *testing.T) {
+	objects := []Object{
+		{ID: "rice", ContainerID: "pantry"},
+		{ID: "milk", ContainerID: "fridge"},
+	}
+	fetched := []Object{
+		{ID: "rice", ContainerID: "pantry", Name: "Rice"},
+		{ID: "oats", Name: "Oats"},
+	}
+
+	got := mergeContainerObjects(objects, "pantry", fetched)
+	if len(got) != 3 {
+		t.Fatalf("got %d objects, want 3: %+v", len(got), got)
+	}
+	if got[0].ID != "milk" {
+		t.Errorf("other containers' objects should be kept, got %+v", got[0])
+	}
+	if got[1].Name != "Rice" {
+		t.Errorf("a fetched object should replace the loaded one, got %+v", got[1])
+	}
+	if got[2].ContainerID != "pantry" {
+		t.Errorf("fetched objects belong to the container, got %q", got[2].ContainerID)
+	}
+}
+
+func TestTreeLoadsOnlyExpandedContainers(t *testing.T) {
+	ga := newTestGioApp()
+	ga.objectViewLayout = ObjectViewTree
+	ga.resetTreeObjects(true)
+
+	if ga.treeContainerLoaded("pantry") {
+		t.Error("no container should be loaded after a lazy fetch")
+	}
+	if ga.treeNeedsAllObjects() {
+		t.Error("the plain tree should not need every object")
+	}
+	ga.showStatsPanel = true
+	if !ga.treeNeedsAllObjects() {
+		t.Error("the stats panel needs every object")
+	}
+	ga.showStatsPanel = false
+	ga.widgetState.objectsSearchField.SetText("rice")
+	if !ga.treeNeedsAllObjects() {
+		t.Error("searching needs every object")
+	}
+
+	generation := ga.treeGeneration
+	ga.resetTreeObjects(false)
+	if !ga.treeContainerLoaded("pantry") {
+		t.Error("every container is loaded after a full fetch")
+	}
+	if ga.treeGeneration == generation {
+		t.Error("a reset should drop fetches still in flight")
+	}
+}
+
+func TestRouteObjectLayout(t *testing.T) {
+	ga := newTestGioApp()
+	ga.viewStates = make(map[string]*viewState)
+	ga.collectionsLoaded = true
+	pantry := &Collection{ID: "col-1", Name: "Pantry"}
+
+	ga.currentView = ViewCollectionDetailGio
+	ga.selectedCollection = pantry
+	ga.syncViewState()
+	ga.objectViewLayout = ObjectViewTree
+	if got := ga.routeObjectLayout(); got != ObjectViewTree {
+		t.Errorf("layout on the current route = %q, want tree", got)
+	}
+
+	ga.currentView = ViewCollectionsGio
+	ga.selectedCollection = nil
+	ga.syncViewState()
+	ga.currentView = ViewCollectionDetailGio
+	ga.selectedCollection = pantry
+	// Not synced yet, as when the collection's fetch starts
+	if got := ga.routeObjectLayout(); got != ObjectViewTree {
+		t.Errorf("saved layout = %q, want tree", got)
+	}
+}
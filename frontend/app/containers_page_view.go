@@ -22,11 +22,15 @@ func (ga *GioApp) renderContainersPageView(gtx layout.Context) layout.Dimensions
 		return layout.Dimensions{}
 	}
 
-	// Handle back button
+	// Handle back button; on compact windows it first returns from the
+	// container's objects to the container list
 	if ga.widgetState.containersBackButton.Clicked(gtx) {
-		ga.currentView = ViewCollectionDetailGio
-		ga.selectedContainer = nil
-		return layout.Dimensions{}
+		if ga.sizeClass == sizeCompact && ga.selectedContainer != nil {
+			ga.selectedContainer = nil
+		} else {
+			ga.currentView = ViewCollectionDetailGio
+			return layout.Dimensions{}
+		}
 	}
 
 	// Handle create container button
@@ -36,6 +40,8 @@ func (ga *GioApp) renderContainersPageView(gtx layout.Context) layout.Dimensions
 		ga.selectedContainerID = nil
 		ga.widgetState.containerNameEditor.SetText("")
 		ga.widgetState.containerLocationEditor.SetText("")
+		ga.widgetState.containerHumidityEditor.SetText("")
+		ga.selectedTemperatureZone = ""
 		ga.selectedParentContainerID = nil
 	}
 
@@ -67,6 +73,13 @@ func (ga *GioApp) renderContainersPageView(gtx layout.Context) layout.Dimensions
 						Left:   unit.Dp(theme.Spacing4),
 						Right:  unit.Dp(theme.Spacing4),
 					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						// Compact windows show one pane at a time
+						if ga.sizeClass == sizeCompact {
+							if ga.selectedContainer == nil {
+								return ga.renderContainersPageList(gtx)
+							}
+							return ga.renderContainerDetailPanel(gtx)
+						}
 						return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
 							// Container list (left)
 							layout.Flexed(0.35, func(gtx layout.Context) layout.Dimensions {
@@ -215,6 +228,8 @@ func (ga *GioApp) renderContainersPageCard(gtx layout.Context, container Contain
 		ga.containerDialogMode = "edit"
 		ga.widgetState.containerNameEditor.SetText(container.Name)
 		ga.widgetState.containerLocationEditor.SetText(container.Location)
+		ga.widgetState.containerHumidityEditor.SetText(formatHumidity(container.Humidity))
+		ga.selectedTemperatureZone = container.TemperatureZone
 		if container.ParentContainerID != nil {
 			ga.selectedParentContainerID = container.ParentContainerID
 		} else {
@@ -253,7 +268,7 @@ func (ga *GioApp) renderContainersPageCard(gtx layout.Context, container Contain
 							return label.Layout(gtx)
 						}),
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							label := material.Body2(ga.theme.Theme, containerTypeLabels[container.Type])
+							label := material.Body2(ga.theme.Theme, containerBadgeText(container))
 							label.Color = theme.ColorTextSecondary
 							return label.Layout(gtx)
 						}),
@@ -305,25 +320,34 @@ func (ga *GioApp) renderContainerDetailPanel(gtx layout.Context) layout.Dimensio
 		}
 	}
 
+	if ga.widgetState.containerPrintButton.Clicked(gtx) {
+		ga.printContainerContents(*ga.selectedContainer, containerObjects)
+	}
+
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		// Container detail header
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						label := material.H6(ga.theme.Theme, ga.selectedContainer.Name)
-						label.Font.Weight = font.Bold
-						return label.Layout(gtx)
-					}),
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						info := containerTypeLabels[ga.selectedContainer.Type]
-						if ga.selectedContainer.Location != "" {
-							info += " - " + ga.selectedContainer.Location
-						}
-						label := material.Body2(ga.theme.Theme, info)
-						label.Color = theme.ColorTextSecondary
-						return label.Layout(gtx)
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								label := material.H6(ga.theme.Theme, ga.selectedContainer.Name)
+								label.Font.Weight = font.Bold
+								return label.Layout(gtx)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								info := containerBadgeText(*ga.selectedContainer)
+								if ga.selectedContainer.Location != "" {
+									info += " - " + ga.selectedContainer.Location
+								}
+								label := material.Body2(ga.theme.Theme, info)
+								label.Color = theme.ColorTextSecondary
+								return label.Layout(gtx)
+							}),
+						)
 					}),
+					layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.containerPrintButton, "Print contents")),
 				)
 			})
 		}),
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestApplyDashboard(t *testing.T) {
+	ga := newTestGioApp()
+	ga.dashboardStatsStale = true
+
+	ga.applyDashboard(&types.Dashboard{
+		User: types.AuthInfoResponse{
+			User:   types.User{ID: "u1", Name: "Ada"},
+			Claims: types.ClaimsInfo{Admin: true},
+		},
+		Groups:      []types.Group{{ID: "g1"}},
+		Collections: []types.Collection{{ID: "c1", LowStockCount: 2}, {ID: "c2", LowStockCount: 1}},
+		CollectionStats: []types.DashboardCollectionStats{
+			{CollectionID: "c1", LowStockCount: 2, ExpiringCount: 3},
+			{CollectionID: "c2", LowStockCount: 1, ExpiredCount: 1},
+		},
+		ExpiringDays:  7,
+		ExpiringCount: 3,
+		ExpiredCount:  1,
+	})
+
+	if ga.currentUser == nil || ga.currentUser.ID != "u1" || !ga.isAdmin {
+		t.Fatalf("currentUser = %v, isAdmin = %v, want admin u1", ga.currentUser, ga.isAdmin)
+	}
+	if len(ga.groups) != 1 || !ga.groupsLoaded {
+		t.Errorf("groups = %v, loaded %v, want g1 loaded", ga.groups, ga.groupsLoaded)
+	}
+	if len(ga.collections) != 2 || !ga.collectionsLoaded {
+		t.Errorf("collections = %v, loaded %v, want both loaded", ga.collections, ga.collectionsLoaded)
+	}
+	want := dashboardStats{lowStock: 3, expiring: 4, days: 7}
+	if ga.dashboardStats != want {
+		t.Errorf("dashboardStats = %+v, want %+v", ga.dashboardStats, want)
+	}
+	if ga.dashboardStatsStale {
+		t.Error("dashboardStatsStale still set after a dashboard was applied")
+	}
+}
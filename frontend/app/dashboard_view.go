@@ -1,22 +1,71 @@
 package app
 
 import (
+	"fmt"
 	"image/color"
 
 	"gioui.org/font"
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/unit"
-	"gioui.org/widget"
 	"gioui.org/widget/material"
 	"github.com/spf13/cast"
 
+	"github.com/nishiki/frontend/pkg/types"
 	"github.com/nishiki/frontend/ui/theme"
 	"github.com/nishiki/frontend/ui/widgets"
 )
 
+// dashboardStats are the counts on the dashboard's stat cards
+type dashboardStats struct {
+	lowStock int
+	// expiring counts the objects due within days, including those that
+	// have already expired
+	expiring int
+	days     int
+}
+
+func newDashboardStats(dashboard *types.Dashboard) dashboardStats {
+	stats := dashboardStats{
+		expiring: dashboard.ExpiringCount + dashboard.ExpiredCount,
+		days:     dashboard.ExpiringDays,
+	}
+	for _, collection := range dashboard.CollectionStats {
+		stats.lowStock += collection.LowStockCount
+	}
+	return stats
+}
+
+// refreshDashboardStats refetches the stat cards' counts after the
+// collections changed. The collection list itself is already up to date.
+func (ga *GioApp) refreshDashboardStats() {
+	if ga.currentUser == nil || ga.dashboardStatsLoading {
+		return
+	}
+	accountID := ga.currentUser.ID
+	session := ga.session
+	ga.dashboardStatsStale = false
+	ga.dashboardStatsLoading = true
+
+	ga.goSafe(func() {
+		dashboard, err := ga.accountsClient.Dashboard(accountID, customSort)
+		ga.doInSession(session, func() {
+			ga.dashboardStatsLoading = false
+			if err != nil {
+				ga.logger.Error("Failed to refresh dashboard stats", "error", err)
+				return
+			}
+			ga.dashboardStats = newDashboardStats(dashboard)
+		})
+	})
+}
+
 // renderDashboardView renders the dashboard with stats and navigation
 func (ga *GioApp) renderDashboardView(gtx layout.Context) layout.Dimensions {
+	if ga.dashboardStatsStale {
+		ga.refreshDashboardStats()
+	}
+
 	// Handle button clicks
 	if ga.widgetState.groupsButton.Clicked(gtx) {
 		ga.logger.Info("Navigating to groups view")
@@ -30,10 +79,28 @@ func (ga *GioApp) renderDashboardView(gtx layout.Context) layout.Dimensions {
 		ga.logger.Info("Navigating to profile view")
 		ga.currentView = ViewProfileGio
 	}
+	if ga.widgetState.mealsButton.Clicked(gtx) && ga.featureEnabled(featureMealPlanner) {
+		ga.logger.Info("Navigating to meal plan view")
+		ga.currentView = ViewMealPlanGio
+	}
 	if ga.widgetState.searchButton.Clicked(gtx) {
 		ga.logger.Info("Navigating to search view")
 		ga.currentView = ViewSearchGio
 	}
+	if ga.widgetState.valuationButton.Clicked(gtx) {
+		ga.logger.Info("Navigating to valuation view")
+		ga.valuationLoaded = false
+		ga.currentView = ViewValuationGio
+	}
+	if ga.widgetState.inboxButton.Clicked(gtx) {
+		ga.logger.Info("Navigating to inbox view")
+		ga.openInbox()
+	}
+	if ga.widgetState.adminButton.Clicked(gtx) && ga.isAdmin {
+		ga.logger.Info("Navigating to admin view")
+		ga.adminLoaded = false
+		ga.currentView = ViewAdminGio
+	}
 
 	// Main layout
 	return layout.Flex{
@@ -68,6 +135,9 @@ func (ga *GioApp) renderDashboardView(gtx layout.Context) layout.Dimensions {
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						return ga.renderStats(gtx)
 					}),
+
+					// Staples that have come due
+					layout.Rigid(ga.renderDueStaples),
 				)
 			})
 		}),
@@ -99,6 +169,14 @@ func (ga *GioApp) renderHeader(gtx layout.Context, title string) layout.Dimensio
 				return label.Layout(gtx)
 			}),
 
+			// Profile switcher (if several profiles are configured)
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if !ga.hasProfiles() {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Right: unit.Dp(theme.Spacing3)}.Layout(gtx, ga.renderProfileSwitcher)
+			}),
+
 			// Username (if available)
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				if ga.currentUser != nil {
@@ -128,13 +206,40 @@ func (ga *GioApp) renderNavigationButtons(gtx layout.Context) layout.Dimensions
 				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.collectionsButton, "Collections")(gtx)
 			})
 		}),
+		// The meal planner can be rolled out to some accounts only
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !ga.featureEnabled(featureMealPlanner) {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.mealsButton, "Meal Plan")(gtx)
+			})
+		}),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.profileButton, "Profile")(gtx)
 			})
 		}),
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.searchButton, "Search")(gtx)
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.searchButton, "Search")(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.valuationButton, "Valuation")(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.inboxButton, "Inbox")(gtx)
+		}),
+		// Only members of the backend's admin group see the admin view
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !ga.isAdmin {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing3)}.Layout(gtx,
+				widgets.AccentButton(ga.theme.Theme, &ga.widgetState.adminButton, "Admin"))
 		}),
 	)
 }
@@ -169,10 +274,28 @@ func (ga *GioApp) renderStats(gtx layout.Context) layout.Dimensions {
 
 				// Collections stat
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{Left: unit.Dp(theme.Spacing2), Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 						return ga.renderStatCard(gtx, cast.ToString(len(ga.collections)), "Collections", theme.ColorAccent, theme.ColorBlack)
 					})
 				}),
+
+				// Low stock stat
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{Left: unit.Dp(theme.Spacing2), Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderStatCard(gtx, cast.ToString(ga.dashboardStats.lowStock), "Low Stock", theme.ColorWarning, theme.ColorWhite)
+					})
+				}),
+
+				// Expiring stat
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					label := "Expiring"
+					if ga.dashboardStats.days > 0 {
+						label = fmt.Sprintf("Expiring in %dd", ga.dashboardStats.days)
+					}
+					return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderStatCard(gtx, cast.ToString(ga.dashboardStats.expiring), label, theme.ColorDanger, theme.ColorWhite)
+					})
+				}),
 			)
 		}),
 	)
@@ -208,27 +331,15 @@ func (ga *GioApp) renderStatCard(gtx layout.Context, value, label string, bgColo
 }
 
 // renderBottomMenu renders the bottom navigation menu and handles its click events.
-// activeView identifies the current view so its tab is highlighted.
+// activeView identifies the current view so its tab is highlighted. On wide
+// windows the sidebar takes over navigation and nothing is rendered.
 func (ga *GioApp) renderBottomMenu(gtx layout.Context, activeView ViewID) layout.Dimensions {
-	// Menu items: clickable, label, target view
-	type menuItem struct {
-		btn    *widget.Clickable
-		label  string
-		target ViewID
-	}
-	items := []menuItem{
-		{&ga.widgetState.menuDashboard, "Home", ViewDashboardGio},
-		{&ga.widgetState.menuGroups, "Groups", ViewGroupsGio},
-		{&ga.widgetState.menuCollections, "Collections", ViewCollectionsGio},
-		{&ga.widgetState.menuProfile, "Profile", ViewProfileGio},
+	if ga.showsSideNav() {
+		return layout.Dimensions{}
 	}
 
-	// Handle clicks — navigate to target view if not already active
-	for _, item := range items {
-		if item.btn.Clicked(gtx) && item.target != activeView {
-			ga.currentView = item.target
-		}
-	}
+	items := ga.navItems()
+	ga.handleNavClicks(gtx, items, activeView, layout.Horizontal)
 
 	return layout.Inset{
 		Top:    unit.Dp(theme.Spacing2),
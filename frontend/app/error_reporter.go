@@ -0,0 +1,268 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nishiki/frontend/config"
+	apiCommon "github.com/nishiki/frontend/pkg/api/common"
+)
+
+const (
+	// maxErrorReports caps the reports one session sends, so a crash loop
+	// cannot flood the backend.
+	maxErrorReports = 20
+	// maxReportMessage and maxReportStack keep reports under the backend's
+	// limits.
+	maxReportMessage = 2 << 10
+	maxReportStack   = 32 << 10
+	// maxPanicStreak is how many frames in a row may panic before the app
+	// gives up recovering and crashes as it would have without recovery.
+	maxPanicStreak = 3
+)
+
+// clientErrorReport is the body of POST /client-errors.
+type clientErrorReport struct {
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	Stack      string `json:"stack,omitempty"`
+	View       string `json:"view,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	AppVersion string `json:"app_version,omitempty"`
+	Method     string `json:"method,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	Status     int    `json:"status,omitempty"`
+}
+
+// errorReporter posts recovered panics and failed API calls to the backend
+// when error reporting is turned on. All methods are safe on a nil
+// reporter, which drops everything.
+type errorReporter struct {
+	url        string
+	httpClient *http.Client
+	tokens     apiCommon.TokenFetcher
+	userAgent  string
+	appVersion string
+	logger     *slog.Logger
+
+	view atomic.Int32 // ViewID on screen, kept current by the event loop
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// newErrorReporter returns nil unless the config opts in to error reporting.
+func newErrorReporter(cfg *config.Config, tokens apiCommon.TokenFetcher, logger *slog.Logger) *errorReporter {
+	if !cfg.ErrorReporting {
+		return nil
+	}
+	return &errorReporter{
+		url:        cfg.APIURL() + "/client-errors",
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		tokens:     tokens,
+		userAgent:  userAgent(),
+		appVersion: appVersion(),
+		logger:     logger,
+		seen:       make(map[string]bool),
+	}
+}
+
+func (r *errorReporter) setView(view ViewID) {
+	if r != nil {
+		r.view.Store(int32(view))
+	}
+}
+
+// reportPanic sends a recovered panic with the stack it was raised from.
+func (r *errorReporter) reportPanic(value any, stack []byte) {
+	r.report(clientErrorReport{
+		Kind:    "panic",
+		Message: fmt.Sprint(value),
+		Stack:   panicStack(stack),
+	})
+}
+
+// reportRequestError matches apiCommon.Client.OnRequestError.
+func (r *errorReporter) reportRequestError(method, endpoint string, status int, err error) {
+	// Query strings can carry search terms; the path is enough to group on
+	endpoint, _, _ = strings.Cut(endpoint, "?")
+	r.report(clientErrorReport{
+		Kind:     "api",
+		Message:  err.Error(),
+		Method:   method,
+		Endpoint: endpoint,
+		Status:   status,
+	})
+}
+
+// report adds the context and sends the report in the background. Each
+// distinct error is sent once per session, and at most maxErrorReports in
+// all.
+func (r *errorReporter) report(rep clientErrorReport) {
+	if r == nil {
+		return
+	}
+	rep.Message = truncate(rep.Message, maxReportMessage)
+	rep.Stack = truncate(rep.Stack, maxReportStack)
+	rep.View = ViewID(r.view.Load()).String()
+	rep.UserAgent = truncate(r.userAgent, 512)
+	rep.AppVersion = r.appVersion
+
+	key := rep.Kind + "\x00" + rep.Message + "\x00" + rep.Method + " " + rep.Endpoint
+	r.mu.Lock()
+	if r.seen[key] || len(r.seen) >= maxErrorReports {
+		r.mu.Unlock()
+		return
+	}
+	r.seen[key] = true
+	r.mu.Unlock()
+
+	go r.send(rep)
+}
+
+func (r *errorReporter) send(rep clientErrorReport) {
+	body, err := json.Marshal(rep)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Attribute the report to the user when signed in, without the refresh
+	// or sign-out an API call would trigger on a stale token
+	if r.tokens != nil && r.tokens.IsTokenValid() {
+		if token, err := r.tokens.GetAccessToken(); err == nil {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Debug("Failed to send error report", "error", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		r.logger.Debug("Error report rejected", "status", resp.StatusCode)
+	}
+}
+
+// panicStack drops the goroutine header and the recovery frames above the
+// panic from a debug.Stack trace, so it starts at the function that
+// panicked. The backend groups reports on that first frame.
+func panicStack(stack []byte) string {
+	s := string(stack)
+	if i := strings.Index(s, "\npanic("); i >= 0 {
+		// Skip the panic( line and its file:line line
+		rest := s[i+1:]
+		for range 2 {
+			_, rest, _ = strings.Cut(rest, "\n")
+		}
+		return rest
+	}
+	if header, rest, ok := strings.Cut(s, "\n"); ok && strings.HasPrefix(header, "goroutine ") {
+		return rest
+	}
+	return s
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+// appVersion names the build by the VCS revision it was built from, falling
+// back to the main module's version.
+func appVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	var revision string
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision == "" {
+		return info.Main.Version
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return revision
+}
+
+// goSafe runs fn on a new goroutine. A panic in it is logged, reported and
+// shown in the error dialog instead of taking the whole app down.
+func (ga *GioApp) goSafe(fn func()) {
+	go func() {
+		defer ga.recoverGoroutine()
+		fn()
+	}()
+}
+
+func (ga *GioApp) recoverGoroutine() {
+	if value := recover(); value != nil {
+		ga.handlePanic(value, debug.Stack())
+		ga.do(func() {
+			ga.showAPIErrorDialog(ga.panicMessage())
+		})
+	}
+}
+
+// recoverFrame is deferred around each frame. A panic while updating or
+// drawing leaves the view in an unknown state, so the app goes back to the
+// dashboard (or the login screen) and explains what happened.
+func (ga *GioApp) recoverFrame() {
+	value := recover()
+	if value == nil {
+		ga.panicStreak = 0
+		return
+	}
+	ga.panicStreak++
+	if ga.panicStreak > maxPanicStreak {
+		panic(value)
+	}
+	ga.handlePanic(value, debug.Stack())
+
+	if ga.isSignedIn {
+		ga.currentView = ViewDashboardGio
+	} else {
+		ga.currentView = ViewLoginGio
+	}
+	ga.showAPIErrorDialog(ga.panicMessage())
+	ga.window.Invalidate()
+}
+
+func (ga *GioApp) handlePanic(value any, stack []byte) {
+	ga.logger.Error("Recovered from panic", "panic", fmt.Sprint(value), "stack", string(stack))
+	ga.errorReporter.reportPanic(value, stack)
+}
+
+func (ga *GioApp) panicMessage() string {
+	if ga.errorReporter != nil {
+		return "Something went wrong. The error has been reported."
+	}
+	return "Something went wrong. The details were logged."
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nishiki/frontend/config"
+)
+
+// staticTokens is a TokenFetcher with a fixed access token.
+type staticTokens struct{ token string }
+
+func (s staticTokens) GetAccessToken() (string, error) { return s.token, nil }
+func (s staticTokens) IsTokenValid() bool              { return s.token != "" }
+
+func TestNewErrorReporterDisabledByDefault(t *testing.T) {
+	r := newErrorReporter(&config.Config{BackendURL: "http://backend"}, nil, slog.New(slog.DiscardHandler))
+	if r != nil {
+		t.Fatal("expected no reporter unless error_reporting is set")
+	}
+	// A nil reporter drops reports
+	r.setView(ViewDashboardGio)
+	r.reportPanic("boom", nil)
+}
+
+func TestErrorReporterSendsReports(t *testing.T) {
+	var mu sync.Mutex
+	var reports []clientErrorReport
+	var auth []string
+	received := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/client-errors" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var rep clientErrorReport
+		if err := json.UnmarshalRead(r.Body, &rep); err != nil {
+			t.Errorf("decoding report: %v", err)
+		}
+		mu.Lock()
+		reports = append(reports, rep)
+		auth = append(auth, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BackendURL: server.URL + "/", ErrorReporting: true}
+	r := newErrorReporter(cfg, staticTokens{token: "abc"}, slog.New(slog.DiscardHandler))
+	r.setView(ViewCollectionDetailGio)
+
+	r.reportRequestError(http.MethodGet, "/accounts/u1/collections?search=milk", 502, errors.New("server error: 502 Bad Gateway"))
+	// The same failure again is not sent twice
+	r.reportRequestError(http.MethodGet, "/accounts/u1/collections?search=eggs", 502, errors.New("server error: 502 Bad Gateway"))
+	r.reportPanic("index out of range", []byte("goroutine 7 [running]:\nruntime/debug.Stack()\n\tstack.go:26\npanic({0x1, 0x2})\n\tpanic.go:785\napp.(*GioApp).render()\n\tgio_app.go:10\n"))
+
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for reports")
+		}
+	}
+	select {
+	case <-received:
+		t.Fatal("duplicate report was sent")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var api, crash clientErrorReport
+	for _, rep := range reports {
+		switch rep.Kind {
+		case "api":
+			api = rep
+		case "panic":
+			crash = rep
+		}
+	}
+	if api.Endpoint != "/accounts/u1/collections" || api.Status != 502 || api.Method != http.MethodGet {
+		t.Errorf("unexpected api report %+v", api)
+	}
+	if api.View != "collection_detail" || api.UserAgent == "" || api.AppVersion == "" {
+		t.Errorf("api report is missing context: %+v", api)
+	}
+	if !strings.HasPrefix(crash.Stack, "app.(*GioApp).render()") {
+		t.Errorf("expected the stack to start at the panicking frame, got %q", crash.Stack)
+	}
+	for _, header := range auth {
+		if header != "Bearer abc" {
+			t.Errorf("expected the report to carry the token, got %q", header)
+		}
+	}
+}
+
+func TestErrorReporterCapsReports(t *testing.T) {
+	r := newErrorReporter(&config.Config{BackendURL: "http://127.0.0.1:0", ErrorReporting: true}, nil, slog.New(slog.DiscardHandler))
+	r.httpClient.Timeout = time.Millisecond
+	for i := range maxErrorReports + 5 {
+		r.reportPanic(strings.Repeat("x", i+1), nil)
+	}
+	if len(r.seen) != maxErrorReports {
+		t.Errorf("expected %d reports, got %d", maxErrorReports, len(r.seen))
+	}
+}
+
+func TestPanicStackWithoutPanicFrame(t *testing.T) {
+	got := panicStack([]byte("goroutine 1 [running]:\nmain.main()\n"))
+	if got != "main.main()\n" {
+		t.Errorf("expected the goroutine header to be dropped, got %q", got)
+	}
+}
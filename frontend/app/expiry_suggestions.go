@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// expiryDateLayout is how the create object dialog takes an expiry date
+const expiryDateLayout = "2006-01-02"
+
+// expiryCategory is the category the API files an object's expiry under:
+// its category property, else its first tag, lowercased
+func expiryCategory(props map[string]any, tags []string) string {
+	if category, ok := props["category"].(string); ok && strings.TrimSpace(category) != "" {
+		return strings.ToLower(strings.TrimSpace(category))
+	}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			return strings.ToLower(tag)
+		}
+	}
+	return ""
+}
+
+// matchExpirySuggestion finds the suggestion for a category
+func matchExpirySuggestion(suggestions []types.ExpirySuggestion, category string) (types.ExpirySuggestion, bool) {
+	if category == "" {
+		return types.ExpirySuggestion{}, false
+	}
+	for _, s := range suggestions {
+		if s.Category == category {
+			return s, true
+		}
+	}
+	return types.ExpirySuggestion{}, false
+}
+
+// parseExpiryDate reads the dialog's expiry field, nil when it is empty. The
+// date is taken at local noon so the API, which counts the days from now
+// rounded, learns the calendar days whatever time the object is created.
+func parseExpiryDate(text string) (*time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	day, err := time.ParseInLocation(expiryDateLayout, text, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("expires must be a date like %s", time.Now().Format(expiryDateLayout))
+	}
+	noon := day.Add(12 * time.Hour)
+	return &noon, nil
+}
+
+// ensureExpirySuggestionsLoaded fetches the account's expiry suggestions
+// unless they are already loaded or on their way. A failure only costs the
+// suggestion, so it is logged and not retried until the next object is
+// created with an expiry.
+func (ga *GioApp) ensureExpirySuggestionsLoaded() {
+	if ga.currentUser == nil || ga.expirySuggestionsLoaded || ga.expirySuggestionsLoading {
+		return
+	}
+	ga.expirySuggestionsLoading = true
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		resp, err := ga.objectsClient.ExpirySuggestions(accountID)
+
+		ga.doInSession(session, func() {
+			ga.expirySuggestionsLoading = false
+			ga.expirySuggestionsLoaded = true
+			if err != nil {
+				ga.logger.Error("Failed to load expiry suggestions", "error", err)
+				return
+			}
+			ga.expirySuggestions = resp.Suggestions
+		})
+	})
+}
+
+func (ga *GioApp) resetExpirySuggestions() {
+	ga.expirySuggestions = nil
+	ga.expirySuggestionsLoaded = false
+}
+
+// renderObjectExpiryField is the create dialog's expiry date, with the
+// usual shelf life of the object's category offered while it is empty
+func (ga *GioApp) renderObjectExpiryField(gtx layout.Context) layout.Dimensions {
+	if ga.objectDialogMode != "create" {
+		return layout.Dimensions{}
+	}
+
+	suggestion, ok := matchExpirySuggestion(ga.expirySuggestions, expiryCategory(ga.collectObjectProperties(), nil))
+	suggested := time.Now().AddDate(0, 0, suggestion.Days).Format(expiryDateLayout)
+	if ok && ga.widgetState.objectUseExpiry.Clicked(gtx) {
+		ga.widgetState.objectExpiresEditor.SetText(suggested)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFormField(gtx, "Expires", &ga.widgetState.objectExpiresEditor, "YYYY-MM-DD (optional)")
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !ok || strings.TrimSpace(ga.widgetState.objectExpiresEditor.Text()) != "" {
+				return layout.Dimensions{}
+			}
+			label := fmt.Sprintf("Your %s usually keeps %s", suggestion.Category, plural(suggestion.Days, "day"))
+			return ga.renderChipSelector(gtx, label, []layout.Widget{func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFilterChip(gtx, &ga.widgetState.objectUseExpiry, "Use "+suggested, false)
+			}})
+		}),
+	)
+}
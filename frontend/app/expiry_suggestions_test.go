@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestExpiryCategory(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]any
+		tags  []string
+		want  string
+	}{
+		{"category property", map[string]any{"category": " Dairy "}, []string{"milk"}, "dairy"},
+		{"first tag", map[string]any{}, []string{"", "Frozen"}, "frozen"},
+		{"blank category falls back to tags", map[string]any{"category": " "}, []string{"bread"}, "bread"},
+		{"neither", map[string]any{"brand": "Acme"}, nil, ""},
+	}
+	for _, tt := range tests {
+		if got := expiryCategory(tt.props, tt.tags); got != tt.want {
+			t.Errorf("%s: expiryCategory = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchExpirySuggestion(t *testing.T) {
+	suggestions := []types.ExpirySuggestion{
+		{Category: "dairy", Days: 7, Samples: 3},
+		{Category: "frozen", Days: 90, Samples: 1},
+	}
+	if s, ok := matchExpirySuggestion(suggestions, "frozen"); !ok || s.Days != 90 {
+		t.Errorf("frozen = %+v, %v", s, ok)
+	}
+	if _, ok := matchExpirySuggestion(suggestions, "bread"); ok {
+		t.Error("unknown category matched")
+	}
+	if _, ok := matchExpirySuggestion(suggestions, ""); ok {
+		t.Error("empty category matched")
+	}
+}
+
+func TestParseExpiryDate(t *testing.T) {
+	got, err := parseExpiryDate(" 2026-10-22 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 10, 22, 12, 0, 0, 0, time.Local)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("parseExpiryDate = %v, want %v", got, want)
+	}
+
+	if got, err := parseExpiryDate(""); got != nil || err != nil {
+		t.Errorf("empty field = %v, %v; want no date", got, err)
+	}
+	if _, err := parseExpiryDate("22/10/2026"); err == nil {
+		t.Error("expected an error for a non-ISO date")
+	}
+}
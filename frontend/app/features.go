@@ -0,0 +1,33 @@
+package app
+
+// Feature names, as the backend's /features lists them
+const featureMealPlanner = "meal_planner"
+
+// fetchFeatures loads which features are on for the signed-in user
+func (ga *GioApp) fetchFeatures() {
+	session := ga.session
+	ga.goSafe(func() {
+		features, err := ga.featuresClient.Get()
+		if err != nil {
+			ga.logger.Error("Failed to fetch features", "error", err)
+			return
+		}
+		ga.doInSession(session, func() {
+			ga.features = features.Features
+			ga.logger.Info("Features loaded", "features", len(features.Features))
+		})
+	})
+}
+
+// featureEnabled reports whether a feature is on for the signed-in user.
+// Until /features has answered, and for features it does not list (an older
+// backend), everything is on: the backend still turns away what is off.
+func (ga *GioApp) featureEnabled(name string) bool {
+	enabled, ok := ga.features[name]
+	return !ok || enabled
+}
+
+// resetFeatures forgets the user's features when the session ends
+func (ga *GioApp) resetFeatures() {
+	ga.features = nil
+}
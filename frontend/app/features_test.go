@@ -0,0 +1,32 @@
+package app
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFeatureEnabled(t *testing.T) {
+	ga := newTestGioApp()
+	if !ga.featureEnabled(featureMealPlanner) {
+		t.Error("features not loaded yet should read as on")
+	}
+
+	ga.features = map[string]bool{featureMealPlanner: false, "labels": true}
+	if ga.featureEnabled(featureMealPlanner) {
+		t.Error("meal planner switched off by the backend still reads as on")
+	}
+	if !ga.featureEnabled("labels") || !ga.featureEnabled("unlisted") {
+		t.Error("listed and unlisted features should read as on")
+	}
+
+	hasMeals := func() bool {
+		return slices.ContainsFunc(ga.navItems(), func(item navItem) bool { return item.target == ViewMealPlanGio })
+	}
+	if hasMeals() {
+		t.Error("navigation offers the meal planner while it is off")
+	}
+	ga.resetFeatures()
+	if !hasMeals() {
+		t.Error("navigation hides the meal planner after the session reset")
+	}
+}
@@ -0,0 +1,530 @@
+package app
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gioui.org/f32"
+	"gioui.org/font"
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	collectionsAPI "github.com/nishiki/frontend/pkg/api/collections"
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// Pins are drawn floorPlanPinRadius across from their centre; a press within
+// floorPlanPinHitRadius picks one up, so they are easy to grab on a phone.
+// A pin has to travel floorPlanDragSlop before a press counts as a move
+// rather than a tap that opens the container.
+const (
+	floorPlanPinRadius    = 9
+	floorPlanPinHitRadius = 20
+	floorPlanDragSlop     = 6
+)
+
+// floorPlanPin is a placed container's pin on the rendered map
+type floorPlanPin struct {
+	ContainerID string
+	Name        string
+	At          f32.Point // pin centre, in map coordinates
+	Highlighted bool      // holds an object matching the search
+}
+
+// fitFloorPlan returns where an image of imgSize lands when scaled to fit
+// inside area, keeping its aspect ratio, centred
+func fitFloorPlan(imgSize, area image.Point) image.Rectangle {
+	if imgSize.X <= 0 || imgSize.Y <= 0 || area.X <= 0 || area.Y <= 0 {
+		return image.Rectangle{}
+	}
+	w, h := area.X, imgSize.Y*area.X/imgSize.X
+	if h > area.Y {
+		w, h = imgSize.X*area.Y/imgSize.Y, area.Y
+	}
+	origin := image.Point{X: (area.X - w) / 2, Y: (area.Y - h) / 2}
+	return image.Rectangle{Min: origin, Max: origin.Add(image.Point{X: w, Y: h})}
+}
+
+// floorPlanPoint turns a placement's fractions of the plan into a point on
+// the rendered map
+func floorPlanPoint(rect image.Rectangle, x, y float64) f32.Point {
+	return f32.Point{
+		X: float32(rect.Min.X) + float32(x)*float32(rect.Dx()),
+		Y: float32(rect.Min.Y) + float32(y)*float32(rect.Dy()),
+	}
+}
+
+// floorPlanFraction is the inverse of floorPlanPoint, clamped to the plan so
+// a pin dropped past its edge stays on it
+func floorPlanFraction(rect image.Rectangle, p f32.Point) (float64, float64) {
+	if rect.Empty() {
+		return 0, 0
+	}
+	x := float64(p.X-float32(rect.Min.X)) / float64(rect.Dx())
+	y := float64(p.Y-float32(rect.Min.Y)) / float64(rect.Dy())
+	return min(max(x, 0), 1), min(max(y, 0), 1)
+}
+
+// floorPlanPinAt returns the index of the pin under p, or -1. Pins drawn
+// later sit on top, so they win where pins overlap.
+func floorPlanPinAt(pins []floorPlanPin, p f32.Point, radius float32) int {
+	for i := len(pins) - 1; i >= 0; i-- {
+		d := pins[i].At.Sub(p)
+		if d.X*d.X+d.Y*d.Y <= radius*radius {
+			return i
+		}
+	}
+	return -1
+}
+
+// containersHoldingObjects returns the IDs of the containers the objects
+// are in
+func containersHoldingObjects(objects []Object) map[string]bool {
+	ids := make(map[string]bool)
+	for _, o := range objects {
+		if o.ContainerID != "" {
+			ids[o.ContainerID] = true
+		}
+	}
+	return ids
+}
+
+// isPlaced reports whether a container has a position on the floor plan
+func isPlaced(c Container) bool {
+	return c.Placement != nil && c.Placement.X != nil && c.Placement.Y != nil
+}
+
+// floorPlanPins lays out a pin for each placed container. While the objects
+// search has text, the containers holding its matches are highlighted.
+func (ga *GioApp) floorPlanPins(rect image.Rectangle) []floorPlanPin {
+	var matches map[string]bool
+	if strings.TrimSpace(ga.widgetState.objectsSearchField.Text()) != "" {
+		filtered, _ := ga.getFilteredObjects()
+		matches = containersHoldingObjects(filtered)
+	}
+	var pins, highlighted []floorPlanPin
+	for _, c := range ga.containers {
+		if !isPlaced(c) {
+			continue
+		}
+		pin := floorPlanPin{
+			ContainerID: c.ID,
+			Name:        c.Name,
+			At:          floorPlanPoint(rect, *c.Placement.X, *c.Placement.Y),
+			Highlighted: matches[c.ID],
+		}
+		if c.ID == ga.floorPlanDragID {
+			pin.At = ga.floorPlanDragPos
+		}
+		if pin.Highlighted {
+			highlighted = append(highlighted, pin)
+		} else {
+			pins = append(pins, pin)
+		}
+	}
+	// Highlighted pins go last so they are drawn over the rest
+	return append(pins, highlighted...)
+}
+
+// resetFloorPlanMap drops an unfinished drag and upload error when leaving
+// the collection
+func (ga *GioApp) resetFloorPlanMap() {
+	ga.floorPlanDragID = ""
+	ga.floorPlanErr = ""
+}
+
+// setContainerPlacement updates a loaded container's placement
+func (ga *GioApp) setContainerPlacement(containerID string, placement *types.ContainerPlacement) {
+	for i := range ga.containers {
+		if ga.containers[i].ID == containerID {
+			ga.containers[i].Placement = placement
+			return
+		}
+	}
+}
+
+// placeContainerOnPlan moves a container's pin to x, y (fractions of the
+// plan), keeping its floor and room. The pin moves straight away.
+func (ga *GioApp) placeContainerOnPlan(containerID string, x, y float64) {
+	if ga.currentUser == nil || ga.selectedCollection == nil {
+		return
+	}
+	var before *types.ContainerPlacement
+	found := false
+	for _, c := range ga.containers {
+		if c.ID == containerID {
+			before, found = c.Placement, true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	accountID := ga.currentUser.ID
+	collectionID := ga.selectedCollection.ID
+	placement := &types.ContainerPlacement{X: &x, Y: &y}
+	if before != nil {
+		placement.Floor, placement.Room = before.Floor, before.Room
+	}
+
+	ga.optimisticUpdate("move container",
+		func() { ga.setContainerPlacement(containerID, placement) },
+		func() { ga.setContainerPlacement(containerID, before) },
+		func() (func(), error) {
+			saved, err := ga.containersClient.UpdatePlacements(accountID, collectionID, types.UpdateContainerPlacementsRequest{
+				Placements: []types.ContainerPlacementRequest{{
+					ContainerID: containerID,
+					X:           &x,
+					Y:           &y,
+					Floor:       placement.Floor,
+					Room:        placement.Room,
+				}},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return func() {
+				for _, c := range saved {
+					ga.setContainerPlacement(c.ID, c.Placement)
+				}
+			}, nil
+		})
+}
+
+// openContainerFromMap shows a container's contents on the containers page
+func (ga *GioApp) openContainerFromMap(containerID string) {
+	for _, c := range ga.containers {
+		if c.ID == containerID {
+			container := c
+			ga.selectedContainer = &container
+			ga.currentView = ViewContainersGio
+			return
+		}
+	}
+}
+
+// pickFloorPlan opens the image picker, or on desktop reads the path typed
+// above the map, and uploads the image as the collection's floor plan
+func (ga *GioApp) pickFloorPlan() {
+	if ga.floorPlanUploading || ga.currentUser == nil || ga.selectedCollection == nil {
+		return
+	}
+	accountID, collectionID := ga.currentUser.ID, ga.selectedCollection.ID
+	ga.floorPlanErr = ""
+	if !photoCaptureSupported() {
+		path := strings.TrimSpace(ga.widgetState.floorPlanPathEditor.Text())
+		if path == "" {
+			ga.floorPlanErr = "Enter the path of the floor plan image"
+			return
+		}
+		ga.goSafe(func() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				ga.do(func() { ga.floorPlanErr = "Could not read the floor plan: " + err.Error() })
+				return
+			}
+			ga.uploadFloorPlan(accountID, collectionID, filepath.Base(path), data)
+		})
+		return
+	}
+	capturePhoto(func(filename string, data []byte) {
+		ga.goSafe(func() { ga.uploadFloorPlan(accountID, collectionID, filename, data) })
+	}, func(err error) {
+		ga.goSafe(func() { ga.do(func() { ga.floorPlanErr = err.Error() }) })
+	})
+}
+
+// uploadFloorPlan shrinks a picked image like a photo and sends it. Call it
+// off the UI goroutine.
+func (ga *GioApp) uploadFloorPlan(accountID, collectionID, filename string, data []byte) {
+	ga.do(func() { ga.floorPlanUploading = true })
+
+	plan, err := func() (*types.FloorPlan, error) {
+		photo, err := compressPhoto(filename, data)
+		if err != nil {
+			return nil, err
+		}
+		return ga.collectionsClient.UploadFloorPlan(accountID, collectionID, collectionsAPI.FloorPlanImage{
+			Filename:    photo.Filename,
+			ContentType: photo.ContentType,
+			Data:        photo.Data,
+		})
+	}()
+
+	ga.do(func() {
+		ga.floorPlanUploading = false
+		if err != nil {
+			ga.logger.Error("Failed to upload floor plan", "collection_id", collectionID, "error", err)
+			ga.floorPlanErr = "Upload failed: " + err.Error()
+			return
+		}
+		ga.logger.Info("Uploaded floor plan", "collection_id", collectionID, "size", plan.Size)
+		if ga.selectedCollection != nil && ga.selectedCollection.ID == collectionID {
+			ga.selectedCollection.FloorPlan = plan
+			ga.setCollection(*ga.selectedCollection)
+		}
+	})
+}
+
+// renderFloorPlanPanel renders the map view: the collection's floor plan
+// with a draggable pin per placed container, and the unplaced containers
+// below it to drop onto the plan
+func (ga *GioApp) renderFloorPlanPanel(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.floorPlanUploadBtn.Clicked(gtx) {
+		ga.pickFloorPlan()
+	}
+	plan := ga.selectedCollection.FloorPlan
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		// Header
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := "Upload floor plan"
+				if plan != nil {
+					label = "Replace floor plan"
+				}
+				if ga.floorPlanUploading {
+					label = "Uploading..."
+				}
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						title := material.H6(ga.theme.Theme, "Map")
+						title.Font.Weight = font.Bold
+						return title.Layout(gtx)
+					}),
+					layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.floorPlanUploadBtn, label)),
+				)
+			})
+		}),
+
+		// Desktop builds take a file path
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if photoCaptureSupported() {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				editor := material.Editor(ga.theme.Theme, &ga.widgetState.floorPlanPathEditor, "Path to a floor plan image")
+				editor.Color = theme.ColorTextPrimary
+				return editor.Layout(gtx)
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if ga.floorPlanErr == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(ga.theme.Theme, ga.floorPlanErr)
+				label.Color = theme.ColorDanger
+				return label.Layout(gtx)
+			})
+		}),
+
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			if plan == nil {
+				return ga.renderFloorPlanMessage(gtx, "No floor plan yet. Upload a picture or drawing of the space to place containers on it.")
+			}
+			img, err := ga.getImageStatus(plan.URL)
+			switch {
+			case img != nil:
+				return ga.renderFloorPlanMap(gtx, img)
+			case err != nil:
+				return ga.renderFloorPlanMessage(gtx, "Could not load the floor plan: "+err.Error())
+			default:
+				return widgets.SkeletonList(skeletonRows)(gtx)
+			}
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if plan == nil {
+				return layout.Dimensions{}
+			}
+			return ga.renderUnplacedContainers(gtx)
+		}),
+	)
+}
+
+func (ga *GioApp) renderFloorPlanMessage(gtx layout.Context, msg string) layout.Dimensions {
+	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body1(ga.theme.Theme, msg)
+		label.Color = theme.ColorTextSecondary
+		label.Alignment = text.Middle
+		return label.Layout(gtx)
+	})
+}
+
+// renderFloorPlanMap draws the plan scaled to fit with the pins on top.
+// Presses anywhere on the map go to one drag gesture, which picks up the
+// pin under the pointer: dropping it saves the new position, and a tap
+// without moving opens the container.
+func (ga *GioApp) renderFloorPlanMap(gtx layout.Context, img image.Image) layout.Dimensions {
+	area := gtx.Constraints.Max
+	rect := fitFloorPlan(img.Bounds().Size(), area)
+	pins := ga.floorPlanPins(rect)
+	hitRadius := float32(gtx.Dp(unit.Dp(floorPlanPinHitRadius)))
+	slop := float32(gtx.Dp(unit.Dp(floorPlanDragSlop)))
+
+	for {
+		ev, ok := ga.widgetState.floorPlanDrag.Update(gtx.Metric, gtx.Source, gesture.Both)
+		if !ok {
+			break
+		}
+		switch ev.Kind {
+		case pointer.Press:
+			if i := floorPlanPinAt(pins, ev.Position, hitRadius); i >= 0 {
+				ga.floorPlanDragID = pins[i].ContainerID
+				ga.floorPlanDragPos = pins[i].At
+				ga.floorPlanDragGrab = pins[i].At.Sub(ev.Position)
+				ga.floorPlanDragStart = ev.Position
+				ga.floorPlanDragMoved = false
+			}
+		case pointer.Drag:
+			if ga.floorPlanDragID == "" {
+				continue
+			}
+			if d := ev.Position.Sub(ga.floorPlanDragStart); d.X*d.X+d.Y*d.Y > slop*slop {
+				ga.floorPlanDragMoved = true
+			}
+			if ga.floorPlanDragMoved {
+				x, y := floorPlanFraction(rect, ev.Position.Add(ga.floorPlanDragGrab))
+				ga.floorPlanDragPos = floorPlanPoint(rect, x, y)
+			}
+		case pointer.Release:
+			id := ga.floorPlanDragID
+			ga.floorPlanDragID = ""
+			if id == "" {
+				continue
+			}
+			if ga.floorPlanDragMoved {
+				x, y := floorPlanFraction(rect, ga.floorPlanDragPos)
+				ga.placeContainerOnPlan(id, x, y)
+			} else {
+				ga.openContainerFromMap(id)
+			}
+		case pointer.Cancel:
+			ga.floorPlanDragID = ""
+		}
+	}
+	// The events may have moved a pin
+	pins = ga.floorPlanPins(rect)
+
+	// Plan image
+	imgStack := op.Offset(rect.Min).Push(gtx.Ops)
+	imgGtx := gtx
+	imgGtx.Constraints = layout.Exact(rect.Size())
+	widget.Image{Src: paint.NewImageOp(img), Fit: widget.Fill}.Layout(imgGtx)
+	imgStack.Pop()
+
+	// Input area over the whole map
+	input := clip.Rect{Max: area}.Push(gtx.Ops)
+	ga.widgetState.floorPlanDrag.Add(gtx.Ops)
+	if ga.floorPlanDragID != "" {
+		pointer.CursorGrabbing.Add(gtx.Ops)
+	}
+	input.Pop()
+
+	searching := strings.TrimSpace(ga.widgetState.objectsSearchField.Text()) != ""
+	for _, pin := range pins {
+		ga.renderFloorPlanPin(gtx, pin, searching && !pin.Highlighted)
+	}
+	return layout.Dimensions{Size: area}
+}
+
+// renderFloorPlanPin draws one pin with the container's name under it.
+// While searching, pins without a match are dimmed and matches drawn larger.
+func (ga *GioApp) renderFloorPlanPin(gtx layout.Context, pin floorPlanPin, dimmed bool) {
+	radius := gtx.Dp(unit.Dp(floorPlanPinRadius))
+	fill := theme.ColorPrimary
+	switch {
+	case pin.Highlighted:
+		radius = radius * 3 / 2
+		fill = theme.ColorAccent
+	case dimmed:
+		fill = theme.ColorGray
+	}
+	border := gtx.Dp(unit.Dp(2))
+	centre := pin.At.Round()
+
+	outer := image.Rectangle{Min: centre.Sub(image.Pt(radius+border, radius+border)), Max: centre.Add(image.Pt(radius+border, radius+border))}
+	paint.FillShape(gtx.Ops, theme.ColorWhite, clip.Ellipse(outer).Op(gtx.Ops))
+	inner := image.Rectangle{Min: centre.Sub(image.Pt(radius, radius)), Max: centre.Add(image.Pt(radius, radius))}
+	paint.FillShape(gtx.Ops, fill, clip.Ellipse(inner).Op(gtx.Ops))
+
+	if dimmed {
+		return
+	}
+	// Name, centred under the pin
+	macro := op.Record(gtx.Ops)
+	labelGtx := gtx
+	labelGtx.Constraints.Min = image.Point{}
+	label := material.Caption(ga.theme.Theme, pin.Name)
+	label.MaxLines = 1
+	label.Font.Weight = font.Bold
+	dims := layout.UniformInset(unit.Dp(2)).Layout(labelGtx, func(gtx layout.Context) layout.Dimensions {
+		return label.Layout(gtx)
+	})
+	call := macro.Stop()
+
+	at := image.Point{X: centre.X - dims.Size.X/2, Y: centre.Y + radius + border}
+	stack := op.Offset(at).Push(gtx.Ops)
+	bg := clip.UniformRRect(image.Rectangle{Max: dims.Size}, gtx.Dp(unit.Dp(theme.RadiusDefault)))
+	paint.FillShape(gtx.Ops, theme.ColorSurface, bg.Op(gtx.Ops))
+	call.Add(gtx.Ops)
+	stack.Pop()
+}
+
+// renderUnplacedContainers lists the containers not yet on the plan; tapping
+// one drops its pin in the middle of the plan to drag into place
+func (ga *GioApp) renderUnplacedContainers(gtx layout.Context) layout.Dimensions {
+	var unplaced []Container
+	for _, c := range ga.containers {
+		if !isPlaced(c) {
+			unplaced = append(unplaced, c)
+		}
+	}
+	if len(unplaced) == 0 {
+		return layout.Dimensions{}
+	}
+	for len(ga.widgetState.floorPlanUnplacedBtns) < len(unplaced) {
+		ga.widgetState.floorPlanUnplacedBtns = append(ga.widgetState.floorPlanUnplacedBtns, widget.Clickable{})
+	}
+
+	chips := make([]layout.Widget, len(unplaced))
+	for i, c := range unplaced {
+		btn := &ga.widgetState.floorPlanUnplacedBtns[i]
+		if btn.Clicked(gtx) {
+			ga.placeContainerOnPlan(c.ID, 0.5, 0.5)
+		}
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, c.Name, false)
+		}
+	}
+
+	return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Caption(ga.theme.Theme, "Not on the map yet, tap to add:")
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				chipGap := gtx.Dp(unit.Dp(theme.Spacing1))
+				return layoutFlowWrap(gtx, chipGap, chipGap, chips...)
+			}),
+		)
+	})
+}
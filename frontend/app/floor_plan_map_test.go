@@ -0,0 +1,106 @@
+package app
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestFitFloorPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		img, area image.Point
+		want      image.Rectangle
+	}{
+		{"wide plan in a tall area", image.Pt(200, 100), image.Pt(100, 300), image.Rect(0, 125, 100, 175)},
+		{"tall plan in a wide area", image.Pt(100, 200), image.Pt(300, 100), image.Rect(125, 0, 175, 100)},
+		{"upscaled", image.Pt(10, 10), image.Pt(50, 50), image.Rect(0, 0, 50, 50)},
+		{"no image yet", image.Point{}, image.Pt(50, 50), image.Rectangle{}},
+	}
+	for _, tt := range tests {
+		if got := fitFloorPlan(tt.img, tt.area); got != tt.want {
+			t.Errorf("%s: fitFloorPlan = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFloorPlanFractionRoundTrip(t *testing.T) {
+	rect := image.Rect(20, 10, 220, 110)
+	p := floorPlanPoint(rect, 0.25, 0.5)
+	if p != (f32.Point{X: 70, Y: 60}) {
+		t.Fatalf("floorPlanPoint = %v, want (70, 60)", p)
+	}
+	if x, y := floorPlanFraction(rect, p); x != 0.25 || y != 0.5 {
+		t.Errorf("floorPlanFraction = %v, %v, want 0.25, 0.5", x, y)
+	}
+	// Dropped past the edge of the plan
+	if x, y := floorPlanFraction(rect, f32.Point{X: 0, Y: 500}); x != 0 || y != 1 {
+		t.Errorf("floorPlanFraction off the plan = %v, %v, want 0, 1", x, y)
+	}
+}
+
+func TestFloorPlanPinAt(t *testing.T) {
+	pins := []floorPlanPin{
+		{ContainerID: "under", At: f32.Point{X: 50, Y: 50}},
+		{ContainerID: "over", At: f32.Point{X: 55, Y: 50}},
+		{ContainerID: "far", At: f32.Point{X: 200, Y: 200}},
+	}
+	if i := floorPlanPinAt(pins, f32.Point{X: 52, Y: 50}, 10); i != 1 {
+		t.Errorf("overlapping pins: got %d, want the one drawn on top (1)", i)
+	}
+	if i := floorPlanPinAt(pins, f32.Point{X: 120, Y: 120}, 10); i != -1 {
+		t.Errorf("empty spot: got %d, want -1", i)
+	}
+}
+
+func TestFloorPlanPinsHighlightSearchMatches(t *testing.T) {
+	x, y := 0.5, 0.5
+	at := &types.ContainerPlacement{X: &x, Y: &y}
+	ga := newTestGioApp()
+	ga.containers = []Container{
+		{ID: "pantry", Name: "Pantry", Placement: at},
+		{ID: "freezer", Name: "Freezer", Placement: at},
+		{ID: "shed", Name: "Shed", Placement: &types.ContainerPlacement{Room: "Garden"}},
+	}
+	ga.objects = []Object{
+		{ID: "o1", Name: "Frozen peas", ContainerID: "freezer"},
+		{ID: "o2", Name: "Rice", ContainerID: "pantry"},
+	}
+
+	pins := ga.floorPlanPins(image.Rect(0, 0, 100, 100))
+	if len(pins) != 2 {
+		t.Fatalf("got %d pins, want 2 (the shed has a room but no position)", len(pins))
+	}
+	for _, p := range pins {
+		if p.Highlighted {
+			t.Errorf("%s highlighted without a search", p.ContainerID)
+		}
+	}
+
+	ga.widgetState.objectsSearchField.SetText("peas")
+	pins = ga.floorPlanPins(image.Rect(0, 0, 100, 100))
+	last := pins[len(pins)-1]
+	if last.ContainerID != "freezer" || !last.Highlighted {
+		t.Errorf("last pin = %+v, want the freezer highlighted and drawn on top", last)
+	}
+	if pins[0].Highlighted {
+		t.Errorf("%s highlighted, but holds no match", pins[0].ContainerID)
+	}
+}
+
+func TestSetContainerPlacement(t *testing.T) {
+	ga := newTestGioApp()
+	ga.containers = []Container{{ID: "c1"}}
+	x, y := 0.1, 0.9
+	ga.setContainerPlacement("c1", &types.ContainerPlacement{X: &x, Y: &y})
+	if !isPlaced(ga.containers[0]) {
+		t.Fatal("container not placed")
+	}
+	ga.setContainerPlacement("c1", nil)
+	if isPlaced(ga.containers[0]) {
+		t.Error("container still placed after rollback")
+	}
+}
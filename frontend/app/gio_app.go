@@ -1,12 +1,17 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"image/color"
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"gioui.org/app"
+	"gioui.org/f32"
+	"gioui.org/gesture"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
@@ -15,14 +20,31 @@ import (
 	"gioui.org/widget/material"
 
 	"github.com/nishiki/backend/app/http/response"
+	"github.com/nishiki/backend/domain/entities"
 
 	"github.com/nishiki/frontend/config"
+	accountsAPI "github.com/nishiki/frontend/pkg/api/accounts"
+	adminAPI "github.com/nishiki/frontend/pkg/api/admin"
 	authAPI "github.com/nishiki/frontend/pkg/api/auth"
+	automationsAPI "github.com/nishiki/frontend/pkg/api/automations"
 	collectionsAPI "github.com/nishiki/frontend/pkg/api/collections"
+	commentsAPI "github.com/nishiki/frontend/pkg/api/comments"
 	apiCommon "github.com/nishiki/frontend/pkg/api/common"
 	containersAPI "github.com/nishiki/frontend/pkg/api/containers"
+	featuresAPI "github.com/nishiki/frontend/pkg/api/features"
+	foldersAPI "github.com/nishiki/frontend/pkg/api/folders"
 	groupsAPI "github.com/nishiki/frontend/pkg/api/groups"
+	importsAPI "github.com/nishiki/frontend/pkg/api/imports"
+	inboxAPI "github.com/nishiki/frontend/pkg/api/inbox"
+	mealPlansAPI "github.com/nishiki/frontend/pkg/api/mealplans"
+	mediaAPI "github.com/nishiki/frontend/pkg/api/media"
+	nutritionAPI "github.com/nishiki/frontend/pkg/api/nutrition"
 	objectsAPI "github.com/nishiki/frontend/pkg/api/objects"
+	objectTypesAPI "github.com/nishiki/frontend/pkg/api/objecttypes"
+	recurrencesAPI "github.com/nishiki/frontend/pkg/api/recurrences"
+	reportsAPI "github.com/nishiki/frontend/pkg/api/reports"
+	snapshotsAPI "github.com/nishiki/frontend/pkg/api/snapshots"
+	statusAPI "github.com/nishiki/frontend/pkg/api/status"
 	"github.com/nishiki/frontend/pkg/types"
 	"github.com/nishiki/frontend/ui/theme"
 	"github.com/nishiki/frontend/ui/widgets"
@@ -34,10 +56,15 @@ type (
 	AuthInfoResponse   = response.AuthInfoResponse
 	ClaimsInfo         = response.ClaimsInfo
 	Group              = response.GroupResponse
+	GroupMember        = response.GroupMemberResponse
 	Collection         = response.CollectionResponse
+	CollectionFolder   = response.CollectionFolderResponse
+	ObjectTypeInfo     = response.ObjectTypeResponse
 	Container          = response.ContainerResponse
 	Object             = response.ObjectResponse
+	ObjectClaim        = response.ObjectClaimResponse
 	PropertySchema     = response.PropertySchemaResponse
+	ShelfLife          = response.ShelfLifeResponse
 	PropertyDefinition = response.PropertyDefinitionResponse
 	TypedValue         = response.TypedValueResponse
 )
@@ -55,9 +82,13 @@ func (cw consoleWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// skeletonRows is how many placeholder cards a list view shows while its
+// first load is in flight
+const skeletonRows = 4
+
 // GioApp holds the Gio-based application state
 type GioApp struct {
-	config             *config.Config
+	config             *config.Config // settings of the active profile
 	authService        *AuthService
 	currentUser        *User
 	groups             []Group
@@ -72,16 +103,42 @@ type GioApp struct {
 	isSignedIn         bool
 	logger             *slog.Logger
 
+	// Profiles to switch between (see profiles.go). baseConfig holds the
+	// settings as loaded; profileAuth keeps each profile's sign-in.
+	baseConfig  *config.Config
+	profileAuth map[string]*AuthService
+
+	// Window width breakpoint, updated from every frame event
+	sizeClass sizeClass
+
 	// Login error message shown on the login screen after auth failures
 	loginErrorMsg string
 
-	// Loading state for async data fetches
+	// Loading state for async data fetches. The list views show skeleton
+	// cards until their first fetch has answered.
 	loadingContainersObjects bool
+	groupsLoaded             bool
+	collectionsLoaded        bool
+
+	// Counts on the dashboard's stat cards (see dashboard_view.go). They are
+	// marked stale whenever the collections are refetched.
+	dashboardStats        dashboardStats
+	dashboardStatsStale   bool
+	dashboardStatsLoading bool
 
 	// Generic API error dialog state
 	showAPIError bool
 	apiErrorMsg  string
 
+	// Retrying transport of the API clients and the offline banner its
+	// circuit breaker drives (see connectivity.go)
+	transport *apiCommon.Transport
+	offline   bool
+
+	// Opt-in crash and API failure reporting (see error_reporter.go)
+	errorReporter *errorReporter
+	panicStreak   int // frames in a row that panicked
+
 	// Dialog state
 	showGroupDialog           bool
 	groupDialogMode           string // "create" or "edit"
@@ -99,10 +156,16 @@ type GioApp struct {
 	deleteContainerID         string
 	showObjectDialog          bool
 	objectDialogMode          string // "create" or "edit"
+	objectDialogErr           string // schema validation message shown in the object dialog
+	objectCondition           string // condition picked in the object dialog; "" for ungraded
+	objectStatus              string // status picked in the object dialog; "" for owned
+	voiceListening            bool   // a quick add phrase is being recorded or parsed
+	voiceTranscript           string // last phrase heard, shown under the name field
 	showDeleteObject          bool
 	deleteObjectID            string
 	selectedObjectType        string
 	selectedContainerType     string
+	selectedTemperatureZone   string // "" = no zone
 	selectedGroupID           *string
 	selectedContainerID       *string
 	selectedParentContainerID *string // nil = no parent (root), pointer to "" = explicitly clearing parent
@@ -110,8 +173,16 @@ type GioApp struct {
 	// Group members dialog state
 	showMembersDialog bool
 	groupMembersOf    *Group
-	groupMembers      []User
+	groupMembers      []GroupMember
 	knownUsers        []User
+	// memberSyncStatus reports how the last member change went in Authentik;
+	// memberSyncFailed colours it as an error.
+	memberSyncStatus string
+	memberSyncFailed bool
+	// groupQuota is the limits and usage of groupMembersOf
+	groupQuota       *types.GroupQuota
+	groupQuotaStatus string
+	groupQuotaBusy   bool
 
 	// Join group dialog state
 	showJoinGroupDialog bool
@@ -132,11 +203,22 @@ type GioApp struct {
 	importFilename       string
 	importNameColumn     string
 	importLocationColumn *string // nil = no location column (automatic distribution)
-	importRunning        bool
-	importResult         *importResult
+	// importSourceFormat names the app the file was exported from ("grocy",
+	// "pantry_csv", "auto"); empty means the columns already use our names.
+	importSourceFormat string
+	importRunning      bool
+	importResult       *importResult
+	// importProgress is how far the running import has got, from its job's
+	// progress events (see import_progress.go)
+	importProgress types.ImportJob
 	// importOmittedColumns tracks columns the user has marked to exclude from
 	// the import. Applies to both import dialogs.
 	importOmittedColumns map[string]bool
+	// importFileContent is the picked or dropped file as read; the import
+	// uploads it whole rather than posting the parsed rows
+	importFileContent []byte
+	// importDragActive is set while a file is dragged over the window
+	importDragActive bool
 	// pendingImportSchema is populated by the schema editor when it is invoked
 	// from an import flow and consumed by the subsequent import. nil means no
 	// user-defined schema override.
@@ -151,7 +233,7 @@ type GioApp struct {
 
 	// Container display mode in collection detail
 	showContainersPanel bool             // whether container column is visible
-	containerViewMode   string           // "split" (side-by-side) or "grouped" (objects grouped by container)
+	containerViewMode   string           // "split" (side-by-side), "grouped" (objects grouped by container) or "map" (floor plan beside objects)
 	objectViewLayout    ObjectViewLayout // ObjectViewList (single column) or ObjectViewGrid (multi-column)
 
 	// Grouped-text filter state (property key → selected value; empty = "All")
@@ -161,6 +243,16 @@ type GioApp struct {
 	objectSortSpecs    []sortSpec // chained sort specs; [0] is primary
 	objectGroupByField string     // "", "location", "container", or a property key
 
+	// Saved per-view sort/group/filter preferences (see view_preferences.go)
+	viewPreferences       map[string]types.ViewPreference // last state loaded from or sent to the backend
+	viewPrefsLoaded       bool
+	viewPrefsSaving       bool
+	viewPrefsCollectionID string // collection whose saved view has been applied
+
+	// Pinned and hand-ordered collections and containers, by ID (see item_order.go)
+	collectionOrder map[string]types.ItemOrder
+	containerOrder  map[string]types.ItemOrder
+
 	// Render caches — invalidated when underlying data changes (see invalidateObjectCaches)
 	cachedGroupedTextValues map[string][]string // collectGroupedTextValues result
 	cachedGroupedTextValid  bool
@@ -191,6 +283,20 @@ type GioApp struct {
 	treeExpandedNodes  map[string]bool
 	treeNodeClickables map[string]*widget.Clickable
 
+	// Lazily loaded tree objects (see container_tree.go)
+	treePartial           bool            // ga.objects holds only the loaded containers' objects
+	treeLoadedContainers  map[string]bool // containers whose objects are in ga.objects
+	treeLoadingContainers map[string]bool
+	treeLoadingAll        bool
+	treeGeneration        int // bumped on reset so late fetches are dropped
+
+	// Per-route scroll, expansion and filter state restored on back
+	// navigation (see view_state.go)
+	viewStates       map[string]*viewState
+	viewRoute        string
+	viewRouteView    ViewID
+	pendingViewState *viewState
+
 	// Stats panel toggle
 	showStatsPanel bool
 
@@ -198,38 +304,355 @@ type GioApp struct {
 	window *app.Window
 	theme  *theme.NishikiTheme
 	ops    chan func()
+	// session counts signed-in sessions so results fetched for an earlier
+	// one are dropped (see session.go)
+	session uint64
 
 	// API clients
 	apiClient         *apiCommon.Client
 	authClient        *authAPI.Client
 	groupsClient      *groupsAPI.Client
 	collectionsClient *collectionsAPI.Client
+	foldersClient     *foldersAPI.Client
+	objectTypesClient *objectTypesAPI.Client
 	containersClient  *containersAPI.Client
 	objectsClient     *objectsAPI.Client
+	accountsClient    *accountsAPI.Client
+	mealPlansClient   *mealPlansAPI.Client
+	inboxClient       *inboxAPI.Client
+	recurrencesClient *recurrencesAPI.Client
+	automationsClient *automationsAPI.Client
+	snapshotsClient   *snapshotsAPI.Client
+	commentsClient    *commentsAPI.Client
+	mediaClient       *mediaAPI.Client
+	nutritionClient   *nutritionAPI.Client
+	adminClient       *adminAPI.Client
+	statusClient      *statusAPI.Client
+	featuresClient    *featuresAPI.Client
+	importsClient     *importsAPI.Client
+	reportsClient     *reportsAPI.Client
 
 	// Widget state
 	widgetState *WidgetState
+
+	// Deletes awaiting their undo window before being sent (see undo.go)
+	pendingDeletes      []*pendingDelete
+	nextPendingDeleteID int
+
+	// Account backup download state (see backup.go)
+	backupInProgress bool
+	backupStatus     string
+
+	// Object labels (see object_label.go)
+	labelInProgress bool
+	labelTemplate   string // label stock from the preferences; "" until loaded
+
+	// Measurement units (see unit_system.go)
+	unitSystem entities.UnitSystem // from the preferences; "" until loaded
+
+	// Personal data export and account deletion (see account_deletion.go)
+	dataExportInProgress  bool
+	dataExportStatus      string
+	showDeleteAccount     bool
+	deleteAccountInFlight bool
+	deleteAccountErr      string
+	reauthRequired        bool // the backend wants a fresh sign-in first
+
+	// Signed-in devices (see sessions.go)
+	sessions        []types.Session
+	sessionsLoaded  bool
+	sessionsLoading bool
+	sessionsBusy    bool // a trust or sign-out request is in flight
+	sessionsStatus  string
+
+	// About dialog on the profile view (see about.go)
+	showAbout      bool
+	aboutStatus    *types.ServerStatus
+	aboutStatusErr string
+
+	// Location timeline for the object in the edit dialog (see object_history.go)
+	objectHistory        *types.ObjectHistory
+	objectHistoryLoading bool
+	objectHistoryErr     string
+
+	// Recurring staples: the edited object's schedule and the dashboard's
+	// shopping list (see staples.go)
+	objectRecurrence        *types.RecurrenceRule
+	objectRecurrenceLoading bool
+	objectRepeat            repeatChoice // schedule picked in the object dialog
+	dueStaples              []types.RecurrenceRule
+	dueStaplesLoaded        bool
+	dueStaplesLoading       bool
+
+	// Archived objects section in the collection view (see archived_objects.go)
+	archivedObjects        []Object
+	archivedObjectsLoaded  bool
+	archivedObjectsLoading bool
+	showArchivedObjects    bool
+
+	// Object whose claim is being saved (see object_claims.go)
+	claimingObjectID string
+
+	// Duplicate finder and merge preview (see object_merge.go)
+	showMergeDialog     bool
+	duplicateGroups     []types.DuplicateGroup
+	duplicatesLoading   bool
+	mergeGroupIndex     int // selected duplicate group, -1 for none
+	mergePreview        *types.MergeObjectsResult
+	mergePreviewLoading bool
+	mergeRunning        bool
+	mergeErr            string
+
+	// Object multi-select and bulk tagging (see object_retag.go), and the
+	// batch move, delete and export in its action bar (see object_batch.go)
+	selectingObjects      bool
+	selectedObjectIDs     map[string]bool
+	selectionNotice       string
+	showRetagDialog       bool
+	retagRunning          bool
+	retagErr              string
+	showBatchMoveDialog   bool
+	showBatchDeleteDialog bool
+	batchMoveContainerID  string
+	batchRunning          bool
+	batchErr              string
+
+	// Object shown in the detail pane beside the objects list, and whether
+	// its name is being edited in place (see object_detail_pane.go)
+	detailObjectID    string
+	editingDetailName bool
+
+	// Scanned code already on an object (see object_code_lookup.go)
+	showCodeMatchDialog bool
+	codeMatches         []types.ObjectCodeMatch
+	pendingCreate       *pendingObjectCreate
+
+	// Collection snapshots and comparison (see collection_snapshots.go)
+	showSnapshotDialog     bool
+	snapshots              []types.CollectionSnapshot
+	snapshotLimit          int
+	snapshotsLoading       bool
+	snapshotIndex          int // selected snapshot, -1 for none
+	snapshotDiff           *types.CollectionSnapshotDiff
+	snapshotDiffLoading    bool
+	snapshotRunning        bool
+	snapshotConfirmRestore bool
+	snapshotErr            string
+	snapshotNotice         string
+
+	// Collection comments and unread badges (see collection_comments.go)
+	showCommentsDialog bool
+	comments           []types.Comment
+	commentsLoading    bool
+	commentPosting     bool
+	commentsErr        string
+	unreadComments     map[string]types.CollectionUnreadComments // by collection ID
+
+	// Photos tab of the collection view, loaded a page at a time (see collection_photos.go)
+	photos             []types.Media
+	photosTotal        int64
+	photosOffset       int // server offset of the next page
+	photosCollectionID string
+	photosLoaded       bool
+	photosLoading      bool
+	photosGeneration   int // bumped on reset so late pages are dropped
+	photosErr          string
+
+	// Photo being taken, compressed and uploaded for an object (see photo_upload.go)
+	showPhotoDialog   bool
+	photoObject       *Object
+	photoPending      *mediaAPI.Photo
+	photoOriginalSize int64
+	photoCompressing  bool
+	photoUploading    bool
+	photoProgress     float32
+	photoErr          string
+
+	// Floor plan map in collection detail (see floor_plan_map.go)
+	floorPlanDragID    string    // container whose pin is held, "" when none
+	floorPlanDragPos   f32.Point // where the held pin is on the map
+	floorPlanDragGrab  f32.Point // pin centre relative to the pointer
+	floorPlanDragStart f32.Point // pointer position at the press
+	floorPlanDragMoved bool      // past the slop, so a move rather than a tap
+	floorPlanUploading bool
+	floorPlanErr       string
+
+	// Pantry nutrition totals in the stats panel of food collections (see
+	// collection_nutrition.go)
+	nutritionStats        *types.NutritionStats
+	nutritionCollectionID string
+	nutritionLoading      bool
+	nutritionGeneration   int // bumped on reset so late responses are dropped
+	nutritionErr          string
+
+	// Folders the collections list is organised into (see collection_folders.go)
+	collectionFolders     []CollectionFolder
+	customObjectTypes     []ObjectTypeInfo
+	currentFolderID       string // folder the list is showing; "" for the top level
+	folderStats           *types.CollectionFolderStats
+	folderStatsID         string
+	folderStatsLoading    bool
+	folderStatsGeneration int  // bumped on reset so late responses are dropped
+	collectionDragActive  bool // a collection is being dragged onto a folder
+	showFolderDialog      bool
+	editingFolderID       string // folder being renamed; "" when creating one
+
+	// Weekly meal plan (see meal_plan_view.go)
+	mealWeekStart        time.Time // Monday of the week on screen, as a UTC calendar date
+	mealPlans            []types.MealPlan
+	mealPlansWeek        time.Time // week the loaded plans belong to
+	mealPlansLoading     bool
+	mealPlansErr         string
+	mealNotice           string   // outcome of the last completed meal
+	mealFoodObjects      []Object // active objects of food collections, for picking ingredients
+	mealFoodLoaded       bool
+	mealFoodLoading      bool
+	showMealPlanDialog   bool
+	editingMealPlan      *types.MealPlan // nil when planning a new meal
+	mealDialogDate       time.Time
+	mealDialogMeal       string
+	mealIngredientDrafts []*mealIngredientDraft
+	mealDialogErr        string
+	mealPlanSaving       bool
+
+	// Features on for the signed-in user, from /features (see features.go)
+	features map[string]bool
+
+	// Admin dashboard (see admin_view.go); isAdmin mirrors the admin claim
+	// of /auth/me
+	isAdmin       bool
+	adminUsers    []types.AdminUser
+	adminStats    *types.SystemStats
+	adminLoaded   bool
+	adminLoading  bool
+	adminErr      string
+	adminUpdating string // ID of the user being disabled or enabled
+
+	// Valuation report (see valuation_view.go)
+	valuationReport       *types.ValuationReport
+	valuationLoaded       bool
+	valuationLoading      bool
+	valuationErr          string
+	valuationExporting    bool
+	valuationExportStatus string
+
+	// Email inbox (see inbox_view.go). inboxCollectionID is where confirmed
+	// lines go; inboxBusy is the ID of the item being confirmed or dismissed.
+	inboxItems        []types.InboxItem
+	inboxAddress      *types.InboxAddress
+	inboxLoaded       bool
+	inboxLoading      bool
+	inboxErr          string
+	inboxNotice       string
+	inboxCollectionID string
+	inboxBusy         string
+	inboxResetting    bool
+
+	// Automation rules (see automations_view.go). automationsBusy is the ID
+	// of the rule being switched or deleted; automationContainers are those
+	// of automationContainersFor, the collection the edited rule watches.
+	automationRules         []types.AutomationRule
+	automationsLoaded       bool
+	automationsLoading      bool
+	automationsErr          string
+	automationsBusy         string
+	showAutomationDialog    bool
+	editingAutomation       *types.AutomationRule // nil when creating a rule
+	automationTriggerKind   string
+	automationCollectionID  string
+	automationActionDrafts  []*automationActionDraft
+	automationContainers    []types.Container
+	automationContainersFor string
+	automationDialogErr     string
+	automationSaving        bool
+
+	// Webhooks dialog (see automation_webhooks.go). webhookNewKey is the key
+	// the last rotation made, shown until the dialog closes; webhookResending
+	// is the ID of the delivery being sent again.
+	showWebhooksDialog bool
+	webhookSigningKey  *types.WebhookSigningKey
+	webhookNewKey      string
+	webhookDeliveries  []types.WebhookDelivery
+	webhooksLoading    bool
+	webhooksErr        string
+	webhookRotating    bool
+	webhookResending   string
+
+	// Expiry suggestions for the create object dialog (see
+	// expiry_suggestions.go), fetched once per session and again after an
+	// object is created with an expiry date
+	expirySuggestions        []types.ExpirySuggestion
+	expirySuggestionsLoaded  bool
+	expirySuggestionsLoading bool
+
+	// Global search (see search_view.go). searchInput is the field text the
+	// last search was scheduled for; searchSeq tells the latest search from
+	// the ones it superseded.
+	searchInput   string
+	searchResults *types.SearchResults
+	searchLoading bool
+	searchErr     string
+	searchSeq     int
+	searchTimer   *time.Timer
+	searchCancel  context.CancelFunc
+
+	// Keyboard shortcuts and command palette
+	shortcuts      *widgets.Shortcuts
+	commandPalette *widgets.CommandPalette
+	pendingFocus   *widget.Editor // editor to focus on the next frame
 }
 
 // WidgetState holds all widget state for the application
 type WidgetState struct {
 	// Login view
-	loginButton widget.Clickable
+	loginButton    widget.Clickable
+	profileButtons map[string]*widget.Clickable // profile switcher chips by name
 
 	// Dashboard navigation buttons
 	groupsButton      widget.Clickable
 	collectionsButton widget.Clickable
 	profileButton     widget.Clickable
 	searchButton      widget.Clickable
+	mealsButton       widget.Clickable
+	adminButton       widget.Clickable
+	valuationButton   widget.Clickable
+	inboxButton       widget.Clickable
 
 	// Profile view
-	logoutButton widget.Clickable
+	logoutButton        widget.Clickable
+	backupButton        widget.Clickable
+	dataExportButton    widget.Clickable
+	deleteAccountButton widget.Clickable
+	reauthButton        widget.Clickable
+	aboutButton         widget.Clickable
+
+	labelTemplateButtons map[string]*widget.Clickable              // label printer chips by template key
+	unitSystemButtons    map[entities.UnitSystem]*widget.Clickable // unit system chips
+
+	// Signed-in devices, buttons by session ID
+	sessionTrustButtons       map[string]*widget.Clickable
+	sessionRevokeButtons      map[string]*widget.Clickable
+	revokeOtherSessionsButton widget.Clickable
+
+	// Delete account dialog
+	deleteAccountDialog  *widgets.Dialog
+	deleteAccountEditor  widget.Editor
+	deleteAccountConfirm widget.Clickable
+	deleteAccountCancel  widget.Clickable
+
+	// About dialog
+	aboutDialog *widgets.Dialog
+	aboutClose  widget.Clickable
+
+	// Undo snackbar
+	undoButton widget.Clickable
 
 	// Bottom menu buttons
 	menuDashboard   widget.Clickable
 	menuGroups      widget.Clickable
 	menuCollections widget.Clickable
 	menuProfile     widget.Clickable
+	menuMeals       widget.Clickable
 
 	// Groups view
 	groupsCreateButton widget.Clickable
@@ -242,6 +665,7 @@ type WidgetState struct {
 	groupDescriptionEditor widget.Editor
 	groupDialogSubmit      widget.Clickable
 	groupDialogCancel      widget.Clickable
+	groupModalTrap         widgets.FocusTrap
 
 	// Collections view
 	collectionsCreateButton widget.Clickable
@@ -253,6 +677,8 @@ type WidgetState struct {
 	collectionNameEditor         widget.Editor
 	collectionLocationEditor     widget.Editor
 	collectionTagsEditor         widget.Editor
+	collectionShelfLifeEditor    widget.Editor
+	collectionShelfLifeSuggest   widget.Clickable
 	collectionTypeButtons        map[string]*widget.Clickable
 	collectionGroupButtons       map[string]*widget.Clickable
 	collectionDialogSubmit       widget.Clickable
@@ -268,19 +694,26 @@ type WidgetState struct {
 	toggleContainersButton widget.Clickable
 	containerViewSplitBtn  widget.Clickable
 	containerViewGroupBtn  widget.Clickable
+	containerViewMapBtn    widget.Clickable
 	objectViewListBtn      widget.Clickable
 	objectViewGridBtn      widget.Clickable
 	objectViewGalleryBtn   widget.Clickable
 	objectViewCompactBtn   widget.Clickable
 	objectViewTableBtn     widget.Clickable
 	objectViewTreeBtn      widget.Clickable
+	objectViewPhotosBtn    widget.Clickable
 	statsToggleBtn         widget.Clickable
 	containersSearchField  widget.Editor
 	containersList         widget.List
 	containerItems         []ContainerItemState
 	objectsSearchField     widget.Editor
 	objectsList            widget.List
+	photosList             widget.List
 	objectItems            []ObjectItemState
+	archivedToggle         widget.Clickable
+	archivedList           widget.List
+	archivedRestoreButtons []widget.Clickable
+	stapleBoughtButtons    []widget.Clickable
 	backToCollections      widget.Clickable
 	createContainerButton  widget.Clickable
 	createObjectButton     widget.Clickable
@@ -289,11 +722,14 @@ type WidgetState struct {
 	importCancelButton     widget.Clickable
 	importDialogList       widget.List
 	importPreviewList      widget.List
+	importChooseFile       widget.Clickable
+	importCreateChooseFile widget.Clickable
 
 	// Import column mapping
 	importNameColumnButtons     map[string]*widget.Clickable
 	importLocationColumnButtons map[string]*widget.Clickable
 	importOmitColumnButtons     map[string]*widget.Clickable
+	importSourceFormatButtons   [len(importSourceFormats)]widget.Clickable
 	importInferSchemaCheck      widget.Bool
 
 	// Import & Create Collection dialog
@@ -319,11 +755,20 @@ type WidgetState struct {
 	containersPageButton widget.Clickable
 	containersBackButton widget.Clickable
 	containerDetailList  widget.List
+	containerPrintButton widget.Clickable
+
+	// Floor plan map
+	floorPlanDrag         gesture.Drag
+	floorPlanUploadBtn    widget.Clickable
+	floorPlanPathEditor   widget.Editor
+	floorPlanUnplacedBtns []widget.Clickable
 
 	// Container dialog widgets
 	containerNameEditor     widget.Editor
 	containerLocationEditor widget.Editor
+	containerHumidityEditor widget.Editor
 	containerTypeButtons    map[string]*widget.Clickable
+	temperatureZoneButtons  map[string]*widget.Clickable
 	parentContainerButtons  map[string]*widget.Clickable
 	containerDialogSubmit   widget.Clickable
 	containerDialogCancel   widget.Clickable
@@ -331,11 +776,20 @@ type WidgetState struct {
 	// Object dialog widgets
 	objectNameEditor        widget.Editor
 	objectDescriptionEditor widget.Editor
-	objectQuantityEditor    widget.Editor
+	objectQuantityStepper   widgets.QuantityStepper
 	objectUnitEditor        widget.Editor
+	objectUnitButtons       map[string]*widget.Clickable // suggested unit chips by unit
+	objectMinQuantityEditor widget.Editor
+	objectExpiresEditor     widget.Editor
+	objectUseExpiry         widget.Clickable
 	objectDialogSubmit      widget.Clickable
 	objectDialogCancel      widget.Clickable
+	objectDialogArchive     widget.Clickable
+	objectVoiceButton       widget.Clickable
 	objectContainerButtons  map[string]*widget.Clickable
+	objectConditionButtons  map[string]*widget.Clickable
+	objectStatusButtons     map[string]*widget.Clickable
+	objectRepeatButtons     map[repeatChoice]*widget.Clickable
 	objectSchemaList        widget.List
 	objectPropertyEditors   map[string]*widget.Editor
 	objectPropertyBools     map[string]*widget.Bool
@@ -345,12 +799,20 @@ type WidgetState struct {
 	membersDialogClose  widget.Clickable
 	membersAddButton    widget.Clickable
 	memberUserIDEditor  widget.Editor
+	membersInviteButton widget.Clickable
+	memberEmailEditor   widget.Editor
 	memberSearchEditor  widget.Editor
 	knownUserClickables map[string]*widget.Clickable
 	memberItems         []MemberItemState
 	membersList         widget.List
 	knownUsersList      widget.List
 
+	// Group quota form in the members dialog
+	quotaContainersEditor widget.Editor
+	quotaObjectsEditor    widget.Editor
+	quotaStorageEditor    widget.Editor
+	quotaSaveButton       widget.Clickable
+
 	// Join group dialog
 	joinGroupButton widget.Clickable
 	joinGroupDialog *widgets.Dialog
@@ -366,6 +828,164 @@ type WidgetState struct {
 	schemaRows         []SchemaRowState
 	schemaList         widget.List
 
+	// Duplicate merge dialog
+	findDuplicatesButton widget.Clickable
+	mergeDialog          *widgets.Dialog
+	mergeGroupButtons    []widget.Clickable
+	mergeGroupList       widget.List
+	mergeConfirm         widget.Clickable
+	mergeCancel          widget.Clickable
+
+	// Object multi-select action bar and its dialogs
+	selectObjectsButton       widget.Clickable
+	selectAllButton           widget.Clickable
+	batchMoveButton           widget.Clickable
+	retagButton               widget.Clickable
+	exportSelectionButton     widget.Clickable
+	batchDeleteButton         widget.Clickable
+	clearSelectionButton      widget.Clickable
+	retagDialog               *widgets.Dialog
+	retagAddEditor            widget.Editor
+	retagRemoveEditor         widget.Editor
+	retagConfirm              widget.Clickable
+	retagCancel               widget.Clickable
+	batchMoveDialog           *widgets.Dialog
+	batchMoveList             widget.List
+	batchMoveContainerButtons map[string]*widget.Clickable
+	batchMoveConfirm          widget.Clickable
+	batchMoveCancel           widget.Clickable
+	batchDeleteDialog         *widgets.Dialog
+	batchDeleteConfirm        widget.Clickable
+	batchDeleteCancel         widget.Clickable
+
+	// Object detail pane
+	objectDetailList       widget.List
+	objectDetailClose      widget.Clickable
+	objectDetailEditAll    widget.Clickable
+	objectDetailMarkOwned  widget.Clickable
+	objectDetailPrintLabel widget.Clickable
+	objectDetailDelete     widget.Clickable
+	objectDetailName       widget.Clickable
+	objectDetailNameEditor widget.Editor
+	objectDetailNameSave   widget.Clickable
+	objectDetailNameCancel widget.Clickable
+	objectDetailQuantity   widgets.QuantityStepper
+	objectDetailTagEditor  widget.Editor
+	objectDetailTagAdd     widget.Clickable
+	objectDetailTagRemove  map[string]*widget.Clickable
+
+	// Object photo upload dialog
+	photoDialog       *widgets.Dialog
+	photoPathEditor   widget.Editor
+	photoPickButton   widget.Clickable
+	photoUploadButton widget.Clickable
+	photoCancelButton widget.Clickable
+
+	// Scanned code match dialog
+	codeMatchDialog   *widgets.Dialog
+	codeMatchIncrease widget.Clickable
+	codeMatchCreate   widget.Clickable
+	codeMatchCancel   widget.Clickable
+
+	// Snapshot dialog
+	snapshotsButton     widget.Clickable
+	snapshotDialog      *widgets.Dialog
+	snapshotLabelEditor widget.Editor
+	snapshotCreate      widget.Clickable
+	snapshotButtons     []widget.Clickable
+	snapshotList        widget.List
+	snapshotDiffList    widget.List
+	snapshotRestore     widget.Clickable
+	snapshotDelete      widget.Clickable
+	snapshotClose       widget.Clickable
+
+	// Comments dialog
+	commentsButton       widget.Clickable
+	commentsDialog       *widgets.Dialog
+	commentEditor        widget.Editor
+	commentPost          widget.Clickable
+	commentDeleteButtons []widget.Clickable
+	commentList          widget.List
+	commentsClose        widget.Clickable
+
+	// Collection folders
+	folderCreateButton widget.Clickable
+	folderRenameButton widget.Clickable
+	folderDeleteButton widget.Clickable
+	folderRootCrumb    widget.Clickable
+	folderItems        map[string]*FolderItemState
+	folderDialog       *widgets.Dialog
+	folderNameEditor   widget.Editor
+	folderDialogSubmit widget.Clickable
+	folderDialogCancel widget.Clickable
+
+	// Meal plan view
+	mealPrevWeek         widget.Clickable
+	mealThisWeek         widget.Clickable
+	mealNextWeek         widget.Clickable
+	mealDaysList         widget.List
+	mealSlotButtons      map[string]*widget.Clickable // "date|meal" → plan a meal in that slot
+	mealPlanItems        map[string]*MealPlanItemState
+	mealPlanDialog       *widgets.Dialog
+	mealDialogList       widget.List
+	mealRecipeEditor     widget.Editor
+	mealDayButtons       [7]widget.Clickable
+	mealTypeButtons      map[string]*widget.Clickable
+	mealIngredientSearch widget.Editor
+	mealFoodButtons      map[string]*widget.Clickable
+	mealPlanDialogSubmit widget.Clickable
+	mealPlanDialogCancel widget.Clickable
+	mealPlanDialogDelete widget.Clickable
+
+	// Admin view
+	adminRefresh   widget.Clickable
+	adminUsersList widget.List
+	adminUserItems map[string]*AdminUserItemState
+
+	// Valuation view
+	valuationRefresh widget.Clickable
+	valuationExport  widget.Clickable
+	valuationList    widget.List
+
+	// Inbox view
+	inboxRefresh           widget.Clickable
+	inboxResetAddress      widget.Clickable
+	inboxList              widget.List
+	inboxItems             map[string]*InboxItemState
+	inboxCollectionButtons map[string]*widget.Clickable
+
+	// Automations view
+	automationsButton           widget.Clickable
+	automationsNew              widget.Clickable
+	automationsList             widget.List
+	automationItems             map[string]*AutomationItemState
+	automationDialog            *widgets.Dialog
+	automationDialogList        widget.List
+	automationNameEditor        widget.Editor
+	automationEnabled           widget.Bool
+	automationTagEditor         widget.Editor
+	automationThresholdEditor   widget.Editor
+	automationDaysEditor        widget.Editor
+	automationTriggerButtons    map[string]*widget.Clickable
+	automationCollectionButtons map[string]*widget.Clickable
+	automationAddAction         widget.Clickable
+	automationDialogSubmit      widget.Clickable
+	automationDialogCancel      widget.Clickable
+	automationsWebhooks         widget.Clickable
+	webhooksDialog              *widgets.Dialog
+	webhooksDialogList          widget.List
+	webhookGraceEditor          widget.Editor
+	webhookKeyEditor            widget.Editor
+	webhookRotate               widget.Clickable
+	webhookRefresh              widget.Clickable
+	webhooksClose               widget.Clickable
+	webhookDeliveryItems        map[string]*WebhookDeliveryState
+
+	// Search view
+	searchField       widget.Editor
+	searchList        widget.List
+	searchResultItems map[string]*widget.Clickable
+
 	// Dialog instances
 	collectionDialog *widgets.Dialog
 	deleteDialog     *widgets.Dialog
@@ -383,26 +1003,76 @@ type GroupItemState struct {
 // MemberItemState holds widget state for a single group member row
 type MemberItemState struct {
 	removeButton widget.Clickable
+	roleButton   widget.Clickable
 }
 
 // CollectionItemState holds widget state for a single collection list item
 type CollectionItemState struct {
-	viewButton   widget.Clickable
-	editButton   widget.Clickable
-	deleteButton widget.Clickable
+	viewButton     widget.Clickable
+	editButton     widget.Clickable
+	deleteButton   widget.Clickable
+	pinButton      widget.Clickable
+	moveUpButton   widget.Clickable
+	moveDownButton widget.Clickable
+	dragHandle     widget.Draggable
+}
+
+// FolderItemState holds widget state for a single collection folder
+type FolderItemState struct {
+	openButton  widget.Clickable
+	crumbButton widget.Clickable
 }
 
 // ContainerItemState holds widget state for a single container list item
 type ContainerItemState struct {
-	clickable    widget.Clickable
-	editButton   widget.Clickable
-	deleteButton widget.Clickable
+	clickable      widget.Clickable
+	editButton     widget.Clickable
+	deleteButton   widget.Clickable
+	pinButton      widget.Clickable
+	moveUpButton   widget.Clickable
+	moveDownButton widget.Clickable
 }
 
 // ObjectItemState holds widget state for a single object list item
 type ObjectItemState struct {
 	editButton   widget.Clickable
 	deleteButton widget.Clickable
+	claimButton  widget.Clickable
+	photoButton  widget.Clickable
+	ownedButton  widget.Clickable
+	cardButton   widget.Clickable
+	longPress    widgets.LongPress
+	selectBox    widget.Bool
+	quantity     widgets.QuantityStepper
+}
+
+// MealPlanItemState holds widget state for a single planned meal
+type MealPlanItemState struct {
+	completeButton widget.Clickable
+	editButton     widget.Clickable
+	deleteButton   widget.Clickable
+}
+
+// InboxItemState holds widget state for a forwarded email in the inbox.
+// lines are rebuilt when the item's lines change, after a partial confirm.
+type InboxItemState struct {
+	lines         []*inboxLineDraft
+	confirmButton widget.Clickable
+	dismissButton widget.Clickable
+}
+
+// AutomationItemState holds widget state for an automation rule's card
+type AutomationItemState struct {
+	enabled      widget.Bool
+	editButton   widget.Clickable
+	deleteButton widget.Clickable
+}
+
+// WebhookDeliveryState holds widget state for a webhook delivery's card
+type WebhookDeliveryState struct {
+	resendButton  widget.Clickable
+	payloadButton widget.Clickable
+	showPayload   bool
 }
 
 // SchemaRowState holds widget state for a single schema definition row
@@ -427,8 +1097,49 @@ const (
 	ViewContainersGio
 	ViewProfileGio
 	ViewSearchGio
+	ViewMealPlanGio
+	ViewAdminGio
+	ViewValuationGio
+	ViewInboxGio
+	ViewAutomationsGio
 )
 
+// String names the view in logs and error reports
+func (v ViewID) String() string {
+	switch v {
+	case ViewLoginGio:
+		return "login"
+	case ViewCallbackGio:
+		return "callback"
+	case ViewDashboardGio:
+		return "dashboard"
+	case ViewGroupsGio:
+		return "groups"
+	case ViewCollectionsGio:
+		return "collections"
+	case ViewCollectionDetailGio:
+		return "collection_detail"
+	case ViewContainersGio:
+		return "containers"
+	case ViewProfileGio:
+		return "profile"
+	case ViewSearchGio:
+		return "search"
+	case ViewMealPlanGio:
+		return "meal_plan"
+	case ViewAdminGio:
+		return "admin"
+	case ViewValuationGio:
+		return "valuation"
+	case ViewInboxGio:
+		return "inbox"
+	case ViewAutomationsGio:
+		return "automations"
+	default:
+		return fmt.Sprintf("view(%d)", int(v))
+	}
+}
+
 // do schedules a state mutation from a goroutine. The mutation is applied
 // inside the next FrameEvent handler, before rendering, so the frame always
 // sees fresh state. Invalidate wakes the blocked window.Event() call.
@@ -452,23 +1163,18 @@ func (ga *GioApp) drainOps() {
 
 // NewGioApp creates a new Gio-based application instance
 func NewGioApp() *GioApp {
-	cfg := config.LoadConfig()
-
 	// Create logger (console output for WebAssembly)
 	logger := slog.New(slog.NewJSONHandler(consoleWriter{}, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
+	return newGioApp(config.LoadConfig(), logger)
+}
 
-	// Create authentication service
-	authService := NewAuthService(cfg, logger)
-
-	// Initialize API clients
-	apiClient := apiCommon.NewClient(cfg.BackendURL, authService)
-	authClient := authAPI.NewClient(apiClient, cfg.ClientID)
-	groupsClient := groupsAPI.NewClient(apiClient)
-	collectionsClient := collectionsAPI.NewClient(apiClient)
-	containersClient := containersAPI.NewClient(apiClient)
-	objectsClient := objectsAPI.NewClient(apiClient)
+// newGioApp wires the app for cfg. The window is created but not run; Run
+// starts its event loop.
+func newGioApp(cfg *config.Config, logger *slog.Logger) *GioApp {
+	// The API clients share one retrying transport across profiles
+	transport := newTransport(cfg.Network)
 
 	// Create Gio window
 	w := new(app.Window)
@@ -482,6 +1188,7 @@ func NewGioApp() *GioApp {
 		collectionTypeButtons:           make(map[string]*widget.Clickable),
 		collectionGroupButtons:          make(map[string]*widget.Clickable),
 		containerTypeButtons:            make(map[string]*widget.Clickable),
+		temperatureZoneButtons:          make(map[string]*widget.Clickable),
 		importNameColumnButtons:         make(map[string]*widget.Clickable),
 		importLocationColumnButtons:     make(map[string]*widget.Clickable),
 		importOmitColumnButtons:         make(map[string]*widget.Clickable),
@@ -502,35 +1209,84 @@ func NewGioApp() *GioApp {
 		schemaDialog:                    widgets.NewDialog(),
 		membersDialog:                   widgets.NewDialog(),
 		joinGroupDialog:                 widgets.NewDialog(),
+		deleteAccountDialog:             widgets.NewDialog(),
+		aboutDialog:                     widgets.NewDialog(),
 		importCreateDialog:              widgets.NewDialog(),
+		mergeDialog:                     widgets.NewDialog(),
+		retagDialog:                     widgets.NewDialog(),
+		batchMoveDialog:                 widgets.NewDialog(),
+		batchDeleteDialog:               widgets.NewDialog(),
+		photoDialog:                     widgets.NewDialog(),
+		codeMatchDialog:                 widgets.NewDialog(),
+		snapshotDialog:                  widgets.NewDialog(),
+		commentsDialog:                  widgets.NewDialog(),
 		knownUserClickables:             make(map[string]*widget.Clickable),
+		batchMoveContainerButtons:       make(map[string]*widget.Clickable),
+		mealSlotButtons:                 make(map[string]*widget.Clickable),
+		mealPlanItems:                   make(map[string]*MealPlanItemState),
+		mealPlanDialog:                  widgets.NewDialog(),
+		folderDialog:                    widgets.NewDialog(),
+		folderItems:                     make(map[string]*FolderItemState),
+		mealDaysList:                    widget.List{List: layout.List{Axis: layout.Vertical}},
+		adminUsersList:                  widget.List{List: layout.List{Axis: layout.Vertical}},
+		adminUserItems:                  make(map[string]*AdminUserItemState),
+		valuationList:                   widget.List{List: layout.List{Axis: layout.Vertical}},
+		batchMoveList:                   widget.List{List: layout.List{Axis: layout.Vertical}},
+		inboxList:                       widget.List{List: layout.List{Axis: layout.Vertical}},
+		inboxItems:                      make(map[string]*InboxItemState),
+		inboxCollectionButtons:          make(map[string]*widget.Clickable),
+		automationsList:                 widget.List{List: layout.List{Axis: layout.Vertical}},
+		automationItems:                 make(map[string]*AutomationItemState),
+		automationDialog:                widgets.NewDialog(),
+		automationDialogList:            widget.List{List: layout.List{Axis: layout.Vertical}},
+		automationTriggerButtons:        make(map[string]*widget.Clickable),
+		automationCollectionButtons:     make(map[string]*widget.Clickable),
+		webhooksDialog:                  widgets.NewDialog(),
+		webhooksDialogList:              widget.List{List: layout.List{Axis: layout.Vertical}},
+		webhookDeliveryItems:            make(map[string]*WebhookDeliveryState),
+		objectConditionButtons:          make(map[string]*widget.Clickable),
+		objectStatusButtons:             make(map[string]*widget.Clickable),
+		objectRepeatButtons:             make(map[repeatChoice]*widget.Clickable),
+		profileButtons:                  make(map[string]*widget.Clickable),
+		labelTemplateButtons:            make(map[string]*widget.Clickable),
+		unitSystemButtons:               make(map[entities.UnitSystem]*widget.Clickable),
+		sessionTrustButtons:             make(map[string]*widget.Clickable),
+		sessionRevokeButtons:            make(map[string]*widget.Clickable),
+		objectUnitButtons:               make(map[string]*widget.Clickable),
+		searchField:                     widget.Editor{SingleLine: true},
+		searchList:                      widget.List{List: layout.List{Axis: layout.Vertical}},
+		searchResultItems:               make(map[string]*widget.Clickable),
+		mealDialogList:                  widget.List{List: layout.List{Axis: layout.Vertical}},
+		mealTypeButtons:                 make(map[string]*widget.Clickable),
+		mealFoodButtons:                 make(map[string]*widget.Clickable),
+		objectDetailList:                widget.List{List: layout.List{Axis: layout.Vertical}},
+		objectDetailTagRemove:           make(map[string]*widget.Clickable),
 	}
 
 	gioApp := &GioApp{
-		config:             cfg,
-		authService:        authService,
+		baseConfig:         cfg,
+		profileAuth:        make(map[string]*AuthService),
 		currentView:        ViewLoginGio,
 		isSignedIn:         false,
 		logger:             logger,
 		imgCache:           newImageCache(),
 		treeExpandedNodes:  make(map[string]bool),
 		treeNodeClickables: make(map[string]*widget.Clickable),
+		viewStates:         make(map[string]*viewState),
 		window:             w,
 		theme:              th,
 		ops:                make(chan func(), 10),
-		apiClient:          apiClient,
-		authClient:         authClient,
-		groupsClient:       groupsClient,
-		collectionsClient:  collectionsClient,
-		containersClient:   containersClient,
-		objectsClient:      objectsClient,
+		transport:          transport,
 		widgetState:        widgetState,
+		shortcuts:          &widgets.Shortcuts{},
+		commandPalette:     widgets.NewCommandPalette(),
 	}
+	gioApp.registerShortcuts()
+	gioApp.installImportDropTarget()
+	gioApp.connect(cfg.ForProfile(loadActiveProfile()))
 
-	// Handle session expiry: any API call that can't obtain a token or receives a 401
-	// will trigger this callback to clear local state and return to the login screen.
-	apiClient.OnAuthError = func() {
-		gioApp.do(gioApp.handleSessionExpired)
+	transport.OnStateChange = func(string, bool) {
+		gioApp.do(gioApp.updateConnectivity)
 	}
 
 	// Check authentication state on startup
@@ -544,9 +1300,9 @@ func (ga *GioApp) Run() error {
 	var ops op.Ops
 
 	// Start with an initial invalidate to get the first frame
-	go func() {
+	ga.goSafe(func() {
 		ga.window.Invalidate()
-	}()
+	})
 
 	for {
 		e := ga.window.Event()
@@ -554,43 +1310,65 @@ func (ga *GioApp) Run() error {
 		case app.DestroyEvent:
 			return e.Err
 		case app.FrameEvent:
-			ga.drainOps()
-			gtx := app.NewContext(&ops, e)
-			ga.render(gtx)
-			e.Frame(gtx.Ops)
+			ga.frame(&ops, e)
 		}
 	}
 }
 
+// frame applies pending state changes and draws one frame. A panic is
+// recovered by recoverFrame.
+func (ga *GioApp) frame(ops *op.Ops, e app.FrameEvent) {
+	defer ga.recoverFrame()
+
+	ga.drainOps()
+	ga.errorReporter.setView(ga.currentView)
+	ga.updateSizeClass(e.Metric.PxToDp(e.Size.X))
+	gtx := app.NewContext(ops, e)
+	ga.render(gtx)
+	e.Frame(gtx.Ops)
+}
+
 // render renders the current view
 func (ga *GioApp) render(gtx layout.Context) layout.Dimensions {
 	// Paint background
 	ga.paintBackground(gtx, theme.ColorBackground)
 
+	// Restore the state of a route navigated to last frame before its view
+	// reads it
+	ga.syncViewState()
+
+	// Global shortcuts run before the views so a requested focus change
+	// lands in the same frame
+	if ga.shortcutsEnabled() {
+		ga.shortcuts.Update(gtx)
+	} else {
+		ga.commandPalette.Close()
+	}
+	defer ga.applyPendingFocus(gtx)
+	// Runs before applyPendingFocus, so a focus change requested this frame
+	// wins over handing focus back from a closed dialog
+	defer widgets.EndFrame(gtx)
+
 	// Use a stack to layer dialogs on top of views
 	return layout.Stack{}.Layout(gtx,
-		// Base view layer
+		// Base view layer, with the sidebar navigation beside it on wide windows
 		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
-			switch ga.currentView {
-			case ViewLoginGio:
-				return ga.renderLoginViewSimple(gtx)
-			case ViewCallbackGio:
-				return ga.renderCallbackView(gtx)
-			case ViewDashboardGio:
-				return ga.renderDashboardView(gtx)
-			case ViewGroupsGio:
-				return ga.renderGroupsView(gtx)
-			case ViewCollectionsGio:
-				return ga.renderCollectionsView(gtx)
-			case ViewCollectionDetailGio:
-				return ga.renderCollectionDetailView(gtx)
-			case ViewContainersGio:
-				return ga.renderContainersPageView(gtx)
-			case ViewProfileGio:
-				return ga.renderProfileView(gtx)
-			default:
-				return ga.renderLoginViewSimple(gtx)
+			view := ga.renderCurrentView
+			if ga.showsSideNav() {
+				view = func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+						layout.Rigid(ga.renderSideNav),
+						layout.Flexed(1, ga.renderCurrentView),
+					)
+				}
+			}
+			if !ga.offline {
+				return view(gtx)
 			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(ga.renderOfflineBanner),
+				layout.Flexed(1, view),
+			)
 		}),
 
 		// Dialog layer (rendered on top)
@@ -627,12 +1405,80 @@ func (ga *GioApp) render(gtx layout.Context) layout.Dimensions {
 				if ga.showSchemaDialog {
 					return ga.renderSchemaEditorDialog(gtx)
 				}
+				if ga.showFolderDialog {
+					return ga.renderFolderDialog(gtx)
+				}
+			}
+			if ga.currentView == ViewMealPlanGio && ga.showMealPlanDialog {
+				return ga.renderMealPlanDialog(gtx)
+			}
+			if ga.currentView == ViewAutomationsGio && ga.showAutomationDialog {
+				return ga.renderAutomationDialog(gtx)
+			}
+			if ga.currentView == ViewAutomationsGio && ga.showWebhooksDialog {
+				return ga.renderWebhooksDialog(gtx)
+			}
+			if ga.currentView == ViewProfileGio && ga.showDeleteAccount {
+				return ga.renderDeleteAccountDialog(gtx)
+			}
+			if ga.currentView == ViewProfileGio && ga.showAbout {
+				return ga.renderAboutDialog(gtx)
+			}
+			// The collection view layers its own error dialog over its dialogs
+			if ga.currentView != ViewCollectionDetailGio && ga.showAPIError {
+				return ga.renderAPIErrorDialog(gtx)
 			}
 			return layout.Dimensions{}
 		}),
+
+		// Undo snackbar layer
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderUndoSnackbar(gtx)
+		}),
+
+		// Command palette layer (above any dialog)
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return ga.commandPalette.Layout(gtx, ga.theme.Theme, ga.paletteCommands())
+		}),
 	)
 }
 
+// renderCurrentView renders the view selected by ga.currentView
+func (ga *GioApp) renderCurrentView(gtx layout.Context) layout.Dimensions {
+	switch ga.currentView {
+	case ViewLoginGio:
+		return ga.renderLoginViewSimple(gtx)
+	case ViewCallbackGio:
+		return ga.renderCallbackView(gtx)
+	case ViewDashboardGio:
+		return ga.renderDashboardView(gtx)
+	case ViewGroupsGio:
+		return ga.renderGroupsView(gtx)
+	case ViewCollectionsGio:
+		return ga.renderCollectionsView(gtx)
+	case ViewCollectionDetailGio:
+		return ga.renderCollectionDetailView(gtx)
+	case ViewContainersGio:
+		return ga.renderContainersPageView(gtx)
+	case ViewProfileGio:
+		return ga.renderProfileView(gtx)
+	case ViewMealPlanGio:
+		return ga.renderMealPlanView(gtx)
+	case ViewSearchGio:
+		return ga.renderSearchView(gtx)
+	case ViewAdminGio:
+		return ga.renderAdminView(gtx)
+	case ViewValuationGio:
+		return ga.renderValuationView(gtx)
+	case ViewInboxGio:
+		return ga.renderInboxView(gtx)
+	case ViewAutomationsGio:
+		return ga.renderAutomationsView(gtx)
+	default:
+		return ga.renderLoginViewSimple(gtx)
+	}
+}
+
 // paintBackground paints a solid color background
 func (ga *GioApp) paintBackground(gtx layout.Context, bgColor color.NRGBA) {
 	defer clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops).Pop()
@@ -640,6 +1486,55 @@ func (ga *GioApp) paintBackground(gtx layout.Context, bgColor color.NRGBA) {
 	paint.PaintOp{}.Add(gtx.Ops)
 }
 
+// connect points the app at cfg's profile: its sign-in and a fresh set of API
+// clients. A profile switched back to reuses its sign-in.
+func (ga *GioApp) connect(cfg *config.Config) {
+	authService := ga.profileAuth[cfg.ActiveProfile()]
+	if authService == nil {
+		authService = NewAuthService(cfg, ga.logger)
+		ga.profileAuth[cfg.ActiveProfile()] = authService
+	}
+	ga.config = cfg
+	ga.authService = authService
+
+	apiClient := apiCommon.NewClient(cfg.APIURL(), authService, ga.transport)
+	apiCommon.SetErrorLanguage(preferredLanguage())
+	ga.apiClient = apiClient
+	ga.authClient = authAPI.NewClient(apiClient, cfg.ClientID)
+	ga.groupsClient = groupsAPI.NewClient(apiClient)
+	ga.collectionsClient = collectionsAPI.NewClient(apiClient)
+	ga.foldersClient = foldersAPI.NewClient(apiClient)
+	ga.objectTypesClient = objectTypesAPI.NewClient(apiClient)
+	ga.containersClient = containersAPI.NewClient(apiClient)
+	ga.objectsClient = objectsAPI.NewClient(apiClient)
+	ga.accountsClient = accountsAPI.NewClient(apiClient)
+	ga.mealPlansClient = mealPlansAPI.NewClient(apiClient)
+	ga.inboxClient = inboxAPI.NewClient(apiClient)
+	ga.recurrencesClient = recurrencesAPI.NewClient(apiClient)
+	ga.automationsClient = automationsAPI.NewClient(apiClient)
+	ga.snapshotsClient = snapshotsAPI.NewClient(apiClient)
+	ga.commentsClient = commentsAPI.NewClient(apiClient)
+	ga.mediaClient = mediaAPI.NewClient(apiClient)
+	ga.nutritionClient = nutritionAPI.NewClient(apiClient)
+	ga.adminClient = adminAPI.NewClient(apiClient)
+	ga.statusClient = statusAPI.NewClient(apiClient)
+	ga.featuresClient = featuresAPI.NewClient(apiClient)
+	ga.importsClient = importsAPI.NewClient(apiClient)
+	ga.reportsClient = reportsAPI.NewClient(apiClient)
+
+	// Report crashes and failed API calls to the backend, if opted in
+	ga.errorReporter = newErrorReporter(cfg, authService, ga.logger)
+	if ga.errorReporter != nil {
+		apiClient.OnRequestError = ga.errorReporter.reportRequestError
+	}
+
+	// Handle session expiry: any API call that can't obtain a token or receives a 401
+	// will trigger this callback to clear local state and return to the login screen.
+	apiClient.OnAuthError = func() {
+		ga.do(ga.handleSessionExpired)
+	}
+}
+
 // initializeAuthState checks authentication state on app startup
 func (ga *GioApp) initializeAuthState() {
 	ga.logger.Info("Initializing auth state on startup")
@@ -647,23 +1542,22 @@ func (ga *GioApp) initializeAuthState() {
 	// Check if we're on the callback URL
 	if ga.isCallbackURL() {
 		ga.logger.Info("Detected callback URL")
+		// A reauthentication returns here while the old token is still valid
+		reauth := ga.authService.TakeReauthPending()
 		// First check if we already have a valid token (e.g., user refreshed the callback page)
-		if ga.authService.IsTokenValid() {
+		if !reauth && ga.authService.IsTokenValid() {
 			ga.logger.Info("Valid token already exists, skipping callback and redirecting to dashboard")
 			ga.isSignedIn = true
 			ga.currentView = ViewDashboardGio
 			// Redirect away from callback URL
-			go func() {
-				ga.redirectToPath("/")
-				ga.loadUserData()
-				ga.window.Invalidate()
-			}()
+			ga.redirectToPath("/")
+			ga.loadUserData()
 			return
 		}
 		// No valid token, proceed with OAuth callback
 		ga.logger.Info("No valid token, handling OAuth callback")
 		ga.currentView = ViewCallbackGio
-		ga.handleAuthCallback()
+		ga.handleAuthCallback(reauth)
 		return
 	}
 
@@ -683,10 +1577,7 @@ func (ga *GioApp) initializeAuthState() {
 		ga.isSignedIn = true
 		ga.currentView = ViewDashboardGio
 		// Load user data asynchronously
-		go func() {
-			ga.loadUserData()
-			ga.window.Invalidate() // Trigger re-render after data loads
-		}()
+		ga.loadUserData()
 	} else {
 		ga.logger.Info("Token expired, attempting refresh")
 		// Try to refresh the token
@@ -700,10 +1591,7 @@ func (ga *GioApp) initializeAuthState() {
 			ga.logger.Info("Token refreshed successfully, signing in user")
 			ga.isSignedIn = true
 			ga.currentView = ViewDashboardGio
-			go func() {
-				ga.loadUserData()
-				ga.window.Invalidate()
-			}()
+			ga.loadUserData()
 		}
 	}
 }
@@ -716,35 +1604,43 @@ func (ga *GioApp) isCallbackURL() bool {
 	return strings.Contains(path, "/auth/callback")
 }
 
-// handleAuthCallback processes the OAuth callback
-func (ga *GioApp) handleAuthCallback() {
+// handleAuthCallback processes the OAuth callback. After a reauthentication
+// the user is returned to the profile view they started from.
+func (ga *GioApp) handleAuthCallback(reauth bool) {
 	ga.logger.Info("Starting auth callback handler")
 	ga.currentView = ViewCallbackGio
 
-	go func() {
+	ga.goSafe(func() {
 		ga.logger.Debug("Exchanging authorization code for token")
 		token, err := ga.authService.HandleCallback()
 		if err != nil {
 			ga.logger.Error("Authentication callback failed", "error", err)
-			ga.isSignedIn = false
-			ga.loginErrorMsg = "Sign in failed. Please try again."
-			ga.currentView = ViewLoginGio
-			ga.window.Invalidate()
+			ga.do(func() {
+				ga.isSignedIn = false
+				ga.loginErrorMsg = "Sign in failed. Please try again."
+				ga.currentView = ViewLoginGio
+			})
 			return
 		}
 
 		// Authentication successful
 		ga.logger.Info("Authentication successful", "expires", token.Expiry)
-		ga.isSignedIn = true
+		ga.do(func() {
+			ga.isSignedIn = true
+			ga.loadUserData()
 
-		// Load user data
-		ga.loadUserData()
+			if reauth {
+				ga.logger.Info("Reauthentication successful, returning to profile")
+				ga.currentView = ViewProfileGio
+				ga.dataExportStatus = "Signed in again. You can now export your data or delete your account."
+				return
+			}
 
-		// Show dashboard
-		ga.logger.Info("Showing dashboard after successful authentication")
-		ga.currentView = ViewDashboardGio
-		ga.window.Invalidate()
-	}()
+			// Show dashboard
+			ga.logger.Info("Showing dashboard after successful authentication")
+			ga.currentView = ViewDashboardGio
+		})
+	})
 }
 
 // renderCallbackView renders a loading message during OAuth callback
@@ -757,8 +1653,16 @@ func (ga *GioApp) renderCallbackView(gtx layout.Context) layout.Dimensions {
 	})
 }
 
-// loadUserData fetches initial user data after authentication
+// loadUserData fetches initial user data after authentication. Call it on
+// the UI goroutine.
 func (ga *GioApp) loadUserData() {
+	// An account remembered from the last start loads with one request
+	if accountID := ga.authService.StoredAccountID(); accountID != "" {
+		ga.logger.Debug("Fetching dashboard from backend", "account_id", accountID)
+		ga.fetchDashboard(accountID, false)
+		return
+	}
+
 	// Fetch user data first
 	ga.logger.Debug("Fetching user data from backend")
 	if err := ga.fetchCurrentUser(); err != nil {
@@ -770,62 +1674,129 @@ func (ga *GioApp) loadUserData() {
 
 // fetchCurrentUser gets the current user from the backend
 func (ga *GioApp) fetchCurrentUser() error {
-	go func() {
+	session := ga.session
+	ga.goSafe(func() {
 		authInfo, err := ga.authClient.GetCurrentUser()
 		if err != nil {
 			ga.logger.Error("Failed to fetch current user", "error", err)
-			ga.do(func() {
+			ga.doInSession(session, func() {
 				// Token might be invalid, clear it and show login
 				ga.authService.ClearToken()
 				ga.isSignedIn = false
 				ga.currentUser = nil
 				ga.currentView = ViewLoginGio
+				if apiCommon.IsCode(err, apiCommon.CodeAccountDisabled) {
+					ga.loginErrorMsg = "This account has been disabled. Contact your administrator."
+				}
 			})
 			return
 		}
 		ga.logger.Info("Current user fetched", "user_id", authInfo.User.ID, "name", authInfo.User.Name)
 		user := authInfo.User
-		ga.do(func() {
+		ga.doInSession(session, func() {
 			ga.currentUser = &user
+			ga.isAdmin = authInfo.Claims.Admin
 			ga.logger.Info("User loaded in state", "user_id", user.ID, "name", user.Name)
-			ga.fetchGroups()
-			ga.fetchCollections()
+			ga.authService.StoreAccountID(user.ID)
+			ga.fetchViewPreferences()
+			ga.fetchDashboard(user.ID, true)
 		})
-	}()
+	})
 	return nil
 }
 
+// fetchDashboard loads the user, their groups and their collections with
+// the dashboard's counts in one request. userLoaded says whether
+// fetchCurrentUser already ran: if so a failure falls back to fetching the
+// lists one by one, otherwise to asking who is signed in, as the remembered
+// account may no longer be.
+func (ga *GioApp) fetchDashboard(accountID string, userLoaded bool) {
+	session := ga.session
+	ga.goSafe(func() {
+		dashboard, err := ga.accountsClient.Dashboard(accountID, customSort)
+		if err != nil {
+			ga.logger.Error("Failed to fetch dashboard", "error", err)
+			ga.doInSession(session, func() {
+				if userLoaded {
+					ga.fetchGroups()
+					ga.fetchCollections()
+					return
+				}
+				if err := ga.fetchCurrentUser(); err != nil {
+					ga.logger.Error("Error fetching user after login", "error", err)
+				}
+			})
+			return
+		}
+		ga.doInSession(session, func() {
+			ga.applyDashboard(dashboard)
+			if !userLoaded {
+				ga.fetchViewPreferences()
+			}
+			ga.fetchCollectionFolders()
+			ga.fetchObjectTypes()
+			ga.fetchUnreadComments()
+			ga.fetchFeatures()
+		})
+	})
+}
+
+// applyDashboard puts a fetched dashboard into the app's state
+func (ga *GioApp) applyDashboard(dashboard *types.Dashboard) {
+	user := dashboard.User.User
+	ga.currentUser = &user
+	ga.isAdmin = dashboard.User.Claims.Admin
+	ga.groups = dashboard.Groups
+	ga.groupsLoaded = true
+	ga.collections = dashboard.Collections
+	ga.collectionsLoaded = true
+	ga.dashboardStats = newDashboardStats(dashboard)
+	ga.dashboardStatsStale = false
+	ga.logger.Info("Dashboard loaded in state", "user_id", user.ID, "groups", len(dashboard.Groups), "collections", len(dashboard.Collections))
+}
+
 // fetchGroups gets the user's groups from the backend
 func (ga *GioApp) fetchGroups() {
-	go func() {
+	session := ga.session
+	ga.goSafe(func() {
 		groups, err := ga.groupsClient.List()
 		if err != nil {
 			ga.logger.Error("Failed to fetch groups", "error", err)
+			ga.doInSession(session, func() { ga.groupsLoaded = true })
 			return
 		}
-		ga.do(func() {
+		ga.doInSession(session, func() {
 			ga.groups = groups
+			ga.groupsLoaded = true
 			ga.logger.Info("Groups loaded in state", "count", len(groups))
 		})
-	}()
+	})
 }
 
 // fetchCollections gets the user's collections from the backend
 func (ga *GioApp) fetchCollections() {
-	go func() {
-		if ga.currentUser == nil {
-			ga.logger.Error("Cannot fetch collections: no current user")
-			return
-		}
+	if ga.currentUser == nil {
+		ga.logger.Error("Cannot fetch collections: no current user")
+		return
+	}
+	accountID := ga.currentUser.ID
+	session := ga.session
+	ga.dashboardStatsStale = true
 
-		collections, err := ga.collectionsClient.List(ga.currentUser.ID)
+	ga.goSafe(func() {
+		collections, err := ga.collectionsClient.List(accountID, customSort)
 		if err != nil {
 			ga.logger.Error("Failed to fetch collections", "error", err)
+			ga.doInSession(session, func() { ga.collectionsLoaded = true })
 			return
 		}
-		ga.do(func() {
+		ga.doInSession(session, func() {
 			ga.collections = collections
+			ga.collectionsLoaded = true
 			ga.logger.Info("Collections loaded in state", "count", len(collections))
 		})
-	}()
+	})
+	ga.fetchCollectionFolders()
+	ga.fetchObjectTypes()
+	ga.fetchUnreadComments()
 }
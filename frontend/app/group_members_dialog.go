@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"strings"
 
 	"gioui.org/font"
@@ -9,6 +10,7 @@ import (
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 
+	apiCommon "github.com/nishiki/frontend/pkg/api/common"
 	"github.com/nishiki/frontend/ui/theme"
 	"github.com/nishiki/frontend/ui/widgets"
 )
@@ -21,13 +23,18 @@ func (ga *GioApp) openMembersDialog(group *Group) {
 	ga.widgetState.memberItems = nil
 	ga.widgetState.memberUserIDEditor.SetText("")
 	ga.widgetState.memberSearchEditor.SetText("")
+	ga.widgetState.memberEmailEditor.SetText("")
+	ga.memberSyncStatus = ""
+	ga.memberSyncFailed = false
 	ga.widgetState.membersDialog.Reset()
 	ga.showMembersDialog = true
+	ga.loadGroupQuota(group.ID)
 
-	go func() {
-		members, err := ga.groupsClient.GetMembers(group.ID)
+	ga.goSafe(func() {
+		members, err := ga.groupsClient.ListMembers(group.ID)
 		if err != nil {
 			ga.logger.Error("Failed to fetch group members", "error", err)
+			ga.reportMemberSync("Could not load members", err)
 			return
 		}
 		ga.groupMembers = members
@@ -36,12 +43,12 @@ func (ga *GioApp) openMembersDialog(group *Group) {
 		// Load known users from all other groups
 		ga.loadKnownUsers(group.ID, members)
 		ga.window.Invalidate()
-	}()
+	})
 }
 
 // loadKnownUsers fetches all users from all groups, excluding current members,
 // and stores them in ga.knownUsers for the user picker.
-func (ga *GioApp) loadKnownUsers(excludeGroupID string, currentMembers []User) {
+func (ga *GioApp) loadKnownUsers(excludeGroupID string, currentMembers []GroupMember) {
 	memberIDs := make(map[string]bool, len(currentMembers))
 	for _, m := range currentMembers {
 		memberIDs[m.ID] = true
@@ -91,6 +98,9 @@ func (ga *GioApp) renderMembersDialog(gtx layout.Context) layout.Dimensions {
 	if ga.widgetState.membersAddButton.Clicked(gtx) {
 		ga.handleAddMember()
 	}
+	if ga.widgetState.membersInviteButton.Clicked(gtx) {
+		ga.handleInviteMember()
+	}
 
 	// Collect known-user click before layout
 	var addKnownUserID string
@@ -116,12 +126,31 @@ func (ga *GioApp) renderMembersDialog(gtx layout.Context) layout.Dimensions {
 	}
 
 	var removeMemberUserID string
+	var roleChange *GroupMember
 
 	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.membersDialog, title)
 	dialogStyle.Width = unit.Dp(500)
 
 	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			// Authentik sync status of the last change
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.memberSyncStatus == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.memberSyncStatus)
+					label.Color = theme.ColorTextSecondary
+					if ga.memberSyncFailed {
+						label.Color = theme.ColorDanger
+					}
+					return label.Layout(gtx)
+				})
+			}),
+
+			// Usage against the group's quota
+			layout.Rigid(ga.renderGroupQuotaSection),
+
 			// Current members list
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				if len(ga.groupMembers) == 0 {
@@ -142,6 +171,9 @@ func (ga *GioApp) renderMembersDialog(gtx layout.Context) layout.Dimensions {
 						if ga.widgetState.memberItems[i].removeButton.Clicked(gtx) {
 							removeMemberUserID = ga.groupMembers[i].ID
 						}
+						if ga.widgetState.memberItems[i].roleButton.Clicked(gtx) {
+							roleChange = &ga.groupMembers[i]
+						}
 					}
 					return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 						return ga.renderMemberRow(gtx, ga.groupMembers[i], i)
@@ -190,6 +222,22 @@ func (ga *GioApp) renderMembersDialog(gtx layout.Context) layout.Dimensions {
 				})
 			}),
 
+			// Invite by email
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								return ga.renderFormField(gtx, "Invite by email", &ga.widgetState.memberEmailEditor, "name@example.com")
+							})
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.membersInviteButton, "Invite")(gtx)
+						}),
+					)
+				})
+			}),
+
 			// Manual user ID fallback
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return layout.Inset{Bottom: unit.Dp(theme.Spacing4)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
@@ -220,6 +268,9 @@ func (ga *GioApp) renderMembersDialog(gtx layout.Context) layout.Dimensions {
 	if removeMemberUserID != "" {
 		ga.handleRemoveMember(removeMemberUserID)
 	}
+	if roleChange != nil {
+		ga.handleSetMemberRole(roleChange.ID, toggledGroupRole(roleChange.Role))
+	}
 	if addKnownUserID != "" {
 		ga.handleAddMemberByID(addKnownUserID)
 	}
@@ -246,14 +297,18 @@ func (ga *GioApp) knownUserClickable(userID string) *widget.Clickable {
 }
 
 // renderMemberRow renders a single current-member row card.
-func (ga *GioApp) renderMemberRow(gtx layout.Context, member User, index int) layout.Dimensions {
+func (ga *GioApp) renderMemberRow(gtx layout.Context, member GroupMember, index int) layout.Dimensions {
 	card := widgets.DefaultCard()
 	return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle, Spacing: layout.SpaceBetween}.Layout(gtx,
 			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						label := material.Body1(ga.theme.Theme, member.Name)
+						name := member.Name
+						if member.Role == groupRoleAdmin {
+							name += " · Admin"
+						}
+						label := material.Body1(ga.theme.Theme, name)
 						label.Font.Weight = font.Bold
 						return label.Layout(gtx)
 					}),
@@ -264,6 +319,17 @@ func (ga *GioApp) renderMemberRow(gtx layout.Context, member User, index int) la
 					}),
 				)
 			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if index >= len(ga.widgetState.memberItems) {
+					return layout.Dimensions{}
+				}
+				roleLabel := "Make admin"
+				if member.Role == groupRoleAdmin {
+					roleLabel = "Make member"
+				}
+				return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+					widgets.CancelButton(ga.theme.Theme, &ga.widgetState.memberItems[index].roleButton, roleLabel))
+			}),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				if index < len(ga.widgetState.memberItems) {
 					return widgets.DangerButton(ga.theme.Theme, &ga.widgetState.memberItems[index].removeButton, "Remove")(gtx)
@@ -318,14 +384,16 @@ func (ga *GioApp) handleAddMemberByID(userID string) {
 	}
 	groupID := ga.groupMembersOf.ID
 
-	go func() {
+	ga.goSafe(func() {
 		if err := ga.groupsClient.AddMember(groupID, userID); err != nil {
 			ga.logger.Error("Failed to add member", "error", err)
+			ga.reportMemberSync("Could not add member", err)
 			return
 		}
 		ga.logger.Info("Member added", "group_id", groupID, "user_id", userID)
+		ga.reportMemberSync("Member added", nil)
 		ga.refreshGroupMembers(groupID)
-	}()
+	})
 }
 
 // handleRemoveMember removes a member from the current group.
@@ -335,19 +403,102 @@ func (ga *GioApp) handleRemoveMember(userID string) {
 	}
 	groupID := ga.groupMembersOf.ID
 
-	go func() {
+	ga.goSafe(func() {
 		if err := ga.groupsClient.RemoveMember(groupID, userID); err != nil {
 			ga.logger.Error("Failed to remove member", "error", err)
+			ga.reportMemberSync("Could not remove member", err)
 			return
 		}
 		ga.logger.Info("Member removed", "group_id", groupID, "user_id", userID)
+		ga.reportMemberSync("Member removed", nil)
+		ga.refreshGroupMembers(groupID)
+	})
+}
+
+// handleInviteMember adds the user registered under the email field to the
+// current group.
+func (ga *GioApp) handleInviteMember() {
+	email := strings.TrimSpace(ga.widgetState.memberEmailEditor.Text())
+	if email == "" || ga.groupMembersOf == nil {
+		return
+	}
+	groupID := ga.groupMembersOf.ID
+
+	ga.goSafe(func() {
+		if err := ga.groupsClient.InviteByEmail(groupID, email); err != nil {
+			ga.logger.Error("Failed to invite member", "error", err)
+			ga.reportMemberSync("Could not invite "+email, err)
+			return
+		}
+		ga.logger.Info("Member invited", "group_id", groupID)
+		ga.widgetState.memberEmailEditor.SetText("")
+		// The server does not say whether the address has an account
+		ga.reportMemberSync(email+" invited if they have an account", nil)
+		ga.refreshGroupMembers(groupID)
+	})
+}
+
+// handleSetMemberRole changes a member's role in the current group.
+func (ga *GioApp) handleSetMemberRole(userID, role string) {
+	if ga.groupMembersOf == nil {
+		return
+	}
+	groupID := ga.groupMembersOf.ID
+
+	ga.goSafe(func() {
+		if err := ga.groupsClient.SetMemberRole(groupID, userID, role); err != nil {
+			ga.logger.Error("Failed to change member role", "error", err)
+			ga.reportMemberSync("Could not change role", err)
+			return
+		}
+		ga.logger.Info("Member role changed", "group_id", groupID, "user_id", userID, "role", role)
+		ga.reportMemberSync("Role changed", nil)
 		ga.refreshGroupMembers(groupID)
-	}()
+	})
+}
+
+// reportMemberSync records the outcome of a member change in the dialog's
+// status line. Failures also open the API error dialog, since the change did
+// not reach Authentik and the member list may not match what was asked for.
+func (ga *GioApp) reportMemberSync(action string, err error) {
+	ga.memberSyncStatus, ga.memberSyncFailed = memberSyncMessage(action, err)
+	if err != nil {
+		ga.showAPIErrorDialog(ga.memberSyncStatus)
+	}
+	ga.window.Invalidate()
+}
+
+const (
+	groupRoleMember = "member"
+	groupRoleAdmin  = "admin"
+)
+
+// toggledGroupRole is the role the role button switches a member to.
+func toggledGroupRole(role string) string {
+	if role == groupRoleAdmin {
+		return groupRoleMember
+	}
+	return groupRoleAdmin
+}
+
+// memberSyncMessage words the outcome of a member change. A bad_gateway
+// error means Authentik rejected or never received the change; the server's
+// own message is shown then, since it says what Authentik objected to.
+func memberSyncMessage(action string, err error) (string, bool) {
+	var apiErr *apiCommon.APIError
+	switch {
+	case err == nil:
+		return action + " — synced with Authentik", false
+	case errors.As(err, &apiErr) && apiErr.Code == apiCommon.CodeBadGateway:
+		return action + " — Authentik sync failed: " + apiErr.Message, true
+	default:
+		return action + ": " + err.Error(), true
+	}
 }
 
 // refreshGroupMembers reloads the members list and known users for the current group dialog.
 func (ga *GioApp) refreshGroupMembers(groupID string) {
-	members, err := ga.groupsClient.GetMembers(groupID)
+	members, err := ga.groupsClient.ListMembers(groupID)
 	if err != nil {
 		ga.logger.Error("Failed to refresh members", "error", err)
 		return
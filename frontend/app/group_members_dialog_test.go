@@ -0,0 +1,38 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	apiCommon "github.com/nishiki/frontend/pkg/api/common"
+)
+
+func TestMemberSyncMessage(t *testing.T) {
+	msg, failed := memberSyncMessage("Role changed", nil)
+	if failed || !strings.Contains(msg, "synced with Authentik") {
+		t.Errorf("success = %q, %v", msg, failed)
+	}
+
+	msg, failed = memberSyncMessage("Could not remove member", &apiCommon.APIError{
+		Status: 502, Code: apiCommon.CodeBadGateway, Message: "identity provider sync failed: remove user from group: permission denied",
+	})
+	if !failed || !strings.Contains(msg, "Authentik sync failed") || !strings.Contains(msg, "permission denied") {
+		t.Errorf("sync failure = %q, %v", msg, failed)
+	}
+
+	msg, failed = memberSyncMessage("Could not change role", &apiCommon.APIError{
+		Status: 403, Code: "forbidden", Message: "only group admins can manage members",
+	})
+	if !failed || strings.Contains(msg, "Authentik") {
+		t.Errorf("forbidden = %q, %v", msg, failed)
+	}
+}
+
+func TestToggledGroupRole(t *testing.T) {
+	if got := toggledGroupRole(groupRoleAdmin); got != groupRoleMember {
+		t.Errorf("admin toggles to %q", got)
+	}
+	if got := toggledGroupRole(groupRoleMember); got != groupRoleAdmin {
+		t.Errorf("member toggles to %q", got)
+	}
+}
@@ -0,0 +1,270 @@
+package app
+
+import (
+	"errors"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+const bytesPerMB = 1024 * 1024
+
+// quotaMeter is one limit of a group quota, laid out as a label over a bar
+type quotaMeter struct {
+	label string
+	used  int64
+	limit int64 // 0 is unlimited
+	bytes bool  // used and limit are byte counts
+}
+
+func groupQuotaMeters(q *types.GroupQuota) []quotaMeter {
+	return []quotaMeter{
+		{label: "Containers", used: int64(q.Usage.Containers), limit: int64(q.MaxContainers)},
+		{label: "Items", used: int64(q.Usage.Objects), limit: int64(q.MaxObjects)},
+		{label: "Photo storage", used: q.Usage.StorageBytes, limit: q.MaxStorageBytes, bytes: true},
+	}
+}
+
+// text describes the meter's usage, e.g. "4 of 10" or "3.2 MB · no limit"
+func (m quotaMeter) text() string {
+	format := func(n int64) string { return strconv.FormatInt(n, 10) }
+	if m.bytes {
+		format = formatBytes
+	}
+	if m.limit == 0 {
+		return format(m.used) + " · no limit"
+	}
+	return format(m.used) + " of " + format(m.limit)
+}
+
+// fraction is how full the meter is, from 0 to 1; unlimited meters are empty
+func (m quotaMeter) fraction() float32 {
+	if m.limit == 0 {
+		return 0
+	}
+	return min(float32(m.used)/float32(m.limit), 1)
+}
+
+// color warns as the limit nears and turns to danger once it is reached
+func (m quotaMeter) color() color.NRGBA {
+	switch f := m.fraction(); {
+	case f >= 1:
+		return theme.ColorDanger
+	case f >= 0.9:
+		return theme.ColorWarning
+	default:
+		return theme.ColorPrimary
+	}
+}
+
+// parseQuotaLimit reads a limit typed into the quota form. Blank and 0 both
+// mean unlimited.
+func parseQuotaLimit(text string) (int64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.New("limits must be whole numbers, or blank for no limit")
+	}
+	return n, nil
+}
+
+// quotaLimitText is a limit as the quota form shows it: blank when unlimited
+func quotaLimitText(limit int64) string {
+	if limit == 0 {
+		return ""
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+// loadGroupQuota fetches the quota of the group whose members dialog is open
+// and fills the form with its limits
+func (ga *GioApp) loadGroupQuota(groupID string) {
+	ga.groupQuota = nil
+	ga.groupQuotaStatus = ""
+	session := ga.session
+
+	ga.goSafe(func() {
+		quota, err := ga.groupsClient.Quota(groupID)
+
+		ga.doInSession(session, func() {
+			if ga.groupMembersOf == nil || ga.groupMembersOf.ID != groupID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load group quota", "error", err)
+				ga.groupQuotaStatus = "Could not load the quota: " + err.Error()
+				return
+			}
+			ga.setGroupQuota(quota)
+		})
+	})
+}
+
+func (ga *GioApp) setGroupQuota(quota *types.GroupQuota) {
+	ga.groupQuota = quota
+	ga.widgetState.quotaContainersEditor.SetText(quotaLimitText(int64(quota.MaxContainers)))
+	ga.widgetState.quotaObjectsEditor.SetText(quotaLimitText(int64(quota.MaxObjects)))
+	ga.widgetState.quotaStorageEditor.SetText(quotaLimitText(quota.MaxStorageBytes / bytesPerMB))
+}
+
+// saveGroupQuota sends the limits in the quota form
+func (ga *GioApp) saveGroupQuota() {
+	if ga.groupMembersOf == nil || ga.groupQuotaBusy {
+		return
+	}
+	var limits [3]int64
+	for i, editor := range []*widget.Editor{
+		&ga.widgetState.quotaContainersEditor,
+		&ga.widgetState.quotaObjectsEditor,
+		&ga.widgetState.quotaStorageEditor,
+	} {
+		n, err := parseQuotaLimit(editor.Text())
+		if err != nil {
+			ga.groupQuotaStatus = err.Error()
+			return
+		}
+		limits[i] = n
+	}
+	req := types.SetGroupQuotaRequest{
+		MaxContainers:   int(limits[0]),
+		MaxObjects:      int(limits[1]),
+		MaxStorageBytes: limits[2] * bytesPerMB,
+	}
+
+	ga.groupQuotaBusy = true
+	ga.groupQuotaStatus = ""
+	groupID := ga.groupMembersOf.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		quota, err := ga.groupsClient.SetQuota(groupID, req)
+
+		ga.doInSession(session, func() {
+			ga.groupQuotaBusy = false
+			if err != nil {
+				ga.logger.Error("Failed to set group quota", "error", err)
+				ga.groupQuotaStatus = "Could not save the quota: " + err.Error()
+				return
+			}
+			ga.logger.Info("Group quota set", "group_id", groupID)
+			if ga.groupMembersOf != nil && ga.groupMembersOf.ID == groupID {
+				ga.setGroupQuota(quota)
+				ga.groupQuotaStatus = "Quota saved"
+			}
+		})
+	})
+}
+
+// renderGroupQuotaSection renders the group's usage against its quota and,
+// for admins, the form to change the limits
+func (ga *GioApp) renderGroupQuotaSection(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.quotaSaveButton.Clicked(gtx) {
+		ga.saveGroupQuota()
+	}
+
+	q := ga.groupQuota
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, "Quota")
+				label.Color = theme.ColorTextSecondary
+				return label.Layout(gtx)
+			})
+		}),
+	}
+	if q == nil && ga.groupQuotaStatus == "" {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body2(ga.theme.Theme, "Loading...")
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}))
+	}
+	if q != nil {
+		for _, m := range groupQuotaMeters(q) {
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderQuotaMeter(gtx, m)
+				})
+			}))
+		}
+		if q.CanEdit {
+			children = append(children, layout.Rigid(ga.renderGroupQuotaForm))
+		}
+	}
+	children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+		if ga.groupQuotaStatus == "" {
+			return layout.Dimensions{}
+		}
+		label := material.Body2(ga.theme.Theme, ga.groupQuotaStatus)
+		label.Color = theme.ColorTextSecondary
+		return label.Layout(gtx)
+	}))
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// renderQuotaMeter renders one limit: its name and usage over a bar that
+// fills as the limit is approached
+func (ga *GioApp) renderQuotaMeter(gtx layout.Context, m quotaMeter) layout.Dimensions {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, m.label)
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, m.text())
+					label.Color = theme.ColorTextSecondary
+					if m.fraction() >= 1 {
+						label.Color = theme.ColorDanger
+					}
+					return label.Layout(gtx)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			bar := material.ProgressBar(ga.theme.Theme, m.fraction())
+			bar.Color = m.color()
+			bar.TrackColor = theme.ColorSurfaceAlt
+			return bar.Layout(gtx)
+		}),
+	)
+}
+
+// renderGroupQuotaForm renders the admin's editors for the three limits
+func (ga *GioApp) renderGroupQuotaForm(gtx layout.Context) layout.Dimensions {
+	field := func(label string, editor *widget.Editor) layout.FlexChild {
+		return layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, label, editor, "No limit")
+			})
+		})
+	}
+	saveLabel := "Save quota"
+	if ga.groupQuotaBusy {
+		saveLabel = "Saving..."
+	}
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		field("Max containers", &ga.widgetState.quotaContainersEditor),
+		field("Max items", &ga.widgetState.quotaObjectsEditor),
+		field("Max photos (MB)", &ga.widgetState.quotaStorageEditor),
+		layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.quotaSaveButton, saveLabel)),
+	)
+}
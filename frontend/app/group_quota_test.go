@@ -0,0 +1,68 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+func TestQuotaMeter(t *testing.T) {
+	tests := []struct {
+		meter    quotaMeter
+		text     string
+		fraction float32
+	}{
+		{quotaMeter{used: 4, limit: 10}, "4 of 10", 0.4},
+		{quotaMeter{used: 7}, "7 · no limit", 0},
+		{quotaMeter{used: 12, limit: 10}, "12 of 10", 1},
+		{quotaMeter{used: 3 << 20, limit: 100 << 20, bytes: true}, "3.0 MB of 100.0 MB", 0.03},
+	}
+	for _, tt := range tests {
+		if got := tt.meter.text(); got != tt.text {
+			t.Errorf("%+v text = %q, want %q", tt.meter, got, tt.text)
+		}
+		if got := tt.meter.fraction(); got != tt.fraction {
+			t.Errorf("%+v fraction = %v, want %v", tt.meter, got, tt.fraction)
+		}
+	}
+
+	if got := (quotaMeter{used: 95, limit: 100}).color(); got != theme.ColorWarning {
+		t.Errorf("meter at 95%% is %v, want the warning colour", got)
+	}
+	if got := (quotaMeter{used: 100, limit: 100}).color(); got != theme.ColorDanger {
+		t.Errorf("full meter is %v, want the danger colour", got)
+	}
+}
+
+func TestGroupQuotaMeters(t *testing.T) {
+	q := &types.GroupQuota{MaxContainers: 5, MaxStorageBytes: 1 << 30}
+	q.Usage.Containers = 2
+	q.Usage.Objects = 40
+	q.Usage.StorageBytes = 1 << 20
+
+	meters := groupQuotaMeters(q)
+	want := []string{"2 of 5", "40 · no limit", "1.0 MB of 1.0 GB"}
+	for i, m := range meters {
+		if got := m.text(); got != want[i] {
+			t.Errorf("%s meter = %q, want %q", m.label, got, want[i])
+		}
+	}
+}
+
+func TestParseQuotaLimit(t *testing.T) {
+	for text, want := range map[string]int64{"": 0, " 0 ": 0, "25": 25} {
+		got, err := parseQuotaLimit(text)
+		if err != nil || got != want {
+			t.Errorf("parseQuotaLimit(%q) = %d, %v; want %d", text, got, err, want)
+		}
+	}
+	for _, text := range []string{"-1", "2.5", "ten"} {
+		if _, err := parseQuotaLimit(text); err == nil {
+			t.Errorf("parseQuotaLimit(%q) accepted a bad limit", text)
+		}
+	}
+	if got := quotaLimitText(0); got != "" {
+		t.Errorf("quotaLimitText(0) = %q, want blank", got)
+	}
+}
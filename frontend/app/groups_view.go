@@ -2,9 +2,11 @@ package app
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"gioui.org/font"
+	"gioui.org/io/event"
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/unit"
@@ -15,6 +17,16 @@ import (
 	"github.com/nishiki/frontend/ui/widgets"
 )
 
+// openCreateGroupDialog resets the group editors and shows the create group dialog
+func (ga *GioApp) openCreateGroupDialog() {
+	ga.logger.Info("Opening create group dialog")
+	ga.showGroupDialog = true
+	ga.groupDialogMode = "create"
+	// Clear editors
+	ga.widgetState.groupNameEditor.SetText("")
+	ga.widgetState.groupDescriptionEditor.SetText("")
+}
+
 // renderGroupsView renders the groups management view with CRUD operations
 func (ga *GioApp) renderGroupsView(gtx layout.Context) layout.Dimensions {
 	// Handle join button click
@@ -24,12 +36,7 @@ func (ga *GioApp) renderGroupsView(gtx layout.Context) layout.Dimensions {
 
 	// Handle create button click
 	if ga.widgetState.groupsCreateButton.Clicked(gtx) {
-		ga.logger.Info("Opening create group dialog")
-		ga.showGroupDialog = true
-		ga.groupDialogMode = "create"
-		// Clear editors
-		ga.widgetState.groupNameEditor.SetText("")
-		ga.widgetState.groupDescriptionEditor.SetText("")
+		ga.openCreateGroupDialog()
 	}
 
 	// Ensure we have group item states for all groups
@@ -118,7 +125,7 @@ func (ga *GioApp) renderGroupsToolbar(gtx layout.Context) layout.Dimensions {
 
 			// Create button
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.groupsCreateButton, "+")(gtx)
+				return widgets.PrimaryIconButton(ga.theme.Theme, &ga.widgetState.groupsCreateButton, "+", "New group")(gtx)
 			}),
 		)
 	})
@@ -126,6 +133,9 @@ func (ga *GioApp) renderGroupsToolbar(gtx layout.Context) layout.Dimensions {
 
 // renderGroupsList renders the list of groups
 func (ga *GioApp) renderGroupsList(gtx layout.Context) layout.Dimensions {
+	if !ga.groupsLoaded {
+		return widgets.SkeletonList(skeletonRows)(gtx)
+	}
 	if len(ga.groups) == 0 {
 		return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 			label := material.H5(ga.theme.Theme, "No groups yet")
@@ -272,7 +282,7 @@ func (ga *GioApp) handleGroupCreate() {
 
 	ga.logger.Info("Creating group", "name", name)
 
-	go func() {
+	ga.goSafe(func() {
 		req := types.CreateGroupRequest{
 			Name:        name,
 			Description: description,
@@ -288,7 +298,7 @@ func (ga *GioApp) handleGroupCreate() {
 		ga.do(func() {
 			ga.groups = append(ga.groups, *group)
 		})
-	}()
+	})
 
 	// Close dialog
 	ga.showGroupDialog = false
@@ -312,30 +322,26 @@ func (ga *GioApp) handleGroupUpdate() {
 
 	ga.logger.Info("Updating group", "group_id", ga.selectedGroup.ID, "name", name)
 
-	groupID := ga.selectedGroup.ID
-
-	go func() {
-		req := types.UpdateGroupRequest{
-			Name:        name,
-			Description: description,
-		}
-
-		updated, err := ga.groupsClient.Update(groupID, req)
+	original := *ga.selectedGroup
+	req := types.UpdateGroupRequest{
+		Name:        name,
+		Description: description,
+	}
+	edited := original
+	edited.Name, edited.Description = name, description
+
+	ga.optimisticUpdate("update group", func() {
+		ga.setGroup(edited)
+	}, func() {
+		ga.setGroup(original)
+	}, func() (func(), error) {
+		updated, err := ga.groupsClient.Update(original.ID, req)
 		if err != nil {
-			ga.logger.Error("Failed to update group", "error", err)
-			return
+			return nil, err
 		}
-
-		ga.logger.Info("Group updated successfully", "group_id", groupID)
-		ga.do(func() {
-			for i, g := range ga.groups {
-				if g.ID == updated.ID {
-					ga.groups[i] = *updated
-					break
-				}
-			}
-		})
-	}()
+		ga.logger.Info("Group updated successfully", "group_id", original.ID)
+		return func() { ga.setGroup(*updated) }, nil
+	})
 
 	// Close dialog
 	ga.showGroupDialog = false
@@ -353,24 +359,21 @@ func (ga *GioApp) handleGroupDelete() {
 	ga.logger.Info("Deleting group", "group_id", ga.deleteGroupID)
 
 	groupID := ga.deleteGroupID
-
-	go func() {
-		err := ga.groupsClient.Delete(groupID)
-		if err != nil {
-			ga.logger.Error("Failed to delete group", "error", err)
-			return
-		}
-
-		ga.logger.Info("Group deleted successfully", "group_id", groupID)
-		ga.do(func() {
-			for i, g := range ga.groups {
-				if g.ID == groupID {
-					ga.groups = append(ga.groups[:i], ga.groups[i+1:]...)
-					break
-				}
+	index := slices.IndexFunc(ga.groups, func(g Group) bool { return g.ID == groupID })
+	if index >= 0 {
+		removed := ga.groups[index]
+		ga.optimisticUpdate("delete group", func() {
+			ga.groups = slices.Delete(ga.groups, index, index+1)
+		}, func() {
+			ga.groups = reinsert(ga.groups, index, removed)
+		}, func() (func(), error) {
+			if err := ga.groupsClient.Delete(groupID); err != nil {
+				return nil, err
 			}
+			ga.logger.Info("Group deleted successfully", "group_id", groupID)
+			return nil, nil
 		})
-	}()
+	}
 
 	// Close dialog
 	ga.showDeleteConfirm = false
@@ -378,6 +381,16 @@ func (ga *GioApp) handleGroupDelete() {
 	ga.window.Invalidate()
 }
 
+// setGroup replaces a group in the list with the given version
+func (ga *GioApp) setGroup(updated Group) {
+	for i, g := range ga.groups {
+		if g.ID == updated.ID {
+			ga.groups[i] = updated
+			return
+		}
+	}
+}
+
 // renderGroupDialog renders the create/edit group dialog
 func (ga *GioApp) renderGroupDialog(gtx layout.Context) layout.Dimensions {
 	if !ga.showGroupDialog {
@@ -394,15 +407,20 @@ func (ga *GioApp) renderGroupDialog(gtx layout.Context) layout.Dimensions {
 		return layout.Dimensions{}
 	}
 
-	// Handle cancel button
-	if ga.widgetState.groupDialogCancel.Clicked(gtx) {
+	// Handle cancel button and Escape
+	if ga.widgetState.groupDialogCancel.Clicked(gtx) || ga.widgetState.groupModalTrap.Update(gtx) {
 		ga.showGroupDialog = false
 		ga.selectedGroup = nil
 		return layout.Dimensions{}
 	}
 
+	title := "Create Group"
+	if ga.groupDialogMode == "edit" {
+		title = "Edit Group"
+	}
+
 	// Render modal overlay
-	return ga.renderModal(gtx, func(gtx layout.Context) layout.Dimensions {
+	return ga.renderModal(gtx, title, &ga.widgetState.groupNameEditor, func(gtx layout.Context) layout.Dimensions {
 		card := widgets.Card{
 			BackgroundColor: theme.ColorSurface,
 			CornerRadius:    unit.Dp(theme.RadiusLG),
@@ -415,10 +433,6 @@ func (ga *GioApp) renderGroupDialog(gtx layout.Context) layout.Dimensions {
 			}.Layout(gtx,
 				// Title
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					title := "Create Group"
-					if ga.groupDialogMode == "edit" {
-						title = "Edit Group"
-					}
 					return layout.Inset{Bottom: unit.Dp(theme.Spacing4)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 						label := material.H6(ga.theme.Theme, title)
 						label.Font.Weight = font.Bold
@@ -506,15 +520,15 @@ func (ga *GioApp) renderDeleteConfirmDialog(gtx layout.Context) layout.Dimension
 		return layout.Dimensions{}
 	}
 
-	// Handle cancel button
-	if ga.widgetState.groupDialogCancel.Clicked(gtx) {
+	// Handle cancel button and Escape
+	if ga.widgetState.groupDialogCancel.Clicked(gtx) || ga.widgetState.groupModalTrap.Update(gtx) {
 		ga.showDeleteConfirm = false
 		ga.deleteGroupID = ""
 		return layout.Dimensions{}
 	}
 
 	// Render modal overlay
-	return ga.renderModal(gtx, func(gtx layout.Context) layout.Dimensions {
+	return ga.renderModal(gtx, "Delete Group", nil, func(gtx layout.Context) layout.Dimensions {
 		card := widgets.Card{
 			BackgroundColor: theme.ColorSurface,
 			CornerRadius:    unit.Dp(theme.RadiusLG),
@@ -564,8 +578,10 @@ func (ga *GioApp) renderDeleteConfirmDialog(gtx layout.Context) layout.Dimension
 	})
 }
 
-// renderModal renders a modal overlay with content
-func (ga *GioApp) renderModal(gtx layout.Context, content layout.Widget) layout.Dimensions {
+// renderModal renders a modal overlay with content, keeping keyboard focus
+// inside it. Screen readers announce it as label; initial is focused when
+// it opens.
+func (ga *GioApp) renderModal(gtx layout.Context, label string, initial event.Tag, content layout.Widget) layout.Dimensions {
 	return layout.Stack{}.Layout(gtx,
 		// Semi-transparent overlay
 		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
@@ -583,7 +599,7 @@ func (ga *GioApp) renderModal(gtx layout.Context, content layout.Widget) layout.
 			return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 				// Constrain width for dialogs
 				gtx.Constraints.Max.X = gtx.Dp(unit.Dp(400))
-				return content(gtx)
+				return ga.widgetState.groupModalTrap.Layout(gtx, label, initial, content)
 			})
 		}),
 	)
@@ -1,13 +1,11 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
 	"gioui.org/font"
 	"gioui.org/layout"
-	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
@@ -22,6 +20,7 @@ func (ga *GioApp) dismissImportCreate() {
 	ga.showImportCreateDialog = false
 	ga.importData = nil
 	ga.importFilename = ""
+	ga.importFileContent = nil
 	ga.importCreateRunning = false
 	ga.importCreateError = ""
 	ga.importContainerCol = nil
@@ -60,7 +59,7 @@ func (ga *GioApp) renderImportCreateDialog(gtx layout.Context) layout.Dimensions
 		name := strings.TrimSpace(ga.widgetState.importCreateNameEditor.Text())
 		if name != "" && !ga.importCreateRunning {
 			if ga.widgetState.importCreateInferSchemaCheck.Value {
-				go ga.executeImportCreate()
+				ga.executeImportCreate()
 			} else {
 				ga.openSchemaEditorForImport("create")
 			}
@@ -80,18 +79,7 @@ func (ga *GioApp) renderImportCreateDialog(gtx layout.Context) layout.Dimensions
 	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
 		// Loading state
 		if ga.importCreateRunning {
-			return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
-				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					return layout.Inset{
-						Top:    unit.Dp(theme.Spacing4),
-						Bottom: unit.Dp(theme.Spacing4),
-					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						label := material.Body1(ga.theme.Theme, "Creating collection and importing...")
-						label.Alignment = text.Middle
-						return label.Layout(gtx)
-					})
-				}),
-			)
+			return ga.renderImportProgress(gtx)
 		}
 
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -102,21 +90,7 @@ func (ga *GioApp) renderImportCreateDialog(gtx layout.Context) layout.Dimensions
 					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 						// File info
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-										label := material.Body1(ga.theme.Theme, "File: "+ga.importFilename)
-										label.Font.Weight = font.Bold
-										return label.Layout(gtx)
-									}),
-									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-										info := fmt.Sprintf("Format: %s | Items: %d", ga.importData.Format, len(ga.importData.Data))
-										label := material.Body2(ga.theme.Theme, info)
-										label.Color = theme.ColorTextSecondary
-										return label.Layout(gtx)
-									}),
-								)
-							})
+							return ga.renderImportFileInfo(gtx, &ga.widgetState.importCreateChooseFile, true)
 						}),
 
 						// Error display
@@ -307,11 +281,13 @@ func (ga *GioApp) renderImportCreateColumnMapping(gtx layout.Context) layout.Dim
 			label.Font.Weight = font.Bold
 			return label.Layout(gtx)
 		}),
+		// Source app
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, ga.renderImportSourceFormatSelector)
+		}),
 		// Name column
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return ga.renderChipSelector(gtx, "Name column:", nameChips)
-			})
+			return ga.renderChipSelector(gtx, "Name column:", nameChips)
 		}),
 		// Container column
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
@@ -352,6 +328,7 @@ func (ga *GioApp) executeImportCreate() {
 
 	ga.importCreateRunning = true
 	ga.importCreateError = ""
+	ga.importProgress = types.ImportJob{}
 	ga.window.Invalidate()
 
 	objectType := ga.selectedObjectType
@@ -359,6 +336,7 @@ func (ga *GioApp) executeImportCreate() {
 	location := strings.TrimSpace(ga.widgetState.importCreateLocationEditor.Text())
 	containerCol := ga.importContainerCol
 	nameCol := ga.importNameColumn
+	sourceFormat := ga.importSourceFormat
 	inferSchema := ga.widgetState.importCreateInferSchemaCheck.Value
 	userID := ga.currentUser.ID
 
@@ -381,135 +359,104 @@ func (ga *GioApp) executeImportCreate() {
 		createReq.Location = location
 	}
 
-	collection, err := ga.collectionsClient.Create(userID, createReq)
-	if err != nil {
-		ga.do(func() {
-			ga.importCreateRunning = false
-			ga.importCreateError = fmt.Sprintf("Failed to create collection: %v", err)
-		})
-		return
-	}
-
-	ga.logger.Info("Collection created for import", "collection_id", collection.ID, "name", name)
-
 	// Step 2: Import data
 	distMode := "automatic"
-	if containerCol != nil {
+	if containerCol != nil || sourceFormat != "" {
 		distMode = "location"
 	}
 
 	filteredData := filterOmittedColumns(ga.importData.Data, ga.importOmittedColumns)
-	importReq := map[string]any{
-		"format":            ga.importData.Format,
-		"data":              filteredData,
-		"distribution_mode": distMode,
-		"infer_schema":      inferSchema,
-	}
-	if containerCol != nil {
-		importReq["location_column"] = *containerCol
-	}
-	if nameCol != "" {
-		importReq["name_column"] = nameCol
-	}
-
-	endpoint := fmt.Sprintf("/accounts/%s/collections/%s/import", userID, collection.ID)
-	resp, err := ga.apiClient.Post(endpoint, importReq)
-	if err != nil {
-		ga.do(func() {
-			ga.importCreateRunning = false
-			ga.importCreateError = fmt.Sprintf("Collection created but import failed: %v", err)
-			// Add collection to list so user can navigate to it
-			ga.collections = append(ga.collections, *collection)
-		})
-		return
+	upload := ga.importUpload()
+	importReq := types.BulkImportCollectionRequest{
+		Format:           ga.importData.Format,
+		Data:             filteredData,
+		DistributionMode: distMode,
+		InferSchema:      inferSchema,
+	}
+	if sourceFormat != "" {
+		importReq.SourceFormat = sourceFormat
+	} else {
+		if containerCol != nil {
+			importReq.LocationColumn = *containerCol
+		}
+		importReq.NameColumn = nameCol
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		_ = json.NewDecoder(resp.Body).Decode(&errResp)
-		resp.Body.Close()
-		errMsg := errResp.Error
-		if errMsg == "" {
-			errMsg = fmt.Sprintf("server error (status %d)", resp.StatusCode)
+	// Create the collection, then import into it
+	ga.goSafe(func() {
+		collection, err := ga.collectionsClient.Create(userID, createReq)
+		if err != nil {
+			ga.do(func() {
+				ga.importCreateRunning = false
+				ga.importCreateError = fmt.Sprintf("Failed to create collection: %v", err)
+			})
+			return
 		}
-		ga.do(func() {
-			ga.importCreateRunning = false
-			ga.importCreateError = "Collection created but import failed: " + errMsg
-			ga.collections = append(ga.collections, *collection)
-		})
-		return
-	}
 
-	var result struct {
-		Imported          int      `json:"imported"`
-		Failed            int      `json:"failed"`
-		Total             int      `json:"total"`
-		ContainersCreated int      `json:"containers_created"`
-		Errors            []string `json:"errors,omitempty"`
-	}
+		ga.logger.Info("Collection created for import", "collection_id", collection.ID, "name", name)
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		ga.do(func() {
-			ga.importCreateRunning = false
-			ga.importCreateError = fmt.Sprintf("Failed to parse import response: %v", err)
-			ga.collections = append(ga.collections, *collection)
-		})
-		return
-	}
+		result, err := ga.runImportJob(userID, collection.ID, importReq, upload)
+		if err != nil {
+			ga.do(func() {
+				ga.importCreateRunning = false
+				ga.importCreateError = fmt.Sprintf("Collection created but import failed: %v", err)
+				// Add collection to list so user can navigate to it
+				ga.collections = append(ga.collections, *collection)
+			})
+			return
+		}
 
-	ga.logger.Info("Import-create completed",
-		"imported", result.Imported,
-		"failed", result.Failed,
-		"total", result.Total,
-		"containers_created", result.ContainersCreated)
+		ga.logger.Info("Import-create completed",
+			"imported", result.Imported,
+			"failed", result.Failed,
+			"total", result.Total)
 
-	if result.Failed > 0 {
-		ga.do(func() {
-			ga.importCreateRunning = false
-			var errSummary string
-			if len(result.Errors) > 0 {
-				maxShow := min(len(result.Errors), 5)
-				errSummary = strings.Join(result.Errors[:maxShow], "; ")
-				if len(result.Errors) > 5 {
-					errSummary += fmt.Sprintf(" ...and %d more", len(result.Errors)-5)
+		if result.Failed > 0 {
+			ga.do(func() {
+				ga.importCreateRunning = false
+				var errSummary string
+				if len(result.Errors) > 0 {
+					maxShow := min(len(result.Errors), 5)
+					errSummary = strings.Join(result.Errors[:maxShow], "; ")
+					if len(result.Errors) > 5 {
+						errSummary += fmt.Sprintf(" ...and %d more", len(result.Errors)-5)
+					}
 				}
-			}
-			ga.importCreateError = fmt.Sprintf("Imported %d of %d items (%d failed). %s",
-				result.Imported, result.Total, result.Failed, errSummary)
+				ga.importCreateError = fmt.Sprintf("Imported %d of %d items (%d failed). %s",
+					result.Imported, result.Total, result.Failed, errSummary)
+				ga.collections = append(ga.collections, *collection)
+			})
+			return
+		}
+
+		// Success — navigate to the new collection
+		collectionID := collection.ID
+		ga.do(func() {
 			ga.collections = append(ga.collections, *collection)
+			ga.selectedCollection = collection
+			ga.currentView = ViewCollectionDetailGio
+			ga.dismissImportCreate()
 		})
-		return
-	}
-
-	// Success — navigate to the new collection
-	collectionID := collection.ID
-	ga.do(func() {
-		ga.collections = append(ga.collections, *collection)
-		ga.selectedCollection = collection
-		ga.currentView = ViewCollectionDetailGio
-		ga.dismissImportCreate()
-	})
 
-	// Refetch collection to pick up the schema (inferred or user-defined).
-	if inferSchema || userSchema != nil {
-		updated, err := ga.collectionsClient.Get(userID, collectionID)
-		if err == nil {
-			ga.do(func() {
-				ga.selectedCollection = updated
-				for i, c := range ga.collections {
-					if c.ID == updated.ID {
-						ga.collections[i] = *updated
-						break
+		// Refetch collection to pick up the schema (inferred or user-defined).
+		if inferSchema || userSchema != nil {
+			updated, err := ga.collectionsClient.Get(userID, collectionID)
+			if err == nil {
+				ga.do(func() {
+					ga.selectedCollection = updated
+					for i, c := range ga.collections {
+						if c.ID == updated.ID {
+							ga.collections[i] = *updated
+							break
+						}
 					}
-				}
-				ga.objectSortSpecs = nil
-				ga.objectGroupByField = ""
-				ga.invalidateObjectCaches()
-			})
+					ga.objectSortSpecs = nil
+					ga.objectGroupByField = ""
+					ga.invalidateObjectCaches()
+				})
+			}
 		}
-	}
 
-	ga.fetchContainersAndObjects()
+		ga.do(ga.fetchContainersAndObjects)
+	})
 }
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
+	collectionsAPI "github.com/nishiki/frontend/pkg/api/collections"
 	"github.com/nishiki/frontend/pkg/types"
 )
 
@@ -20,19 +22,20 @@ type ImportData struct {
 
 // importResult holds the outcome of a completed import for display.
 type importResult struct {
-	Imported          int
-	Failed            int
-	Total             int
-	ContainersCreated int
+	Imported int
+	Failed   int
+	Total    int
 }
 
 func (ga *GioApp) dismissImport() {
 	ga.showImportPreview = false
 	ga.importData = nil
 	ga.importFilename = ""
+	ga.importFileContent = nil
 	ga.importRunning = false
 	ga.importResult = nil
 	ga.importOmittedColumns = nil
+	ga.importSourceFormat = ""
 	ga.schemaEditorForImport = false
 	ga.importSchemaReturnTo = ""
 }
@@ -60,7 +63,9 @@ func (ga *GioApp) handleImportFileContent(content string, filename string) {
 
 	ga.importData = importData
 	ga.importFilename = filename
+	ga.importFileContent = []byte(content)
 	ga.importOmittedColumns = make(map[string]bool)
+	ga.importSourceFormat = ""
 
 	// Initialize column mapping with auto-detected values
 	ga.importNameColumn = detectNameColumn(importData.Data)
@@ -183,6 +188,25 @@ func filterOmittedColumns(data []map[string]any, omitted map[string]bool) []map[
 	return out
 }
 
+// importUpload is the import file to upload whole, with the omitted columns
+// for the backend to drop, or nil when the rows did not come from a file.
+func (ga *GioApp) importUpload() *collectionsAPI.ImportFile {
+	if ga.importFileContent == nil {
+		return nil
+	}
+	upload := &collectionsAPI.ImportFile{
+		Filename: ga.importFilename,
+		Content:  ga.importFileContent,
+	}
+	for column, omitted := range ga.importOmittedColumns {
+		if omitted {
+			upload.OmitColumns = append(upload.OmitColumns, column)
+		}
+	}
+	slices.Sort(upload.OmitColumns)
+	return upload
+}
+
 // nonOmittedColumns returns the columns sorted, filtered by the omit set.
 func nonOmittedColumns(data []map[string]any, omitted map[string]bool) []string {
 	all := importColumns(data)
@@ -269,30 +293,36 @@ func filenameToCollectionName(filename string) string {
 
 // executeImport sends the import request to the backend.
 func (ga *GioApp) executeImport() {
-	if ga.selectedCollection == nil || ga.importData == nil {
+	if ga.selectedCollection == nil || ga.importData == nil || ga.currentUser == nil {
 		ga.logger.Error("Cannot execute import: missing collection or data")
 		return
 	}
 
 	ga.logger.Info("Executing import", "collection_id", ga.selectedCollection.ID, "items", len(ga.importData.Data))
 	ga.importRunning = true
-
-	go func() {
-		locationCol := ga.importLocationColumn
-		nameCol := ga.importNameColumn
-		inferSchema := ga.widgetState.importInferSchemaCheck.Value
-		filteredData := filterOmittedColumns(ga.importData.Data, ga.importOmittedColumns)
-		// schemaChanged covers both inferred and user-supplied schemas; either
-		// one means the in-memory collection is now stale and must be refetched.
-		schemaChanged := inferSchema
-
+	ga.importProgress = types.ImportJob{}
+
+	accountID := ga.currentUser.ID
+	collectionID := ga.selectedCollection.ID
+	locationCol := ga.importLocationColumn
+	nameCol := ga.importNameColumn
+	sourceFormat := ga.importSourceFormat
+	inferSchema := ga.widgetState.importInferSchemaCheck.Value
+	format := ga.importData.Format
+	filteredData := filterOmittedColumns(ga.importData.Data, ga.importOmittedColumns)
+	upload := ga.importUpload()
+	userSchema := ga.pendingImportSchema
+	// schemaChanged covers both inferred and user-supplied schemas; either
+	// one means the in-memory collection is now stale and must be refetched.
+	schemaChanged := inferSchema || userSchema != nil
+
+	ga.goSafe(func() {
 		// A user-defined schema overrides inference: apply it to the collection
 		// first, then run the import without inferring.
-		if ga.pendingImportSchema != nil {
+		if userSchema != nil {
 			inferSchema = false
-			schemaChanged = true
-			if err := ga.collectionsClient.UpdateSchema(ga.currentUser.ID, ga.selectedCollection.ID, types.UpdatePropertySchemaRequest{
-				PropertySchema: *ga.pendingImportSchema,
+			if err := ga.collectionsClient.UpdateSchema(accountID, collectionID, types.UpdatePropertySchemaRequest{
+				PropertySchema: *userSchema,
 			}); err != nil {
 				ga.do(func() {
 					ga.importRunning = false
@@ -302,95 +332,73 @@ func (ga *GioApp) executeImport() {
 				})
 				return
 			}
-			ga.pendingImportSchema = nil
+			ga.do(func() { ga.pendingImportSchema = nil })
 		}
 
 		distMode := "automatic"
-		if locationCol != nil {
+		if locationCol != nil || sourceFormat != "" {
 			distMode = "location"
 		}
 
-		req := map[string]any{
-			"format":            ga.importData.Format,
-			"data":              filteredData,
-			"distribution_mode": distMode,
-			"infer_schema":      inferSchema,
-		}
-		if locationCol != nil {
-			req["location_column"] = *locationCol
+		req := types.BulkImportCollectionRequest{
+			Format:           format,
+			Data:             filteredData,
+			DistributionMode: distMode,
+			InferSchema:      inferSchema,
 		}
-		if nameCol != "" {
-			req["name_column"] = nameCol
+		if sourceFormat != "" {
+			// The backend maps the app's columns to name and location itself
+			req.SourceFormat = sourceFormat
+		} else {
+			if locationCol != nil {
+				req.LocationColumn = *locationCol
+			}
+			req.NameColumn = nameCol
 		}
 
-		endpoint := fmt.Sprintf("/accounts/%s/collections/%s/import", ga.currentUser.ID, ga.selectedCollection.ID)
-		resp, err := ga.apiClient.Post(endpoint, req)
+		// Large files are imported in the background; the dialog shows the
+		// job's progress until it finishes
+		result, err := ga.runImportJob(accountID, collectionID, req, upload)
 		if err != nil {
-			ga.importRunning = false
 			ga.logger.Error("Import failed", "error", err)
-			return
-		}
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			var errResp struct {
-				Error string `json:"error"`
-			}
-			_ = json.NewDecoder(resp.Body).Decode(&errResp)
-			resp.Body.Close()
-			ga.importRunning = false
-			errMsg := errResp.Error
-			if errMsg == "" {
-				errMsg = fmt.Sprintf("server error (status %d)", resp.StatusCode)
-			}
-			ga.logger.Error("Import failed", "error", errMsg)
-			ga.importData.Errors = []string{errMsg}
-			ga.window.Invalidate()
-			return
-		}
-
-		var result struct {
-			Imported          int      `json:"imported"`
-			Failed            int      `json:"failed"`
-			Total             int      `json:"total"`
-			ContainersCreated int      `json:"containers_created"`
-			Errors            []string `json:"errors,omitempty"`
-		}
-
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			ga.importRunning = false
-			ga.logger.Error("Failed to parse import response", "error", err)
+			ga.do(func() {
+				ga.importRunning = false
+				if ga.importData != nil {
+					ga.importData.Errors = []string{err.Error()}
+				}
+			})
 			return
 		}
 
 		ga.logger.Info("Import completed",
 			"imported", result.Imported,
 			"failed", result.Failed,
-			"total", result.Total,
-			"containers_created", result.ContainersCreated)
+			"total", result.Total)
 
-		ga.importRunning = false
-		if result.Failed > 0 {
-			for _, errMsg := range result.Errors {
-				ga.logger.Warn("Import item failed", "error", errMsg)
-			}
-			// Keep dialog open and show errors so the user can see what failed
-			ga.importData.Data = nil // Clear preview data
-			ga.importData.Errors = result.Errors
-			ga.importResult = &importResult{
-				Imported:          result.Imported,
-				Failed:            result.Failed,
-				Total:             result.Total,
-				ContainersCreated: result.ContainersCreated,
+		ga.do(func() {
+			ga.importRunning = false
+			if result.Failed > 0 {
+				for _, errMsg := range result.Errors {
+					ga.logger.Warn("Import item failed", "error", errMsg)
+				}
+				// Keep dialog open and show errors so the user can see what failed
+				if ga.importData != nil {
+					ga.importData.Data = nil // Clear preview data
+					ga.importData.Errors = result.Errors
+				}
+				ga.importResult = &importResult{
+					Imported: result.Imported,
+					Failed:   result.Failed,
+					Total:    result.Total,
+				}
+			} else {
+				ga.dismissImport()
 			}
-		} else {
-			ga.dismissImport()
-		}
+		})
 
 		// Refetch collection to pick up inferred or user-defined schema
 		if schemaChanged {
-			userID := ga.currentUser.ID
-			collectionID := ga.selectedCollection.ID
-			updated, err := ga.collectionsClient.Get(userID, collectionID)
+			updated, err := ga.collectionsClient.Get(accountID, collectionID)
 			if err != nil {
 				ga.logger.Warn("Failed to refetch collection after import", "error", err)
 			} else {
@@ -410,6 +418,6 @@ func (ga *GioApp) executeImport() {
 			}
 		}
 
-		ga.fetchContainersAndObjects()
-	}()
+		ga.do(ga.fetchContainersAndObjects)
+	})
 }
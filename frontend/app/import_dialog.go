@@ -26,7 +26,7 @@ func (ga *GioApp) renderImportPreviewDialog(gtx layout.Context) layout.Dimension
 	if ga.widgetState.importExecuteButton.Clicked(gtx) {
 		if ga.widgetState.importInferSchemaCheck.Value {
 			ga.logger.Info("Executing import")
-			go ga.executeImport()
+			ga.executeImport()
 		} else {
 			ga.openSchemaEditorForImport("preview")
 		}
@@ -58,18 +58,7 @@ func (ga *GioApp) renderImportPreviewDialog(gtx layout.Context) layout.Dimension
 
 		// Show loading state while import is running
 		if ga.importRunning {
-			return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
-				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					return layout.Inset{
-						Top:    unit.Dp(theme.Spacing4),
-						Bottom: unit.Dp(theme.Spacing4),
-					}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-						label := material.Body1(ga.theme.Theme, "Importing...")
-						label.Alignment = text.Middle
-						return label.Layout(gtx)
-					})
-				}),
-			)
+			return ga.renderImportProgress(gtx)
 		}
 
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -80,21 +69,7 @@ func (ga *GioApp) renderImportPreviewDialog(gtx layout.Context) layout.Dimension
 					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 						// File info
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-							return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-										label := material.Body1(ga.theme.Theme, "File: "+ga.importFilename)
-										label.Font.Weight = font.Bold
-										return label.Layout(gtx)
-									}),
-									layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-										info := fmt.Sprintf("Format: %s | Items: %d", ga.importData.Format, len(ga.importData.Data))
-										label := material.Body2(ga.theme.Theme, info)
-										label.Color = theme.ColorTextSecondary
-										return label.Layout(gtx)
-									}),
-								)
-							})
+							return ga.renderImportFileInfo(gtx, &ga.widgetState.importChooseFile, false)
 						}),
 
 						// Errors section (if any)
@@ -263,17 +238,23 @@ func (ga *GioApp) renderImportResultView(gtx layout.Context) layout.Dimensions {
 	)
 }
 
-// renderImportPreview renders a scrollable preview of items using the provided
-// list widget state, so the import-preview and import-create dialogs keep
-// independent scroll positions.
+// renderImportPreview renders a scrollable preview of the first
+// importPreviewRows items using the provided list widget state, so the
+// import-preview and import-create dialogs keep independent scroll positions.
 func (ga *GioApp) renderImportPreview(gtx layout.Context, list *widget.List) layout.Dimensions {
 	if len(ga.importData.Data) == 0 {
 		return layout.Dimensions{}
 	}
 
+	rows := min(len(ga.importData.Data), importPreviewRows)
+	title := fmt.Sprintf("Preview (%d items):", rows)
+	if rows < len(ga.importData.Data) {
+		title = fmt.Sprintf("Preview (first %d of %d items):", rows, len(ga.importData.Data))
+	}
+
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			label := material.Body2(ga.theme.Theme, fmt.Sprintf("Preview (%d items):", len(ga.importData.Data)))
+			label := material.Body2(ga.theme.Theme, title)
 			label.Font.Weight = font.Bold
 			return label.Layout(gtx)
 		}),
@@ -285,7 +266,7 @@ func (ga *GioApp) renderImportPreview(gtx layout.Context, list *widget.List) lay
 			}
 
 			listStyle := material.List(ga.theme.Theme, list)
-			return listStyle.Layout(gtx, len(ga.importData.Data), func(gtx layout.Context, i int) layout.Dimensions {
+			return listStyle.Layout(gtx, rows, func(gtx layout.Context, i int) layout.Dimensions {
 				return ga.renderPreviewItem(gtx, ga.importData.Data[i], i+1)
 			})
 		}),
@@ -384,6 +365,36 @@ func (ga *GioApp) getImportOmitColButton(col string) *widget.Clickable {
 	return btn
 }
 
+// importSourceFormats are the export formats the backend can map onto
+// Nishiki fields, in the order they are offered.
+var importSourceFormats = [...]struct {
+	value string
+	label string
+}{
+	{"", "Nishiki / other"},
+	{"auto", "Auto-detect"},
+	{"grocy", "Grocy"},
+	{"pantry_csv", "Pantry app CSV"},
+}
+
+// renderImportSourceFormatSelector renders the source app chips. Picking an
+// app lets the backend map its columns, so name and location columns chosen
+// below are not sent.
+func (ga *GioApp) renderImportSourceFormatSelector(gtx layout.Context) layout.Dimensions {
+	chips := make([]layout.Widget, len(importSourceFormats))
+	for i, f := range importSourceFormats {
+		btn := &ga.widgetState.importSourceFormatButtons[i]
+		if btn.Clicked(gtx) {
+			ga.importSourceFormat = f.value
+		}
+		active := ga.importSourceFormat == f.value
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, f.label, active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Exported from:", chips)
+}
+
 // toggleOmittedColumn flips the omitted state for col. When a column that is
 // currently acting as Name or Location is omitted, that role is also cleared.
 func (ga *GioApp) toggleOmittedColumn(col string) {
@@ -481,9 +492,11 @@ func (ga *GioApp) renderImportColumnMapping(gtx layout.Context) layout.Dimension
 		}),
 
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				return ga.renderChipSelector(gtx, "Name column:", nameChips)
-			})
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, ga.renderImportSourceFormatSelector)
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderChipSelector(gtx, "Name column:", nameChips)
 		}),
 
 		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
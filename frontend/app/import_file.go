@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// importPreviewRows caps how many rows the import dialogs preview; the whole
+// file is still imported.
+const importPreviewRows = 20
+
+// isImportFile reports whether filename has an extension the import parses.
+func isImportFile(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".json":
+		return true
+	}
+	return false
+}
+
+// prepareImportDrop decides what a file dropped on the window imports into
+// and sets ga.importCreateMode to match: an open import dialog keeps its
+// mode, the collections list starts a new collection and a collection's
+// view imports into it. It returns false, telling the user why where it
+// helps, when the drop should be ignored.
+func (ga *GioApp) prepareImportDrop(filename string) bool {
+	if ga.importRunning || ga.importCreateRunning {
+		return false
+	}
+	switch {
+	case ga.showImportCreateDialog:
+		ga.importCreateMode = true
+	case ga.showImportPreview:
+		if ga.importResult != nil {
+			return false
+		}
+		ga.importCreateMode = false
+	case ga.currentView == ViewCollectionsGio:
+		ga.importCreateMode = true
+	case ga.currentView == ViewCollectionDetailGio && ga.selectedCollection != nil:
+		ga.importCreateMode = false
+	default:
+		return false
+	}
+	if !isImportFile(filename) {
+		ga.importCreateMode = false
+		ga.showAPIErrorDialog("Only CSV and JSON files can be imported")
+		return false
+	}
+	return true
+}
+
+// renderImportFileInfo renders the file name and row count at the top of
+// the import dialogs with a button to choose a different file. create says
+// which dialog it is in, so the new file stays in the same flow.
+func (ga *GioApp) renderImportFileInfo(gtx layout.Context, chooseButton *widget.Clickable, create bool) layout.Dimensions {
+	if chooseButton.Clicked(gtx) {
+		ga.importCreateMode = create
+		go ga.SelectImportFile()
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := material.Body1(ga.theme.Theme, "File: "+ga.importFilename)
+						label.Font.Weight = font.Bold
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						info := fmt.Sprintf("Format: %s | Items: %d", ga.importData.Format, len(ga.importData.Data))
+						label := material.Body2(ga.theme.Theme, info)
+						label.Color = theme.ColorTextSecondary
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if !importDropSupported {
+							return layout.Dimensions{}
+						}
+						hint := "Drop another CSV or JSON file here to replace it"
+						label := material.Caption(ga.theme.Theme, hint)
+						label.Color = theme.ColorTextSecondary
+						if ga.importDragActive {
+							label.Text = "Release to import this file instead"
+							label.Color = theme.ColorPrimary
+							label.Font.Weight = font.Bold
+						}
+						return label.Layout(gtx)
+					}),
+				)
+			}),
+			layout.Rigid(widgets.CancelButton(ga.theme.Theme, chooseButton, "Choose file...")),
+		)
+	})
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrepareImportDrop(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(ga *GioApp)
+		filename   string
+		want       bool
+		wantCreate bool
+		wantError  bool
+	}{
+		{
+			name:       "collections list starts a new collection",
+			setup:      func(ga *GioApp) { ga.currentView = ViewCollectionsGio },
+			filename:   "pantry.csv",
+			want:       true,
+			wantCreate: true,
+		},
+		{
+			name: "collection view imports into it",
+			setup: func(ga *GioApp) {
+				ga.currentView = ViewCollectionDetailGio
+				ga.selectedCollection = &Collection{ID: "c1"}
+			},
+			filename: "Pantry.JSON",
+			want:     true,
+		},
+		{
+			name: "open create dialog keeps its mode",
+			setup: func(ga *GioApp) {
+				ga.currentView = ViewCollectionsGio
+				ga.showImportCreateDialog = true
+			},
+			filename:   "books.csv",
+			want:       true,
+			wantCreate: true,
+		},
+		{
+			name: "running import ignores drops",
+			setup: func(ga *GioApp) {
+				ga.currentView = ViewCollectionDetailGio
+				ga.selectedCollection = &Collection{ID: "c1"}
+				ga.showImportPreview = true
+				ga.importRunning = true
+			},
+			filename: "pantry.csv",
+		},
+		{
+			name:     "other views ignore drops",
+			setup:    func(ga *GioApp) { ga.currentView = ViewProfileGio },
+			filename: "pantry.csv",
+		},
+		{
+			name:      "unsupported files are refused",
+			setup:     func(ga *GioApp) { ga.currentView = ViewCollectionsGio },
+			filename:  "receipt.pdf",
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ga := &GioApp{}
+			tt.setup(ga)
+			if got := ga.prepareImportDrop(tt.filename); got != tt.want {
+				t.Errorf("prepareImportDrop(%q) = %v, want %v", tt.filename, got, tt.want)
+			}
+			if ga.importCreateMode != tt.wantCreate {
+				t.Errorf("importCreateMode = %v, want %v", ga.importCreateMode, tt.wantCreate)
+			}
+			if ga.showAPIError != tt.wantError {
+				t.Errorf("showAPIError = %v, want %v", ga.showAPIError, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestImportUpload(t *testing.T) {
+	ga := &GioApp{}
+	if ga.importUpload() != nil {
+		t.Fatal("importUpload() without a file should be nil")
+	}
+
+	ga.importFilename = "pantry.csv"
+	ga.importFileContent = []byte("name,notes,aisle\nRice,,3\n")
+	ga.importOmittedColumns = map[string]bool{"notes": true, "aisle": true, "name": false}
+	upload := ga.importUpload()
+	if upload == nil {
+		t.Fatal("importUpload() = nil, want the picked file")
+	}
+	if upload.Filename != "pantry.csv" || string(upload.Content) != string(ga.importFileContent) {
+		t.Errorf("importUpload() = %q with %q, want the picked file", upload.Filename, upload.Content)
+	}
+	if want := []string{"aisle", "notes"}; !slices.Equal(upload.OmitColumns, want) {
+		t.Errorf("OmitColumns = %v, want %v", upload.OmitColumns, want)
+	}
+}
@@ -4,6 +4,9 @@ package app
 
 import "syscall/js"
 
+// importDropSupported reports whether files can be dropped onto the window.
+const importDropSupported = true
+
 // SelectImportFile opens a browser file-picker dialog and processes the selected file.
 func (ga *GioApp) SelectImportFile() {
 	input := js.Global().Get("document").Call("createElement", "input")
@@ -12,41 +15,126 @@ func (ga *GioApp) SelectImportFile() {
 
 	var changeHandler js.Func
 	changeHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer changeHandler.Release()
 		files := input.Get("files")
 		if files.Length() == 0 {
-			changeHandler.Release()
 			return nil
 		}
+		ga.readImportFile(files.Index(0))
+		return nil
+	})
 
-		file := files.Index(0)
-		filename := file.Get("name").String()
+	input.Call("addEventListener", "change", changeHandler)
+	input.Call("click")
+}
 
-		reader := js.Global().Get("FileReader").New()
+// installImportDropTarget lets CSV and JSON files be dropped anywhere on the
+// page. A drop on the collections list starts an import into a new
+// collection and one on a collection imports into it; an open import dialog
+// has its file replaced. ga.importDragActive is set while a file hovers so
+// the dialogs can highlight their drop hint.
+func (ga *GioApp) installImportDropTarget() {
+	document := js.Global().Get("document")
 
-		var loadHandler js.Func
-		loadHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			result := reader.Get("result").String()
-			ga.handleImportFileContent(result, filename)
-			loadHandler.Release()
-			changeHandler.Release()
+	// Only react to files, not text or links dragged within the page
+	hasFiles := func(event js.Value) bool {
+		transfer := event.Get("dataTransfer")
+		if transfer.IsNull() || transfer.IsUndefined() {
+			return false
+		}
+		types := transfer.Get("types")
+		for i := 0; i < types.Length(); i++ {
+			if types.Index(i).String() == "Files" {
+				return true
+			}
+		}
+		return false
+	}
+	// JS callbacks must not block the browser's event loop, which the UI
+	// needs to drain ga.do's queue, so state changes are handed off
+	dragActive := false
+	setDragActive := func(active bool) {
+		if active != dragActive {
+			dragActive = active
+			go ga.do(func() { ga.importDragActive = active })
+		}
+	}
+
+	// The browser opens a dropped file in place of the app unless dragover
+	// and drop are cancelled
+	document.Call("addEventListener", "dragover", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		if !hasFiles(event) {
 			return nil
-		})
+		}
+		event.Call("preventDefault")
+		event.Get("dataTransfer").Set("dropEffect", "copy")
+		return nil
+	}))
+	document.Call("addEventListener", "dragenter", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if hasFiles(args[0]) {
+			setDragActive(true)
+		}
+		return nil
+	}))
+	document.Call("addEventListener", "dragleave", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		// relatedTarget is null once the pointer leaves the page
+		if related := args[0].Get("relatedTarget"); related.IsNull() || related.IsUndefined() {
+			setDragActive(false)
+		}
+		return nil
+	}))
+	document.Call("addEventListener", "drop", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		if !hasFiles(event) {
+			return nil
+		}
+		event.Call("preventDefault")
+		setDragActive(false)
 
-		var errorHandler js.Func
-		errorHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			ga.logger.Error("Failed to read import file")
-			errorHandler.Release()
-			loadHandler.Release()
-			changeHandler.Release()
+		files := event.Get("dataTransfer").Get("files")
+		if files.Length() == 0 {
 			return nil
+		}
+		file := files.Index(0)
+		filename := file.Get("name").String()
+		go ga.do(func() {
+			if ga.prepareImportDrop(filename) {
+				ga.readImportFile(file)
+			}
 		})
+		return nil
+	}))
+}
 
-		reader.Set("onload", loadHandler)
-		reader.Set("onerror", errorHandler)
-		reader.Call("readAsText", file)
+// readImportFile reads a File with the browser's FileReader and processes
+// it as import data.
+func (ga *GioApp) readImportFile(file js.Value) {
+	filename := file.Get("name").String()
+	reader := js.Global().Get("FileReader").New()
+
+	var loadHandler, errorHandler js.Func
+	release := func() {
+		loadHandler.Release()
+		errorHandler.Release()
+	}
+	loadHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer release()
+		result := reader.Get("result").String()
+		go ga.do(func() { ga.handleImportFileContent(result, filename) })
+		return nil
+	})
+	errorHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer release()
+		ga.logger.Error("Failed to read import file", "filename", filename)
+		go ga.do(func() {
+			ga.importCreateMode = false
+			ga.showAPIErrorDialog("Failed to read " + filename)
+		})
 		return nil
 	})
 
-	input.Call("addEventListener", "change", changeHandler)
-	input.Call("click")
+	reader.Set("onload", loadHandler)
+	reader.Set("onerror", errorHandler)
+	reader.Call("readAsText", file)
 }
@@ -3,13 +3,40 @@
 package app
 
 import (
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 )
 
-// SelectImportFile on desktop is a no-op; the import dialog provides a file path
-// input field and calls SelectImportFileByPath directly.
-func (ga *GioApp) SelectImportFile() {}
+// importDropSupported reports whether files can be dropped onto the window.
+// Gio has no OS file drop on desktop, so the picker is the only way in.
+const importDropSupported = false
+
+// installImportDropTarget is a no-op on desktop; see importDropSupported.
+func (ga *GioApp) installImportDropTarget() {}
+
+// SelectImportFile opens the platform's native file dialog and processes the
+// selected file. Call it from a goroutine: it blocks until the dialog closes.
+func (ga *GioApp) SelectImportFile() {
+	path, err := pickImportFile()
+	if err != nil {
+		ga.logger.Error("Failed to open file dialog", "error", err)
+		ga.do(func() {
+			ga.importCreateMode = false
+			ga.showAPIErrorDialog("Could not open a file dialog: " + err.Error())
+		})
+		return
+	}
+	if path == "" {
+		// Cancelled
+		ga.do(func() { ga.importCreateMode = false })
+		return
+	}
+	ga.SelectImportFileByPath(path)
+}
 
 // SelectImportFileByPath reads a file from disk and processes it as import data.
 func (ga *GioApp) SelectImportFileByPath(filePath string) {
@@ -18,5 +45,45 @@ func (ga *GioApp) SelectImportFileByPath(filePath string) {
 		ga.logger.Error("Failed to read import file", "path", filePath, "error", err)
 		return
 	}
-	ga.handleImportFileContent(string(content), filepath.Base(filePath))
+	ga.do(func() {
+		ga.handleImportFileContent(string(content), filepath.Base(filePath))
+	})
+}
+
+// pickImportFile asks the user for a CSV or JSON file with the platform's
+// file dialog: zenity or kdialog on Linux and the BSDs, AppleScript on macOS
+// and a Windows Forms dialog on Windows. It returns "" if the user cancels.
+func pickImportFile() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e",
+			`POSIX path of (choose file with prompt "Choose a file to import" of type {"csv", "json", "public.comma-separated-values-text", "public.json"})`)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+			`Add-Type -AssemblyName System.Windows.Forms; `+
+				`$d = New-Object System.Windows.Forms.OpenFileDialog; `+
+				`$d.Filter = 'CSV or JSON (*.csv;*.json)|*.csv;*.json'; `+
+				`if ($d.ShowDialog() -eq 'OK') { $d.FileName }`)
+	default:
+		if _, err := exec.LookPath("zenity"); err == nil {
+			cmd = exec.Command("zenity", "--file-selection", "--title=Choose a file to import",
+				"--file-filter=CSV or JSON | *.csv *.json")
+		} else if _, err := exec.LookPath("kdialog"); err == nil {
+			cmd = exec.Command("kdialog", "--getopenfilename", ".", "*.csv *.json|CSV or JSON")
+		} else {
+			return "", errors.New("install zenity or kdialog to pick files")
+		}
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		// The dialogs exit non-zero when cancelled
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
 }
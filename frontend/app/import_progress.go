@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	collectionsAPI "github.com/nishiki/frontend/pkg/api/collections"
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// runImportJob queues an import into a collection and waits for it to
+// finish, keeping ga.importProgress current for the progress bar. With an
+// upload the file goes up whole and req only carries the options. Call it
+// from a goroutine; it returns the import's counts once done.
+func (ga *GioApp) runImportJob(accountID, collectionID string, req types.BulkImportCollectionRequest, upload *collectionsAPI.ImportFile) (*types.ImportResult, error) {
+	var job *types.ImportJob
+	var err error
+	if upload != nil {
+		job, err = ga.collectionsClient.UploadImport(accountID, collectionID, *upload, req)
+	} else {
+		job, err = ga.collectionsClient.ImportObjects(accountID, collectionID, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	queued := *job
+	ga.do(func() { ga.importProgress = queued })
+
+	final, err := ga.importsClient.Watch(context.Background(), job.JobID, func(update *types.ImportJob) {
+		progress := *update
+		ga.do(func() { ga.importProgress = progress })
+	})
+	if err != nil {
+		return nil, err
+	}
+	if final.Status == "failed" {
+		return nil, errors.New(final.Error)
+	}
+	if final.Result == nil {
+		return nil, errors.New("import finished without a result")
+	}
+	return final.Result, nil
+}
+
+// importProgressLabel describes how far an import has got
+func importProgressLabel(job types.ImportJob) string {
+	switch {
+	case job.Status == "queued":
+		return "Waiting for other imports to finish..."
+	case job.Total > 0:
+		return fmt.Sprintf("Importing %d of %d rows...", job.Done, job.Total)
+	default:
+		return "Importing..."
+	}
+}
+
+// importProgressFraction is the share of rows handled, for the progress bar
+func importProgressFraction(job types.ImportJob) float32 {
+	if job.Total <= 0 {
+		return 0
+	}
+	return min(float32(job.Done)/float32(job.Total), 1)
+}
+
+// renderImportProgress shows the running import's progress bar and row
+// count in place of an import dialog's content
+func (ga *GioApp) renderImportProgress(gtx layout.Context) layout.Dimensions {
+	return layout.Inset{
+		Top:    unit.Dp(theme.Spacing4),
+		Bottom: unit.Dp(theme.Spacing4),
+	}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, importProgressLabel(ga.importProgress))
+					label.Alignment = text.Middle
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(material.ProgressBar(ga.theme.Theme, importProgressFraction(ga.importProgress)).Layout),
+		)
+	})
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestImportProgress(t *testing.T) {
+	tests := []struct {
+		job      types.ImportJob
+		label    string
+		fraction float32
+	}{
+		{types.ImportJob{}, "Importing...", 0},
+		{types.ImportJob{Status: "queued", Total: 10}, "Waiting for other imports to finish...", 0},
+		{types.ImportJob{Status: "running", Done: 250, Total: 1000}, "Importing 250 of 1000 rows...", 0.25},
+		{types.ImportJob{Status: "running", Done: 12, Total: 10}, "Importing 12 of 10 rows...", 1},
+	}
+	for _, tt := range tests {
+		if got := importProgressLabel(tt.job); got != tt.label {
+			t.Errorf("importProgressLabel(%+v) = %q, want %q", tt.job, got, tt.label)
+		}
+		if got := importProgressFraction(tt.job); got != tt.fraction {
+			t.Errorf("importProgressFraction(%+v) = %v, want %v", tt.job, got, tt.fraction)
+		}
+	}
+}
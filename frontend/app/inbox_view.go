@@ -0,0 +1,493 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// inboxDateLayout is how the day an email arrived is shown
+const inboxDateLayout = "Jan 2, 15:04"
+
+// inboxLineDraft is a line of an inbox item as the user is editing it: whether
+// it becomes an object, and under which name
+type inboxLineDraft struct {
+	line       types.InboxLine
+	include    widget.Bool
+	nameEditor widget.Editor
+}
+
+func newInboxLineDraft(line types.InboxLine) *inboxLineDraft {
+	d := &inboxLineDraft{line: line}
+	d.include.Value = true
+	d.nameEditor.SingleLine = true
+	d.nameEditor.SetText(line.Name)
+	return d
+}
+
+// inboxLineDetail is the quantity and price read from a line, e.g.
+// "2 kg · 3.49", or "" when the email gave neither
+func inboxLineDetail(line types.InboxLine) string {
+	var parts []string
+	if line.Quantity != nil {
+		parts = append(parts, formatMealQuantity(*line.Quantity, line.Unit))
+	}
+	if line.Price != nil {
+		parts = append(parts, strconv.FormatFloat(*line.Price, 'f', 2, 64))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// inboxLineRequests returns the lines the user kept, under their edited
+// names; ok is false when a kept line has no name
+func inboxLineRequests(drafts []*inboxLineDraft) (lines []types.InboxLineRequest, ok bool) {
+	for _, d := range drafts {
+		if !d.include.Value {
+			continue
+		}
+		name := strings.TrimSpace(d.nameEditor.Text())
+		if name == "" {
+			return nil, false
+		}
+		lines = append(lines, types.InboxLineRequest{
+			Name:     name,
+			Quantity: d.line.Quantity,
+			Unit:     d.line.Unit,
+			Price:    d.line.Price,
+		})
+	}
+	return lines, true
+}
+
+// inboxConfirmNotice summarizes a confirm: what was added and, for lines left
+// in the inbox, why
+func inboxConfirmNotice(result *types.ConfirmInboxItemResult) string {
+	msg := fmt.Sprintf("Added %d item(s)", len(result.Created))
+	if len(result.Failed) > 0 {
+		failed := make([]string, len(result.Failed))
+		for i, f := range result.Failed {
+			failed[i] = fmt.Sprintf("%s (%s)", f.Line.Name, f.Error)
+		}
+		msg += ". Still in the inbox: " + strings.Join(failed, ", ")
+	}
+	return msg
+}
+
+// defaultInboxCollection picks the collection confirmed lines go to until the
+// user chooses one: the first food collection, since most receipts are
+// groceries, or else the first collection
+func defaultInboxCollection(collections []types.Collection) string {
+	for _, c := range collections {
+		if c.ObjectType == ObjectTypeFood {
+			return c.ID
+		}
+	}
+	if len(collections) > 0 {
+		return collections[0].ID
+	}
+	return ""
+}
+
+// linesChanged reports whether drafts were built for other lines than an
+// item's current ones
+func linesChanged(drafts []*inboxLineDraft, lines []types.InboxLine) bool {
+	if len(drafts) != len(lines) {
+		return true
+	}
+	for i, d := range drafts {
+		if d.line.Name != lines[i].Name {
+			return true
+		}
+	}
+	return false
+}
+
+// openInbox shows the inbox, fetching it again so newly forwarded emails
+// appear
+func (ga *GioApp) openInbox() {
+	ga.inboxLoaded = false
+	ga.inboxNotice = ""
+	ga.navigateTo(ViewInboxGio)
+}
+
+// resetInbox drops the loaded inbox when the session ends
+func (ga *GioApp) resetInbox() {
+	ga.inboxItems = nil
+	ga.inboxAddress = nil
+	ga.inboxLoaded = false
+	ga.inboxErr = ""
+	ga.inboxNotice = ""
+	ga.inboxCollectionID = ""
+	ga.inboxBusy = ""
+	clear(ga.widgetState.inboxItems)
+}
+
+// ensureInboxLoaded fetches the pending items and the forwarding address
+// unless they are already loaded or on their way
+func (ga *GioApp) ensureInboxLoaded() {
+	if ga.currentUser == nil || ga.inboxLoaded || ga.inboxLoading {
+		return
+	}
+	ga.inboxLoading = true
+	ga.inboxErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		address, addrErr := ga.inboxClient.Address(accountID)
+		list, err := ga.inboxClient.List(accountID)
+
+		ga.doInSession(session, func() {
+			ga.inboxLoading = false
+			ga.inboxLoaded = true
+			if addrErr != nil {
+				ga.logger.Error("Failed to load inbox address", "error", addrErr)
+			} else {
+				ga.inboxAddress = address
+			}
+			if err != nil {
+				ga.logger.Error("Failed to load inbox", "error", err)
+				ga.inboxErr = "Could not load the inbox: " + err.Error()
+				return
+			}
+			ga.inboxItems = list.Items
+		})
+	})
+}
+
+// resetInboxAddress replaces the forwarding address, for when it has leaked
+func (ga *GioApp) resetInboxAddress() {
+	if ga.currentUser == nil || ga.inboxResetting {
+		return
+	}
+	ga.inboxResetting = true
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		address, err := ga.inboxClient.ResetAddress(accountID)
+
+		ga.doInSession(session, func() {
+			ga.inboxResetting = false
+			if err != nil {
+				ga.logger.Error("Failed to reset inbox address", "error", err)
+				ga.inboxErr = "Could not reset the address: " + err.Error()
+				return
+			}
+			ga.inboxAddress = address
+			ga.inboxNotice = "New address ready; mail to the old one is refused from now on"
+		})
+	})
+}
+
+// confirmInboxItem adds the lines the user kept to the chosen collection.
+// Lines that fail stay in the item, as the backend keeps them.
+func (ga *GioApp) confirmInboxItem(item types.InboxItem, state *InboxItemState) {
+	if ga.currentUser == nil || ga.inboxBusy != "" {
+		return
+	}
+	collectionID := ga.inboxCollectionID
+	if collectionID == "" {
+		ga.inboxErr = "Pick a collection to add the items to"
+		return
+	}
+	lines, ok := inboxLineRequests(state.lines)
+	if !ok {
+		ga.inboxErr = "Every ticked line needs a name"
+		return
+	}
+	if len(lines) == 0 {
+		ga.inboxErr = "Tick at least one line, or dismiss the email"
+		return
+	}
+
+	ga.inboxBusy = item.ID
+	ga.inboxErr = ""
+	ga.inboxNotice = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		result, err := ga.inboxClient.Confirm(accountID, item.ID, types.ConfirmInboxItemRequest{
+			CollectionID: collectionID,
+			Lines:        &lines,
+		})
+
+		ga.doInSession(session, func() {
+			ga.inboxBusy = ""
+			if err != nil {
+				ga.logger.Error("Failed to confirm inbox item", "inbox_item_id", item.ID, "error", err)
+				ga.inboxErr = "Could not add the items: " + err.Error()
+				return
+			}
+			if len(result.Failed) == 0 {
+				ga.inboxItems = slices.DeleteFunc(ga.inboxItems, func(i types.InboxItem) bool { return i.ID == item.ID })
+				delete(ga.widgetState.inboxItems, item.ID)
+			} else {
+				remaining := make([]types.InboxLine, len(result.Failed))
+				for i, f := range result.Failed {
+					remaining[i] = f.Line
+				}
+				for i := range ga.inboxItems {
+					if ga.inboxItems[i].ID == item.ID {
+						ga.inboxItems[i].Lines = remaining
+					}
+				}
+			}
+			if ga.selectedCollection != nil && ga.selectedCollection.ID == collectionID {
+				for _, obj := range result.Created {
+					ga.addObject(obj)
+				}
+			}
+			ga.dashboardStatsStale = true
+			ga.inboxNotice = inboxConfirmNotice(result)
+		})
+	})
+}
+
+// dismissInboxItem drops an email from the inbox without adding anything
+func (ga *GioApp) dismissInboxItem(item types.InboxItem) {
+	if ga.currentUser == nil || ga.inboxBusy != "" {
+		return
+	}
+	ga.inboxBusy = item.ID
+	ga.inboxErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		err := ga.inboxClient.Dismiss(accountID, item.ID)
+
+		ga.doInSession(session, func() {
+			ga.inboxBusy = ""
+			if err != nil {
+				ga.logger.Error("Failed to dismiss inbox item", "inbox_item_id", item.ID, "error", err)
+				ga.inboxErr = "Could not dismiss " + item.Subject + ": " + err.Error()
+				return
+			}
+			ga.inboxItems = slices.DeleteFunc(ga.inboxItems, func(i types.InboxItem) bool { return i.ID == item.ID })
+			delete(ga.widgetState.inboxItems, item.ID)
+		})
+	})
+}
+
+// renderInboxView renders the forwarding address, the collection confirmed
+// items go to, and one card per forwarded email
+func (ga *GioApp) renderInboxView(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.inboxRefresh.Clicked(gtx) && !ga.inboxLoading {
+		ga.inboxLoaded = false
+	}
+	if ga.widgetState.inboxResetAddress.Clicked(gtx) {
+		ga.resetInboxAddress()
+	}
+	ga.ensureInboxLoaded()
+	if ga.inboxCollectionID == "" {
+		ga.inboxCollectionID = defaultInboxCollection(ga.collections)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderHeader(gtx, "Inbox")
+		}),
+
+		// Address, collection and status
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing4), Right: unit.Dp(theme.Spacing4)}.Layout(gtx, ga.renderInboxSummary)
+		}),
+
+		// Forwarded emails
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing3),
+				Bottom: unit.Dp(theme.Spacing20), // Space for bottom menu
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				if ga.inboxLoading && !ga.inboxLoaded {
+					return widgets.SkeletonList(skeletonRows)(gtx)
+				}
+				if len(ga.inboxItems) == 0 {
+					return ga.mealNoteLabel(gtx, "Nothing waiting. Forwarded receipts show up here.")
+				}
+				return material.List(ga.theme.Theme, &ga.widgetState.inboxList).Layout(gtx, len(ga.inboxItems), func(gtx layout.Context, i int) layout.Dimensions {
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderInboxItem(gtx, ga.inboxItems[i])
+					})
+				})
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBottomMenu(gtx, ViewDashboardGio)
+		}),
+	)
+}
+
+// renderInboxSummary renders the forwarding address with its reset and
+// refresh buttons, the collection picker, and the error or notice below
+func (ga *GioApp) renderInboxSummary(gtx layout.Context) layout.Dimensions {
+	address := "Loading address..."
+	switch a := ga.inboxAddress; {
+	case a != nil && !a.Enabled:
+		address = "Forwarding by email is not set up on this server"
+	case a != nil:
+		address = "Forward receipts to " + a.Address
+	case ga.inboxLoaded:
+		address = "The forwarding address could not be loaded"
+	}
+
+	status, statusColor := ga.inboxNotice, theme.ColorTextSecondary
+	if ga.inboxErr != "" {
+		status, statusColor = ga.inboxErr, theme.ColorDanger
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, address)
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					if ga.inboxAddress == nil || !ga.inboxAddress.Enabled {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+						widgets.CancelButton(ga.theme.Theme, &ga.widgetState.inboxResetAddress, "New Address"))
+				}),
+				layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.inboxRefresh, "Refresh")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, ga.renderInboxCollectionSelector)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if status == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Body2(ga.theme.Theme, status)
+			label.Color = statusColor
+			return label.Layout(gtx)
+		}),
+	)
+}
+
+// renderInboxCollectionSelector renders a chip per collection; confirmed
+// lines go to the chosen one's default container
+func (ga *GioApp) renderInboxCollectionSelector(gtx layout.Context) layout.Dimensions {
+	chips := make([]layout.Widget, 0, len(ga.collections))
+	for _, c := range ga.collections {
+		btn := ga.widgetState.inboxCollectionButtons[c.ID]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.inboxCollectionButtons[c.ID] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.inboxCollectionID = c.ID
+		}
+		active := ga.inboxCollectionID == c.ID
+		chips = append(chips, func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, c.Name, active)
+		})
+	}
+	return ga.renderChipSelector(gtx, "Add to collection", chips)
+}
+
+// renderInboxItem renders a forwarded email: its subject and sender, a row
+// per line to tick and rename, and the confirm and dismiss buttons
+func (ga *GioApp) renderInboxItem(gtx layout.Context, item types.InboxItem) layout.Dimensions {
+	state := ga.widgetState.inboxItems[item.ID]
+	if state == nil {
+		state = &InboxItemState{}
+		ga.widgetState.inboxItems[item.ID] = state
+	}
+	if linesChanged(state.lines, item.Lines) {
+		state.lines = make([]*inboxLineDraft, len(item.Lines))
+		for i, line := range item.Lines {
+			state.lines[i] = newInboxLineDraft(line)
+		}
+	}
+	if state.confirmButton.Clicked(gtx) {
+		ga.confirmInboxItem(item, state)
+	}
+	if state.dismissButton.Clicked(gtx) {
+		ga.dismissInboxItem(item)
+	}
+
+	subject := item.Subject
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	from := item.ReceivedAt.Local().Format(inboxDateLayout)
+	if item.Sender != "" {
+		from = item.Sender + " · " + from
+	}
+	kept := 0
+	for _, d := range state.lines {
+		if d.include.Value {
+			kept++
+		}
+	}
+	busy := ga.inboxBusy == item.ID
+
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Body1(ga.theme.Theme, subject)
+				label.Font.Weight = font.Bold
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(ga.theme.Theme, from)
+				label.Color = theme.ColorTextSecondary
+				return label.Layout(gtx)
+			}),
+		}
+		for _, d := range state.lines {
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(material.CheckBox(ga.theme.Theme, &d.include, "").Layout),
+						layout.Flexed(0.7, func(gtx layout.Context) layout.Dimensions {
+							return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+								material.Editor(ga.theme.Theme, &d.nameEditor, "Name").Layout)
+						}),
+						layout.Flexed(0.3, func(gtx layout.Context) layout.Dimensions {
+							label := material.Caption(ga.theme.Theme, inboxLineDetail(d.line))
+							label.Color = theme.ColorTextSecondary
+							return label.Layout(gtx)
+						}),
+					)
+				})
+			}))
+		}
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				confirm := fmt.Sprintf("Add %d", kept)
+				if busy {
+					confirm = "Adding..."
+				}
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.AccentButton(ga.theme.Theme, &state.confirmButton, confirm))
+					}),
+					layout.Rigid(widgets.DangerButton(ga.theme.Theme, &state.dismissButton, "Dismiss")),
+				)
+			})
+		}))
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
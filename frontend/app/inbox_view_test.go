@@ -0,0 +1,72 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestInboxLineDetail(t *testing.T) {
+	two, kilos, price := 2.0, 1.5, 3.49
+	tests := []struct {
+		line types.InboxLine
+		want string
+	}{
+		{types.InboxLine{Name: "Milk", Quantity: &two, Price: &price}, "2 · 3.49"},
+		{types.InboxLine{Name: "Apples", Quantity: &kilos, Unit: "kg"}, "1.5 kg"},
+		{types.InboxLine{Name: "Bread", Price: &price}, "3.49"},
+		{types.InboxLine{Name: "Oats"}, ""},
+	}
+	for _, tt := range tests {
+		if got := inboxLineDetail(tt.line); got != tt.want {
+			t.Errorf("inboxLineDetail(%s) = %q, want %q", tt.line.Name, got, tt.want)
+		}
+	}
+}
+
+func TestInboxLineRequests(t *testing.T) {
+	price := 3.49
+	milk := newInboxLineDraft(types.InboxLine{Name: "WHL MLK", Price: &price})
+	milk.nameEditor.SetText("  Whole Milk ")
+	bag := newInboxLineDraft(types.InboxLine{Name: "Bag fee"})
+	bag.include.Value = false
+
+	lines, ok := inboxLineRequests([]*inboxLineDraft{milk, bag})
+	if !ok || len(lines) != 1 {
+		t.Fatalf("got %+v, %v; want the milk line only", lines, ok)
+	}
+	if lines[0].Name != "Whole Milk" || lines[0].Price == nil || *lines[0].Price != price {
+		t.Errorf("line = %+v, want the edited name and the price", lines[0])
+	}
+
+	milk.nameEditor.SetText(" ")
+	if _, ok := inboxLineRequests([]*inboxLineDraft{milk}); ok {
+		t.Error("a ticked line without a name was accepted")
+	}
+}
+
+func TestInboxConfirmNotice(t *testing.T) {
+	result := &types.ConfirmInboxItemResult{
+		Created: []types.Object{{Name: "Milk"}, {Name: "Bread"}},
+		Failed: []types.InboxLineError{
+			{Line: types.InboxLine{Name: "Eggs"}, Error: "access denied"},
+		},
+	}
+	if got, want := inboxConfirmNotice(result), "Added 2 item(s). Still in the inbox: Eggs (access denied)"; got != want {
+		t.Errorf("notice = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultInboxCollection(t *testing.T) {
+	books := types.Collection{ID: "b", ObjectType: "book"}
+	pantry := types.Collection{ID: "p", ObjectType: ObjectTypeFood}
+	if got := defaultInboxCollection([]types.Collection{books, pantry}); got != "p" {
+		t.Errorf("got %q, want the food collection", got)
+	}
+	if got := defaultInboxCollection([]types.Collection{books}); got != "b" {
+		t.Errorf("got %q, want the first collection", got)
+	}
+	if got := defaultInboxCollection(nil); got != "" {
+		t.Errorf("got %q, want none", got)
+	}
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"maps"
+	"slices"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// customSort asks the backend for pinned items first, then the user's own
+// order, then everything never placed by name
+var customSort = types.SortOptions{Field: "custom", Order: "asc"}
+
+// placeItems reorders ids after the user moved the item at index by delta
+// (-1 for up, +1 for down) and returns the placements that changed. Every
+// item is given its position so the order survives items being added later.
+// Items only move among items with the same pin state; a move past that
+// boundary, or off either end, returns nil.
+func placeItems(ids []string, order map[string]types.ItemOrder, index, delta int) map[string]types.ItemOrder {
+	target := index + delta
+	if index < 0 || index >= len(ids) || target < 0 || target >= len(ids) {
+		return nil
+	}
+	if order[ids[index]].Pinned != order[ids[target]].Pinned {
+		return nil
+	}
+
+	moved := slices.Clone(ids)
+	moved[index], moved[target] = moved[target], moved[index]
+
+	changed := map[string]types.ItemOrder{}
+	for i, id := range moved {
+		placement := types.ItemOrder{SortIndex: i + 1, Pinned: order[id].Pinned}
+		if placement != order[id] {
+			changed[id] = placement
+		}
+	}
+	return changed
+}
+
+// togglePin pins or unpins one item, keeping its place in the custom order
+func togglePin(order map[string]types.ItemOrder, id string) map[string]types.ItemOrder {
+	placement := order[id]
+	placement.Pinned = !placement.Pinned
+	return map[string]types.ItemOrder{id: placement}
+}
+
+// sortByPlacement orders items the way the backend's custom sort does,
+// keeping items never placed in their current order
+func sortByPlacement[T any](items []T, id func(T) string, order map[string]types.ItemOrder) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		oa, ob := order[id(a)], order[id(b)]
+		switch {
+		case oa.Pinned != ob.Pinned:
+			if oa.Pinned {
+				return -1
+			}
+			return 1
+		case oa.SortIndex == ob.SortIndex:
+			return 0
+		case oa.SortIndex == 0:
+			return 1
+		case ob.SortIndex == 0:
+			return -1
+		}
+		return oa.SortIndex - ob.SortIndex
+	})
+}
+
+func itemOrderRequests(changed map[string]types.ItemOrder) []types.ItemOrderRequest {
+	items := make([]types.ItemOrderRequest, 0, len(changed))
+	for _, id := range slices.Sorted(maps.Keys(changed)) {
+		items = append(items, types.ItemOrderRequest{ID: id, SortIndex: changed[id].SortIndex, Pinned: changed[id].Pinned})
+	}
+	return items
+}
+
+func collectionItemID(c Collection) string { return c.ID }
+
+func containerItemID(c Container) string { return c.ID }
+
+// moveCollection moves a collection up or down the list and saves the new
+// order
+func (ga *GioApp) moveCollection(index, delta int) {
+	ids := make([]string, len(ga.collections))
+	for i, c := range ga.collections {
+		ids[i] = c.ID
+	}
+	ga.updateCollectionOrder(placeItems(ids, ga.collectionOrder, index, delta))
+}
+
+func (ga *GioApp) toggleCollectionPin(id string) {
+	ga.updateCollectionOrder(togglePin(ga.collectionOrder, id))
+}
+
+// updateCollectionOrder applies placements locally straight away and saves
+// them in the background; a failed save is logged and undone on the next load
+func (ga *GioApp) updateCollectionOrder(changed map[string]types.ItemOrder) {
+	if len(changed) == 0 || ga.currentUser == nil {
+		return
+	}
+	if ga.collectionOrder == nil {
+		ga.collectionOrder = map[string]types.ItemOrder{}
+	}
+	maps.Copy(ga.collectionOrder, changed)
+	sortByPlacement(ga.collections, collectionItemID, ga.collectionOrder)
+	accountID := ga.currentUser.ID
+	items := itemOrderRequests(changed)
+
+	ga.goSafe(func() {
+		if _, err := ga.accountsClient.UpdateCollectionOrder(accountID, items); err != nil {
+			ga.logger.Error("Failed to save collection order", "error", err)
+		}
+	})
+}
+
+// moveContainer moves a container up or down the collection's list and
+// saves the new order
+func (ga *GioApp) moveContainer(index, delta int) {
+	ids := make([]string, len(ga.containers))
+	for i, c := range ga.containers {
+		ids[i] = c.ID
+	}
+	ga.updateContainerOrder(placeItems(ids, ga.containerOrder, index, delta))
+}
+
+func (ga *GioApp) toggleContainerPin(id string) {
+	ga.updateContainerOrder(togglePin(ga.containerOrder, id))
+}
+
+func (ga *GioApp) updateContainerOrder(changed map[string]types.ItemOrder) {
+	if len(changed) == 0 || ga.currentUser == nil {
+		return
+	}
+	if ga.containerOrder == nil {
+		ga.containerOrder = map[string]types.ItemOrder{}
+	}
+	maps.Copy(ga.containerOrder, changed)
+	sortByPlacement(ga.containers, containerItemID, ga.containerOrder)
+	ga.invalidateObjectCaches()
+	accountID := ga.currentUser.ID
+	items := itemOrderRequests(changed)
+
+	ga.goSafe(func() {
+		if _, err := ga.accountsClient.UpdateContainerOrder(accountID, items); err != nil {
+			ga.logger.Error("Failed to save container order", "error", err)
+		}
+	})
+}
+
+func (ga *GioApp) renderPinnedMarker(gtx layout.Context, pinned bool) layout.Dimensions {
+	if !pinned {
+		return layout.Dimensions{}
+	}
+	return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, "Pinned")
+		label.Color = theme.ColorPrimary
+		return label.Layout(gtx)
+	})
+}
+
+// renderOrderButtons renders the pin toggle and move up/down buttons of a
+// collection or container card
+func (ga *GioApp) renderOrderButtons(gtx layout.Context, pin, up, down *widget.Clickable, pinned bool) layout.Dimensions {
+	pinLabel := "Pin"
+	if pinned {
+		pinLabel = "Unpin"
+	}
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, widgets.CancelButton(ga.theme.Theme, pin, pinLabel))
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing1)}.Layout(gtx, widgets.IconButton(ga.theme.Theme, up, "↑", "Move up"))
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, widgets.IconButton(ga.theme.Theme, down, "↓", "Move down"))
+		}),
+	)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestPlaceItemsNumbersTheWholeList(t *testing.T) {
+	order := map[string]types.ItemOrder{"a": {SortIndex: 1, Pinned: true}}
+	ids := []string{"a", "b", "c"}
+
+	changed := placeItems(ids, order, 2, -1)
+
+	want := map[string]types.ItemOrder{
+		"c": {SortIndex: 2},
+		"b": {SortIndex: 3},
+	}
+	if !maps.Equal(changed, want) {
+		t.Errorf("got %v, want %v", changed, want)
+	}
+}
+
+func TestPlaceItemsStaysWithinPinState(t *testing.T) {
+	order := map[string]types.ItemOrder{"a": {Pinned: true}}
+	ids := []string{"a", "b"}
+
+	if changed := placeItems(ids, order, 1, -1); changed != nil {
+		t.Errorf("moving above a pinned item should do nothing, got %v", changed)
+	}
+	if changed := placeItems(ids, order, 0, -1); changed != nil {
+		t.Errorf("moving off the top should do nothing, got %v", changed)
+	}
+}
+
+func TestSortByPlacement(t *testing.T) {
+	order := map[string]types.ItemOrder{
+		"garage": {SortIndex: 3, Pinned: true},
+		"books":  {SortIndex: 1},
+		"pantry": {SortIndex: 2},
+	}
+	ids := []string{"attic", "books", "cellar", "garage", "pantry"}
+
+	sortByPlacement(ids, func(id string) string { return id }, order)
+
+	want := []string{"garage", "books", "pantry", "attic", "cellar"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+}
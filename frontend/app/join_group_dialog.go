@@ -85,14 +85,13 @@ func (ga *GioApp) handleJoinGroup() {
 	ga.showJoinGroupDialog = false
 	ga.widgetState.joinGroupDialog.Reset()
 
-	go func() {
+	ga.goSafe(func() {
 		group, err := ga.groupsClient.JoinByHash(hash)
 		if err != nil {
 			ga.logger.Error("Failed to join group", "error", err)
 			return
 		}
 		ga.logger.Info("Joined group", "group_id", group.ID, "group_name", group.Name)
-		ga.fetchGroups()
-		ga.window.Invalidate()
-	}()
+		ga.do(ga.fetchGroups)
+	})
 }
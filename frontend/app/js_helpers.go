@@ -11,8 +11,85 @@ func getCurrentPath() string {
 	return js.Global().Get("window").Get("location").Get("pathname").String()
 }
 
+// userAgent returns the browser's user agent string
+func userAgent() string {
+	return js.Global().Get("navigator").Get("userAgent").String()
+}
+
+// preferredLanguage returns the browser's language tag, e.g. "de-DE"
+func preferredLanguage() string {
+	return js.Global().Get("navigator").Get("language").String()
+}
+
 // redirectToPath changes the URL path without reloading the page
 func (ga *GioApp) redirectToPath(path string) {
 	history := js.Global().Get("history")
 	history.Call("pushState", nil, "", path)
 }
+
+// saveDownload hands data to the browser as a file download. The returned
+// path is always empty since the browser decides where the file goes.
+func saveDownload(filename string, data []byte, mimeType string) (string, error) {
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+
+	blob := js.Global().Get("Blob").New([]any{array}, map[string]any{"type": mimeType})
+	urlAPI := js.Global().Get("URL")
+	url := urlAPI.Call("createObjectURL", blob)
+
+	anchor := js.Global().Get("document").Call("createElement", "a")
+	anchor.Set("href", url)
+	anchor.Set("download", filename)
+	anchor.Call("click")
+
+	// Revoking straight away can cancel the download in some browsers
+	revoke := urlAPI.Get("revokeObjectURL").Call("bind", urlAPI, url)
+	js.Global().Call("setTimeout", revoke, 1000)
+	return "", nil
+}
+
+// openPrintable opens an HTML page in a new tab, where it brings up the
+// print dialog itself. If the browser blocks the tab the page is downloaded
+// instead.
+func openPrintable(filename string, html []byte) (string, error) {
+	array := js.Global().Get("Uint8Array").New(len(html))
+	js.CopyBytesToJS(array, html)
+
+	blob := js.Global().Get("Blob").New([]any{array}, map[string]any{"type": "text/html"})
+	urlAPI := js.Global().Get("URL")
+	url := urlAPI.Call("createObjectURL", blob)
+
+	win := js.Global().Call("open", url, "_blank")
+	if win.IsNull() || win.IsUndefined() {
+		urlAPI.Call("revokeObjectURL", url)
+		return saveDownload(filename, html, "text/html")
+	}
+
+	// The new tab needs the URL until it has loaded
+	revoke := urlAPI.Get("revokeObjectURL").Call("bind", urlAPI, url)
+	js.Global().Call("setTimeout", revoke, 60000)
+	return "", nil
+}
+
+// activeProfileKey is the localStorage key remembering the chosen profile, so
+// the sign-in redirect and the next visit come back to it
+const activeProfileKey = "active_profile"
+
+// loadActiveProfile returns the profile chosen last, "" for the default one
+func loadActiveProfile() string {
+	v := js.Global().Get("localStorage").Call("getItem", activeProfileKey)
+	if v.IsNull() || v.IsUndefined() {
+		return ""
+	}
+	return v.String()
+}
+
+// saveActiveProfile remembers the chosen profile; "" forgets it
+func saveActiveProfile(name string) {
+	localStorage := js.Global().Get("localStorage")
+	if name == "" {
+		localStorage.Call("removeItem", activeProfileKey)
+		return
+	}
+	localStorage.Call("setItem", activeProfileKey, name)
+}
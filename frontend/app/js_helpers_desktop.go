@@ -2,9 +2,63 @@
 
 package app
 
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
 // Desktop stubs for browser URL helpers. On desktop, navigation is handled
 // by the Gio windowing system rather than browser URL changes.
 
 func getCurrentPath() string { return "" }
 
 func (ga *GioApp) redirectToPath(_ string) {}
+
+// Desktop sessions start on the default profile; the choice is not kept
+// between runs.
+func loadActiveProfile() string { return "" }
+
+func saveActiveProfile(_ string) {}
+
+// userAgent identifies the desktop build by platform, standing in for the
+// browser user agent in error reports.
+func userAgent() string { return "nishiki-desktop " + runtime.GOOS + "/" + runtime.GOARCH }
+
+// preferredLanguage reads the locale from the environment as POSIX programs
+// do, e.g. "de_DE.UTF-8"
+func preferredLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// saveDownload writes data to the user's Downloads folder, falling back to the
+// working directory, and returns the path written.
+func saveDownload(filename string, data []byte, _ string) (string, error) {
+	dir := "."
+	if home, err := os.UserHomeDir(); err == nil {
+		if downloads := filepath.Join(home, "Downloads"); isDir(downloads) {
+			dir = downloads
+		}
+	}
+	path := filepath.Join(dir, filename)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// openPrintable saves the page to Downloads like saveDownload; opening it in
+// a browser brings up the print dialog.
+func openPrintable(filename string, html []byte) (string, error) {
+	return saveDownload(filename, html, "text/html")
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
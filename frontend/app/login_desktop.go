@@ -5,7 +5,7 @@ package app
 // handleLogin initiates the desktop OAuth PKCE flow via the system browser.
 func (ga *GioApp) handleLogin() {
 	ga.logger.Info("Initiating desktop login")
-	go func() {
+	ga.goSafe(func() {
 		token, err := ga.authService.DesktopLogin()
 		if err != nil {
 			ga.logger.Error("Desktop login failed", "error", err)
@@ -15,9 +15,29 @@ func (ga *GioApp) handleLogin() {
 			return
 		}
 		ga.logger.Info("Desktop login successful", "expires", token.Expiry)
-		ga.isSignedIn = true
-		ga.currentView = ViewDashboardGio
-		ga.loadUserData()
-		ga.window.Invalidate()
-	}()
+		ga.do(func() {
+			ga.isSignedIn = true
+			ga.currentView = ViewDashboardGio
+			ga.loadUserData()
+		})
+	})
+}
+
+// handleReauth asks the provider for a fresh sign-in via the system browser,
+// leaving the profile view and any open dialog in place for the retry.
+func (ga *GioApp) handleReauth() {
+	ga.logger.Info("Initiating desktop reauthentication")
+	ga.goSafe(func() {
+		_, err := ga.authService.DesktopReauth()
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Desktop reauthentication failed", "error", err)
+				ga.dataExportStatus = "Sign in failed. Please try again."
+				return
+			}
+			ga.reauthRequired = false
+			ga.deleteAccountErr = ""
+			ga.dataExportStatus = "Signed in again. You can now export your data or delete your account."
+		})
+	})
 }
@@ -124,6 +124,18 @@ func (ga *GioApp) renderLoginViewSimple(gtx layout.Context) layout.Dimensions {
 								})
 							}),
 
+							// Profile to sign in to, when several are configured
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								if !ga.hasProfiles() {
+									return layout.Dimensions{}
+								}
+								return layout.Inset{
+									Bottom: unit.Dp(theme.Spacing4),
+								}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+									return layout.Center.Layout(gtx, ga.renderProfileSwitcher)
+								})
+							}),
+
 							// Login button - centered
 							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 								return layout.Inset{
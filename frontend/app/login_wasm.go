@@ -9,3 +9,12 @@ func (ga *GioApp) handleLogin() {
 		ga.logger.Error("Failed to initiate login", "error", err)
 	}
 }
+
+// handleReauth sends the user back to the provider for a fresh sign-in. The
+// page reloads on return and the callback lands on the profile view again.
+func (ga *GioApp) handleReauth() {
+	ga.logger.Info("Initiating reauthentication")
+	if err := ga.authService.InitiateReauth(); err != nil {
+		ga.logger.Error("Failed to initiate reauthentication", "error", err)
+	}
+}
@@ -0,0 +1,847 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// mealPlanDateLayout is the calendar-day format used by the meal plan API
+const mealPlanDateLayout = "2006-01-02"
+
+// maxIngredientSuggestions caps how many food objects the ingredient picker offers
+const maxIngredientSuggestions = 8
+
+// mealTypes lists the meals of a day in the order they are shown
+var mealTypes = []string{"breakfast", "lunch", "dinner", "snack"}
+
+var mealTypeLabels = map[string]string{
+	"breakfast": "Breakfast",
+	"lunch":     "Lunch",
+	"dinner":    "Dinner",
+	"snack":     "Snack",
+}
+
+// mealIngredientDraft is an ingredient row in the meal plan dialog
+type mealIngredientDraft struct {
+	objectID       string
+	name           string
+	quantityEditor widget.Editor
+	unitEditor     widget.Editor
+	removeButton   widget.Clickable
+}
+
+func newMealIngredientDraft(objectID, name, quantity, unit string) *mealIngredientDraft {
+	d := &mealIngredientDraft{objectID: objectID, name: name}
+	d.quantityEditor.SingleLine = true
+	d.unitEditor.SingleLine = true
+	d.quantityEditor.SetText(quantity)
+	d.unitEditor.SetText(unit)
+	return d
+}
+
+// calendarDay returns t's local calendar date as midnight UTC, so day
+// arithmetic is not affected by daylight saving changes
+func calendarDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart returns the Monday of the week containing day
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func sortMealPlans(plans []types.MealPlan) {
+	slices.SortStableFunc(plans, func(a, b types.MealPlan) int {
+		if c := strings.Compare(a.Date, b.Date); c != 0 {
+			return c
+		}
+		return slices.Index(mealTypes, a.Meal) - slices.Index(mealTypes, b.Meal)
+	})
+}
+
+func formatMealQuantity(quantity float64, unit string) string {
+	return strings.TrimSpace(strconv.FormatFloat(quantity, 'f', -1, 64) + " " + unit)
+}
+
+// mealCompletionNotice summarizes what completing a meal used up
+func mealCompletionNotice(result *types.CompleteMealPlanResult) string {
+	msg := fmt.Sprintf("Cooked %s: used up %d ingredient(s)", result.MealPlan.RecipeName, len(result.Adjusted))
+	if len(result.Skipped) > 0 {
+		names := make([]string, len(result.Skipped))
+		for i, s := range result.Skipped {
+			names[i] = s.Ingredient.Name
+		}
+		msg += ". Not adjusted: " + strings.Join(names, ", ")
+	}
+	return msg
+}
+
+// currentMealWeek returns the Monday of the week on screen, starting at the
+// current week
+func (ga *GioApp) currentMealWeek() time.Time {
+	if ga.mealWeekStart.IsZero() {
+		ga.mealWeekStart = weekStart(calendarDay(time.Now()))
+	}
+	return ga.mealWeekStart
+}
+
+// shiftMealWeek moves the view by the given number of weeks
+func (ga *GioApp) shiftMealWeek(weeks int) {
+	ga.mealWeekStart = ga.currentMealWeek().AddDate(0, 0, 7*weeks)
+	ga.mealNotice = ""
+}
+
+// resetMealPlans drops the loaded plans and food objects when the session ends
+func (ga *GioApp) resetMealPlans() {
+	ga.mealWeekStart = time.Time{}
+	ga.mealPlans = nil
+	ga.mealPlansWeek = time.Time{}
+	ga.mealPlansErr = ""
+	ga.mealNotice = ""
+	ga.mealFoodObjects = nil
+	ga.mealFoodLoaded = false
+	ga.showMealPlanDialog = false
+	ga.editingMealPlan = nil
+}
+
+// ensureMealPlansLoaded fetches the plans of the week on screen unless they
+// are already loaded or on their way
+func (ga *GioApp) ensureMealPlansLoaded() {
+	week := ga.currentMealWeek()
+	if ga.currentUser == nil || ga.mealPlansLoading || ga.mealPlansWeek.Equal(week) {
+		return
+	}
+
+	userID := ga.currentUser.ID
+	ga.mealPlansLoading = true
+	ga.mealPlansErr = ""
+
+	ga.goSafe(func() {
+		list, err := ga.mealPlansClient.List(userID, week.Format(mealPlanDateLayout), week.AddDate(0, 0, 7).Format(mealPlanDateLayout))
+
+		ga.do(func() {
+			ga.mealPlansLoading = false
+			// The user moved on to another week; the next frame loads it
+			if !ga.mealWeekStart.Equal(week) {
+				return
+			}
+			ga.mealPlansWeek = week
+			if err != nil {
+				ga.logger.Error("Failed to load meal plans", "week", week.Format(mealPlanDateLayout), "error", err)
+				ga.mealPlans = nil
+				ga.mealPlansErr = "Could not load meal plans: " + err.Error()
+				return
+			}
+			ga.mealPlans = list.MealPlans
+		})
+	})
+}
+
+// loadMealFoodObjects fetches the active objects of every food collection
+// for the ingredient picker
+func (ga *GioApp) loadMealFoodObjects() {
+	if ga.currentUser == nil || ga.mealFoodLoaded || ga.mealFoodLoading {
+		return
+	}
+	var collectionIDs []string
+	for _, c := range ga.collections {
+		if c.ObjectType == ObjectTypeFood {
+			collectionIDs = append(collectionIDs, c.ID)
+		}
+	}
+
+	userID := ga.currentUser.ID
+	ga.mealFoodLoading = true
+
+	ga.goSafe(func() {
+		var objects []Object
+		var failed []string
+		for _, id := range collectionIDs {
+			objs, err := ga.objectsClient.ListByCollection(userID, id, types.SortOptions{Field: "name", Order: "asc"})
+			if err != nil {
+				ga.logger.Error("Failed to load food objects", "collection_id", id, "error", err)
+				failed = append(failed, id)
+				continue
+			}
+			objects = append(objects, objs...)
+		}
+
+		ga.do(func() {
+			ga.mealFoodLoading = false
+			ga.mealFoodLoaded = true
+			ga.mealFoodObjects = objects
+			if len(failed) > 0 && ga.showMealPlanDialog {
+				ga.mealDialogErr = fmt.Sprintf("Food from %d collection(s) could not be loaded", len(failed))
+			}
+		})
+	})
+}
+
+// storeMealPlan inserts or replaces a plan in the loaded week, dropping it
+// when it was moved to another week
+func (ga *GioApp) storeMealPlan(plan types.MealPlan) {
+	ga.mealPlans = slices.DeleteFunc(ga.mealPlans, func(p types.MealPlan) bool { return p.ID == plan.ID })
+	day, err := time.Parse(mealPlanDateLayout, plan.Date)
+	if err != nil || day.Before(ga.mealPlansWeek) || !day.Before(ga.mealPlansWeek.AddDate(0, 0, 7)) {
+		return
+	}
+	ga.mealPlans = append(ga.mealPlans, plan)
+	sortMealPlans(ga.mealPlans)
+}
+
+// openCreateMealPlanDialog opens the dialog to plan a meal in a day's slot
+func (ga *GioApp) openCreateMealPlanDialog(day time.Time, meal string) {
+	ga.editingMealPlan = nil
+	ga.mealDialogDate = day
+	ga.mealDialogMeal = meal
+	ga.mealIngredientDrafts = nil
+	ga.widgetState.mealRecipeEditor.SetText("")
+	ga.openMealPlanDialog()
+}
+
+// openCreateMealPlanDialogToday plans a dinner today, or on the first day of
+// the week on screen when today is not in it
+func (ga *GioApp) openCreateMealPlanDialogToday() {
+	week := ga.currentMealWeek()
+	day := calendarDay(time.Now())
+	if day.Before(week) || !day.Before(week.AddDate(0, 0, 7)) {
+		day = week
+	}
+	ga.openCreateMealPlanDialog(day, "dinner")
+}
+
+// openEditMealPlanDialog opens the dialog for a plan that has not been cooked
+func (ga *GioApp) openEditMealPlanDialog(plan types.MealPlan) {
+	day, err := time.Parse(mealPlanDateLayout, plan.Date)
+	if err != nil {
+		ga.logger.Error("Invalid meal plan date", "meal_plan_id", plan.ID, "date", plan.Date)
+		return
+	}
+	ga.editingMealPlan = &plan
+	ga.mealDialogDate = day
+	ga.mealDialogMeal = plan.Meal
+	ga.mealIngredientDrafts = make([]*mealIngredientDraft, len(plan.Ingredients))
+	for i, ing := range plan.Ingredients {
+		ga.mealIngredientDrafts[i] = newMealIngredientDraft(ing.ObjectID, ing.Name, strconv.FormatFloat(ing.Quantity, 'f', -1, 64), ing.Unit)
+	}
+	ga.widgetState.mealRecipeEditor.SetText(plan.RecipeName)
+	ga.openMealPlanDialog()
+}
+
+func (ga *GioApp) openMealPlanDialog() {
+	ga.mealDialogErr = ""
+	ga.widgetState.mealRecipeEditor.SingleLine = true
+	ga.widgetState.mealIngredientSearch.SingleLine = true
+	ga.widgetState.mealIngredientSearch.SetText("")
+	ga.widgetState.mealPlanDialog.Reset()
+	ga.showMealPlanDialog = true
+	ga.loadMealFoodObjects()
+}
+
+func (ga *GioApp) closeMealPlanDialog() {
+	ga.showMealPlanDialog = false
+	ga.editingMealPlan = nil
+	ga.mealIngredientDrafts = nil
+	ga.mealDialogErr = ""
+	ga.widgetState.mealPlanDialog.Reset()
+}
+
+// addMealIngredient links a food object to the meal being edited, defaulting
+// to one of the object's unit
+func (ga *GioApp) addMealIngredient(obj Object) {
+	for _, d := range ga.mealIngredientDrafts {
+		if d.objectID == obj.ID {
+			return
+		}
+	}
+	ga.mealIngredientDrafts = append(ga.mealIngredientDrafts, newMealIngredientDraft(obj.ID, obj.Name, "1", obj.Unit))
+	ga.widgetState.mealIngredientSearch.SetText("")
+}
+
+// submitMealPlanDialog saves the meal being planned or edited
+func (ga *GioApp) submitMealPlanDialog() {
+	if ga.currentUser == nil || ga.mealPlanSaving {
+		return
+	}
+	recipe := strings.TrimSpace(ga.widgetState.mealRecipeEditor.Text())
+	if recipe == "" {
+		ga.mealDialogErr = "Recipe name is required"
+		return
+	}
+	ingredients := make([]types.MealIngredientRequest, 0, len(ga.mealIngredientDrafts))
+	for _, d := range ga.mealIngredientDrafts {
+		quantity, err := strconv.ParseFloat(strings.TrimSpace(d.quantityEditor.Text()), 64)
+		if err != nil || quantity <= 0 {
+			ga.mealDialogErr = fmt.Sprintf("Enter a quantity above zero for %s", d.name)
+			return
+		}
+		ingredients = append(ingredients, types.MealIngredientRequest{
+			ObjectID: d.objectID,
+			Quantity: quantity,
+			Unit:     strings.TrimSpace(d.unitEditor.Text()),
+		})
+	}
+
+	date := ga.mealDialogDate.Format(mealPlanDateLayout)
+	meal := ga.mealDialogMeal
+	userID := ga.currentUser.ID
+	editing := ga.editingMealPlan
+	ga.mealPlanSaving = true
+	ga.mealDialogErr = ""
+
+	ga.goSafe(func() {
+		var saved *types.MealPlan
+		var err error
+		if editing == nil {
+			saved, err = ga.mealPlansClient.Create(userID, types.CreateMealPlanRequest{
+				Date:        date,
+				Meal:        meal,
+				RecipeName:  recipe,
+				Ingredients: ingredients,
+			})
+		} else {
+			saved, err = ga.mealPlansClient.Update(userID, editing.ID, types.UpdateMealPlanRequest{
+				Date:        &date,
+				Meal:        &meal,
+				RecipeName:  &recipe,
+				Ingredients: &ingredients,
+			})
+		}
+
+		ga.do(func() {
+			ga.mealPlanSaving = false
+			if err != nil {
+				ga.logger.Error("Failed to save meal plan", "error", err)
+				ga.mealDialogErr = "Could not save meal: " + err.Error()
+				return
+			}
+			ga.storeMealPlan(*saved)
+			ga.closeMealPlanDialog()
+		})
+	})
+}
+
+// completeMealPlan marks the meal as cooked, which uses up its ingredients
+func (ga *GioApp) completeMealPlan(plan types.MealPlan) {
+	if ga.currentUser == nil {
+		return
+	}
+	userID := ga.currentUser.ID
+	ga.logger.Info("Completing meal plan", "meal_plan_id", plan.ID, "ingredients", len(plan.Ingredients))
+
+	ga.goSafe(func() {
+		result, err := ga.mealPlansClient.Complete(userID, plan.ID)
+
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to complete meal plan", "meal_plan_id", plan.ID, "error", err)
+				ga.mealPlansErr = "Could not complete " + plan.RecipeName + ": " + err.Error()
+				return
+			}
+			ga.storeMealPlan(result.MealPlan)
+			for _, obj := range result.Adjusted {
+				ga.updateObject(obj, obj.ContainerID)
+				for i := range ga.mealFoodObjects {
+					if ga.mealFoodObjects[i].ID == obj.ID {
+						ga.mealFoodObjects[i] = obj
+					}
+				}
+			}
+			ga.mealNotice = mealCompletionNotice(result)
+		})
+	})
+}
+
+// deleteMealPlan deletes a plan; quantities it used up are not restored
+func (ga *GioApp) deleteMealPlan(plan types.MealPlan) {
+	if ga.currentUser == nil {
+		return
+	}
+	userID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		err := ga.mealPlansClient.Delete(userID, plan.ID)
+
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to delete meal plan", "meal_plan_id", plan.ID, "error", err)
+				ga.mealPlansErr = "Could not delete " + plan.RecipeName + ": " + err.Error()
+				return
+			}
+			ga.mealPlans = slices.DeleteFunc(ga.mealPlans, func(p types.MealPlan) bool { return p.ID == plan.ID })
+			delete(ga.widgetState.mealPlanItems, plan.ID)
+		})
+	})
+}
+
+// renderMealPlanView renders the week's meals, one card per day
+func (ga *GioApp) renderMealPlanView(gtx layout.Context) layout.Dimensions {
+	if !ga.featureEnabled(featureMealPlanner) {
+		ga.currentView = ViewDashboardGio
+		return ga.renderDashboardView(gtx)
+	}
+	if ga.widgetState.mealPrevWeek.Clicked(gtx) {
+		ga.shiftMealWeek(-1)
+	}
+	if ga.widgetState.mealThisWeek.Clicked(gtx) {
+		ga.mealWeekStart = weekStart(calendarDay(time.Now()))
+		ga.mealNotice = ""
+	}
+	if ga.widgetState.mealNextWeek.Clicked(gtx) {
+		ga.shiftMealWeek(1)
+	}
+	ga.ensureMealPlansLoaded()
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderHeader(gtx, "Meal Plan")
+		}),
+
+		// Week navigation and status
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing4), Right: unit.Dp(theme.Spacing4)}.Layout(gtx, ga.renderMealWeekBar)
+		}),
+
+		// Days
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing3),
+				Bottom: unit.Dp(theme.Spacing20), // Space for bottom menu
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				week := ga.currentMealWeek()
+				return material.List(ga.theme.Theme, &ga.widgetState.mealDaysList).Layout(gtx, 7, func(gtx layout.Context, i int) layout.Dimensions {
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderMealDay(gtx, week.AddDate(0, 0, i))
+					})
+				})
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBottomMenu(gtx, ViewMealPlanGio)
+		}),
+	)
+}
+
+// renderMealWeekBar renders the week switcher with the loading, error and
+// completion messages below it
+func (ga *GioApp) renderMealWeekBar(gtx layout.Context) layout.Dimensions {
+	week := ga.currentMealWeek()
+	title := week.Format("Jan 2") + " – " + week.AddDate(0, 0, 6).Format("Jan 2, 2006")
+
+	status, statusColor := ga.mealNotice, theme.ColorAccentDark
+	switch {
+	case ga.mealPlansErr != "":
+		status, statusColor = ga.mealPlansErr, theme.ColorDanger
+	case ga.mealPlansLoading:
+		status, statusColor = "Loading meals...", theme.ColorTextSecondary
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.mealPrevWeek, "‹ Prev")),
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body1(ga.theme.Theme, title)
+						label.Font.Weight = font.Bold
+						return label.Layout(gtx)
+					})
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+						widgets.CancelButton(ga.theme.Theme, &ga.widgetState.mealThisWeek, "This Week"))
+				}),
+				layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.mealNextWeek, "Next ›")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if status == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, status)
+				label.Color = statusColor
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
+
+// renderMealDay renders one day with a row per meal
+func (ga *GioApp) renderMealDay(gtx layout.Context, day time.Time) layout.Dimensions {
+	date := day.Format(mealPlanDateLayout)
+	isToday := day.Equal(calendarDay(time.Now()))
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.H6(ga.theme.Theme, day.Format("Monday, Jan 2"))
+				if isToday {
+					label.Color = theme.ColorPrimary
+				}
+				return label.Layout(gtx)
+			})
+		}),
+	}
+	for _, meal := range mealTypes {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderMealSlot(gtx, day, date, meal)
+		}))
+	}
+
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// renderMealSlot renders the plans for one meal of a day and a button to
+// plan another
+func (ga *GioApp) renderMealSlot(gtx layout.Context, day time.Time, date, meal string) layout.Dimensions {
+	key := date + "|" + meal
+	addBtn := ga.widgetState.mealSlotButtons[key]
+	if addBtn == nil {
+		addBtn = &widget.Clickable{}
+		ga.widgetState.mealSlotButtons[key] = addBtn
+	}
+	if addBtn.Clicked(gtx) {
+		ga.openCreateMealPlanDialog(day, meal)
+	}
+
+	var plans []layout.FlexChild
+	for _, plan := range ga.mealPlans {
+		if plan.Date != date || plan.Meal != meal {
+			continue
+		}
+		plans = append(plans, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderMealPlanEntry(gtx, plan)
+			})
+		}))
+	}
+	if len(plans) == 0 {
+		plans = append(plans, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body2(ga.theme.Theme, "Nothing planned")
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}))
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = gtx.Dp(unit.Dp(88))
+				label := material.Body2(ga.theme.Theme, mealTypeLabels[meal])
+				label.Font.Weight = font.Bold
+				return label.Layout(gtx)
+			}),
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, plans...)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				chip := filterChip("+", false)
+				chip.Description = "Plan a " + strings.ToLower(mealTypeLabels[meal])
+				return chip.Layout(gtx, ga.theme.Theme, addBtn)
+			}),
+		)
+	})
+}
+
+// renderMealPlanEntry renders a planned meal with its ingredients and actions
+func (ga *GioApp) renderMealPlanEntry(gtx layout.Context, plan types.MealPlan) layout.Dimensions {
+	item := ga.widgetState.mealPlanItems[plan.ID]
+	if item == nil {
+		item = &MealPlanItemState{}
+		ga.widgetState.mealPlanItems[plan.ID] = item
+	}
+	if item.completeButton.Clicked(gtx) && !plan.Completed {
+		ga.completeMealPlan(plan)
+	}
+	if item.editButton.Clicked(gtx) && !plan.Completed {
+		ga.openEditMealPlanDialog(plan)
+	}
+	if item.deleteButton.Clicked(gtx) {
+		ga.deleteMealPlan(plan)
+	}
+
+	ingredients := make([]string, len(plan.Ingredients))
+	for i, ing := range plan.Ingredients {
+		ingredients[i] = ing.Name + " " + formatMealQuantity(ing.Quantity, ing.Unit)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			name := plan.RecipeName
+			if plan.Completed {
+				name = "✓ " + name
+			}
+			label := material.Body1(ga.theme.Theme, name)
+			if plan.Completed {
+				label.Color = theme.ColorTextSecondary
+			}
+			return label.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if len(ingredients) == 0 {
+				return layout.Dimensions{}
+			}
+			label := material.Caption(ga.theme.Theme, strings.Join(ingredients, " · "))
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				if plan.Completed {
+					return widgets.DangerButton(ga.theme.Theme, &item.deleteButton, "Delete")(gtx)
+				}
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.AccentButton(ga.theme.Theme, &item.completeButton, "Cooked"))
+					}),
+					layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &item.editButton, "Edit")),
+				)
+			})
+		}),
+	)
+}
+
+// renderMealPlanDialog renders the dialog to plan or edit a meal
+func (ga *GioApp) renderMealPlanDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showMealPlanDialog {
+		return layout.Dimensions{}
+	}
+
+	ws := ga.widgetState
+	if ws.mealPlanDialogSubmit.Clicked(gtx) {
+		ga.submitMealPlanDialog()
+	}
+	if ws.mealPlanDialogDelete.Clicked(gtx) && ga.editingMealPlan != nil {
+		ga.deleteMealPlan(*ga.editingMealPlan)
+		ga.closeMealPlanDialog()
+		return layout.Dimensions{}
+	}
+	if ws.mealPlanDialogCancel.Clicked(gtx) {
+		ga.closeMealPlanDialog()
+		return layout.Dimensions{}
+	}
+	ga.mealIngredientDrafts = slices.DeleteFunc(ga.mealIngredientDrafts, func(d *mealIngredientDraft) bool {
+		return d.removeButton.Clicked(gtx)
+	})
+
+	title := "Plan Meal"
+	if ga.editingMealPlan != nil {
+		title = "Edit Meal"
+	}
+	dialogStyle := widgets.DefaultDialogStyle(ws.mealPlanDialog, title)
+	dialogStyle.Width = unit.Dp(560)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return material.List(ga.theme.Theme, &ws.mealDialogList).Layout(gtx, 1, func(gtx layout.Context, _ int) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return ga.renderFormField(gtx, "Recipe *", &ws.mealRecipeEditor, "e.g., Spaghetti pomodoro")
+						}),
+						layout.Rigid(ga.renderMealDaySelector),
+						layout.Rigid(ga.renderMealTypeSelector),
+						layout.Rigid(ga.renderMealIngredientRows),
+						layout.Rigid(ga.renderMealIngredientPicker),
+					)
+				})
+			}),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.mealDialogErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.mealDialogErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.editingMealPlan == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.DangerButton(ga.theme.Theme, &ws.mealPlanDialogDelete, "Delete"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ws.mealPlanDialogCancel, "Cancel"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := "Save"
+						if ga.mealPlanSaving {
+							label = "Saving..."
+						}
+						return widgets.PrimaryButton(ga.theme.Theme, &ws.mealPlanDialogSubmit, label)(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeMealPlanDialog()
+	}
+	return dims
+}
+
+// renderMealDaySelector renders a chip per day of the meal's week
+func (ga *GioApp) renderMealDaySelector(gtx layout.Context) layout.Dimensions {
+	week := weekStart(ga.mealDialogDate)
+	chips := make([]layout.Widget, 7)
+	for i := range chips {
+		day := week.AddDate(0, 0, i)
+		btn := &ga.widgetState.mealDayButtons[i]
+		if btn.Clicked(gtx) {
+			ga.mealDialogDate = day
+		}
+		active := day.Equal(ga.mealDialogDate)
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, day.Format("Mon 2"), active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Day *", chips)
+}
+
+// renderMealTypeSelector renders the breakfast/lunch/dinner/snack chips
+func (ga *GioApp) renderMealTypeSelector(gtx layout.Context) layout.Dimensions {
+	chips := make([]layout.Widget, len(mealTypes))
+	for i, meal := range mealTypes {
+		btn := ga.widgetState.mealTypeButtons[meal]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.mealTypeButtons[meal] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.mealDialogMeal = meal
+		}
+		active := ga.mealDialogMeal == meal
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, mealTypeLabels[meal], active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Meal *", chips)
+}
+
+// renderMealIngredientRows renders the linked ingredients with editable
+// quantity and unit
+func (ga *GioApp) renderMealIngredientRows(gtx layout.Context) layout.Dimensions {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body2(ga.theme.Theme, "Ingredients")
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}),
+	}
+	if len(ga.mealIngredientDrafts) == 0 {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Caption(ga.theme.Theme, "Pick food below; cooking the meal uses up these amounts")
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}))
+	}
+	for _, d := range ga.mealIngredientDrafts {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(0.45, material.Body1(ga.theme.Theme, d.name).Layout),
+					layout.Flexed(0.2, func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							material.Editor(ga.theme.Theme, &d.quantityEditor, "Qty").Layout)
+					}),
+					layout.Flexed(0.2, func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							material.Editor(ga.theme.Theme, &d.unitEditor, "Unit").Layout)
+					}),
+					layout.Rigid(widgets.CancelButton(ga.theme.Theme, &d.removeButton, "Remove")),
+				)
+			})
+		}))
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// renderMealIngredientPicker renders a search over the food objects and the
+// best matches as chips that add an ingredient
+func (ga *GioApp) renderMealIngredientPicker(gtx layout.Context) layout.Dimensions {
+	switch {
+	case ga.mealFoodLoading:
+		return ga.mealNoteLabel(gtx, "Loading food...")
+	case len(ga.mealFoodObjects) == 0:
+		return ga.mealNoteLabel(gtx, "No food objects to link. Add a food collection to track ingredients.")
+	}
+
+	query := strings.ToLower(strings.TrimSpace(ga.widgetState.mealIngredientSearch.Text()))
+	var chips []layout.Widget
+	for _, obj := range ga.mealFoodObjects {
+		if len(chips) == maxIngredientSuggestions {
+			break
+		}
+		if query != "" && !strings.Contains(strings.ToLower(obj.Name), query) {
+			continue
+		}
+		btn := ga.widgetState.mealFoodButtons[obj.ID]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.mealFoodButtons[obj.ID] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.addMealIngredient(obj)
+		}
+		label := obj.Name
+		if obj.Quantity != nil {
+			label += " (" + formatMealQuantity(*obj.Quantity, obj.Unit) + ")"
+		}
+		chips = append(chips, func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, label, false)
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFormField(gtx, "Add Ingredient", &ga.widgetState.mealIngredientSearch, "Search food")
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if len(chips) == 0 {
+				return ga.mealNoteLabel(gtx, "No matching food")
+			}
+			return ga.renderChipSelector(gtx, "Matches", chips)
+		}),
+	)
+}
+
+func (ga *GioApp) mealNoteLabel(gtx layout.Context, msg string) layout.Dimensions {
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, msg)
+		label.Color = theme.ColorTextSecondary
+		return label.Layout(gtx)
+	})
+}
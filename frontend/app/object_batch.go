@@ -0,0 +1,348 @@
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// objectTapped reads the presses on an object's row or card. A long press
+// starts multi-select with the object picked, and in multi-select a tap
+// picks or drops it. It reports the other taps, which open the object.
+func (ga *GioApp) objectTapped(gtx layout.Context, obj Object, btn *widget.Clickable, press *widgets.LongPress) bool {
+	clicked := btn.Clicked(gtx)
+	if press.Update(gtx, btn) {
+		ga.startObjectSelection(obj.ID)
+		return false
+	}
+	if !clicked || press.Fired(btn) {
+		return false
+	}
+	if ga.selectingObjects {
+		ga.setObjectSelected(obj.ID, !ga.selectedObjectIDs[obj.ID])
+		return false
+	}
+	return true
+}
+
+// startObjectSelection enters multi-select, keeping any selection already
+// made, and picks objectID
+func (ga *GioApp) startObjectSelection(objectID string) {
+	ga.selectingObjects = true
+	ga.setObjectSelected(objectID, true)
+}
+
+// selectAllObjects picks every object the search and filters leave shown
+func (ga *GioApp) selectAllObjects() {
+	objects, _ := ga.getFilteredObjects()
+	for _, obj := range objects {
+		ga.setObjectSelected(obj.ID, true)
+	}
+}
+
+// selectedObjectIDList returns the IDs of the selected objects, in list order
+func (ga *GioApp) selectedObjectIDList() []string {
+	objects := ga.selectedObjects()
+	ids := make([]string, len(objects))
+	for i, obj := range objects {
+		ids[i] = obj.ID
+	}
+	return ids
+}
+
+func (ga *GioApp) openBatchMoveDialog() {
+	ga.showBatchMoveDialog = true
+	ga.batchMoveContainerID = ""
+	ga.batchErr = ""
+	ga.widgetState.batchMoveDialog.Reset()
+}
+
+func (ga *GioApp) closeBatchMoveDialog() {
+	ga.showBatchMoveDialog = false
+	ga.batchErr = ""
+	ga.widgetState.batchMoveDialog.Reset()
+}
+
+func (ga *GioApp) openBatchDeleteDialog() {
+	ga.showBatchDeleteDialog = true
+	ga.batchErr = ""
+	ga.widgetState.batchDeleteDialog.Reset()
+}
+
+func (ga *GioApp) closeBatchDeleteDialog() {
+	ga.showBatchDeleteDialog = false
+	ga.batchErr = ""
+	ga.widgetState.batchDeleteDialog.Reset()
+}
+
+// runBatch moves or deletes the selected objects in one request and mirrors
+// the change locally once the backend accepts. The backend changes nothing
+// when any of them has gone, so a failure leaves the list as it was.
+func (ga *GioApp) runBatch(operation, containerID string) {
+	if ga.currentUser == nil || ga.selectedCollection == nil || ga.batchRunning {
+		return
+	}
+	objects := ga.selectedObjects()
+	if len(objects) == 0 {
+		ga.closeBatchMoveDialog()
+		ga.closeBatchDeleteDialog()
+		return
+	}
+	userID := ga.currentUser.ID
+	collectionID := ga.selectedCollection.ID
+	req := types.BatchObjectsRequest{
+		Operation:   operation,
+		ObjectIDs:   ga.selectedObjectIDList(),
+		ContainerID: containerID,
+	}
+	ga.batchRunning = true
+	ga.batchErr = ""
+	ga.logger.Info("Applying batch operation", "operation", operation, "collection_id", collectionID, "count", len(objects))
+
+	ga.goSafe(func() {
+		result, err := ga.objectsClient.Batch(userID, collectionID, req)
+
+		ga.do(func() {
+			ga.batchRunning = false
+			if err != nil {
+				ga.logger.Error("Failed to apply batch operation", "operation", operation, "collection_id", collectionID, "error", err)
+				ga.batchErr = "That did not work: " + err.Error()
+				return
+			}
+			if ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			for _, obj := range objects {
+				if operation == "delete" {
+					ga.removeObject(obj.ID, obj.ContainerID)
+					continue
+				}
+				oldContainerID := obj.ContainerID
+				obj.ContainerID = containerID
+				ga.updateObject(obj, oldContainerID)
+			}
+			ga.logger.Info("Applied batch operation", "operation", operation, "changed", result.Changed)
+			ga.closeBatchMoveDialog()
+			ga.closeBatchDeleteDialog()
+			ga.resetObjectSelection()
+		})
+	})
+}
+
+// selectionCSV writes objects as CSV with the columns a collection import
+// reads, so the file can be imported elsewhere. location is the name of
+// the container each object is in.
+func selectionCSV(objects []Object, containerNames map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"name", "description", "quantity", "unit", "expires_at", "tags", "location"}); err != nil {
+		return nil, err
+	}
+	for _, obj := range objects {
+		quantity := ""
+		if obj.Quantity != nil {
+			quantity = strconv.FormatFloat(*obj.Quantity, 'f', -1, 64)
+		}
+		expires := ""
+		if obj.ExpiresAt != nil {
+			expires = obj.ExpiresAt.Format("2006-01-02")
+		}
+		record := []string{obj.Name, obj.Description, quantity, obj.Unit, expires, strings.Join(obj.Tags, ", "), containerNames[obj.ContainerID]}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// exportSelectedObjects saves the selected objects as a CSV file
+func (ga *GioApp) exportSelectedObjects() {
+	objects := ga.selectedObjects()
+	if len(objects) == 0 || ga.selectedCollection == nil {
+		return
+	}
+	names := make(map[string]string, len(ga.containers))
+	for _, c := range ga.containers {
+		names[c.ID] = c.Name
+	}
+	data, err := selectionCSV(objects, names)
+	if err != nil {
+		ga.selectionNotice = "Export failed: " + err.Error()
+		return
+	}
+	filename := safeFilename(ga.selectedCollection.Name, "collection") + "-selection-" + time.Now().Format("2006-01-02") + ".csv"
+	session := ga.session
+
+	ga.goSafe(func() {
+		path, err := saveDownload(filename, data, "text/csv")
+
+		ga.doInSession(session, func() {
+			switch {
+			case err != nil:
+				ga.logger.Error("Failed to export selection", "error", err)
+				ga.selectionNotice = "Export failed: " + err.Error()
+			case path != "":
+				ga.selectionNotice = fmt.Sprintf("CSV saved to %s", path)
+			default:
+				ga.selectionNotice = "CSV downloaded"
+			}
+		})
+	})
+}
+
+// renderBatchMoveDialog asks which container of the collection to move
+// the selected objects to
+func (ga *GioApp) renderBatchMoveDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showBatchMoveDialog {
+		return layout.Dimensions{}
+	}
+	ws := ga.widgetState
+	if ws.batchMoveConfirm.Clicked(gtx) {
+		if ga.batchMoveContainerID == "" {
+			ga.batchErr = "Pick a container"
+		} else {
+			ga.runBatch("move", ga.batchMoveContainerID)
+		}
+	}
+	if ws.batchMoveCancel.Clicked(gtx) {
+		ga.closeBatchMoveDialog()
+		return layout.Dimensions{}
+	}
+
+	chips := make([]layout.Widget, len(ga.containers))
+	for i, c := range ga.containers {
+		btn := ws.batchMoveContainerButtons[c.ID]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ws.batchMoveContainerButtons[c.ID] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.batchMoveContainerID = c.ID
+		}
+		active := ga.batchMoveContainerID == c.ID
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, c.Name, active)
+		}
+	}
+
+	title := fmt.Sprintf("Move %d Selected", len(ga.selectedObjects()))
+	dialogStyle := widgets.DefaultDialogStyle(ws.batchMoveDialog, title)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				if len(chips) == 0 {
+					return ga.mealNoteLabel(gtx, "This collection has no containers to move objects to.")
+				}
+				return material.List(ga.theme.Theme, &ws.batchMoveList).Layout(gtx, 1, func(gtx layout.Context, _ int) layout.Dimensions {
+					return ga.renderChipSelector(gtx, "Move to", chips)
+				})
+			}),
+			layout.Rigid(ga.renderBatchError),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderBatchButtons(gtx, &ws.batchMoveCancel, &ws.batchMoveConfirm, "Move", "Moving...", false)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeBatchMoveDialog()
+	}
+	return dims
+}
+
+// renderBatchDeleteDialog confirms deleting the selected objects
+func (ga *GioApp) renderBatchDeleteDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showBatchDeleteDialog {
+		return layout.Dimensions{}
+	}
+	ws := ga.widgetState
+	if ws.batchDeleteConfirm.Clicked(gtx) {
+		ga.runBatch("delete", "")
+	}
+	if ws.batchDeleteCancel.Clicked(gtx) {
+		ga.closeBatchDeleteDialog()
+		return layout.Dimensions{}
+	}
+
+	count := len(ga.selectedObjects())
+	dialogStyle := widgets.DefaultDialogStyle(ws.batchDeleteDialog, fmt.Sprintf("Delete %d Selected", count))
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, "The selected objects and their photos will be deleted. This cannot be undone.")
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(ga.renderBatchError),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderBatchButtons(gtx, &ws.batchDeleteCancel, &ws.batchDeleteConfirm, "Delete", "Deleting...", true)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeBatchDeleteDialog()
+	}
+	return dims
+}
+
+func (ga *GioApp) renderBatchError(gtx layout.Context) layout.Dimensions {
+	if ga.batchErr == "" {
+		return layout.Dimensions{}
+	}
+	return layout.Inset{Top: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, ga.batchErr)
+		label.Color = theme.ColorDanger
+		return label.Layout(gtx)
+	})
+}
+
+// renderBatchButtons renders a batch dialog's cancel and confirm buttons
+func (ga *GioApp) renderBatchButtons(gtx layout.Context, cancel, confirm *widget.Clickable, label, running string, danger bool) layout.Dimensions {
+	if ga.batchRunning {
+		label = running
+	}
+	button := widgets.PrimaryButton
+	if danger {
+		button = widgets.DangerButton
+	}
+	return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+				widgets.CancelButton(ga.theme.Theme, cancel, "Cancel"))
+		}),
+		layout.Rigid(button(ga.theme.Theme, confirm, label)),
+	)
+}
+
+// selectionHighlight tints the area of a row or thumbnail whose object is
+// selected
+func selectionHighlight(gtx layout.Context, selected bool) layout.Dimensions {
+	size := gtx.Constraints.Min
+	if selected {
+		tint := theme.ColorPrimary
+		tint.A = 50
+		defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+		paint.ColorOp{Color: tint}.Add(gtx.Ops)
+		paint.PaintOp{}.Add(gtx.Ops)
+	}
+	return layout.Dimensions{Size: size}
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectionCSV(t *testing.T) {
+	quantity := 2.5
+	expires := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	objects := []Object{
+		{Name: "Rice", Description: "Long grain, white", Quantity: &quantity, Unit: "kg", ExpiresAt: &expires, Tags: []string{"grain", "bulk"}, ContainerID: "c1"},
+		{Name: "Lamp", ContainerID: "gone"},
+	}
+
+	data, err := selectionCSV(objects, map[string]string{"c1": "Pantry"})
+	if err != nil {
+		t.Fatalf("selectionCSV() error = %v", err)
+	}
+
+	want := "name,description,quantity,unit,expires_at,tags,location\n" +
+		"Rice,\"Long grain, white\",2.5,kg,2026-03-01,\"grain, bulk\",Pantry\n" +
+		"Lamp,,,,,,\n"
+	if string(data) != want {
+		t.Errorf("selectionCSV() =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestSelectAllObjects(t *testing.T) {
+	ga := newTestGioApp()
+	ga.objects = makeObjects(3, "category", []string{"a"})
+	ga.widgetState.objectsSearchField.SetText("object 1")
+
+	ga.startObjectSelection("obj-0")
+	ga.selectAllObjects()
+
+	if !ga.selectingObjects {
+		t.Error("selection did not start")
+	}
+	ids := ga.selectedObjectIDList()
+	if len(ids) != 2 || ids[0] != "obj-0" || ids[1] != "obj-1" {
+		t.Errorf("selected = %v, want obj-0 kept and only obj-1 added by the search", ids)
+	}
+
+	ga.resetObjectSelection()
+	if ga.selectingObjects || len(ga.selectedObjectIDList()) != 0 {
+		t.Error("reset kept the selection")
+	}
+}
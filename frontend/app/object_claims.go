@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+	"unicode"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// activeClaim returns the object's claim unless it has run out since the
+// object was loaded
+func activeClaim(obj Object, now time.Time) *ObjectClaim {
+	if obj.Claim == nil || !obj.Claim.Until.After(now) {
+		return nil
+	}
+	return obj.Claim
+}
+
+// claimInitials turns a username into the one or two letters shown in the
+// claimer's avatar, e.g. "jane.doe" becomes "JD"
+func claimInitials(username string) string {
+	parts := strings.FieldsFunc(username, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var initials []rune
+	for _, p := range parts {
+		initials = append(initials, unicode.ToUpper([]rune(p)[0]))
+		if len(initials) == 2 {
+			break
+		}
+	}
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// claimTimeLeft describes how long a claim has to run, rounded down to hours
+// or days
+func claimTimeLeft(until, now time.Time) string {
+	left := until.Sub(now)
+	switch {
+	case left < time.Hour:
+		return "less than 1h left"
+	case left < 48*time.Hour:
+		return fmt.Sprintf("%dh left", int(left.Hours()))
+	default:
+		return fmt.Sprintf("%dd left", int(left.Hours()/24))
+	}
+}
+
+// canReleaseClaim reports whether the signed-in user may drop the claim: the
+// claimer and the collection owner can, other members have to wait it out.
+func (ga *GioApp) canReleaseClaim(claim *ObjectClaim) bool {
+	if ga.currentUser == nil {
+		return false
+	}
+	if claim.UserID == ga.currentUser.ID {
+		return true
+	}
+	return ga.selectedCollection != nil && ga.selectedCollection.UserID == ga.currentUser.ID
+}
+
+// setObjectClaimed claims an object for the default duration or releases the
+// claim, then swaps in the object the server returns
+func (ga *GioApp) setObjectClaimed(obj Object, claimed bool) {
+	if ga.currentUser == nil || ga.claimingObjectID != "" {
+		return
+	}
+	userID := ga.currentUser.ID
+	ga.claimingObjectID = obj.ID
+	ga.logger.Info("Setting object claim", "object_id", obj.ID, "claimed", claimed)
+
+	ga.goSafe(func() {
+		var updated *Object
+		var err error
+		if claimed {
+			updated, err = ga.objectsClient.Claim(userID, obj.ID, nil)
+		} else {
+			updated, err = ga.objectsClient.ReleaseClaim(userID, obj.ID)
+		}
+
+		ga.do(func() {
+			ga.claimingObjectID = ""
+			if err != nil {
+				ga.logger.Error("Failed to update object claim", "object_id", obj.ID, "error", err)
+				ga.showAPIErrorDialog("Failed to update claim: " + err.Error())
+				return
+			}
+			ga.updateObject(*updated, obj.ContainerID)
+		})
+	})
+}
+
+// claimButtonText labels the claim button on an object card, or returns ""
+// when the signed-in user cannot act on the current claim
+func (ga *GioApp) claimButtonText(obj Object) string {
+	switch claim := activeClaim(obj, time.Now()); {
+	case ga.claimingObjectID == obj.ID:
+		return ""
+	case claim == nil:
+		return "Claim"
+	case ga.canReleaseClaim(claim):
+		return "Release"
+	default:
+		return ""
+	}
+}
+
+// renderClaimBadge renders the claimer's initials in a round avatar next to
+// who holds the object and for how long
+func (ga *GioApp) renderClaimBadge(gtx layout.Context, claim *ObjectClaim) layout.Dimensions {
+	name := claim.Username
+	if ga.currentUser != nil && claim.UserID == ga.currentUser.ID {
+		name = "you"
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			size := gtx.Dp(unit.Dp(theme.Spacing6))
+			gtx.Constraints = layout.Exact(image.Pt(size, size))
+			return layout.Stack{Alignment: layout.Center}.Layout(gtx,
+				layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+					defer clip.Ellipse{Max: gtx.Constraints.Min}.Push(gtx.Ops).Pop()
+					paint.ColorOp{Color: theme.ColorPrimary}.Add(gtx.Ops)
+					paint.PaintOp{}.Add(gtx.Ops)
+					return layout.Dimensions{Size: gtx.Constraints.Min}
+				}),
+				layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+					label := material.Caption(ga.theme.Theme, claimInitials(claim.Username))
+					label.Color = theme.ColorWhite
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Caption(ga.theme.Theme, fmt.Sprintf("Claimed by %s · %s", name, claimTimeLeft(claim.Until, time.Now())))
+				label.Color = theme.ColorTextSecondary
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClaimInitials(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"alice", "A"},
+		{"jane.doe", "JD"},
+		{"Mary Ann Smith", "MA"},
+		{"__", "?"},
+		{"", "?"},
+	}
+	for _, tt := range tests {
+		if got := claimInitials(tt.in); got != tt.want {
+			t.Errorf("claimInitials(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestClaimTimeLeft(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{10 * time.Minute, "less than 1h left"},
+		{5*time.Hour + 30*time.Minute, "5h left"},
+		{30 * time.Hour, "30h left"},
+		{3*24*time.Hour + time.Hour, "3d left"},
+	}
+	for _, tt := range tests {
+		if got := claimTimeLeft(now.Add(tt.left), now); got != tt.want {
+			t.Errorf("claimTimeLeft(%v) = %q, want %q", tt.left, got, tt.want)
+		}
+	}
+}
+
+func TestActiveClaim(t *testing.T) {
+	now := time.Now()
+	obj := Object{ID: "obj-1"}
+	if activeClaim(obj, now) != nil {
+		t.Error("unclaimed object has an active claim")
+	}
+
+	obj.Claim = &ObjectClaim{UserID: "user-1", Username: "alice", Until: now.Add(time.Hour)}
+	if activeClaim(obj, now) == nil {
+		t.Error("claim running for another hour is not active")
+	}
+	if activeClaim(obj, now.Add(2*time.Hour)) != nil {
+		t.Error("claim is still active after it ran out")
+	}
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// objectCodeKeys mirror the backend's code properties: a barcode, ISBN or
+// SKU saying what an object is
+var objectCodeKeys = []string{"upc", "ean", "barcode", "isbn", "sku"}
+
+// pendingObjectCreate is a create held back while the user decides whether
+// a scanned code means adding to an existing object instead
+type pendingObjectCreate struct {
+	userID       string
+	collectionID string
+	req          types.CreateObjectRequest
+}
+
+// objectCode returns the first barcode, ISBN or SKU in the properties, or ""
+func objectCode(props map[string]any) string {
+	for _, key := range objectCodeKeys {
+		if code, ok := props[key].(string); ok && strings.TrimSpace(code) != "" {
+			return strings.TrimSpace(code)
+		}
+	}
+	return ""
+}
+
+// preferredCodeMatch picks the match to add to: the first one in the open
+// collection, else the first one
+func preferredCodeMatch(matches []types.ObjectCodeMatch, collectionID string) int {
+	for i, m := range matches {
+		if m.CollectionID == collectionID {
+			return i
+		}
+	}
+	return 0
+}
+
+// increasedQuantity adds a new item's quantity to an existing one, counting
+// a missing quantity as one item
+func increasedQuantity(existing, added *float64) float64 {
+	have, more := 1.0, 1.0
+	if existing != nil {
+		have = *existing
+	}
+	if added != nil {
+		more = *added
+	}
+	return have + more
+}
+
+// createObjectChecked creates the object unless it carries a code already
+// on one of the account's objects, in which case the user is asked whether
+// to increase that object's quantity instead. A failed lookup does not block
+// the create.
+func (ga *GioApp) createObjectChecked(pending pendingObjectCreate) {
+	ga.goSafe(func() {
+		if code := objectCode(pending.req.Properties); code != "" {
+			lookup, err := ga.objectsClient.Lookup(pending.userID, code)
+			if err != nil {
+				ga.logger.Warn("Failed to look up object code, creating anyway", "error", err)
+			} else if len(lookup.Matches) > 0 {
+				ga.do(func() {
+					ga.pendingCreate = &pending
+					ga.codeMatches = lookup.Matches
+					ga.showCodeMatchDialog = true
+					ga.widgetState.codeMatchDialog.Reset()
+				})
+				return
+			}
+		}
+		ga.sendObjectCreate(pending)
+	})
+}
+
+// sendObjectCreate creates the object; it runs off the UI goroutine
+func (ga *GioApp) sendObjectCreate(pending pendingObjectCreate) {
+	object, err := ga.objectsClient.Create(pending.userID, pending.req, pending.collectionID)
+	if err != nil {
+		ga.logger.Error("Failed to create object", "error", err)
+		ga.do(func() { ga.showAPIErrorDialog("Failed to create object: " + err.Error()) })
+		return
+	}
+
+	ga.logger.Info("Object created successfully", "object_id", object.ID)
+	if object.StorageWarning != nil {
+		ga.logger.Warn("Object stored too warm", "object_id", object.ID, "warning", object.StorageWarning.Message)
+	}
+	ga.do(func() {
+		if ga.selectedCollection != nil && ga.selectedCollection.ID == pending.collectionID {
+			ga.addObject(*object)
+		}
+	})
+}
+
+// increaseMatchedObject adds the held-back item to the matched object's
+// quantity instead of creating a duplicate
+func (ga *GioApp) increaseMatchedObject() {
+	pending := ga.pendingCreate
+	if pending == nil || len(ga.codeMatches) == 0 {
+		ga.closeCodeMatchDialog()
+		return
+	}
+	match := ga.codeMatches[preferredCodeMatch(ga.codeMatches, pending.collectionID)]
+	quantity := increasedQuantity(match.Object.Quantity, pending.req.Quantity)
+	req := types.UpdateObjectRequest{ContainerID: match.Object.ContainerID, Quantity: &quantity}
+	ga.closeCodeMatchDialog()
+
+	ga.goSafe(func() {
+		object, err := ga.objectsClient.Update(pending.userID, match.Object.ID, req)
+		if err != nil {
+			ga.logger.Error("Failed to increase object quantity", "object_id", match.Object.ID, "error", err)
+			ga.do(func() { ga.showAPIErrorDialog("Failed to increase quantity: " + err.Error()) })
+			return
+		}
+
+		ga.logger.Info("Increased quantity of scanned object", "object_id", object.ID, "quantity", quantity)
+		ga.do(func() {
+			if ga.selectedCollection != nil && ga.selectedCollection.ID == match.CollectionID {
+				ga.updateObject(*object, match.Object.ContainerID)
+			}
+		})
+	})
+}
+
+// createDespiteMatch creates the held-back object after all
+func (ga *GioApp) createDespiteMatch() {
+	pending := ga.pendingCreate
+	ga.closeCodeMatchDialog()
+	if pending == nil {
+		return
+	}
+	ga.goSafe(func() { ga.sendObjectCreate(*pending) })
+}
+
+func (ga *GioApp) closeCodeMatchDialog() {
+	ga.showCodeMatchDialog = false
+	ga.pendingCreate = nil
+	ga.codeMatches = nil
+}
+
+// renderCodeMatchDialog offers to increase the quantity of an object that
+// already carries the scanned code
+func (ga *GioApp) renderCodeMatchDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showCodeMatchDialog || ga.pendingCreate == nil || len(ga.codeMatches) == 0 {
+		return layout.Dimensions{}
+	}
+
+	if ga.widgetState.codeMatchIncrease.Clicked(gtx) {
+		ga.increaseMatchedObject()
+		return layout.Dimensions{}
+	}
+	if ga.widgetState.codeMatchCreate.Clicked(gtx) {
+		ga.createDespiteMatch()
+		return layout.Dimensions{}
+	}
+	if ga.widgetState.codeMatchCancel.Clicked(gtx) {
+		ga.closeCodeMatchDialog()
+		return layout.Dimensions{}
+	}
+
+	match := ga.codeMatches[preferredCodeMatch(ga.codeMatches, ga.pendingCreate.collectionID)]
+	obj := match.Object
+	message := fmt.Sprintf("You already have \"%s\" with this code (%s).", obj.Name, ga.mergeObjectDetail(obj))
+	if n := len(ga.codeMatches); n > 1 {
+		message += fmt.Sprintf(" %d objects carry it; the quantity goes to this one.", n)
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.codeMatchDialog, "Already in Inventory")
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return material.Body1(ga.theme.Theme, message).Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.codeMatchCancel, "Cancel"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.AccentButton(ga.theme.Theme, &ga.widgetState.codeMatchCreate, "Create Anyway"))
+					}),
+					layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.codeMatchIncrease, "Increase Quantity")),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeCodeMatchDialog()
+	}
+	return dims
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestObjectCode(t *testing.T) {
+	tests := []struct {
+		props map[string]any
+		want  string
+	}{
+		{map[string]any{"upc": " 012345678905 "}, "012345678905"},
+		{map[string]any{"isbn": "0-306-40615-2", "sku": "A1"}, "0-306-40615-2"},
+		{map[string]any{"upc": "", "sku": "SHELF-7"}, "SHELF-7"},
+		{map[string]any{"upc": 12345678.0}, ""},
+		{map[string]any{"brand": "Acme"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := objectCode(tt.props); got != tt.want {
+			t.Errorf("objectCode(%v) = %q, want %q", tt.props, got, tt.want)
+		}
+	}
+}
+
+func TestPreferredCodeMatch(t *testing.T) {
+	matches := []types.ObjectCodeMatch{{CollectionID: "c1"}, {CollectionID: "c2"}}
+	if got := preferredCodeMatch(matches, "c2"); got != 1 {
+		t.Errorf("match in open collection = %d, want 1", got)
+	}
+	if got := preferredCodeMatch(matches, "c9"); got != 0 {
+		t.Errorf("match elsewhere = %d, want 0", got)
+	}
+}
+
+func TestIncreasedQuantity(t *testing.T) {
+	if got := increasedQuantity(new(2.0), new(3.0)); got != 5 {
+		t.Errorf("2 + 3 = %v", got)
+	}
+	if got := increasedQuantity(nil, nil); got != 2 {
+		t.Errorf("uncounted items = %v, want 2", got)
+	}
+	if got := increasedQuantity(new(0.5), nil); got != 1.5 {
+		t.Errorf("0.5 + one item = %v, want 1.5", got)
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// objectConditions are the grades the backend accepts, best first
+var objectConditions = []string{"mint", "near_mint", "good", "fair", "poor"}
+
+var conditionLabels = map[string]string{
+	"mint":      "Mint",
+	"near_mint": "Near mint",
+	"good":      "Good",
+	"fair":      "Fair",
+	"poor":      "Poor",
+}
+
+var conditionColors = map[string]color.NRGBA{
+	"mint":      theme.ColorConditionMint,
+	"near_mint": theme.ColorConditionNearMint,
+	"good":      theme.ColorConditionGood,
+	"fair":      theme.ColorConditionFair,
+	"poor":      theme.ColorConditionPoor,
+}
+
+// gradedObjectType reports whether objects of the type can be given a
+// condition; the backend only grades the built-in collectible types.
+func gradedObjectType(objectType string) bool {
+	switch objectType {
+	case "book", "videogame", "music", "boardgame":
+		return true
+	default:
+		return false
+	}
+}
+
+// conditionSummary counts the graded objects per condition, best first,
+// e.g. "Mint 2 · Good 5"; "" when nothing is graded
+func conditionSummary(objects []Object) string {
+	counts := make(map[string]int)
+	for _, obj := range objects {
+		if obj.Condition != "" {
+			counts[obj.Condition]++
+		}
+	}
+	var parts []string
+	for _, condition := range objectConditions {
+		if n := counts[condition]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", conditionLabels[condition], n))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// renderConditionBadge renders the object's condition as a pill colored from
+// green (mint) to red (poor)
+func (ga *GioApp) renderConditionBadge(gtx layout.Context, obj Object) layout.Dimensions {
+	label, ok := conditionLabels[obj.Condition]
+	if !ok {
+		return layout.Dimensions{}
+	}
+	return ga.renderPill(gtx, label, conditionColors[obj.Condition])
+}
+
+// objectDialogType is the object type the object dialog is editing
+func (ga *GioApp) objectDialogType() string {
+	if ga.objectDialogMode == "edit" && ga.selectedObject != nil {
+		return ga.selectedObject.ObjectType
+	}
+	if ga.selectedCollection != nil {
+		return ga.selectedCollection.ObjectType
+	}
+	return ""
+}
+
+// renderObjectConditionSelector renders the condition chips in the object
+// dialog for collectibles. Clicking the picked grade again clears it.
+func (ga *GioApp) renderObjectConditionSelector(gtx layout.Context) layout.Dimensions {
+	if !gradedObjectType(ga.objectDialogType()) {
+		return layout.Dimensions{}
+	}
+	chips := make([]layout.Widget, len(objectConditions))
+	for i, condition := range objectConditions {
+		btn := ga.widgetState.objectConditionButtons[condition]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.objectConditionButtons[condition] = btn
+		}
+		if btn.Clicked(gtx) {
+			if ga.objectCondition == condition {
+				ga.objectCondition = ""
+			} else {
+				ga.objectCondition = condition
+			}
+		}
+		active := ga.objectCondition == condition
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, conditionLabels[condition], active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Condition", chips)
+}
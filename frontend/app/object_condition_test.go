@@ -0,0 +1,31 @@
+package app
+
+import "testing"
+
+func TestConditionSummary(t *testing.T) {
+	objects := []Object{
+		{Name: "Dune", Condition: "good"},
+		{Name: "Piranesi", Condition: "mint"},
+		{Name: "Hades", Condition: "good"},
+		{Name: "Spoon"},
+	}
+	if got, want := conditionSummary(objects), "Mint 1 · Good 2"; got != want {
+		t.Errorf("conditionSummary() = %q, want %q", got, want)
+	}
+	if got := conditionSummary([]Object{{Name: "Spoon"}}); got != "" {
+		t.Errorf("conditionSummary() without grades = %q, want empty", got)
+	}
+}
+
+func TestGradedObjectType(t *testing.T) {
+	for _, objectType := range []string{"book", "videogame", "music", "boardgame"} {
+		if !gradedObjectType(objectType) {
+			t.Errorf("%s should be graded", objectType)
+		}
+	}
+	for _, objectType := range []string{"food", "general", "wine"} {
+		if gradedObjectType(objectType) {
+			t.Errorf("%s should not be graded", objectType)
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// openObjectDetail shows an object in the detail pane, where its name,
+// quantity and tags can be changed without opening the edit dialog
+func (ga *GioApp) openObjectDetail(obj Object) {
+	ga.detailObjectID = obj.ID
+	ga.editingDetailName = false
+	ga.widgetState.objectDetailList.Position = layout.Position{}
+	ga.widgetState.objectDetailNameEditor.SingleLine = true
+	ga.widgetState.objectDetailNameEditor.Submit = true
+	ga.widgetState.objectDetailTagEditor.SingleLine = true
+	ga.widgetState.objectDetailTagEditor.Submit = true
+	ga.widgetState.objectDetailTagEditor.SetText("")
+}
+
+// closeObjectDetail hides the detail pane
+func (ga *GioApp) closeObjectDetail() {
+	ga.detailObjectID = ""
+	ga.editingDetailName = false
+}
+
+// detailObject is the object shown in the detail pane, or nil when the pane
+// is closed or the object is gone
+func (ga *GioApp) detailObject() *Object {
+	if ga.detailObjectID == "" {
+		return nil
+	}
+	for i := range ga.objects {
+		if ga.objects[i].ID == ga.detailObjectID {
+			return &ga.objects[i]
+		}
+	}
+	return nil
+}
+
+// patchObject sends only the fields in patch. apply makes the same change to
+// a copy of obj so the list and pane show it before the server answers.
+func (ga *GioApp) patchObject(obj Object, action string, patch types.MergePatch, apply func(*Object)) {
+	if ga.currentUser == nil {
+		return
+	}
+	userID := ga.currentUser.ID
+	edited := obj
+	apply(&edited)
+
+	ga.optimisticUpdate(action, func() {
+		ga.updateObject(edited, obj.ContainerID)
+	}, func() {
+		ga.updateObject(obj, obj.ContainerID)
+	}, func() (func(), error) {
+		updated, err := ga.objectsClient.Patch(userID, obj.ID, patch)
+		if err != nil {
+			return nil, err
+		}
+		return func() { ga.updateObject(*updated, obj.ContainerID) }, nil
+	})
+}
+
+// withTag returns tags with tag added, or false when it is blank or the
+// object already has it
+func withTag(tags []string, tag string) ([]string, bool) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || slices.Contains(tags, tag) {
+		return tags, false
+	}
+	return append(slices.Clone(tags), tag), true
+}
+
+// withoutTag returns tags without tag
+func withoutTag(tags []string, tag string) []string {
+	return slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag })
+}
+
+// commitDetailName saves the name typed into the pane, ignoring a blank or
+// unchanged one
+func (ga *GioApp) commitDetailName(obj Object) {
+	ga.editingDetailName = false
+	name := strings.TrimSpace(ga.widgetState.objectDetailNameEditor.Text())
+	if name == "" || name == obj.Name {
+		return
+	}
+	ga.patchObject(obj, "rename object", types.MergePatch{"name": name}, func(o *Object) {
+		o.Name = name
+	})
+}
+
+// setObjectQuantity saves a quantity settled on with a stepper, ignoring
+// an unchanged one
+func (ga *GioApp) setObjectQuantity(obj Object, quantity float64) {
+	if obj.Quantity != nil && *obj.Quantity == quantity {
+		return
+	}
+	ga.patchObject(obj, "update quantity", types.MergePatch{"quantity": quantity}, func(o *Object) {
+		o.Quantity = &quantity
+	})
+}
+
+// setDetailTags replaces the object's tags
+func (ga *GioApp) setDetailTags(obj Object, tags []string) {
+	ga.patchObject(obj, "update tags", types.MergePatch{"tags": tags}, func(o *Object) {
+		o.Tags = tags
+	})
+}
+
+// handleObjectDetailInput reacts to the pane's buttons and editors before
+// it is laid out
+func (ga *GioApp) handleObjectDetailInput(gtx layout.Context, obj Object) {
+	ws := ga.widgetState
+
+	if ws.objectDetailClose.Clicked(gtx) {
+		ga.closeObjectDetail()
+		return
+	}
+	if ws.objectDetailEditAll.Clicked(gtx) {
+		ga.openObjectEditDialog(obj)
+	}
+	if ws.objectDetailMarkOwned.Clicked(gtx) {
+		ga.markObjectOwned(obj)
+	}
+	if ws.objectDetailPrintLabel.Clicked(gtx) {
+		ga.printObjectLabel(obj)
+	}
+	if ws.objectDetailDelete.Clicked(gtx) {
+		ga.showDeleteObject = true
+		ga.deleteObjectID = obj.ID
+	}
+
+	// Name: click to edit, Enter or Save to keep
+	if ws.objectDetailName.Clicked(gtx) {
+		ga.editingDetailName = true
+		ws.objectDetailNameEditor.SetText(obj.Name)
+		ws.objectDetailNameEditor.SetCaret(len(obj.Name), 0)
+		ga.pendingFocus = &ws.objectDetailNameEditor
+	}
+	if ga.editingDetailName {
+		for {
+			ev, ok := ws.objectDetailNameEditor.Update(gtx)
+			if !ok {
+				break
+			}
+			if _, ok := ev.(widget.SubmitEvent); ok {
+				ga.commitDetailName(obj)
+			}
+		}
+		if ws.objectDetailNameSave.Clicked(gtx) {
+			ga.commitDetailName(obj)
+		}
+		if ws.objectDetailNameCancel.Clicked(gtx) {
+			ga.editingDetailName = false
+		}
+	}
+
+	// Quantity stepper; a blank quantity shows as blank until stepped
+	if obj.Quantity != nil {
+		ws.objectDetailQuantity.SetValue(*obj.Quantity)
+	} else if !ws.objectDetailQuantity.Active() {
+		ws.objectDetailQuantity.SetText("")
+	}
+	if quantity, ok := ws.objectDetailQuantity.Update(gtx); ok {
+		ga.setObjectQuantity(obj, quantity)
+	}
+
+	// Tags: remove by chip, add from the editor
+	for _, tag := range obj.Tags {
+		if btn := ws.objectDetailTagRemove[tag]; btn != nil && btn.Clicked(gtx) {
+			ga.setDetailTags(obj, withoutTag(obj.Tags, tag))
+			return
+		}
+	}
+	addTag := ws.objectDetailTagAdd.Clicked(gtx)
+	for {
+		ev, ok := ws.objectDetailTagEditor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			addTag = true
+		}
+	}
+	if addTag {
+		if tags, ok := withTag(obj.Tags, ws.objectDetailTagEditor.Text()); ok {
+			ga.setDetailTags(obj, tags)
+		}
+		ws.objectDetailTagEditor.SetText("")
+	}
+}
+
+// renderWithObjectDetail lays out the objects area with the detail pane
+// beside it, or on compact windows the pane alone
+func (ga *GioApp) renderWithObjectDetail(gtx layout.Context, obj Object, objects layout.Widget) layout.Dimensions {
+	if ga.sizeClass == sizeCompact {
+		return ga.renderObjectDetailPane(gtx, obj)
+	}
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		layout.Flexed(0.6, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, objects)
+		}),
+		layout.Flexed(0.4, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderObjectDetailPane(gtx, obj)
+			})
+		}),
+	)
+}
+
+// renderObjectDetailPane renders the object's fields with in-place editing
+// for its name, quantity and tags
+func (ga *GioApp) renderObjectDetailPane(gtx layout.Context, obj Object) layout.Dimensions {
+	ga.handleObjectDetailInput(gtx, obj)
+	if ga.detailObjectID == "" {
+		return layout.Dimensions{}
+	}
+	if current := ga.detailObject(); current != nil {
+		obj = *current
+	}
+
+	rows := []layout.Widget{
+		func(gtx layout.Context) layout.Dimensions { return ga.renderDetailNameRow(gtx, obj) },
+		func(gtx layout.Context) layout.Dimensions {
+			if obj.Description == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Body2(ga.theme.Theme, obj.Description)
+			label.Color = theme.ColorTextSecondary
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, label.Layout)
+		},
+		func(gtx layout.Context) layout.Dimensions {
+			loc := ga.getObjectEffectiveLocation(obj)
+			if loc == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Body2(ga.theme.Theme, loc)
+			label.Color = theme.ColorPrimaryLight
+			return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, label.Layout)
+		},
+		func(gtx layout.Context) layout.Dimensions { return ga.renderDetailQuantityRow(gtx, obj) },
+		func(gtx layout.Context) layout.Dimensions { return ga.renderDetailTags(gtx, obj) },
+		func(gtx layout.Context) layout.Dimensions {
+			if len(obj.Properties) == 0 {
+				return layout.Dimensions{}
+			}
+			var defs []PropertyDefinition
+			if ga.selectedCollection != nil && ga.selectedCollection.PropertySchema != nil {
+				defs = ga.selectedCollection.PropertySchema.Definitions
+			}
+			return ga.renderObjectProperties(gtx, obj.Properties, defs)
+		},
+		func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceStart}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if isOwned(obj) {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.objectDetailMarkOwned, "Move to owned"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := "Print label"
+						if ga.labelInProgress {
+							label = "Preparing label..."
+						}
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.objectDetailPrintLabel, label))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.AccentButton(ga.theme.Theme, &ga.widgetState.objectDetailEditAll, "Edit all fields"))
+					}),
+					layout.Rigid(widgets.DangerButton(ga.theme.Theme, &ga.widgetState.objectDetailDelete, "Delete")),
+				)
+			})
+		},
+	}
+
+	card := widgets.DefaultCard()
+	return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return material.List(ga.theme.Theme, &ga.widgetState.objectDetailList).Layout(gtx, len(rows), func(gtx layout.Context, i int) layout.Dimensions {
+			return rows[i](gtx)
+		})
+	})
+}
+
+// renderDetailNameRow shows the name, which turns into an editor when
+// clicked, and the button closing the pane
+func (ga *GioApp) renderDetailNameRow(gtx layout.Context, obj Object) layout.Dimensions {
+	ws := ga.widgetState
+	if ga.editingDetailName {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				editor := material.Editor(ga.theme.Theme, &ws.objectDetailNameEditor, "Name")
+				editor.Color = theme.ColorTextPrimary
+				return editor.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx,
+					widgets.PrimaryButton(ga.theme.Theme, &ws.objectDetailNameSave, "Save"))
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing1)}.Layout(gtx,
+					widgets.CancelButton(ga.theme.Theme, &ws.objectDetailNameCancel, "Cancel"))
+			}),
+		)
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return material.Clickable(gtx, &ws.objectDetailName, func(gtx layout.Context) layout.Dimensions {
+				label := material.H6(ga.theme.Theme, obj.Name)
+				label.Font.Weight = font.Bold
+				return label.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderStockBadge(gtx, obj)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if obj.Condition == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Left: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderConditionBadge(gtx, obj)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if isOwned(obj) {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Left: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderStatusBadge(gtx, obj)
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx,
+				widgets.CancelButton(ga.theme.Theme, &ws.objectDetailClose, "Close"))
+		}),
+	)
+}
+
+// convertedQuantityText is the object's quantity in the display units, or
+// "" when that reads the same as the quantity the steppers count in
+func (ga *GioApp) convertedQuantityText(obj Object) string {
+	if obj.Quantity == nil {
+		return ""
+	}
+	converted := ga.objectQuantityText(obj)
+	if entered := strings.TrimSpace(fmt.Sprintf("%v %s", *obj.Quantity, obj.Unit)); entered == converted {
+		return ""
+	}
+	return converted
+}
+
+// renderDetailQuantityRow shows the quantity in a stepper, in the unit it
+// was entered in, followed by its converted amount when that differs
+func (ga *GioApp) renderDetailQuantityRow(gtx layout.Context, obj Object) layout.Dimensions {
+	converted := ga.convertedQuantityText(obj)
+	return layout.Inset{Top: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, "Quantity")
+				label.Color = theme.ColorTextSecondary
+				return layout.Inset{Right: unit.Dp(theme.Spacing3)}.Layout(gtx, label.Layout)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.widgetState.objectDetailQuantity.Layout(gtx, ga.theme.Theme, obj.Unit)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if converted == "" {
+					return layout.Dimensions{}
+				}
+				label := material.Body2(ga.theme.Theme, "("+converted+")")
+				label.Color = theme.ColorTextSecondary
+				return layout.Inset{Left: unit.Dp(theme.Spacing3)}.Layout(gtx, label.Layout)
+			}),
+		)
+	})
+}
+
+// renderDetailTags shows the tags as chips that remove the tag when
+// clicked, followed by an editor for adding one
+func (ga *GioApp) renderDetailTags(gtx layout.Context, obj Object) layout.Dimensions {
+	ws := ga.widgetState
+	chips := make([]layout.Widget, 0, len(obj.Tags))
+	for _, tag := range obj.Tags {
+		btn := ws.objectDetailTagRemove[tag]
+		if btn == nil {
+			btn = new(widget.Clickable)
+			ws.objectDetailTagRemove[tag] = btn
+		}
+		chips = append(chips, func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, tag+" ×", false)
+		})
+	}
+
+	return layout.Inset{Top: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if len(chips) == 0 {
+					return layout.Dimensions{}
+				}
+				return ga.renderChipSelector(gtx, "Tags", chips)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						editor := material.Editor(ga.theme.Theme, &ws.objectDetailTagEditor, "Add tag...")
+						editor.Color = theme.ColorTextPrimary
+						return editor.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ws.objectDetailTagAdd, "Add"))
+					}),
+				)
+			}),
+		)
+	})
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestWithTag(t *testing.T) {
+	tags := []string{"tools"}
+
+	got, ok := withTag(tags, "  garage ")
+	if !ok || !slices.Equal(got, []string{"tools", "garage"}) {
+		t.Errorf("withTag = %v, %v", got, ok)
+	}
+	if !slices.Equal(tags, []string{"tools"}) {
+		t.Errorf("withTag changed its input: %v", tags)
+	}
+	if _, ok := withTag(tags, "tools"); ok {
+		t.Error("withTag added a tag the object already has")
+	}
+	if _, ok := withTag(tags, "   "); ok {
+		t.Error("withTag added a blank tag")
+	}
+}
+
+func TestWithoutTag(t *testing.T) {
+	tags := []string{"tools", "garage"}
+	if got := withoutTag(tags, "tools"); !slices.Equal(got, []string{"garage"}) {
+		t.Errorf("withoutTag = %v", got)
+	}
+	if !slices.Equal(tags, []string{"tools", "garage"}) {
+		t.Errorf("withoutTag changed its input: %v", tags)
+	}
+}
+
+func TestDetailObjectFollowsObjects(t *testing.T) {
+	ga := newTestGioApp()
+	ga.objects = []Object{{ID: "o1", Name: "Hammer"}, {ID: "o2", Name: "Wrench"}}
+
+	ga.openObjectDetail(ga.objects[1])
+	ga.updateObject(Object{ID: "o2", Name: "Spanner"}, "")
+	if obj := ga.detailObject(); obj == nil || obj.Name != "Spanner" {
+		t.Errorf("detail object = %v, want the renamed wrench", obj)
+	}
+
+	ga.objects = ga.objects[:1]
+	if obj := ga.detailObject(); obj != nil {
+		t.Errorf("detail object = %v after it was removed", obj)
+	}
+
+	ga.openObjectDetail(ga.objects[0])
+	ga.closeObjectDetail()
+	if ga.detailObject() != nil {
+		t.Error("detail pane still shows an object after closing")
+	}
+}
+
+func TestObjectEditSendsOnlyChanges(t *testing.T) {
+	quantity := 2.0
+	original := Object{
+		ID:          "o1",
+		ContainerID: "c1",
+		Name:        "Flour",
+		Description: "Plain",
+		Quantity:    &quantity,
+		Unit:        "kg",
+		Properties:  map[string]TypedValue{"brand": {Val: "Acme"}},
+		Tags:        []string{"baking"},
+	}
+	edited := original
+	edited.Description = ""
+	edited.Quantity = nil
+	edited.Properties = map[string]TypedValue{"brand": {Val: "Acme"}, "organic": {Val: true}}
+
+	patch, err := types.DiffMergePatch(objectPatchBody(original), objectPatchBody(edited))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.MergePatch{
+		"description": nil,
+		"quantity":    nil,
+		"properties":  map[string]any{"organic": true},
+	}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("patch = %v, want %v", patch, want)
+	}
+}
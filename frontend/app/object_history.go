@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// loadObjectHistory fetches the location timeline for the object being edited
+func (ga *GioApp) loadObjectHistory(objectID string) {
+	if ga.currentUser == nil {
+		return
+	}
+	ga.objectHistory = nil
+	ga.objectHistoryErr = ""
+	ga.objectHistoryLoading = true
+	accountID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		history, err := ga.objectsClient.History(accountID, objectID)
+
+		ga.do(func() {
+			// Ignore responses for a dialog that has since been closed or
+			// switched to another object.
+			if ga.selectedObject == nil || ga.selectedObject.ID != objectID {
+				return
+			}
+			ga.objectHistoryLoading = false
+			if err != nil {
+				ga.logger.Error("Failed to load object history", "object_id", objectID, "error", err)
+				ga.objectHistoryErr = "Could not load location history"
+				return
+			}
+			ga.objectHistory = history
+		})
+	})
+}
+
+// clearObjectHistory drops the timeline when the object dialog closes
+func (ga *GioApp) clearObjectHistory() {
+	ga.objectHistory = nil
+	ga.objectHistoryErr = ""
+	ga.objectHistoryLoading = false
+}
+
+// renderObjectHistory renders the object's location timeline, newest first
+func (ga *GioApp) renderObjectHistory(gtx layout.Context) layout.Dimensions {
+	var children []layout.FlexChild
+
+	children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+		return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			label := material.Body1(ga.theme.Theme, "Location history")
+			label.Font.Weight = font.Bold
+			return label.Layout(gtx)
+		})
+	}))
+
+	switch {
+	case ga.objectHistoryLoading:
+		children = append(children, ga.objectHistoryNote("Loading..."))
+	case ga.objectHistoryErr != "":
+		children = append(children, ga.objectHistoryNote(ga.objectHistoryErr))
+	case ga.objectHistory == nil || len(ga.objectHistory.Timeline) == 0:
+		children = append(children, ga.objectHistoryNote("No history yet"))
+	default:
+		timeline := ga.objectHistory.Timeline
+		for i := len(timeline) - 1; i >= 0; i-- {
+			entry := timeline[i]
+
+			place := entry.ContainerName
+			if entry.Location != "" {
+				place += " · " + entry.Location
+			}
+
+			until := "now"
+			if entry.Until != nil {
+				until = formatHistoryTime(*entry.Until)
+			}
+			span := fmt.Sprintf("%s → %s", formatHistoryTime(entry.Since), until)
+
+			current := entry.Until == nil
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							label := material.Body2(ga.theme.Theme, place)
+							if current {
+								label.Font.Weight = font.Bold
+								label.Color = theme.ColorPrimary
+							}
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							label := material.Caption(ga.theme.Theme, span)
+							label.Color = theme.ColorTextSecondary
+							return label.Layout(gtx)
+						}),
+					)
+				})
+			}))
+		}
+	}
+
+	return layout.Inset{Top: unit.Dp(theme.Spacing2), Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+func (ga *GioApp) objectHistoryNote(msg string) layout.FlexChild {
+	return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, msg)
+		label.Color = theme.ColorTextSecondary
+		return label.Layout(gtx)
+	})
+}
+
+// formatHistoryTime formats a timeline timestamp in local time
+func formatHistoryTime(t time.Time) string {
+	return t.Local().Format("Jan 2, 2006 15:04")
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// labelTemplate is a label stock the backend can size labels for
+type labelTemplate struct {
+	key  string
+	name string
+}
+
+// labelTemplates mirrors the backend's entities.LabelTemplates
+var labelTemplates = []labelTemplate{
+	{"dymo-30252", "Dymo 30252"},
+	{"dymo-30336", "Dymo 30336"},
+	{"dymo-11354", "Dymo 11354"},
+	{"brother-dk-11201", "Brother DK-11201"},
+	{"brother-dk-11204", "Brother DK-11204"},
+	{"brother-dk-11209", "Brother DK-11209"},
+}
+
+// defaultLabelTemplate is the backend's default until the account picks one
+const defaultLabelTemplate = "dymo-30252"
+
+// labelFilename names the downloaded label after the object
+func labelFilename(name string) string {
+	return safeFilename(name, "object") + "-label.pdf"
+}
+
+// safeFilename replaces the characters file systems reject in name, or
+// returns fallback when name is blank
+func safeFilename(name, fallback string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// printObjectLabel fetches the object's label as a PDF sized to the
+// account's label template and hands it to the browser to print or save
+func (ga *GioApp) printObjectLabel(obj Object) {
+	if ga.labelInProgress || ga.currentUser == nil {
+		return
+	}
+	ga.labelInProgress = true
+	accountID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		data, err := ga.objectsClient.Label(accountID, obj.ID, "pdf", "")
+		var path string
+		if err == nil {
+			path, err = saveDownload(labelFilename(obj.Name), data, "application/pdf")
+		}
+
+		ga.do(func() {
+			ga.labelInProgress = false
+			if err != nil {
+				ga.logger.Error("Failed to download label", "object_id", obj.ID, "error", err)
+				ga.showAPIErrorDialog("Failed to print label: " + err.Error())
+				return
+			}
+			ga.logger.Info("Label downloaded", "object_id", obj.ID, "path", path, "bytes", len(data))
+		})
+	})
+}
+
+// setLabelTemplate saves the label stock labels are printed on, reverting
+// the choice if the backend rejects it
+func (ga *GioApp) setLabelTemplate(key string) {
+	if ga.currentUser == nil || key == ga.currentLabelTemplate() {
+		return
+	}
+	accountID := ga.currentUser.ID
+	previous := ga.labelTemplate
+
+	ga.optimisticUpdate("save label template", func() {
+		ga.labelTemplate = key
+	}, func() {
+		ga.labelTemplate = previous
+	}, func() (func(), error) {
+		prefs, err := ga.accountsClient.UpdatePreferences(accountID, types.UpdateUserPreferencesRequest{LabelTemplate: &key})
+		if err != nil {
+			return nil, err
+		}
+		return func() { ga.labelTemplate = prefs.LabelTemplate }, nil
+	})
+}
+
+// currentLabelTemplate is the account's label template, the default before
+// preferences have loaded
+func (ga *GioApp) currentLabelTemplate() string {
+	if ga.labelTemplate == "" {
+		return defaultLabelTemplate
+	}
+	return ga.labelTemplate
+}
+
+// renderLabelTemplateSection renders a chip per label stock in the profile
+// view, the one in use highlighted
+func (ga *GioApp) renderLabelTemplateSection(gtx layout.Context) layout.Dimensions {
+	for _, t := range labelTemplates {
+		btn := ga.widgetState.labelTemplateButtons[t.key]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.labelTemplateButtons[t.key] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.setLabelTemplate(t.key)
+		}
+	}
+
+	current := ga.currentLabelTemplate()
+	chips := make([]layout.Widget, len(labelTemplates))
+	for i, t := range labelTemplates {
+		btn := ga.widgetState.labelTemplateButtons[t.key]
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, t.name, t.key == current)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Label printer", chips)
+}
@@ -0,0 +1,30 @@
+package app
+
+import "testing"
+
+func TestLabelFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Strawberry jam", "Strawberry jam-label.pdf"},
+		{" AC/DC: Back in Black ", "AC-DC- Back in Black-label.pdf"},
+		{"   ", "object-label.pdf"},
+	}
+	for _, tt := range tests {
+		if got := labelFilename(tt.name); got != tt.want {
+			t.Errorf("labelFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentLabelTemplate(t *testing.T) {
+	ga := &GioApp{}
+	if got := ga.currentLabelTemplate(); got != defaultLabelTemplate {
+		t.Errorf("currentLabelTemplate() before preferences load = %q, want %q", got, defaultLabelTemplate)
+	}
+	ga.labelTemplate = "brother-dk-11204"
+	if got := ga.currentLabelTemplate(); got != "brother-dk-11204" {
+		t.Errorf("currentLabelTemplate() = %q, want the saved template", got)
+	}
+}
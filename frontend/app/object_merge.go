@@ -0,0 +1,320 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// openMergeDialog opens the duplicate finder for the selected collection and
+// loads the duplicate groups
+func (ga *GioApp) openMergeDialog() {
+	if ga.selectedCollection == nil || ga.currentUser == nil {
+		return
+	}
+	ga.showMergeDialog = true
+	ga.duplicateGroups = nil
+	ga.mergeGroupIndex = -1
+	ga.mergePreview = nil
+	ga.mergeErr = ""
+	ga.widgetState.mergeDialog.Reset()
+
+	collectionID := ga.selectedCollection.ID
+	userID := ga.currentUser.ID
+	ga.duplicatesLoading = true
+
+	ga.goSafe(func() {
+		groups, err := ga.objectsClient.FindDuplicates(userID, collectionID)
+
+		ga.do(func() {
+			ga.duplicatesLoading = false
+			if !ga.showMergeDialog || ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			if err != nil {
+				ga.logger.Error("Failed to find duplicate objects", "collection_id", collectionID, "error", err)
+				ga.mergeErr = "Could not look for duplicates: " + err.Error()
+				return
+			}
+			ga.duplicateGroups = groups
+		})
+	})
+}
+
+func (ga *GioApp) closeMergeDialog() {
+	ga.showMergeDialog = false
+	ga.duplicateGroups = nil
+	ga.mergePreview = nil
+	ga.mergeErr = ""
+	ga.widgetState.mergeDialog.Reset()
+}
+
+// selectMergeGroup asks the backend what merging the group would produce
+// without saving it
+func (ga *GioApp) selectMergeGroup(index int) {
+	if ga.currentUser == nil || index == ga.mergeGroupIndex {
+		return
+	}
+	ga.mergeGroupIndex = index
+	ga.mergePreview = nil
+	ga.mergeErr = ""
+	ga.mergePreviewLoading = true
+
+	userID := ga.currentUser.ID
+	req := types.MergeObjectsRequest{ObjectIDs: duplicateGroupIDs(ga.duplicateGroups[index]), Preview: true}
+
+	ga.goSafe(func() {
+		preview, err := ga.objectsClient.Merge(userID, req)
+
+		ga.do(func() {
+			// A newer selection supersedes this preview
+			if ga.mergeGroupIndex != index {
+				return
+			}
+			ga.mergePreviewLoading = false
+			if err != nil {
+				ga.logger.Error("Failed to preview merge", "error", err)
+				ga.mergeErr = "Cannot merge these objects: " + err.Error()
+				return
+			}
+			ga.mergePreview = preview
+		})
+	})
+}
+
+// confirmMerge merges the selected group and applies the result to the
+// loaded objects
+func (ga *GioApp) confirmMerge() {
+	if ga.currentUser == nil || ga.mergePreview == nil || ga.mergeRunning {
+		return
+	}
+	index := ga.mergeGroupIndex
+	group := ga.duplicateGroups[index]
+	userID := ga.currentUser.ID
+	ga.mergeRunning = true
+	ga.logger.Info("Merging duplicate objects", "key", group.Key, "count", len(group.Objects))
+
+	ga.goSafe(func() {
+		result, err := ga.objectsClient.Merge(userID, types.MergeObjectsRequest{ObjectIDs: duplicateGroupIDs(group)})
+
+		ga.do(func() {
+			ga.mergeRunning = false
+			if err != nil {
+				ga.logger.Error("Failed to merge objects", "key", group.Key, "error", err)
+				ga.mergeErr = "Merge failed: " + err.Error()
+				return
+			}
+
+			for _, obj := range group.Objects {
+				if obj.ID == result.Object.ID {
+					ga.updateObject(result.Object, obj.ContainerID)
+				} else {
+					ga.removeObject(obj.ID, obj.ContainerID)
+				}
+			}
+
+			ga.duplicateGroups = append(ga.duplicateGroups[:index], ga.duplicateGroups[index+1:]...)
+			ga.mergeGroupIndex = -1
+			ga.mergePreview = nil
+			if len(ga.duplicateGroups) == 0 {
+				ga.closeMergeDialog()
+			}
+		})
+	})
+}
+
+func duplicateGroupIDs(group types.DuplicateGroup) []string {
+	ids := make([]string, len(group.Objects))
+	for i, obj := range group.Objects {
+		ids[i] = obj.ID
+	}
+	return ids
+}
+
+// renderMergeDialog renders the duplicate groups of the collection and a
+// preview of merging the selected one
+func (ga *GioApp) renderMergeDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showMergeDialog {
+		return layout.Dimensions{}
+	}
+
+	if n := len(ga.duplicateGroups); len(ga.widgetState.mergeGroupButtons) < n {
+		ga.widgetState.mergeGroupButtons = make([]widget.Clickable, n)
+	}
+	for i := range ga.duplicateGroups {
+		if ga.widgetState.mergeGroupButtons[i].Clicked(gtx) {
+			ga.selectMergeGroup(i)
+		}
+	}
+	if ga.widgetState.mergeConfirm.Clicked(gtx) {
+		ga.confirmMerge()
+	}
+	if ga.widgetState.mergeCancel.Clicked(gtx) {
+		ga.closeMergeDialog()
+		return layout.Dimensions{}
+	}
+
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.mergeDialog, "Merge Duplicates")
+	dialogStyle.Width = unit.Dp(560)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(ga.renderDuplicateGroups),
+			layout.Rigid(ga.renderMergePreview),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.mergeErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.mergeErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.mergeCancel, "Close"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.mergePreview == nil {
+							return layout.Dimensions{}
+						}
+						label := "Merge"
+						if ga.mergeRunning {
+							label = "Merging..."
+						}
+						return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.mergeConfirm, label)(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeMergeDialog()
+	}
+	return dims
+}
+
+func (ga *GioApp) renderDuplicateGroups(gtx layout.Context) layout.Dimensions {
+	switch {
+	case ga.duplicatesLoading:
+		return ga.mergeNote(gtx, "Looking for duplicates...")
+	case len(ga.duplicateGroups) == 0 && ga.mergeErr == "":
+		return ga.mergeNote(gtx, "No duplicates found in this collection")
+	}
+
+	gtx.Constraints.Max.Y = min(gtx.Constraints.Max.Y, gtx.Dp(unit.Dp(240)))
+	list := &ga.widgetState.mergeGroupList
+	list.Axis = layout.Vertical
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return list.Layout(gtx, len(ga.duplicateGroups), func(gtx layout.Context, index int) layout.Dimensions {
+			group := ga.duplicateGroups[index]
+			selected := index == ga.mergeGroupIndex
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return widgets.ClickArea(gtx, &ga.widgetState.mergeGroupButtons[index], "Review "+group.Objects[0].Name, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							label := material.Body1(ga.theme.Theme, fmt.Sprintf("%s (%d)", group.Objects[0].Name, len(group.Objects)))
+							if selected {
+								label.Font.Weight = font.Bold
+								label.Color = theme.ColorPrimary
+							}
+							return label.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							details := make([]string, len(group.Objects))
+							for i, obj := range group.Objects {
+								details[i] = ga.mergeObjectDetail(obj)
+							}
+							label := material.Caption(ga.theme.Theme, strings.Join(details, " | "))
+							label.Color = theme.ColorTextSecondary
+							return label.Layout(gtx)
+						}),
+					)
+				})
+			})
+		})
+	})
+}
+
+// renderMergePreview shows what the selected group merges into
+func (ga *GioApp) renderMergePreview(gtx layout.Context) layout.Dimensions {
+	if ga.mergeGroupIndex < 0 {
+		if len(ga.duplicateGroups) > 0 {
+			return ga.mergeNote(gtx, "Select a group to preview the merge")
+		}
+		return layout.Dimensions{}
+	}
+	if ga.mergePreviewLoading {
+		return ga.mergeNote(gtx, "Preparing preview...")
+	}
+	if ga.mergePreview == nil {
+		return layout.Dimensions{}
+	}
+
+	obj := ga.mergePreview.Object
+	lines := []string{ga.mergeObjectDetail(obj)}
+	if len(obj.Tags) > 0 {
+		lines = append(lines, "Tags: "+strings.Join(obj.Tags, ", "))
+	}
+	lines = append(lines,
+		"Added "+obj.CreatedAt.Local().Format("Jan 2, 2006"),
+		fmt.Sprintf("%d object(s) will be removed", len(ga.mergePreview.MergedIDs)))
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body1(ga.theme.Theme, "Result: "+obj.Name)
+			label.Font.Weight = font.Bold
+			return label.Layout(gtx)
+		}),
+	}
+	for _, line := range lines {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body2(ga.theme.Theme, line)
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}))
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// mergeObjectDetail describes an object's quantity and container
+func (ga *GioApp) mergeObjectDetail(obj Object) string {
+	var parts []string
+	if obj.Quantity != nil {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%v %s", *obj.Quantity, obj.Unit)))
+	}
+	for _, c := range ga.containers {
+		if c.ID == obj.ContainerID {
+			parts = append(parts, c.Name)
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return "no quantity"
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (ga *GioApp) mergeNote(gtx layout.Context, msg string) layout.Dimensions {
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, msg)
+		label.Color = theme.ColorTextSecondary
+		return label.Layout(gtx)
+	})
+}
@@ -0,0 +1,308 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// toggleObjectSelection switches the objects column in and out of
+// multi-select; leaving it drops the selection
+func (ga *GioApp) toggleObjectSelection() {
+	ga.selectingObjects = !ga.selectingObjects
+	ga.selectedObjectIDs = nil
+	ga.selectionNotice = ""
+}
+
+// resetObjectSelection leaves multi-select, e.g. when another collection opens
+func (ga *GioApp) resetObjectSelection() {
+	ga.selectingObjects = false
+	ga.selectedObjectIDs = nil
+	ga.selectionNotice = ""
+	ga.showRetagDialog = false
+	ga.showBatchMoveDialog = false
+	ga.showBatchDeleteDialog = false
+}
+
+func (ga *GioApp) setObjectSelected(objectID string, selected bool) {
+	if !selected {
+		delete(ga.selectedObjectIDs, objectID)
+		return
+	}
+	if ga.selectedObjectIDs == nil {
+		ga.selectedObjectIDs = make(map[string]bool)
+	}
+	ga.selectedObjectIDs[objectID] = true
+}
+
+// selectedObjects returns the loaded objects that are selected, in list order
+func (ga *GioApp) selectedObjects() []Object {
+	var objects []Object
+	for _, obj := range ga.objects {
+		if ga.selectedObjectIDs[obj.ID] {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
+
+// renderSelectObjectsButton toggles multi-select from the objects header
+func (ga *GioApp) renderSelectObjectsButton(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.selectObjectsButton.Clicked(gtx) {
+		ga.toggleObjectSelection()
+	}
+	label := "Select"
+	if ga.selectingObjects {
+		label = "Done"
+	}
+	return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+		widgets.CancelButton(ga.theme.Theme, &ga.widgetState.selectObjectsButton, label))
+}
+
+// renderObjectSelectBox renders the checkbox of an object card in
+// multi-select, keeping it in step with the selection
+func (ga *GioApp) renderObjectSelectBox(gtx layout.Context, object Object, itemState *ObjectItemState) layout.Dimensions {
+	itemState.selectBox.Value = ga.selectedObjectIDs[object.ID]
+	if itemState.selectBox.Update(gtx) {
+		ga.setObjectSelected(object.ID, itemState.selectBox.Value)
+	}
+	return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+		material.CheckBox(ga.theme.Theme, &itemState.selectBox, "").Layout)
+}
+
+// renderSelectionToolbar is the action bar shown while the objects column
+// is in multi-select: the selection count and what can be done to it
+func (ga *GioApp) renderSelectionToolbar(gtx layout.Context) layout.Dimensions {
+	if !ga.selectingObjects {
+		return layout.Dimensions{}
+	}
+	ws := ga.widgetState
+	count := len(ga.selectedObjects())
+	if ws.selectAllButton.Clicked(gtx) {
+		ga.selectAllObjects()
+	}
+	if ws.clearSelectionButton.Clicked(gtx) {
+		ga.selectedObjectIDs = nil
+		ga.selectionNotice = ""
+	}
+	if count > 0 {
+		if ws.batchMoveButton.Clicked(gtx) {
+			ga.openBatchMoveDialog()
+		}
+		if ws.retagButton.Clicked(gtx) {
+			ga.openRetagDialog()
+		}
+		if ws.exportSelectionButton.Clicked(gtx) {
+			ga.exportSelectedObjects()
+		}
+		if ws.batchDeleteButton.Clicked(gtx) {
+			ga.openBatchDeleteDialog()
+		}
+	}
+	count = len(ga.selectedObjects())
+
+	th := ga.theme.Theme
+	actions := []layout.Widget{
+		widgets.CancelButton(th, &ws.selectAllButton, "All"),
+	}
+	if count > 0 {
+		actions = append(actions,
+			widgets.PrimaryButton(th, &ws.batchMoveButton, "Move..."),
+			widgets.PrimaryButton(th, &ws.retagButton, "Tag..."),
+			widgets.CancelButton(th, &ws.exportSelectionButton, "Export"),
+			widgets.DangerButton(th, &ws.batchDeleteButton, "Delete..."),
+		)
+	}
+	actions = append(actions, widgets.CancelButton(th, &ws.clearSelectionButton, "Clear"))
+
+	gap := gtx.Dp(unit.Dp(theme.Spacing2))
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(th, fmt.Sprintf("%d selected", count))
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layoutFlowWrap(gtx, gap, gap, actions...)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.selectionNotice == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.mealNoteLabel(gtx, ga.selectionNotice)
+				})
+			}),
+		)
+	})
+}
+
+func (ga *GioApp) openRetagDialog() {
+	ga.showRetagDialog = true
+	ga.retagErr = ""
+	ga.widgetState.retagAddEditor.SetText("")
+	ga.widgetState.retagRemoveEditor.SetText("")
+	ga.widgetState.retagDialog.Reset()
+}
+
+func (ga *GioApp) closeRetagDialog() {
+	ga.showRetagDialog = false
+	ga.retagErr = ""
+	ga.widgetState.retagDialog.Reset()
+}
+
+// confirmRetag applies the dialog's tag changes to the selected objects in
+// one request and mirrors them locally once the backend accepts
+func (ga *GioApp) confirmRetag() {
+	if ga.currentUser == nil || ga.selectedCollection == nil || ga.retagRunning {
+		return
+	}
+	add := splitTags(ga.widgetState.retagAddEditor.Text())
+	remove := splitTags(ga.widgetState.retagRemoveEditor.Text())
+	if len(add) == 0 && len(remove) == 0 {
+		ga.retagErr = "Enter tags to add or remove"
+		return
+	}
+	objects := ga.selectedObjects()
+	if len(objects) == 0 {
+		ga.closeRetagDialog()
+		return
+	}
+
+	ids := make([]string, len(objects))
+	for i, obj := range objects {
+		ids[i] = obj.ID
+	}
+	userID := ga.currentUser.ID
+	collectionID := ga.selectedCollection.ID
+	req := types.RetagObjectsRequest{
+		Filter:     types.RetagFilter{ObjectIDs: ids},
+		AddTags:    add,
+		RemoveTags: remove,
+	}
+	ga.retagRunning = true
+	ga.retagErr = ""
+	ga.logger.Info("Retagging objects", "collection_id", collectionID, "count", len(ids))
+
+	ga.goSafe(func() {
+		result, err := ga.objectsClient.Retag(userID, collectionID, req)
+
+		ga.do(func() {
+			ga.retagRunning = false
+			if err != nil {
+				ga.logger.Error("Failed to retag objects", "collection_id", collectionID, "error", err)
+				ga.retagErr = "Tagging failed: " + err.Error()
+				return
+			}
+			ga.logger.Info("Retagged objects", "matched", result.Matched, "updated", result.Updated)
+			if ga.selectedCollection == nil || ga.selectedCollection.ID != collectionID {
+				return
+			}
+			for _, obj := range objects {
+				obj.Tags = retagTags(obj.Tags, add, remove)
+				ga.updateObject(obj, obj.ContainerID)
+			}
+			ga.closeRetagDialog()
+			ga.resetObjectSelection()
+		})
+	})
+}
+
+// renderRetagDialog asks for the tags to add to and remove from the
+// selected objects
+func (ga *GioApp) renderRetagDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showRetagDialog {
+		return layout.Dimensions{}
+	}
+	if ga.widgetState.retagConfirm.Clicked(gtx) {
+		ga.confirmRetag()
+	}
+	if ga.widgetState.retagCancel.Clicked(gtx) {
+		ga.closeRetagDialog()
+		return layout.Dimensions{}
+	}
+
+	title := fmt.Sprintf("Tag %d Selected", len(ga.selectedObjects()))
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.retagDialog, title)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "Add tags", &ga.widgetState.retagAddEditor, "e.g. sale, gift")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFormField(gtx, "Remove tags", &ga.widgetState.retagRemoveEditor, "e.g. wishlist")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.retagErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.retagErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.retagCancel, "Cancel"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						label := "Apply"
+						if ga.retagRunning {
+							label = "Applying..."
+						}
+						return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.retagConfirm, label)(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closeRetagDialog()
+	}
+	return dims
+}
+
+// splitTags parses a comma separated tag list, dropping blanks and repeats
+func splitTags(text string) []string {
+	var tags []string
+	for tag := range strings.SplitSeq(text, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// retagTags mirrors the backend retag: remove first, then append the added
+// tags the object does not have yet
+func retagTags(tags, add, remove []string) []string {
+	out := make([]string, 0, len(tags)+len(add))
+	for _, tag := range tags {
+		if !slices.Contains(remove, tag) {
+			out = append(out, tag)
+		}
+	}
+	for _, tag := range add {
+		if !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
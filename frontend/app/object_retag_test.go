@@ -0,0 +1,33 @@
+package app
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitTags(t *testing.T) {
+	got := splitTags(" sale, gift,, sale ,  ")
+	if want := []string{"sale", "gift"}; !slices.Equal(got, want) {
+		t.Errorf("splitTags = %q, want %q", got, want)
+	}
+	if got := splitTags("  "); got != nil {
+		t.Errorf("blank tags = %q, want none", got)
+	}
+}
+
+func TestRetagTags(t *testing.T) {
+	tests := []struct {
+		tags, add, remove []string
+		want              []string
+	}{
+		{[]string{"a", "b"}, []string{"c"}, nil, []string{"a", "b", "c"}},
+		{[]string{"a", "b"}, []string{"b"}, []string{"a"}, []string{"b"}},
+		{nil, []string{"x", "x"}, nil, []string{"x"}},
+		{[]string{"a"}, nil, []string{"missing"}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		if got := retagTags(tt.tags, tt.add, tt.remove); !slices.Equal(got, tt.want) {
+			t.Errorf("retagTags(%q, +%q, -%q) = %q, want %q", tt.tags, tt.add, tt.remove, got, tt.want)
+		}
+	}
+}
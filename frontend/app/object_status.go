@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// objectStatuses are the statuses the backend accepts
+var objectStatuses = []string{"owned", "wanted", "ordered"}
+
+var statusLabels = map[string]string{
+	"owned":   "Owned",
+	"wanted":  "Wanted",
+	"ordered": "Ordered",
+}
+
+var statusColors = map[string]color.NRGBA{
+	"wanted":  theme.ColorStatusWanted,
+	"ordered": theme.ColorStatusOrdered,
+}
+
+// isOwned reports whether the object is in hand. Objects from servers that
+// predate statuses have none and are owned.
+func isOwned(obj Object) bool {
+	return obj.Status == "" || obj.Status == "owned"
+}
+
+// ownedObjects drops the wanted and ordered objects, which the backend
+// leaves out of counts and stats
+func ownedObjects(objects []Object) []Object {
+	owned := make([]Object, 0, len(objects))
+	for _, obj := range objects {
+		if isOwned(obj) {
+			owned = append(owned, obj)
+		}
+	}
+	return owned
+}
+
+// wishlistSummary counts the objects not owned yet per status, e.g.
+// "Wanted 3 · Ordered 1"; "" when the wishlist is empty
+func wishlistSummary(objects []Object) string {
+	counts := make(map[string]int)
+	for _, obj := range objects {
+		if !isOwned(obj) {
+			counts[obj.Status]++
+		}
+	}
+	var parts []string
+	for _, status := range objectStatuses {
+		if n := counts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", statusLabels[status], n))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// renderStatusBadge renders a pill for wanted and ordered objects; owned ones
+// get none
+func (ga *GioApp) renderStatusBadge(gtx layout.Context, obj Object) layout.Dimensions {
+	c, ok := statusColors[obj.Status]
+	if !ok {
+		return layout.Dimensions{}
+	}
+	return ga.renderPill(gtx, statusLabels[obj.Status], c)
+}
+
+// markObjectOwned moves a wishlist item into the inventory once it arrives
+func (ga *GioApp) markObjectOwned(obj Object) {
+	if isOwned(obj) {
+		return
+	}
+	ga.patchObject(obj, "move to owned", types.MergePatch{"status": "owned"}, func(o *Object) {
+		o.Status = "owned"
+	})
+}
+
+// renderObjectStatusSelector renders the status chips in the object dialog
+func (ga *GioApp) renderObjectStatusSelector(gtx layout.Context) layout.Dimensions {
+	for _, status := range objectStatuses {
+		btn := ga.widgetState.objectStatusButtons[status]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.objectStatusButtons[status] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.objectStatus = status
+		}
+	}
+	current := ga.objectStatus
+	if current == "" {
+		current = "owned"
+	}
+	chips := make([]layout.Widget, len(objectStatuses))
+	for i, status := range objectStatuses {
+		btn := ga.widgetState.objectStatusButtons[status]
+		active := current == status
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, statusLabels[status], active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Status", chips)
+}
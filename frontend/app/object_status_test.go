@@ -0,0 +1,22 @@
+package app
+
+import "testing"
+
+func TestWishlistSummary(t *testing.T) {
+	objects := []Object{
+		{Name: "Dune", Status: "owned"},
+		{Name: "Cascadia", Status: "ordered"},
+		{Name: "The Dispossessed", Status: "wanted"},
+		{Name: "Piranesi", Status: "wanted"},
+		{Name: "Spoon"},
+	}
+	if got, want := wishlistSummary(objects), "Wanted 2 · Ordered 1"; got != want {
+		t.Errorf("wishlistSummary() = %q, want %q", got, want)
+	}
+	if got := len(ownedObjects(objects)); got != 2 {
+		t.Errorf("ownedObjects() kept %d objects, want 2 (owned and unset)", got)
+	}
+	if got := wishlistSummary([]Object{{Name: "Spoon"}}); got != "" {
+		t.Errorf("wishlistSummary() without wishlist items = %q, want empty", got)
+	}
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// objectTypeLabels names the built-in object types. Custom types an account
+// defines come from the backend into ga.customObjectTypes; the
+// objectType* accessors below look in both.
+var objectTypeLabels = map[string]string{
+	ObjectTypeFood:      "Food",
+	ObjectTypeBook:      "Books",
+	ObjectTypeVideoGame: "Video Games",
+	ObjectTypeMusic:     "Music",
+	ObjectTypeBoardGame: "Board Games",
+	ObjectTypeGeneral:   "General",
+}
+
+// fetchObjectTypes gets the user's custom object types so pickers and
+// collection cards can show them
+func (ga *GioApp) fetchObjectTypes() {
+	if ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	session := ga.session
+	ga.goSafe(func() {
+		all, err := ga.objectTypesClient.List(accountID)
+		if err != nil {
+			ga.logger.Error("Failed to fetch object types", "error", err)
+			return
+		}
+		custom := make([]ObjectTypeInfo, 0, len(all))
+		for _, ot := range all {
+			if !ot.BuiltIn {
+				custom = append(custom, ot)
+			}
+		}
+		ga.doInSession(session, func() { ga.customObjectTypes = custom })
+	})
+}
+
+// resetObjectTypes forgets the loaded custom types, e.g. on sign out
+func (ga *GioApp) resetObjectTypes() {
+	ga.customObjectTypes = nil
+}
+
+// customObjectType returns the loaded custom type with key, or nil
+func (ga *GioApp) customObjectType(key string) *ObjectTypeInfo {
+	for i := range ga.customObjectTypes {
+		if ga.customObjectTypes[i].Key == key {
+			return &ga.customObjectTypes[i]
+		}
+	}
+	return nil
+}
+
+// objectTypeKeys lists the types a collection can be created with: the
+// built-in ones, then the user's own by name
+func (ga *GioApp) objectTypeKeys() []string {
+	keys := make([]string, 0, len(objectTypes)+len(ga.customObjectTypes))
+	keys = append(keys, objectTypes...)
+	for _, ot := range ga.customObjectTypes {
+		keys = append(keys, ot.Key)
+	}
+	return keys
+}
+
+// objectTypeLabel names a type. A custom type not loaded yet, or one shared
+// from another account, falls back to its key made readable.
+func (ga *GioApp) objectTypeLabel(key string) string {
+	if label, ok := objectTypeLabels[key]; ok {
+		return label
+	}
+	if ot := ga.customObjectType(key); ot != nil {
+		return ot.Name
+	}
+	return objectTypeKeyLabel(key)
+}
+
+// objectTypeIcon is the symbol shown before a type's label; built-in types
+// have none
+func (ga *GioApp) objectTypeIcon(key string) string {
+	if ot := ga.customObjectType(key); ot != nil {
+		return ot.Icon
+	}
+	return ""
+}
+
+// objectTypeColor is the color a type's label is drawn in. Custom types
+// without a color of their own use the accent like the built-in ones.
+func (ga *GioApp) objectTypeColor(key string) color.NRGBA {
+	if ot := ga.customObjectType(key); ot != nil {
+		if c, ok := parseHexColor(ot.Color); ok {
+			return c
+		}
+	}
+	return theme.ColorAccent
+}
+
+// objectTypeTitle is the icon and label together, as chips and cards show
+// them
+func (ga *GioApp) objectTypeTitle(key string) string {
+	if icon := ga.objectTypeIcon(key); icon != "" {
+		return icon + " " + ga.objectTypeLabel(key)
+	}
+	return ga.objectTypeLabel(key)
+}
+
+// objectTypeKeyLabel turns a key like "wine_bottles" into "Wine Bottles"
+func objectTypeKeyLabel(key string) string {
+	words := strings.Fields(strings.ReplaceAll(key, "_", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// parseHexColor reads a "#rrggbb" color
+func parseHexColor(s string) (color.NRGBA, bool) {
+	if len(s) != 7 || s[0] != '#' {
+		return color.NRGBA{}, false
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}, true
+}
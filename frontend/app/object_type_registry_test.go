@@ -0,0 +1,51 @@
+package app
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+func TestObjectTypeRegistry(t *testing.T) {
+	ga := &GioApp{customObjectTypes: []ObjectTypeInfo{
+		{ID: "t1", Key: "wine", Name: "Wine", Icon: "W", Color: "#7f1d1d"},
+		{ID: "t2", Key: "tools", Name: "Tools"},
+	}}
+
+	if got := ga.objectTypeKeys(); !slices.Equal(got[len(objectTypes):], []string{"wine", "tools"}) || len(got) != len(objectTypes)+2 {
+		t.Errorf("objectTypeKeys() = %v", got)
+	}
+
+	tests := []struct {
+		key   string
+		title string
+		color color.NRGBA
+	}{
+		{ObjectTypeBook, "Books", theme.ColorAccent},
+		{"wine", "W Wine", color.NRGBA{R: 0x7f, G: 0x1d, B: 0x1d, A: 255}},
+		{"tools", "Tools", theme.ColorAccent},
+		// Shared from another account, so not in the registry
+		{"comic_books", "Comic Books", theme.ColorAccent},
+	}
+	for _, tt := range tests {
+		if got := ga.objectTypeTitle(tt.key); got != tt.title {
+			t.Errorf("objectTypeTitle(%q) = %q, want %q", tt.key, got, tt.title)
+		}
+		if got := ga.objectTypeColor(tt.key); got != tt.color {
+			t.Errorf("objectTypeColor(%q) = %v, want %v", tt.key, got, tt.color)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	if c, ok := parseHexColor("#3b82f6"); !ok || c != (color.NRGBA{R: 0x3b, G: 0x82, B: 0xf6, A: 255}) {
+		t.Errorf("parseHexColor(#3b82f6) = %v, %v", c, ok)
+	}
+	for _, s := range []string{"", "3b82f6", "#3b82f", "#zzzzzz"} {
+		if _, ok := parseHexColor(s); ok {
+			t.Errorf("parseHexColor(%q) accepted", s)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import "slices"
+
+// optimisticUpdate applies a change to local state straight away so the UI
+// does not wait on the network, then sends it in the background. send runs off
+// the UI goroutine and returns a confirm func that swaps the local guess for
+// what the server stored. When send fails, rollback undoes apply and the
+// error is shown.
+func (ga *GioApp) optimisticUpdate(action string, apply, rollback func(), send func() (confirm func(), err error)) {
+	apply()
+	ga.goSafe(func() {
+		confirm, err := send()
+		ga.do(func() { ga.settleOptimistic(action, rollback, confirm, err) })
+	})
+}
+
+// settleOptimistic finishes an optimistic update on the UI goroutine once the
+// server has answered
+func (ga *GioApp) settleOptimistic(action string, rollback, confirm func(), err error) {
+	if err != nil {
+		ga.logger.Error("Request failed, rolling back local change", "action", action, "error", err)
+		rollback()
+		ga.showAPIErrorDialog("Failed to " + action + ": " + err.Error())
+		return
+	}
+	if confirm != nil {
+		confirm()
+	}
+}
+
+// reinsert puts a removed item back at index i, or at the end when the list
+// has shrunk since
+func reinsert[T any](list []T, i int, item T) []T {
+	return slices.Insert(list, min(i, len(list)), item)
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSettleOptimisticRollsBackOnError(t *testing.T) {
+	ga := newTestGioApp()
+	ga.groups = []Group{{ID: "g1", Name: "Old"}}
+
+	ga.setGroup(Group{ID: "g1", Name: "New"})
+	confirmed := false
+	ga.settleOptimistic("update group", func() { ga.setGroup(Group{ID: "g1", Name: "Old"}) },
+		func() { confirmed = true }, errors.New("boom"))
+
+	if ga.groups[0].Name != "Old" {
+		t.Errorf("name = %q after failed update, want Old", ga.groups[0].Name)
+	}
+	if confirmed {
+		t.Error("confirm ran for a failed request")
+	}
+	if !ga.showAPIError || ga.apiErrorMsg != "Failed to update group: boom" {
+		t.Errorf("error dialog = %v %q", ga.showAPIError, ga.apiErrorMsg)
+	}
+}
+
+func TestSettleOptimisticConfirms(t *testing.T) {
+	ga := newTestGioApp()
+	rolledBack, confirmed := false, false
+	ga.settleOptimistic("update group", func() { rolledBack = true }, func() { confirmed = true }, nil)
+
+	if rolledBack || !confirmed {
+		t.Errorf("rolledBack = %v, confirmed = %v; want false, true", rolledBack, confirmed)
+	}
+	if ga.showAPIError {
+		t.Error("error dialog shown for a successful request")
+	}
+
+	// A nil confirm is allowed when the local guess is already right
+	ga.settleOptimistic("delete group", func() {}, nil, nil)
+}
+
+func TestReinsert(t *testing.T) {
+	list := []string{"a", "c"}
+	if got := reinsert(list, 1, "b"); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("reinsert at 1 = %v", got)
+	}
+	// The list shrank since the item was removed
+	if got := reinsert([]string{"a"}, 3, "d"); !slices.Equal(got, []string{"a", "d"}) {
+		t.Errorf("reinsert past end = %v", got)
+	}
+}
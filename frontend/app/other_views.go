@@ -17,6 +17,18 @@ func (ga *GioApp) renderProfileView(gtx layout.Context) layout.Dimensions {
 		ga.handleLogout()
 	}
 
+	if ga.widgetState.backupButton.Clicked(gtx) {
+		ga.downloadBackup()
+	}
+
+	if ga.widgetState.aboutButton.Clicked(gtx) {
+		ga.openAboutDialog()
+	}
+
+	if ga.widgetState.automationsButton.Clicked(gtx) {
+		ga.openAutomations()
+	}
+
 	return layout.Flex{
 		Axis: layout.Vertical,
 	}.Layout(gtx,
@@ -48,6 +60,63 @@ func (ga *GioApp) renderProfileView(gtx layout.Context) layout.Dimensions {
 						return layout.Dimensions{}
 					}),
 
+					// Backup download
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{
+							Bottom: unit.Dp(theme.Spacing4),
+						}.Layout(gtx, ga.renderBackupSection)
+					}),
+
+					// Label printer used by "Print label"
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.currentUser == nil {
+							return layout.Dimensions{}
+						}
+						return ga.renderLabelTemplateSection(gtx)
+					}),
+
+					// Units quantities are shown in
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.currentUser == nil {
+							return layout.Dimensions{}
+						}
+						return ga.renderUnitSystemSection(gtx)
+					}),
+
+					// Devices signed in to the account
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.currentUser == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{
+							Bottom: unit.Dp(theme.Spacing4),
+						}.Layout(gtx, ga.renderSessionsSection)
+					}),
+
+					// Personal data export and account deletion
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{
+							Bottom: unit.Dp(theme.Spacing4),
+						}.Layout(gtx, ga.renderAccountDataSection)
+					}),
+
+					// Rules that act on object changes
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.currentUser == nil {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{
+							Bottom: unit.Dp(theme.Spacing4),
+						}.Layout(gtx, widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.automationsButton, "Automations"))
+					}),
+
+					// About dialog
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{
+							Bottom: unit.Dp(theme.Spacing4),
+						}.Layout(gtx, widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.aboutButton, "About"))
+					}),
+
 					// Logout button
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 						return widgets.DangerButton(ga.theme.Theme, &ga.widgetState.logoutButton, "Sign Out")(gtx)
@@ -123,7 +192,11 @@ func (ga *GioApp) renderUserInfoCard(gtx layout.Context) layout.Dimensions {
 						return label.Layout(gtx)
 					}),
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						label := material.Body1(ga.theme.Theme, ga.config.BackendURL)
+						text := ga.config.BackendURL
+						if ga.hasProfiles() {
+							text = ga.config.ActiveProfile() + " · " + text
+						}
+						label := material.Body1(ga.theme.Theme, text)
 						return label.Layout(gtx)
 					}),
 				)
@@ -132,6 +205,30 @@ func (ga *GioApp) renderUserInfoCard(gtx layout.Context) layout.Dimensions {
 	})
 }
 
+// renderBackupSection renders the Download Backup button and its last status
+func (ga *GioApp) renderBackupSection(gtx layout.Context) layout.Dimensions {
+	label := "Download Backup"
+	if ga.backupInProgress {
+		label = "Downloading..."
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.backupButton, label)),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if ga.backupStatus == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				status := material.Body2(ga.theme.Theme, ga.backupStatus)
+				status.Color = theme.ColorTextSecondary
+				return status.Layout(gtx)
+			})
+		}),
+	)
+}
+
 // handleSessionExpired is called when an API request fails due to an expired or
 // invalid token. It clears local auth state and returns to the login screen
 // without attempting an Authentik end-session redirect.
@@ -140,25 +237,61 @@ func (ga *GioApp) handleSessionExpired() {
 		return // already handled
 	}
 	ga.authService.ClearToken()
+	ga.apiClient.ClearCache()
+	ga.resetSessionState()
+	ga.resetMealPlans()
+	ga.loginErrorMsg = "Your session has expired. Please sign in again."
+	ga.currentView = ViewLoginGio
+	ga.window.Invalidate()
+}
+
+// resetSessionState forgets the signed-in user's data, sending deletes still
+// waiting out their undo window and dropping results still being fetched
+func (ga *GioApp) resetSessionState() {
+	ga.endSession()
+	ga.flushPendingDeletes(nil)
 	ga.currentUser = nil
 	ga.groups = nil
 	ga.collections = nil
+	ga.groupsLoaded = false
+	ga.collectionsLoaded = false
+	ga.dashboardStats = dashboardStats{}
+	ga.dashboardStatsStale = false
+	ga.dashboardStatsLoading = false
+	ga.resetViewPreferences()
+	ga.resetViewStates()
+	ga.resetCollectionPhotos()
+	ga.resetNutritionStats()
+	ga.resetCollectionFolders()
+	ga.resetObjectTypes()
+	ga.resetAdmin()
+	ga.resetFeatures()
+	ga.resetValuation()
+	ga.resetInbox()
+	ga.resetAutomations()
+	ga.resetExpirySuggestions()
+	ga.resetStaples()
+	ga.resetSessions()
+	ga.backupStatus = ""
+	ga.dataExportStatus = ""
+	ga.reauthRequired = false
 	ga.isSignedIn = false
-	ga.loginErrorMsg = "Your session has expired. Please sign in again."
-	ga.currentView = ViewLoginGio
-	ga.window.Invalidate()
 }
 
 // handleLogout logs out the current user
 func (ga *GioApp) handleLogout() {
-	// Clear token from localStorage
-	ga.authService.ClearToken()
+	// Clear token from localStorage once deletes still in their undo window
+	// have been sent with it, unless the user has signed in again meanwhile
+	ga.isSignedIn = false
+	authService := ga.authService
+	ga.flushPendingDeletes(func() {
+		if !ga.isSignedIn {
+			authService.ClearToken()
+		}
+	})
 
 	// Reset app state
-	ga.currentUser = nil
-	ga.groups = nil
-	ga.collections = nil
-	ga.isSignedIn = false
+	ga.resetSessionState()
 
 	// Navigate to login view
 	ga.currentView = ViewLoginGio
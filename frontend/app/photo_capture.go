@@ -0,0 +1,59 @@
+//go:build js && wasm
+
+package app
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// photoCaptureSupported reports whether photos are picked through the
+// browser; on phones the picker offers the camera
+func photoCaptureSupported() bool { return true }
+
+// capturePhoto opens the browser's image picker, asking mobile browsers for
+// the rear camera. onPhoto gets the picked file's bytes and onError a read
+// failure, both from the browser's event loop, so neither may block. Nothing
+// is called if the picker is dismissed.
+func capturePhoto(onPhoto func(filename string, data []byte), onError func(error)) {
+	input := js.Global().Get("document").Call("createElement", "input")
+	input.Set("type", "file")
+	input.Set("accept", "image/*")
+	input.Call("setAttribute", "capture", "environment")
+
+	var changeHandler js.Func
+	changeHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer changeHandler.Release()
+		files := input.Get("files")
+		if files.Length() == 0 {
+			return nil
+		}
+		file := files.Index(0)
+		filename := file.Get("name").String()
+
+		reader := js.Global().Get("FileReader").New()
+		var loadHandler, errorHandler js.Func
+		loadHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			buf := js.Global().Get("Uint8Array").New(reader.Get("result"))
+			data := make([]byte, buf.Get("length").Int())
+			js.CopyBytesToGo(data, buf)
+			loadHandler.Release()
+			errorHandler.Release()
+			onPhoto(filename, data)
+			return nil
+		})
+		errorHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			loadHandler.Release()
+			errorHandler.Release()
+			onError(errors.New("could not read " + filename))
+			return nil
+		})
+		reader.Set("onload", loadHandler)
+		reader.Set("onerror", errorHandler)
+		reader.Call("readAsArrayBuffer", file)
+		return nil
+	})
+
+	input.Call("addEventListener", "change", changeHandler)
+	input.Call("click")
+}
@@ -0,0 +1,11 @@
+//go:build !js || !wasm
+
+package app
+
+// Desktop builds have no camera picker; the photo dialog takes a file path.
+
+func photoCaptureSupported() bool { return false }
+
+func capturePhoto(_ func(string, []byte), onError func(error)) {
+	onError(errPhotoCaptureUnsupported)
+}
@@ -0,0 +1,300 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	stddraw "image/draw"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"golang.org/x/image/draw"
+
+	mediaAPI "github.com/nishiki/frontend/pkg/api/media"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// Photos are scaled down to fit maxPhotoEdge pixels on their longest side
+// and saved as JPEG before upload, so a phone camera's 4-12 MB shot goes
+// over a slow connection as a few hundred KB.
+const (
+	maxPhotoEdge     = 1600
+	photoJPEGQuality = 80
+)
+
+var errPhotoCaptureUnsupported = errors.New("taking photos is not supported here, enter a file path")
+
+// compressPhoto shrinks a picked photo for upload. GIFs are kept as they are
+// so animations survive; anything else the decoders read, including WebP
+// which the server does not accept, comes back as a JPEG. A JPEG that is
+// already small enough and would not get smaller is left alone.
+func compressPhoto(filename string, data []byte) (mediaAPI.Photo, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return mediaAPI.Photo{}, fmt.Errorf("%s is not an image this app can read", filename)
+	}
+	if format == "gif" {
+		return mediaAPI.Photo{Filename: filename, ContentType: "image/gif", Data: data}, nil
+	}
+
+	bounds := img.Bounds()
+	w, h := fitPhotoSize(bounds.Dx(), bounds.Dy(), maxPhotoEdge)
+	resized := w != bounds.Dx() || h != bounds.Dy()
+
+	// JPEG has no alpha, so transparent areas go on white rather than black
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	stddraw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, stddraw.Src)
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, dst, &jpeg.Options{Quality: photoJPEGQuality}); err != nil {
+		return mediaAPI.Photo{}, fmt.Errorf("failed to compress %s: %w", filename, err)
+	}
+	if format == "jpeg" && !resized && out.Len() >= len(data) {
+		return mediaAPI.Photo{Filename: filename, ContentType: "image/jpeg", Data: data}, nil
+	}
+	name := strings.TrimSuffix(filename, filepath.Ext(filename)) + ".jpg"
+	return mediaAPI.Photo{Filename: name, ContentType: "image/jpeg", Data: out.Bytes()}, nil
+}
+
+// fitPhotoSize scales w x h down, keeping its aspect ratio, so neither side
+// exceeds maxEdge. Smaller images keep their size.
+func fitPhotoSize(w, h, maxEdge int) (int, int) {
+	if w <= maxEdge && h <= maxEdge {
+		return w, h
+	}
+	if w >= h {
+		return maxEdge, max(1, h*maxEdge/w)
+	}
+	return max(1, w*maxEdge/h), maxEdge
+}
+
+// openPhotoDialog starts attaching a photo to object
+func (ga *GioApp) openPhotoDialog(object Object) {
+	ga.showPhotoDialog = true
+	ga.photoObject = &object
+	ga.photoPending = nil
+	ga.photoOriginalSize = 0
+	ga.photoCompressing = false
+	ga.photoProgress = 0
+	ga.photoErr = ""
+	ga.widgetState.photoPathEditor.SetText("")
+	ga.widgetState.photoDialog.Reset()
+}
+
+func (ga *GioApp) closePhotoDialog() {
+	// An upload in flight still finishes; only its result is dropped
+	ga.showPhotoDialog = false
+	ga.photoObject = nil
+	ga.photoPending = nil
+	ga.photoErr = ""
+	ga.widgetState.photoDialog.Reset()
+}
+
+// pickPhoto opens the camera or image picker, or on desktop reads the path
+// typed into the dialog
+func (ga *GioApp) pickPhoto() {
+	if !photoCaptureSupported() {
+		path := strings.TrimSpace(ga.widgetState.photoPathEditor.Text())
+		if path == "" {
+			ga.photoErr = errPhotoCaptureUnsupported.Error()
+			return
+		}
+		ga.goSafe(func() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				ga.do(func() { ga.photoErr = "Could not read the photo: " + err.Error() })
+				return
+			}
+			ga.preparePhoto(filepath.Base(path), data)
+		})
+		return
+	}
+	capturePhoto(func(filename string, data []byte) {
+		ga.goSafe(func() { ga.preparePhoto(filename, data) })
+	}, func(err error) {
+		ga.goSafe(func() { ga.do(func() { ga.photoErr = err.Error() }) })
+	})
+}
+
+// preparePhoto compresses a picked photo off the UI goroutine and holds it
+// in the dialog until it is uploaded
+func (ga *GioApp) preparePhoto(filename string, data []byte) {
+	ga.do(func() {
+		ga.photoCompressing = true
+		ga.photoErr = ""
+	})
+	photo, err := compressPhoto(filename, data)
+	ga.do(func() {
+		ga.photoCompressing = false
+		if !ga.showPhotoDialog {
+			return
+		}
+		if err != nil {
+			ga.photoErr = err.Error()
+			return
+		}
+		ga.logger.Info("Compressed photo", "filename", filename, "original", len(data), "compressed", len(photo.Data))
+		ga.photoPending = &photo
+		ga.photoOriginalSize = int64(len(data))
+	})
+}
+
+// uploadPhoto sends the pending photo, updating the dialog's progress bar as
+// the request body goes out
+func (ga *GioApp) uploadPhoto() {
+	if ga.currentUser == nil || ga.photoObject == nil || ga.photoPending == nil || ga.photoUploading {
+		return
+	}
+	userID := ga.currentUser.ID
+	objectID := ga.photoObject.ID
+	photo := *ga.photoPending
+	ga.photoUploading = true
+	ga.photoProgress = 0
+	ga.photoErr = ""
+
+	ga.goSafe(func() {
+		lastPercent := -1
+		result, err := ga.mediaClient.UploadObject(userID, objectID, []mediaAPI.Photo{photo}, func(sent, total int64) {
+			// Only repaint when the bar visibly moves
+			percent := int(sent * 100 / max(total, 1))
+			if percent == lastPercent {
+				return
+			}
+			lastPercent = percent
+			ga.do(func() { ga.photoProgress = float32(percent) / 100 })
+		})
+
+		ga.do(func() {
+			ga.photoUploading = false
+			if err == nil && len(result.Failed) > 0 {
+				err = errors.New(result.Failed[0].Error)
+			}
+			if err != nil {
+				ga.logger.Error("Failed to upload photo", "object_id", objectID, "error", err)
+				if ga.showPhotoDialog {
+					ga.photoErr = "Upload failed: " + err.Error()
+				}
+				return
+			}
+			ga.logger.Info("Uploaded photo", "object_id", objectID, "size", len(photo.Data))
+			// The Photos tab reloads with the new photo when next shown
+			ga.resetCollectionPhotos()
+			if ga.showPhotoDialog && ga.photoObject != nil && ga.photoObject.ID == objectID {
+				ga.closePhotoDialog()
+			}
+		})
+	})
+}
+
+// renderPhotoDialog lets the user take or pick a photo of an object, shows
+// how much compressing it saved, and reports upload progress
+func (ga *GioApp) renderPhotoDialog(gtx layout.Context) layout.Dimensions {
+	if !ga.showPhotoDialog || ga.photoObject == nil {
+		return layout.Dimensions{}
+	}
+	if ga.widgetState.photoPickButton.Clicked(gtx) && !ga.photoUploading {
+		ga.pickPhoto()
+	}
+	if ga.widgetState.photoUploadButton.Clicked(gtx) {
+		ga.uploadPhoto()
+	}
+	if ga.widgetState.photoCancelButton.Clicked(gtx) {
+		ga.closePhotoDialog()
+		return layout.Dimensions{}
+	}
+
+	pickLabel := "Take or choose photo"
+	if !photoCaptureSupported() {
+		pickLabel = "Load photo"
+	}
+	dialogStyle := widgets.DefaultDialogStyle(ga.widgetState.photoDialog, "Add Photo to "+ga.photoObject.Name)
+
+	dims, dismissed := dialogStyle.Layout(gtx, ga.theme.Theme, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if photoCaptureSupported() {
+					return layout.Dimensions{}
+				}
+				return ga.renderFormField(gtx, "Photo file", &ga.widgetState.photoPathEditor, "/path/to/photo.jpg")
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx,
+					widgets.AccentButton(ga.theme.Theme, &ga.widgetState.photoPickButton, pickLabel))
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				var status string
+				switch {
+				case ga.photoCompressing:
+					status = "Compressing..."
+				case ga.photoPending != nil:
+					status = fmt.Sprintf("%s: %s, compressed from %s", ga.photoPending.Filename,
+						formatBytes(int64(len(ga.photoPending.Data))), formatBytes(ga.photoOriginalSize))
+				default:
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, status)
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if !ga.photoUploading {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(material.ProgressBar(ga.theme.Theme, ga.photoProgress).Layout),
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							label := material.Caption(ga.theme.Theme, fmt.Sprintf("Uploading... %d%%", int(ga.photoProgress*100)))
+							label.Color = theme.ColorTextSecondary
+							return label.Layout(gtx)
+						}),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.photoErr == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, ga.photoErr)
+					label.Color = theme.ColorDanger
+					return label.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx,
+							widgets.CancelButton(ga.theme.Theme, &ga.widgetState.photoCancelButton, "Cancel"))
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if ga.photoPending == nil {
+							return layout.Dimensions{}
+						}
+						label := "Upload"
+						if ga.photoUploading {
+							label = "Uploading..."
+						}
+						return widgets.PrimaryButton(ga.theme.Theme, &ga.widgetState.photoUploadButton, label)(gtx)
+					}),
+				)
+			}),
+		)
+	})
+
+	if dismissed {
+		ga.closePhotoDialog()
+	}
+	return dims
+}
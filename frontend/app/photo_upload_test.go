@@ -0,0 +1,103 @@
+package app
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestFitPhotoSize(t *testing.T) {
+	tests := []struct {
+		w, h         int
+		wantW, wantH int
+	}{
+		{800, 600, 800, 600},
+		{4000, 3000, 1600, 1200},
+		{3000, 4000, 1200, 1600},
+		{1600, 1600, 1600, 1600},
+		{10000, 2, 1600, 1},
+	}
+	for _, tt := range tests {
+		if w, h := fitPhotoSize(tt.w, tt.h, maxPhotoEdge); w != tt.wantW || h != tt.wantH {
+			t.Errorf("fitPhotoSize(%d, %d) = %dx%d, want %dx%d", tt.w, tt.h, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+// noisyImage stands in for a camera shot, which PNG cannot compress well
+func noisyImage(w, h int) *image.RGBA {
+	rng := rand.New(rand.NewPCG(1, 2))
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{uint8(rng.Uint32()), uint8(rng.Uint32()), uint8(rng.Uint32()), 255})
+		}
+	}
+	return img
+}
+
+func TestCompressPhotoShrinksLargePNG(t *testing.T) {
+	var src bytes.Buffer
+	if err := png.Encode(&src, noisyImage(2400, 1200)); err != nil {
+		t.Fatal(err)
+	}
+
+	photo, err := compressPhoto("shelf.png", src.Bytes())
+	if err != nil {
+		t.Fatalf("compressPhoto: %v", err)
+	}
+	if photo.Filename != "shelf.jpg" || photo.ContentType != "image/jpeg" {
+		t.Errorf("photo = %s (%s), want shelf.jpg (image/jpeg)", photo.Filename, photo.ContentType)
+	}
+	if len(photo.Data) >= src.Len() {
+		t.Errorf("compressed to %d bytes from %d", len(photo.Data), src.Len())
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(photo.Data))
+	if err != nil {
+		t.Fatalf("compressed photo is not a JPEG: %v", err)
+	}
+	if cfg.Width != 1600 || cfg.Height != 800 {
+		t.Errorf("compressed size = %dx%d, want 1600x800", cfg.Width, cfg.Height)
+	}
+}
+
+func TestCompressPhotoKeepsSmallJPEG(t *testing.T) {
+	var src bytes.Buffer
+	if err := jpeg.Encode(&src, noisyImage(64, 64), &jpeg.Options{Quality: 30}); err != nil {
+		t.Fatal(err)
+	}
+
+	photo, err := compressPhoto("tiny.jpeg", src.Bytes())
+	if err != nil {
+		t.Fatalf("compressPhoto: %v", err)
+	}
+	if photo.Filename != "tiny.jpeg" || !bytes.Equal(photo.Data, src.Bytes()) {
+		t.Errorf("small JPEG was re-encoded as %s (%d bytes)", photo.Filename, len(photo.Data))
+	}
+}
+
+func TestCompressPhotoKeepsGIF(t *testing.T) {
+	var src bytes.Buffer
+	if err := gif.Encode(&src, noisyImage(2000, 20), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	photo, err := compressPhoto("spin.gif", src.Bytes())
+	if err != nil {
+		t.Fatalf("compressPhoto: %v", err)
+	}
+	if photo.ContentType != "image/gif" || !bytes.Equal(photo.Data, src.Bytes()) {
+		t.Errorf("GIF was converted to %s", photo.ContentType)
+	}
+}
+
+func TestCompressPhotoRejectsNonImages(t *testing.T) {
+	if _, err := compressPhoto("notes.txt", []byte("not a photo")); err == nil {
+		t.Error("compressPhoto accepted a text file")
+	}
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// switchProfile signs the app in to another profile's account. The current
+// profile's data is dropped and deletes waiting out their undo window are sent,
+// while its sign-in is kept for switching back. A profile without a stored
+// token lands on the login screen.
+func (ga *GioApp) switchProfile(name string) {
+	if name == ga.config.ActiveProfile() {
+		return
+	}
+	ga.logger.Info("Switching profile", "from", ga.config.ActiveProfile(), "to", name)
+
+	ga.cancelSearch()
+	ga.apiClient.ClearCache()
+	ga.resetSessionState()
+	ga.resetMealPlans()
+	ga.loginErrorMsg = ""
+
+	ga.connect(ga.baseConfig.ForProfile(name))
+	saveActiveProfile(ga.config.ProfileKey())
+
+	// An expired token is refreshed by the first request; if that fails the
+	// session-expired handler shows the login screen
+	if _, err := ga.authService.GetStoredToken(); err != nil {
+		ga.currentView = ViewLoginGio
+		ga.window.Invalidate()
+		return
+	}
+	ga.isSignedIn = true
+	ga.currentView = ViewDashboardGio
+	ga.loadUserData()
+}
+
+// hasProfiles reports whether profiles besides the top-level one are
+// configured, which is when the switcher is shown
+func (ga *GioApp) hasProfiles() bool {
+	return len(ga.baseConfig.ProfileNames()) > 1
+}
+
+// renderProfileSwitcher renders a chip per configured profile, the active one
+// highlighted
+func (ga *GioApp) renderProfileSwitcher(gtx layout.Context) layout.Dimensions {
+	names := ga.baseConfig.ProfileNames()
+	for _, name := range names {
+		btn := ga.widgetState.profileButtons[name]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.profileButtons[name] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.switchProfile(name)
+		}
+	}
+
+	active := ga.config.ActiveProfile()
+	chips := make([]layout.FlexChild, len(names))
+	for i, name := range names {
+		btn := ga.widgetState.profileButtons[name]
+		chips[i] = layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Right: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return ga.renderFilterChip(gtx, btn, name, name == active)
+			})
+		})
+	}
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx, chips...)
+}
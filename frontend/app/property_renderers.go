@@ -47,15 +47,7 @@ func renderTypedValue(tv TypedValue, def *PropertyDefinition) string {
 		if err != nil {
 			return fmt.Sprintf("%v", tv.Val)
 		}
-		symbol := currencySymbols[strings.ToUpper(currencyCode)]
-		if symbol == "" {
-			if currencyCode != "" {
-				symbol = currencyCode + " "
-			} else {
-				symbol = "$"
-			}
-		}
-		return fmt.Sprintf("%s%.2f", symbol, f)
+		return fmt.Sprintf("%s%.2f", currencySymbol(currencyCode), f)
 
 	case "date":
 		var t time.Time
@@ -127,6 +119,18 @@ func renderTypedValue(tv TypedValue, def *PropertyDefinition) string {
 	}
 }
 
+// currencySymbol is the prefix for an amount in the given currency: its
+// symbol when known, else the code itself, and "$" when there is no code
+func currencySymbol(currencyCode string) string {
+	if symbol := currencySymbols[strings.ToUpper(currencyCode)]; symbol != "" {
+		return symbol
+	}
+	if currencyCode != "" {
+		return currencyCode + " "
+	}
+	return "$"
+}
+
 func toFloat(value any) (float64, error) {
 	switch v := value.(type) {
 	case float64:
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"slices"
+
+	"gioui.org/io/event"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// sizeClass buckets the window width into the breakpoints the views adapt to.
+type sizeClass int
+
+const (
+	sizeCompact  sizeClass = iota // phones: bottom menu, single-pane views
+	sizeMedium                    // tablets: bottom menu, multi-column lists
+	sizeExpanded                  // desktops: sidebar navigation, wide grids
+)
+
+// Breakpoints in dp, matching the common phone/tablet/desktop split.
+const (
+	mediumMinWidthDp   = 600
+	expandedMinWidthDp = 1024
+	sideNavWidthDp     = 200
+)
+
+func sizeClassFor(width unit.Dp) sizeClass {
+	switch {
+	case width >= expandedMinWidthDp:
+		return sizeExpanded
+	case width >= mediumMinWidthDp:
+		return sizeMedium
+	default:
+		return sizeCompact
+	}
+}
+
+// updateSizeClass records the breakpoint for the window width of the
+// current frame.
+func (ga *GioApp) updateSizeClass(width unit.Dp) {
+	class := sizeClassFor(width)
+	if class != ga.sizeClass {
+		ga.logger.Debug("Window size class changed", "width_dp", width, "from", ga.sizeClass, "to", class)
+		ga.sizeClass = class
+	}
+}
+
+// collectionColumns returns how many collection cards fit side by side.
+func (c sizeClass) collectionColumns() int {
+	switch c {
+	case sizeExpanded:
+		return 3
+	case sizeMedium:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// showsSideNav reports whether navigation lives in the sidebar instead of
+// the per-view bottom menu.
+func (ga *GioApp) showsSideNav() bool {
+	if ga.sizeClass != sizeExpanded || !ga.isSignedIn {
+		return false
+	}
+	return ga.currentView != ViewLoginGio && ga.currentView != ViewCallbackGio
+}
+
+type navItem struct {
+	btn    *widget.Clickable
+	label  string
+	target ViewID
+}
+
+// navItems returns the top-level destinations shared by the bottom menu and
+// the sidebar. Only one of the two is laid out per frame, so they share
+// clickables.
+func (ga *GioApp) navItems() []navItem {
+	items := []navItem{
+		{&ga.widgetState.menuDashboard, "Home", ViewDashboardGio},
+		{&ga.widgetState.menuGroups, "Groups", ViewGroupsGio},
+		{&ga.widgetState.menuCollections, "Collections", ViewCollectionsGio},
+		{&ga.widgetState.menuMeals, "Meals", ViewMealPlanGio},
+		{&ga.widgetState.menuProfile, "Profile", ViewProfileGio},
+	}
+	if !ga.featureEnabled(featureMealPlanner) {
+		items = slices.DeleteFunc(items, func(item navItem) bool { return item.target == ViewMealPlanGio })
+	}
+	return items
+}
+
+// handleNavClicks navigates to the clicked destination if it is not
+// already active. The arrow keys along axis move between the destinations.
+func (ga *GioApp) handleNavClicks(gtx layout.Context, items []navItem, activeView ViewID, axis layout.Axis) {
+	tags := make([]event.Tag, len(items))
+	for i, item := range items {
+		if item.btn.Clicked(gtx) && item.target != activeView {
+			ga.currentView = item.target
+		}
+		tags[i] = item.btn
+	}
+	widgets.ArrowFocus(gtx, axis, tags...)
+}
+
+// navViewFor maps a view to the top-level destination it belongs under.
+func navViewFor(view ViewID) ViewID {
+	switch view {
+	case ViewCollectionDetailGio, ViewContainersGio:
+		return ViewCollectionsGio
+	default:
+		return view
+	}
+}
+
+// renderSideNav renders the vertical navigation rail used on wide windows.
+func (ga *GioApp) renderSideNav(gtx layout.Context) layout.Dimensions {
+	items := ga.navItems()
+	activeView := navViewFor(ga.currentView)
+	ga.handleNavClicks(gtx, items, activeView, layout.Vertical)
+
+	width := gtx.Dp(unit.Dp(sideNavWidthDp))
+	gtx.Constraints.Min.X = width
+	gtx.Constraints.Max.X = width
+	gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+
+	card := widgets.Card{
+		BackgroundColor: theme.ColorSurface,
+		CornerRadius:    0,
+		Inset:           layout.UniformInset(unit.Dp(theme.Spacing3)),
+	}
+	return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		children := make([]layout.FlexChild, len(items))
+		for i, item := range items {
+			children[i] = layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					variant := widgets.PrimaryButton
+					if item.target == activeView {
+						variant = widgets.AccentButton
+					}
+					return variant(ga.theme.Theme, item.btn, item.label)(gtx)
+				})
+			})
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
@@ -408,7 +408,7 @@ func (ga *GioApp) handleSchemaUpdate() {
 	collectionID := ga.selectedCollection.ID
 	accountID := ga.currentUser.ID
 
-	go func() {
+	ga.goSafe(func() {
 		err := ga.collectionsClient.UpdateSchema(accountID, collectionID, types.UpdatePropertySchemaRequest{
 			PropertySchema: *schemaReq,
 		})
@@ -440,8 +440,8 @@ func (ga *GioApp) handleSchemaUpdate() {
 			ga.invalidateObjectCaches()
 		})
 		// Refetch objects so any re-coercion the backend applied is reflected.
-		ga.fetchContainersAndObjects()
-	}()
+		ga.do(ga.fetchContainersAndObjects)
+	})
 
 	ga.showSchemaDialog = false
 	ga.widgetState.schemaRows = nil
@@ -464,10 +464,10 @@ func (ga *GioApp) handleImportSchemaContinue() {
 	switch returnTo {
 	case "preview":
 		// Run the import against the existing collection using the user schema.
-		go ga.executeImport()
+		ga.executeImport()
 	case "create":
 		// Resume the import-create flow with the user schema.
-		go ga.executeImportCreate()
+		ga.executeImportCreate()
 	}
 	ga.window.Invalidate()
 }
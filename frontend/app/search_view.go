@@ -0,0 +1,287 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+const (
+	// searchDebounce is how long typing must pause before a search is sent
+	searchDebounce = 250 * time.Millisecond
+	// searchLimit is how many matches the search view asks for
+	searchLimit = 50
+)
+
+// scheduleSearch searches for text once typing pauses. Any pending or
+// in-flight search is dropped, so only the latest text's results are shown.
+func (ga *GioApp) scheduleSearch(text string) {
+	ga.searchInput = text
+	ga.searchSeq++
+	if ga.searchTimer != nil {
+		ga.searchTimer.Stop()
+		ga.searchTimer = nil
+	}
+	ga.cancelSearch()
+
+	query := strings.TrimSpace(text)
+	if query == "" {
+		ga.searchResults = nil
+		ga.searchLoading = false
+		ga.searchErr = ""
+		return
+	}
+
+	seq := ga.searchSeq
+	ga.searchLoading = true
+	ga.searchTimer = time.AfterFunc(searchDebounce, func() {
+		ga.do(func() { ga.runSearch(seq, query) })
+	})
+}
+
+// runSearch sends the search scheduled as seq unless newer typing has
+// superseded it
+func (ga *GioApp) runSearch(seq int, query string) {
+	if seq != ga.searchSeq || ga.currentUser == nil {
+		return
+	}
+	ga.searchTimer = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ga.searchCancel = cancel
+	userID := ga.currentUser.ID
+	ga.goSafe(func() {
+		results, err := ga.objectsClient.Search(ctx, userID, query, searchLimit)
+		ga.do(func() { ga.applySearchResults(seq, results, err) })
+	})
+}
+
+// applySearchResults shows the answer to search seq. Answers to superseded
+// searches, including the cancellation errors of abandoned requests, are
+// dropped.
+func (ga *GioApp) applySearchResults(seq int, results *types.SearchResults, err error) {
+	if seq != ga.searchSeq {
+		return
+	}
+	ga.cancelSearch()
+	ga.searchLoading = false
+	if err != nil {
+		ga.logger.Error("Search failed", "error", err)
+		ga.searchErr = err.Error()
+		return
+	}
+	ga.searchErr = ""
+	ga.searchResults = results
+}
+
+// cancelSearch abandons the in-flight search request, if any
+func (ga *GioApp) cancelSearch() {
+	if ga.searchCancel != nil {
+		ga.searchCancel()
+		ga.searchCancel = nil
+	}
+}
+
+// openSearchMatch opens the match's collection with the object in the
+// detail pane
+func (ga *GioApp) openSearchMatch(match types.SearchMatch) {
+	i := slices.IndexFunc(ga.collections, func(c Collection) bool { return c.ID == match.CollectionID })
+	if i < 0 {
+		ga.showAPIErrorDialog(fmt.Sprintf("Collection %q is not loaded yet. Try again in a moment.", match.CollectionName))
+		return
+	}
+	ga.logger.Info("Opening search match", "object_id", match.Object.ID, "collection_id", match.CollectionID)
+	collection := ga.collections[i]
+	ga.selectedCollection = &collection
+	ga.currentView = ViewCollectionDetailGio
+	ga.fetchContainersAndObjects()
+	// The pane shows the object once the collection's objects arrive
+	ga.openObjectDetail(match.Object)
+}
+
+// renderSearchView renders the search field and the matches across every
+// collection the user can see
+func (ga *GioApp) renderSearchView(gtx layout.Context) layout.Dimensions {
+	if text := ga.widgetState.searchField.Text(); text != ga.searchInput {
+		ga.scheduleSearch(text)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderHeader(gtx, "Search")
+		}),
+
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing4),
+				Bottom: unit.Dp(theme.Spacing20), // Space for bottom menu
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							editor := material.Editor(ga.theme.Theme, &ga.widgetState.searchField, "Search names, tags, descriptions, locations...")
+							editor.Color = theme.ColorTextPrimary
+							return editor.Layout(gtx)
+						})
+					}),
+					layout.Rigid(ga.renderSearchStatus),
+					layout.Flexed(1, ga.renderSearchResults),
+				)
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBottomMenu(gtx, ViewDashboardGio)
+		}),
+	)
+}
+
+// renderSearchStatus renders the searching, error or result count line
+func (ga *GioApp) renderSearchStatus(gtx layout.Context) layout.Dimensions {
+	status, statusColor := "", theme.ColorTextSecondary
+	switch {
+	case ga.searchErr != "":
+		status, statusColor = ga.searchErr, theme.ColorDanger
+	case ga.searchLoading:
+		status = "Searching..."
+	case ga.searchResults == nil:
+	case len(ga.searchResults.Matches) == 0:
+		status = fmt.Sprintf("Nothing matches %q", ga.searchResults.Query)
+	case ga.searchResults.Truncated:
+		status = fmt.Sprintf("First %d matches", len(ga.searchResults.Matches))
+	default:
+		status = fmt.Sprintf("%d match(es)", len(ga.searchResults.Matches))
+	}
+	if status == "" {
+		return layout.Dimensions{}
+	}
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, status)
+		label.Color = statusColor
+		return label.Layout(gtx)
+	})
+}
+
+// renderSearchResults renders a card per match. Results stay up while a
+// newer search is in flight so the list does not flicker while typing.
+func (ga *GioApp) renderSearchResults(gtx layout.Context) layout.Dimensions {
+	if ga.searchResults == nil {
+		return layout.Dimensions{}
+	}
+	matches := ga.searchResults.Matches
+	return material.List(ga.theme.Theme, &ga.widgetState.searchList).Layout(gtx, len(matches), func(gtx layout.Context, i int) layout.Dimensions {
+		return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return ga.renderSearchMatch(gtx, matches[i])
+		})
+	})
+}
+
+// renderSearchMatch renders one match: the object's name, where it is kept,
+// and the snippet it matched with the matched terms highlighted
+func (ga *GioApp) renderSearchMatch(gtx layout.Context, match types.SearchMatch) layout.Dimensions {
+	click := ga.widgetState.searchResultItems[match.Object.ID]
+	if click == nil {
+		click = &widget.Clickable{}
+		ga.widgetState.searchResultItems[match.Object.ID] = click
+	}
+	if click.Clicked(gtx) {
+		ga.openSearchMatch(match)
+	}
+
+	location := match.CollectionName
+	if match.ContainerName != "" {
+		location += " › " + match.ContainerName
+	}
+
+	return material.Clickable(gtx, click, func(gtx layout.Context) layout.Dimensions {
+		return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, match.Object.Name)
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					label := material.Caption(ga.theme.Theme, location)
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					// The name is already shown in full above
+					if match.Field == "name" {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Top: unit.Dp(theme.Spacing1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderSnippet(gtx, match)
+					})
+				}),
+			)
+		})
+	})
+}
+
+// renderSnippet lays out the match's snippet on one line, labelled with the
+// field it came from, with the highlighted ranges in bold
+func (ga *GioApp) renderSnippet(gtx layout.Context, match types.SearchMatch) layout.Dimensions {
+	spans := snippetSpans(match.Snippet, match.Highlights)
+	children := make([]layout.FlexChild, 0, len(spans)+1)
+	children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+		label := material.Body2(ga.theme.Theme, match.Field+": ")
+		label.Color = theme.ColorTextSecondary
+		return label.Layout(gtx)
+	}))
+	for _, span := range spans {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			label := material.Body2(ga.theme.Theme, span.text)
+			label.MaxLines = 1
+			if span.match {
+				label.Font.Weight = font.Bold
+				label.Color = theme.ColorPrimaryDark
+			}
+			return label.Layout(gtx)
+		}))
+	}
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, children...)
+}
+
+// snippetSpan is a run of snippet text, highlighted or not
+type snippetSpan struct {
+	text  string
+	match bool
+}
+
+// snippetSpans splits snippet at the highlighted byte ranges. Ranges are
+// expected sorted and disjoint, as the backend sends them; any that fall
+// outside the snippet or overlap an earlier one are ignored.
+func snippetSpans(snippet string, highlights []types.TextRange) []snippetSpan {
+	var spans []snippetSpan
+	pos := 0
+	for _, h := range highlights {
+		if h.Start < pos || h.End <= h.Start || h.End > len(snippet) {
+			continue
+		}
+		if h.Start > pos {
+			spans = append(spans, snippetSpan{text: snippet[pos:h.Start]})
+		}
+		spans = append(spans, snippetSpan{text: snippet[h.Start:h.End], match: true})
+		pos = h.End
+	}
+	if pos < len(snippet) {
+		spans = append(spans, snippetSpan{text: snippet[pos:]})
+	}
+	return spans
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestSnippetSpans(t *testing.T) {
+	tests := []struct {
+		name       string
+		snippet    string
+		highlights []types.TextRange
+		want       []snippetSpan
+	}{
+		{"no highlights", "red mug", nil, []snippetSpan{{text: "red mug"}}},
+		{
+			"middle and end",
+			"a red mug",
+			[]types.TextRange{{Start: 2, End: 5}, {Start: 6, End: 9}},
+			[]snippetSpan{{text: "a "}, {text: "red", match: true}, {text: " "}, {text: "mug", match: true}},
+		},
+		{
+			"whole snippet",
+			"mug",
+			[]types.TextRange{{Start: 0, End: 3}},
+			[]snippetSpan{{text: "mug", match: true}},
+		},
+		{
+			"out of range and overlapping ranges are ignored",
+			"red mug",
+			[]types.TextRange{{Start: 0, End: 3}, {Start: 1, End: 2}, {Start: 4, End: 20}},
+			[]snippetSpan{{text: "red", match: true}, {text: " mug"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippetSpans(tt.snippet, tt.highlights); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snippetSpans() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplySearchResults_DropsSupersededAnswers(t *testing.T) {
+	ga := newTestGioApp()
+	ga.searchSeq = 2
+	ga.searchLoading = true
+
+	// The answer to an earlier search, such as its cancellation error
+	ga.applySearchResults(1, nil, errors.New("context canceled"))
+	if ga.searchErr != "" || !ga.searchLoading {
+		t.Fatalf("stale answer applied: err=%q loading=%v", ga.searchErr, ga.searchLoading)
+	}
+
+	results := &types.SearchResults{Query: "mug", Matches: []types.SearchMatch{{CollectionID: "col-1"}}}
+	ga.applySearchResults(2, results, nil)
+	if ga.searchLoading || ga.searchResults != results {
+		t.Fatalf("latest answer not applied: loading=%v results=%v", ga.searchLoading, ga.searchResults)
+	}
+}
+
+func TestScheduleSearch_EmptyQueryClearsResults(t *testing.T) {
+	ga := newTestGioApp()
+	canceled := false
+	ga.searchCancel = func() { canceled = true }
+	ga.searchResults = &types.SearchResults{Query: "mug"}
+	ga.searchLoading = true
+
+	ga.scheduleSearch("   ")
+	if !canceled {
+		t.Error("in-flight search was not cancelled")
+	}
+	if ga.searchResults != nil || ga.searchLoading || ga.searchTimer != nil {
+		t.Errorf("results=%v loading=%v timer=%v, want all cleared", ga.searchResults, ga.searchLoading, ga.searchTimer)
+	}
+	if ga.searchInput != "   " || ga.searchSeq != 1 {
+		t.Errorf("input=%q seq=%d", ga.searchInput, ga.searchSeq)
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+// The UI goroutine owns the app's state: frames, input handlers and the
+// functions queued with do all run there, one at a time, so they never need
+// locks. Background work never touches GioApp fields directly. It gets what
+// it needs (the account ID, the selected collection) copied on the UI
+// goroutine before it starts, and hands its results back through do, which
+// also wakes the window so the next frame draws them.
+//
+// A result can outlive the session it was fetched for: a slow request may
+// finish after the user signed out or switched profile. Fetches of the
+// signed-in user's data note ga.session when they start and apply their
+// results with doInSession, which drops them once the session has changed.
+
+// doInSession schedules fn like do, unless the session has ended by the time
+// it would run: the user signed out, the session expired or another profile
+// was switched to since session was read from ga.session.
+func (ga *GioApp) doInSession(session uint64, fn func()) {
+	ga.do(func() {
+		if ga.session != session {
+			ga.logger.Debug("Dropping a result from an ended session")
+			return
+		}
+		fn()
+	})
+}
+
+// endSession makes results still on their way for the current session be
+// dropped; see doInSession.
+func (ga *GioApp) endSession() {
+	ga.session++
+}
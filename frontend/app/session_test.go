@@ -0,0 +1,34 @@
+package app
+
+import (
+	"testing"
+
+	"gioui.org/app"
+)
+
+func TestDoInSessionDropsResultsFromEndedSession(t *testing.T) {
+	ga := newTestGioApp()
+	ga.ops = make(chan func(), 10)
+	ga.window = new(app.Window)
+
+	stale := ga.session
+	ga.doInSession(stale, func() { ga.groups = []Group{{ID: "g1"}} })
+	ga.drainOps()
+	if len(ga.groups) != 1 {
+		t.Fatalf("groups = %v, want the result applied in its own session", ga.groups)
+	}
+
+	// A profile switch ends the session while a fetch is still running
+	ga.endSession()
+	ga.groups = nil
+	ga.doInSession(stale, func() { ga.groups = []Group{{ID: "g1"}} })
+	ga.doInSession(ga.session, func() { ga.collections = []Collection{{ID: "c1"}} })
+	ga.drainOps()
+
+	if ga.groups != nil {
+		t.Errorf("groups = %v, want the ended session's result dropped", ga.groups)
+	}
+	if len(ga.collections) != 1 {
+		t.Errorf("collections = %v, want the current session's result applied", ga.collections)
+	}
+}
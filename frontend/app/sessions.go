@@ -0,0 +1,270 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// sessionLastSeen describes when a session was last used, rounded down to
+// minutes, hours or days
+func sessionLastSeen(lastSeen, now time.Time) string {
+	ago := now.Sub(lastSeen)
+	switch {
+	case ago < 2*time.Minute:
+		return "Active now"
+	case ago < time.Hour:
+		return fmt.Sprintf("Active %dm ago", int(ago.Minutes()))
+	case ago < 48*time.Hour:
+		return fmt.Sprintf("Active %dh ago", int(ago.Hours()))
+	default:
+		return fmt.Sprintf("Active %dd ago", int(ago.Hours()/24))
+	}
+}
+
+// sessionDetail is the line under a session's device name, e.g.
+// "203.0.113.7 · Active 5m ago · Trusted"
+func sessionDetail(s types.Session, now time.Time) string {
+	var parts []string
+	if s.IPAddress != "" {
+		parts = append(parts, s.IPAddress)
+	}
+	parts = append(parts, sessionLastSeen(s.LastSeenAt, now))
+	if s.Trusted {
+		parts = append(parts, "Trusted")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// resetSessions drops the loaded device list when the session ends
+func (ga *GioApp) resetSessions() {
+	ga.sessions = nil
+	ga.sessionsLoaded = false
+	ga.sessionsStatus = ""
+	clear(ga.widgetState.sessionTrustButtons)
+	clear(ga.widgetState.sessionRevokeButtons)
+}
+
+// ensureSessionsLoaded fetches the signed-in devices unless they are already
+// loaded or on their way
+func (ga *GioApp) ensureSessionsLoaded() {
+	if ga.currentUser == nil || ga.sessionsLoaded || ga.sessionsLoading {
+		return
+	}
+	ga.sessionsLoading = true
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		list, err := ga.accountsClient.Sessions(accountID)
+
+		ga.doInSession(session, func() {
+			ga.sessionsLoading = false
+			ga.sessionsLoaded = true
+			if err != nil {
+				ga.logger.Error("Failed to load sessions", "error", err)
+				ga.sessionsStatus = "Could not load your devices: " + err.Error()
+				return
+			}
+			ga.sessions = list.Sessions
+		})
+	})
+}
+
+// updateSessions runs a session change in the background, then reloads the
+// list and shows the status the change reports
+func (ga *GioApp) updateSessions(action string, fn func(accountID string) (string, error)) {
+	if ga.currentUser == nil || ga.sessionsBusy {
+		return
+	}
+	ga.sessionsBusy = true
+	ga.sessionsStatus = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		status, err := fn(accountID)
+
+		ga.doInSession(session, func() {
+			ga.sessionsBusy = false
+			if err != nil {
+				ga.logger.Error("Failed to "+action, "error", err)
+				ga.sessionsStatus = "Could not " + action + ": " + err.Error()
+				return
+			}
+			ga.sessionsStatus = status
+			ga.sessionsLoaded = false
+		})
+	})
+}
+
+// revokeSession signs out one other device
+func (ga *GioApp) revokeSession(s types.Session) {
+	ga.updateSessions("sign out "+s.Device, func(accountID string) (string, error) {
+		if _, err := ga.accountsClient.RevokeSession(accountID, s.ID); err != nil {
+			return "", err
+		}
+		return "Signed out " + s.Device, nil
+	})
+}
+
+// revokeOtherSessions signs out every untrusted device but this one
+func (ga *GioApp) revokeOtherSessions() {
+	ga.updateSessions("sign out other devices", func(accountID string) (string, error) {
+		result, err := ga.accountsClient.RevokeOtherSessions(accountID)
+		if err != nil {
+			return "", err
+		}
+		if result.Revoked == 1 {
+			return "Signed out 1 device", nil
+		}
+		return fmt.Sprintf("Signed out %d devices", result.Revoked), nil
+	})
+}
+
+// setSessionTrusted marks a device trusted or forgets it
+func (ga *GioApp) setSessionTrusted(s types.Session, trusted bool) {
+	ga.updateSessions("update "+s.Device, func(accountID string) (string, error) {
+		if _, err := ga.accountsClient.SetSessionTrusted(accountID, s.ID, trusted); err != nil {
+			return "", err
+		}
+		if trusted {
+			return s.Device + " stays signed in when you sign out other devices", nil
+		}
+		return "", nil
+	})
+}
+
+// sessionButton returns the clickable for a session's row, creating it on
+// first use
+func sessionButton(buttons map[string]*widget.Clickable, id string) *widget.Clickable {
+	btn := buttons[id]
+	if btn == nil {
+		btn = &widget.Clickable{}
+		buttons[id] = btn
+	}
+	return btn
+}
+
+// renderSessionsSection renders the devices the user is signed in on, with
+// buttons to trust or sign out each other device and to sign out all of them
+func (ga *GioApp) renderSessionsSection(gtx layout.Context) layout.Dimensions {
+	for _, s := range ga.sessions {
+		if sessionButton(ga.widgetState.sessionTrustButtons, s.ID).Clicked(gtx) {
+			ga.setSessionTrusted(s, !s.Trusted)
+		}
+		if sessionButton(ga.widgetState.sessionRevokeButtons, s.ID).Clicked(gtx) {
+			ga.revokeSession(s)
+		}
+	}
+	if ga.widgetState.revokeOtherSessionsButton.Clicked(gtx) {
+		ga.revokeOtherSessions()
+	}
+	ga.ensureSessionsLoaded()
+
+	now := time.Now()
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, "Signed-in devices")
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				})
+			}),
+		}
+		if ga.sessionsLoading && len(ga.sessions) == 0 {
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, "Loading...")
+				label.Color = theme.ColorTextSecondary
+				return label.Layout(gtx)
+			}))
+		}
+		for _, s := range ga.sessions {
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return ga.renderSessionRow(gtx, s, now)
+				})
+			}))
+		}
+		children = append(children,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if len(ga.sessions) < 2 {
+					return layout.Dimensions{}
+				}
+				label := "Sign out other devices"
+				if ga.sessionsBusy {
+					label = "Signing out..."
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing3)}.Layout(gtx,
+					widgets.DangerButton(ga.theme.Theme, &ga.widgetState.revokeOtherSessionsButton, label))
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if ga.sessionsStatus == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					status := material.Body2(ga.theme.Theme, ga.sessionsStatus)
+					status.Color = theme.ColorTextSecondary
+					return status.Layout(gtx)
+				})
+			}),
+		)
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// renderSessionRow renders one device: its name and details, the Trust
+// button and, for devices other than this one, Sign Out
+func (ga *GioApp) renderSessionRow(gtx layout.Context, s types.Session, now time.Time) layout.Dimensions {
+	name := s.Device
+	if s.Current {
+		name += " (this device)"
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					label := material.Body2(ga.theme.Theme, name)
+					label.MaxLines = 1
+					if s.Current {
+						label.Font.Weight = font.Bold
+					}
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					label := material.Caption(ga.theme.Theme, sessionDetail(s, now))
+					label.Color = theme.ColorTextSecondary
+					label.MaxLines = 1
+					return label.Layout(gtx)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			trust := "Trust"
+			if s.Trusted {
+				trust = "Untrust"
+			}
+			return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx,
+				widgets.CancelButton(ga.theme.Theme, sessionButton(ga.widgetState.sessionTrustButtons, s.ID), trust))
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if s.Current {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx,
+				widgets.CancelButton(ga.theme.Theme, sessionButton(ga.widgetState.sessionRevokeButtons, s.ID), "Sign Out"))
+		}),
+	)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestSessionLastSeen(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{30 * time.Second, "Active now"},
+		{5 * time.Minute, "Active 5m ago"},
+		{3*time.Hour + 20*time.Minute, "Active 3h ago"},
+		{47 * time.Hour, "Active 47h ago"},
+		{72 * time.Hour, "Active 3d ago"},
+	}
+	for _, tt := range tests {
+		if got := sessionLastSeen(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("sessionLastSeen(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestSessionDetail(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	s := types.Session{IPAddress: "203.0.113.7", Trusted: true, LastSeenAt: now.Add(-5 * time.Minute)}
+	if got, want := sessionDetail(s, now), "203.0.113.7 · Active 5m ago · Trusted"; got != want {
+		t.Errorf("sessionDetail = %q, want %q", got, want)
+	}
+
+	s = types.Session{LastSeenAt: now}
+	if got, want := sessionDetail(s, now), "Active now"; got != want {
+		t.Errorf("sessionDetail without IP = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// suggestedShelfLife is the template offered in the collection dialog for a
+// new pantry. Food matching none of the categories keeps a month.
+const suggestedShelfLife = "dairy: 7, meat: 3, produce: 7, bread: 5, frozen: 90, pantry: 365, *: 30"
+
+// shelfLifeFallback stands in for the rule with an empty category, which the
+// API applies to food no other rule matches
+const shelfLifeFallback = "*"
+
+// formatShelfLife renders shelf life rules as "dairy: 7, frozen: 90" for the
+// collection dialog's editor
+func formatShelfLife(rules []ShelfLife) string {
+	parts := make([]string, len(rules))
+	for i, r := range rules {
+		category := r.Category
+		if category == "" {
+			category = shelfLifeFallback
+		}
+		parts[i] = fmt.Sprintf("%s: %d", category, r.Days)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseShelfLife reads the collection dialog's "category: days" list. Ranges
+// and duplicates are left to the API to reject.
+func parseShelfLife(text string) ([]types.ShelfLifeRequest, error) {
+	rules := []types.ShelfLifeRequest{}
+	for entry := range strings.SplitSeq(text, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		category, daysText, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q is not in the form category: days", entry)
+		}
+		days, err := strconv.Atoi(strings.TrimSpace(daysText))
+		if err != nil {
+			return nil, fmt.Errorf("%q: days must be a whole number", entry)
+		}
+		category = strings.TrimSpace(category)
+		if category == shelfLifeFallback {
+			category = ""
+		}
+		rules = append(rules, types.ShelfLifeRequest{Category: category, Days: days})
+	}
+	return rules, nil
+}
+
+// shelfLifeFromDialog parses the collection dialog's shelf life editor. It
+// returns nil when the collection is not a food collection, leaving any
+// existing rules alone.
+func (ga *GioApp) shelfLifeFromDialog() ([]types.ShelfLifeRequest, error) {
+	if ga.selectedObjectType != ObjectTypeFood {
+		return nil, nil
+	}
+	return parseShelfLife(ga.widgetState.collectionShelfLifeEditor.Text())
+}
+
+// saveShelfLife sends the rules typed into the collection dialog when they
+// differ from what the collection has. It runs off the UI goroutine after the
+// collection itself is saved.
+func (ga *GioApp) saveShelfLife(userID string, collection Collection, rules []types.ShelfLifeRequest) {
+	if rules == nil {
+		return
+	}
+	current, _ := parseShelfLife(formatShelfLife(collection.ShelfLife))
+	if slices.Equal(current, rules) {
+		return
+	}
+	updated, err := ga.collectionsClient.UpdateShelfLife(userID, collection.ID, types.UpdateShelfLifeRequest{ShelfLife: rules})
+	if err != nil {
+		ga.logger.Error("Failed to update shelf life defaults", "collection_id", collection.ID, "error", err)
+		ga.do(func() { ga.showAPIErrorDialog("Failed to save shelf life defaults: " + err.Error()) })
+		return
+	}
+	ga.do(func() { ga.setCollection(*updated) })
+}
+
+// renderShelfLifeField renders the default shelf life editor for food
+// collections, with a button that fills in the suggested template
+func (ga *GioApp) renderShelfLifeField(gtx layout.Context) layout.Dimensions {
+	if ga.selectedObjectType != ObjectTypeFood {
+		return layout.Dimensions{}
+	}
+	if ga.widgetState.collectionShelfLifeSuggest.Clicked(gtx) {
+		ga.widgetState.collectionShelfLifeEditor.SetText(suggestedShelfLife)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFormField(gtx, "Default Shelf Life (days)", &ga.widgetState.collectionShelfLifeEditor, "e.g., dairy: 7, frozen: 90, *: 30")
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.collectionShelfLifeSuggest, "Use Suggested")),
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+							hint := material.Caption(ga.theme.Theme, "Food added without an expiry date gets one from its category or tags; * covers the rest")
+							hint.Color = theme.ColorTextSecondary
+							return hint.Layout(gtx)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestParseShelfLife(t *testing.T) {
+	got, err := parseShelfLife(" Dairy: 7, frozen:90,, *: 30 ")
+	if err != nil {
+		t.Fatalf("parseShelfLife: %v", err)
+	}
+	want := []types.ShelfLifeRequest{
+		{Category: "Dairy", Days: 7},
+		{Category: "frozen", Days: 90},
+		{Category: "", Days: 30},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseShelfLife = %+v, want %+v", got, want)
+	}
+
+	if got, err := parseShelfLife(""); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("empty text = %+v, %v; want an empty, non-nil list to clear the rules", got, err)
+	}
+
+	for _, bad := range []string{"dairy 7", "dairy: a week"} {
+		if _, err := parseShelfLife(bad); err == nil {
+			t.Errorf("parseShelfLife(%q) accepted bad input", bad)
+		}
+	}
+}
+
+func TestFormatShelfLifeRoundTrip(t *testing.T) {
+	rules := []ShelfLife{{Category: "dairy", Days: 7}, {Category: "", Days: 30}}
+	text := formatShelfLife(rules)
+	if text != "dairy: 7, *: 30" {
+		t.Errorf("formatShelfLife = %q", text)
+	}
+	parsed, err := parseShelfLife(text)
+	if err != nil || len(parsed) != 2 || parsed[1].Category != "" || parsed[1].Days != 30 {
+		t.Errorf("round trip = %+v, %v", parsed, err)
+	}
+
+	if _, err := parseShelfLife(suggestedShelfLife); err != nil {
+		t.Errorf("suggested template does not parse: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"slices"
+
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// registerShortcuts binds the global keyboard shortcuts. Plain-key shortcuts
+// only fire while no text field has focus (see widgets.Shortcuts).
+func (ga *GioApp) registerShortcuts() {
+	s := ga.shortcuts
+	s.OnPalette = ga.commandPalette.Toggle
+
+	s.Bind("/", "Focus search", ga.focusSearch)
+	s.Bind("n", "Create new item", ga.createInCurrentView)
+	s.Bind("g h", "Go to dashboard", func() { ga.navigateTo(ViewDashboardGio) })
+	s.Bind("g c", "Go to collections", func() { ga.navigateTo(ViewCollectionsGio) })
+	s.Bind("g g", "Go to groups", func() { ga.navigateTo(ViewGroupsGio) })
+	s.Bind("g p", "Go to profile", func() { ga.navigateTo(ViewProfileGio) })
+	s.Bind("g m", "Go to meal plan", func() {
+		if ga.featureEnabled(featureMealPlanner) {
+			ga.navigateTo(ViewMealPlanGio)
+		}
+	})
+	s.Bind("g v", "Go to valuation", func() { ga.navigateTo(ViewValuationGio) })
+	s.Bind("g i", "Go to inbox", func() { ga.openInbox() })
+}
+
+// shortcutsEnabled reports whether global shortcuts and the command palette
+// are available; they are disabled until the user has signed in.
+func (ga *GioApp) shortcutsEnabled() bool {
+	return ga.isSignedIn && ga.currentView != ViewLoginGio && ga.currentView != ViewCallbackGio
+}
+
+// applyPendingFocus moves keyboard focus to the editor requested by a shortcut
+// or palette command during this frame.
+func (ga *GioApp) applyPendingFocus(gtx layout.Context) {
+	if ga.pendingFocus != nil {
+		gtx.Execute(key.FocusCmd{Tag: ga.pendingFocus})
+		ga.pendingFocus = nil
+	}
+}
+
+// paletteCommands builds the command palette entries for the current view
+func (ga *GioApp) paletteCommands() []widgets.Command {
+	cmds := []widgets.Command{
+		{Title: "Go to Dashboard", Shortcut: "g h", Action: func() { ga.navigateTo(ViewDashboardGio) }},
+		{Title: "Go to Collections", Shortcut: "g c", Action: func() { ga.navigateTo(ViewCollectionsGio) }},
+		{Title: "Go to Groups", Shortcut: "g g", Action: func() { ga.navigateTo(ViewGroupsGio) }},
+		{Title: "Go to Profile", Shortcut: "g p", Action: func() { ga.navigateTo(ViewProfileGio) }},
+		{Title: "Go to Meal Plan", Shortcut: "g m", Action: func() { ga.navigateTo(ViewMealPlanGio) }},
+		{Title: "Go to Valuation", Shortcut: "g v", Action: func() { ga.navigateTo(ViewValuationGio) }},
+		{Title: "Go to Inbox", Shortcut: "g i", Action: ga.openInbox},
+		{Title: "Go to Automations", Action: ga.openAutomations},
+	}
+	if !ga.featureEnabled(featureMealPlanner) {
+		cmds = slices.DeleteFunc(cmds, func(cmd widgets.Command) bool { return cmd.Shortcut == "g m" })
+	}
+
+	switch ga.currentView {
+	case ViewGroupsGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Create Group", Shortcut: "n", Action: ga.openCreateGroupDialog},
+			widgets.Command{Title: "Search Groups", Shortcut: "/", Action: ga.focusSearch},
+		)
+	case ViewCollectionsGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Create Collection", Shortcut: "n", Action: ga.openCreateCollectionDialog},
+			widgets.Command{Title: "Search Collections", Shortcut: "/", Action: ga.focusSearch},
+		)
+	case ViewCollectionDetailGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Create Object", Shortcut: "n", Action: ga.openCreateObjectDialog},
+			widgets.Command{Title: "Search Objects", Shortcut: "/", Action: ga.focusSearch},
+			widgets.Command{Title: "Manage Containers", Action: func() {
+				ga.currentView = ViewContainersGio
+				ga.selectedContainer = nil
+			}},
+		)
+	case ViewContainersGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Search Containers", Shortcut: "/", Action: ga.focusSearch},
+		)
+	case ViewMealPlanGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Plan Meal", Shortcut: "n", Action: ga.openCreateMealPlanDialogToday},
+			widgets.Command{Title: "Previous Week", Action: func() { ga.shiftMealWeek(-1) }},
+			widgets.Command{Title: "Next Week", Action: func() { ga.shiftMealWeek(1) }},
+		)
+	case ViewValuationGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Export Valuation CSV", Action: ga.exportValuationCSV},
+		)
+	case ViewInboxGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "Refresh Inbox", Action: func() { ga.inboxLoaded = false }},
+		)
+	case ViewAutomationsGio:
+		cmds = append(cmds,
+			widgets.Command{Title: "New Rule", Shortcut: "n", Action: ga.openCreateAutomationDialog},
+		)
+	}
+
+	if ga.selectedCollection != nil && ga.currentView != ViewCollectionDetailGio {
+		cmds = append(cmds, widgets.Command{
+			Title:  "Open " + ga.selectedCollection.Name,
+			Action: func() { ga.navigateTo(ViewCollectionDetailGio) },
+		})
+	}
+
+	cmds = append(cmds, widgets.Command{Title: "Sign Out", Action: ga.handleLogout})
+	return cmds
+}
+
+// navigateTo switches to the given view unless a modal dialog is open
+func (ga *GioApp) navigateTo(view ViewID) {
+	if ga.dialogOpen() {
+		return
+	}
+	ga.currentView = view
+}
+
+// focusSearch focuses the search field of the current view, if it has one
+func (ga *GioApp) focusSearch() {
+	if ga.dialogOpen() {
+		return
+	}
+	var field *widget.Editor
+	switch ga.currentView {
+	case ViewGroupsGio:
+		field = &ga.widgetState.groupsSearchField
+	case ViewCollectionsGio:
+		field = &ga.widgetState.collectionsSearchField
+	case ViewCollectionDetailGio:
+		field = &ga.widgetState.objectsSearchField
+	case ViewContainersGio:
+		field = &ga.widgetState.containersSearchField
+	case ViewSearchGio:
+		field = &ga.widgetState.searchField
+	}
+	if field != nil {
+		ga.pendingFocus = field
+	}
+}
+
+// createInCurrentView opens the create dialog appropriate for the current view
+func (ga *GioApp) createInCurrentView() {
+	if ga.dialogOpen() {
+		return
+	}
+	switch ga.currentView {
+	case ViewGroupsGio:
+		ga.openCreateGroupDialog()
+	case ViewCollectionsGio:
+		ga.openCreateCollectionDialog()
+	case ViewCollectionDetailGio:
+		ga.openCreateObjectDialog()
+	case ViewMealPlanGio:
+		ga.openCreateMealPlanDialogToday()
+	case ViewAutomationsGio:
+		ga.openCreateAutomationDialog()
+	}
+}
+
+// dialogOpen reports whether any modal dialog is currently shown
+func (ga *GioApp) dialogOpen() bool {
+	return ga.showAPIError ||
+		ga.showGroupDialog || ga.showDeleteConfirm ||
+		ga.showCollectionDialog || ga.showDeleteCollection || ga.showDeleteCollectionError ||
+		ga.showContainerDialog || ga.showDeleteContainer ||
+		ga.showObjectDialog || ga.showDeleteObject ||
+		ga.showRetagDialog || ga.showBatchMoveDialog || ga.showBatchDeleteDialog ||
+		ga.showMembersDialog || ga.showJoinGroupDialog || ga.showSchemaDialog ||
+		ga.showImportPreview || ga.showImportCreateDialog ||
+		ga.showMealPlanDialog || ga.showAutomationDialog || ga.showWebhooksDialog
+}
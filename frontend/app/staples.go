@@ -0,0 +1,270 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// repeatChoice is a schedule an object can be put back on the shopping list
+// on; the zero value is no schedule
+type repeatChoice struct {
+	every  int
+	period string
+}
+
+// repeatChoices are the schedules offered in the object dialog
+var repeatChoices = []repeatChoice{
+	{},
+	{1, "week"},
+	{2, "week"},
+	{1, "month"},
+}
+
+// repeatChoiceOf is the schedule of a rule, the zero choice for none
+func repeatChoiceOf(rule *types.RecurrenceRule) repeatChoice {
+	if rule == nil {
+		return repeatChoice{}
+	}
+	return repeatChoice{rule.Every, rule.Period}
+}
+
+var repeatPeriodLabels = map[string]string{
+	"day":   "Daily",
+	"week":  "Weekly",
+	"month": "Monthly",
+}
+
+// label names the schedule, e.g. "Weekly" or "Every 2 weeks"
+func (c repeatChoice) label() string {
+	switch {
+	case c.every == 0:
+		return "Off"
+	case c.every == 1:
+		return repeatPeriodLabels[c.period]
+	}
+	return fmt.Sprintf("Every %d %ss", c.every, c.period)
+}
+
+// loadObjectRecurrence fetches the schedule of the object being edited
+func (ga *GioApp) loadObjectRecurrence(objectID string) {
+	if ga.currentUser == nil {
+		return
+	}
+	ga.objectRecurrence = nil
+	ga.objectRepeat = repeatChoice{}
+	ga.objectRecurrenceLoading = true
+	accountID := ga.currentUser.ID
+
+	ga.goSafe(func() {
+		rule, err := ga.recurrencesClient.ForObject(accountID, objectID)
+
+		ga.do(func() {
+			if ga.selectedObject == nil || ga.selectedObject.ID != objectID {
+				return
+			}
+			ga.objectRecurrenceLoading = false
+			if err != nil {
+				ga.logger.Error("Failed to load object recurrence", "object_id", objectID, "error", err)
+				return
+			}
+			ga.objectRecurrence = rule
+			ga.objectRepeat = repeatChoiceOf(rule)
+		})
+	})
+}
+
+// saveObjectRecurrence creates, changes or removes the edited object's
+// schedule to match the choice made in the dialog
+func (ga *GioApp) saveObjectRecurrence(objectID string) {
+	if ga.currentUser == nil || ga.objectRecurrenceLoading {
+		return
+	}
+	existing := ga.objectRecurrence
+	choice := ga.objectRepeat
+	if choice == repeatChoiceOf(existing) {
+		return
+	}
+	accountID := ga.currentUser.ID
+	every, period := choice.every, choice.period
+
+	ga.goSafe(func() {
+		var err error
+		switch {
+		case existing == nil:
+			_, err = ga.recurrencesClient.Create(accountID, types.CreateRecurrenceRuleRequest{ObjectID: objectID, Every: every, Period: period})
+		case every == 0:
+			err = ga.recurrencesClient.Delete(accountID, existing.ID)
+		default:
+			_, err = ga.recurrencesClient.Update(accountID, existing.ID, types.UpdateRecurrenceRuleRequest{Every: &every, Period: &period})
+		}
+
+		ga.do(func() {
+			if err != nil {
+				ga.logger.Error("Failed to save object recurrence", "object_id", objectID, "error", err)
+				ga.showAPIErrorDialog("Failed to save repeat schedule: " + err.Error())
+				return
+			}
+			if every == 0 {
+				ga.dueStaples = slices.DeleteFunc(ga.dueStaples, func(r types.RecurrenceRule) bool { return r.ObjectID == objectID })
+			}
+		})
+	})
+}
+
+// renderObjectRepeatSelector renders the schedule chips in the object
+// dialog. A schedule set through the API that is not one of the choices gets
+// a chip of its own.
+func (ga *GioApp) renderObjectRepeatSelector(gtx layout.Context) layout.Dimensions {
+	if ga.objectRecurrenceLoading {
+		return layout.Dimensions{}
+	}
+	choices := repeatChoices
+	if current := repeatChoiceOf(ga.objectRecurrence); !slices.Contains(choices, current) {
+		choices = append(slices.Clone(choices), current)
+	}
+	chips := make([]layout.Widget, len(choices))
+	for i, c := range choices {
+		btn := ga.widgetState.objectRepeatButtons[c]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.objectRepeatButtons[c] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.objectRepeat = c
+		}
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, c.label(), c == ga.objectRepeat)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Repeat", chips)
+}
+
+// resetStaples drops the loaded shopping list when the session ends
+func (ga *GioApp) resetStaples() {
+	ga.dueStaples = nil
+	ga.dueStaplesLoaded = false
+	ga.dueStaplesLoading = false
+	ga.objectRecurrence = nil
+	ga.objectRepeat = repeatChoice{}
+	ga.objectRecurrenceLoading = false
+}
+
+// fetchDueStaples loads the staples that are back on the shopping list
+func (ga *GioApp) fetchDueStaples() {
+	if ga.currentUser == nil || ga.dueStaplesLoading {
+		return
+	}
+	accountID := ga.currentUser.ID
+	session := ga.session
+	ga.dueStaplesLoading = true
+
+	ga.goSafe(func() {
+		list, err := ga.recurrencesClient.List(accountID, true)
+		ga.doInSession(session, func() {
+			ga.dueStaplesLoading = false
+			ga.dueStaplesLoaded = true
+			if err != nil {
+				ga.logger.Error("Failed to load due staples", "error", err)
+				return
+			}
+			ga.dueStaples = list.Rules
+		})
+	})
+}
+
+// markStapleBought takes a staple off the shopping list until it next
+// comes due
+func (ga *GioApp) markStapleBought(rule types.RecurrenceRule) {
+	if ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	index := slices.IndexFunc(ga.dueStaples, func(r types.RecurrenceRule) bool { return r.ID == rule.ID })
+	if index < 0 {
+		return
+	}
+
+	ga.optimisticUpdate("mark staple bought", func() {
+		ga.dueStaples = slices.Delete(ga.dueStaples, index, index+1)
+	}, func() {
+		ga.dueStaples = reinsert(ga.dueStaples, index, rule)
+	}, func() (func(), error) {
+		_, err := ga.recurrencesClient.Update(accountID, rule.ID, types.UpdateRecurrenceRuleRequest{Bought: true})
+		return nil, err
+	})
+}
+
+// renderDueStaples renders the dashboard's list of staples to buy, nothing
+// when none are due
+func (ga *GioApp) renderDueStaples(gtx layout.Context) layout.Dimensions {
+	if !ga.dueStaplesLoaded {
+		ga.fetchDueStaples()
+	}
+	if n := len(ga.dueStaples); len(ga.widgetState.stapleBoughtButtons) < n {
+		ga.widgetState.stapleBoughtButtons = make([]widget.Clickable, n)
+	}
+	for i, rule := range ga.dueStaples {
+		if ga.widgetState.stapleBoughtButtons[i].Clicked(gtx) {
+			ga.markStapleBought(rule)
+			break
+		}
+	}
+	if len(ga.dueStaples) == 0 {
+		return layout.Dimensions{}
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return material.H6(ga.theme.Theme, "Staples to Buy").Layout(gtx)
+			})
+		}),
+	}
+	for i, rule := range ga.dueStaples {
+		detail := repeatChoiceOf(&rule).label()
+		if rule.Quantity != nil {
+			detail = "buy " + strconv.FormatFloat(*rule.Quantity, 'f', -1, 64) + " · " + detail
+		}
+		if rule.DueSince != nil {
+			detail += " · due " + rule.DueSince.Local().Format("Jan 2")
+		}
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								label := material.Body1(ga.theme.Theme, rule.Name)
+								label.Font.Weight = font.Bold
+								return label.Layout(gtx)
+							}),
+							layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+								label := material.Caption(ga.theme.Theme, detail)
+								label.Color = theme.ColorTextSecondary
+								return label.Layout(gtx)
+							}),
+						)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return widgets.AccentButton(ga.theme.Theme, &ga.widgetState.stapleBoughtButtons[i], "Bought")(gtx)
+					}),
+				)
+			})
+		}))
+	}
+
+	return layout.Inset{Top: unit.Dp(theme.Spacing6)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
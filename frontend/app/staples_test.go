@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestRepeatChoiceLabel(t *testing.T) {
+	tests := []struct {
+		rule *types.RecurrenceRule
+		want string
+	}{
+		{nil, "Off"},
+		{&types.RecurrenceRule{Every: 1, Period: "day"}, "Daily"},
+		{&types.RecurrenceRule{Every: 1, Period: "week"}, "Weekly"},
+		{&types.RecurrenceRule{Every: 2, Period: "week"}, "Every 2 weeks"},
+		{&types.RecurrenceRule{Every: 1, Period: "month"}, "Monthly"},
+		{&types.RecurrenceRule{Every: 10, Period: "day"}, "Every 10 days"},
+	}
+	for _, tt := range tests {
+		if got := repeatChoiceOf(tt.rule).label(); got != tt.want {
+			t.Errorf("label() of %+v = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// Temperature zones a container can keep; "" means the container does not say
+const (
+	TemperatureZoneFrozen  = "frozen"
+	TemperatureZoneChilled = "chilled"
+	TemperatureZoneAmbient = "ambient"
+)
+
+var temperatureZones = []string{
+	TemperatureZoneFrozen,
+	TemperatureZoneChilled,
+	TemperatureZoneAmbient,
+}
+
+var temperatureZoneLabels = map[string]string{
+	TemperatureZoneFrozen:  "Frozen",
+	TemperatureZoneChilled: "Chilled",
+	TemperatureZoneAmbient: "Ambient",
+}
+
+// containerBadgeText is the type label on a container card, followed by its
+// temperature zone and humidity when set, e.g. "Cabinet · Chilled · 40%".
+func containerBadgeText(c Container) string {
+	parts := []string{containerTypeLabels[c.Type]}
+	if label, ok := temperatureZoneLabels[c.TemperatureZone]; ok {
+		parts = append(parts, label)
+	}
+	if c.Humidity != nil {
+		parts = append(parts, formatHumidity(c.Humidity)+"%")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatHumidity renders a humidity for the container dialog editor
+func formatHumidity(humidity *float64) string {
+	if humidity == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*humidity, 'f', -1, 64)
+}
+
+// parseHumidity reads the container dialog's humidity editor; blank is unset
+func parseHumidity(s string) (*float64, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	if s == "" {
+		return nil, nil
+	}
+	humidity, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || humidity < 0 || humidity > 100 {
+		return nil, errors.New("humidity must be a percentage between 0 and 100")
+	}
+	return &humidity, nil
+}
+
+// renderTemperatureZoneSelector renders temperature zone chips; "(none)"
+// leaves the container without a zone.
+func (ga *GioApp) renderTemperatureZoneSelector(gtx layout.Context) layout.Dimensions {
+	zones := append([]string{""}, temperatureZones...)
+	chips := make([]layout.Widget, len(zones))
+	for i, zone := range zones {
+		if ga.widgetState.temperatureZoneButtons[zone] == nil {
+			ga.widgetState.temperatureZoneButtons[zone] = &widget.Clickable{}
+		}
+		btn := ga.widgetState.temperatureZoneButtons[zone]
+		if btn.Clicked(gtx) {
+			ga.selectedTemperatureZone = zone
+		}
+		label := temperatureZoneLabels[zone]
+		if zone == "" {
+			label = "(none)"
+		}
+		active := ga.selectedTemperatureZone == zone
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, label, active)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Temperature Zone", chips)
+}
@@ -0,0 +1,38 @@
+package app
+
+import "testing"
+
+func TestContainerBadgeText(t *testing.T) {
+	humidity := 40.0
+	tests := []struct {
+		container Container
+		want      string
+	}{
+		{Container{Type: ContainerTypeShelf}, "Shelf"},
+		{Container{Type: ContainerTypeCabinet, TemperatureZone: TemperatureZoneChilled}, "Cabinet · Chilled"},
+		{Container{Type: ContainerTypeGeneral, TemperatureZone: TemperatureZoneAmbient, Humidity: &humidity}, "General · Ambient · 40%"},
+	}
+	for _, tt := range tests {
+		if got := containerBadgeText(tt.container); got != tt.want {
+			t.Errorf("containerBadgeText(%+v) = %q, want %q", tt.container, got, tt.want)
+		}
+	}
+}
+
+func TestParseHumidity(t *testing.T) {
+	got, err := parseHumidity(" 55.5% ")
+	if err != nil || got == nil || *got != 55.5 {
+		t.Fatalf("parseHumidity(55.5%%) = %v, %v", got, err)
+	}
+	if got, err := parseHumidity("  "); err != nil || got != nil {
+		t.Errorf("blank humidity = %v, %v, want unset", got, err)
+	}
+	for _, s := range []string{"damp", "-1", "101"} {
+		if _, err := parseHumidity(s); err == nil {
+			t.Errorf("parseHumidity(%q) accepted", s)
+		}
+	}
+	if formatHumidity(got) != "55.5" {
+		t.Errorf("formatHumidity = %q, want 55.5", formatHumidity(got))
+	}
+}
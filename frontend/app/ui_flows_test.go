@@ -0,0 +1,110 @@
+//go:build !js || !wasm
+
+package app
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUIFlowLogin(t *testing.T) {
+	h := newUIHarness(t)
+	if h.ga.currentView != ViewLoginGio {
+		t.Fatalf("start view = %s, want login", h.ga.currentView)
+	}
+
+	h.signIn()
+
+	if h.ga.currentUser.ID != stubUserID {
+		t.Errorf("current user = %q, want %q", h.ga.currentUser.ID, stubUserID)
+	}
+	if !h.backend.requested("POST /v1/auth/token") {
+		t.Error("authorization code was not exchanged at /v1/auth/token")
+	}
+	if !h.backend.requested("GET /v1/accounts/" + stubUserID + "/dashboard") {
+		t.Error("groups and collections were not loaded from the dashboard endpoint")
+	}
+	if h.backend.requested("GET /v1/groups") {
+		t.Error("groups were fetched on their own besides the dashboard")
+	}
+}
+
+func TestUIFlowCollectionAndObjects(t *testing.T) {
+	h := newUIHarness(t)
+	h.signIn()
+
+	// Dashboard to the collections list
+	h.click(&h.ga.widgetState.collectionsButton)
+	if h.ga.currentView != ViewCollectionsGio {
+		t.Fatalf("view = %s, want collections", h.ga.currentView)
+	}
+
+	// Create a food collection through the dialog
+	h.click(&h.ga.widgetState.collectionsCreateButton)
+	if !h.ga.showCollectionDialog {
+		t.Fatal("create collection dialog did not open")
+	}
+	h.ga.widgetState.collectionNameEditor.SetText("Pantry")
+	foodButton := h.ga.widgetState.collectionTypeButtons[ObjectTypeFood]
+	if foodButton == nil {
+		t.Fatal("object type buttons were not laid out")
+	}
+	h.waitFor("custom object types", func() bool { return len(h.ga.customObjectTypes) == 1 })
+	h.frame()
+	if h.ga.widgetState.collectionTypeButtons["wine"] == nil {
+		t.Error("custom object type was not offered in the picker")
+	}
+	h.click(foodButton)
+	h.click(&h.ga.widgetState.collectionDialogSubmit)
+	if h.ga.showCollectionDialog {
+		t.Fatal("create collection dialog stayed open")
+	}
+	h.waitFor("created collection", func() bool {
+		return slices.ContainsFunc(h.ga.collections, func(c Collection) bool { return c.Name == "Pantry" })
+	})
+	h.expectNoError()
+	if got := h.ga.collections[0].ObjectType; got != ObjectTypeFood {
+		t.Errorf("collection type = %q, want %q", got, ObjectTypeFood)
+	}
+
+	// Open it
+	h.frame()
+	h.click(&h.collectionItem("Pantry").viewButton)
+	h.waitFor("collection detail", func() bool {
+		return h.ga.currentView == ViewCollectionDetailGio && !h.ga.loadingContainersObjects
+	})
+
+	// Add an object
+	h.click(&h.ga.widgetState.createObjectButton)
+	if !h.ga.showObjectDialog {
+		t.Fatal("create object dialog did not open")
+	}
+	h.ga.widgetState.objectNameEditor.SetText("Oats")
+	h.ga.widgetState.objectQuantityStepper.SetText("2")
+	h.click(&h.ga.widgetState.objectDialogSubmit)
+	h.waitFor("created object", func() bool {
+		return slices.ContainsFunc(h.ga.objects, func(o Object) bool { return o.Name == "Oats" })
+	})
+	h.expectNoError()
+	if !h.backend.requested("POST /v1/accounts/" + stubUserID + "/objects") {
+		t.Error("object was not created on the backend")
+	}
+
+	// Delete it, letting the undo window run out
+	h.frame()
+	h.click(&h.objectItem("Oats").deleteButton)
+	if !h.ga.showDeleteObject {
+		t.Fatal("delete confirmation did not open")
+	}
+	h.click(&h.ga.widgetState.objectDialogSubmit)
+	if slices.ContainsFunc(h.ga.objects, func(o Object) bool { return o.Name == "Oats" }) {
+		t.Fatal("deleted object is still listed")
+	}
+	h.commitPendingDeletes()
+	h.waitFor("object deleted on the backend", func() bool {
+		h.backend.mu.Lock()
+		defer h.backend.mu.Unlock()
+		return len(h.backend.objects) == 0
+	})
+	h.expectNoError()
+}
@@ -0,0 +1,460 @@
+//go:build !js || !wasm
+
+package app
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"image"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gioui.org/io/input"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/config"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+const (
+	stubUserID      = "user-1"
+	stubAccessToken = "stub-access-token"
+	harnessWidthDp  = 1280
+	harnessHeightDp = 800
+	harnessTimeout  = 5 * time.Second
+)
+
+// uiHarness drives a real GioApp headlessly: frames are laid out into an op
+// list, buttons are pressed through widget.Clickable.Click so the views' own
+// click handling runs, and every API call goes to a stubBackend.
+type uiHarness struct {
+	t       *testing.T
+	ga      *GioApp
+	backend *stubBackend
+	router  input.Router
+	ops     op.Ops
+}
+
+// newUIHarness starts a stub backend and an app pointed at it, showing the
+// login screen
+func newUIHarness(t *testing.T) *uiHarness {
+	t.Helper()
+	backend := newStubBackend(t)
+
+	cfg := &config.Config{
+		BackendURL:   backend.server.URL,
+		AuthorizeURL: backend.server.URL + "/authorize",
+		ClientID:     "nishiki-test",
+		RedirectURL:  "http://" + freeLocalAddr(t) + "/auth/callback",
+	}
+	ga := newGioApp(cfg, slog.New(slog.DiscardHandler))
+	ga.authService.browse = fakeBrowser
+
+	h := &uiHarness{t: t, ga: ga, backend: backend}
+	t.Cleanup(func() {
+		for _, pd := range ga.pendingDeletes {
+			pd.timer.Stop()
+		}
+	})
+	h.frame()
+	return h
+}
+
+// frame applies queued state changes and lays out one frame. Unlike
+// GioApp.frame it does not recover, so a panic in a view fails the test.
+func (h *uiHarness) frame() {
+	h.t.Helper()
+	h.ga.drainOps()
+	h.ga.updateSizeClass(harnessWidthDp)
+	h.ops.Reset()
+	gtx := layout.Context{
+		Ops:         &h.ops,
+		Now:         time.Now(),
+		Metric:      unit.Metric{PxPerDp: 1, PxPerSp: 1},
+		Constraints: layout.Exact(image.Pt(harnessWidthDp, harnessHeightDp)),
+		Source:      h.router.Source(),
+	}
+	h.ga.render(gtx)
+	h.router.Frame(&h.ops)
+}
+
+// click presses btn and lays out the frame that handles the press. The
+// button must have been laid out at least once.
+func (h *uiHarness) click(btn *widget.Clickable) {
+	h.t.Helper()
+	btn.Click()
+	h.frame()
+}
+
+// waitFor lays out frames until cond holds, failing the test after
+// harnessTimeout. Background requests land through GioApp.do between frames.
+func (h *uiHarness) waitFor(what string, cond func() bool) {
+	h.t.Helper()
+	deadline := time.Now().Add(harnessTimeout)
+	for {
+		h.frame()
+		if cond() {
+			return
+		}
+		if time.Now().After(deadline) {
+			h.t.Fatalf("timed out waiting for %s (view %s)", what, h.ga.currentView)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// expectNoError fails the test if the app is showing its error dialog,
+// which is also where recovered goroutine panics end up
+func (h *uiHarness) expectNoError() {
+	h.t.Helper()
+	if h.ga.showAPIError {
+		h.t.Fatalf("error dialog shown: %s", h.ga.apiErrorMsg)
+	}
+}
+
+// signIn presses the login button and waits for the dashboard with the
+// user's data loaded
+func (h *uiHarness) signIn() {
+	h.t.Helper()
+	h.click(&h.ga.widgetState.loginButton)
+	h.waitFor("dashboard", func() bool {
+		return h.ga.currentView == ViewDashboardGio && h.ga.currentUser != nil && h.ga.collectionsLoaded
+	})
+	h.expectNoError()
+}
+
+// collectionItem returns the card state of the named collection
+func (h *uiHarness) collectionItem(name string) *CollectionItemState {
+	h.t.Helper()
+	i := slices.IndexFunc(h.ga.collections, func(c Collection) bool { return c.Name == name })
+	if i < 0 || i >= len(h.ga.widgetState.collectionItems) {
+		h.t.Fatalf("collection %q is not on screen", name)
+	}
+	return &h.ga.widgetState.collectionItems[i]
+}
+
+// objectItem returns the card state of the named object
+func (h *uiHarness) objectItem(name string) *ObjectItemState {
+	h.t.Helper()
+	i := slices.IndexFunc(h.ga.objects, func(o Object) bool { return o.Name == name })
+	if i < 0 || i >= len(h.ga.widgetState.objectItems) {
+		h.t.Fatalf("object %q is not on screen", name)
+	}
+	return &h.ga.widgetState.objectItems[i]
+}
+
+// commitPendingDeletes sends the deletes waiting out their undo window, as
+// the window's timer would
+func (h *uiHarness) commitPendingDeletes() {
+	h.t.Helper()
+	for len(h.ga.pendingDeletes) > 0 {
+		h.ga.commitPendingDelete(h.ga.pendingDeletes[0].id)
+	}
+}
+
+// fakeBrowser stands in for the user signing in at the provider: it follows
+// the authorization URL straight back to the app's callback with a code
+func fakeBrowser(authURL string) error {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	callback := q.Get("redirect_uri") + "?" + url.Values{"code": {"stub-code"}, "state": {q.Get("state")}}.Encode()
+	resp, err := http.Get(callback)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// freeLocalAddr reserves a loopback port for the OAuth callback server
+func freeLocalAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserving callback port: %v", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+	return addr
+}
+
+// stubBackend is an in-memory stand-in for the backend API under /v1. It
+// keeps just enough state for the UI flows and fails the test on any request
+// it does not know, so new traffic from a view is noticed.
+type stubBackend struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	collections []types.Collection
+	containers  []types.Container
+	objects     []types.Object
+	requests    []string
+}
+
+func newStubBackend(t *testing.T) *stubBackend {
+	t.Helper()
+	b := &stubBackend{}
+
+	api := http.NewServeMux()
+	api.HandleFunc("POST /auth/token", b.token)
+	api.HandleFunc("GET /auth/me", b.me)
+	api.HandleFunc("GET /groups", b.emptyList)
+	api.HandleFunc("GET /accounts/{account}/preferences", b.preferences)
+	api.HandleFunc("GET /accounts/{account}/collection-folders", b.folders)
+	api.HandleFunc("GET /accounts/{account}/object-types", b.objectTypes)
+	api.HandleFunc("GET /accounts/{account}/comments/unread", b.unreadComments)
+	api.HandleFunc("GET /accounts/{account}/dashboard", b.dashboard)
+	api.HandleFunc("GET /accounts/{account}/collections", b.listCollections)
+	api.HandleFunc("POST /accounts/{account}/collections", b.createCollection)
+	api.HandleFunc("GET /accounts/{account}/collections/{collection}/containers", b.listContainers)
+	api.HandleFunc("GET /accounts/{account}/collections/{collection}/objects", b.listObjects)
+	api.HandleFunc("POST /accounts/{account}/objects", b.createObject)
+	api.HandleFunc("DELETE /accounts/{account}/objects/{object}", b.deleteObject)
+
+	b.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		b.requests = append(b.requests, r.Method+" "+r.URL.Path)
+		b.mu.Unlock()
+
+		path, ok := strings.CutPrefix(r.URL.Path, config.APIBasePath)
+		if !ok {
+			t.Errorf("unversioned API request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		r.URL.Path = path
+		if path != "/auth/token" && r.Header.Get("Authorization") != "Bearer "+stubAccessToken {
+			writeStubJSON(w, http.StatusUnauthorized, types.ErrorResponse{Message: "missing token"})
+			return
+		}
+		if _, pattern := api.Handler(r); pattern == "" {
+			t.Errorf("unexpected API request %s %s", r.Method, r.URL.Path)
+		}
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(b.server.Close)
+	return b
+}
+
+// requested reports whether a request was made, as "METHOD /v1/path"
+func (b *stubBackend) requested(call string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Contains(b.requests, call)
+}
+
+func (b *stubBackend) newID(prefix string) string {
+	b.nextID++
+	return fmt.Sprintf("%s-%d", prefix, b.nextID)
+}
+
+func writeStubJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.MarshalWrite(w, v)
+}
+
+func (b *stubBackend) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "stub-code" {
+		writeStubJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	writeStubJSON(w, http.StatusOK, map[string]any{
+		"access_token":  stubAccessToken,
+		"token_type":    "Bearer",
+		"refresh_token": "stub-refresh-token",
+		"expires_in":    3600,
+	})
+}
+
+func (b *stubBackend) me(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, types.AuthInfoResponse{
+		User:   types.User{ID: stubUserID, Name: "Test User", Email: "test@example.com"},
+		Claims: types.ClaimsInfo{Subject: stubUserID, Email: "test@example.com"},
+	})
+}
+
+func (b *stubBackend) dashboard(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := make([]types.DashboardCollectionStats, len(b.collections))
+	for i, collection := range b.collections {
+		stats[i] = types.DashboardCollectionStats{CollectionID: collection.ID}
+	}
+	writeStubJSON(w, http.StatusOK, types.Dashboard{
+		User: types.AuthInfoResponse{
+			User:   types.User{ID: stubUserID, Name: "Test User", Email: "test@example.com"},
+			Claims: types.ClaimsInfo{Subject: stubUserID, Email: "test@example.com"},
+		},
+		Groups:          []types.Group{},
+		Collections:     append([]types.Collection{}, b.collections...),
+		CollectionStats: stats,
+		ExpiringDays:    7,
+	})
+}
+
+func (b *stubBackend) emptyList(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, []any{})
+}
+
+func (b *stubBackend) preferences(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, types.UserPreferences{})
+}
+
+func (b *stubBackend) folders(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, types.CollectionFolderList{Folders: []types.CollectionFolder{}})
+}
+
+func (b *stubBackend) objectTypes(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, types.ObjectTypeList{ObjectTypes: []types.ObjectType{
+		{Key: ObjectTypeFood, Name: "Food", BuiltIn: true},
+		{ID: "type-wine", Key: "wine", Name: "Wine", Color: "#7f1d1d"},
+	}})
+}
+
+func (b *stubBackend) unreadComments(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, types.UnreadComments{})
+}
+
+func (b *stubBackend) listCollections(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	writeStubJSON(w, http.StatusOK, append([]types.Collection{}, b.collections...))
+}
+
+func (b *stubBackend) createCollection(w http.ResponseWriter, r *http.Request) {
+	var req types.CreateCollectionRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil || req.Name == "" {
+		writeStubJSON(w, http.StatusBadRequest, types.ErrorResponse{Message: "invalid collection"})
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	collection := types.Collection{
+		ID:         b.newID("collection"),
+		UserID:     stubUserID,
+		Name:       req.Name,
+		ObjectType: req.ObjectType,
+		Location:   req.Location,
+		Tags:       req.Tags,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	b.collections = append(b.collections, collection)
+	writeStubJSON(w, http.StatusCreated, collection)
+}
+
+func (b *stubBackend) listContainers(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	containers := []types.Container{}
+	for _, c := range b.containers {
+		if c.CollectionID == r.PathValue("collection") {
+			containers = append(containers, c)
+		}
+	}
+	writeStubJSON(w, http.StatusOK, containers)
+}
+
+func (b *stubBackend) listObjects(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	objects := []types.Object{}
+	if r.URL.Query().Get("archived") != "true" {
+		for _, o := range b.objects {
+			if b.collectionOf(o.ContainerID) == r.PathValue("collection") {
+				objects = append(objects, o)
+			}
+		}
+	}
+	writeStubJSON(w, http.StatusOK, map[string]any{"objects": objects, "total": len(objects)})
+}
+
+// createObject files the object in the given container, or like the backend
+// in the collection's default container
+func (b *stubBackend) createObject(w http.ResponseWriter, r *http.Request) {
+	var req types.CreateObjectRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil || req.Name == "" {
+		writeStubJSON(w, http.StatusBadRequest, types.ErrorResponse{Message: "invalid object"})
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	containerID := req.ContainerID
+	if containerID == "" {
+		containerID = b.defaultContainer(r.URL.Query().Get("collection_id"))
+	}
+	now := time.Now()
+	object := types.Object{
+		ID:          b.newID("object"),
+		ContainerID: containerID,
+		Name:        req.Name,
+		Description: req.Description,
+		ObjectType:  req.ObjectType,
+		Quantity:    req.Quantity,
+		Unit:        req.Unit,
+		Tags:        req.Tags,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	b.objects = append(b.objects, object)
+	writeStubJSON(w, http.StatusCreated, object)
+}
+
+func (b *stubBackend) deleteObject(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := slices.IndexFunc(b.objects, func(o types.Object) bool { return o.ID == r.PathValue("object") })
+	if i < 0 {
+		writeStubJSON(w, http.StatusNotFound, types.ErrorResponse{Message: "object not found"})
+		return
+	}
+	b.objects = slices.Delete(b.objects, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// defaultContainer returns the collection's first container, creating one
+// if it has none. Callers hold b.mu.
+func (b *stubBackend) defaultContainer(collectionID string) string {
+	for _, c := range b.containers {
+		if c.CollectionID == collectionID {
+			return c.ID
+		}
+	}
+	now := time.Now()
+	container := types.Container{
+		ID:           b.newID("container"),
+		CollectionID: collectionID,
+		Name:         "Unsorted",
+		Type:         "general",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	b.containers = append(b.containers, container)
+	return container.ID
+}
+
+// collectionOf returns the collection a container belongs to. Callers hold b.mu.
+func (b *stubBackend) collectionOf(containerID string) string {
+	for _, c := range b.containers {
+		if c.ID == containerID {
+			return c.CollectionID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"gioui.org/layout"
+
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// undoWindow is how long a delete stays reversible before the DELETE request
+// is sent to the backend. Once sent, a delete is final: restoring confirmed
+// deletes and redo need a trash on the backend (see docs/next-steps.md).
+const undoWindow = 10 * time.Second
+
+// pendingDelete is a delete that has been applied to local state but not yet
+// sent to the backend. Undoing it restores the local state and drops the request.
+type pendingDelete struct {
+	id           int
+	message      string
+	collectionID string
+	restore      func()       // re-inserts the removed items into local state
+	send         func() error // sends the DELETE request; called off the UI goroutine
+	failed       func(error)  // reports a failed send; called on the UI goroutine
+	timer        *time.Timer
+}
+
+// scheduleDelete pushes a delete onto the undo stack and sends it once the
+// undo window elapses. The caller must already have removed the items locally.
+func (ga *GioApp) scheduleDelete(message, collectionID string, restore func(), send func() error, failed func(error)) {
+	ga.nextPendingDeleteID++
+	pd := &pendingDelete{
+		id:           ga.nextPendingDeleteID,
+		message:      message,
+		collectionID: collectionID,
+		restore:      restore,
+		send:         send,
+		failed:       failed,
+	}
+	pd.timer = time.AfterFunc(undoWindow, func() {
+		ga.do(func() { ga.commitPendingDelete(pd.id) })
+	})
+	ga.pendingDeletes = append(ga.pendingDeletes, pd)
+}
+
+// commitPendingDelete removes the delete from the undo stack and sends it.
+// A no-op if the delete was undone in the meantime.
+func (ga *GioApp) commitPendingDelete(id int) {
+	for i, pd := range ga.pendingDeletes {
+		if pd.id == id {
+			ga.pendingDeletes = append(ga.pendingDeletes[:i], ga.pendingDeletes[i+1:]...)
+			ga.goSafe(func() {
+				if err := pd.send(); err != nil {
+					ga.do(func() { pd.failed(err) })
+				}
+			})
+			return
+		}
+	}
+}
+
+// undoLastDelete cancels the most recent pending delete and restores its items
+func (ga *GioApp) undoLastDelete() {
+	if len(ga.pendingDeletes) == 0 {
+		return
+	}
+	pd := ga.pendingDeletes[len(ga.pendingDeletes)-1]
+	ga.pendingDeletes = ga.pendingDeletes[:len(ga.pendingDeletes)-1]
+	pd.timer.Stop()
+
+	ga.logger.Info("Undoing delete", "message", pd.message)
+	ga.restoreDeleted(pd.collectionID, pd.restore)
+}
+
+// restoreDeleted re-inserts deleted items if their collection is still the one
+// on screen. Otherwise the next fetch of that collection will pick them up.
+func (ga *GioApp) restoreDeleted(collectionID string, restore func()) {
+	if ga.selectedCollection != nil && ga.selectedCollection.ID == collectionID {
+		restore()
+	}
+}
+
+// flushPendingDeletes sends all pending deletes without waiting out their undo
+// window, then calls done on the UI goroutine. Used when the session ends: the
+// user confirmed these deletes, so they must reach the server while its token
+// is still good. The session's views are gone by the time a send fails, so
+// failures are only logged; the items show again on the next sign-in.
+func (ga *GioApp) flushPendingDeletes(done func()) {
+	pending := ga.pendingDeletes
+	ga.pendingDeletes = nil
+	if len(pending) == 0 {
+		if done != nil {
+			done()
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, pd := range pending {
+		pd.timer.Stop()
+		wg.Go(func() {
+			defer ga.recoverGoroutine()
+			if err := pd.send(); err != nil {
+				ga.logger.Error("Failed to send delete at end of session", "message", pd.message, "error", err)
+			}
+		})
+	}
+	ga.goSafe(func() {
+		wg.Wait()
+		if done != nil {
+			ga.do(done)
+		}
+	})
+}
+
+// renderUndoSnackbar shows the most recent pending delete with an Undo action
+func (ga *GioApp) renderUndoSnackbar(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.undoButton.Clicked(gtx) {
+		ga.undoLastDelete()
+	}
+	if len(ga.pendingDeletes) == 0 {
+		return layout.Dimensions{}
+	}
+	pd := ga.pendingDeletes[len(ga.pendingDeletes)-1]
+	return widgets.Snackbar(pd.message, "Undo", &ga.widgetState.undoButton).Layout(gtx, ga.theme.Theme)
+}
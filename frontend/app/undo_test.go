@@ -0,0 +1,121 @@
+package app
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// noFailure is the failure handler of deletes in tests that expect none
+func noFailure(t *testing.T) func(error) {
+	return func(err error) { t.Errorf("unexpected delete failure: %v", err) }
+}
+
+func TestUndoLastDeleteRestoresObject(t *testing.T) {
+	ga := newTestGioApp()
+	ga.selectedCollection = &Collection{ID: "col-1"}
+	ga.objects = makeObjects(3, "category", []string{"a"})
+
+	removed := ga.objects[1]
+	committed := false
+	ga.removeObject(removed.ID, removed.ContainerID)
+	ga.scheduleDelete("Deleted", "col-1", func() { ga.addObject(removed) }, func() error { committed = true; return nil }, noFailure(t))
+
+	if len(ga.objects) != 2 {
+		t.Fatalf("expected 2 objects after delete, got %d", len(ga.objects))
+	}
+
+	ga.undoLastDelete()
+
+	if len(ga.objects) != 3 {
+		t.Fatalf("expected 3 objects after undo, got %d", len(ga.objects))
+	}
+	if len(ga.pendingDeletes) != 0 {
+		t.Errorf("expected empty undo stack, got %d entries", len(ga.pendingDeletes))
+	}
+
+	// A late timer firing must not send the undone delete
+	ga.commitPendingDelete(1)
+	if committed {
+		t.Error("undone delete was committed")
+	}
+}
+
+func TestUndoLastDeleteOtherCollection(t *testing.T) {
+	ga := newTestGioApp()
+	ga.selectedCollection = &Collection{ID: "col-2"}
+
+	restored := false
+	ga.scheduleDelete("Deleted", "col-1", func() { restored = true }, func() error { return nil }, noFailure(t))
+	ga.undoLastDelete()
+
+	if restored {
+		t.Error("restore ran for a collection that is no longer displayed")
+	}
+}
+
+func TestCommitPendingDeleteOrder(t *testing.T) {
+	ga := newTestGioApp()
+	sent := make(chan string, 2)
+	ga.scheduleDelete("first", "col-1", func() {}, func() error { sent <- "first"; return nil }, noFailure(t))
+	ga.scheduleDelete("second", "col-1", func() {}, func() error { sent <- "second"; return nil }, noFailure(t))
+	defer ga.flushPendingDeletes(nil)
+
+	ga.commitPendingDelete(1)
+
+	if got := <-sent; got != "first" {
+		t.Fatalf("expected first delete sent, got %s", got)
+	}
+	if len(ga.pendingDeletes) != 1 || ga.pendingDeletes[0].message != "second" {
+		t.Errorf("expected second delete still pending, got %d entries", len(ga.pendingDeletes))
+	}
+}
+
+func TestFlushPendingDeletesSendsAll(t *testing.T) {
+	ga := newTestGioApp()
+	var (
+		mu   sync.Mutex
+		sent []string
+		wg   sync.WaitGroup
+	)
+	for _, name := range []string{"first", "second"} {
+		wg.Add(1)
+		ga.scheduleDelete(name, "col-1", func() {}, func() error {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			sent = append(sent, name)
+			return nil
+		}, noFailure(t))
+	}
+
+	ga.flushPendingDeletes(nil)
+
+	if len(ga.pendingDeletes) != 0 {
+		t.Errorf("expected empty undo stack, got %d entries", len(ga.pendingDeletes))
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pending deletes were not sent")
+	}
+	slices.Sort(sent)
+	if !slices.Equal(sent, []string{"first", "second"}) {
+		t.Errorf("sent = %v, want both deletes", sent)
+	}
+
+	// A late timer firing must not send a delete twice
+	ga.commitPendingDelete(1)
+}
+
+func TestFlushPendingDeletesNothingPending(t *testing.T) {
+	ga := newTestGioApp()
+	called := false
+	ga.flushPendingDeletes(func() { called = true })
+	if !called {
+		t.Error("done was not called with nothing to send")
+	}
+}
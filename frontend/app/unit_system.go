@@ -0,0 +1,121 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/backend/domain/services"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// unitSystems are the choices shown in the profile view
+var unitSystems = []struct {
+	system entities.UnitSystem
+	name   string
+}{
+	{entities.UnitSystemMetric, "Metric (g, kg, ml, l)"},
+	{entities.UnitSystemImperial, "Imperial (oz, lb, fl oz, gal)"},
+}
+
+// setUnitSystem saves the system quantities are shown in, reverting the
+// choice if the backend rejects it
+func (ga *GioApp) setUnitSystem(system entities.UnitSystem) {
+	if ga.currentUser == nil || system == ga.currentUnitSystem() {
+		return
+	}
+	accountID := ga.currentUser.ID
+	previous := ga.unitSystem
+	name := string(system)
+
+	ga.optimisticUpdate("save unit system", func() {
+		ga.unitSystem = system
+	}, func() {
+		ga.unitSystem = previous
+	}, func() (func(), error) {
+		prefs, err := ga.accountsClient.UpdatePreferences(accountID, types.UpdateUserPreferencesRequest{UnitSystem: &name})
+		if err != nil {
+			return nil, err
+		}
+		return func() { ga.unitSystem = entities.UnitSystem(prefs.UnitSystem) }, nil
+	})
+}
+
+// currentUnitSystem is the account's unit system, the default before
+// preferences have loaded
+func (ga *GioApp) currentUnitSystem() entities.UnitSystem {
+	if ga.unitSystem == "" {
+		return entities.DefaultUnitSystem
+	}
+	return ga.unitSystem
+}
+
+// formatQuantity renders a quantity in the account's unit system, e.g.
+// "12 oz" as "340.19 g" for a metric account. Units the backend does not
+// know are shown as entered.
+func (ga *GioApp) formatQuantity(quantity float64, unit string) string {
+	quantity, unit = services.DisplayQuantity(quantity, unit, ga.currentUnitSystem())
+	return strings.TrimSpace(strconv.FormatFloat(quantity, 'f', -1, 64) + " " + unit)
+}
+
+// objectQuantityText is the object's quantity in the account's unit system,
+// "" when it has none
+func (ga *GioApp) objectQuantityText(obj Object) string {
+	if obj.Quantity == nil {
+		return ""
+	}
+	return ga.formatQuantity(*obj.Quantity, obj.Unit)
+}
+
+// renderUnitSystemSection renders a chip per unit system in the profile
+// view, the one in use highlighted
+func (ga *GioApp) renderUnitSystemSection(gtx layout.Context) layout.Dimensions {
+	current := ga.currentUnitSystem()
+	chips := make([]layout.Widget, len(unitSystems))
+	for i, s := range unitSystems {
+		btn := ga.widgetState.unitSystemButtons[s.system]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.unitSystemButtons[s.system] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.setUnitSystem(s.system)
+			current = s.system
+		}
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, s.name, s.system == current)
+		}
+	}
+	return ga.renderChipSelector(gtx, "Units", chips)
+}
+
+// renderObjectUnitChips offers the account's unit system's units under the
+// unit field of the object dialog; clicking one fills the field in
+func (ga *GioApp) renderObjectUnitChips(gtx layout.Context) layout.Dimensions {
+	units := services.SuggestedUnits(ga.currentUnitSystem())
+	entered := ga.widgetState.objectUnitEditor.Text()
+	chips := make([]layout.Widget, len(units))
+	for i, u := range units {
+		btn := ga.widgetState.objectUnitButtons[u]
+		if btn == nil {
+			btn = &widget.Clickable{}
+			ga.widgetState.objectUnitButtons[u] = btn
+		}
+		if btn.Clicked(gtx) {
+			ga.widgetState.objectUnitEditor.SetText(u)
+			entered = u
+		}
+		chips[i] = func(gtx layout.Context) layout.Dimensions {
+			return ga.renderFilterChip(gtx, btn, u, services.SameUnit(entered, u))
+		}
+	}
+	return ga.renderChipSelector(gtx, "Common units", chips)
+}
+
+// objectUnitHint is the unit field's placeholder for the account's unit system
+func (ga *GioApp) objectUnitHint() string {
+	return "e.g., " + strings.Join(services.SuggestedUnits(ga.currentUnitSystem())[:2], ", ")
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/backend/domain/entities"
+)
+
+func TestObjectQuantityText(t *testing.T) {
+	quantity := func(q float64) *float64 { return &q }
+	tests := []struct {
+		name   string
+		system entities.UnitSystem
+		obj    Object
+		want   string
+	}{
+		{"metric by default", "", Object{Quantity: quantity(12), Unit: "oz"}, "340.19 g"},
+		{"imperial", entities.UnitSystemImperial, Object{Quantity: quantity(1500), Unit: "g"}, "3.31 lb"},
+		{"already in the system", entities.UnitSystemImperial, Object{Quantity: quantity(2), Unit: "lb"}, "2 lb"},
+		{"count", entities.UnitSystemImperial, Object{Quantity: quantity(6), Unit: "eggs"}, "6 eggs"},
+		{"no unit", entities.UnitSystemMetric, Object{Quantity: quantity(3)}, "3"},
+		{"no quantity", entities.UnitSystemMetric, Object{Unit: "kg"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ga := newTestGioApp()
+			ga.unitSystem = tt.system
+			if got := ga.objectQuantityText(tt.obj); got != tt.want {
+				t.Errorf("objectQuantityText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectUnitHint(t *testing.T) {
+	ga := newTestGioApp()
+	if got := ga.objectUnitHint(); got != "e.g., g, kg" {
+		t.Errorf("objectUnitHint() = %q for metric", got)
+	}
+	ga.unitSystem = entities.UnitSystemImperial
+	if got := ga.objectUnitHint(); got != "e.g., oz, lb" {
+		t.Errorf("objectUnitHint() = %q for imperial", got)
+	}
+}
@@ -0,0 +1,304 @@
+package app
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+// valuationSection is one breakdown of the valuation report, with the label
+// shown for groups the backend keys ""
+type valuationSection struct {
+	title  string
+	groups []types.ValuationGroup
+	empty  string
+}
+
+// valuationSections lists the report's breakdowns in display order
+func valuationSections(r *types.ValuationReport) []valuationSection {
+	return []valuationSection{
+		{"By Collection", r.ByCollection, ""},
+		{"By Type", r.ByObjectType, ""},
+		{"By Tag", r.ByTag, "Untagged"},
+		{"By Condition", r.ByCondition, "Not graded"},
+	}
+}
+
+// formatMoney renders an amount with its currency symbol and comma
+// separators, e.g. "$1,234.50"
+func formatMoney(amount float64, currencyCode string) string {
+	cents := int64(math.Round(amount * 100))
+	return fmt.Sprintf("%s%s.%02d", currencySymbol(currencyCode), formatThousands(float64(cents/100)), cents%100)
+}
+
+// valuationSummary is the one-line total across currencies, e.g.
+// "$1,234.50 (40 objects) · €90.00 (3 objects)"
+func valuationSummary(r *types.ValuationReport) string {
+	if len(r.Totals) == 0 {
+		return "Nothing priced yet"
+	}
+	parts := make([]string, len(r.Totals))
+	for i, t := range r.Totals {
+		parts[i] = fmt.Sprintf("%s (%d objects)", formatMoney(t.Total, t.Currency), t.Objects)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// valuationCoverage notes the objects the totals leave out, or "" when none is
+func valuationCoverage(r *types.ValuationReport) string {
+	if r.Unvalued == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Not counted: %d without a price", r.Unvalued)
+}
+
+// valuationGroupLabel names a bar, e.g. "Books · $420.00 (12)"
+func valuationGroupLabel(g types.ValuationGroup, empty string) string {
+	name := g.Name
+	if g.Key == "" {
+		name = empty
+	} else if label, ok := conditionLabels[name]; ok {
+		name = label
+	}
+	return fmt.Sprintf("%s · %s (%d)", name, formatMoney(g.Total, g.Currency), g.Objects)
+}
+
+// resetValuation drops the loaded report when the session ends
+func (ga *GioApp) resetValuation() {
+	ga.valuationReport = nil
+	ga.valuationLoaded = false
+	ga.valuationErr = ""
+	ga.valuationExportStatus = ""
+}
+
+// ensureValuationLoaded fetches the valuation report unless it is already
+// loaded or on its way
+func (ga *GioApp) ensureValuationLoaded() {
+	if ga.currentUser == nil || ga.valuationLoaded || ga.valuationLoading {
+		return
+	}
+	ga.valuationLoading = true
+	ga.valuationErr = ""
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		report, err := ga.reportsClient.Valuation(accountID)
+
+		ga.doInSession(session, func() {
+			ga.valuationLoading = false
+			ga.valuationLoaded = true
+			if err != nil {
+				ga.logger.Error("Failed to load valuation report", "error", err)
+				ga.valuationErr = "Could not load the valuation report: " + err.Error()
+				return
+			}
+			ga.valuationReport = report
+		})
+	})
+}
+
+// exportValuationCSV downloads the valuation report as a CSV file
+func (ga *GioApp) exportValuationCSV() {
+	if ga.valuationExporting || ga.currentUser == nil {
+		return
+	}
+	ga.valuationExporting = true
+	ga.valuationExportStatus = "Preparing CSV..."
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		data, err := ga.reportsClient.ValuationCSV(accountID)
+		var path string
+		if err == nil {
+			filename := "nishiki-valuation-" + time.Now().Format("2006-01-02") + ".csv"
+			path, err = saveDownload(filename, data, "text/csv")
+		}
+
+		ga.doInSession(session, func() {
+			ga.valuationExporting = false
+			switch {
+			case err != nil:
+				ga.logger.Error("Failed to export valuation report", "error", err)
+				ga.valuationExportStatus = "Export failed: " + err.Error()
+			case path != "":
+				ga.valuationExportStatus = fmt.Sprintf("CSV saved to %s", path)
+			default:
+				ga.valuationExportStatus = "CSV downloaded"
+			}
+		})
+	})
+}
+
+// renderValuationView renders what the user's objects are worth: the total
+// per currency, then a bar chart for each breakdown
+func (ga *GioApp) renderValuationView(gtx layout.Context) layout.Dimensions {
+	if ga.widgetState.valuationRefresh.Clicked(gtx) && !ga.valuationLoading {
+		ga.valuationLoaded = false
+	}
+	if ga.widgetState.valuationExport.Clicked(gtx) {
+		ga.exportValuationCSV()
+	}
+	ga.ensureValuationLoaded()
+
+	var sections []valuationSection
+	if ga.valuationReport != nil {
+		for _, s := range valuationSections(ga.valuationReport) {
+			if len(s.groups) > 0 {
+				sections = append(sections, s)
+			}
+		}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderHeader(gtx, "Valuation")
+		}),
+
+		// Totals and status
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing4), Right: unit.Dp(theme.Spacing4)}.Layout(gtx, ga.renderValuationSummary)
+		}),
+
+		// Breakdowns
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing3),
+				Bottom: unit.Dp(theme.Spacing20), // Space for bottom menu
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				if ga.valuationLoading && ga.valuationReport == nil {
+					return widgets.SkeletonList(skeletonRows)(gtx)
+				}
+				return material.List(ga.theme.Theme, &ga.widgetState.valuationList).Layout(gtx, len(sections), func(gtx layout.Context, i int) layout.Dimensions {
+					return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+						return ga.renderValuationSection(gtx, sections[i])
+					})
+				})
+			})
+		}),
+
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ga.renderBottomMenu(gtx, ViewDashboardGio)
+		}),
+	)
+}
+
+// renderValuationSummary renders the totals with the refresh and export
+// buttons, and the coverage, loading or error message below them
+func (ga *GioApp) renderValuationSummary(gtx layout.Context) layout.Dimensions {
+	var summary, coverage string
+	if r := ga.valuationReport; r != nil {
+		summary = valuationSummary(r)
+		coverage = valuationCoverage(r)
+	}
+
+	status, statusColor := ga.valuationExportStatus, theme.ColorTextSecondary
+	switch {
+	case ga.valuationErr != "":
+		status, statusColor = ga.valuationErr, theme.ColorDanger
+	case ga.valuationLoading && ga.valuationReport != nil:
+		status = "Refreshing..."
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, summary)
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.valuationExport, "Export CSV")),
+				layout.Rigid(layout.Spacer{Width: unit.Dp(theme.Spacing2)}.Layout),
+				layout.Rigid(widgets.CancelButton(ga.theme.Theme, &ga.widgetState.valuationRefresh, "Refresh")),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if coverage == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Caption(ga.theme.Theme, coverage)
+			label.Color = theme.ColorTextSecondary
+			return label.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if status == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(ga.theme.Theme, status)
+				label.Color = statusColor
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
+
+// renderValuationSection renders one breakdown as a bar chart. Amounts in
+// different currencies cannot be compared, so each bar is scaled against
+// the largest group in its own currency.
+func (ga *GioApp) renderValuationSection(gtx layout.Context, s valuationSection) layout.Dimensions {
+	maxTotal := make(map[string]float64)
+	for _, g := range s.groups {
+		maxTotal[g.Currency] = max(maxTotal[g.Currency], g.Total)
+	}
+
+	return widgets.DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		maxWidth := gtx.Constraints.Max.X
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(ga.theme.Theme, s.title)
+					label.Font.Weight = font.Bold
+					return label.Layout(gtx)
+				})
+			}),
+		}
+		for _, g := range s.groups {
+			children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Top: unit.Dp(2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							lbl := material.Caption(ga.theme.Theme, valuationGroupLabel(g, s.empty))
+							lbl.Color = theme.ColorTextSecondary
+							lbl.MaxLines = 1
+							cgtx := gtx
+							cgtx.Constraints.Min.X = gtx.Dp(unit.Dp(200))
+							cgtx.Constraints.Max.X = gtx.Dp(unit.Dp(200))
+							return lbl.Layout(cgtx)
+						}),
+						layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+							barMaxW := maxWidth - gtx.Dp(unit.Dp(210))
+							barW := 4
+							if top := maxTotal[g.Currency]; top > 0 {
+								barW = max(int(float64(barMaxW)*g.Total/top), 4)
+							}
+							sz := image.Point{X: barW, Y: gtx.Dp(unit.Dp(12))}
+							defer clip.RRect{Rect: image.Rectangle{Max: sz}, SE: 3, SW: 3, NW: 3, NE: 3}.Push(gtx.Ops).Pop()
+							paint.ColorOp{Color: theme.ColorAccent}.Add(gtx.Ops)
+							paint.PaintOp{}.Add(gtx.Ops)
+							return layout.Dimensions{Size: sz}
+						}),
+					)
+				})
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
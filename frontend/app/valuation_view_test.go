@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     string
+	}{
+		{0, "USD", "$0.00"},
+		{1234.5, "USD", "$1,234.50"},
+		{9.999, "EUR", "€10.00"},
+		{42, "CHF", "CHF 42.00"},
+		{3.1, "", "$3.10"},
+	}
+	for _, tt := range tests {
+		if got := formatMoney(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("formatMoney(%v, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestValuationSummaryAndCoverage(t *testing.T) {
+	r := &types.ValuationReport{
+		Totals: []types.ValuationTotal{
+			{Currency: "USD", Objects: 40, Total: 1234.5},
+			{Currency: "EUR", Objects: 3, Total: 90},
+		},
+		Unvalued: 2,
+	}
+
+	if got, want := valuationSummary(r), "$1,234.50 (40 objects) · €90.00 (3 objects)"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if got, want := valuationCoverage(r), "Not counted: 2 without a price"; got != want {
+		t.Errorf("coverage = %q, want %q", got, want)
+	}
+	if got, want := valuationSummary(&types.ValuationReport{}), "Nothing priced yet"; got != want {
+		t.Errorf("empty summary = %q, want %q", got, want)
+	}
+}
+
+func TestValuationGroupLabel(t *testing.T) {
+	tests := []struct {
+		group types.ValuationGroup
+		empty string
+		want  string
+	}{
+		{types.ValuationGroup{Key: "c1", Name: "Books", Currency: "USD", Objects: 12, Total: 420}, "", "Books · $420.00 (12)"},
+		{types.ValuationGroup{Key: "", Currency: "USD", Objects: 3, Total: 15}, "Untagged", "Untagged · $15.00 (3)"},
+		{types.ValuationGroup{Key: "near_mint", Name: "near_mint", Currency: "GBP", Objects: 1, Total: 80}, "Not graded", "Near mint · £80.00 (1)"},
+	}
+	for _, tt := range tests {
+		if got := valuationGroupLabel(tt.group, tt.empty); got != tt.want {
+			t.Errorf("valuationGroupLabel(%+v) = %q, want %q", tt.group, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/nishiki/backend/domain/entities"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// collectionViewKey is the preferences key of a collection's object listing
+func collectionViewKey(collectionID string) string {
+	return "collection:" + collectionID
+}
+
+// fetchViewPreferences loads the saved sort, grouping and filter choices so
+// they can be restored when a collection is opened, along with the pinned and
+// hand-ordered collections and containers
+func (ga *GioApp) fetchViewPreferences() {
+	if ga.currentUser == nil {
+		return
+	}
+	accountID := ga.currentUser.ID
+	session := ga.session
+
+	ga.goSafe(func() {
+		prefs, err := ga.accountsClient.GetPreferences(accountID)
+		ga.doInSession(session, func() {
+			ga.viewPreferences = map[string]types.ViewPreference{}
+			if err != nil {
+				// Carry on with defaults; choices made now are still saved
+				ga.logger.Error("Failed to fetch view preferences", "error", err)
+			} else {
+				if prefs.Views != nil {
+					ga.viewPreferences = prefs.Views
+				}
+				ga.collectionOrder = prefs.CollectionOrder
+				ga.containerOrder = prefs.ContainerOrder
+				ga.labelTemplate = prefs.LabelTemplate
+				ga.unitSystem = entities.UnitSystem(prefs.UnitSystem)
+			}
+			ga.viewPrefsLoaded = true
+			ga.logger.Info("View preferences loaded", "views", len(ga.viewPreferences))
+		})
+	})
+}
+
+// resetViewPreferences drops the loaded preferences when the session ends
+func (ga *GioApp) resetViewPreferences() {
+	ga.viewPreferences = nil
+	ga.viewPrefsLoaded = false
+	ga.viewPrefsSaving = false
+	ga.viewPrefsCollectionID = ""
+	ga.collectionOrder = nil
+	ga.containerOrder = nil
+	ga.labelTemplate = ""
+	ga.unitSystem = ""
+}
+
+// syncCollectionViewPreference restores the saved view the first time a
+// collection is shown and afterwards saves any change to its sort, grouping or
+// filters. Only one save is in flight at a time; a change made meanwhile is
+// picked up on the frame after it finishes.
+func (ga *GioApp) syncCollectionViewPreference() {
+	if !ga.viewPrefsLoaded || ga.selectedCollection == nil || ga.currentUser == nil {
+		return
+	}
+	key := collectionViewKey(ga.selectedCollection.ID)
+
+	if ga.viewPrefsCollectionID != ga.selectedCollection.ID {
+		ga.viewPrefsCollectionID = ga.selectedCollection.ID
+		ga.applyViewPreference(ga.viewPreferences[key])
+		return
+	}
+
+	current := ga.currentViewPreference()
+	if ga.viewPrefsSaving || viewPreferencesEqual(current, ga.viewPreferences[key]) {
+		return
+	}
+
+	if isEmptyViewPreference(current) {
+		delete(ga.viewPreferences, key)
+	} else {
+		ga.viewPreferences[key] = current
+	}
+	ga.viewPrefsSaving = true
+	accountID := ga.currentUser.ID
+	req := types.UpdateUserPreferencesRequest{
+		Views: map[string]*types.ViewPreferenceRequest{key: viewPreferenceRequest(current)},
+	}
+
+	ga.goSafe(func() {
+		_, err := ga.accountsClient.UpdatePreferences(accountID, req)
+		ga.do(func() {
+			ga.viewPrefsSaving = false
+			if err != nil {
+				ga.logger.Error("Failed to save view preferences", "view", key, "error", err)
+			}
+		})
+	})
+}
+
+// currentViewPreference captures the collection detail's sort, grouping and
+// filter state. Filters set to "All" are left out.
+func (ga *GioApp) currentViewPreference() types.ViewPreference {
+	var pref types.ViewPreference
+	for _, sp := range ga.objectSortSpecs {
+		pref.Sort = append(pref.Sort, types.ViewSort{Field: sp.field, Direction: sp.dir})
+	}
+	pref.GroupBy = ga.objectGroupByField
+	for key, value := range ga.activeGroupedTextFilters {
+		if value == "" {
+			continue
+		}
+		if pref.Filters == nil {
+			pref.Filters = map[string]string{}
+		}
+		pref.Filters[key] = value
+	}
+	return pref
+}
+
+// applyViewPreference replaces the collection detail's sort, grouping and
+// filter state with a saved preference
+func (ga *GioApp) applyViewPreference(pref types.ViewPreference) {
+	ga.objectSortSpecs = nil
+	for _, s := range pref.Sort {
+		ga.objectSortSpecs = append(ga.objectSortSpecs, sortSpec{field: s.Field, dir: s.Direction})
+	}
+	ga.objectGroupByField = pref.GroupBy
+	ga.activeGroupedTextFilters = copyStringMap(pref.Filters)
+}
+
+func isEmptyViewPreference(pref types.ViewPreference) bool {
+	return len(pref.Sort) == 0 && pref.GroupBy == "" && len(pref.Filters) == 0
+}
+
+// viewPreferencesEqual returns true if two preferences hold the same state
+func viewPreferencesEqual(a, b types.ViewPreference) bool {
+	return slices.Equal(a.Sort, b.Sort) && a.GroupBy == b.GroupBy && mapsEqual(a.Filters, b.Filters)
+}
+
+// viewPreferenceRequest converts a preference to its update body; an empty
+// preference becomes nil so the backend forgets the view
+func viewPreferenceRequest(pref types.ViewPreference) *types.ViewPreferenceRequest {
+	if isEmptyViewPreference(pref) {
+		return nil
+	}
+	req := &types.ViewPreferenceRequest{GroupBy: pref.GroupBy, Filters: copyStringMap(pref.Filters)}
+	for _, s := range pref.Sort {
+		req.Sort = append(req.Sort, types.ViewSortRequest{Field: s.Field, Direction: s.Direction})
+	}
+	return req
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestSyncCollectionViewPreferenceRestoresSavedView(t *testing.T) {
+	ga := newTestGioApp()
+	ga.currentUser = &types.User{ID: "user-1"}
+	ga.selectedCollection = &Collection{ID: "col-1"}
+	ga.viewPrefsLoaded = true
+	ga.viewPreferences = map[string]types.ViewPreference{
+		"collection:col-1": {
+			Sort:    []types.ViewSort{{Field: "expires_at", Direction: "asc"}, {Field: "name", Direction: "desc"}},
+			GroupBy: "container",
+			Filters: map[string]string{"category": "grain"},
+		},
+	}
+
+	ga.syncCollectionViewPreference()
+
+	want := []sortSpec{{field: "expires_at", dir: "asc"}, {field: "name", dir: "desc"}}
+	if !sortSpecsEqual(ga.objectSortSpecs, want) {
+		t.Errorf("sort specs = %+v, want %+v", ga.objectSortSpecs, want)
+	}
+	if ga.objectGroupByField != "container" {
+		t.Errorf("group by = %q, want container", ga.objectGroupByField)
+	}
+	if ga.activeGroupedTextFilters["category"] != "grain" {
+		t.Errorf("filters = %v, want category=grain", ga.activeGroupedTextFilters)
+	}
+
+	// The restored state matches what was saved, so nothing is sent
+	ga.syncCollectionViewPreference()
+	if ga.viewPrefsSaving {
+		t.Error("unchanged view started a save")
+	}
+}
+
+func TestSyncCollectionViewPreferenceWaitsForLoad(t *testing.T) {
+	ga := newTestGioApp()
+	ga.currentUser = &types.User{ID: "user-1"}
+	ga.selectedCollection = &Collection{ID: "col-1"}
+	ga.objectSortSpecs = []sortSpec{{field: "name", dir: "asc"}}
+
+	ga.syncCollectionViewPreference()
+
+	if ga.viewPrefsCollectionID != "" || ga.viewPrefsSaving {
+		t.Error("preferences were applied or saved before they loaded")
+	}
+	if len(ga.objectSortSpecs) != 1 {
+		t.Errorf("sort specs changed before load: %+v", ga.objectSortSpecs)
+	}
+}
+
+func TestCurrentViewPreferenceSkipsAllFilters(t *testing.T) {
+	ga := newTestGioApp()
+	ga.activeGroupedTextFilters = map[string]string{"category": "", "color": "red"}
+
+	pref := ga.currentViewPreference()
+	if len(pref.Filters) != 1 || pref.Filters["color"] != "red" {
+		t.Errorf("filters = %v, want only color=red", pref.Filters)
+	}
+
+	ga.activeGroupedTextFilters = map[string]string{"category": ""}
+	if pref := ga.currentViewPreference(); !isEmptyViewPreference(pref) {
+		t.Errorf("expected empty preference, got %+v", pref)
+	}
+	if req := viewPreferenceRequest(types.ViewPreference{}); req != nil {
+		t.Errorf("empty preference should clear the view, got %+v", req)
+	}
+}
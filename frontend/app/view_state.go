@@ -0,0 +1,203 @@
+package app
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// viewState is what a route looked like when the user left it, so going back
+// shows it as it was instead of rebuilding it from the top
+type viewState struct {
+	scroll      []layout.Position // one per routeLists entry
+	expanded    map[string]bool
+	filters     map[string]string
+	search      string
+	containerID string
+
+	showContainersPanel bool
+	containerViewMode   string
+	objectViewLayout    ObjectViewLayout
+}
+
+// routeKey identifies the current screen. Views showing a collection are
+// kept apart per collection.
+func (ga *GioApp) routeKey() string {
+	switch ga.currentView {
+	case ViewCollectionDetailGio, ViewContainersGio:
+		if ga.selectedCollection != nil {
+			return ga.currentView.String() + "/" + ga.selectedCollection.ID
+		}
+	}
+	return ga.currentView.String()
+}
+
+// routeObjectLayout is the object layout the current route will show.
+// Right after navigating it is still the previous route's until
+// syncViewState runs, so the saved state is consulted instead.
+func (ga *GioApp) routeObjectLayout() ObjectViewLayout {
+	route := ga.routeKey()
+	if route == ga.viewRoute {
+		return ga.objectViewLayout
+	}
+	if state, ok := ga.viewStates[route]; ok {
+		return state.objectViewLayout
+	}
+	return ""
+}
+
+// routeLists are the scrolling lists of a view, in the order their
+// positions are kept in viewState.scroll
+func (ga *GioApp) routeLists(view ViewID) []*widget.List {
+	ws := ga.widgetState
+	switch view {
+	case ViewGroupsGio:
+		return []*widget.List{&ws.groupsList}
+	case ViewCollectionsGio:
+		return []*widget.List{&ws.collectionsList}
+	case ViewCollectionDetailGio:
+		return []*widget.List{&ws.objectsList, &ws.containersList, &ws.photosList}
+	case ViewContainersGio:
+		return []*widget.List{&ws.containersList, &ws.containerDetailList}
+	case ViewMealPlanGio:
+		return []*widget.List{&ws.mealDaysList}
+	case ViewAdminGio:
+		return []*widget.List{&ws.adminUsersList}
+	case ViewValuationGio:
+		return []*widget.List{&ws.valuationList}
+	case ViewInboxGio:
+		return []*widget.List{&ws.inboxList}
+	case ViewAutomationsGio:
+		return []*widget.List{&ws.automationsList}
+	}
+	return nil
+}
+
+// routeSearchField is the search box of a view, if it has one
+func (ga *GioApp) routeSearchField(view ViewID) *widget.Editor {
+	switch view {
+	case ViewGroupsGio:
+		return &ga.widgetState.groupsSearchField
+	case ViewCollectionsGio:
+		return &ga.widgetState.collectionsSearchField
+	case ViewCollectionDetailGio:
+		return &ga.widgetState.objectsSearchField
+	case ViewContainersGio:
+		return &ga.widgetState.containersSearchField
+	}
+	return nil
+}
+
+// routeLoading reports whether the data behind a view is still being
+// fetched. A list laid out while empty forgets its position, so restoring
+// scroll and filters waits until the data is there.
+func (ga *GioApp) routeLoading(view ViewID) bool {
+	switch view {
+	case ViewGroupsGio:
+		return !ga.groupsLoaded
+	case ViewCollectionsGio:
+		return !ga.collectionsLoaded
+	case ViewCollectionDetailGio, ViewContainersGio:
+		return ga.loadingContainersObjects
+	}
+	return false
+}
+
+// syncViewState runs at the start of every frame. When the previous frame
+// navigated, it saves the route that was left and restores the one entered,
+// or resets it if the route has not been visited yet.
+func (ga *GioApp) syncViewState() {
+	route := ga.routeKey()
+	if route == ga.viewRoute {
+		if ga.pendingViewState != nil && !ga.routeLoading(ga.currentView) {
+			ga.restoreLoadedViewState(ga.pendingViewState)
+			ga.pendingViewState = nil
+		}
+		return
+	}
+
+	if ga.viewRoute != "" {
+		ga.viewStates[ga.viewRoute] = ga.captureViewState(ga.viewRouteView)
+	}
+	ga.viewRoute = route
+	ga.viewRouteView = ga.currentView
+
+	state, ok := ga.viewStates[route]
+	if !ok {
+		state = &viewState{}
+	}
+	ga.restoreViewState(state)
+	ga.pendingViewState = state
+	if !ga.routeLoading(ga.currentView) {
+		ga.restoreLoadedViewState(state)
+		ga.pendingViewState = nil
+	}
+}
+
+// captureViewState copies the state of view as it is now. The state owns
+// the expansion and filter maps from here on; restoring hands them back.
+func (ga *GioApp) captureViewState(view ViewID) *viewState {
+	state := &viewState{
+		expanded:            ga.treeExpandedNodes,
+		filters:             ga.activeGroupedTextFilters,
+		showContainersPanel: ga.showContainersPanel,
+		containerViewMode:   ga.containerViewMode,
+		objectViewLayout:    ga.objectViewLayout,
+	}
+	for _, list := range ga.routeLists(view) {
+		state.scroll = append(state.scroll, list.Position)
+	}
+	if field := ga.routeSearchField(view); field != nil {
+		state.search = field.Text()
+	}
+	if view == ViewContainersGio && ga.selectedContainer != nil {
+		state.containerID = ga.selectedContainer.ID
+	}
+	return state
+}
+
+// resetViewStates forgets every route's state when the session ends
+func (ga *GioApp) resetViewStates() {
+	ga.viewStates = make(map[string]*viewState)
+	ga.pendingViewState = nil
+}
+
+// restoreViewState puts back the parts of state that do not depend on the
+// route's data and clears the rest until restoreLoadedViewState runs
+func (ga *GioApp) restoreViewState(state *viewState) {
+	ga.treeExpandedNodes = state.expanded
+	if ga.treeExpandedNodes == nil {
+		ga.treeExpandedNodes = make(map[string]bool)
+	}
+	ga.activeGroupedTextFilters = nil
+	ga.selectedContainer = nil
+	ga.showContainersPanel = state.showContainersPanel
+	ga.containerViewMode = state.containerViewMode
+	ga.objectViewLayout = state.objectViewLayout
+	if field := ga.routeSearchField(ga.currentView); field != nil && field.Text() != state.search {
+		field.SetText(state.search)
+	}
+	for _, list := range ga.routeLists(ga.currentView) {
+		list.Position = layout.Position{}
+	}
+}
+
+// restoreLoadedViewState puts back the scroll positions, filters and open
+// container once the route's data has arrived
+func (ga *GioApp) restoreLoadedViewState(state *viewState) {
+	for i, list := range ga.routeLists(ga.currentView) {
+		if i < len(state.scroll) {
+			list.Position = state.scroll[i]
+		}
+	}
+	if state.filters != nil {
+		ga.activeGroupedTextFilters = state.filters
+	}
+	if ga.currentView == ViewContainersGio {
+		for i := range ga.containers {
+			if ga.containers[i].ID == state.containerID {
+				ga.selectedContainer = &ga.containers[i]
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import "testing"
+
+func TestViewStateRestoredOnBackNavigation(t *testing.T) {
+	ga := newTestGioApp()
+	ga.viewStates = make(map[string]*viewState)
+	ga.collectionsLoaded = true
+	pantry := &Collection{ID: "col-1", Name: "Pantry"}
+	garage := &Collection{ID: "col-2", Name: "Garage"}
+
+	// Open the pantry, scroll, expand a container and filter
+	ga.currentView = ViewCollectionDetailGio
+	ga.selectedCollection = pantry
+	ga.syncViewState()
+	ga.widgetState.objectsList.Position.First = 7
+	ga.treeExpandedNodes["shelf"] = true
+	ga.activeGroupedTextFilters = map[string]string{"color": "red"}
+	ga.widgetState.objectsSearchField.SetText("oat")
+	ga.objectViewLayout = ObjectViewGrid
+
+	// Back to the collections list, then into another collection
+	ga.currentView = ViewCollectionsGio
+	ga.selectedCollection = nil
+	ga.syncViewState()
+	ga.currentView = ViewCollectionDetailGio
+	ga.selectedCollection = garage
+	ga.syncViewState()
+	if ga.treeExpandedNodes["shelf"] || ga.activeGroupedTextFilters != nil {
+		t.Error("a collection not visited yet should start collapsed and unfiltered")
+	}
+	if got := ga.widgetState.objectsSearchField.Text(); got != "" {
+		t.Errorf("search in new collection = %q, want empty", got)
+	}
+	if ga.objectViewLayout != "" {
+		t.Errorf("layout in new collection = %q, want default", ga.objectViewLayout)
+	}
+
+	// Back to the pantry while its objects are fetched again
+	ga.currentView = ViewCollectionsGio
+	ga.selectedCollection = nil
+	ga.syncViewState()
+	ga.currentView = ViewCollectionDetailGio
+	ga.selectedCollection = pantry
+	ga.loadingContainersObjects = true
+	ga.syncViewState()
+	if !ga.treeExpandedNodes["shelf"] {
+		t.Error("expanded container was not restored")
+	}
+	if got := ga.widgetState.objectsSearchField.Text(); got != "oat" {
+		t.Errorf("search = %q, want %q", got, "oat")
+	}
+	if ga.objectViewLayout != ObjectViewGrid {
+		t.Errorf("layout = %q, want %q", ga.objectViewLayout, ObjectViewGrid)
+	}
+	if got := ga.widgetState.objectsList.Position.First; got != 0 {
+		t.Errorf("scroll restored before objects loaded: first = %d", got)
+	}
+
+	ga.loadingContainersObjects = false
+	ga.syncViewState()
+	if got := ga.widgetState.objectsList.Position.First; got != 7 {
+		t.Errorf("scroll first = %d, want 7", got)
+	}
+	if got := ga.activeGroupedTextFilters["color"]; got != "red" {
+		t.Errorf("filter = %q, want %q", got, "red")
+	}
+}
+
+func TestViewStateRestoresOpenContainer(t *testing.T) {
+	ga := newTestGioApp()
+	ga.viewStates = make(map[string]*viewState)
+	ga.selectedCollection = &Collection{ID: "col-1"}
+	ga.containers = []Container{{ID: "c-1"}, {ID: "c-2"}}
+
+	ga.currentView = ViewContainersGio
+	ga.syncViewState()
+	ga.selectedContainer = &ga.containers[1]
+
+	ga.currentView = ViewCollectionDetailGio
+	ga.syncViewState()
+	if ga.selectedContainer != nil {
+		t.Error("container stayed selected after leaving the containers page")
+	}
+
+	ga.currentView = ViewContainersGio
+	ga.syncViewState()
+	if ga.selectedContainer == nil || ga.selectedContainer.ID != "c-2" {
+		t.Errorf("selected container = %v, want c-2", ga.selectedContainer)
+	}
+}
@@ -0,0 +1,78 @@
+//go:build js && wasm
+
+package app
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// speechRecognition returns the browser's SpeechRecognition constructor,
+// which Chrome and Safari only ship with a webkit prefix
+func speechRecognition() js.Value {
+	if ctor := js.Global().Get("SpeechRecognition"); ctor.Truthy() {
+		return ctor
+	}
+	return js.Global().Get("webkitSpeechRecognition")
+}
+
+// speechInputSupported reports whether the browser can turn speech into text
+func speechInputSupported() bool {
+	return speechRecognition().Truthy()
+}
+
+// listenForPhrase records one phrase with the Web Speech API. Exactly one of
+// onResult and onError is called, from the browser's event loop, so neither
+// may block.
+func listenForPhrase(onResult func(transcript string), onError func(error)) {
+	ctor := speechRecognition()
+	if !ctor.Truthy() {
+		onError(errSpeechUnsupported)
+		return
+	}
+
+	recognition := ctor.New()
+	recognition.Set("lang", js.Global().Get("navigator").Get("language"))
+	recognition.Set("interimResults", false)
+	recognition.Set("maxAlternatives", 1)
+
+	settled := false
+	var resultHandler, errorHandler, endHandler js.Func
+	resultHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !settled {
+			settled = true
+			onResult(args[0].Get("results").Index(0).Index(0).Get("transcript").String())
+		}
+		return nil
+	})
+	errorHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !settled {
+			settled = true
+			switch code := args[0].Get("error").String(); code {
+			case "not-allowed", "service-not-allowed":
+				onError(errMicrophoneDenied)
+			case "no-speech":
+				onError(errNoSpeech)
+			default:
+				onError(fmt.Errorf("voice input failed: %s", code))
+			}
+		}
+		return nil
+	})
+	// end always fires last, after a result or an error
+	endHandler = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !settled {
+			settled = true
+			onError(errNoSpeech)
+		}
+		resultHandler.Release()
+		errorHandler.Release()
+		endHandler.Release()
+		return nil
+	})
+
+	recognition.Set("onresult", resultHandler)
+	recognition.Set("onerror", errorHandler)
+	recognition.Set("onend", endHandler)
+	recognition.Call("start")
+}
@@ -0,0 +1,11 @@
+//go:build !js || !wasm
+
+package app
+
+// Desktop builds have no speech recognition; the microphone button is hidden.
+
+func speechInputSupported() bool { return false }
+
+func listenForPhrase(_ func(string), onError func(error)) {
+	onError(errSpeechUnsupported)
+}
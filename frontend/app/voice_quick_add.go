@@ -0,0 +1,108 @@
+package app
+
+import (
+	"errors"
+	"strconv"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/pkg/types"
+	"github.com/nishiki/frontend/ui/theme"
+	"github.com/nishiki/frontend/ui/widgets"
+)
+
+var (
+	errSpeechUnsupported = errors.New("voice input is not supported in this browser")
+	errMicrophoneDenied  = errors.New("microphone access was denied")
+	errNoSpeech          = errors.New("no speech was heard, try again")
+)
+
+// startVoiceQuickAdd listens for a phrase like "three cans of tomatoes in the
+// pantry" and fills the create object dialog from what the server reads in it
+func (ga *GioApp) startVoiceQuickAdd() {
+	if ga.voiceListening || ga.currentUser == nil {
+		return
+	}
+	ga.voiceListening = true
+	ga.voiceTranscript = ""
+	ga.objectDialogErr = ""
+
+	userID := ga.currentUser.ID
+	req := types.ParseObjectRequest{}
+	if ga.selectedCollection != nil {
+		req.CollectionID = ga.selectedCollection.ID
+	}
+
+	listenForPhrase(func(transcript string) {
+		ga.goSafe(func() {
+			req.Text = transcript
+			parsed, err := ga.objectsClient.Parse(userID, req)
+			ga.do(func() {
+				ga.voiceListening = false
+				ga.voiceTranscript = transcript
+				if err != nil {
+					ga.logger.Warn("Failed to parse quick add phrase", "error", err)
+					ga.objectDialogErr = "Could not read that phrase: " + err.Error()
+					return
+				}
+				ga.applyParsedObject(*parsed)
+			})
+		})
+	}, func(err error) {
+		ga.goSafe(func() {
+			ga.do(func() {
+				ga.voiceListening = false
+				ga.objectDialogErr = err.Error()
+			})
+		})
+	})
+}
+
+// applyParsedObject prefills the create object dialog. Fields the phrase did
+// not mention keep whatever the user already typed.
+func (ga *GioApp) applyParsedObject(parsed types.ParsedObject) {
+	ga.widgetState.objectNameEditor.SetText(parsed.Name)
+	if parsed.Quantity != nil {
+		ga.widgetState.objectQuantityStepper.SetText(strconv.FormatFloat(*parsed.Quantity, 'f', -1, 64))
+	}
+	if parsed.Unit != "" {
+		ga.widgetState.objectUnitEditor.SetText(parsed.Unit)
+	}
+	if parsed.ContainerID != "" {
+		ga.selectedContainerID = new(parsed.ContainerID)
+	} else if parsed.Location != "" {
+		ga.objectDialogErr = "No container matches \"" + parsed.Location + "\""
+	}
+}
+
+// renderVoiceQuickAdd renders the microphone button and the last phrase heard.
+// It is only shown when creating an object in a browser with speech input.
+func (ga *GioApp) renderVoiceQuickAdd(gtx layout.Context) layout.Dimensions {
+	if ga.objectDialogMode != "create" || !speechInputSupported() {
+		return layout.Dimensions{}
+	}
+
+	label := "Speak"
+	if ga.voiceListening {
+		label = "Listening..."
+	}
+	caption := "Say something like \"three cans of tomatoes in the pantry\""
+	if ga.voiceTranscript != "" {
+		caption = "Heard: \"" + ga.voiceTranscript + "\""
+	}
+
+	return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(widgets.AccentButton(ga.theme.Theme, &ga.widgetState.objectVoiceButton, label)),
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Left: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					hint := material.Caption(ga.theme.Theme, caption)
+					hint.Color = theme.ColorTextSecondary
+					return hint.Layout(gtx)
+				})
+			}),
+		)
+	})
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+func TestApplyParsedObject(t *testing.T) {
+	ga := newTestGioApp()
+	ga.widgetState.objectUnitEditor.SetText("kg")
+
+	quantity := 3.0
+	ga.applyParsedObject(types.ParsedObject{Name: "tomatoes", Quantity: &quantity, ContainerID: "ctr-1"})
+
+	if got := ga.widgetState.objectNameEditor.Text(); got != "tomatoes" {
+		t.Errorf("name = %q", got)
+	}
+	if got := ga.widgetState.objectQuantityStepper.Text(); got != "3" {
+		t.Errorf("quantity = %q, want 3", got)
+	}
+	if got := ga.widgetState.objectUnitEditor.Text(); got != "kg" {
+		t.Errorf("unit = %q, want the typed unit kept", got)
+	}
+	if ga.selectedContainerID == nil || *ga.selectedContainerID != "ctr-1" {
+		t.Errorf("container = %v, want ctr-1", ga.selectedContainerID)
+	}
+	if ga.objectDialogErr != "" {
+		t.Errorf("unexpected error %q", ga.objectDialogErr)
+	}
+}
+
+func TestApplyParsedObjectUnknownPlace(t *testing.T) {
+	ga := newTestGioApp()
+	ga.applyParsedObject(types.ParsedObject{Name: "milk", Location: "garage"})
+
+	if ga.selectedContainerID != nil {
+		t.Errorf("container = %v, want none", *ga.selectedContainerID)
+	}
+	if ga.objectDialogErr != `No container matches "garage"` {
+		t.Errorf("error = %q", ga.objectDialogErr)
+	}
+}
@@ -1,164 +1,32 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strings"
 
 	nishikiConfig "github.com/nishiki/frontend/config"
+	gioweb "github.com/nishiki/frontend/gio-web"
+	"github.com/nishiki/frontend/pkg/webserve"
 )
 
-// spaHandler implements SPA fallback routing
-type spaHandler struct {
-	staticDir  string
-	indexPath  string
-	backendURL string
-}
-
-func (h spaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Serve docs.html with backend URL injected
-	if r.URL.Path == "/docs" || r.URL.Path == "/docs/" {
-		h.serveDocs(w, r)
-		return
-	}
-
-	// Get the requested path
-	urlPath := r.URL.Path
-	path := filepath.Join(h.staticDir, urlPath)
-
-	// Check if the request is for a static asset (has file extension)
-	ext := filepath.Ext(urlPath)
-	isStaticAsset := ext != ""
-
-	// Check if file exists
-	_, err := os.Stat(path)
-	if os.IsNotExist(err) {
-		// If it's a static asset, try to find it in the root
-		if isStaticAsset {
-			// Extract just the filename
-			filename := filepath.Base(urlPath)
-			rootPath := filepath.Join(h.staticDir, filename)
-
-			// Check if file exists in root
-			if _, err := os.Stat(rootPath); err == nil {
-				// File exists in root, serve it
-				contentType := getContentType(rootPath)
-				if contentType != "" {
-					w.Header().Set("Content-Type", contentType)
-				}
-				http.ServeFile(w, r, rootPath)
-				return
-			}
-
-			// Static asset not found anywhere, return 404
-			http.NotFound(w, r)
-			return
-		}
-
-		// Not a static asset, serve index.html for SPA routing
-		http.ServeFile(w, r, h.indexPath)
-		return
-	} else if err != nil {
-		// Other error, return internal server error
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// If it's a directory, check for index.html
-	info, err := os.Stat(path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if info.IsDir() {
-		indexFile := filepath.Join(path, "index.html")
-		_, err := os.Stat(indexFile)
-		if os.IsNotExist(err) {
-			// No index.html in directory, serve root index.html
-			http.ServeFile(w, r, h.indexPath)
-			return
-		}
-	}
-
-	// File exists, serve it with proper content type
-	contentType := getContentType(path)
-	if contentType != "" {
-		w.Header().Set("Content-Type", contentType)
-	}
-	http.ServeFile(w, r, path)
-}
-
-func (h spaHandler) serveDocs(w http.ResponseWriter, r *http.Request) {
-	docsPath := filepath.Join(h.staticDir, "docs.html")
-	content, err := os.ReadFile(docsPath)
-	if err != nil {
-		http.NotFound(w, r)
-		return
-	}
-	// Inject the backend URL so the page can find the OpenAPI spec
-	injected := strings.Replace(string(content),
-		"window.__NISHIKI_BACKEND_URL__",
-		fmt.Sprintf("'%s'", h.backendURL),
-		1)
-	w.Header().Set("Content-Type", "text/html")
-	w.Write([]byte(injected)) //nolint:errcheck
-}
-
-func getContentType(path string) string {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".wasm":
-		return "application/wasm"
-	case ".js":
-		return "application/javascript"
-	case ".css":
-		return "text/css"
-	case ".html":
-		return "text/html"
-	case ".json":
-		return "application/json"
-	case ".png":
-		return "image/png"
-	case ".svg":
-		return "image/svg+xml"
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	default:
-		return ""
-	}
-}
-
 func main() {
+	dir := flag.String("dir", "", "serve the web build from this directory, reloading pages after a rebuild, instead of the one bundled into the binary")
+	flag.Parse()
+
 	slog.Info("Starting Nishiki Gio web server...")
 
 	// Load frontend configuration to get port
 	frontendConfig := nishikiConfig.LoadConfig()
 
-	// Get current working directory (should be frontend root)
-	cwd, err := os.Getwd()
+	webFS, source, err := webAssets(*dir)
 	if err != nil {
-		slog.Error("getting current working directory", "error", err)
-		os.Exit(1)
-	}
-
-	// Path relative to frontend root - using gio-web directory
-	webOutputDir := filepath.Join(cwd, "gio-web")
-
-	// Verify we're in the right place and gio-web directory exists
-	if _, err := os.Stat(webOutputDir); os.IsNotExist(err) {
-		slog.Error("gio-web directory not found; build first with: go run cmd/gio-web/main.go",
-			"cwd", cwd, "expected", webOutputDir)
-		os.Exit(1)
-	}
-
-	indexPath := filepath.Join(webOutputDir, "index.html")
-	if _, err := os.Stat(indexPath); os.IsNotExist(err) {
-		slog.Error("index.html not found", "path", indexPath)
+		slog.Error("no web build to serve", "error", err)
 		os.Exit(1)
 	}
 
@@ -168,16 +36,16 @@ func main() {
 		port = "3000"
 	}
 
-	// Create SPA handler
-	spa := spaHandler{
-		staticDir:  webOutputDir,
-		indexPath:  indexPath,
-		backendURL: frontendConfig.BackendURL,
+	// Load the build into memory; pages pick up later rebuilds on their own
+	spa, err := webserve.New(webFS, webserve.Options{BackendURL: frontendConfig.BackendURL})
+	if err != nil {
+		slog.Error("caching web assets", "source", source, "error", err)
+		os.Exit(1)
 	}
 
 	addr := ":" + port
 	slog.Info("Serving Gio app",
-		"dir", webOutputDir,
+		"source", source,
 		"url", "http://localhost:"+port,
 		"note", "SPA routing enabled — press Ctrl+C to stop")
 
@@ -185,3 +53,20 @@ func main() {
 		log.Fatalf("Error serving web application: %v\n", err)
 	}
 }
+
+// webAssets picks the web build to serve: dir when given, otherwise the build
+// bundled into this binary, otherwise ./gio-web for a binary compiled before
+// app.wasm was built. It also returns a description of where the files came
+// from for the startup log.
+func webAssets(dir string) (fs.FS, string, error) {
+	if dir == "" {
+		if gioweb.HasApp() {
+			return gioweb.FS(), "bundled", nil
+		}
+		dir = "gio-web"
+	}
+	if _, err := os.Stat(filepath.Join(dir, "index.html")); err != nil {
+		return nil, "", fmt.Errorf("%s has no index.html and this binary was built without app.wasm; run `just build` and rebuild, or pass -dir: %w", dir, err)
+	}
+	return os.DirFS(dir), dir, nil
+}
@@ -2,14 +2,25 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
+// smallTags are the build tags -small adds to strip optional features
+const smallTags = "nocjk"
+
 func main() {
-	slog.Info("Building Gio app for WebAssembly...")
+	small := flag.Bool("small", false, "size-reduced build: strip debug info, leave out the CJK font (nocjk) and run wasm-opt if installed")
+	tags := flag.String("tags", "", "extra comma-separated build tags, e.g. nocjk")
+	tinygo := flag.Bool("tinygo", false, "compile with TinyGo instead of the Go toolchain (experimental)")
+	wasmOpt := flag.Bool("wasm-opt", false, "post-process app.wasm with wasm-opt -Oz (implied by -small)")
+	flag.Parse()
+
+	slog.Info("Building Gio app for WebAssembly...", "small", *small, "tinygo", *tinygo)
 
 	// Get current working directory (should be frontend root)
 	cwd, err := os.Getwd()
@@ -35,10 +46,38 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Remember the previous build's size to report the difference
+	var previousSize int64
+	if stat, err := os.Stat(wasmOutput); err == nil {
+		previousSize = stat.Size()
+	}
+
+	buildTags := *tags
+	if *small {
+		buildTags = strings.Trim(buildTags+","+smallTags, ",")
+	}
+
 	// Build the WASM binary
-	cmd := exec.CommandContext(context.Background(), "go", "build",
-		"-o", wasmOutput,
-		"./cmd/gio-webmain")
+	var cmd *exec.Cmd
+	if *tinygo {
+		args := []string{"build", "-o", wasmOutput, "-target", "wasm", "-no-debug"}
+		if *small {
+			args = append(args, "-opt", "z")
+		}
+		if buildTags != "" {
+			args = append(args, "-tags", strings.ReplaceAll(buildTags, ",", " "))
+		}
+		cmd = exec.CommandContext(context.Background(), "tinygo", append(args, "./cmd/gio-webmain")...)
+	} else {
+		args := []string{"build", "-o", wasmOutput}
+		if *small {
+			args = append(args, "-trimpath", "-ldflags=-s -w")
+		}
+		if buildTags != "" {
+			args = append(args, "-tags", buildTags)
+		}
+		cmd = exec.CommandContext(context.Background(), "go", append(args, "./cmd/gio-webmain")...)
+	}
 
 	cmd.Env = append(os.Environ(),
 		"GOOS=js",
@@ -47,28 +86,31 @@ func main() {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	slog.Info("Running go build", "cmd", "GOOS=js GOARCH=wasm go build -o "+wasmOutput+" ./cmd/gio-webmain")
+	slog.Info("Running build", "cmd", "GOOS=js GOARCH=wasm "+strings.Join(cmd.Args, " "))
 
 	if err := cmd.Run(); err != nil {
 		slog.Error("building WASM", "error", err)
 		os.Exit(1)
 	}
 
-	// Copy wasm_exec.js from Go installation
-	goRoot := os.Getenv("GOROOT")
-	if goRoot == "" {
-		// Try to get GOROOT from go env
-		cmd := exec.CommandContext(context.Background(), "go", "env", "GOROOT")
-		output, err := cmd.Output()
-		if err != nil {
-			slog.Error("getting GOROOT", "error", err)
-			os.Exit(1)
+	removeStaleCompressed(wasmOutput)
+
+	stages := []sizeStage{{name: "compiled", size: fileSize(wasmOutput)}}
+	if *small || *wasmOpt {
+		if optimized, err := runWasmOpt(wasmOutput); err != nil {
+			slog.Warn("skipping wasm-opt", "error", err)
+		} else if optimized {
+			stages = append(stages, sizeStage{name: "wasm-opt -Oz", size: fileSize(wasmOutput)})
 		}
-		goRoot = string(output[:len(output)-1]) // Remove trailing newline
 	}
 
-	// Copy wasm_exec.js from Go installation (Go 1.23+)
-	wasmExecSrc := filepath.Join(goRoot, "lib", "wasm", "wasm_exec.js")
+	// Copy wasm_exec.js from the toolchain that built app.wasm; TinyGo ships
+	// its own, which is not compatible with the Go one
+	wasmExecSrc, err := wasmExecSource(*tinygo)
+	if err != nil {
+		slog.Error("locating wasm_exec.js", "error", err)
+		os.Exit(1)
+	}
 	wasmExecDst := filepath.Join(webOutputDir, "wasm_exec.js")
 
 	input, err := os.ReadFile(wasmExecSrc)
@@ -82,6 +124,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := writePWAFiles(webOutputDir); err != nil {
+		slog.Error("writing install-as-app files", "error", err)
+		os.Exit(1)
+	}
+
 	// Update vendor assets (redoc) if missing
 	vendorDir := filepath.Join(webOutputDir, "vendor")
 	redocPath := filepath.Join(vendorDir, "redoc.standalone.js")
@@ -99,9 +146,7 @@ func main() {
 
 	slog.Info("WASM build completed successfully", "output", wasmOutput)
 
-	if stat, err := os.Stat(wasmOutput); err == nil {
-		slog.Info("WASM size", "mb", float64(stat.Size())/(1024*1024))
-	}
+	reportSizes(previousSize, stages, wasmOutput)
 
 	slog.Info("Next steps: 1) create index.html in gio-web; 2) serve with `go run cmd/serve/main.go`")
 }
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/nishiki/frontend/pkg/webserve"
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// iconSizes are the PNG icons written next to the manifest; browsers want
+// 192 and 512 pixels to offer installing the app
+var iconSizes = []int{192, 512}
+
+// serviceWorker caches the app shell under the build hash the server fills
+// in, so a new build gets a new cache and the old one is dropped once the
+// new worker takes over. Pages go to the network first so a rebuild is seen
+// straight away, and fall back to the cached shell offline. Versioned assets
+// never change and are served from the cache. Everything else, the API
+// included, is left to the network.
+const serviceWorker = `// Generated by cmd/web; the server fills in the build hash.
+const BUILD = '` + webserve.ServiceWorkerBuildPlaceholder + `';
+const CACHE = 'nishiki-' + BUILD;
+const SHELL = ['/', '/app.wasm?v=' + BUILD, '/wasm_exec.js?v=' + BUILD, '/manifest.webmanifest', %s];
+
+self.addEventListener('install', (event) => {
+    event.waitUntil(caches.open(CACHE)
+        .then((cache) => cache.addAll(SHELL))
+        .then(() => self.skipWaiting()));
+});
+
+self.addEventListener('activate', (event) => {
+    event.waitUntil(caches.keys()
+        .then((keys) => Promise.all(keys
+            .filter((key) => key.startsWith('nishiki-') && key !== CACHE)
+            .map((key) => caches.delete(key))))
+        .then(() => self.clients.claim()));
+});
+
+self.addEventListener('fetch', (event) => {
+    const request = event.request;
+    const url = new URL(request.url);
+    if (request.method !== 'GET' || url.origin !== self.location.origin) {
+        return;
+    }
+    if (request.mode === 'navigate') {
+        event.respondWith(fetch(request).catch(() => caches.match('/', { cacheName: CACHE })));
+        return;
+    }
+    if (url.searchParams.get('v') === BUILD || SHELL.includes(url.pathname)) {
+        event.respondWith(caches.match(request, { cacheName: CACHE })
+            .then((cached) => cached || fetch(request)));
+    }
+});
+`
+
+// webManifest is the subset of the Web App Manifest the app uses
+type webManifest struct {
+	Name            string         `json:"name"`
+	ShortName       string         `json:"short_name"`
+	Description     string         `json:"description"`
+	StartURL        string         `json:"start_url"`
+	Scope           string         `json:"scope"`
+	Display         string         `json:"display"`
+	BackgroundColor string         `json:"background_color"`
+	ThemeColor      string         `json:"theme_color"`
+	Icons           []manifestIcon `json:"icons"`
+}
+
+type manifestIcon struct {
+	Src     string `json:"src"`
+	Sizes   string `json:"sizes"`
+	Type    string `json:"type"`
+	Purpose string `json:"purpose"`
+}
+
+// writePWAFiles writes the service worker, web manifest and icons that let
+// browsers install the app and open it offline. Their colors follow the
+// default theme.
+func writePWAFiles(webOutputDir string) error {
+	manifest := webManifest{
+		Name:            "Nishiki - Inventory Management",
+		ShortName:       "Nishiki",
+		Description:     "Keep track of what you own and where it is",
+		StartURL:        "/",
+		Scope:           "/",
+		Display:         "standalone",
+		BackgroundColor: hexColor(theme.DarkPalette.Background),
+		ThemeColor:      hexColor(theme.DarkPalette.Background),
+	}
+	var shellIcons string
+	for _, size := range iconSizes {
+		name := fmt.Sprintf("icon-%d.png", size)
+		if err := writeIcon(filepath.Join(webOutputDir, name), size); err != nil {
+			return err
+		}
+		manifest.Icons = append(manifest.Icons, manifestIcon{
+			Src:     "/" + name,
+			Sizes:   fmt.Sprintf("%dx%d", size, size),
+			Type:    "image/png",
+			Purpose: "any maskable",
+		})
+		if shellIcons != "" {
+			shellIcons += ", "
+		}
+		shellIcons += "'/" + name + "'"
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding web manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(webOutputDir, "manifest.webmanifest"), append(manifestJSON, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing web manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(webOutputDir, webserve.ServiceWorkerScript), fmt.Appendf(nil, serviceWorker, shellIcons), 0644); err != nil {
+		return fmt.Errorf("writing service worker: %w", err)
+	}
+	return nil
+}
+
+// writeIcon draws the app icon, an open box on the primary color. The box
+// stays inside the middle 60% so masks that crop to a circle keep it whole.
+func writeIcon(path string, size int) error {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{theme.DarkPalette.Primary}, image.Point{}, draw.Src)
+
+	box := theme.DarkPalette.Background
+	// Drawn on a 20x20 grid, centered when size is not a multiple of 20
+	unit := size / 20
+	offset := (size - 20*unit) / 2
+	left, right := 6*unit, 14*unit
+	fill := func(x0, y0, x1, y1 int, c color.NRGBA) {
+		r := image.Rect(x0, y0, x1, y1).Add(image.Pt(offset, offset))
+		draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+	}
+	// Lid, then the body below it with its handle slot
+	fill(left-unit/2, 6*unit, right+unit/2, 8*unit, box)
+	fill(left, 9*unit, right, 14*unit, box)
+	fill(9*unit, 10*unit, 11*unit, 11*unit, theme.DarkPalette.Primary)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating icon: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("encoding icon: %w", err)
+	}
+	return f.Close()
+}
+
+func hexColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// sizeStage is app.wasm's size after one step of the build
+type sizeStage struct {
+	name string
+	size int64
+}
+
+func fileSize(path string) int64 {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return stat.Size()
+}
+
+// runWasmOpt shrinks the binary in place with Binaryen's wasm-opt. Reports
+// false when wasm-opt is not installed, since it is an optional extra.
+func runWasmOpt(wasmPath string) (bool, error) {
+	bin, err := exec.LookPath("wasm-opt")
+	if err != nil {
+		slog.Info("wasm-opt not found on PATH; install binaryen to shrink app.wasm further")
+		return false, nil
+	}
+	tmp := wasmPath + ".opt"
+	// Go's wasm output relies on these post-MVP features
+	cmd := exec.CommandContext(context.Background(), bin, "-Oz",
+		"--enable-bulk-memory", "--enable-sign-ext", "--enable-nontrapping-float-to-int",
+		"-o", tmp, wasmPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	slog.Info("Running wasm-opt", "cmd", strings.Join(cmd.Args, " "))
+	if err := cmd.Run(); err != nil {
+		os.Remove(tmp) //nolint:errcheck
+		return false, fmt.Errorf("wasm-opt: %w", err)
+	}
+	if err := os.Rename(tmp, wasmPath); err != nil {
+		return false, fmt.Errorf("replacing app.wasm: %w", err)
+	}
+	return true, nil
+}
+
+// wasmExecSource finds the wasm_exec.js matching the compiler
+func wasmExecSource(tinygo bool) (string, error) {
+	if tinygo {
+		out, err := exec.CommandContext(context.Background(), "tinygo", "env", "TINYGOROOT").Output()
+		if err != nil {
+			return "", fmt.Errorf("tinygo env TINYGOROOT: %w", err)
+		}
+		return filepath.Join(strings.TrimSpace(string(out)), "targets", "wasm_exec.js"), nil
+	}
+
+	goRoot := os.Getenv("GOROOT")
+	if goRoot == "" {
+		// Try to get GOROOT from go env
+		out, err := exec.CommandContext(context.Background(), "go", "env", "GOROOT").Output()
+		if err != nil {
+			return "", fmt.Errorf("go env GOROOT: %w", err)
+		}
+		goRoot = strings.TrimSpace(string(out))
+	}
+	if goRoot == "" {
+		return "", errors.New("GOROOT is empty")
+	}
+	// Go 1.23+ keeps it under lib/wasm
+	return filepath.Join(goRoot, "lib", "wasm", "wasm_exec.js"), nil
+}
+
+// removeStaleCompressed deletes app.wasm.br/.gz left by an earlier build so
+// the server does not hand out an old binary to clients that accept them
+func removeStaleCompressed(wasmPath string) {
+	for _, ext := range []string{".br", ".gz"} {
+		if err := os.Remove(wasmPath + ext); err == nil {
+			slog.Info("Removed stale pre-compressed copy", "file", filepath.Base(wasmPath+ext))
+		}
+	}
+}
+
+// reportSizes logs app.wasm's size after each build step, the change from
+// the previous build and roughly what a browser downloads with gzip
+func reportSizes(previous int64, stages []sizeStage, wasmPath string) {
+	for i, stage := range stages {
+		attrs := []any{"stage", stage.name, "mb", megabytes(stage.size)}
+		if i > 0 {
+			attrs = append(attrs, "delta", sizeDelta(stages[i-1].size, stage.size))
+		}
+		slog.Info("WASM size", attrs...)
+	}
+
+	final := stages[len(stages)-1].size
+	if previous > 0 {
+		slog.Info("WASM size vs previous build", "previous_mb", megabytes(previous), "mb", megabytes(final), "delta", sizeDelta(previous, final))
+	}
+	if gz, err := gzipSize(wasmPath); err == nil {
+		slog.Info("WASM transfer size", "gzip_mb", megabytes(gz))
+	}
+}
+
+func megabytes(n int64) string {
+	return fmt.Sprintf("%.2f", float64(n)/(1024*1024))
+}
+
+// sizeDelta formats a size change, e.g. "-5.12 MB (-15.8%)"
+func sizeDelta(before, after int64) string {
+	diff := after - before
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	pct := 0.0
+	if before > 0 {
+		pct = float64(after-before) / float64(before) * 100
+	}
+	return fmt.Sprintf("%s%s MB (%+.1f%%)", sign, megabytes(diff), pct)
+}
+
+func gzipSize(path string) (int64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := zw.Write(content); err != nil {
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	return int64(buf.Len()), nil
+}
@@ -1,5 +1,11 @@
 package config
 
+import (
+	"cmp"
+	"strings"
+	"time"
+)
+
 // Config holds application configuration
 type Config struct {
 	BackendURL  string `mapstructure:"backend_url"`
@@ -7,4 +13,132 @@ type Config struct {
 	ClientID    string `mapstructure:"client_id"`
 	RedirectURL string `mapstructure:"redirect_url"`
 	Port        string `mapstructure:"port"`
+
+	// AuthorizeURL and EndSessionURL override the Authentik endpoint layout
+	// under AuthURL, for other OIDC providers (Keycloak, Auth0, Dex).
+	AuthorizeURL  string `mapstructure:"authorize_url"`
+	EndSessionURL string `mapstructure:"end_session_url"`
+
+	// ErrorReporting sends crashes and failed API calls to the backend's
+	// /client-errors endpoint. Off unless the deployment opts in.
+	ErrorReporting bool `mapstructure:"error_reporting"`
+
+	// Network tunes retries and the circuit breaker of API requests.
+	Network NetworkConfig `mapstructure:"network"`
+
+	// ProfileName labels the account the top-level settings sign in to in
+	// the profile switcher; DefaultProfileName when empty.
+	ProfileName string `mapstructure:"profile_name"`
+
+	// Profiles are further accounts to switch between, such as a shared
+	// household account in another Authentik realm.
+	Profiles []Profile `mapstructure:"profiles"`
+
+	// profile is the name of the entry in Profiles this configuration was
+	// made for by ForProfile; empty for the top-level settings
+	profile string
+}
+
+// DefaultProfileName labels the top-level settings when profile_name is unset
+const DefaultProfileName = "Personal"
+
+// Profile is one [[profiles]] entry. Empty fields fall back to the top-level
+// settings, so a profile in another realm usually only sets auth_url and
+// client_id.
+type Profile struct {
+	Name          string `mapstructure:"name"`
+	BackendURL    string `mapstructure:"backend_url"`
+	AuthURL       string `mapstructure:"auth_url"`
+	ClientID      string `mapstructure:"client_id"`
+	AuthorizeURL  string `mapstructure:"authorize_url"`
+	EndSessionURL string `mapstructure:"end_session_url"`
+}
+
+// ProfileNames lists the profiles to switch between, the top-level one first.
+func (c *Config) ProfileNames() []string {
+	names := []string{c.defaultProfileName()}
+	for _, p := range c.Profiles {
+		if p.Name != "" {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// ActiveProfile returns the name of the profile this configuration is for.
+func (c *Config) ActiveProfile() string {
+	if c.profile != "" {
+		return c.profile
+	}
+	return c.defaultProfileName()
+}
+
+// ProfileKey namespaces what is stored per profile, such as tokens. It is
+// empty for the top-level profile so its sign-in survives adding profiles.
+func (c *Config) ProfileKey() string {
+	return c.profile
+}
+
+// ForProfile returns a copy of the configuration with the named profile's
+// settings applied. An unknown name gives the top-level profile.
+func (c *Config) ForProfile(name string) *Config {
+	cfg := *c
+	cfg.profile = ""
+	for _, p := range c.Profiles {
+		if p.Name == "" || p.Name != name {
+			continue
+		}
+		cfg.profile = p.Name
+		cfg.BackendURL = cmp.Or(p.BackendURL, c.BackendURL)
+		cfg.AuthURL = cmp.Or(p.AuthURL, c.AuthURL)
+		cfg.ClientID = cmp.Or(p.ClientID, c.ClientID)
+		// A profile in another realm must not inherit endpoint overrides
+		// pointing at the top-level provider
+		if p.AuthURL != "" {
+			cfg.AuthorizeURL, cfg.EndSessionURL = p.AuthorizeURL, p.EndSessionURL
+		} else {
+			cfg.AuthorizeURL = cmp.Or(p.AuthorizeURL, c.AuthorizeURL)
+			cfg.EndSessionURL = cmp.Or(p.EndSessionURL, c.EndSessionURL)
+		}
+		break
+	}
+	return &cfg
+}
+
+func (c *Config) defaultProfileName() string {
+	return cmp.Or(c.ProfileName, DefaultProfileName)
+}
+
+// NetworkConfig holds the [network] section. Zero values use the API
+// client's defaults; max_retries = -1 turns retries off.
+type NetworkConfig struct {
+	MaxRetries       int           `mapstructure:"max_retries"`
+	RetryDelay       time.Duration `mapstructure:"retry_delay"`
+	MaxRetryDelay    time.Duration `mapstructure:"max_retry_delay"`
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+}
+
+// APIBasePath is the version prefix the backend serves its API under
+const APIBasePath = "/v1"
+
+// APIURL returns the base URL of the versioned backend API.
+func (c *Config) APIURL() string {
+	return strings.TrimRight(c.BackendURL, "/") + APIBasePath
+}
+
+// AuthorizeEndpoint returns the provider's authorization endpoint.
+func (c *Config) AuthorizeEndpoint() string {
+	if c.AuthorizeURL != "" {
+		return c.AuthorizeURL
+	}
+	return strings.TrimRight(c.AuthURL, "/") + "/application/o/authorize/"
+}
+
+// EndSessionEndpoint returns the page that signs the user out of the provider.
+func (c *Config) EndSessionEndpoint() string {
+	if c.EndSessionURL != "" {
+		return c.EndSessionURL
+	}
+	return strings.TrimRight(c.AuthURL, "/") + "/application/o/nishiki/end-session/"
 }
@@ -37,7 +37,8 @@ func LoadConfig() *Config {
 		"auth_url", cfg.AuthURL,
 		"client_id", cfg.ClientID,
 		"redirect_url", cfg.RedirectURL,
-		"port", cfg.Port)
+		"port", cfg.Port,
+		"error_reporting", cfg.ErrorReporting)
 
 	return &cfg
 }
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForProfile(t *testing.T) {
+	base := &Config{
+		BackendURL:   "http://localhost:3001",
+		AuthURL:      "https://authentik.local",
+		ClientID:     "personal",
+		AuthorizeURL: "https://authentik.local/authorize",
+		Profiles: []Profile{
+			{Name: "Household", ClientID: "household"},
+			{Name: "Work", AuthURL: "https://sso.work.example.com", ClientID: "work"},
+		},
+	}
+
+	if got, want := base.ProfileNames(), []string{"Personal", "Household", "Work"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ProfileNames() = %v, want %v", got, want)
+	}
+
+	household := base.ForProfile("Household")
+	if household.ActiveProfile() != "Household" || household.ProfileKey() != "Household" {
+		t.Errorf("ForProfile(Household) is for %q (key %q)", household.ActiveProfile(), household.ProfileKey())
+	}
+	if household.ClientID != "household" || household.BackendURL != base.BackendURL || household.AuthorizeURL != base.AuthorizeURL {
+		t.Errorf("ForProfile(Household) = %+v, want its client ID over the top-level settings", household)
+	}
+
+	// Another realm must not sign in through the top-level provider's endpoints
+	if work := base.ForProfile("Work"); work.AuthURL != "https://sso.work.example.com" || work.AuthorizeURL != "" {
+		t.Errorf("ForProfile(Work) auth = %q, authorize = %q", work.AuthURL, work.AuthorizeURL)
+	}
+
+	for _, name := range []string{"Personal", "Unknown"} {
+		if cfg := base.ForProfile(name); cfg.ProfileKey() != "" || cfg.ClientID != "personal" {
+			t.Errorf("ForProfile(%s) = key %q, client %q; want the top-level profile", name, cfg.ProfileKey(), cfg.ClientID)
+		}
+	}
+	if base.ClientID != "personal" {
+		t.Error("ForProfile modified the base configuration")
+	}
+}
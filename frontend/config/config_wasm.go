@@ -30,18 +30,29 @@ func LoadConfig() *Config {
 		slog.Error("unmarshaling config", "error", err)
 	}
 
+	// A server that hosts the app next to its API (the backend's single-binary
+	// mode) names the API base in the page, usually as a path like "/api"
+	origin := js.Global().Get("window").Get("location").Get("origin").String()
+	if backendURL := js.Global().Get("__NISHIKI_BACKEND_URL__"); backendURL.Type() == js.TypeString {
+		cfg.BackendURL = backendURL.String()
+		if strings.HasPrefix(cfg.BackendURL, "/") {
+			cfg.BackendURL = origin + cfg.BackendURL
+		}
+	}
+
 	// Auto-generate redirect URL from the browser's actual origin so it matches
 	// the URL the user is accessing regardless of port mapping or reverse proxy.
 	if cfg.RedirectURL == "" {
-		origin := js.Global().Get("window").Get("location").Get("origin").String()
 		cfg.RedirectURL = origin + "/auth/callback"
 	}
 
 	slog.Info("Loaded config",
+		"backend_url", cfg.BackendURL,
 		"auth_url", cfg.AuthURL,
 		"client_id", cfg.ClientID,
 		"redirect_url", cfg.RedirectURL,
-		"port", cfg.Port)
+		"port", cfg.Port,
+		"error_reporting", cfg.ErrorReporting)
 
 	return &cfg
 }
@@ -0,0 +1,27 @@
+// Package gioweb bundles the web build output into any binary that imports
+// it, so the SPA can be served without the gio-web directory on disk. Build
+// the WASM app first (`just build`) or the bundle has no app.wasm.
+package gioweb
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// all: keeps vendor/.gitkeep, so the pattern matches before update-vendor.sh
+// has fetched anything
+//
+//go:embed all:*
+var files embed.FS
+
+// FS returns the bundled web build: index.html, docs.html, wasm_exec.js,
+// the vendored scripts and app.wasm when it was built before compiling.
+func FS() fs.FS {
+	return files
+}
+
+// HasApp reports whether app.wasm was built before this binary was compiled
+func HasApp() bool {
+	_, err := fs.Stat(files, "app.wasm")
+	return err == nil
+}
@@ -0,0 +1,34 @@
+// Generated by cmd/web; the server fills in the build hash.
+const BUILD = '__NISHIKI_BUILD__';
+const CACHE = 'nishiki-' + BUILD;
+const SHELL = ['/', '/app.wasm?v=' + BUILD, '/wasm_exec.js?v=' + BUILD, '/manifest.webmanifest', '/icon-192.png', '/icon-512.png'];
+
+self.addEventListener('install', (event) => {
+    event.waitUntil(caches.open(CACHE)
+        .then((cache) => cache.addAll(SHELL))
+        .then(() => self.skipWaiting()));
+});
+
+self.addEventListener('activate', (event) => {
+    event.waitUntil(caches.keys()
+        .then((keys) => Promise.all(keys
+            .filter((key) => key.startsWith('nishiki-') && key !== CACHE)
+            .map((key) => caches.delete(key))))
+        .then(() => self.clients.claim()));
+});
+
+self.addEventListener('fetch', (event) => {
+    const request = event.request;
+    const url = new URL(request.url);
+    if (request.method !== 'GET' || url.origin !== self.location.origin) {
+        return;
+    }
+    if (request.mode === 'navigate') {
+        event.respondWith(fetch(request).catch(() => caches.match('/', { cacheName: CACHE })));
+        return;
+    }
+    if (url.searchParams.get('v') === BUILD || SHELL.includes(url.pathname)) {
+        event.respondWith(caches.match(request, { cacheName: CACHE })
+            .then((cached) => cached || fetch(request)));
+    }
+});
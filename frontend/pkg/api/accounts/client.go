@@ -0,0 +1,176 @@
+package accounts
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles account-level API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new accounts API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// deleteRequest repeats the username to confirm account deletion
+type deleteRequest struct {
+	ConfirmUsername string `json:"confirm_username"`
+}
+
+// Backup downloads the full JSON backup archive for the account
+func (c *Client) Backup(accountID string) ([]byte, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/backup", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, common.CheckResponse(resp)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	return data, nil
+}
+
+// ExportData downloads the personal data export for the account. The backend
+// only serves it to a recently signed-in session.
+func (c *Client) ExportData(accountID string) ([]byte, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/data-export", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, common.CheckResponse(resp)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data export: %w", err)
+	}
+	return data, nil
+}
+
+// Delete permanently deletes the account's data. confirmUsername must match
+// the account username and the session must be recently signed in.
+func (c *Client) Delete(accountID, confirmUsername string) error {
+	resp, err := c.common.Request(http.MethodDelete, "/accounts/"+accountID, deleteRequest{ConfirmUsername: confirmUsername})
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// Dashboard fetches the signed-in user, their groups and their collections
+// with stock and expiry counts in one request. Collections are ordered by
+// sort.
+func (c *Client) Dashboard(accountID string, sort types.SortOptions) (*types.Dashboard, error) {
+	url := fmt.Sprintf("/accounts/%s/dashboard", accountID)
+	if q := sort.Query(); q != "" {
+		url += "?" + q
+	}
+	resp, err := c.common.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Dashboard](resp)
+}
+
+// GetPreferences fetches the account's saved per-view sort and filter choices
+func (c *Client) GetPreferences(accountID string) (*types.UserPreferences, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/preferences", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.UserPreferences](resp)
+}
+
+// UpdatePreferences saves the given views. Views left out are kept by the
+// backend; a nil preference forgets its view.
+func (c *Client) UpdatePreferences(accountID string, req types.UpdateUserPreferencesRequest) (*types.UserPreferences, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/preferences", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.UserPreferences](resp)
+}
+
+// UpdateCollectionOrder pins or places the given collections. Collections
+// left out keep their placement.
+func (c *Client) UpdateCollectionOrder(accountID string, items []types.ItemOrderRequest) (*types.UserPreferences, error) {
+	return c.updateOrder(fmt.Sprintf("/accounts/%s/collections/order", accountID), items)
+}
+
+// UpdateContainerOrder pins or places the given containers
+func (c *Client) UpdateContainerOrder(accountID string, items []types.ItemOrderRequest) (*types.UserPreferences, error) {
+	return c.updateOrder(fmt.Sprintf("/accounts/%s/containers/order", accountID), items)
+}
+
+func (c *Client) updateOrder(path string, items []types.ItemOrderRequest) (*types.UserPreferences, error) {
+	resp, err := c.common.Patch(path, types.UpdateItemOrderRequest{Items: items})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.UserPreferences](resp)
+}
+
+// Sessions lists the devices the account is signed in on, most recently used
+// first
+func (c *Client) Sessions(accountID string) (*types.SessionList, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/sessions", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.SessionList](resp)
+}
+
+// SetSessionTrusted marks a session trusted, so signing out other devices
+// leaves it signed in, or clears the mark
+func (c *Client) SetSessionTrusted(accountID, sessionID string, trusted bool) (*types.Session, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/sessions/%s", accountID, sessionID), types.UpdateSessionRequest{Trusted: trusted})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Session](resp)
+}
+
+// RevokeSession signs out one other session
+func (c *Client) RevokeSession(accountID, sessionID string) (*types.RevokeSessionsResult, error) {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/sessions/%s", accountID, sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RevokeSessionsResult](resp)
+}
+
+// RevokeOtherSessions signs out every untrusted session but the current one
+func (c *Client) RevokeOtherSessions(accountID string) (*types.RevokeSessionsResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/sessions/revoke-others", accountID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RevokeSessionsResult](resp)
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles the admin dashboard API calls. The backend refuses them
+// with 403 for users outside the admin group.
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new admin API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// ListUsers fetches every user with their usage, most recently seen first
+func (c *Client) ListUsers() (*types.AdminUserList, error) {
+	resp, err := c.common.Get("/admin/users")
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.AdminUserList](resp)
+}
+
+// Stats fetches the deployment-wide totals
+func (c *Client) Stats() (*types.SystemStats, error) {
+	resp, err := c.common.Get("/admin/stats")
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.SystemStats](resp)
+}
+
+// SetDisabled disables or re-enables a user's account
+func (c *Client) SetDisabled(userID string, disabled bool) error {
+	action := "enable"
+	if disabled {
+		action = "disable"
+	}
+	resp, err := c.common.Post(fmt.Sprintf("/admin/users/%s/%s", userID, action), nil)
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
@@ -0,0 +1,103 @@
+package automations
+
+import (
+	"fmt"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles automation rule API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new automations API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// List gets the account's automation rules, oldest first
+func (c *Client) List(accountID string) (*types.AutomationRuleList, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/automations", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.AutomationRuleList](resp)
+}
+
+// Create adds a rule
+func (c *Client) Create(accountID string, req types.AutomationRuleRequest) (*types.AutomationRule, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/automations", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.AutomationRule](resp)
+}
+
+// Update replaces a rule's settings. Webhooks sent without a secret keep
+// the one they have
+func (c *Client) Update(accountID, ruleID string, req types.AutomationRuleRequest) (*types.AutomationRule, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/automations/%s", accountID, ruleID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.AutomationRule](resp)
+}
+
+// Delete removes a rule
+func (c *Client) Delete(accountID, ruleID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/automations/%s", accountID, ruleID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// SigningKey describes the account's webhook signing key, without the key
+func (c *Client) SigningKey(accountID string) (*types.WebhookSigningKey, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/webhooks/signing-key", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.WebhookSigningKey](resp)
+}
+
+// RotateSigningKey makes a new webhook signing key and returns it, the only
+// time it is shown. The old key keeps signing for graceHours.
+func (c *Client) RotateSigningKey(accountID string, graceHours int) (*types.WebhookSigningKey, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/webhooks/signing-key/rotate", accountID), types.RotateWebhookKeyRequest{GraceHours: &graceHours})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.WebhookSigningKey](resp)
+}
+
+// Deliveries gets the account's most recent webhook deliveries, newest first
+func (c *Client) Deliveries(accountID string) (*types.WebhookDeliveryList, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/webhooks/deliveries", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.WebhookDeliveryList](resp)
+}
+
+// RetryDelivery sends a delivery again and returns it with the attempt
+// recorded
+func (c *Client) RetryDelivery(accountID, deliveryID string) (*types.WebhookDelivery, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/webhooks/deliveries/%s/retry", accountID, deliveryID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.WebhookDelivery](resp)
+}
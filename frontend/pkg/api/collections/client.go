@@ -1,7 +1,11 @@
 package collections
 
 import (
+	"bytes"
 	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
 
 	"github.com/nishiki/frontend/pkg/api/common"
 	"github.com/nishiki/frontend/pkg/types"
@@ -19,9 +23,13 @@ func NewClient(commonClient *common.Client) *Client {
 	}
 }
 
-// List gets all collections for a user
-func (c *Client) List(accountID string) ([]types.Collection, error) {
-	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collections", accountID))
+// List gets all collections for a user in the requested order
+func (c *Client) List(accountID string, sort types.SortOptions) ([]types.Collection, error) {
+	url := fmt.Sprintf("/accounts/%s/collections", accountID)
+	if q := sort.Query(); q != "" {
+		url += "?" + q
+	}
+	resp, err := c.common.Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +67,17 @@ func (c *Client) Update(accountID, collectionID string, req types.UpdateCollecti
 	return common.DecodeResponse[types.Collection](resp)
 }
 
+// Patch changes only the fields in patch, leaving the rest of the
+// collection as it is
+func (c *Client) Patch(accountID, collectionID string, patch types.MergePatch) (*types.Collection, error) {
+	resp, err := c.common.MergePatch(fmt.Sprintf("/accounts/%s/collections/%s", accountID, collectionID), patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Collection](resp)
+}
+
 // Delete deletes a collection. If force is true, cascade-deletes containers and objects.
 func (c *Client) Delete(accountID, collectionID string, force bool) error {
 	path := fmt.Sprintf("/accounts/%s/collections/%s", accountID, collectionID)
@@ -83,12 +102,120 @@ func (c *Client) UpdateSchema(accountID, collectionID string, req types.UpdatePr
 	return common.CheckResponse(resp)
 }
 
-// ImportObjects imports objects to a collection in bulk
-func (c *Client) ImportObjects(accountID, collectionID string, req types.BulkImportCollectionRequest) error {
+// UpdateShelfLife replaces the default shelf life rules for a collection
+func (c *Client) UpdateShelfLife(accountID, collectionID string, req types.UpdateShelfLifeRequest) (*types.Collection, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/collections/%s/shelf-life", accountID, collectionID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Collection](resp)
+}
+
+// FloorPlanImage is the image a collection's containers are placed on
+type FloorPlanImage struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// UploadFloorPlan sets the collection's floor plan, replacing any earlier
+// one. Containers keep their positions.
+func (c *Client) UploadFloorPlan(accountID, collectionID string, image FloorPlanImage) (*types.FloorPlan, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", multipart.FileContentDisposition("file", image.Filename))
+	header.Set("Content-Type", image.ContentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s: %w", image.Filename, err)
+	}
+	if _, err := part.Write(image.Data); err != nil {
+		return nil, fmt.Errorf("failed to add %s: %w", image.Filename, err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build upload: %w", err)
+	}
+
+	resp, err := c.common.UploadReplace(fmt.Sprintf("/accounts/%s/collections/%s/floor-plan", accountID, collectionID), form.FormDataContentType(), &body, int64(body.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.FloorPlan](resp)
+}
+
+// ImportObjects queues a bulk import into a collection. The import runs in
+// the background; follow the returned job with the imports client.
+func (c *Client) ImportObjects(accountID, collectionID string, req types.BulkImportCollectionRequest) (*types.ImportJob, error) {
 	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/collections/%s/import", accountID, collectionID), req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return common.CheckResponse(resp)
+	return common.DecodeResponse[types.ImportJob](resp)
+}
+
+// ImportFile is a CSV or JSON file uploaded as-is for the backend to parse
+type ImportFile struct {
+	Filename    string
+	Content     []byte
+	OmitColumns []string // columns dropped from every row
+}
+
+// UploadImport queues a bulk import of a whole file, sent as
+// multipart/form-data with req's options as form fields; req.Data is not
+// sent. Follow the returned job with the imports client.
+func (c *Client) UploadImport(accountID, collectionID string, file ImportFile, req types.BulkImportCollectionRequest) (*types.ImportJob, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"format":            req.Format,
+		"distribution_mode": req.DistributionMode,
+		"location_column":   req.LocationColumn,
+		"name_column":       req.NameColumn,
+		"source_format":     req.SourceFormat,
+	}
+	if req.TargetContainerID != nil {
+		fields["target_container_id"] = *req.TargetContainerID
+	}
+	if req.InferSchema {
+		fields["infer_schema"] = strconv.FormatBool(req.InferSchema)
+	}
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := form.WriteField(key, value); err != nil {
+			return nil, fmt.Errorf("failed to build upload: %w", err)
+		}
+	}
+	for _, tag := range req.DefaultTags {
+		if err := form.WriteField("default_tags", tag); err != nil {
+			return nil, fmt.Errorf("failed to build upload: %w", err)
+		}
+	}
+	for _, column := range file.OmitColumns {
+		if err := form.WriteField("omit_columns", column); err != nil {
+			return nil, fmt.Errorf("failed to build upload: %w", err)
+		}
+	}
+	part, err := form.CreateFormFile("file", file.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s: %w", file.Filename, err)
+	}
+	if _, err := part.Write(file.Content); err != nil {
+		return nil, fmt.Errorf("failed to add %s: %w", file.Filename, err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build upload: %w", err)
+	}
+
+	resp, err := c.common.Upload(fmt.Sprintf("/accounts/%s/collections/%s/import", accountID, collectionID), form.FormDataContentType(), &body, int64(body.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ImportJob](resp)
 }
@@ -0,0 +1,106 @@
+package comments
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles collection and object comment API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new comments API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+func collectionCommentsPath(accountID, collectionID string) string {
+	return fmt.Sprintf("/accounts/%s/collections/%s/comments", accountID, collectionID)
+}
+
+func objectCommentsPath(accountID, objectID string) string {
+	return fmt.Sprintf("/accounts/%s/objects/%s/comments", accountID, objectID)
+}
+
+// ListCollection gets up to limit comments on the collection and its
+// objects posted before the given time (newest when zero), newest first
+func (c *Client) ListCollection(accountID, collectionID string, before time.Time, limit int) (*types.CommentList, error) {
+	return c.list(collectionCommentsPath(accountID, collectionID), before, limit)
+}
+
+// ListObject gets up to limit comments on the object, newest first
+func (c *Client) ListObject(accountID, objectID string, before time.Time, limit int) (*types.CommentList, error) {
+	return c.list(objectCommentsPath(accountID, objectID), before, limit)
+}
+
+func (c *Client) list(path string, before time.Time, limit int) (*types.CommentList, error) {
+	query := url.Values{"limit": {strconv.Itoa(limit)}}
+	if !before.IsZero() {
+		query.Set("before", before.Format(time.RFC3339Nano))
+	}
+	resp, err := c.common.Get(path + "?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CommentList](resp)
+}
+
+// CreateOnCollection posts a comment on the collection
+func (c *Client) CreateOnCollection(accountID, collectionID, body string) (*types.Comment, error) {
+	resp, err := c.common.Post(collectionCommentsPath(accountID, collectionID), types.CreateCommentRequest{Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Comment](resp)
+}
+
+// CreateOnObject posts a comment on the object
+func (c *Client) CreateOnObject(accountID, objectID, body string) (*types.Comment, error) {
+	resp, err := c.common.Post(objectCommentsPath(accountID, objectID), types.CreateCommentRequest{Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Comment](resp)
+}
+
+// Delete deletes a comment
+func (c *Client) Delete(accountID, commentID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/comments/%s", accountID, commentID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// MarkRead clears the collection's unread comments up to readAt, or up to
+// now when readAt is zero
+func (c *Client) MarkRead(accountID, collectionID string, readAt time.Time) error {
+	resp, err := c.common.Post(collectionCommentsPath(accountID, collectionID)+"/read", types.MarkCommentsReadRequest{ReadAt: readAt})
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// Unread gets the unread comment counts of the user's collections
+func (c *Client) Unread(accountID string) (*types.UnreadComments, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/comments/unread", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.UnreadComments](resp)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json/v2"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,14 +25,21 @@ type Client struct {
 	HTTPClient   *http.Client
 	TokenFetcher TokenFetcher
 	OnAuthError  func() // called when the token cannot be obtained or a 401 is received
+	// OnRequestError is called when a request fails to reach the server or
+	// the server answers with a 5xx status; status is 0 for transport errors.
+	OnRequestError func(method, endpoint string, status int, err error)
+
+	cache responseCache
 }
 
-// NewClient creates a new API client
-func NewClient(baseURL string, tokenFetcher TokenFetcher) *Client {
+// NewClient creates a new API client whose requests go through transport
+// (see Transport); nil means http.DefaultTransport.
+func NewClient(baseURL string, tokenFetcher TokenFetcher, transport http.RoundTripper) *Client {
 	return &Client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: transport,
+			Timeout:   30 * time.Second,
 		},
 		TokenFetcher: tokenFetcher,
 	}
@@ -39,6 +47,12 @@ func NewClient(baseURL string, tokenFetcher TokenFetcher) *Client {
 
 // Request makes an authenticated HTTP request
 func (c *Client) Request(method, endpoint string, body any) (*http.Response, error) {
+	return c.send(context.Background(), method, endpoint, "application/json", body)
+}
+
+// send makes an authenticated request whose body, if any, is marshalled as
+// JSON and labelled with contentType
+func (c *Client) send(ctx context.Context, method, endpoint, contentType string, body any) (*http.Response, error) {
 	var reqBody io.Reader
 
 	if body != nil {
@@ -49,15 +63,57 @@ func (c *Client) Request(method, endpoint string, body any) (*http.Response, err
 		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), method, c.BaseURL+endpoint, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.do(c.HTTPClient, req, method, endpoint)
+}
+
+// Upload POSTs a raw body of size bytes, such as a multipart form, which is
+// sent as is with the given content type
+func (c *Client) Upload(endpoint, contentType string, body io.Reader, size int64) (*http.Response, error) {
+	return c.upload(http.MethodPost, endpoint, contentType, body, size)
+}
+
+// UploadReplace is Upload for endpoints that replace a single file, which
+// take a PUT
+func (c *Client) UploadReplace(endpoint, contentType string, body io.Reader, size int64) (*http.Response, error) {
+	return c.upload(http.MethodPut, endpoint, contentType, body, size)
+}
+
+func (c *Client) upload(method, endpoint, contentType string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, c.BaseURL+endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = size
+	return c.do(c.HTTPClient, req, method, endpoint)
+}
 
+// Stream GETs a server-sent event stream (see ReadEvents). Unlike Get it has
+// no overall timeout: the stream lasts until the server ends it or ctx is
+// done.
+func (c *Client) Stream(ctx context.Context, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	client := *c.HTTPClient
+	client.Timeout = 0
+	return c.do(&client, req, http.MethodGet, endpoint)
+}
+
+// do authenticates and sends req with client, reporting auth and server
+// errors
+func (c *Client) do(client *http.Client, req *http.Request, method, endpoint string) (*http.Response, error) {
 	// Get access token from token fetcher
 	accessToken, err := c.TokenFetcher.GetAccessToken()
 	if err != nil {
@@ -69,21 +125,47 @@ func (c *Client) Request(method, endpoint string, body any) (*http.Response, err
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.HTTPClient.Do(req)
+	if method == http.MethodGet {
+		c.cache.addValidators(req, endpoint)
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
+		// While the circuit is open the failure was already reported, and a
+		// cancelled request was abandoned by the caller
+		if c.OnRequestError != nil && !errors.Is(err, ErrCircuitOpen) && !errors.Is(err, context.Canceled) {
+			c.OnRequestError(method, endpoint, 0, err)
+		}
 		return nil, err
 	}
 	if resp.StatusCode == http.StatusUnauthorized && c.OnAuthError != nil {
 		c.OnAuthError()
 	}
+	if resp.StatusCode >= http.StatusInternalServerError && c.OnRequestError != nil {
+		c.OnRequestError(method, endpoint, resp.StatusCode, fmt.Errorf("server error: %s", resp.Status))
+	}
+	if method == http.MethodGet {
+		return c.cache.apply(endpoint, resp)
+	}
 	return resp, nil
 }
 
+// ClearCache forgets every cached GET response, e.g. when the user signs out
+func (c *Client) ClearCache() {
+	c.cache.clear()
+}
+
 // Get makes a GET request
 func (c *Client) Get(endpoint string) (*http.Response, error) {
 	return c.Request(http.MethodGet, endpoint, nil)
 }
 
+// GetContext makes a GET request that is abandoned when ctx is done, such as
+// a search superseded by a newer one
+func (c *Client) GetContext(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, endpoint, "application/json", nil)
+}
+
 // Post makes a POST request
 func (c *Client) Post(endpoint string, body any) (*http.Response, error) {
 	return c.Request(http.MethodPost, endpoint, body)
@@ -94,6 +176,16 @@ func (c *Client) Put(endpoint string, body any) (*http.Response, error) {
 	return c.Request(http.MethodPut, endpoint, body)
 }
 
+// Patch makes a PATCH request
+func (c *Client) Patch(endpoint string, body any) (*http.Response, error) {
+	return c.Request(http.MethodPatch, endpoint, body)
+}
+
+// MergePatch makes a PATCH request whose body is an RFC 7386 JSON Merge Patch
+func (c *Client) MergePatch(endpoint string, patch types.MergePatch) (*http.Response, error) {
+	return c.send(context.Background(), http.MethodPatch, endpoint, "application/merge-patch+json", patch)
+}
+
 // Delete makes a DELETE request
 func (c *Client) Delete(endpoint string) (*http.Response, error) {
 	return c.Request(http.MethodDelete, endpoint, nil)
@@ -104,11 +196,7 @@ func DecodeResponse[T any](resp *http.Response) (*T, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp types.ErrorResponse
-		if err := json.UnmarshalRead(resp.Body, &errResp); err != nil {
-			return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
-		}
-		return nil, fmt.Errorf("API error: %s (code: %d)", errResp.Message, resp.StatusCode)
+		return nil, apiError(resp)
 	}
 
 	var result T
@@ -124,11 +212,7 @@ func DecodeResponseList[T any](resp *http.Response) ([]T, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp types.ErrorResponse
-		if err := json.UnmarshalRead(resp.Body, &errResp); err != nil {
-			return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
-		}
-		return nil, fmt.Errorf("API error: %s (code: %d)", errResp.Message, resp.StatusCode)
+		return nil, apiError(resp)
 	}
 
 	var result []T
@@ -144,11 +228,7 @@ func CheckResponse(resp *http.Response) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp types.ErrorResponse
-		if err := json.UnmarshalRead(resp.Body, &errResp); err != nil {
-			return fmt.Errorf("API error: status %d", resp.StatusCode)
-		}
-		return fmt.Errorf("API error: %s (code: %d)", errResp.Message, resp.StatusCode)
+		return apiError(resp)
 	}
 
 	return nil
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// referenceLabel is the catalog key of the word introducing a request ID
+const referenceLabel = "reference"
+
+// defaultLanguage is used for languages without a catalog and for codes a
+// catalog lacks
+const defaultLanguage = "en"
+
+// errorMessages holds, per language, the message shown for each backend
+// error code. Codes missing everywhere fall back to the server's wording.
+var errorMessages = map[string]map[string]string{
+	"en": {
+		"invalid_request":        "The request was not valid.",
+		"validation_failed":      "Some of the details are not valid:",
+		"unauthorized":           "Your session has ended. Please sign in again.",
+		"forbidden":              "You do not have permission to do that.",
+		"not_found":              "It no longer exists, or you no longer have access to it.",
+		"method_not_allowed":     "That action is not supported.",
+		"conflict":               "That clashes with an existing entry or a newer change. Refresh and try again.",
+		"gone":                   "That is no longer available.",
+		"precondition_failed":    "It was changed elsewhere in the meantime. Refresh and try again.",
+		"payload_too_large":      "That is too large to upload.",
+		"unsupported_media_type": "That file type is not supported.",
+		"unprocessable":          "The server could not process that.",
+		"rate_limited":           "Too many requests. Wait a moment and try again.",
+		"internal_error":         "Something went wrong on the server.",
+		"not_implemented":        "The server does not support that yet.",
+		"bad_gateway":            "A service the server relies on did not respond.",
+		"service_unavailable":    "The server is temporarily unavailable. Try again shortly.",
+		"gateway_timeout":        "The server took too long to respond.",
+		"reauth_required":        "Please sign in again to confirm it is you.",
+		"session_revoked":        "This device was signed out.",
+		"account_disabled":       "Your account has been disabled. Contact an administrator.",
+		"quota_exceeded":         "The group has reached its quota. Ask a group admin to raise it.",
+		referenceLabel:           "reference",
+	},
+	"de": {
+		"invalid_request":        "Die Anfrage war ungültig.",
+		"validation_failed":      "Einige Angaben sind ungültig:",
+		"unauthorized":           "Deine Sitzung ist abgelaufen. Bitte melde dich erneut an.",
+		"forbidden":              "Dazu fehlt dir die Berechtigung.",
+		"not_found":              "Das existiert nicht mehr, oder du hast keinen Zugriff mehr darauf.",
+		"method_not_allowed":     "Diese Aktion wird nicht unterstützt.",
+		"conflict":               "Das kollidiert mit einem vorhandenen Eintrag oder einer neueren Änderung. Bitte neu laden und erneut versuchen.",
+		"gone":                   "Das ist nicht mehr verfügbar.",
+		"precondition_failed":    "Das wurde zwischenzeitlich anderswo geändert. Bitte neu laden und erneut versuchen.",
+		"payload_too_large":      "Das ist zu groß zum Hochladen.",
+		"unsupported_media_type": "Dieser Dateityp wird nicht unterstützt.",
+		"unprocessable":          "Der Server konnte das nicht verarbeiten.",
+		"rate_limited":           "Zu viele Anfragen. Bitte kurz warten und erneut versuchen.",
+		"internal_error":         "Auf dem Server ist ein Fehler aufgetreten.",
+		"not_implemented":        "Der Server unterstützt das noch nicht.",
+		"bad_gateway":            "Ein Dienst, auf den der Server angewiesen ist, hat nicht geantwortet.",
+		"service_unavailable":    "Der Server ist vorübergehend nicht erreichbar. Bitte gleich erneut versuchen.",
+		"gateway_timeout":        "Der Server hat zu lange für die Antwort gebraucht.",
+		"reauth_required":        "Bitte melde dich zur Bestätigung erneut an.",
+		"session_revoked":        "Dieses Gerät wurde abgemeldet.",
+		"account_disabled":       "Dein Konto wurde deaktiviert. Wende dich an einen Administrator.",
+		"quota_exceeded":         "Die Gruppe hat ihr Kontingent erreicht. Bitte einen Gruppenadmin, es zu erhöhen.",
+		referenceLabel:           "Referenz",
+	},
+	"es": {
+		"invalid_request":        "La solicitud no es válida.",
+		"validation_failed":      "Algunos datos no son válidos:",
+		"unauthorized":           "Tu sesión ha terminado. Vuelve a iniciar sesión.",
+		"forbidden":              "No tienes permiso para hacer eso.",
+		"not_found":              "Ya no existe o ya no tienes acceso.",
+		"method_not_allowed":     "Esa acción no está permitida.",
+		"conflict":               "Eso choca con una entrada existente o con un cambio más reciente. Actualiza e inténtalo de nuevo.",
+		"gone":                   "Ya no está disponible.",
+		"precondition_failed":    "Se modificó en otro lugar mientras tanto. Actualiza e inténtalo de nuevo.",
+		"payload_too_large":      "Es demasiado grande para subirlo.",
+		"unsupported_media_type": "Ese tipo de archivo no es compatible.",
+		"unprocessable":          "El servidor no pudo procesarlo.",
+		"rate_limited":           "Demasiadas solicitudes. Espera un momento e inténtalo de nuevo.",
+		"internal_error":         "Algo salió mal en el servidor.",
+		"not_implemented":        "El servidor todavía no admite eso.",
+		"bad_gateway":            "Un servicio del que depende el servidor no respondió.",
+		"service_unavailable":    "El servidor no está disponible temporalmente. Inténtalo de nuevo en breve.",
+		"gateway_timeout":        "El servidor tardó demasiado en responder.",
+		"reauth_required":        "Vuelve a iniciar sesión para confirmar que eres tú.",
+		"session_revoked":        "Se cerró la sesión en este dispositivo.",
+		"account_disabled":       "Tu cuenta ha sido desactivada. Contacta con un administrador.",
+		"quota_exceeded":         "El grupo ha alcanzado su cuota. Pide a un administrador del grupo que la aumente.",
+		referenceLabel:           "referencia",
+	},
+}
+
+var errorLanguage atomic.Pointer[string]
+
+// SetErrorLanguage picks the language of API error messages from a tag such
+// as "de-AT" or "es_ES.UTF-8". Languages without a catalog use English.
+func SetErrorLanguage(tag string) {
+	lang := strings.ToLower(tag)
+	if i := strings.IndexAny(lang, "-_."); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := errorMessages[lang]; !ok {
+		lang = defaultLanguage
+	}
+	errorLanguage.Store(&lang)
+}
+
+// errorMessage is the message for code in the chosen language, falling back
+// to English, or "" for an unknown code
+func errorMessage(code string) string {
+	lang := defaultLanguage
+	if l := errorLanguage.Load(); l != nil {
+		lang = *l
+	}
+	if msg, ok := errorMessages[lang][code]; ok {
+		return msg
+	}
+	return errorMessages[defaultLanguage][code]
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Error codes the backend sends that the app branches on. The rest are only
+// used to pick a message (see errorMessages).
+const (
+	CodeValidationFailed = "validation_failed"
+	CodeBadGateway       = "bad_gateway"
+	CodeReauthRequired   = "reauth_required"
+	CodeSessionRevoked   = "session_revoked"
+	CodeAccountDisabled  = "account_disabled"
+	CodeQuotaExceeded    = "quota_exceeded"
+)
+
+// APIError is a failed API response. Its Error is a message for the user
+// in their language, chosen by Code; the server's own wording is kept in
+// Message for logs and error reports.
+type APIError struct {
+	Status    int
+	Code      string
+	Message   string
+	Fields    []types.ErrorField
+	RequestID string
+}
+
+// Error words the failure for the user. Validation failures list the
+// rejected fields, and server errors carry the request ID so a report can
+// be matched to the server's log.
+func (e *APIError) Error() string {
+	msg := errorMessage(e.Code)
+	if msg == "" {
+		msg = e.Message
+	}
+	if e.Code == CodeValidationFailed && len(e.Fields) > 0 {
+		details := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			details[i] = f.Message
+		}
+		msg += " " + strings.Join(details, "; ")
+	}
+	if e.Status >= http.StatusInternalServerError && e.RequestID != "" {
+		msg += " (" + errorMessage(referenceLabel) + " " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// LogValue logs the error as the server reported it, rather than the
+// translated message
+func (e *APIError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("status", e.Status),
+		slog.String("code", e.Code),
+		slog.String("message", e.Message),
+		slog.String("request_id", e.RequestID),
+	)
+}
+
+// IsCode reports whether err is an API error with the given code
+func IsCode(err error, code string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// apiError reads the error envelope of a failed response. Bodies without a
+// code, from proxies or older servers, get the generic code for their
+// status so the user still sees a translated message.
+func apiError(resp *http.Response) error {
+	apiErr := &APIError{Status: resp.StatusCode}
+	var body types.ErrorResponse
+	if err := json.UnmarshalRead(resp.Body, &body); err == nil {
+		apiErr.Code = body.Code
+		apiErr.Message = body.Message
+		if apiErr.Message == "" {
+			apiErr.Message = body.Error
+		}
+		apiErr.Fields = body.Fields
+		apiErr.RequestID = body.RequestID
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = statusCode(resp.StatusCode)
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("X-Request-ID")
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = resp.Status
+	}
+	return apiErr
+}
+
+// statusCode is the backend's generic error code for an HTTP status
+func statusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusPreconditionFailed:
+		return "precondition_failed"
+	case http.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusBadGateway:
+		return CodeBadGateway
+	case http.StatusServiceUnavailable:
+		return "service_unavailable"
+	case http.StatusGatewayTimeout:
+		return "gateway_timeout"
+	}
+	if status >= http.StatusInternalServerError {
+		return "internal_error"
+	}
+	return "invalid_request"
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func errorResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"X-Request-Id": {"req-from-header"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	t.Cleanup(func() { SetErrorLanguage("") })
+
+	tests := []struct {
+		name   string
+		lang   string
+		status int
+		body   string
+		want   string
+	}{
+		{
+			name:   "code picks the message",
+			lang:   "en-GB",
+			status: http.StatusForbidden,
+			body:   `{"error":"reauthentication required","code":"reauth_required","message":"reauthentication required"}`,
+			want:   "Please sign in again to confirm it is you.",
+		},
+		{
+			name:   "validation lists the fields",
+			status: http.StatusBadRequest,
+			body:   `{"code":"validation_failed","message":"name must be between 1 and 255 characters","fields":[{"field":"name","message":"name must be between 1 and 255 characters"}]}`,
+			want:   "Some of the details are not valid: name must be between 1 and 255 characters",
+		},
+		{
+			name:   "server errors quote the request ID",
+			lang:   "de_DE.UTF-8",
+			status: http.StatusInternalServerError,
+			body:   `{"code":"internal_error","message":"internal server error","request_id":"abc123"}`,
+			want:   "Auf dem Server ist ein Fehler aufgetreten. (Referenz abc123)",
+		},
+		{
+			name:   "a proxy's body without a code falls back to the status",
+			lang:   "es",
+			status: http.StatusBadGateway,
+			body:   `<html>Bad Gateway</html>`,
+			want:   "Un servicio del que depende el servidor no respondió. (referencia req-from-header)",
+		},
+		{
+			name:   "unknown codes show the server's wording",
+			status: http.StatusConflict,
+			body:   `{"code":"username_taken","message":"username is already taken"}`,
+			want:   "username is already taken",
+		},
+	}
+	for _, tt := range tests {
+		SetErrorLanguage(tt.lang)
+		err := apiError(errorResponse(tt.status, tt.body))
+		if got := err.Error(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	err := apiError(errorResponse(http.StatusForbidden, `{"error":"account disabled","code":"account_disabled"}`))
+	if !IsCode(err, CodeAccountDisabled) {
+		t.Errorf("%v is not account_disabled", err)
+	}
+	if IsCode(err, CodeReauthRequired) || IsCode(io.EOF, CodeAccountDisabled) {
+		t.Error("IsCode matched the wrong error")
+	}
+	apiErr := err.(*APIError)
+	if apiErr.Message != "account disabled" || apiErr.Status != http.StatusForbidden {
+		t.Errorf("server wording lost: %+v", apiErr)
+	}
+}
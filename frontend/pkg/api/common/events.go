@@ -0,0 +1,56 @@
+package common
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxEventSize bounds one server-sent event; an import result listing many
+// failed rows is the largest the backend sends.
+const maxEventSize = 1 << 20
+
+// Event is one server-sent event.
+type Event struct {
+	Name string // "message" when the server did not name it
+	Data string
+}
+
+// ReadEvents parses a text/event-stream body, calling fn for each event in
+// order until the stream ends, fn returns an error, or reading fails. A
+// stream that ends normally returns nil.
+func ReadEvents(r io.Reader, fn func(Event) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxEventSize)
+
+	var name string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line ends the event; comments alone produce none
+			if len(data) > 0 {
+				if name == "" {
+					name = "message"
+				}
+				if err := fn(Event{Name: name, Data: strings.Join(data, "\n")}); err != nil {
+					return err
+				}
+			}
+			name, data = "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadEvents(t *testing.T) {
+	stream := ": keep-alive\n\n" +
+		"event: progress\ndata: {\"done\":1}\n\n" +
+		"data: first\ndata: second\n\n" +
+		"event: completed\ndata:{\"done\":2}\n\n"
+
+	var got []Event
+	err := ReadEvents(strings.NewReader(stream), func(e Event) error {
+		got = append(got, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadEvents: %v", err)
+	}
+
+	want := []Event{
+		{Name: "progress", Data: `{"done":1}`},
+		{Name: "message", Data: "first\nsecond"},
+		{Name: "completed", Data: `{"done":2}`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReadEvents_StopsOnCallbackError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := ReadEvents(strings.NewReader("data: a\n\ndata: b\n\n"), func(Event) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("err = %v after %d calls, want stop after 1", err, calls)
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxCachedResponses bounds how many GET bodies the client keeps for
+// revalidation; the least recently stored entry is dropped first
+const maxCachedResponses = 256
+
+// cachedResponse is a GET body with the validators the server sent for it
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	contentType  string
+	body         []byte
+	storedAt     time.Time
+}
+
+// responseCache keeps GET bodies so unchanged resources are answered with
+// 304 Not Modified instead of being transferred again. The zero value is
+// ready to use.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+// addValidators sets If-None-Match / If-Modified-Since from the cached copy
+// of endpoint, if there is one
+func (rc *responseCache) addValidators(req *http.Request, endpoint string) {
+	rc.mu.Lock()
+	entry := rc.entries[endpoint]
+	rc.mu.Unlock()
+	if entry == nil {
+		return
+	}
+
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	} else if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+}
+
+// apply turns a 304 into the cached 200 response and remembers successful
+// responses that carry a validator. Callers decode the result as usual.
+func (rc *responseCache) apply(endpoint string, resp *http.Response) (*http.Response, error) {
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		rc.mu.Lock()
+		entry := rc.entries[endpoint]
+		rc.mu.Unlock()
+		if entry == nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		cached := *resp
+		cached.StatusCode = http.StatusOK
+		cached.Status = "200 OK"
+		cached.Header = resp.Header.Clone()
+		cached.Header.Set("Content-Type", entry.contentType)
+		cached.ContentLength = int64(len(entry.body))
+		cached.Body = io.NopCloser(bytes.NewReader(entry.body))
+		return &cached, nil
+
+	case http.StatusOK:
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" {
+			return resp, nil
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		rc.store(endpoint, &cachedResponse{
+			etag:         etag,
+			lastModified: lastModified,
+			contentType:  resp.Header.Get("Content-Type"),
+			body:         body,
+			storedAt:     time.Now(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (rc *responseCache) store(endpoint string, entry *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.entries == nil {
+		rc.entries = make(map[string]*cachedResponse)
+	}
+	if _, exists := rc.entries[endpoint]; !exists && len(rc.entries) >= maxCachedResponses {
+		var oldest string
+		for key, e := range rc.entries {
+			if oldest == "" || e.storedAt.Before(rc.entries[oldest].storedAt) {
+				oldest = key
+			}
+		}
+		delete(rc.entries, oldest)
+	}
+	rc.entries[endpoint] = entry
+}
+
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	rc.entries = nil
+	rc.mu.Unlock()
+}
@@ -0,0 +1,275 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the server while a host's
+// circuit breaker is open after repeated failures.
+var ErrCircuitOpen = errors.New("server unreachable, not retrying yet")
+
+// Defaults for the zero fields of TransportConfig.
+const (
+	DefaultMaxRetries       = 3
+	DefaultRetryDelay       = 500 * time.Millisecond
+	DefaultMaxRetryDelay    = 5 * time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 15 * time.Second
+)
+
+// TransportConfig tunes retries and the circuit breaker. Zero fields take
+// the defaults above; a negative MaxRetries turns retries off.
+type TransportConfig struct {
+	MaxRetries       int
+	RetryDelay       time.Duration // first backoff, doubled on each retry
+	MaxRetryDelay    time.Duration
+	BreakerThreshold int           // consecutive failures that open a host's breaker
+	BreakerCooldown  time.Duration // how long an open breaker fails fast before letting a probe through
+}
+
+func (c TransportConfig) withDefaults() TransportConfig {
+	if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultMaxRetries
+	} else if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = DefaultRetryDelay
+	}
+	if c.MaxRetryDelay <= 0 {
+		c.MaxRetryDelay = DefaultMaxRetryDelay
+	}
+	if c.BreakerThreshold <= 0 {
+		c.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if c.BreakerCooldown <= 0 {
+		c.BreakerCooldown = DefaultBreakerCooldown
+	}
+	return c
+}
+
+// breaker is the circuit state of one host. It opens after threshold
+// consecutive failures; once the cooldown has passed a single probe request
+// is let through, whose outcome closes the breaker or restarts the cooldown.
+type breaker struct {
+	failures int
+	openedAt time.Time // zero while closed
+	probing  bool
+}
+
+// Transport is an http.RoundTripper shared by the API clients. Idempotent
+// requests that fail on the network or with 502, 503 or 504 are retried with
+// exponential backoff and jitter, and each host has a circuit breaker so an
+// unreachable server fails fast instead of stacking up timeouts.
+type Transport struct {
+	// Base performs the requests; nil means http.DefaultTransport.
+	Base http.RoundTripper
+	// OnStateChange is called, outside any lock, when a host's breaker
+	// opens or closes.
+	OnStateChange func(host string, open bool)
+
+	cfg TransportConfig
+
+	mu    sync.Mutex
+	hosts map[string]*breaker
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewTransport creates a Transport over http.DefaultTransport.
+func NewTransport(cfg TransportConfig) *Transport {
+	return &Transport{
+		cfg:   cfg.withDefaults(),
+		hosts: make(map[string]*breaker),
+		now:   time.Now,
+		sleep: sleepContext,
+	}
+}
+
+// Offline reports whether any host's breaker is open.
+func (t *Transport) Offline() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, b := range t.hosts {
+		if !b.openedAt.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if !t.allow(host) {
+		return nil, fmt.Errorf("%s: %w", host, ErrCircuitOpen)
+	}
+
+	retries := 0
+	if isIdempotent(req.Method) && (req.Body == nil || req.GetBody != nil) {
+		retries = t.cfg.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				t.release(host)
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base().RoundTrip(attemptReq)
+		if errors.Is(req.Context().Err(), context.Canceled) {
+			// The caller gave up; that says nothing about the server
+			t.release(host)
+			return resp, err
+		}
+		if !isTransient(resp, err) {
+			t.record(host, true)
+			return resp, err
+		}
+		if attempt >= retries || req.Context().Err() != nil || !t.allowRetry(host) {
+			t.record(host, false)
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := t.sleep(req.Context(), delay); err != nil {
+			t.record(host, false)
+			return nil, err
+		}
+	}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// allow reports whether a request to host may be sent, claiming the probe
+// slot when the cooldown of an open breaker has passed.
+func (t *Transport) allow(host string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b := t.hosts[host]
+	if b == nil || b.openedAt.IsZero() {
+		return true
+	}
+	if b.probing || t.now().Sub(b.openedAt) < t.cfg.BreakerCooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// allowRetry stops retrying once the breaker has opened, e.g. because
+// concurrent requests to the same host failed meanwhile.
+func (t *Transport) allowRetry(host string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b := t.hosts[host]
+	return b == nil || b.openedAt.IsZero() || b.probing
+}
+
+// release frees the probe slot of a request whose outcome is not counted.
+func (t *Transport) release(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if b := t.hosts[host]; b != nil {
+		b.probing = false
+	}
+}
+
+// record counts the outcome of a request towards the host's breaker.
+func (t *Transport) record(host string, ok bool) {
+	t.mu.Lock()
+	b := t.hosts[host]
+	if b == nil {
+		b = &breaker{}
+		t.hosts[host] = b
+	}
+	wasOpen := !b.openedAt.IsZero()
+	b.probing = false
+	if ok {
+		b.failures = 0
+		b.openedAt = time.Time{}
+	} else {
+		b.failures++
+		if wasOpen || b.failures >= t.cfg.BreakerThreshold {
+			b.openedAt = t.now()
+		}
+	}
+	isOpen := !b.openedAt.IsZero()
+	t.mu.Unlock()
+
+	if wasOpen != isOpen && t.OnStateChange != nil {
+		t.OnStateChange(host, isOpen)
+	}
+}
+
+// backoff is the wait before retry number attempt+1: the doubled base delay
+// with jitter, or the server's Retry-After when that is longer, capped at
+// MaxRetryDelay.
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	delay := t.cfg.RetryDelay << attempt
+	if delay <= 0 || delay > t.cfg.MaxRetryDelay {
+		delay = t.cfg.MaxRetryDelay
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			delay = max(delay, time.Duration(seconds)*time.Second)
+		}
+	}
+	return min(delay, t.cfg.MaxRetryDelay)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isTransient reports whether a request failed in a way that retrying, or
+// the server coming back, could fix.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
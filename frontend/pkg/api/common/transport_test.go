@@ -0,0 +1,163 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedTransport answers with the next outcome of its script: an HTTP
+// status, or 0 for a network error.
+type scriptedTransport struct {
+	script []int
+	calls  int
+	bodies []string
+}
+
+func (s *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	status := http.StatusOK
+	if s.calls < len(s.script) {
+		status = s.script[s.calls]
+	}
+	s.calls++
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		s.bodies = append(s.bodies, string(body))
+	}
+	if status == 0 {
+		return nil, errors.New("network is unreachable")
+	}
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+// newTestTransport returns a Transport over base that does not really sleep
+// and whose clock is at *now.
+func newTestTransport(base http.RoundTripper, cfg TransportConfig, now *time.Time) *Transport {
+	t := NewTransport(cfg)
+	t.Base = base
+	t.now = func() time.Time { return *now }
+	t.sleep = func(context.Context, time.Duration) error { return nil }
+	return t
+}
+
+func doRequest(t *testing.T, transport http.RoundTripper, method string, body string) (*http.Response, error) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, "http://backend.test/groups", reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transport.RoundTrip(req)
+}
+
+func TestTransportRetriesIdempotentRequests(t *testing.T) {
+	now := time.Now()
+	base := &scriptedTransport{script: []int{0, http.StatusServiceUnavailable, http.StatusOK}}
+	transport := newTestTransport(base, TransportConfig{}, &now)
+
+	resp, err := doRequest(t, transport, http.MethodPut, `{"name":"x"}`)
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || base.calls != 3 {
+		t.Errorf("expected 200 after 3 calls, got %d after %d", resp.StatusCode, base.calls)
+	}
+	for _, body := range base.bodies {
+		if body != `{"name":"x"}` {
+			t.Errorf("expected the body to be replayed, got %q", body)
+		}
+	}
+}
+
+func TestTransportDoesNotRetryPost(t *testing.T) {
+	now := time.Now()
+	base := &scriptedTransport{script: []int{0}}
+	transport := newTestTransport(base, TransportConfig{}, &now)
+
+	if _, err := doRequest(t, transport, http.MethodPost, `{}`); err == nil {
+		t.Fatal("expected the network error")
+	}
+	if base.calls != 1 {
+		t.Errorf("expected a single attempt, got %d", base.calls)
+	}
+}
+
+func TestTransportDoesNotRetryServerErrors(t *testing.T) {
+	now := time.Now()
+	base := &scriptedTransport{script: []int{http.StatusInternalServerError}}
+	transport := newTestTransport(base, TransportConfig{}, &now)
+
+	resp, err := doRequest(t, transport, http.MethodGet, "")
+	if err != nil || resp.StatusCode != http.StatusInternalServerError || base.calls != 1 {
+		t.Errorf("expected the 500 to be returned as is, got %v, %v after %d calls", resp, err, base.calls)
+	}
+}
+
+func TestTransportCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	base := &scriptedTransport{script: []int{0, 0, 0}}
+	transport := newTestTransport(base, TransportConfig{MaxRetries: -1, BreakerThreshold: 3, BreakerCooldown: time.Minute}, &now)
+	var changes []bool
+	transport.OnStateChange = func(host string, open bool) {
+		if host != "backend.test" {
+			t.Errorf("unexpected host %q", host)
+		}
+		changes = append(changes, open)
+	}
+
+	for range 3 {
+		_, _ = doRequest(t, transport, http.MethodGet, "")
+	}
+	if !transport.Offline() {
+		t.Fatal("expected the breaker to open after 3 failures")
+	}
+
+	// Open: fail fast without calling the server
+	if _, err := doRequest(t, transport, http.MethodGet, ""); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if base.calls != 3 {
+		t.Errorf("expected no call while open, got %d calls", base.calls)
+	}
+
+	// After the cooldown a probe goes through and closes the breaker
+	now = now.Add(time.Minute)
+	resp, err := doRequest(t, transport, http.MethodGet, "")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the probe to succeed, got %v, %v", resp, err)
+	}
+	if transport.Offline() {
+		t.Error("expected the breaker to close after a successful probe")
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Errorf("expected open then close, got %v", changes)
+	}
+}
+
+func TestTransportFailedProbeReopens(t *testing.T) {
+	now := time.Now()
+	base := &scriptedTransport{script: []int{0, 0, 0, 0, 0, 0}}
+	transport := newTestTransport(base, TransportConfig{MaxRetries: 2, BreakerThreshold: 1, BreakerCooldown: time.Minute}, &now)
+
+	// The breaker counts requests, not attempts: it opens once all three
+	// attempts have failed
+	_, _ = doRequest(t, transport, http.MethodGet, "")
+	if !transport.Offline() || base.calls != 3 {
+		t.Fatalf("expected the breaker to open after 3 attempts, got %d", base.calls)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := doRequest(t, transport, http.MethodGet, ""); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the probe to reach the server and fail, got %v", err)
+	}
+	if _, err := doRequest(t, transport, http.MethodGet, ""); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected a failed probe to restart the cooldown, got %v", err)
+	}
+}
@@ -19,9 +19,14 @@ func NewClient(commonClient *common.Client) *Client {
 	}
 }
 
-// List gets all containers for a specific collection (without embedded objects).
-func (c *Client) List(accountID, collectionID string) ([]types.Container, error) {
-	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collections/%s/containers?exclude_objects=true", accountID, collectionID))
+// List gets all containers for a specific collection (without embedded objects)
+// in the requested order.
+func (c *Client) List(accountID, collectionID string, sort types.SortOptions) ([]types.Container, error) {
+	url := fmt.Sprintf("/accounts/%s/collections/%s/containers?exclude_objects=true", accountID, collectionID)
+	if q := sort.Query(); q != "" {
+		url += "&" + q
+	}
+	resp, err := c.common.Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +76,17 @@ func (c *Client) Update(accountID, collectionID, containerID string, req types.U
 	return common.DecodeResponse[types.Container](resp)
 }
 
+// Patch changes only the fields in patch, leaving the rest of the container
+// as it is
+func (c *Client) Patch(accountID, collectionID, containerID string, patch types.MergePatch) (*types.Container, error) {
+	resp, err := c.common.MergePatch("/containers/"+containerID, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Container](resp)
+}
+
 // Delete deletes a container
 func (c *Client) Delete(accountID, collectionID, containerID string) error {
 	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/collections/%s/containers/%s", accountID, collectionID, containerID))
@@ -81,12 +97,13 @@ func (c *Client) Delete(accountID, collectionID, containerID string) error {
 	return common.CheckResponse(resp)
 }
 
-// GetObjects gets all objects in a container
-func (c *Client) GetObjects(accountID, collectionID, containerID string) ([]types.Object, error) {
-	resp, err := c.common.Get(fmt.Sprintf("/containers/%s/objects", containerID))
+// UpdatePlacements moves containers on the collection's floor plan and
+// returns them as saved. Containers not in req keep their placement.
+func (c *Client) UpdatePlacements(accountID, collectionID string, req types.UpdateContainerPlacementsRequest) ([]types.Container, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/collections/%s/containers/placements", accountID, collectionID), req)
 	if err != nil {
 		return nil, err
 	}
 
-	return common.DecodeResponseList[types.Object](resp)
+	return common.DecodeResponseList[types.Container](resp)
 }
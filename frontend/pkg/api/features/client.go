@@ -0,0 +1,28 @@
+package features
+
+import (
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client fetches the feature flags evaluated for the signed-in user
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new features API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// Get fetches whether each known feature is on for the current user
+func (c *Client) Get() (*types.Features, error) {
+	resp, err := c.common.Get("/features")
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Features](resp)
+}
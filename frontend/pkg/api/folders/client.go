@@ -0,0 +1,94 @@
+package folders
+
+import (
+	"fmt"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles collection folder API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new collection folders API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// List gets all of the user's folders
+func (c *Client) List(accountID string) ([]types.CollectionFolder, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collection-folders", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := common.DecodeResponse[types.CollectionFolderList](resp)
+	if err != nil {
+		return nil, err
+	}
+	return list.Folders, nil
+}
+
+// Create creates a folder, nested in req.ParentID when set
+func (c *Client) Create(accountID string, req types.CreateCollectionFolderRequest) (*types.CollectionFolder, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/collection-folders", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionFolder](resp)
+}
+
+// Update renames or moves a folder
+func (c *Client) Update(accountID, folderID string, req types.UpdateCollectionFolderRequest) (*types.CollectionFolder, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/collection-folders/%s", accountID, folderID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionFolder](resp)
+}
+
+// Delete deletes a folder; what was in it moves up to its parent
+func (c *Client) Delete(accountID, folderID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/collection-folders/%s", accountID, folderID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// File moves a collection into a folder, out of any other
+func (c *Client) File(accountID, folderID, collectionID string) (*types.CollectionFolder, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/collection-folders/%s/collections/%s", accountID, folderID, collectionID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionFolder](resp)
+}
+
+// Unfile takes a collection out of a folder, back to the top level
+func (c *Client) Unfile(accountID, folderID, collectionID string) (*types.CollectionFolder, error) {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/collection-folders/%s/collections/%s", accountID, folderID, collectionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionFolder](resp)
+}
+
+// Stats gets the totals across a folder and its subfolders
+func (c *Client) Stats(accountID, folderID string) (*types.CollectionFolderStats, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collection-folders/%s/stats", accountID, folderID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionFolderStats](resp)
+}
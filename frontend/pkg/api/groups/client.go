@@ -79,15 +79,56 @@ func (c *Client) GetMembers(id string) ([]types.User, error) {
 	return common.DecodeResponseList[types.User](resp)
 }
 
-// InviteUser invites a user to join a group
-/*func (c *Client) InviteUser(groupID string, req types.InviteUserRequest) error {
-	resp, err := c.common.Post(fmt.Sprintf("/groups/%s/invite", groupID), req)
+// ListMembers gets all members of a group with their roles
+func (c *Client) ListMembers(id string) ([]types.GroupMember, error) {
+	resp, err := c.common.Get("/groups/" + id + "/members")
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponseList[types.GroupMember](resp)
+}
+
+// InviteByEmail adds the user registered under email to a group. It
+// succeeds whether or not the address has an account.
+func (c *Client) InviteByEmail(groupID, email string) error {
+	resp, err := c.common.Post(fmt.Sprintf("/groups/%s/invitations", groupID), types.InviteGroupMemberRequest{Email: email})
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// SetMemberRole makes a member an admin or a plain member
+func (c *Client) SetMemberRole(groupID, userID, role string) error {
+	resp, err := c.common.Put(fmt.Sprintf("/groups/%s/users/%s/role", groupID, userID), types.SetGroupMemberRoleRequest{Role: role})
 	if err != nil {
 		return err
 	}
 
 	return common.CheckResponse(resp)
-}*/
+}
+
+// Quota gets a group's limits and what its shared collections use
+func (c *Client) Quota(groupID string) (*types.GroupQuota, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/groups/%s/quota", groupID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.GroupQuota](resp)
+}
+
+// SetQuota replaces a group's limits; 0 leaves one unlimited
+func (c *Client) SetQuota(groupID string, req types.SetGroupQuotaRequest) (*types.GroupQuota, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/groups/%s/quota", groupID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.GroupQuota](resp)
+}
 
 // AddMember adds a user to a group
 func (c *Client) AddMember(groupID, userID string) error {
@@ -0,0 +1,108 @@
+package imports
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// pollInterval is how often Watch asks for the job when the event stream
+// cannot be used, e.g. behind a proxy that buffers responses
+const pollInterval = time.Second
+
+// errJobFinished ends the event stream once the final event arrived
+var errJobFinished = errors.New("import job finished")
+
+// Client follows collection imports running in the background
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new imports API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// Get fetches an import job's progress, and its result once finished
+func (c *Client) Get(jobID string) (*types.ImportJob, error) {
+	resp, err := c.common.Get("/imports/" + jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ImportJob](resp)
+}
+
+// Watch follows a job until it finishes, calling onUpdate with each change,
+// and returns its final state. It listens to the job's event stream and
+// falls back to polling if the stream breaks off early.
+func (c *Client) Watch(ctx context.Context, jobID string, onUpdate func(*types.ImportJob)) (*types.ImportJob, error) {
+	job, err := c.stream(ctx, jobID, onUpdate)
+	if err == nil || ctx.Err() != nil {
+		return job, err
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		job, err = c.Get(jobID)
+		if err != nil {
+			return nil, err
+		}
+		onUpdate(job)
+		if finished(job) {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// stream reads the job's events until the final one
+func (c *Client) stream(ctx context.Context, jobID string, onUpdate func(*types.ImportJob)) (*types.ImportJob, error) {
+	resp, err := c.common.Stream(ctx, "/imports/"+jobID+"/events")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
+	}
+
+	var last *types.ImportJob
+	err = common.ReadEvents(resp.Body, func(e common.Event) error {
+		var job types.ImportJob
+		if err := json.Unmarshal([]byte(e.Data), &job); err != nil {
+			return fmt.Errorf("failed to decode import event: %w", err)
+		}
+		last = &job
+		onUpdate(last)
+		if finished(last) {
+			return errJobFinished
+		}
+		return nil
+	})
+	if errors.Is(err, errJobFinished) {
+		return last, nil
+	}
+	if err == nil {
+		err = errors.New("import event stream ended early")
+	}
+	return nil, err
+}
+
+// finished reports whether the job has its result or error
+func finished(job *types.ImportJob) bool {
+	return job.Status == "completed" || job.Status == "failed"
+}
@@ -0,0 +1,71 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+type staticToken struct{}
+
+func (staticToken) GetAccessToken() (string, error) { return "token", nil }
+func (staticToken) IsTokenValid() bool              { return true }
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return NewClient(common.NewClient(server.URL, staticToken{}, nil))
+}
+
+func TestWatch_Stream(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /imports/job-1/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: progress\ndata: {\"job_id\":\"job-1\",\"status\":\"running\",\"done\":1,\"total\":2}\n\n")
+		fmt.Fprint(w, "event: completed\ndata: {\"job_id\":\"job-1\",\"status\":\"completed\",\"done\":2,\"total\":2,\"result\":{\"imported\":2,\"failed\":0,\"total\":2}}\n\n")
+	})
+	client := newTestClient(t, mux)
+
+	var seen []string
+	job, err := client.Watch(context.Background(), "job-1", func(j *types.ImportJob) {
+		seen = append(seen, fmt.Sprintf("%s %d/%d", j.Status, j.Done, j.Total))
+	})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if job.Result == nil || job.Result.Imported != 2 {
+		t.Errorf("result = %+v, want 2 imported", job.Result)
+	}
+	if want := []string{"running 1/2", "completed 2/2"}; fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Errorf("updates = %v, want %v", seen, want)
+	}
+}
+
+func TestWatch_PollsWhenStreamUnavailable(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /imports/job-1/events", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not here", http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /imports/job-1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"job_id":"job-1","status":"failed","done":0,"total":2,"error":"collection not found"}`)
+	})
+	client := newTestClient(t, mux)
+
+	job, err := client.Watch(context.Background(), "job-1", func(*types.ImportJob) {})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if job.Status != "failed" || job.Error != "collection not found" || polls != 1 {
+		t.Errorf("job = %+v after %d polls, want the failed job after 1", job, polls)
+	}
+}
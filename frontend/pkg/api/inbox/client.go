@@ -0,0 +1,70 @@
+package inbox
+
+import (
+	"fmt"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles email inbox API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new inbox API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// List gets the forwarded emails waiting to be confirmed, newest first
+func (c *Client) List(accountID string) (*types.InboxItemList, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/inbox", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.InboxItemList](resp)
+}
+
+// Address gets the address the user forwards receipts to
+func (c *Client) Address(accountID string) (*types.InboxAddress, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/inbox/address", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.InboxAddress](resp)
+}
+
+// ResetAddress replaces the forwarding address; mail to the old one is refused
+func (c *Client) ResetAddress(accountID string) (*types.InboxAddress, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/inbox/address/reset", accountID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.InboxAddress](resp)
+}
+
+// Confirm creates objects from an item's lines; lines that fail stay in the inbox
+func (c *Client) Confirm(accountID, itemID string, req types.ConfirmInboxItemRequest) (*types.ConfirmInboxItemResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/inbox/%s/confirm", accountID, itemID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ConfirmInboxItemResult](resp)
+}
+
+// Dismiss removes an item from the inbox without creating anything
+func (c *Client) Dismiss(accountID, itemID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/inbox/%s", accountID, itemID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
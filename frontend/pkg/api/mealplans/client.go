@@ -0,0 +1,75 @@
+package mealplans
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles meal plan API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new meal plans API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// List gets the meal plans dated on or after from and before to, both
+// formatted as YYYY-MM-DD
+func (c *Client) List(accountID, from, to string) (*types.MealPlanList, error) {
+	query := url.Values{}
+	query.Set("from", from)
+	query.Set("to", to)
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/meal-plans?%s", accountID, query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.MealPlanList](resp)
+}
+
+// Create plans a new meal
+func (c *Client) Create(accountID string, req types.CreateMealPlanRequest) (*types.MealPlan, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/meal-plans", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.MealPlan](resp)
+}
+
+// Update changes a meal that has not been completed yet
+func (c *Client) Update(accountID, mealPlanID string, req types.UpdateMealPlanRequest) (*types.MealPlan, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/meal-plans/%s", accountID, mealPlanID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.MealPlan](resp)
+}
+
+// Delete deletes a meal plan
+func (c *Client) Delete(accountID, mealPlanID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/meal-plans/%s", accountID, mealPlanID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
+
+// Complete marks the meal as cooked, which uses up its linked ingredients
+func (c *Client) Complete(accountID, mealPlanID string) (*types.CompleteMealPlanResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/meal-plans/%s/complete", accountID, mealPlanID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CompleteMealPlanResult](resp)
+}
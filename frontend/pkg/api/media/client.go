@@ -0,0 +1,96 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strconv"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles object and container photo API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new media API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// ListCollection gets one page of the photos attached to anything in the
+// collection, newest first
+func (c *Client) ListCollection(accountID, collectionID string, limit, offset int) (*types.MediaList, error) {
+	query := url.Values{"limit": {strconv.Itoa(limit)}, "offset": {strconv.Itoa(offset)}}
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collections/%s/media?%s", accountID, collectionID, query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.MediaList](resp)
+}
+
+// Photo is an image ready to upload
+type Photo struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// UploadObject attaches photos to an object. progress, when set, is called
+// from the uploading goroutine as the request body is sent.
+func (c *Client) UploadObject(accountID, objectID string, photos []Photo, progress func(sent, total int64)) (*types.MediaUploadResult, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for _, p := range photos {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", multipart.FileContentDisposition("files", p.Filename))
+		header.Set("Content-Type", p.ContentType)
+		part, err := form.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", p.Filename, err)
+		}
+		if _, err := part.Write(p.Data); err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", p.Filename, err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build upload: %w", err)
+	}
+
+	size := int64(body.Len())
+	var reader io.Reader = &body
+	if progress != nil {
+		reader = &progressReader{r: &body, total: size, progress: progress}
+	}
+	resp, err := c.common.Upload(fmt.Sprintf("/accounts/%s/objects/%s/media", accountID, objectID), form.FormDataContentType(), reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.MediaUploadResult](resp)
+}
+
+// progressReader reports how much of a request body has been read
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
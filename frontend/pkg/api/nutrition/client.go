@@ -0,0 +1,31 @@
+package nutrition
+
+import (
+	"fmt"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles food nutrition API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new nutrition API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// Stats gets the calories, protein, carbs and fat available in a food
+// collection, with calories per container
+func (c *Client) Stats(accountID, collectionID string) (*types.NutritionStats, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collections/%s/nutrition", accountID, collectionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.NutritionStats](resp)
+}
@@ -1,8 +1,13 @@
 package objects
 
 import (
+	"context"
 	"encoding/json/v2"
 	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/nishiki/frontend/pkg/api/common"
 	"github.com/nishiki/frontend/pkg/types"
@@ -55,6 +60,17 @@ func (c *Client) Update(accountID, objectID string, req types.UpdateObjectReques
 	return common.DecodeResponse[types.Object](resp)
 }
 
+// Patch changes only the fields in patch, leaving the rest of the object as
+// it is
+func (c *Client) Patch(accountID, objectID string, patch types.MergePatch) (*types.Object, error) {
+	resp, err := c.common.MergePatch(fmt.Sprintf("/accounts/%s/objects/%s", accountID, objectID), patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Object](resp)
+}
+
 // Delete deletes an object
 func (c *Client) Delete(accountID, objectID, containerID string) error {
 	url := fmt.Sprintf("/accounts/%s/objects/%s?container_id=%s", accountID, objectID, containerID)
@@ -66,15 +82,20 @@ func (c *Client) Delete(accountID, objectID, containerID string) error {
 	return common.CheckResponse(resp)
 }
 
-// Search searches for objects based on filter criteria
-func (c *Client) Search(accountID string, filter types.SearchFilter) ([]types.SearchResult, error) {
-	// Convert filter to query parameters or POST body as needed
-	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/objects/search", accountID), filter)
+// Search finds the objects across the account's collections matching every
+// term of query, returning at most limit matches (0 for the server default).
+// Cancelling ctx abandons the request.
+func (c *Client) Search(ctx context.Context, accountID, query string, limit int) (*types.SearchResults, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	resp, err := c.common.GetContext(ctx, fmt.Sprintf("/accounts/%s/search?%s", accountID, params.Encode()))
 	if err != nil {
 		return nil, err
 	}
 
-	return common.DecodeResponseList[types.SearchResult](resp)
+	return common.DecodeResponse[types.SearchResults](resp)
 }
 
 // Move moves an object to a different container
@@ -88,9 +109,196 @@ func (c *Client) Move(accountID, objectID, newContainerID string) (*types.Object
 	return common.DecodeResponse[types.Object](resp)
 }
 
-// ListByCollection lists all objects in a collection
-func (c *Client) ListByCollection(accountID, collectionID string) ([]types.Object, error) {
-	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collections/%s/objects", accountID, collectionID))
+// Copy duplicates an object into a container, next to the original when
+// containerID is empty. The copy's DroppedProperties lists the properties
+// the target collection's schema had no place for.
+func (c *Client) Copy(accountID, objectID, containerID string) (*types.Object, error) {
+	req := types.CopyObjectRequest{ContainerID: containerID}
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/objects/%s/copy", accountID, objectID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Object](resp)
+}
+
+// History gets the timeline of containers an object has been in, oldest first
+func (c *Client) History(accountID, objectID string) (*types.ObjectHistory, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/objects/%s/history", accountID, objectID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ObjectHistory](resp)
+}
+
+// Label downloads a printable label for the object in the given format (pdf
+// or png). An empty template uses the one saved in the account preferences.
+func (c *Client) Label(accountID, objectID, format, template string) ([]byte, error) {
+	q := url.Values{}
+	q.Set("format", format)
+	if template != "" {
+		q.Set("template", template)
+	}
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/objects/%s/label?%s", accountID, objectID, q.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, common.CheckResponse(resp)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label: %w", err)
+	}
+	return data, nil
+}
+
+// ListByCollection lists all objects in a collection in the requested order
+func (c *Client) ListByCollection(accountID, collectionID string, sort types.SortOptions) ([]types.Object, error) {
+	url := fmt.Sprintf("/accounts/%s/collections/%s/objects", accountID, collectionID)
+	if q := sort.Query(); q != "" {
+		url += "?" + q
+	}
+	return c.list(url)
+}
+
+// ListByContainer lists the objects in one of a collection's containers in
+// the requested order
+func (c *Client) ListByContainer(accountID, collectionID, containerID string, sort types.SortOptions) ([]types.Object, error) {
+	url := fmt.Sprintf("/accounts/%s/collections/%s/containers/%s/objects", accountID, collectionID, containerID)
+	if q := sort.Query(); q != "" {
+		url += "?" + q
+	}
+	return c.list(url)
+}
+
+// ListArchivedByCollection lists the archived objects in a collection
+func (c *Client) ListArchivedByCollection(accountID, collectionID string) ([]types.Object, error) {
+	return c.list(fmt.Sprintf("/accounts/%s/collections/%s/objects?archived=true", accountID, collectionID))
+}
+
+// SetArchived archives an object, or restores it when archived is false
+func (c *Client) SetArchived(accountID, objectID string, archived bool) (*types.Object, error) {
+	req := map[string]bool{"archived": archived}
+	resp, err := c.common.Patch(fmt.Sprintf("/accounts/%s/objects/%s/archive", accountID, objectID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Object](resp)
+}
+
+// Claim reserves an object for the signed-in user until the given time, or
+// for the server's default duration when until is nil
+func (c *Client) Claim(accountID, objectID string, until *time.Time) (*types.Object, error) {
+	req := types.ClaimObjectRequest{Until: until}
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/objects/%s/claim", accountID, objectID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Object](resp)
+}
+
+// ReleaseClaim removes the claim on an object
+func (c *Client) ReleaseClaim(accountID, objectID string) (*types.Object, error) {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/objects/%s/claim", accountID, objectID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.Object](resp)
+}
+
+// Merge folds the given objects into the oldest of them. With req.Preview set
+// the merged object is returned without saving anything.
+func (c *Client) Merge(accountID string, req types.MergeObjectsRequest) (*types.MergeObjectsResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/objects/merge", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.MergeObjectsResult](resp)
+}
+
+// Retag adds and removes tags on the collection's objects matching
+// req.Filter, returning how many matched and changed
+func (c *Client) Retag(accountID, collectionID string, req types.RetagObjectsRequest) (*types.RetagObjectsResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/collections/%s/objects/retag", accountID, collectionID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RetagObjectsResult](resp)
+}
+
+// Batch moves or deletes the collection's objects named in req, returning
+// how many changed
+func (c *Client) Batch(accountID, collectionID string, req types.BatchObjectsRequest) (*types.BatchObjectsResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/collections/%s/objects/batch", accountID, collectionID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.BatchObjectsResult](resp)
+}
+
+// Parse reads object fields out of a quick add phrase such as "three cans of
+// tomatoes in the pantry". Nothing is saved.
+func (c *Client) Parse(accountID string, req types.ParseObjectRequest) (*types.ParsedObject, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/objects/parse", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ParsedObject](resp)
+}
+
+// FindDuplicates lists groups of same-named active objects in a collection
+func (c *Client) FindDuplicates(accountID, collectionID string) ([]types.DuplicateGroup, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/collections/%s/duplicates", accountID, collectionID))
+	if err != nil {
+		return nil, err
+	}
+
+	type duplicateGroupsResponse struct {
+		Groups []types.DuplicateGroup `json:"groups"`
+	}
+	result, err := common.DecodeResponse[duplicateGroupsResponse](resp)
+	if err != nil {
+		return nil, err
+	}
+	return result.Groups, nil
+}
+
+// Lookup finds the account's objects already carrying a barcode, ISBN or SKU
+func (c *Client) Lookup(accountID, code string) (*types.ObjectCodeLookup, error) {
+	query := url.Values{"code": {code}}
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/lookup?%s", accountID, query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ObjectCodeLookup](resp)
+}
+
+// ExpirySuggestions lists how many days the account's objects usually keep,
+// by category
+func (c *Client) ExpirySuggestions(accountID string) (*types.ExpirySuggestions, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/expiry-suggestions", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ExpirySuggestions](resp)
+}
+
+func (c *Client) list(url string) ([]types.Object, error) {
+	resp, err := c.common.Get(url)
 	if err != nil {
 		return nil, err
 	}
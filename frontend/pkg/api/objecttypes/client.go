@@ -0,0 +1,64 @@
+package objecttypes
+
+import (
+	"fmt"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles object type API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new object types API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// List gets the built-in object types followed by the user's custom ones
+func (c *Client) List(accountID string) ([]types.ObjectType, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/object-types", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := common.DecodeResponse[types.ObjectTypeList](resp)
+	if err != nil {
+		return nil, err
+	}
+	return list.ObjectTypes, nil
+}
+
+// Create defines a custom object type
+func (c *Client) Create(accountID string, req types.CreateObjectTypeRequest) (*types.ObjectType, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/object-types", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ObjectType](resp)
+}
+
+// Update changes a custom object type's name, icon, color or schema
+func (c *Client) Update(accountID, typeID string, req types.UpdateObjectTypeRequest) (*types.ObjectType, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/object-types/%s", accountID, typeID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ObjectType](resp)
+}
+
+// Delete deletes a custom object type no collection uses
+func (c *Client) Delete(accountID, typeID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/object-types/%s", accountID, typeID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
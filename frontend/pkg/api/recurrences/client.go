@@ -0,0 +1,83 @@
+package recurrences
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles recurring staple API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new recurrences API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// List gets the account's recurrence rules, soonest due first. dueOnly keeps
+// just the staples waiting to be bought
+func (c *Client) List(accountID string, dueOnly bool) (*types.RecurrenceRuleList, error) {
+	query := url.Values{}
+	if dueOnly {
+		query.Set("due", "true")
+	}
+	return c.list(accountID, query)
+}
+
+// ForObject gets the object's recurrence rule, nil when it has none
+func (c *Client) ForObject(accountID, objectID string) (*types.RecurrenceRule, error) {
+	list, err := c.list(accountID, url.Values{"object_id": {objectID}})
+	if err != nil || len(list.Rules) == 0 {
+		return nil, err
+	}
+	return &list.Rules[0], nil
+}
+
+func (c *Client) list(accountID string, query url.Values) (*types.RecurrenceRuleList, error) {
+	path := fmt.Sprintf("/accounts/%s/recurrences", accountID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := c.common.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RecurrenceRuleList](resp)
+}
+
+// Create puts an object on a schedule
+func (c *Client) Create(accountID string, req types.CreateRecurrenceRuleRequest) (*types.RecurrenceRule, error) {
+	resp, err := c.common.Post(fmt.Sprintf("/accounts/%s/recurrences", accountID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RecurrenceRule](resp)
+}
+
+// Update changes a rule's schedule or quantity, or marks its staple bought
+func (c *Client) Update(accountID, ruleID string, req types.UpdateRecurrenceRuleRequest) (*types.RecurrenceRule, error) {
+	resp, err := c.common.Put(fmt.Sprintf("/accounts/%s/recurrences/%s", accountID, ruleID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RecurrenceRule](resp)
+}
+
+// Delete takes the object off its schedule
+func (c *Client) Delete(accountID, ruleID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("/accounts/%s/recurrences/%s", accountID, ruleID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
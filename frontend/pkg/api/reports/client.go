@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles account-wide report API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new reports API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// Valuation gets what the account's objects are worth, in total and by
+// collection, object type, tag and condition
+func (c *Client) Valuation(accountID string) (*types.ValuationReport, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/reports/valuation", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ValuationReport](resp)
+}
+
+// ValuationCSV downloads the valuation report as CSV
+func (c *Client) ValuationCSV(accountID string) ([]byte, error) {
+	resp, err := c.common.Get(fmt.Sprintf("/accounts/%s/reports/valuation?format=csv", accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, common.CheckResponse(resp)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read valuation report: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,80 @@
+package snapshots
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client handles collection snapshot API calls
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new snapshots API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+func snapshotsPath(accountID, collectionID string) string {
+	return fmt.Sprintf("/accounts/%s/collections/%s/snapshots", accountID, collectionID)
+}
+
+// List gets a collection's snapshots, newest first
+func (c *Client) List(accountID, collectionID string) (*types.CollectionSnapshotList, error) {
+	resp, err := c.common.Get(snapshotsPath(accountID, collectionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionSnapshotList](resp)
+}
+
+// Create snapshots the collection as it is now
+func (c *Client) Create(accountID, collectionID, label string) (*types.CollectionSnapshot, error) {
+	resp, err := c.common.Post(snapshotsPath(accountID, collectionID), types.CreateCollectionSnapshotRequest{Label: label})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionSnapshot](resp)
+}
+
+// Diff compares a snapshot with a later one, or with the current state when
+// againstID is empty
+func (c *Client) Diff(accountID, collectionID, snapshotID, againstID string) (*types.CollectionSnapshotDiff, error) {
+	path := fmt.Sprintf("%s/%s/diff", snapshotsPath(accountID, collectionID), snapshotID)
+	if againstID != "" {
+		path += "?" + url.Values{"against": {againstID}}.Encode()
+	}
+	resp, err := c.common.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.CollectionSnapshotDiff](resp)
+}
+
+// Restore replaces the collection's containers and objects with the snapshot
+func (c *Client) Restore(accountID, collectionID, snapshotID string) (*types.RestoreSnapshotResult, error) {
+	resp, err := c.common.Post(fmt.Sprintf("%s/%s/restore", snapshotsPath(accountID, collectionID), snapshotID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.RestoreSnapshotResult](resp)
+}
+
+// Delete deletes a snapshot
+func (c *Client) Delete(accountID, collectionID, snapshotID string) error {
+	resp, err := c.common.Delete(fmt.Sprintf("%s/%s", snapshotsPath(accountID, collectionID), snapshotID))
+	if err != nil {
+		return err
+	}
+
+	return common.CheckResponse(resp)
+}
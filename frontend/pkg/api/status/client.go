@@ -0,0 +1,28 @@
+package status
+
+import (
+	"github.com/nishiki/frontend/pkg/api/common"
+	"github.com/nishiki/frontend/pkg/types"
+)
+
+// Client fetches the backend's public status, as shown in the About dialog
+type Client struct {
+	common *common.Client
+}
+
+// NewClient creates a new status API client
+func NewClient(commonClient *common.Client) *Client {
+	return &Client{
+		common: commonClient,
+	}
+}
+
+// Get fetches the server version, uptime and request counters
+func (c *Client) Get() (*types.ServerStatus, error) {
+	resp, err := c.common.Get("/status")
+	if err != nil {
+		return nil, err
+	}
+
+	return common.DecodeResponse[types.ServerStatus](resp)
+}
@@ -1,6 +1,8 @@
 package types
 
-import "time"
+import (
+	"net/url"
+)
 
 // DialogState manages dialog visibility and content
 type DialogState struct {
@@ -25,42 +27,40 @@ type DialogState struct {
 	CurrentObjectID     string
 }
 
-// SearchFilter represents search and filter criteria
-type SearchFilter struct {
-	Query         string
-	Category      string
-	Tags          []string
-	ObjectType    string
-	ContainerID   string
-	CollectionID  string
-	SortBy        string // name, created_at, updated_at, expires_at
-	SortDirection string // asc, desc
-	ExpiryRange   *DateRange
+// SortOptions selects server-side ordering for list endpoints. The zero value
+// keeps the backend's storage order.
+type SortOptions struct {
+	Field string // name, created_at, updated_at, expires_at, quantity, or custom for collections and containers
+	Order string // asc, desc
 }
 
-// DateRange represents a date range for filtering
-type DateRange struct {
-	Start *time.Time
-	End   *time.Time
+// Query encodes the sort as URL query parameters, or "" when unset
+func (s SortOptions) Query() string {
+	if s.Field == "" {
+		return ""
+	}
+	v := url.Values{"sort": {s.Field}}
+	if s.Order != "" {
+		v.Set("order", s.Order)
+	}
+	return v.Encode()
 }
 
-// SearchResult represents a search result item
-type SearchResult struct {
-	ObjectID       string
-	ObjectName     string
-	ContainerID    string
-	ContainerName  string
-	CollectionID   string
-	CollectionName string
-	Category       string
-	Snippet        string
+// ErrorResponse is the backend's error envelope. Code is stable and meant
+// for branching on; Message is the server's own wording. Error repeats
+// Message, and is all some proxies and older servers send.
+type ErrorResponse struct {
+	Error     string       `json:"error"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Fields    []ErrorField `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
 }
 
-// ErrorResponse represents an API error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
+// ErrorField names one rejected field of a request
+type ErrorField struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
-	Code    int    `json:"code"`
 }
 
 // SuccessResponse represents a generic success response
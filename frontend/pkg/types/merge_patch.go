@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/json/v2"
+	"reflect"
+)
+
+// MergePatch is an RFC 7386 JSON Merge Patch: the members to change, a nil
+// value removing one
+type MergePatch map[string]any
+
+// DiffMergePatch returns the merge patch that turns before into after, two
+// request bodies of the same type. Members after leaves out or sends empty
+// become nulls; nested objects, such as properties, are diffed member by
+// member while lists are sent whole.
+func DiffMergePatch(before, after any) (MergePatch, error) {
+	old, err := toJSONObject(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := toJSONObject(after)
+	if err != nil {
+		return nil, err
+	}
+	return diffObjects(old, updated), nil
+}
+
+func toJSONObject(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func diffObjects(before, after map[string]any) MergePatch {
+	patch := MergePatch{}
+	for k, v := range after {
+		oldObj, oldIsObj := before[k].(map[string]any)
+		newObj, newIsObj := v.(map[string]any)
+		if oldIsObj && newIsObj {
+			if nested := diffObjects(oldObj, newObj); len(nested) > 0 {
+				patch[k] = map[string]any(nested)
+			}
+			continue
+		}
+		if old, ok := before[k]; !ok || !reflect.DeepEqual(old, v) {
+			patch[k] = v
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
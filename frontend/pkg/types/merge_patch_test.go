@@ -0,0 +1,40 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMergePatch(t *testing.T) {
+	name, renamed := "Hammer", "Mallet"
+	quantity := 2.0
+	before := PatchObjectRequest{
+		Name:       &name,
+		Quantity:   &quantity,
+		Properties: map[string]any{"brand": "Acme", "color": "red", "weight": 1.5},
+		Tags:       []string{"tools"},
+	}
+	after := PatchObjectRequest{
+		Name:       &renamed,
+		Properties: map[string]any{"brand": "Acme", "color": "blue"},
+		Tags:       []string{"tools", "garage"},
+	}
+
+	got, err := DiffMergePatch(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MergePatch{
+		"name":       "Mallet",
+		"quantity":   nil,
+		"properties": map[string]any{"color": "blue", "weight": nil},
+		"tags":       []any{"tools", "garage"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffMergePatch = %v, want %v", got, want)
+	}
+
+	if got, _ := DiffMergePatch(before, before); len(got) != 0 {
+		t.Errorf("DiffMergePatch of unchanged body = %v, want empty", got)
+	}
+}
@@ -10,20 +10,133 @@ type User = response.UserResponse
 type AuthInfoResponse = response.AuthInfoResponse
 type ClaimsInfo = response.ClaimsInfo
 type Group = response.GroupResponse
+type GroupMember = response.GroupMemberResponse
+type GroupQuota = response.GroupQuotaResponse
 type Collection = response.CollectionResponse
 type Container = response.ContainerResponse
+type ContainerPlacement = response.ContainerPlacementResponse
+type FloorPlan = response.FloorPlanResponse
 type Object = response.ObjectResponse
+type ObjectClaim = response.ObjectClaimResponse
+type ObjectHistory = response.ObjectHistoryResponse
+type MergeObjectsResult = response.MergeObjectsResponse
+type RetagObjectsResult = response.RetagObjectsResponse
+type BatchObjectsResult = response.BatchObjectsResponse
+type DuplicateGroup = response.DuplicateGroupResponse
+type ParsedObject = response.ParsedObjectResponse
+type ObjectCodeLookup = response.ObjectCodeLookupResponse
+type ExpirySuggestion = response.ExpirySuggestionResponse
+type ExpirySuggestions = response.ExpirySuggestionsResponse
+type ObjectCodeMatch = response.ObjectCodeMatchResponse
+type SearchResults = response.SearchResponse
+type SearchMatch = response.SearchMatchResponse
+type TextRange = response.TextRangeResponse
 type Category = response.CategoryResponse
+type CollectionFolder = response.CollectionFolderResponse
+type CollectionFolderList = response.CollectionFolderListResponse
+type CollectionFolderStats = response.CollectionFolderStatsResponse
+type ObjectType = response.ObjectTypeResponse
+type ObjectTypeList = response.ObjectTypeListResponse
+type MealPlan = response.MealPlanResponse
+type MealIngredient = response.MealIngredientResponse
+type MealPlanList = response.MealPlanListResponse
+type CompleteMealPlanResult = response.CompleteMealPlanResponse
+type RecurrenceRule = response.RecurrenceRuleResponse
+type RecurrenceRuleList = response.RecurrenceRuleListResponse
+type AutomationRule = response.AutomationRuleResponse
+type AutomationRuleList = response.AutomationRuleListResponse
+type AutomationTrigger = response.AutomationTriggerResponse
+type AutomationAction = response.AutomationActionResponse
+type WebhookSigningKey = response.WebhookSigningKeyResponse
+type WebhookDelivery = response.WebhookDeliveryResponse
+type WebhookDeliveryList = response.WebhookDeliveryListResponse
+type InboxItem = response.InboxItemResponse
+type InboxLine = response.InboxLineResponse
+type InboxLineError = response.InboxLineErrorResponse
+type InboxItemList = response.InboxItemListResponse
+type InboxAddress = response.InboxAddressResponse
+type ConfirmInboxItemResult = response.ConfirmInboxItemResponse
+type CollectionSnapshot = response.CollectionSnapshotResponse
+type CollectionSnapshotList = response.CollectionSnapshotListResponse
+type CollectionSnapshotDiff = response.CollectionSnapshotDiffResponse
+type SnapshotContainerRef = response.SnapshotContainerRef
+type SnapshotObjectChange = response.SnapshotObjectChange
+type RestoreSnapshotResult = response.RestoreCollectionSnapshotResponse
+type UserPreferences = response.UserPreferencesResponse
+type Session = response.SessionResponse
+type SessionList = response.SessionListResponse
+type RevokeSessionsResult = response.RevokeSessionsResponse
+type Dashboard = response.DashboardResponse
+type DashboardCollectionStats = response.DashboardCollectionStatsResponse
+type Media = response.MediaResponse
+type MediaList = response.MediaListResponse
+type MediaUploadResult = response.MediaUploadResponse
+type Comment = response.CommentResponse
+type CommentList = response.CommentListResponse
+type UnreadComments = response.UnreadCommentsResponse
+type CollectionUnreadComments = response.CollectionUnreadComments
+type NutritionStats = response.NutritionStatsResponse
+type ContainerNutrition = response.ContainerNutritionResponse
+type ViewPreference = response.ViewPreferenceResponse
+type ViewSort = response.ViewSortResponse
+type ItemOrder = response.ItemOrderResponse
+type AdminUser = response.AdminUserResponse
+type AdminUserList = response.AdminUserListResponse
+type SystemStats = response.SystemStatsResponse
+type ServerStatus = response.StatusResponse
+type Features = response.FeaturesResponse
+type ImportJob = response.ImportJobResponse
+type ImportResult = response.BulkImportResponse
+type ValuationReport = response.ValuationReportResponse
+type ValuationTotal = response.ValuationTotalResponse
+type ValuationGroup = response.ValuationGroupResponse
 
 // Re-export backend request types
 type CreateGroupRequest = request.CreateGroupRequest
 type UpdateGroupRequest = request.UpdateGroupRequest
+type InviteGroupMemberRequest = request.InviteGroupMemberRequest
+type SetGroupMemberRoleRequest = request.SetGroupMemberRoleRequest
+type SetGroupQuotaRequest = request.SetGroupQuotaRequest
 type CreateCollectionRequest = request.CreateCollectionRequest
 type UpdateCollectionRequest = request.UpdateCollectionRequest
+type PatchCollectionRequest = request.PatchCollectionRequest
 type CreateContainerRequest = request.CreateContainerRequest
 type UpdateContainerRequest = request.UpdateContainerRequest
+type PatchContainerRequest = request.PatchContainerRequest
 type CreateObjectRequest = request.CreateObjectRequest
 type UpdateObjectRequest = request.UpdateObjectRequest
+type PatchObjectRequest = request.PatchObjectRequest
+type MergeObjectsRequest = request.MergeObjectsRequest
+type RetagObjectsRequest = request.RetagObjectsRequest
+type RetagFilter = request.RetagFilter
+type BatchObjectsRequest = request.BatchObjectsRequest
+type ParseObjectRequest = request.ParseObjectRequest
+type ClaimObjectRequest = request.ClaimObjectRequest
+type CopyObjectRequest = request.CopyObjectRequest
+type CreateCollectionFolderRequest = request.CreateCollectionFolderRequest
+type UpdateCollectionFolderRequest = request.UpdateCollectionFolderRequest
+type CreateObjectTypeRequest = request.CreateCustomObjectTypeRequest
+type UpdateObjectTypeRequest = request.UpdateCustomObjectTypeRequest
+type CreateMealPlanRequest = request.CreateMealPlanRequest
+type UpdateMealPlanRequest = request.UpdateMealPlanRequest
+type MealIngredientRequest = request.MealIngredientRequest
+type CreateRecurrenceRuleRequest = request.CreateRecurrenceRuleRequest
+type UpdateRecurrenceRuleRequest = request.UpdateRecurrenceRuleRequest
+type AutomationRuleRequest = request.AutomationRuleRequest
+type AutomationTriggerRequest = request.AutomationTriggerRequest
+type AutomationActionRequest = request.AutomationActionRequest
+type RotateWebhookKeyRequest = request.RotateWebhookKeyRequest
+type ConfirmInboxItemRequest = request.ConfirmInboxItemRequest
+type InboxLineRequest = request.InboxLineRequest
+type CreateCollectionSnapshotRequest = request.CreateCollectionSnapshotRequest
+type CreateCommentRequest = request.CreateCommentRequest
+type MarkCommentsReadRequest = request.MarkCommentsReadRequest
+type UpdateUserPreferencesRequest = request.UpdateUserPreferencesRequest
+type UpdateSessionRequest = request.UpdateSessionRequest
+type ViewPreferenceRequest = request.ViewPreferenceRequest
+type ViewSortRequest = request.ViewSortRequest
+type UpdateItemOrderRequest = request.UpdateItemOrderRequest
+type ItemOrderRequest = request.ItemOrderRequest
 type CreateCategoryRequest = request.CreateCategoryRequest
 type UpdateCategoryRequest = request.UpdateCategoryRequest
 type BulkImportRequest = request.BulkImportRequest
@@ -31,3 +144,7 @@ type BulkImportCollectionRequest = request.BulkImportCollectionRequest
 type UpdatePropertySchemaRequest = request.UpdatePropertySchemaRequest
 type PropertySchemaRequest = request.PropertySchemaRequest
 type PropertyDefinitionRequest = request.PropertyDefinitionRequest
+type UpdateShelfLifeRequest = request.UpdateShelfLifeRequest
+type ShelfLifeRequest = request.ShelfLifeRequest
+type UpdateContainerPlacementsRequest = request.UpdateContainerPlacementsRequest
+type ContainerPlacementRequest = request.ContainerPlacementRequest
@@ -0,0 +1,310 @@
+package webserve
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// versionedAssets are the URLs the HTML pages load that get the build hash
+// appended, so browsers may cache them for good and still pick up a rebuild.
+var versionedAssets = []string{"app.wasm", "wasm_exec.js", "vendor/redoc.standalone.js"}
+
+// ServiceWorkerScript is the service worker cmd/web writes into the web
+// output. It must be served from the root to control the whole app.
+const ServiceWorkerScript = "sw.js"
+
+// ServiceWorkerBuildPlaceholder is replaced with the build hash when the
+// service worker is loaded, so it names its cache after the build and
+// precaches the same versioned URLs the pages load
+const ServiceWorkerBuildPlaceholder = "__NISHIKI_BUILD__"
+
+// appBackendURLGlobal is the window property the WASM app reads its backend
+// URL from when the server sets one; see config.LoadConfig
+const appBackendURLGlobal = "__NISHIKI_BACKEND_URL__"
+
+// compressible lists the content types worth pre-compressing
+var compressible = []string{"application/wasm", "application/javascript", "text/css", "text/html", "application/json", "application/manifest+json", "image/svg+xml"}
+
+// asset is a file from the web output directory held in memory together
+// with its compressed encodings
+type asset struct {
+	contentType string
+	modTime     time.Time
+	etag        string            // quoted hash of the identity content
+	encodings   map[string][]byte // "" (identity), "br", "gzip"
+}
+
+// assetCache keeps the whole web output directory in memory so requests never
+// touch the disk. Compressed copies are made once at load: app.wasm.br or
+// app.wasm.gz files next to an asset are used when the build produced them,
+// otherwise gzip is done here. Brotli needs the prebuilt file.
+type assetCache struct {
+	fsys fs.FS // the gio-web directory on disk or the bundled build
+	opts Options
+
+	mu        sync.RWMutex
+	assets    map[string]*asset // by slash path relative to fsys, e.g. "vendor/redoc.standalone.js"
+	buildHash string
+	stamp     time.Time // newest mtime of the files checked by refresh
+}
+
+func newAssetCache(fsys fs.FS, opts Options) (*assetCache, error) {
+	c := &assetCache{fsys: fsys, opts: opts}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load reads every file in fsys and swaps it in as the current cache
+func (c *assetCache) load() error {
+	start := time.Now()
+	raw := make(map[string][]byte)
+	modTimes := make(map[string]time.Time)
+	err := fs.WalkDir(c.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := fs.ReadFile(c.fsys, name)
+		if err != nil {
+			return err
+		}
+		raw[name] = content
+		modTimes[name] = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("loading web assets: %w", err)
+	}
+
+	buildHash := hashAssets(raw)
+	assets := make(map[string]*asset)
+	for name, content := range raw {
+		if !isServed(name) {
+			continue
+		}
+		if strings.HasSuffix(name, ".html") {
+			content = c.rewriteHTML(name, content, buildHash)
+		}
+		if name == ServiceWorkerScript {
+			content = bytes.ReplaceAll(content, []byte(ServiceWorkerBuildPlaceholder), []byte(buildHash))
+		}
+		a := &asset{
+			contentType: getContentType(name),
+			modTime:     modTimes[name],
+			etag:        contentETag(content),
+			encodings:   map[string][]byte{"": content},
+		}
+		if a.contentType == "" {
+			a.contentType = http.DetectContentType(content)
+		}
+		if slices.Contains(compressible, a.contentType) && len(content) >= 1024 {
+			// A rewritten file no longer matches its prebuilt compressed
+			// copies, and neither does a file rebuilt after they were made
+			fresh := func(ext string) bool {
+				mt, ok := modTimes[name+ext]
+				return ok && !rewritten(name) && !mt.Before(modTimes[name])
+			}
+			if fresh(".br") {
+				a.encodings["br"] = raw[name+".br"]
+			}
+			if fresh(".gz") {
+				a.encodings["gzip"] = raw[name+".gz"]
+			} else if gz, err := gzipBytes(content); err == nil {
+				a.encodings["gzip"] = gz
+			}
+		}
+		assets[name] = a
+	}
+
+	c.mu.Lock()
+	c.assets = assets
+	c.buildHash = buildHash
+	c.stamp = c.newestMTime()
+	c.mu.Unlock()
+
+	slog.Info("Web assets cached", "files", len(assets), "build", buildHash, "took", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// refresh reloads the cache when index.html or app.wasm changed on disk, so
+// `just build` shows up on the next page load without restarting the server.
+// It is only called for page loads, which keeps asset requests off the disk.
+// A bundled build has no modification times and is never reloaded.
+func (c *assetCache) refresh() {
+	c.mu.RLock()
+	stamp := c.stamp
+	c.mu.RUnlock()
+	if !c.newestMTime().After(stamp) {
+		return
+	}
+	if err := c.load(); err != nil {
+		slog.Error("reloading web assets", "error", err)
+	}
+}
+
+func (c *assetCache) newestMTime() time.Time {
+	var newest time.Time
+	for _, name := range []string{"index.html", "app.wasm"} {
+		if info, err := fs.Stat(c.fsys, name); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest
+}
+
+// rewriteHTML appends the build hash to the versioned asset URLs a page
+// loads, and injects the backend URL into the docs page so it can find the
+// OpenAPI spec, and into the app page when Options.ConfigureApp is set
+func (c *assetCache) rewriteHTML(name string, content []byte, buildHash string) []byte {
+	page := string(content)
+	for _, v := range versionedAssets {
+		versioned := v + "?v=" + buildHash
+		page = strings.ReplaceAll(page, `"`+v+`"`, `"`+versioned+`"`)
+		page = strings.ReplaceAll(page, `'`+v+`'`, `'`+versioned+`'`)
+	}
+	if name == "docs.html" {
+		page = strings.Replace(page, "window.__NISHIKI_BACKEND_URL__", fmt.Sprintf("'%s'", c.opts.BackendURL), 1)
+	}
+	if name == "index.html" && c.opts.ConfigureApp {
+		script := fmt.Sprintf("<script>window.%s = %s;</script>\n</head>", appBackendURLGlobal, strconv.Quote(c.opts.BackendURL))
+		page = strings.Replace(page, "</head>", script, 1)
+	}
+	return []byte(page)
+}
+
+func (c *assetCache) lookup(name string) (*asset, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	a, ok := c.assets[strings.TrimPrefix(path.Clean("/"+name), "/")]
+	return a, ok
+}
+
+// serve writes a cached asset. Pages and the service worker must be
+// revalidated on every load so a rebuild is picked up; assets requested with
+// the current build hash never change and may be kept for a year; anything
+// else is revalidated by ETag.
+func (c *assetCache) serve(w http.ResponseWriter, r *http.Request, a *asset) {
+	c.mu.RLock()
+	buildHash := c.buildHash
+	worker := c.assets[ServiceWorkerScript]
+	c.mu.RUnlock()
+
+	h := w.Header()
+	h.Set("Content-Type", a.contentType)
+	switch {
+	case a.contentType == "text/html", a == worker:
+		h.Set("Cache-Control", "no-cache")
+	case r.URL.Query().Get("v") == buildHash:
+		h.Set("Cache-Control", "public, max-age=31536000, immutable")
+	default:
+		h.Set("Cache-Control", "public, no-cache")
+	}
+
+	encoding, body := a.negotiate(r.Header.Get("Accept-Encoding"))
+	if len(a.encodings) > 1 {
+		h.Add("Vary", "Accept-Encoding")
+	}
+	etag := a.etag
+	if encoding != "" {
+		h.Set("Content-Encoding", encoding)
+		etag = strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+	}
+	h.Set("ETag", etag)
+
+	http.ServeContent(w, r, "", a.modTime, bytes.NewReader(body))
+}
+
+// negotiate picks the smallest encoding the client accepts: brotli, then
+// gzip, then the identity content
+func (a *asset) negotiate(acceptEncoding string) (string, []byte) {
+	accepted := make(map[string]bool)
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		accepted[strings.ToLower(name)] = true
+	}
+	for _, enc := range []string{"br", "gzip"} {
+		if body, ok := a.encodings[enc]; ok && (accepted[enc] || accepted["*"]) {
+			return enc, body
+		}
+	}
+	return "", a.encodings[""]
+}
+
+// isServed reports whether a file in the web output is an asset of its own
+// rather than a compressed copy, a build script or the bundling source
+func isServed(name string) bool {
+	if strings.HasPrefix(path.Base(name), ".") {
+		return false
+	}
+	switch path.Ext(name) {
+	case ".br", ".gz", ".sh", ".go":
+		return false
+	}
+	return true
+}
+
+// rewritten reports whether load changes the file's content, the pages and
+// the service worker
+func rewritten(name string) bool {
+	return strings.HasSuffix(name, ".html") || name == ServiceWorkerScript
+}
+
+// hashAssets fingerprints the build from everything but the pages, which
+// only reference it
+func hashAssets(raw map[string][]byte) string {
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		if !strings.HasSuffix(name, ".html") {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write(raw[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func gzipBytes(content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(content); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
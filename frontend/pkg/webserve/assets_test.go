@@ -0,0 +1,219 @@
+package webserve
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const testIndex = `<script src="wasm_exec.js"></script><script>fetch('app.wasm')</script>`
+
+func writeWebDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":   testIndex,
+		"docs.html":    `<script src="vendor/redoc.standalone.js"></script><script>var u = window.__NISHIKI_BACKEND_URL__;</script>`,
+		"app.wasm":     strings.Repeat("wasm", 1024),
+		"app.wasm.br":  "brotli bytes",
+		"wasm_exec.js": "// tiny",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func get(t *testing.T, h http.Handler, target string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSPAHandler_CachesAssets(t *testing.T) {
+	dir := writeWebDir(t)
+	h, err := New(os.DirFS(dir), Options{BackendURL: "http://api.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assets := h.assets
+	v := "?v=" + assets.buildHash
+
+	index := get(t, h, "/collections/123", nil)
+	if body := index.Body.String(); !strings.Contains(body, `src="wasm_exec.js`+v+`"`) || !strings.Contains(body, `fetch('app.wasm`+v+`')`) {
+		t.Errorf("index.html asset URLs not versioned: %s", body)
+	}
+	if got := index.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("index Cache-Control = %q, want no-cache", got)
+	}
+
+	br := get(t, h, "/app.wasm"+v, map[string]string{"Accept-Encoding": "gzip, br"})
+	if br.Header().Get("Content-Encoding") != "br" || br.Body.String() != "brotli bytes" {
+		t.Errorf("expected the prebuilt brotli copy, got %q encoded %q", br.Body.String(), br.Header().Get("Content-Encoding"))
+	}
+	if got := br.Header().Get("Cache-Control"); !strings.Contains(got, "immutable") {
+		t.Errorf("versioned asset Cache-Control = %q, want immutable", got)
+	}
+	if br.Header().Get("Content-Type") != "application/wasm" || br.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("unexpected headers %v", br.Header())
+	}
+
+	gz := get(t, h, "/app.wasm", map[string]string{"Accept-Encoding": "gzip, br;q=0"})
+	if gz.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip, got %q", gz.Header().Get("Content-Encoding"))
+	}
+	zr, err := gzip.NewReader(gz.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, _ := io.ReadAll(zr)
+	if !bytes.Equal(plain, []byte(strings.Repeat("wasm", 1024))) {
+		t.Error("gzip copy does not decode to app.wasm")
+	}
+	if got := gz.Header().Get("Cache-Control"); got != "public, no-cache" {
+		t.Errorf("unversioned asset Cache-Control = %q, want revalidation", got)
+	}
+
+	notModified := get(t, h, "/app.wasm", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": gz.Header().Get("ETag")})
+	if notModified.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match status = %d, want 304", notModified.Code)
+	}
+
+	docs := get(t, h, "/docs/", nil)
+	if body := docs.Body.String(); !strings.Contains(body, "'http://api.test'") || !strings.Contains(body, "redoc.standalone.js"+v) {
+		t.Errorf("docs page not rewritten: %s", body)
+	}
+
+	if rec := get(t, h, "/missing.png", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("missing asset status = %d, want 404", rec.Code)
+	}
+	if rec := get(t, h, "/objects/wasm_exec.js", nil); rec.Body.String() != "// tiny" {
+		t.Errorf("asset under an SPA route not served from the root: %q", rec.Body.String())
+	}
+}
+
+func TestSPAHandler_PicksUpRebuild(t *testing.T) {
+	dir := writeWebDir(t)
+	h, err := New(os.DirFS(dir), Options{BackendURL: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assets := h.assets
+	before := assets.buildHash
+
+	wasm := filepath.Join(dir, "app.wasm")
+	if err := os.WriteFile(wasm, []byte("rebuilt"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(wasm, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	// Asset requests stay off the disk until the next page load
+	if rec := get(t, h, "/app.wasm", nil); rec.Body.String() == "rebuilt" {
+		t.Error("asset request reloaded the cache")
+	}
+	index := get(t, h, "/", nil)
+	if assets.buildHash == before || !strings.Contains(index.Body.String(), assets.buildHash) {
+		t.Errorf("page load did not pick up the rebuild (hash %s)", assets.buildHash)
+	}
+	if rec := get(t, h, "/app.wasm", nil); rec.Body.String() != "rebuilt" {
+		t.Errorf("app.wasm = %q after reload", rec.Body.String())
+	}
+}
+
+func TestSPAHandler_BundledBuild(t *testing.T) {
+	// Embedded files have no modification times
+	bundle := fstest.MapFS{
+		"index.html":       {Data: []byte(testIndex)},
+		"app.wasm":         {Data: []byte("wasm")},
+		"wasm_exec.js":     {Data: []byte("// tiny")},
+		"embed.go":         {Data: []byte("package gioweb")},
+		"vendor/.gitkeep":  {},
+		"update-vendor.sh": {Data: []byte("#!/bin/sh")},
+	}
+	h, err := New(bundle, Options{BackendURL: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assets := h.assets
+
+	if rec := get(t, h, "/app.wasm", nil); rec.Body.String() != "wasm" || rec.Header().Get("Last-Modified") != "" {
+		t.Errorf("bundled app.wasm = %q, Last-Modified %q", rec.Body.String(), rec.Header().Get("Last-Modified"))
+	}
+	if rec := get(t, h, "/groups", nil); !strings.Contains(rec.Body.String(), "wasm_exec.js?v="+assets.buildHash) {
+		t.Errorf("SPA route not served index.html: %q", rec.Body.String())
+	}
+	for _, name := range []string{"/embed.go", "/vendor/.gitkeep", "/update-vendor.sh"} {
+		if rec := get(t, h, name, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("%s status = %d, want 404", name, rec.Code)
+		}
+	}
+}
+
+func TestHandler_ConfigureApp(t *testing.T) {
+	dir := writeWebDir(t)
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<head></head>"+testIndex), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h, err := New(os.DirFS(dir), Options{BackendURL: "/api", ConfigureApp: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := get(t, h, "/objects/1", nil).Body.String()
+	if !strings.Contains(index, `<script>window.__NISHIKI_BACKEND_URL__ = "/api";</script>`+"\n</head>") {
+		t.Errorf("backend URL not handed to the app: %s", index)
+	}
+	if docs := get(t, h, "/docs", nil).Body.String(); !strings.Contains(docs, `var u = '/api';`) {
+		t.Errorf("backend URL not injected into docs: %s", docs)
+	}
+}
+
+func TestSPAHandler_ServiceWorker(t *testing.T) {
+	dir := writeWebDir(t)
+	worker := "const BUILD = '" + ServiceWorkerBuildPlaceholder + "';\n" + strings.Repeat("// padding\n", 200)
+	if err := os.WriteFile(filepath.Join(dir, ServiceWorkerScript), []byte(worker), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A stale prebuilt copy still holds the placeholder
+	if err := os.WriteFile(filepath.Join(dir, ServiceWorkerScript+".br"), []byte("brotli bytes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.webmanifest"), []byte(`{"name":"Nishiki"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h, err := New(os.DirFS(dir), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sw := get(t, h, "/sw.js", map[string]string{"Accept-Encoding": "br"})
+	if body := sw.Body.String(); !strings.Contains(body, "const BUILD = '"+h.assets.buildHash+"';") {
+		t.Errorf("build hash not filled in: %q", body)
+	}
+	if got := sw.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("service worker Cache-Control = %q, want no-cache", got)
+	}
+
+	manifest := get(t, h, "/manifest.webmanifest", nil)
+	if got := manifest.Header().Get("Content-Type"); got != "application/manifest+json" {
+		t.Errorf("manifest Content-Type = %q", got)
+	}
+}
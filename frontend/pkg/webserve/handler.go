@@ -0,0 +1,113 @@
+// Package webserve serves the Gio web build as a single-page app from
+// memory, with SPA fallback routing, pre-compressed assets and build-hash
+// cache busting. It is shared by the frontend's serve command and the
+// backend's single-binary mode.
+package webserve
+
+import (
+	"io/fs"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Options configures a Handler
+type Options struct {
+	// BackendURL is where the docs page fetches the OpenAPI spec from. It
+	// may be a path on the same origin, e.g. "/api".
+	BackendURL string
+	// ConfigureApp also hands BackendURL to the WASM app, overriding the
+	// backend_url it was built with. Set it when the page and the API share
+	// an origin.
+	ConfigureApp bool
+}
+
+// Handler implements SPA fallback routing over the in-memory asset cache
+type Handler struct {
+	assets *assetCache
+}
+
+// New loads the web build in fsys into memory. A build on disk (os.DirFS)
+// is reloaded on the next page load after index.html or app.wasm change.
+func New(fsys fs.FS, opts Options) (*Handler, error) {
+	assets, err := newAssetCache(fsys, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{assets: assets}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	urlPath := path.Clean("/" + r.URL.Path)
+
+	// Serve docs.html with backend URL injected
+	if urlPath == "/docs" {
+		h.servePage(w, r, "docs.html")
+		return
+	}
+
+	if a, ok := h.assets.lookup(urlPath); ok {
+		if a.contentType == "text/html" {
+			h.servePage(w, r, urlPath)
+			return
+		}
+		h.assets.serve(w, r, a)
+		return
+	}
+
+	// Check if the request is for a static asset (has file extension)
+	if path.Ext(urlPath) != "" {
+		// Assets requested relative to an SPA route live in the root
+		if a, ok := h.assets.lookup(path.Base(urlPath)); ok {
+			h.assets.serve(w, r, a)
+			return
+		}
+		http.NotFound(w, r)
+		return
+	}
+
+	// A directory with its own index.html, otherwise SPA routing
+	if _, ok := h.assets.lookup(path.Join(urlPath, "index.html")); ok {
+		h.servePage(w, r, path.Join(urlPath, "index.html"))
+		return
+	}
+	h.servePage(w, r, "index.html")
+}
+
+// servePage serves an HTML page, first picking up a rebuild if there was one
+func (h *Handler) servePage(w http.ResponseWriter, r *http.Request, name string) {
+	h.assets.refresh()
+	a, ok := h.assets.lookup(name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	h.assets.serve(w, r, a)
+}
+
+func getContentType(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".wasm":
+		return "application/wasm"
+	case ".js":
+		return "application/javascript"
+	case ".css":
+		return "text/css"
+	case ".html":
+		return "text/html"
+	case ".json":
+		return "application/json"
+	case ".webmanifest":
+		return "application/manifest+json"
+	case ".png":
+		return "image/png"
+	case ".svg":
+		return "image/svg+xml"
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	default:
+		return ""
+	}
+}
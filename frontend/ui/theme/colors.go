@@ -136,6 +136,20 @@ var (
 	ColorBlue600 = color.NRGBA{R: 37, G: 99, B: 235, A: 255} // #2563eb
 	ColorBlue700 = color.NRGBA{R: 29, G: 78, B: 216, A: 255} // #1d4ed8
 
+	// Warning color (for low-stock badges)
+	ColorWarning = color.NRGBA{R: 217, G: 119, B: 6, A: 255} // #d97706
+
+	// Condition grade colors (for collectible badges), best to worst
+	ColorConditionMint     = color.NRGBA{R: 5, G: 150, B: 105, A: 255}  // #059669
+	ColorConditionNearMint = color.NRGBA{R: 101, G: 163, B: 13, A: 255} // #65a30d
+	ColorConditionGood     = color.NRGBA{R: 2, G: 132, B: 199, A: 255}  // #0284c7
+	ColorConditionFair     = color.NRGBA{R: 217, G: 119, B: 6, A: 255}  // #d97706
+	ColorConditionPoor     = color.NRGBA{R: 220, G: 38, B: 38, A: 255}  // #dc2626
+
+	// Wishlist status colors (for wanted and ordered badges)
+	ColorStatusWanted  = color.NRGBA{R: 124, G: 58, B: 237, A: 255} // #7c3aed
+	ColorStatusOrdered = color.NRGBA{R: 79, G: 70, B: 229, A: 255}  // #4f46e5
+
 	// Legacy aliases (kept for compatibility, map to palette)
 	ColorGrayLightest = ActivePalette.SurfaceAlt
 	ColorGrayLight    = ActivePalette.Border
@@ -0,0 +1,24 @@
+//go:build !nocjk
+
+package theme
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gioui.org/font"
+	"gioui.org/font/opentype"
+)
+
+//go:embed fonts/NotoSansJP-Regular.otf
+var notoSansJPRegular []byte
+
+// cjkFaces returns Noto Sans JP so Japanese names render. It adds about
+// 4.5 MB to the binary; build with -tags nocjk to leave it out.
+func cjkFaces() []font.FontFace {
+	jpFaces, err := opentype.ParseCollection(notoSansJPRegular)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse Noto Sans JP font: %w", err))
+	}
+	return jpFaces
+}
@@ -0,0 +1,9 @@
+//go:build nocjk
+
+package theme
+
+import "gioui.org/font"
+
+// cjkFaces returns nothing in nocjk builds; CJK text then falls back to the
+// Go fonts and shows as missing glyphs.
+func cjkFaces() []font.FontFace { return nil }
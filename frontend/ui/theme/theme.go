@@ -1,21 +1,15 @@
 package theme
 
 import (
-	_ "embed"
-	"fmt"
 	"image/color"
 
 	"gioui.org/font"
 	"gioui.org/font/gofont"
-	"gioui.org/font/opentype"
 	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget/material"
 )
 
-//go:embed fonts/NotoSansJP-Regular.otf
-var notoSansJPRegular []byte
-
 // NishikiTheme extends material.Theme with custom colors and styling
 type NishikiTheme struct {
 	*material.Theme
@@ -46,13 +40,9 @@ func NewTheme() *NishikiTheme {
 	// Start with default material theme and register Go fonts + CJK fallback
 	th := material.NewTheme()
 
-	// Build font collection: Go fonts + Noto Sans JP for CJK characters
-	fonts := gofont.Collection()
-	jpFaces, err := opentype.ParseCollection(notoSansJPRegular)
-	if err != nil {
-		panic(fmt.Errorf("failed to parse Noto Sans JP font: %w", err))
-	}
-	fonts = append(fonts, jpFaces...)
+	// Build font collection: Go fonts + Noto Sans JP for CJK characters,
+	// unless the build left it out with the nocjk tag
+	fonts := append(gofont.Collection(), cjkFaces()...)
 
 	th.Shaper = text.NewShaper(text.WithCollection(fonts))
 
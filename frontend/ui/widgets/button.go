@@ -1,9 +1,12 @@
 package widgets
 
 import (
+	"image"
 	"image/color"
 
+	"gioui.org/io/semantic"
 	"gioui.org/layout"
+	"gioui.org/op"
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
@@ -13,7 +16,10 @@ import (
 
 // Button renders a custom styled button
 type Button struct {
-	Text            string
+	Text string
+	// Description is what screen readers announce in place of Text, for
+	// buttons whose text is a symbol
+	Description     string
 	BackgroundColor color.NRGBA
 	TextColor       color.NRGBA
 	CornerRadius    unit.Dp
@@ -34,13 +40,21 @@ func (b Button) Layout(gtx layout.Context, th *material.Theme, btn *widget.Click
 	bls.Background = b.BackgroundColor
 	bls.CornerRadius = b.CornerRadius
 
-	return bls.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+	TrackFocus(gtx, btn)
+	dims := bls.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		if b.Description != "" {
+			semantic.DescriptionOp(b.Description).Add(gtx.Ops)
+		}
 		return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 			label := material.Label(th, unit.Sp(theme.FontSizeBase), b.Text)
 			label.Color = b.TextColor
 			return label.Layout(gtx)
 		})
 	})
+	if gtx.Focused(btn) {
+		paintFocusRing(gtx, dims.Size, b.CornerRadius)
+	}
+	return dims
 }
 
 // PrimaryButton creates a primary styled button
@@ -117,3 +131,64 @@ func LoginButton(th *material.Theme, btn *widget.Clickable, text string) layout.
 		return b.Layout(gtx, th, btn)
 	}
 }
+
+// IconButton creates a compact button showing a symbol such as "+" or "↑".
+// Screen readers announce label instead, and it is shown as a tooltip while
+// the button is hovered or focused.
+func IconButton(th *material.Theme, btn *widget.Clickable, symbol, label string) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		b := Button{
+			Text:            symbol,
+			Description:     label,
+			BackgroundColor: theme.ColorSurfaceAlt,
+			TextColor:       theme.ColorTextPrimary,
+			CornerRadius:    unit.Dp(theme.RadiusDefault),
+			Inset:           layout.UniformInset(unit.Dp(theme.Spacing4)),
+		}
+		return b.layoutWithTooltip(gtx, th, btn)
+	}
+}
+
+// PrimaryIconButton is an IconButton in the primary style, for the "+"
+// buttons that create things
+func PrimaryIconButton(th *material.Theme, btn *widget.Clickable, symbol, label string) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		b := Button{
+			Text:            symbol,
+			Description:     label,
+			BackgroundColor: theme.ColorPrimary,
+			TextColor:       theme.ColorWhite,
+			CornerRadius:    unit.Dp(theme.RadiusDefault),
+			Inset:           layout.UniformInset(unit.Dp(theme.Spacing4)),
+		}
+		return b.layoutWithTooltip(gtx, th, btn)
+	}
+}
+
+// layoutWithTooltip lays out the button with its description in a tooltip
+// below it while it is hovered or focused
+func (b Button) layoutWithTooltip(gtx layout.Context, th *material.Theme, btn *widget.Clickable) layout.Dimensions {
+	dims := b.Layout(gtx, th, btn)
+	if b.Description == "" || !(btn.Hovered() || gtx.Focused(btn)) {
+		return dims
+	}
+
+	// Deferred so the tooltip draws over the widgets laid out after the
+	// button
+	macro := op.Record(gtx.Ops)
+	op.Offset(image.Pt(0, dims.Size.Y+gtx.Dp(unit.Dp(theme.Spacing1)))).Add(gtx.Ops)
+	tgtx := gtx
+	tgtx.Constraints.Min = image.Point{}
+	Card{
+		BackgroundColor: theme.ColorTextPrimary,
+		CornerRadius:    unit.Dp(theme.RadiusSM),
+		Inset:           layout.UniformInset(unit.Dp(theme.Spacing1)),
+	}.Layout(tgtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Caption(th, b.Description)
+		label.Color = theme.ColorSurface
+		label.MaxLines = 1
+		return label.Layout(gtx)
+	})
+	op.Defer(gtx.Ops, macro.Stop())
+	return dims
+}
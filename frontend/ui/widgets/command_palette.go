@@ -0,0 +1,260 @@
+package widgets
+
+import (
+	"slices"
+	"strings"
+
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// Command is a single entry in the command palette
+type Command struct {
+	Title    string
+	Shortcut string // Optional key hint shown next to the title (e.g. "g c")
+	Action   func()
+}
+
+// CommandPalette is a searchable modal list of commands, opened with Ctrl/Cmd+K
+type CommandPalette struct {
+	visible    bool
+	search     widget.Editor
+	list       widget.List
+	clickables []widget.Clickable
+	selected   int
+	dialog     *Dialog
+}
+
+// NewCommandPalette creates a hidden command palette
+func NewCommandPalette() *CommandPalette {
+	return &CommandPalette{
+		search: widget.Editor{SingleLine: true, Submit: true},
+		list:   widget.List{List: layout.List{Axis: layout.Vertical}},
+		dialog: NewDialog(),
+	}
+}
+
+// Visible reports whether the palette is open
+func (cp *CommandPalette) Visible() bool {
+	return cp.visible
+}
+
+// Open shows the palette with an empty query and focuses its search field
+func (cp *CommandPalette) Open() {
+	cp.visible = true
+	cp.selected = 0
+	cp.search.SetText("")
+}
+
+// Close hides the palette
+func (cp *CommandPalette) Close() {
+	cp.visible = false
+}
+
+// Toggle opens the palette if hidden and closes it otherwise
+func (cp *CommandPalette) Toggle() {
+	if cp.visible {
+		cp.Close()
+	} else {
+		cp.Open()
+	}
+}
+
+// filterCommands returns the commands matching the query, best matches
+// first: titles starting with the query, then titles with a word starting
+// with it, titles containing it, matching shortcuts, and last titles holding
+// its letters in order, so "gcol" finds "Go to Collections". Commands that
+// match equally well keep their order.
+func filterCommands(commands []Command, query string) []Command {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return commands
+	}
+	type match struct {
+		command Command
+		rank    int
+	}
+	var matches []match
+	for _, c := range commands {
+		if rank, ok := commandRank(c, query); ok {
+			matches = append(matches, match{command: c, rank: rank})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int { return a.rank - b.rank })
+
+	out := make([]Command, len(matches))
+	for i, m := range matches {
+		out[i] = m.command
+	}
+	return out
+}
+
+// commandRank scores how well c matches the lowercase query; lower is better
+func commandRank(c Command, query string) (int, bool) {
+	title := strings.ToLower(c.Title)
+	switch {
+	case strings.HasPrefix(title, query):
+		return 0, true
+	case strings.Contains(title, " "+query):
+		return 1, true
+	case strings.Contains(title, query):
+		return 2, true
+	case strings.Contains(strings.ToLower(c.Shortcut), query):
+		return 3, true
+	case isSubsequence(query, title):
+		return 4, true
+	}
+	return 0, false
+}
+
+// isSubsequence reports whether the runes of sub appear in s in order
+func isSubsequence(sub, s string) bool {
+	rest := []rune(sub)
+	for _, r := range s {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
+
+// Layout renders the palette over the current view. The selected command's
+// Action is run after the palette closes, so actions may reopen dialogs.
+func (cp *CommandPalette) Layout(gtx layout.Context, th *material.Theme, commands []Command) layout.Dimensions {
+	if !cp.visible {
+		return layout.Dimensions{}
+	}
+
+	matches := filterCommands(commands, cp.search.Text())
+	if len(cp.clickables) < len(matches) {
+		cp.clickables = append(cp.clickables, make([]widget.Clickable, len(matches)-len(cp.clickables))...)
+	}
+
+	var run func()
+
+	// Keyboard navigation must be read before the editor lays out, otherwise
+	// the editor consumes the arrow keys itself.
+	for {
+		ev, ok := gtx.Event(
+			key.Filter{Focus: &cp.search, Name: key.NameUpArrow},
+			key.Filter{Focus: &cp.search, Name: key.NameDownArrow},
+			key.Filter{Focus: &cp.search, Name: key.NameEscape},
+		)
+		if !ok {
+			break
+		}
+		e, ok := ev.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+		switch e.Name {
+		case key.NameUpArrow:
+			if cp.selected > 0 {
+				cp.selected--
+			}
+		case key.NameDownArrow:
+			if cp.selected < len(matches)-1 {
+				cp.selected++
+			}
+		case key.NameEscape:
+			cp.Close()
+			return layout.Dimensions{}
+		}
+	}
+
+	for {
+		ev, ok := cp.search.Update(gtx)
+		if !ok {
+			break
+		}
+		switch ev.(type) {
+		case widget.ChangeEvent:
+			cp.selected = 0
+		case widget.SubmitEvent:
+			if cp.selected < len(matches) {
+				run = matches[cp.selected].Action
+			}
+		}
+	}
+
+	for i := range matches {
+		if cp.clickables[i].Clicked(gtx) {
+			run = matches[i].Action
+		}
+	}
+
+	if cp.selected >= len(matches) {
+		cp.selected = max(len(matches)-1, 0)
+	}
+
+	if run != nil {
+		cp.Close()
+		run()
+		return layout.Dimensions{}
+	}
+
+	style := DefaultDialogStyle(cp.dialog, "Command Palette")
+	style.InitialFocus = &cp.search
+	dims, dismissed := style.Layout(gtx, th, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				editor := material.Editor(th, &cp.search, "Type a command...")
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, editor.Layout)
+			}),
+			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+				if len(matches) == 0 {
+					label := material.Body2(th, "No matching commands")
+					label.Color = theme.ColorTextSecondary
+					return label.Layout(gtx)
+				}
+				return material.List(th, &cp.list).Layout(gtx, len(matches), func(gtx layout.Context, i int) layout.Dimensions {
+					return cp.layoutRow(gtx, th, matches[i], &cp.clickables[i], i == cp.selected)
+				})
+			}),
+		)
+	})
+	if dismissed {
+		cp.Close()
+	}
+	return dims
+}
+
+// layoutRow renders a single command row with its shortcut hint
+func (cp *CommandPalette) layoutRow(gtx layout.Context, th *material.Theme, cmd Command, click *widget.Clickable, selected bool) layout.Dimensions {
+	bg := theme.ColorSurface
+	if selected || click.Hovered() {
+		bg = theme.ColorSurfaceAlt
+	}
+	return click.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		card := Card{
+			BackgroundColor: bg,
+			CornerRadius:    unit.Dp(theme.RadiusSM),
+			Inset:           layout.UniformInset(unit.Dp(theme.Spacing2)),
+		}
+		return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					label := material.Body1(th, cmd.Title)
+					label.Color = theme.ColorTextPrimary
+					return label.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					if cmd.Shortcut == "" {
+						return layout.Dimensions{}
+					}
+					hint := material.Caption(th, cmd.Shortcut)
+					hint.Color = theme.ColorTextSecondary
+					return hint.Layout(gtx)
+				}),
+			)
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package widgets
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFilterCommands(t *testing.T) {
+	commands := []Command{
+		{Title: "Go to Dashboard", Shortcut: "g h"},
+		{Title: "Go to Collections", Shortcut: "g c"},
+		{Title: "Go to Groups", Shortcut: "g g"},
+		{Title: "Create Collection", Shortcut: "n"},
+		{Title: "Search Collections", Shortcut: "/"},
+		{Title: "Sign Out"},
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"empty query lists everything", "  ", []string{"Go to Dashboard", "Go to Collections", "Go to Groups", "Create Collection", "Search Collections", "Sign Out"}},
+		{"case is ignored", "SIGN", []string{"Sign Out"}},
+		{"title prefix before word start", "co", []string{"Go to Collections", "Create Collection", "Search Collections"}},
+		{"prefix beats word start", "create", []string{"Create Collection"}},
+		{"word start before substring", "out", []string{"Sign Out"}},
+		{"substring", "llect", []string{"Go to Collections", "Create Collection", "Search Collections"}},
+		{"shortcut", "g c", []string{"Go to Collections"}},
+		{"letters in order", "gcol", []string{"Go to Collections"}},
+		{"title match before shortcut and fuzzy", "g", []string{"Go to Dashboard", "Go to Collections", "Go to Groups", "Sign Out"}},
+		{"letters in order rank last", "to", []string{"Go to Dashboard", "Go to Collections", "Go to Groups", "Create Collection", "Search Collections"}},
+		{"no match", "xyz", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range filterCommands(commands, tt.query) {
+			got = append(got, c.Title)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: filterCommands(%q) = %q, want %q", tt.name, tt.query, got, tt.want)
+		}
+	}
+}
@@ -6,13 +6,13 @@ import (
 
 	"gioui.org/f32"
 	"gioui.org/gesture"
+	"gioui.org/io/event"
 	"gioui.org/io/pointer"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
 	"gioui.org/unit"
-	"gioui.org/widget"
 	"gioui.org/widget/material"
 
 	"github.com/nishiki/frontend/ui/theme"
@@ -27,11 +27,13 @@ type Dialog struct {
 	// Drag gesture for moving the dialog
 	drag gesture.Drag
 	// Click for backdrop dismissal
-	backdropClick widget.Clickable
+	backdropClick gesture.Click
 	// Click absorber for dialog body (prevents backdrop dismissal)
-	bodyClick widget.Clickable
+	bodyClick gesture.Click
 	// Offset during drag
 	dragOffset f32.Point
+	// Keeps keyboard focus inside the dialog while it is open
+	trap FocusTrap
 }
 
 // DialogStyle configures the appearance of a dialog
@@ -42,7 +44,11 @@ type DialogStyle struct {
 	BackgroundColor color.NRGBA
 	TitleBarColor   color.NRGBA
 	CornerRadius    unit.Dp
+	// CloseOnBackdrop dismisses the dialog on a click outside it or Escape
 	CloseOnBackdrop bool
+	// InitialFocus is focused when the dialog opens, e.g. its first
+	// editor; nil focuses the dialog itself so screen readers read its title
+	InitialFocus event.Tag
 }
 
 // NewDialog creates a new dialog instance
@@ -75,8 +81,17 @@ func (ds DialogStyle) Layout(gtx layout.Context, th *material.Theme, content lay
 		ds.Dialog.positioned = true
 	}
 
-	// Handle backdrop click for dismissal
-	if ds.CloseOnBackdrop && ds.Dialog.backdropClick.Clicked(gtx) {
+	// Handle backdrop click and Escape for dismissal
+	for ds.CloseOnBackdrop {
+		e, ok := ds.Dialog.backdropClick.Update(gtx.Source)
+		if !ok {
+			break
+		}
+		if e.Kind == gesture.KindClick {
+			dismissed = true
+		}
+	}
+	if ds.CloseOnBackdrop && ds.Dialog.trap.Update(gtx) {
 		dismissed = true
 	}
 
@@ -105,9 +120,7 @@ func (ds DialogStyle) Layout(gtx layout.Context, th *material.Theme, content lay
 
 			// Handle backdrop clicks
 			if ds.CloseOnBackdrop {
-				ds.Dialog.backdropClick.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-					return layout.Dimensions{Size: gtx.Constraints.Max}
-				})
+				ds.Dialog.backdropClick.Add(gtx.Ops)
 			}
 
 			return layout.Dimensions{Size: gtx.Constraints.Max}
@@ -157,49 +170,62 @@ func (ds DialogStyle) Layout(gtx layout.Context, th *material.Theme, content lay
 
 // layoutDialog renders the dialog structure (title bar + content)
 func (ds DialogStyle) layoutDialog(gtx layout.Context, th *material.Theme, content layout.Widget) layout.Dimensions {
-	// Absorb pointer events on the dialog body to prevent backdrop dismissal
-	// when clicking inside the dialog but not on an interactive widget
-	return ds.Dialog.bodyClick.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		// Constrain width inside the clickable
-		gtx.Constraints.Max.X = gtx.Dp(ds.Width)
-		gtx.Constraints.Min.X = gtx.Dp(ds.Width)
-
-		// Cap dialog height to 85% of viewport so content can use Flexed
-		maxDialogHeight := gtx.Constraints.Max.Y * 85 / 100
-		if maxDialogHeight > 0 {
-			gtx.Constraints.Max.Y = maxDialogHeight
+	return ds.Dialog.trap.Layout(gtx, ds.Title, ds.InitialFocus, func(gtx layout.Context) layout.Dimensions {
+		return ds.layoutBody(gtx, th, content)
+	})
+}
+
+// layoutBody renders the title bar and content on the dialog background
+func (ds DialogStyle) layoutBody(gtx layout.Context, th *material.Theme, content layout.Widget) layout.Dimensions {
+	for {
+		if _, ok := ds.Dialog.bodyClick.Update(gtx.Source); !ok {
+			break
 		}
+	}
 
-		// Record dialog content for background
-		macro := op.Record(gtx.Ops)
-		dims := layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-			// Title bar (draggable)
-			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				return ds.layoutTitleBar(gtx, th)
-			}),
-			// Content area (uses remaining space for Flexed children)
-			layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-				return layout.Inset{
-					Top:    unit.Dp(theme.Spacing4),
-					Bottom: unit.Dp(theme.Spacing4),
-					Left:   unit.Dp(theme.Spacing4),
-					Right:  unit.Dp(theme.Spacing4),
-				}.Layout(gtx, content)
-			}),
-		)
-		call := macro.Stop()
+	gtx.Constraints.Max.X = gtx.Dp(ds.Width)
+	gtx.Constraints.Min.X = gtx.Dp(ds.Width)
 
-		// Draw rounded background
-		rr := gtx.Dp(ds.CornerRadius)
-		defer clip.UniformRRect(image.Rectangle{Max: dims.Size}, rr).Push(gtx.Ops).Pop()
-		paint.ColorOp{Color: ds.BackgroundColor}.Add(gtx.Ops)
-		paint.PaintOp{}.Add(gtx.Ops)
+	// Cap dialog height to 85% of viewport so content can use Flexed
+	maxDialogHeight := gtx.Constraints.Max.Y * 85 / 100
+	if maxDialogHeight > 0 {
+		gtx.Constraints.Max.Y = maxDialogHeight
+	}
 
-		// Draw content
-		call.Add(gtx.Ops)
+	// Record dialog content for background
+	macro := op.Record(gtx.Ops)
+	dims := layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		// Title bar (draggable)
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return ds.layoutTitleBar(gtx, th)
+		}),
+		// Content area (uses remaining space for Flexed children)
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:    unit.Dp(theme.Spacing4),
+				Bottom: unit.Dp(theme.Spacing4),
+				Left:   unit.Dp(theme.Spacing4),
+				Right:  unit.Dp(theme.Spacing4),
+			}.Layout(gtx, content)
+		}),
+	)
+	call := macro.Stop()
 
-		return dims
-	})
+	// Draw rounded background
+	rr := gtx.Dp(ds.CornerRadius)
+	defer clip.UniformRRect(image.Rectangle{Max: dims.Size}, rr).Push(gtx.Ops).Pop()
+	paint.ColorOp{Color: ds.BackgroundColor}.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+
+	// Absorb pointer events on the dialog body to prevent backdrop dismissal
+	// when clicking inside the dialog but not on an interactive widget. A
+	// gesture rather than a Clickable, so it is not a stop for Tab.
+	ds.Dialog.bodyClick.Add(gtx.Ops)
+
+	// Draw content
+	call.Add(gtx.Ops)
+
+	return dims
 }
 
 // layoutTitleBar renders the draggable title bar
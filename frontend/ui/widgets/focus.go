@@ -0,0 +1,201 @@
+package widgets
+
+import (
+	"image"
+
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// focus is the keyboard focus bookkeeping shared by the buttons and the
+// modal surfaces of the app's one window. Layout runs on a single
+// goroutine, so it needs no locking.
+var focus focusState
+
+type focusState struct {
+	frame uint64 // frames finished by EndFrame
+
+	// focused is the control outside any modal that had keyboard focus in
+	// the frame being laid out
+	focused event.Tag
+	// returnTo gets the focus back once the open modals have all closed
+	returnTo event.Tag
+
+	depth   int        // modals whose content is being laid out
+	open    bool       // a modal was laid out this frame
+	wasOpen bool       // a modal was laid out the previous frame
+	top     *FocusTrap // the last modal laid out this frame
+	prevTop *FocusTrap // the last modal laid out the previous frame
+}
+
+// EndFrame finishes the frame's focus bookkeeping. When the last open
+// modal closed during the frame, keyboard focus goes back to the control
+// that had it when the first one opened. Call it once at the end of every
+// frame.
+func EndFrame(gtx layout.Context) {
+	if focus.wasOpen && !focus.open && focus.returnTo != nil {
+		gtx.Execute(key.FocusCmd{Tag: focus.returnTo})
+	}
+	if !focus.open {
+		focus.returnTo = nil
+	}
+	focus.wasOpen, focus.open = focus.open, false
+	focus.prevTop, focus.top = focus.top, nil
+	focus.focused = nil
+	focus.frame++
+}
+
+// TrackFocus notes that tag, a control laid out this frame, may hold the
+// keyboard focus a modal hands back when it closes. The buttons in this
+// package track themselves; custom controls call it from their layout.
+func TrackFocus(gtx layout.Context, tag event.Tag) {
+	if focus.depth == 0 && gtx.Focused(tag) {
+		focus.focused = tag
+	}
+}
+
+// FocusTrap keeps keyboard focus inside a modal surface such as a dialog.
+// The first frame it is laid out it takes the focus, Tab cycles through
+// the controls inside it rather than those of the view underneath, and
+// Escape reports a dismissal. When the last open trap closes, focus goes
+// back to where it was before (see EndFrame).
+type FocusTrap struct {
+	shown bool
+	frame uint64 // value of focus.frame when last laid out
+	// end is the tag of a focus stop after the content; reaching it wraps
+	// Tab back to the start
+	end int
+}
+
+// Update reports whether Escape was pressed to dismiss the trap. Only the
+// modal laid out on top of the others listens for it.
+func (t *FocusTrap) Update(gtx layout.Context) bool {
+	if focus.prevTop != t {
+		return false
+	}
+	dismissed := false
+	for {
+		ev, ok := gtx.Event(key.Filter{Name: key.NameEscape})
+		if !ok {
+			break
+		}
+		if e, ok := ev.(key.Event); ok && e.State == key.Press {
+			dismissed = true
+		}
+	}
+	return dismissed
+}
+
+// Layout lays out w as a modal surface that screen readers announce as
+// label. initial is focused when the trap opens; nil focuses the surface
+// itself.
+func (t *FocusTrap) Layout(gtx layout.Context, label string, initial event.Tag, w layout.Widget) layout.Dimensions {
+	if opening := !t.shown || focus.frame > t.frame+1; opening {
+		if !focus.open && !focus.wasOpen {
+			focus.returnTo = focus.focused
+		}
+		if initial == nil {
+			initial = t
+		}
+		gtx.Execute(key.FocusCmd{Tag: initial})
+	}
+	t.shown, t.frame = true, focus.frame
+	focus.open, focus.top = true, t
+
+	for {
+		_, ok := gtx.Event(
+			key.FocusFilter{Target: t},
+			// Shift+Tab from the start would leave for the view underneath
+			key.Filter{Focus: t, Name: key.NameTab, Required: key.ModShift},
+		)
+		if !ok {
+			break
+		}
+	}
+	for {
+		ev, ok := gtx.Event(key.FocusFilter{Target: &t.end})
+		if !ok {
+			break
+		}
+		if e, ok := ev.(key.FocusEvent); ok && e.Focus {
+			gtx.Execute(key.FocusCmd{Tag: t})
+		}
+	}
+
+	focus.depth++
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx)
+	call := macro.Stop()
+	focus.depth--
+
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	semantic.LabelOp(label).Add(gtx.Ops)
+	event.Op(gtx.Ops, t)
+	call.Add(gtx.Ops)
+	event.Op(gtx.Ops, &t.end)
+	if gtx.Focused(t) {
+		paintFocusRing(gtx, dims.Size, unit.Dp(theme.RadiusLG))
+	}
+	return dims
+}
+
+// ClickArea lays out w as the content of btn for a card, row or other custom
+// control, which screen readers announce as label. It shows a focus ring
+// while btn has keyboard focus, since the content draws no button of its own.
+func ClickArea(gtx layout.Context, btn *widget.Clickable, label string, w layout.Widget) layout.Dimensions {
+	TrackFocus(gtx, btn)
+	dims := btn.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		semantic.DescriptionOp(label).Add(gtx.Ops)
+		return w(gtx)
+	})
+	if gtx.Focused(btn) {
+		paintFocusRing(gtx, dims.Size, unit.Dp(theme.RadiusDefault))
+	}
+	return dims
+}
+
+// ArrowFocus lets the arrow keys along axis move the keyboard focus between
+// tags, the buttons of a menu in the order they are laid out, wrapping at
+// either end. Tab still leaves the menu as usual.
+func ArrowFocus(gtx layout.Context, axis layout.Axis, tags ...event.Tag) {
+	prev, next := key.NameLeftArrow, key.NameRightArrow
+	if axis == layout.Vertical {
+		prev, next = key.NameUpArrow, key.NameDownArrow
+	}
+	for i, tag := range tags {
+		for {
+			ev, ok := gtx.Event(
+				key.Filter{Focus: tag, Name: prev},
+				key.Filter{Focus: tag, Name: next},
+			)
+			if !ok {
+				break
+			}
+			e, ok := ev.(key.Event)
+			if !ok || e.State != key.Press {
+				continue
+			}
+			to := i + 1
+			if e.Name == prev {
+				to = i - 1 + len(tags)
+			}
+			gtx.Execute(key.FocusCmd{Tag: tags[to%len(tags)]})
+		}
+	}
+}
+
+// paintFocusRing outlines a focused control of the given size
+func paintFocusRing(gtx layout.Context, size image.Point, radius unit.Dp) {
+	width := float32(gtx.Dp(unit.Dp(2)))
+	rr := clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(radius))
+	paint.FillShape(gtx.Ops, theme.ColorAccent, clip.Stroke{Path: rr.Path(gtx.Ops), Width: width}.Op())
+}
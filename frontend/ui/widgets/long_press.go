@@ -0,0 +1,50 @@
+package widgets
+
+import (
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+)
+
+// LongPressDuration is how long a press is held before it counts as long
+const LongPressDuration = 500 * time.Millisecond
+
+// LongPress detects a press held on a Clickable, the touch screen stand-in
+// for a right click. The zero value is ready to use.
+type LongPress struct {
+	fired time.Time // Start of the last press reported as long
+}
+
+// Update reports, once per press, that btn has been held down for
+// LongPressDuration, asking for a frame at that moment while it waits. Call
+// it after btn's click events have been read.
+func (lp *LongPress) Update(gtx layout.Context, btn *widget.Clickable) bool {
+	press, ok := lastPress(btn)
+	if !ok || !press.End.IsZero() || !btn.Pressed() || press.Start.Equal(lp.fired) {
+		return false
+	}
+	at := press.Start.Add(LongPressDuration)
+	if gtx.Now.Before(at) {
+		gtx.Execute(op.InvalidateCmd{At: at})
+		return false
+	}
+	lp.fired = press.Start
+	return true
+}
+
+// Fired reports whether btn's latest press was reported as long, so the
+// click its release makes can be ignored.
+func (lp *LongPress) Fired(btn *widget.Clickable) bool {
+	press, ok := lastPress(btn)
+	return ok && press.Start.Equal(lp.fired)
+}
+
+func lastPress(btn *widget.Clickable) (widget.Press, bool) {
+	history := btn.History()
+	if len(history) == 0 {
+		return widget.Press{}, false
+	}
+	return history[len(history)-1], true
+}
@@ -0,0 +1,130 @@
+package widgets
+
+import (
+	"strings"
+	"time"
+
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/layout"
+)
+
+// sequenceTimeout is how long the first key of a two-key sequence (e.g. the
+// "g" in "g c") stays armed before it is discarded.
+const sequenceTimeout = 1500 * time.Millisecond
+
+// Shortcut binds a key sequence to an action. Keys is a space-separated list
+// of key names as reported by Gio ("/", "N", "G C").
+type Shortcut struct {
+	Keys        string
+	Description string
+	Action      func()
+}
+
+// Shortcuts dispatches global keyboard shortcuts, including two-key sequences.
+//
+// Plain-key bindings only fire while no widget holds keyboard focus, so typing
+// into an editor never triggers them. The command palette binding
+// (Ctrl/Cmd+K) is always active.
+type Shortcuts struct {
+	bindings []Shortcut
+	// OnPalette is invoked when Ctrl/Cmd+K is pressed.
+	OnPalette func()
+
+	pending   string
+	pendingAt time.Time
+}
+
+// Bind registers a shortcut. Key names are case-insensitive.
+func (s *Shortcuts) Bind(keys, description string, action func()) {
+	s.bindings = append(s.bindings, Shortcut{
+		Keys:        strings.ToUpper(strings.Join(strings.Fields(keys), " ")),
+		Description: description,
+		Action:      action,
+	})
+}
+
+// Update processes pending key events and runs any matched action. Call it
+// once per frame before laying out views so focus changes requested by an
+// action take effect in the same frame.
+func (s *Shortcuts) Update(gtx layout.Context) {
+	filters := []event.Filter{
+		key.Filter{Name: "K", Required: key.ModShortcut},
+	}
+	// Only listen for unmodified keys when nothing is focused; registering
+	// these filters while an editor is focused would swallow typed text.
+	if gtx.Focused(nil) {
+		for _, name := range s.keyNames() {
+			filters = append(filters, key.Filter{Name: name, Optional: key.ModShift})
+		}
+	}
+
+	for {
+		ev, ok := gtx.Event(filters...)
+		if !ok {
+			break
+		}
+		e, ok := ev.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+		if e.Name == "K" && e.Modifiers.Contain(key.ModShortcut) {
+			s.pending = ""
+			if s.OnPalette != nil {
+				s.OnPalette()
+			}
+			continue
+		}
+		s.dispatch(string(e.Name), gtx.Now)
+	}
+}
+
+// dispatch resolves name against the pending sequence prefix and runs the
+// matching binding, if any.
+func (s *Shortcuts) dispatch(name string, now time.Time) {
+	if s.pending != "" && now.Sub(s.pendingAt) > sequenceTimeout {
+		s.pending = ""
+	}
+
+	seq := name
+	if s.pending != "" {
+		seq = s.pending + " " + name
+	}
+
+	for _, b := range s.bindings {
+		if b.Keys == seq {
+			s.pending = ""
+			b.Action()
+			return
+		}
+	}
+
+	// Arm a sequence if this key starts one; otherwise start over with
+	// this key alone so a stray prefix doesn't block a single-key binding.
+	for _, b := range s.bindings {
+		if strings.HasPrefix(b.Keys, seq+" ") {
+			s.pending = seq
+			s.pendingAt = now
+			return
+		}
+	}
+	if s.pending != "" {
+		s.pending = ""
+		s.dispatch(name, now)
+	}
+}
+
+// keyNames returns the distinct key names used by any binding.
+func (s *Shortcuts) keyNames() []key.Name {
+	seen := make(map[string]bool)
+	var names []key.Name
+	for _, b := range s.bindings {
+		for _, k := range strings.Fields(b.Keys) {
+			if !seen[k] {
+				seen[k] = true
+				names = append(names, key.Name(k))
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+package widgets
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"gioui.org/io/event"
+	"gioui.org/io/input"
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// newTestShortcuts binds the app's kinds of shortcuts, recording which fire
+func newTestShortcuts(fired *[]string) *Shortcuts {
+	s := &Shortcuts{OnPalette: func() { *fired = append(*fired, "palette") }}
+	for _, keys := range []string{"/", "n", "g c", "g g"} {
+		s.Bind(keys, "", func() { *fired = append(*fired, keys) })
+	}
+	return s
+}
+
+func TestShortcutsDispatch(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	type press struct {
+		name  string
+		after time.Duration // since the first press
+	}
+	tests := []struct {
+		name    string
+		presses []press
+		want    []string
+	}{
+		{"single key", []press{{"/", 0}}, []string{"/"}},
+		{"lowercase binding matches the key name", []press{{"N", 0}}, []string{"n"}},
+		{"sequence", []press{{"G", 0}, {"C", 200 * time.Millisecond}}, []string{"g c"}},
+		{"prefix alone does nothing", []press{{"G", 0}}, nil},
+		{"sequence times out", []press{{"G", 0}, {"C", 2 * time.Second}}, nil},
+		{"stray prefix does not block a single key", []press{{"G", 0}, {"N", 100 * time.Millisecond}}, []string{"n"}},
+		{"unbound key", []press{{"X", 0}}, nil},
+		{"sequences repeat", []press{{"G", 0}, {"G", 100 * time.Millisecond}, {"G", 200 * time.Millisecond}, {"C", 300 * time.Millisecond}}, []string{"g g", "g c"}},
+	}
+	for _, tt := range tests {
+		var fired []string
+		s := newTestShortcuts(&fired)
+		for _, p := range tt.presses {
+			s.dispatch(p.name, start.Add(p.after))
+		}
+		if !slices.Equal(fired, tt.want) {
+			t.Errorf("%s: fired %q, want %q", tt.name, fired, tt.want)
+		}
+	}
+}
+
+func TestShortcutsUpdate(t *testing.T) {
+	editor := new(int) // stands in for a text field's focus tag
+	tests := []struct {
+		name    string
+		event   key.Event
+		focused bool
+		want    []string
+	}{
+		{"plain key", key.Event{Name: "N"}, false, []string{"n"}},
+		{"shift is allowed", key.Event{Name: "N", Modifiers: key.ModShift}, false, []string{"n"}},
+		{"ctrl is not", key.Event{Name: "N", Modifiers: key.ModShortcut}, false, nil},
+		{"palette", key.Event{Name: "K", Modifiers: key.ModShortcut}, false, []string{"palette"}},
+		{"plain key while typing", key.Event{Name: "N"}, true, nil},
+		{"palette while typing", key.Event{Name: "K", Modifiers: key.ModShortcut}, true, []string{"palette"}},
+	}
+	for _, tt := range tests {
+		var fired []string
+		s := newTestShortcuts(&fired)
+		var r input.Router
+		frame := func() {
+			var ops op.Ops
+			gtx := layout.Context{Ops: &ops, Source: r.Source(), Now: time.Now()}
+			s.Update(gtx)
+			// The text field takes key events when focused
+			event.Op(&ops, editor)
+			gtx.Event(key.FocusFilter{Target: editor}, key.Filter{Focus: editor, Name: "N"})
+			r.Frame(&ops)
+		}
+		frame()
+		if tt.focused {
+			r.Source().Execute(key.FocusCmd{Tag: editor})
+			frame()
+		}
+		tt.event.State = key.Press
+		r.Queue(tt.event)
+		frame()
+		if !slices.Equal(fired, tt.want) {
+			t.Errorf("%s: fired %q, want %q", tt.name, fired, tt.want)
+		}
+	}
+}
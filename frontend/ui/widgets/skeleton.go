@@ -0,0 +1,71 @@
+package widgets
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// skeletonPulse is how long the placeholder bars take to fade out and back in
+const skeletonPulse = 1200 * time.Millisecond
+
+// skeletonAlpha returns the bar opacity for the given frame time, between
+// roughly 45% and 100% of the border colour
+func skeletonAlpha(now time.Time) uint8 {
+	phase := float64(now.UnixNano()%int64(skeletonPulse)) / float64(skeletonPulse)
+	level := 0.725 + 0.275*math.Cos(2*math.Pi*phase)
+	return uint8(level * 255)
+}
+
+// SkeletonBar draws a rounded placeholder bar for a line of text that has not
+// loaded yet. width is a fraction of the available width.
+func SkeletonBar(gtx layout.Context, width float32, height unit.Dp) layout.Dimensions {
+	size := image.Pt(int(float32(gtx.Constraints.Max.X)*width), gtx.Dp(height))
+	c := theme.ColorBorder
+	c.A = skeletonAlpha(gtx.Now)
+
+	defer clip.UniformRRect(image.Rectangle{Max: size}, size.Y/2).Push(gtx.Ops).Pop()
+	paint.ColorOp{Color: c}.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+	gtx.Execute(op.InvalidateCmd{})
+	return layout.Dimensions{Size: size}
+}
+
+// SkeletonCard lays out a card shaped like a list item: a title bar and a
+// shorter line of detail below it
+func SkeletonCard(gtx layout.Context) layout.Dimensions {
+	return DefaultCard().Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return SkeletonBar(gtx, 0.6, unit.Dp(theme.Spacing4))
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Top: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return SkeletonBar(gtx, 0.35, unit.Dp(theme.Spacing3))
+				})
+			}),
+		)
+	})
+}
+
+// SkeletonList stacks rows skeleton cards to stand in for a list while its
+// first load is in flight
+func SkeletonList(rows int) layout.Widget {
+	return func(gtx layout.Context) layout.Dimensions {
+		children := make([]layout.FlexChild, rows)
+		for i := range children {
+			children[i] = layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Inset{Bottom: unit.Dp(theme.Spacing3)}.Layout(gtx, SkeletonCard)
+			})
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+}
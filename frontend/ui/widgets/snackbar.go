@@ -0,0 +1,77 @@
+package widgets
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+// SnackbarStyle renders a transient message bar anchored to the bottom of the
+// screen with an optional action button (e.g. "Undo")
+type SnackbarStyle struct {
+	Message     string
+	ActionLabel string
+	Action      *widget.Clickable
+	// BottomOffset lifts the bar above fixed footer content such as the bottom menu
+	BottomOffset unit.Dp
+	MaxWidth     unit.Dp
+}
+
+// Snackbar creates a snackbar style with default values
+func Snackbar(message, actionLabel string, action *widget.Clickable) SnackbarStyle {
+	return SnackbarStyle{
+		Message:      message,
+		ActionLabel:  actionLabel,
+		Action:       action,
+		BottomOffset: unit.Dp(theme.Spacing20),
+		MaxWidth:     unit.Dp(560),
+	}
+}
+
+// Layout renders the snackbar centered horizontally at the bottom of the
+// available space
+func (s SnackbarStyle) Layout(gtx layout.Context, th *material.Theme) layout.Dimensions {
+	gtx.Constraints.Min = gtx.Constraints.Max
+	return layout.S.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Inset{
+			Bottom: s.BottomOffset,
+			Left:   unit.Dp(theme.Spacing4),
+			Right:  unit.Dp(theme.Spacing4),
+		}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = 0
+			if maxW := gtx.Dp(s.MaxWidth); gtx.Constraints.Max.X > maxW {
+				gtx.Constraints.Max.X = maxW
+			}
+			card := Card{
+				BackgroundColor: theme.ColorTextPrimary,
+				CornerRadius:    unit.Dp(theme.RadiusDefault),
+				Inset: layout.Inset{
+					Top:    unit.Dp(theme.Spacing2),
+					Bottom: unit.Dp(theme.Spacing2),
+					Left:   unit.Dp(theme.Spacing4),
+					Right:  unit.Dp(theme.Spacing2),
+				},
+			}
+			return card.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+						label := material.Body2(th, s.Message)
+						label.Color = theme.ColorWhite
+						label.MaxLines = 2
+						return label.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						if s.Action == nil || s.ActionLabel == "" {
+							return layout.Dimensions{}
+						}
+						return layout.Inset{Left: unit.Dp(theme.Spacing3)}.Layout(gtx,
+							AccentButton(th, s.Action, s.ActionLabel))
+					}),
+				)
+			})
+		})
+	})
+}
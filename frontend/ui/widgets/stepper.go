@@ -0,0 +1,229 @@
+package widgets
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/nishiki/frontend/ui/theme"
+)
+
+const (
+	// holdDelay is how long a stepper button is held before it repeats
+	holdDelay = 400 * time.Millisecond
+	// fastHold and bigStepHold are how long into a hold the repeats speed
+	// up, then step ten at a time
+	fastHold    = 1500 * time.Millisecond
+	bigStepHold = 3 * time.Second
+)
+
+// QuantityStepper is a quantity between − and + buttons, with the number
+// editable in place and an optional unit after it. Holding a button
+// repeats the step, faster the longer it is held. It never goes below
+// zero.
+//
+// Call Update before Layout every frame. Update reports the value once the
+// user settles on it, so a hold that passes through many values is saved
+// once when the button is released.
+type QuantityStepper struct {
+	// Step is the amount of one press; zero means 1
+	Step float64
+
+	dec, inc widget.Clickable
+	editor   widget.Editor
+
+	holdDir     float64 // -1 or 1 while a button is held
+	holdStart   time.Time
+	nextRepeat  time.Time
+	repeated    bool   // the current hold has stepped at least once
+	focused     bool   // the editor had the focus last frame
+	settledText string // the text last set or reported
+}
+
+// Active reports whether the user is changing the value, by holding a
+// button or typing, during which SetValue leaves it alone
+func (s *QuantityStepper) Active() bool {
+	return s.holdDir != 0 || s.focused
+}
+
+// SetValue shows v unless the user is changing the value
+func (s *QuantityStepper) SetValue(v float64) {
+	if s.Active() {
+		return
+	}
+	s.SetText(formatQuantity(v))
+}
+
+// SetText shows text, which may be blank for no quantity
+func (s *QuantityStepper) SetText(t string) {
+	if s.editor.Text() != t {
+		s.editor.SetText(t)
+	}
+	s.settledText = t
+}
+
+// Text is the quantity as shown, trimmed
+func (s *QuantityStepper) Text() string {
+	return strings.TrimSpace(s.editor.Text())
+}
+
+// Value is the quantity shown, or false when the field is blank or does
+// not hold a number
+func (s *QuantityStepper) Value() (float64, bool) {
+	v, err := strconv.ParseFloat(s.Text(), 64)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// Update handles the buttons and the editor and reports the value when the
+// user has settled on one: after a click, at the end of a hold, or when a
+// typed number is submitted or the field loses the focus. A blank or
+// malformed number is not reported.
+func (s *QuantityStepper) Update(gtx layout.Context) (float64, bool) {
+	s.editor.SingleLine = true
+	s.editor.Submit = true
+	s.editor.Filter = "0123456789."
+	s.editor.Alignment = text.Middle
+
+	settled := false
+	for _, b := range []struct {
+		btn *widget.Clickable
+		dir float64
+	}{{&s.dec, -1}, {&s.inc, 1}} {
+		for {
+			if _, ok := b.btn.Update(gtx); !ok {
+				break
+			}
+			if s.repeated {
+				// The release that ends a hold; the hold already stepped
+				s.repeated = false
+			} else {
+				s.step(b.dir, 1)
+			}
+			settled = true
+		}
+	}
+
+	pressed := 0.0
+	switch {
+	case s.dec.Pressed():
+		pressed = -1
+	case s.inc.Pressed():
+		pressed = 1
+	}
+	switch {
+	case pressed != 0 && s.holdDir != pressed:
+		s.holdDir, s.holdStart, s.nextRepeat = pressed, gtx.Now, gtx.Now.Add(holdDelay)
+	case pressed == 0 && s.holdDir != 0:
+		// Released off the button, so no click ends the hold
+		s.holdDir = 0
+		if s.repeated {
+			s.repeated = false
+			settled = true
+		}
+	}
+	if s.holdDir != 0 {
+		if !gtx.Now.Before(s.nextRepeat) {
+			interval, multiple := holdRepeat(gtx.Now.Sub(s.holdStart))
+			s.step(s.holdDir, multiple)
+			s.repeated = true
+			s.nextRepeat = gtx.Now.Add(interval)
+		}
+		gtx.Execute(op.InvalidateCmd{At: s.nextRepeat})
+	}
+
+	for {
+		ev, ok := s.editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			settled = true
+		}
+	}
+	focused := gtx.Focused(&s.editor)
+	if s.focused && !focused && s.Text() != s.settledText {
+		settled = true
+	}
+	s.focused = focused
+
+	if !settled {
+		return 0, false
+	}
+	s.settledText = s.Text()
+	return s.Value()
+}
+
+// step moves the value by multiple steps in dir, counting a blank field as
+// zero and stopping at zero
+func (s *QuantityStepper) step(dir, multiple float64) {
+	size := s.Step
+	if size == 0 {
+		size = 1
+	}
+	v, _ := s.Value()
+	s.editor.SetText(formatQuantity(steppedQuantity(v, dir*size*multiple)))
+}
+
+// steppedQuantity adds delta to v without going below zero, rounding away
+// the float error repeated fractional steps pile up
+func steppedQuantity(v, delta float64) float64 {
+	return max(math.Round((v+delta)*1e6)/1e6, 0)
+}
+
+// holdRepeat is how often a held button repeats and by how many steps,
+// given how long it has been held
+func holdRepeat(held time.Duration) (time.Duration, float64) {
+	switch {
+	case held < fastHold:
+		return 150 * time.Millisecond, 1
+	case held < bigStepHold:
+		return 60 * time.Millisecond, 1
+	default:
+		return 60 * time.Millisecond, 10
+	}
+}
+
+func formatQuantity(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Layout renders the stepper with unitLabel, if any, after the number
+func (s *QuantityStepper) Layout(gtx layout.Context, th *material.Theme, unitLabel string) layout.Dimensions {
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(IconButton(th, &s.dec, "−", "Decrease quantity")),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(theme.Spacing2), Right: unit.Dp(theme.Spacing2)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = gtx.Dp(unit.Dp(56))
+				gtx.Constraints.Max.X = max(gtx.Constraints.Min.X, min(gtx.Constraints.Max.X, gtx.Dp(unit.Dp(96))))
+				return Card{
+					BackgroundColor: theme.ColorSurfaceAlt,
+					CornerRadius:    unit.Dp(theme.RadiusSM),
+					Inset:           layout.UniformInset(unit.Dp(theme.Spacing2)),
+				}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					ed := material.Editor(th, &s.editor, "0")
+					return ed.Layout(gtx)
+				})
+			})
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if unitLabel == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Body2(th, unitLabel)
+			label.Color = theme.ColorTextSecondary
+			return layout.Inset{Right: unit.Dp(theme.Spacing2)}.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(IconButton(th, &s.inc, "+", "Increase quantity")),
+	)
+}
@@ -0,0 +1,59 @@
+package widgets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSteppedQuantity(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     float64
+		delta float64
+		want  float64
+	}{
+		{"up", 3, 1, 4},
+		{"down", 3, -1, 2},
+		{"never below zero", 0.5, -1, 0},
+		{"fractional steps stay exact", 0.3, 0.1, 0.4},
+		{"big step", 2, 10, 12},
+	}
+	for _, tt := range tests {
+		if got := steppedQuantity(tt.v, tt.delta); got != tt.want {
+			t.Errorf("%s: steppedQuantity = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHoldRepeatAccelerates(t *testing.T) {
+	slow, one := holdRepeat(holdDelay)
+	fast, stillOne := holdRepeat(2 * time.Second)
+	_, ten := holdRepeat(5 * time.Second)
+	if fast >= slow {
+		t.Errorf("repeat interval after 2s = %v, want less than %v", fast, slow)
+	}
+	if one != 1 || stillOne != 1 || ten != 10 {
+		t.Errorf("steps per repeat = %v, %v, %v, want 1, 1, 10", one, stillOne, ten)
+	}
+}
+
+func TestQuantityStepperValue(t *testing.T) {
+	var s QuantityStepper
+	s.SetValue(2.5)
+	if got, ok := s.Value(); !ok || got != 2.5 {
+		t.Errorf("Value = %v, %v, want 2.5", got, ok)
+	}
+	s.step(-1, 1)
+	if got := s.Text(); got != "1.5" {
+		t.Errorf("Text after a step down = %q, want 1.5", got)
+	}
+
+	s.SetText("")
+	if _, ok := s.Value(); ok {
+		t.Error("a blank stepper reported a value")
+	}
+	s.step(1, 1)
+	if got := s.Text(); got != "1" {
+		t.Errorf("a blank stepper stepped up to %q, want 1", got)
+	}
+}